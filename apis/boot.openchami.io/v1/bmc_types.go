@@ -7,7 +7,9 @@ package v1
 
 import (
 	"context"
+	"errors"
 
+	bootvalidation "github.com/openchami/boot-service/pkg/validation"
 	"github.com/openchami/fabrica/pkg/resource"
 )
 
@@ -39,10 +41,35 @@ type BMCStatus struct { // nolint:revive
 	Phase   string `json:"phase,omitempty" yaml:"phase,omitempty"`
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 	Ready   bool   `json:"ready" yaml:"ready"`
+
+	// Hardware reported by the BMC's Redfish service when discovered
+	Manufacturer    string `json:"manufacturer,omitempty" yaml:"manufacturer,omitempty"`
+	Model           string `json:"model,omitempty" yaml:"model,omitempty"`
+	FirmwareVersion string `json:"firmwareVersion,omitempty" yaml:"firmwareVersion,omitempty"`
+	// Telemetry polled from the BMC's Redfish service: the power state of
+	// its system, such as On or Off, and the worst health of the BMC and its
+	// systems, OK, Warning, or Critical
+	PowerState string `json:"powerState,omitempty" yaml:"powerState,omitempty"`
+	Health     string `json:"health,omitempty" yaml:"health,omitempty"`
+	// Nodes are the xnames of the nodes discovery correlated the BMC with
+	Nodes []string `json:"nodes,omitempty" yaml:"nodes,omitempty"`
 }
 
 // Validate implements custom validation logic for BMC.
 func (r *BMC) Validate(ctx context.Context) error { //nolint:revive,unused
 	_ = ctx
+
+	if r.Spec.XName != "" && !bootvalidation.ValidateXNameType(r.Spec.XName, bootvalidation.BMCXNameTypes...) {
+		if xnameType := bootvalidation.GetXNameType(r.Spec.XName); xnameType != bootvalidation.XNameTypeInvalid {
+			return errors.New("XName " + r.Spec.XName + " names a " + string(xnameType) + ", not a BMC")
+		}
+		return errors.New("invalid XName format: " + r.Spec.XName)
+	}
+
+	if !bootvalidation.ValidateMAC(r.Spec.Interface.MAC) {
+		return errors.New("invalid interface MAC format: " + r.Spec.Interface.MAC)
+	}
+	r.Spec.Interface.MAC = bootvalidation.NormalizeMAC(r.Spec.Interface.MAC)
+
 	return nil
 }
@@ -7,7 +7,17 @@ package v1
 import (
 	"context"
 	"errors"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
 
+	"github.com/openchami/boot-service/pkg/admission"
+	"github.com/openchami/boot-service/pkg/hostlist"
+	"github.com/openchami/boot-service/pkg/paramprofiles"
+	"github.com/openchami/boot-service/pkg/rootfs"
+	"github.com/openchami/boot-service/pkg/schedule"
+	"github.com/openchami/boot-service/pkg/tenancy"
 	bootvalidation "github.com/openchami/boot-service/pkg/validation"
 	"github.com/openchami/fabrica/pkg/resource"
 )
@@ -32,29 +42,146 @@ type BootConfiguration struct {
 // selects from matching profile configurations. If no matching profile exists, it falls back
 // to the default profile (empty profile field).
 //
-// Selection priority: exact MAC match (100) > NID match (75) > host pattern (50) >
+// Selection priority (default weights): exact MAC match (100) > NID match (75) > host pattern (50) >
 // group membership (25) > default (1). When scores tie, the Priority field determines selection.
 // See docs/PROFILES.md for comprehensive profile documentation.
 type BootConfigurationSpec struct { // nolint:revive
 	// Node targeting criteria (at least one required)
-	Hosts  []string `json:"hosts,omitempty" yaml:"hosts,omitempty"`   // XName patterns (e.g., "x0c0s*")
-	MACs   []string `json:"macs,omitempty" yaml:"macs,omitempty"`     // MAC addresses (case-insensitive)
+	Hosts  []string `json:"hosts,omitempty" yaml:"hosts,omitempty"`   // XName patterns (e.g., "x0c0s*") or hostlists (e.g., "nid00[000-100]")
+	MACs   []string `json:"macs,omitempty" yaml:"macs,omitempty"`     // MAC addresses (any notation)
 	NIDs   []int32  `json:"nids,omitempty" yaml:"nids,omitempty"`     // Numeric node IDs
 	Groups []string `json:"groups,omitempty" yaml:"groups,omitempty"` // Inventory group memberships
 
+	// NIDRanges target nodes by NID range lists such as "1000-1999" or
+	// "1,5,10-20"; a match scores like an entry in NIDs
+	NIDRanges []string `json:"nidRanges,omitempty" yaml:"nidRanges,omitempty"`
+
 	// Boot profile for organizing configurations
 	// Empty or "default" indicates the default fallback profile.
 	// See docs/PROFILES.md for profile usage and selection logic.
 	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
 
 	// Boot parameters
-	Kernel string `json:"kernel" yaml:"kernel"`                     // Required: kernel URL or path
+	Kernel string `json:"kernel" yaml:"kernel"`                     // Kernel URL or path; required unless kernelArtifact, imageRef, or chainURL is set
 	Initrd string `json:"initrd,omitempty" yaml:"initrd,omitempty"` // Optional: initrd/initramfs URL or path
-	Params string `json:"params,omitempty" yaml:"params,omitempty"` // Kernel parameters (console, root, etc.)
+	Params string `json:"params,omitempty" yaml:"params,omitempty"` // Kernel parameters (console, root, etc.); may use {{.XName}}-style node variables
+
+	// ParamProfiles names parameter profiles (see /parameterprofiles) merged
+	// in order before Params, which replaces parameters with the same key
+	ParamProfiles []string `json:"paramProfiles,omitempty" yaml:"paramProfiles,omitempty"`
+
+	// Registered artifact names (see /bootartifacts). When set, the artifact URL
+	// is used in place of Kernel or Initrd at boot script generation time.
+	KernelArtifact string `json:"kernelArtifact,omitempty" yaml:"kernelArtifact,omitempty"`
+	InitrdArtifact string `json:"initrdArtifact,omitempty" yaml:"initrdArtifact,omitempty"`
+
+	// ImageRef names an image of the image service, such as "compute" or
+	// "compute:v2". Its current kernel, initrd, and root filesystem are
+	// looked up at boot script generation time, so rebuilt images boot
+	// without editing the configuration. It replaces kernel and initrd.
+	ImageRef string `json:"imageRef,omitempty" yaml:"imageRef,omitempty"`
+
+	// RootFS boots nodes from a network root filesystem image instead of
+	// hand-written root= parameters. Its parameters come before Params,
+	// which may override them.
+	RootFS *RootFS `json:"rootfs,omitempty" yaml:"rootfs,omitempty"`
+
+	// ChainURL delegates matching nodes to another boot server: instead of
+	// booting a kernel, the node chains to this http, https, or tftp URL. It
+	// may use the same {{.XName}}-style variables as Params, and iPXE expands
+	// settings such as ${mac}. It replaces kernel, initrd, and params.
+	ChainURL string `json:"chainURL,omitempty" yaml:"chainURL,omitempty"`
+
+	// Firmware variants override the kernel, initrd, or params for nodes
+	// booting with a given iPXE ${platform} and, optionally, ${buildarch}.
+	// Nodes that do not report their firmware get a script that picks the
+	// variant at boot time.
+	Firmware []FirmwareVariant `json:"firmware,omitempty" yaml:"firmware,omitempty"`
 
 	// Priority for tiebreaking within the same profile when multiple configs match
 	// Higher values take precedence. Default configurations typically use priority 1.
 	Priority int `json:"priority,omitempty" yaml:"priority,omitempty"`
+
+	// Tenant is the tenant (partition) that owns the configuration. It only
+	// matches nodes of the same tenant; a configuration without a tenant
+	// matches nodes of every tenant.
+	Tenant string `json:"tenant,omitempty" yaml:"tenant,omitempty"`
+
+	// Scheduling: the configuration only matches nodes while it is active,
+	// so a new kernel can go live during a maintenance window. ActiveFrom
+	// and ActiveUntil bound when it may be active; with Windows it is
+	// active only within one of them. All unset means always active.
+	ActiveFrom  time.Time        `json:"activeFrom,omitzero" yaml:"activeFrom,omitempty"`
+	ActiveUntil time.Time        `json:"activeUntil,omitzero" yaml:"activeUntil,omitempty"`
+	Windows     []ScheduleWindow `json:"windows,omitempty" yaml:"windows,omitempty"`
+}
+
+// RootFS describes a network root filesystem, such as a squashfs image served
+// over HTTP, that the initramfs mounts with a writable overlay
+type RootFS struct {
+	URL            string `json:"url,omitempty" yaml:"url,omitempty"`                       // http(s) URL; defaults to the rootfs of imageRef
+	Distro         string `json:"distro,omitempty" yaml:"distro,omitempty"`                 // initramfs flavor: "dracut" (default) or "debian-live"
+	Overlay        string `json:"overlay,omitempty" yaml:"overlay,omitempty"`               // "overlayfs" (default) or "readonly"
+	OverlaySizeMiB int    `json:"overlaySizeMiB,omitempty" yaml:"overlaySizeMiB,omitempty"` // 0 leaves the initramfs default
+	ToRAM          bool   `json:"toRAM,omitempty" yaml:"toRAM,omitempty"`                   // copy the image into memory before mounting it
+}
+
+// Options returns the root filesystem as kernel parameter builder options
+func (r *RootFS) Options() rootfs.Options {
+	return rootfs.Options{URL: r.URL, Overlay: r.Overlay, OverlaySizeMiB: r.OverlaySizeMiB, ToRAM: r.ToRAM}
+}
+
+// Firmware platforms, as iPXE reports them in ${platform}
+const (
+	FirmwareEFI  = "efi"    // UEFI
+	FirmwareBIOS = "pcbios" // legacy BIOS
+)
+
+// FirmwareVariant overrides parts of a boot configuration for one firmware.
+// Empty fields inherit the configuration's value.
+type FirmwareVariant struct {
+	Platform string `json:"platform" yaml:"platform"`             // "efi" or "pcbios"
+	Arch     string `json:"arch,omitempty" yaml:"arch,omitempty"` // iPXE ${buildarch}, e.g. "x86_64" or "arm64"; empty matches any
+	Kernel   string `json:"kernel,omitempty" yaml:"kernel,omitempty"`
+	Initrd   string `json:"initrd,omitempty" yaml:"initrd,omitempty"`
+	Params   string `json:"params,omitempty" yaml:"params,omitempty"` // replaces, not extends, the configuration's params
+}
+
+// Matches reports whether the variant applies to a node booting with
+// platform and arch. An empty arch, when the node did not report it, only
+// matches variants without an arch.
+func (v FirmwareVariant) Matches(platform, arch string) bool {
+	return v.Platform == platform && (v.Arch == "" || v.Arch == arch)
+}
+
+// firmwareArch matches iPXE ${buildarch} values
+var firmwareArch = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// imageRefPattern matches image names with an optional tag or digest
+var imageRefPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*([:@][A-Za-z0-9._:-]+)?$`)
+
+// ScheduleWindow is a recurring period during which a boot configuration is
+// active
+type ScheduleWindow struct {
+	// Cron is a five-field cron expression or descriptor such as @weekly
+	// for when the window opens, evaluated in UTC unless prefixed with
+	// CRON_TZ=<zone> (e.g. "CRON_TZ=America/Denver 0 2 * * 6")
+	Cron string `json:"cron" yaml:"cron"`
+	// Duration is how long the window stays open, e.g. "4h"
+	Duration string `json:"duration" yaml:"duration"`
+}
+
+// Schedule returns when the configuration is active
+func (s *BootConfigurationSpec) Schedule() (schedule.Schedule, error) {
+	result := schedule.Schedule{From: s.ActiveFrom, Until: s.ActiveUntil}
+	for _, window := range s.Windows {
+		parsed, err := schedule.ParseWindow(window.Cron, window.Duration)
+		if err != nil {
+			return schedule.Schedule{}, err
+		}
+		result.Windows = append(result.Windows, parsed)
+	}
+	return result, nil
 }
 
 // BootConfigurationStatus defines the observed state of BootConfiguration.
@@ -67,10 +194,30 @@ type BootConfigurationStatus struct { // nolint:revive
 
 // Validate implements custom validation logic for BootConfiguration.
 func (r *BootConfiguration) Validate(ctx context.Context) error { //nolint:revive,unused
-	_ = ctx
+	if err := tenancy.Assign(ctx, &r.Spec.Tenant); err != nil {
+		return err
+	}
+	// Site policy hooks may deny or patch the write; the result is validated below
+	if err := admission.Review(ctx, "BootConfiguration", r.Metadata.UID, r); err != nil {
+		return err
+	}
 
-	if r.Spec.Kernel == "" {
-		return errors.New("kernel field is required")
+	if r.Spec.ChainURL != "" {
+		if r.Spec.Kernel != "" || r.Spec.KernelArtifact != "" || r.Spec.Initrd != "" || r.Spec.InitrdArtifact != "" || r.Spec.ImageRef != "" || r.Spec.RootFS != nil || r.Spec.Params != "" || len(r.Spec.ParamProfiles) > 0 {
+			return errors.New("chainURL cannot be combined with kernel, initrd, or params")
+		}
+		if !bootvalidation.ValidateChainURL(r.Spec.ChainURL) {
+			return errors.New("invalid chainURL: " + r.Spec.ChainURL)
+		}
+	} else if r.Spec.ImageRef != "" {
+		if r.Spec.Kernel != "" || r.Spec.KernelArtifact != "" || r.Spec.Initrd != "" || r.Spec.InitrdArtifact != "" {
+			return errors.New("imageRef cannot be combined with kernel or initrd")
+		}
+		if !imageRefPattern.MatchString(r.Spec.ImageRef) {
+			return errors.New("invalid imageRef: " + r.Spec.ImageRef)
+		}
+	} else if r.Spec.Kernel == "" && r.Spec.KernelArtifact == "" {
+		return errors.New("kernel, kernelArtifact, or imageRef field is required")
 	}
 
 	// Note: Targeting criteria (hosts, macs, nids, groups) are all optional.
@@ -78,19 +225,33 @@ func (r *BootConfiguration) Validate(ctx context.Context) error { //nolint:reviv
 	// This is intentional for supporting default profile configurations that apply when
 	// more specific profiles don't match. See docs/PROFILES.md for details.
 
-	for _, host := range r.Spec.Hosts {
+	for i, host := range r.Spec.Hosts {
+		if hostlist.IsExpression(host) {
+			if _, err := hostlist.Parse(host); err != nil {
+				return err
+			}
+			continue
+		}
 		if !bootvalidation.ValidateXNameOrDefault(host) {
 			return errors.New("invalid host XName format: " + host)
 		}
+		r.Spec.Hosts[i] = bootvalidation.NormalizeXName(host)
 	}
 
-	for _, mac := range r.Spec.MACs {
+	for _, nids := range r.Spec.NIDRanges {
+		if _, err := hostlist.ParseRanges(nids); err != nil {
+			return errors.New("invalid nidRanges entry: " + err.Error())
+		}
+	}
+
+	for i, mac := range r.Spec.MACs {
 		if !bootvalidation.ValidateMAC(mac) {
 			return errors.New("invalid MAC address format: " + mac)
 		}
+		r.Spec.MACs[i] = bootvalidation.NormalizeMAC(mac)
 	}
 
-	if !bootvalidation.ValidateURLOrPath(r.Spec.Kernel) {
+	if r.Spec.Kernel != "" && !bootvalidation.ValidateURLOrPath(r.Spec.Kernel) {
 		return errors.New("invalid kernel URL or path: " + r.Spec.Kernel)
 	}
 
@@ -98,9 +259,93 @@ func (r *BootConfiguration) Validate(ctx context.Context) error { //nolint:reviv
 		return errors.New("invalid initrd URL or path: " + r.Spec.Initrd)
 	}
 
+	if err := validateParamsTemplate(r.Spec.Params); err != nil {
+		return err
+	}
+
+	if err := validateParamProfiles(r.Spec.ParamProfiles); err != nil {
+		return err
+	}
+
+	if r.Spec.RootFS != nil {
+		if r.Spec.RootFS.URL == "" && r.Spec.ImageRef == "" {
+			return errors.New("rootfs.url is required unless imageRef is set")
+		}
+		var err error
+		if r.Spec.RootFS.URL != "" {
+			_, err = rootfs.Params(r.Spec.RootFS.Distro, r.Spec.RootFS.Options())
+		} else {
+			err = rootfs.Validate(r.Spec.RootFS.Distro, r.Spec.RootFS.Options())
+		}
+		if err != nil {
+			return errors.New("invalid rootfs: " + err.Error())
+		}
+	}
+
+	if len(r.Spec.Firmware) > 0 && r.Spec.ChainURL != "" {
+		return errors.New("chainURL cannot be combined with firmware variants")
+	}
+	seen := map[string]bool{}
+	for _, variant := range r.Spec.Firmware {
+		if variant.Platform != FirmwareEFI && variant.Platform != FirmwareBIOS {
+			return errors.New("invalid firmware platform (want efi or pcbios): " + variant.Platform)
+		}
+		if variant.Arch != "" && !firmwareArch.MatchString(variant.Arch) {
+			return errors.New("invalid firmware arch: " + variant.Arch)
+		}
+		key := strings.TrimSuffix(variant.Platform+"/"+variant.Arch, "/")
+		if seen[key] {
+			return errors.New("duplicate firmware variant: " + key)
+		}
+		seen[key] = true
+		if variant.Kernel != "" && !bootvalidation.ValidateURLOrPath(variant.Kernel) {
+			return errors.New("invalid firmware kernel URL or path: " + variant.Kernel)
+		}
+		if variant.Initrd != "" && !bootvalidation.ValidateURLOrPathOptional(variant.Initrd) {
+			return errors.New("invalid firmware initrd URL or path: " + variant.Initrd)
+		}
+		if err := validateParamsTemplate(variant.Params); err != nil {
+			return err
+		}
+	}
+
 	if r.Spec.Priority < 0 || r.Spec.Priority > 100 {
 		return errors.New("priority must be between 0 and 100")
 	}
 
+	sched, err := r.Spec.Schedule()
+	if err != nil {
+		return errors.New("invalid schedule window: " + err.Error())
+	}
+	if err := sched.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateParamsTemplate checks the node variable template syntax of params
+func validateParamsTemplate(params string) error {
+	if strings.Contains(params, "{{") {
+		if _, err := template.New("params").Funcs(bootvalidation.ParamsTemplateFuncs).Parse(params); err != nil {
+			return errors.New("invalid params template: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// validateParamProfiles checks the names of referenced parameter profiles.
+// Whether they exist is only known when a script is rendered.
+func validateParamProfiles(names []string) error {
+	seen := map[string]bool{}
+	for _, name := range names {
+		if !paramprofiles.ValidName(name) {
+			return errors.New("invalid paramProfiles entry: " + name)
+		}
+		if seen[name] {
+			return errors.New("duplicate paramProfiles entry: " + name)
+		}
+		seen[name] = true
+	}
 	return nil
 }
@@ -7,7 +7,13 @@ package v1
 import (
 	"context"
 	"errors"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
 
+	"github.com/openchami/boot-service/pkg/admission"
+	"github.com/openchami/boot-service/pkg/tenancy"
 	bootvalidation "github.com/openchami/boot-service/pkg/validation"
 	"github.com/openchami/fabrica/pkg/resource"
 )
@@ -31,6 +37,40 @@ type NodeSpec struct { // nolint:revive
 	Hostname   string          `json:"hostname,omitempty" yaml:"hostname,omitempty"`
 	Interfaces []NodeInterface `json:"interfaces,omitempty" yaml:"interfaces,omitempty"`
 	Groups     []string        `json:"groups,omitempty" yaml:"groups,omitempty"`
+
+	// Aliases are further names the node can be looked up by, such as a DNS
+	// alias. Boot script requests may identify the node by its hostname, an
+	// alias, or any interface MAC.
+	Aliases []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+
+	// UUID and Serial are the node's SMBIOS system UUID and serial number,
+	// which iPXE reports as ${uuid} and ${serial}. Unlike MACs they survive
+	// NIC replacement, so boot script requests may identify the node by them.
+	UUID   string `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+	Serial string `json:"serial,omitempty" yaml:"serial,omitempty"`
+
+	// Tenant is the tenant (partition) that owns the node. With tenancy
+	// enabled it defaults to the ClusterID of the creating token.
+	Tenant string `json:"tenant,omitempty" yaml:"tenant,omitempty"`
+
+	// Metadata holds free-form per-node values that kernel parameter
+	// templates can reference as {{.Metadata.key}}.
+	Metadata map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	// ParamProfiles names parameter profiles (see /parameterprofiles) merged
+	// in order over the matched BootConfiguration's parameters, before
+	// ParamsOverride
+	ParamProfiles []string `json:"paramProfiles,omitempty" yaml:"paramProfiles,omitempty"`
+
+	// Per-node kernel parameter overlays applied on top of the matched
+	// BootConfiguration. ParamsOverride replaces every parameter with the same
+	// key (e.g. "console=ttyS1,115200"); ParamsAppend is added at the end.
+	ParamsOverride string `json:"paramsOverride,omitempty" yaml:"paramsOverride,omitempty"`
+	ParamsAppend   string `json:"paramsAppend,omitempty" yaml:"paramsAppend,omitempty"`
+
+	// ChainURL delegates the node to another boot server whatever
+	// configuration it matches, as BootConfigurationSpec.ChainURL does
+	ChainURL string `json:"chainURL,omitempty" yaml:"chainURL,omitempty"`
 }
 
 // NodeInterface represents a network interface.
@@ -38,6 +78,131 @@ type NodeInterface struct {
 	MAC  string `json:"mac,omitempty" yaml:"mac,omitempty"`
 	IP   string `json:"ip,omitempty" yaml:"ip,omitempty"`
 	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// Name is the interface's name in the booted system, such as eth0
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Netmask (dotted, or a prefix length) and Gateway complete a static
+	// IPv4 address, so boot scripts can configure it with ip=
+	Netmask string `json:"netmask,omitempty" yaml:"netmask,omitempty"`
+	Gateway string `json:"gateway,omitempty" yaml:"gateway,omitempty"`
+	// VLAN is the 802.1Q VLAN ID the interface's traffic is tagged with
+	VLAN int `json:"vlan,omitempty" yaml:"vlan,omitempty"`
+}
+
+// ManagementInterface returns the interface that carries the node's
+// management network: the one of type "management", else the boot
+// interface, else the first, among interfaces with an IP. It returns nil when
+// no interface has one.
+func (s *NodeSpec) ManagementInterface() *NodeInterface {
+	var found *NodeInterface
+	for i := range s.Interfaces {
+		iface := &s.Interfaces[i]
+		if iface.IP == "" {
+			continue
+		}
+		if strings.EqualFold(iface.Type, "management") {
+			return iface
+		}
+		if found == nil || bootvalidation.EqualMAC(iface.MAC, s.BootMAC) && !bootvalidation.EqualMAC(found.MAC, s.BootMAC) {
+			found = iface
+		}
+	}
+	return found
+}
+
+// MACs returns the boot MAC and the interface MACs of the node, normalized,
+// without duplicates, and the boot MAC first
+func (s *NodeSpec) MACs() []string {
+	var macs []string
+	seen := map[string]bool{}
+	add := func(mac string) {
+		mac = strings.ToLower(bootvalidation.NormalizeMAC(mac))
+		if mac != "" && !seen[mac] {
+			seen[mac] = true
+			macs = append(macs, mac)
+		}
+	}
+	add(s.BootMAC)
+	for _, iface := range s.Interfaces {
+		add(iface.MAC)
+	}
+	return macs
+}
+
+// HasMAC reports whether mac is the boot MAC or an interface MAC of the node,
+// in any notation
+func (s *NodeSpec) HasMAC(mac string) bool {
+	if bootvalidation.EqualMAC(s.BootMAC, mac) {
+		return true
+	}
+	for _, iface := range s.Interfaces {
+		if bootvalidation.EqualMAC(iface.MAC, mac) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAlias reports whether name is the hostname or one of the aliases of the
+// node. Names compare case-insensitively, as host names and UUIDs do.
+func (s *NodeSpec) HasAlias(name string) bool {
+	if name == "" {
+		return false
+	}
+	if strings.EqualFold(s.Hostname, name) {
+		return true
+	}
+	for _, alias := range s.Aliases {
+		if strings.EqualFold(alias, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasUUID reports whether uuid is the node's SMBIOS UUID, in any case
+func (s *NodeSpec) HasUUID(uuid string) bool {
+	return uuid != "" && strings.EqualFold(s.UUID, uuid)
+}
+
+// HasSerial reports whether serial is the node's serial number
+func (s *NodeSpec) HasSerial(serial string) bool {
+	return serial != "" && s.Serial == serial
+}
+
+// validateInterfaceNetwork checks the addressing an interface's ip= kernel
+// argument is generated from
+func validateInterfaceNetwork(iface NodeInterface) error {
+	if iface.Netmask != "" {
+		if _, ok := bootvalidation.NormalizeNetmask(iface.Netmask); !ok {
+			return errors.New("invalid interface netmask: " + iface.Netmask)
+		}
+	}
+	if !bootvalidation.ValidateIPv4(iface.Gateway) {
+		return errors.New("invalid interface gateway: " + iface.Gateway)
+	}
+	if iface.VLAN < 0 || iface.VLAN > 4094 {
+		return errors.New("interface VLAN must be between 1 and 4094")
+	}
+	if strings.ContainsAny(iface.Name, ":/ \t") {
+		return errors.New("invalid interface name: " + iface.Name)
+	}
+	return nil
+}
+
+// DiscoveredLabel marks a node created by first-boot discovery. A discovered
+// node may have no xname; it boots the discovery configuration until an
+// operator assigns it one and removes the label.
+const DiscoveredLabel = "boot.openchami.io/discovered"
+
+// NodeStateDiscovered is the status state of a node created by discovery
+const NodeStateDiscovered = "discovered"
+
+// Discovered reports whether the node was created by discovery and not yet
+// adopted
+func (r *Node) Discovered() bool {
+	_, ok := r.Metadata.Labels[DiscoveredLabel]
+	return ok
 }
 
 // NodeStatus defines the observed state of Node.
@@ -51,9 +216,20 @@ type NodeStatus struct { // nolint:revive
 
 // Validate implements custom validation logic for Node.
 func (r *Node) Validate(ctx context.Context) error { //nolint:revive,unused
-	_ = ctx
+	if err := tenancy.Assign(ctx, &r.Spec.Tenant); err != nil {
+		return err
+	}
+	// Site policy hooks may deny or patch the write; the result is validated below
+	if err := admission.Review(ctx, "Node", r.Metadata.UID, r); err != nil {
+		return err
+	}
 
-	if !bootvalidation.ValidateXName(r.Spec.XName) {
+	// Discovered nodes are known only by their hardware until adopted
+	pending := r.Spec.XName == "" && r.Discovered()
+	if !pending && !bootvalidation.ValidateXName(r.Spec.XName) {
+		if xnameType := bootvalidation.GetXNameType(r.Spec.XName); xnameType != bootvalidation.XNameTypeInvalid {
+			return errors.New("XName " + r.Spec.XName + " names a " + string(xnameType) + ", not a Node")
+		}
 		return errors.New("invalid XName format: " + r.Spec.XName)
 	}
 
@@ -61,5 +237,80 @@ func (r *Node) Validate(ctx context.Context) error { //nolint:revive,unused
 		return errors.New("invalid BootMAC format: " + r.Spec.BootMAC)
 	}
 
+	for _, iface := range r.Spec.Interfaces {
+		if iface.MAC != "" && !bootvalidation.ValidateMAC(iface.MAC) {
+			return errors.New("invalid interface MAC format: " + iface.MAC)
+		}
+		if err := validateInterfaceNetwork(iface); err != nil {
+			return err
+		}
+	}
+
+	if !bootvalidation.ValidateHostname(r.Spec.Hostname) {
+		return errors.New("invalid hostname: " + strconv.Quote(r.Spec.Hostname))
+	}
+
+	if !bootvalidation.ValidateUUID(r.Spec.UUID) {
+		return errors.New("invalid UUID format: " + r.Spec.UUID)
+	}
+	if r.Spec.Serial != strings.TrimSpace(r.Spec.Serial) || strings.IndexFunc(r.Spec.Serial, unicode.IsControl) >= 0 {
+		return errors.New("serial must not have surrounding spaces or control characters")
+	}
+
+	// Store xnames, MACs, and UUIDs in one notation so lookups and exports
+	// agree
+	r.Spec.XName = bootvalidation.NormalizeXName(r.Spec.XName)
+	r.Spec.UUID = strings.ToLower(r.Spec.UUID)
+	r.Spec.BootMAC = bootvalidation.NormalizeMAC(r.Spec.BootMAC)
+	for i := range r.Spec.Interfaces {
+		r.Spec.Interfaces[i].MAC = bootvalidation.NormalizeMAC(r.Spec.Interfaces[i].MAC)
+	}
+
+	// An alias shaped like an xname, NID, or MAC would be looked up as that
+	// identifier instead, so it could never match
+	seen := map[string]bool{}
+	for _, alias := range r.Spec.Aliases {
+		key := strings.ToLower(alias)
+		switch {
+		case strings.TrimSpace(alias) == "":
+			return errors.New("aliases must not be empty")
+		case seen[key]:
+			return errors.New("duplicate alias: " + alias)
+		case bootvalidation.IsXName(alias) || bootvalidation.ValidateMAC(alias) || isNumeric(alias):
+			return errors.New("alias " + alias + " would be read as an xname, NID, or MAC")
+		}
+		seen[key] = true
+	}
+
+	if r.Spec.ChainURL != "" && !bootvalidation.ValidateChainURL(r.Spec.ChainURL) {
+		return errors.New("invalid chainURL: " + r.Spec.ChainURL)
+	}
+
+	if err := validateParamProfiles(r.Spec.ParamProfiles); err != nil {
+		return err
+	}
+
+	overlays := []struct{ field, params string }{
+		{"paramsOverride", r.Spec.ParamsOverride},
+		{"paramsAppend", r.Spec.ParamsAppend},
+	}
+	for _, overlay := range overlays {
+		if !strings.Contains(overlay.params, "{{") {
+			continue
+		}
+		if _, err := template.New(overlay.field).Funcs(bootvalidation.ParamsTemplateFuncs).Parse(overlay.params); err != nil {
+			return errors.New("invalid " + overlay.field + " template: " + err.Error())
+		}
+	}
+
 	return nil
 }
+
+func isNumeric(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/pkg/apikeys"
+	"github.com/openchami/boot-service/pkg/auth"
+)
+
+// tokenAuthConfig verifies bearer tokens against jwks_endpoint and, when
+// keys is set, API keys in the X-API-Key header
+func tokenAuthConfig(config Config, keys *apikeys.Store) auth.Config {
+	authConfig := auth.DefaultConfig()
+	authConfig.JWKSURL = config.JWKSEndpoint
+	if keys != nil {
+		authConfig.APIKeys = keys
+	}
+	return authConfig
+}
+
+// registerAPIKeys serves the API key API at /admin/api-keys to tokens, or
+// keys, carrying api_key_admin_scope
+func registerAPIKeys(r chi.Router, config Config, keys *apikeys.Store) {
+	authConfig := tokenAuthConfig(config, keys)
+	if config.APIKeyAdminScope != "" {
+		authConfig.RequiredScopes = []string{config.APIKeyAdminScope}
+	}
+	r.Group(func(r chi.Router) {
+		r.Use(authConfig.CreateMiddleware(log.New(os.Stdout, "api-keys: ", log.LstdFlags)))
+		apikeys.NewHandler(keys).RegisterRoutes(r)
+	})
+	log.Printf("API key authentication enabled; keys are managed at %s (scope: %q)", apikeys.Path, config.APIKeyAdminScope)
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/openchami/boot-service/pkg/apikeys"
+	"github.com/openchami/boot-service/pkg/audit"
+	"github.com/openchami/boot-service/pkg/auth"
+)
+
+// auditWebhookQueueSize is how many records may wait for webhook delivery
+const auditWebhookQueueSize = 1000
+
+// auditActors attributes each request's writes for the audit log. When
+// jwks_endpoint is set, a bearer token or API key on a mutating request is
+// verified so its subject can be recorded; requests without one are recorded as
+// anonymous. Tokens already verified for tenant scoping are reused.
+func auditActors(config Config, keys *apikeys.Store) func(http.Handler) http.Handler {
+	verify := func(next http.Handler) http.Handler { return next }
+	if config.JWKSEndpoint != "" {
+		authConfig := tokenAuthConfig(config, keys)
+		verify = authConfig.CreateMiddleware(log.New(os.Stdout, "audit: ", log.LstdFlags))
+	}
+
+	return func(next http.Handler) http.Handler {
+		attributed := audit.Middleware(next)
+		verified := verify(attributed)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, err := auth.GetClaimsFromRequest(r); err != nil && isMutating(r.Method) && (r.Header.Get("Authorization") != "" || r.Header.Get(auth.APIKeyHeader) != "") {
+				verified.ServeHTTP(w, r)
+				return
+			}
+			attributed.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isMutating(method string) bool {
+	return method != http.MethodGet && method != http.MethodHead && method != http.MethodOptions
+}
+
+// newAuditLog creates the audit log persisted in backend, with the syslog
+// and webhook forwarders that are configured
+func newAuditLog(ctx context.Context, config Config, backend fabricaStorage.StorageBackend) (*audit.Log, error) {
+	logger := log.New(os.Stdout, "audit: ", log.LstdFlags)
+	auditLog := audit.NewLog(backend, logger)
+
+	if config.AuditSyslogAddress != "" {
+		forwarder, err := audit.NewSyslogForwarder(config.AuditSyslogAddress)
+		if err != nil {
+			return nil, err
+		}
+		go func() {
+			<-ctx.Done()
+			forwarder.Close() //nolint:errcheck
+		}()
+		auditLog.AddForwarder(forwarder)
+		log.Printf("Forwarding audit records to syslog at %s", config.AuditSyslogAddress)
+	}
+	if config.AuditWebhookURL != "" {
+		forwarder := audit.NewWebhookForwarder(config.AuditWebhookURL, nil, auditWebhookQueueSize, logger)
+		go forwarder.Run(ctx)
+		auditLog.AddForwarder(forwarder)
+		log.Printf("Forwarding audit records to %s", config.AuditWebhookURL)
+	}
+	return auditLog, nil
+}
+
+// registerAuditMetrics exports audit log counters
+func registerAuditMetrics(registry prometheus.Registerer, auditLog *audit.Log) error {
+	collectors := []prometheus.Collector{
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: "main", Subsystem: "audit", Name: "records_total",
+			Help: "Resource writes recorded in the audit log",
+		}, func() float64 { return float64(auditLog.Stats().Recorded) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: "main", Subsystem: "audit", Name: "record_failures_total",
+			Help: "Resource writes that could not be recorded in the audit log",
+		}, func() float64 { return float64(auditLog.Stats().Failed) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: "main", Subsystem: "audit", Name: "forward_failures_total",
+			Help: "Audit records that could not be handed to syslog or the webhook",
+		}, func() float64 { return float64(auditLog.Stats().ForwardFailed) }),
+	}
+	for _, collector := range collectors {
+		if err := registry.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
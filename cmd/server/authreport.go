@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/auth"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// authReportPath serves the requests non-enforcing legacy auth let through
+const authReportPath = "/admin/auth/report"
+
+// authReportInterval is how often non-enforcing legacy auth logs the
+// requests it would have rejected
+const authReportInterval = time.Hour
+
+// registerAuthReport serves the bypass report at authReportPath. With
+// tenancy enabled only an administrator may read it.
+func registerAuthReport(r chi.Router, bypasses *auth.BypassReport) {
+	r.Get(authReportPath, func(w http.ResponseWriter, r *http.Request) {
+		if _, scoped := tenancy.FromContext(r.Context()); scoped {
+			httputil.WriteError(w, http.StatusForbidden, "Forbidden", "the auth report requires an administrator token")
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		httputil.WriteJSON(w, http.StatusOK, bypasses.Snapshot())
+	})
+}
+
+// registerAuthReportMetrics exposes the bypass report counters
+func registerAuthReportMetrics(registry prometheus.Registerer, bypasses *auth.BypassReport) error {
+	collectors := []prometheus.Collector{
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: "main", Subsystem: "auth", Name: "checked_requests_total",
+			Help: "Legacy API requests checked by non-enforcing authorization",
+		}, func() float64 { return float64(bypasses.Checked()) }),
+	}
+	for _, reason := range auth.Reasons {
+		collectors = append(collectors, prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: "main", Subsystem: "auth", Name: "bypassed_requests_total",
+			Help:        "Legacy API requests non-enforcing authorization served that enforcing would reject, by reason",
+			ConstLabels: prometheus.Labels{"reason": reason},
+		}, func() float64 { return float64(bypasses.Count(reason)) }))
+	}
+	for _, collector := range collectors {
+		if err := registry.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
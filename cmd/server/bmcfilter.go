@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/openchami/boot-service/internal/httputil"
+)
+
+// bmcFilters are the status fields GET /bmcs filters on, by query parameter
+var bmcFilters = []string{"health", "powerState"}
+
+// filterBMCs filters the BMC list by the telemetry in their status. With
+// ?health=Critical or ?powerState=On,PoweringOn it returns only the BMCs
+// whose status has one of the comma-separated values, compared without
+// case. Requests without a filter are passed through unchanged.
+func filterBMCs(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		wanted := map[string][]string{}
+		for _, param := range bmcFilters {
+			for _, value := range strings.Split(query.Get(param), ",") {
+				if value = strings.TrimSpace(value); value != "" {
+					wanted[param] = append(wanted[param], strings.ToLower(value))
+				}
+			}
+		}
+		if r.Method != http.MethodGet || strings.TrimSuffix(r.URL.Path, "/") != "/bmcs" || len(wanted) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		recorder := &bufferedResponse{header: w.Header(), status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		if recorder.status != http.StatusOK {
+			w.WriteHeader(recorder.status)
+			_, _ = w.Write(recorder.body.Bytes())
+			return
+		}
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(recorder.body.Bytes(), &items); err != nil {
+			httputil.WriteError(w, http.StatusInternalServerError, "Filtering failed", err.Error())
+			return
+		}
+		matched := make([]json.RawMessage, 0, len(items))
+		for _, item := range items {
+			var bmc struct {
+				Status map[string]any `json:"status"`
+			}
+			if err := json.Unmarshal(item, &bmc); err != nil {
+				httputil.WriteError(w, http.StatusInternalServerError, "Filtering failed", err.Error())
+				return
+			}
+			if matchesBMCFilters(bmc.Status, wanted) {
+				matched = append(matched, item)
+			}
+		}
+		w.Header().Del("Content-Length")
+		httputil.WriteJSON(w, http.StatusOK, matched)
+	})
+}
+
+// matchesBMCFilters reports whether status has one of the wanted values of
+// every filter
+func matchesBMCFilters(status map[string]any, wanted map[string][]string) bool {
+	for param, values := range wanted {
+		value, _ := status[param].(string)
+		if !slices.Contains(values, strings.ToLower(value)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFilterBMCs(t *testing.T) {
+	list := `[
+		{"metadata":{"uid":"bmc-1"},"status":{"health":"OK","powerState":"On"}},
+		{"metadata":{"uid":"bmc-2"},"status":{"health":"Critical","powerState":"On"}},
+		{"metadata":{"uid":"bmc-3"},"status":{"health":"Warning","powerState":"Off"}},
+		{"metadata":{"uid":"bmc-4"},"status":{}}
+	]`
+	handler := filterBMCs(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(list))
+	}))
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"bmc-1", "bmc-2", "bmc-3", "bmc-4"}},
+		{"?health=critical", []string{"bmc-2"}},
+		{"?health=Warning,%20Critical", []string{"bmc-2", "bmc-3"}},
+		{"?powerState=On&health=OK", []string{"bmc-1"}},
+		{"?powerState=PoweringOn", nil},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/bmcs"+tt.query, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET /bmcs%s = %d: %s", tt.query, rec.Code, rec.Body)
+		}
+		var items []struct {
+			Metadata struct {
+				UID string `json:"uid"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &items); err != nil {
+			t.Fatalf("GET /bmcs%s returned invalid JSON: %v", tt.query, err)
+		}
+		var got []string
+		for _, item := range items {
+			got = append(got, item.Metadata.UID)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("GET /bmcs%s = %v, want %v", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("GET /bmcs%s = %v, want %v", tt.query, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"net/http"
+
+	"github.com/openchami/boot-service/pkg/activity"
+)
+
+// bootEventsPath is the WebSocket endpoint of the live boot activity feed
+const bootEventsPath = "/ws/boot-events"
+
+// serveBootEvents serves the boot activity feed at bootEventsPath. The
+// connections are long-lived and hijacked, so like watch requests they are
+// served ahead of the request timeout and metrics middleware. With tenancy
+// enabled, scope requires a token and limits tenants to their own nodes'
+// events.
+func serveBootEvents(feed *activity.Feed, config Config, scope func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	var events http.Handler = activity.NewHandler(feed, parseScopeHintCSV(config.BootEventsOrigins))
+	if scope != nil {
+		events = scope(events)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == bootEventsPath && r.Method == http.MethodGet {
+				events.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
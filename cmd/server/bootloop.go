@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/openchami/boot-service/pkg/activity"
+	"github.com/openchami/boot-service/pkg/bootloop"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/handlers/boot"
+	"github.com/openchami/boot-service/pkg/sharedstate"
+)
+
+// accessRecorders hands each boot script request to every recorder
+type accessRecorders []bootscript.AccessRecorder
+
+func (r accessRecorders) RecordAccess(node string, access bootscript.Access) {
+	for _, recorder := range r {
+		recorder.RecordAccess(node, access)
+	}
+}
+
+// activityRecorders hands each boot activity event to every recorder
+type activityRecorders []boot.ActivityRecorder
+
+func (r activityRecorders) Publish(event activity.Event) {
+	for _, recorder := range r {
+		recorder.Publish(event)
+	}
+}
+
+// newBootLoopDetector creates the boot loop detector configured by the
+// boot_loop settings, with its webhook running until ctx is done. Detected
+// loops switch nodes to the diagnostic configuration of controller. With
+// redisClient, requests and loops are shared by every replica.
+func newBootLoopDetector(ctx context.Context, config Config, controller *bootscript.BootScriptController, redisClient *redis.Client) *bootloop.Detector {
+	logger := log.New(os.Stdout, "bootloop: ", log.LstdFlags)
+	detector := bootloop.NewDetector(bootloop.Config{
+		Threshold:  config.BootLoopThreshold,
+		Window:     time.Duration(config.BootLoopWindow) * time.Minute,
+		Diagnostic: config.BootLoopDiagnosticConfig,
+	}, controller, controller.InvalidateNodeScripts, logger)
+	if redisClient != nil {
+		detector.SetState(sharedstate.NewRedisBootLoopState(redisClient, config.RedisKeyPrefix))
+	}
+	if config.BootLoopWebhookURL != "" {
+		notifier := bootloop.NewWebhookNotifier(config.BootLoopWebhookURL, logger)
+		go notifier.Run(ctx)
+		detector.AddNotifier(notifier)
+	}
+	controller.SetBootLoopGuard(detector)
+	return detector
+}
+
+// registerBootLoopMetrics exports how many nodes are in a boot loop and how
+// many loops have been detected
+func registerBootLoopMetrics(registry prometheus.Registerer, detector *bootloop.Detector) error {
+	collectors := []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: "main", Subsystem: "bootscript", Name: "boot_loops",
+			Help: "Nodes currently in a boot loop",
+		}, func() float64 { return float64(len(detector.Loops())) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: "main", Subsystem: "bootscript", Name: "boot_loops_detected_total",
+			Help: "Boot loops detected",
+		}, func() float64 { return float64(detector.Detected()) }),
+	}
+	for _, collector := range collectors {
+		if err := registry.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
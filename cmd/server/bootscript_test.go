@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/handlers/boot"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// TestNodeBootScriptRouteAlongsideGeneratedRoutes checks that the custom
+// /nodes and /bootconfigurations subroutes coexist with the generated routes.
+func TestNodeBootScriptRouteAlongsideGeneratedRoutes(t *testing.T) {
+	router := newGeneratedRouterForTest(t).(chi.Router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	bootClient, err := client.NewClient(server.URL, server.Client(), client.DefaultLogger())
+	if err != nil {
+		t.Fatalf("failed to create boot client: %v", err)
+	}
+	logger := log.New(io.Discard, "", 0)
+	controller := bootscript.NewBootScriptController(bootClient, logger)
+	boot.NewHandlerWithController(bootClient, controller, logger).RegisterModernRoutes(router)
+
+	var node v1.Node
+	createResourceForPatchTest(t, server.URL, "/nodes",
+		`{"metadata":{"name":"x0c0s0b0n0"},"spec":{"xname":"x0c0s0b0n0","nid":1,"bootMac":"aa:bb:cc:dd:ee:01","groups":["compute"]}}`,
+		&node)
+	var config v1.BootConfiguration
+	createResourceForPatchTest(t, server.URL, "/bootconfigurations",
+		`{"metadata":{"name":"compute"},"spec":{"kernel":"http://files.example.com/vmlinuz","groups":["compute"]}}`,
+		&config)
+
+	resp, err := http.Get(server.URL + "/nodes/" + node.Metadata.UID)
+	if err != nil {
+		t.Fatalf("GET node failed: %v", err)
+	}
+	resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /nodes/{uid} returned status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	resp, err = http.Get(server.URL + "/nodes/" + node.Metadata.UID + "/bootscript?dry-run=true")
+	if err != nil {
+		t.Fatalf("GET node bootscript failed: %v", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /nodes/{uid}/bootscript returned status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var preview bootscript.BootScriptPreview
+	if err := json.NewDecoder(resp.Body).Decode(&preview); err != nil {
+		t.Fatalf("failed to decode preview: %v", err)
+	}
+	if preview.NodeXName != "x0c0s0b0n0" || preview.MatchedConfiguration != "compute" {
+		t.Errorf("preview matched node %q and config %q, want x0c0s0b0n0 and compute", preview.NodeXName, preview.MatchedConfiguration)
+	}
+
+	for path, want := range map[string]int{
+		"/nodes/" + node.Metadata.UID + "/matching-configs":       http.StatusOK,
+		"/bootconfigurations/" + config.Metadata.UID + "/matches": http.StatusOK,
+		"/bootconfigurations/missing/matches":                     http.StatusNotFound,
+		"/bootconfigurations/active?at=2026-03-01T02:00:00Z":      http.StatusOK,
+		"/bootconfigurations/active?at=tomorrow":                  http.StatusBadRequest,
+	} {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		resp.Body.Close() //nolint:errcheck
+		if resp.StatusCode != want {
+			t.Errorf("GET %s returned status %d, want %d", path, resp.StatusCode, want)
+		}
+	}
+}
+
+func TestBootScriptCacheInvalidatedOnWrite(t *testing.T) {
+	router := newGeneratedRouterForTest(t).(chi.Router)
+	changes := resourcewatch.NewBackend(storage.Backend)
+	storage.Init(changes)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	bootClient, err := client.NewClient(server.URL, server.Client(), client.DefaultLogger())
+	if err != nil {
+		t.Fatalf("failed to create boot client: %v", err)
+	}
+	logger := log.New(io.Discard, "", 0)
+	controller := bootscript.NewBootScriptController(bootClient, logger)
+	changes.Subscribe(controller.HandleResourceChange)
+	boot.NewHandlerWithController(bootClient, controller, logger).RegisterModernRoutes(router)
+
+	var node v1.Node
+	createResourceForPatchTest(t, server.URL, "/nodes",
+		`{"metadata":{"name":"x0c0s0b0n0"},"spec":{"xname":"x0c0s0b0n0","nid":1,"bootMac":"aa:bb:cc:dd:ee:01","groups":["compute"]}}`,
+		&node)
+	var config v1.BootConfiguration
+	createResourceForPatchTest(t, server.URL, "/bootconfigurations",
+		`{"metadata":{"name":"compute"},"spec":{"kernel":"http://files.example.com/vmlinuz-old","groups":["compute"]}}`,
+		&config)
+
+	getScript := func() string {
+		t.Helper()
+		resp, err := http.Get(server.URL + "/bootscript?host=x0c0s0b0n0")
+		if err != nil {
+			t.Fatalf("GET /bootscript failed: %v", err)
+		}
+		defer resp.Body.Close() //nolint:errcheck
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	if script := getScript(); !strings.Contains(script, "vmlinuz-old") {
+		t.Fatalf("expected initial kernel in script, got: %s", script)
+	}
+
+	resp := sendPatchForTest(t, server.URL+"/bootconfigurations/"+config.Metadata.UID,
+		"application/merge-patch+json", `{"kernel":"http://files.example.com/vmlinuz-new"}`)
+	resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("PATCH returned status %d", resp.StatusCode)
+	}
+
+	if script := getScript(); !strings.Contains(script, "vmlinuz-new") {
+		t.Errorf("expected updated kernel immediately after PATCH, got: %s", script)
+	}
+}
@@ -0,0 +1,234 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/health"
+	"github.com/openchami/boot-service/pkg/leader"
+	"github.com/openchami/boot-service/pkg/maintenance"
+	"github.com/openchami/boot-service/pkg/ratelimit"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// diagnosticsPath serves the self-diagnostics report
+const diagnosticsPath = "/admin/diagnostics"
+
+// redactedValue replaces secrets in the reported configuration
+const redactedValue = "REDACTED"
+
+// secretConfigKeys are the settings that hold credentials. URLs are reported
+// with any password removed.
+var secretConfigKeys = map[string]bool{
+	"tokensmith_bootstrap_token": true,
+	"hsm_auth_token":             true,
+	"resource_api_token":         true,
+	"bss_upstream_token":         true,
+	"s3_secret_access_key":       true,
+	"s3_session_token":           true,
+	"image_service_token":        true,
+	"cloud_init_token":           true,
+	"script_signing_key":         true,
+	"etcd_password":              true,
+	"gitops_password":            true,
+	"bmc_discovery_password":     true,
+}
+
+// serviceStartTime is when the process started serving
+var serviceStartTime = time.Now()
+
+// diagnostics collects the runtime state reported at /admin/diagnostics. Its
+// optional parts are nil when the feature is not in use.
+type diagnostics struct {
+	reloader    *configReloader
+	controller  *bootscript.BootScriptController
+	limiter     *ratelimit.Limiter
+	readiness   *health.Checker
+	elector     *leader.Elector
+	watches     *resourcewatch.Hub
+	maintenance *maintenance.Switch
+	provider    func(ctx context.Context) map[string]interface{} // HSM provider stats
+}
+
+// diagnosticsReport is the /admin/diagnostics response
+type diagnosticsReport struct {
+	GeneratedAt time.Time              `json:"generatedAt"`
+	Service     serviceDiagnostics     `json:"service"`
+	Runtime     runtimeDiagnostics     `json:"runtime"`
+	BootScripts bootScriptDiagnostics  `json:"bootScripts"`
+	Readiness   health.Report          `json:"readiness"`
+	Leader      *leader.Status         `json:"leader,omitempty"`
+	LeaderError string                 `json:"leaderError,omitempty"`
+	Watchers    int                    `json:"watchers"`
+	Maintenance *maintenance.State     `json:"maintenance,omitempty"`
+	Provider    map[string]interface{} `json:"provider,omitempty"`
+	Config      map[string]any         `json:"config"`
+	LastReload  *reloadResult          `json:"lastReload,omitempty"`
+}
+
+type serviceDiagnostics struct {
+	Version        string    `json:"version"`
+	FabricaVersion string    `json:"fabricaVersion"`
+	StartedAt      time.Time `json:"startedAt"`
+	UptimeSeconds  int64     `json:"uptimeSeconds"`
+}
+
+type runtimeDiagnostics struct {
+	GoVersion      string `json:"goVersion"`
+	OS             string `json:"os"`
+	Arch           string `json:"arch"`
+	CPUs           int    `json:"cpus"`
+	GOMAXPROCS     int    `json:"gomaxprocs"`
+	Goroutines     int    `json:"goroutines"`
+	HeapAllocBytes uint64 `json:"heapAllocBytes"`
+	HeapInuseBytes uint64 `json:"heapInuseBytes"`
+	SysBytes       uint64 `json:"sysBytes"`
+	NumGC          uint32 `json:"numGC"`
+}
+
+type bootScriptDiagnostics struct {
+	CacheEntries        int    `json:"cacheEntries"`
+	CacheBytes          int64  `json:"cacheBytes"`
+	CacheHits           uint64 `json:"cacheHits"`
+	CacheMisses         uint64 `json:"cacheMisses"`
+	CacheEvictions      uint64 `json:"cacheEvictions"`
+	Coalesced           uint64 `json:"coalesced"`
+	DeduplicatedLookups uint64 `json:"deduplicatedLookups"`
+	RateLimited         uint64 `json:"rateLimited"`
+	// Fallbacks counts requests served by each fallback rule
+	Fallbacks []bootscript.FallbackCount `json:"fallbacks,omitempty"`
+}
+
+// RegisterRoutes registers GET /admin/diagnostics
+func (d *diagnostics) RegisterRoutes(r chi.Router) {
+	r.Get(diagnosticsPath, d.GetDiagnostics)
+}
+
+// GetDiagnostics handles GET /admin/diagnostics. With tenancy enabled only
+// an administrator may read it, since it covers every tenant.
+func (d *diagnostics) GetDiagnostics(w http.ResponseWriter, r *http.Request) {
+	if _, scoped := tenancy.FromContext(r.Context()); scoped {
+		httputil.WriteError(w, http.StatusForbidden, "Forbidden", "diagnostics require an administrator token")
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	httputil.WriteJSON(w, http.StatusOK, d.Report(r.Context()))
+}
+
+// Report collects the current diagnostics
+func (d *diagnostics) Report(ctx context.Context) diagnosticsReport {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	now := time.Now()
+
+	report := diagnosticsReport{
+		GeneratedAt: now.UTC(),
+		Service: serviceDiagnostics{
+			Version:        serviceVersionString(),
+			FabricaVersion: fabricaVersion,
+			StartedAt:      serviceStartTime.UTC(),
+			UptimeSeconds:  int64(now.Sub(serviceStartTime).Seconds()),
+		},
+		Runtime: runtimeDiagnostics{
+			GoVersion:      runtime.Version(),
+			OS:             runtime.GOOS,
+			Arch:           runtime.GOARCH,
+			CPUs:           runtime.NumCPU(),
+			GOMAXPROCS:     runtime.GOMAXPROCS(0),
+			Goroutines:     runtime.NumGoroutine(),
+			HeapAllocBytes: mem.HeapAlloc,
+			HeapInuseBytes: mem.HeapInuse,
+			SysBytes:       mem.Sys,
+			NumGC:          mem.NumGC,
+		},
+		Config:     redactConfig(d.reloader.Current()),
+		LastReload: d.reloader.LastReload(),
+	}
+
+	if d.controller != nil {
+		stats := d.controller.CacheStats()
+		report.BootScripts = bootScriptDiagnostics{
+			CacheEntries:        stats.TotalEntries,
+			CacheBytes:          stats.Bytes,
+			CacheHits:           stats.Hits,
+			CacheMisses:         stats.Misses,
+			CacheEvictions:      stats.Evictions,
+			Coalesced:           d.controller.CoalescedRequests(),
+			DeduplicatedLookups: d.controller.DeduplicatedLookups(),
+			Fallbacks:           d.controller.FallbackUsage(),
+		}
+	}
+	if d.limiter != nil {
+		report.BootScripts.RateLimited = d.limiter.Rejected()
+	}
+	if d.readiness != nil {
+		report.Readiness = d.readiness.Run(ctx)
+	}
+	if d.elector != nil {
+		if status, err := d.elector.Status(ctx); err != nil {
+			report.LeaderError = err.Error()
+		} else {
+			report.Leader = &status
+		}
+	}
+	if d.watches != nil {
+		report.Watchers = d.watches.Len()
+	}
+	if d.maintenance != nil {
+		state := d.maintenance.State()
+		report.Maintenance = &state
+	}
+	if d.provider != nil {
+		report.Provider = d.provider(ctx)
+	}
+	return report
+}
+
+// redactConfig returns the settings by config key, with credentials replaced
+// and passwords removed from URLs
+func redactConfig(config Config) map[string]any {
+	settings := map[string]any{}
+	value := reflect.ValueOf(config)
+	for i := 0; i < value.NumField(); i++ {
+		key := configKey(value.Type().Field(i))
+		if key == "" {
+			continue
+		}
+		field := value.Field(i)
+		if field.Kind() != reflect.String {
+			settings[key] = field.Interface()
+			continue
+		}
+		settings[key] = redactSetting(key, field.String())
+	}
+	return settings
+}
+
+// redactSetting redacts one string setting
+func redactSetting(key, value string) string {
+	if value == "" {
+		return value
+	}
+	if secretConfigKeys[key] {
+		return redactedValue
+	}
+	if parsed, err := url.Parse(value); err == nil && parsed.User != nil {
+		if _, hasPassword := parsed.User.Password(); hasPassword {
+			return parsed.Redacted()
+		}
+	}
+	return value
+}
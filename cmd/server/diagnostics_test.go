@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/pkg/health"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+func TestRedactConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.HSMAuthToken = "hsm-secret"
+	config.S3SecretAccessKey = "s3-secret"
+	config.RedisURL = "redis://:pw@redis:6379/0"
+	config.HSMURL = "http://smd:27779"
+
+	settings := redactConfig(config)
+	if settings["hsm_auth_token"] != redactedValue || settings["s3_secret_access_key"] != redactedValue {
+		t.Errorf("credentials not redacted: %v, %v", settings["hsm_auth_token"], settings["s3_secret_access_key"])
+	}
+	if settings["redis_url"] != "redis://:xxxxx@redis:6379/0" {
+		t.Errorf("redis_url = %v, want the password removed", settings["redis_url"])
+	}
+	if settings["hsm_url"] != "http://smd:27779" || settings["port"] != 8080 || settings["resource_api_token"] != "" {
+		t.Errorf("other settings changed: %v, %v, %v", settings["hsm_url"], settings["port"], settings["resource_api_token"])
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	config := DefaultConfig()
+	config.HSMAuthToken = "hsm-secret"
+	readiness := health.NewChecker(time.Second)
+	readiness.Add("templates", true, func(ctx context.Context) error { return nil })
+	watches := resourcewatch.NewHub(1)
+	watches.Watch("Node")
+	diag := &diagnostics{reloader: newConfigReloader(config, loadConfig), readiness: readiness, watches: watches}
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if tenant := r.Header.Get("X-Tenant"); tenant != "" {
+				r = r.WithContext(tenancy.WithTenant(r.Context(), tenant))
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	diag.RegisterRoutes(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, diagnosticsPath, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("diagnostics returned %d: %s", w.Code, w.Body.String())
+	}
+	var report diagnosticsReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Runtime.Goroutines == 0 || report.Service.Version == "" || report.Watchers != 1 ||
+		report.Readiness.Status != health.StatusOK || report.Config["hsm_auth_token"] != redactedValue {
+		t.Errorf("unexpected report: %s", w.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodGet, diagnosticsPath, nil)
+	req.Header.Set("X-Tenant", "site-a")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("tenant request returned %d, want 403", w.Code)
+	}
+}
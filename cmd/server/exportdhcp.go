@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/dhcp"
+)
+
+// exportDHCPOptions configures a DHCP reservations export
+type exportDHCPOptions struct {
+	format  string
+	group   string
+	subnet  string
+	dataDir string
+	output  string
+}
+
+// newExportDHCPCommand creates the export-dhcp command
+func newExportDHCPCommand() *cobra.Command {
+	opts := exportDHCPOptions{}
+	cmd := &cobra.Command{
+		Use:   "export-dhcp",
+		Short: "Write DHCP host reservations for dnsmasq or CoreDHCP",
+		Long: `Render static DHCP host reservations from the stored nodes: one per interface
+MAC with its IP address, and the node's hostname (or xname) on the boot MAC.
+
+The dnsmasq format writes dhcp-host lines for a configuration file, e.g. in
+/etc/dnsmasq.d or included with conf-file. The coredhcp format writes
+"<mac> <ip>" lines for the CoreDHCP file plugin, as used in coresmd
+deployments; interfaces without an IP are left out of it. Interfaces with an invalid MAC or IP, or an address
+already reserved for another node, are skipped with a warning.
+
+The same output is served at GET /dhcp/hosts on a running service.`,
+		Example: `  boot-service export-dhcp --output /etc/dnsmasq.d/nodes.conf
+  boot-service export-dhcp --format coredhcp --subnet 10.1.0.0/16 --output leases.txt`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive
+			out := cmd.OutOrStdout()
+			if opts.output != "" && opts.output != "-" {
+				file, err := os.Create(opts.output)
+				if err != nil {
+					return err
+				}
+				defer file.Close() //nolint:errcheck
+				out = file
+			}
+			return runExportDHCP(cmd.Context(), out, cmd.ErrOrStderr(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.format, "format", dhcp.FormatDnsmasq, "Output format: dnsmasq or coredhcp")
+	cmd.Flags().StringVar(&opts.group, "group", "", "Only include nodes in this group")
+	cmd.Flags().StringVar(&opts.subnet, "subnet", "", "Only include addresses in this IPv4 CIDR")
+	cmd.Flags().StringVar(&opts.dataDir, "data-dir", "", "Directory for file storage (default data_dir from the configuration file, or ./data)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "File to write (default stdout)")
+	return cmd
+}
+
+func runExportDHCP(ctx context.Context, out, errOut io.Writer, opts exportDHCPOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !dhcp.ValidFormat(opts.format) {
+		return fmt.Errorf("invalid --format %q: must be %s or %s", opts.format, dhcp.FormatDnsmasq, dhcp.FormatCoreDHCP)
+	}
+	filter := dhcp.Options{Group: opts.group}
+	if opts.subnet != "" {
+		prefix, err := netip.ParsePrefix(opts.subnet)
+		if err != nil || !prefix.Addr().Is4() {
+			return fmt.Errorf("invalid --subnet %q: must be an IPv4 CIDR", opts.subnet)
+		}
+		filter.Subnet = prefix.Masked()
+	}
+
+	if err := storage.InitFileBackend(stateDataDir(opts.dataDir)); err != nil {
+		return fmt.Errorf("failed to initialize storage: %v", err)
+	}
+	nodes, err := client.NewInProcessClient().GetNodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := dhcp.Reservations(nodes, filter)
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(errOut, "warning: %s\n", warning) //nolint:errcheck
+	}
+	return dhcp.Write(out, opts.format, result)
+}
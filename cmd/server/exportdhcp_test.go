@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunExportDHCP(t *testing.T) {
+	registerResourcePrefixesOnce.Do(func() {
+		if err := registerResourcePrefixes(); err != nil {
+			t.Fatalf("failed to register resource prefixes: %v", err)
+		}
+	})
+
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	inventory := filepath.Join(t.TempDir(), "inventory.csv")
+	csv := "xname,bootMac,hostname,groups\nx0c0s0b0n0,aa:bb:cc:dd:ee:01,nid0001,compute\nx0c0s1b0n0,aa:bb:cc:dd:ee:02,,storage\n"
+	if err := os.WriteFile(inventory, []byte(csv), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := runImportNodes(ctx, &bytes.Buffer{}, inventory, importNodesOptions{dataDir: dataDir}); err != nil {
+		t.Fatalf("seeding nodes failed: %v", err)
+	}
+
+	var out, errOut bytes.Buffer
+	if err := runExportDHCP(ctx, &out, &errOut, exportDHCPOptions{format: "dnsmasq", group: "compute", dataDir: dataDir}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "dhcp-host=aa:bb:cc:dd:ee:01,nid0001\n") || strings.Contains(out.String(), "ee:02") {
+		t.Errorf("unexpected dnsmasq output:\n%s", out.String())
+	}
+
+	for _, opts := range []exportDHCPOptions{{format: "isc"}, {format: "coredhcp", subnet: "10.0.0.1"}} {
+		opts.dataDir = dataDir
+		if err := runExportDHCP(ctx, &out, &errOut, opts); err == nil {
+			t.Errorf("export with %+v succeeded, want an error", opts)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/clients/hsm"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/health"
+	"github.com/openchami/boot-service/pkg/storagemigration"
+)
+
+// readinessChecks are the checks readiness_checks may name
+var readinessChecks = []string{"storage", "templates", "hsm", "migration"}
+
+// registerReadiness serves /livez and /readyz with the checks named in
+// readiness_checks and returns the checker. Storage is checked through bootClient, the same path boot
+// scripts read nodes and configurations through. HSM is only checked when
+// configured and never makes the service unready, since boot scripts are
+// still served from the last sync. The migration check fails while a
+// restore, import, or migration rewrites the storage.
+func registerReadiness(r chi.Router, config Config, bootClient client.API, hsmClient *hsm.HSMClient) *health.Checker {
+	checker := health.NewChecker(time.Duration(config.ReadinessTimeoutMS) * time.Millisecond)
+	for _, name := range parseScopeHintCSV(config.ReadinessChecks) {
+		switch name {
+		case "storage":
+			checker.Add(name, true, func(ctx context.Context) error {
+				_, err := bootClient.GetBootConfigurations(ctx)
+				return err
+			})
+		case "templates":
+			checker.Add(name, true, func(context.Context) error {
+				return bootscript.CheckTemplates()
+			})
+		case "hsm":
+			if hsmClient != nil {
+				checker.Add(name, false, hsmClient.Health)
+			}
+		case "migration":
+			checker.Add(name, true, storagemigration.Check(storage.Backend))
+		}
+	}
+	health.NewHandler(checker).RegisterRoutes(r)
+	log.Printf("Readiness checks at /readyz: %s", strings.Join(checker.Names(), ", "))
+	return checker
+}
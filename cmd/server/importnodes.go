@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/nodeimport"
+)
+
+// importNodesOptions configures a node import from an inventory file
+type importNodesOptions struct {
+	format     string
+	mapping    []string
+	dataDir    string
+	reportFile string
+	dryRun     bool
+	strict     bool
+}
+
+// newImportNodesCommand creates the import-nodes command
+func newImportNodesCommand() *cobra.Command {
+	opts := importNodesOptions{}
+	cmd := &cobra.Command{
+		Use:   "import-nodes <file>",
+		Short: "Create and update nodes from a CSV or SLS inventory",
+		Long: `Read nodes from a CSV spreadsheet or a Cray System Layout Service (SLS) dump
+and write them to the storage backend. Nodes already stored with the same
+xname keep their UID and are updated; fields the inventory leaves empty keep
+their current value.
+
+CSV input needs a header row. Columns named after a node field (xname, nid,
+bootMac, macs, role, subRole, hostname, groups, or metadata.<key>) are mapped
+automatically; --map <field>=<column> maps other columns. List cells (macs,
+groups) are separated by semicolons or spaces.
+
+SLS input is a dumpstate document or a GET /v1/hardware list. Its
+comptype_node entries set the xname, NID, role, subrole, and hostname (the
+first alias).
+
+Invalid entries are skipped and listed, or with --strict fail the whole
+import. The format defaults to the file extension (.csv or .json).
+
+Like import, this writes to the storage backend directly. On a running
+service, POST the inventory to /nodes:import instead.`,
+		Example: `  boot-service import-nodes --dry-run inventory.csv
+  boot-service import-nodes --map xname=Component --map bootMac="Boot MAC" inventory.csv
+  boot-service import-nodes --format sls --report import.json sls_dump.json`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// UIDs of created nodes use the same prefixes as the API
+			if err := registerResourcePrefixes(); err != nil {
+				return fmt.Errorf("failed to register resource prefixes: %w", err)
+			}
+			return runImportNodes(cmd.Context(), cmd.OutOrStdout(), args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.format, "format", "", "Inventory format: csv or sls (default from the file extension)")
+	cmd.Flags().StringArrayVar(&opts.mapping, "map", nil, "Map a CSV column to a node field, as <field>=<column> (repeatable)")
+	cmd.Flags().StringVar(&opts.dataDir, "data-dir", "", "Directory for file storage (default data_dir from the configuration file, or ./data)")
+	cmd.Flags().StringVar(&opts.reportFile, "report", "", "Write a JSON import report to this file")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Validate and report without writing to storage")
+	cmd.Flags().BoolVar(&opts.strict, "strict", false, "Write nothing when any entry is invalid")
+	return cmd
+}
+
+func runImportNodes(ctx context.Context, out io.Writer, path string, opts importNodesOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	format := opts.format
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			format = nodeimport.FormatCSV
+		case ".json":
+			format = nodeimport.FormatSLS
+		default:
+			return fmt.Errorf("cannot tell the format of %s: use --format csv or --format sls", path)
+		}
+	}
+	mapping, err := nodeimport.ParseMapping(opts.mapping)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close() //nolint:errcheck
+
+	if err := storage.InitFileBackend(stateDataDir(opts.dataDir)); err != nil {
+		return fmt.Errorf("failed to initialize storage: %v", err)
+	}
+	report, err := nodeimport.Run(ctx, storage.Backend, file, nodeimport.Options{
+		Format:  format,
+		Mapping: mapping,
+		DryRun:  opts.dryRun,
+		Strict:  opts.strict,
+	})
+	if report == nil {
+		return err
+	}
+
+	counts := report.Import["Node"]
+	fmt.Fprintf(out, "Read %d entries from %s\n", report.Entries, path)                //nolint:errcheck
+	fmt.Fprintf(out, "Node: %d created, %d updated\n", counts.Created, counts.Updated) //nolint:errcheck
+	for _, problem := range report.Skipped {
+		fmt.Fprintf(out, "skipped %s: %s\n", problem.Location, problem.Error) //nolint:errcheck
+	}
+	for _, warning := range report.Warnings {
+		fmt.Fprintf(out, "warning: %s\n", warning) //nolint:errcheck
+	}
+	if opts.dryRun && err == nil {
+		fmt.Fprintln(out, "Dry run: no changes written") //nolint:errcheck
+	}
+
+	if opts.reportFile != "" {
+		data, marshalErr := json.MarshalIndent(report, "", "  ")
+		if marshalErr != nil {
+			return marshalErr
+		}
+		if writeErr := os.WriteFile(opts.reportFile, append(data, '\n'), 0o600); writeErr != nil {
+			return fmt.Errorf("failed to write report: %w", writeErr)
+		}
+	}
+	if errors.Is(err, nodeimport.ErrInvalidEntries) {
+		return fmt.Errorf("%w; nothing was written", err)
+	}
+	return err
+}
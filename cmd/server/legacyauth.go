@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/apikeys"
+	"github.com/openchami/boot-service/pkg/auth"
+)
+
+// legacyAccess is the access a legacy BSS request needs. Each level grants
+// the ones below it, so a token that may write may also read.
+type legacyAccess int
+
+const (
+	legacyBootScript legacyAccess = iota
+	legacyRead
+	legacyWrite
+)
+
+// legacyAccessNames are the names legacy_scopes maps to scopes
+var legacyAccessNames = map[string]legacyAccess{
+	"bootscript": legacyBootScript,
+	"read":       legacyRead,
+	"write":      legacyWrite,
+}
+
+// defaultLegacyScopes is the default of legacy_scopes
+const defaultLegacyScopes = "bootscript=bss:bootscript,read=bss:read,write=bss:write"
+
+// parseLegacyScopes parses "<access>=<scope>" entries over the defaults. An
+// empty scope accepts any verified token for that access.
+func parseLegacyScopes(entries []string) (map[legacyAccess]string, error) {
+	scopes := map[legacyAccess]string{
+		legacyBootScript: "bss:bootscript",
+		legacyRead:       "bss:read",
+		legacyWrite:      "bss:write",
+	}
+	for _, entry := range entries {
+		name, scope, ok := strings.Cut(entry, "=")
+		access, known := legacyAccessNames[strings.TrimSpace(name)]
+		if !ok || !known {
+			return nil, fmt.Errorf("invalid legacy scope %q: want bootscript, read, or write=<scope>", entry)
+		}
+		scopes[access] = strings.TrimSpace(scope)
+	}
+	return scopes, nil
+}
+
+// requiredLegacyAccess returns the access a request to the legacy API needs
+func requiredLegacyAccess(r *http.Request) legacyAccess {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return legacyWrite
+	}
+	if isLegacyBootScript(r.URL.Path) {
+		return legacyBootScript
+	}
+	return legacyRead
+}
+
+func isLegacyBootScript(path string) bool {
+	return path == "/boot/v1/bootscript" || path == "/boot/v1/bootscript.sig"
+}
+
+// legacyAuth requires a token verified against jwks_endpoint, or an API
+// key, carrying the scope legacy_scopes maps each request's access to, on the legacy BSS API.
+// With legacy_auth_non_enforcing the requests it would reject are served and
+// counted in bypasses.
+func legacyAuth(config Config, keys *apikeys.Store, bypasses *auth.BypassReport) func(http.Handler) http.Handler {
+	logger := log.New(os.Stdout, "legacy-auth: ", log.LstdFlags)
+	authConfig := tokenAuthConfig(config, keys)
+	authn := authConfig.CreateMiddleware(logger)
+	scopes, _ := parseLegacyScopes(parseScopeHintCSV(config.LegacyScopes))                     // validated
+	allowed, _ := httputil.ParseNetworks(parseScopeHintCSV(config.LegacyBootScriptAllowCIDRs)) // validated
+	log.Printf("Legacy API authorization enabled (scopes: bootscript=%q, read=%q, write=%q; unauthenticated boot scripts from %d networks)",
+		scopes[legacyBootScript], scopes[legacyRead], scopes[legacyWrite], len(allowed))
+	if config.LegacyAuthNonEnforcing {
+		log.Printf("Legacy API authorization is not enforced; rejections are counted at %s", authReportPath)
+		return auth.ReportOnly(protectedLegacy(authn, scopes, allowed), bypasses, logger)
+	}
+	return protectedLegacy(authn, scopes, allowed)
+}
+
+// protectedLegacy wraps the legacy API in authn and a scope check, except
+// for boot script requests from the allowed networks, such as the PXE
+// network, and the service status and version endpoints
+func protectedLegacy(authn func(http.Handler) http.Handler, scopes map[legacyAccess]string, allowed httputil.Networks) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		authorized := authn(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !hasLegacyAccess(r, scopes, requiredLegacyAccess(r)) {
+				http.Error(w, "insufficient scope", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		}))
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/boot/v1/service/") ||
+				(requiredLegacyAccess(r) == legacyBootScript && allowed.ContainsClient(r)) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			authorized.ServeHTTP(w, r)
+		})
+	}
+}
+
+// hasLegacyAccess reports whether the verified token of r carries the
+// scope of access, or of an access that grants it
+func hasLegacyAccess(r *http.Request, scopes map[legacyAccess]string, access legacyAccess) bool {
+	claims, err := auth.GetClaimsFromRequest(r)
+	if err != nil {
+		return false
+	}
+	for level := access; level <= legacyWrite; level++ {
+		if scopes[level] == "" || slices.Contains(claims.Scope, scopes[level]) {
+			return true
+		}
+	}
+	return false
+}
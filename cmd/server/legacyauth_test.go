@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/auth"
+)
+
+func TestProtectedLegacy(t *testing.T) {
+	keyPair, err := auth.GenerateTestKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	scopes, err := parseLegacyScopes([]string{"bootscript=pxe"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowed, err := httputil.ParseNetworks([]string{"10.1.0.0/16"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	authConfig := auth.CreateStaticKeyConfig(keyPair.PublicKeyPEM)
+	handler := protectedLegacy(authConfig.CreateMiddleware(nil), scopes, allowed)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(method, path, from string, scopes ...string) int {
+		req := httptest.NewRequest(method, path, nil)
+		if from != "" {
+			req.RemoteAddr = from + ":40000"
+		}
+		if scopes != nil {
+			tok, err := auth.CreateTestTokenWithScopes(keyPair, scopes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Authorization", "Bearer "+tok)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		from   string
+		scopes []string
+		want   int
+	}{
+		{"boot script without a token", http.MethodGet, "/boot/v1/bootscript?mac=aa:bb:cc:dd:ee:ff", "", nil, http.StatusUnauthorized},
+		{"boot script from the PXE network", http.MethodGet, "/boot/v1/bootscript?mac=aa:bb:cc:dd:ee:ff", "10.1.2.3", nil, http.StatusOK},
+		{"boot parameters from the PXE network", http.MethodGet, "/boot/v1/bootparameters", "10.1.2.3", nil, http.StatusUnauthorized},
+		{"boot script with the mapped scope", http.MethodGet, "/boot/v1/bootscript", "", []string{"pxe"}, http.StatusOK},
+		{"boot script with a read token", http.MethodGet, "/boot/v1/bootscript", "", []string{"bss:read"}, http.StatusOK},
+		{"boot parameters with the boot script scope", http.MethodGet, "/boot/v1/bootparameters", "", []string{"pxe"}, http.StatusForbidden},
+		{"boot parameters with a read token", http.MethodGet, "/boot/v1/bootparameters", "", []string{"bss:read"}, http.StatusOK},
+		{"write with a read token", http.MethodPost, "/boot/v1/bootparameters", "", []string{"bss:read"}, http.StatusForbidden},
+		{"read with a write token", http.MethodGet, "/boot/v1/bootparameters", "", []string{"bss:write"}, http.StatusOK},
+		{"write with a write token", http.MethodDelete, "/boot/v1/bootparameters", "", []string{"bss:write"}, http.StatusOK},
+		{"service status", http.MethodGet, "/boot/v1/service/status", "", nil, http.StatusOK},
+	}
+	for _, tt := range tests {
+		if code := serve(tt.method, tt.path, tt.from, tt.scopes...); code != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, code, tt.want)
+		}
+	}
+
+	// Non-enforcing, every request is served and the rejections are counted
+	bypasses := auth.NewBypassReport()
+	handler = auth.ReportOnly(protectedLegacy(authConfig.CreateMiddleware(nil), scopes, allowed), bypasses, nil)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	for _, tt := range tests {
+		if code := serve(tt.method, tt.path, tt.from, tt.scopes...); code != http.StatusOK {
+			t.Errorf("non-enforcing %s: got %d, want %d", tt.name, code, http.StatusOK)
+		}
+	}
+	report := bypasses.Snapshot()
+	if report.Checked != int64(len(tests)) || report.ByReason[auth.ReasonMissingToken] != 2 || report.ByReason[auth.ReasonMissingScope] != 2 || report.Bypassed != 4 {
+		t.Errorf("report = %+v, want 2 missing tokens and 2 missing scopes of %d", report, len(tests))
+	}
+}
+
+func TestValidateConfig_LegacyAuth(t *testing.T) {
+	config := DefaultConfig()
+	config.LegacyAuthEnabled = true
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for legacy-auth-enabled without enable-legacy-api")
+	}
+	config.EnableLegacyAPI = true
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for legacy-auth-enabled without jwks-endpoint")
+	}
+	config.JWKSEndpoint = "https://tokensmith.example.com/.well-known/jwks.json"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig() error = %v", err)
+	}
+	config.LegacyScopes = "bootscript=pxe,admin=bss:admin"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for an unknown legacy access")
+	}
+	config.LegacyScopes = defaultLegacyScopes
+	config.LegacyBootScriptAllowCIDRs = "10.1.0.0/16,pxe-net"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for a boot script CIDR that is not a CIDR or address")
+	}
+	config.LegacyBootScriptAllowCIDRs = ""
+	config.LegacyAuthNonEnforcing = true
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig() error = %v", err)
+	}
+	config.LegacyAuthEnabled = false
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for legacy-auth-non-enforcing without legacy-auth-enabled")
+	}
+}
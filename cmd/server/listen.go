@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// sdListenFDsStart is the first file descriptor systemd passes to a
+// socket-activated service
+const sdListenFDsStart = 3
+
+// listenSocketMode is the permission of the Unix domain socket, so a
+// reverse proxy in the socket's group can connect
+const listenSocketMode = 0o660
+
+// openListeners returns the listeners the server accepts connections on:
+// the sockets systemd passed when socket-activated, or else host:port and,
+// when set, the Unix domain socket at listen_socket
+func openListeners(config Config) ([]net.Listener, error) {
+	activated, err := systemdListeners(os.Getenv("LISTEN_PID"), os.Getenv("LISTEN_FDS"), sdListenFDsStart)
+	// The sockets belong to this process only, not to anything it starts
+	os.Unsetenv("LISTEN_PID")     //nolint:errcheck
+	os.Unsetenv("LISTEN_FDS")     //nolint:errcheck
+	os.Unsetenv("LISTEN_FDNAMES") //nolint:errcheck
+	if err != nil || len(activated) > 0 {
+		return activated, err
+	}
+
+	var listenConfig net.ListenConfig
+	if config.ReusePort {
+		listenConfig.Control = reusePort
+	}
+	tcp, err := listenConfig.Listen(context.Background(), "tcp", net.JoinHostPort(config.Host, strconv.Itoa(config.Port)))
+	if err != nil {
+		return nil, err
+	}
+	listeners := []net.Listener{tcp}
+	if config.ListenSocket != "" {
+		socket, err := unixListener(config.ListenSocket, config.ReusePort)
+		if err != nil {
+			tcp.Close() //nolint:errcheck
+			return nil, err
+		}
+		listeners = append(listeners, socket)
+	}
+	return listeners, nil
+}
+
+// systemdListeners returns the listening sockets passed by systemd socket
+// activation (sd_listen_fds), given the LISTEN_PID and LISTEN_FDS variables.
+// They are meant for this process only when LISTEN_PID is its PID.
+func systemdListeners(listenPID, listenFDs string, firstFD int) ([]net.Listener, error) {
+	if listenPID == "" || listenPID != strconv.Itoa(os.Getpid()) {
+		return nil, nil
+	}
+	count, err := strconv.Atoi(listenFDs)
+	if err != nil || count < 1 {
+		return nil, fmt.Errorf("invalid LISTEN_FDS %q", listenFDs)
+	}
+
+	listeners := make([]net.Listener, 0, count)
+	for fd := firstFD; fd < firstFD+count; fd++ {
+		syscall.CloseOnExec(fd)
+		file := os.NewFile(uintptr(fd), "systemd-socket-"+strconv.Itoa(fd))
+		listener, err := net.FileListener(file)
+		// FileListener duplicates the descriptor
+		file.Close() //nolint:errcheck
+		if err != nil {
+			for _, l := range listeners {
+				l.Close() //nolint:errcheck
+			}
+			return nil, fmt.Errorf("socket-activated file descriptor %d: %w", fd, err)
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners, nil
+}
+
+// reusePort sets SO_REUSEPORT on a socket before it binds, so the kernel
+// spreads connections across every process listening on the same port
+func reusePort(_, _ string, conn syscall.RawConn) error {
+	var sockErr error
+	if err := conn.Control(func(fd uintptr) {
+		sockErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEPORT, 1)
+	}); err != nil {
+		return err
+	}
+	return sockErr
+}
+
+// unixListener listens on a Unix domain socket at path, replacing a socket
+// left behind by a server that is no longer running. With takeOver, a socket
+// still in use is replaced too: the old server keeps serving connections it
+// accepted, and new ones reach this one.
+func unixListener(path string, takeOver bool) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("listen-socket %s exists and is not a socket", path)
+		}
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close() //nolint:errcheck
+			if !takeOver {
+				return nil, fmt.Errorf("listen-socket %s is in use", path)
+			}
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("removing listen-socket: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, listenSocketMode); err != nil {
+		listener.Close() //nolint:errcheck
+		return nil, err
+	}
+	if takeOver {
+		// The path may belong to a newer server by the time this one stops
+		listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	}
+	return listener, nil
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+)
+
+// getOverUnix fetches path from a server listening on the Unix socket at
+// socketPath
+func getOverUnix(t *testing.T, socketPath, path string) string {
+	t.Helper()
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+		},
+	}}
+	resp, err := client.Get("http://boot-service" + path)
+	if err != nil {
+		t.Fatalf("GET over %s failed: %v", socketPath, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestUnixListener(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "boot-service.sock")
+
+	// A socket left behind by a server that is gone is replaced
+	stale, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close() //nolint:errcheck
+
+	listener, err := unixListener(socketPath, false)
+	if err != nil {
+		t.Fatalf("unixListener failed: %v", err)
+	}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok")) //nolint:errcheck
+	})}
+	go server.Serve(listener) //nolint:errcheck
+	defer server.Close()      //nolint:errcheck
+
+	info, err := os.Stat(socketPath)
+	if err != nil || info.Mode().Perm() != listenSocketMode {
+		t.Errorf("socket mode = %v, %v, want %o", info.Mode().Perm(), err, listenSocketMode)
+	}
+	if body := getOverUnix(t, socketPath, "/health"); body != "ok" {
+		t.Errorf("response = %q, want ok", body)
+	}
+
+	// A socket another server is listening on is left alone
+	if _, err := unixListener(socketPath, false); err == nil {
+		t.Error("expected an error for a socket in use")
+	}
+	regular := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(regular, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := unixListener(regular, false); err == nil {
+		t.Error("expected an error for a path that is not a socket")
+	}
+}
+
+func TestUnixListener_TakeOver(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "boot-service.sock")
+	serve := func(listener net.Listener, body string) *http.Server {
+		server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(body)) //nolint:errcheck
+		})}
+		go server.Serve(listener) //nolint:errcheck
+		return server
+	}
+
+	old, err := unixListener(socketPath, true)
+	if err != nil {
+		t.Fatalf("unixListener failed: %v", err)
+	}
+	oldServer := serve(old, "old")
+	replacement, err := unixListener(socketPath, true)
+	if err != nil {
+		t.Fatalf("taking over a socket in use failed: %v", err)
+	}
+	defer serve(replacement, "new").Close() //nolint:errcheck
+
+	// The old server stopping leaves the replacement's socket in place
+	oldServer.Close() //nolint:errcheck
+	if body := getOverUnix(t, socketPath, "/health"); body != "new" {
+		t.Errorf("response = %q, want new", body)
+	}
+}
+
+func TestOpenListeners_ReusePort(t *testing.T) {
+	config := DefaultConfig()
+	config.Host = "127.0.0.1"
+	config.Port = 0
+	config.ReusePort = true
+	first, err := openListeners(config)
+	if err != nil {
+		t.Fatalf("openListeners failed: %v", err)
+	}
+	defer first[0].Close() //nolint:errcheck
+
+	// A second process can bind the same port while the first still listens
+	config.Port = first[0].Addr().(*net.TCPAddr).Port
+	second, err := openListeners(config)
+	if err != nil {
+		t.Fatalf("binding a port in use with reuse_port failed: %v", err)
+	}
+	second[0].Close() //nolint:errcheck
+
+	config.ReusePort = false
+	if listeners, err := openListeners(config); err == nil {
+		listeners[0].Close() //nolint:errcheck
+		t.Error("expected an error binding a port in use without reuse_port")
+	}
+}
+
+func TestSystemdListeners(t *testing.T) {
+	if listeners, err := systemdListeners("", "", sdListenFDsStart); err != nil || listeners != nil {
+		t.Errorf("without LISTEN_PID = %v, %v, want no listeners", listeners, err)
+	}
+	if listeners, err := systemdListeners(strconv.Itoa(os.Getpid()+1), "1", sdListenFDsStart); err != nil || listeners != nil {
+		t.Errorf("for another process = %v, %v, want no listeners", listeners, err)
+	}
+	if _, err := systemdListeners(strconv.Itoa(os.Getpid()), "none", sdListenFDsStart); err == nil {
+		t.Error("expected an error for an invalid LISTEN_FDS")
+	}
+
+	// Pass a socket the way systemd would, at a known descriptor
+	passed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer passed.Close() //nolint:errcheck
+	file, err := passed.(*net.TCPListener).File()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// systemdListeners takes ownership of the descriptor it is passed
+	fd, err := syscall.Dup(int(file.Fd()))
+	file.Close() //nolint:errcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listeners, err := systemdListeners(strconv.Itoa(os.Getpid()), "1", fd)
+	if err != nil || len(listeners) != 1 {
+		t.Fatalf("systemdListeners = %v, %v, want one listener", listeners, err)
+	}
+	defer listeners[0].Close() //nolint:errcheck
+	if listeners[0].Addr().String() != passed.Addr().String() {
+		t.Errorf("listener address = %s, want %s", listeners[0].Addr(), passed.Addr())
+	}
+}
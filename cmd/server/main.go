@@ -9,10 +9,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -24,8 +31,26 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/openchami/boot-service/internal/httputil"
 	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/activity"
+	"github.com/openchami/boot-service/pkg/apikeys"
+	"github.com/openchami/boot-service/pkg/artifacts"
+	"github.com/openchami/boot-service/pkg/audit"
+	"github.com/openchami/boot-service/pkg/backend"
+	"github.com/openchami/boot-service/pkg/bmcdiscovery"
+	"github.com/openchami/boot-service/pkg/bmctelemetry"
 	"github.com/openchami/boot-service/pkg/clients/hsm"
+	"github.com/openchami/boot-service/pkg/clients/redfish"
+	"github.com/openchami/boot-service/pkg/gitops"
+	"github.com/openchami/boot-service/pkg/handlers/boot"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/schemas"
+	"github.com/openchami/boot-service/pkg/sharedstate"
+	"github.com/openchami/boot-service/pkg/tftp"
+	"github.com/openchami/boot-service/pkg/trash"
+	"github.com/openchami/boot-service/pkg/utilityboot"
+	"github.com/openchami/boot-service/pkg/validation"
 )
 
 // Config holds all configuration for the boot service
@@ -36,10 +61,28 @@ type Config struct {
 	ReadTimeout  int    `mapstructure:"read_timeout"`
 	WriteTimeout int    `mapstructure:"write_timeout"`
 	IdleTimeout  int    `mapstructure:"idle_timeout"`
+	// ListenSocket is a Unix domain socket path served in addition to
+	// host:port, such as for a local reverse proxy
+	ListenSocket string `mapstructure:"listen_socket"`
+	// ReusePort binds host:port with SO_REUSEPORT, so a new process can
+	// start listening before the old one stops
+	ReusePort bool `mapstructure:"reuse_port"`
+	// DrainTimeout is how long in-flight requests have to complete on
+	// shutdown before their connections are closed
+	DrainTimeout int `mapstructure:"drain_timeout"`
+	// TrustedProxies are the comma-separated CIDRs of proxies whose
+	// X-Forwarded-For and X-Real-IP headers name the client
+	TrustedProxies string `mapstructure:"trusted_proxies"`
 
 	// Storage Configuration
 	DataDir     string `mapstructure:"data_dir"`
-	StorageType string `mapstructure:"storage_type"` // file, database
+	StorageType string `mapstructure:"storage_type"` // file, etcd
+
+	// Etcd Storage Configuration (storage_type etcd)
+	EtcdURL      string `mapstructure:"etcd_url"`
+	EtcdPrefix   string `mapstructure:"etcd_prefix"`
+	EtcdUsername string `mapstructure:"etcd_username"`
+	EtcdPassword string `mapstructure:"etcd_password"`
 
 	// Feature Flags
 	EnableAuth      bool `mapstructure:"enable_auth"`
@@ -47,6 +90,45 @@ type Config struct {
 	EnableLegacyAPI bool `mapstructure:"enable_legacy_api"`
 	MetricsPort     int  `mapstructure:"metrics_port"`
 
+	// SpecSchemaValidation rejects resource creates and replacements whose
+	// spec does not match its published JSON Schema (see /schemas)
+	SpecSchemaValidation bool `mapstructure:"spec_schema_validation"`
+
+	// ValidationProfile sets how strictly node xnames, MAC addresses, and
+	// kernel and initrd URLs are validated: "strict" or "lenient". A
+	// pattern replaces the profile's rule for its field.
+	ValidationProfile      string `mapstructure:"validation_profile"`
+	ValidationXNamePattern string `mapstructure:"validation_xname_pattern"`
+	ValidationMACPattern   string `mapstructure:"validation_mac_pattern"`
+	ValidationURLPattern   string `mapstructure:"validation_url_pattern"`
+
+	// Legacy API exchanges are appended to this file for replay against BSS
+	LegacyRecordFile string `mapstructure:"legacy_record_file"`
+
+	// BSS instance legacy writes are mirrored to and missing reads fall back to
+	BSSUpstreamURL   string `mapstructure:"bss_upstream_url"`
+	BSSUpstreamToken string `mapstructure:"bss_upstream_token"`
+
+	// LegacyAuthEnabled requires tokens verified against jwks_endpoint on
+	// the legacy API, with the scope LegacyScopes maps each access to
+	// ("bootscript", "read", or "write"). Boot scripts are served without a
+	// token to the comma-separated LegacyBootScriptAllowCIDRs. With
+	// LegacyAuthNonEnforcing, requests that would be rejected are served
+	// and counted instead.
+	LegacyAuthEnabled          bool   `mapstructure:"legacy_auth_enabled"`
+	LegacyScopes               string `mapstructure:"legacy_scopes"`
+	LegacyBootScriptAllowCIDRs string `mapstructure:"legacy_bootscript_allow_cidrs"`
+	LegacyAuthNonEnforcing     bool   `mapstructure:"legacy_auth_non_enforcing"`
+
+	// Profiling Configuration (net/http/pprof on the metrics listener)
+	EnablePprof bool   `mapstructure:"enable_pprof"`
+	PprofScope  string `mapstructure:"pprof_scope"` // scope a token needs to profile
+
+	// APIKeysEnabled accepts API keys in X-API-Key wherever bearer tokens
+	// are verified, and serves the key API to APIKeyAdminScope
+	APIKeysEnabled   bool   `mapstructure:"api_keys_enabled"`
+	APIKeyAdminScope string `mapstructure:"api_key_admin_scope"`
+
 	// Authentication Configuration (when enabled)
 	TokenSmithURL                       string `mapstructure:"tokensmith_url"`
 	TokenSmithBootstrapToken            string `mapstructure:"tokensmith_bootstrap_token"`
@@ -56,10 +138,230 @@ type Config struct {
 	TokenSmithRefreshSkewSec            int    `mapstructure:"tokensmith_refresh_skew_sec"`
 	JWKSEndpoint                        string `mapstructure:"jwks_endpoint"`
 
+	// Multi-Tenancy Configuration (tokens are verified against jwks_endpoint)
+	TenancyEnabled   bool   `mapstructure:"tenancy_enabled"`
+	TenantAdminScope string `mapstructure:"tenant_admin_scope"`
+
+	// Audit Log Configuration
+	AuditEnabled       bool   `mapstructure:"audit_enabled"`
+	AuditRetentionDays int    `mapstructure:"audit_retention_days"` // 0 keeps records forever
+	AuditSyslogAddress string `mapstructure:"audit_syslog_address"` // local, udp://host:port, or tcp://host:port
+	AuditWebhookURL    string `mapstructure:"audit_webhook_url"`
+
+	// Backup Configuration (enabled by a directory or S3 URL)
+	BackupDir           string `mapstructure:"backup_dir"`
+	BackupS3URL         string `mapstructure:"backup_s3_url"`         // s3://bucket/prefix, signed with the s3_* credentials
+	BackupInterval      int    `mapstructure:"backup_interval"`       // in minutes
+	BackupRetention     int    `mapstructure:"backup_retention"`      // backups kept; 0 keeps all
+	BackupRetentionDays int    `mapstructure:"backup_retention_days"` // 0 keeps backups of any age
+
+	// Soft Delete Configuration
+	SoftDeleteRetentionDays int `mapstructure:"soft_delete_retention_days"` // 0 deletes boot configurations immediately
+
+	// GitOps Configuration (enabled by a repository URL)
+	GitOpsURL               string `mapstructure:"gitops_url"`
+	GitOpsBranch            string `mapstructure:"gitops_branch"`
+	GitOpsPath              string `mapstructure:"gitops_path"`     // directory of resource files within the repository
+	GitOpsMode              string `mapstructure:"gitops_mode"`     // apply or report
+	GitOpsPrune             bool   `mapstructure:"gitops_prune"`    // delete managed resources removed from the repository
+	GitOpsInterval          int    `mapstructure:"gitops_interval"` // in seconds
+	GitOpsDir               string `mapstructure:"gitops_dir"`      // checkout directory; temporary when empty
+	GitOpsSSHKeyFile        string `mapstructure:"gitops_ssh_key_file"`
+	GitOpsSSHKnownHostsFile string `mapstructure:"gitops_ssh_known_hosts_file"`
+	GitOpsUsername          string `mapstructure:"gitops_username"`
+	GitOpsPassword          string `mapstructure:"gitops_password"` // password or access token for https
+
+	// Kubernetes Operator Configuration (reconciles Node and BootConfiguration custom resources)
+	KubernetesEnabled        bool   `mapstructure:"kubernetes_enabled"`
+	KubernetesAPIURL         string `mapstructure:"kubernetes_api_url"`         // in-cluster API server when empty
+	KubernetesTokenFile      string `mapstructure:"kubernetes_token_file"`      // service account token when empty
+	KubernetesCAFile         string `mapstructure:"kubernetes_ca_file"`         // service account CA when empty
+	KubernetesNamespace      string `mapstructure:"kubernetes_namespace"`       // every namespace when empty
+	KubernetesResyncInterval int    `mapstructure:"kubernetes_resync_interval"` // in seconds
+
+	// Admission Webhook Configuration (reviews node and boot configuration writes)
+	AdmissionWebhookURL       string `mapstructure:"admission_webhook_url"`
+	AdmissionWebhookTimeoutMS int    `mapstructure:"admission_webhook_timeout_ms"`
+	AdmissionWebhookFailOpen  bool   `mapstructure:"admission_webhook_fail_open"`
+
+	// OPA Policy Configuration (policies are evaluated by an OPA server)
+	OPAURL            string `mapstructure:"opa_url"`
+	OPAAdmissionPath  string `mapstructure:"opa_admission_path"`
+	OPABootScriptPath string `mapstructure:"opa_bootscript_path"`
+	OPATimeoutMS      int    `mapstructure:"opa_timeout_ms"`
+	OPAFailOpen       bool   `mapstructure:"opa_fail_open"`
+
 	// Hardware State Manager Configuration (when enabled)
 	HSMURL          string `mapstructure:"hsm_url"`
 	HSMSyncEnabled  bool   `mapstructure:"hsm_sync_enabled"`
 	HSMSyncInterval int    `mapstructure:"hsm_sync_interval"` // in minutes
+	HSMAuthToken    string `mapstructure:"hsm_auth_token"`    // static bearer token, e.g. a vault: reference
+	// HSMSyncConflictPolicy decides which local node edits a sync
+	// overwrites, such as "hsm" or "local,groups=hsm"
+	HSMSyncConflictPolicy string `mapstructure:"hsm_sync_conflict_policy"`
+	// HSMPushEnabled creates HSM components and ethernet interfaces for
+	// nodes created in the boot service
+	HSMPushEnabled bool `mapstructure:"hsm_push_enabled"`
+	// GroupSyncEnabled copies inventory group memberships to node groups
+	// every GroupSyncInterval minutes
+	GroupSyncEnabled  bool `mapstructure:"group_sync_enabled"`
+	GroupSyncInterval int  `mapstructure:"group_sync_interval"`
+
+	// BMC Discovery Configuration (scans networks for Redfish services)
+	BMCDiscoveryEnabled     bool   `mapstructure:"bmc_discovery_enabled"`
+	BMCDiscoveryRanges      string `mapstructure:"bmc_discovery_ranges"`   // comma-separated CIDRs or addresses
+	BMCDiscoveryInterval    int    `mapstructure:"bmc_discovery_interval"` // in minutes
+	BMCDiscoveryPort        int    `mapstructure:"bmc_discovery_port"`
+	BMCDiscoveryUsername    string `mapstructure:"bmc_discovery_username"`
+	BMCDiscoveryPassword    string `mapstructure:"bmc_discovery_password"`
+	BMCDiscoveryInsecure    bool   `mapstructure:"bmc_discovery_insecure"` // skip TLS certificate verification
+	BMCDiscoveryConcurrency int    `mapstructure:"bmc_discovery_concurrency"`
+	BMCDiscoveryTimeoutMS   int    `mapstructure:"bmc_discovery_timeout_ms"` // per address
+	// BMCDiscoveryMACDistance is how far apart a BMC MAC and a node MAC may
+	// be to correlate them; 0 disables MAC adjacency
+	BMCDiscoveryMACDistance int `mapstructure:"bmc_discovery_mac_distance"`
+
+	// BMC Telemetry Configuration (polls BMCs with the bmc_discovery port,
+	// credentials, TLS, concurrency, and timeout settings)
+	BMCTelemetryEnabled  bool `mapstructure:"bmc_telemetry_enabled"`
+	BMCTelemetryInterval int  `mapstructure:"bmc_telemetry_interval"` // in minutes
+	// BMCTelemetryFaultHealth lists the comma-separated Redfish health values
+	// that refuse boot scripts to a BMC's nodes; empty never refuses
+	BMCTelemetryFaultHealth string `mapstructure:"bmc_telemetry_fault_health"`
+
+	// Reboot Workflow Configuration (power cycles nodes with the
+	// bmc_discovery Redfish settings)
+	RebootWorkflowsEnabled   bool   `mapstructure:"reboot_workflows_enabled"`
+	RebootWorkflowBatchSize  int    `mapstructure:"reboot_workflow_batch_size"`
+	RebootWorkflowBatchDelay int    `mapstructure:"reboot_workflow_batch_delay"` // in seconds
+	RebootWorkflowTimeout    int    `mapstructure:"reboot_workflow_timeout"`     // in minutes
+	RebootWorkflowRetries    int    `mapstructure:"reboot_workflow_retries"`
+	RebootWorkflowResetType  string `mapstructure:"reboot_workflow_reset_type"`
+
+	// Resource API Configuration (controllers use storage in-process when unset)
+	ResourceAPIURL   string `mapstructure:"resource_api_url"`
+	ResourceAPIToken string `mapstructure:"resource_api_token"`
+
+	// Artifact Serving Configuration
+	ArtifactCacheEnabled bool   `mapstructure:"artifact_cache_enabled"`
+	ArtifactCacheDir     string `mapstructure:"artifact_cache_dir"` // defaults to <data_dir>/artifact-cache
+	ArtifactBaseURL      string `mapstructure:"artifact_base_url"`  // URL nodes use to reach this service
+
+	// S3 Artifact Source Configuration (enabled when credentials are provided)
+	S3Endpoint        string `mapstructure:"s3_endpoint"` // empty means AWS S3
+	S3Region          string `mapstructure:"s3_region"`
+	S3AccessKeyID     string `mapstructure:"s3_access_key_id"`
+	S3SecretAccessKey string `mapstructure:"s3_secret_access_key"`
+	S3SessionToken    string `mapstructure:"s3_session_token"`
+	S3PathStyle       bool   `mapstructure:"s3_path_style"`
+	S3PresignExpiry   int    `mapstructure:"s3_presign_expiry"` // in seconds
+
+	// Image Service Configuration (enables imageRef in boot configurations)
+	ImageServiceURL      string `mapstructure:"image_service_url"`
+	ImageServiceToken    string `mapstructure:"image_service_token"`
+	ImageServiceCacheTTL int    `mapstructure:"image_service_cache_ttl"` // in seconds
+
+	// Cloud-init Service Configuration (registers synced nodes, adds ds= to scripts)
+	CloudInitURL   string `mapstructure:"cloud_init_url"`
+	CloudInitToken string `mapstructure:"cloud_init_token"`
+
+	// Boot Script Cache Configuration
+	ScriptCacheTTL          int   `mapstructure:"script_cache_ttl"` // in seconds
+	ScriptCacheMaxEntries   int   `mapstructure:"script_cache_max_entries"`
+	ScriptCacheMaxBytes     int64 `mapstructure:"script_cache_max_bytes"`
+	ScriptCachePrewarm      bool  `mapstructure:"script_cache_prewarm"`
+	ScriptCachePrewarmDelay int   `mapstructure:"script_cache_prewarm_delay"` // in seconds
+
+	// Boot Script Deadline Budget (0 disables a limit)
+	BootScriptTimeoutMS             int `mapstructure:"bootscript_timeout_ms"`
+	BootScriptNodeLookupTimeoutMS   int `mapstructure:"bootscript_node_lookup_timeout_ms"`
+	BootScriptConfigLookupTimeoutMS int `mapstructure:"bootscript_config_lookup_timeout_ms"`
+	BootScriptArtifactTimeoutMS     int `mapstructure:"bootscript_artifact_timeout_ms"`
+	BootScriptFallbackRetryDelay    int `mapstructure:"bootscript_fallback_retry_delay"` // in seconds
+
+	// Boot Configuration Match Scoring
+	MatchWeightMAC     int  `mapstructure:"match_weight_mac"`
+	MatchWeightNID     int  `mapstructure:"match_weight_nid"`
+	MatchWeightHost    int  `mapstructure:"match_weight_host"`
+	MatchWeightGroup   int  `mapstructure:"match_weight_group"`
+	MatchWeightDefault int  `mapstructure:"match_weight_default"`
+	MatchStrict        bool `mapstructure:"match_strict"` // refuse ambiguous ties
+
+	// Boot configuration writes that tie with stored ones: off, warn, or reject
+	BootConfigurationConflicts string `mapstructure:"bootconfiguration_conflicts"`
+
+	// Boot Script Rate Limiting (0 disables a limit)
+	BootScriptRateLimit      float64 `mapstructure:"bootscript_rate_limit"` // requests per second
+	BootScriptRateBurst      int     `mapstructure:"bootscript_rate_burst"`
+	BootScriptPerIPRateLimit float64 `mapstructure:"bootscript_per_ip_rate_limit"` // requests per second
+	BootScriptPerIPRateBurst int     `mapstructure:"bootscript_per_ip_rate_burst"`
+
+	// Boot Script Signing Configuration (enabled when both files are set)
+	ScriptSigningCert string `mapstructure:"script_signing_cert"` // PEM certificate, then any intermediates
+	ScriptSigningKey  string `mapstructure:"script_signing_key"`  // PEM RSA private key
+
+	// Boot Script Request Variables (query parameters exposed as {{.Request.name}})
+	BootScriptRequestVars string `mapstructure:"bootscript_request_vars"` // comma-separated query parameter names
+
+	// Boot Loop Detection Configuration (disabled when the threshold is 0)
+	BootLoopThreshold        int    `mapstructure:"boot_loop_threshold"` // requests within the window
+	BootLoopWindow           int    `mapstructure:"boot_loop_window"`    // minutes
+	BootLoopWebhookURL       string `mapstructure:"boot_loop_webhook_url"`
+	BootLoopDiagnosticConfig string `mapstructure:"boot_loop_diagnostic_config"` // boot configuration name
+
+	// Utility Boot Configurations (built-in memtest, rescue, and wipe-disk)
+	UtilityBootConfigs string `mapstructure:"utility_boot_configs"`  // comma-separated library entries
+	UtilityBootBaseURL string `mapstructure:"utility_boot_base_url"` // where their kernels and initrds are served
+
+	// Node Discovery Configuration (registers unknown MACs on their first boot)
+	NodeDiscovery         bool   `mapstructure:"node_discovery"`
+	NodeDiscoveryConfig   string `mapstructure:"node_discovery_config"`   // boot configuration discovered nodes boot
+	NodeDiscoveryNetworks string `mapstructure:"node_discovery_networks"` // comma-separated CIDRs whose requests register nodes
+	NodeDiscoveryLimit    int    `mapstructure:"node_discovery_limit"`    // discovered nodes awaiting adoption at once
+
+	// UEFI HTTP Boot Configuration (GRUB configs at /httpboot/{mac}/grub.cfg)
+	HTTPBootLoader string `mapstructure:"http_boot_loader"` // EFI binary path, or URL to redirect to, served at /httpboot/{mac}/boot.efi
+
+	// Embedded TFTP Server Configuration (iPXE binaries for firmware without HTTP boot)
+	TFTPAddress   string `mapstructure:"tftp_address"`    // UDP host:port, such as :69; empty disables it
+	TFTPBootFiles string `mapstructure:"tftp_boot_files"` // comma-separated <arch>=<path>, by DHCP option 93 client architecture
+
+	// ProxyDHCP Configuration (answers PXE clients of known nodes next to a DHCP server)
+	ProxyDHCPEnabled  bool   `mapstructure:"proxy_dhcp_enabled"`
+	ProxyDHCPServerIP string `mapstructure:"proxy_dhcp_server_ip"` // IPv4 address nodes reach this service at
+
+	// Secret Store Configuration (resolves {{secret "name"}} in kernel parameters)
+	SecretsFile    string `mapstructure:"secrets_file"`
+	SecretsKeyFile string `mapstructure:"secrets_key_file"` // base64 of a 32-byte key
+
+	// Boot Activity Feed Configuration (served at /ws/boot-events)
+	BootEventsOrigins string `mapstructure:"boot_events_origins"` // comma-separated browser origin host patterns
+
+	// Readiness Probe Configuration (served at /readyz)
+	ReadinessChecks    string `mapstructure:"readiness_checks"` // comma-separated: storage, templates, hsm
+	ReadinessTimeoutMS int    `mapstructure:"readiness_timeout_ms"`
+
+	// Maintenance Mode Configuration (switched at /admin/maintenance)
+	MaintenanceHoldScriptFile string `mapstructure:"maintenance_hold_script_file"` // iPXE script for held nodes
+
+	// Vault Configuration (string settings may hold vault:<path>#<field> references)
+	VaultAddr            string `mapstructure:"vault_addr"`
+	VaultTokenFile       string `mapstructure:"vault_token_file"` // e.g. a Vault Agent sink
+	VaultRoleID          string `mapstructure:"vault_role_id"`    // AppRole login
+	VaultSecretIDFile    string `mapstructure:"vault_secret_id_file"`
+	VaultCACert          string `mapstructure:"vault_ca_cert"`
+	VaultRefreshInterval int    `mapstructure:"vault_refresh_interval"` // in seconds
+	VaultSecretsPath     string `mapstructure:"vault_secrets_path"`     // resolves {{secret "name"}} from Vault
+
+	// Shared State Configuration (for multi-replica deployments)
+	CacheBackend   string `mapstructure:"cache_backend"` // memory or redis
+	RedisURL       string `mapstructure:"redis_url"`
+	RedisKeyPrefix string `mapstructure:"redis_key_prefix"`
+
+	// Leader Election Configuration (background sync runs on the leader only)
+	LeaderElectionEnabled bool   `mapstructure:"leader_election_enabled"`
+	LeaderLeaseTTL        int    `mapstructure:"leader_lease_ttl"` // in seconds
+	InstanceID            string `mapstructure:"instance_id"`      // defaults to <hostname>-<pid>
 }
 
 // DefaultConfig returns a configuration with sensible defaults
@@ -70,12 +372,33 @@ func DefaultConfig() Config {
 		ReadTimeout:                         30,
 		WriteTimeout:                        30,
 		IdleTimeout:                         120,
+		ListenSocket:                        "",
+		ReusePort:                           false,
+		DrainTimeout:                        10,
+		TrustedProxies:                      "",
 		DataDir:                             "./data",
 		StorageType:                         "file",
+		EtcdPrefix:                          backend.DefaultEtcdPrefix,
 		EnableAuth:                          false,
 		EnableMetrics:                       false,
 		EnableLegacyAPI:                     false,
+		SpecSchemaValidation:                true,
+		ValidationProfile:                   validation.ProfileStrict,
+		ValidationXNamePattern:              "",
+		ValidationMACPattern:                "",
+		ValidationURLPattern:                "",
+		LegacyRecordFile:                    "",
+		BSSUpstreamURL:                      "",
+		BSSUpstreamToken:                    "",
+		LegacyAuthEnabled:                   false,
+		LegacyScopes:                        defaultLegacyScopes,
+		LegacyBootScriptAllowCIDRs:          "",
+		LegacyAuthNonEnforcing:              false,
 		MetricsPort:                         9090,
+		EnablePprof:                         false,
+		PprofScope:                          "admin",
+		APIKeysEnabled:                      false,
+		APIKeyAdminScope:                    "admin",
 		TokenSmithURL:                       "",
 		TokenSmithBootstrapToken:            "",
 		TokenSmithTargetService:             "hsm",
@@ -83,9 +406,132 @@ func DefaultConfig() Config {
 		TokenSmithScopesLegacy:              "",
 		TokenSmithRefreshSkewSec:            120,
 		JWKSEndpoint:                        "",
+		TenancyEnabled:                      false,
+		TenantAdminScope:                    "admin",
+		AuditEnabled:                        false,
+		AuditRetentionDays:                  90,
+		AuditSyslogAddress:                  "",
+		AuditWebhookURL:                     "",
+		BackupInterval:                      60,
+		BackupRetention:                     48,
+		BackupRetentionDays:                 0,
+		SoftDeleteRetentionDays:             7,
+		GitOpsBranch:                        "main",
+		GitOpsMode:                          gitops.ModeApply,
+		GitOpsPrune:                         false,
+		GitOpsInterval:                      60,
+		KubernetesEnabled:                   false,
+		KubernetesResyncInterval:            300,
+		AdmissionWebhookURL:                 "",
+		AdmissionWebhookTimeoutMS:           2000,
+		AdmissionWebhookFailOpen:            false,
+		OPAURL:                              "",
+		OPAAdmissionPath:                    "bootservice/admission/deny",
+		OPABootScriptPath:                   "",
+		OPATimeoutMS:                        500,
+		OPAFailOpen:                         false,
 		HSMURL:                              "",
 		HSMSyncEnabled:                      true,
 		HSMSyncInterval:                     5, // 5 minutes
+		HSMSyncConflictPolicy:               hsm.PolicyHSM,
+		HSMPushEnabled:                      false,
+		GroupSyncEnabled:                    false,
+		GroupSyncInterval:                   5, // 5 minutes
+		BMCDiscoveryEnabled:                 false,
+		BMCDiscoveryRanges:                  "",
+		BMCDiscoveryInterval:                60, // 1 hour
+		BMCDiscoveryPort:                    443,
+		BMCDiscoveryUsername:                "",
+		BMCDiscoveryPassword:                "",
+		BMCDiscoveryInsecure:                false,
+		BMCDiscoveryConcurrency:             32,
+		BMCDiscoveryTimeoutMS:               5000,
+		BMCDiscoveryMACDistance:             8,
+		BMCTelemetryEnabled:                 false,
+		BMCTelemetryInterval:                5,
+		BMCTelemetryFaultHealth:             "Critical",
+		RebootWorkflowsEnabled:              false,
+		RebootWorkflowBatchSize:             10,
+		RebootWorkflowBatchDelay:            30,
+		RebootWorkflowTimeout:               15,
+		RebootWorkflowRetries:               1,
+		RebootWorkflowResetType:             "ForceRestart",
+		HSMAuthToken:                        "",
+		ResourceAPIURL:                      "",
+		ResourceAPIToken:                    "",
+		ArtifactCacheEnabled:                false,
+		ArtifactCacheDir:                    "",
+		ArtifactBaseURL:                     "",
+		S3Endpoint:                          "",
+		S3Region:                            "us-east-1",
+		S3AccessKeyID:                       "",
+		S3SecretAccessKey:                   "",
+		S3SessionToken:                      "",
+		S3PathStyle:                         true,
+		S3PresignExpiry:                     3600, // 1 hour
+		CloudInitURL:                        "",
+		CloudInitToken:                      "",
+		ImageServiceURL:                     "",
+		ImageServiceToken:                   "",
+		ImageServiceCacheTTL:                60,  // 1 minute
+		ScriptCacheTTL:                      300, // 5 minutes
+		ScriptCacheMaxEntries:               10000,
+		ScriptCacheMaxBytes:                 64 << 20, // 64 MiB
+		ScriptCachePrewarm:                  false,
+		ScriptCachePrewarmDelay:             5,
+		BootScriptTimeoutMS:                 5000,
+		BootScriptNodeLookupTimeoutMS:       2000,
+		BootScriptConfigLookupTimeoutMS:     2000,
+		BootScriptArtifactTimeoutMS:         2000,
+		BootScriptFallbackRetryDelay:        10,
+		MatchWeightMAC:                      100,
+		MatchWeightNID:                      75,
+		MatchWeightHost:                     50,
+		MatchWeightGroup:                    25,
+		MatchWeightDefault:                  1,
+		MatchStrict:                         false,
+		BootConfigurationConflicts:          "off",
+		BootScriptRateLimit:                 0,
+		BootScriptRateBurst:                 200,
+		BootScriptPerIPRateLimit:            0,
+		BootScriptPerIPRateBurst:            5,
+		ScriptSigningCert:                   "",
+		ScriptSigningKey:                    "",
+		HTTPBootLoader:                      "",
+		TFTPAddress:                         "",
+		TFTPBootFiles:                       "",
+		ProxyDHCPEnabled:                    false,
+		ProxyDHCPServerIP:                   "",
+		BootScriptRequestVars:               "",
+		BootLoopThreshold:                   0,
+		BootLoopWindow:                      10,
+		BootLoopWebhookURL:                  "",
+		BootLoopDiagnosticConfig:            "",
+		UtilityBootConfigs:                  "",
+		UtilityBootBaseURL:                  "",
+		NodeDiscovery:                       false,
+		NodeDiscoveryConfig:                 "",
+		NodeDiscoveryNetworks:               "",
+		NodeDiscoveryLimit:                  1000,
+		SecretsFile:                         "",
+		SecretsKeyFile:                      "",
+		BootEventsOrigins:                   "",
+		ReadinessChecks:                     "storage,templates,hsm,migration",
+		ReadinessTimeoutMS:                  2000,
+		MaintenanceHoldScriptFile:           "",
+		VaultAddr:                           "",
+		VaultTokenFile:                      "",
+		VaultRoleID:                         "",
+		VaultSecretIDFile:                   "",
+		VaultCACert:                         "",
+		VaultRefreshInterval:                300, // 5 minutes
+		VaultSecretsPath:                    "",
+		CacheBackend:                        "memory",
+		RedisURL:                            "",
+		RedisKeyPrefix:                      "boot-service",
+		LeaderElectionEnabled:               false,
+		LeaderLeaseTTL:                      15,
+		InstanceID:                          "",
 	}
 }
 
@@ -124,16 +570,40 @@ func init() {
 	serveCmd.Flags().Int("read-timeout", 30, "Read timeout in seconds")
 	serveCmd.Flags().Int("write-timeout", 30, "Write timeout in seconds")
 	serveCmd.Flags().Int("idle-timeout", 120, "Idle timeout in seconds")
+	serveCmd.Flags().String("listen-socket", "", "Unix domain socket path to serve on in addition to host:port")
+	serveCmd.Flags().Bool("reuse-port", false, "Bind host:port with SO_REUSEPORT so a new process can listen before the old one stops")
+	serveCmd.Flags().Int("drain-timeout", 10, "Seconds in-flight requests have to complete on shutdown")
+	serveCmd.Flags().String("trusted-proxies", "", "Comma-separated CIDRs of proxies trusted to forward the client address in X-Forwarded-For or X-Real-IP")
 
 	// Storage configuration flags
 	serveCmd.Flags().String("data-dir", "./data", "Directory for file storage")
-	serveCmd.Flags().String("storage-type", "file", "Storage backend: file or database")
+	serveCmd.Flags().String("storage-type", "file", "Storage backend: file, etcd")
+	serveCmd.Flags().String("etcd-url", "", "etcd JSON gateway URL, such as http://etcd:2379 (storage-type etcd)")
+	serveCmd.Flags().String("etcd-prefix", backend.DefaultEtcdPrefix, "Key prefix resources are stored under in etcd")
+	serveCmd.Flags().String("etcd-username", "", "etcd user, when the cluster has auth enabled")
+	serveCmd.Flags().String("etcd-password", "", "Password of etcd-username")
 
 	// Feature flags
 	serveCmd.Flags().Bool("enable-auth", false, "Enable authentication with TokenSmith")
 	serveCmd.Flags().Bool("enable-metrics", false, "Enable Prometheus metrics")
 	serveCmd.Flags().Bool("enable-legacy-api", true, "Enable legacy BSS API compatibility")
+	serveCmd.Flags().Bool("spec-schema-validation", true, "Reject resource specs that do not match their JSON Schema at /schemas")
+	serveCmd.Flags().String("validation-profile", validation.ProfileStrict, "How strictly xnames, MACs, and kernel URLs are validated (strict or lenient)")
+	serveCmd.Flags().String("validation-xname-pattern", "", "Regular expression node xnames must match instead of the profile's rule")
+	serveCmd.Flags().String("validation-mac-pattern", "", "Regular expression MAC addresses must match instead of the profile's rule")
+	serveCmd.Flags().String("validation-url-pattern", "", "Regular expression kernel and initrd URLs must match instead of the profile's rule")
+	serveCmd.Flags().String("legacy-record-file", "", "Append sanitized legacy API requests and responses to this file for replay against BSS")
+	serveCmd.Flags().String("bss-upstream-url", "", "BSS instance legacy API writes are mirrored to and unknown reads fall back to during migration")
+	serveCmd.Flags().String("bss-upstream-token", "", "Bearer token for the BSS upstream")
+	serveCmd.Flags().Bool("legacy-auth-enabled", false, "Require tokens verified against jwks-endpoint, with the scope of each access, on the legacy API")
+	serveCmd.Flags().String("legacy-scopes", defaultLegacyScopes, "Token scope of each legacy API access: bootscript, read, and write (each granting the ones before it)")
+	serveCmd.Flags().String("legacy-bootscript-allow-cidrs", "", "Comma-separated CIDRs served legacy boot scripts without a token")
+	serveCmd.Flags().Bool("legacy-auth-non-enforcing", false, "Serve legacy API requests that fail authorization, counting them in /admin/auth/report")
 	serveCmd.Flags().Int("metrics-port", 9090, "Port for metrics endpoint")
+	serveCmd.Flags().Bool("enable-pprof", false, "Serve net/http/pprof at /debug/pprof/ on the metrics port to tokens verified against jwks-endpoint")
+	serveCmd.Flags().String("pprof-scope", "admin", "Token scope required for /debug/pprof/ (empty accepts any verified token)")
+	serveCmd.Flags().Bool("api-keys-enabled", false, "Accept API keys in X-API-Key wherever tokens are verified, managed at /admin/api-keys")
+	serveCmd.Flags().String("api-key-admin-scope", "admin", "Token scope required to manage API keys (empty accepts any verified token)")
 
 	// Authentication configuration flags
 	serveCmd.Flags().String("tokensmith-url", "", "TokenSmith service URL for authentication")
@@ -144,10 +614,206 @@ func init() {
 	serveCmd.Flags().Int("tokensmith-refresh-skew-sec", 120, "Refresh service tokens when this many seconds remain before expiry")
 	serveCmd.Flags().String("jwks-endpoint", "", "JWKS endpoint for JWT validation")
 
+	// Multi-tenancy flags
+	serveCmd.Flags().Bool("tenancy-enabled", false, "Scope nodes and boot configurations to the tenant in the cluster_id claim of each request's token")
+	serveCmd.Flags().String("tenant-admin-scope", "admin", "Token scope that grants access to the resources of every tenant")
+
+	// Audit log flags
+	serveCmd.Flags().Bool("audit-enabled", false, "Record every create, update, and delete of a resource and serve the records at /audit")
+	serveCmd.Flags().Int("audit-retention-days", 90, "Days to keep audit records (0 keeps them forever)")
+	serveCmd.Flags().String("audit-syslog-address", "", "Also send audit records to syslog: local, udp://host:port, or tcp://host:port")
+	serveCmd.Flags().String("audit-webhook-url", "", "Also POST audit records as JSON to this URL")
+
+	// Backup flags
+	serveCmd.Flags().String("backup-dir", "", "Directory to write scheduled backups of the stored resources to")
+	serveCmd.Flags().String("backup-s3-url", "", "s3://bucket/prefix to write scheduled backups to, with the s3-* credentials")
+	serveCmd.Flags().Int("backup-interval", 60, "Minutes between backups")
+	serveCmd.Flags().Int("backup-retention", 48, "Number of backups to keep (0 keeps all)")
+	serveCmd.Flags().Int("backup-retention-days", 0, "Days to keep backups (0 keeps backups of any age)")
+
+	// Soft delete flags
+	serveCmd.Flags().Int("soft-delete-retention-days", 7, "Days deleted boot configurations can be restored (0 deletes them immediately)")
+
+	// GitOps flags
+	serveCmd.Flags().String("gitops-url", "", "Git repository to sync nodes and boot configurations from (https, ssh, or a local path)")
+	serveCmd.Flags().String("gitops-branch", "main", "Branch of the GitOps repository to sync")
+	serveCmd.Flags().String("gitops-path", "", "Directory of the GitOps repository holding resource files (default the whole repository)")
+	serveCmd.Flags().String("gitops-mode", gitops.ModeApply, "apply writes drift from the repository; report only reports it")
+	serveCmd.Flags().Bool("gitops-prune", false, "Delete synced resources that are removed from the repository")
+	serveCmd.Flags().Int("gitops-interval", 60, "Seconds between GitOps syncs")
+	serveCmd.Flags().String("gitops-dir", "", "Directory for the repository checkout (default a temporary directory)")
+	serveCmd.Flags().String("gitops-ssh-key-file", "", "Private key for ssh repository URLs (default the ssh agent)")
+	serveCmd.Flags().String("gitops-ssh-known-hosts-file", "", "Known hosts file that verifies ssh repository servers (default ~/.ssh/known_hosts)")
+	serveCmd.Flags().String("gitops-username", "", "Username for https repository URLs")
+	serveCmd.Flags().String("gitops-password", "", "Password or access token for https repository URLs")
+
+	// Kubernetes operator flags
+	serveCmd.Flags().Bool("kubernetes-enabled", false, "Reconcile Node and BootConfiguration custom resources from Kubernetes into storage")
+	serveCmd.Flags().String("kubernetes-api-url", "", "Kubernetes API server (default the cluster the service runs in)")
+	serveCmd.Flags().String("kubernetes-token-file", "", "Bearer token for the Kubernetes API (default the pod's service account token)")
+	serveCmd.Flags().String("kubernetes-ca-file", "", "CA certificate that verifies the Kubernetes API (default the pod's service account CA)")
+	serveCmd.Flags().String("kubernetes-namespace", "", "Namespace to read custom resources from (default every namespace)")
+	serveCmd.Flags().Int("kubernetes-resync-interval", 300, "Seconds between full reconciles of the custom resources")
+
+	// Admission webhook flags
+	serveCmd.Flags().String("admission-webhook-url", "", "Webhook that reviews and may deny or patch every node and boot configuration write")
+	serveCmd.Flags().Int("admission-webhook-timeout-ms", 2000, "Time allowed for the admission webhook to answer")
+	serveCmd.Flags().Bool("admission-webhook-fail-open", false, "Allow writes when the admission webhook cannot be reached (default refuses them)")
+
+	// OPA policy flags
+	serveCmd.Flags().String("opa-url", "", "OPA server that evaluates policies, e.g. http://localhost:8181")
+	serveCmd.Flags().String("opa-admission-path", "bootservice/admission/deny", "OPA rule that denies node and boot configuration writes (empty disables)")
+	serveCmd.Flags().String("opa-bootscript-path", "", "OPA rule that denies boot script requests (empty disables)")
+	serveCmd.Flags().Int("opa-timeout-ms", 500, "Time allowed for OPA to evaluate a policy")
+	serveCmd.Flags().Bool("opa-fail-open", false, "Allow writes and boots when OPA cannot be reached (default refuses them)")
+
 	// Hardware State Manager configuration flags
 	serveCmd.Flags().String("hsm-url", "", "Hardware State Manager service URL (enables HSM when provided)")
 	serveCmd.Flags().Bool("hsm-sync-enabled", true, "Enable background sync with HSM")
 	serveCmd.Flags().Int("hsm-sync-interval", 5, "HSM sync interval in minutes")
+	serveCmd.Flags().Bool("hsm-push-enabled", false, "Create HSM components and ethernet interfaces for nodes created manually or by discovery")
+	serveCmd.Flags().Bool("group-sync-enabled", false, "Sync node group memberships from the inventory service's groups (requires hsm-url)")
+	serveCmd.Flags().Int("group-sync-interval", 5, "Inventory group sync interval in minutes")
+	serveCmd.Flags().Bool("bmc-discovery-enabled", false, "Discover BMCs by scanning networks for Redfish services")
+	serveCmd.Flags().String("bmc-discovery-ranges", "", "Comma-separated CIDRs or addresses scanned for BMCs on the interval")
+	serveCmd.Flags().Int("bmc-discovery-interval", 60, "BMC discovery scan interval in minutes")
+	serveCmd.Flags().Int("bmc-discovery-port", 443, "HTTPS port of the Redfish services scanned for")
+	serveCmd.Flags().String("bmc-discovery-username", "", "Redfish username for reading BMC details")
+	serveCmd.Flags().String("bmc-discovery-password", "", "Redfish password for reading BMC details")
+	serveCmd.Flags().Bool("bmc-discovery-insecure", false, "Skip TLS certificate verification of Redfish services")
+	serveCmd.Flags().Int("bmc-discovery-concurrency", 32, "Addresses probed at once by a BMC discovery scan")
+	serveCmd.Flags().Int("bmc-discovery-timeout-ms", 5000, "Time allowed for probing one address in milliseconds")
+	serveCmd.Flags().Int("bmc-discovery-mac-distance", 8, "How far apart a BMC MAC and a node MAC may be to correlate them (0 disables)")
+	serveCmd.Flags().Bool("bmc-telemetry-enabled", false, "Poll BMCs for power state, firmware, and health, using the bmc-discovery port, credentials, and timeouts")
+	serveCmd.Flags().Int("bmc-telemetry-interval", 5, "BMC telemetry poll interval in minutes")
+	serveCmd.Flags().String("bmc-telemetry-fault-health", "Critical", "Comma-separated Redfish health values (OK, Warning, Critical) that refuse boot scripts to a BMC's nodes; empty never refuses")
+	serveCmd.Flags().Bool("reboot-workflows-enabled", false, "Serve /workflows/reboot, which reboots nodes into a boot configuration through their BMCs, using the bmc-discovery port, credentials, and timeouts")
+	serveCmd.Flags().Int("reboot-workflow-batch-size", 10, "Nodes a reboot workflow power cycles at once")
+	serveCmd.Flags().Int("reboot-workflow-batch-delay", 30, "Seconds a reboot workflow pauses between batches")
+	serveCmd.Flags().Int("reboot-workflow-timeout", 15, "Minutes a node has to phone home after a power cycle")
+	serveCmd.Flags().Int("reboot-workflow-retries", 1, "Further power cycles of a node that does not phone home")
+	serveCmd.Flags().String("reboot-workflow-reset-type", "ForceRestart", "Redfish reset type of powered-on nodes: ForceRestart, GracefulRestart, or PowerCycle")
+	serveCmd.Flags().String("hsm-sync-conflict-policy", hsm.PolicyHSM, "Which local node edits HSM sync overwrites: hsm, local, or last-writer-wins, optionally with per-field overrides such as local,groups=hsm")
+	serveCmd.Flags().String("hsm-auth-token", "", "Static bearer token for HSM requests, such as a vault:<path>#<field> reference (takes precedence over TokenSmith)")
+
+	// Resource API flags
+	serveCmd.Flags().String("resource-api-url", "", "URL of a remote boot service to read and write nodes and boot configurations through (default in-process storage)")
+	serveCmd.Flags().String("resource-api-token", "", "Bearer token for the remote boot service at resource-api-url")
+
+	// Artifact serving flags
+	serveCmd.Flags().Bool("artifact-cache-enabled", false, "Cache registered artifacts locally and serve them at /artifacts/{name}")
+	serveCmd.Flags().String("artifact-cache-dir", "", "Directory for cached artifacts (default <data-dir>/artifact-cache)")
+	serveCmd.Flags().String("artifact-base-url", "", "Base URL nodes use to download cached artifacts from this service")
+
+	// S3 artifact source flags
+	serveCmd.Flags().String("s3-endpoint", "", "S3-compatible endpoint for s3:// artifacts (default AWS S3)")
+	serveCmd.Flags().String("s3-region", "us-east-1", "S3 region used for request signing")
+	serveCmd.Flags().String("s3-access-key-id", "", "S3 access key ID (enables s3:// artifacts when provided)")
+	serveCmd.Flags().String("s3-secret-access-key", "", "S3 secret access key")
+	serveCmd.Flags().String("s3-session-token", "", "Optional S3 session token for temporary credentials")
+	serveCmd.Flags().Bool("s3-path-style", true, "Use path-style bucket addressing (required by most MinIO deployments)")
+	serveCmd.Flags().Int("s3-presign-expiry", 3600, "Lifetime of presigned artifact URLs in seconds")
+
+	// Image service flags
+	serveCmd.Flags().String("image-service-url", "", "OpenCHAMI image service URL (enables imageRef in boot configurations)")
+	serveCmd.Flags().String("image-service-token", "", "Bearer token for image service requests")
+	serveCmd.Flags().Int("image-service-cache-ttl", 60, "How long resolved images are reused before asking the image service again, in seconds")
+
+	// Cloud-init service flags
+	serveCmd.Flags().String("cloud-init-url", "", "OpenCHAMI cloud-init service URL, such as http://cloud-init:27777/cloud-init (registers HSM-synced nodes and adds its NoCloud datasource to boot scripts)")
+	serveCmd.Flags().String("cloud-init-token", "", "Bearer token for cloud-init service requests")
+
+	// Boot script cache flags
+	serveCmd.Flags().Int("script-cache-ttl", 300, "Lifetime of cached boot scripts in seconds")
+	serveCmd.Flags().Int("script-cache-max-entries", 10000, "Maximum number of cached boot scripts")
+	serveCmd.Flags().Int64("script-cache-max-bytes", 64<<20, "Approximate memory limit for cached boot scripts in bytes")
+	serveCmd.Flags().Bool("script-cache-prewarm", false, "Render and cache boot scripts for all nodes at startup and after resource changes")
+	serveCmd.Flags().Int("script-cache-prewarm-delay", 5, "Seconds to wait after the last resource change before pre-warming the boot script cache")
+
+	// Boot script deadline budget flags
+	serveCmd.Flags().Int("bootscript-timeout-ms", 5000, "Total time budget for generating a boot script before the fallback script is served (0 disables)")
+	serveCmd.Flags().Int("bootscript-node-lookup-timeout-ms", 2000, "Time budget for resolving a node from storage or the node provider (0 disables)")
+	serveCmd.Flags().Int("bootscript-config-lookup-timeout-ms", 2000, "Time budget for loading boot configurations (0 disables)")
+	serveCmd.Flags().Int("bootscript-artifact-timeout-ms", 2000, "Time budget for resolving kernel and initrd artifacts (0 disables)")
+	serveCmd.Flags().Int("bootscript-fallback-retry-delay", 10, "Seconds the fallback boot script waits before rebooting the node to retry")
+
+	// Boot configuration match scoring flags
+	serveCmd.Flags().Int("match-weight-mac", 100, "Score a boot configuration gains for each macs entry matching the node")
+	serveCmd.Flags().Int("match-weight-nid", 75, "Score a boot configuration gains for each nids or nidRanges entry matching the node")
+	serveCmd.Flags().Int("match-weight-host", 50, "Score a boot configuration gains for each hosts entry matching the node")
+	serveCmd.Flags().Int("match-weight-group", 25, "Score a boot configuration gains for each group it shares with the node")
+	serveCmd.Flags().Int("match-weight-default", 1, "Score of a boot configuration without targeting criteria")
+	serveCmd.Flags().Bool("match-strict", false, "Serve an error script instead of choosing by name when the best boot configurations tie on score and priority")
+	serveCmd.Flags().String("bootconfiguration-conflicts", "off", "Check boot configuration writes for ties with stored configurations: off, warn, or reject")
+
+	// Boot script rate limiting flags
+	serveCmd.Flags().Float64("bootscript-rate-limit", 0, "Boot script requests per second allowed across all clients (0 disables)")
+	serveCmd.Flags().Int("bootscript-rate-burst", 200, "Boot script requests allowed at once above bootscript-rate-limit")
+	serveCmd.Flags().Float64("bootscript-per-ip-rate-limit", 0, "Boot script requests per second allowed per client IP (0 disables)")
+	serveCmd.Flags().Int("bootscript-per-ip-rate-burst", 5, "Boot script requests one client IP may make at once above bootscript-per-ip-rate-limit")
+
+	// Boot script signing flags
+	serveCmd.Flags().String("script-signing-cert", "", "PEM code signing certificate, followed by any intermediates, for signing boot scripts")
+	serveCmd.Flags().String("script-signing-key", "", "PEM RSA private key of the boot script signing certificate")
+
+	// Boot script request variable flags
+	serveCmd.Flags().String("bootscript-request-vars", "", "Comma-separated boot script query parameters, such as serial,uuid, that kernel parameter templates read as {{.Request.name}}")
+
+	// Boot loop detection flags
+	serveCmd.Flags().Int("boot-loop-threshold", 0, "Report nodes that request their boot script more than this many times within boot-loop-window without phoning home (0 disables)")
+	serveCmd.Flags().Int("boot-loop-window", 10, "Window in minutes within which boot script requests are counted for boot loop detection")
+	serveCmd.Flags().String("boot-loop-webhook-url", "", "POST a JSON event to this URL for each detected boot loop")
+	serveCmd.Flags().String("boot-loop-diagnostic-config", "", "Boot configuration that nodes in a boot loop boot until the loop is cleared")
+
+	// Utility boot configuration flags
+	serveCmd.Flags().String("utility-boot-configs", "", "Comma-separated built-in utility boot configurations to install: memtest, rescue, wipe-disk")
+	serveCmd.Flags().String("utility-boot-base-url", "", "Base URL serving the kernels and initrds of the utility boot configurations")
+
+	// Node discovery flags
+	serveCmd.Flags().Bool("node-discovery", false, "Register nodes that request a boot script with an unknown MAC as discovered nodes")
+	serveCmd.Flags().String("node-discovery-config", "", "Boot configuration, such as an inventory image, that discovered nodes boot until adopted")
+	serveCmd.Flags().String("node-discovery-networks", "", "Comma-separated CIDRs whose boot script requests register nodes; empty allows any")
+	serveCmd.Flags().Int("node-discovery-limit", 1000, "Discovered nodes that may await adoption at once; 0 allows any number")
+
+	// UEFI HTTP boot flags
+	serveCmd.Flags().String("http-boot-loader", "", "EFI binary, such as a signed shim or GRUB, served at /httpboot/{mac}/boot.efi; an http(s) URL is redirected to instead")
+	serveCmd.Flags().String("tftp-address", "", "UDP address, such as :69, of the embedded TFTP server for iPXE binaries (empty disables it)")
+	serveCmd.Flags().String("tftp-boot-files", "", "Comma-separated <arch>=<path> boot files served over TFTP, by DHCP option 93 client architecture, e.g. 0=/srv/undionly.kpxe,7=/srv/ipxe.efi")
+	serveCmd.Flags().Bool("proxy-dhcp-enabled", false, "Answer PXE requests of known nodes as a ProxyDHCP server on UDP ports 67 and 4011 (requires tftp-address)")
+	serveCmd.Flags().String("proxy-dhcp-server-ip", "", "IPv4 address of this service sent to PXE clients as their next server")
+
+	// Secret store flags
+	serveCmd.Flags().String("secrets-file", "", "Encrypted local secret store for {{secret \"name\"}} kernel parameter references")
+	serveCmd.Flags().String("secrets-key-file", "", "File holding the base64 32-byte key of the secret store")
+
+	// Boot activity feed flags
+	serveCmd.Flags().String("boot-events-origins", "", "Comma-separated host patterns of other origins whose pages may open /ws/boot-events, e.g. dashboard.example.com")
+
+	// Readiness probe flags
+	serveCmd.Flags().String("readiness-checks", "storage,templates,hsm,migration", "Comma-separated checks run by /readyz: storage, templates, hsm, migration (hsm never fails readiness)")
+	serveCmd.Flags().Int("readiness-timeout-ms", 2000, "Time limit in milliseconds for each /readyz check")
+
+	// Maintenance mode flags
+	serveCmd.Flags().String("maintenance-hold-script-file", "", "iPXE script served to nodes held by maintenance mode (default waits 60 seconds and reboots)")
+
+	// Vault flags
+	serveCmd.Flags().String("vault-addr", "", "Vault server URL; string settings may then be vault:<path>#<field> references")
+	serveCmd.Flags().String("vault-token-file", "", "File holding the Vault token, such as a Vault Agent sink (default VAULT_TOKEN)")
+	serveCmd.Flags().String("vault-role-id", "", "AppRole role ID for logging in to Vault")
+	serveCmd.Flags().String("vault-secret-id-file", "", "File holding the AppRole secret ID for logging in to Vault")
+	serveCmd.Flags().String("vault-ca-cert", "", "PEM CA certificate for verifying the Vault server")
+	serveCmd.Flags().Int("vault-refresh-interval", 300, "Seconds between re-reads of the HSM auth token from Vault")
+	serveCmd.Flags().String("vault-secrets-path", "", "Vault path below which {{secret \"name\"}} kernel parameters are read")
+
+	// Shared state flags
+	serveCmd.Flags().String("cache-backend", "memory", "Boot script cache backend: memory or redis")
+	serveCmd.Flags().String("redis-url", "", "Redis URL for shared state, e.g. redis://redis:6379/0")
+	serveCmd.Flags().String("redis-key-prefix", "boot-service", "Prefix for keys stored in Redis")
+
+	// Leader election flags
+	serveCmd.Flags().Bool("leader-election-enabled", false, "Run background sync only on the replica holding a lease in Redis")
+	serveCmd.Flags().Int("leader-lease-ttl", 15, "Leader lease lifetime in seconds")
+	serveCmd.Flags().String("instance-id", "", "Replica ID used in leader election (default <hostname>-<pid>)")
 
 	// Bind flags to viper
 	if err := bindFlagsWithUnderscoreKeys(viper.GetViper(), serveCmd.Flags()); err != nil {
@@ -157,6 +823,18 @@ func init() {
 	// Add commands
 	rootCmd.AddCommand(serveCmd)
 	rootCmd.AddCommand(NewVersionCommand())
+	rootCmd.AddCommand(newValidateCommand())
+	rootCmd.AddCommand(newExportCommand())
+	rootCmd.AddCommand(newImportCommand())
+	rootCmd.AddCommand(newRestoreCommand())
+	rootCmd.AddCommand(newImportNodesCommand())
+	rootCmd.AddCommand(newExportDHCPCommand())
+	rootCmd.AddCommand(newMigrateCommand())
+	rootCmd.AddCommand(newRenderCommand())
+	rootCmd.AddCommand(newSeedCommand())
+	rootCmd.AddCommand(newSecretsCommand())
+	rootCmd.AddCommand(newSupportBundleCommand())
+	rootCmd.AddCommand(newMaintenanceCommand())
 }
 
 func main() {
@@ -180,6 +858,11 @@ func main() {
 	viper.BindEnv("tokensmith_scopes", "TOKENSMITH_SCOPES")                                             //nolint:errcheck
 	viper.BindEnv("tokensmith_refresh_skew_sec", "TOKENSMITH_REFRESH_SKEW_SEC")                         //nolint:errcheck
 
+	// Standard AWS credential env vars for the S3 artifact source.
+	viper.BindEnv("s3_access_key_id", "BOOT_SERVICE_S3_ACCESS_KEY_ID", "AWS_ACCESS_KEY_ID")             //nolint:errcheck
+	viper.BindEnv("s3_secret_access_key", "BOOT_SERVICE_S3_SECRET_ACCESS_KEY", "AWS_SECRET_ACCESS_KEY") //nolint:errcheck
+	viper.BindEnv("s3_session_token", "BOOT_SERVICE_S3_SESSION_TOKEN", "AWS_SESSION_TOKEN")             //nolint:errcheck
+
 	// Read config file if present
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -192,31 +875,68 @@ func main() {
 	}
 }
 
+// backgroundTasks is the work that must finish before the server exits, such
+// as reboot workflows rolling back their boot-once overrides
+var backgroundTasks sync.WaitGroup
+
 func runServe(cmd *cobra.Command, args []string) error { //nolint:revive
 	// Load configuration
-	config := DefaultConfig()
-	if err := viper.Unmarshal(&config); err != nil {
-		return fmt.Errorf("failed to unmarshal config: %v", err)
-	} // Validate configuration
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	// Setup graceful shutdown context early so it can be used for background workers
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Read credentials referenced as vault:<path>#<field> before validating
+	// the settings they fill in
+	vaultClient, err := newVaultClient(ctx, config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Vault: %w", err)
+	}
+	vaultRefs, err := resolveVaultReferences(ctx, &config, vaultClient)
+	if err != nil {
+		return fmt.Errorf("failed to resolve Vault references: %w", err)
+	}
+	if vaultClient != nil {
+		go vaultClient.RunRenewal(ctx)
+		log.Printf("Vault enabled at %s (%d settings read from Vault)", config.VaultAddr, len(vaultRefs))
+	}
+
+	// Validate configuration
 	if err := validateConfig(config); err != nil {
 		return fmt.Errorf("invalid configuration: %v", err)
 	}
+	profile, _ := validationProfile(config) // validated
+	validation.SetProfile(profile)
 
 	// Print startup configuration
 	log.Printf("Starting boot service with configuration:")
 	log.Printf("  Server: %s:%d", config.Host, config.Port)
-	log.Printf("  Storage: %s (%s)", config.StorageType, config.DataDir)
+	if config.StorageType == "etcd" {
+		log.Printf("  Storage: %s (%s, prefix %s)", config.StorageType, config.EtcdURL, config.EtcdPrefix)
+	} else {
+		log.Printf("  Storage: %s (%s)", config.StorageType, config.DataDir)
+	}
 	log.Printf("  Features: auth=%v, hsm=%v, metrics=%v, legacy-api=%v",
 		config.EnableAuth, config.HSMURL != "", config.EnableMetrics, config.EnableLegacyAPI)
+	log.Printf("  Validation: %s", profile)
 
 	// Initialize storage backend
-	if err := storage.InitFileBackend(config.DataDir); err != nil {
+	store, err := newStorageBackend(ctx, config)
+	if err != nil {
 		return fmt.Errorf("failed to initialize storage: %v", err)
 	}
+	storage.Init(store)
 
-	// Setup graceful shutdown context early so it can be used for background workers
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// API keys are kept beneath the watched backend, so their hashes are
+	// never streamed to watchers
+	var apiKeys *apikeys.Store
+	if config.APIKeysEnabled {
+		apiKeys = apikeys.NewStore(store, log.New(os.Stdout, "api-keys: ", log.LstdFlags))
+	}
 
 	// Initialize HSM client if configured
 	// When HSM URL is provided, the service will use FlexibleBootScriptController
@@ -230,12 +950,16 @@ func runServe(cmd *cobra.Command, args []string) error { //nolint:revive
 
 		hsmLogger := log.New(os.Stdout, "smd: ", log.LstdFlags)
 
-		serviceTokenManager, err = initializeHSMServiceTokenManager(ctx, config, hsmLogger)
-		if err != nil {
-			return err
-		}
-		if serviceTokenManager != nil {
-			hsmConfig.ServiceTokenManager = serviceTokenManager
+		if config.HSMAuthToken != "" {
+			hsmAuthToken(&hsmConfig, config, vaultClient, vaultRefs)
+		} else {
+			serviceTokenManager, err = initializeHSMServiceTokenManager(ctx, config, hsmLogger)
+			if err != nil {
+				return err
+			}
+			if serviceTokenManager != nil {
+				hsmConfig.ServiceTokenManager = serviceTokenManager
+			}
 		}
 
 		hsmClient, err = hsm.NewHSMClient(hsmConfig, hsmLogger)
@@ -263,7 +987,12 @@ func runServe(cmd *cobra.Command, args []string) error { //nolint:revive
 
 	// Add all middleware first, before any routes
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
+	// Forwarded client addresses are believed only from trusted proxies
+	proxies, err := httputil.ParseTrustedProxies(parseScopeHintCSV(config.TrustedProxies))
+	if err != nil {
+		return fmt.Errorf("trusted-proxies: %w", err)
+	}
+	r.Use(proxies.Middleware)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 	// Generated chi routes are registered with trailing slashes, while existing
@@ -271,6 +1000,17 @@ func runServe(cmd *cobra.Command, args []string) error { //nolint:revive
 	// use slashless resource paths. RedirectSlashes preserves that compatibility
 	// without hand-editing generated route registrations.
 	r.Use(middleware.RedirectSlashes)
+
+	// Watch requests and the boot activity feed stream for as long as the
+	// client stays connected, so they are served ahead of the request timeout.
+	var scope func(http.Handler) http.Handler
+	if config.TenancyEnabled {
+		scope = tenantScope(config, apiKeys)
+	}
+	watches := resourcewatch.NewHub(resourcewatch.DefaultWatchBuffer)
+	r.Use(watchLists(watches, scope))
+	bootEvents := activity.NewFeed(activity.DefaultBuffer)
+	r.Use(serveBootEvents(bootEvents, config, scope))
 	r.Use(middleware.Timeout(time.Duration(config.ReadTimeout) * time.Second))
 
 	var metrics *Metrics
@@ -281,7 +1021,37 @@ func runServe(cmd *cobra.Command, args []string) error { //nolint:revive
 		}
 	}
 
+	// YAML bodies are converted before versioning reads the requested version
+	r.Use(negotiateYAML)
 	r.Use(versioning.VersionNegotiationMiddleware(versioning.GlobalVersionRegistry, nil))
+	// Deleted boot configurations are kept for restore during the retention
+	// window and listed with ?deleted=true
+	var deleted *trash.Bin
+	if config.SoftDeleteRetentionDays > 0 {
+		deleted = trash.NewBin(time.Duration(config.SoftDeleteRetentionDays)*24*time.Hour,
+			log.New(os.Stdout, "trash: ", log.LstdFlags), "BootConfiguration")
+		r.Use(listDeleted(deleted, scope))
+	}
+	r.Use(paginateLists)
+	r.Use(filterBMCs)
+	if scope != nil {
+		r.Use(scope)
+	}
+	if config.AuditEnabled {
+		r.Use(auditActors(config, apiKeys))
+	}
+	specSchemas, err := schemas.NewRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to generate spec schemas: %w", err)
+	}
+	// Patches are applied here rather than by the generated handlers, so
+	// removed fields are cleared and the patched spec is checked too
+	var patchSchemas *schemas.Registry
+	if config.SpecSchemaValidation {
+		r.Use(specSchemas.ValidateWrites)
+		patchSchemas = specSchemas
+	}
+	r.Use(patchSpecs(patchSchemas))
 
 	// Register health check
 	r.Get("/health", func(w http.ResponseWriter, req *http.Request) { //nolint:revive
@@ -293,16 +1063,22 @@ func runServe(cmd *cobra.Command, args []string) error { //nolint:revive
 	// Register OpenAPI endpoints
 	r.Get("/openapi.json", ServeOpenAPISpec)
 	r.Get("/docs", ServeSwaggerUI)
+	schemas.NewHandler(specSchemas).RegisterRoutes(r)
 
 	// Metrics endpoint is available when enabled at runtime.
 	if config.EnableMetrics && metrics != nil {
 		r.Handle("/metrics", metrics.Handler())
-		go startMetricsServer(config, metrics.Handler())
+		go startMetricsServer(config, metrics.Handler(), apiKeys)
 	}
 
-	if err := registerCustomServerIntegrations(r, config, hsmClient, ctx); err != nil {
+	reloader := newConfigReloader(config, vaultConfigLoader(ctx, vaultClient, loadConfig))
+	if apiKeys != nil {
+		registerAPIKeys(r, config, apiKeys)
+	}
+	if err := registerCustomServerIntegrations(r, config, hsmClient, vaultClient, watches, bootEvents, deleted, specSchemas, metrics, reloader, apiKeys, ctx); err != nil {
 		return err
 	}
+	go watchConfig(ctx, reloader)
 
 	// Configure server
 	server := &http.Server{
@@ -312,6 +1088,8 @@ func runServe(cmd *cobra.Command, args []string) error { //nolint:revive
 		WriteTimeout: time.Duration(config.WriteTimeout) * time.Second,
 		IdleTimeout:  time.Duration(config.IdleTimeout) * time.Second,
 	}
+	server.RegisterOnShutdown(watches.Close)
+	server.RegisterOnShutdown(bootEvents.Close)
 
 	// Setup graceful shutdown handler
 
@@ -320,24 +1098,40 @@ func runServe(cmd *cobra.Command, args []string) error { //nolint:revive
 		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 		<-sigChan
 
-		log.Println("Shutting down server...")
-		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		// Listeners close at once, so new connections go to a replacement
+		// process sharing them, while in-flight requests are drained
+		drainTimeout := time.Duration(config.DrainTimeout) * time.Second
+		log.Printf("Shutting down server, draining connections for up to %s...", drainTimeout)
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), drainTimeout)
 		defer shutdownCancel()
 
 		if err := server.Shutdown(shutdownCtx); err != nil {
-			log.Printf("Server shutdown error: %v", err)
+			log.Printf("Server shutdown error: %v; closing remaining connections", err)
+			server.Close() //nolint:errcheck
 		}
 		cancel()
 	}()
 
-	// Start server
-	log.Printf("Server starting on %s", server.Addr)
-	log.Println("Modern API available at: /nodes, /bootconfigurations")
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	// Start server on every listener; socket activation replaces host:port
+	listeners, err := openListeners(config)
+	if err != nil {
 		return fmt.Errorf("server failed: %v", err)
 	}
+	serveErrs := make(chan error, len(listeners))
+	for _, listener := range listeners {
+		log.Printf("Server starting on %s", listener.Addr())
+		go func() { serveErrs <- server.Serve(listener) }()
+	}
+	log.Println("Modern API available at: /nodes, /bootconfigurations")
+	for range listeners {
+		if err := <-serveErrs; err != nil && err != http.ErrServerClosed {
+			server.Close() //nolint:errcheck
+			return fmt.Errorf("server failed: %v", err)
+		}
+	}
 
 	<-ctx.Done()
+	backgroundTasks.Wait()
 	log.Println("Server stopped")
 	return nil
 }
@@ -346,16 +1140,481 @@ func validateConfig(config Config) error {
 	if config.Port <= 0 || config.Port > 65535 {
 		return fmt.Errorf("invalid port: %d", config.Port)
 	}
+	switch config.StorageType {
+	case "file":
+	case "etcd":
+		parsed, err := url.Parse(config.EtcdURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("etcd-url must be an http(s) URL when storage-type is etcd")
+		}
+		if (config.EtcdUsername == "") != (config.EtcdPassword == "") {
+			return fmt.Errorf("etcd-username and etcd-password must be set together")
+		}
+	default:
+		return fmt.Errorf("unsupported storage-type %q (supported: file, etcd)", config.StorageType)
+	}
 	if config.EnableAuth && config.TokenSmithURL == "" {
 		return fmt.Errorf("tokensmith-url is required when auth is enabled")
 	}
 	if config.TokenSmithRefreshSkewSec < 0 {
 		return fmt.Errorf("tokensmith-refresh-skew-sec must be >= 0")
 	}
+	if _, err := hsm.ParseConflictPolicy(config.HSMSyncConflictPolicy); err != nil {
+		return fmt.Errorf("invalid hsm-sync-conflict-policy: %w", err)
+	}
+	if config.HSMPushEnabled && config.HSMURL == "" {
+		return fmt.Errorf("hsm-push-enabled requires hsm-url")
+	}
+	if config.GroupSyncEnabled && config.HSMURL == "" {
+		return fmt.Errorf("group-sync-enabled requires hsm-url")
+	}
+	if config.GroupSyncEnabled && config.GroupSyncInterval < 1 {
+		return fmt.Errorf("group-sync-interval must be at least 1 minute")
+	}
+	if config.BMCDiscoveryEnabled {
+		if err := validateBMCDiscovery(config); err != nil {
+			return err
+		}
+	}
+	if config.BMCTelemetryEnabled {
+		if err := validateBMCTelemetry(config); err != nil {
+			return err
+		}
+	}
+	if config.RebootWorkflowsEnabled {
+		if err := validateRebootWorkflows(config); err != nil {
+			return err
+		}
+	}
+	if config.TenancyEnabled {
+		parsed, err := url.Parse(config.JWKSEndpoint)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("jwks-endpoint must be an http(s) URL when tenancy is enabled")
+		}
+		// A remote resource API would bypass the tenant-scoped storage
+		if config.ResourceAPIURL != "" {
+			return fmt.Errorf("tenancy-enabled cannot be combined with resource-api-url")
+		}
+	}
+	if config.EnablePprof {
+		if !config.EnableMetrics {
+			return fmt.Errorf("enable-pprof requires enable-metrics")
+		}
+		parsed, err := url.Parse(config.JWKSEndpoint)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("jwks-endpoint must be an http(s) URL when pprof is enabled")
+		}
+	}
+	if config.LegacyRecordFile != "" && !config.EnableLegacyAPI {
+		return fmt.Errorf("legacy-record-file requires enable-legacy-api")
+	}
+	if config.APIKeysEnabled {
+		parsed, err := url.Parse(config.JWKSEndpoint)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("jwks-endpoint must be an http(s) URL when API keys are enabled")
+		}
+	}
+	if config.LegacyAuthEnabled {
+		if !config.EnableLegacyAPI {
+			return fmt.Errorf("legacy-auth-enabled requires enable-legacy-api")
+		}
+		parsed, err := url.Parse(config.JWKSEndpoint)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("jwks-endpoint must be an http(s) URL when legacy auth is enabled")
+		}
+	}
+	if _, err := validationProfile(config); err != nil {
+		return fmt.Errorf("validation-profile: %w", err)
+	}
+	if config.LegacyAuthNonEnforcing && !config.LegacyAuthEnabled {
+		return fmt.Errorf("legacy-auth-non-enforcing requires legacy-auth-enabled")
+	}
+	if _, err := parseLegacyScopes(parseScopeHintCSV(config.LegacyScopes)); err != nil {
+		return fmt.Errorf("legacy-scopes: %w", err)
+	}
+	if _, err := httputil.ParseNetworks(parseScopeHintCSV(config.LegacyBootScriptAllowCIDRs)); err != nil {
+		return fmt.Errorf("legacy-bootscript-allow-cidrs: %w", err)
+	}
+	if config.BSSUpstreamURL != "" {
+		if !config.EnableLegacyAPI {
+			return fmt.Errorf("bss-upstream-url requires enable-legacy-api")
+		}
+		parsed, err := url.Parse(config.BSSUpstreamURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("bss-upstream-url must be an http(s) URL")
+		}
+	}
+	if config.ImageServiceURL != "" {
+		parsed, err := url.Parse(config.ImageServiceURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("image-service-url must be an http(s) URL")
+		}
+		if config.ImageServiceCacheTTL <= 0 {
+			return fmt.Errorf("image-service-cache-ttl must be positive")
+		}
+	}
+	if config.CloudInitURL != "" {
+		parsed, err := url.Parse(config.CloudInitURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("cloud-init-url must be an http(s) URL")
+		}
+		// The URL becomes part of ds=nocloud-net;s=<url>, which ends at a
+		// space or semicolon
+		if strings.ContainsAny(config.CloudInitURL, " ;") {
+			return fmt.Errorf("cloud-init-url must not contain spaces or semicolons")
+		}
+	}
+	if config.AuditRetentionDays < 0 {
+		return fmt.Errorf("audit-retention-days must be >= 0")
+	}
+	if config.AuditSyslogAddress != "" {
+		if _, _, err := audit.ParseSyslogAddress(config.AuditSyslogAddress); err != nil {
+			return err
+		}
+	}
+	if config.AuditWebhookURL != "" {
+		parsed, err := url.Parse(config.AuditWebhookURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("invalid audit-webhook-url: %q", config.AuditWebhookURL)
+		}
+	}
+	if err := validateBackupConfig(config); err != nil {
+		return err
+	}
+	if config.SoftDeleteRetentionDays < 0 {
+		return fmt.Errorf("soft-delete-retention-days must be >= 0")
+	}
+	if err := validateGitOpsConfig(config); err != nil {
+		return err
+	}
+	if err := validateKubernetesConfig(config); err != nil {
+		return err
+	}
+	if config.AdmissionWebhookURL != "" {
+		parsed, err := url.Parse(config.AdmissionWebhookURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("invalid admission-webhook-url: %q", config.AdmissionWebhookURL)
+		}
+		if config.AdmissionWebhookTimeoutMS <= 0 {
+			return fmt.Errorf("admission-webhook-timeout-ms must be > 0")
+		}
+	}
+	if config.OPAURL != "" {
+		parsed, err := url.Parse(config.OPAURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("invalid opa-url: %q", config.OPAURL)
+		}
+		if config.OPATimeoutMS <= 0 {
+			return fmt.Errorf("opa-timeout-ms must be > 0")
+		}
+	} else if config.OPABootScriptPath != "" {
+		return fmt.Errorf("opa-bootscript-path requires opa-url")
+	}
 	// Note: HSM is auto-enabled when hsm-url is provided, no explicit validation needed
+	if config.ResourceAPIURL != "" {
+		parsed, err := url.Parse(config.ResourceAPIURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("invalid resource-api-url: %q", config.ResourceAPIURL)
+		}
+	}
+	if config.ArtifactCacheEnabled {
+		if _, err := artifactBaseURL(config); err != nil {
+			return err
+		}
+	}
+	if config.ScriptCacheTTL <= 0 {
+		return fmt.Errorf("script-cache-ttl must be > 0")
+	}
+	if config.ScriptCacheMaxEntries <= 0 {
+		return fmt.Errorf("script-cache-max-entries must be > 0")
+	}
+	if config.ScriptCacheMaxBytes <= 0 {
+		return fmt.Errorf("script-cache-max-bytes must be > 0")
+	}
+	if config.ScriptCachePrewarmDelay < 0 {
+		return fmt.Errorf("script-cache-prewarm-delay must be >= 0")
+	}
+	if config.BootScriptTimeoutMS < 0 || config.BootScriptNodeLookupTimeoutMS < 0 ||
+		config.BootScriptConfigLookupTimeoutMS < 0 || config.BootScriptArtifactTimeoutMS < 0 {
+		return fmt.Errorf("bootscript timeouts must be >= 0")
+	}
+	if config.BootScriptFallbackRetryDelay < 0 {
+		return fmt.Errorf("bootscript-fallback-retry-delay must be >= 0")
+	}
+	if err := matchScoring(config).Validate(); err != nil {
+		return err
+	}
+	switch config.BootConfigurationConflicts {
+	case "off", "warn", "reject":
+	default:
+		return fmt.Errorf("bootconfiguration-conflicts must be off, warn, or reject")
+	}
+	// The fallback script must be served before the request timeout cuts the
+	// connection.
+	if config.BootScriptTimeoutMS > 0 && config.ReadTimeout > 0 && config.BootScriptTimeoutMS >= config.ReadTimeout*1000 {
+		return fmt.Errorf("bootscript-timeout-ms must be less than read-timeout (%d seconds)", config.ReadTimeout)
+	}
+	if config.BootScriptRateLimit < 0 || config.BootScriptPerIPRateLimit < 0 {
+		return fmt.Errorf("bootscript rate limits must be >= 0")
+	}
+	if config.BootScriptRateLimit > 0 && config.BootScriptRateBurst < 1 {
+		return fmt.Errorf("bootscript-rate-burst must be >= 1 when bootscript-rate-limit is set")
+	}
+	if config.BootScriptPerIPRateLimit > 0 && config.BootScriptPerIPRateBurst < 1 {
+		return fmt.Errorf("bootscript-per-ip-rate-burst must be >= 1 when bootscript-per-ip-rate-limit is set")
+	}
+	if (config.ScriptSigningCert == "") != (config.ScriptSigningKey == "") {
+		return fmt.Errorf("script-signing-cert and script-signing-key must be set together")
+	}
+	if _, err := boot.ParseRequestVars(config.BootScriptRequestVars); err != nil {
+		return fmt.Errorf("bootscript-request-vars: %w", err)
+	}
+	if config.BootLoopThreshold < 0 {
+		return fmt.Errorf("boot-loop-threshold must not be negative")
+	}
+	if config.BootLoopThreshold > 0 {
+		if config.BootLoopWindow <= 0 {
+			return fmt.Errorf("boot-loop-window must be positive when boot-loop-threshold is set")
+		}
+		if config.BootLoopWebhookURL != "" {
+			parsed, err := url.Parse(config.BootLoopWebhookURL)
+			if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				return fmt.Errorf("invalid boot-loop-webhook-url: %q", config.BootLoopWebhookURL)
+			}
+		}
+	} else if config.BootLoopWebhookURL != "" || config.BootLoopDiagnosticConfig != "" {
+		return fmt.Errorf("boot-loop-webhook-url and boot-loop-diagnostic-config require boot-loop-threshold")
+	}
+	if names, err := utilityboot.ParseNames(config.UtilityBootConfigs); err != nil {
+		return fmt.Errorf("utility-boot-configs: %w", err)
+	} else if len(names) > 0 {
+		parsed, err := url.Parse(config.UtilityBootBaseURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("utility-boot-base-url must be an http(s) URL when utility-boot-configs is set")
+		}
+	}
+	if config.NodeDiscovery && config.NodeDiscoveryConfig == "" {
+		return fmt.Errorf("node-discovery requires node-discovery-config")
+	} else if !config.NodeDiscovery && config.NodeDiscoveryConfig != "" {
+		return fmt.Errorf("node-discovery-config requires node-discovery")
+	}
+	if _, err := httputil.ParseNetworks(parseScopeHintCSV(config.NodeDiscoveryNetworks)); err != nil {
+		return fmt.Errorf("node-discovery-networks: %w", err)
+	}
+	if config.NodeDiscoveryLimit < 0 {
+		return fmt.Errorf("node-discovery-limit must not be negative")
+	}
+	if loader := config.HTTPBootLoader; loader != "" && !strings.HasPrefix(loader, "http://") && !strings.HasPrefix(loader, "https://") {
+		if info, err := os.Stat(loader); err != nil {
+			return fmt.Errorf("http-boot-loader: %w", err)
+		} else if info.IsDir() {
+			return fmt.Errorf("http-boot-loader %s is a directory", loader)
+		}
+	}
+	if config.TFTPAddress != "" {
+		if _, _, err := net.SplitHostPort(config.TFTPAddress); err != nil {
+			return fmt.Errorf("tftp-address: %w", err)
+		}
+		files, err := tftp.ParseBootFiles(parseScopeHintCSV(config.TFTPBootFiles))
+		if err == nil && len(files.Architectures()) == 0 {
+			err = fmt.Errorf("no boot files")
+		}
+		if err == nil {
+			err = files.Check()
+		}
+		if err != nil {
+			return fmt.Errorf("tftp-boot-files: %w", err)
+		}
+	} else if config.TFTPBootFiles != "" {
+		return fmt.Errorf("tftp-boot-files requires tftp-address")
+	}
+	if config.ProxyDHCPEnabled {
+		if config.TFTPAddress == "" {
+			return fmt.Errorf("proxy-dhcp-enabled requires tftp-address")
+		}
+		if ip := net.ParseIP(config.ProxyDHCPServerIP); ip == nil || ip.To4() == nil || ip.IsUnspecified() {
+			return fmt.Errorf("proxy-dhcp-server-ip must be an IPv4 address when proxy-dhcp is enabled")
+		}
+	}
+	if (config.SecretsFile == "") != (config.SecretsKeyFile == "") {
+		return fmt.Errorf("secrets-file and secrets-key-file must be set together")
+	}
+	if _, err := httputil.ParseTrustedProxies(parseScopeHintCSV(config.TrustedProxies)); err != nil {
+		return fmt.Errorf("trusted-proxies: %w", err)
+	}
+	if config.DrainTimeout < 0 {
+		return fmt.Errorf("drain-timeout must be >= 0")
+	}
+	for _, pattern := range parseScopeHintCSV(config.BootEventsOrigins) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid boot-events-origins pattern %q", pattern)
+		}
+	}
+	for _, name := range parseScopeHintCSV(config.ReadinessChecks) {
+		if !slices.Contains(readinessChecks, name) {
+			return fmt.Errorf("unknown readiness-checks check %q (want %s)", name, strings.Join(readinessChecks, ", "))
+		}
+	}
+	if config.ReadinessTimeoutMS <= 0 {
+		return fmt.Errorf("readiness-timeout-ms must be > 0")
+	}
+	if config.VaultAddr != "" {
+		parsed, err := url.Parse(config.VaultAddr)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("invalid vault-addr: %q", config.VaultAddr)
+		}
+		if config.VaultRefreshInterval <= 0 {
+			return fmt.Errorf("vault-refresh-interval must be > 0")
+		}
+	} else if config.VaultSecretsPath != "" {
+		return fmt.Errorf("vault-secrets-path requires vault-addr")
+	}
+	if (config.VaultRoleID == "") != (config.VaultSecretIDFile == "") {
+		return fmt.Errorf("vault-role-id and vault-secret-id-file must be set together")
+	}
+	if config.VaultTokenFile != "" && config.VaultRoleID != "" {
+		return fmt.Errorf("vault-token-file cannot be combined with vault-role-id")
+	}
+	if config.SecretsFile != "" && config.VaultSecretsPath != "" {
+		return fmt.Errorf("secrets-file cannot be combined with vault-secrets-path")
+	}
+	if config.CacheBackend != "memory" && config.CacheBackend != "redis" {
+		return fmt.Errorf("invalid cache-backend %q: must be memory or redis", config.CacheBackend)
+	}
+	if config.LeaderLeaseTTL < 3 {
+		return fmt.Errorf("leader-lease-ttl must be at least 3 seconds")
+	}
+	if config.CacheBackend == "redis" || config.LeaderElectionEnabled {
+		if config.RedisURL == "" {
+			return fmt.Errorf("redis-url is required when cache-backend is redis or leader election is enabled")
+		}
+		client, err := sharedstate.NewRedisClient(config.RedisURL)
+		if err != nil {
+			return err
+		}
+		client.Close() //nolint:errcheck
+	}
+	if config.S3AccessKeyID != "" || config.S3SecretAccessKey != "" {
+		// Presigned URLs are embedded in cached boot scripts, so they must outlive them.
+		if config.S3PresignExpiry < config.ScriptCacheTTL {
+			return fmt.Errorf("s3-presign-expiry must be at least script-cache-ttl (%d seconds)", config.ScriptCacheTTL)
+		}
+		if _, err := artifacts.NewS3Presigner(s3Config(config)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// validateBackupConfig checks the backup destination and schedule
+func validateBackupConfig(config Config) error {
+	if config.BackupDir != "" && config.BackupS3URL != "" {
+		return fmt.Errorf("backup-dir and backup-s3-url cannot both be set")
+	}
+	if config.BackupS3URL != "" {
+		bucket, _, _ := strings.Cut(strings.TrimPrefix(config.BackupS3URL, "s3://"), "/")
+		if !strings.HasPrefix(config.BackupS3URL, "s3://") || bucket == "" {
+			return fmt.Errorf("invalid backup-s3-url %q: expected s3://bucket/prefix", config.BackupS3URL)
+		}
+		if config.S3AccessKeyID == "" || config.S3SecretAccessKey == "" {
+			return fmt.Errorf("backup-s3-url requires s3-access-key-id and s3-secret-access-key")
+		}
+	}
+	if config.BackupDir != "" || config.BackupS3URL != "" {
+		if config.BackupInterval < 1 {
+			return fmt.Errorf("backup-interval must be at least 1 minute")
+		}
+	}
+	if config.BackupRetention < 0 {
+		return fmt.Errorf("backup-retention must be >= 0")
+	}
+	if config.BackupRetentionDays < 0 {
+		return fmt.Errorf("backup-retention-days must be >= 0")
+	}
+	return nil
+}
+
+// validateGitOpsConfig checks the GitOps repository, its credentials, and
+// the sync schedule
+func validateGitOpsConfig(config Config) error {
+	if config.GitOpsURL == "" {
+		return nil
+	}
+	transport := gitops.Transport(config.GitOpsURL)
+	switch {
+	case transport == "":
+		return fmt.Errorf("invalid gitops-url %q: expected an https, ssh, or file URL, user@host:path, or an absolute path", config.GitOpsURL)
+	case config.GitOpsBranch == "":
+		return fmt.Errorf("gitops-branch is required with gitops-url")
+	case config.GitOpsMode != gitops.ModeApply && config.GitOpsMode != gitops.ModeReport:
+		return fmt.Errorf("invalid gitops-mode %q: must be apply or report", config.GitOpsMode)
+	case config.GitOpsInterval < 10:
+		return fmt.Errorf("gitops-interval must be at least 10 seconds")
+	case filepath.IsAbs(config.GitOpsPath) || strings.HasPrefix(filepath.Clean(config.GitOpsPath), ".."):
+		return fmt.Errorf("invalid gitops-path %q: must be a directory within the repository", config.GitOpsPath)
+	case (config.GitOpsSSHKeyFile != "" || config.GitOpsSSHKnownHostsFile != "") && transport != gitops.TransportSSH:
+		return fmt.Errorf("gitops-ssh-key-file and gitops-ssh-known-hosts-file require an ssh gitops-url")
+	case (config.GitOpsUsername != "" || config.GitOpsPassword != "") && transport != gitops.TransportHTTP:
+		return fmt.Errorf("gitops-username and gitops-password require an https gitops-url")
+	case config.GitOpsPassword != "" && config.GitOpsUsername == "":
+		return fmt.Errorf("gitops-password requires gitops-username")
+	}
+	return nil
+}
+
+// validateKubernetesConfig checks the operator's API server and resync
+// interval
+func validateKubernetesConfig(config Config) error {
+	if !config.KubernetesEnabled {
+		return nil
+	}
+	if config.KubernetesAPIURL != "" {
+		parsed, err := url.Parse(config.KubernetesAPIURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("invalid kubernetes-api-url: %q", config.KubernetesAPIURL)
+		}
+	}
+	switch {
+	case config.KubernetesResyncInterval < 10:
+		return fmt.Errorf("kubernetes-resync-interval must be at least 10 seconds")
+	case config.GitOpsURL != "":
+		// Each would revert the other's writes to resources both declare
+		return fmt.Errorf("kubernetes-enabled and gitops-url cannot be combined: declare resources in one place")
+	}
+	return nil
+}
+
+// s3Config converts the S3 settings into a presigner configuration
+func s3Config(config Config) artifacts.S3Config {
+	return artifacts.S3Config{
+		Endpoint:        config.S3Endpoint,
+		Region:          config.S3Region,
+		AccessKeyID:     config.S3AccessKeyID,
+		SecretAccessKey: config.S3SecretAccessKey,
+		SessionToken:    config.S3SessionToken,
+		PathStyle:       config.S3PathStyle,
+		Expiry:          time.Duration(config.S3PresignExpiry) * time.Second,
+	}
+}
+
+// artifactBaseURL returns the URL nodes use to reach cached artifacts. It
+// falls back to the listen address unless that is a wildcard address.
+func artifactBaseURL(config Config) (string, error) {
+	if config.ArtifactBaseURL != "" {
+		parsed, err := url.Parse(config.ArtifactBaseURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return "", fmt.Errorf("invalid artifact-base-url: %q", config.ArtifactBaseURL)
+		}
+		return config.ArtifactBaseURL, nil
+	}
+
+	if config.Host == "" || config.Host == "0.0.0.0" || config.Host == "::" {
+		return "", fmt.Errorf("artifact-base-url is required when artifact-cache-enabled is set and host is a wildcard address")
+	}
+
+	return fmt.Sprintf("http://%s", net.JoinHostPort(config.Host, strconv.Itoa(config.Port))), nil
+}
+
 func tokenSmithScopeHintCSV(config Config) string {
 	if strings.TrimSpace(config.TokenSmithBootstrapPolicyScopesHint) != "" {
 		return config.TokenSmithBootstrapPolicyScopesHint
@@ -365,6 +1624,77 @@ func tokenSmithScopeHintCSV(config Config) string {
 	return config.TokenSmithScopesLegacy
 }
 
+// validateBMCDiscovery checks the BMC discovery settings
+func validateBMCDiscovery(config Config) error {
+	if _, err := bmcdiscovery.ParsePrefixes(config.BMCDiscoveryRanges); err != nil {
+		return fmt.Errorf("bmc-discovery-ranges: %w", err)
+	}
+	switch {
+	case config.BMCDiscoveryInterval < 1:
+		return fmt.Errorf("bmc-discovery-interval must be at least 1 minute")
+	case config.BMCDiscoveryMACDistance < 0:
+		return fmt.Errorf("bmc-discovery-mac-distance must not be negative")
+	}
+	return validateRedfishAccess(config)
+}
+
+// validateBMCTelemetry checks the BMC telemetry settings
+func validateBMCTelemetry(config Config) error {
+	if config.BMCTelemetryInterval < 1 {
+		return fmt.Errorf("bmc-telemetry-interval must be at least 1 minute")
+	}
+	if _, err := bmctelemetry.ParseHealth(config.BMCTelemetryFaultHealth); err != nil {
+		return fmt.Errorf("bmc-telemetry-fault-health: %w", err)
+	}
+	return validateRedfishAccess(config)
+}
+
+// rebootWorkflowResetTypes are the Redfish reset types reboot workflows may
+// use
+var rebootWorkflowResetTypes = []string{redfish.ResetForceRestart, redfish.ResetGracefulRestart, redfish.ResetPowerCycle}
+
+// validateRebootWorkflows checks the reboot workflow settings
+func validateRebootWorkflows(config Config) error {
+	switch {
+	case config.RebootWorkflowBatchSize < 1:
+		return fmt.Errorf("reboot-workflow-batch-size must be at least 1")
+	case config.RebootWorkflowBatchDelay < 0:
+		return fmt.Errorf("reboot-workflow-batch-delay must not be negative")
+	case config.RebootWorkflowTimeout < 1:
+		return fmt.Errorf("reboot-workflow-timeout must be at least 1 minute")
+	case config.RebootWorkflowRetries < 0:
+		return fmt.Errorf("reboot-workflow-retries must not be negative")
+	case !slices.Contains(rebootWorkflowResetTypes, config.RebootWorkflowResetType):
+		return fmt.Errorf("reboot-workflow-reset-type must be one of %s", strings.Join(rebootWorkflowResetTypes, ", "))
+	}
+	return validateRedfishAccess(config)
+}
+
+// validateRedfishAccess checks the settings BMC discovery and telemetry
+// share for reaching Redfish services
+func validateRedfishAccess(config Config) error {
+	switch {
+	case config.BMCDiscoveryPort < 1 || config.BMCDiscoveryPort > 65535:
+		return fmt.Errorf("bmc-discovery-port must be between 1 and 65535")
+	case config.BMCDiscoveryConcurrency < 1:
+		return fmt.Errorf("bmc-discovery-concurrency must be at least 1")
+	case config.BMCDiscoveryTimeoutMS < 1:
+		return fmt.Errorf("bmc-discovery-timeout-ms must be positive")
+	case (config.BMCDiscoveryUsername == "") != (config.BMCDiscoveryPassword == ""):
+		return fmt.Errorf("bmc-discovery-username and bmc-discovery-password must be set together")
+	}
+	return nil
+}
+
+// validationProfileKeys are the settings that make up the validation profile
+var validationProfileKeys = []string{"validation_profile", "validation_xname_pattern", "validation_mac_pattern", "validation_url_pattern"}
+
+// validationProfile builds the validation profile of config
+func validationProfile(config Config) (*validation.Profile, error) {
+	return validation.NewProfile(config.ValidationProfile, config.ValidationXNamePattern,
+		config.ValidationMACPattern, config.ValidationURLPattern)
+}
+
 func parseScopeHintCSV(raw string) []string {
 	if strings.TrimSpace(raw) == "" {
 		return nil
@@ -433,9 +1763,13 @@ func initializeHSMServiceTokenManager(ctx context.Context, config Config, hsmLog
 	return serviceTokenManager, nil
 }
 
-func startMetricsServer(config Config, handler http.Handler) {
+func startMetricsServer(config Config, handler http.Handler, keys *apikeys.Store) {
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", handler)
+	if config.EnablePprof {
+		mux.Handle("/debug/pprof/", pprofHandler(config, keys))
+		log.Printf("Profiling enabled at /debug/pprof/ on the metrics listener (scope: %q)", config.PprofScope)
+	}
 
 	metricsAddr := fmt.Sprintf("%s:%d", config.Host, config.MetricsPort)
 	log.Printf("Metrics server starting on %s", metricsAddr)
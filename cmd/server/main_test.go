@@ -11,18 +11,25 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/openchami/boot-service/internal/storage"
 	bootclient "github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
 	"github.com/openchami/boot-service/pkg/handlers/boot"
+	"github.com/openchami/boot-service/pkg/sharedstate"
 )
 
 func TestBindFlagsWithUnderscoreKeys_ConfigValuesBeatUnchangedFlagDefaults(t *testing.T) {
@@ -114,6 +121,10 @@ func TestBindFlagsWithUnderscoreKeys_ChangedHyphenatedFlagsUseUnderscoreKeys(t *
 	}
 }
 
+// registerResourcePrefixesOnce guards the process-wide Fabrica UID prefix
+// registry, which panics on duplicate registration.
+var registerResourcePrefixesOnce sync.Once
+
 func newGeneratedRouterForTest(t *testing.T) http.Handler {
 	t.Helper()
 
@@ -121,9 +132,15 @@ func newGeneratedRouterForTest(t *testing.T) http.Handler {
 	if err := storage.InitFileBackend(dataDir); err != nil {
 		t.Fatalf("failed to initialize file backend: %v", err)
 	}
+	registerResourcePrefixesOnce.Do(func() {
+		if err := registerResourcePrefixes(); err != nil {
+			t.Fatalf("failed to register resource prefixes: %v", err)
+		}
+	})
 
 	r := chi.NewRouter()
 	r.Use(middleware.RedirectSlashes)
+	r.Use(patchSpecs(nil))
 	RegisterGeneratedRoutes(r)
 
 	return r
@@ -320,9 +337,10 @@ func newRouterWithLegacyModeForTest(t *testing.T, enableLegacyAPI bool) http.Han
 
 	r := chi.NewRouter()
 	r.Use(middleware.RedirectSlashes)
+	r.Use(patchSpecs(nil))
 	RegisterGeneratedRoutes(r)
 
-	bootHandler := boot.NewHandler(*bootClient, log.New(io.Discard, "", 0))
+	bootHandler := boot.NewHandler(bootClient, log.New(io.Discard, "", 0))
 
 	// Always register modern routes
 	bootHandler.RegisterModernRoutes(r)
@@ -377,3 +395,985 @@ func TestInitializeHSMServiceTokenManager_RequiresBootstrapTokenWhenAuthEnabled(
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestArtifactBaseURL(t *testing.T) {
+	config := DefaultConfig()
+	if _, err := artifactBaseURL(config); err == nil {
+		t.Fatal("expected error for wildcard host without artifact_base_url")
+	}
+
+	config.Host = "10.0.0.1"
+	if got, err := artifactBaseURL(config); err != nil || got != "http://10.0.0.1:8080" {
+		t.Fatalf("artifactBaseURL = %q, %v; want http://10.0.0.1:8080", got, err)
+	}
+
+	config.ArtifactBaseURL = "https://boot.example.com"
+	if got, err := artifactBaseURL(config); err != nil || got != "https://boot.example.com" {
+		t.Fatalf("artifactBaseURL = %q, %v; want https://boot.example.com", got, err)
+	}
+
+	config.ArtifactBaseURL = "boot.example.com"
+	if _, err := artifactBaseURL(config); err == nil {
+		t.Fatal("expected error for artifact_base_url without scheme")
+	}
+}
+
+func TestValidateConfig_StorageType(t *testing.T) {
+	config := DefaultConfig()
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error for file storage: %v", err)
+	}
+	config.StorageType = "database"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for an unsupported storage type")
+	}
+}
+
+func TestValidateConfig_Backups(t *testing.T) {
+	config := DefaultConfig()
+	config.BackupDir = "/var/backups/boot-service"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+	config.BackupInterval = 0
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for a zero backup interval")
+	}
+
+	config = DefaultConfig()
+	config.BackupS3URL = "s3://backups/boot-service"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for backup-s3-url without S3 credentials")
+	}
+	config.S3AccessKeyID = "minio"
+	config.S3SecretAccessKey = "minio-secret"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+	config.BackupDir = "/var/backups/boot-service"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for both backup-dir and backup-s3-url")
+	}
+
+	config = DefaultConfig()
+	config.BackupS3URL = "https://backups.example.com"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for a backup-s3-url that is not s3://")
+	}
+}
+
+func TestValidateConfig_SoftDeleteRetention(t *testing.T) {
+	config := DefaultConfig()
+	config.SoftDeleteRetentionDays = 0
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+	config.SoftDeleteRetentionDays = -1
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for a negative soft-delete-retention-days")
+	}
+}
+
+func TestValidateConfig_GitOps(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "https with token", modify: func(c *Config) {
+			c.GitOpsURL = "https://git.example.com/site/boot-state.git"
+			c.GitOpsUsername, c.GitOpsPassword = "deploy", "token"
+		}},
+		{name: "scp-like ssh with key", modify: func(c *Config) {
+			c.GitOpsURL = "git@git.example.com:site/boot-state.git"
+			c.GitOpsSSHKeyFile = "/etc/boot-service/deploy_key"
+		}},
+		{name: "report mode in a subdirectory", modify: func(c *Config) {
+			c.GitOpsURL, c.GitOpsMode, c.GitOpsPath = "/srv/git/boot-state", "report", "clusters/a"
+		}},
+		{name: "unsupported url", modify: func(c *Config) { c.GitOpsURL = "ftp://git.example.com/boot" }, wantErr: true},
+		{name: "unknown mode", modify: func(c *Config) {
+			c.GitOpsURL, c.GitOpsMode = "/srv/git/boot-state", "sync"
+		}, wantErr: true},
+		{name: "short interval", modify: func(c *Config) {
+			c.GitOpsURL, c.GitOpsInterval = "/srv/git/boot-state", 5
+		}, wantErr: true},
+		{name: "path outside the repository", modify: func(c *Config) {
+			c.GitOpsURL, c.GitOpsPath = "/srv/git/boot-state", "../other"
+		}, wantErr: true},
+		{name: "ssh key with https", modify: func(c *Config) {
+			c.GitOpsURL = "https://git.example.com/site/boot-state.git"
+			c.GitOpsSSHKeyFile = "/etc/boot-service/deploy_key"
+		}, wantErr: true},
+		{name: "password with ssh", modify: func(c *Config) {
+			c.GitOpsURL = "ssh://git@git.example.com/site/boot-state.git"
+			c.GitOpsUsername, c.GitOpsPassword = "deploy", "token"
+		}, wantErr: true},
+		{name: "password without username", modify: func(c *Config) {
+			c.GitOpsURL, c.GitOpsPassword = "https://git.example.com/site/boot-state.git", "token"
+		}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(&config)
+			err := validateConfig(config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_Kubernetes(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "in cluster", modify: func(c *Config) { c.KubernetesEnabled = true }},
+		{name: "explicit API server", modify: func(c *Config) {
+			c.KubernetesEnabled, c.KubernetesAPIURL = true, "https://k8s.example.com:6443"
+			c.KubernetesTokenFile, c.KubernetesNamespace = "/etc/boot-service/token", "boot"
+		}},
+		{name: "invalid API URL", modify: func(c *Config) {
+			c.KubernetesEnabled, c.KubernetesAPIURL = true, "k8s.example.com"
+		}, wantErr: true},
+		{name: "short resync interval", modify: func(c *Config) {
+			c.KubernetesEnabled, c.KubernetesResyncInterval = true, 5
+		}, wantErr: true},
+		{name: "combined with GitOps", modify: func(c *Config) {
+			c.KubernetesEnabled, c.GitOpsURL = true, "/srv/git/boot-state"
+		}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(&config)
+			err := validateConfig(config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_HSMSyncConflictPolicy(t *testing.T) {
+	tests := []struct {
+		policy  string
+		wantErr bool
+	}{
+		{policy: "hsm"},
+		{policy: "last-writer-wins"},
+		{policy: "local,groups=hsm,bootMac=last-writer-wins"},
+		{policy: "groups=local"},
+		{policy: "manual", wantErr: true},
+		{policy: "hsm,local", wantErr: true},
+		{policy: "hostname=local", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			config := DefaultConfig()
+			config.HSMSyncConflictPolicy = tt.policy
+			err := validateConfig(config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_HSMPush(t *testing.T) {
+	config := DefaultConfig()
+	config.HSMPushEnabled = true
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for hsm-push-enabled without hsm-url")
+	}
+	config.HSMURL = "http://smd:27779"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig() error = %v", err)
+	}
+}
+
+func TestValidateConfig_APIKeys(t *testing.T) {
+	config := DefaultConfig()
+	config.APIKeysEnabled = true
+	config.JWKSEndpoint = ""
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for api-keys-enabled without jwks-endpoint")
+	}
+	config.JWKSEndpoint = "https://tokensmith.example.com/.well-known/jwks.json"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig() error = %v", err)
+	}
+}
+
+func TestValidateConfig_GroupSync(t *testing.T) {
+	config := DefaultConfig()
+	config.GroupSyncEnabled = true
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for group-sync-enabled without hsm-url")
+	}
+	config.HSMURL = "http://smd:27779"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig() error = %v", err)
+	}
+	config.GroupSyncInterval = 0
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for a group-sync-interval under a minute")
+	}
+}
+
+func TestValidateConfig_BMCDiscovery(t *testing.T) {
+	config := DefaultConfig()
+	config.BMCDiscoveryEnabled = true
+	config.BMCDiscoveryRanges = "10.254.0.0/16, 10.100.0.5"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig() error = %v", err)
+	}
+
+	invalid := map[string]func(*Config){
+		"a range that is not a CIDR":    func(c *Config) { c.BMCDiscoveryRanges = "bmc-net" },
+		"a range too large":             func(c *Config) { c.BMCDiscoveryRanges = "10.0.0.0/8" },
+		"an interval under a minute":    func(c *Config) { c.BMCDiscoveryInterval = 0 },
+		"a port out of range":           func(c *Config) { c.BMCDiscoveryPort = 70000 },
+		"no concurrency":                func(c *Config) { c.BMCDiscoveryConcurrency = 0 },
+		"a negative MAC distance":       func(c *Config) { c.BMCDiscoveryMACDistance = -1 },
+		"a username without a password": func(c *Config) { c.BMCDiscoveryUsername = "root" },
+	}
+	for name, mutate := range invalid {
+		bad := config
+		mutate(&bad)
+		if err := validateConfig(bad); err == nil {
+			t.Errorf("expected error for %s", name)
+		}
+	}
+}
+
+func TestValidateConfig_BMCTelemetry(t *testing.T) {
+	config := DefaultConfig()
+	config.BMCTelemetryEnabled = true
+	config.BMCTelemetryFaultHealth = "warning, Critical"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig() error = %v", err)
+	}
+
+	invalid := map[string]func(*Config){
+		"an interval under a minute":     func(c *Config) { c.BMCTelemetryInterval = 0 },
+		"an unknown fault health":        func(c *Config) { c.BMCTelemetryFaultHealth = "Degraded" },
+		"a timeout that is not positive": func(c *Config) { c.BMCDiscoveryTimeoutMS = 0 },
+		"a username without a password":  func(c *Config) { c.BMCDiscoveryUsername = "root" },
+	}
+	for name, mutate := range invalid {
+		bad := config
+		mutate(&bad)
+		if err := validateConfig(bad); err == nil {
+			t.Errorf("expected error for %s", name)
+		}
+	}
+}
+
+func TestValidateConfig_RebootWorkflows(t *testing.T) {
+	config := DefaultConfig()
+	config.RebootWorkflowsEnabled = true
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig() error = %v", err)
+	}
+
+	invalid := map[string]func(*Config){
+		"no batch size":                 func(c *Config) { c.RebootWorkflowBatchSize = 0 },
+		"a negative batch delay":        func(c *Config) { c.RebootWorkflowBatchDelay = -1 },
+		"a timeout under a minute":      func(c *Config) { c.RebootWorkflowTimeout = 0 },
+		"negative retries":              func(c *Config) { c.RebootWorkflowRetries = -1 },
+		"an unknown reset type":         func(c *Config) { c.RebootWorkflowResetType = "ForceOff" },
+		"a username without a password": func(c *Config) { c.BMCDiscoveryUsername = "root" },
+	}
+	for name, mutate := range invalid {
+		bad := config
+		mutate(&bad)
+		if err := validateConfig(bad); err == nil {
+			t.Errorf("expected error for %s", name)
+		}
+	}
+}
+
+func TestValidateConfig_TrustedProxies(t *testing.T) {
+	config := DefaultConfig()
+	config.TrustedProxies = "10.0.0.0/8, 192.0.2.7"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig() error = %v", err)
+	}
+	config.TrustedProxies = "10.0.0.0/8,ingress"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for a trusted_proxies entry that is not a CIDR or address")
+	}
+}
+
+func TestValidateConfig_DrainTimeout(t *testing.T) {
+	config := DefaultConfig()
+	config.DrainTimeout = 0
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig() error = %v", err)
+	}
+	config.DrainTimeout = -1
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for a negative drain_timeout")
+	}
+}
+
+func TestValidateConfig_TFTP(t *testing.T) {
+	loader := filepath.Join(t.TempDir(), "ipxe.efi")
+	if err := os.WriteFile(loader, []byte("MZ"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config := DefaultConfig()
+	config.TFTPAddress = "127.0.0.1:6969"
+	config.TFTPBootFiles = "7=" + loader + ",9=" + loader
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig() error = %v", err)
+	}
+
+	for _, files := range []string{"", "7=" + loader + ".missing", "efi=" + loader} {
+		config.TFTPBootFiles = files
+		if err := validateConfig(config); err == nil {
+			t.Errorf("expected error for tftp_boot_files %q", files)
+		}
+	}
+	config.TFTPAddress = ""
+	config.TFTPBootFiles = "7=" + loader
+	if err := validateConfig(config); err == nil {
+		t.Error("expected error for tftp_boot_files without tftp_address")
+	}
+}
+
+func TestValidateConfig_ProxyDHCP(t *testing.T) {
+	loader := filepath.Join(t.TempDir(), "undionly.kpxe")
+	if err := os.WriteFile(loader, []byte("pxe"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config := DefaultConfig()
+	config.ProxyDHCPEnabled = true
+	config.ProxyDHCPServerIP = "10.0.0.1"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for proxy_dhcp_enabled without tftp_address")
+	}
+	config.TFTPAddress = ":6969"
+	config.TFTPBootFiles = "0=" + loader
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig() error = %v", err)
+	}
+	for _, ip := range []string{"", "0.0.0.0", "2001:db8::1", "boot.example.com"} {
+		config.ProxyDHCPServerIP = ip
+		if err := validateConfig(config); err == nil {
+			t.Errorf("expected error for proxy_dhcp_server_ip %q", ip)
+		}
+	}
+}
+
+func TestCheckBindCapability(t *testing.T) {
+	if err := checkBindCapability(40000); err != nil {
+		t.Errorf("unprivileged port: %v", err)
+	}
+	// Whether port 67 is allowed depends on who runs the tests, but the
+	// check must not fail to read the process capabilities
+	if err := checkBindCapability(67); err != nil && !strings.Contains(err.Error(), "CAP_NET_BIND_SERVICE") {
+		t.Errorf("privileged port: %v", err)
+	}
+}
+
+func TestValidateConfig_EtcdStorage(t *testing.T) {
+	config := DefaultConfig()
+	config.StorageType = "etcd"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for etcd storage without etcd-url")
+	}
+
+	config.EtcdURL = "http://etcd:2379"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+
+	config.EtcdUsername = "boot"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for etcd-username without etcd-password")
+	}
+	config.EtcdPassword = "secret"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error with etcd credentials: %v", err)
+	}
+}
+
+func TestValidateConfig_S3PresignExpiry(t *testing.T) {
+	config := DefaultConfig()
+	config.S3AccessKeyID = "minio"
+	config.S3SecretAccessKey = "minio-secret"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+
+	config.S3PresignExpiry = 60
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for presign expiry shorter than the boot script cache")
+	}
+
+	config.ScriptCacheTTL = 60
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error with matching cache TTL: %v", err)
+	}
+}
+
+func TestValidateConfig_ScriptCache(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{name: "zero ttl", modify: func(c *Config) { c.ScriptCacheTTL = 0 }},
+		{name: "zero max entries", modify: func(c *Config) { c.ScriptCacheMaxEntries = 0 }},
+		{name: "negative max bytes", modify: func(c *Config) { c.ScriptCacheMaxBytes = -1 }},
+		{name: "unknown backend", modify: func(c *Config) { c.CacheBackend = "memcached" }},
+		{name: "redis without url", modify: func(c *Config) { c.CacheBackend = "redis" }},
+		{name: "invalid redis url", modify: func(c *Config) { c.CacheBackend = "redis"; c.RedisURL = "http://redis:6379" }},
+		{name: "leader election without redis url", modify: func(c *Config) { c.LeaderElectionEnabled = true }},
+		{name: "short leader lease", modify: func(c *Config) { c.LeaderLeaseTTL = 1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(&config)
+			if err := validateConfig(config); err == nil {
+				t.Fatal("expected validation error")
+			}
+		})
+	}
+}
+
+func TestValidateConfig_BootScriptBudget(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "defaults", modify: func(*Config) {}},
+		{name: "limits disabled", modify: func(c *Config) { c.BootScriptTimeoutMS = 0; c.BootScriptNodeLookupTimeoutMS = 0 }},
+		{name: "negative stage limit", modify: func(c *Config) { c.BootScriptArtifactTimeoutMS = -1 }, wantErr: true},
+		{name: "negative retry delay", modify: func(c *Config) { c.BootScriptFallbackRetryDelay = -1 }, wantErr: true},
+		{name: "budget exceeds read timeout", modify: func(c *Config) { c.ReadTimeout = 5 }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(&config)
+			if err := validateConfig(config); (err != nil) != tt.wantErr {
+				t.Fatalf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_BootScriptRateLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "disabled by default", modify: func(*Config) {}},
+		{name: "limits set", modify: func(c *Config) { c.BootScriptRateLimit = 100; c.BootScriptPerIPRateLimit = 0.5 }},
+		{name: "negative rate", modify: func(c *Config) { c.BootScriptPerIPRateLimit = -1 }, wantErr: true},
+		{name: "zero burst with rate", modify: func(c *Config) { c.BootScriptRateLimit = 10; c.BootScriptRateBurst = 0 }, wantErr: true},
+		{name: "zero burst without rate", modify: func(c *Config) { c.BootScriptPerIPRateBurst = 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(&config)
+			if err := validateConfig(config); (err != nil) != tt.wantErr {
+				t.Fatalf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_BootLoop(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "disabled by default", modify: func(*Config) {}},
+		{name: "enabled", modify: func(c *Config) {
+			c.BootLoopThreshold = 5
+			c.BootLoopWebhookURL = "https://alerts.example.com/boot-loops"
+			c.BootLoopDiagnosticConfig = "rescue"
+		}},
+		{name: "negative threshold", modify: func(c *Config) { c.BootLoopThreshold = -1 }, wantErr: true},
+		{name: "zero window", modify: func(c *Config) { c.BootLoopThreshold = 5; c.BootLoopWindow = 0 }, wantErr: true},
+		{name: "bad webhook", modify: func(c *Config) { c.BootLoopThreshold = 5; c.BootLoopWebhookURL = "alerts" }, wantErr: true},
+		{name: "diagnostic without threshold", modify: func(c *Config) { c.BootLoopDiagnosticConfig = "rescue" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(&config)
+			if err := validateConfig(config); (err != nil) != tt.wantErr {
+				t.Fatalf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_UtilityBoot(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "disabled by default", modify: func(*Config) {}},
+		{name: "enabled", modify: func(c *Config) {
+			c.UtilityBootConfigs = "memtest, rescue"
+			c.UtilityBootBaseURL = "http://files.example.com/utility"
+		}},
+		{name: "unknown entry", modify: func(c *Config) {
+			c.UtilityBootConfigs = "memtest,fdisk"
+			c.UtilityBootBaseURL = "http://files.example.com/utility"
+		}, wantErr: true},
+		{name: "no base URL", modify: func(c *Config) { c.UtilityBootConfigs = "memtest" }, wantErr: true},
+		{name: "base URL without scheme", modify: func(c *Config) {
+			c.UtilityBootConfigs = "memtest"
+			c.UtilityBootBaseURL = "files.example.com/utility"
+		}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(&config)
+			if err := validateConfig(config); (err != nil) != tt.wantErr {
+				t.Fatalf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_NodeDiscovery(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "disabled by default", modify: func(*Config) {}},
+		{name: "enabled", modify: func(c *Config) {
+			c.NodeDiscovery = true
+			c.NodeDiscoveryConfig = "inventory"
+		}},
+		{name: "no configuration", modify: func(c *Config) { c.NodeDiscovery = true }, wantErr: true},
+		{name: "configuration without discovery", modify: func(c *Config) { c.NodeDiscoveryConfig = "inventory" }, wantErr: true},
+		{name: "networks", modify: func(c *Config) { c.NodeDiscoveryNetworks = "10.1.0.0/16, 10.2.0.5" }},
+		{name: "invalid network", modify: func(c *Config) { c.NodeDiscoveryNetworks = "10.1.0.0/33" }, wantErr: true},
+		{name: "negative limit", modify: func(c *Config) { c.NodeDiscoveryLimit = -1 }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(&config)
+			if err := validateConfig(config); (err != nil) != tt.wantErr {
+				t.Fatalf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_CloudInit(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "disabled by default"},
+		{name: "http URL", url: "http://cloud-init:27777/cloud-init"},
+		{name: "no scheme", url: "cloud-init:27777/cloud-init", wantErr: true},
+		{name: "semicolon", url: "http://cloud-init/a;b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.CloudInitURL = tt.url
+			if err := validateConfig(config); (err != nil) != tt.wantErr {
+				t.Fatalf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_ResourceAPIURL(t *testing.T) {
+	config := DefaultConfig()
+	config.ResourceAPIURL = "https://boot.example.com"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+
+	config.ResourceAPIURL = "boot.example.com:8080"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for resource_api_url without scheme")
+	}
+}
+
+func TestValidateConfig_Tenancy(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "disabled", modify: func(*Config) {}},
+		{name: "enabled with jwks endpoint", modify: func(c *Config) {
+			c.TenancyEnabled = true
+			c.JWKSEndpoint = "https://tokensmith.example.com/.well-known/jwks.json"
+		}},
+		{name: "enabled without jwks endpoint", modify: func(c *Config) { c.TenancyEnabled = true }, wantErr: true},
+		{name: "enabled with remote resource api", modify: func(c *Config) {
+			c.TenancyEnabled = true
+			c.JWKSEndpoint = "https://tokensmith.example.com/.well-known/jwks.json"
+			c.ResourceAPIURL = "https://boot.example.com"
+		}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(&config)
+			if err := validateConfig(config); (err != nil) != tt.wantErr {
+				t.Fatalf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_Audit(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "defaults", modify: func(*Config) {}},
+		{name: "forwarding", modify: func(c *Config) {
+			c.AuditEnabled = true
+			c.AuditSyslogAddress = "udp://syslog.example.com:514"
+			c.AuditWebhookURL = "https://siem.example.com/hooks/boot"
+		}},
+		{name: "local syslog", modify: func(c *Config) { c.AuditSyslogAddress = "local" }},
+		{name: "negative retention", modify: func(c *Config) { c.AuditRetentionDays = -1 }, wantErr: true},
+		{name: "syslog without scheme", modify: func(c *Config) { c.AuditSyslogAddress = "syslog.example.com:514" }, wantErr: true},
+		{name: "webhook without scheme", modify: func(c *Config) { c.AuditWebhookURL = "siem.example.com/hooks" }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(&config)
+			if err := validateConfig(config); (err != nil) != tt.wantErr {
+				t.Fatalf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_AdmissionWebhook(t *testing.T) {
+	config := DefaultConfig()
+	config.AdmissionWebhookURL = "https://policy.example.com/admit"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+
+	config.AdmissionWebhookTimeoutMS = 0
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for admission webhook without a timeout")
+	}
+
+	config = DefaultConfig()
+	config.AdmissionWebhookURL = "policy.example.com/admit"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for admission_webhook_url without scheme")
+	}
+}
+
+func TestValidateConfig_OPA(t *testing.T) {
+	config := DefaultConfig()
+	config.OPAURL = "http://localhost:8181"
+	config.OPABootScriptPath = "bootservice/bootscript/deny"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+
+	config.OPATimeoutMS = 0
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for OPA without a timeout")
+	}
+
+	config = DefaultConfig()
+	config.OPAURL = "localhost:8181"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for opa_url without scheme")
+	}
+
+	config = DefaultConfig()
+	config.OPABootScriptPath = "bootservice/bootscript/deny"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for opa_bootscript_path without opa_url")
+	}
+}
+
+func TestValidateConfig_ScriptSigning(t *testing.T) {
+	config := DefaultConfig()
+	config.ScriptSigningCert = "/etc/boot-service/signing.pem"
+	config.ScriptSigningKey = "/etc/boot-service/signing.key"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+
+	config.ScriptSigningKey = ""
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for script_signing_cert without script_signing_key")
+	}
+}
+
+func TestValidateConfig_Secrets(t *testing.T) {
+	config := DefaultConfig()
+	config.SecretsFile = "/var/lib/boot-service/secrets.json"
+	config.SecretsKeyFile = "/etc/boot-service/secrets.key"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+
+	config.SecretsKeyFile = ""
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for secrets_file without secrets_key_file")
+	}
+}
+
+func TestValidateConfig_Vault(t *testing.T) {
+	config := DefaultConfig()
+	config.VaultAddr = "https://vault.example.com:8200"
+	config.VaultRoleID = "boot-service"
+	config.VaultSecretIDFile = "/etc/boot-service/vault-secret-id"
+	config.VaultSecretsPath = "secret/data/boot-service/params"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+
+	config.VaultSecretIDFile = ""
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for vault_role_id without vault_secret_id_file")
+	}
+
+	config.VaultSecretIDFile = "/etc/boot-service/vault-secret-id"
+	config.VaultTokenFile = "/run/vault/token"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for vault_token_file combined with vault_role_id")
+	}
+
+	config = DefaultConfig()
+	config.VaultSecretsPath = "secret/data/boot-service/params"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for vault_secrets_path without vault_addr")
+	}
+
+	config.VaultAddr = "vault.example.com"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for vault_addr without a scheme")
+	}
+}
+
+func TestValidateConfig_BootEvents(t *testing.T) {
+	config := DefaultConfig()
+	config.BootEventsOrigins = "dashboard.example.com, *.ops.example.com"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+
+	config.BootEventsOrigins = "dashboard.example.com,[ops"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for a malformed boot_events_origins pattern")
+	}
+}
+
+func TestValidateConfig_Readiness(t *testing.T) {
+	config := DefaultConfig()
+	config.ReadinessChecks = "storage, templates"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+
+	config.ReadinessChecks = "storage,redis"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for an unknown readiness check")
+	}
+
+	config = DefaultConfig()
+	config.ReadinessTimeoutMS = 0
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for a non-positive readiness_timeout_ms")
+	}
+}
+
+func TestValidateConfig_Pprof(t *testing.T) {
+	config := DefaultConfig()
+	config.EnablePprof = true
+	config.EnableMetrics = true
+	config.JWKSEndpoint = "https://auth.example.com/.well-known/jwks.json"
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+
+	config.EnableMetrics = false
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for enable_pprof without enable_metrics")
+	}
+
+	config.EnableMetrics = true
+	config.JWKSEndpoint = ""
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for enable_pprof without jwks_endpoint")
+	}
+}
+
+func TestValidateConfig_ValidationProfile(t *testing.T) {
+	config := DefaultConfig()
+	config.ValidationProfile = "lenient"
+	config.ValidationXNamePattern = `cn\d+`
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig returned error: %v", err)
+	}
+
+	config.ValidationProfile = "relaxed"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for an unknown validation_profile")
+	}
+
+	config.ValidationProfile = "strict"
+	config.ValidationMACPattern = "[0-9a-f"
+	if err := validateConfig(config); err == nil {
+		t.Fatal("expected error for an invalid validation_mac_pattern")
+	}
+}
+
+func TestIsTenantScoped(t *testing.T) {
+	tests := map[string]bool{
+		"/nodes":                   true,
+		"/nodes/nod-1/bootscript":  true,
+		"/nodes:import":            true,
+		"/dhcp/hosts":              true,
+		"/bootconfigurations/":     true,
+		"/boot/v1/bootparameters":  true,
+		"/bootscript/preview":      true,
+		"/bootscript":              false,
+		"/boot/v1/bootscript":      false,
+		"/health":                  false,
+		"/nodesextra":              false,
+		"/boot/v1/service/version": false,
+	}
+	for path, want := range tests {
+		if got := isTenantScoped(path); got != want {
+			t.Errorf("isTenantScoped(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestNewResourceClient(t *testing.T) {
+	config := DefaultConfig()
+	if c, err := newResourceClient(config); err != nil {
+		t.Fatalf("newResourceClient failed: %v", err)
+	} else if _, ok := c.(*bootclient.InProcessClient); !ok {
+		t.Errorf("got %T, want in-process client without resource_api_url", c)
+	}
+
+	config.ResourceAPIURL = "http://boot.example.com"
+	if c, err := newResourceClient(config); err != nil {
+		t.Fatalf("newResourceClient failed: %v", err)
+	} else if _, ok := c.(*bootclient.Client); !ok {
+		t.Errorf("got %T, want HTTP client with resource_api_url", c)
+	}
+}
+
+func TestNewRedisClient_OnlyWhenSharedStateConfigured(t *testing.T) {
+	config := DefaultConfig()
+	config.RedisURL = "redis://127.0.0.1:1/0"
+
+	if client, err := newRedisClient(context.Background(), config); client != nil || err != nil {
+		t.Fatalf("newRedisClient = %v, %v; want nil, nil without redis features", client, err)
+	}
+
+	config.LeaderElectionEnabled = true
+	if _, err := newRedisClient(context.Background(), config); err == nil {
+		t.Fatal("expected leader election to require a reachable Redis")
+	}
+}
+
+func TestNewScriptCache_Redis(t *testing.T) {
+	server := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config := DefaultConfig()
+	config.CacheBackend = "redis"
+	config.RedisURL = "redis://" + server.Addr() + "/0"
+
+	redisClient, err := newRedisClient(ctx, config)
+	if err != nil {
+		t.Fatalf("newRedisClient returned error: %v", err)
+	}
+	cache := newScriptCache(ctx, config, redisClient)
+	if _, ok := cache.(*sharedstate.RedisScriptCache); !ok {
+		t.Fatalf("newScriptCache returned %T, want *sharedstate.RedisScriptCache", cache)
+	}
+
+	cache.Set("x0c0s0b0n0:default", "script", "x0c0s0b0n0", "compute")
+	if !server.Exists("boot-service:script:x0c0s0b0n0:default") {
+		t.Errorf("expected script stored in Redis, keys: %v", server.Keys())
+	}
+
+	server.Close()
+	if _, err := newRedisClient(ctx, config); err == nil {
+		t.Error("expected error when Redis is unreachable")
+	}
+}
+
+func TestRegisterScriptCacheMetrics(t *testing.T) {
+	cache := bootscript.NewScriptCache(time.Minute)
+	defer cache.Close()
+	cache.Set("key", "script", "node", "config")
+	cache.Get("key")
+	cache.Get("missing")
+
+	registry := prometheus.NewRegistry()
+	if err := registerScriptCacheMetrics(registry, cache); err != nil {
+		t.Fatalf("registerScriptCacheMetrics returned error: %v", err)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather returned error: %v", err)
+	}
+	values := map[string]float64{}
+	for _, family := range families {
+		metric := family.GetMetric()[0]
+		if counter := metric.GetCounter(); counter != nil {
+			values[family.GetName()] = counter.GetValue()
+		} else {
+			values[family.GetName()] = metric.GetGauge().GetValue()
+		}
+	}
+
+	for name, want := range map[string]float64{
+		"main_bootscript_cache_hits_total":      1,
+		"main_bootscript_cache_misses_total":    1,
+		"main_bootscript_cache_evictions_total": 0,
+		"main_bootscript_cache_entries":         1,
+	} {
+		if got, ok := values[name]; !ok || got != want {
+			t.Errorf("%s = %v (present=%v), want %v", name, got, ok, want)
+		}
+	}
+}
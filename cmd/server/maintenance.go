@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openchami/boot-service/pkg/maintenance"
+)
+
+// loadHoldScript reads the iPXE script served to nodes held by maintenance
+// mode; an empty path keeps the built-in one
+func loadHoldScript(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read maintenance hold script: %w", err)
+	}
+	if !strings.HasPrefix(string(data), "#!ipxe") {
+		return "", fmt.Errorf("maintenance hold script %s does not start with #!ipxe", path)
+	}
+	return string(data), nil
+}
+
+// maintenanceClientOptions locate the running server for the maintenance
+// commands
+type maintenanceClientOptions struct {
+	serverURL string
+	token     string
+	timeout   time.Duration
+}
+
+// newMaintenanceCommand creates the maintenance command, which switches the
+// running server's maintenance mode through /admin/maintenance
+func newMaintenanceCommand() *cobra.Command {
+	opts := maintenanceClientOptions{}
+	cmd := &cobra.Command{
+		Use:   "maintenance",
+		Short: "Show or switch maintenance mode on the running server",
+		Long: `Show or switch maintenance mode on the running server. While maintenance mode
+is on, held nodes get a hold script that waits and reboots, or a script that
+boots from local disk, instead of their boot configuration, so nothing is
+reprovisioned during an incident. The setting is stored and survives restarts.`,
+		Example: `  boot-service maintenance status
+  boot-service maintenance enable --reason "storage outage" --groups compute,gpu
+  boot-service maintenance enable --reason "network change" --action local
+  boot-service maintenance disable`,
+	}
+	cmd.PersistentFlags().StringVar(&opts.serverURL, "server", "", "Base URL of the running server (default http://localhost:<port>)")
+	cmd.PersistentFlags().StringVar(&opts.token, "token", "", "Bearer token for /admin/maintenance when tenancy is enabled (default $BOOT_SERVICE_TOKEN)")
+	cmd.PersistentFlags().DurationVar(&opts.timeout, "timeout", 30*time.Second, "Time limit for the request")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:          "status",
+		Short:        "Show whether maintenance mode is on",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive
+			return runMaintenance(cmd.Context(), cmd.OutOrStdout(), opts, http.MethodGet, nil)
+		},
+	})
+
+	var state maintenance.State
+	var groups []string
+	enable := &cobra.Command{
+		Use:          "enable",
+		Short:        "Turn maintenance mode on",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive
+			state.Groups = groups
+			return runMaintenance(cmd.Context(), cmd.OutOrStdout(), opts, http.MethodPut, &state)
+		},
+	}
+	enable.Flags().StringVar(&state.Action, "action", maintenance.ActionHold, "What held nodes boot: hold (wait and reboot) or local (local disk)")
+	enable.Flags().StringSliceVar(&groups, "groups", nil, "Hold only nodes in these groups (default every node)")
+	enable.Flags().StringVar(&state.Reason, "reason", "", "Why maintenance mode is on, shown on held nodes' consoles")
+	enable.Flags().StringVar(&state.EnabledBy, "by", os.Getenv("USER"), "Who is switching, recorded when the server cannot tell from the token")
+	cmd.AddCommand(enable)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:          "disable",
+		Short:        "Turn maintenance mode off",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive
+			return runMaintenance(cmd.Context(), cmd.OutOrStdout(), opts, http.MethodDelete, nil)
+		},
+	})
+	return cmd
+}
+
+// runMaintenance sends one /admin/maintenance request and prints the
+// resulting state
+func runMaintenance(ctx context.Context, out io.Writer, opts maintenanceClientOptions, method string, body *maintenance.State) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts.serverURL == "" {
+		config, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		opts.serverURL = fmt.Sprintf("http://localhost:%d", config.Port)
+	}
+	if opts.token == "" {
+		opts.token = os.Getenv("BOOT_SERVICE_TOKEN")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(opts.serverURL, "/")+maintenance.Path, payload)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if opts.token != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s returned %s: %s", method, maintenance.Path, resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	var state maintenance.State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", maintenance.Path, err)
+	}
+	fmt.Fprintln(out, describeMaintenance(state)) //nolint:errcheck
+	return nil
+}
+
+// describeMaintenance summarizes a maintenance state in one line
+func describeMaintenance(state maintenance.State) string {
+	if !state.Enabled {
+		return "Maintenance mode is off"
+	}
+	held := "all nodes"
+	if len(state.Groups) > 0 {
+		held = "groups " + strings.Join(state.Groups, ", ")
+	}
+	summary := fmt.Sprintf("Maintenance mode is on since %s: %s for %s", state.EnabledAt.Format(time.RFC3339), state.Action, held)
+	if state.EnabledBy != "" {
+		summary += ", by " + state.EnabledBy
+	}
+	if state.Reason != "" {
+		summary += " (" + state.Reason + ")"
+	}
+	return summary
+}
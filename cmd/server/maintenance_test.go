@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/openchami/boot-service/pkg/maintenance"
+)
+
+func TestMaintenanceCommands(t *testing.T) {
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sw := maintenance.NewSwitch(backend, log.New(io.Discard, "", 0))
+	router := chi.NewRouter()
+	maintenance.NewHandler(sw).RegisterRoutes(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx := context.Background()
+	opts := maintenanceClientOptions{serverURL: server.URL, token: "admin-token", timeout: 5 * time.Second}
+	var out bytes.Buffer
+	err = runMaintenance(ctx, &out, opts, http.MethodPut, &maintenance.State{Groups: []string{"compute"}, Reason: "storage outage", EnabledBy: "ops"})
+	if err != nil {
+		t.Fatalf("enable failed: %v", err)
+	}
+	if got := out.String(); !strings.Contains(got, "hold for groups compute, by ops (storage outage)") {
+		t.Errorf("unexpected enable output: %q", got)
+	}
+	if !sw.State().Enabled {
+		t.Error("expected maintenance mode on")
+	}
+
+	out.Reset()
+	if err := runMaintenance(ctx, &out, opts, http.MethodDelete, nil); err != nil {
+		t.Fatalf("disable failed: %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "Maintenance mode is off" {
+		t.Errorf("disable output = %q", got)
+	}
+
+	err = runMaintenance(ctx, &out, opts, http.MethodPut, &maintenance.State{Action: "wipe"})
+	if err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("expected the server's 400 for an invalid action, got %v", err)
+	}
+}
+
+func TestLoadHoldScript(t *testing.T) {
+	if script, err := loadHoldScript(""); err != nil || script != "" {
+		t.Errorf("loadHoldScript(\"\") = %q, %v; want the built-in script", script, err)
+	}
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "hold.ipxe")
+	if err := os.WriteFile(valid, []byte("#!ipxe\nsleep 300\nreboot\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if script, err := loadHoldScript(valid); err != nil || !strings.Contains(script, "sleep 300") {
+		t.Errorf("loadHoldScript = %q, %v", script, err)
+	}
+
+	invalid := filepath.Join(dir, "hold.sh")
+	if err := os.WriteFile(invalid, []byte("sleep 300\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadHoldScript(invalid); err == nil {
+		t.Error("expected an error for a script without #!ipxe")
+	}
+	if _, err := loadHoldScript(filepath.Join(dir, "missing.ipxe")); err == nil {
+		t.Error("expected an error for a missing script")
+	}
+}
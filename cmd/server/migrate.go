@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/migrate"
+	"github.com/openchami/boot-service/pkg/snapshot"
+)
+
+// migrateOptions configures a migration from BSS
+type migrateOptions struct {
+	bssURL      string
+	postgresDSN string
+	token       string
+	dataDir     string
+	reportFile  string
+	dryRun      bool
+}
+
+// migrateReport is the report written with --report
+type migrateReport struct {
+	Source  string          `json:"source"`
+	DryRun  bool            `json:"dryRun"`
+	Convert *migrate.Report `json:"convert"`
+	Import  snapshot.Result `json:"import"`
+}
+
+// newMigrateCommand creates the migrate command group
+func newMigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate state from other boot services",
+	}
+	cmd.AddCommand(newMigrateFromBSSCommand())
+	cmd.AddCommand(newMigrateReplayCommand())
+	return cmd
+}
+
+// newMigrateFromBSSCommand creates the migrate from-bss command
+func newMigrateFromBSSCommand() *cobra.Command {
+	opts := migrateOptions{}
+	cmd := &cobra.Command{
+		Use:   "from-bss",
+		Short: "Import boot parameters and hosts from BSS",
+		Long: `Read the boot parameters and hosts of an existing Boot Script Service (BSS),
+either through its REST API (--url) or directly from its PostgreSQL database
+(--postgres-dsn), convert them through the legacy API converter, and write the
+resulting nodes and boot configurations to the storage backend.
+
+Hosts entries that are XNames become host targets, "Default" becomes a
+catch-all configuration, and other names such as roles become group targets;
+nodes join the groups named after their role. Re-running the migration
+updates the resources it created before. Entries that cannot be converted are
+skipped and listed in the report.`,
+		Example: `  boot-service migrate from-bss --url http://bss:27778 --dry-run
+  boot-service migrate from-bss --postgres-dsn postgres://bss@db:5432/bssdb --report migration.json`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive
+			if opts.token == "" {
+				opts.token = os.Getenv("BSS_TOKEN")
+			}
+			// UIDs of created resources use the same prefixes as the API
+			if err := registerResourcePrefixes(); err != nil {
+				return fmt.Errorf("failed to register resource prefixes: %w", err)
+			}
+			return runMigrateFromBSS(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.bssURL, "url", "", "Base URL of the BSS REST API, e.g. http://bss:27778")
+	cmd.Flags().StringVar(&opts.postgresDSN, "postgres-dsn", "", "PostgreSQL DSN of a BSS database, read instead of the REST API")
+	cmd.Flags().StringVar(&opts.token, "token", "", "Bearer token for the BSS REST API (default $BSS_TOKEN)")
+	cmd.Flags().StringVar(&opts.dataDir, "data-dir", "", "Directory for file storage (default data_dir from the configuration file, or ./data)")
+	cmd.Flags().StringVar(&opts.reportFile, "report", "", "Write a JSON migration report to this file")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Convert and report without writing to storage")
+	return cmd
+}
+
+func runMigrateFromBSS(ctx context.Context, out io.Writer, opts migrateOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if (opts.bssURL == "") == (opts.postgresDSN == "") {
+		return errors.New("exactly one of --url or --postgres-dsn is required")
+	}
+
+	var src migrate.Source
+	sourceName := opts.bssURL
+	if opts.bssURL != "" {
+		httpSource, err := migrate.NewHTTPSource(opts.bssURL, opts.token)
+		if err != nil {
+			return err
+		}
+		src = httpSource
+	} else {
+		pgSource, err := migrate.NewPostgresSource(ctx, opts.postgresDSN)
+		if err != nil {
+			return err
+		}
+		defer pgSource.Close() //nolint:errcheck
+		src = pgSource
+		sourceName = "postgres"
+	}
+
+	params, hosts, err := migrate.Read(ctx, src)
+	if err != nil {
+		return fmt.Errorf("failed to read from BSS: %w", err)
+	}
+
+	if err := storage.InitFileBackend(stateDataDir(opts.dataDir)); err != nil {
+		return fmt.Errorf("failed to initialize storage: %v", err)
+	}
+	existing, err := snapshot.Export(ctx, storage.Backend)
+	if err != nil {
+		return err
+	}
+
+	converted, convertReport, err := migrate.Convert(params, hosts, existing)
+	if err != nil {
+		return err
+	}
+	result, err := importMarked(ctx, "migration", converted, snapshot.ImportOptions{DryRun: opts.dryRun})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Read %d boot parameters entries and %d hosts from %s\n", //nolint:errcheck
+		convertReport.BootParameters, convertReport.Hosts, sourceName)
+	for _, resourceType := range []string{"Node", "BootConfiguration"} {
+		counts := result[resourceType]
+		fmt.Fprintf(out, "%s: %d created, %d updated\n", resourceType, counts.Created, counts.Updated) //nolint:errcheck
+	}
+	for _, warning := range convertReport.Warnings {
+		fmt.Fprintf(out, "warning: %s\n", warning) //nolint:errcheck
+	}
+	if opts.dryRun {
+		fmt.Fprintln(out, "Dry run: no changes written") //nolint:errcheck
+	}
+
+	if opts.reportFile != "" {
+		data, err := json.MarshalIndent(migrateReport{
+			Source:  sourceName,
+			DryRun:  opts.dryRun,
+			Convert: convertReport,
+			Import:  result,
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(opts.reportFile, append(data, '\n'), 0o600); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+	return nil
+}
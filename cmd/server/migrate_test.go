@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openchami/boot-service/internal/storage"
+)
+
+func TestRunMigrateFromBSS(t *testing.T) {
+	bss := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/boot/v1/bootparameters":
+			_, _ = w.Write([]byte(`[
+				{"hosts":["Compute"],"kernel":"http://files/vmlinuz","params":"console=ttyS0"},
+				{"hosts":["x0c0s0b0n0"],"params":"quiet"}
+			]`))
+		case "/boot/v1/hosts":
+			_, _ = w.Write([]byte(`[{"ID":"x0c0s0b0n0","Type":"Node","NID":1,"Role":"Compute","MAC":["aa:bb:cc:dd:ee:01"]}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer bss.Close()
+
+	registerResourcePrefixesOnce.Do(func() {
+		if err := registerResourcePrefixes(); err != nil {
+			t.Fatalf("failed to register resource prefixes: %v", err)
+		}
+	})
+
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	opts := migrateOptions{bssURL: bss.URL, dataDir: dataDir, dryRun: true}
+
+	var out bytes.Buffer
+	if err := runMigrateFromBSS(ctx, &out, opts); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	for _, want := range []string{"Node: 1 created, 0 updated", "BootConfiguration: 1 created, 0 updated", "skipped: kernel, kernelArtifact, or imageRef field is required", "Dry run"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("dry run output missing %q:\n%s", want, out.String())
+		}
+	}
+	if nodes, _ := storage.LoadAllNodes(ctx); len(nodes) != 0 {
+		t.Fatalf("dry run wrote %d nodes", len(nodes))
+	}
+
+	opts.dryRun = false
+	opts.reportFile = filepath.Join(t.TempDir(), "report.json")
+	for run := 1; run <= 2; run++ {
+		out.Reset()
+		if err := runMigrateFromBSS(ctx, &out, opts); err != nil {
+			t.Fatalf("migration run %d failed: %v", run, err)
+		}
+	}
+	// The second run updates the resources created by the first
+	if !strings.Contains(out.String(), "Node: 0 created, 1 updated") {
+		t.Errorf("expected rerun to update existing resources:\n%s", out.String())
+	}
+
+	nodes, err := storage.LoadAllNodes(ctx)
+	if err != nil || len(nodes) != 1 {
+		t.Fatalf("got %d nodes (err %v), want 1", len(nodes), err)
+	}
+	if groups := nodes[0].Spec.Groups; len(groups) != 1 || groups[0] != "Compute" {
+		t.Errorf("expected node to join its role group, got %v", groups)
+	}
+
+	data, err := os.ReadFile(opts.reportFile)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	var report migrateReport
+	if err := json.Unmarshal(data, &report); err != nil || report.Convert.BootParameters != 2 || len(report.Convert.Warnings) != 1 {
+		t.Errorf("unexpected report (err %v):\n%s", err, data)
+	}
+}
+
+func TestRunMigrateFromBSS_RequiresOneSource(t *testing.T) {
+	for _, opts := range []migrateOptions{{}, {bssURL: "http://bss", postgresDSN: "postgres://db"}} {
+		if err := runMigrateFromBSS(context.Background(), &bytes.Buffer{}, opts); err == nil {
+			t.Errorf("expected error for options %+v", opts)
+		}
+	}
+}
@@ -25,23 +25,396 @@
 //	}
 package main
 
-import "github.com/getkin/kin-openapi/openapi3"
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
 
 // registerCustomOpenAPIPaths is called by GenerateOpenAPISpec after all
 // Fabrica-generated resource paths have been registered.
 // Add your custom / non-generated route definitions here.
 func registerCustomOpenAPIPaths(spec *openapi3.T) {
-	// Add custom route definitions here.
-	// Example (uncomment and extend as needed):
-	//
-	// op := openapi3.NewOperation()
-	// op.OperationID = "myCustomEndpoint"
-	// op.Summary   = "My custom endpoint"
-	// op.Tags      = []string{"Custom"}
-	// op.Responses = openapi3.NewResponses()
-	// op.Responses.Set("200", &openapi3.ResponseRef{
-	//     Value: openapi3.NewResponse().WithDescription("OK"),
-	// })
-	// spec.Paths.Set("/my-endpoint", &openapi3.PathItem{Get: op})
-	_ = spec
+	// Artifact registry
+	spec.Paths.Set("/bootartifacts", &openapi3.PathItem{
+		Get: newCustomOperation("listBootArtifacts", "List registered boot artifacts", "Artifacts",
+			map[string]string{"200": "Registered artifacts"}),
+		Post: newCustomOperation("registerBootArtifact", "Register a boot artifact after verifying its checksum", "Artifacts",
+			map[string]string{"201": "Artifact registered", "400": "Invalid artifact", "422": "Artifact unreachable or checksum mismatch"}),
+	})
+	spec.Paths.Set("/bootartifacts/{name}", &openapi3.PathItem{
+		Get: newCustomOperation("getBootArtifact", "Get a registered boot artifact", "Artifacts",
+			map[string]string{"200": "Artifact", "404": "Artifact not found"}),
+		Put: newCustomOperation("updateBootArtifact", "Re-register a boot artifact after verifying its checksum", "Artifacts",
+			map[string]string{"200": "Artifact updated", "400": "Invalid artifact", "422": "Artifact unreachable or checksum mismatch"}),
+		Delete: newCustomOperation("deleteBootArtifact", "Delete a boot artifact", "Artifacts",
+			map[string]string{"204": "Artifact deleted", "404": "Artifact not found"}),
+	})
+	spec.Paths.Set("/bootartifacts/{name}/verify", &openapi3.PathItem{
+		Post: newCustomOperation("verifyBootArtifact", "Re-download a boot artifact and verify its checksum", "Artifacts",
+			map[string]string{"200": "Artifact verified", "404": "Artifact not found", "422": "Artifact unreachable or checksum mismatch"}),
+	})
+
+	// JSON Schemas of the resource specs
+	spec.Paths.Set("/schemas", &openapi3.PathItem{
+		Get: newCustomOperation("listSchemas", "List the JSON Schemas of the resource specs", "Service",
+			map[string]string{"200": "Schema names and links"}),
+	})
+	spec.Paths.Set("/schemas/{name}", &openapi3.PathItem{
+		Get: newCustomOperation("getSchema", "Get the JSON Schema of a resource spec (NodeSpec, BootConfigurationSpec, or BMCSpec)", "Service",
+			map[string]string{"200": "JSON Schema document", "404": "Schema not found"}),
+	})
+
+	// Kernel parameter profiles
+	spec.Paths.Set("/parameterprofiles", &openapi3.PathItem{
+		Get: newCustomOperation("listParameterProfiles", "List kernel parameter profiles", "Boot",
+			map[string]string{"200": "Parameter profiles"}),
+		Post: newCustomOperation("createParameterProfile", "Store a kernel parameter profile", "Boot",
+			map[string]string{"201": "Profile stored", "400": "Invalid profile, or a missing or circular include"}),
+	})
+	spec.Paths.Set("/parameterprofiles/{name}", &openapi3.PathItem{
+		Get: newCustomOperation("getParameterProfile", "Get a kernel parameter profile", "Boot",
+			map[string]string{"200": "Parameter profile", "404": "Profile not found"}),
+		Put: newCustomOperation("updateParameterProfile", "Replace a kernel parameter profile", "Boot",
+			map[string]string{"200": "Profile stored", "400": "Invalid profile, or a missing or circular include"}),
+		Delete: newCustomOperation("deleteParameterProfile", "Delete a kernel parameter profile", "Boot",
+			map[string]string{"204": "Profile deleted", "404": "Profile not found", "409": "Profile included by another profile"}),
+	})
+
+	// Cloud-init user-data of roles, groups, and nodes
+	spec.Paths.Set("/userdata", &openapi3.PathItem{
+		Get: newCustomOperation("listUserData", "List cloud-init user-data in merge order", "Boot",
+			map[string]string{"200": "User-data"}),
+	})
+	spec.Paths.Set("/userdata/default", &openapi3.PathItem{
+		Get: newCustomOperation("getDefaultUserData", "Get the user-data of every node", "Boot",
+			map[string]string{"200": "User-data", "404": "User-data not found"}),
+		Put: newCustomOperation("putDefaultUserData", "Store the user-data of every node", "Boot",
+			map[string]string{"200": "User-data stored", "400": "Invalid user-data"}),
+		Delete: newCustomOperation("deleteDefaultUserData", "Delete the user-data of every node", "Boot",
+			map[string]string{"204": "User-data deleted", "404": "User-data not found"}),
+	})
+	spec.Paths.Set("/userdata/{scope}/{target}", &openapi3.PathItem{
+		Get: newCustomOperation("getUserData", "Get the user-data of a role, group, or node", "Boot",
+			map[string]string{"200": "User-data", "404": "User-data not found"}),
+		Put: newCustomOperation("putUserData", "Store the user-data of a role, group, or node", "Boot",
+			map[string]string{"200": "User-data stored", "400": "Invalid user-data or scope"}),
+		Delete: newCustomOperation("deleteUserData", "Delete the user-data of a role, group, or node", "Boot",
+			map[string]string{"204": "User-data deleted", "404": "User-data not found"}),
+	})
+
+	// Node imports from CSV and SLS inventories
+	spec.Paths.Set("/nodes:import", &openapi3.PathItem{
+		Post: newCustomOperation("importNodes", "Create and update nodes from a CSV or SLS inventory, or report with ?dryRun=true", "Node",
+			map[string]string{"200": "Import report", "400": "Unreadable inventory or invalid parameters", "422": "Invalid entries with ?strict=true; nothing written"}),
+	})
+
+	// Static DHCP host reservations
+	spec.Paths.Set("/dhcp/hosts", &openapi3.PathItem{
+		Get: newCustomOperation("getDHCPHosts", "Render DHCP host reservations for dnsmasq or CoreDHCP from node data", "Node",
+			map[string]string{"200": "Host reservations as plain text", "400": "Invalid format or subnet"}),
+	})
+	spec.Paths.Set("/dhcp/bootfiles", &openapi3.PathItem{
+		Get: newCustomOperation("getDHCPBootFiles", "Render dnsmasq options naming the TFTP boot file of each client architecture (tftp_address)", "Boot",
+			map[string]string{"200": "dnsmasq options as plain text", "400": "Invalid server address"}),
+	})
+
+	// Local artifact serving (artifact_cache_enabled)
+	spec.Paths.Set("/artifacts/{name}", &openapi3.PathItem{
+		Get: newCustomOperation("downloadArtifact", "Download a cached boot artifact", "Artifacts",
+			map[string]string{"200": "Artifact content", "404": "Artifact not found", "502": "Artifact could not be fetched or failed verification"}),
+	})
+
+	// Boot script dry-run previews
+	spec.Paths.Set("/bootscript/preview", &openapi3.PathItem{
+		Get: newCustomOperation("previewBootScript", "Explain which configuration a node matches and render its boot script without caching", "Boot",
+			map[string]string{"200": "Boot script preview", "400": "Missing node identifier"}),
+	})
+	spec.Paths.Set("/nodes/{uid}/bootscript", &openapi3.PathItem{
+		Get: newCustomOperation("getNodeBootScript", "Generate the boot script for a node, or a JSON preview with ?dry-run=true", "Boot",
+			map[string]string{"200": "iPXE script or boot script preview", "503": "Node cordoned as unavailable"}),
+	})
+
+	// Boot script signing (script_signing_cert)
+	spec.Paths.Set("/bootscript.sig", &openapi3.PathItem{
+		Get: newCustomOperation("getBootScriptSignature", "Get the detached CMS signature of a node's boot script", "Boot",
+			map[string]string{"200": "DER CMS signature", "400": "Missing node identifier"}),
+	})
+	spec.Paths.Set("/nodes/{uid}/bootscript.sig", &openapi3.PathItem{
+		Get: newCustomOperation("getNodeBootScriptSignature", "Get the detached CMS signature of a node's boot script", "Boot",
+			map[string]string{"200": "DER CMS signature"}),
+	})
+	spec.Paths.Set("/.well-known/boot-service/script-signing.pem", &openapi3.PathItem{
+		Get: newCustomOperation("getScriptSigningCertificate", "Get the boot script signing certificate chain", "Boot",
+			map[string]string{"200": "PEM certificates"}),
+	})
+
+	// Match explanation
+	spec.Paths.Set("/nodes/{uid}/matching-configs", &openapi3.PathItem{
+		Get: newCustomOperation("getNodeMatchingConfigurations", "List the boot configurations that match a node, with score breakdowns", "Boot",
+			map[string]string{"200": "Matching configurations", "404": "Node not found"}),
+	})
+	spec.Paths.Set("/nodes/{uid}/access-stats", &openapi3.PathItem{
+		Get: newCustomOperation("getNodeAccessStats", "Get how often a node fetched its boot script, and its last client address and user agent", "Boot",
+			map[string]string{"200": "Node access statistics", "404": "Node not found"}),
+	})
+	spec.Paths.Set("/nodes/{uid}/timeline", &openapi3.PathItem{
+		Get: newCustomOperation("getNodeTimeline", "Get a node's boot timeline: scripts served, failures, and phone-homes, optionally from ?since= to ?until=", "Boot",
+			map[string]string{"200": "Node boot timeline", "400": "Invalid time range", "404": "Node not found"}),
+	})
+	spec.Paths.Set("/nodes/{uid}/user-data", &openapi3.PathItem{
+		Get: newCustomOperation("getNodeUserData", "Get a node's merged cloud-init user-data, or with ?explain=true the user-data merged", "Boot",
+			map[string]string{"200": "Merged user-data", "404": "Node not found or no user-data applies"}),
+	})
+	spec.Paths.Set("/nodes/{uid}/cordon", &openapi3.PathItem{
+		Get: newCustomOperation("getNodeCordon", "Get a node's cordon", "Boot",
+			map[string]string{"200": "Node cordon", "404": "Node not found or not cordoned"}),
+		Post: newCustomOperation("cordonNode", "Cordon a node, serving it a hold script or 503 until it is uncordoned", "Boot",
+			map[string]string{"200": "Node cordoned", "400": "Invalid cordon", "404": "Node not found"}),
+	})
+	spec.Paths.Set("/nodes/{uid}/uncordon", &openapi3.PathItem{
+		Post: newCustomOperation("uncordonNode", "Let a cordoned node boot its configuration again", "Boot",
+			map[string]string{"204": "Node uncordoned", "404": "Node not found"}),
+	})
+	spec.Paths.Set("/nodes/{uid}/boot-once", &openapi3.PathItem{
+		Get: newCustomOperation("getNodeBootOnce", "Get a node's pending or last served one-time boot override", "Boot",
+			map[string]string{"200": "Boot-once override", "404": "Node not found or no override"}),
+		Post: newCustomOperation("setNodeBootOnce", "Boot a node another configuration on its next boot script request only", "Boot",
+			map[string]string{"200": "Override set", "400": "Invalid override", "404": "Node not found"}),
+		Delete: newCustomOperation("cancelNodeBootOnce", "Cancel a node's pending one-time boot override", "Boot",
+			map[string]string{"204": "Override canceled", "404": "Node not found or no pending override"}),
+	})
+	spec.Paths.Set("/bootconfigurations/{uid}/matches", &openapi3.PathItem{
+		Get: newCustomOperation("getBootConfigurationMatches", "List the nodes a boot configuration matches, with score breakdowns", "Boot",
+			map[string]string{"200": "Matching nodes", "404": "Boot configuration not found"}),
+	})
+	spec.Paths.Set("/bootconfigurations/{uid}/restore", &openapi3.PathItem{
+		Post: newCustomOperation("restoreBootConfiguration", "Restore a deleted boot configuration within soft_delete_retention_days", "Boot",
+			map[string]string{"200": "Restored boot configuration", "404": "No deleted boot configuration with this UID", "409": "A resource with this UID exists"}),
+	})
+	spec.Paths.Set("/bootconfigurations/active", &openapi3.PathItem{
+		Get: newCustomOperation("getActiveBootConfigurations", "List which boot configurations their schedules make active now or at ?at=", "Boot",
+			map[string]string{"200": "Configuration schedule report", "400": "Invalid evaluation time"}),
+	})
+	spec.Paths.Set("/bootconfigurations/diff", &openapi3.PathItem{
+		Get: newCustomOperation("diffBootConfigurations", "Compare boot configurations ?a= and ?b= and list the nodes that would change assignment if b replaced a", "Boot",
+			map[string]string{"200": "Configuration diff", "400": "Missing a or b", "404": "Boot configuration not found"}),
+	})
+	spec.Paths.Set("/bootconfigurations/simulate", &openapi3.PathItem{
+		Post: newCustomOperation("simulateBootConfiguration", "Report the nodes, sampled boot scripts, and conflicts a proposed boot configuration would change, without storing it", "Boot",
+			map[string]string{"200": "Simulated impact", "400": "Invalid proposal or sample"}),
+	})
+
+	// Administration
+	spec.Paths.Set("/admin/leader", &openapi3.PathItem{
+		Get: newCustomOperation("getLeaderStatus", "Report which replica runs background sync", "Admin",
+			map[string]string{"200": "Leader election status", "503": "Lock service unavailable"}),
+	})
+	spec.Paths.Set("/audit", &openapi3.PathItem{
+		Get: newCustomOperation("listAuditRecords", "List recorded resource changes (audit_enabled)", "Admin",
+			map[string]string{"200": "Audit records, oldest first", "400": "Invalid query"}),
+	})
+	spec.Paths.Set(diagnosticsPath, &openapi3.PathItem{
+		Get: newCustomOperation("getDiagnostics", "Report runtime, cache, sync, and redacted configuration state", "Admin",
+			map[string]string{"200": "Diagnostics report", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/admin/backups", &openapi3.PathItem{
+		Get: newCustomOperation("listBackups", "List stored backups, newest first (backup_dir or backup_s3_url)", "Admin",
+			map[string]string{"200": "Backups", "403": "Requires an administrator token"}),
+		Post: newCustomOperation("takeBackup", "Back the stored resources up now", "Admin",
+			map[string]string{"201": "Backup taken", "403": "Requires an administrator token", "500": "Backup failed"}),
+	})
+	spec.Paths.Set("/admin/sync/status", &openapi3.PathItem{
+		Get: newCustomOperation("getHSMSyncStatus", "Report the last HSM sync and whether scheduled syncs are paused (hsm_url)", "Admin",
+			map[string]string{"200": "HSM sync status", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/admin/sync/conflicts", &openapi3.PathItem{
+		Get: newCustomOperation("getHSMSyncConflicts", "List node fields whose local edits the last HSM sync kept (hsm_sync_conflict_policy)", "Admin",
+			map[string]string{"200": "Sync conflicts", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/admin/sync/run", &openapi3.PathItem{
+		Post: newCustomOperation("runHSMSync", "Sync nodes from HSM now", "Admin",
+			map[string]string{"200": "Sync outcome", "403": "Requires an administrator token", "502": "Sync failed"}),
+	})
+	spec.Paths.Set("/admin/sync/pause", &openapi3.PathItem{
+		Post: newCustomOperation("pauseHSMSync", "Pause scheduled HSM syncs until resumed", "Admin",
+			map[string]string{"200": "Sync pause", "400": "Invalid pause", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/admin/sync/resume", &openapi3.PathItem{
+		Post: newCustomOperation("resumeHSMSync", "Let scheduled HSM syncs run again", "Admin",
+			map[string]string{"204": "Syncs resumed", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/admin/api-keys", &openapi3.PathItem{
+		Get: newCustomOperation("listAPIKeys", "List API keys without their secrets (api_keys_enabled)", "Admin",
+			map[string]string{"200": "API keys", "401": "Missing or invalid token", "403": "Requires api_key_admin_scope"}),
+		Post: newCustomOperation("createAPIKey", "Create an API key, returned once", "Admin",
+			map[string]string{"201": "API key created", "400": "Invalid API key request", "401": "Missing or invalid token", "403": "Requires api_key_admin_scope"}),
+	})
+	spec.Paths.Set("/admin/api-keys/{id}", &openapi3.PathItem{
+		Delete: newCustomOperation("revokeAPIKey", "Revoke an API key", "Admin",
+			map[string]string{"204": "API key revoked", "401": "Missing or invalid token", "403": "Requires api_key_admin_scope", "404": "API key not found"}),
+	})
+	spec.Paths.Set("/admin/auth/report", &openapi3.PathItem{
+		Get: newCustomOperation("getAuthReport", "Report the legacy API requests non-enforcing authorization served that enforcing would reject (legacy_auth_non_enforcing)", "Admin",
+			map[string]string{"200": "Requests checked and bypassed by reason", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/admin/group-sync/status", &openapi3.PathItem{
+		Get: newCustomOperation("getGroupSyncStatus", "Report the last inventory group sync (group_sync_enabled)", "Admin",
+			map[string]string{"200": "Group sync status", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/admin/group-sync/run", &openapi3.PathItem{
+		Post: newCustomOperation("runGroupSync", "Sync node group memberships from the inventory now", "Admin",
+			map[string]string{"200": "Sync outcome", "403": "Requires an administrator token", "502": "Sync failed"}),
+	})
+	spec.Paths.Set("/admin/bmc-discovery/status", &openapi3.PathItem{
+		Get: newCustomOperation("getBMCDiscoveryStatus", "Report the progress of the running or last BMC discovery scan (bmc_discovery_enabled)", "Admin",
+			map[string]string{"200": "Scan progress", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/admin/bmc-discovery/scan", &openapi3.PathItem{
+		Post: newCustomOperation("scanForBMCs", "Scan the configured ranges, or the ranges given, for Redfish services now", "Admin",
+			map[string]string{"202": "Scan started", "400": "Invalid or missing ranges", "403": "Requires an administrator token", "409": "A scan is running"}),
+	})
+	spec.Paths.Set("/admin/bmc-telemetry/status", &openapi3.PathItem{
+		Get: newCustomOperation("getBMCTelemetryStatus", "Report the last BMC telemetry poll and the BMCs whose hardware faults refuse boot scripts (bmc_telemetry_enabled)", "Admin",
+			map[string]string{"200": "Last poll and faults", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/admin/bmc-telemetry/poll", &openapi3.PathItem{
+		Post: newCustomOperation("pollBMCTelemetry", "Poll every BMC for power state, firmware, and health now", "Admin",
+			map[string]string{"200": "Poll outcome", "403": "Requires an administrator token", "502": "Poll failed"}),
+	})
+	spec.Paths.Set("/workflows", &openapi3.PathItem{
+		Get: newCustomOperation("listWorkflows", "List the workflows of this replica, newest first (reboot_workflows_enabled)", "Admin",
+			map[string]string{"200": "Workflows without their nodes", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/workflows/reboot", &openapi3.PathItem{
+		Post: newCustomOperation("startRebootWorkflow", "Reboot nodes into a boot configuration once, power cycling them through their BMCs in batches or a rolling window", "Admin",
+			map[string]string{"202": "Workflow started", "400": "Invalid request", "403": "Requires an administrator token", "409": "A node is rebooted by a running workflow"}),
+	})
+	spec.Paths.Set("/workflows/{id}", &openapi3.PathItem{
+		Get: newCustomOperation("getWorkflow", "Report the progress of a workflow and each of its nodes", "Admin",
+			map[string]string{"200": "Workflow", "403": "Requires an administrator token", "404": "Workflow not found"}),
+		Delete: newCustomOperation("cancelWorkflow", "Cancel a workflow, rolling back the boot-once overrides of nodes it has not finished", "Admin",
+			map[string]string{"200": "Canceled workflow", "403": "Requires an administrator token", "404": "Workflow not found"}),
+	})
+	spec.Paths.Set("/workflows/{id}/pause", &openapi3.PathItem{
+		Post: newCustomOperation("pauseWorkflow", "Stop a workflow from starting nodes until it is resumed; nodes rebooting carry on", "Admin",
+			map[string]string{"200": "Paused workflow", "403": "Requires an administrator token", "404": "Workflow not found", "409": "Workflow finished"}),
+	})
+	spec.Paths.Set("/workflows/{id}/resume", &openapi3.PathItem{
+		Post: newCustomOperation("resumeWorkflow", "Let a paused workflow start nodes again", "Admin",
+			map[string]string{"200": "Resumed workflow", "403": "Requires an administrator token", "404": "Workflow not found", "409": "Workflow finished"}),
+	})
+	spec.Paths.Set("/admin/gitops", &openapi3.PathItem{
+		Get: newCustomOperation("getGitOpsStatus", "Report the last sync from the GitOps repository and the drift it found (gitops_url)", "Admin",
+			map[string]string{"200": "GitOps status", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/admin/gitops/sync", &openapi3.PathItem{
+		Post: newCustomOperation("syncGitOps", "Sync nodes and boot configurations from the GitOps repository now", "Admin",
+			map[string]string{"200": "GitOps status", "403": "Requires an administrator token", "500": "Sync failed"}),
+	})
+	spec.Paths.Set("/admin/kubernetes", &openapi3.PathItem{
+		Get: newCustomOperation("getKubernetesStatus", "Report the last reconcile of Kubernetes custom resources (kubernetes_enabled)", "Admin",
+			map[string]string{"200": "Operator status", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/admin/kubernetes/reconcile", &openapi3.PathItem{
+		Post: newCustomOperation("reconcileKubernetes", "Reconcile nodes and boot configurations from Kubernetes custom resources now", "Admin",
+			map[string]string{"200": "Operator status", "403": "Requires an administrator token", "500": "Reconcile failed"}),
+	})
+	spec.Paths.Set("/admin/maintenance", &openapi3.PathItem{
+		Get: newCustomOperation("getMaintenanceMode", "Report whether maintenance mode holds nodes", "Admin",
+			map[string]string{"200": "Maintenance state", "403": "Requires an administrator token"}),
+		Put: newCustomOperation("enableMaintenanceMode", "Hold nodes with a hold or local-disk boot script", "Admin",
+			map[string]string{"200": "Maintenance state", "400": "Invalid maintenance state", "403": "Requires an administrator token"}),
+		Delete: newCustomOperation("disableMaintenanceMode", "Turn maintenance mode off", "Admin",
+			map[string]string{"200": "Maintenance state", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/admin/fallback-policy", &openapi3.PathItem{
+		Get: newCustomOperation("getFallbackPolicy", "Report what unknown nodes and nodes without a matching configuration boot", "Admin",
+			map[string]string{"200": "Fallback policy", "403": "Requires an administrator token"}),
+		Put: newCustomOperation("putFallbackPolicy", "Replace the fallback policy", "Admin",
+			map[string]string{"200": "Fallback policy", "400": "Invalid fallback policy", "403": "Requires an administrator token"}),
+		Delete: newCustomOperation("deleteFallbackPolicy", "Restore the built-in fallback behavior", "Admin",
+			map[string]string{"200": "Fallback policy", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/admin/boot-loops", &openapi3.PathItem{
+		Get: newCustomOperation("listBootLoops", "List nodes in a boot loop (with boot_loop_threshold)", "Admin",
+			map[string]string{"200": "Boot loops", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/admin/boot-loops/{node}", &openapi3.PathItem{
+		Delete: newCustomOperation("clearBootLoop", "End a node's boot loop, returning it to its own configuration", "Admin",
+			map[string]string{"204": "Loop cleared", "403": "Requires an administrator token", "404": "Node not in a boot loop"}),
+	})
+	spec.Paths.Set("/admin/cordons", &openapi3.PathItem{
+		Get: newCustomOperation("listCordons", "List cordoned nodes", "Admin",
+			map[string]string{"200": "Node cordons", "403": "Requires an administrator token"}),
+	})
+	spec.Paths.Set("/livez", &openapi3.PathItem{
+		Get: newCustomOperation("getLiveness", "Liveness probe; checks no dependencies", "Service",
+			map[string]string{"200": "The service is serving requests"}),
+	})
+	spec.Paths.Set("/readyz", &openapi3.PathItem{
+		Get: newCustomOperation("getReadiness", "Readiness probe with per-check results", "Service",
+			map[string]string{"200": "Ready; status is degraded if a non-critical check failed", "503": "A critical check failed"}),
+	})
+	spec.Paths.Set(bootEventsPath, &openapi3.PathItem{
+		Get: newCustomOperation("watchBootEvents", "Stream live boot activity over a WebSocket", "Admin",
+			map[string]string{"101": "Switching to the WebSocket protocol", "403": "Origin not allowed"}),
+	})
+	spec.Paths.Set("/phone-home/{id}", &openapi3.PathItem{
+		Post: newCustomOperation("postPhoneHome", "Report that a node finished booting", "Boot",
+			map[string]string{"204": "Report recorded", "400": "Invalid report"}),
+	})
+
+	// List pagination (paginateLists) on the generated collection routes
+	for collection := range paginatedCollections {
+		if item := spec.Paths.Value(collection); item != nil && item.Get != nil {
+			item.Get.AddParameter(openapi3.NewQueryParameter("limit").
+				WithDescription(fmt.Sprintf("Return at most this many resources, ordered by UID (1-%d)", maxPageLimit)).
+				WithSchema(openapi3.NewIntegerSchema().WithMin(1).WithMax(maxPageLimit)))
+			item.Get.AddParameter(openapi3.NewQueryParameter("after").
+				WithDescription("Return resources whose UID sorts after this one").
+				WithSchema(openapi3.NewStringSchema()))
+		}
+	}
+
+	// Watch streams (watchLists) on the generated collection routes
+	for collection := range watchedCollections {
+		if item := spec.Paths.Value(collection); item != nil && item.Get != nil {
+			item.Get.AddParameter(openapi3.NewQueryParameter("watch").
+				WithDescription("Stream the list and then every change as newline-delimited JSON, or server-sent events with Accept: text/event-stream").
+				WithSchema(openapi3.NewBoolSchema()))
+		}
+	}
+
+	// Deleted resources (listDeleted) on the generated collection routes
+	if item := spec.Paths.Value("/bootconfigurations"); item != nil && item.Get != nil {
+		item.Get.AddParameter(openapi3.NewQueryParameter("deleted").
+			WithDescription("List deleted boot configurations that can still be restored, newest first (soft_delete_retention_days)").
+			WithSchema(openapi3.NewBoolSchema()))
+	}
+
+	// Telemetry filters (filterBMCs) on the generated BMC list
+	if item := spec.Paths.Value("/bmcs"); item != nil && item.Get != nil {
+		item.Get.AddParameter(openapi3.NewQueryParameter("health").
+			WithDescription("Return BMCs whose status.health is one of these comma-separated Redfish health values, such as Critical").
+			WithSchema(openapi3.NewStringSchema()))
+		item.Get.AddParameter(openapi3.NewQueryParameter("powerState").
+			WithDescription("Return BMCs whose status.powerState is one of these comma-separated Redfish power states, such as On").
+			WithSchema(openapi3.NewStringSchema()))
+	}
+}
+
+// newCustomOperation builds a minimal OpenAPI operation for a custom route
+func newCustomOperation(operationID, summary, tag string, responses map[string]string) *openapi3.Operation {
+	op := openapi3.NewOperation()
+	op.OperationID = operationID
+	op.Summary = summary
+	op.Tags = []string{tag}
+	op.Responses = openapi3.NewResponses()
+	for status, description := range responses {
+		op.Responses.Set(status, &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().WithDescription(description),
+		})
+	}
+	return op
 }
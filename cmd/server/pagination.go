@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openchami/boot-service/internal/httputil"
+)
+
+// maxPageLimit bounds the limit query parameter on paginated list endpoints
+const maxPageLimit = 1000
+
+// paginatedCollections are the list endpoints that accept limit and after
+var paginatedCollections = map[string]bool{
+	"/bmcs":               true,
+	"/bootconfigurations": true,
+	"/nodes":              true,
+}
+
+// paginateLists adds keyset pagination to the generated list endpoints. With
+// ?limit=N it returns at most N resources ordered by UID, starting after the
+// UID given in ?after=. Requests without limit or after are passed through
+// unchanged, so existing clients still receive the full list.
+func paginateLists(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.Method != http.MethodGet || !paginatedCollections[strings.TrimSuffix(r.URL.Path, "/")] ||
+			(!query.Has("limit") && !query.Has("after")) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		limit := maxPageLimit
+		if value := query.Get("limit"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 1 || parsed > maxPageLimit {
+				httputil.WriteError(w, http.StatusBadRequest, "Invalid limit",
+					fmt.Sprintf("limit must be an integer between 1 and %d", maxPageLimit))
+				return
+			}
+			limit = parsed
+		}
+		after := query.Get("after")
+
+		recorder := &bufferedResponse{header: w.Header(), status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		if recorder.status != http.StatusOK {
+			w.WriteHeader(recorder.status)
+			_, _ = w.Write(recorder.body.Bytes())
+			return
+		}
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(recorder.body.Bytes(), &items); err != nil {
+			httputil.WriteError(w, http.StatusInternalServerError, "Pagination failed", err.Error())
+			return
+		}
+		page, err := pageByUID(items, after, limit)
+		if err != nil {
+			httputil.WriteError(w, http.StatusInternalServerError, "Pagination failed", err.Error())
+			return
+		}
+		w.Header().Del("Content-Length")
+		httputil.WriteJSON(w, http.StatusOK, page)
+	})
+}
+
+// pageByUID sorts items by metadata.uid and returns at most limit items whose
+// UID sorts after the given one
+func pageByUID(items []json.RawMessage, after string, limit int) ([]json.RawMessage, error) {
+	type keyed struct {
+		uid  string
+		item json.RawMessage
+	}
+	sorted := make([]keyed, 0, len(items))
+	for _, item := range items {
+		var meta struct {
+			Metadata struct {
+				UID string `json:"uid"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal(item, &meta); err != nil {
+			return nil, fmt.Errorf("failed to read resource UID: %w", err)
+		}
+		if meta.Metadata.UID > after {
+			sorted = append(sorted, keyed{uid: meta.Metadata.UID, item: item})
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].uid < sorted[j].uid })
+
+	page := make([]json.RawMessage, 0, min(limit, len(sorted)))
+	for _, entry := range sorted {
+		if len(page) == limit {
+			break
+		}
+		page = append(page, entry.item)
+	}
+	return page, nil
+}
+
+// bufferedResponse captures a handler's response so it can be rewritten
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header { return b.header }
+
+func (b *bufferedResponse) WriteHeader(status int) { b.status = status }
+
+func (b *bufferedResponse) Write(p []byte) (int, error) { return b.body.Write(p) }
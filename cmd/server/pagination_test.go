@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/client"
+)
+
+func TestPaginateLists(t *testing.T) {
+	newGeneratedRouterForTest(t)
+	r := chi.NewRouter()
+	r.Use(middleware.RedirectSlashes)
+	r.Use(paginateLists)
+	RegisterGeneratedRoutes(r)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	c, err := client.NewClient(server.URL, nil, client.DefaultLogger())
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		spec := v1.NodeSpec{
+			XName:   fmt.Sprintf("x1000c0s%db0n0", i),
+			NID:     int32(i + 1),
+			BootMAC: fmt.Sprintf("aa:bb:cc:dd:ee:%02x", i),
+		}
+		if _, err := c.CreateNodeSimple(ctx, spec.XName, spec); err != nil {
+			t.Fatalf("CreateNode failed: %v", err)
+		}
+	}
+
+	var uids []string
+	for node, err := range c.AllNodes(ctx, 2) {
+		if err != nil {
+			t.Fatalf("iteration failed: %v", err)
+		}
+		uids = append(uids, node.Metadata.UID)
+	}
+	if len(uids) != 5 {
+		t.Fatalf("iterated %d nodes, want 5", len(uids))
+	}
+	for i := 1; i < len(uids); i++ {
+		if uids[i-1] >= uids[i] {
+			t.Errorf("nodes not ordered by UID: %v", uids)
+		}
+	}
+
+	// Without pagination parameters the full list is returned
+	all, err := c.GetNodes(ctx)
+	if err != nil || len(all) != 5 {
+		t.Errorf("GetNodes() returned %d nodes, err %v", len(all), err)
+	}
+
+	for _, query := range []string{"limit=0", "limit=abc", "limit=1001"} {
+		resp, err := http.Get(server.URL + "/nodes?" + query)
+		if err != nil {
+			t.Fatalf("GET failed: %v", err)
+		}
+		resp.Body.Close() //nolint:errcheck
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: got status %d, want 400", query, resp.StatusCode)
+		}
+	}
+}
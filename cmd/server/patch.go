@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/patch"
+	"github.com/openchami/fabrica/pkg/resource"
+	"github.com/openchami/fabrica/pkg/validation"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/schemas"
+)
+
+// specPatcher patches the spec of one resource kind. With registry, the
+// patched spec is checked against the named schema.
+type specPatcher interface {
+	patch(w http.ResponseWriter, r *http.Request, uid string, registry *schemas.Registry, schema string)
+}
+
+// specPatchers are the spec patchers by resource collection
+var specPatchers = map[string]specPatcher{
+	"/nodes": specPatch[v1.Node, v1.NodeSpec]{
+		kind: "Node",
+		load: storage.LoadNode,
+		save: storage.SaveNode,
+		parts: func(node *v1.Node) (*resource.Metadata, *v1.NodeSpec) {
+			return &node.Metadata, &node.Spec
+		},
+	},
+	"/bootconfigurations": specPatch[v1.BootConfiguration, v1.BootConfigurationSpec]{
+		kind: "BootConfiguration",
+		load: storage.LoadBootConfiguration,
+		save: storage.SaveBootConfiguration,
+		parts: func(config *v1.BootConfiguration) (*resource.Metadata, *v1.BootConfigurationSpec) {
+			return &config.Metadata, &config.Spec
+		},
+	},
+	"/bmcs": specPatch[v1.BMC, v1.BMCSpec]{
+		kind: "BMC",
+		load: storage.LoadBMC,
+		save: storage.SaveBMC,
+		parts: func(bmc *v1.BMC) (*resource.Metadata, *v1.BMCSpec) {
+			return &bmc.Metadata, &bmc.Spec
+		},
+	},
+}
+
+// patchSpecs serves PATCH requests to a node, boot configuration, or BMC.
+// The generated handlers decode the patched spec over the stored one, so a
+// field removed by a merge-patch null or a JSON Patch remove kept its stored
+// value. Here the patched spec replaces the stored one, as RFC 7386 and RFC
+// 6902 require, after it is checked against its schema in registry, when
+// given. Other requests, including status patches, are passed on.
+func patchSpecs(registry *schemas.Registry) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPatch {
+				next.ServeHTTP(w, r)
+				return
+			}
+			collection, uid, _ := strings.Cut(strings.TrimPrefix(strings.TrimSuffix(r.URL.Path, "/"), "/"), "/")
+			patcher, ok := specPatchers["/"+collection]
+			if !ok || uid == "" || strings.Contains(uid, "/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			var schema string
+			if registry != nil {
+				schema, _ = registry.SpecSchema("/" + collection)
+			}
+			patcher.patch(w, r, uid, registry, schema)
+		})
+	}
+}
+
+// patchLocks serializes the patches of each resource, so concurrent patches
+// apply one after another instead of each to the same stored copy, which
+// would keep only the last one's edits
+var patchLocks = resourceLocks{locks: map[string]*resourceLock{}}
+
+// resourceLocks holds a mutex for each resource being patched
+type resourceLocks struct {
+	mu    sync.Mutex
+	locks map[string]*resourceLock
+}
+
+// resourceLock is a mutex with a count of the requests holding or waiting
+// for it, which is dropped when the count reaches zero
+type resourceLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock locks the resource key and returns the function that unlocks it
+func (l *resourceLocks) lock(key string) func() {
+	l.mu.Lock()
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = &resourceLock{}
+		l.locks[key] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		l.mu.Lock()
+		if lock.refs--; lock.refs == 0 {
+			delete(l.locks, key)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// specPatch patches the spec S of resources R
+type specPatch[R, S any] struct {
+	kind  string
+	load  func(ctx context.Context, uid string) (*R, error)
+	save  func(ctx context.Context, res *R) error
+	parts func(res *R) (*resource.Metadata, *S)
+}
+
+func (p specPatch[R, S]) patch(w http.ResponseWriter, r *http.Request, uid string, registry *schemas.Registry, schema string) {
+	unlock := patchLocks.lock(p.kind + "/" + uid)
+	defer unlock()
+
+	res, err := p.load(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("%s not found: %w", p.kind, err))
+		return
+	}
+	metadata, spec := p.parts(res)
+
+	patchData, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("failed to read patch data: %w", err))
+		return
+	}
+	currentSpecJSON, err := json.Marshal(spec)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal current spec: %w", err))
+		return
+	}
+	patchType := patch.DetectPatchType(r.Header.Get("Content-Type"))
+	patchResult, err := patch.ApplyPatchWithOptions(currentSpecJSON, patchData, patchType, patch.PatchOptions{
+		AllowAddFields:    true,
+		AllowRemoveFields: true,
+	})
+	if err != nil {
+		respondError(w, http.StatusUnprocessableEntity, fmt.Errorf("failed to apply patch to spec: %w", err))
+		return
+	}
+
+	if schema != "" {
+		if errs := registry.Validate(schema, patchResult.Updated, "/spec"); len(errs) > 0 {
+			schemas.WriteValidationError(w, schema, errs)
+			return
+		}
+	}
+
+	// Fields the patch removed are left at their zero value
+	var patched S
+	if err := json.Unmarshal(patchResult.Updated, &patched); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid patched spec: %w", err))
+		return
+	}
+	*spec = patched
+	metadata.UpdatedAt = time.Now()
+
+	if err := validation.ValidateWithContext(r.Context(), res); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("validation failed: %w", err))
+		return
+	}
+	if err := p.save(r.Context(), res); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save patched %s: %w", p.kind, err))
+		return
+	}
+
+	patchMetadata := map[string]interface{}{
+		"patchType": patchType,
+		"updatedAt": metadata.UpdatedAt,
+	}
+	if err := events.PublishResourcePatched(r.Context(), p.kind, metadata.UID, metadata.Name, res, patchMetadata); err != nil {
+		// Events are non-critical
+		fmt.Printf("Warning: Failed to publish resource patched event for %s %s: %v\n", p.kind, metadata.UID, err)
+	}
+	respondJSON(w, http.StatusOK, res)
+}
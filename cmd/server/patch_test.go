@@ -0,0 +1,326 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/admission"
+	"github.com/openchami/boot-service/pkg/schemas"
+)
+
+func createResourceForPatchTest(t *testing.T, serverURL, collection, body string, out interface{}) {
+	t.Helper()
+
+	resp, err := http.Post(serverURL+collection, "application/json", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatalf("POST %s failed: %v", collection, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("POST %s returned status %d, want %d: %s", collection, resp.StatusCode, http.StatusCreated, body)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatalf("failed to decode created resource: %v", err)
+	}
+}
+
+func sendPatchForTest(t *testing.T, url, contentType, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPatch, url, bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatalf("failed to build PATCH request: %v", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("PATCH %s failed: %v", url, err)
+	}
+	return resp
+}
+
+func TestPatchBootConfiguration_JSONPatchAndMergePatch(t *testing.T) {
+	server := httptest.NewServer(newGeneratedRouterForTest(t))
+	defer server.Close()
+
+	var created v1.BootConfiguration
+	createResourceForPatchTest(t, server.URL, "/bootconfigurations",
+		`{"metadata":{"name":"compute"},"spec":{"kernel":"http://files.example.com/vmlinuz","params":"console=ttyS0","groups":["compute"]}}`,
+		&created)
+
+	resourceURL := server.URL + "/bootconfigurations/" + created.Metadata.UID
+
+	// RFC 6902: the "test" operation guards the write against concurrent edits.
+	resp := sendPatchForTest(t, resourceURL, "application/json-patch+json",
+		`[{"op":"test","path":"/params","value":"console=ttyS0"},{"op":"replace","path":"/params","value":"console=ttyS0 quiet"},{"op":"add","path":"/groups/-","value":"gpu"}]`)
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("JSON Patch returned status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var patched v1.BootConfiguration
+	if err := json.NewDecoder(resp.Body).Decode(&patched); err != nil {
+		t.Fatalf("failed to decode patched resource: %v", err)
+	}
+	if patched.Spec.Params != "console=ttyS0 quiet" {
+		t.Errorf("params = %q, want %q", patched.Spec.Params, "console=ttyS0 quiet")
+	}
+	if len(patched.Spec.Groups) != 2 || patched.Spec.Groups[1] != "gpu" {
+		t.Errorf("groups = %v, want [compute gpu]", patched.Spec.Groups)
+	}
+
+	// A failed "test" operation must leave the resource untouched.
+	staleResp := sendPatchForTest(t, resourceURL, "application/json-patch+json",
+		`[{"op":"test","path":"/params","value":"console=ttyS0"},{"op":"replace","path":"/params","value":"stale"}]`)
+	staleResp.Body.Close() //nolint:errcheck
+	if staleResp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("stale JSON Patch returned status %d, want %d", staleResp.StatusCode, http.StatusUnprocessableEntity)
+	}
+
+	// RFC 7386: members are merged; an empty list clears a list field.
+	mergeResp := sendPatchForTest(t, resourceURL, "application/merge-patch+json",
+		`{"initrd":"http://files.example.com/initrd.img","groups":[]}`)
+	defer mergeResp.Body.Close() //nolint:errcheck
+	if mergeResp.StatusCode != http.StatusOK {
+		t.Fatalf("merge patch returned status %d, want %d", mergeResp.StatusCode, http.StatusOK)
+	}
+
+	var merged v1.BootConfiguration
+	if err := json.NewDecoder(mergeResp.Body).Decode(&merged); err != nil {
+		t.Fatalf("failed to decode merged resource: %v", err)
+	}
+	if merged.Spec.Initrd != "http://files.example.com/initrd.img" {
+		t.Errorf("initrd = %q, want merged value", merged.Spec.Initrd)
+	}
+	if merged.Spec.Params != "console=ttyS0 quiet" {
+		t.Errorf("params = %q, want value preserved by merge patch", merged.Spec.Params)
+	}
+	if len(merged.Spec.Groups) != 0 {
+		t.Errorf("groups = %v, want cleared by merge patch", merged.Spec.Groups)
+	}
+}
+
+func TestPatchNode_MergePatchValidatesResult(t *testing.T) {
+	server := httptest.NewServer(newGeneratedRouterForTest(t))
+	defer server.Close()
+
+	var created v1.Node
+	createResourceForPatchTest(t, server.URL, "/nodes",
+		`{"metadata":{"name":"x0c0s0b0n0"},"spec":{"xname":"x0c0s0b0n0","nid":1,"bootMac":"aa:bb:cc:dd:ee:ff"}}`,
+		&created)
+
+	resourceURL := server.URL + "/nodes/" + created.Metadata.UID
+
+	resp := sendPatchForTest(t, resourceURL, "application/merge-patch+json", `{"hostname":"nid0001"}`)
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("merge patch returned status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var patched v1.Node
+	if err := json.NewDecoder(resp.Body).Decode(&patched); err != nil {
+		t.Fatalf("failed to decode patched node: %v", err)
+	}
+	if patched.Spec.Hostname != "nid0001" || patched.Spec.XName != "x0c0s0b0n0" {
+		t.Errorf("unexpected patched spec: %+v", patched.Spec)
+	}
+
+	invalidResp := sendPatchForTest(t, resourceURL, "application/json-patch+json",
+		`[{"op":"replace","path":"/bootMac","value":"not-a-mac"}]`)
+	defer invalidResp.Body.Close() //nolint:errcheck
+	if invalidResp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("invalid patch returned status %d, want %d", invalidResp.StatusCode, http.StatusBadRequest)
+	}
+
+	hostnameResp := sendPatchForTest(t, resourceURL, "application/merge-patch+json", `{"hostname":"n1\ndhcp-script=/tmp/x"}`)
+	hostnameResp.Body.Close() //nolint:errcheck
+	if hostnameResp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("patch with an invalid hostname returned status %d, want %d", hostnameResp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestPatchNode_RemoveClearsFields(t *testing.T) {
+	server := httptest.NewServer(newGeneratedRouterForTest(t))
+	defer server.Close()
+
+	var created v1.Node
+	createResourceForPatchTest(t, server.URL, "/nodes",
+		`{"metadata":{"name":"x0c0s0b0n0"},"spec":{"xname":"x0c0s0b0n0","bootMac":"aa:bb:cc:dd:ee:ff","hostname":"nid0001","paramsAppend":"quiet"}}`,
+		&created)
+
+	resourceURL := server.URL + "/nodes/" + created.Metadata.UID
+
+	// RFC 6902: "remove" drops the member
+	resp := sendPatchForTest(t, resourceURL, "application/json-patch+json", `[{"op":"remove","path":"/paramsAppend"}]`)
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("JSON Patch returned status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var removed v1.Node
+	if err := json.NewDecoder(resp.Body).Decode(&removed); err != nil {
+		t.Fatalf("failed to decode patched node: %v", err)
+	}
+	if removed.Spec.ParamsAppend != "" || removed.Spec.Hostname != "nid0001" {
+		t.Errorf("paramsAppend = %q, hostname = %q; want paramsAppend cleared and hostname kept", removed.Spec.ParamsAppend, removed.Spec.Hostname)
+	}
+
+	// RFC 7386: null removes the member
+	mergeResp := sendPatchForTest(t, resourceURL, "application/merge-patch+json", `{"hostname":null}`)
+	defer mergeResp.Body.Close() //nolint:errcheck
+	if mergeResp.StatusCode != http.StatusOK {
+		t.Fatalf("merge patch returned status %d, want %d", mergeResp.StatusCode, http.StatusOK)
+	}
+
+	getResp, err := http.Get(resourceURL)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", resourceURL, err)
+	}
+	defer getResp.Body.Close() //nolint:errcheck
+	var stored v1.Node
+	if err := json.NewDecoder(getResp.Body).Decode(&stored); err != nil {
+		t.Fatalf("failed to decode stored node: %v", err)
+	}
+	if stored.Spec.Hostname != "" || stored.Spec.ParamsAppend != "" || stored.Spec.BootMAC != "aa:bb:cc:dd:ee:ff" {
+		t.Errorf("stored spec = %+v, want hostname and paramsAppend cleared", stored.Spec)
+	}
+}
+
+func TestPatchNode_CheckedAgainstSchema(t *testing.T) {
+	registry, err := schemas.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(patchSpecs(registry)(newGeneratedRouterForTest(t)))
+	defer server.Close()
+
+	var created v1.Node
+	createResourceForPatchTest(t, server.URL, "/nodes",
+		`{"metadata":{"name":"x0c0s0b0n0"},"spec":{"xname":"x0c0s0b0n0","bootMac":"aa:bb:cc:dd:ee:ff"}}`,
+		&created)
+
+	resourceURL := server.URL + "/nodes/" + created.Metadata.UID
+	resp := sendPatchForTest(t, resourceURL, "application/merge-patch+json", `{"bootmac":"aa:bb:cc:dd:ee:01","nid":"one"}`)
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("patch with misspelled and mistyped fields returned status %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	var problem schemas.ValidationError
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+		t.Fatalf("failed to decode validation error: %v", err)
+	}
+	if len(problem.Errors) != 2 || problem.Errors[0].Field != "/spec" || problem.Errors[1].Field != "/spec/nid" {
+		t.Errorf("errors = %+v, want the unknown field and /spec/nid", problem.Errors)
+	}
+}
+
+func TestAdmissionHooks_ReviewGeneratedWrites(t *testing.T) {
+	policy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req admission.Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var config v1.BootConfiguration
+		_ = json.Unmarshal(req.Object, &config)
+		if !strings.HasPrefix(config.Spec.Kernel, "http://images.example.com/") {
+			w.Write([]byte(`{"allowed":false,"reason":"kernels must come from images.example.com"}`)) //nolint:errcheck
+			return
+		}
+		w.Write([]byte(`{"allowed":true,"patch":[{"op":"add","path":"/spec/params","value":"console=ttyS0"}]}`)) //nolint:errcheck
+	}))
+	defer policy.Close()
+
+	admission.SetDefault(admission.NewChain(nil, admission.NewWebhook("policy", policy.URL, time.Second, false, nil)))
+	defer admission.SetDefault(nil)
+
+	server := httptest.NewServer(newGeneratedRouterForTest(t))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/bootconfigurations", "application/json",
+		bytes.NewBufferString(`{"metadata":{"name":"rogue"},"spec":{"kernel":"http://evil.example.com/vmlinuz","groups":["compute"]}}`))
+	if err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusBadRequest || !strings.Contains(string(body), "images.example.com") {
+		t.Errorf("denied create returned %d: %s", resp.StatusCode, body)
+	}
+
+	var created v1.BootConfiguration
+	createResourceForPatchTest(t, server.URL, "/bootconfigurations",
+		`{"metadata":{"name":"compute"},"spec":{"kernel":"http://images.example.com/vmlinuz","groups":["compute"]}}`,
+		&created)
+	if created.Spec.Params != "console=ttyS0" {
+		t.Errorf("params = %q, want the webhook's patch applied", created.Spec.Params)
+	}
+}
+
+func TestPatchNode_ConcurrentPatchesKeepEveryEdit(t *testing.T) {
+	// The stored node is loaded slowly, so unserialized patches would each
+	// apply to the same copy and all but the last edit would be lost
+	var mu sync.Mutex
+	stored := v1.Node{Spec: v1.NodeSpec{XName: "x0c0s0b0n0", BootMAC: "aa:bb:cc:dd:ee:ff", Groups: []string{"compute"}}}
+	patcher := specPatch[v1.Node, v1.NodeSpec]{
+		kind: "Node",
+		load: func(ctx context.Context, uid string) (*v1.Node, error) {
+			mu.Lock()
+			node := stored
+			node.Spec.Groups = slices.Clone(stored.Spec.Groups)
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			return &node, nil
+		},
+		save: func(ctx context.Context, node *v1.Node) error {
+			mu.Lock()
+			defer mu.Unlock()
+			stored = *node
+			return nil
+		},
+		parts: func(node *v1.Node) (*resource.Metadata, *v1.NodeSpec) {
+			return &node.Metadata, &node.Spec
+		},
+	}
+
+	const patches = 20
+	var wg sync.WaitGroup
+	for i := range patches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body := `[{"op":"add","path":"/groups/-","value":"g` + strconv.Itoa(i) + `"}]`
+			req := httptest.NewRequest(http.MethodPatch, "/nodes/nod-1", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json-patch+json")
+			rec := httptest.NewRecorder()
+			patcher.patch(rec, req, "nod-1", nil, "")
+			if rec.Code != http.StatusOK {
+				t.Errorf("patch %d returned status %d, want %d: %s", i, rec.Code, http.StatusOK, rec.Body.String())
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(stored.Spec.Groups) != patches+1 {
+		t.Errorf("groups = %v, want compute and all %d patched groups", stored.Spec.Groups, patches)
+	}
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"log"
+	"net/http"
+	"net/http/pprof"
+	"os"
+
+	"github.com/openchami/boot-service/pkg/apikeys"
+	"github.com/openchami/boot-service/pkg/auth"
+)
+
+// pprofHandler serves net/http/pprof under /debug/pprof/ to callers with a
+// token verified against jwks_endpoint, or an API key, that carries
+// pprof_scope
+func pprofHandler(config Config, keys *apikeys.Store) http.Handler {
+	authConfig := tokenAuthConfig(config, keys)
+	return protectedPprof(authConfig.CreateMiddleware(log.New(os.Stdout, "pprof: ", log.LstdFlags)), config.PprofScope)
+}
+
+// protectedPprof wraps the profiling handlers in authn and, unless scope is
+// empty, a scope check
+func protectedPprof(authn func(http.Handler) http.Handler, scope string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index) // also serves heap, goroutine, allocs, block, and mutex
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	var scoped []string
+	if scope != "" {
+		scoped = append(scoped, scope)
+	}
+	return authn(auth.CreateScopeMiddleware(scoped...)(mux))
+}
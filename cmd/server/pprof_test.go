@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openchami/boot-service/pkg/auth"
+)
+
+func TestProtectedPprof(t *testing.T) {
+	keyPair, err := auth.GenerateTestKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	authConfig := auth.CreateStaticKeyConfig(keyPair.PublicKeyPEM)
+	handler := protectedPprof(authConfig.CreateMiddleware(nil), "admin")
+
+	get := func(path string, scopes ...string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if scopes != nil {
+			tok, err := auth.CreateTestTokenWithScopes(keyPair, scopes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Authorization", "Bearer "+tok)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := get("/debug/pprof/"); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a token, got %d", code)
+	}
+	if code := get("/debug/pprof/", "read"); code != http.StatusForbidden {
+		t.Fatalf("expected 403 without the pprof scope, got %d", code)
+	}
+	if code := get("/debug/pprof/", "admin"); code != http.StatusOK {
+		t.Fatalf("expected 200 for the index, got %d", code)
+	}
+	if code := get("/debug/pprof/heap?debug=1", "admin"); code != http.StatusOK {
+		t.Fatalf("expected 200 for the heap profile, got %d", code)
+	}
+
+	anyScope := protectedPprof(authConfig.CreateMiddleware(nil), "")
+	req := httptest.NewRequest(http.MethodGet, "/debug/pprof/cmdline", nil)
+	tok, err := auth.CreateTestTokenWithScopes(keyPair, []string{"read"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+tok)
+	rec := httptest.NewRecorder()
+	anyScope.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 with an empty pprof scope, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/proxydhcp"
+	"github.com/openchami/boot-service/pkg/tftp"
+)
+
+// capNetBindService is the capability to bind ports below
+// net.ipv4.ip_unprivileged_port_start
+const capNetBindService = 10
+
+// startProxyDHCP answers PXE clients of the nodes in bootClient on the DHCP
+// and PXE ports until ctx is done, pointing them at the TFTP server and the
+// boot script
+func startProxyDHCP(ctx context.Context, config Config, bootClient client.API) error {
+	if err := checkBindCapability(proxydhcp.DHCPPort); err != nil {
+		return fmt.Errorf("proxy-dhcp: %w", err)
+	}
+	files, err := tftp.ParseBootFiles(parseScopeHintCSV(config.TFTPBootFiles))
+	if err != nil {
+		return fmt.Errorf("tftp-boot-files: %w", err)
+	}
+	serverIP := net.ParseIP(config.ProxyDHCPServerIP)
+	logger := log.New(os.Stdout, "proxydhcp: ", log.LstdFlags)
+	server := proxydhcp.NewServer(bootClient, proxydhcp.Config{
+		ServerIP:  serverIP,
+		BootFiles: files,
+		ScriptURL: fmt.Sprintf("http://%s/bootscript?mac=${net0/mac}", net.JoinHostPort(serverIP.String(), strconv.Itoa(config.Port))),
+	}, logger)
+
+	listenConfig := net.ListenConfig{Control: dhcpSocket}
+	for _, port := range []int{proxydhcp.DHCPPort, proxydhcp.PXEPort} {
+		conn, err := listenConfig.ListenPacket(ctx, "udp4", net.JoinHostPort("", strconv.Itoa(port)))
+		if err != nil {
+			return fmt.Errorf("proxy-dhcp: %w", err)
+		}
+		go func() {
+			if err := server.Serve(conn); err != nil {
+				logger.Printf("ProxyDHCP responder stopped: %v", err)
+			}
+		}()
+		go func() {
+			<-ctx.Done()
+			conn.Close() //nolint:errcheck
+		}()
+	}
+	log.Printf("ProxyDHCP answering PXE clients of known nodes on UDP ports %d and %d (next server %s)",
+		proxydhcp.DHCPPort, proxydhcp.PXEPort, serverIP)
+	return nil
+}
+
+// dhcpSocket lets a socket broadcast to clients without an address, and
+// share the DHCP port with a DHCP server on the same host
+func dhcpSocket(_, _ string, conn syscall.RawConn) error {
+	var sockErr error
+	if err := conn.Control(func(fd uintptr) {
+		if sockErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_BROADCAST, 1); sockErr == nil {
+			sockErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEADDR, 1)
+		}
+	}); err != nil {
+		return err
+	}
+	return sockErr
+}
+
+// checkBindCapability reports an error if this process may not bind port:
+// one below net.ipv4.ip_unprivileged_port_start needs CAP_NET_BIND_SERVICE
+func checkBindCapability(port int) error {
+	start := 1024
+	if data, err := os.ReadFile("/proc/sys/net/ipv4/ip_unprivileged_port_start"); err == nil {
+		if value, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil {
+			start = value
+		}
+	}
+	if port >= start {
+		return nil
+	}
+	status, err := os.ReadFile("/proc/self/status")
+	if err != nil {
+		return fmt.Errorf("cannot check capabilities: %w", err)
+	}
+	for _, line := range strings.Split(string(status), "\n") {
+		if value, ok := strings.CutPrefix(line, "CapEff:"); ok {
+			caps, err := strconv.ParseUint(strings.TrimSpace(value), 16, 64)
+			if err == nil && caps&(1<<capNetBindService) != 0 {
+				return nil
+			}
+			break
+		}
+	}
+	return fmt.Errorf("binding UDP port %d needs CAP_NET_BIND_SERVICE", port)
+}
@@ -0,0 +1,294 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// configReloader re-reads the configuration at runtime and applies settings
+// that are safe to change without a restart. Other changed settings are
+// reported as requiring a restart and keep their running values.
+type configReloader struct {
+	mu       sync.Mutex
+	current  Config
+	load     func() (Config, error)
+	handlers []reloadHandler
+	last     *reloadResult
+}
+
+// reloadResult reports a reload, so a changed ConfigMap can be seen to have
+// applied
+type reloadResult struct {
+	At      time.Time `json:"at"`
+	Trigger string    `json:"trigger"`
+	Applied []string  `json:"applied,omitempty"`
+	// Restart lists changed settings that keep their running values until
+	// a restart
+	Restart []string `json:"restartRequired,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+type reloadHandler struct {
+	keys  []string
+	apply func(Config)
+}
+
+func newConfigReloader(current Config, load func() (Config, error)) *configReloader {
+	return &configReloader{current: current, load: load}
+}
+
+// Current returns the running configuration
+func (r *configReloader) Current() Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current
+}
+
+// LastReload returns the result of the last reload, or nil before the first
+func (r *configReloader) LastReload() *reloadResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
+
+// OnChange registers apply to run with the new configuration when any of the
+// given config keys changes
+func (r *configReloader) OnChange(keys []string, apply func(Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers = append(r.handlers, reloadHandler{keys: keys, apply: apply})
+}
+
+// Reload loads and validates the configuration, applies the changed settings
+// that support it, and returns the keys applied and the keys that require a
+// restart. An invalid configuration is rejected as a whole.
+func (r *configReloader) Reload() (applied, restart []string, err error) {
+	next, err := r.load()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateConfig(next); err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changedKeys := changedConfigKeys(r.current, next)
+	changed := map[string]bool{}
+	for _, key := range changedKeys {
+		changed[key] = true
+	}
+
+	reloadable := map[string]bool{}
+	for _, handler := range r.handlers {
+		matched := false
+		for _, key := range handler.keys {
+			reloadable[key] = true
+			matched = matched || changed[key]
+		}
+		if matched {
+			handler.apply(next)
+		}
+	}
+
+	for _, key := range changedKeys {
+		if reloadable[key] {
+			applied = append(applied, key)
+			copyConfigKey(&r.current, next, key)
+		} else {
+			restart = append(restart, key)
+		}
+	}
+	return applied, restart, nil
+}
+
+// reloadAndLog reloads the configuration and logs the outcome
+func (r *configReloader) reloadAndLog(trigger string) {
+	applied, restart, err := r.Reload()
+	result := &reloadResult{At: time.Now().UTC(), Trigger: trigger, Applied: applied, Restart: restart}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	r.mu.Lock()
+	r.last = result
+	r.mu.Unlock()
+
+	switch {
+	case err != nil:
+		log.Printf("Configuration reload (%s) rejected: %v", trigger, err)
+		return
+	case len(applied) == 0 && len(restart) == 0:
+		log.Printf("Configuration reload (%s): no changes", trigger)
+		return
+	}
+	if len(applied) > 0 {
+		log.Printf("Configuration reload (%s) applied: %s", trigger, strings.Join(applied, ", "))
+	}
+	if len(restart) > 0 {
+		log.Printf("Configuration reload (%s): restart required to apply %s", trigger, strings.Join(restart, ", "))
+	}
+}
+
+// configFileSettle is how long the config file must stay unchanged before
+// it is reloaded, so a file written in several steps is read once complete
+const configFileSettle = 500 * time.Millisecond
+
+// watchConfig reloads the configuration on SIGHUP and, when a config file is
+// in use, whenever the file changes. Reloads are made one at a time from
+// this goroutine. It returns when ctx is done.
+func watchConfig(ctx context.Context, reloader *configReloader) {
+	var fileChanges <-chan struct{}
+	if file := viper.ConfigFileUsed(); file != "" {
+		changes, err := watchConfigFile(ctx, file, configFileSettle)
+		if err != nil {
+			log.Printf("Not watching %s for configuration changes: %v", file, err)
+		} else {
+			fileChanges = changes
+			log.Printf("Watching %s for configuration changes", file)
+		}
+	}
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	for {
+		trigger := "SIGHUP"
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+		case <-fileChanges:
+			trigger = "file change"
+		}
+		if err := viper.ReadInConfig(); err != nil {
+			if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+				log.Printf("Configuration reload (%s) rejected: %v", trigger, err)
+				continue
+			}
+		}
+		reloader.reloadAndLog(trigger)
+	}
+}
+
+// watchConfigFile signals when the contents of the config file change. The
+// directory holding it is watched rather than the file, so replacing the
+// file is seen, whether by an editor saving through a rename or by
+// Kubernetes swapping the symlinks of a mounted ConfigMap. Changes are
+// noticed by their contents, once the file has settled, so touching the
+// file or a burst of writes does not reload it repeatedly.
+func watchConfigFile(ctx context.Context, path string, settle time.Duration) (<-chan struct{}, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close() //nolint:errcheck
+		return nil, err
+	}
+
+	changes := make(chan struct{}, 1)
+	last := fileDigest(path)
+	go func() {
+		defer watcher.Close() //nolint:errcheck
+		timer := time.NewTimer(settle)
+		timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				timer.Reset(settle)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("Watching %s: %v", path, err)
+			case <-timer.C:
+				// A missing or empty file is waited out, since it is being
+				// replaced
+				digest := fileDigest(path)
+				if digest == "" || digest == last {
+					continue
+				}
+				last = digest
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// fileDigest returns a digest of the contents of a file, following
+// symlinks, or "" when it cannot be read or is empty, as it is while being
+// written
+func fileDigest(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return string(sum[:])
+}
+
+// loadConfig reads the configuration from viper on top of the defaults
+func loadConfig() (Config, error) {
+	config := DefaultConfig()
+	if err := viper.Unmarshal(&config); err != nil {
+		return Config{}, fmt.Errorf("failed to unmarshal config: %v", err)
+	}
+	return config, nil
+}
+
+// changedConfigKeys returns the config keys whose values differ
+func changedConfigKeys(old, next Config) []string {
+	var keys []string
+	oldValue, nextValue := reflect.ValueOf(old), reflect.ValueOf(next)
+	for i := 0; i < oldValue.NumField(); i++ {
+		if !reflect.DeepEqual(oldValue.Field(i).Interface(), nextValue.Field(i).Interface()) {
+			keys = append(keys, configKey(oldValue.Type().Field(i)))
+		}
+	}
+	return keys
+}
+
+// copyConfigKey copies the setting named key from src to dst
+func copyConfigKey(dst *Config, src Config, key string) {
+	dstValue, srcValue := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src)
+	for i := 0; i < dstValue.NumField(); i++ {
+		if configKey(dstValue.Type().Field(i)) == key {
+			dstValue.Field(i).Set(srcValue.Field(i))
+			return
+		}
+	}
+}
+
+func configKey(field reflect.StructField) string {
+	key, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+	return key
+}
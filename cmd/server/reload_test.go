@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfigReloader(t *testing.T) {
+	running := DefaultConfig()
+	next := running
+	reloader := newConfigReloader(running, func() (Config, error) { return next, nil })
+
+	var cacheTTL, syncInterval []int
+	reloader.OnChange([]string{"script_cache_ttl", "script_cache_max_entries"}, func(config Config) {
+		cacheTTL = append(cacheTTL, config.ScriptCacheTTL)
+	})
+	reloader.OnChange([]string{"hsm_sync_interval"}, func(config Config) {
+		syncInterval = append(syncInterval, config.HSMSyncInterval)
+	})
+
+	tests := []struct {
+		name         string
+		modify       func(*Config)
+		wantApplied  []string
+		wantRestart  []string
+		wantCacheTTL []int
+		wantError    bool
+	}{
+		{
+			name:   "no changes",
+			modify: func(*Config) {},
+		},
+		{
+			name:         "reloadable and restart-only settings",
+			modify:       func(c *Config) { c.ScriptCacheTTL = 60; c.Port = 9000 },
+			wantApplied:  []string{"script_cache_ttl"},
+			wantRestart:  []string{"port"},
+			wantCacheTTL: []int{60},
+		},
+		{
+			// The port change is still pending, so it is reported again
+			name:         "restart-only setting reported until restart",
+			modify:       func(c *Config) { c.ScriptCacheTTL = 60; c.Port = 9000 },
+			wantRestart:  []string{"port"},
+			wantCacheTTL: []int{60},
+		},
+		{
+			name:         "invalid configuration rejected",
+			modify:       func(c *Config) { c.ScriptCacheTTL = 0 },
+			wantCacheTTL: []int{60},
+			wantError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next = DefaultConfig()
+			tt.modify(&next)
+
+			applied, restart, err := reloader.Reload()
+			if (err != nil) != tt.wantError {
+				t.Fatalf("Reload error = %v, want error %v", err, tt.wantError)
+			}
+			if !reflect.DeepEqual(applied, tt.wantApplied) || !reflect.DeepEqual(restart, tt.wantRestart) {
+				t.Errorf("Reload = applied %v, restart %v; want %v, %v", applied, restart, tt.wantApplied, tt.wantRestart)
+			}
+			if !reflect.DeepEqual(cacheTTL, tt.wantCacheTTL) {
+				t.Errorf("cache handler calls = %v, want %v", cacheTTL, tt.wantCacheTTL)
+			}
+		})
+	}
+
+	if len(syncInterval) != 0 {
+		t.Errorf("sync interval handler called %d times for unchanged setting", len(syncInterval))
+	}
+}
+
+func TestWatchConfigFile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Lay the directory out as Kubernetes mounts a ConfigMap: the file is a
+	// symlink through ..data, which is swapped to a new directory on update
+	dir := t.TempDir()
+	writeVersion := func(name, contents string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name, "config.yaml"), []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(name, filepath.Join(dir, "..data_tmp")); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeVersion("..v1", "port: 8080\n")
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.Symlink(filepath.Join("..data", "config.yaml"), path); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := watchConfigFile(ctx, path, 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("watchConfigFile returned error: %v", err)
+	}
+	expect := func(want bool, event string) {
+		t.Helper()
+		select {
+		case <-changes:
+			if !want {
+				t.Errorf("%s signaled a change", event)
+			}
+		case <-time.After(500 * time.Millisecond):
+			if want {
+				t.Errorf("%s was not signaled", event)
+			}
+		}
+	}
+
+	writeVersion("..v2", "port: 9090\n")
+	expect(true, "a ConfigMap update")
+	writeVersion("..v3", "port: 9090\n")
+	expect(false, "an update with the same contents")
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/clients/local"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+)
+
+// renderOptions selects the node and files rendered by the render command
+type renderOptions struct {
+	node        string
+	profile     string
+	nodesFile   string
+	configsFile string
+	explain     bool
+}
+
+// newRenderCommand creates the render command, which builds a boot script
+// from local files without a running service
+func newRenderCommand() *cobra.Command {
+	opts := renderOptions{}
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render a node's boot script from local files",
+		Long: `Render the iPXE boot script a node would receive, using nodes from a YAML
+node provider file or directory and boot configurations from a YAML or JSON file, without
+a running service. Configuration selection and parameter templating are the
+same as at /boot/v1/bootscript. Exits non-zero when the node is not found, no
+configuration matches, or the script cannot be built, so it can check boot
+configurations in CI.`,
+		Example: `  boot-service render --node x1000c0s0b0n0 --nodes-file nodes.yaml --configs-file configs.yaml
+  boot-service render --node aa:bb:cc:dd:ee:01 --profile debug --nodes-file nodes.yaml --configs-file configs.yaml --explain`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive
+			return runRender(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.node, "node", "", "Node XName, MAC address, or NID to render")
+	cmd.Flags().StringVar(&opts.profile, "profile", "", "Boot profile to render (default selects across all profiles)")
+	cmd.Flags().StringVar(&opts.nodesFile, "nodes-file", "", "Nodes YAML file in the YAML node provider format, or a directory of them")
+	cmd.Flags().StringVar(&opts.configsFile, "configs-file", "", "Boot configurations file (YAML or JSON)")
+	cmd.Flags().BoolVar(&opts.explain, "explain", false, "Print the matched configuration and candidate scores as JSON instead of the script")
+	_ = cmd.MarkFlagRequired("node")
+	_ = cmd.MarkFlagRequired("nodes-file")
+	_ = cmd.MarkFlagRequired("configs-file")
+
+	return cmd
+}
+
+func runRender(ctx context.Context, out io.Writer, opts renderOptions) error {
+	if opts.node == "" || opts.nodesFile == "" || opts.configsFile == "" {
+		return errors.New("--node, --nodes-file, and --configs-file are required")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	paths, err := local.NodeFiles(opts.nodesFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", opts.nodesFile, err)
+	}
+	var nodes []v1.Node
+	for _, path := range paths {
+		var nodesFile local.YAMLNodesFile
+		if err := decodeStrictYAML(path, &nodesFile); err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		for _, node := range nodesFile.Nodes {
+			nodes = append(nodes, *node.Node())
+		}
+	}
+
+	configs, err := loadBootConfigurations(opts.configsFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", opts.configsFile, err)
+	}
+	for i := range configs {
+		if err := configs[i].Validate(ctx); err != nil {
+			return fmt.Errorf("%s: %s: %w", opts.configsFile, configDisplayLocation(i, &configs[i]), err)
+		}
+	}
+
+	resources := &bootscript.StaticResources{Nodes: nodes, BootConfigurations: configs}
+	controller := bootscript.NewBootScriptControllerWithReader(resources, log.New(io.Discard, "", 0))
+	preview, err := controller.PreviewBootScript(ctx, opts.node, opts.profile)
+	if err != nil {
+		return err
+	}
+
+	if opts.explain {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(preview); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprint(out, preview.Script) //nolint:errcheck
+	}
+
+	if preview.Template != bootscript.TemplateDefault {
+		return fmt.Errorf("rendered the %s script: %s", preview.Template, preview.Reason)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRunRender(t *testing.T) {
+	nodesFile := writeValidateTestFile(t, "nodes.yaml", `nodes:
+  - xname: x1000c0s0b0n0
+    nid: 7
+    role: Compute
+    boot_mac: aa:bb:cc:dd:ee:01
+  - xname: x1000c0s1b0n0
+    boot_mac: aa:bb:cc:dd:ee:02
+`)
+	configsFile := writeValidateTestFile(t, "configs.yaml", `- metadata: {name: compute}
+  spec: {kernel: "http://files/vmlinuz", params: "console=ttyS0 nid={{.NID}}", hosts: [x1000c0s0b0n0]}
+- metadata: {name: compute-debug}
+  spec: {kernel: "http://files/vmlinuz-debug", profile: debug, hosts: [x1000c0s0b0n0]}
+`)
+
+	tests := []struct {
+		name      string
+		opts      renderOptions
+		want      []string
+		wantError string
+	}{
+		{
+			name: "by xname",
+			opts: renderOptions{node: "x1000c0s0b0n0"},
+			want: []string{"#!ipxe", "Configuration: compute", "set params console=ttyS0 nid=7 BOOTIF=01-aa-bb-cc-dd-ee-01"},
+		},
+		{
+			name: "by MAC with profile",
+			opts: renderOptions{node: "AA:BB:CC:DD:EE:01", profile: "debug"},
+			want: []string{"Configuration: compute-debug", "http://files/vmlinuz-debug"},
+		},
+		{
+			name: "explain",
+			opts: renderOptions{node: "7", explain: true},
+			want: []string{`"matchedConfiguration": "compute"`, `"candidates"`},
+		},
+		{
+			name:      "no matching configuration",
+			opts:      renderOptions{node: "x1000c0s1b0n0"},
+			wantError: "rendered the minimal script",
+		},
+		{
+			name:      "unknown node",
+			opts:      renderOptions{node: "x9c0s0b0n0"},
+			wantError: "node not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.nodesFile = nodesFile
+			tt.opts.configsFile = configsFile
+
+			var out bytes.Buffer
+			err := runRender(context.Background(), &out, tt.opts)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("got error %v, want %q", err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runRender failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunRender_InvalidConfiguration(t *testing.T) {
+	opts := renderOptions{
+		node:        "x1000c0s0b0n0",
+		nodesFile:   writeValidateTestFile(t, "nodes.yaml", "nodes:\n  - xname: x1000c0s0b0n0\n"),
+		configsFile: writeValidateTestFile(t, "configs.yaml", "- metadata: {name: broken}\n  spec: {params: quiet}\n"),
+	}
+	err := runRender(context.Background(), &bytes.Buffer{}, opts)
+	if err == nil || !strings.Contains(err.Error(), "[0] (broken): kernel, kernelArtifact, or imageRef field is required") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
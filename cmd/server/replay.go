@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openchami/boot-service/pkg/recording"
+)
+
+// replayOptions configures a replay of recorded legacy API exchanges
+type replayOptions struct {
+	recordingFile string
+	bssURL        string
+	token         string
+	includeWrites bool
+	reportFile    string
+	timeout       time.Duration
+}
+
+// newMigrateReplayCommand creates the migrate replay command
+func newMigrateReplayCommand() *cobra.Command {
+	opts := replayOptions{}
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Replay recorded legacy API requests against BSS and compare responses",
+		Long: `Send the legacy /boot/v1 requests recorded with legacy_record_file to a
+Boot Script Service (BSS) instance and compare its responses with the ones
+this service returned. Each exchange is reported as match (byte for byte),
+equivalent (the same JSON value), mismatch, skipped, or failed. Writes are
+skipped unless --include-writes is set, since they change BSS. Exits non-zero
+when any exchange mismatched or failed.`,
+		Example: `  boot-service migrate replay --recording legacy.jsonl --url http://bss:27778
+  boot-service migrate replay --recording legacy.jsonl --url http://bss:27778 --report replay.json`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive
+			if opts.token == "" {
+				opts.token = os.Getenv("BSS_TOKEN")
+			}
+			return runMigrateReplay(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.recordingFile, "recording", "", "Recording file written by legacy_record_file")
+	cmd.Flags().StringVar(&opts.bssURL, "url", "", "Base URL of the BSS REST API, e.g. http://bss:27778")
+	cmd.Flags().StringVar(&opts.token, "token", "", "Bearer token for the BSS REST API (default $BSS_TOKEN)")
+	cmd.Flags().BoolVar(&opts.includeWrites, "include-writes", false, "Also replay POST, PUT, PATCH, and DELETE requests, which change BSS")
+	cmd.Flags().StringVar(&opts.reportFile, "report", "", "Write every result as JSON to this file")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 10*time.Second, "Timeout for each replayed request")
+	_ = cmd.MarkFlagRequired("recording")
+	_ = cmd.MarkFlagRequired("url")
+	return cmd
+}
+
+func runMigrateReplay(ctx context.Context, out io.Writer, opts replayOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts.recordingFile == "" || opts.bssURL == "" {
+		return errors.New("--recording and --url are required")
+	}
+
+	file, err := os.Open(opts.recordingFile)
+	if err != nil {
+		return fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer file.Close() //nolint:errcheck
+	exchanges, err := recording.ReadExchanges(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", opts.recordingFile, err)
+	}
+
+	summary := recording.Replay(ctx, exchanges, recording.ReplayOptions{
+		BaseURL:       opts.bssURL,
+		Token:         opts.token,
+		IncludeWrites: opts.includeWrites,
+		Client:        &http.Client{Timeout: opts.timeout},
+	})
+
+	for _, result := range summary.Results {
+		switch result.Outcome {
+		case recording.Mismatch:
+			fmt.Fprintf(out, "mismatch: %s %s: status %d, recorded %d\n", result.Method, result.URL, result.Status, result.RecordedStatus) //nolint:errcheck
+		case recording.Failed:
+			fmt.Fprintf(out, "failed: %s %s: %s\n", result.Method, result.URL, result.Error) //nolint:errcheck
+		}
+	}
+	fmt.Fprintf(out, "Replayed %d exchanges: %d match, %d equivalent, %d mismatch, %d skipped, %d failed\n", //nolint:errcheck
+		len(summary.Results), summary.Counts[recording.Match], summary.Counts[recording.Equivalent],
+		summary.Counts[recording.Mismatch], summary.Counts[recording.Skipped], summary.Counts[recording.Failed])
+
+	if opts.reportFile != "" {
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(opts.reportFile, append(data, '\n'), 0o600); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+	if !summary.Compatible() {
+		return errors.New("responses differ from the recording")
+	}
+	return nil
+}
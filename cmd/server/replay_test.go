@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunMigrateReplay(t *testing.T) {
+	bss := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer bss-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("#!ipxe\nkernel vmlinuz\n"))
+	}))
+	defer bss.Close()
+
+	path := filepath.Join(t.TempDir(), "legacy.jsonl")
+	recorded := `{"method":"GET","url":"/boot/v1/bootscript?mac=aa%3Abb%3Acc%3Add%3Aee%3A01","status":200,"responseBody":"#!ipxe\nkernel vmlinuz\n"}
+{"method":"GET","url":"/boot/v1/bootscript?nid=2","status":200,"responseBody":"#!ipxe\nkernel vmlinuz-old\n"}
+{"method":"DELETE","url":"/boot/v1/bootparameters","requestBody":"{\"hosts\":[\"x0\"]}","status":200,"responseBody":""}
+`
+	if err := os.WriteFile(path, []byte(recorded), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	report := filepath.Join(t.TempDir(), "replay.json")
+
+	var out bytes.Buffer
+	err := runMigrateReplay(context.Background(), &out, replayOptions{recordingFile: path, bssURL: bss.URL, token: "bss-token", reportFile: report})
+	if err == nil {
+		t.Fatal("expected an error for the mismatched script")
+	}
+	for _, want := range []string{"mismatch: GET /boot/v1/bootscript?nid=2", "Replayed 3 exchanges: 1 match, 0 equivalent, 1 mismatch, 1 skipped, 0 failed"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+	if data, err := os.ReadFile(report); err != nil || !strings.Contains(string(data), `"outcome": "mismatch"`) {
+		t.Errorf("unexpected report %s: %v", data, err)
+	}
+}
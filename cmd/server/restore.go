@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/artifacts"
+	"github.com/openchami/boot-service/pkg/backend"
+	"github.com/openchami/boot-service/pkg/backup"
+	"github.com/openchami/boot-service/pkg/snapshot"
+)
+
+// restoreOptions configures a restore from a scheduled backup
+type restoreOptions struct {
+	at          string
+	dataDir     string
+	backupDir   string
+	backupS3URL string
+	dryRun      bool
+}
+
+// newRestoreCommand creates the restore command, which returns storage to
+// the state of a scheduled backup
+func newRestoreCommand() *cobra.Command {
+	opts := restoreOptions{}
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore resources from a scheduled backup",
+		Long: `Restore nodes, boot configurations, BMCs, and artifact records from the newest
+backup taken at or before --at (default the newest backup). Storage is
+returned to the backed-up state: resources missing from the backup are
+deleted. Backups are read from backup_dir or backup_s3_url in the
+configuration file unless given on the command line.
+
+Restore writes to the storage backend directly: file storage under --data-dir
+or data_dir, or etcd when storage_type is etcd. Stop the service before
+restoring file storage; replicas sharing etcd pick the restored resources up
+as they are written.`,
+		Example: `  boot-service restore --at 2026-10-17T06:00:00Z --dry-run
+  boot-service restore --backup-dir /var/backups/boot-service --data-dir /var/lib/boot-service`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive
+			return runRestore(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.at, "at", "", "Point in time to restore, in RFC 3339 (default the newest backup)")
+	cmd.Flags().StringVar(&opts.dataDir, "data-dir", "", "Directory for file storage (default data_dir from the configuration file, or ./data)")
+	cmd.Flags().StringVar(&opts.backupDir, "backup-dir", "", "Directory to read backups from (default backup_dir)")
+	cmd.Flags().StringVar(&opts.backupS3URL, "backup-s3-url", "", "s3://bucket/prefix to read backups from (default backup_s3_url)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Report the changes without writing them")
+	return cmd
+}
+
+func runRestore(ctx context.Context, out io.Writer, opts restoreOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var at time.Time
+	if opts.at != "" {
+		parsed, err := time.Parse(time.RFC3339, opts.at)
+		if err != nil {
+			return fmt.Errorf("invalid --at %q: expected RFC 3339, such as 2026-10-17T06:00:00Z", opts.at)
+		}
+		at = parsed
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if opts.backupDir != "" || opts.backupS3URL != "" {
+		config.BackupDir, config.BackupS3URL = opts.backupDir, opts.backupS3URL
+	}
+	if err := validateBackupConfig(config); err != nil {
+		return err
+	}
+	store, err := newBackupStore(config)
+	if err != nil {
+		return err
+	}
+	if store == nil {
+		return fmt.Errorf("no backups configured: set backup_dir or backup_s3_url, or pass --backup-dir or --backup-s3-url")
+	}
+
+	info, err := backup.Find(ctx, store, at)
+	if err != nil {
+		return err
+	}
+	state, err := backup.Load(ctx, store, info.Name)
+	if err != nil {
+		return err
+	}
+
+	if opts.dataDir != "" || config.StorageType == "file" {
+		err = storage.InitFileBackend(stateDataDir(opts.dataDir))
+	} else {
+		var shared backend.Backend
+		if shared, err = newStorageBackend(ctx, config); err == nil {
+			defer shared.Close() //nolint:errcheck
+			storage.Init(shared)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %v", err)
+	}
+
+	fmt.Fprintf(out, "Restoring backup %s (taken %s)\n", info.Name, info.TakenAt.Format(time.RFC3339)) //nolint:errcheck
+	result, err := importMarked(ctx, "restore", state, snapshot.ImportOptions{Replace: true, DryRun: opts.dryRun})
+	printImportResult(out, result)
+	if err != nil {
+		return err
+	}
+	if opts.dryRun {
+		fmt.Fprintln(out, "Dry run: no changes written") //nolint:errcheck
+	}
+	return nil
+}
+
+// newBackupStore returns where backups are kept, or nil when backups are not
+// configured
+func newBackupStore(config Config) (backup.Store, error) {
+	switch {
+	case config.BackupDir != "":
+		return backup.NewDir(config.BackupDir)
+	case config.BackupS3URL != "":
+		presigner, err := artifacts.NewS3Presigner(s3Config(config))
+		if err != nil {
+			return nil, err
+		}
+		return backup.NewS3(config.BackupS3URL, presigner)
+	}
+	return nil, nil
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/backup"
+)
+
+func TestRestoreCommand(t *testing.T) {
+	ctx := context.Background()
+
+	sourceDir, backupDir := t.TempDir(), t.TempDir()
+	if err := storage.InitFileBackend(sourceDir); err != nil {
+		t.Fatalf("failed to initialize storage: %v", err)
+	}
+	node := &v1.Node{
+		Kind:     "Node",
+		Metadata: resource.Metadata{UID: "node-abc123", Name: "x0c0s0b0n0"},
+		Spec:     v1.NodeSpec{XName: "x0c0s0b0n0", BootMAC: "aa:bb:cc:dd:ee:01"},
+	}
+	if err := storage.SaveNode(ctx, node); err != nil {
+		t.Fatalf("failed to save node: %v", err)
+	}
+	store, err := backup.NewDir(backupDir)
+	if err != nil {
+		t.Fatalf("NewDir returned error: %v", err)
+	}
+	info, err := backup.NewManager(storage.Backend, store, backup.Retention{}, log.New(io.Discard, "", 0)).Take(ctx)
+	if err != nil {
+		t.Fatalf("Take returned error: %v", err)
+	}
+
+	// Restoring replaces everything stored since
+	targetDir := t.TempDir()
+	if err := storage.InitFileBackend(targetDir); err != nil {
+		t.Fatalf("failed to initialize storage: %v", err)
+	}
+	stray := &v1.Node{Kind: "Node", Metadata: resource.Metadata{UID: "node-stray", Name: "x0c0s1b0n0"},
+		Spec: v1.NodeSpec{XName: "x0c0s1b0n0", BootMAC: "aa:bb:cc:dd:ee:02"}}
+	if err := storage.SaveNode(ctx, stray); err != nil {
+		t.Fatalf("failed to save node: %v", err)
+	}
+
+	var out bytes.Buffer
+	opts := restoreOptions{dataDir: targetDir, backupDir: backupDir}
+	if err := runRestore(ctx, &out, opts); err != nil {
+		t.Fatalf("runRestore failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "Restoring backup "+info.Name) ||
+		!strings.Contains(out.String(), "Node: 1 created, 0 updated, 1 deleted") {
+		t.Errorf("unexpected restore summary:\n%s", out.String())
+	}
+	if _, err := storage.LoadNode(ctx, "node-abc123"); err != nil {
+		t.Errorf("restored node not found: %v", err)
+	}
+	if _, err := storage.LoadNode(ctx, "node-stray"); err == nil {
+		t.Error("node missing from the backup was kept")
+	}
+
+	opts.at = info.TakenAt.Add(-time.Second).Format(time.RFC3339)
+	if err := runRestore(ctx, &out, opts); !errors.Is(err, backup.ErrNoBackup) {
+		t.Errorf("restore before the first backup returned %v, want ErrNoBackup", err)
+	}
+	opts.at = "yesterday"
+	if err := runRestore(ctx, &out, opts); err == nil {
+		t.Error("restore accepted an invalid --at")
+	}
+}
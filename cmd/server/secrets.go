@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/openchami/boot-service/pkg/secrets"
+)
+
+// secretsOptions selects the local secret store the secrets command manages
+type secretsOptions struct {
+	file    string
+	keyFile string
+}
+
+// newSecretsCommand creates the secrets command, which manages the encrypted
+// local secret store that kernel parameters reference
+func newSecretsCommand() *cobra.Command {
+	opts := secretsOptions{}
+	cmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Manage secrets referenced by kernel parameters",
+		Long: `Manage the encrypted local secret store (secrets_file). Kernel parameters
+reference a secret with {{secret "name"}}; its value is inserted when a boot
+script is rendered and never appears in boot configurations, API responses,
+or the audit log. Changes apply to the running service without a restart,
+once cached scripts expire.`,
+		Example: `  openssl rand -base64 32 > /etc/boot-service/secrets.key
+  printf '%s' "$ROOT_HASH" | boot-service secrets set root-password
+  boot-service secrets list
+  boot-service secrets delete root-password`,
+	}
+	cmd.PersistentFlags().StringVar(&opts.file, "file", "", "Secret store file (default secrets_file from the configuration)")
+	cmd.PersistentFlags().StringVar(&opts.keyFile, "key-file", "", "Secret store key file (default secrets_key_file from the configuration)")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:          "set <name>",
+		Short:        "Store a secret read from standard input",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := opts.open()
+			if err != nil {
+				return err
+			}
+			value, err := readSecretValue(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			return store.Set(args[0], value)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:          "list",
+		Short:        "List stored secret names",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive
+			store, err := opts.open()
+			if err != nil {
+				return err
+			}
+			names, err := store.Names()
+			if err != nil {
+				return err
+			}
+			for _, name := range names {
+				fmt.Fprintln(cmd.OutOrStdout(), name) //nolint:errcheck
+			}
+			return nil
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:          "delete <name>",
+		Short:        "Delete a secret",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive
+			store, err := opts.open()
+			if err != nil {
+				return err
+			}
+			return store.Delete(args[0])
+		},
+	})
+	return cmd
+}
+
+// open opens the selected store, falling back to the configuration file
+func (o secretsOptions) open() (*secrets.LocalStore, error) {
+	file, keyFile := o.file, o.keyFile
+	if file == "" {
+		file = viper.GetString("secrets_file")
+	}
+	if keyFile == "" {
+		keyFile = viper.GetString("secrets_key_file")
+	}
+	if file == "" || keyFile == "" {
+		return nil, errors.New("--file and --key-file are required when secrets_file and secrets_key_file are not configured")
+	}
+	return openSecretStore(file, keyFile)
+}
+
+// openSecretStore opens the encrypted local secret store
+func openSecretStore(file, keyFile string) (*secrets.LocalStore, error) {
+	key, err := secrets.LoadKey(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return secrets.NewLocalStore(file, key)
+}
+
+// readSecretValue reads a secret from r, without the trailing newline a
+// terminal or echo adds
+func readSecretValue(r io.Reader) (string, error) {
+	data, err := io.ReadAll(bufio.NewReader(r))
+	if err != nil {
+		return "", err
+	}
+	value := strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+	if value == "" {
+		return "", errors.New("no secret value on standard input")
+	}
+	return value, nil
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSecretsCommand(t *testing.T) {
+	dir := t.TempDir()
+	file, keyFile := filepath.Join(dir, "secrets.json"), filepath.Join(dir, "secrets.key")
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32))
+	if err := os.WriteFile(keyFile, []byte(key+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(stdin string, args ...string) (string, error) {
+		cmd := newSecretsCommand()
+		var out bytes.Buffer
+		cmd.SetIn(strings.NewReader(stdin))
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(append(args, "--file", file, "--key-file", keyFile))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	if _, err := run("hunter2\n", "set", "root-password"); err != nil {
+		t.Fatalf("secrets set failed: %v", err)
+	}
+	if _, err := run("", "set", "empty"); err == nil {
+		t.Error("expected error setting an empty secret")
+	}
+	if out, err := run("", "list"); err != nil || out != "root-password\n" {
+		t.Errorf("secrets list = %q, %v", out, err)
+	}
+
+	store, err := openSecretStore(file, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := store.Secret(context.Background(), "root-password"); err != nil || value != "hunter2" {
+		t.Errorf("stored secret = %q, %v", value, err)
+	}
+
+	if _, err := run("", "delete", "root-password"); err != nil {
+		t.Fatalf("secrets delete failed: %v", err)
+	}
+	if out, err := run("", "list"); err != nil || out != "" {
+		t.Errorf("secrets list after delete = %q, %v", out, err)
+	}
+}
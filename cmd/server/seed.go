@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+	"github.com/spf13/cobra"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/snapshot"
+)
+
+// seedTime is the creation time of all seeded resources, so that seeding is
+// fully deterministic
+var seedTime = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// seedOptions configures the seed command
+type seedOptions struct {
+	nodes   int
+	configs int
+	dataDir string
+	reset   bool
+}
+
+// newSeedCommand creates the seed command, which fills storage with demo data
+func newSeedCommand() *cobra.Command {
+	opts := seedOptions{}
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Populate storage with demo nodes and boot configurations",
+		Long: `Populate the storage backend with deterministic demo nodes and boot
+configurations for local development and integration tests. The same flags
+always produce the same resources, including UIDs, so seeding again updates
+rather than duplicates them.
+
+The first node is x1000c0s0b0n0 (NID 123, MAC 00:1b:63:84:45:e6) and the first
+configuration, test-direct, targets it; the legacy API integration tests
+expect both. Further nodes continue the slot, NID, and MAC sequences. Further
+configurations are demo-compute (group compute), demo-default (catch-all),
+and demo-profile-N (group compute in profile profile-N).`,
+		Example: `  boot-service seed --nodes 10 --configs 2
+  boot-service seed --nodes 100 --configs 4 --reset --data-dir /tmp/boot-data`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive
+			return runSeed(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.nodes, "nodes", 10, "Number of demo nodes")
+	cmd.Flags().IntVar(&opts.configs, "configs", 2, "Number of demo boot configurations")
+	cmd.Flags().StringVar(&opts.dataDir, "data-dir", "", "Directory for file storage (default data_dir from the configuration file, or ./data)")
+	cmd.Flags().BoolVar(&opts.reset, "reset", false, "Delete all other nodes, boot configurations, BMCs, and artifact records")
+	return cmd
+}
+
+func runSeed(ctx context.Context, out io.Writer, opts seedOptions) error {
+	if opts.nodes < 0 || opts.configs < 0 {
+		return errors.New("--nodes and --configs must not be negative")
+	}
+	if opts.nodes > 0xffff {
+		return fmt.Errorf("--nodes must be at most %d", 0xffff)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := storage.InitFileBackend(stateDataDir(opts.dataDir)); err != nil {
+		return fmt.Errorf("failed to initialize storage: %v", err)
+	}
+
+	result, err := importMarked(ctx, "seed", demoSnapshot(opts.nodes, opts.configs), snapshot.ImportOptions{Replace: opts.reset})
+	if err != nil {
+		return err
+	}
+	for _, resourceType := range []string{"Node", "BootConfiguration"} {
+		counts := result[resourceType]
+		fmt.Fprintf(out, "%s: %d created, %d updated, %d deleted\n", //nolint:errcheck
+			resourceType, counts.Created, counts.Updated, counts.Deleted)
+	}
+	return nil
+}
+
+// demoSnapshot builds the demo resources for the seed command
+func demoSnapshot(nodeCount, configCount int) *snapshot.Snapshot {
+	state := &snapshot.Snapshot{Version: snapshot.FormatVersion, ExportedAt: seedTime}
+
+	for i := 0; i < nodeCount; i++ {
+		xname := fmt.Sprintf("x1000c0s%db0n0", i)
+		mac := fmt.Sprintf("00:1b:63:84:%02x:%02x", (0x45e6+i)>>8&0xff, (0x45e6+i)&0xff)
+		groups := []string{"compute"}
+		if i%2 == 1 {
+			groups = append(groups, "gpu")
+		}
+		state.Nodes = append(state.Nodes, v1.Node{
+			APIVersion: "boot.openchami.io/v1",
+			Kind:       "Node",
+			Metadata:   seedMetadata("node", i, xname),
+			Spec: v1.NodeSpec{
+				XName:      xname,
+				NID:        int32(123 + i),
+				BootMAC:    mac,
+				Role:       "Compute",
+				Hostname:   fmt.Sprintf("nid%06d", 123+i),
+				Interfaces: []v1.NodeInterface{{MAC: mac, Type: "management"}},
+				Groups:     groups,
+			},
+		})
+	}
+
+	for j := 0; j < configCount; j++ {
+		spec := v1.BootConfigurationSpec{
+			Kernel: "http://demo.openchami.local/images/compute/vmlinuz",
+			Initrd: "http://demo.openchami.local/images/compute/initrd.img",
+			Params: "console=ttyS0,115200 root=live:http://demo.openchami.local/images/compute/rootfs nid={{.NID}}",
+		}
+		var name string
+		switch j {
+		case 0:
+			name = "test-direct"
+			spec.Hosts = []string{"x1000c0s0b0n0"}
+			spec.Priority = 100
+		case 1:
+			name = "demo-compute"
+			spec.Groups = []string{"compute"}
+			spec.Priority = 50
+		case 2:
+			name = "demo-default"
+			spec.Kernel = "http://demo.openchami.local/images/default/vmlinuz"
+			spec.Initrd = "http://demo.openchami.local/images/default/initrd.img"
+			spec.Params = "console=ttyS0,115200"
+			spec.Priority = 1
+		default:
+			name = fmt.Sprintf("demo-profile-%d", j)
+			spec.Groups = []string{"compute"}
+			spec.Profile = fmt.Sprintf("profile-%d", j)
+			spec.Params += " rd.debug"
+		}
+		state.BootConfigurations = append(state.BootConfigurations, v1.BootConfiguration{
+			APIVersion: "boot.openchami.io/v1",
+			Kind:       "BootConfiguration",
+			Metadata:   seedMetadata("bootconfiguration", j, name),
+			Spec:       spec,
+		})
+	}
+
+	return state
+}
+
+// seedMetadata returns metadata with a UID derived from the resource's index
+// instead of a random one
+func seedMetadata(prefix string, index int, name string) resource.Metadata {
+	return resource.Metadata{
+		Name:      name,
+		UID:       fmt.Sprintf("%s-%08x", prefix, index+1),
+		Labels:    map[string]string{"boot.openchami.io/seed": "demo"},
+		CreatedAt: seedTime,
+		UpdatedAt: seedTime,
+	}
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+)
+
+func TestRunSeed(t *testing.T) {
+	ctx := context.Background()
+	dataDir := t.TempDir()
+
+	var out bytes.Buffer
+	if err := runSeed(ctx, &out, seedOptions{nodes: 10, configs: 4, dataDir: dataDir}); err != nil {
+		t.Fatalf("runSeed failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Node: 10 created") || !strings.Contains(out.String(), "BootConfiguration: 4 created") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+
+	nodes, _ := storage.LoadAllNodes(ctx)
+	configs, _ := storage.LoadAllBootConfigurations(ctx)
+	if len(nodes) != 10 || len(configs) != 4 {
+		t.Fatalf("got %d nodes and %d configurations, want 10 and 4", len(nodes), len(configs))
+	}
+
+	// The legacy API tests look up the first node by xname, MAC, and NID and
+	// expect the test-direct configuration
+	resources := &bootscript.StaticResources{}
+	for _, node := range nodes {
+		resources.Nodes = append(resources.Nodes, *node)
+	}
+	for _, config := range configs {
+		resources.BootConfigurations = append(resources.BootConfigurations, *config)
+	}
+	controller := bootscript.NewBootScriptControllerWithReader(resources, log.New(io.Discard, "", 0))
+	for _, identifier := range []string{"x1000c0s0b0n0", "00:1B:63:84:45:E6", "123"} {
+		preview, err := controller.PreviewBootScript(ctx, identifier, "")
+		if err != nil || preview.MatchedConfiguration != "test-direct" {
+			t.Errorf("%s: got configuration %q (err %v), want test-direct", identifier, preview.MatchedConfiguration, err)
+		}
+	}
+
+	// Seeding again with fewer resources and --reset yields the same UIDs
+	if err := runSeed(ctx, &out, seedOptions{nodes: 2, configs: 1, dataDir: dataDir, reset: true}); err != nil {
+		t.Fatalf("runSeed with reset failed: %v", err)
+	}
+	uids, _ := storage.ListNodeUIDs(ctx)
+	if want := []string{"node-00000001", "node-00000002"}; !reflect.DeepEqual(uids, want) {
+		t.Errorf("got node UIDs %v, want %v", uids, want)
+	}
+}
+
+func TestRunSeed_RejectsNegativeCounts(t *testing.T) {
+	if err := runSeed(context.Background(), &bytes.Buffer{}, seedOptions{nodes: -1, dataDir: t.TempDir()}); err == nil {
+		t.Fatal("expected error for negative node count")
+	}
+}
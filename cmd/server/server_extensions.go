@@ -6,23 +6,122 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
 
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/accessstats"
+	"github.com/openchami/boot-service/pkg/activity"
+	"github.com/openchami/boot-service/pkg/admission"
+	"github.com/openchami/boot-service/pkg/apikeys"
+	"github.com/openchami/boot-service/pkg/artifacts"
+	"github.com/openchami/boot-service/pkg/audit"
+	"github.com/openchami/boot-service/pkg/auth"
+	"github.com/openchami/boot-service/pkg/backend"
+	"github.com/openchami/boot-service/pkg/backup"
+	"github.com/openchami/boot-service/pkg/bmcdiscovery"
+	"github.com/openchami/boot-service/pkg/bmctelemetry"
+	"github.com/openchami/boot-service/pkg/bootloop"
+	"github.com/openchami/boot-service/pkg/bootonce"
 	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/clients/cloudinit"
 	"github.com/openchami/boot-service/pkg/clients/hsm"
+	"github.com/openchami/boot-service/pkg/clients/imageservice"
+	"github.com/openchami/boot-service/pkg/clients/redfish"
 	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/cordon"
+	"github.com/openchami/boot-service/pkg/dhcp"
+	"github.com/openchami/boot-service/pkg/discovery"
+	"github.com/openchami/boot-service/pkg/fallback"
+	"github.com/openchami/boot-service/pkg/gitops"
+	"github.com/openchami/boot-service/pkg/groupsync"
 	"github.com/openchami/boot-service/pkg/handlers/boot"
+	"github.com/openchami/boot-service/pkg/hsmsync"
+	"github.com/openchami/boot-service/pkg/kubernetes"
+	"github.com/openchami/boot-service/pkg/leader"
+	"github.com/openchami/boot-service/pkg/maintenance"
+	"github.com/openchami/boot-service/pkg/nodeimport"
+	"github.com/openchami/boot-service/pkg/paramprofiles"
+	"github.com/openchami/boot-service/pkg/policy"
+	"github.com/openchami/boot-service/pkg/ratelimit"
+	"github.com/openchami/boot-service/pkg/recording"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/schemas"
+	"github.com/openchami/boot-service/pkg/sharedstate"
+	"github.com/openchami/boot-service/pkg/signing"
+	"github.com/openchami/boot-service/pkg/tenancy"
+	"github.com/openchami/boot-service/pkg/timeline"
+	"github.com/openchami/boot-service/pkg/trash"
+	"github.com/openchami/boot-service/pkg/userdata"
+	"github.com/openchami/boot-service/pkg/utilityboot"
+	"github.com/openchami/boot-service/pkg/validation"
+	"github.com/openchami/boot-service/pkg/vault"
+	"github.com/openchami/boot-service/pkg/workflow"
 )
 
 // registerCustomServerIntegrations keeps generated route wiring and legacy compatibility
 // route setup together outside runServe's core startup flow.
-func registerCustomServerIntegrations(r chi.Router, config Config, hsmClient *hsm.HSMClient, ctx context.Context) error {
+func registerCustomServerIntegrations(r chi.Router, config Config, hsmClient *hsm.HSMClient, vaultClient *vault.Client, watches *resourcewatch.Hub, bootEvents *activity.Feed, deleted *trash.Bin, specSchemas *schemas.Registry, metrics *Metrics, reloader *configReloader, keys *apikeys.Store, ctx context.Context) error {
+	// A built-in template that renders a broken script would fail every boot
+	if err := bootscript.CheckTemplates(); err != nil {
+		return fmt.Errorf("built-in boot script templates: %w", err)
+	}
+
+	// Deletes of kept types move resources into the bin beneath the watch,
+	// so watchers and the audit log see an ordinary delete
+	base := storage.Backend
+	if deleted != nil {
+		base = deleted.Wrap(base)
+	}
+	// Report every resource write, whichever API made it, so dependent state
+	// such as cached boot scripts is invalidated immediately.
+	changes := resourcewatch.NewBackend(base)
+	changes.Subscribe(watches.Publish)
+	// Shared storage such as etcd also reports the writes of other replicas,
+	// so their caches and watchers stay current here too
+	if source, ok := storage.Backend.(backend.Watcher); ok {
+		if err := changes.Follow(ctx, source); err != nil {
+			return fmt.Errorf("failed to watch storage: %w", err)
+		}
+	}
+	if config.TenancyEnabled {
+		// Tenant-scoped requests only see and write their own resources
+		storage.Init(tenancy.NewBackend(changes))
+	} else {
+		storage.Init(changes)
+	}
+
+	// Site policy hooks review node and boot configuration writes from every API.
+	var hooks []admission.Hook
+	if config.AdmissionWebhookURL != "" {
+		webhookURL, _ := url.Parse(config.AdmissionWebhookURL)
+		hooks = append(hooks, admission.NewWebhook(webhookURL.Host, config.AdmissionWebhookURL,
+			time.Duration(config.AdmissionWebhookTimeoutMS)*time.Millisecond, config.AdmissionWebhookFailOpen,
+			log.New(os.Stdout, "admission: ", log.LstdFlags)))
+		log.Printf("Admission webhook enabled at %s (fail open: %v)", config.AdmissionWebhookURL, config.AdmissionWebhookFailOpen)
+	}
+	var opa *policy.OPA
+	policyLogger := log.New(os.Stdout, "policy: ", log.LstdFlags)
+	if config.OPAURL != "" {
+		opa = policy.NewOPA(config.OPAURL, time.Duration(config.OPATimeoutMS)*time.Millisecond)
+		if config.OPAAdmissionPath != "" {
+			hooks = append(hooks, policy.NewAdmissionHook(opa, config.OPAAdmissionPath, config.OPAFailOpen, policyLogger))
+			log.Printf("Evaluating resource writes with OPA policy %s at %s", config.OPAAdmissionPath, config.OPAURL)
+		}
+	}
 	// Register UID prefixes used by generated handlers when creating resources.
 	if err := registerResourcePrefixes(); err != nil {
 		return fmt.Errorf("failed to register resource prefixes: %w", err)
@@ -30,16 +129,259 @@ func registerCustomServerIntegrations(r chi.Router, config Config, hsmClient *hs
 
 	// Register generated routes (modern API) - middleware already applied above.
 	RegisterGeneratedRoutes(r)
+	// Bulk node imports from CSV and SLS inventories
+	nodeimport.NewHandler(storage.Backend).RegisterRoutes(r)
 
-	bootClient, err := client.NewClient(fmt.Sprintf("http://%s:%d", config.Host, config.Port),
-		&http.Client{Timeout: 30 * time.Second}, client.DefaultLogger())
+	bootClient, err := newResourceClient(config)
 	if err != nil {
 		return fmt.Errorf("failed to create boot script API client: %v", err)
 	}
 
+	readiness := registerReadiness(r, config, bootClient, hsmClient)
+	// Static DHCP reservations rendered from the same node data as boot scripts
+	dhcp.NewHandler(bootClient).RegisterRoutes(r)
+	diag := &diagnostics{reloader: reloader, readiness: readiness, watches: watches}
+
 	logger := log.New(os.Stdout, "boot: ", log.LstdFlags)
 
+	// Artifact registry shares the resource storage backend.
+	artifactLogger := log.New(os.Stdout, "artifacts: ", log.LstdFlags)
+	artifactRegistry := artifacts.NewRegistry(storage.Backend, nil, artifactLogger)
+	if config.S3AccessKeyID != "" {
+		presigner, err := artifacts.NewS3Presigner(s3Config(config))
+		if err != nil {
+			return fmt.Errorf("failed to configure S3 artifact source: %w", err)
+		}
+		artifactRegistry.SetS3Presigner(presigner)
+		log.Printf("S3 artifact source enabled (presigned URLs valid for %ds)", config.S3PresignExpiry)
+	}
+	artifacts.NewHandler(artifactRegistry).RegisterRoutes(r)
+
+	// Boot scripts reference upstream artifact URLs unless local serving is enabled.
+	var artifactResolver bootscript.ArtifactResolver = artifactRegistry
+	if config.ArtifactCacheEnabled {
+		baseURL, err := artifactBaseURL(config)
+		if err != nil {
+			return err
+		}
+		cacheDir := config.ArtifactCacheDir
+		if cacheDir == "" {
+			cacheDir = filepath.Join(config.DataDir, "artifact-cache")
+		}
+		artifactCache, err := artifacts.NewLocalCache(artifactRegistry, cacheDir, baseURL, nil, artifactLogger)
+		if err != nil {
+			return fmt.Errorf("failed to create artifact cache: %w", err)
+		}
+		artifactCache.RegisterRoutes(r)
+		artifactResolver = artifactCache
+
+		go func() {
+			if err := artifactCache.Prefetch(ctx); err != nil {
+				artifactLogger.Printf("Artifact prefetch incomplete: %v", err)
+			}
+		}()
+		log.Printf("Local artifact serving enabled at %s/artifacts (cache: %s)", baseURL, cacheDir)
+	}
+
+	// Parameter profiles share the resource storage backend as well.
+	paramProfiles := paramprofiles.NewStore(storage.Backend, log.New(os.Stdout, "paramprofiles: ", log.LstdFlags))
+	paramprofiles.NewHandler(paramProfiles).RegisterRoutes(r)
+
+	// So does cloud-init user-data attached to roles, groups, and nodes
+	userData := userdata.NewStore(storage.Backend, log.New(os.Stdout, "userdata: ", log.LstdFlags))
+	userdata.NewHandler(userData).RegisterRoutes(r)
+
+	redisClient, err := newRedisClient(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	scriptCache := newScriptCache(ctx, config, redisClient)
+	reloader.OnChange(validationProfileKeys, func(config Config) {
+		profile, _ := validationProfile(config) // validated
+		validation.SetProfile(profile)
+		log.Printf("Validation profile is now %s", profile)
+	})
+	reloader.OnChange([]string{"script_cache_ttl", "script_cache_max_entries", "script_cache_max_bytes"}, func(config Config) {
+		ttl := time.Duration(config.ScriptCacheTTL) * time.Second
+		switch cache := scriptCache.(type) {
+		case *bootscript.ScriptCache:
+			cache.SetLimits(ttl, config.ScriptCacheMaxEntries, config.ScriptCacheMaxBytes)
+		case *sharedstate.RedisScriptCache:
+			cache.SetTTL(ttl)
+		}
+	})
+	if metrics != nil {
+		if err := registerScriptCacheMetrics(metrics.registry, scriptCache); err != nil {
+			return fmt.Errorf("failed to register script cache metrics: %w", err)
+		}
+	}
+
+	// Only the leader runs background sync; every replica serves reads.
+	var lock leader.Lock = leader.NewLocalLock()
+	if config.LeaderElectionEnabled {
+		lock = sharedstate.NewRedisLock(redisClient, config.RedisKeyPrefix)
+	}
+	instanceID := config.InstanceID
+	if instanceID == "" {
+		instanceID = leader.DefaultID()
+	}
+	elector := leader.NewElector(lock, instanceID, time.Duration(config.LeaderLeaseTTL)*time.Second,
+		log.New(os.Stdout, "leader: ", log.LstdFlags))
+	go elector.Run(ctx)
+	leader.NewHandler(elector).RegisterRoutes(r)
+	diag.elector = elector
+	if config.LeaderElectionEnabled {
+		log.Printf("Leader election enabled as instance %s (lease: %ds)", instanceID, config.LeaderLeaseTTL)
+	}
+
+	// Record every write for change control. Records are stored beneath the
+	// watched backend so they are not themselves audited.
+	if config.AuditEnabled {
+		auditLog, err := newAuditLog(ctx, config, changes.StorageBackend)
+		if err != nil {
+			return fmt.Errorf("failed to configure audit log: %w", err)
+		}
+		changes.Subscribe(auditLog.HandleResourceChange)
+		audit.NewHandler(auditLog).RegisterRoutes(r)
+		if config.AuditRetentionDays > 0 {
+			retention := time.Duration(config.AuditRetentionDays) * 24 * time.Hour
+			go elector.RunWhileLeader(ctx, func(ctx context.Context) {
+				auditLog.RunRetention(ctx, retention, time.Hour)
+			})
+		}
+		if metrics != nil {
+			if err := registerAuditMetrics(metrics.registry, auditLog); err != nil {
+				return fmt.Errorf("failed to register audit metrics: %w", err)
+			}
+		}
+		log.Printf("Audit log enabled (retention: %d days)", config.AuditRetentionDays)
+	}
+
+	// Scheduled backups of the stored resources, for disaster recovery with
+	// the restore command. Only the leader takes them.
+	backupStore, err := newBackupStore(config)
+	if err != nil {
+		return fmt.Errorf("failed to configure backups: %w", err)
+	}
+	if backupStore != nil {
+		backups := backup.NewManager(changes.StorageBackend, backupStore, backup.Retention{
+			Count:  config.BackupRetention,
+			MaxAge: time.Duration(config.BackupRetentionDays) * 24 * time.Hour,
+		}, log.New(os.Stdout, "backup: ", log.LstdFlags))
+		interval := time.Duration(config.BackupInterval) * time.Minute
+		go elector.RunWhileLeader(ctx, func(ctx context.Context) {
+			backups.Run(ctx, interval)
+		})
+		backup.NewHandler(backups).RegisterRoutes(r)
+		log.Printf("Backups enabled every %d minutes (keeping %d, %d days)", config.BackupInterval, config.BackupRetention, config.BackupRetentionDays)
+	}
+
+	// Nodes and boot configurations declared in a Git repository. Only the
+	// leader syncs on the interval; syncs write through the API backend, so
+	// they are watched and audited like any other write.
+	if config.GitOpsURL != "" {
+		repo, err := gitops.NewRepo(gitops.RepoConfig{
+			URL:               config.GitOpsURL,
+			Branch:            config.GitOpsBranch,
+			Dir:               config.GitOpsDir,
+			SSHKeyFile:        config.GitOpsSSHKeyFile,
+			SSHKnownHostsFile: config.GitOpsSSHKnownHostsFile,
+			Username:          config.GitOpsUsername,
+			Password:          config.GitOpsPassword,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to configure GitOps: %w", err)
+		}
+		syncer := gitops.NewSyncer(repo, storage.Backend, gitops.Options{
+			Path:  config.GitOpsPath,
+			Mode:  config.GitOpsMode,
+			Prune: config.GitOpsPrune,
+		}, log.New(os.Stdout, "gitops: ", log.LstdFlags))
+		interval := time.Duration(config.GitOpsInterval) * time.Second
+		go elector.RunWhileLeader(ctx, func(ctx context.Context) {
+			syncer.Run(ctx, interval)
+		})
+		gitops.NewHandler(syncer).RegisterRoutes(r)
+		log.Printf("GitOps enabled from %s (branch: %s, mode: %s, prune: %v)", repo.Redacted(), config.GitOpsBranch, config.GitOpsMode, config.GitOpsPrune)
+	}
+
+	// Nodes and boot configurations declared as Kubernetes custom resources.
+	// Only the leader watches and reconciles them.
+	if config.KubernetesEnabled {
+		operator, err := kubernetes.NewOperator(kubernetes.Config{
+			APIURL:    config.KubernetesAPIURL,
+			TokenFile: config.KubernetesTokenFile,
+			CAFile:    config.KubernetesCAFile,
+			Namespace: config.KubernetesNamespace,
+		}, storage.Backend, log.New(os.Stdout, "kubernetes: ", log.LstdFlags))
+		if err != nil {
+			return fmt.Errorf("failed to configure the Kubernetes operator: %w", err)
+		}
+		resync := time.Duration(config.KubernetesResyncInterval) * time.Second
+		go elector.RunWhileLeader(ctx, func(ctx context.Context) {
+			operator.Run(ctx, resync)
+		})
+		kubernetes.NewHandler(operator).RegisterRoutes(r)
+		namespace := config.KubernetesNamespace
+		if namespace == "" {
+			namespace = "all namespaces"
+		}
+		log.Printf("Kubernetes operator enabled for %s (namespace: %s)", operator.APIURL(), namespace)
+	}
+
+	if deleted != nil {
+		// Restores are validated and write through the API backend, so they
+		// are checked, scoped, and reported like any other create
+		var restoreSchemas *schemas.Registry
+		if config.SpecSchemaValidation {
+			restoreSchemas = specSchemas
+		}
+		trash.NewHandler(deleted, storage.Backend, validateRestore(restoreSchemas)).RegisterRoutes(r)
+		go elector.RunWhileLeader(ctx, func(ctx context.Context) {
+			deleted.RunPurge(ctx, time.Hour)
+		})
+		log.Printf("Soft delete enabled (retention: %d days)", config.SoftDeleteRetentionDays)
+	}
+
+	// BMCs are discovered by scanning for Redfish services. The leader
+	// scans on the interval; any replica runs a scan requested at
+	// /admin/bmc-discovery.
+	if config.BMCDiscoveryEnabled {
+		store, ok := bootClient.(bmcdiscovery.Store)
+		if !ok {
+			return fmt.Errorf("BMC discovery requires a resource client that manages BMCs")
+		}
+		prefixes, _ := bmcdiscovery.ParsePrefixes(config.BMCDiscoveryRanges) // validated
+		scanner := bmcdiscovery.NewScanner(store, nil, bmcdiscovery.Config{
+			Prefixes:    prefixes,
+			Interval:    time.Duration(config.BMCDiscoveryInterval) * time.Minute,
+			Port:        config.BMCDiscoveryPort,
+			Username:    config.BMCDiscoveryUsername,
+			Password:    config.BMCDiscoveryPassword,
+			Insecure:    config.BMCDiscoveryInsecure,
+			Concurrency: config.BMCDiscoveryConcurrency,
+			Timeout:     time.Duration(config.BMCDiscoveryTimeoutMS) * time.Millisecond,
+			MACDistance: config.BMCDiscoveryMACDistance,
+		}, log.New(os.Stdout, "bmc-discovery: ", log.LstdFlags))
+		bmcdiscovery.NewHandler(scanner).RegisterRoutes(r)
+		if len(prefixes) > 0 {
+			go elector.RunWhileLeader(ctx, scanner.Start)
+		}
+		log.Printf("BMC discovery enabled (ranges: %q, interval: %d minutes)", config.BMCDiscoveryRanges, config.BMCDiscoveryInterval)
+	}
+
 	var bootHandler *boot.Handler
+	var scriptController *bootscript.BootScriptController
+	var cloudInit *cloudinit.Client
+	if config.CloudInitURL != "" {
+		cloudInit = cloudinit.NewClient(cloudinit.Config{
+			BaseURL:   config.CloudInitURL,
+			AuthToken: config.CloudInitToken,
+			Timeout:   10 * time.Second,
+		}, log.New(os.Stdout, "cloud-init: ", log.LstdFlags))
+		cloudInit.SetUserData(userData)
+	}
 
 	if hsmClient != nil {
 		// Use FlexibleBootScriptController with HSM provider.
@@ -48,6 +390,7 @@ func registerCustomServerIntegrations(r chi.Router, config Config, hsmClient *hs
 		hsmIntegrationConfig.HSMConfig.Timeout = 30 * time.Second
 		hsmIntegrationConfig.SyncEnabled = config.HSMSyncEnabled
 		hsmIntegrationConfig.SyncInterval = time.Duration(config.HSMSyncInterval) * time.Minute
+		hsmIntegrationConfig.ConflictPolicy, _ = hsm.ParseConflictPolicy(config.HSMSyncConflictPolicy) // validated
 
 		providerConfig := bootscript.ProviderConfig{
 			Type:      "hsm",
@@ -56,22 +399,382 @@ func registerCustomServerIntegrations(r chi.Router, config Config, hsmClient *hs
 		}
 
 		controllerLogger := log.New(os.Stdout, "bootscript: ", log.LstdFlags)
-		flexController, err := bootscript.NewFlexibleBootScriptController(*bootClient, providerConfig, controllerLogger)
+		flexController, err := bootscript.NewFlexibleBootScriptController(bootClient, providerConfig, controllerLogger)
 		if err != nil {
 			return fmt.Errorf("failed to create flexible controller with HSM: %v", err)
 		}
+		flexController.SetArtifactResolver(artifactResolver)
+		flexController.SetScriptCache(scriptCache)
+		flexController.SetBudget(bootScriptBudget(config))
+		reloader.OnChange(bootScriptBudgetKeys, func(config Config) {
+			flexController.SetBudget(bootScriptBudget(config))
+		})
+		changes.Subscribe(flexController.HandleResourceChange)
+		if cloudInit != nil {
+			// Synced nodes are registered with the cloud-init service
+			flexController.SetSyncObserver(func(ctx context.Context, nodes []v1.NodeSpec) {
+				if err := cloudInit.RegisterNodes(ctx, nodes); err != nil {
+					log.Printf("Failed to register nodes with cloud-init: %v", err)
+				}
+			})
+		}
 
 		// Start background sync worker if enabled.
+		reloader.OnChange([]string{"hsm_sync_interval"}, func(config Config) {
+			flexController.SetSyncInterval(time.Duration(config.HSMSyncInterval) * time.Minute)
+		})
+
 		if config.HSMSyncEnabled {
-			go flexController.StartBackgroundSync(ctx)
+			go elector.RunWhileLeader(ctx, flexController.StartBackgroundSync)
 			log.Printf("HSM background sync enabled (interval: %d minutes)", config.HSMSyncInterval)
 		}
+		// Report, run, and pause syncs, and list nodes that diverge from HSM,
+		// at /admin/sync. A pause is stored beneath the watched backend so
+		// whichever replica leads honors it.
+		if integration, ok := flexController.HSMIntegration(); ok {
+			reloader.OnChange([]string{"hsm_sync_conflict_policy"}, func(config Config) {
+				policy, _ := hsm.ParseConflictPolicy(config.HSMSyncConflictPolicy)
+				integration.SetConflictPolicy(policy)
+			})
+			// Local edits that differ from HSM stream to dashboards with
+			// boot activity
+			integration.SetConflictObserver(func(conflict hsm.Conflict) {
+				bootEvents.Publish(hsmsync.ConflictEvent(conflict))
+			})
+			syncControl := hsmsync.NewControl(integration, changes.StorageBackend, log.New(os.Stdout, "hsm-sync: ", log.LstdFlags))
+			hsmsync.NewHandler(syncControl).RegisterRoutes(r)
+		}
+
+		// Nodes created here, manually or by discovery, are added to HSM.
+		// Each replica pushes the nodes written through it.
+		if config.HSMPushEnabled {
+			pusher := hsm.NewPusher(hsmClient, log.New(os.Stdout, "hsm-push: ", log.LstdFlags))
+			changes.Subscribe(pusher.HandleResourceChange)
+			go pusher.Run(ctx)
+			log.Printf("HSM push of locally created nodes enabled")
+		}
 
-		bootHandler = boot.NewHandlerWithController(*bootClient, flexController, logger)
+		// Node groups follow the inventory's groups, so boot configurations
+		// targeting a group track membership changes. The leader syncs on
+		// the interval; any replica runs a sync requested at
+		// /admin/group-sync.
+		if config.GroupSyncEnabled {
+			groupSyncer := groupsync.NewSyncer(hsmClient, bootClient, time.Duration(config.GroupSyncInterval)*time.Minute,
+				log.New(os.Stdout, "group-sync: ", log.LstdFlags))
+			groupsync.NewHandler(groupSyncer).RegisterRoutes(r)
+			go elector.RunWhileLeader(ctx, groupSyncer.Start)
+			log.Printf("Inventory group sync enabled (interval: %d minutes)", config.GroupSyncInterval)
+		}
+
+		bootHandler = boot.NewHandlerWithController(bootClient, flexController, logger)
+		scriptController = flexController.BootScriptController
+		diag.provider = flexController.GetProviderStats
 	} else {
 		// Use standard controller with local storage.
-		bootHandler = boot.NewHandler(*bootClient, logger)
+		controller := bootscript.NewBootScriptController(bootClient, logger)
+		controller.SetArtifactResolver(artifactResolver)
+		controller.SetScriptCache(scriptCache)
+		controller.SetBudget(bootScriptBudget(config))
+		reloader.OnChange(bootScriptBudgetKeys, func(config Config) {
+			controller.SetBudget(bootScriptBudget(config))
+		})
+		changes.Subscribe(controller.HandleResourceChange)
+		bootHandler = boot.NewHandlerWithController(bootClient, controller, logger)
+		scriptController = controller
+	}
+	scriptController.SetParamProfileResolver(paramProfiles)
+	if config.ImageServiceURL != "" {
+		scriptController.SetImageResolver(imageservice.NewClient(imageservice.Config{
+			BaseURL:   config.ImageServiceURL,
+			AuthToken: config.ImageServiceToken,
+			Timeout:   10 * time.Second,
+			CacheTTL:  time.Duration(config.ImageServiceCacheTTL) * time.Second,
+		}, log.New(os.Stdout, "images: ", log.LstdFlags)))
+		log.Printf("Image references resolved with the image service at %s", config.ImageServiceURL)
+	}
+	if cloudInit != nil {
+		scriptController.SetCloudInitSeed(cloudInit.SeedURL())
+		log.Printf("Pointing nodes at the cloud-init service at %s", cloudInit.SeedURL())
+	}
+	scriptController.SetScoring(matchScoring(config))
+	reloader.OnChange(matchScoringKeys, func(config Config) {
+		scriptController.SetScoring(matchScoring(config))
+	})
+	if config.BootConfigurationConflicts != "off" {
+		hooks = append(hooks, bootscript.NewConflictHook(scriptController, config.BootConfigurationConflicts == "reject",
+			log.New(os.Stdout, "conflicts: ", log.LstdFlags)))
+		log.Printf("Checking boot configuration writes for conflicts (mode: %s)", config.BootConfigurationConflicts)
+	}
+	if len(hooks) > 0 {
+		admission.SetDefault(admission.NewChain(func(ctx context.Context, kind, uid string) (json.RawMessage, error) {
+			return storage.Backend.Load(ctx, kind, uid)
+		}, hooks...))
+	}
+	if opa != nil && config.OPABootScriptPath != "" {
+		scriptController.SetBootPolicy(policy.NewBootPolicy(opa, config.OPABootScriptPath, config.OPAFailOpen, policyLogger))
+		log.Printf("Evaluating boot script requests with OPA policy %s at %s", config.OPABootScriptPath, config.OPAURL)
+	}
+
+	if config.SecretsFile != "" {
+		store, err := openSecretStore(config.SecretsFile, config.SecretsKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to open secret store: %w", err)
+		}
+		scriptController.SetSecretStore(store)
+		log.Printf("Kernel parameter secrets enabled (store: %s)", config.SecretsFile)
+	} else if config.VaultSecretsPath != "" {
+		scriptController.SetSecretStore(vault.NewSecretStore(vaultClient, config.VaultSecretsPath))
+		log.Printf("Kernel parameter secrets enabled (Vault path: %s)", config.VaultSecretsPath)
+	}
+
+	// Maintenance mode serves held nodes a hold or local-disk script instead
+	// of their configuration. It is restored from storage across restarts
+	// and follows the switches made through other replicas.
+	maintenanceSwitch := maintenance.NewSwitch(storage.Backend, log.New(os.Stdout, "maintenance: ", log.LstdFlags))
+	if err := maintenanceSwitch.Load(ctx); err != nil {
+		return err
+	}
+	holdScript, err := loadHoldScript(config.MaintenanceHoldScriptFile)
+	if err != nil {
+		return err
+	}
+	changes.Subscribe(maintenanceSwitch.HandleResourceChange)
+	scriptController.SetMaintenance(maintenanceSwitch, holdScript)
+	maintenance.NewHandler(maintenanceSwitch).RegisterRoutes(r)
+	diag.maintenance = maintenanceSwitch
+
+	// The fallback policy says what unknown nodes and nodes without a
+	// matching configuration boot
+	fallbackStore := fallback.NewStore(storage.Backend, log.New(os.Stdout, "fallback: ", log.LstdFlags))
+	if err := fallbackStore.Load(ctx); err != nil {
+		return err
+	}
+	changes.Subscribe(fallbackStore.HandleResourceChange)
+	scriptController.SetFallbackPolicy(fallbackStore)
+	fallback.NewHandler(fallbackStore).RegisterRoutes(r)
+
+	// Cordoned nodes are kept from booting their configuration until they
+	// are uncordoned. Cordons are restored from storage across restarts and
+	// follow the cordons made through other replicas.
+	cordons := cordon.NewStore(storage.Backend, scriptController.InvalidateNodeScripts, log.New(os.Stdout, "cordon: ", log.LstdFlags))
+	if err := cordons.Load(ctx); err != nil {
+		return err
+	}
+	changes.Subscribe(cordons.HandleResourceChange)
+	scriptController.SetCordons(cordons)
+	bootHandler.SetCordons(cordons)
+	cordon.NewHandler(cordons).RegisterRoutes(r)
+
+	// The leader polls BMCs for their power state, firmware, and health.
+	// Every replica indexes the faults the polls write, and refuses boot
+	// scripts to the nodes of faulted BMCs.
+	if config.BMCTelemetryEnabled {
+		store, ok := bootClient.(interface {
+			bmctelemetry.Store
+			bmctelemetry.Inventory
+		})
+		if !ok {
+			return fmt.Errorf("BMC telemetry requires a resource client that manages BMCs")
+		}
+		poller := bmctelemetry.NewPoller(store, nil, bmctelemetry.Config{
+			Interval:    time.Duration(config.BMCTelemetryInterval) * time.Minute,
+			Port:        config.BMCDiscoveryPort,
+			Username:    config.BMCDiscoveryUsername,
+			Password:    config.BMCDiscoveryPassword,
+			Insecure:    config.BMCDiscoveryInsecure,
+			Concurrency: config.BMCDiscoveryConcurrency,
+			Timeout:     time.Duration(config.BMCDiscoveryTimeoutMS) * time.Millisecond,
+		}, log.New(os.Stdout, "bmc-telemetry: ", log.LstdFlags))
+		faultHealth, _ := bmctelemetry.ParseHealth(config.BMCTelemetryFaultHealth) // validated
+		faults := bmctelemetry.NewFaults(store, faultHealth, scriptController.InvalidateNodeScripts, log.New(os.Stdout, "bmc-telemetry: ", log.LstdFlags))
+		if err := faults.Load(ctx); err != nil {
+			return err
+		}
+		changes.Subscribe(faults.HandleResourceChange)
+		scriptController.SetHardwareFaults(faults)
+		bmctelemetry.NewHandler(poller, faults).RegisterRoutes(r)
+		go elector.RunWhileLeader(ctx, poller.Start)
+		log.Printf("BMC telemetry enabled (interval: %d minutes, fault health: %q)", config.BMCTelemetryInterval, config.BMCTelemetryFaultHealth)
+	}
+
+	// A one-time override boots a node another configuration, such as
+	// memtest, on its next request only. Overrides are stored in the watched
+	// backend, so the audit log records each one and when it was served.
+	bootOnce := bootonce.NewStore(storage.Backend, scriptController.InvalidateNodeScripts, log.New(os.Stdout, "boot-once: ", log.LstdFlags))
+	if err := bootOnce.Load(ctx); err != nil {
+		return err
+	}
+	changes.Subscribe(bootOnce.HandleResourceChange)
+	scriptController.SetBootOnce(bootOnce)
+	bootHandler.SetBootOnce(bootOnce)
+	// Built-in utility configurations, such as memtest, give boot-once
+	// overrides something to boot without writing configurations by hand
+	if names, _ := utilityboot.ParseNames(config.UtilityBootConfigs); len(names) > 0 {
+		if err := utilityboot.Install(ctx, storage.Backend, names, config.UtilityBootBaseURL, log.New(os.Stdout, "utility: ", log.LstdFlags)); err != nil {
+			return fmt.Errorf("failed to install utility boot configurations: %w", err)
+		}
+		log.Printf("Utility boot configurations: %s (from %s)", strings.Join(names, ", "), config.UtilityBootBaseURL)
+	}
+
+	// Unknown nodes are registered on their first boot and boot the
+	// discovery configuration, such as an inventory image, until adopted
+	if config.NodeDiscovery {
+		networks, _ := httputil.ParseNetworks(parseScopeHintCSV(config.NodeDiscoveryNetworks)) // validated
+		registrar := discovery.NewRegistrar(bootClient, discovery.Config{Networks: networks, Limit: config.NodeDiscoveryLimit},
+			log.New(os.Stdout, "discovery: ", log.LstdFlags))
+		if err := registrar.Load(ctx); err != nil {
+			return err
+		}
+		changes.Subscribe(registrar.HandleResourceChange)
+		scriptController.SetDiscovery(registrar, config.NodeDiscoveryConfig)
+		log.Printf("Node discovery enabled (configuration %s, %d networks, limit %d)", config.NodeDiscoveryConfig, len(networks), config.NodeDiscoveryLimit)
+	}
+
+	// Keep scripts for every known node cached so the first boot after a
+	// rollout does not render them all at once. A shared Redis cache only
+	// needs one replica to do it.
+	if config.ScriptCachePrewarm {
+		prewarmer := bootscript.NewPrewarmer(scriptController, time.Duration(config.ScriptCachePrewarmDelay)*time.Second,
+			log.New(os.Stdout, "prewarm: ", log.LstdFlags))
+		changes.Subscribe(prewarmer.HandleResourceChange)
+		if config.CacheBackend == "redis" {
+			go elector.RunWhileLeader(ctx, prewarmer.Run)
+		} else {
+			go prewarmer.Run(ctx)
+		}
+		log.Printf("Boot script cache pre-warming enabled (delay: %ds)", config.ScriptCachePrewarmDelay)
+	}
+
+	// Rate limit boot script requests so a reboot storm cannot overwhelm the
+	// service; identical concurrent requests are coalesced by the controller.
+	limiter := ratelimit.NewLimiter(bootScriptRateLimits(config))
+	reloader.OnChange(bootScriptRateLimitKeys, func(config Config) {
+		limiter.SetConfig(bootScriptRateLimits(config))
+	})
+	bootHandler.SetBootScriptMiddleware(limiter.Middleware)
+
+	// Stream boot activity to dashboards at /ws/boot-events, and keep each
+	// node's boot timeline. Timelines are stored every 30 seconds beneath
+	// the watched backend, like access statistics.
+	timelines := timeline.NewRecorder(changes.StorageBackend, scriptController, log.New(os.Stdout, "timeline: ", log.LstdFlags))
+	changes.Subscribe(timelines.HandleResourceChange)
+	go timelines.Run(ctx, 30*time.Second)
+	scriptController.SetActivityRecorder(activityRecorders{bootEvents, timelines})
+	activityRecorder := activityRecorders{bootEvents, timelines}
+	bootHandler.SetTimeline(timelines)
+	bootHandler.SetUserData(userData)
+
+	// Reboot workflows boot nodes into a configuration once by setting
+	// boot-once overrides and power cycling them through their BMCs in
+	// batches or a rolling window, and follow them through their timelines
+	// until they phone home. Cordoned nodes are skipped. Workflows are
+	// stored beneath the watched backend, like timelines, so every replica
+	// reports them; only the leader runs them, and it cancels them, rolling
+	// back their overrides, before the server exits.
+	if config.RebootWorkflowsEnabled {
+		inventory, ok := bootClient.(workflow.Inventory)
+		if !ok {
+			return fmt.Errorf("reboot workflows require a resource client that manages BMCs")
+		}
+		workflows := workflow.NewManager(changes.StorageBackend, inventory, bootOnce, timelines, &workflow.Redfish{
+			HTTP:      redfish.NewHTTPClient(config.BMCDiscoveryInsecure),
+			Port:      config.BMCDiscoveryPort,
+			Username:  config.BMCDiscoveryUsername,
+			Password:  config.BMCDiscoveryPassword,
+			ResetType: config.RebootWorkflowResetType,
+			Timeout:   time.Duration(config.BMCDiscoveryTimeoutMS) * time.Millisecond,
+		}, workflow.Config{
+			BatchSize:  config.RebootWorkflowBatchSize,
+			BatchDelay: time.Duration(config.RebootWorkflowBatchDelay) * time.Second,
+			Timeout:    time.Duration(config.RebootWorkflowTimeout) * time.Minute,
+			Retries:    config.RebootWorkflowRetries,
+		}, log.New(os.Stdout, "workflow: ", log.LstdFlags))
+		workflows.SetCordons(cordons)
+		if err := workflows.Load(ctx); err != nil {
+			return fmt.Errorf("failed to load reboot workflows: %w", err)
+		}
+		changes.Subscribe(workflows.HandleResourceChange)
+		backgroundTasks.Add(1)
+		go func() {
+			defer backgroundTasks.Done()
+			elector.RunWhileLeader(ctx, workflows.Run)
+		}()
+		workflow.NewHandler(workflows).RegisterRoutes(r)
+		log.Printf("Reboot workflows enabled (batches of %d, %s reset)", config.RebootWorkflowBatchSize, config.RebootWorkflowResetType)
+	}
+
+	// Count each node's boot script requests so boot loops stand out. Counts
+	// are stored every 30 seconds beneath the watched backend, like audit
+	// records, so they do not invalidate cached scripts. Replicas sharing
+	// storage add their counts to the same statistics.
+	accessStats := accessstats.NewTracker(changes.StorageBackend, log.New(os.Stdout, "access: ", log.LstdFlags))
+	changes.Subscribe(accessStats.HandleResourceChange)
+	go accessStats.Run(ctx, 30*time.Second)
+	accessRecorder := accessRecorders{accessStats}
+	bootHandler.SetAccessStats(accessStats)
+
+	// Nodes that keep requesting their script without phoning home are in
+	// a boot loop. Replicas using Redis count requests and detect loops
+	// together.
+	if config.BootLoopThreshold > 0 {
+		detector := newBootLoopDetector(ctx, config, scriptController, redisClient)
+		accessRecorder = append(accessRecorder, detector)
+		activityRecorder = append(activityRecorder, detector)
+		bootloop.NewHandler(detector).RegisterRoutes(r)
+		if metrics != nil {
+			if err := registerBootLoopMetrics(metrics.registry, detector); err != nil {
+				return fmt.Errorf("failed to register boot loop metrics: %w", err)
+			}
+		}
+		log.Printf("Boot loop detection enabled (more than %d requests in %d minutes)", config.BootLoopThreshold, config.BootLoopWindow)
+	}
+	scriptController.SetAccessRecorder(accessRecorder)
+	bootHandler.SetActivityRecorder(activityRecorder)
+
+	// Sign boot scripts for iPXE clients that verify them with imgverify.
+	if config.ScriptSigningCert != "" {
+		signer, err := loadScriptSigner(config)
+		if err != nil {
+			return fmt.Errorf("failed to load boot script signing key: %w", err)
+		}
+		bootHandler.SetScriptSigner(signer)
+		log.Printf("Boot script signing enabled (certificate: %s)", boot.SigningCertificatePath)
+	}
+	// Query parameters listed here reach kernel parameter templates
+	if requestVars, _ := boot.ParseRequestVars(config.BootScriptRequestVars); len(requestVars) > 0 {
+		bootHandler.SetRequestVars(requestVars)
+		log.Printf("Boot script request variables: %s", strings.Join(requestVars, ", "))
+	}
+	// UEFI HTTP boot nodes load this from /httpboot/{mac}/boot.efi, then
+	// read /httpboot/{mac}/grub.cfg
+	if config.HTTPBootLoader != "" {
+		bootHandler.SetHTTPBootLoader(config.HTTPBootLoader)
+		log.Printf("UEFI HTTP boot loader served at /httpboot/{mac}/boot.efi from %s", config.HTTPBootLoader)
 	}
+	// Firmware without HTTP boot loads iPXE over TFTP first
+	if config.TFTPAddress != "" {
+		if err := startTFTP(ctx, r, config); err != nil {
+			return err
+		}
+	}
+	if config.ProxyDHCPEnabled {
+		if err := startProxyDHCP(ctx, config, bootClient); err != nil {
+			return err
+		}
+	}
+	if bootScriptRateLimits(config).Enabled() {
+		log.Printf("Boot script rate limiting enabled (%g req/s overall, %g req/s per client IP; 0 is unlimited)",
+			config.BootScriptRateLimit, config.BootScriptPerIPRateLimit)
+	}
+	if metrics != nil {
+		if err := registerBootScriptSurgeMetrics(metrics.registry, limiter, scriptController); err != nil {
+			return fmt.Errorf("failed to register boot script surge metrics: %w", err)
+		}
+	}
+
+	diag.controller = scriptController
+	diag.limiter = limiter
+	diag.RegisterRoutes(r)
 
 	// Always register "modern" boot API paths at /.
 	bootHandler.RegisterModernRoutes(r)
@@ -79,7 +782,49 @@ func registerCustomServerIntegrations(r chi.Router, config Config, hsmClient *hs
 	// Only register legacy BSS-compatible API if enable_legacy_api is true.
 	// These live at /boot/v1/*.
 	if config.EnableLegacyAPI {
-		bootHandler.RegisterLegacyRoutes(r)
+		if config.BSSUpstreamURL != "" {
+			upstream, err := boot.NewUpstream(config.BSSUpstreamURL, config.BSSUpstreamToken, 10*time.Second,
+				log.New(os.Stdout, "bss-upstream: ", log.LstdFlags))
+			if err != nil {
+				return err
+			}
+			bootHandler.SetUpstream(upstream)
+			log.Printf("Legacy API writes are mirrored to BSS at %s, and unknown reads fall back to it", config.BSSUpstreamURL)
+		}
+		var legacyMiddleware []func(http.Handler) http.Handler
+		if config.LegacyAuthEnabled {
+			bypasses := auth.NewBypassReport()
+			legacyMiddleware = append(legacyMiddleware, legacyAuth(config, keys, bypasses))
+			if config.LegacyAuthNonEnforcing {
+				registerAuthReport(r, bypasses)
+				if metrics != nil {
+					if err := registerAuthReportMetrics(metrics.registry, bypasses); err != nil {
+						return fmt.Errorf("failed to register auth report metrics: %w", err)
+					}
+				}
+				go bypasses.Run(ctx, authReportInterval, log.New(os.Stdout, "legacy-auth: ", log.LstdFlags))
+			}
+		}
+		if config.LegacyRecordFile != "" {
+			recorder, err := recording.NewRecorder(config.LegacyRecordFile, log.New(os.Stdout, "recording: ", log.LstdFlags))
+			if err != nil {
+				return err
+			}
+			// Boot scripts carry the values of secret references, which
+			// only nodes may see. Such scripts are never cached, so each
+			// was rendered by this replica with the values it redacts.
+			recorder.SetRedactor(scriptController.RedactSecrets)
+			go func() {
+				<-ctx.Done()
+				_ = recorder.Close()
+			}()
+			legacyMiddleware = append(legacyMiddleware, recorder.Middleware)
+			log.Printf("Recording legacy API exchanges to %s", config.LegacyRecordFile)
+		}
+		r.Group(func(r chi.Router) {
+			r.Use(legacyMiddleware...)
+			bootHandler.RegisterLegacyRoutes(r)
+		})
 		if hsmClient != nil {
 			log.Println("Legacy BSS API enabled with HSM integration at: /boot/v1/*")
 		} else {
@@ -92,3 +837,231 @@ func registerCustomServerIntegrations(r chi.Router, config Config, hsmClient *hs
 
 	return nil
 }
+
+// bootScriptBudgetKeys are the settings that make up the boot script
+// deadline budget; they apply without a restart
+var bootScriptBudgetKeys = []string{
+	"bootscript_timeout_ms",
+	"bootscript_node_lookup_timeout_ms",
+	"bootscript_config_lookup_timeout_ms",
+	"bootscript_artifact_timeout_ms",
+	"bootscript_fallback_retry_delay",
+}
+
+// bootScriptBudget converts the boot script budget settings
+func bootScriptBudget(config Config) bootscript.Budget {
+	return bootscript.Budget{
+		Total:              time.Duration(config.BootScriptTimeoutMS) * time.Millisecond,
+		NodeLookup:         time.Duration(config.BootScriptNodeLookupTimeoutMS) * time.Millisecond,
+		ConfigLookup:       time.Duration(config.BootScriptConfigLookupTimeoutMS) * time.Millisecond,
+		ArtifactResolution: time.Duration(config.BootScriptArtifactTimeoutMS) * time.Millisecond,
+		FallbackRetryDelay: time.Duration(config.BootScriptFallbackRetryDelay) * time.Second,
+	}
+}
+
+// matchScoringKeys are the boot configuration match scoring settings; they
+// apply without a restart
+var matchScoringKeys = []string{
+	"match_weight_mac",
+	"match_weight_nid",
+	"match_weight_host",
+	"match_weight_group",
+	"match_weight_default",
+	"match_strict",
+}
+
+// matchScoring converts the match scoring settings
+func matchScoring(config Config) bootscript.Scoring {
+	return bootscript.Scoring{
+		Weights: bootscript.Weights{
+			MAC:     config.MatchWeightMAC,
+			NID:     config.MatchWeightNID,
+			Host:    config.MatchWeightHost,
+			Group:   config.MatchWeightGroup,
+			Default: config.MatchWeightDefault,
+		},
+		Strict: config.MatchStrict,
+	}
+}
+
+// bootScriptRateLimitKeys are the boot script rate limiting settings; they
+// apply without a restart
+var bootScriptRateLimitKeys = []string{
+	"bootscript_rate_limit",
+	"bootscript_rate_burst",
+	"bootscript_per_ip_rate_limit",
+	"bootscript_per_ip_rate_burst",
+}
+
+// bootScriptRateLimits converts the boot script rate limiting settings
+func bootScriptRateLimits(config Config) ratelimit.Config {
+	return ratelimit.Config{
+		Rate:       config.BootScriptRateLimit,
+		Burst:      config.BootScriptRateBurst,
+		PerIPRate:  config.BootScriptPerIPRateLimit,
+		PerIPBurst: config.BootScriptPerIPRateBurst,
+	}
+}
+
+// newStorageBackend opens the storage backend selected by storage_type
+func newStorageBackend(ctx context.Context, config Config) (backend.Backend, error) {
+	switch config.StorageType {
+	case "file":
+		return backend.NewFile(config.DataDir)
+	case "etcd":
+		return backend.NewEtcd(ctx, backend.EtcdConfig{
+			URL:      config.EtcdURL,
+			Prefix:   config.EtcdPrefix,
+			Username: config.EtcdUsername,
+			Password: config.EtcdPassword,
+		}, log.New(os.Stdout, "etcd: ", log.LstdFlags))
+	default:
+		return nil, fmt.Errorf("unsupported storage type %q", config.StorageType)
+	}
+}
+
+// newResourceClient returns the client controllers and legacy handlers use
+// for nodes and boot configurations: storage in-process, or the remote service
+// at resource_api_url.
+func newResourceClient(config Config) (client.API, error) {
+	if config.ResourceAPIURL == "" {
+		return client.NewInProcessClient(), nil
+	}
+	remote, err := client.NewClientWithOptions(config.ResourceAPIURL,
+		client.Options{BearerToken: config.ResourceAPIToken}, client.DefaultLogger())
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Using remote resource API at %s", config.ResourceAPIURL)
+	return remote, nil
+}
+
+// newRedisClient connects to redis_url when shared state is configured and
+// closes the client when ctx is done. It returns nil if Redis is not used.
+func newRedisClient(ctx context.Context, config Config) (*redis.Client, error) {
+	if config.CacheBackend != "redis" && !config.LeaderElectionEnabled {
+		return nil, nil
+	}
+
+	client, err := sharedstate.NewRedisClient(config.RedisURL)
+	if err != nil {
+		return nil, err
+	}
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := client.Ping(pingCtx).Err(); err != nil {
+		client.Close() //nolint:errcheck
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	go func() {
+		<-ctx.Done()
+		client.Close() //nolint:errcheck
+	}()
+
+	return client, nil
+}
+
+// newScriptCache creates the boot script cache selected by cache_backend and
+// releases it when ctx is done.
+func newScriptCache(ctx context.Context, config Config, redisClient *redis.Client) bootscript.Cache {
+	ttl := time.Duration(config.ScriptCacheTTL) * time.Second
+
+	if config.CacheBackend == "redis" {
+		log.Printf("Boot script cache shared through Redis (key prefix %q)", config.RedisKeyPrefix)
+		return sharedstate.NewRedisScriptCache(redisClient, config.RedisKeyPrefix, ttl,
+			log.New(os.Stdout, "sharedstate: ", log.LstdFlags))
+	}
+
+	cache := bootscript.NewBoundedScriptCache(ttl, config.ScriptCacheMaxEntries, config.ScriptCacheMaxBytes)
+	go func() {
+		<-ctx.Done()
+		cache.Close()
+	}()
+	return cache
+}
+
+// registerScriptCacheMetrics exports boot script cache statistics. Values are
+// read from the cache on each scrape.
+func registerScriptCacheMetrics(registry prometheus.Registerer, cache bootscript.Cache) error {
+	opts := func(name, help string) prometheus.Opts {
+		return prometheus.Opts{Namespace: "main", Subsystem: "bootscript_cache", Name: name, Help: help}
+	}
+
+	collectors := []prometheus.Collector{
+		prometheus.NewCounterFunc(prometheus.CounterOpts(opts("hits_total", "Boot script cache hits")),
+			func() float64 { return float64(cache.Stats().Hits) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts(opts("misses_total", "Boot script cache misses")),
+			func() float64 { return float64(cache.Stats().Misses) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts(opts("evictions_total", "Boot scripts evicted to stay within the cache limits")),
+			func() float64 { return float64(cache.Stats().Evictions) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts(opts("entries", "Boot scripts currently cached")),
+			func() float64 { return float64(cache.Stats().TotalEntries) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts(opts("bytes", "Estimated memory used by cached boot scripts")),
+			func() float64 { return float64(cache.Stats().Bytes) }),
+	}
+	for _, collector := range collectors {
+		if err := registry.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// registerBootScriptSurgeMetrics exports how many boot script requests were
+// rate limited, how many shared a concurrent generation or node lookup, how
+// many failed linting, and how many were served by a fallback rule.
+func registerBootScriptSurgeMetrics(registry prometheus.Registerer, limiter *ratelimit.Limiter, controller *bootscript.BootScriptController) error {
+	collectors := []prometheus.Collector{
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: "main", Subsystem: "bootscript", Name: "rate_limited_total",
+			Help: "Boot script requests refused by the rate limiter",
+		}, func() float64 { return float64(limiter.Rejected()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: "main", Subsystem: "bootscript", Name: "coalesced_total",
+			Help: "Boot script requests served by a concurrent identical generation",
+		}, func() float64 { return float64(controller.CoalescedRequests()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: "main", Subsystem: "bootscript", Name: "deduplicated_node_lookups_total",
+			Help: "Node lookups served by a concurrent identical lookup",
+		}, func() float64 { return float64(controller.DeduplicatedLookups()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: "main", Subsystem: "bootscript", Name: "lint_failures_total",
+			Help: "Generated boot scripts that failed linting and were replaced by the fallback script",
+		}, func() float64 { return float64(controller.LintFailures()) }),
+		fallbackCollector{controller: controller, desc: prometheus.NewDesc("main_bootscript_fallback_total",
+			"Boot script requests served by a fallback rule because no configuration matched or the node is unknown",
+			[]string{"rule", "action"}, nil)},
+	}
+	for _, collector := range collectors {
+		if err := registry.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fallbackCollector exports the controller's fallback rule usage, which is
+// labelled by rule and action and so cannot be a CounterFunc
+type fallbackCollector struct {
+	controller *bootscript.BootScriptController
+	desc       *prometheus.Desc
+}
+
+func (f fallbackCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- f.desc
+}
+
+func (f fallbackCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, usage := range f.controller.FallbackUsage() {
+		ch <- prometheus.MustNewConstMetric(f.desc, prometheus.CounterValue, float64(usage.Count), usage.Rule, usage.Action)
+	}
+}
+
+// loadScriptSigner loads the boot script signing certificate and key from
+// files, or from the PEM settings themselves when they were read from Vault
+func loadScriptSigner(config Config) (*signing.Signer, error) {
+	if strings.HasPrefix(config.ScriptSigningCert, "-----BEGIN") && strings.HasPrefix(config.ScriptSigningKey, "-----BEGIN") {
+		return signing.Parse([]byte(config.ScriptSigningCert), []byte(config.ScriptSigningKey))
+	}
+	return signing.Load(config.ScriptSigningCert, config.ScriptSigningKey)
+}
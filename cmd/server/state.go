@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/artifacts"
+	"github.com/openchami/boot-service/pkg/snapshot"
+	"github.com/openchami/boot-service/pkg/storagemigration"
+)
+
+// newExportCommand creates the export command, which writes every stored
+// resource to a snapshot file
+func newExportCommand() *cobra.Command {
+	var dataDir, output string
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export all resources to a snapshot file",
+		Long: `Export all nodes, boot configurations, BMCs, and artifact records from the
+storage backend as a JSON snapshot. The snapshot keeps resource UIDs and
+metadata, so importing it restores the same state on another instance or
+storage backend.`,
+		Example: `  boot-service export --output state.json
+  boot-service export --data-dir /var/lib/boot-service > state.json`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive
+			out := cmd.OutOrStdout()
+			if output != "" && output != "-" {
+				file, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer file.Close() //nolint:errcheck
+				out = file
+			}
+			return runExport(cmd.Context(), out, stateDataDir(dataDir))
+		},
+	}
+
+	cmd.Flags().StringVar(&dataDir, "data-dir", "", "Directory for file storage (default data_dir from the configuration file, or ./data)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Snapshot file to write (default stdout)")
+	return cmd
+}
+
+// newImportCommand creates the import command, which restores a snapshot
+// written by export
+func newImportCommand() *cobra.Command {
+	var dataDir string
+	opts := snapshot.ImportOptions{}
+	cmd := &cobra.Command{
+		Use:   "import <snapshot-file>",
+		Short: "Import resources from a snapshot file",
+		Long: `Import nodes, boot configurations, BMCs, and artifact records from a snapshot
+written by export (JSON or YAML). Resources keep their UIDs: existing
+resources with the same UID are overwritten and, with --replace, resources
+missing from the snapshot are deleted. The whole snapshot is validated first
+and nothing is written when any resource is invalid.
+
+Import writes to the storage backend directly. Run it while the service is
+stopped, or expect cached boot scripts to refresh after script_cache_ttl.`,
+		Example: `  boot-service import state.json
+  boot-service import --replace --dry-run state.json`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close() //nolint:errcheck
+			return runImport(cmd.Context(), cmd.OutOrStdout(), file, stateDataDir(dataDir), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&dataDir, "data-dir", "", "Directory for file storage (default data_dir from the configuration file, or ./data)")
+	cmd.Flags().BoolVar(&opts.Replace, "replace", false, "Delete stored resources that are not in the snapshot")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Report the changes without writing them")
+	return cmd
+}
+
+// stateDataDir returns the data directory given on the command line, falling
+// back to the configured data_dir
+func stateDataDir(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if dataDir := viper.GetString("data_dir"); dataDir != "" {
+		return dataDir
+	}
+	return DefaultConfig().DataDir
+}
+
+func runExport(ctx context.Context, out io.Writer, dataDir string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := storage.InitFileBackend(dataDir); err != nil {
+		return fmt.Errorf("failed to initialize storage: %v", err)
+	}
+
+	state, err := snapshot.Export(ctx, storage.Backend)
+	if err != nil {
+		return err
+	}
+	return snapshot.Write(out, state)
+}
+
+func runImport(ctx context.Context, out io.Writer, in io.Reader, dataDir string, opts snapshot.ImportOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	state, err := snapshot.Read(in)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if err := storage.InitFileBackend(dataDir); err != nil {
+		return fmt.Errorf("failed to initialize storage: %v", err)
+	}
+
+	result, err := importMarked(ctx, "import", state, opts)
+	printImportResult(out, result)
+	if err != nil {
+		return err
+	}
+	if opts.DryRun {
+		fmt.Fprintln(out, "Dry run: no changes written") //nolint:errcheck
+	}
+	return nil
+}
+
+// importMarked imports state into storage.Backend, marking storage as
+// migrating while it is written so replicas sharing it report themselves not
+// ready
+func importMarked(ctx context.Context, operation string, state *snapshot.Snapshot, opts snapshot.ImportOptions) (snapshot.Result, error) {
+	if !opts.DryRun {
+		end, err := storagemigration.Begin(ctx, storage.Backend, operation, log.New(os.Stderr, "", 0))
+		if err != nil {
+			return nil, err
+		}
+		defer end()
+	}
+	return snapshot.Import(ctx, storage.Backend, state, opts)
+}
+
+// printImportResult reports the changes of an import by resource type
+func printImportResult(out io.Writer, result snapshot.Result) {
+	for _, resourceType := range []string{"Node", "BootConfiguration", "BMC", artifacts.ResourceType} {
+		counts, ok := result[resourceType]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(out, "%s: %d created, %d updated, %d deleted\n", //nolint:errcheck
+			resourceType, counts.Created, counts.Updated, counts.Deleted)
+	}
+}
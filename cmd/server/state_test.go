@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/resource"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/snapshot"
+)
+
+func TestExportImportCommands(t *testing.T) {
+	ctx := context.Background()
+
+	sourceDir := t.TempDir()
+	if err := storage.InitFileBackend(sourceDir); err != nil {
+		t.Fatalf("failed to initialize storage: %v", err)
+	}
+	node := &v1.Node{
+		Kind:     "Node",
+		Metadata: resource.Metadata{UID: "node-abc123", Name: "x0c0s0b0n0"},
+		Spec:     v1.NodeSpec{XName: "x0c0s0b0n0", BootMAC: "aa:bb:cc:dd:ee:01"},
+	}
+	if err := storage.SaveNode(ctx, node); err != nil {
+		t.Fatalf("failed to save node: %v", err)
+	}
+
+	var state bytes.Buffer
+	if err := runExport(ctx, &state, sourceDir); err != nil {
+		t.Fatalf("runExport failed: %v", err)
+	}
+	if !strings.Contains(state.String(), `"uid": "node-abc123"`) {
+		t.Fatalf("export missing node:\n%s", state.String())
+	}
+
+	targetDir := t.TempDir()
+	var out bytes.Buffer
+	if err := runImport(ctx, &out, bytes.NewReader(state.Bytes()), targetDir, snapshot.ImportOptions{}); err != nil {
+		t.Fatalf("runImport failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "Node: 1 created, 0 updated, 0 deleted") {
+		t.Errorf("unexpected import summary:\n%s", out.String())
+	}
+
+	imported, err := storage.LoadNode(ctx, "node-abc123")
+	if err != nil {
+		t.Fatalf("imported node not found: %v", err)
+	}
+	if imported.Spec.BootMAC != node.Spec.BootMAC {
+		t.Errorf("got boot MAC %q, want %q", imported.Spec.BootMAC, node.Spec.BootMAC)
+	}
+}
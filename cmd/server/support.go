@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/snapshot"
+)
+
+// defaultSupportLogBytes is how much of the end of each log file is collected
+const defaultSupportLogBytes = 10 << 20
+
+// supportBundleOptions configures the support-bundle command
+type supportBundleOptions struct {
+	output      string
+	serverURL   string
+	token       string
+	dataDir     string
+	logFiles    []string
+	logBytes    int64
+	journalUnit string
+	since       time.Duration
+	noState     bool
+	timeout     time.Duration
+}
+
+// supportManifest describes a support bundle: what it holds and what could
+// not be collected
+type supportManifest struct {
+	CreatedAt time.Time         `json:"createdAt"`
+	Version   string            `json:"version"`
+	Hostname  string            `json:"hostname"`
+	GoVersion string            `json:"goVersion"`
+	Files     []string          `json:"files"`
+	Errors    map[string]string `json:"errors,omitempty"` // file -> why it is missing
+}
+
+// newSupportBundleCommand creates the support-bundle command, which collects
+// what a bug report needs into one tarball
+func newSupportBundleCommand() *cobra.Command {
+	opts := supportBundleOptions{}
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Collect diagnostics, state, and logs into a tarball for bug reports",
+		Long: `Collect a support bundle: the running server's /admin/diagnostics report, the
+configuration with credentials redacted, a snapshot of the stored resources
+(as written by export), and the end of the service logs, from files or the
+systemd journal. Anything that cannot be collected is listed in the bundle's
+manifest.json instead of failing the command.
+
+The snapshot includes kernel parameters as stored. Review the bundle before
+sharing it if parameters hold credentials other than {{secret}} references.`,
+		Example: `  boot-service support-bundle --journal-unit boot-service
+  boot-service support-bundle --server http://boot:8080 --token "$TOKEN" --log-file /var/log/boot-service.log
+  boot-service support-bundle --no-state --output /tmp/bundle.tar.gz`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive
+			return runSupportBundle(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Bundle file to write (default boot-service-support-<time>.tar.gz)")
+	cmd.Flags().StringVar(&opts.serverURL, "server", "", "Base URL of the running server (default http://localhost:<port>)")
+	cmd.Flags().StringVar(&opts.token, "token", "", "Bearer token for /admin/diagnostics when tenancy is enabled (default $BOOT_SERVICE_TOKEN)")
+	cmd.Flags().StringVar(&opts.dataDir, "data-dir", "", "Directory for file storage (default data_dir from the configuration file, or ./data)")
+	cmd.Flags().StringArrayVar(&opts.logFiles, "log-file", nil, "Service log file to include; may be repeated")
+	cmd.Flags().Int64Var(&opts.logBytes, "log-bytes", defaultSupportLogBytes, "Bytes collected from the end of each log file")
+	cmd.Flags().StringVar(&opts.journalUnit, "journal-unit", "", "systemd unit whose journal to include, e.g. boot-service")
+	cmd.Flags().DurationVar(&opts.since, "since", 24*time.Hour, "How far back to collect the journal")
+	cmd.Flags().BoolVar(&opts.noState, "no-state", false, "Leave out the resource snapshot")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 30*time.Second, "Time limit for fetching diagnostics and reading the journal")
+	return cmd
+}
+
+func runSupportBundle(ctx context.Context, out io.Writer, opts supportBundleOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	now := time.Now().UTC()
+	name := "boot-service-support-" + now.Format("20060102T150405Z")
+	if opts.output == "" {
+		opts.output = name + ".tar.gz"
+	}
+
+	config, configErr := loadConfig()
+	if opts.serverURL == "" {
+		opts.serverURL = fmt.Sprintf("http://localhost:%d", config.Port)
+	}
+	if opts.token == "" {
+		opts.token = os.Getenv("BOOT_SERVICE_TOKEN")
+	}
+	hostname, _ := os.Hostname()
+	manifest := supportManifest{
+		CreatedAt: now,
+		Version:   serviceVersionString(),
+		Hostname:  hostname,
+		GoVersion: runtime.Version(),
+		Errors:    map[string]string{},
+	}
+	files := map[string][]byte{}
+	// collect(file)(data, err) adds a file, or records why it is missing
+	collect := func(file string) func([]byte, error) {
+		return func(data []byte, err error) {
+			if err != nil {
+				manifest.Errors[file] = err.Error()
+				return
+			}
+			files[file] = data
+			manifest.Files = append(manifest.Files, file)
+		}
+	}
+
+	if configErr != nil {
+		collect("config.json")(nil, configErr)
+	} else {
+		collect("config.json")(marshalIndent(redactConfig(config)))
+	}
+	collect("diagnostics.json")(fetchDiagnostics(ctx, opts))
+	if !opts.noState {
+		collect("state.json")(exportState(ctx, stateDataDir(opts.dataDir)))
+	}
+	for i, logFile := range opts.logFiles {
+		collect(fmt.Sprintf("logs/%d-%s", i+1, filepath.Base(logFile)))(tailFile(logFile, opts.logBytes))
+	}
+	if opts.journalUnit != "" {
+		collect("logs/journal-" + opts.journalUnit + ".log")(readJournal(ctx, opts))
+	}
+
+	manifestData, err := marshalIndent(manifest)
+	if err != nil {
+		return err
+	}
+	if err := writeSupportBundle(opts.output, name, now, manifestData, manifest.Files, files); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Wrote %s (%d files)\n", opts.output, len(manifest.Files)+1) //nolint:errcheck
+	for file, reason := range manifest.Errors {
+		fmt.Fprintf(out, "  not collected: %s: %s\n", file, reason) //nolint:errcheck
+	}
+	return nil
+}
+
+// marshalIndent encodes v as indented JSON
+func marshalIndent(v any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// fetchDiagnostics reads the running server's diagnostics report
+func fetchDiagnostics(ctx context.Context, opts supportBundleOptions) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(opts.serverURL, "/")+diagnosticsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	if opts.token != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", diagnosticsPath, resp.Status)
+	}
+	return body, nil
+}
+
+// exportState snapshots the resources in dataDir, without creating the
+// directory if it does not exist
+func exportState(ctx context.Context, dataDir string) ([]byte, error) {
+	if _, err := os.Stat(dataDir); err != nil {
+		return nil, err
+	}
+	if err := storage.InitFileBackend(dataDir); err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %v", err)
+	}
+	state, err := snapshot.Export(ctx, storage.Backend)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := snapshot.Write(&buf, state); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// tailFile reads up to limit bytes from the end of a file
+func tailFile(path string, limit int64) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() //nolint:errcheck
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && info.Size() > limit {
+		if _, err := file.Seek(-limit, io.SeekEnd); err != nil {
+			return nil, err
+		}
+	}
+	return io.ReadAll(file)
+}
+
+// readJournal reads the unit's journal for the --since window
+func readJournal(ctx context.Context, opts supportBundleOptions) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+	since := fmt.Sprintf("-%ds", int64(opts.since.Seconds()))
+	output, err := exec.CommandContext(ctx, "journalctl", "--unit", opts.journalUnit, "--since", since, "--no-pager").Output()
+	if err != nil {
+		return nil, fmt.Errorf("journalctl: %w", err)
+	}
+	return output, nil
+}
+
+// writeSupportBundle writes the manifest and files, in manifest order, to a
+// gzipped tarball under the directory name
+func writeSupportBundle(path, name string, modTime time.Time, manifest []byte, order []string, files map[string][]byte) (err error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	gz := gzip.NewWriter(file)
+	archive := tar.NewWriter(gz)
+	add := func(file string, data []byte) error {
+		header := &tar.Header{Name: name + "/" + file, Mode: 0o600, Size: int64(len(data)), ModTime: modTime}
+		if err := archive.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := archive.Write(data)
+		return err
+	}
+	if err := add("manifest.json", manifest); err != nil {
+		return err
+	}
+	for _, file := range order {
+		if err := add(file, files[file]); err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
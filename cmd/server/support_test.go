@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/storage"
+)
+
+func TestSupportBundle(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dataDir := filepath.Join(dir, "data")
+	if err := storage.InitFileBackend(dataDir); err != nil {
+		t.Fatal(err)
+	}
+	node := &v1.Node{Kind: "Node", Metadata: resource.Metadata{UID: "node-abc123", Name: "x0c0s0b0n0"}, Spec: v1.NodeSpec{XName: "x0c0s0b0n0"}}
+	if err := storage.SaveNode(ctx, node); err != nil {
+		t.Fatal(err)
+	}
+	logFile := filepath.Join(dir, "boot-service.log")
+	if err := os.WriteFile(logFile, []byte("old line\nrecent line\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != diagnosticsPath || r.Header.Get("Authorization") != "Bearer admin-token" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"runtime":{"goroutines":12}}`)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	output := filepath.Join(dir, "bundle.tar.gz")
+	var out bytes.Buffer
+	err := runSupportBundle(ctx, &out, supportBundleOptions{
+		output: output, serverURL: server.URL, token: "admin-token", dataDir: dataDir,
+		logFiles: []string{logFile, filepath.Join(dir, "missing.log")}, logBytes: 12, timeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("runSupportBundle failed: %v", err)
+	}
+
+	files := readTarball(t, output)
+	var manifest supportManifest
+	if err := json.Unmarshal(files["manifest.json"], &manifest); err != nil {
+		t.Fatalf("invalid manifest: %v", err)
+	}
+	if _, ok := manifest.Errors["logs/2-missing.log"]; !ok || len(manifest.Files) != 4 {
+		t.Errorf("manifest = %+v, want four files and the missing log", manifest)
+	}
+	if !strings.Contains(string(files["state.json"]), "node-abc123") {
+		t.Errorf("state.json missing the node:\n%s", files["state.json"])
+	}
+	if string(files["diagnostics.json"]) != `{"runtime":{"goroutines":12}}` {
+		t.Errorf("diagnostics.json = %s", files["diagnostics.json"])
+	}
+	if string(files["logs/1-boot-service.log"]) != "recent line\n" {
+		t.Errorf("log = %q, want only its last 12 bytes", files["logs/1-boot-service.log"])
+	}
+	if _, ok := files["config.json"]; !ok {
+		t.Error("bundle is missing config.json")
+	}
+	if !strings.Contains(out.String(), "not collected: logs/2-missing.log") {
+		t.Errorf("output does not report the missing log:\n%s", out.String())
+	}
+}
+
+// readTarball returns the files of a support bundle by their path within it
+func readTarball(t *testing.T, path string) map[string][]byte {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close() //nolint:errcheck
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string][]byte{}
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(archive)
+		_, name, _ := strings.Cut(header.Name, "/")
+		files[name] = data
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/openchami/boot-service/pkg/apikeys"
+	"github.com/openchami/boot-service/pkg/dhcp"
+	"github.com/openchami/boot-service/pkg/nodeimport"
+	"github.com/openchami/boot-service/pkg/tenancy"
+	"github.com/openchami/boot-service/pkg/workflow"
+)
+
+// tenantScopedPaths are the path prefixes that serve tenant-owned resources.
+// Boot script requests from nodes carry no token and stay unscoped.
+var tenantScopedPaths = []string{
+	"/nodes",
+	nodeimport.Path,
+	"/bootconfigurations",
+	"/bootparameters",
+	"/bootscript/preview",
+	"/boot/v1/bootparameters",
+	"/audit",
+	"/userdata",
+	dhcp.Path,
+	bootEventsPath,
+}
+
+// sharedPaths are the path prefixes of resources that every tenant's boot
+// configurations use. Any tenant may read them; changing them requires
+// tenant_admin_scope.
+var sharedPaths = []string{
+	"/parameterprofiles",
+	"/bootartifacts",
+}
+
+// administratorPaths are the path prefixes of the administration APIs, which
+// act on every tenant's resources. The API key API checks its own
+// api_key_admin_scope.
+var administratorPaths = []string{
+	"/admin",
+	workflow.Path,
+}
+
+// tenantScope requires a token verified against jwks_endpoint, or an API key,
+// on the tenant-scoped paths and restricts each request to the tenant in its
+// cluster_id claim, which for an API key is the tenant it was created with.
+// The administration APIs, and writes to shared resources, require
+// tenant_admin_scope.
+func tenantScope(config Config, keys *apikeys.Store) func(http.Handler) http.Handler {
+	authConfig := tokenAuthConfig(config, keys)
+	authn := authConfig.CreateMiddleware(log.New(os.Stdout, "tenancy: ", log.LstdFlags))
+	log.Printf("Tenant scoping enabled (admin scope: %q)", config.TenantAdminScope)
+	return scopeRequests(authn, config.TenantAdminScope)
+}
+
+// scopeRequests authenticates requests to the administration APIs and the
+// tenant-scoped paths with authn; other requests pass through
+func scopeRequests(authn func(http.Handler) http.Handler, adminScope string) func(http.Handler) http.Handler {
+	scope := tenancy.Middleware(adminScope)
+	administrators := tenancy.AdministratorsOnly(adminScope)
+	return func(next http.Handler) http.Handler {
+		scoped := authn(scope(next))
+		administration := authn(administrators(next))
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case isAdministration(r.URL.Path), isSharedWrite(r):
+				administration.ServeHTTP(w, r)
+			case isTenantScoped(r.URL.Path), hasPathPrefix(r.URL.Path, sharedPaths):
+				scoped.ServeHTTP(w, r)
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+func isTenantScoped(path string) bool {
+	return hasPathPrefix(path, tenantScopedPaths)
+}
+
+func isSharedWrite(r *http.Request) bool {
+	return hasPathPrefix(r.URL.Path, sharedPaths) && r.Method != http.MethodGet && r.Method != http.MethodHead
+}
+
+func isAdministration(path string) bool {
+	return hasPathPrefix(path, administratorPaths) && !hasPathPrefix(path, []string{apikeys.Path})
+}
+
+func hasPathPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
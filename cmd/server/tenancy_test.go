@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openchami/boot-service/pkg/auth"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+func TestScopeRequests(t *testing.T) {
+	keyPair, err := auth.GenerateTestKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	authConfig := auth.CreateStaticKeyConfig(keyPair.PublicKeyPEM)
+	handler := scopeRequests(authConfig.CreateMiddleware(nil), "admin")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tenant, ok := tenancy.FromContext(r.Context()); ok {
+			w.Header().Set("X-Tenant", tenant)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(method, path string, scopes ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if scopes != nil {
+			tok, err := auth.CreateTestTokenWithScopes(keyPair, scopes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Authorization", "Bearer "+tok)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		scopes []string
+		want   int
+	}{
+		{"anonymous reboot workflow", http.MethodPost, "/workflows/reboot", nil, http.StatusUnauthorized},
+		{"anonymous workflow list", http.MethodGet, "/workflows", nil, http.StatusUnauthorized},
+		{"tenant reboot workflow", http.MethodPost, "/workflows/reboot", []string{"read"}, http.StatusForbidden},
+		{"administrator reboot workflow", http.MethodPost, "/workflows/reboot", []string{"admin"}, http.StatusOK},
+		{"anonymous backup", http.MethodPost, "/admin/backups", nil, http.StatusUnauthorized},
+		{"anonymous BMC scan", http.MethodPost, "/admin/bmc-discovery/scan", nil, http.StatusUnauthorized},
+		{"anonymous boot loop reset", http.MethodDelete, "/admin/boot-loops/x0c0s0b0n0", nil, http.StatusUnauthorized},
+		{"tenant maintenance", http.MethodPut, "/admin/maintenance", []string{"read"}, http.StatusForbidden},
+		{"administrator cordons", http.MethodGet, "/admin/cordons", []string{"admin"}, http.StatusOK},
+		{"anonymous nodes", http.MethodGet, "/nodes", nil, http.StatusUnauthorized},
+		{"tenant nodes", http.MethodGet, "/nodes", []string{"read"}, http.StatusOK},
+		{"anonymous boot events", http.MethodGet, bootEventsPath, nil, http.StatusUnauthorized},
+		{"tenant boot events", http.MethodGet, bootEventsPath, []string{"read"}, http.StatusOK},
+		{"anonymous user-data", http.MethodPut, "/userdata/default", nil, http.StatusUnauthorized},
+		{"tenant parameter profiles", http.MethodGet, "/parameterprofiles", []string{"read"}, http.StatusOK},
+		{"tenant parameter profile write", http.MethodPut, "/parameterprofiles/serial", []string{"read"}, http.StatusForbidden},
+		{"administrator parameter profile write", http.MethodPut, "/parameterprofiles/serial", []string{"admin"}, http.StatusOK},
+		{"tenant artifact delete", http.MethodDelete, "/bootartifacts/kernel", []string{"read"}, http.StatusForbidden},
+		{"tenant artifacts", http.MethodGet, "/bootartifacts", []string{"read"}, http.StatusOK},
+		{"API keys check their own scope", http.MethodGet, "/admin/api-keys", nil, http.StatusOK},
+		{"boot script", http.MethodGet, "/boot/v1/bootscript?mac=aa:bb:cc:dd:ee:ff", nil, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := serve(tt.method, tt.path, tt.scopes...); rec.Code != tt.want {
+				t.Errorf("%s %s = %d, want %d", tt.method, tt.path, rec.Code, tt.want)
+			}
+		})
+	}
+
+	for _, path := range []string{"/nodes", bootEventsPath, "/userdata"} {
+		if rec := serve(http.MethodGet, path, "read"); rec.Header().Get("X-Tenant") != "test-cluster" {
+			t.Errorf("tenant request to %s scoped to %q, want test-cluster", path, rec.Header().Get("X-Tenant"))
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/pkg/tftp"
+)
+
+// startTFTP serves the tftp_boot_files over TFTP at tftp_address until ctx
+// is done, and the dnsmasq options naming them at /dhcp/bootfiles
+func startTFTP(ctx context.Context, r chi.Router, config Config) error {
+	files, err := tftp.ParseBootFiles(parseScopeHintCSV(config.TFTPBootFiles))
+	if err != nil {
+		return fmt.Errorf("tftp-boot-files: %w", err)
+	}
+	conn, err := net.ListenPacket("udp", config.TFTPAddress)
+	if err != nil {
+		return fmt.Errorf("failed to start TFTP server: %w", err)
+	}
+	logger := log.New(os.Stdout, "tftp: ", log.LstdFlags)
+	go func() {
+		if err := tftp.NewServer(files, logger).Serve(conn); err != nil {
+			logger.Printf("TFTP server stopped: %v", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		conn.Close() //nolint:errcheck
+	}()
+
+	// Point DHCP at this server only when it listens on one address
+	server, _, _ := net.SplitHostPort(config.TFTPAddress)
+	if ip := net.ParseIP(server); ip == nil || ip.IsUnspecified() || ip.To4() == nil {
+		server = ""
+	}
+	tftp.NewHandler(files, server).RegisterRoutes(r)
+	log.Printf("TFTP server for boot files on %s (DHCP options at %s)", conn.LocalAddr(), tftp.Path)
+	return nil
+}
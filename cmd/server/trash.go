@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/openchami/fabrica/pkg/validation"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/schemas"
+	"github.com/openchami/boot-service/pkg/tenancy"
+	"github.com/openchami/boot-service/pkg/trash"
+)
+
+// deletedCollections maps the list endpoints that accept ?deleted=true to the
+// storage resource type they list
+var deletedCollections = map[string]string{
+	"/bootconfigurations": "BootConfiguration",
+	"/nodes":              "Node",
+}
+
+// listDeleted serves ?deleted=true on the lists of the types the bin keeps,
+// answering with the bin entries, most recently deleted first, instead of
+// the stored resources. scope, the tenant scoping middleware when tenancy is
+// enabled, limits the entries to those of the caller's tenant.
+func listDeleted(bin *trash.Bin, scope func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resourceType, ok := deletedCollections[strings.TrimSuffix(r.URL.Path, "/")]
+			query := r.URL.Query()
+			if r.Method != http.MethodGet || !ok || !bin.Keeps(resourceType) || !query.Has("deleted") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			deleted, err := strconv.ParseBool(query.Get("deleted"))
+			if err != nil {
+				httputil.WriteError(w, http.StatusBadRequest, "Invalid deleted", "deleted must be true or false")
+				return
+			}
+			if !deleted {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if query.Has("limit") || query.Has("after") || query.Has("watch") {
+				httputil.WriteError(w, http.StatusBadRequest, "Invalid deleted", "deleted cannot be combined with limit, after, or watch")
+				return
+			}
+
+			var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				entries, err := bin.List(r.Context(), resourceType)
+				if err != nil {
+					httputil.WriteError(w, http.StatusInternalServerError, "Failed to list deleted resources", err.Error())
+					return
+				}
+				visible := make([]trash.Entry, 0, len(entries))
+				for _, entry := range entries {
+					if tenancy.Visible(r.Context(), tenancy.Owner(entry.Object)) {
+						visible = append(visible, entry)
+					}
+				}
+				httputil.WriteJSON(w, http.StatusOK, visible)
+			})
+			if scope != nil {
+				handler = scope(handler)
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// validateRestore checks deleted resources as the API checks a create: the
+// spec against its schema when registry is set, then the resource's Validate,
+// which runs the admission webhooks and OPA policy. Validate may normalize
+// or patch the resource, which is restored as it returns it.
+func validateRestore(registry *schemas.Registry) trash.Validator {
+	return func(ctx context.Context, resourceType string, object json.RawMessage) (json.RawMessage, error) {
+		var resource validation.CustomValidator
+		switch resourceType {
+		case "Node":
+			resource = &v1.Node{}
+		case "BootConfiguration":
+			resource = &v1.BootConfiguration{}
+		default:
+			return object, nil
+		}
+		if registry != nil {
+			var payload struct {
+				Spec json.RawMessage `json:"spec"`
+			}
+			if err := json.Unmarshal(object, &payload); err != nil {
+				return nil, err
+			}
+			if errs := registry.Validate(resourceType+"Spec", payload.Spec, "/spec"); len(errs) > 0 {
+				return nil, errs[0]
+			}
+		}
+		if err := json.Unmarshal(object, resource); err != nil {
+			return nil, fmt.Errorf("decoding deleted %s: %w", resourceType, err)
+		}
+		if err := validation.ValidateWithContext(ctx, resource); err != nil {
+			return nil, err
+		}
+		return json.Marshal(resource)
+	}
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/admission"
+	"github.com/openchami/boot-service/pkg/schemas"
+	"github.com/openchami/boot-service/pkg/tenancy"
+	"github.com/openchami/boot-service/pkg/trash"
+)
+
+func TestListDeleted(t *testing.T) {
+	ctx := context.Background()
+	raw, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	bin := trash.NewBin(time.Hour, log.New(io.Discard, "", 0), "BootConfiguration")
+	wrapped := bin.Wrap(raw)
+	for uid, tenant := range map[string]string{"bc-a": "a", "bc-b": "b"} {
+		config := `{"metadata":{"uid":"` + uid + `"},"spec":{"tenant":"` + tenant + `"}}`
+		if err := wrapped.Save(ctx, "BootConfiguration", uid, []byte(config)); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+		if err := wrapped.Delete(ctx, "BootConfiguration", uid); err != nil {
+			t.Fatalf("Delete returned error: %v", err)
+		}
+	}
+
+	scope := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(tenancy.WithTenant(r.Context(), "a")))
+		})
+	}
+	handler := listDeleted(bin, scope)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { //nolint:revive
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/bootconfigurations?deleted=true", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET ?deleted=true = %d: %s", rec.Code, rec.Body.String())
+	}
+	var entries []trash.Entry
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid response %q: %v", rec.Body.String(), err)
+	}
+	if len(entries) != 1 || entries[0].UID != "bc-a" {
+		t.Errorf("deleted boot configurations = %+v, want only tenant a's bc-a", entries)
+	}
+
+	tests := map[string]int{
+		"/bootconfigurations":                         http.StatusTeapot,
+		"/bootconfigurations?deleted=false":           http.StatusTeapot,
+		"/nodes?deleted=true":                         http.StatusTeapot, // nodes are not kept
+		"/bootconfigurations?deleted=maybe":           http.StatusBadRequest,
+		"/bootconfigurations?deleted=true&limit=10":   http.StatusBadRequest,
+		"/bootconfigurations?deleted=true&watch=true": http.StatusBadRequest,
+	}
+	for target, want := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != want {
+			t.Errorf("GET %s = %d, want %d", target, rec.Code, want)
+		}
+	}
+}
+
+func TestRestore_Validated(t *testing.T) {
+	ctx := context.Background()
+	raw, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	bin := trash.NewBin(time.Hour, log.New(io.Discard, "", 0), "BootConfiguration")
+	wrapped := bin.Wrap(raw)
+	config := `{"metadata":{"uid":"bc-1","name":"compute"},"spec":{"kernel":"http://mirror.example.com/vmlinuz"}}`
+	if err := wrapped.Save(ctx, "BootConfiguration", "bc-1", []byte(config)); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := wrapped.Delete(ctx, "BootConfiguration", "bc-1"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	policy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req admission.Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		var config v1.BootConfiguration
+		_ = json.Unmarshal(req.Object, &config)
+		if !strings.HasPrefix(config.Spec.Kernel, "http://images.example.com/") {
+			w.Write([]byte(`{"allowed":false,"reason":"kernels must come from images.example.com"}`)) //nolint:errcheck
+			return
+		}
+		w.Write([]byte(`{"allowed":true}`)) //nolint:errcheck
+	}))
+	defer policy.Close()
+	admission.SetDefault(admission.NewChain(nil, admission.NewWebhook("policy", policy.URL, time.Second, false, nil)))
+	defer admission.SetDefault(nil)
+
+	registry, err := schemas.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := chi.NewRouter()
+	trash.NewHandler(bin, wrapped, validateRestore(registry)).RegisterRoutes(r)
+	restore := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/bootconfigurations/bc-1/restore", nil))
+		return rec
+	}
+
+	if rec := restore(); rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "images.example.com") {
+		t.Fatalf("restore denied by policy = %d: %s, want 400", rec.Code, rec.Body.String())
+	}
+	if exists, _ := wrapped.Exists(ctx, "BootConfiguration", "bc-1"); exists {
+		t.Fatal("a restore denied by policy was saved")
+	}
+	if _, err := bin.Get(ctx, "BootConfiguration", "bc-1"); err != nil {
+		t.Fatalf("a restore denied by policy left the bin: %v", err)
+	}
+
+	admission.SetDefault(nil)
+	if rec := restore(); rec.Code != http.StatusOK {
+		t.Fatalf("restore = %d: %s, want 200", rec.Code, rec.Body.String())
+	}
+	if exists, _ := wrapped.Exists(ctx, "BootConfiguration", "bc-1"); !exists {
+		t.Error("restored boot configuration was not saved")
+	}
+}
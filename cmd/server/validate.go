@@ -0,0 +1,386 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/clients/local"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// validateOptions selects the files checked by the validate command
+type validateOptions struct {
+	configFile      string
+	nodesFile       string
+	bootConfigsFile string
+	checkURLs       bool
+	urlTimeout      time.Duration
+}
+
+// validationProblem is one error found in a validated file
+type validationProblem struct {
+	file     string
+	location string
+	message  string
+}
+
+func (p validationProblem) String() string {
+	if p.location == "" {
+		return fmt.Sprintf("%s: %s", p.file, p.message)
+	}
+	return fmt.Sprintf("%s: %s: %s", p.file, p.location, p.message)
+}
+
+// newValidateCommand creates the validate command, which checks files before
+// they are deployed and exits non-zero when any check fails
+func newValidateCommand() *cobra.Command {
+	opts := validateOptions{}
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate configuration and data files",
+		Long: `Validate a server configuration file, a nodes YAML file or directory, and/or
+a file of exported boot configurations (YAML or JSON). Reports schema errors, invalid
+XNames and MACs, duplicate nodes, overlapping configuration targets, and with
+--check-urls, unreachable kernel and initrd URLs. Exits non-zero when any
+problem is found.`,
+		Example: `  boot-service validate --config config.yaml
+  boot-service validate --nodes nodes.yaml --boot-configs bootconfigs.yaml --check-urls`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive
+			return runValidate(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.configFile, "config", "", "Server configuration file to validate")
+	cmd.Flags().StringVar(&opts.nodesFile, "nodes", "", "Nodes YAML file, or directory of nodes files, to validate")
+	cmd.Flags().StringVar(&opts.bootConfigsFile, "boot-configs", "", "Boot configurations file (YAML or JSON) to validate")
+	cmd.Flags().BoolVar(&opts.checkURLs, "check-urls", false, "Check that http(s) kernel and initrd URLs are reachable")
+	cmd.Flags().DurationVar(&opts.urlTimeout, "url-timeout", 10*time.Second, "Timeout for each URL check")
+
+	return cmd
+}
+
+func runValidate(ctx context.Context, out io.Writer, opts validateOptions) error {
+	if opts.configFile == "" && opts.nodesFile == "" && opts.bootConfigsFile == "" {
+		return errors.New("nothing to validate: pass --config, --nodes, and/or --boot-configs")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var problems []validationProblem
+	if opts.configFile != "" {
+		config, configProblems := validateConfigFile(opts.configFile)
+		problems = append(problems, configProblems...)
+		// Resources are checked with the validation profile the server
+		// would apply
+		if profile, err := validationProfile(config); err == nil {
+			validation.SetProfile(profile)
+		}
+	}
+	if opts.nodesFile != "" {
+		problems = append(problems, validateNodesFile(opts.nodesFile)...)
+	}
+	if opts.bootConfigsFile != "" {
+		problems = append(problems, validateBootConfigsFile(ctx, opts)...)
+	}
+
+	for _, problem := range problems {
+		fmt.Fprintln(out, problem) //nolint:errcheck
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("validation failed: %d problem(s) found", len(problems))
+	}
+
+	fmt.Fprintln(out, "All files are valid") //nolint:errcheck
+	return nil
+}
+
+// validateConfigFile checks a server configuration file for unknown keys,
+// type errors, and the same rules enforced at startup, and returns the
+// configuration it sets
+func validateConfigFile(path string) (Config, []validationProblem) {
+	problem := func(location, format string, args ...interface{}) validationProblem {
+		return validationProblem{file: path, location: location, message: fmt.Sprintf(format, args...)}
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	config := DefaultConfig()
+	if err := v.ReadInConfig(); err != nil {
+		return config, []validationProblem{problem("", "%v", err)}
+	}
+
+	var problems []validationProblem
+	known := map[string]bool{}
+	configType := reflect.TypeOf(Config{})
+	for i := 0; i < configType.NumField(); i++ {
+		known[configKey(configType.Field(i))] = true
+	}
+	for _, key := range v.AllKeys() {
+		if !known[key] {
+			problems = append(problems, problem(key, "unknown configuration key"))
+		}
+	}
+
+	if err := v.Unmarshal(&config); err != nil {
+		return DefaultConfig(), append(problems, problem("", "%v", err))
+	}
+	if err := validateConfig(config); err != nil {
+		problems = append(problems, problem("", "%v", err))
+	}
+	return config, problems
+}
+
+// validateNodesFile checks a nodes YAML file, or every nodes file of a
+// directory, as read by the YAML node provider. Identifiers must be unique
+// across all the files of a directory.
+func validateNodesFile(path string) []validationProblem {
+	files, err := local.NodeFiles(path)
+	if err != nil {
+		return []validationProblem{{file: path, message: err.Error()}}
+	}
+	if len(files) == 0 {
+		return []validationProblem{{file: path, message: "no .yaml or .yml nodes files in directory"}}
+	}
+
+	var problems []validationProblem
+	seen := map[string]validationProblem{} // identifier -> file and location of first use
+	for _, file := range files {
+		problems = append(problems, validateNodes(file, seen)...)
+	}
+	return problems
+}
+
+// validateNodes checks one nodes file, recording the identifiers it uses in
+// seen
+func validateNodes(path string, seen map[string]validationProblem) []validationProblem {
+	var file local.YAMLNodesFile
+	if err := decodeStrictYAML(path, &file); err != nil {
+		return []validationProblem{{file: path, message: err.Error()}}
+	}
+
+	var problems []validationProblem
+	claim := func(location, kind, value string) {
+		if value == "" {
+			return
+		}
+		key := kind + " " + value
+		if first, ok := seen[key]; ok {
+			usedBy := first.location
+			if first.file != path {
+				usedBy = first.file + " " + first.location
+			}
+			problems = append(problems, validationProblem{path, location, fmt.Sprintf("duplicate %s, also used by %s", key, usedBy)})
+			return
+		}
+		seen[key] = validationProblem{file: path, location: location}
+	}
+
+	for i, node := range file.Nodes {
+		location := fmt.Sprintf("nodes[%d]", i)
+		if node.XName != "" {
+			location += " (" + node.XName + ")"
+		}
+
+		if !validation.ValidateXName(node.XName) {
+			problems = append(problems, validationProblem{path, location, fmt.Sprintf("invalid xname %q", node.XName)})
+		}
+		macs := []string{node.BootMAC}
+		for _, iface := range node.EthernetInterfaces {
+			macs = append(macs, iface.MACAddress)
+		}
+		for _, mac := range macs {
+			if !validation.ValidateMAC(mac) {
+				problems = append(problems, validationProblem{path, location, fmt.Sprintf("invalid MAC address %q", mac)})
+			}
+		}
+
+		if !validation.ValidateUUID(node.UUID) {
+			problems = append(problems, validationProblem{path, location, fmt.Sprintf("invalid UUID %q", node.UUID)})
+		}
+
+		claim(location, "id", node.ID)
+		claim(location, "xname", node.XName)
+		claim(location, "uuid", strings.ToLower(node.UUID))
+		claim(location, "serial", node.Serial)
+		if node.NID > 0 {
+			claim(location, "nid", fmt.Sprint(node.NID))
+		}
+		// A MAC may appear as both boot_mac and an interface of one node
+		nodeMACs := map[string]bool{}
+		for _, mac := range macs {
+			mac = validation.NormalizeMAC(mac)
+			if mac != "" && !nodeMACs[mac] {
+				nodeMACs[mac] = true
+				claim(location, "mac", mac)
+			}
+		}
+	}
+	return problems
+}
+
+// validateBootConfigsFile checks boot configurations against the API
+// validation rules, for duplicate names, for overlapping targets, and
+// optionally for unreachable URLs
+func validateBootConfigsFile(ctx context.Context, opts validateOptions) []validationProblem {
+	path := opts.bootConfigsFile
+	configs, err := loadBootConfigurations(path)
+	if err != nil {
+		return []validationProblem{{file: path, message: err.Error()}}
+	}
+
+	var problems []validationProblem
+	names := map[string]bool{}
+	for i := range configs {
+		config := &configs[i]
+		location := configDisplayLocation(i, config)
+		if config.Metadata.Name != "" {
+			if names[config.Metadata.Name] {
+				problems = append(problems, validationProblem{path, location, "duplicate name"})
+			}
+			names[config.Metadata.Name] = true
+		}
+		if err := config.Validate(ctx); err != nil {
+			problems = append(problems, validationProblem{path, location, err.Error()})
+		}
+	}
+
+	for _, overlap := range bootscript.FindOverlaps(configs) {
+		problems = append(problems, validationProblem{path, "", fmt.Sprintf(
+			"%s and %s overlap on %s (profile %s, priority %d); matching nodes boot either one",
+			overlap.First, overlap.Second, overlap.Target, overlap.Profile, overlap.Priority)})
+	}
+
+	if opts.checkURLs {
+		problems = append(problems, checkBootConfigURLs(ctx, path, configs, opts.urlTimeout)...)
+	}
+	return problems
+}
+
+// checkBootConfigURLs reports http(s) kernel and initrd URLs that do not
+// respond with a success status. Paths and s3:// URLs are not checked.
+func checkBootConfigURLs(ctx context.Context, path string, configs []v1.BootConfiguration, timeout time.Duration) []validationProblem {
+	client := &http.Client{Timeout: timeout}
+	checked := map[string]error{}
+
+	var problems []validationProblem
+	for i := range configs {
+		config := &configs[i]
+		for _, rawURL := range []string{config.Spec.Kernel, config.Spec.Initrd} {
+			parsed, err := url.Parse(rawURL)
+			if rawURL == "" || err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+				continue
+			}
+			err, done := checked[rawURL]
+			if !done {
+				err = checkURL(ctx, client, rawURL)
+				checked[rawURL] = err
+			}
+			if err != nil {
+				problems = append(problems, validationProblem{path, configDisplayLocation(i, config), fmt.Sprintf("unreachable URL %s: %v", rawURL, err)})
+			}
+		}
+	}
+	return problems
+}
+
+// checkURL sends a HEAD request, falling back to GET for servers that do not
+// support HEAD
+func checkURL(ctx context.Context, client *http.Client, rawURL string) error {
+	status := 0
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close() //nolint:errcheck
+		status = resp.StatusCode
+		if status != http.StatusMethodNotAllowed && status != http.StatusNotImplemented {
+			break
+		}
+	}
+	if status >= 400 {
+		return fmt.Errorf("HTTP %d", status)
+	}
+	return nil
+}
+
+func configDisplayLocation(index int, config *v1.BootConfiguration) string {
+	if config.Metadata.Name == "" {
+		return fmt.Sprintf("[%d]", index)
+	}
+	return fmt.Sprintf("[%d] (%s)", index, config.Metadata.Name)
+}
+
+// loadBootConfigurations reads boot configurations from a YAML or JSON file
+// holding either a list of resources or a single resource
+func loadBootConfigurations(path string) ([]v1.BootConfiguration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+
+	if document.Content[0].Kind == yaml.SequenceNode {
+		var configs []v1.BootConfiguration
+		if err := decodeStrict(data, &configs); err != nil {
+			return nil, err
+		}
+		return configs, nil
+	}
+
+	var config v1.BootConfiguration
+	if err := decodeStrict(data, &config); err != nil {
+		return nil, err
+	}
+	return []v1.BootConfiguration{config}, nil
+}
+
+// decodeStrictYAML decodes a YAML file, rejecting unknown fields
+func decodeStrictYAML(path string, out interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return decodeStrict(data, out)
+}
+
+func decodeStrict(data []byte, out interface{}) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
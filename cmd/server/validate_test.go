@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeValidateTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// writeNodesDir writes nodes files into a new directory
+func writeNodesDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestRunValidate(t *testing.T) {
+	files := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/vmlinuz" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer files.Close()
+
+	tests := []struct {
+		name         string
+		opts         validateOptions
+		wantProblems []string
+	}{
+		{
+			name: "valid files",
+			opts: validateOptions{
+				configFile: writeValidateTestFile(t, "config.yaml", "port: 8080\nscript_cache_ttl: 300\n"),
+				nodesFile: writeValidateTestFile(t, "nodes.yaml", `nodes:
+  - xname: x0c0s0b0n0
+    nid: 1
+    boot_mac: aa:bb:cc:dd:ee:01
+    ethernet_interfaces:
+      - mac_address: AA:BB:CC:DD:EE:01
+`),
+				bootConfigsFile: writeValidateTestFile(t, "bootconfigs.json",
+					`[{"metadata":{"name":"compute"},"spec":{"kernel":"`+files.URL+`/vmlinuz","groups":["compute"]}}]`),
+				checkURLs: true,
+			},
+		},
+		{
+			name: "config",
+			opts: validateOptions{
+				configFile: writeValidateTestFile(t, "config.yaml", "port: 70000\nauth:\n  enabled: true\n"),
+			},
+			wantProblems: []string{"auth.enabled: unknown configuration key", "invalid port: 70000"},
+		},
+		{
+			name: "nodes schema",
+			opts: validateOptions{
+				nodesFile: writeValidateTestFile(t, "nodes.yaml", `nodes:
+  - xname: x0c0s0b0n0
+    boot_mac: aa:bb:cc:dd:ee:01
+  - xname: compute-1
+    boot_mac: AA:BB:CC:DD:EE:01
+    colour: blue
+`),
+			},
+			wantProblems: []string{"field colour not found"},
+		},
+		{
+			name: "nodes",
+			opts: validateOptions{
+				nodesFile: writeValidateTestFile(t, "nodes.yaml", `nodes:
+  - xname: x0c0s0b0n0
+    boot_mac: aa:bb:cc:dd:ee:01
+  - xname: compute-1
+    boot_mac: AA:BB:CC:DD:EE:01
+  - xname: x0c0s2b0n0
+    boot_mac: not-a-mac
+`),
+			},
+			wantProblems: []string{
+				`nodes[1] (compute-1): invalid xname "compute-1"`,
+				"nodes[1] (compute-1): duplicate mac aa:bb:cc:dd:ee:01, also used by nodes[0] (x0c0s0b0n0)",
+				`nodes[2] (x0c0s2b0n0): invalid MAC address "not-a-mac"`,
+			},
+		},
+		{
+			name: "nodes directory",
+			opts: validateOptions{
+				nodesFile: writeNodesDir(t, map[string]string{
+					"rack1.yaml": "nodes:\n  - xname: x1000c0s0b0n0\n    nid: 1\n    boot_mac: aa:bb:cc:dd:ee:01\n",
+					"rack2.yml":  "nodes:\n  - xname: x1001c0s0b0n0\n    nid: 1\n    boot_mac: aa:bb:cc:dd:ee:02\n",
+					"README.md":  "not a nodes file",
+				}),
+			},
+			wantProblems: []string{"rack2.yml: nodes[0] (x1001c0s0b0n0): duplicate nid 1, also used by ", "rack1.yaml nodes[0] (x1000c0s0b0n0)"},
+		},
+		{
+			name: "boot configurations",
+			opts: validateOptions{
+				bootConfigsFile: writeValidateTestFile(t, "bootconfigs.yaml", `- metadata: {name: compute}
+  spec: {kernel: "`+files.URL+`/missing", groups: [compute]}
+- metadata: {name: compute-new}
+  spec: {kernel: "`+files.URL+`/vmlinuz", groups: [compute, gpu]}
+- metadata: {name: broken}
+  spec: {kernel: "vmlinuz", hosts: [node1]}
+`),
+				checkURLs: true,
+			},
+			wantProblems: []string{
+				"[2] (broken): invalid host XName format: node1",
+				"compute and compute-new overlap on group compute (profile default, priority 0)",
+				"[0] (compute): unreachable URL " + files.URL + "/missing: HTTP 404",
+			},
+		},
+		{
+			name: "boot configuration schedules",
+			opts: validateOptions{
+				bootConfigsFile: writeValidateTestFile(t, "bootconfigs.yaml", `- metadata: {name: upgrade}
+  spec:
+    kernel: http://files.example.com/vmlinuz
+    activeFrom: 2026-03-01T00:00:00Z
+    windows: [{cron: "0 2 * * 6", duration: 4h}]
+- metadata: {name: bad-window}
+  spec:
+    kernel: http://files.example.com/vmlinuz
+    windows: [{cron: "0 25 * * *", duration: 4h}]
+- metadata: {name: bad-range}
+  spec:
+    kernel: http://files.example.com/vmlinuz
+    activeFrom: 2026-03-01T00:00:00Z
+    activeUntil: 2026-02-01T00:00:00Z
+`),
+			},
+			wantProblems: []string{
+				`[1] (bad-window): invalid schedule window: invalid cron expression "0 25 * * *"`,
+				"[2] (bad-range): activeUntil must be after activeFrom",
+			},
+		},
+		{
+			name: "chain configurations",
+			opts: validateOptions{
+				bootConfigsFile: writeValidateTestFile(t, "bootconfigs.yaml", `- metadata: {name: vendor}
+  spec:
+    groups: [vendor]
+    chainURL: "http://provision.example.com/ipxe/{{.XName}}?mac=${mac}"
+- metadata: {name: mixed}
+  spec:
+    kernel: http://files.example.com/vmlinuz
+    chainURL: http://provision.example.com/boot.ipxe
+- metadata: {name: bad-scheme}
+  spec:
+    chainURL: ftp://provision.example.com/boot.ipxe
+`),
+			},
+			wantProblems: []string{
+				"[1] (mixed): chainURL cannot be combined with kernel, initrd, or params",
+				"[2] (bad-scheme): invalid chainURL: ftp://provision.example.com/boot.ipxe",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := runValidate(context.Background(), &out, tt.opts)
+
+			if len(tt.wantProblems) == 0 {
+				if err != nil {
+					t.Fatalf("runValidate returned error: %v\n%s", err, out.String())
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected validation to fail, output:\n%s", out.String())
+			}
+			for _, want := range tt.wantProblems {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunValidate_RequiresInput(t *testing.T) {
+	if err := runValidate(context.Background(), &bytes.Buffer{}, validateOptions{}); err == nil {
+		t.Fatal("expected error when no files are given")
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/openchami/boot-service/pkg/clients/hsm"
+	"github.com/openchami/boot-service/pkg/vault"
+)
+
+// newVaultClient logs in to Vault when vault-addr is set, and returns nil
+// otherwise. Without a token file or AppRole, the VAULT_TOKEN environment
+// variable supplies the token.
+func newVaultClient(ctx context.Context, config Config) (*vault.Client, error) {
+	if config.VaultAddr == "" {
+		return nil, nil
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if config.VaultCACert != "" {
+		pem, err := os.ReadFile(config.VaultCACert)
+		if err != nil {
+			return nil, fmt.Errorf("reading vault-ca-cert: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", config.VaultCACert)
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+		httpClient.Transport = transport
+	}
+
+	vaultConfig := vault.Config{
+		Address:      config.VaultAddr,
+		TokenFile:    config.VaultTokenFile,
+		RoleID:       config.VaultRoleID,
+		SecretIDFile: config.VaultSecretIDFile,
+		HTTPClient:   httpClient,
+	}
+	if config.VaultTokenFile == "" && config.VaultRoleID == "" {
+		vaultConfig.Token = strings.TrimSpace(os.Getenv("VAULT_TOKEN"))
+	}
+	client, err := vault.NewClient(vaultConfig, log.New(os.Stdout, "vault: ", log.LstdFlags))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Login(ctx); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// resolveVaultReferences replaces every string setting holding a
+// vault:<path>#<field> reference with the value it references, and returns
+// the references by config key. The vault settings themselves are needed to
+// reach Vault and are never resolved.
+func resolveVaultReferences(ctx context.Context, config *Config, client *vault.Client) (map[string]vault.Reference, error) {
+	refs := map[string]vault.Reference{}
+	value := reflect.ValueOf(config).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		key := configKey(value.Type().Field(i))
+		if field.Kind() != reflect.String || key == "" || strings.HasPrefix(key, "vault_") || !vault.IsReference(field.String()) {
+			continue
+		}
+		flag := strings.ReplaceAll(key, "_", "-")
+		if client == nil {
+			return nil, fmt.Errorf("%s references Vault but vault-addr is not set", flag)
+		}
+		ref, err := vault.ParseReference(field.String())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", flag, err)
+		}
+		resolved, err := client.ReadField(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("%s: reading %s: %w", flag, ref, err)
+		}
+		field.SetString(resolved)
+		refs[key] = ref
+	}
+	return refs, nil
+}
+
+// vaultConfigLoader wraps load so reloaded configurations are resolved the
+// same way as the one the service started with
+func vaultConfigLoader(ctx context.Context, client *vault.Client, load func() (Config, error)) func() (Config, error) {
+	return func() (Config, error) {
+		config, err := load()
+		if err != nil {
+			return config, err
+		}
+		if _, err := resolveVaultReferences(ctx, &config, client); err != nil {
+			return config, err
+		}
+		return config, nil
+	}
+}
+
+// hsmAuthToken configures the static HSM bearer token. A token read from
+// Vault is re-read every vault-refresh-interval so rotations apply without a
+// restart.
+func hsmAuthToken(hsmConfig *hsm.HSMConfig, config Config, client *vault.Client, refs map[string]vault.Reference) {
+	if ref, ok := refs["hsm_auth_token"]; ok {
+		hsmConfig.AuthTokenProvider = client.CachedField(ref, time.Duration(config.VaultRefreshInterval)*time.Second)
+		return
+	}
+	hsmConfig.AuthToken = config.HSMAuthToken
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveVaultReferences(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/auth/token/lookup-self":
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"ttl": 0}}) //nolint:errcheck
+		case "/v1/secret/data/boot-service":
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{ //nolint:errcheck
+				"data":     map[string]any{"hsm_token": "hsm-secret", "redis": "redis://:pw@redis:6379/0"},
+				"metadata": map[string]any{},
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("VAULT_TOKEN", "root")
+	ctx := context.Background()
+
+	config := DefaultConfig()
+	config.VaultAddr = server.URL
+	config.HSMAuthToken = "vault:secret/data/boot-service#hsm_token"
+	config.RedisURL = "vault:secret/data/boot-service#redis"
+	client, err := newVaultClient(ctx, config)
+	if err != nil {
+		t.Fatalf("newVaultClient failed: %v", err)
+	}
+
+	refs, err := resolveVaultReferences(ctx, &config, client)
+	if err != nil {
+		t.Fatalf("resolveVaultReferences failed: %v", err)
+	}
+	if config.HSMAuthToken != "hsm-secret" || config.RedisURL != "redis://:pw@redis:6379/0" {
+		t.Errorf("resolved settings = %q, %q", config.HSMAuthToken, config.RedisURL)
+	}
+	if len(refs) != 2 || refs["hsm_auth_token"].Field != "hsm_token" {
+		t.Errorf("refs = %+v", refs)
+	}
+
+	config.S3SecretAccessKey = "vault:secret/data/boot-service#missing"
+	if _, err := resolveVaultReferences(ctx, &config, client); err == nil {
+		t.Error("expected error for a missing field")
+	}
+
+	config = DefaultConfig()
+	config.HSMAuthToken = "vault:secret/data/boot-service#hsm_token"
+	if _, err := resolveVaultReferences(ctx, &config, nil); err == nil {
+		t.Error("expected error for a reference without vault-addr")
+	}
+}
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// watchedCollections maps the list endpoints that accept ?watch=true to the
+// storage resource type they list
+var watchedCollections = map[string]string{
+	"/bootconfigurations": "BootConfiguration",
+	"/nodes":              "Node",
+}
+
+// Watch event types sent besides the resourcewatch write types
+const (
+	watchListed = "listed" // a resource in the initial list
+	watchSynced = "synced" // the initial list is complete
+	watchError  = "error"  // the watch ended; list again
+)
+
+// watchHeartbeatInterval is how often an idle server-sent event stream gets a
+// comment, so proxies keep it open and dead clients are noticed
+const watchHeartbeatInterval = 15 * time.Second
+
+// watchEvent is one event in a watch stream
+type watchEvent struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// watchLists serves ?watch=true on the node and boot configuration lists. The
+// response is the current list, as "listed" events followed by "synced", and
+// then a "created", "updated", or "deleted" event for every later write, as
+// server-sent events when the client accepts text/event-stream and as
+// newline-delimited JSON otherwise.
+//
+// It must run before the request timeout, which would end the stream; the
+// initial list is fetched through the rest of the chain, so it is subject to
+// the timeout and to scope, the tenant scoping middleware when tenancy is
+// enabled, which also filters the events.
+func watchLists(hub *resourcewatch.Hub, scope func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resourceType, ok := watchedCollections[strings.TrimSuffix(r.URL.Path, "/")]
+			query := r.URL.Query()
+			if r.Method != http.MethodGet || !ok || !query.Has("watch") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			watch, err := strconv.ParseBool(query.Get("watch"))
+			if err != nil {
+				httputil.WriteError(w, http.StatusBadRequest, "Invalid watch", "watch must be true or false")
+				return
+			}
+			if !watch {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if query.Has("limit") || query.Has("after") {
+				httputil.WriteError(w, http.StatusBadRequest, "Invalid watch", "watch cannot be combined with limit or after")
+				return
+			}
+
+			var handler http.Handler = &listWatcher{hub: hub, list: next, resourceType: resourceType}
+			if scope != nil {
+				handler = scope(handler)
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// listWatcher streams one watch request
+type listWatcher struct {
+	hub          *resourcewatch.Hub
+	list         http.Handler
+	resourceType string
+}
+
+func (l *listWatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Watch before listing so no write between the two is missed
+	watcher := l.hub.Watch(l.resourceType)
+	defer watcher.Stop()
+
+	listRequest := r.Clone(r.Context())
+	query := listRequest.URL.Query()
+	query.Del("watch")
+	listRequest.URL.RawQuery = query.Encode()
+	listRequest.RequestURI = listRequest.URL.RequestURI()
+	recorder := &bufferedResponse{header: http.Header{}, status: http.StatusOK}
+	l.list.ServeHTTP(recorder, listRequest)
+	if recorder.status != http.StatusOK {
+		for key, values := range recorder.header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(recorder.status)
+		_, _ = w.Write(recorder.body.Bytes())
+		return
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(recorder.body.Bytes(), &items); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Watch failed", err.Error())
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	sse := strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+	if sse {
+		w.Header().Set("Content-Type", "text/event-stream")
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	// The stream outlives the server's write timeout
+	_ = controller.SetWriteDeadline(time.Time{})
+	w.WriteHeader(http.StatusOK)
+
+	send := func(event watchEvent) error {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if sse {
+			_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		} else {
+			_, err = w.Write(append(data, '\n'))
+		}
+		if err != nil {
+			return err
+		}
+		return controller.Flush()
+	}
+
+	for _, item := range items {
+		if send(watchEvent{Type: watchListed, Object: item}) != nil {
+			return
+		}
+	}
+	if send(watchEvent{Type: watchSynced}) != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(watchHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if sse {
+				if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil || controller.Flush() != nil {
+					return
+				}
+			}
+		case event, ok := <-watcher.Events:
+			if !ok {
+				if watcher.Dropped() {
+					send(watchEvent{Type: watchError, Error: "watch fell behind; list again"}) //nolint:errcheck
+				}
+				return
+			}
+			object := event.New
+			if event.Type == resourcewatch.Deleted {
+				object = event.Old
+			}
+			if !tenancy.Visible(r.Context(), tenancy.Owner(object)) {
+				continue
+			}
+			if send(watchEvent{Type: event.Type, Object: object}) != nil {
+				return
+			}
+		}
+	}
+}
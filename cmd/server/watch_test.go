@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+func TestWatchLists(t *testing.T) {
+	hub := resourcewatch.NewHub(resourcewatch.DefaultWatchBuffer)
+	list := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("watch") {
+			t.Error("initial list request still has the watch parameter")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"metadata":{"uid":"nod-1"},"spec":{"tenant":"a"}}]`)) //nolint:errcheck
+	})
+	scope := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(tenancy.WithTenant(r.Context(), "a")))
+		})
+	}
+	server := httptest.NewServer(watchLists(hub, scope)(list))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/nodes?watch=true", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.Header.Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("Content-Type = %q", resp.Header.Get("Content-Type"))
+	}
+	lines := bufio.NewScanner(resp.Body)
+	next := func() watchEvent {
+		t.Helper()
+		if !lines.Scan() {
+			t.Fatalf("stream ended: %v", lines.Err())
+		}
+		var event watchEvent
+		if err := json.Unmarshal(lines.Bytes(), &event); err != nil {
+			t.Fatalf("invalid event %q: %v", lines.Text(), err)
+		}
+		return event
+	}
+
+	if event := next(); event.Type != watchListed || !strings.Contains(string(event.Object), "nod-1") {
+		t.Errorf("first event = %+v, want the listed node", event)
+	}
+	if event := next(); event.Type != watchSynced {
+		t.Errorf("second event = %+v, want synced", event)
+	}
+
+	// Events of other tenants and other resource types are not sent
+	hub.Publish(ctx, resourcewatch.Event{Type: resourcewatch.Created, ResourceType: "Node", UID: "nod-2",
+		New: json.RawMessage(`{"metadata":{"uid":"nod-2"},"spec":{"tenant":"b"}}`)})
+	hub.Publish(ctx, resourcewatch.Event{Type: resourcewatch.Created, ResourceType: "BootConfiguration", UID: "bc-1",
+		New: json.RawMessage(`{"metadata":{"uid":"bc-1"},"spec":{"tenant":"a"}}`)})
+	hub.Publish(ctx, resourcewatch.Event{Type: resourcewatch.Deleted, ResourceType: "Node", UID: "nod-1",
+		Old: json.RawMessage(`{"metadata":{"uid":"nod-1"},"spec":{"tenant":"a"}}`)})
+	if event := next(); event.Type != resourcewatch.Deleted || !strings.Contains(string(event.Object), "nod-1") {
+		t.Errorf("change event = %+v, want nod-1 deleted", event)
+	}
+
+	// Closing the hub at shutdown ends the stream
+	hub.Close()
+	if lines.Scan() {
+		t.Errorf("unexpected event after close: %s", lines.Text())
+	}
+}
+
+func TestWatchLists_ServerSentEvents(t *testing.T) {
+	hub := resourcewatch.NewHub(resourcewatch.DefaultWatchBuffer)
+	list := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { //nolint:revive
+		w.Write([]byte(`[]`)) //nolint:errcheck
+	})
+	server := httptest.NewServer(watchLists(hub, nil)(list))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/bootconfigurations?watch=1", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	reader := bufio.NewReader(resp.Body)
+	for _, want := range []string{"event: synced\n", `data: {"type":"synced"}` + "\n", "\n"} {
+		if line, err := reader.ReadString('\n'); err != nil || line != want {
+			t.Fatalf("line = %q, %v, want %q", line, err, want)
+		}
+	}
+	hub.Close()
+}
+
+func TestWatchLists_PassesThroughAndRejects(t *testing.T) {
+	hub := resourcewatch.NewHub(resourcewatch.DefaultWatchBuffer)
+	handler := watchLists(hub, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { //nolint:revive
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	tests := map[string]int{
+		"/nodes":                      http.StatusTeapot,
+		"/nodes?watch=false":          http.StatusTeapot,
+		"/bmcs?watch=true":            http.StatusTeapot,
+		"/nodes?watch=maybe":          http.StatusBadRequest,
+		"/nodes?watch=true&limit=10":  http.StatusBadRequest,
+		"/nodes?watch=true&after=n-1": http.StatusBadRequest,
+		"/nodes?watch=true":           http.StatusTeapot, // a failed initial list is returned as is
+	}
+	for target, want := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != want {
+			t.Errorf("GET %s = %d, want %d", target, rec.Code, want)
+		}
+	}
+}
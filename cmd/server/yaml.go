@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/openchami/boot-service/internal/httputil"
+)
+
+// yamlContentType is the media type of YAML responses
+const yamlContentType = "application/yaml"
+
+// yamlCollections are the resource endpoints that accept and return YAML,
+// with every path under them
+var yamlCollections = []string{"/bmcs", "/bootconfigurations", "/nodes"}
+
+// yamlRequestTypes maps the YAML media types requests may be sent as to the
+// JSON media types the handlers read
+var yamlRequestTypes = map[string]string{
+	"application/yaml":             "application/json",
+	"application/x-yaml":           "application/json",
+	"text/yaml":                    "application/json",
+	"application/merge-patch+yaml": "application/merge-patch+json",
+	"application/json-patch+yaml":  "application/json-patch+json",
+}
+
+// negotiateYAML lets clients of the resource endpoints send YAML and
+// receive it. A request body sent with a YAML Content-Type is converted to
+// JSON before it is handled, and a JSON response is converted to YAML when
+// the first YAML or JSON type in Accept is YAML. Other requests are passed
+// through unchanged.
+func negotiateYAML(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !yamlCollection(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if jsonType, ok := yamlRequestTypes[mediaType]; ok && r.Body != nil {
+			data, err := io.ReadAll(r.Body)
+			if err != nil {
+				httputil.WriteError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+				return
+			}
+			converted, err := yamlToJSON(data)
+			if err != nil {
+				httputil.WriteError(w, http.StatusBadRequest, "Invalid YAML", err.Error())
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(converted))
+			r.ContentLength = int64(len(converted))
+			r.Header.Set("Content-Type", jsonType)
+			r.Header.Set("Content-Length", strconv.Itoa(len(converted)))
+		}
+
+		w.Header().Add("Vary", "Accept")
+		if !acceptsYAML(r.Header.Get("Accept")) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		recorder := &bufferedResponse{header: w.Header(), status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		responseType, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+		if responseType != "application/json" || recorder.body.Len() == 0 {
+			w.WriteHeader(recorder.status)
+			_, _ = w.Write(recorder.body.Bytes())
+			return
+		}
+
+		converted, err := jsonToYAML(recorder.body.Bytes())
+		if err != nil {
+			httputil.WriteError(w, http.StatusInternalServerError, "Failed to encode YAML", err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", yamlContentType)
+		w.Header().Del("Content-Length")
+		w.WriteHeader(recorder.status)
+		_, _ = w.Write(converted)
+	})
+}
+
+// yamlCollection reports whether path is a resource endpoint that speaks
+// YAML
+func yamlCollection(path string) bool {
+	for _, collection := range yamlCollections {
+		if path == collection || strings.HasPrefix(path, collection+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptsYAML reports whether the first YAML or JSON media type in an Accept
+// header is YAML. Quality values are not weighed.
+func acceptsYAML(accept string) bool {
+	for _, value := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		if _, ok := yamlRequestTypes[mediaType]; ok {
+			return true
+		}
+		if mediaType == "application/json" || mediaType == "*/*" {
+			return false
+		}
+	}
+	return false
+}
+
+// yamlToJSON converts a YAML document to JSON
+func yamlToJSON(data []byte) ([]byte, error) {
+	var value any
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// jsonToYAML converts a JSON document to YAML, keeping the order of object
+// keys and the text of numbers
+func jsonToYAML(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	node, err := jsonNode(decoder)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// jsonNode reads the next JSON value from decoder as a YAML node
+func jsonNode(decoder *json.Decoder) (*yaml.Node, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch value := token.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if value == '{' {
+			node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		for decoder.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+			child, err := jsonNode(decoder)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		// The closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	case json.Number:
+		if _, err := value.Int64(); err == nil {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value.String()}, nil
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: value.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(value)}, nil
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+	return nil, fmt.Errorf("unexpected JSON token %v", token)
+}
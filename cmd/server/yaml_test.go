@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func sendYAMLForTest(t *testing.T, method, url, contentType, accept, body string) (*http.Response, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to build %s request: %v", method, err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", accept)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, url, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	return resp, data
+}
+
+func TestNegotiateYAML(t *testing.T) {
+	generated := newGeneratedRouterForTest(t)
+	server := httptest.NewServer(negotiateYAML(generated))
+	defer server.Close()
+
+	resp, data := sendYAMLForTest(t, http.MethodPost, server.URL+"/bootconfigurations", "application/yaml", "application/yaml", `
+metadata:
+  name: compute
+spec:
+  kernel: http://files.example.com/vmlinuz
+  params: console=ttyS0
+  groups: [compute]
+  priority: 10
+`)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("YAML POST returned status %d, want %d: %s", resp.StatusCode, http.StatusCreated, data)
+	}
+	if got := resp.Header.Get("Content-Type"); got != yamlContentType {
+		t.Errorf("Content-Type = %q, want %q", got, yamlContentType)
+	}
+	var created struct {
+		Metadata struct {
+			UID  string `yaml:"uid"`
+			Name string `yaml:"name"`
+		} `yaml:"metadata"`
+		Spec struct {
+			Kernel   string   `yaml:"kernel"`
+			Groups   []string `yaml:"groups"`
+			Priority int      `yaml:"priority"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(data, &created); err != nil {
+		t.Fatalf("response is not YAML: %v\n%s", err, data)
+	}
+	if created.Metadata.Name != "compute" || created.Spec.Kernel != "http://files.example.com/vmlinuz" ||
+		len(created.Spec.Groups) != 1 || created.Spec.Priority != 10 {
+		t.Errorf("created = %+v, want the YAML sent", created)
+	}
+	resourceURL := server.URL + "/bootconfigurations/" + created.Metadata.UID
+
+	resp, data = sendYAMLForTest(t, http.MethodPatch, resourceURL, "application/merge-patch+yaml", "application/json", "params: console=ttyS0 quiet\n")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("YAML merge patch returned status %d, want %d: %s", resp.StatusCode, http.StatusOK, data)
+	}
+	var patched struct {
+		Spec struct {
+			Params string `json:"params"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(data, &patched); err != nil {
+		t.Fatalf("response to an Accept of JSON is not JSON: %v\n%s", err, data)
+	}
+	if patched.Spec.Params != "console=ttyS0 quiet" {
+		t.Errorf("params = %q, want %q", patched.Spec.Params, "console=ttyS0 quiet")
+	}
+
+	// The first of YAML and JSON listed wins
+	resp, data = sendYAMLForTest(t, http.MethodGet, resourceURL, "", "application/json, application/yaml", "")
+	if resp.StatusCode != http.StatusOK || !json.Valid(data) {
+		t.Errorf("GET preferring JSON returned %d: %s", resp.StatusCode, data)
+	}
+	resp, data = sendYAMLForTest(t, http.MethodGet, server.URL+"/bootconfigurations", "", "text/yaml;q=0.9, */*;q=0.1", "")
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(string(data), "- apiVersion:") {
+		t.Errorf("YAML list returned %d:\n%s", resp.StatusCode, data)
+	}
+
+	resp, data = sendYAMLForTest(t, http.MethodPost, server.URL+"/bootconfigurations", "application/yaml", "application/yaml", "metadata: [unclosed\n")
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid YAML returned status %d, want %d: %s", resp.StatusCode, http.StatusBadRequest, data)
+	}
+}
+
+func TestJSONToYAML(t *testing.T) {
+	got, err := jsonToYAML([]byte(`{"name":"x","nid":12,"ratio":0.5,"on":true,"none":null,"version":"10","empty":[],"nested":{"b":1,"a":2}}`))
+	if err != nil {
+		t.Fatalf("jsonToYAML failed: %v", err)
+	}
+	want := `name: x
+nid: 12
+ratio: 0.5
+on: true
+none: null
+version: "10"
+empty: []
+nested:
+  b: 1
+  a: 2
+`
+	if string(got) != want {
+		t.Errorf("jsonToYAML =\n%s\nwant\n%s", got, want)
+	}
+}
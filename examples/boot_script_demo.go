@@ -37,7 +37,7 @@ func main() {
 
 	// Create controller
 	logger := log.New(os.Stderr, "demo: ", log.LstdFlags)
-	controller := bootscript.NewBootScriptController(*bootClient, logger)
+	controller := bootscript.NewBootScriptController(bootClient, logger)
 
 	// Generate boot script
 	ctx := context.Background()
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package flight collapses concurrent identical lookups into one call, so a
+// boot storm asking for the same data costs a single upstream request.
+package flight
+
+import (
+	"context"
+	"sync/atomic"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Group deduplicates concurrent calls by key. The zero Group is ready to use.
+type Group[T any] struct {
+	group  singleflight.Group
+	shared atomic.Uint64
+}
+
+// Do calls fn once for all concurrent callers with the same key and gives
+// each of them its result. fn runs detached from the caller's cancellation,
+// so one caller giving up does not fail the others, and must therefore
+// bound its own running time; each caller still stops waiting when its own
+// ctx is done.
+func (g *Group[T]) Do(ctx context.Context, key string, fn func(context.Context) (T, error)) (T, error) {
+	called := false
+	results := g.group.DoChan(key, func() (interface{}, error) {
+		called = true
+		return fn(context.WithoutCancel(ctx))
+	})
+
+	select {
+	case res := <-results:
+		if res.Shared && !called {
+			g.shared.Add(1)
+		}
+		value, _ := res.Val.(T)
+		return value, res.Err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Shared returns how many calls were served by another caller's call
+// instead of making their own
+func (g *Group[T]) Shared() uint64 {
+	return g.shared.Load()
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package httputil
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Networks is a set of IP networks
+type Networks []*net.IPNet
+
+// ParseNetworks parses CIDRs, or single addresses
+func ParseNetworks(cidrs []string) (Networks, error) {
+	var networks Networks
+	for _, cidr := range cidrs {
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid address %q", cidr)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// Contains reports whether ip belongs to one of the networks
+func (n Networks) Contains(ip net.IP) bool {
+	for _, network := range n {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsClient reports whether the client address of r, as resolved by
+// TrustedProxies.Middleware, belongs to one of the networks
+func (n Networks) ContainsClient(r *http.Request) bool {
+	ip := net.ParseIP(addressHost(r.RemoteAddr))
+	return ip != nil && n.Contains(ip)
+}
+
+// TrustedProxies resolves the client address of requests forwarded by
+// proxies in trusted networks, such as an ingress or API gateway
+type TrustedProxies struct {
+	networks Networks
+}
+
+// ParseTrustedProxies parses CIDRs, or single addresses, of trusted proxies
+func ParseTrustedProxies(cidrs []string) (*TrustedProxies, error) {
+	networks, err := ParseNetworks(cidrs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxy: %w", err)
+	}
+	return &TrustedProxies{networks: networks}, nil
+}
+
+// trusted reports whether ip belongs to a trusted proxy
+func (p *TrustedProxies) trusted(ip net.IP) bool {
+	return p.networks.Contains(ip)
+}
+
+type proxyKey struct{}
+
+// Middleware replaces the remote address of a request from a trusted proxy
+// with the client address it forwarded. X-Forwarded-For is read from the
+// right, skipping trusted proxies, so addresses a client prepends are
+// ignored; without it, X-Real-IP is used. Headers of requests from other
+// peers are ignored.
+func (p *TrustedProxies) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		peer := addressHost(r.RemoteAddr)
+		peerIP := net.ParseIP(peer)
+		if peerIP == nil || !p.trusted(peerIP) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if client := p.forwardedClient(r.Header); client != "" {
+			r.RemoteAddr = client
+			r = r.WithContext(context.WithValue(r.Context(), proxyKey{}, peer))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// forwardedClient returns the client address forwarded in headers, or ""
+// when they name none
+func (p *TrustedProxies) forwardedClient(header http.Header) string {
+	var hops []string
+	for _, value := range header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(value, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	client := ""
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(hops[i])
+		if ip == nil {
+			// An address the proxies did not write ends the trusted chain
+			break
+		}
+		client = ip.String()
+		if !p.trusted(ip) {
+			break
+		}
+	}
+	if client != "" {
+		return client
+	}
+	if ip := net.ParseIP(strings.TrimSpace(header.Get("X-Real-IP"))); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
+
+// Proxy returns the address of the trusted proxy that forwarded a request,
+// or "" when it came directly from its client
+func Proxy(r *http.Request) string {
+	proxy, _ := r.Context().Value(proxyKey{}).(string)
+	return proxy
+}
+
+// addressHost returns the host of an address with or without a port
+func addressHost(address string) string {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+	return address
+}
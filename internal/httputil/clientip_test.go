@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTrustedProxies_Middleware(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.7", "2001:db8::/32"})
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		header     http.Header
+		wantClient string
+		wantProxy  string
+	}{
+		{"direct", "198.51.100.4:51000", nil, "198.51.100.4:51000", ""},
+		{"untrusted peer", "198.51.100.4:51000", http.Header{"X-Forwarded-For": {"203.0.113.9"}}, "198.51.100.4:51000", ""},
+		{"trusted proxy", "10.1.2.3:443", http.Header{"X-Forwarded-For": {"203.0.113.9"}}, "203.0.113.9", "10.1.2.3"},
+		{"spoofed hop", "10.1.2.3:443", http.Header{"X-Forwarded-For": {"1.1.1.1, 203.0.113.9"}}, "203.0.113.9", "10.1.2.3"},
+		{"proxy chain", "192.0.2.7:443", http.Header{"X-Forwarded-For": {"203.0.113.9, 10.9.9.9", "10.1.2.3"}}, "203.0.113.9", "192.0.2.7"},
+		{"only proxies", "10.1.2.3:443", http.Header{"X-Forwarded-For": {"10.4.4.4, 10.5.5.5"}}, "10.4.4.4", "10.1.2.3"},
+		{"real ip", "10.1.2.3:443", http.Header{"X-Real-Ip": {"203.0.113.9"}}, "203.0.113.9", "10.1.2.3"},
+		{"no headers", "10.1.2.3:443", nil, "10.1.2.3:443", ""},
+		{"ipv6 proxy", "[2001:db8::1]:443", http.Header{"X-Forwarded-For": {"2001:db9::5"}}, "2001:db9::5", "2001:db8::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotClient, gotProxy string
+			handler := proxies.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				gotClient, gotProxy = r.RemoteAddr, Proxy(r)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/bootscript", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for name, values := range tt.header {
+				req.Header[name] = values
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			if gotClient != tt.wantClient || gotProxy != tt.wantProxy {
+				t.Errorf("client, proxy = %q, %q, want %q, %q", gotClient, gotProxy, tt.wantClient, tt.wantProxy)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxies_Invalid(t *testing.T) {
+	for _, cidr := range []string{"10.0.0.0/33", "proxy.example.com", ""} {
+		if _, err := ParseTrustedProxies([]string{cidr}); err == nil {
+			t.Errorf("ParseTrustedProxies(%q) succeeded, want an error", cidr)
+		}
+	}
+}
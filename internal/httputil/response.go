@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package httputil holds the JSON response and client address helpers shared
+// by the custom (non-generated) HTTP handlers.
+package httputil
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// ErrorResponse is an RFC 7807 style problem body, matching the format used by
+// the boot API handlers.
+type ErrorResponse struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+	Status int    `json:"status"`
+}
+
+// WriteJSON writes data as a JSON response with the given status code
+func WriteJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("Error encoding JSON response: %v", err)
+	}
+}
+
+// WriteError writes an RFC 7807 style error response
+func WriteError(w http.ResponseWriter, status int, title, detail string) {
+	WriteJSON(w, status, ErrorResponse{
+		Type:   "about:blank",
+		Title:  title,
+		Detail: detail,
+		Status: status,
+	})
+}
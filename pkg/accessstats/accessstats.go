@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package accessstats counts how often each node fetches its boot script and
+// remembers where the last request came from, so a node stuck in a boot loop
+// stands out. Requests are counted in memory and added to the stored counts
+// periodically; replicas sharing storage each add their own.
+package accessstats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// ResourceType is the storage resource type of node access statistics
+const ResourceType = "NodeAccessStats"
+
+// Stats are the boot script requests of one node
+type Stats struct {
+	Node string `json:"node"`
+	// Requests counts every boot script served, including cached ones
+	Requests      uint64    `json:"requests"`
+	FirstSeen     time.Time `json:"firstSeen,omitzero"`
+	LastSeen      time.Time `json:"lastSeen,omitzero"`
+	LastClient    string    `json:"lastClient,omitempty"`
+	LastProxy     string    `json:"lastProxy,omitempty"` // the trusted proxy that forwarded the last request
+	LastUserAgent string    `json:"lastUserAgent,omitempty"`
+}
+
+// merge adds the requests of other, which happened after those of s
+func (s *Stats) merge(other Stats) {
+	s.Requests += other.Requests
+	if s.FirstSeen.IsZero() || (!other.FirstSeen.IsZero() && other.FirstSeen.Before(s.FirstSeen)) {
+		s.FirstSeen = other.FirstSeen
+	}
+	if other.LastSeen.After(s.LastSeen) {
+		s.LastSeen = other.LastSeen
+		s.LastClient = other.LastClient
+		s.LastProxy = other.LastProxy
+		s.LastUserAgent = other.LastUserAgent
+	}
+}
+
+// Tracker records boot script requests per node. It implements
+// bootscript.AccessRecorder.
+type Tracker struct {
+	backend fabricaStorage.StorageBackend
+	logger  *log.Logger
+
+	mu      sync.Mutex
+	pending map[string]*Stats // requests not yet added to the stored counts
+}
+
+// NewTracker creates a tracker persisted in backend. Run adds the counted
+// requests to storage.
+func NewTracker(backend fabricaStorage.StorageBackend, logger *log.Logger) *Tracker {
+	return &Tracker{backend: backend, logger: logger, pending: make(map[string]*Stats)}
+}
+
+// RecordAccess counts a boot script request of node
+func (t *Tracker) RecordAccess(node string, access bootscript.Access) {
+	now := time.Now().UTC()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats, ok := t.pending[node]
+	if !ok {
+		stats = &Stats{Node: node, FirstSeen: now}
+		t.pending[node] = stats
+	}
+	stats.Requests++
+	stats.LastSeen = now
+	stats.LastClient = access.Client
+	stats.LastProxy = access.Proxy
+	stats.LastUserAgent = access.UserAgent
+}
+
+// Get returns the statistics of node, including requests not yet stored.
+// A node that has not requested a script has zero statistics.
+func (t *Tracker) Get(ctx context.Context, node string) (Stats, error) {
+	stats, err := t.load(ctx, node)
+	if err != nil {
+		return Stats{}, err
+	}
+	t.mu.Lock()
+	if pending, ok := t.pending[node]; ok {
+		stats.merge(*pending)
+	}
+	t.mu.Unlock()
+	return stats, nil
+}
+
+// Run adds the counted requests to storage every interval until ctx is done,
+// and once more then
+func (t *Tracker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// Flush with a fresh context: ctx is already done
+			if err := t.Flush(context.WithoutCancel(ctx)); err != nil {
+				t.logger.Printf("Failed to store node access statistics: %v", err)
+			}
+			return
+		case <-ticker.C:
+			if err := t.Flush(ctx); err != nil {
+				t.logger.Printf("Failed to store node access statistics: %v", err)
+			}
+		}
+	}
+}
+
+// Flush adds the counted requests to the stored statistics. Requests of a
+// node that could not be stored are kept for the next flush.
+func (t *Tracker) Flush(ctx context.Context) error {
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = make(map[string]*Stats, len(pending))
+	t.mu.Unlock()
+
+	var errs []error
+	for node, delta := range pending {
+		if err := t.add(ctx, node, *delta); err != nil {
+			errs = append(errs, err)
+			t.mu.Lock()
+			if newer, ok := t.pending[node]; ok {
+				delta.merge(*newer)
+			}
+			t.pending[node] = delta
+			t.mu.Unlock()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HandleResourceChange drops the statistics of deleted nodes
+func (t *Tracker) HandleResourceChange(ctx context.Context, event resourcewatch.Event) {
+	if event.ResourceType != "Node" || event.New != nil || event.Old == nil {
+		return
+	}
+	var node apiv1.Node
+	if json.Unmarshal(event.Old, &node) != nil || node.Spec.XName == "" {
+		return
+	}
+	name := node.Spec.XName
+
+	t.mu.Lock()
+	delete(t.pending, name)
+	t.mu.Unlock()
+	if err := t.backend.Delete(ctx, ResourceType, name); err != nil && !errors.Is(err, fabricaStorage.ErrNotFound) {
+		t.logger.Printf("Failed to delete access statistics of node %s: %v", name, err)
+	}
+}
+
+// add merges delta into the stored statistics of node
+func (t *Tracker) add(ctx context.Context, node string, delta Stats) error {
+	stats, err := t.load(ctx, node)
+	if err != nil {
+		return err
+	}
+	stats.merge(delta)
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("encoding access statistics of node %s: %w", node, err)
+	}
+	if err := t.backend.Save(ctx, ResourceType, node, data); err != nil {
+		return fmt.Errorf("saving access statistics of node %s: %w", node, err)
+	}
+	return nil
+}
+
+// load returns the stored statistics of node
+func (t *Tracker) load(ctx context.Context, node string) (Stats, error) {
+	data, err := t.backend.Load(ctx, ResourceType, node)
+	if errors.Is(err, fabricaStorage.ErrNotFound) {
+		return Stats{Node: node}, nil
+	}
+	if err != nil {
+		return Stats{}, fmt.Errorf("loading access statistics of node %s: %w", node, err)
+	}
+	var stats Stats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return Stats{}, fmt.Errorf("decoding access statistics of node %s: %w", node, err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package accessstats
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"testing"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+func newTestBackend(t *testing.T) fabricaStorage.StorageBackend {
+	t.Helper()
+
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	return backend
+}
+
+func TestTracker_FlushAddsToStoredCounts(t *testing.T) {
+	backend := newTestBackend(t)
+	ctx := context.Background()
+	logger := log.New(io.Discard, "", 0)
+
+	// Two replicas sharing storage
+	first := NewTracker(backend, logger)
+	second := NewTracker(backend, logger)
+
+	first.RecordAccess("x0c0s0b0n0", bootscript.Access{Client: "10.1.0.21", UserAgent: "iPXE/1.21.1"})
+	first.RecordAccess("x0c0s0b0n0", bootscript.Access{Client: "10.1.0.21", UserAgent: "iPXE/1.21.1"})
+	if err := first.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	second.RecordAccess("x0c0s0b0n0", bootscript.Access{Client: "10.1.0.22", UserAgent: "curl/8.5.0"})
+	if err := second.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	// Not yet flushed
+	first.RecordAccess("x0c0s0b0n0", bootscript.Access{Client: "10.1.0.23", UserAgent: "iPXE/1.21.1"})
+
+	stats, err := first.Get(ctx, "x0c0s0b0n0")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if stats.Requests != 4 || stats.LastClient != "10.1.0.23" || stats.FirstSeen.IsZero() || stats.LastSeen.Before(stats.FirstSeen) {
+		t.Errorf("Get = %+v, want 4 requests, last from 10.1.0.23", stats)
+	}
+
+	// A restarted tracker reads what was stored
+	stats, err = NewTracker(backend, logger).Get(ctx, "x0c0s0b0n0")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if stats.Requests != 3 || stats.LastUserAgent != "curl/8.5.0" {
+		t.Errorf("stored stats = %+v, want 3 requests, last by curl/8.5.0", stats)
+	}
+
+	stats, err = first.Get(ctx, "x0c0s1b0n0")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if stats.Node != "x0c0s1b0n0" || stats.Requests != 0 {
+		t.Errorf("Get of a node without requests = %+v, want zero stats", stats)
+	}
+}
+
+func TestTracker_HandleResourceChange(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewTracker(newTestBackend(t), log.New(io.Discard, "", 0))
+
+	tracker.RecordAccess("x0c0s0b0n0", bootscript.Access{Client: "10.1.0.21"})
+	if err := tracker.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	tracker.RecordAccess("x0c0s0b0n0", bootscript.Access{Client: "10.1.0.21"})
+
+	old, _ := json.Marshal(apiv1.Node{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0"}})
+	// Updates keep the statistics
+	tracker.HandleResourceChange(ctx, resourcewatch.Event{ResourceType: "Node", Old: old, New: old})
+	if stats, _ := tracker.Get(ctx, "x0c0s0b0n0"); stats.Requests != 2 {
+		t.Fatalf("Requests after an update = %d, want 2", stats.Requests)
+	}
+
+	tracker.HandleResourceChange(ctx, resourcewatch.Event{ResourceType: "Node", Old: old})
+	stats, err := tracker.Get(ctx, "x0c0s0b0n0")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if stats.Requests != 0 {
+		t.Errorf("Requests after deleting the node = %d, want 0", stats.Requests)
+	}
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package activity streams live boot activity, such as boot script requests
+// and the configurations they matched, to dashboards.
+//
+// Events are not stored: a subscriber sees what happens while it is
+// connected, and one that falls too far behind is dropped.
+package activity
+
+import (
+	"sync"
+	"time"
+)
+
+// Event types
+const (
+	Request   = "request"    // a node asked for its boot script
+	Match     = "match"      // a boot script was served, with what it matched
+	PhoneHome = "phone-home" // a booted node reported in
+	Discovery = "discovery"  // an unknown node was registered on its first boot
+	// SyncConflict is a local node edit that HSM sync found differing from
+	// HSM, and kept or overwrote
+	SyncConflict = "sync-conflict"
+)
+
+// DefaultBuffer is the number of events a subscriber may fall behind by
+// before it is dropped
+const DefaultBuffer = 1024
+
+// Event is one boot activity event
+type Event struct {
+	Type       string    `json:"type"`
+	Time       time.Time `json:"time"`
+	Identifier string    `json:"identifier,omitempty"` // as the node gave it: xname, MAC, NID, or UID
+	Client     string    `json:"client,omitempty"`     // address the request came from
+	Node       string    `json:"node,omitempty"`       // xname of the resolved node
+	Config     string    `json:"config,omitempty"`     // name of the matched boot configuration
+	Kernel     string    `json:"kernel,omitempty"`     // kernel of the matched configuration
+	Initrd     string    `json:"initrd,omitempty"`     // initrd of the matched configuration
+	Template   string    `json:"template,omitempty"`   // default, minimal, error, or fallback
+	Reason     string    `json:"reason,omitempty"`     // why a script other than default was served
+	Cached     bool      `json:"cached,omitempty"`
+	Hostname   string    `json:"hostname,omitempty"` // reported by phone-home
+
+	// Tenant owns the node, when known; tenants only see their own events
+	Tenant string `json:"-"`
+}
+
+// Feed fans boot activity out to subscribers
+type Feed struct {
+	buffer int
+
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+// Subscription receives the events published after it was made. Events is
+// closed when the subscription is closed or, if it fell more than the feed's
+// buffer behind, dropped.
+type Subscription struct {
+	Events <-chan Event
+
+	feed    *Feed
+	events  chan Event
+	dropped bool
+}
+
+// NewFeed creates a feed whose subscribers may fall buffer events behind
+func NewFeed(buffer int) *Feed {
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+	return &Feed{buffer: buffer, subscribers: map[*Subscription]struct{}{}}
+}
+
+// Publish delivers event to every subscriber without blocking. The event time
+// defaults to now.
+func (f *Feed) Publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for s := range f.subscribers {
+		select {
+		case s.events <- event:
+		default:
+			s.dropped = true
+			f.remove(s)
+		}
+	}
+}
+
+// Subscribe starts delivering events
+func (f *Feed) Subscribe() *Subscription {
+	events := make(chan Event, f.buffer)
+	s := &Subscription{Events: events, feed: f, events: events}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		close(events)
+		return s
+	}
+	f.subscribers[s] = struct{}{}
+	return s
+}
+
+// Close ends every subscription, and any made later, so live connections end
+// when the server shuts down
+func (f *Feed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	for s := range f.subscribers {
+		f.remove(s)
+	}
+}
+
+// remove closes a subscription's channel. The caller holds f.mu.
+func (f *Feed) remove(s *Subscription) {
+	if _, ok := f.subscribers[s]; ok {
+		delete(f.subscribers, s)
+		close(s.events)
+	}
+}
+
+// Close stops delivering events and closes Events
+func (s *Subscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	s.feed.remove(s)
+}
+
+// Dropped reports whether the subscription was dropped for falling behind.
+// It is meaningful once Events is closed.
+func (s *Subscription) Dropped() bool {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	return s.dropped
+}
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package activity
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+func TestFeed(t *testing.T) {
+	feed := NewFeed(2)
+	first, second := feed.Subscribe(), feed.Subscribe()
+
+	feed.Publish(Event{Type: Request, Identifier: "x1000c0s0b0n0"})
+	event := <-first.Events
+	if event.Identifier != "x1000c0s0b0n0" || event.Time.IsZero() {
+		t.Errorf("event = %+v, want the identifier and a time", event)
+	}
+	<-second.Events
+
+	// A subscriber more than the buffer behind is dropped; others are not
+	for i := 0; i < 3; i++ {
+		feed.Publish(Event{Type: Match})
+		<-second.Events
+	}
+	for range first.Events {
+	}
+	if !first.Dropped() || second.Dropped() {
+		t.Errorf("dropped = %v, %v; want only the lagging subscriber dropped", first.Dropped(), second.Dropped())
+	}
+
+	feed.Close()
+	if _, ok := <-second.Events; ok || second.Dropped() {
+		t.Error("expected Close to end subscriptions without dropping them")
+	}
+	if _, ok := <-feed.Subscribe().Events; ok {
+		t.Error("expected subscriptions made after Close to be ended")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	feed := NewFeed(DefaultBuffer)
+	handler := NewHandler(feed, nil)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tenant := r.URL.Query().Get("tenant"); tenant != "" {
+			r = r.WithContext(tenancy.WithTenant(r.Context(), tenant))
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	all, _, err := websocket.Dial(ctx, url, nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer all.CloseNow() //nolint:errcheck
+	tenant, _, err := websocket.Dial(ctx, url+"?tenant=site-a", nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer tenant.CloseNow() //nolint:errcheck
+
+	// Both connections are subscribed once the server is handling them
+	for deadline := time.Now().Add(2 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		feed.mu.Lock()
+		subscribed := len(feed.subscribers)
+		feed.mu.Unlock()
+		if subscribed == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d subscribers, want 2", subscribed)
+		}
+	}
+
+	feed.Publish(Event{Type: Match, Node: "x1000c0s0b0n0", Tenant: "site-b"})
+	feed.Publish(Event{Type: Match, Node: "x1000c0s0b1n0", Tenant: "site-a"})
+
+	var event Event
+	for _, want := range []string{"x1000c0s0b0n0", "x1000c0s0b1n0"} {
+		if err := wsjson.Read(ctx, all, &event); err != nil || event.Node != want {
+			t.Errorf("unscoped connection got %+v, %v; want %s", event, err, want)
+		}
+	}
+	if err := wsjson.Read(ctx, tenant, &event); err != nil || event.Node != "x1000c0s0b1n0" {
+		t.Errorf("tenant connection got %+v, %v; want only its own node", event, err)
+	}
+
+	feed.Close()
+	if _, _, err := all.Read(ctx); websocket.CloseStatus(err) != websocket.StatusGoingAway {
+		t.Errorf("read after Close = %v, want going away", err)
+	}
+}
+
+func TestHandler_RejectsOtherOrigins(t *testing.T) {
+	server := httptest.NewServer(NewHandler(NewFeed(1), []string{"dashboard.example.com"}))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	for origin, want := range map[string]bool{"https://dashboard.example.com": true, "https://evil.example.com": false} {
+		conn, _, err := websocket.Dial(context.Background(), url, &websocket.DialOptions{HTTPHeader: http.Header{"Origin": {origin}}})
+		if (err == nil) != want {
+			t.Errorf("origin %s: err = %v, want allowed %v", origin, err, want)
+		}
+		if conn != nil {
+			conn.CloseNow() //nolint:errcheck
+		}
+	}
+}
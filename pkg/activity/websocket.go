@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package activity
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// WebSocket timing
+const (
+	pingInterval = 30 * time.Second
+	writeTimeout = 10 * time.Second
+)
+
+// Handler serves the feed over WebSocket, one JSON text message per event.
+// Messages from the client are ignored.
+type Handler struct {
+	feed    *Feed
+	origins []string
+}
+
+// NewHandler creates a handler for feed. Browsers may connect from the
+// server's own origin and from hosts matching origins, which are
+// path.Match patterns such as "dashboard.example.com" or "*.example.com".
+func NewHandler(feed *Feed, origins []string) *Handler {
+	return &Handler{feed: feed, origins: origins}
+}
+
+// ServeHTTP upgrades the request and streams events until the client
+// disconnects, falls behind, or the feed is closed. A request scoped to a
+// tenant only receives that tenant's events.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{OriginPatterns: h.origins})
+	if err != nil {
+		// Accept has written the error response
+		return
+	}
+	defer conn.CloseNow() //nolint:errcheck
+
+	subscription := h.feed.Subscribe()
+	defer subscription.Close()
+	ctx := conn.CloseRead(r.Context())
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ping.C:
+			if err := withTimeout(ctx, conn.Ping); err != nil {
+				return
+			}
+		case event, ok := <-subscription.Events:
+			if !ok {
+				if subscription.Dropped() {
+					conn.Close(websocket.StatusTryAgainLater, "fell behind; reconnect") //nolint:errcheck
+				} else {
+					conn.Close(websocket.StatusGoingAway, "server shutting down") //nolint:errcheck
+				}
+				return
+			}
+			if !tenancy.Visible(r.Context(), event.Tenant) {
+				continue
+			}
+			if err := withTimeout(ctx, func(ctx context.Context) error { return wsjson.Write(ctx, conn, event) }); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func withTimeout(ctx context.Context, fn func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+	return fn(ctx)
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package admission runs site policy hooks before resources are persisted.
+//
+// Hooks review every create and update of a node or boot configuration,
+// whichever API made it, and may deny the write or mutate the resource with a
+// JSON Patch, much like Kubernetes admission webhooks. Resources call Review
+// from their Validate method, so a denial is reported as a validation failure.
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+
+	"github.com/openchami/boot-service/pkg/audit"
+)
+
+// Operations
+const (
+	Create = "CREATE"
+	Update = "UPDATE"
+)
+
+// Request describes a write under review
+type Request struct {
+	Operation string          `json:"operation"`
+	Kind      string          `json:"kind"`
+	UID       string          `json:"uid"`
+	Subject   string          `json:"subject,omitempty"`
+	Object    json.RawMessage `json:"object"`
+	OldObject json.RawMessage `json:"oldObject,omitempty"`
+}
+
+// Response is a hook's decision. Patch is an RFC 6902 JSON Patch applied to
+// the object when the write is allowed.
+type Response struct {
+	Allowed bool            `json:"allowed"`
+	Reason  string          `json:"reason,omitempty"`
+	Patch   json.RawMessage `json:"patch,omitempty"`
+}
+
+// Hook reviews writes. An error means the hook could not decide; the write
+// is refused.
+type Hook interface {
+	Name() string
+	Admit(ctx context.Context, req Request) (Response, error)
+}
+
+// Loader returns the stored resource a write replaces, or an error if there
+// is none
+type Loader func(ctx context.Context, kind, uid string) (json.RawMessage, error)
+
+// DeniedError is returned when a hook denies a write
+type DeniedError struct {
+	Hook   string
+	Reason string
+}
+
+func (e *DeniedError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("denied by admission hook %s", e.Hook)
+	}
+	return fmt.Sprintf("denied by admission hook %s: %s", e.Hook, e.Reason)
+}
+
+// Chain runs hooks in order. Each hook sees the object as patched by the
+// hooks before it.
+type Chain struct {
+	load  Loader
+	hooks []Hook
+}
+
+// NewChain creates a chain that looks up replaced resources with load
+func NewChain(load Loader, hooks ...Hook) *Chain {
+	return &Chain{load: load, hooks: hooks}
+}
+
+// Admit reviews a write of object, a pointer to a resource of kind, and
+// applies any patches to it
+func (c *Chain) Admit(ctx context.Context, kind, uid string, object any) error {
+	if len(c.hooks) == 0 {
+		return nil
+	}
+
+	current, err := json.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("encoding %s for admission: %w", kind, err)
+	}
+	req := Request{Operation: Create, Kind: kind, UID: uid, Subject: audit.SystemSubject}
+	if actor, ok := audit.ActorFromContext(ctx); ok {
+		req.Subject = actor.Subject
+	}
+	if c.load != nil && uid != "" {
+		if old, err := c.load(ctx, kind, uid); err == nil {
+			req.Operation = Update
+			req.OldObject = old
+		}
+	}
+
+	patched := false
+	for _, hook := range c.hooks {
+		req.Object = current
+		resp, err := hook.Admit(ctx, req)
+		if err != nil {
+			return fmt.Errorf("admission hook %s: %w", hook.Name(), err)
+		}
+		if !resp.Allowed {
+			return &DeniedError{Hook: hook.Name(), Reason: resp.Reason}
+		}
+		if len(resp.Patch) == 0 || string(resp.Patch) == "null" {
+			continue
+		}
+		current, err = applyPatch(current, resp.Patch)
+		if err != nil {
+			return fmt.Errorf("admission hook %s returned an invalid patch: %w", hook.Name(), err)
+		}
+		patched = true
+	}
+
+	if !patched {
+		return nil
+	}
+	if err := json.Unmarshal(current, object); err != nil {
+		return fmt.Errorf("decoding %s patched by admission hooks: %w", kind, err)
+	}
+	return nil
+}
+
+// applyPatch applies a JSON Patch that must not change the resource's UID
+func applyPatch(data, patch json.RawMessage) (json.RawMessage, error) {
+	decoded, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := decoded.Apply(data)
+	if err != nil {
+		return nil, err
+	}
+	if uidOf(patched) != uidOf(data) {
+		return nil, errors.New("patch changes metadata.uid")
+	}
+	return patched, nil
+}
+
+func uidOf(data json.RawMessage) string {
+	var resource struct {
+		Metadata struct {
+			UID string `json:"uid"`
+		} `json:"metadata"`
+	}
+	_ = json.Unmarshal(data, &resource)
+	return resource.Metadata.UID
+}
+
+var defaultChain atomic.Pointer[Chain]
+
+// SetDefault installs the chain Review uses; nil removes it
+func SetDefault(chain *Chain) {
+	defaultChain.Store(chain)
+}
+
+// Review admits a write through the default chain. Without one every write
+// is allowed unchanged.
+func Review(ctx context.Context, kind, uid string, object any) error {
+	chain := defaultChain.Load()
+	if chain == nil {
+		return nil
+	}
+	return chain.Admit(ctx, kind, uid, object)
+}
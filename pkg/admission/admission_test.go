@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchami/boot-service/pkg/audit"
+)
+
+type testResource struct {
+	Metadata struct {
+		UID string `json:"uid"`
+	} `json:"metadata"`
+	Spec struct {
+		Kernel string `json:"kernel"`
+		Params string `json:"params,omitempty"`
+	} `json:"spec"`
+}
+
+// hookFunc adapts a function to Hook
+type hookFunc func(ctx context.Context, req Request) (Response, error)
+
+func (f hookFunc) Name() string { return "test" }
+
+func (f hookFunc) Admit(ctx context.Context, req Request) (Response, error) { return f(ctx, req) }
+
+func newTestResource(kernel string) *testResource {
+	resource := &testResource{}
+	resource.Metadata.UID = "bc-1"
+	resource.Spec.Kernel = kernel
+	return resource
+}
+
+func TestChain_Admit(t *testing.T) {
+	approvedHost := hookFunc(func(_ context.Context, req Request) (Response, error) {
+		var resource testResource
+		if err := json.Unmarshal(req.Object, &resource); err != nil {
+			return Response{}, err
+		}
+		if !strings.HasPrefix(resource.Spec.Kernel, "http://images.example.com/") {
+			return Response{Reason: "kernels must come from images.example.com"}, nil
+		}
+		return Response{Allowed: true}, nil
+	})
+	addConsole := hookFunc(func(context.Context, Request) (Response, error) {
+		return Response{Allowed: true, Patch: json.RawMessage(`[{"op":"add","path":"/spec/params","value":"console=ttyS0"}]`)}, nil
+	})
+	changeUID := hookFunc(func(context.Context, Request) (Response, error) {
+		return Response{Allowed: true, Patch: json.RawMessage(`[{"op":"replace","path":"/metadata/uid","value":"bc-2"}]`)}, nil
+	})
+	unavailable := hookFunc(func(context.Context, Request) (Response, error) {
+		return Response{}, errors.New("connection refused")
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		err := NewChain(nil, approvedHost).Admit(context.Background(), "BootConfiguration", "bc-1", newTestResource("http://evil.example.com/vmlinuz"))
+		var denied *DeniedError
+		if !errors.As(err, &denied) || denied.Reason != "kernels must come from images.example.com" {
+			t.Fatalf("Admit() error = %v, want denial", err)
+		}
+	})
+
+	t.Run("patched then reviewed", func(t *testing.T) {
+		var seenParams string
+		record := hookFunc(func(_ context.Context, req Request) (Response, error) {
+			var resource testResource
+			_ = json.Unmarshal(req.Object, &resource)
+			seenParams = resource.Spec.Params
+			return Response{Allowed: true}, nil
+		})
+		resource := newTestResource("http://images.example.com/vmlinuz")
+		if err := NewChain(nil, addConsole, approvedHost, record).Admit(context.Background(), "BootConfiguration", "bc-1", resource); err != nil {
+			t.Fatalf("Admit() error = %v", err)
+		}
+		if resource.Spec.Params != "console=ttyS0" || seenParams != "console=ttyS0" {
+			t.Errorf("params = %q, later hook saw %q, want console=ttyS0", resource.Spec.Params, seenParams)
+		}
+	})
+
+	t.Run("patch may not change uid", func(t *testing.T) {
+		resource := newTestResource("http://images.example.com/vmlinuz")
+		if err := NewChain(nil, changeUID).Admit(context.Background(), "BootConfiguration", "bc-1", resource); err == nil {
+			t.Fatal("expected error for patch changing metadata.uid")
+		}
+		if resource.Metadata.UID != "bc-1" {
+			t.Errorf("uid changed to %s", resource.Metadata.UID)
+		}
+	})
+
+	t.Run("hook error refuses write", func(t *testing.T) {
+		if err := NewChain(nil, unavailable).Admit(context.Background(), "BootConfiguration", "bc-1", newTestResource("")); err == nil {
+			t.Fatal("expected error from unavailable hook")
+		}
+	})
+}
+
+func TestChain_DescribesWrite(t *testing.T) {
+	var got Request
+	capture := hookFunc(func(_ context.Context, req Request) (Response, error) {
+		got = req
+		return Response{Allowed: true}, nil
+	})
+	stored := map[string]json.RawMessage{"bc-1": json.RawMessage(`{"spec":{"kernel":"old"}}`)}
+	chain := NewChain(func(_ context.Context, kind, uid string) (json.RawMessage, error) {
+		if data, ok := stored[uid]; ok && kind == "BootConfiguration" {
+			return data, nil
+		}
+		return nil, errors.New("not found")
+	}, capture)
+
+	ctx := audit.WithActor(context.Background(), audit.Actor{Subject: "alice"})
+	if err := chain.Admit(ctx, "BootConfiguration", "bc-1", newTestResource("new")); err != nil {
+		t.Fatalf("Admit() error = %v", err)
+	}
+	if got.Operation != Update || string(got.OldObject) != `{"spec":{"kernel":"old"}}` || got.Subject != "alice" {
+		t.Errorf("update request = %+v", got)
+	}
+
+	if err := chain.Admit(context.Background(), "BootConfiguration", "bc-9", newTestResource("new")); err != nil {
+		t.Fatalf("Admit() error = %v", err)
+	}
+	if got.Operation != Create || got.OldObject != nil || got.Subject != audit.SystemSubject {
+		t.Errorf("create request = %+v", got)
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch req.UID {
+		case "allow":
+			w.Write([]byte(`{"allowed":true,"patch":[{"op":"add","path":"/spec/params","value":"quiet"}]}`)) //nolint:errcheck
+		case "deny":
+			w.Write([]byte(`{"allowed":false,"reason":"frozen"}`)) //nolint:errcheck
+		default:
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	logger := log.New(io.Discard, "", 0)
+
+	closed := NewWebhook("policy", server.URL, time.Second, false, logger)
+	resp, err := closed.Admit(context.Background(), Request{UID: "allow", Object: json.RawMessage(`{}`)})
+	if err != nil || !resp.Allowed || string(resp.Patch) == "" {
+		t.Errorf("allow response = %+v, %v", resp, err)
+	}
+	resp, err = closed.Admit(context.Background(), Request{UID: "deny", Object: json.RawMessage(`{}`)})
+	if err != nil || resp.Allowed || resp.Reason != "frozen" {
+		t.Errorf("deny response = %+v, %v", resp, err)
+	}
+	if _, err := closed.Admit(context.Background(), Request{UID: "error", Object: json.RawMessage(`{}`)}); err == nil {
+		t.Error("expected error from failing webhook")
+	}
+
+	open := NewWebhook("policy", server.URL, time.Second, true, logger)
+	if resp, err := open.Admit(context.Background(), Request{UID: "error", Object: json.RawMessage(`{}`)}); err != nil || !resp.Allowed {
+		t.Errorf("fail-open response = %+v, %v", resp, err)
+	}
+}
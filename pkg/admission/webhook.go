@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// maxWebhookResponse bounds the size of a webhook's response body
+const maxWebhookResponse = 1 << 20
+
+// Webhook is a hook that POSTs each Request as JSON to a URL and expects a
+// Response in return
+type Webhook struct {
+	name       string
+	url        string
+	httpClient *http.Client
+	failOpen   bool
+	logger     *log.Logger
+}
+
+// NewWebhook creates a webhook hook calling url within timeout. With
+// failOpen, writes are allowed when the webhook cannot be reached or answers
+// with an error; otherwise they are refused.
+func NewWebhook(name, url string, timeout time.Duration, failOpen bool, logger *log.Logger) *Webhook {
+	if logger == nil {
+		logger = log.New(log.Writer(), "admission: ", log.LstdFlags)
+	}
+	return &Webhook{
+		name:       name,
+		url:        url,
+		httpClient: &http.Client{Timeout: timeout},
+		failOpen:   failOpen,
+		logger:     logger,
+	}
+}
+
+// Name returns the webhook's name
+func (w *Webhook) Name() string {
+	return w.name
+}
+
+// Admit asks the webhook to review req
+func (w *Webhook) Admit(ctx context.Context, req Request) (Response, error) {
+	resp, err := w.call(ctx, req)
+	if err != nil && w.failOpen {
+		w.logger.Printf("Admission webhook %s failed, allowing %s of %s %s: %v", w.name, req.Operation, req.Kind, req.UID, err)
+		return Response{Allowed: true}, nil
+	}
+	return resp, err
+}
+
+func (w *Webhook) call(ctx context.Context, req Request) (Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return Response{}, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return Response{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := w.httpClient.Do(httpReq)
+	if err != nil {
+		return Response{}, err
+	}
+	defer httpResp.Body.Close() //nolint:errcheck
+
+	if httpResp.StatusCode != http.StatusOK {
+		return Response{}, fmt.Errorf("webhook returned %s", httpResp.Status)
+	}
+	var resp Response
+	if err := json.NewDecoder(io.LimitReader(httpResp.Body, maxWebhookResponse)).Decode(&resp); err != nil {
+		return Response{}, fmt.Errorf("decoding webhook response: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package apikeys authenticates machine clients, such as DHCP and TFTP
+// integrations, with long-lived API keys instead of JWTs. A key is shown
+// once when it is created; only its SHA-256 hash is stored, with the scopes
+// and tenant the key acts with. Keys are sent in the X-API-Key header and
+// accepted wherever bearer tokens are verified.
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+	"github.com/openchami/tokensmith/pkg/token"
+)
+
+// ResourceType is the storage resource type of API keys
+const ResourceType = "APIKey"
+
+// keyPrefix starts every key, so leaked keys are easy to recognize
+const keyPrefix = "bsk_"
+
+var (
+	// ErrInvalidKey is returned for a key that is unknown, revoked, or expired
+	ErrInvalidKey = errors.New("invalid API key")
+	// ErrInvalidRequest is returned for a key that cannot be created
+	ErrInvalidRequest = errors.New("invalid API key request")
+)
+
+// Key is a stored API key
+type Key struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Scopes are the token scopes requests with the key carry
+	Scopes []string `json:"scopes"`
+	// Tenant is the cluster_id requests with the key are scoped to, if any
+	Tenant    string    `json:"tenant,omitempty"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitzero"`
+	ExpiresAt time.Time `json:"expiresAt,omitzero"`
+	// Hash is the hex SHA-256 of the key. It is never returned by the API.
+	Hash string `json:"hash,omitempty"`
+}
+
+// Subject is the subject requests with the key are attributed to
+func (k Key) Subject() string {
+	return "apikey:" + k.Name
+}
+
+// Request describes a key to create
+type Request struct {
+	Name      string    `json:"name"`
+	Scopes    []string  `json:"scopes"`
+	Tenant    string    `json:"tenant,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt,omitzero"`
+}
+
+// Store creates, lists, revokes, and verifies the API keys persisted in the
+// resource storage backend. It implements auth.APIKeyVerifier.
+type Store struct {
+	backend fabricaStorage.StorageBackend
+	logger  *log.Logger
+}
+
+// NewStore creates an API key store persisted in backend
+func NewStore(backend fabricaStorage.StorageBackend, logger *log.Logger) *Store {
+	return &Store{backend: backend, logger: logger}
+}
+
+// Create stores a new key for req, returning it and the key itself, which
+// cannot be recovered later
+func (s *Store) Create(ctx context.Context, req Request, createdBy string) (Key, string, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return Key{}, "", fmt.Errorf("%w: name is required", ErrInvalidRequest)
+	}
+	if !req.ExpiresAt.IsZero() && !req.ExpiresAt.After(time.Now()) {
+		return Key{}, "", fmt.Errorf("%w: expiresAt is in the past", ErrInvalidRequest)
+	}
+	var scopes []string
+	for _, scope := range req.Scopes {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	id, err := randomBytes(6)
+	if err != nil {
+		return Key{}, "", err
+	}
+	secret, err := randomBytes(32)
+	if err != nil {
+		return Key{}, "", err
+	}
+	plain := keyPrefix + hex.EncodeToString(id) + "_" + base64.RawURLEncoding.EncodeToString(secret)
+
+	key := Key{
+		ID:        hex.EncodeToString(id),
+		Name:      req.Name,
+		Scopes:    scopes,
+		Tenant:    strings.TrimSpace(req.Tenant),
+		CreatedBy: createdBy,
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: req.ExpiresAt.UTC(),
+		Hash:      hashKey(plain),
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return Key{}, "", fmt.Errorf("encoding API key %s: %w", key.Name, err)
+	}
+	if err := s.backend.Save(ctx, ResourceType, key.ID, data); err != nil {
+		return Key{}, "", fmt.Errorf("saving API key %s: %w", key.Name, err)
+	}
+	s.logger.Printf("API key %s (%s) created by %q with scopes %v", key.ID, key.Name, createdBy, key.Scopes)
+	key.Hash = ""
+	return key, plain, nil
+}
+
+// List returns the keys, without their hashes, sorted by name
+func (s *Store) List(ctx context.Context) ([]Key, error) {
+	items, err := s.backend.LoadAll(ctx, ResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("loading API keys: %w", err)
+	}
+	keys := make([]Key, 0, len(items))
+	for _, item := range items {
+		var key Key
+		if err := json.Unmarshal(item, &key); err != nil {
+			return nil, fmt.Errorf("decoding API key: %w", err)
+		}
+		key.Hash = ""
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Name != keys[j].Name {
+			return keys[i].Name < keys[j].Name
+		}
+		return keys[i].ID < keys[j].ID
+	})
+	return keys, nil
+}
+
+// Revoke deletes the key with id. It reports whether the key existed.
+func (s *Store) Revoke(ctx context.Context, id string) (bool, error) {
+	err := s.backend.Delete(ctx, ResourceType, id)
+	if errors.Is(err, fabricaStorage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("deleting API key %s: %w", id, err)
+	}
+	s.logger.Printf("API key %s revoked", id)
+	return true, nil
+}
+
+// Verify returns the stored key of plain, or ErrInvalidKey
+func (s *Store) Verify(ctx context.Context, plain string) (Key, error) {
+	rest, ok := strings.CutPrefix(plain, keyPrefix)
+	id, _, ok2 := strings.Cut(rest, "_")
+	if !ok || !ok2 || id == "" {
+		return Key{}, ErrInvalidKey
+	}
+	data, err := s.backend.Load(ctx, ResourceType, id)
+	if errors.Is(err, fabricaStorage.ErrNotFound) {
+		return Key{}, ErrInvalidKey
+	}
+	if err != nil {
+		return Key{}, fmt.Errorf("loading API key %s: %w", id, err)
+	}
+	var key Key
+	if err := json.Unmarshal(data, &key); err != nil {
+		return Key{}, fmt.Errorf("decoding API key %s: %w", id, err)
+	}
+	if subtle.ConstantTimeCompare([]byte(key.Hash), []byte(hashKey(plain))) != 1 {
+		return Key{}, ErrInvalidKey
+	}
+	if !key.ExpiresAt.IsZero() && time.Now().After(key.ExpiresAt) {
+		return Key{}, ErrInvalidKey
+	}
+	key.Hash = ""
+	return key, nil
+}
+
+// VerifyAPIKey returns the claims requests with plain act with. It
+// implements auth.APIKeyVerifier.
+func (s *Store) VerifyAPIKey(ctx context.Context, plain string) (*token.TSClaims, error) {
+	key, err := s.Verify(ctx, plain)
+	if err != nil {
+		return nil, err
+	}
+	claims := &token.TSClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: key.Subject(), ID: key.ID},
+		Scope:            key.Scopes,
+		ClusterID:        key.Tenant,
+	}
+	return claims, nil
+}
+
+// hashKey returns the hex SHA-256 of a key. Keys carry 256 random bits, so
+// a fast hash is enough.
+func hashKey(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomBytes returns n random bytes
+func randomBytes(n int) ([]byte, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("generating API key: %w", err)
+	}
+	return buf, nil
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package apikeys
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	return NewStore(backend, log.New(io.Discard, "", 0))
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	key, plain, err := store.Create(ctx, Request{Name: "dhcp", Scopes: []string{"bss:bootscript", " "}, Tenant: "site-a"}, "alice")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if !strings.HasPrefix(plain, keyPrefix+key.ID+"_") || key.Hash != "" || key.CreatedBy != "alice" || len(key.Scopes) != 1 {
+		t.Errorf("Create = %+v, %q", key, plain)
+	}
+
+	claims, err := store.VerifyAPIKey(ctx, plain)
+	if err != nil {
+		t.Fatalf("VerifyAPIKey failed: %v", err)
+	}
+	if claims.Subject != "apikey:dhcp" || claims.ClusterID != "site-a" || len(claims.Scope) != 1 || claims.Scope[0] != "bss:bootscript" {
+		t.Errorf("claims = %+v", claims)
+	}
+	for _, wrong := range []string{"", "bsk_", plain + "x", keyPrefix + "000000000000_" + strings.Repeat("a", 43), "Bearer " + plain} {
+		if _, err := store.Verify(ctx, wrong); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Verify(%q) = %v, want ErrInvalidKey", wrong, err)
+		}
+	}
+
+	keys, err := store.List(ctx)
+	if err != nil || len(keys) != 1 || keys[0].Hash != "" || keys[0].Name != "dhcp" {
+		t.Errorf("List = %+v, %v, want the key without its hash", keys, err)
+	}
+
+	if revoked, err := store.Revoke(ctx, key.ID); err != nil || !revoked {
+		t.Fatalf("Revoke = %v, %v", revoked, err)
+	}
+	if _, err := store.Verify(ctx, plain); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("revoked key verified: %v", err)
+	}
+	if revoked, err := store.Revoke(ctx, key.ID); err != nil || revoked {
+		t.Errorf("second Revoke = %v, %v, want nothing to revoke", revoked, err)
+	}
+}
+
+func TestStore_Expiry(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	if _, _, err := store.Create(ctx, Request{Name: "old", ExpiresAt: time.Now().Add(-time.Hour)}, ""); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("Create with a past expiry = %v, want ErrInvalidRequest", err)
+	}
+	if _, _, err := store.Create(ctx, Request{Name: " "}, ""); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("Create without a name = %v, want ErrInvalidRequest", err)
+	}
+
+	_, plain, err := store.Create(ctx, Request{Name: "short", ExpiresAt: time.Now().Add(50 * time.Millisecond)}, "")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := store.Verify(ctx, plain); err != nil {
+		t.Fatalf("Verify before expiry failed: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := store.Verify(ctx, plain); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("expired key verified: %v", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	store := newTestStore(t)
+	router := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(router)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := serve(http.MethodPost, Path, `{"name": "tftp", "scopes": ["bss:bootscript"]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected status 201, got %d: %s", w.Code, w.Body)
+	}
+	var created Created
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatal(err)
+	}
+	if created.APIKey == "" || created.Hash != "" {
+		t.Errorf("created = %+v, want the key once and no hash", created)
+	}
+	if _, err := store.Verify(context.Background(), created.APIKey); err != nil {
+		t.Errorf("created key does not verify: %v", err)
+	}
+	if w := serve(http.MethodPost, Path, `{"scopes": ["admin"]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("create without a name: expected status 400, got %d", w.Code)
+	}
+
+	w = serve(http.MethodGet, Path, "")
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), created.APIKey) || !strings.Contains(w.Body.String(), `"tftp"`) {
+		t.Errorf("list: got %d: %s", w.Code, w.Body)
+	}
+
+	if w := serve(http.MethodDelete, Path+"/"+created.ID, ""); w.Code != http.StatusNoContent {
+		t.Errorf("revoke: expected status 204, got %d: %s", w.Code, w.Body)
+	}
+	if w := serve(http.MethodDelete, Path+"/"+created.ID, ""); w.Code != http.StatusNotFound {
+		t.Errorf("second revoke: expected status 404, got %d", w.Code)
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package apikeys
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/auth"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Path serves the API key API
+const Path = "/admin/api-keys"
+
+// maxRequestSize bounds the body of POST /admin/api-keys
+const maxRequestSize = 64 << 10
+
+// Created is the response to creating a key. APIKey is only ever shown
+// here.
+type Created struct {
+	Key
+	APIKey string `json:"apiKey"`
+}
+
+// Handler serves the API key API
+type Handler struct {
+	store *Store
+}
+
+// NewHandler creates an API key API handler
+func NewHandler(store *Store) *Handler {
+	return &Handler{store: store}
+}
+
+// RegisterRoutes registers GET and POST /admin/api-keys and DELETE
+// /admin/api-keys/{id}
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route(Path, func(r chi.Router) {
+		r.Use(tenancy.Unscoped)
+		r.Get("/", h.ListKeys)
+		r.Post("/", h.CreateKey)
+		r.Delete("/{id}", h.RevokeKey)
+	})
+}
+
+// ListKeys handles GET /admin/api-keys
+func (h *Handler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.store.List(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to list API keys", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, keys)
+}
+
+// CreateKey handles POST /admin/api-keys, returning the new key once
+func (h *Handler) CreateKey(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid API key request", err.Error())
+		return
+	}
+	var createdBy string
+	if claims, err := auth.GetClaimsFromRequest(r); err == nil {
+		createdBy = claims.Subject
+	}
+
+	key, plain, err := h.store.Create(r.Context(), req, createdBy)
+	if errors.Is(err, ErrInvalidRequest) {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid API key request", err.Error())
+		return
+	}
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to create API key", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusCreated, Created{Key: key, APIKey: plain})
+}
+
+// RevokeKey handles DELETE /admin/api-keys/{id}
+func (h *Handler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	revoked, err := h.store.Revoke(r.Context(), id)
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to revoke API key", err.Error())
+		return
+	}
+	if !revoked {
+		httputil.WriteError(w, http.StatusNotFound, "API key not found", "no API key "+id)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package artifacts tracks the kernel and initrd images referenced by boot
+// configurations.
+//
+// Each artifact records where an image lives and what it should contain (URL,
+// SHA256, size, architecture). URLs are plain HTTP(S) or s3://bucket/key
+// objects, which are exposed to nodes through presigned URLs. Registering an
+// artifact downloads it once to confirm it is reachable and matches the
+// declared checksum. Boot
+// configurations then reference the artifact by name, so publishing a new
+// kernel is a single artifact update instead of an edit to every configuration.
+package artifacts
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ResourceType is the storage resource type used for artifact records
+const ResourceType = "BootArtifact"
+
+// Artifact types
+const (
+	TypeKernel = "kernel"
+	TypeInitrd = "initrd"
+	TypeOther  = "other"
+)
+
+var (
+	// ErrNotFound is returned when an artifact name is not registered
+	ErrNotFound = errors.New("artifact not found")
+
+	// ErrVerificationFailed is returned when downloaded content does not match the declared SHA256 or size
+	ErrVerificationFailed = errors.New("artifact verification failed")
+
+	namePattern   = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]*[a-z0-9])?$`)
+	sha256Pattern = regexp.MustCompile(`^[a-f0-9]{64}$`)
+)
+
+// Artifact describes a boot image tracked by the registry
+type Artifact struct {
+	Name         string    `json:"name" yaml:"name"`
+	Type         string    `json:"type,omitempty" yaml:"type,omitempty"` // kernel, initrd, other
+	URL          string    `json:"url" yaml:"url"`
+	SHA256       string    `json:"sha256" yaml:"sha256"`
+	Size         int64     `json:"size,omitempty" yaml:"size,omitempty"`
+	Architecture string    `json:"architecture,omitempty" yaml:"architecture,omitempty"` // e.g. x86_64, aarch64
+	Description  string    `json:"description,omitempty" yaml:"description,omitempty"`
+	VerifiedAt   time.Time `json:"verifiedAt,omitempty" yaml:"verifiedAt,omitempty"`
+	CreatedAt    time.Time `json:"createdAt" yaml:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt" yaml:"updatedAt"`
+}
+
+// Validate checks the static fields of an artifact record
+func (a *Artifact) Validate() error {
+	if !ValidName(a.Name) {
+		return fmt.Errorf("invalid artifact name %q: use lowercase letters, digits, '.', '_' and '-'", a.Name)
+	}
+
+	if isS3URL(a.URL) {
+		if _, _, err := parseS3URL(a.URL); err != nil {
+			return err
+		}
+	} else if parsed, err := url.Parse(a.URL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid artifact URL %q: must be an http, https, or s3 URL", a.URL)
+	}
+
+	a.SHA256 = strings.ToLower(a.SHA256)
+	if !sha256Pattern.MatchString(a.SHA256) {
+		return fmt.Errorf("invalid sha256 %q: must be 64 hexadecimal characters", a.SHA256)
+	}
+
+	if a.Size < 0 {
+		return errors.New("size must not be negative")
+	}
+
+	switch a.Type {
+	case "", TypeKernel, TypeInitrd, TypeOther:
+	default:
+		return fmt.Errorf("invalid artifact type %q: must be kernel, initrd, or other", a.Type)
+	}
+
+	return nil
+}
+
+// ValidName reports whether name can be used as an artifact name
+func ValidName(name string) bool {
+	return len(name) <= 253 && namePattern.MatchString(name) && !strings.Contains(name, "..")
+}
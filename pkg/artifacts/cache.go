@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package artifacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+)
+
+// LocalCache downloads registered artifacts into a local directory and serves
+// them at /artifacts/{name}, so nodes do not depend on the upstream file server
+// while booting. Files are stored by SHA256 and verified before use.
+type LocalCache struct {
+	registry   *Registry
+	dir        string
+	baseURL    string
+	httpClient *http.Client
+	logger     *log.Logger
+
+	mu         sync.Mutex
+	fetching   map[string]*sync.Mutex // keyed by SHA256
+	background map[string]bool        // SHA256s being fetched in the background
+}
+
+// NewLocalCache creates a local artifact cache rooted at dir. baseURL is the
+// externally reachable URL of this service (e.g. "http://10.0.0.1:8080") and
+// is used to rewrite artifact URLs in generated boot scripts.
+func NewLocalCache(registry *Registry, dir, baseURL string, httpClient *http.Client, logger *log.Logger) (*LocalCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating artifact cache directory %s: %w", dir, err)
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Minute}
+	}
+
+	return &LocalCache{
+		registry:   registry,
+		dir:        dir,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+		logger:     logger,
+		fetching:   make(map[string]*sync.Mutex),
+		background: make(map[string]bool),
+	}, nil
+}
+
+// RegisterRoutes registers GET and HEAD /artifacts/{name}
+func (c *LocalCache) RegisterRoutes(r chi.Router) {
+	r.Get("/artifacts/{name}", c.ServeArtifact)
+	r.Head("/artifacts/{name}", c.ServeArtifact)
+}
+
+// ResolveArtifactURL returns the URL of the artifact on this service and starts
+// fetching it in the background, unless it is cached, so it is ready when the
+// node requests it. It implements bootscript.ArtifactResolver.
+func (c *LocalCache) ResolveArtifactURL(ctx context.Context, name string) (string, error) {
+	artifact, err := c.registry.Get(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	c.fetchInBackground(artifact)
+	return c.baseURL + "/artifacts/" + url.PathEscape(artifact.Name), nil
+}
+
+// fetchInBackground starts fetching artifact unless it is cached or already
+// being fetched in the background, so rendering many scripts that use it
+// starts one download
+func (c *LocalCache) fetchInBackground(artifact *Artifact) {
+	if _, err := os.Stat(filepath.Join(c.dir, artifact.SHA256)); err == nil {
+		return
+	}
+	c.mu.Lock()
+	if c.background[artifact.SHA256] {
+		c.mu.Unlock()
+		return
+	}
+	c.background[artifact.SHA256] = true
+	c.mu.Unlock()
+
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			delete(c.background, artifact.SHA256)
+			c.mu.Unlock()
+		}()
+		// The download is bounded by the timeout of the HTTP client, if any
+		if _, err := c.fetch(context.Background(), artifact); err != nil {
+			c.logger.Printf("Background fetch of artifact %s failed: %v", artifact.Name, err)
+		}
+	}()
+}
+
+// Prefetch downloads every registered artifact that is not cached yet
+func (c *LocalCache) Prefetch(ctx context.Context) error {
+	artifacts, err := c.registry.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range artifacts {
+		if _, err := c.fetch(ctx, &artifacts[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ServeArtifact handles GET and HEAD /artifacts/{name}
+func (c *LocalCache) ServeArtifact(w http.ResponseWriter, r *http.Request) {
+	artifact, err := c.registry.Get(r.Context(), chi.URLParam(r, "name"))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			httputil.WriteError(w, http.StatusNotFound, "Artifact not found", err.Error())
+			return
+		}
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to get artifact", err.Error())
+		return
+	}
+
+	path, err := c.fetch(r.Context(), artifact)
+	if err != nil {
+		c.logger.Printf("Failed to fetch artifact %s: %v", artifact.Name, err)
+		httputil.WriteError(w, http.StatusBadGateway, "Artifact unavailable", err.Error())
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to open artifact", err.Error())
+		return
+	}
+	defer file.Close() //nolint:errcheck
+
+	info, err := file.Stat()
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to open artifact", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("ETag", `"`+artifact.SHA256+`"`)
+	http.ServeContent(w, r, artifact.Name, info.ModTime(), file)
+}
+
+// fetch returns the local path of the artifact, downloading and verifying it
+// first if it is not cached
+func (c *LocalCache) fetch(ctx context.Context, artifact *Artifact) (string, error) {
+	path := filepath.Join(c.dir, artifact.SHA256)
+
+	lock := c.lockFor(artifact.SHA256)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	downloadURL, err := c.registry.downloadURL(artifact)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("creating request for %s: %w", artifact.URL, err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("downloading %s: %w", artifact.URL, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading %s: HTTP %d", artifact.URL, resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp(c.dir, artifact.SHA256+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("creating cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	hash := sha256.New()
+	_, copyErr := io.Copy(io.MultiWriter(tmp, hash), resp.Body)
+	closeErr := tmp.Close()
+	if copyErr != nil {
+		return "", fmt.Errorf("downloading %s: %w", artifact.URL, copyErr)
+	}
+	if closeErr != nil {
+		return "", fmt.Errorf("writing cache file: %w", closeErr)
+	}
+
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != artifact.SHA256 {
+		return "", fmt.Errorf("%w: %s has sha256 %s, expected %s", ErrVerificationFailed, artifact.URL, sum, artifact.SHA256)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("storing cache file: %w", err)
+	}
+
+	c.logger.Printf("Cached artifact %s from %s", artifact.Name, artifact.URL)
+	return path, nil
+}
+
+func (c *LocalCache) lockFor(sha string) *sync.Mutex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lock, ok := c.fetching[sha]
+	if !ok {
+		lock = &sync.Mutex{}
+		c.fetching[sha] = lock
+	}
+	return lock
+}
@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package artifacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+)
+
+func TestLocalCache_ServesAndRewritesArtifacts(t *testing.T) {
+	var upstreamRequests atomic.Int32
+	var otherContent atomic.Value
+	otherContent.Store("other image")
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamRequests.Add(1)
+		if r.URL.Path == "/other" {
+			_, _ = io.WriteString(w, otherContent.Load().(string))
+			return
+		}
+		_, _ = io.WriteString(w, testKernelContent)
+	}))
+	defer upstream.Close()
+
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	logger := log.New(io.Discard, "", 0)
+	registry := NewRegistry(backend, upstream.Client(), logger)
+
+	ctx := context.Background()
+	if _, err := registry.Register(ctx, Artifact{Name: "kernel", URL: upstream.URL + "/vmlinuz", SHA256: testKernelSHA256()}); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	otherSum := sha256.Sum256([]byte("other image"))
+	if _, err := registry.Register(ctx, Artifact{Name: "tampered", URL: upstream.URL + "/other", SHA256: hex.EncodeToString(otherSum[:])}); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	upstreamRequests.Store(0)
+
+	cache, err := NewLocalCache(registry, t.TempDir(), "http://boot.example.com:8080/", upstream.Client(), logger)
+	if err != nil {
+		t.Fatalf("NewLocalCache returned error: %v", err)
+	}
+
+	r := chi.NewRouter()
+	cache.RegisterRoutes(r)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	for i := 0; i < 2; i++ {
+		resp, err := http.Get(server.URL + "/artifacts/kernel")
+		if err != nil {
+			t.Fatalf("GET /artifacts/kernel failed: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close() //nolint:errcheck
+		if resp.StatusCode != http.StatusOK || string(body) != testKernelContent {
+			t.Fatalf("GET /artifacts/kernel = %d %q, want 200 %q", resp.StatusCode, body, testKernelContent)
+		}
+	}
+	if got := upstreamRequests.Load(); got != 1 {
+		t.Errorf("upstream requests = %d, want 1", got)
+	}
+
+	// Content that no longer matches the registered checksum is never served.
+	otherContent.Store("replaced upstream")
+	resp, err := http.Get(server.URL + "/artifacts/tampered")
+	if err != nil {
+		t.Fatalf("GET /artifacts/tampered failed: %v", err)
+	}
+	resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("GET /artifacts/tampered returned %d, want %d", resp.StatusCode, http.StatusBadGateway)
+	}
+
+	url, err := cache.ResolveArtifactURL(ctx, "kernel")
+	if err != nil {
+		t.Fatalf("ResolveArtifactURL returned error: %v", err)
+	}
+	if url != "http://boot.example.com:8080/artifacts/kernel" {
+		t.Errorf("ResolveArtifactURL = %q", url)
+	}
+	if _, err := cache.ResolveArtifactURL(ctx, "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestLocalCache_ResolveFetchesOnce(t *testing.T) {
+	var upstreamRequests atomic.Int32
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if upstreamRequests.Add(1) > 1 {
+			<-release
+		}
+		_, _ = io.WriteString(w, testKernelContent)
+	}))
+	defer upstream.Close()
+
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	logger := log.New(io.Discard, "", 0)
+	registry := NewRegistry(backend, upstream.Client(), logger)
+	ctx := context.Background()
+	// Registration downloads the artifact once to check it
+	if _, err := registry.Register(ctx, Artifact{Name: "kernel", URL: upstream.URL + "/vmlinuz", SHA256: testKernelSHA256()}); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	dir := t.TempDir()
+	cache, err := NewLocalCache(registry, dir, "http://boot.example.com:8080", upstream.Client(), logger)
+	if err != nil {
+		t.Fatalf("NewLocalCache returned error: %v", err)
+	}
+
+	// Rendering many scripts while the download runs starts it once
+	for range 20 {
+		if _, err := cache.ResolveArtifactURL(ctx, "kernel"); err != nil {
+			t.Fatalf("ResolveArtifactURL returned error: %v", err)
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for upstreamRequests.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cache.mu.Lock()
+	fetching := len(cache.background)
+	cache.mu.Unlock()
+	if fetching != 1 {
+		t.Errorf("%d background fetches running, want 1", fetching)
+	}
+	close(release)
+	for time.Now().Before(deadline) {
+		cache.mu.Lock()
+		fetching := len(cache.background)
+		cache.mu.Unlock()
+		if fetching == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := upstreamRequests.Load(); got != 2 {
+		t.Fatalf("upstream requests = %d, want one download after registration", got)
+	}
+
+	// Once cached, rendering starts no download
+	if _, err := cache.ResolveArtifactURL(ctx, "kernel"); err != nil {
+		t.Fatalf("ResolveArtifactURL returned error: %v", err)
+	}
+	cache.mu.Lock()
+	fetching = len(cache.background)
+	cache.mu.Unlock()
+	if fetching != 0 {
+		t.Error("ResolveArtifactURL started a fetch of a cached artifact")
+	}
+}
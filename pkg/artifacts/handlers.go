@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package artifacts
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Handler serves the artifact registry API
+type Handler struct {
+	registry *Registry
+}
+
+// NewHandler creates an artifact registry API handler
+func NewHandler(registry *Registry) *Handler {
+	return &Handler{registry: registry}
+}
+
+// RegisterRoutes registers the artifact registry routes at /bootartifacts.
+// Every tenant's boot configurations use the artifacts, so tenants may read
+// but not change them.
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route("/bootartifacts", func(r chi.Router) {
+		r.Get("/", h.ListArtifacts)
+		r.Get("/{name}", h.GetArtifact)
+		r.Group(func(r chi.Router) {
+			r.Use(tenancy.Unscoped)
+			r.Post("/", h.RegisterArtifact)
+			r.Put("/{name}", h.RegisterArtifact)
+			r.Delete("/{name}", h.DeleteArtifact)
+			r.Post("/{name}/verify", h.VerifyArtifact)
+		})
+	})
+}
+
+// ListArtifacts handles GET /bootartifacts
+func (h *Handler) ListArtifacts(w http.ResponseWriter, r *http.Request) {
+	artifacts, err := h.registry.List(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to list artifacts", err.Error())
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, artifacts)
+}
+
+// GetArtifact handles GET /bootartifacts/{name}
+func (h *Handler) GetArtifact(w http.ResponseWriter, r *http.Request) {
+	artifact, err := h.registry.Get(r.Context(), chi.URLParam(r, "name"))
+	if err != nil {
+		h.writeRegistryError(w, "Failed to get artifact", err)
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, artifact)
+}
+
+// RegisterArtifact handles POST /bootartifacts and PUT /bootartifacts/{name}
+func (h *Handler) RegisterArtifact(w http.ResponseWriter, r *http.Request) {
+	var artifact Artifact
+	if err := json.NewDecoder(r.Body).Decode(&artifact); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	status := http.StatusCreated
+	if name := chi.URLParam(r, "name"); name != "" {
+		if artifact.Name != "" && artifact.Name != name {
+			httputil.WriteError(w, http.StatusBadRequest, "Invalid request body", "artifact name does not match URL")
+			return
+		}
+		artifact.Name = name
+		status = http.StatusOK
+	}
+
+	if err := artifact.Validate(); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid artifact", err.Error())
+		return
+	}
+
+	registered, err := h.registry.Register(r.Context(), artifact)
+	if err != nil {
+		httputil.WriteError(w, http.StatusUnprocessableEntity, "Artifact verification failed", err.Error())
+		return
+	}
+
+	httputil.WriteJSON(w, status, registered)
+}
+
+// VerifyArtifact handles POST /bootartifacts/{name}/verify
+func (h *Handler) VerifyArtifact(w http.ResponseWriter, r *http.Request) {
+	artifact, err := h.registry.Verify(r.Context(), chi.URLParam(r, "name"))
+	if err != nil {
+		h.writeRegistryError(w, "Artifact verification failed", err)
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, artifact)
+}
+
+// DeleteArtifact handles DELETE /bootartifacts/{name}
+func (h *Handler) DeleteArtifact(w http.ResponseWriter, r *http.Request) {
+	if err := h.registry.Delete(r.Context(), chi.URLParam(r, "name")); err != nil {
+		h.writeRegistryError(w, "Failed to delete artifact", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) writeRegistryError(w http.ResponseWriter, title string, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		httputil.WriteError(w, http.StatusNotFound, title, err.Error())
+	case errors.Is(err, ErrVerificationFailed):
+		httputil.WriteError(w, http.StatusUnprocessableEntity, title, err.Error())
+	default:
+		httputil.WriteError(w, http.StatusInternalServerError, title, err.Error())
+	}
+}
@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package artifacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+)
+
+// Registry stores artifact records and verifies their content
+type Registry struct {
+	backend    fabricaStorage.StorageBackend
+	httpClient *http.Client
+	logger     *log.Logger
+	presigner  *S3Presigner
+}
+
+// NewRegistry creates an artifact registry persisted in the given storage backend
+func NewRegistry(backend fabricaStorage.StorageBackend, httpClient *http.Client, logger *log.Logger) *Registry {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Minute}
+	}
+	return &Registry{
+		backend:    backend,
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
+// SetS3Presigner enables s3://bucket/key artifact URLs
+func (r *Registry) SetS3Presigner(presigner *S3Presigner) {
+	r.presigner = presigner
+}
+
+// Register validates an artifact, downloads it to verify reachability and
+// checksum, and stores it. Registering an existing name replaces the record
+// while keeping its creation time.
+func (r *Registry) Register(ctx context.Context, artifact Artifact) (*Artifact, error) {
+	if err := artifact.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := r.verify(ctx, &artifact); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	artifact.CreatedAt = now
+	if existing, err := r.Get(ctx, artifact.Name); err == nil {
+		artifact.CreatedAt = existing.CreatedAt
+	}
+	artifact.UpdatedAt = now
+
+	if err := r.save(ctx, &artifact); err != nil {
+		return nil, err
+	}
+
+	r.logger.Printf("Registered artifact %s (%s, %d bytes)", artifact.Name, artifact.URL, artifact.Size)
+	return &artifact, nil
+}
+
+// Verify re-downloads a registered artifact and records the verification time
+func (r *Registry) Verify(ctx context.Context, name string) (*Artifact, error) {
+	artifact, err := r.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.verify(ctx, artifact); err != nil {
+		return nil, err
+	}
+
+	artifact.UpdatedAt = time.Now().UTC()
+	if err := r.save(ctx, artifact); err != nil {
+		return nil, err
+	}
+
+	return artifact, nil
+}
+
+// Get returns the artifact registered under name
+func (r *Registry) Get(ctx context.Context, name string) (*Artifact, error) {
+	if !ValidName(name) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	data, err := r.backend.Load(ctx, ResourceType, name)
+	if err != nil {
+		if errors.Is(err, fabricaStorage.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		return nil, fmt.Errorf("loading artifact %s: %w", name, err)
+	}
+
+	var artifact Artifact
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, fmt.Errorf("decoding artifact %s: %w", name, err)
+	}
+
+	return &artifact, nil
+}
+
+// List returns all registered artifacts sorted by name
+func (r *Registry) List(ctx context.Context) ([]Artifact, error) {
+	rawData, err := r.backend.LoadAll(ctx, ResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("loading artifacts: %w", err)
+	}
+
+	artifacts := make([]Artifact, 0, len(rawData))
+	for _, data := range rawData {
+		var artifact Artifact
+		if err := json.Unmarshal(data, &artifact); err != nil {
+			return nil, fmt.Errorf("decoding artifact: %w", err)
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Name < artifacts[j].Name })
+	return artifacts, nil
+}
+
+// Delete removes an artifact record
+func (r *Registry) Delete(ctx context.Context, name string) error {
+	if _, err := r.Get(ctx, name); err != nil {
+		return err
+	}
+
+	if err := r.backend.Delete(ctx, ResourceType, name); err != nil {
+		return fmt.Errorf("deleting artifact %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// ResolveArtifactURL returns the download URL of a registered artifact. For
+// s3:// artifacts this is a freshly presigned URL.
+// It implements bootscript.ArtifactResolver.
+func (r *Registry) ResolveArtifactURL(ctx context.Context, name string) (string, error) {
+	artifact, err := r.Get(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return r.downloadURL(artifact)
+}
+
+// downloadURL returns an HTTP(S) URL for fetching the artifact content
+func (r *Registry) downloadURL(artifact *Artifact) (string, error) {
+	if !isS3URL(artifact.URL) {
+		return artifact.URL, nil
+	}
+	if r.presigner == nil {
+		return "", fmt.Errorf("artifact %s uses %s but no S3 credentials are configured", artifact.Name, artifact.URL)
+	}
+	return r.presigner.PresignS3URL(artifact.URL)
+}
+
+func (r *Registry) save(ctx context.Context, artifact *Artifact) error {
+	data, err := json.Marshal(artifact)
+	if err != nil {
+		return fmt.Errorf("encoding artifact %s: %w", artifact.Name, err)
+	}
+
+	if err := r.backend.Save(ctx, ResourceType, artifact.Name, data); err != nil {
+		return fmt.Errorf("saving artifact %s: %w", artifact.Name, err)
+	}
+
+	return nil
+}
+
+// verify downloads the artifact and checks its SHA256 and, when declared, its
+// size. An undeclared size is filled in from the download.
+func (r *Registry) verify(ctx context.Context, artifact *Artifact) error {
+	downloadURL, err := r.downloadURL(artifact)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return fmt.Errorf("creating request for %s: %w", artifact.URL, err)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("artifact %s is not reachable: %w", artifact.URL, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("artifact %s is not reachable: HTTP %d", artifact.URL, resp.StatusCode)
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, resp.Body)
+	if err != nil {
+		return fmt.Errorf("downloading artifact %s: %w", artifact.URL, err)
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if sum != artifact.SHA256 {
+		return fmt.Errorf("%w: %s has sha256 %s, expected %s", ErrVerificationFailed, artifact.URL, sum, artifact.SHA256)
+	}
+
+	if artifact.Size != 0 && artifact.Size != size {
+		return fmt.Errorf("%w: %s is %d bytes, expected %d", ErrVerificationFailed, artifact.URL, size, artifact.Size)
+	}
+
+	artifact.Size = size
+	artifact.VerifiedAt = time.Now().UTC()
+	return nil
+}
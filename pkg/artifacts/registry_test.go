@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+const testKernelContent = "fake kernel image"
+
+func newTestRegistry(t *testing.T) (*Registry, *httptest.Server) {
+	t.Helper()
+
+	fileServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/vmlinuz" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, testKernelContent)
+	}))
+	t.Cleanup(fileServer.Close)
+
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+
+	return NewRegistry(backend, fileServer.Client(), log.New(io.Discard, "", 0)), fileServer
+}
+
+func testKernelSHA256() string {
+	sum := sha256.Sum256([]byte(testKernelContent))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRegistry_RegisterVerifiesChecksum(t *testing.T) {
+	registry, fileServer := newTestRegistry(t)
+	ctx := context.Background()
+
+	registered, err := registry.Register(ctx, Artifact{
+		Name:         "kernel-6.1",
+		Type:         TypeKernel,
+		URL:          fileServer.URL + "/vmlinuz",
+		SHA256:       strings.ToUpper(testKernelSHA256()),
+		Architecture: "x86_64",
+	})
+	if err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if registered.Size != int64(len(testKernelContent)) {
+		t.Errorf("Size = %d, want %d", registered.Size, len(testKernelContent))
+	}
+	if registered.VerifiedAt.IsZero() {
+		t.Error("expected VerifiedAt to be set")
+	}
+
+	url, err := registry.ResolveArtifactURL(ctx, "kernel-6.1")
+	if err != nil {
+		t.Fatalf("ResolveArtifactURL returned error: %v", err)
+	}
+	if url != fileServer.URL+"/vmlinuz" {
+		t.Errorf("ResolveArtifactURL = %q, want %q", url, fileServer.URL+"/vmlinuz")
+	}
+
+	artifacts, err := registry.List(ctx)
+	if err != nil || len(artifacts) != 1 {
+		t.Fatalf("List = %v, %v; want one artifact", artifacts, err)
+	}
+}
+
+func TestRegistry_RegisterRejectsBadContent(t *testing.T) {
+	registry, fileServer := newTestRegistry(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		artifact Artifact
+	}{
+		{"ChecksumMismatch", Artifact{Name: "bad-sum", URL: fileServer.URL + "/vmlinuz", SHA256: strings.Repeat("0", 64)}},
+		{"SizeMismatch", Artifact{Name: "bad-size", URL: fileServer.URL + "/vmlinuz", SHA256: testKernelSHA256(), Size: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := registry.Register(ctx, tt.artifact)
+			if !errors.Is(err, ErrVerificationFailed) {
+				t.Fatalf("Register error = %v, want ErrVerificationFailed", err)
+			}
+			if _, err := registry.Get(ctx, tt.artifact.Name); !errors.Is(err, ErrNotFound) {
+				t.Errorf("expected rejected artifact not to be stored, got %v", err)
+			}
+		})
+	}
+
+	if _, err := registry.Register(ctx, Artifact{Name: "missing", URL: fileServer.URL + "/missing", SHA256: testKernelSHA256()}); err == nil {
+		t.Error("expected error for unreachable artifact")
+	}
+}
+
+func TestArtifactValidate(t *testing.T) {
+	valid := Artifact{Name: "initrd-6.1.x86_64", URL: "https://files.example.com/initrd", SHA256: testKernelSHA256()}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate returned error for valid artifact: %v", err)
+	}
+
+	invalid := []Artifact{
+		{Name: "../etc", URL: valid.URL, SHA256: valid.SHA256},
+		{Name: "Kernel", URL: valid.URL, SHA256: valid.SHA256},
+		{Name: "kernel", URL: "/local/vmlinuz", SHA256: valid.SHA256},
+		{Name: "kernel", URL: valid.URL, SHA256: "abc"},
+		{Name: "kernel", URL: valid.URL, SHA256: valid.SHA256, Type: "rootfs"},
+	}
+	for _, artifact := range invalid {
+		if err := artifact.Validate(); err == nil {
+			t.Errorf("expected validation error for %+v", artifact)
+		}
+	}
+}
+
+func TestHandler_RegisterAndGet(t *testing.T) {
+	registry, fileServer := newTestRegistry(t)
+
+	r := chi.NewRouter()
+	NewHandler(registry).RegisterRoutes(r)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	body, _ := json.Marshal(Artifact{Name: "kernel", URL: fileServer.URL + "/vmlinuz", SHA256: testKernelSHA256()})
+	resp, err := http.Post(server.URL+"/bootartifacts", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST /bootartifacts failed: %v", err)
+	}
+	resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST /bootartifacts returned %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+
+	resp, err = http.Get(server.URL + "/bootartifacts/kernel")
+	if err != nil {
+		t.Fatalf("GET /bootartifacts/kernel failed: %v", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /bootartifacts/kernel returned %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	resp, err = http.Get(server.URL + "/bootartifacts/unknown")
+	if err != nil {
+		t.Fatalf("GET /bootartifacts/unknown failed: %v", err)
+	}
+	resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET /bootartifacts/unknown returned %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestHandler_TenantWritesRefused(t *testing.T) {
+	registry, fileServer := newTestRegistry(t)
+	if _, err := registry.Register(context.Background(), Artifact{Name: "kernel", URL: fileServer.URL + "/vmlinuz", SHA256: testKernelSHA256()}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(tenancy.WithTenant(r.Context(), "red")))
+		})
+	})
+	NewHandler(registry).RegisterRoutes(r)
+
+	serve := func(method, path string) int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(`{"url":"http://other/vmlinuz"}`)))
+		return w.Code
+	}
+	if status := serve(http.MethodGet, "/bootartifacts/kernel"); status != http.StatusOK {
+		t.Errorf("tenant GET returned %d, want %d", status, http.StatusOK)
+	}
+	for _, method := range []string{http.MethodPut, http.MethodDelete} {
+		if status := serve(method, "/bootartifacts/kernel"); status != http.StatusForbidden {
+			t.Errorf("tenant %s returned %d, want %d", method, status, http.StatusForbidden)
+		}
+	}
+	if _, err := registry.Get(context.Background(), "kernel"); err != nil {
+		t.Errorf("artifact after tenant writes: %v", err)
+	}
+}
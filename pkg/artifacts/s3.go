@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package artifacts
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3Config configures presigned URL generation for s3:// artifact URLs
+type S3Config struct {
+	// Endpoint is the object store URL, e.g. "https://minio.example.com:9000".
+	// Empty means AWS S3 for the configured region.
+	Endpoint        string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// PathStyle addresses buckets as <endpoint>/<bucket>/<key> instead of
+	// <bucket>.<endpoint>/<key>. MinIO and most on-premises stores need it.
+	PathStyle bool
+	// Expiry is how long generated URLs stay valid.
+	Expiry time.Duration
+}
+
+// S3Presigner generates AWS Signature Version 4 presigned GET URLs, so boot
+// scripts can reference objects in a private bucket
+type S3Presigner struct {
+	config   S3Config
+	endpoint *url.URL
+	now      func() time.Time
+}
+
+// NewS3Presigner validates the configuration and creates a presigner
+func NewS3Presigner(config S3Config) (*S3Presigner, error) {
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, errors.New("s3 access key ID and secret access key are required")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	if config.Expiry <= 0 || config.Expiry > 7*24*time.Hour {
+		return nil, fmt.Errorf("s3 presign expiry must be between 1s and 7 days, got %s", config.Expiry)
+	}
+
+	rawEndpoint := config.Endpoint
+	if rawEndpoint == "" {
+		rawEndpoint = "https://s3." + config.Region + ".amazonaws.com"
+	}
+	endpoint, err := url.Parse(rawEndpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", rawEndpoint)
+	}
+
+	return &S3Presigner{config: config, endpoint: endpoint, now: time.Now}, nil
+}
+
+// PresignS3URL converts an s3://bucket/key URL into a presigned HTTP(S) URL
+func (p *S3Presigner) PresignS3URL(s3URL string) (string, error) {
+	bucket, key, err := parseS3URL(s3URL)
+	if err != nil {
+		return "", err
+	}
+	return p.PresignGet(bucket, key), nil
+}
+
+// PresignGet returns a presigned GET URL for the object
+func (p *S3Presigner) PresignGet(bucket, key string) string {
+	return p.Presign("GET", bucket, key, nil)
+}
+
+// Presign returns a presigned URL for a request with method to an object, or
+// to the bucket itself when key is empty, with extra query parameters such as
+// those of a listing
+func (p *S3Presigner) Presign(method, bucket, key string, params map[string]string) string {
+	now := p.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := now.Format("20060102") + "/" + p.config.Region + "/s3/aws4_request"
+
+	host := p.endpoint.Host
+	path := strings.TrimRight(p.endpoint.Path, "/") + "/" + key
+	if p.config.PathStyle {
+		path = strings.TrimRight(p.endpoint.Path, "/") + "/" + bucket
+		if key != "" {
+			path += "/" + key
+		}
+	} else {
+		host = bucket + "." + host
+	}
+	canonicalURI := s3Escape(path, false)
+
+	query := map[string]string{
+		"X-Amz-Algorithm":     "AWS4-HMAC-SHA256",
+		"X-Amz-Credential":    p.config.AccessKeyID + "/" + scope,
+		"X-Amz-Date":          amzDate,
+		"X-Amz-Expires":       strconv.Itoa(int(p.config.Expiry / time.Second)),
+		"X-Amz-SignedHeaders": "host",
+	}
+	for name, value := range params {
+		query[name] = value
+	}
+	if p.config.SessionToken != "" {
+		query["X-Amz-Security-Token"] = p.config.SessionToken
+	}
+	canonicalQuery := canonicalS3Query(query)
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonicalURI,
+		canonicalQuery,
+		"host:" + host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+p.config.SecretAccessKey), now.Format("20060102"))
+	signingKey = hmacSHA256(signingKey, p.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	return p.endpoint.Scheme + "://" + host + canonicalURI + "?" + canonicalQuery + "&X-Amz-Signature=" + signature
+}
+
+// isS3URL reports whether an artifact URL refers to object storage
+func isS3URL(rawURL string) bool {
+	return strings.HasPrefix(rawURL, "s3://")
+}
+
+func parseS3URL(rawURL string) (bucket, key string, err error) {
+	if !isS3URL(rawURL) {
+		return "", "", fmt.Errorf("not an s3 URL: %q", rawURL)
+	}
+	bucket, key, _ = strings.Cut(strings.TrimPrefix(rawURL, "s3://"), "/")
+	if bucket == "" || key == "" {
+		return "", "", fmt.Errorf("invalid s3 URL %q: expected s3://bucket/key", rawURL)
+	}
+	return bucket, key, nil
+}
+
+func canonicalS3Query(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, s3Escape(k, true)+"="+s3Escape(values[k], true))
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3Escape applies the SigV4 URI encoding: everything except unreserved
+// characters is percent-encoded, and '/' is kept unless encodeSlash is set
+func s3Escape(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
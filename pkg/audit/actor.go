@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/openchami/boot-service/pkg/auth"
+)
+
+// AnonymousSubject is recorded for requests without a verified token
+const AnonymousSubject = "anonymous"
+
+// Actor identifies who made a request
+type Actor struct {
+	Subject   string
+	Address   string
+	RequestID string
+}
+
+type actorContextKey struct{}
+
+// WithActor returns a context whose writes are attributed to actor
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor writes in ctx are attributed to
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorContextKey{}).(Actor)
+	return actor, ok
+}
+
+// Middleware attributes the writes of each request to the subject of its
+// verified token, or to AnonymousSubject. It must run after any
+// authentication middleware so the token's claims are available.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := Actor{
+			Subject:   AnonymousSubject,
+			Address:   r.RemoteAddr,
+			RequestID: middleware.GetReqID(r.Context()),
+		}
+		if claims, err := auth.GetClaimsFromRequest(r); err == nil && claims.Subject != "" {
+			actor.Subject = claims.Subject
+		}
+		next.ServeHTTP(w, r.WithContext(WithActor(r.Context(), actor)))
+	})
+}
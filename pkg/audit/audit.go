@@ -0,0 +1,305 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package audit records who changed which resource, how, and when.
+//
+// Log subscribes to resource writes reported by resourcewatch, so creates,
+// updates, and deletes are recorded whichever API made them, including the
+// legacy endpoints and provider sync. Each record is persisted to storage and
+// may be forwarded to syslog or a webhook.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// ResourceType is the storage resource type used for audit records
+const ResourceType = "AuditRecord"
+
+// SystemSubject is recorded for writes made by the service itself, such as
+// provider sync
+const SystemSubject = "system"
+
+// Record describes one committed write
+type Record struct {
+	ID        string          `json:"id"`
+	Time      time.Time       `json:"time"`
+	Action    string          `json:"action"`   // created, updated, deleted
+	Resource  string          `json:"resource"` // collection name, e.g. "bootconfigurations"
+	UID       string          `json:"uid"`
+	Name      string          `json:"name,omitempty"`
+	Tenant    string          `json:"tenant,omitempty"`
+	Subject   string          `json:"subject"`
+	Address   string          `json:"address,omitempty"`
+	RequestID string          `json:"requestId,omitempty"`
+	Diff      json.RawMessage `json:"diff,omitempty"` // merge patch from the old resource to the new one
+}
+
+// Forwarder sends records to an external system
+type Forwarder interface {
+	Forward(ctx context.Context, record Record) error
+}
+
+// Filter selects records in Query. Zero fields match everything.
+type Filter struct {
+	Resource string
+	UID      string
+	Subject  string
+	Action   string
+	Since    time.Time
+	Until    time.Time
+	Limit    int
+}
+
+// Stats counts recorded writes and failures
+type Stats struct {
+	Recorded      uint64
+	Failed        uint64
+	ForwardFailed uint64
+	Pruned        uint64
+}
+
+// Log persists audit records in a storage backend
+type Log struct {
+	backend fabricaStorage.StorageBackend
+	logger  *log.Logger
+	now     func() time.Time
+
+	mu         sync.RWMutex
+	forwarders []Forwarder
+	lastID     string
+
+	recorded      atomic.Uint64
+	failed        atomic.Uint64
+	forwardFailed atomic.Uint64
+	pruned        atomic.Uint64
+}
+
+// NewLog creates an audit log persisted in backend. The backend must not be
+// the one Log subscribes to, or every record would be audited in turn.
+func NewLog(backend fabricaStorage.StorageBackend, logger *log.Logger) *Log {
+	if logger == nil {
+		logger = log.New(log.Writer(), "audit: ", log.LstdFlags)
+	}
+	return &Log{backend: backend, logger: logger, now: time.Now}
+}
+
+// AddForwarder sends every new record to forwarder as well
+func (l *Log) AddForwarder(forwarder Forwarder) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.forwarders = append(l.forwarders, forwarder)
+}
+
+// HandleResourceChange records a committed write. Failures are logged rather
+// than returned because the write has already happened. Writes made by other
+// replicas are recorded by those replicas.
+func (l *Log) HandleResourceChange(ctx context.Context, event resourcewatch.Event) {
+	if event.ResourceType == ResourceType || resourcewatch.Remote(ctx) {
+		return
+	}
+
+	record := l.newRecord(ctx, event)
+	data, err := json.Marshal(record)
+	if err == nil {
+		err = l.backend.Save(context.WithoutCancel(ctx), ResourceType, record.ID, data)
+	}
+	if err != nil {
+		l.failed.Add(1)
+		l.logger.Printf("Failed to record %s of %s %s by %s: %v", record.Action, record.Resource, record.UID, record.Subject, err)
+	} else {
+		l.recorded.Add(1)
+	}
+
+	l.mu.RLock()
+	forwarders := l.forwarders
+	l.mu.RUnlock()
+	for _, forwarder := range forwarders {
+		if err := forwarder.Forward(ctx, record); err != nil {
+			l.forwardFailed.Add(1)
+			l.logger.Printf("Failed to forward audit record %s: %v", record.ID, err)
+		}
+	}
+}
+
+func (l *Log) newRecord(ctx context.Context, event resourcewatch.Event) Record {
+	now := l.now().UTC()
+	record := Record{
+		ID:       l.nextID(now),
+		Time:     now,
+		Action:   event.Type,
+		Resource: Collection(event.ResourceType),
+		UID:      event.UID,
+		Subject:  SystemSubject,
+	}
+	if actor, ok := ActorFromContext(ctx); ok {
+		record.Subject = actor.Subject
+		record.Address = actor.Address
+		record.RequestID = actor.RequestID
+	}
+
+	current := event.New
+	if current == nil {
+		current = event.Old
+	}
+	record.Name = resourceName(current)
+	record.Tenant = tenancy.Owner(current)
+
+	switch {
+	case event.Old == nil:
+		record.Diff = event.New
+	case event.New != nil:
+		diff, err := jsonpatch.CreateMergePatch(event.Old, event.New)
+		if err != nil {
+			l.logger.Printf("Failed to diff %s %s: %v", record.Resource, record.UID, err)
+			break
+		}
+		record.Diff = diff
+	}
+	return record
+}
+
+// nextID returns a unique ID that sorts in recording order
+func (l *Log) nextID(now time.Time) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	id := fmt.Sprintf("aud-%019d", now.UnixNano())
+	if id <= l.lastID {
+		// Two records in the same nanosecond, or the clock went back
+		id = l.lastID + "a"
+	}
+	l.lastID = id
+	return id
+}
+
+// Query returns the records matching filter, oldest first. Records of
+// resources owned by another tenant than the one in ctx are omitted.
+func (l *Log) Query(ctx context.Context, filter Filter) ([]Record, error) {
+	items, err := l.backend.LoadAll(ctx, ResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("loading audit records: %w", err)
+	}
+
+	records := make([]Record, 0, len(items))
+	for _, item := range items {
+		var record Record
+		if err := json.Unmarshal(item, &record); err != nil {
+			continue
+		}
+		if filter.matches(record) && tenancy.Visible(ctx, record.Tenant) {
+			records = append(records, record)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[:filter.Limit]
+	}
+	return records, nil
+}
+
+func (f Filter) matches(record Record) bool {
+	switch {
+	case f.Resource != "" && record.Resource != f.Resource:
+		return false
+	case f.UID != "" && record.UID != f.UID:
+		return false
+	case f.Subject != "" && record.Subject != f.Subject:
+		return false
+	case f.Action != "" && record.Action != f.Action:
+		return false
+	case !f.Since.IsZero() && record.Time.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !record.Time.Before(f.Until):
+		return false
+	}
+	return true
+}
+
+// Prune deletes records older than before and returns how many it deleted
+func (l *Log) Prune(ctx context.Context, before time.Time) (int, error) {
+	records, err := l.Query(ctx, Filter{Until: before})
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, record := range records {
+		if err := l.backend.Delete(ctx, ResourceType, record.ID); err != nil {
+			return deleted, fmt.Errorf("deleting audit record %s: %w", record.ID, err)
+		}
+		deleted++
+	}
+	l.pruned.Add(uint64(deleted))
+	return deleted, nil
+}
+
+// RunRetention prunes records older than retention every interval until ctx
+// is done
+func (l *Log) RunRetention(ctx context.Context, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if deleted, err := l.Prune(ctx, l.now().Add(-retention)); err != nil {
+			l.logger.Printf("Audit retention failed after %d records: %v", deleted, err)
+		} else if deleted > 0 {
+			l.logger.Printf("Pruned %d audit records older than %s", deleted, retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stats returns counters for recorded, failed, and pruned records
+func (l *Log) Stats() Stats {
+	return Stats{
+		Recorded:      l.recorded.Load(),
+		Failed:        l.failed.Load(),
+		ForwardFailed: l.forwardFailed.Load(),
+		Pruned:        l.pruned.Load(),
+	}
+}
+
+// Collection returns the API collection name of a storage resource type,
+// e.g. "bootconfigurations" for "BootConfiguration"
+func Collection(resourceType string) string {
+	return strings.ToLower(resourceType) + "s"
+}
+
+// resourceName returns metadata.name, or name for records without metadata
+func resourceName(data json.RawMessage) string {
+	var resource struct {
+		Name     string `json:"name"`
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	}
+	if json.Unmarshal(data, &resource) != nil {
+		return ""
+	}
+	if resource.Metadata.Name != "" {
+		return resource.Metadata.Name
+	}
+	return resource.Name
+}
@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+	"github.com/openchami/tokensmith/pkg/authn"
+
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// newTestLog returns an audit log subscribed to a watched file backend
+func newTestLog(t *testing.T) (*Log, *resourcewatch.Backend) {
+	t.Helper()
+	inner, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	changes := resourcewatch.NewBackend(inner)
+	auditLog := NewLog(inner, log.New(io.Discard, "", 0))
+	changes.Subscribe(auditLog.HandleResourceChange)
+	return auditLog, changes
+}
+
+func TestLog_RecordsWrites(t *testing.T) {
+	auditLog, changes := newTestLog(t)
+	ctx := WithActor(context.Background(), Actor{Subject: "alice", Address: "10.0.0.5:4321", RequestID: "req-1"})
+
+	writes := []string{
+		`{"metadata":{"name":"compute"},"spec":{"kernel":"http://files/vmlinuz-1","params":"quiet"}}`,
+		`{"metadata":{"name":"compute"},"spec":{"kernel":"http://files/vmlinuz-2","params":"quiet"}}`,
+	}
+	for _, data := range writes {
+		if err := changes.Save(ctx, "BootConfiguration", "bc-1", json.RawMessage(data)); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+	}
+	// Writes without an actor are made by the service itself
+	if err := changes.Delete(context.Background(), "BootConfiguration", "bc-1"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	records, err := auditLog.Query(context.Background(), Filter{Resource: "bootconfigurations"})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3: %+v", len(records), records)
+	}
+
+	wantActions := []string{resourcewatch.Created, resourcewatch.Updated, resourcewatch.Deleted}
+	wantSubjects := []string{"alice", "alice", SystemSubject}
+	for i, record := range records {
+		if record.Action != wantActions[i] || record.Subject != wantSubjects[i] {
+			t.Errorf("record %d = %s by %s, want %s by %s", i, record.Action, record.Subject, wantActions[i], wantSubjects[i])
+		}
+		if record.UID != "bc-1" || record.Name != "compute" {
+			t.Errorf("record %d identifies %s/%s", i, record.UID, record.Name)
+		}
+	}
+	if records[0].Address != "10.0.0.5:4321" || records[0].RequestID != "req-1" {
+		t.Errorf("create record lost request details: %+v", records[0])
+	}
+	if diff := string(records[1].Diff); diff != `{"spec":{"kernel":"http://files/vmlinuz-2"}}` {
+		t.Errorf("update diff = %s", diff)
+	}
+	if records[2].Diff != nil {
+		t.Errorf("delete diff = %s, want none", records[2].Diff)
+	}
+	if stats := auditLog.Stats(); stats.Recorded != 3 || stats.Failed != 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestLog_Query(t *testing.T) {
+	auditLog, changes := newTestLog(t)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	auditLog.now = func() time.Time { return now }
+
+	writes := []struct {
+		subject, resourceType, uid, data string
+	}{
+		{"alice", "Node", "nod-1", `{"spec":{"xname":"x1","tenant":"red"}}`},
+		{"bob", "BootConfiguration", "bc-1", `{"spec":{"tenant":"blue"}}`},
+		{"alice", "BootConfiguration", "bc-2", `{"spec":{}}`},
+	}
+	for _, w := range writes {
+		ctx := WithActor(context.Background(), Actor{Subject: w.subject})
+		if err := changes.Save(ctx, w.resourceType, w.uid, json.RawMessage(w.data)); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+		now = now.Add(time.Hour)
+	}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		filter Filter
+		want   []string
+	}{
+		{name: "all", ctx: context.Background(), want: []string{"nod-1", "bc-1", "bc-2"}},
+		{name: "resource", ctx: context.Background(), filter: Filter{Resource: "bootconfigurations"}, want: []string{"bc-1", "bc-2"}},
+		{name: "subject", ctx: context.Background(), filter: Filter{Subject: "alice"}, want: []string{"nod-1", "bc-2"}},
+		{name: "since", ctx: context.Background(), filter: Filter{Since: start.Add(time.Hour)}, want: []string{"bc-1", "bc-2"}},
+		{name: "until", ctx: context.Background(), filter: Filter{Until: start.Add(time.Hour)}, want: []string{"nod-1"}},
+		{name: "limit", ctx: context.Background(), filter: Filter{Limit: 2}, want: []string{"nod-1", "bc-1"}},
+		{name: "tenant", ctx: tenancy.WithTenant(context.Background(), "blue"), want: []string{"bc-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := auditLog.Query(tt.ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("Query returned error: %v", err)
+			}
+			var got []string
+			for _, record := range records {
+				got = append(got, record.UID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	deleted, err := auditLog.Prune(context.Background(), start.Add(90*time.Minute))
+	if err != nil || deleted != 2 {
+		t.Fatalf("Prune() = %d, %v, want 2 records", deleted, err)
+	}
+	if records, _ := auditLog.Query(context.Background(), Filter{}); len(records) != 1 || records[0].UID != "bc-2" {
+		t.Errorf("records after prune = %+v", records)
+	}
+}
+
+func TestHandler_ListRecords(t *testing.T) {
+	auditLog, changes := newTestLog(t)
+	if err := changes.Save(context.Background(), "Node", "nod-1", json.RawMessage(`{"spec":{}}`)); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	handler := NewHandler(auditLog)
+
+	tests := []struct {
+		query      string
+		wantStatus int
+		wantCount  int
+	}{
+		{query: "", wantStatus: http.StatusOK, wantCount: 1},
+		{query: "?resource=nodes&since=2000-01-01T00:00:00Z", wantStatus: http.StatusOK, wantCount: 1},
+		{query: "?resource=bootconfigurations", wantStatus: http.StatusOK, wantCount: 0},
+		{query: "?since=yesterday", wantStatus: http.StatusBadRequest},
+		{query: "?limit=0", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ListRecords(rec, httptest.NewRequest(http.MethodGet, "/audit"+tt.query, nil))
+		if rec.Code != tt.wantStatus {
+			t.Errorf("GET /audit%s status = %d, want %d", tt.query, rec.Code, tt.wantStatus)
+			continue
+		}
+		if tt.wantStatus != http.StatusOK {
+			continue
+		}
+		var records []Record
+		if err := json.Unmarshal(rec.Body.Bytes(), &records); err != nil || len(records) != tt.wantCount {
+			t.Errorf("GET /audit%s returned %d records (%v), want %d", tt.query, len(records), err, tt.wantCount)
+		}
+	}
+}
+
+func TestWebhookForwarder(t *testing.T) {
+	received := make(chan Record, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var record Record
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			t.Errorf("invalid webhook body: %v", err)
+		}
+		received <- record
+	}))
+	defer server.Close()
+
+	forwarder := NewWebhookForwarder(server.URL, server.Client(), 1, log.New(io.Discard, "", 0))
+	if err := forwarder.Forward(context.Background(), Record{ID: "aud-1"}); err != nil {
+		t.Fatalf("Forward returned error: %v", err)
+	}
+	if err := forwarder.Forward(context.Background(), Record{ID: "aud-2"}); err != ErrQueueFull {
+		t.Errorf("Forward on full queue error = %v, want %v", err, ErrQueueFull)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go forwarder.Run(ctx)
+
+	select {
+	case record := <-received:
+		if record.ID != "aud-1" {
+			t.Errorf("delivered %s, want aud-1", record.ID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("record was not delivered")
+	}
+}
+
+func TestParseSyslogAddress(t *testing.T) {
+	tests := []struct {
+		address, network, raddr string
+		wantErr                 bool
+	}{
+		{address: "local"},
+		{address: "udp://syslog.example.com:514", network: "udp", raddr: "syslog.example.com:514"},
+		{address: "tcp://10.0.0.1:601", network: "tcp", raddr: "10.0.0.1:601"},
+		{address: "syslog.example.com:514", wantErr: true},
+		{address: "http://syslog.example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		network, raddr, err := ParseSyslogAddress(tt.address)
+		if (err != nil) != tt.wantErr || network != tt.network || raddr != tt.raddr {
+			t.Errorf("ParseSyslogAddress(%q) = %q, %q, %v", tt.address, network, raddr, err)
+		}
+	}
+}
+
+func TestMiddleware_AttributesRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims map[string]any
+		want   string
+	}{
+		{name: "anonymous", want: AnonymousSubject},
+		{name: "verified token", claims: map[string]any{"sub": "alice"}, want: "alice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actor Actor
+			handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actor, _ = ActorFromContext(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodPost, "/nodes", nil)
+			if tt.claims != nil {
+				req = req.WithContext(authn.ContextWithVerifiedClaims(req.Context(), tt.claims))
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			if actor.Subject != tt.want || actor.Address != req.RemoteAddr {
+				t.Errorf("actor = %+v, want subject %s", actor, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"log/syslog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrQueueFull is returned when a forwarder cannot keep up and drops a record
+var ErrQueueFull = errors.New("audit forwarding queue full")
+
+// SyslogForwarder writes each record to syslog as a JSON message
+type SyslogForwarder struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogForwarder connects to the syslog daemon at address: "local" for
+// the local daemon, or udp://host:port or tcp://host:port for a remote one
+func NewSyslogForwarder(address string) (*SyslogForwarder, error) {
+	network, raddr, err := ParseSyslogAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := syslog.Dial(network, raddr, syslog.LOG_NOTICE|syslog.LOG_AUTHPRIV, "boot-service")
+	if err != nil {
+		return nil, fmt.Errorf("connecting to syslog at %s: %w", address, err)
+	}
+	return &SyslogForwarder{writer: writer}, nil
+}
+
+// ParseSyslogAddress splits a syslog address into the network and address
+// arguments of syslog.Dial
+func ParseSyslogAddress(address string) (string, string, error) {
+	if address == "local" {
+		return "", "", nil
+	}
+	parsed, err := url.Parse(address)
+	if err != nil || (parsed.Scheme != "udp" && parsed.Scheme != "tcp") || parsed.Host == "" {
+		return "", "", fmt.Errorf("invalid syslog address %q: want local, udp://host:port, or tcp://host:port", address)
+	}
+	return parsed.Scheme, parsed.Host, nil
+}
+
+// Forward writes record to syslog
+func (f *SyslogForwarder) Forward(ctx context.Context, record Record) error { //nolint:revive
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return f.writer.Notice(string(data))
+}
+
+// Close closes the syslog connection
+func (f *SyslogForwarder) Close() error {
+	return f.writer.Close()
+}
+
+// WebhookForwarder POSTs each record as JSON to a URL. Records are queued and
+// sent in order by Run, so a slow receiver does not delay writes; when the
+// queue is full new records are dropped.
+type WebhookForwarder struct {
+	url        string
+	httpClient *http.Client
+	queue      chan Record
+	logger     *log.Logger
+}
+
+// NewWebhookForwarder creates a forwarder to url that queues up to queueSize
+// records
+func NewWebhookForwarder(url string, httpClient *http.Client, queueSize int, logger *log.Logger) *WebhookForwarder {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if logger == nil {
+		logger = log.New(log.Writer(), "audit: ", log.LstdFlags)
+	}
+	return &WebhookForwarder{
+		url:        url,
+		httpClient: httpClient,
+		queue:      make(chan Record, queueSize),
+		logger:     logger,
+	}
+}
+
+// Forward queues record for delivery
+func (f *WebhookForwarder) Forward(ctx context.Context, record Record) error { //nolint:revive
+	select {
+	case f.queue <- record:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Run delivers queued records until ctx is done. A record the receiver
+// rejects is logged and skipped.
+func (f *WebhookForwarder) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case record := <-f.queue:
+			if err := f.send(ctx, record); err != nil && ctx.Err() == nil {
+				f.logger.Printf("Failed to deliver audit record %s to webhook: %v", record.ID, err)
+			}
+		}
+	}
+}
+
+func (f *WebhookForwarder) send(ctx context.Context, record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
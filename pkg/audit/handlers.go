@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+)
+
+// Handler serves the audit query API
+type Handler struct {
+	log *Log
+}
+
+// NewHandler creates an audit query API handler
+func NewHandler(log *Log) *Handler {
+	return &Handler{log: log}
+}
+
+// RegisterRoutes registers GET /audit
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/audit", h.ListRecords)
+}
+
+// ListRecords handles GET /audit. The resource, uid, subject, and action
+// query parameters select records by exact match; since and until (RFC 3339)
+// bound their time, and limit caps how many are returned.
+func (h *Handler) ListRecords(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseFilter(r)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid audit query", err.Error())
+		return
+	}
+
+	records, err := h.log.Query(r.Context(), filter)
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to query audit log", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, records)
+}
+
+func parseFilter(r *http.Request) (Filter, error) {
+	query := r.URL.Query()
+	filter := Filter{
+		Resource: query.Get("resource"),
+		UID:      query.Get("uid"),
+		Subject:  query.Get("subject"),
+		Action:   query.Get("action"),
+	}
+
+	for name, target := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if value := query.Get(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return Filter{}, fmt.Errorf("%s must be an RFC 3339 time: %q", name, value)
+			}
+			*target = parsed
+		}
+	}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return Filter{}, fmt.Errorf("limit must be a positive integer: %q", value)
+		}
+		filter.Limit = limit
+	}
+	return filter, nil
+}
@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Reasons a request would have been rejected
+const (
+	ReasonMissingToken  = "missing_token"
+	ReasonInvalidToken  = "invalid_token"
+	ReasonExpired       = "expired"
+	ReasonMissingScope  = "missing_scope"
+	ReasonInvalidAPIKey = "invalid_api_key"
+)
+
+// Reasons lists every rejection reason
+var Reasons = []string{ReasonMissingToken, ReasonInvalidToken, ReasonExpired, ReasonMissingScope, ReasonInvalidAPIKey}
+
+// recentBypasses bounds the bypassed requests a report keeps
+const recentBypasses = 50
+
+// Bypass is a request non-enforcing mode let through that enforcing mode
+// would have rejected
+type Bypass struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	Path   string    `json:"path"`
+	Client string    `json:"client"`
+	Reason string    `json:"reason"`
+}
+
+// BypassSnapshot summarizes the requests checked in non-enforcing mode
+type BypassSnapshot struct {
+	Since time.Time `json:"since"`
+	// Checked counts the requests checked; Bypassed those that would have
+	// been rejected
+	Checked  int64            `json:"checked"`
+	Bypassed int64            `json:"bypassed"`
+	ByReason map[string]int64 `json:"byReason"`
+	// Recent are the latest bypassed requests, newest first
+	Recent []Bypass `json:"recent"`
+}
+
+// BypassReport counts the requests non-enforcing mode lets through that
+// enforcing mode would reject, by reason, to judge when enforcing is safe
+type BypassReport struct {
+	mu       sync.Mutex
+	since    time.Time
+	checked  int64
+	byReason map[string]int64
+	recent   []Bypass
+}
+
+// NewBypassReport creates an empty report
+func NewBypassReport() *BypassReport {
+	return &BypassReport{since: time.Now().UTC(), byReason: map[string]int64{}}
+}
+
+// check counts a request that was checked
+func (b *BypassReport) check() {
+	b.mu.Lock()
+	b.checked++
+	b.mu.Unlock()
+}
+
+// record counts a request that would have been rejected for reason
+func (b *BypassReport) record(r *http.Request, reason string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.byReason[reason]++
+	b.recent = append(b.recent, Bypass{
+		Time:   time.Now().UTC(),
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Client: r.RemoteAddr,
+		Reason: reason,
+	})
+	if len(b.recent) > recentBypasses {
+		b.recent = b.recent[len(b.recent)-recentBypasses:]
+	}
+}
+
+// Count returns how many requests would have been rejected for reason
+func (b *BypassReport) Count(reason string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.byReason[reason]
+}
+
+// Checked returns how many requests were checked
+func (b *BypassReport) Checked() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.checked
+}
+
+// Snapshot returns the report so far
+func (b *BypassReport) Snapshot() BypassSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	snapshot := BypassSnapshot{
+		Since:    b.since,
+		Checked:  b.checked,
+		ByReason: make(map[string]int64, len(Reasons)),
+		Recent:   make([]Bypass, 0, len(b.recent)),
+	}
+	for _, reason := range Reasons {
+		snapshot.ByReason[reason] = b.byReason[reason]
+		snapshot.Bypassed += b.byReason[reason]
+	}
+	for i := len(b.recent) - 1; i >= 0; i-- {
+		snapshot.Recent = append(snapshot.Recent, b.recent[i])
+	}
+	return snapshot
+}
+
+// Run logs the requests checked and bypassed in each interval until ctx is
+// done
+func (b *BypassReport) Run(ctx context.Context, interval time.Duration, logger *log.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := b.Snapshot()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		current := b.Snapshot()
+		var reasons []string
+		for _, reason := range Reasons {
+			if n := current.ByReason[reason] - last.ByReason[reason]; n > 0 {
+				reasons = append(reasons, reason+"="+strconv.FormatInt(n, 10))
+			}
+		}
+		logger.Printf("Non-enforcing auth: %d of %d requests in the last %s would have been rejected (%s)",
+			current.Bypassed-last.Bypassed, current.Checked-last.Checked, interval, strings.Join(reasons, ", "))
+		last = current
+	}
+}
+
+type probeKey struct{}
+
+// probe records whether a request passed the enforcing middleware, and the
+// request it passed on
+type probe struct {
+	passed bool
+	req    *http.Request
+}
+
+// discardWriter swallows the rejection an enforcing middleware writes
+type discardWriter struct {
+	header http.Header
+	status int
+}
+
+func (d *discardWriter) Header() http.Header { return d.header }
+
+func (d *discardWriter) Write(p []byte) (int, error) {
+	if d.status == 0 {
+		d.status = http.StatusOK
+	}
+	return len(p), nil
+}
+
+func (d *discardWriter) WriteHeader(status int) {
+	if d.status == 0 {
+		d.status = status
+	}
+}
+
+// ReportOnly checks each request with enforce but passes on those it would
+// reject, counting them in report, which may be nil, and logging them.
+// Requests enforce accepts are passed on as enforce passes them, with their
+// verified claims.
+func ReportOnly(enforce func(http.Handler) http.Handler, report *BypassReport, logger *log.Logger) func(http.Handler) http.Handler {
+	if report == nil {
+		report = NewBypassReport()
+	}
+	if logger == nil {
+		logger = log.New(log.Writer(), "auth: ", log.LstdFlags)
+	}
+	check := enforce(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		p := r.Context().Value(probeKey{}).(*probe)
+		p.passed, p.req = true, r
+	}))
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p := &probe{}
+			rejected := &discardWriter{header: http.Header{}}
+			check.ServeHTTP(rejected, r.WithContext(context.WithValue(r.Context(), probeKey{}, p)))
+			report.check()
+			if p.passed {
+				next.ServeHTTP(w, p.req)
+				return
+			}
+			reason := rejectionReason(r, rejected.status)
+			report.record(r, reason)
+			logger.Printf("non-enforcing: would reject %s %s from %s (%s)", r.Method, r.URL.Path, r.RemoteAddr, reason)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rejectionReason classifies a request an enforcing middleware rejected
+// with status
+func rejectionReason(r *http.Request, status int) string {
+	if status == http.StatusForbidden {
+		return ReasonMissingScope
+	}
+	if strings.TrimSpace(r.Header.Get(APIKeyHeader)) != "" {
+		return ReasonInvalidAPIKey
+	}
+	header := strings.TrimSpace(r.Header.Get("Authorization"))
+	if header == "" {
+		return ReasonMissingToken
+	}
+	scheme, raw, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ReasonInvalidToken
+	}
+	// The signature is not checked: the token is already rejected, and
+	// only its expiry is read
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(strings.TrimSpace(raw), claims); err != nil {
+		return ReasonInvalidToken
+	}
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil && exp.Before(time.Now()) {
+		return ReasonExpired
+	}
+	return ReasonInvalidToken
+}
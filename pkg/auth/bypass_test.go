@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/openchami/tokensmith/pkg/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportOnly(t *testing.T) {
+	keyPair, err := GenerateTestKeyPair()
+	require.NoError(t, err)
+	valid, err := CreateTestTokenWithScopes(keyPair, []string{"read"})
+	require.NoError(t, err)
+	past := time.Now().Add(-2 * time.Hour)
+	expired, err := CreateTestToken(keyPair, &token.TSClaims{RegisteredClaims: jwt.RegisteredClaims{
+		Issuer:    "test-issuer",
+		Subject:   "test-user",
+		Audience:  []string{"boot-service"},
+		ExpiresAt: jwt.NewNumericDate(past.Add(time.Hour)),
+		IssuedAt:  jwt.NewNumericDate(past),
+	}})
+	require.NoError(t, err)
+
+	config := CreateStaticKeyConfig(keyPair.PublicKeyPEM)
+	config.NonEnforcing = true
+	config.RequiredScopes = []string{"write"}
+	config.Bypasses = NewBypassReport()
+	var subject string
+	handler := config.CreateMiddleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject = ""
+		if claims, err := GetClaimsFromRequest(r); err == nil {
+			subject = claims.Subject
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := map[string]string{
+		"":                   ReasonMissingToken,
+		"Bearer not-a-token": ReasonInvalidToken,
+		"Basic dXNlcjpwYXNz": ReasonInvalidToken,
+		"Bearer " + expired:  ReasonExpired,
+		"Bearer " + valid:    ReasonMissingScope,
+	}
+	for header, reason := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		before := config.Bypasses.Count(reason)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code, "request with %q was blocked", header)
+		assert.Equal(t, before+1, config.Bypasses.Count(reason), "request with %q not counted as %s", header, reason)
+	}
+
+	snapshot := config.Bypasses.Snapshot()
+	assert.Equal(t, int64(5), snapshot.Checked)
+	assert.Equal(t, int64(5), snapshot.Bypassed)
+	assert.Equal(t, int64(2), snapshot.ByReason[ReasonInvalidToken])
+	assert.Len(t, snapshot.Recent, 5)
+
+	// A request that passes is not counted as bypassed, and keeps its claims
+	allowed, err := CreateTestTokenWithScopes(keyPair, []string{"write"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+allowed)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "test-user", subject)
+	assert.Equal(t, int64(6), config.Bypasses.Checked())
+	assert.Equal(t, int64(5), config.Bypasses.Snapshot().Bypassed)
+}
@@ -48,6 +48,22 @@ type Config struct {
 	// Development/Testing
 	AllowEmptyToken bool `json:"allowEmptyToken"` // For development only
 	NonEnforcing    bool `json:"nonEnforcing"`    // Log errors but don't block
+
+	// APIKeys, when set, also accepts requests carrying an API key in the
+	// APIKeyHeader instead of a bearer token
+	APIKeys APIKeyVerifier `json:"-"`
+
+	// Bypasses, when set, counts the requests non-enforcing mode lets
+	// through that would otherwise be rejected
+	Bypasses *BypassReport `json:"-"`
+}
+
+// APIKeyHeader carries the API key of machine clients
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyVerifier resolves an API key to the claims its holder acts with
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (*token.TSClaims, error)
 }
 
 // DefaultConfig returns sensible defaults for authentication
@@ -93,9 +109,9 @@ func (c Config) CreateMiddleware(logger *log.Logger) func(http.Handler) http.Han
 	}
 	if c.NonEnforcing {
 		logger.Printf("Authentication non-enforcing mode enabled")
-		return func(next http.Handler) http.Handler {
-			return next
-		}
+		enforcing := c
+		enforcing.NonEnforcing = false
+		return ReportOnly(enforcing.CreateMiddleware(logger), c.Bypasses, logger)
 	}
 
 	// Determine key source
@@ -152,7 +168,7 @@ func (c Config) CreateMiddleware(logger *log.Logger) func(http.Handler) http.Han
 		}
 	} else {
 		// Keep behavior fail-closed for auth-enabled configs with no verification key.
-		return func(_ http.Handler) http.Handler {
+		jwtMiddleware = func(_ http.Handler) http.Handler {
 			return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 				http.Error(w, "invalid token", http.StatusUnauthorized)
 			})
@@ -160,14 +176,35 @@ func (c Config) CreateMiddleware(logger *log.Logger) func(http.Handler) http.Han
 	}
 
 	// If scopes are required, chain with scope middleware
-	if len(c.RequiredScopes) > 0 {
-		scopeMiddleware := CreateScopeMiddleware(c.RequiredScopes...)
-		return func(next http.Handler) http.Handler {
-			return jwtMiddleware(scopeMiddleware(next))
-		}
+	scopeMiddleware := CreateScopeMiddleware(c.RequiredScopes...)
+	if c.APIKeys != nil {
+		return c.apiKeyMiddleware(jwtMiddleware, scopeMiddleware)
+	}
+	return func(next http.Handler) http.Handler {
+		return jwtMiddleware(scopeMiddleware(next))
 	}
+}
 
-	return jwtMiddleware
+// apiKeyMiddleware verifies requests carrying an API key with the APIKeys
+// verifier, and other requests with jwtMiddleware
+func (c Config) apiKeyMiddleware(jwtMiddleware, scopeMiddleware func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		scoped := scopeMiddleware(next)
+		viaToken := jwtMiddleware(scoped)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := strings.TrimSpace(r.Header.Get(APIKeyHeader))
+			if key == "" {
+				viaToken.ServeHTTP(w, r)
+				return
+			}
+			claims, err := c.APIKeys.VerifyAPIKey(r.Context(), key)
+			if err != nil {
+				http.Error(w, "invalid API key", http.StatusUnauthorized)
+				return
+			}
+			scoped.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), claimsContextKey{}, claims)))
+		})
+	}
 }
 
 func (c Config) createStaticKeyMiddleware(staticKey *rsa.PublicKey, logger *log.Logger) func(http.Handler) http.Handler {
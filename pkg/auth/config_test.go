@@ -6,11 +6,14 @@
 package auth
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/openchami/tokensmith/pkg/token"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -81,6 +84,55 @@ func TestCreateMiddleware(t *testing.T) {
 	})
 }
 
+// staticKeys accepts one API key with fixed scopes
+type staticKeys map[string][]string
+
+func (k staticKeys) VerifyAPIKey(_ context.Context, key string) (*token.TSClaims, error) {
+	scopes, ok := k[key]
+	if !ok {
+		return nil, errors.New("unknown key")
+	}
+	claims := &token.TSClaims{Scope: scopes}
+	claims.Subject = "apikey:test"
+	return claims, nil
+}
+
+func TestCreateMiddleware_APIKeys(t *testing.T) {
+	keyPair, err := GenerateTestKeyPair()
+	require.NoError(t, err)
+	config := CreateStaticKeyConfig(keyPair.PublicKeyPEM)
+	config.RequiredScopes = []string{"bss:read"}
+	config.APIKeys = staticKeys{"reader": {"bss:read"}, "pxe": {"bss:bootscript"}}
+
+	var subject string
+	handler := config.CreateMiddleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, err := GetClaimsFromRequest(r)
+		require.NoError(t, err)
+		subject = claims.Subject
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(header, value string) int {
+		req := httptest.NewRequest("GET", "/test", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(APIKeyHeader, "reader"))
+	assert.Equal(t, "apikey:test", subject)
+	assert.Equal(t, http.StatusForbidden, serve(APIKeyHeader, "pxe"))
+	assert.Equal(t, http.StatusUnauthorized, serve(APIKeyHeader, "unknown"))
+	assert.Equal(t, http.StatusUnauthorized, serve("", ""))
+
+	// Bearer tokens are still accepted alongside keys
+	tok, err := CreateTestTokenWithScopes(keyPair, []string{"bss:read"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, serve("Authorization", "Bearer "+tok))
+}
+
 func TestScopeMiddleware(t *testing.T) {
 	t.Run("NoScopesRequired", func(t *testing.T) {
 		middleware := CreateScopeMiddleware()
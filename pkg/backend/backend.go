@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package backend defines the storage backends the service keeps its
+// resources in.
+//
+// A Backend is a Fabrica storage backend, which the generated storage
+// functions use, that can also run transactions. Backends whose storage is
+// shared, such as a database used by several replicas, may implement Watcher
+// to report writes made by any client. The file and etcd backends are built
+// in; other backends are validated with the conformance suite in package
+// backendtest.
+package backend
+
+import (
+	"context"
+	"encoding/json"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// Errors returned by backends, shared with Fabrica so callers can check them
+// with errors.Is whichever backend is used
+var (
+	ErrNotFound    = fabricaStorage.ErrNotFound
+	ErrInvalidData = fabricaStorage.ErrInvalidData
+)
+
+// Backend stores resources as JSON documents keyed by resource type and UID.
+//
+// Load and Delete of a missing resource fail with ErrNotFound, and Save of
+// data that is not JSON fails with ErrInvalidData. LoadAll and List of a type
+// with no resources return an empty result, not an error.
+type Backend interface {
+	fabricaStorage.StorageBackend
+
+	// Transaction runs fn with a transaction. Reads through tx see the
+	// transaction's own writes; the writes are applied together if fn
+	// returns nil and discarded otherwise, and fn's error is returned.
+	// Transactions are serializable: one that reads a resource is not
+	// interleaved with another writing it. fn may be run again if the
+	// transaction conflicts with another, so it must not have other side
+	// effects.
+	Transaction(ctx context.Context, fn func(tx Tx) error) error
+}
+
+// Tx reads and writes resources within a transaction
+type Tx interface {
+	Load(ctx context.Context, resourceType, uid string) (json.RawMessage, error)
+	Save(ctx context.Context, resourceType, uid string, data json.RawMessage) error
+	Delete(ctx context.Context, resourceType, uid string) error
+}
+
+// Watcher is implemented by backends that report every write to their
+// storage, including those of other replicas, rather than only the writes
+// made through them
+type Watcher interface {
+	// Watch calls fn for every write committed after Watch returns, until
+	// ctx is done. Events of one resource are delivered in order.
+	Watch(ctx context.Context, fn resourcewatch.Subscriber) error
+}
@@ -0,0 +1,340 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package backendtest is the conformance suite of storage backends. A backend
+// passes when it behaves like the built-in file backend:
+//
+//	func TestConformance(t *testing.T) {
+//		backendtest.Run(t, func(t *testing.T) backend.Backend {
+//			return newEmptyBackend(t)
+//		})
+//	}
+//
+// Watch is only checked for backends that implement backend.Watcher.
+package backendtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openchami/boot-service/pkg/backend"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// watchTimeout is how long a watcher may take to report a write
+const watchTimeout = 10 * time.Second
+
+// Run runs the conformance suite. newBackend returns an empty backend, and
+// is called once for every test.
+func Run(t *testing.T, newBackend func(t *testing.T) backend.Backend) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, b backend.Backend)
+	}{
+		{"SaveLoad", testSaveLoad},
+		{"NotFound", testNotFound},
+		{"InvalidData", testInvalidData},
+		{"LoadAllAndList", testLoadAllAndList},
+		{"Delete", testDelete},
+		{"TransactionCommit", testTransactionCommit},
+		{"TransactionRollback", testTransactionRollback},
+		{"TransactionsSerialize", testTransactionsSerialize},
+		{"Watch", testWatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newBackend(t))
+		})
+	}
+}
+
+func testSaveLoad(t *testing.T, b backend.Backend) {
+	ctx := context.Background()
+	save(t, b, "Node", "node-1", `{"spec":{"xname":"x1000c0s0b0n0"}}`)
+	expectData(t, b, "Node", "node-1", `{"spec":{"xname":"x1000c0s0b0n0"}}`)
+
+	// A save replaces the resource
+	save(t, b, "Node", "node-1", `{"spec":{"xname":"x1000c0s0b0n1"}}`)
+	expectData(t, b, "Node", "node-1", `{"spec":{"xname":"x1000c0s0b0n1"}}`)
+
+	if exists, err := b.Exists(ctx, "Node", "node-1"); err != nil || !exists {
+		t.Errorf("Exists = %v, %v; want true", exists, err)
+	}
+}
+
+func testNotFound(t *testing.T, b backend.Backend) {
+	ctx := context.Background()
+	if _, err := b.Load(ctx, "Node", "node-missing"); !errors.Is(err, backend.ErrNotFound) {
+		t.Errorf("Load of a missing resource = %v, want ErrNotFound", err)
+	}
+	if err := b.Delete(ctx, "Node", "node-missing"); !errors.Is(err, backend.ErrNotFound) {
+		t.Errorf("Delete of a missing resource = %v, want ErrNotFound", err)
+	}
+	if exists, err := b.Exists(ctx, "Node", "node-missing"); err != nil || exists {
+		t.Errorf("Exists of a missing resource = %v, %v; want false", exists, err)
+	}
+}
+
+func testInvalidData(t *testing.T, b backend.Backend) {
+	ctx := context.Background()
+	if err := b.Save(ctx, "Node", "node-1", json.RawMessage(`{"spec":`)); !errors.Is(err, backend.ErrInvalidData) {
+		t.Errorf("Save of invalid JSON = %v, want ErrInvalidData", err)
+	}
+	if exists, _ := b.Exists(ctx, "Node", "node-1"); exists {
+		t.Error("invalid JSON was stored")
+	}
+}
+
+func testLoadAllAndList(t *testing.T, b backend.Backend) {
+	ctx := context.Background()
+	if items, err := b.LoadAll(ctx, "Node"); err != nil || len(items) != 0 {
+		t.Errorf("LoadAll of an empty type = %d items, %v; want none", len(items), err)
+	}
+	if uids, err := b.List(ctx, "Node"); err != nil || len(uids) != 0 {
+		t.Errorf("List of an empty type = %v, %v; want none", uids, err)
+	}
+
+	save(t, b, "Node", "node-1", `{"name":"a"}`)
+	save(t, b, "Node", "node-2", `{"name":"b"}`)
+	save(t, b, "BootConfiguration", "bootconfiguration-1", `{"name":"c"}`)
+
+	items, err := b.LoadAll(ctx, "Node")
+	if err != nil {
+		t.Fatalf("LoadAll returned error: %v", err)
+	}
+	var names []string
+	for _, item := range items {
+		var doc struct{ Name string }
+		if err := json.Unmarshal(item, &doc); err != nil {
+			t.Fatalf("LoadAll returned invalid JSON %s: %v", item, err)
+		}
+		names = append(names, doc.Name)
+	}
+	slices.Sort(names)
+	if !slices.Equal(names, []string{"a", "b"}) {
+		t.Errorf("LoadAll = %v, want only the nodes", names)
+	}
+
+	uids, err := b.List(ctx, "Node")
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	slices.Sort(uids)
+	if !slices.Equal(uids, []string{"node-1", "node-2"}) {
+		t.Errorf("List = %v, want the node UIDs", uids)
+	}
+}
+
+func testDelete(t *testing.T, b backend.Backend) {
+	ctx := context.Background()
+	save(t, b, "Node", "node-1", `{}`)
+	if err := b.Delete(ctx, "Node", "node-1"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if _, err := b.Load(ctx, "Node", "node-1"); !errors.Is(err, backend.ErrNotFound) {
+		t.Errorf("Load after Delete = %v, want ErrNotFound", err)
+	}
+	if uids, _ := b.List(ctx, "Node"); len(uids) != 0 {
+		t.Errorf("List after Delete = %v, want none", uids)
+	}
+}
+
+func testTransactionCommit(t *testing.T, b backend.Backend) {
+	ctx := context.Background()
+	save(t, b, "Node", "node-1", `{"n":1}`)
+	save(t, b, "Node", "node-2", `{"n":2}`)
+
+	err := b.Transaction(ctx, func(tx backend.Tx) error {
+		if err := tx.Save(ctx, "Node", "node-1", json.RawMessage(`{"n":10}`)); err != nil {
+			return err
+		}
+		if err := tx.Save(ctx, "Node", "node-3", json.RawMessage(`{"n":3}`)); err != nil {
+			return err
+		}
+		if err := tx.Delete(ctx, "Node", "node-2"); err != nil {
+			return err
+		}
+		// The transaction reads its own writes
+		if data, err := tx.Load(ctx, "Node", "node-1"); err != nil || !sameJSON(data, `{"n":10}`) {
+			return fmt.Errorf("Load of a saved resource in the transaction = %s, %v", data, err)
+		}
+		if _, err := tx.Load(ctx, "Node", "node-2"); !errors.Is(err, backend.ErrNotFound) {
+			return fmt.Errorf("Load of a deleted resource in the transaction = %v, want ErrNotFound", err)
+		}
+		if err := tx.Delete(ctx, "Node", "node-missing"); !errors.Is(err, backend.ErrNotFound) {
+			return fmt.Errorf("Delete of a missing resource in the transaction = %v, want ErrNotFound", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Transaction returned error: %v", err)
+	}
+
+	expectData(t, b, "Node", "node-1", `{"n":10}`)
+	expectData(t, b, "Node", "node-3", `{"n":3}`)
+	if _, err := b.Load(ctx, "Node", "node-2"); !errors.Is(err, backend.ErrNotFound) {
+		t.Errorf("Load of a resource the transaction deleted = %v, want ErrNotFound", err)
+	}
+}
+
+func testTransactionRollback(t *testing.T, b backend.Backend) {
+	ctx := context.Background()
+	save(t, b, "Node", "node-1", `{"n":1}`)
+
+	errAbort := errors.New("abort")
+	err := b.Transaction(ctx, func(tx backend.Tx) error {
+		if err := tx.Save(ctx, "Node", "node-1", json.RawMessage(`{"n":10}`)); err != nil {
+			return err
+		}
+		if err := tx.Save(ctx, "Node", "node-2", json.RawMessage(`{"n":2}`)); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Transaction = %v, want the error of fn", err)
+	}
+
+	expectData(t, b, "Node", "node-1", `{"n":1}`)
+	if exists, _ := b.Exists(ctx, "Node", "node-2"); exists {
+		t.Error("a discarded transaction created a resource")
+	}
+}
+
+func testTransactionsSerialize(t *testing.T, b backend.Backend) {
+	ctx := context.Background()
+	save(t, b, "Counter", "counter-1", `{"n":0}`)
+
+	const increments = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, increments)
+	for i := 0; i < increments; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- b.Transaction(ctx, func(tx backend.Tx) error {
+				data, err := tx.Load(ctx, "Counter", "counter-1")
+				if err != nil {
+					return err
+				}
+				var counter struct{ N int }
+				if err := json.Unmarshal(data, &counter); err != nil {
+					return err
+				}
+				return tx.Save(ctx, "Counter", "counter-1", json.RawMessage(fmt.Sprintf(`{"n":%d}`, counter.N+1)))
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Transaction returned error: %v", err)
+		}
+	}
+	expectData(t, b, "Counter", "counter-1", fmt.Sprintf(`{"n":%d}`, increments))
+}
+
+func testWatch(t *testing.T, b backend.Backend) {
+	watcher, ok := b.(backend.Watcher)
+	if !ok {
+		t.Skip("backend does not implement Watcher")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan resourcewatch.Event, 16)
+	if err := watcher.Watch(ctx, func(_ context.Context, event resourcewatch.Event) {
+		events <- event
+	}); err != nil {
+		t.Fatalf("Watch returned error: %v", err)
+	}
+
+	save(t, b, "Node", "node-1", `{"n":1}`)
+	save(t, b, "Node", "node-1", `{"n":2}`)
+	if err := b.Delete(ctx, "Node", "node-1"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	want := []struct {
+		typ      string
+		old, new string
+	}{
+		{resourcewatch.Created, "", `{"n":1}`},
+		{resourcewatch.Updated, `{"n":1}`, `{"n":2}`},
+		{resourcewatch.Deleted, `{"n":2}`, ""},
+	}
+	for _, w := range want {
+		select {
+		case event := <-events:
+			if event.Type != w.typ || event.ResourceType != "Node" || event.UID != "node-1" ||
+				!sameJSON(event.Old, w.old) || !sameJSON(event.New, w.new) {
+				t.Errorf("event = %s %s %s old %s new %s; want %s with old %s new %s",
+					event.Type, event.ResourceType, event.UID, event.Old, event.New, w.typ, w.old, w.new)
+			}
+		case <-time.After(watchTimeout):
+			t.Fatalf("no %s event within %v", w.typ, watchTimeout)
+		}
+	}
+
+	// Nothing is reported once the watch ends
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+	drain(events)
+	save(t, b, "Node", "node-2", `{}`)
+	select {
+	case event := <-events:
+		t.Errorf("event after the watch ended: %+v", event)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func save(t *testing.T, b backend.Backend, resourceType, uid, data string) {
+	t.Helper()
+	if err := b.Save(context.Background(), resourceType, uid, json.RawMessage(data)); err != nil {
+		t.Fatalf("Save(%s %s) returned error: %v", resourceType, uid, err)
+	}
+}
+
+func expectData(t *testing.T, b backend.Backend, resourceType, uid, want string) {
+	t.Helper()
+	data, err := b.Load(context.Background(), resourceType, uid)
+	if err != nil {
+		t.Fatalf("Load(%s %s) returned error: %v", resourceType, uid, err)
+	}
+	if !sameJSON(data, want) {
+		t.Errorf("Load(%s %s) = %s, want %s", resourceType, uid, data, want)
+	}
+}
+
+// sameJSON reports whether data and want hold the same JSON value; an empty
+// want matches no data
+func sameJSON(data json.RawMessage, want string) bool {
+	if want == "" || len(data) == 0 {
+		return want == "" && len(data) == 0
+	}
+	var got, expected any
+	if json.Unmarshal(data, &got) != nil || json.Unmarshal([]byte(want), &expected) != nil {
+		return false
+	}
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(expected)
+	return string(gotJSON) == string(wantJSON)
+}
+
+func drain(events chan resourcewatch.Event) {
+	for {
+		select {
+		case <-events:
+		default:
+			return
+		}
+	}
+}
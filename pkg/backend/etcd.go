@@ -0,0 +1,784 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// DefaultEtcdPrefix is the key prefix resources are stored under by default
+const DefaultEtcdPrefix = "/openchami/boot-service/"
+
+// etcd request timing
+const (
+	defaultEtcdTimeout = 10 * time.Second
+	minWatchRetry      = time.Second
+	maxWatchRetry      = 30 * time.Second
+	// maxTxnAttempts bounds how often a transaction is run again after
+	// conflicting with other writes
+	maxTxnAttempts = 100
+)
+
+// maxEtcdResponseSize bounds the size of a unary etcd response body
+const maxEtcdResponseSize = 256 << 20
+
+// ErrConflict is returned by a transaction that kept conflicting with other
+// writes
+var ErrConflict = errors.New("transaction conflicted with concurrent writes")
+
+// EtcdConfig selects the etcd cluster and where resources are kept in it
+type EtcdConfig struct {
+	// URL is the JSON gateway of an etcd member or of a load balancer in
+	// front of the cluster, such as http://etcd:2379
+	URL string
+	// Prefix starts the key of every resource, <prefix><type>/<uid>
+	Prefix string
+	// Username and Password log in when the cluster has auth enabled
+	Username string
+	Password string
+	// Timeout bounds each request other than watches
+	Timeout    time.Duration
+	HTTPClient *http.Client // must not set a timeout, which would end watches
+}
+
+// Etcd stores each resource as a JSON value in etcd, at the key
+// <prefix><type>/<uid>. Transactions are optimistic: they commit only if
+// nothing they read has changed since, as told by the keys' revisions, and
+// are run again otherwise. Watch follows writes made by every client of the
+// cluster.
+//
+// Resources are stored at a single API version, v1.
+type Etcd struct {
+	config     EtcdConfig
+	baseURL    string
+	httpClient *http.Client
+	logger     *log.Logger
+
+	mu    sync.Mutex
+	token string
+
+	// closing is canceled by Close to end watches
+	closing context.Context
+	close   context.CancelFunc
+}
+
+var (
+	_ Backend = (*Etcd)(nil)
+	_ Watcher = (*Etcd)(nil)
+)
+
+// NewEtcd creates an etcd backend and checks that the cluster can be reached
+func NewEtcd(ctx context.Context, config EtcdConfig, logger *log.Logger) (*Etcd, error) {
+	parsed, err := url.Parse(config.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid etcd URL %q", config.URL)
+	}
+	if config.Prefix == "" {
+		config.Prefix = DefaultEtcdPrefix
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultEtcdTimeout
+	}
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	e := &Etcd{
+		config:     config,
+		baseURL:    strings.TrimRight(config.URL, "/"),
+		httpClient: httpClient,
+		logger:     logger,
+	}
+	e.closing, e.close = context.WithCancel(context.Background())
+	if _, err := e.rangeKeys(ctx, e.config.Prefix, prefixEnd(e.config.Prefix), rangeCountOnly); err != nil {
+		e.close()
+		return nil, fmt.Errorf("connecting to etcd at %s: %w", config.URL, err)
+	}
+	return e, nil
+}
+
+// Close ends the backend's watches
+func (e *Etcd) Close() error {
+	e.close()
+	e.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// LoadAll returns all resources of a type
+func (e *Etcd) LoadAll(ctx context.Context, resourceType string) ([]json.RawMessage, error) {
+	prefix, err := e.typePrefix(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := e.rangeKeys(ctx, prefix, prefixEnd(prefix), rangeValues)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]json.RawMessage, 0, len(resp.KVs))
+	for _, kv := range resp.KVs {
+		items = append(items, json.RawMessage(kv.Value))
+	}
+	return items, nil
+}
+
+// Load returns a resource
+func (e *Etcd) Load(ctx context.Context, resourceType, uid string) (json.RawMessage, error) {
+	kv, err := e.get(ctx, resourceType, uid)
+	if err != nil {
+		return nil, err
+	}
+	if kv == nil {
+		return nil, ErrNotFound
+	}
+	return json.RawMessage(kv.Value), nil
+}
+
+// Save stores a resource
+func (e *Etcd) Save(ctx context.Context, resourceType, uid string, data json.RawMessage) error {
+	if !json.Valid(data) {
+		return fmt.Errorf("invalid JSON data: %w", ErrInvalidData)
+	}
+	key, err := e.key(resourceType, uid)
+	if err != nil {
+		return err
+	}
+	return e.call(ctx, "/v3/kv/put", etcdPut{Key: []byte(key), Value: data}, nil)
+}
+
+// Delete removes a resource
+func (e *Etcd) Delete(ctx context.Context, resourceType, uid string) error {
+	key, err := e.key(resourceType, uid)
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Deleted etcdInt `json:"deleted"`
+	}
+	if err := e.call(ctx, "/v3/kv/deleterange", etcdDelete{Key: []byte(key)}, &resp); err != nil {
+		return err
+	}
+	if resp.Deleted == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Exists reports whether a resource exists
+func (e *Etcd) Exists(ctx context.Context, resourceType, uid string) (bool, error) {
+	key, err := e.key(resourceType, uid)
+	if err != nil {
+		return false, err
+	}
+	resp, err := e.rangeKeys(ctx, key, "", rangeCountOnly)
+	if err != nil {
+		return false, err
+	}
+	return resp.Count > 0, nil
+}
+
+// List returns the UIDs of all resources of a type
+func (e *Etcd) List(ctx context.Context, resourceType string) ([]string, error) {
+	prefix, err := e.typePrefix(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := e.rangeKeys(ctx, prefix, prefixEnd(prefix), rangeKeysOnly)
+	if err != nil {
+		return nil, err
+	}
+	uids := make([]string, 0, len(resp.KVs))
+	for _, kv := range resp.KVs {
+		uids = append(uids, strings.TrimPrefix(string(kv.Key), prefix))
+	}
+	return uids, nil
+}
+
+// LoadWithVersion returns a resource stored at version v1
+func (e *Etcd) LoadWithVersion(ctx context.Context, resourceType, uid, version string) (json.RawMessage, string, error) {
+	if err := checkStoredVersion(resourceType, version); err != nil {
+		return nil, "", err
+	}
+	data, err := e.Load(ctx, resourceType, uid)
+	return data, storedVersion, err
+}
+
+// LoadAllWithVersion returns all resources of a type stored at version v1
+func (e *Etcd) LoadAllWithVersion(ctx context.Context, resourceType, version string) ([]json.RawMessage, error) {
+	if err := checkStoredVersion(resourceType, version); err != nil {
+		return nil, err
+	}
+	return e.LoadAll(ctx, resourceType)
+}
+
+// SaveWithVersion stores a resource given at version v1
+func (e *Etcd) SaveWithVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, version string) error {
+	if err := checkStoredVersion(resourceType, version); err != nil {
+		return err
+	}
+	return e.Save(ctx, resourceType, uid, data)
+}
+
+// storedVersion is the only API version the etcd backend stores
+const storedVersion = "v1"
+
+func checkStoredVersion(resourceType, version string) error {
+	if version != "" && version != storedVersion {
+		return fmt.Errorf("unsupported version %s for %s", version, resourceType)
+	}
+	return nil
+}
+
+// Transaction runs fn and commits its writes if nothing it read has changed,
+// running it again otherwise
+func (e *Etcd) Transaction(ctx context.Context, fn func(tx Tx) error) error {
+	for attempt := 0; attempt < maxTxnAttempts; attempt++ {
+		tx := &etcdTx{etcd: e, reads: map[string]*etcdKV{}, writes: map[string]json.RawMessage{}}
+		if err := fn(tx); err != nil {
+			return err
+		}
+		if len(tx.order) == 0 {
+			return nil
+		}
+		committed, err := e.commit(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if committed {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return ErrConflict
+}
+
+// commit applies the writes of tx if the keys it read are unchanged
+func (e *Etcd) commit(ctx context.Context, tx *etcdTx) (bool, error) {
+	var req etcdTxn
+	for key, kv := range tx.reads {
+		var revision etcdInt
+		if kv != nil {
+			revision = kv.ModRevision
+		}
+		// A key that did not exist has mod revision 0
+		req.Compare = append(req.Compare, etcdCompare{
+			Key: []byte(key), Target: "MOD", Result: "EQUAL", ModRevision: revision,
+		})
+	}
+	for _, key := range tx.order {
+		if data := tx.writes[key]; data != nil {
+			req.Success = append(req.Success, etcdOp{RequestPut: &etcdPut{Key: []byte(key), Value: data}})
+		} else {
+			req.Success = append(req.Success, etcdOp{RequestDeleteRange: &etcdDelete{Key: []byte(key)}})
+		}
+	}
+	var resp struct {
+		Succeeded bool `json:"succeeded"`
+	}
+	if err := e.call(ctx, "/v3/kv/txn", req, &resp); err != nil {
+		return false, err
+	}
+	return resp.Succeeded, nil
+}
+
+// etcdTx buffers the writes of a transaction and remembers the revision of
+// every key it read. A nil write is a delete, and a nil read a missing key.
+type etcdTx struct {
+	etcd   *Etcd
+	reads  map[string]*etcdKV
+	writes map[string]json.RawMessage
+	order  []string
+}
+
+func (t *etcdTx) Load(ctx context.Context, resourceType, uid string) (json.RawMessage, error) {
+	key, err := t.etcd.key(resourceType, uid)
+	if err != nil {
+		return nil, err
+	}
+	if data, ok := t.writes[key]; ok {
+		if data == nil {
+			return nil, ErrNotFound
+		}
+		return data, nil
+	}
+	kv, ok := t.reads[key]
+	if !ok {
+		if kv, err = t.etcd.get(ctx, resourceType, uid); err != nil {
+			return nil, err
+		}
+		t.reads[key] = kv
+	}
+	if kv == nil {
+		return nil, ErrNotFound
+	}
+	return json.RawMessage(kv.Value), nil
+}
+
+func (t *etcdTx) Save(_ context.Context, resourceType, uid string, data json.RawMessage) error {
+	if !json.Valid(data) {
+		return fmt.Errorf("invalid JSON data: %w", ErrInvalidData)
+	}
+	key, err := t.etcd.key(resourceType, uid)
+	if err != nil {
+		return err
+	}
+	t.write(key, append(json.RawMessage(nil), data...))
+	return nil
+}
+
+func (t *etcdTx) Delete(ctx context.Context, resourceType, uid string) error {
+	if _, err := t.Load(ctx, resourceType, uid); err != nil {
+		return err
+	}
+	key, _ := t.etcd.key(resourceType, uid)
+	t.write(key, nil)
+	return nil
+}
+
+func (t *etcdTx) write(key string, data json.RawMessage) {
+	if _, ok := t.writes[key]; !ok {
+		t.order = append(t.order, key)
+	}
+	t.writes[key] = data
+}
+
+// Watch calls fn for every write to the backend's keys, by any client, from
+// now until ctx is done. A broken watch is resumed from the last revision it
+// saw; writes compacted away in the meantime are lost, which is logged.
+func (e *Etcd) Watch(ctx context.Context, fn resourcewatch.Subscriber) error {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(e.closing, cancel)
+	stream, err := e.openWatch(ctx, 0)
+	if err != nil {
+		stop()
+		cancel()
+		return err
+	}
+	go func() {
+		defer cancel()
+		defer stop()
+		e.follow(ctx, stream, fn)
+	}()
+	return nil
+}
+
+// watchStream is an open watch. revision is the last revision it reported.
+type watchStream struct {
+	body     io.ReadCloser
+	decoder  *json.Decoder
+	revision int64
+}
+
+// openWatch starts a watch of every key under the prefix, from start or,
+// when start is 0, from now. It returns once etcd has created the watch.
+func (e *Etcd) openWatch(ctx context.Context, start int64) (*watchStream, error) {
+	req := etcdWatchRequest{CreateRequest: etcdWatchCreate{
+		Key:           []byte(e.config.Prefix),
+		RangeEnd:      []byte(prefixEnd(e.config.Prefix)),
+		StartRevision: etcdInt(start),
+		PrevKV:        true,
+	}}
+	body, err := e.send(ctx, "/v3/watch", req)
+	if err != nil {
+		return nil, err
+	}
+	stream := &watchStream{body: body, decoder: json.NewDecoder(body), revision: start - 1}
+	var msg etcdWatchMessage
+	if err := stream.decoder.Decode(&msg); err != nil {
+		body.Close() //nolint:errcheck
+		return nil, fmt.Errorf("starting etcd watch: %w", err)
+	}
+	if msg.Error != nil || !msg.Result.Created {
+		body.Close() //nolint:errcheck
+		return nil, fmt.Errorf("etcd refused the watch: %s", msg.errorMessage())
+	}
+	if start == 0 {
+		stream.revision = int64(msg.Result.Header.Revision)
+	}
+	return stream, nil
+}
+
+// follow delivers the events of stream to fn, reopening the watch when it
+// breaks, until ctx is done
+func (e *Etcd) follow(ctx context.Context, stream *watchStream, fn resourcewatch.Subscriber) {
+	retry := minWatchRetry
+	for {
+		err := e.deliver(ctx, stream, fn)
+		stream.body.Close() //nolint:errcheck
+		if ctx.Err() != nil {
+			return
+		}
+		e.logger.Printf("etcd watch ended, resuming: %v", err)
+
+		revision := stream.revision
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retry):
+			}
+			stream, err = e.openWatch(ctx, revision+1)
+			if errors.Is(err, errCompacted) {
+				e.logger.Printf("etcd compacted revisions after %d; writes made while the watch was down are not reported", revision)
+				stream, err = e.openWatch(ctx, 0)
+			}
+			if err == nil {
+				retry = minWatchRetry
+				break
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			e.logger.Printf("Failed to resume etcd watch: %v", err)
+			retry = min(2*retry, maxWatchRetry)
+		}
+	}
+}
+
+// errCompacted is returned when a watch starts at a compacted revision
+var errCompacted = errors.New("required revision has been compacted")
+
+// deliver reads stream until it breaks
+func (e *Etcd) deliver(ctx context.Context, stream *watchStream, fn resourcewatch.Subscriber) error {
+	for {
+		var msg etcdWatchMessage
+		if err := stream.decoder.Decode(&msg); err != nil {
+			return err
+		}
+		if msg.Error != nil || msg.Result.Canceled {
+			return fmt.Errorf("etcd canceled the watch: %s", msg.errorMessage())
+		}
+		for _, event := range msg.Result.Events {
+			stream.revision = int64(event.KV.ModRevision)
+			if change, ok := e.change(event); ok {
+				fn(ctx, change)
+			}
+		}
+		if len(msg.Result.Events) == 0 && int64(msg.Result.Header.Revision) > stream.revision {
+			// Progress notifications move the watch along too
+			stream.revision = int64(msg.Result.Header.Revision)
+		}
+	}
+}
+
+// change converts a watch event on a resource key
+func (e *Etcd) change(event etcdEvent) (resourcewatch.Event, bool) {
+	resourceType, uid, ok := strings.Cut(strings.TrimPrefix(string(event.KV.Key), e.config.Prefix), "/")
+	if !ok || resourceType == "" || uid == "" {
+		return resourcewatch.Event{}, false
+	}
+	change := resourcewatch.Event{Type: resourcewatch.Updated, ResourceType: resourceType, UID: uid}
+	if event.PrevKV != nil {
+		change.Old = json.RawMessage(event.PrevKV.Value)
+	}
+	switch {
+	case event.Type == "DELETE":
+		change.Type = resourcewatch.Deleted
+	case event.KV.Version == 1:
+		change.Type = resourcewatch.Created
+		change.New = json.RawMessage(event.KV.Value)
+	default:
+		change.New = json.RawMessage(event.KV.Value)
+	}
+	return change, true
+}
+
+// key returns the key of a resource
+func (e *Etcd) key(resourceType, uid string) (string, error) {
+	prefix, err := e.typePrefix(resourceType)
+	if err != nil {
+		return "", err
+	}
+	if uid == "" || strings.Contains(uid, "/") {
+		return "", fmt.Errorf("invalid UID %q", uid)
+	}
+	return prefix + uid, nil
+}
+
+// typePrefix returns the prefix of the keys of a resource type
+func (e *Etcd) typePrefix(resourceType string) (string, error) {
+	if resourceType == "" || strings.ContainsFunc(resourceType, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_'
+	}) {
+		return "", fmt.Errorf("invalid resource type %q", resourceType)
+	}
+	return e.config.Prefix + resourceType + "/", nil
+}
+
+// prefixEnd returns the end of the range of keys starting with prefix
+func prefixEnd(prefix string) string {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return string(end[:i+1])
+		}
+	}
+	// Every key sorts after a prefix of 0xff bytes
+	return "\x00"
+}
+
+// Range kinds
+const (
+	rangeValues = iota
+	rangeKeysOnly
+	rangeCountOnly
+)
+
+// get returns the key-value of a resource, or nil if it does not exist
+func (e *Etcd) get(ctx context.Context, resourceType, uid string) (*etcdKV, error) {
+	key, err := e.key(resourceType, uid)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := e.rangeKeys(ctx, key, "", rangeValues)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.KVs) == 0 {
+		return nil, nil
+	}
+	return &resp.KVs[0], nil
+}
+
+func (e *Etcd) rangeKeys(ctx context.Context, key, end string, kind int) (*etcdRangeResponse, error) {
+	req := etcdRange{Key: []byte(key), KeysOnly: kind == rangeKeysOnly, CountOnly: kind == rangeCountOnly}
+	if end != "" {
+		req.RangeEnd = []byte(end)
+	}
+	var resp etcdRangeResponse
+	if err := e.call(ctx, "/v3/kv/range", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// call sends a unary request and decodes its response into out
+func (e *Etcd) call(ctx context.Context, path string, req, out any) error {
+	ctx, cancel := context.WithTimeout(ctx, e.config.Timeout)
+	defer cancel()
+	body, err := e.send(ctx, path, req)
+	if err != nil {
+		return err
+	}
+	defer body.Close() //nolint:errcheck
+	if out == nil {
+		_, err = io.Copy(io.Discard, body)
+		return err
+	}
+	if err := json.NewDecoder(io.LimitReader(body, maxEtcdResponseSize)).Decode(out); err != nil {
+		return fmt.Errorf("decoding etcd response: %w", err)
+	}
+	return nil
+}
+
+// send posts a request, logging in first when the cluster needs it, and
+// returns the response body
+func (e *Etcd) send(ctx context.Context, path string, req any) (io.ReadCloser, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	token, err := e.authToken(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	for attempt := 0; ; attempt++ {
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+path, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			httpReq.Header.Set("Authorization", token)
+		}
+		resp, err := e.httpClient.Do(httpReq)
+		if err != nil {
+			return nil, fmt.Errorf("etcd request failed: %w", err)
+		}
+		if resp.StatusCode == http.StatusOK {
+			return resp.Body, nil
+		}
+
+		var failure etcdError
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&failure)
+		resp.Body.Close() //nolint:errcheck
+		message := failure.message()
+		switch {
+		case strings.Contains(message, "compacted"):
+			return nil, errCompacted
+		case attempt == 0 && e.config.Username != "" && strings.Contains(message, "auth token"):
+			// Tokens expire; log in again once
+			if token, err = e.authToken(ctx, true); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if message == "" {
+			message = resp.Status
+		}
+		return nil, fmt.Errorf("etcd %s failed: %s", path, message)
+	}
+}
+
+// authToken returns the token requests are sent with, logging in when there
+// is none or renew is set. Without a username no token is used.
+func (e *Etcd) authToken(ctx context.Context, renew bool) (string, error) {
+	if e.config.Username == "" {
+		return "", nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.token != "" && !renew {
+		return e.token, nil
+	}
+
+	payload, _ := json.Marshal(map[string]string{"name": e.config.Username, "password": e.config.Password})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/v3/auth/authenticate", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("etcd login failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	var result struct {
+		Token string `json:"token"`
+		etcdError
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&result); err != nil || resp.StatusCode != http.StatusOK || result.Token == "" {
+		return "", fmt.Errorf("etcd login as %s failed: %s", e.config.Username, result.message())
+	}
+	e.token = result.Token
+	return e.token, nil
+}
+
+// etcdInt is an int64 of the etcd JSON gateway, which sends them as strings
+type etcdInt int64
+
+func (i etcdInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatInt(int64(i), 10))
+}
+
+func (i *etcdInt) UnmarshalJSON(data []byte) error {
+	n, err := strconv.ParseInt(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = etcdInt(n)
+	return nil
+}
+
+// etcd JSON gateway messages. Byte fields are base64, as the gateway expects.
+type (
+	etcdKV struct {
+		Key            []byte  `json:"key"`
+		Value          []byte  `json:"value,omitempty"`
+		CreateRevision etcdInt `json:"create_revision,omitempty"`
+		ModRevision    etcdInt `json:"mod_revision,omitempty"`
+		Version        etcdInt `json:"version,omitempty"`
+	}
+	etcdHeader struct {
+		Revision etcdInt `json:"revision,omitempty"`
+	}
+	etcdRange struct {
+		Key       []byte `json:"key"`
+		RangeEnd  []byte `json:"range_end,omitempty"`
+		KeysOnly  bool   `json:"keys_only,omitempty"`
+		CountOnly bool   `json:"count_only,omitempty"`
+	}
+	etcdRangeResponse struct {
+		Header etcdHeader `json:"header"`
+		KVs    []etcdKV   `json:"kvs,omitempty"`
+		Count  etcdInt    `json:"count,omitempty"`
+	}
+	etcdPut struct {
+		Key   []byte `json:"key"`
+		Value []byte `json:"value"`
+	}
+	etcdDelete struct {
+		Key []byte `json:"key"`
+	}
+	etcdCompare struct {
+		Key         []byte  `json:"key"`
+		Target      string  `json:"target"`
+		Result      string  `json:"result"`
+		ModRevision etcdInt `json:"mod_revision"`
+	}
+	etcdOp struct {
+		RequestPut         *etcdPut    `json:"request_put,omitempty"`
+		RequestDeleteRange *etcdDelete `json:"request_delete_range,omitempty"`
+	}
+	etcdTxn struct {
+		Compare []etcdCompare `json:"compare,omitempty"`
+		Success []etcdOp      `json:"success,omitempty"`
+	}
+	etcdWatchCreate struct {
+		Key           []byte  `json:"key"`
+		RangeEnd      []byte  `json:"range_end,omitempty"`
+		StartRevision etcdInt `json:"start_revision,omitempty"`
+		PrevKV        bool    `json:"prev_kv,omitempty"`
+	}
+	etcdWatchRequest struct {
+		CreateRequest etcdWatchCreate `json:"create_request"`
+	}
+	etcdEvent struct {
+		Type   string  `json:"type,omitempty"` // PUT is the default and may be left out
+		KV     etcdKV  `json:"kv"`
+		PrevKV *etcdKV `json:"prev_kv,omitempty"`
+	}
+	etcdWatchMessage struct {
+		Result struct {
+			Header          etcdHeader  `json:"header"`
+			Created         bool        `json:"created,omitempty"`
+			Canceled        bool        `json:"canceled,omitempty"`
+			CompactRevision etcdInt     `json:"compact_revision,omitempty"`
+			CancelReason    string      `json:"cancel_reason,omitempty"`
+			Events          []etcdEvent `json:"events,omitempty"`
+		} `json:"result"`
+		Error *etcdError `json:"error,omitempty"`
+	}
+	etcdError struct {
+		Error   string `json:"error,omitempty"`
+		Message string `json:"message,omitempty"`
+		Code    int    `json:"code,omitempty"`
+	}
+)
+
+func (e etcdError) message() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return e.Error
+}
+
+func (m etcdWatchMessage) errorMessage() string {
+	switch {
+	case m.Error != nil:
+		return m.Error.message()
+	case m.Result.CancelReason != "":
+		return m.Result.CancelReason
+	case m.Result.CompactRevision > 0:
+		return fmt.Sprintf("revision compacted at %d", m.Result.CompactRevision)
+	}
+	return "no reason given"
+}
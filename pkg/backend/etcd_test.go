@@ -0,0 +1,417 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package backend_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openchami/boot-service/pkg/backend"
+	"github.com/openchami/boot-service/pkg/backend/backendtest"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+func TestEtcdConformance(t *testing.T) {
+	backendtest.Run(t, func(t *testing.T) backend.Backend {
+		return newTestEtcd(t, newFakeEtcd(t), backend.EtcdConfig{})
+	})
+}
+
+func TestEtcdLogin(t *testing.T) {
+	fake := newFakeEtcd(t)
+	fake.password = "secret"
+	etcd := newTestEtcd(t, fake, backend.EtcdConfig{Username: "boot", Password: "secret"})
+	ctx := context.Background()
+
+	if err := etcd.Save(ctx, "Node", "node-1", json.RawMessage(`{}`)); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	// An expired token is replaced
+	fake.expireTokens()
+	if _, err := etcd.Load(ctx, "Node", "node-1"); err != nil {
+		t.Fatalf("Load after the token expired returned error: %v", err)
+	}
+
+	fake.password = "changed"
+	fake.expireTokens()
+	if _, err := etcd.Load(ctx, "Node", "node-1"); err == nil {
+		t.Fatal("Load with a rejected password succeeded")
+	}
+}
+
+func TestEtcdWatchResumes(t *testing.T) {
+	fake := newFakeEtcd(t)
+	etcd := newTestEtcd(t, fake, backend.EtcdConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan resourcewatch.Event, 10)
+	if err := etcd.Watch(ctx, func(_ context.Context, event resourcewatch.Event) {
+		events <- event
+	}); err != nil {
+		t.Fatalf("Watch returned error: %v", err)
+	}
+
+	// A write made while the watch is broken is delivered once it resumes
+	fake.dropWatches()
+	if err := etcd.Save(ctx, "Node", "node-1", json.RawMessage(`{"n":1}`)); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	select {
+	case event := <-events:
+		if event.Type != resourcewatch.Created || event.UID != "node-1" {
+			t.Errorf("event = %s %s, want created node-1", event.Type, event.UID)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("write was not reported after the watch broke")
+	}
+}
+
+func TestNewEtcdRejectsBadURL(t *testing.T) {
+	if _, err := backend.NewEtcd(context.Background(), backend.EtcdConfig{URL: "etcd:2379"}, log.Default()); err == nil {
+		t.Fatal("NewEtcd accepted a URL without a scheme")
+	}
+}
+
+func newTestEtcd(t *testing.T, fake *fakeEtcd, config backend.EtcdConfig) *backend.Etcd {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	config.URL = server.URL
+	etcd, err := backend.NewEtcd(context.Background(), config, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewEtcd returned error: %v", err)
+	}
+	t.Cleanup(func() { etcd.Close() }) //nolint:errcheck
+	return etcd
+}
+
+// fakeEtcd serves the part of the etcd v3 JSON gateway the backend uses,
+// keeping keys in memory
+type fakeEtcd struct {
+	t *testing.T
+
+	mu       sync.Mutex
+	revision int64
+	kvs      map[string]fakeKV
+	history  []fakeEvent
+	watches  map[chan fakeEvent]chan struct{}
+	password string
+	tokens   map[string]bool
+}
+
+type fakeKV struct {
+	Key            []byte `json:"key"`
+	Value          []byte `json:"value,omitempty"`
+	CreateRevision string `json:"create_revision,omitempty"`
+	ModRevision    string `json:"mod_revision,omitempty"`
+	Version        string `json:"version,omitempty"`
+
+	create, mod, version int64
+}
+
+type fakeEvent struct {
+	Type   string  `json:"type,omitempty"`
+	KV     fakeKV  `json:"kv"`
+	PrevKV *fakeKV `json:"prev_kv,omitempty"`
+}
+
+func newFakeEtcd(t *testing.T) *fakeEtcd {
+	return &fakeEtcd{
+		t:        t,
+		revision: 1,
+		kvs:      map[string]fakeKV{},
+		watches:  map[chan fakeEvent]chan struct{}{},
+		tokens:   map[string]bool{},
+	}
+}
+
+func (f *fakeEtcd) expireTokens() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens = map[string]bool{}
+}
+
+// dropWatches ends every open watch stream
+func (f *fakeEtcd) dropWatches() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for events, done := range f.watches {
+		close(done)
+		delete(f.watches, events)
+	}
+}
+
+type fakeRequest struct {
+	Key      []byte `json:"key"`
+	RangeEnd []byte `json:"range_end"`
+	Value    []byte `json:"value"`
+
+	KeysOnly  bool `json:"keys_only"`
+	CountOnly bool `json:"count_only"`
+
+	Compare []struct {
+		Key         []byte `json:"key"`
+		Target      string `json:"target"`
+		Result      string `json:"result"`
+		ModRevision string `json:"mod_revision"`
+	} `json:"compare"`
+	Success []struct {
+		RequestPut         *fakeRequest `json:"request_put"`
+		RequestDeleteRange *fakeRequest `json:"request_delete_range"`
+	} `json:"success"`
+
+	CreateRequest *struct {
+		Key           []byte `json:"key"`
+		RangeEnd      []byte `json:"range_end"`
+		StartRevision string `json:"start_revision"`
+	} `json:"create_request"`
+
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+func (f *fakeEtcd) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req fakeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		f.fail(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if r.URL.Path == "/v3/auth/authenticate" {
+		f.authenticate(w, req)
+		return
+	}
+
+	f.mu.Lock()
+	if f.password != "" && !f.tokens[r.Header.Get("Authorization")] {
+		f.mu.Unlock()
+		f.fail(w, http.StatusUnauthorized, "etcdserver: invalid auth token")
+		return
+	}
+	switch r.URL.Path {
+	case "/v3/kv/range":
+		defer f.mu.Unlock()
+		f.respond(w, f.rangeKeys(req))
+	case "/v3/kv/put":
+		defer f.mu.Unlock()
+		f.revision++
+		f.put(string(req.Key), req.Value)
+		f.respond(w, map[string]any{})
+	case "/v3/kv/deleterange":
+		defer f.mu.Unlock()
+		deleted := 0
+		if _, ok := f.kvs[string(req.Key)]; ok {
+			f.revision++
+			f.delete(string(req.Key))
+			deleted = 1
+		}
+		f.respond(w, map[string]any{"deleted": strconv.Itoa(deleted)})
+	case "/v3/kv/txn":
+		defer f.mu.Unlock()
+		f.respond(w, map[string]any{"succeeded": f.txn(req)})
+	case "/v3/watch":
+		f.watch(w, r, req)
+	default:
+		f.mu.Unlock()
+		f.fail(w, http.StatusNotFound, "unknown path "+r.URL.Path)
+	}
+}
+
+func (f *fakeEtcd) authenticate(w http.ResponseWriter, req fakeRequest) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if req.Password != f.password {
+		f.fail(w, http.StatusBadRequest, "etcdserver: authentication failed, invalid user ID or password")
+		return
+	}
+	token := "token-" + strconv.Itoa(len(f.tokens)+1) + "-" + strconv.FormatInt(f.revision, 10)
+	f.tokens[token] = true
+	f.respond(w, map[string]string{"token": token})
+}
+
+func (f *fakeEtcd) rangeKeys(req fakeRequest) map[string]any {
+	var kvs []fakeKV
+	for key, kv := range f.kvs {
+		if !inRange(key, string(req.Key), string(req.RangeEnd)) {
+			continue
+		}
+		if req.KeysOnly {
+			kv.Value = nil
+		}
+		kvs = append(kvs, kv)
+	}
+	resp := map[string]any{
+		"header": map[string]string{"revision": strconv.FormatInt(f.revision, 10)},
+		"count":  strconv.Itoa(len(kvs)),
+	}
+	if !req.CountOnly && len(kvs) > 0 {
+		resp["kvs"] = kvs
+	}
+	return resp
+}
+
+func (f *fakeEtcd) txn(req fakeRequest) bool {
+	for _, compare := range req.Compare {
+		if compare.Target != "MOD" || compare.Result != "EQUAL" {
+			f.t.Errorf("unexpected compare %s %s", compare.Target, compare.Result)
+			return false
+		}
+		if strconv.FormatInt(f.kvs[string(compare.Key)].mod, 10) != orZero(compare.ModRevision) {
+			return false
+		}
+	}
+	f.revision++
+	for _, op := range req.Success {
+		switch {
+		case op.RequestPut != nil:
+			f.put(string(op.RequestPut.Key), op.RequestPut.Value)
+		case op.RequestDeleteRange != nil:
+			f.delete(string(op.RequestDeleteRange.Key))
+		}
+	}
+	return true
+}
+
+func (f *fakeEtcd) put(key string, value []byte) {
+	kv, existed := f.kvs[key]
+	prev := kv
+	if !existed {
+		kv.create = f.revision
+	}
+	kv.Key, kv.Value = []byte(key), value
+	kv.mod = f.revision
+	kv.version++
+	kv.CreateRevision = strconv.FormatInt(kv.create, 10)
+	kv.ModRevision = strconv.FormatInt(kv.mod, 10)
+	kv.Version = strconv.FormatInt(kv.version, 10)
+	f.kvs[key] = kv
+
+	event := fakeEvent{KV: kv}
+	if existed {
+		event.PrevKV = &prev
+	}
+	f.publish(event)
+}
+
+func (f *fakeEtcd) delete(key string) {
+	prev, ok := f.kvs[key]
+	if !ok {
+		return
+	}
+	delete(f.kvs, key)
+	f.publish(fakeEvent{
+		Type:   "DELETE",
+		KV:     fakeKV{Key: []byte(key), ModRevision: strconv.FormatInt(f.revision, 10), mod: f.revision},
+		PrevKV: &prev,
+	})
+}
+
+func (f *fakeEtcd) publish(event fakeEvent) {
+	f.history = append(f.history, event)
+	for events, done := range f.watches {
+		select {
+		case events <- event:
+		case <-done:
+		}
+	}
+}
+
+// watch streams events until the client goes away or the watch is dropped.
+// It is called with f.mu held.
+func (f *fakeEtcd) watch(w http.ResponseWriter, r *http.Request, req fakeRequest) {
+	if req.CreateRequest == nil {
+		f.mu.Unlock()
+		f.fail(w, http.StatusBadRequest, "not a create request")
+		return
+	}
+	start, end := string(req.CreateRequest.Key), string(req.CreateRequest.RangeEnd)
+	startRevision, _ := strconv.ParseInt(orZero(req.CreateRequest.StartRevision), 10, 64)
+
+	var backlog []fakeEvent
+	if startRevision > 0 {
+		for _, event := range f.history {
+			if event.KV.mod >= startRevision {
+				backlog = append(backlog, event)
+			}
+		}
+	}
+	events := make(chan fakeEvent, 100)
+	done := make(chan struct{})
+	f.watches[events] = done
+	header := map[string]string{"revision": strconv.FormatInt(f.revision, 10)}
+	f.mu.Unlock()
+
+	flusher := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	send := func(result map[string]any) bool {
+		if err := encoder.Encode(map[string]any{"result": result}); err != nil {
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+	send(map[string]any{"header": header, "created": true})
+	for _, event := range backlog {
+		if inRange(string(event.KV.Key), start, end) {
+			send(map[string]any{"header": header, "events": []fakeEvent{event}})
+		}
+	}
+	defer func() {
+		f.mu.Lock()
+		delete(f.watches, events)
+		f.mu.Unlock()
+	}()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-done:
+			return
+		case event := <-events:
+			if !inRange(string(event.KV.Key), start, end) {
+				continue
+			}
+			if !send(map[string]any{"events": []fakeEvent{event}}) {
+				return
+			}
+		}
+	}
+}
+
+func (f *fakeEtcd) respond(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body) //nolint:errcheck
+}
+
+func (f *fakeEtcd) fail(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{"error": message, "message": message, "code": 2}) //nolint:errcheck
+}
+
+// inRange reports whether key is in [start, end), or is start when end is
+// empty
+func inRange(key, start, end string) bool {
+	if end == "" {
+		return key == start
+	}
+	return bytes.Compare([]byte(key), []byte(start)) >= 0 && (end == "\x00" || key < end)
+}
+
+func orZero(n string) string {
+	if n == "" {
+		return "0"
+	}
+	return n
+}
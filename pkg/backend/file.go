@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+)
+
+// File stores each resource in a JSON file beneath a directory. Transactions
+// hold a lock that keeps other writes out, and apply their writes one file
+// at a time, so a crash while committing may leave part of a transaction
+// applied.
+type File struct {
+	*fabricaStorage.FileBackend
+
+	// mu is held for reading by single writes and for writing by
+	// transactions
+	mu sync.RWMutex
+}
+
+var _ Backend = (*File)(nil)
+
+// NewFile creates a file backend in dir, creating it if needed
+func NewFile(dir string) (*File, error) {
+	files, err := fabricaStorage.NewFileBackend(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file backend: %w", err)
+	}
+	return &File{FileBackend: files}, nil
+}
+
+// Save stores a resource
+func (f *File) Save(ctx context.Context, resourceType, uid string, data json.RawMessage) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.FileBackend.Save(ctx, resourceType, uid, data)
+}
+
+// SaveWithVersion stores a resource given at a specific API version
+func (f *File) SaveWithVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, version string) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.FileBackend.SaveWithVersion(ctx, resourceType, uid, data, version)
+}
+
+// Delete removes a resource
+func (f *File) Delete(ctx context.Context, resourceType, uid string) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.FileBackend.Delete(ctx, resourceType, uid)
+}
+
+// Transaction runs fn with the backend locked against other writes
+func (f *File) Transaction(ctx context.Context, fn func(tx Tx) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tx := &fileTx{files: f.FileBackend, writes: map[writeKey]json.RawMessage{}}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	for _, key := range tx.order {
+		data := tx.writes[key]
+		var err error
+		if data == nil {
+			err = f.FileBackend.Delete(ctx, key.resourceType, key.uid)
+			if errors.Is(err, ErrNotFound) {
+				// Created and deleted within the transaction
+				err = nil
+			}
+		} else {
+			err = f.FileBackend.Save(ctx, key.resourceType, key.uid, data)
+		}
+		if err != nil {
+			return fmt.Errorf("committing %s %s: %w", key.resourceType, key.uid, err)
+		}
+	}
+	return nil
+}
+
+type writeKey struct {
+	resourceType string
+	uid          string
+}
+
+// fileTx buffers the writes of a transaction. A nil entry is a delete.
+type fileTx struct {
+	files  *fabricaStorage.FileBackend
+	writes map[writeKey]json.RawMessage
+	order  []writeKey
+}
+
+func (t *fileTx) Load(ctx context.Context, resourceType, uid string) (json.RawMessage, error) {
+	if data, ok := t.writes[writeKey{resourceType, uid}]; ok {
+		if data == nil {
+			return nil, ErrNotFound
+		}
+		return data, nil
+	}
+	return t.files.Load(ctx, resourceType, uid)
+}
+
+func (t *fileTx) Save(ctx context.Context, resourceType, uid string, data json.RawMessage) error {
+	if !json.Valid(data) {
+		return fmt.Errorf("invalid JSON data: %w", ErrInvalidData)
+	}
+	// A type or UID the files cannot be named by fails now, not halfway
+	// through the commit
+	if _, err := t.files.Exists(ctx, resourceType, uid); err != nil {
+		return err
+	}
+	t.write(writeKey{resourceType, uid}, append(json.RawMessage(nil), data...))
+	return nil
+}
+
+func (t *fileTx) Delete(ctx context.Context, resourceType, uid string) error {
+	if _, err := t.Load(ctx, resourceType, uid); err != nil {
+		return err
+	}
+	t.write(writeKey{resourceType, uid}, nil)
+	return nil
+}
+
+func (t *fileTx) write(key writeKey, data json.RawMessage) {
+	if _, ok := t.writes[key]; !ok {
+		t.order = append(t.order, key)
+	}
+	t.writes[key] = data
+}
@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/openchami/boot-service/pkg/backend"
+	"github.com/openchami/boot-service/pkg/backend/backendtest"
+)
+
+func TestFileConformance(t *testing.T) {
+	backendtest.Run(t, func(t *testing.T) backend.Backend {
+		files, err := backend.NewFile(t.TempDir())
+		if err != nil {
+			t.Fatalf("NewFile returned error: %v", err)
+		}
+		return files
+	})
+}
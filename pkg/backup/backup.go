@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package backup takes scheduled snapshots of the stored resources and keeps
+// them in a directory or S3 bucket, for disaster recovery of boot data.
+//
+// Each backup is a snapshot (package snapshot) named after the time it was
+// taken, so a restore can pick the newest backup at or before any point in
+// time. Older backups are pruned by a retention policy after every backup.
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/openchami/boot-service/pkg/snapshot"
+)
+
+// Backup file names are namePrefix, the UTC time taken, and nameSuffix
+const (
+	namePrefix = "boot-service-"
+	nameSuffix = ".json"
+	timeLayout = "20060102T150405Z"
+)
+
+// ErrNoBackup is returned when no backup was taken at or before a time
+var ErrNoBackup = errors.New("no backup found")
+
+// Store keeps backup files
+type Store interface {
+	Put(ctx context.Context, name string, data []byte) error
+	Get(ctx context.Context, name string) ([]byte, error)
+	// List returns the names of every file in the store
+	List(ctx context.Context) ([]string, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// Info describes a stored backup
+type Info struct {
+	Name    string    `json:"name"`
+	TakenAt time.Time `json:"takenAt"`
+}
+
+// Name returns the file name of a backup taken at t
+func Name(t time.Time) string {
+	return namePrefix + t.UTC().Format(timeLayout) + nameSuffix
+}
+
+// ParseName returns the time a backup file was taken, and false for files
+// that are not backups
+func ParseName(name string) (time.Time, bool) {
+	stamp, ok := strings.CutPrefix(name, namePrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	if stamp, ok = strings.CutSuffix(stamp, nameSuffix); !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(timeLayout, stamp)
+	return t, err == nil
+}
+
+// Retention decides which backups are kept. Zero values keep everything; the
+// newest backup is always kept.
+type Retention struct {
+	Count  int           // backups kept, newest first
+	MaxAge time.Duration // age after which backups are deleted
+}
+
+// Manager takes, lists, and prunes backups of a storage backend
+type Manager struct {
+	backend   fabricaStorage.StorageBackend
+	store     Store
+	retention Retention
+	logger    *log.Logger
+	now       func() time.Time
+
+	// mu keeps backups and pruning from overlapping
+	mu sync.Mutex
+}
+
+// NewManager creates a manager that backs backend up to store
+func NewManager(backend fabricaStorage.StorageBackend, store Store, retention Retention, logger *log.Logger) *Manager {
+	return &Manager{backend: backend, store: store, retention: retention, logger: logger, now: time.Now}
+}
+
+// List returns the stored backups, newest first
+func (m *Manager) List(ctx context.Context) ([]Info, error) {
+	return List(ctx, m.store)
+}
+
+// Take backs the resources up now and prunes backups the retention policy
+// no longer keeps
+func (m *Manager) Take(ctx context.Context) (Info, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, err := snapshot.Export(ctx, m.backend)
+	if err != nil {
+		return Info{}, fmt.Errorf("exporting resources: %w", err)
+	}
+	state.ExportedAt = m.now().UTC().Truncate(time.Second)
+	var buf bytes.Buffer
+	if err := snapshot.Write(&buf, state); err != nil {
+		return Info{}, err
+	}
+	info := Info{Name: Name(state.ExportedAt), TakenAt: state.ExportedAt}
+	if err := m.store.Put(ctx, info.Name, buf.Bytes()); err != nil {
+		return Info{}, fmt.Errorf("storing backup %s: %w", info.Name, err)
+	}
+
+	if err := m.prune(ctx); err != nil {
+		m.logger.Printf("Failed to prune backups: %v", err)
+	}
+	return info, nil
+}
+
+// prune deletes the backups the retention policy no longer keeps
+func (m *Manager) prune(ctx context.Context) error {
+	backups, err := m.List(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for i, info := range backups {
+		if i == 0 {
+			continue
+		}
+		expired := m.retention.MaxAge > 0 && m.now().Sub(info.TakenAt) > m.retention.MaxAge
+		if (m.retention.Count <= 0 || i < m.retention.Count) && !expired {
+			continue
+		}
+		if err := m.store.Delete(ctx, info.Name); err != nil {
+			errs = append(errs, fmt.Errorf("deleting %s: %w", info.Name, err))
+			continue
+		}
+		m.logger.Printf("Deleted backup %s", info.Name)
+	}
+	return errors.Join(errs...)
+}
+
+// Run takes a backup every interval until ctx is done. The first is taken as
+// soon as the newest stored backup is an interval old, so restarts do not
+// delay backups or take extra ones.
+func (m *Manager) Run(ctx context.Context, interval time.Duration) {
+	wait := time.Duration(0)
+	if backups, err := m.List(ctx); err != nil {
+		m.logger.Printf("Failed to list backups: %v", err)
+	} else if len(backups) > 0 {
+		wait = max(0, interval-m.now().Sub(backups[0].TakenAt))
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		if info, err := m.Take(ctx); err != nil {
+			m.logger.Printf("Backup failed: %v", err)
+		} else {
+			m.logger.Printf("Backed up resources to %s", info.Name)
+		}
+		timer.Reset(interval)
+	}
+}
+
+// List returns the backups in store, newest first
+func List(ctx context.Context, store Store) ([]Info, error) {
+	names, err := store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]Info, 0, len(names))
+	for _, name := range names {
+		if takenAt, ok := ParseName(name); ok {
+			backups = append(backups, Info{Name: name, TakenAt: takenAt})
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].TakenAt.After(backups[j].TakenAt) })
+	return backups, nil
+}
+
+// Find returns the newest backup in store taken at or before at, or the
+// newest of all when at is zero
+func Find(ctx context.Context, store Store, at time.Time) (Info, error) {
+	backups, err := List(ctx, store)
+	if err != nil {
+		return Info{}, err
+	}
+	for _, info := range backups {
+		if at.IsZero() || !info.TakenAt.After(at) {
+			return info, nil
+		}
+	}
+	if at.IsZero() {
+		return Info{}, ErrNoBackup
+	}
+	return Info{}, fmt.Errorf("%w at or before %s", ErrNoBackup, at.UTC().Format(time.RFC3339))
+}
+
+// Load reads a stored backup
+func Load(ctx context.Context, store Store, name string) (*snapshot.Snapshot, error) {
+	data, err := store.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	state, err := snapshot.Read(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("reading backup %s: %w", name, err)
+	}
+	return state, nil
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"slices"
+	"testing"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+)
+
+// newTestManager returns a manager of a file backend holding one node, whose
+// clock reads *now
+func newTestManager(t *testing.T, retention Retention, now *time.Time) (*Manager, *Dir) {
+	t.Helper()
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	node := `{"apiVersion":"boot.openchami.io/v1","kind":"Node","metadata":{"uid":"node-1","name":"x0c0s0b0n0"},` +
+		`"spec":{"xname":"x0c0s0b0n0","nid":1,"bootMac":"aa:bb:cc:dd:ee:01"}}`
+	if err := backend.Save(context.Background(), "Node", "node-1", []byte(node)); err != nil {
+		t.Fatalf("failed to save node: %v", err)
+	}
+	store, err := NewDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDir returned error: %v", err)
+	}
+	manager := NewManager(backend, store, retention, log.New(io.Discard, "", 0))
+	manager.now = func() time.Time { return *now }
+	return manager, store
+}
+
+func backupNames(backups []Info) []string {
+	names := make([]string, 0, len(backups))
+	for _, info := range backups {
+		names = append(names, info.Name)
+	}
+	return names
+}
+
+func TestManager_TakeAndPrune(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	manager, store := newTestManager(t, Retention{Count: 3, MaxAge: 150 * time.Minute}, &now)
+	if err := store.Put(ctx, "notes.txt", []byte("not a backup")); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+
+	for range 4 {
+		if _, err := manager.Take(ctx); err != nil {
+			t.Fatalf("Take returned error: %v", err)
+		}
+		now = now.Add(time.Hour)
+	}
+	backups, err := manager.List(ctx)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	want := []string{
+		"boot-service-20261017T030000Z.json",
+		"boot-service-20261017T020000Z.json",
+		"boot-service-20261017T010000Z.json",
+	}
+	if got := backupNames(backups); !slices.Equal(got, want) {
+		t.Errorf("backups after reaching the count = %v, want %v", got, want)
+	}
+
+	// Backups older than the maximum age are deleted
+	now = now.Add(time.Hour)
+	if _, err := manager.Take(ctx); err != nil {
+		t.Fatalf("Take returned error: %v", err)
+	}
+	backups, _ = manager.List(ctx)
+	want = []string{"boot-service-20261017T050000Z.json", "boot-service-20261017T030000Z.json"}
+	if got := backupNames(backups); !slices.Equal(got, want) {
+		t.Errorf("backups after expiry = %v, want %v", got, want)
+	}
+	if _, err := store.Get(ctx, "notes.txt"); err != nil {
+		t.Errorf("pruning removed a file that is not a backup: %v", err)
+	}
+}
+
+func TestFindAndLoad(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	manager, store := newTestManager(t, Retention{}, &now)
+	for range 3 {
+		if _, err := manager.Take(ctx); err != nil {
+			t.Fatalf("Take returned error: %v", err)
+		}
+		now = now.Add(24 * time.Hour)
+	}
+
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{time.Time{}, "boot-service-20261019T120000Z.json"},
+		{time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), "boot-service-20261018T120000Z.json"},
+		{time.Date(2026, 10, 18, 11, 59, 59, 0, time.UTC), "boot-service-20261017T120000Z.json"},
+	}
+	for _, tt := range tests {
+		info, err := Find(ctx, store, tt.at)
+		if err != nil {
+			t.Fatalf("Find(%s) returned error: %v", tt.at, err)
+		}
+		if info.Name != tt.want {
+			t.Errorf("Find(%s) = %s, want %s", tt.at, info.Name, tt.want)
+		}
+	}
+	if _, err := Find(ctx, store, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrNoBackup) {
+		t.Errorf("Find before the first backup returned %v, want ErrNoBackup", err)
+	}
+
+	state, err := Load(ctx, store, "boot-service-20261018T120000Z.json")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(state.Nodes) != 1 || state.Nodes[0].Spec.XName != "x0c0s0b0n0" {
+		t.Errorf("backup nodes = %+v, want x0c0s0b0n0", state.Nodes)
+	}
+	if want := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC); !state.ExportedAt.Equal(want) {
+		t.Errorf("backup exportedAt = %s, want %s", state.ExportedAt, want)
+	}
+}
+
+func TestParseName(t *testing.T) {
+	taken := time.Date(2026, 10, 17, 8, 30, 15, 0, time.UTC)
+	if got, ok := ParseName(Name(taken)); !ok || !got.Equal(taken) {
+		t.Errorf("ParseName(Name(%s)) = %s, %v", taken, got, ok)
+	}
+	for _, name := range []string{"boot-service-latest.json", "state.json", "boot-service-20261017T083015Z.yaml"} {
+		if _, ok := ParseName(name); ok {
+			t.Errorf("ParseName(%q) accepted a file that is not a backup", name)
+		}
+	}
+}
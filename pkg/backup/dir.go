@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Dir keeps backups as files in a directory, such as a mounted volume
+type Dir struct {
+	path string
+}
+
+var _ Store = (*Dir)(nil)
+
+// NewDir creates a directory store, creating the directory if needed
+func NewDir(path string) (*Dir, error) {
+	if err := os.MkdirAll(path, 0o700); err != nil {
+		return nil, fmt.Errorf("creating backup directory: %w", err)
+	}
+	return &Dir{path: path}, nil
+}
+
+// Put atomically writes a file
+func (d *Dir) Put(_ context.Context, name string, data []byte) error {
+	path, err := d.file(name)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(d.path, ".backup-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get reads a file
+func (d *Dir) Get(_ context.Context, name string) ([]byte, error) {
+	path, err := d.file(name)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+// List returns the names of the files in the directory
+func (d *Dir) List(_ context.Context) ([]string, error) {
+	entries, err := os.ReadDir(d.path)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// Delete removes a file
+func (d *Dir) Delete(_ context.Context, name string) error {
+	path, err := d.file(name)
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// file returns the path of a file, refusing names outside the directory
+func (d *Dir) file(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid backup name %q", name)
+	}
+	return filepath.Join(d.path, name), nil
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Path serves the backup listing
+const Path = "/admin/backups"
+
+// Handler serves the backup API
+type Handler struct {
+	manager *Manager
+}
+
+// NewHandler creates a backup API handler
+func NewHandler(manager *Manager) *Handler {
+	return &Handler{manager: manager}
+}
+
+// RegisterRoutes registers GET and POST /admin/backups
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route(Path, func(r chi.Router) {
+		r.Use(tenancy.Unscoped)
+		r.Get("/", h.ListBackups)
+		r.Post("/", h.TakeBackup)
+	})
+}
+
+// ListBackups handles GET /admin/backups, listing backups newest first
+func (h *Handler) ListBackups(w http.ResponseWriter, r *http.Request) {
+	backups, err := h.manager.List(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to list backups", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, backups)
+}
+
+// TakeBackup handles POST /admin/backups, which backs up now
+func (h *Handler) TakeBackup(w http.ResponseWriter, r *http.Request) {
+	info, err := h.manager.Take(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Backup failed", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusCreated, info)
+}
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openchami/boot-service/pkg/artifacts"
+)
+
+// maxBackupSize bounds the size of a backup read back from S3
+const maxBackupSize = 1 << 30
+
+// S3 keeps backups as objects under a prefix of an S3 bucket, with requests
+// signed by the artifact presigner
+type S3 struct {
+	bucket     string
+	prefix     string
+	presigner  *artifacts.S3Presigner
+	httpClient *http.Client
+}
+
+var _ Store = (*S3)(nil)
+
+// NewS3 creates a store for s3://bucket/prefix
+func NewS3(rawURL string, presigner *artifacts.S3Presigner) (*S3, error) {
+	location, ok := strings.CutPrefix(rawURL, "s3://")
+	bucket, prefix, _ := strings.Cut(location, "/")
+	if !ok || bucket == "" {
+		return nil, fmt.Errorf("invalid backup URL %q: expected s3://bucket/prefix", rawURL)
+	}
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return &S3{
+		bucket:     bucket,
+		prefix:     prefix,
+		presigner:  presigner,
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+// Put uploads an object
+func (s *S3) Put(ctx context.Context, name string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, s.prefix+name, nil, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	return checkS3Response(resp, http.StatusOK)
+}
+
+// Get downloads an object
+func (s *S3) Get(ctx context.Context, name string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, s.prefix+name, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if err := checkS3Response(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxBackupSize))
+}
+
+// List returns the names of the objects under the prefix
+func (s *S3) List(ctx context.Context) ([]string, error) {
+	var names []string
+	params := map[string]string{"list-type": "2", "prefix": s.prefix}
+	for {
+		resp, err := s.do(ctx, http.MethodGet, "", params, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			Contents []struct {
+				Key string `xml:"Key"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		err = checkS3Response(resp, http.StatusOK)
+		if err == nil {
+			err = xml.NewDecoder(io.LimitReader(resp.Body, 16<<20)).Decode(&result)
+		}
+		resp.Body.Close() //nolint:errcheck
+		if err != nil {
+			return nil, fmt.Errorf("listing s3://%s/%s: %w", s.bucket, s.prefix, err)
+		}
+		for _, object := range result.Contents {
+			name := strings.TrimPrefix(object.Key, s.prefix)
+			// Objects in "subdirectories" of the prefix are not backups
+			if name != "" && !strings.Contains(name, "/") {
+				names = append(names, name)
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return names, nil
+		}
+		params["continuation-token"] = result.NextContinuationToken
+	}
+}
+
+// Delete removes an object
+func (s *S3) Delete(ctx context.Context, name string) error {
+	resp, err := s.do(ctx, http.MethodDelete, s.prefix+name, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	return checkS3Response(resp, http.StatusNoContent, http.StatusOK)
+}
+
+func (s *S3) do(ctx context.Context, method, key string, params map[string]string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.presigner.Presign(method, s.bucket, key, params), body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 %s failed: %w", method, err)
+	}
+	return resp, nil
+}
+
+// checkS3Response returns an error, with S3's message, unless the response
+// has one of the expected statuses
+func checkS3Response(resp *http.Response, expected ...int) error {
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	var failure struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	_ = xml.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&failure)
+	if failure.Code != "" {
+		return fmt.Errorf("s3 %s %s: %s: %s", resp.Request.Method, resp.Status, failure.Code, failure.Message)
+	}
+	return fmt.Errorf("s3 %s: unexpected status %s", resp.Request.Method, resp.Status)
+}
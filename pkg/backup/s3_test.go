@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openchami/boot-service/pkg/artifacts"
+)
+
+// fakeS3 serves path-style object requests for one bucket from memory. It
+// lists one object per page to exercise continuation.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.URL.Query().Get("X-Amz-Signature") == "" {
+		http.Error(w, "unsigned", http.StatusForbidden)
+		return
+	}
+	key, isObject := strings.CutPrefix(r.URL.Path, "/backups/")
+	switch {
+	case r.Method == http.MethodPut && isObject:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[key] = data
+	case r.Method == http.MethodGet && isObject:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>missing</Message></Error>`)) //nolint:errcheck
+			return
+		}
+		w.Write(data) //nolint:errcheck
+	case r.Method == http.MethodDelete && isObject:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && r.URL.Path == "/backups":
+		var keys []string
+		for key := range f.objects {
+			if strings.HasPrefix(key, r.URL.Query().Get("prefix")) && key > r.URL.Query().Get("continuation-token") {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		type object struct {
+			Key string `xml:"Key"`
+		}
+		result := struct {
+			XMLName               xml.Name `xml:"ListBucketResult"`
+			Contents              []object `xml:"Contents"`
+			IsTruncated           bool     `xml:"IsTruncated"`
+			NextContinuationToken string   `xml:"NextContinuationToken,omitempty"`
+		}{}
+		if len(keys) > 0 {
+			result.Contents = []object{{Key: keys[0]}}
+			result.IsTruncated = len(keys) > 1
+			result.NextContinuationToken = keys[0]
+		}
+		xml.NewEncoder(w).Encode(result) //nolint:errcheck
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{"other/boot-service-20261017T000000Z.json": []byte("{}")}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	presigner, err := artifacts.NewS3Presigner(artifacts.S3Config{
+		Endpoint: server.URL, AccessKeyID: "key", SecretAccessKey: "secret", PathStyle: true, Expiry: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewS3Presigner returned error: %v", err)
+	}
+	store, err := NewS3("s3://backups/site-a", presigner)
+	if err != nil {
+		t.Fatalf("NewS3 returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, name := range []string{"a.json", "b.json", "c.json"} {
+		if err := store.Put(ctx, name, []byte(`{"name":"`+name+`"}`)); err != nil {
+			t.Fatalf("Put(%s) returned error: %v", name, err)
+		}
+	}
+	if _, ok := fake.objects["site-a/b.json"]; !ok {
+		t.Errorf("objects = %v, want site-a/b.json", fake.objects)
+	}
+
+	names, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if want := []string{"a.json", "b.json", "c.json"}; !slices.Equal(names, want) {
+		t.Errorf("List = %v, want %v", names, want)
+	}
+
+	data, err := store.Get(ctx, "b.json")
+	if err != nil || string(data) != `{"name":"b.json"}` {
+		t.Errorf("Get = %s, %v", data, err)
+	}
+	if err := store.Delete(ctx, "b.json"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if _, err := store.Get(ctx, "b.json"); err == nil || !strings.Contains(err.Error(), "NoSuchKey") {
+		t.Errorf("Get of a deleted object returned %v, want NoSuchKey", err)
+	}
+
+	if _, err := NewS3("https://backups/site-a", presigner); err == nil {
+		t.Error("NewS3 accepted a URL that is not s3://")
+	}
+}
@@ -0,0 +1,476 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package bmcdiscovery finds BMCs by scanning networks for Redfish services.
+// Each address of the configured ranges is probed for a Redfish service
+// root; a BMC resource is created for every service found, or the one with
+// its MAC or address is updated, with the manufacturer, model, and firmware
+// the service reports. Discovered BMCs are correlated with the nodes they
+// manage by xname, by the MACs of their systems' interfaces, or by MAC
+// adjacency, and a BMC without an xname takes the one its nodes share.
+package bmcdiscovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/fabrica"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/clients/redfish"
+)
+
+// EndpointAnnotation records the Redfish service root a BMC was discovered at
+const EndpointAnnotation = "boot.openchami.io/redfish-endpoint"
+
+// PhaseDiscovered is the status phase of a BMC a scan found
+const PhaseDiscovered = "Discovered"
+
+// MaxPrefixAddresses bounds the addresses of one scanned range, a /16 of
+// IPv4
+const MaxPrefixAddresses = 1 << 16
+
+// ErrScanRunning is returned when a scan is requested while one runs
+var ErrScanRunning = errors.New("a BMC discovery scan is already running")
+
+// Store lists and writes BMCs and lists nodes. client.Client and
+// client.InProcessClient implement it.
+type Store interface {
+	client.BMCAPI
+	GetNodes(ctx context.Context) ([]v1.Node, error)
+}
+
+// Config configures scans
+type Config struct {
+	Prefixes    []netip.Prefix // ranges scanned on the interval
+	Interval    time.Duration
+	Port        int // of the Redfish services, 443 when zero
+	Username    string
+	Password    string
+	Insecure    bool          // skip TLS certificate verification
+	Concurrency int           // addresses probed at once
+	Timeout     time.Duration // for probing one address
+	// MACDistance is how far apart, within a vendor prefix, a BMC MAC and
+	// a node MAC may be for the BMC to be correlated with the node when
+	// nothing better matches. Zero disables MAC adjacency.
+	MACDistance int
+}
+
+// Progress reports the running or last scan
+type Progress struct {
+	Running    bool       `json:"running"`
+	Interval   string     `json:"interval,omitempty"`
+	NextRun    time.Time  `json:"nextRun,omitzero"` // of the scheduled scan
+	Runs       int        `json:"runs"`             // scans since startup
+	StartedAt  time.Time  `json:"startedAt,omitzero"`
+	Duration   string     `json:"duration,omitempty"`
+	Ranges     []string   `json:"ranges,omitempty"`
+	Addresses  int        `json:"addresses"` // to probe
+	Probed     int        `json:"probed"`
+	Found      int        `json:"found"` // Redfish services
+	Created    int        `json:"created"`
+	Updated    int        `json:"updated"`
+	Unchanged  int        `json:"unchanged"`
+	Failed     int        `json:"failed"`     // BMCs that could not be written
+	Correlated int        `json:"correlated"` // BMCs correlated with nodes
+	Endpoints  []Endpoint `json:"endpoints,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// Scanner scans for BMCs
+type Scanner struct {
+	store  Store
+	http   *http.Client
+	config Config
+	logger *log.Logger
+
+	// scanMu is held while a scan runs; mu guards progress and nextRun
+	scanMu   sync.Mutex
+	mu       sync.Mutex
+	progress Progress
+	nextRun  time.Time
+}
+
+// NewScanner creates a scanner. A nil httpClient uses one verifying
+// certificates unless config.Insecure is set.
+func NewScanner(store Store, httpClient *http.Client, config Config, logger *log.Logger) *Scanner {
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	if config.Port == 0 {
+		config.Port = 443
+	}
+	if config.Concurrency < 1 {
+		config.Concurrency = 1
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+	if httpClient == nil {
+		httpClient = redfish.NewHTTPClient(config.Insecure)
+	}
+	return &Scanner{store: store, http: httpClient, config: config, logger: logger}
+}
+
+// Start scans the configured ranges now and then every interval until ctx
+// is done. A scheduled scan is skipped while a requested one runs.
+func (s *Scanner) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+	for {
+		s.mu.Lock()
+		s.nextRun = time.Now().Add(s.config.Interval)
+		s.mu.Unlock()
+		if _, err := s.Scan(ctx, s.config.Prefixes); err != nil && !errors.Is(err, ErrScanRunning) {
+			s.logger.Printf("BMC discovery scan failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			s.mu.Lock()
+			s.nextRun = time.Time{}
+			s.mu.Unlock()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prefixes returns the ranges scanned on the interval
+func (s *Scanner) Prefixes() []netip.Prefix {
+	return s.config.Prefixes
+}
+
+// Progress returns the progress of the running scan, or the outcome of the
+// last one
+func (s *Scanner) Progress() Progress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	progress := s.progress
+	progress.Ranges = slices.Clone(progress.Ranges)
+	progress.Endpoints = slices.Clone(progress.Endpoints)
+	if s.config.Interval > 0 {
+		progress.Interval = s.config.Interval.String()
+	}
+	progress.NextRun = s.nextRun
+	return progress
+}
+
+// StartScan scans prefixes in the background until done or ctx is done. It
+// returns ErrScanRunning instead when a scan is running.
+func (s *Scanner) StartScan(ctx context.Context, prefixes []netip.Prefix) error {
+	if !s.scanMu.TryLock() {
+		return ErrScanRunning
+	}
+	s.begin(prefixes)
+	go func() {
+		defer s.scanMu.Unlock()
+		if _, err := s.scan(ctx, prefixes); err != nil {
+			s.logger.Printf("BMC discovery scan failed: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Scan scans prefixes and returns the outcome. It returns ErrScanRunning
+// instead when a scan is running.
+func (s *Scanner) Scan(ctx context.Context, prefixes []netip.Prefix) (Progress, error) {
+	if !s.scanMu.TryLock() {
+		return s.Progress(), ErrScanRunning
+	}
+	defer s.scanMu.Unlock()
+	s.begin(prefixes)
+	return s.scan(ctx, prefixes)
+}
+
+// begin resets the progress for a scan of prefixes
+func (s *Scanner) begin(prefixes []netip.Prefix) {
+	ranges := make([]string, 0, len(prefixes))
+	addresses := 0
+	for _, prefix := range prefixes {
+		ranges = append(ranges, prefix.String())
+		addresses += len(Addresses(prefix))
+	}
+	s.mu.Lock()
+	s.progress = Progress{
+		Running:   true,
+		Runs:      s.progress.Runs + 1,
+		StartedAt: time.Now(),
+		Ranges:    ranges,
+		Addresses: addresses,
+	}
+	s.mu.Unlock()
+}
+
+// scan runs one scan, recording its progress as it goes
+func (s *Scanner) scan(ctx context.Context, prefixes []netip.Prefix) (Progress, error) {
+	endpoints := s.probeAll(ctx, prefixes)
+	err := ctx.Err()
+	if err == nil {
+		err = s.reconcile(ctx, endpoints)
+	}
+
+	s.mu.Lock()
+	s.progress.Running = false
+	s.progress.Duration = time.Since(s.progress.StartedAt).Round(time.Millisecond).String()
+	if err != nil {
+		s.progress.Error = err.Error()
+	}
+	s.mu.Unlock()
+	progress := s.Progress()
+	s.logger.Printf("BMC discovery scan complete: %d addresses probed, %d Redfish services, %d BMCs created, %d updated, %d unchanged, %d failed, %d correlated with nodes",
+		progress.Probed, progress.Found, progress.Created, progress.Updated, progress.Unchanged, progress.Failed, progress.Correlated)
+	return progress, err
+}
+
+// probeAll probes every address of prefixes, Concurrency at a time, and
+// returns the Redfish services found in address order
+func (s *Scanner) probeAll(ctx context.Context, prefixes []netip.Prefix) []Endpoint {
+	addresses := make(chan netip.Addr)
+	found := map[netip.Addr]Endpoint{}
+	var wg sync.WaitGroup
+	for range s.config.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for addr := range addresses {
+				endpoint := s.probe(ctx, addr.String())
+				s.mu.Lock()
+				s.progress.Probed++
+				if endpoint != nil {
+					s.progress.Found++
+					found[addr] = *endpoint
+				}
+				s.mu.Unlock()
+			}
+		}()
+	}
+feed:
+	for _, prefix := range prefixes {
+		for _, addr := range Addresses(prefix) {
+			select {
+			case addresses <- addr:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+	}
+	close(addresses)
+	wg.Wait()
+
+	endpoints := make([]Endpoint, 0, len(found))
+	for _, addr := range slices.SortedFunc(maps.Keys(found), netip.Addr.Compare) {
+		endpoints = append(endpoints, found[addr])
+	}
+	return endpoints
+}
+
+// reconcile creates or updates a BMC for each endpoint
+func (s *Scanner) reconcile(ctx context.Context, endpoints []Endpoint) error {
+	bmcs, err := s.store.GetBMCs(ctx)
+	if err != nil {
+		return fmt.Errorf("listing BMCs: %w", err)
+	}
+	nodes, err := s.store.GetNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("listing nodes: %w", err)
+	}
+	// Nodes without an xname, such as ones discovered on first boot, are
+	// not correlated
+	nodes = slices.DeleteFunc(nodes, func(node v1.Node) bool { return node.Spec.XName == "" })
+	for _, endpoint := range endpoints {
+		outcome, correlated := s.reconcileEndpoint(ctx, endpoint, bmcs, nodes)
+		s.mu.Lock()
+		s.progress.Endpoints = append(s.progress.Endpoints, endpoint)
+		switch outcome {
+		case outcomeCreated:
+			s.progress.Created++
+		case outcomeUpdated:
+			s.progress.Updated++
+		case outcomeUnchanged:
+			s.progress.Unchanged++
+		default:
+			s.progress.Failed++
+		}
+		if correlated {
+			s.progress.Correlated++
+		}
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// outcome is what reconciling an endpoint did
+type outcome int
+
+const (
+	outcomeFailed outcome = iota
+	outcomeCreated
+	outcomeUpdated
+	outcomeUnchanged
+)
+
+// reconcileEndpoint writes the BMC of endpoint, reporting what it did and
+// whether the BMC was correlated with nodes
+func (s *Scanner) reconcileEndpoint(ctx context.Context, endpoint Endpoint, bmcs []v1.BMC, nodes []v1.Node) (outcome, bool) {
+	existing := findBMC(bmcs, endpoint)
+	var spec v1.BMCSpec
+	if existing != nil {
+		spec = existing.Spec
+	}
+	spec.Interface.IP = endpoint.Address
+	if endpoint.MAC != "" {
+		spec.Interface.MAC = endpoint.MAC
+	}
+	correlated, xname := correlate(spec, endpoint, nodes, s.config.MACDistance)
+	if spec.XName == "" {
+		spec.XName = xname
+	}
+
+	wantStatus := v1.BMCStatus{
+		Phase:           PhaseDiscovered,
+		Message:         endpoint.Error,
+		Ready:           true,
+		Manufacturer:    endpoint.Manufacturer,
+		Model:           endpoint.Model,
+		FirmwareVersion: endpoint.FirmwareVersion,
+		Nodes:           correlated,
+	}
+	annotations := map[string]string{EndpointAnnotation: endpoint.URL}
+
+	result := outcomeUnchanged
+	var bmc *v1.BMC
+	var err error
+	switch {
+	case existing == nil:
+		bmc, err = s.store.CreateBMC(ctx, client.CreateBMCRequest{
+			Metadata:    fabrica.Metadata{Name: bmcName(spec, endpoint)},
+			Spec:        spec,
+			Annotations: annotations,
+		})
+		if err != nil {
+			s.logger.Printf("Warning: failed to create BMC at %s: %v", endpoint.Address, err)
+			return outcomeFailed, len(correlated) > 0
+		}
+		s.logger.Printf("Discovered BMC %s at %s", bmc.Metadata.Name, endpoint.Address)
+		result = outcomeCreated
+	case !equalSpec(spec, existing.Spec) || existing.Metadata.Annotations[EndpointAnnotation] != endpoint.URL:
+		bmc, err = s.store.UpdateBMC(ctx, existing.Metadata.UID, client.UpdateBMCRequest{
+			Spec:        spec,
+			Annotations: annotations,
+		})
+		if err != nil {
+			s.logger.Printf("Warning: failed to update BMC %s: %v", existing.Metadata.Name, err)
+			return outcomeFailed, len(correlated) > 0
+		}
+		result = outcomeUpdated
+	default:
+		bmc = existing
+	}
+
+	// Power state and health are kept current by telemetry polls
+	wantStatus.PowerState = bmc.Status.PowerState
+	wantStatus.Health = bmc.Status.Health
+	if !equalStatus(wantStatus, bmc.Status) {
+		if _, err := s.store.UpdateBMCStatus(ctx, bmc.Metadata.UID, wantStatus); err != nil {
+			s.logger.Printf("Warning: failed to update status of BMC %s: %v", bmc.Metadata.Name, err)
+			return outcomeFailed, len(correlated) > 0
+		}
+		if result == outcomeUnchanged {
+			result = outcomeUpdated
+		}
+	}
+	return result, len(correlated) > 0
+}
+
+// findBMC returns the BMC with the MAC of endpoint, or else its address
+func findBMC(bmcs []v1.BMC, endpoint Endpoint) *v1.BMC {
+	if endpoint.MAC != "" {
+		for i := range bmcs {
+			if normalizeMAC(bmcs[i].Spec.Interface.MAC) == endpoint.MAC {
+				return &bmcs[i]
+			}
+		}
+	}
+	for i := range bmcs {
+		if bmcs[i].Spec.Interface.IP == endpoint.Address {
+			return &bmcs[i]
+		}
+	}
+	return nil
+}
+
+// bmcName names a discovered BMC by its xname, MAC, or address
+func bmcName(spec v1.BMCSpec, endpoint Endpoint) string {
+	switch {
+	case spec.XName != "":
+		return spec.XName
+	case endpoint.MAC != "":
+		return "bmc-" + strings.ReplaceAll(endpoint.MAC, ":", "")
+	}
+	return "bmc-" + strings.NewReplacer(".", "-", ":", "-").Replace(endpoint.Address)
+}
+
+func equalSpec(a, b v1.BMCSpec) bool {
+	return a.XName == b.XName && a.Description == b.Description && a.Interface == b.Interface
+}
+
+func equalStatus(a, b v1.BMCStatus) bool {
+	return a.Phase == b.Phase && a.Message == b.Message && a.Ready == b.Ready &&
+		a.Manufacturer == b.Manufacturer && a.Model == b.Model && a.FirmwareVersion == b.FirmwareVersion &&
+		a.PowerState == b.PowerState && a.Health == b.Health && slices.Equal(a.Nodes, b.Nodes)
+}
+
+// Addresses returns the host addresses of prefix, at most
+// MaxPrefixAddresses: every address but the network and broadcast
+// addresses of IPv4 ranges larger than a /31
+func Addresses(prefix netip.Prefix) []netip.Addr {
+	prefix = prefix.Masked()
+	var addresses []netip.Addr
+	for addr := prefix.Addr(); prefix.Contains(addr); addr = addr.Next() {
+		addresses = append(addresses, addr)
+		if len(addresses) == MaxPrefixAddresses || !addr.Next().IsValid() {
+			break
+		}
+	}
+	if prefix.Addr().Is4() && prefix.Bits() < 31 && len(addresses) > 2 {
+		addresses = addresses[1 : len(addresses)-1]
+	}
+	return addresses
+}
+
+// ParsePrefixes parses comma-separated CIDRs or addresses, each of at most
+// MaxPrefixAddresses addresses
+func ParsePrefixes(value string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(field)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(field)
+			if addrErr != nil {
+				return nil, fmt.Errorf("%q is not a CIDR or address", field)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		if prefix.Addr().BitLen()-prefix.Bits() > 16 {
+			return nil, fmt.Errorf("%s has more than %d addresses", prefix, MaxPrefixAddresses)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
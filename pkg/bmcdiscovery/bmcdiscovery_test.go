@@ -0,0 +1,311 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bmcdiscovery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/clients/redfish"
+)
+
+// fakeStore keeps BMCs and nodes in memory
+type fakeStore struct {
+	mu     sync.Mutex
+	bmcs   []v1.BMC
+	nodes  []v1.Node
+	writes int
+}
+
+func (f *fakeStore) GetBMCs(context.Context) ([]v1.BMC, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.bmcs), nil
+}
+
+func (f *fakeStore) CreateBMC(_ context.Context, req client.CreateBMCRequest) (*v1.BMC, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.writes++
+	bmc := v1.BMC{Spec: req.Spec}
+	bmc.Metadata.UID = "bmc-" + strconv.Itoa(len(f.bmcs))
+	bmc.Metadata.Name = req.Metadata.Name
+	bmc.Metadata.Annotations = req.Annotations
+	f.bmcs = append(f.bmcs, bmc)
+	return &bmc, nil
+}
+
+func (f *fakeStore) UpdateBMC(_ context.Context, uid string, req client.UpdateBMCRequest) (*v1.BMC, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.writes++
+	for i := range f.bmcs {
+		if f.bmcs[i].Metadata.UID == uid {
+			f.bmcs[i].Spec = req.Spec
+			if f.bmcs[i].Metadata.Annotations == nil {
+				f.bmcs[i].Metadata.Annotations = map[string]string{}
+			}
+			for k, v := range req.Annotations {
+				f.bmcs[i].Metadata.Annotations[k] = v
+			}
+			bmc := f.bmcs[i]
+			return &bmc, nil
+		}
+	}
+	return nil, client.ErrNotFound
+}
+
+func (f *fakeStore) UpdateBMCStatus(_ context.Context, uid string, status v1.BMCStatus) (*v1.BMC, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.writes++
+	for i := range f.bmcs {
+		if f.bmcs[i].Metadata.UID == uid {
+			f.bmcs[i].Status = status
+			bmc := f.bmcs[i]
+			return &bmc, nil
+		}
+	}
+	return nil, client.ErrNotFound
+}
+
+func (f *fakeStore) GetNodes(context.Context) ([]v1.Node, error) {
+	return slices.Clone(f.nodes), nil
+}
+
+// redfishServer serves a Redfish service with one manager and one system,
+// requiring basic auth beyond the service root
+func redfishServer(t *testing.T, bmcMAC, hostMAC string) *httptest.Server {
+	t.Helper()
+	docs := map[string]any{
+		"/redfish/v1/": map[string]any{
+			"RedfishVersion": "1.11.0", "Vendor": "Acme", "Product": "Acme BMC",
+			"Managers": map[string]string{"@odata.id": "/redfish/v1/Managers"},
+			"Systems":  map[string]string{"@odata.id": "/redfish/v1/Systems"},
+		},
+		"/redfish/v1/Managers": map[string]any{"Members": []map[string]string{{"@odata.id": "/redfish/v1/Managers/1"}}},
+		"/redfish/v1/Managers/1": map[string]any{
+			"Model": "AC-9000", "FirmwareVersion": "2.4.1",
+			"EthernetInterfaces": map[string]string{"@odata.id": "/redfish/v1/Managers/1/EthernetInterfaces"},
+		},
+		"/redfish/v1/Managers/1/EthernetInterfaces":   map[string]any{"Members": []map[string]string{{"@odata.id": "/redfish/v1/Managers/1/EthernetInterfaces/1"}}},
+		"/redfish/v1/Managers/1/EthernetInterfaces/1": map[string]any{"MACAddress": bmcMAC},
+		"/redfish/v1/Systems":                         map[string]any{"Members": []map[string]string{{"@odata.id": "/redfish/v1/Systems/1"}}},
+		"/redfish/v1/Systems/1": map[string]any{
+			"EthernetInterfaces": map[string]string{"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces"},
+		},
+		"/redfish/v1/Systems/1/EthernetInterfaces":   map[string]any{"Members": []map[string]string{{"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/1"}}},
+		"/redfish/v1/Systems/1/EthernetInterfaces/1": map[string]any{"MACAddress": hostMAC},
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		doc, ok := docs[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if user, pass, _ := r.BasicAuth(); r.URL.Path != redfish.ServiceRootPath && (user != "admin" || pass != "secret") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(doc) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestScanner scans the address of server
+func newTestScanner(t *testing.T, server *httptest.Server, store *fakeStore, username string) (*Scanner, []netip.Prefix) {
+	t.Helper()
+	u, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(u.Port())
+	prefixes, err := ParsePrefixes(u.Hostname())
+	if err != nil {
+		t.Fatalf("ParsePrefixes failed: %v", err)
+	}
+	return NewScanner(store, server.Client(), Config{
+		Prefixes: prefixes, Interval: time.Hour, Port: port, Username: username, Password: "secret",
+		Concurrency: 4, Timeout: 5 * time.Second, MACDistance: 8,
+	}, nil), prefixes
+}
+
+func TestScan_CreatesAndCorrelatesBMCs(t *testing.T) {
+	server := redfishServer(t, "AA:BB:CC:00:00:10", "aa:bb:cc:00:00:01")
+	store := &fakeStore{nodes: []v1.Node{
+		{Spec: v1.NodeSpec{XName: "x1000c0s0b0n0", BootMAC: "aa:bb:cc:00:00:01"}},
+		{Spec: v1.NodeSpec{XName: "x1000c0s1b0n0", BootMAC: "aa:bb:cc:00:00:02"}},
+		{Spec: v1.NodeSpec{BootMAC: "aa:bb:cc:00:00:03"}},
+	}}
+	scanner, prefixes := newTestScanner(t, server, store, "admin")
+
+	progress, err := scanner.Scan(context.Background(), prefixes)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if progress.Running || progress.Runs != 1 || progress.Probed != 1 || progress.Found != 1 || progress.Created != 1 || progress.Correlated != 1 {
+		t.Fatalf("unexpected progress: %+v", progress)
+	}
+	if len(store.bmcs) != 1 {
+		t.Fatalf("expected one BMC, got %+v", store.bmcs)
+	}
+	bmc := store.bmcs[0]
+	if bmc.Metadata.Name != "x1000c0s0b0" || bmc.Spec.XName != "x1000c0s0b0" || bmc.Spec.Interface.MAC != "aa:bb:cc:00:00:10" ||
+		bmc.Spec.Interface.IP != "127.0.0.1" || bmc.Metadata.Annotations[EndpointAnnotation] != server.URL+redfish.ServiceRootPath {
+		t.Errorf("unexpected BMC: %+v", bmc)
+	}
+	want := v1.BMCStatus{Phase: PhaseDiscovered, Ready: true, Manufacturer: "Acme", Model: "AC-9000", FirmwareVersion: "2.4.1", Nodes: []string{"x1000c0s0b0n0"}}
+	if !equalStatus(bmc.Status, want) {
+		t.Errorf("status = %+v, want %+v", bmc.Status, want)
+	}
+
+	// A second scan finds nothing to change
+	writes := store.writes
+	progress, err = scanner.Scan(context.Background(), prefixes)
+	if err != nil || progress.Unchanged != 1 || progress.Runs != 2 || store.writes != writes {
+		t.Errorf("second scan = %+v, %v with %d writes", progress, err, store.writes-writes)
+	}
+}
+
+func TestScan_WithoutCredentials(t *testing.T) {
+	server := redfishServer(t, "aa:bb:cc:00:00:10", "aa:bb:cc:00:00:01")
+	store := &fakeStore{bmcs: []v1.BMC{{Spec: v1.BMCSpec{XName: "x1000c0s5b0", Interface: v1.BMCInterface{IP: "127.0.0.1"}}}}}
+	store.bmcs[0].Metadata.UID = "bmc-existing"
+	scanner, prefixes := newTestScanner(t, server, store, "")
+
+	progress, err := scanner.Scan(context.Background(), prefixes)
+	if err != nil || progress.Updated != 1 || progress.Created != 0 {
+		t.Fatalf("Scan() = %+v, %v", progress, err)
+	}
+	bmc := store.bmcs[0]
+	if bmc.Spec.XName != "x1000c0s5b0" || bmc.Status.Model != "Acme BMC" || !strings.Contains(bmc.Status.Message, "401") {
+		t.Errorf("unexpected BMC: %+v", bmc)
+	}
+}
+
+func TestCorrelate(t *testing.T) {
+	nodes := []v1.Node{
+		{Spec: v1.NodeSpec{XName: "x1000c0s0b0n0", BootMAC: "aa:bb:cc:00:00:01"}},
+		{Spec: v1.NodeSpec{XName: "x1000c0s0b0n1", Interfaces: []v1.NodeInterface{{MAC: "aa:bb:cc:00:00:02"}}}},
+		{Spec: v1.NodeSpec{XName: "x1000c0s1b0n0", BootMAC: "aa:bb:cc:00:00:20"}},
+	}
+	tests := []struct {
+		name      string
+		spec      v1.BMCSpec
+		endpoint  Endpoint
+		distance  int
+		wantNodes []string
+		wantXName string
+	}{
+		{"by xname", v1.BMCSpec{XName: "x1000c0s0b0"}, Endpoint{}, 0, []string{"x1000c0s0b0n0", "x1000c0s0b0n1"}, "x1000c0s0b0"},
+		{"by host MAC", v1.BMCSpec{}, Endpoint{HostMACs: []string{"aa:bb:cc:00:00:20"}}, 0, []string{"x1000c0s1b0n0"}, "x1000c0s1b0"},
+		{"by nearest MAC", v1.BMCSpec{}, Endpoint{MAC: "aa:bb:cc:00:00:1e"}, 8, []string{"x1000c0s1b0n0"}, "x1000c0s1b0"},
+		{"too far", v1.BMCSpec{}, Endpoint{MAC: "aa:bb:cc:00:00:10"}, 8, nil, ""},
+		{"other vendor", v1.BMCSpec{}, Endpoint{MAC: "aa:bb:cd:00:00:01"}, 8, nil, ""},
+		{"adjacency disabled", v1.BMCSpec{}, Endpoint{MAC: "aa:bb:cc:00:00:1e"}, 0, nil, ""},
+		{"ambiguous BMC", v1.BMCSpec{}, Endpoint{HostMACs: []string{"aa:bb:cc:00:00:01", "aa:bb:cc:00:00:20"}}, 0,
+			[]string{"x1000c0s0b0n0", "x1000c0s1b0n0"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, xname := correlate(tt.spec, tt.endpoint, nodes, tt.distance)
+			if !slices.Equal(nodes, tt.wantNodes) || xname != tt.wantXName {
+				t.Errorf("correlate() = %v, %q, want %v, %q", nodes, xname, tt.wantNodes, tt.wantXName)
+			}
+		})
+	}
+}
+
+func TestManagesAndBaseURL(t *testing.T) {
+	bmc := &v1.BMC{
+		Spec:   v1.BMCSpec{XName: "x1000c0s0b0", Interface: v1.BMCInterface{IP: "10.254.0.5"}},
+		Status: v1.BMCStatus{Nodes: []string{"x9000c0s0b0n0"}},
+	}
+	for node, want := range map[string]bool{"x1000c0s0b0n1": true, "x9000c0s0b0n0": true, "x1000c0s1b0n0": false, "": false} {
+		if got := Manages(bmc, node); got != want {
+			t.Errorf("Manages(%q) = %v, want %v", node, got, want)
+		}
+	}
+
+	if got := BaseURL(bmc, 8443); got != "https://10.254.0.5:8443" {
+		t.Errorf("BaseURL() = %q", got)
+	}
+	bmc.Metadata.Annotations = map[string]string{EndpointAnnotation: "https://bmc.example" + redfish.ServiceRootPath}
+	if got := BaseURL(bmc, 8443); got != "https://bmc.example" {
+		t.Errorf("BaseURL() with endpoint = %q", got)
+	}
+}
+
+func TestParsePrefixes(t *testing.T) {
+	prefixes, err := ParsePrefixes("10.0.0.0/30, 10.1.0.5,fd00::/126")
+	if err != nil {
+		t.Fatalf("ParsePrefixes failed: %v", err)
+	}
+	var addresses []string
+	for _, prefix := range prefixes {
+		for _, addr := range Addresses(prefix) {
+			addresses = append(addresses, addr.String())
+		}
+	}
+	want := []string{"10.0.0.1", "10.0.0.2", "10.1.0.5", "fd00::", "fd00::1", "fd00::2", "fd00::3"}
+	if !slices.Equal(addresses, want) {
+		t.Errorf("addresses = %v, want %v", addresses, want)
+	}
+
+	for _, bad := range []string{"10.0.0.0/8", "bmc-net", "10.0.0.0/33"} {
+		if _, err := ParsePrefixes(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	server := redfishServer(t, "aa:bb:cc:00:00:10", "aa:bb:cc:00:00:01")
+	store := &fakeStore{}
+	scanner, _ := newTestScanner(t, server, store, "admin")
+	r := chi.NewRouter()
+	NewHandler(scanner).RegisterRoutes(r)
+
+	req := httptest.NewRequest(http.MethodPost, Path+"/scan", strings.NewReader(`{"ranges":["10.0.0.0/8"]}`))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a range too large, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, Path+"/scan", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body)
+	}
+
+	var progress Progress
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path+"/status", nil))
+		if err := json.Unmarshal(w.Body.Bytes(), &progress); err != nil {
+			t.Fatalf("invalid status: %v", err)
+		}
+		if !progress.Running {
+			break
+		}
+	}
+	if progress.Running || progress.Created != 1 || progress.Interval != "1h0m0s" || len(progress.Endpoints) != 1 {
+		t.Errorf("unexpected progress: %+v", progress)
+	}
+}
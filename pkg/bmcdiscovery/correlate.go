@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bmcdiscovery
+
+import (
+	"net"
+	"slices"
+	"strings"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// nodeBMC returns the xname of the BMC of the node xname, or ""
+func nodeBMC(xname string) string {
+	return validation.NodeBMC(strings.ToLower(xname))
+}
+
+// Manages reports whether bmc manages node, by xname: discovery correlated
+// them, or the node's xname is beneath the BMC's
+func Manages(bmc *v1.BMC, node string) bool {
+	if node == "" {
+		return false
+	}
+	if slices.Contains(bmc.Status.Nodes, node) {
+		return true
+	}
+	return bmc.Spec.XName != "" && nodeBMC(node) == strings.ToLower(bmc.Spec.XName)
+}
+
+// correlate returns the sorted xnames of the nodes, which all have one, a BMC with spec, found at
+// endpoint, manages, and the BMC xname they share, if one. The first rule
+// matching any node decides:
+//
+//   - nodes whose xname is beneath the BMC's xname
+//   - nodes with a MAC of the interfaces of the BMC's systems
+//   - nodes with the MACs nearest the BMC's MAC, within distance and sharing
+//     its vendor prefix
+func correlate(spec v1.BMCSpec, endpoint Endpoint, nodes []v1.Node, distance int) ([]string, string) {
+	var matched []string
+	if spec.XName != "" {
+		for _, node := range nodes {
+			if nodeBMC(node.Spec.XName) == strings.ToLower(spec.XName) {
+				matched = append(matched, node.Spec.XName)
+			}
+		}
+	}
+	if len(matched) == 0 && len(endpoint.HostMACs) > 0 {
+		for _, node := range nodes {
+			if slices.ContainsFunc(nodeMACs(node), func(mac string) bool { return slices.Contains(endpoint.HostMACs, mac) }) {
+				matched = append(matched, node.Spec.XName)
+			}
+		}
+	}
+	if len(matched) == 0 && endpoint.MAC != "" && distance > 0 {
+		matched = adjacentNodes(endpoint.MAC, nodes, distance)
+	}
+	if len(matched) == 0 {
+		return nil, ""
+	}
+	slices.Sort(matched)
+	matched = slices.Compact(matched)
+
+	shared := nodeBMC(matched[0])
+	for _, xname := range matched[1:] {
+		if nodeBMC(xname) != shared {
+			return matched, ""
+		}
+	}
+	return matched, shared
+}
+
+// adjacentNodes returns the xnames of the nodes with a MAC nearest mac,
+// within distance and sharing its vendor prefix
+func adjacentNodes(mac string, nodes []v1.Node, distance int) []string {
+	bmc, ok := macValue(mac)
+	if !ok {
+		return nil
+	}
+	nearest := distance + 1
+	var matched []string
+	for _, node := range nodes {
+		for _, nodeMAC := range nodeMACs(node) {
+			value, ok := macValue(nodeMAC)
+			if !ok || value>>24 != bmc>>24 || value == bmc {
+				continue
+			}
+			d := int(max(value, bmc) - min(value, bmc))
+			switch {
+			case d < nearest:
+				nearest = d
+				matched = []string{node.Spec.XName}
+			case d == nearest:
+				matched = append(matched, node.Spec.XName)
+			}
+		}
+	}
+	return matched
+}
+
+// nodeMACs returns the normalized MACs of node
+func nodeMACs(node v1.Node) []string {
+	var macs []string
+	if mac := normalizeMAC(node.Spec.BootMAC); mac != "" {
+		macs = append(macs, mac)
+	}
+	for _, iface := range node.Spec.Interfaces {
+		if mac := normalizeMAC(iface.MAC); mac != "" {
+			macs = append(macs, mac)
+		}
+	}
+	return macs
+}
+
+// macValue returns a 48-bit MAC as a number
+func macValue(mac string) (uint64, bool) {
+	hw, err := net.ParseMAC(mac)
+	if err != nil || len(hw) != 6 {
+		return 0, false
+	}
+	var value uint64
+	for _, b := range hw {
+		value = value<<8 | uint64(b)
+	}
+	return value, true
+}
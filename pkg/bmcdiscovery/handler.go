@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bmcdiscovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Path serves the BMC discovery API
+const Path = "/admin/bmc-discovery"
+
+// Handler serves the BMC discovery API
+type Handler struct {
+	scanner *Scanner
+}
+
+// NewHandler creates a BMC discovery API handler
+func NewHandler(scanner *Scanner) *Handler {
+	return &Handler{scanner: scanner}
+}
+
+// RegisterRoutes registers GET /admin/bmc-discovery/status and POST
+// /admin/bmc-discovery/scan
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route(Path, func(r chi.Router) {
+		r.Use(tenancy.Unscoped)
+		r.Get("/status", h.GetStatus)
+		r.Post("/scan", h.Scan)
+	})
+}
+
+// ScanRequest is the optional body of POST /admin/bmc-discovery/scan
+type ScanRequest struct {
+	// Ranges are CIDRs or addresses scanned instead of the configured ones
+	Ranges []string `json:"ranges,omitempty"`
+}
+
+// GetStatus handles GET /admin/bmc-discovery/status
+func (h *Handler) GetStatus(w http.ResponseWriter, _ *http.Request) {
+	httputil.WriteJSON(w, http.StatusOK, h.scanner.Progress())
+}
+
+// Scan handles POST /admin/bmc-discovery/scan, which starts a scan now
+// instead of waiting for the interval. Its progress is reported at
+// /admin/bmc-discovery/status.
+func (h *Handler) Scan(w http.ResponseWriter, r *http.Request) {
+	var req ScanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+	prefixes := h.scanner.Prefixes()
+	if len(req.Ranges) > 0 {
+		var err error
+		if prefixes, err = ParsePrefixes(strings.Join(req.Ranges, ",")); err != nil {
+			httputil.WriteError(w, http.StatusBadRequest, "Invalid range", err.Error())
+			return
+		}
+	}
+	if len(prefixes) == 0 {
+		httputil.WriteError(w, http.StatusBadRequest, "No ranges", "No ranges are configured or given to scan")
+		return
+	}
+
+	// The scan outlives the request
+	if err := h.scanner.StartScan(context.WithoutCancel(r.Context()), prefixes); err != nil {
+		httputil.WriteError(w, http.StatusConflict, "Scan running", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusAccepted, h.scanner.Progress())
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bmcdiscovery
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/clients/redfish"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// Endpoint is a Redfish service found by a scan
+type Endpoint struct {
+	Address         string   `json:"address"`
+	URL             string   `json:"url"`
+	Manufacturer    string   `json:"manufacturer,omitempty"`
+	Model           string   `json:"model,omitempty"`
+	FirmwareVersion string   `json:"firmwareVersion,omitempty"`
+	MAC             string   `json:"mac,omitempty"`      // of the BMC's own interface
+	HostMACs        []string `json:"hostMACs,omitempty"` // of the systems it manages
+	// Error explains details missing because the service refused or failed
+	// the requests after its service root
+	Error string `json:"error,omitempty"`
+}
+
+// BaseURL returns where the Redfish service of bmc is: the one discovery
+// found it at, or else HTTPS on port of its address, or "" when it has
+// neither
+func BaseURL(bmc *v1.BMC, port int) string {
+	if endpoint := bmc.Metadata.Annotations[EndpointAnnotation]; endpoint != "" {
+		return strings.TrimSuffix(endpoint, redfish.ServiceRootPath)
+	}
+	if bmc.Spec.Interface.IP == "" {
+		return ""
+	}
+	return "https://" + net.JoinHostPort(bmc.Spec.Interface.IP, strconv.Itoa(port))
+}
+
+// probe returns the Redfish service at address, or nil when none answers.
+// The service root identifies the service without credentials; its
+// manager, the interfaces of the manager, and the interfaces of its systems
+// fill in the rest when the service allows.
+func (s *Scanner) probe(ctx context.Context, address string) *Endpoint {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	c := &redfish.Client{
+		HTTP:     s.http,
+		BaseURL:  "https://" + net.JoinHostPort(address, strconv.Itoa(s.config.Port)),
+		Username: s.config.Username,
+		Password: s.config.Password,
+	}
+	root, err := c.ServiceRoot(ctx)
+	if err != nil {
+		return nil
+	}
+	endpoint := &Endpoint{Address: address, URL: c.BaseURL + redfish.ServiceRootPath, Manufacturer: root.Vendor, Model: root.Product}
+	if err := readManager(ctx, c, root, endpoint); err != nil {
+		endpoint.Error = err.Error()
+		return endpoint
+	}
+	if err := readSystems(ctx, c, root, endpoint); err != nil {
+		endpoint.Error = err.Error()
+	}
+	return endpoint
+}
+
+// readManager fills in the model, firmware, and MAC address of the first
+// manager, taking the interface holding the endpoint's address, or else the
+// first one with a MAC address
+func readManager(ctx context.Context, c *redfish.Client, root redfish.ServiceRoot, endpoint *Endpoint) error {
+	mgr, ok, err := c.FirstManager(ctx, root)
+	if err != nil || !ok {
+		return err
+	}
+	if mgr.Manufacturer != "" {
+		endpoint.Manufacturer = mgr.Manufacturer
+	}
+	if mgr.Model != "" {
+		endpoint.Model = mgr.Model
+	}
+	endpoint.FirmwareVersion = mgr.FirmwareVersion
+	interfaces, err := c.Interfaces(ctx, mgr.EthernetInterfaces.ID)
+	for _, iface := range interfaces {
+		mac := normalizeMAC(iface.MACAddress)
+		if mac == "" {
+			continue
+		}
+		if endpoint.MAC == "" {
+			endpoint.MAC = mac
+		}
+		for _, addr := range iface.IPv4Addresses {
+			if addr.Address == endpoint.Address {
+				endpoint.MAC = mac
+			}
+		}
+	}
+	return err
+}
+
+// readSystems fills in the MAC addresses of the systems' interfaces
+func readSystems(ctx context.Context, c *redfish.Client, root redfish.ServiceRoot, endpoint *Endpoint) error {
+	systems, err := c.Systems(ctx, root)
+	if err != nil {
+		return err
+	}
+	for _, system := range systems {
+		interfaces, err := c.Interfaces(ctx, system.EthernetInterfaces.ID)
+		for _, iface := range interfaces {
+			if mac := normalizeMAC(iface.MACAddress); mac != "" {
+				endpoint.HostMACs = append(endpoint.HostMACs, mac)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeMAC returns mac in lower-case colon notation, or "" when it is
+// not a MAC address. BMCs report MACs as they are, whatever the validation
+// profile accepts from operators.
+func normalizeMAC(mac string) string {
+	hw, err := net.ParseMAC(validation.NormalizeMAC(strings.TrimSpace(mac)))
+	if err != nil || len(hw) != 6 {
+		return ""
+	}
+	return hw.String()
+}
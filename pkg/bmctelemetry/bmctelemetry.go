@@ -0,0 +1,327 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package bmctelemetry keeps the status of BMCs current with their Redfish
+// services. A Poller reads each BMC's firmware version, the power state of
+// its system, and the health of both on an interval, and writes what
+// changed to the BMC's status. Faults tracks the BMCs whose health is a
+// fault, so boot scripts are refused to the nodes they manage.
+package bmctelemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/bmcdiscovery"
+	"github.com/openchami/boot-service/pkg/clients/redfish"
+)
+
+// Store lists BMCs and writes their status. client.Client and
+// client.InProcessClient implement it.
+type Store interface {
+	GetBMCs(ctx context.Context) ([]v1.BMC, error)
+	UpdateBMCStatus(ctx context.Context, uid string, status v1.BMCStatus) (*v1.BMC, error)
+}
+
+// Config configures polls
+type Config struct {
+	Interval time.Duration
+	// Port of the Redfish services of BMCs not found by discovery, which
+	// records the service it found; 443 when zero
+	Port        int
+	Username    string
+	Password    string
+	Insecure    bool          // skip TLS certificate verification
+	Concurrency int           // BMCs polled at once
+	Timeout     time.Duration // for polling one BMC
+}
+
+// Status reports the last poll
+type Status struct {
+	Interval    string    `json:"interval"`
+	Runs        int       `json:"runs"`             // polls since startup
+	LastRun     time.Time `json:"lastRun,omitzero"` // when the last poll started
+	Duration    string    `json:"duration,omitempty"`
+	Polled      int       `json:"polled"` // BMCs with an address
+	Updated     int       `json:"updated"`
+	Unchanged   int       `json:"unchanged"`
+	Unreachable int       `json:"unreachable"` // BMCs that could not be read
+	Skipped     int       `json:"skipped"`     // BMCs without an address
+	Failed      int       `json:"failed"`      // statuses that could not be written
+	Error       string    `json:"error,omitempty"`
+}
+
+// Poller polls BMCs for telemetry
+type Poller struct {
+	store  Store
+	http   *http.Client
+	config Config
+	logger *log.Logger
+
+	// pollMu serializes polls; mu guards last
+	pollMu sync.Mutex
+	mu     sync.Mutex
+	last   Status
+}
+
+// NewPoller creates a poller that polls every interval once started. A nil
+// httpClient uses one verifying certificates unless config.Insecure is set.
+func NewPoller(store Store, httpClient *http.Client, config Config, logger *log.Logger) *Poller {
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	if config.Port == 0 {
+		config.Port = 443
+	}
+	if config.Concurrency < 1 {
+		config.Concurrency = 1
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+	if httpClient == nil {
+		httpClient = redfish.NewHTTPClient(config.Insecure)
+	}
+	return &Poller{store: store, http: httpClient, config: config, logger: logger}
+}
+
+// Start polls now and then every interval until ctx is done
+func (p *Poller) Start(ctx context.Context) {
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := p.Poll(ctx); err != nil {
+			p.logger.Printf("BMC telemetry poll failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// LastPoll returns the outcome of the last poll
+func (p *Poller) LastPoll() Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	status := p.last
+	status.Interval = p.config.Interval.String()
+	return status
+}
+
+// Poll polls every BMC now and returns the outcome. A poll already running
+// is waited for first.
+func (p *Poller) Poll(ctx context.Context) (Status, error) {
+	p.pollMu.Lock()
+	defer p.pollMu.Unlock()
+
+	start := time.Now()
+	status, err := p.poll(ctx)
+	status.LastRun = start
+	status.Duration = time.Since(start).Round(time.Millisecond).String()
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	p.mu.Lock()
+	status.Runs = p.last.Runs + 1
+	p.last = status
+	p.mu.Unlock()
+	status.Interval = p.config.Interval.String()
+	return status, err
+}
+
+// outcome is what polling one BMC did
+type outcome int
+
+const (
+	outcomeSkipped outcome = iota
+	outcomeUpdated
+	outcomeUnchanged
+	outcomeUnreachable
+	outcomeFailed
+)
+
+// poll runs one poll, Concurrency BMCs at a time
+func (p *Poller) poll(ctx context.Context) (Status, error) {
+	var status Status
+	bmcs, err := p.store.GetBMCs(ctx)
+	if err != nil {
+		return status, fmt.Errorf("listing BMCs: %w", err)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	work := make(chan *v1.BMC)
+	for range p.config.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for bmc := range work {
+				result := p.pollBMC(ctx, bmc)
+				mu.Lock()
+				switch result {
+				case outcomeSkipped:
+					status.Skipped++
+				case outcomeUpdated:
+					status.Updated++
+				case outcomeUnchanged:
+					status.Unchanged++
+				case outcomeUnreachable:
+					status.Unreachable++
+				case outcomeFailed:
+					status.Failed++
+				}
+				if result != outcomeSkipped {
+					status.Polled++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+feed:
+	for i := range bmcs {
+		select {
+		case work <- &bmcs[i]:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return status, err
+	}
+
+	p.logger.Printf("BMC telemetry poll complete: %d BMCs polled, %d updated, %d unchanged, %d unreachable, %d without an address, %d failed",
+		status.Polled, status.Updated, status.Unchanged, status.Unreachable, status.Skipped, status.Failed)
+	return status, nil
+}
+
+// pollBMC reads the telemetry of bmc and writes its status when it changed.
+// A BMC that cannot be read keeps its telemetry and is marked not ready.
+func (p *Poller) pollBMC(ctx context.Context, bmc *v1.BMC) outcome {
+	baseURL := bmcdiscovery.BaseURL(bmc, p.config.Port)
+	if baseURL == "" {
+		return outcomeSkipped
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+
+	status := bmc.Status
+	telemetry, err := p.read(ctx, baseURL)
+	result := outcomeUnchanged
+	if err != nil {
+		status.Ready = false
+		status.Message = "telemetry: " + err.Error()
+		result = outcomeUnreachable
+	} else {
+		status.Ready = true
+		status.Message = ""
+		status.PowerState = telemetry.PowerState
+		status.Health = telemetry.Health
+		if telemetry.FirmwareVersion != "" {
+			status.FirmwareVersion = telemetry.FirmwareVersion
+		}
+	}
+	if equalTelemetry(status, bmc.Status) {
+		return result
+	}
+	if _, err := p.store.UpdateBMCStatus(ctx, bmc.Metadata.UID, status); err != nil {
+		p.logger.Printf("Warning: failed to update status of BMC %s: %v", bmc.Metadata.Name, err)
+		return outcomeFailed
+	}
+	if status.Health != bmc.Status.Health {
+		p.logger.Printf("BMC %s health changed from %q to %q", bmc.Metadata.Name, bmc.Status.Health, status.Health)
+	}
+	if result == outcomeUnchanged {
+		result = outcomeUpdated
+	}
+	return result
+}
+
+// telemetry is what a poll reads from a Redfish service
+type telemetry struct {
+	FirmwareVersion string
+	PowerState      string
+	Health          string
+}
+
+// read reads the telemetry of the Redfish service at baseURL: the firmware
+// and health of its first manager, the power state of its first system, and
+// the health of every system including its components
+func (p *Poller) read(ctx context.Context, baseURL string) (telemetry, error) {
+	var t telemetry
+	c := &redfish.Client{HTTP: p.http, BaseURL: baseURL, Username: p.config.Username, Password: p.config.Password}
+	root, err := c.ServiceRoot(ctx)
+	if err != nil {
+		return t, err
+	}
+	manager, ok, err := c.FirstManager(ctx, root)
+	if err != nil {
+		return t, err
+	}
+	if ok {
+		t.FirmwareVersion = manager.FirmwareVersion
+		t.Health = manager.Status.Health
+	}
+	systems, err := c.Systems(ctx, root)
+	if err != nil {
+		return t, err
+	}
+	for i, system := range systems {
+		if i == 0 {
+			t.PowerState = system.PowerState
+		}
+		health := system.Status.HealthRollup
+		if health == "" {
+			health = system.Status.Health
+		}
+		t.Health = redfish.WorseHealth(t.Health, health)
+	}
+	if t.Health == "" && (ok || len(systems) > 0) {
+		return t, errors.New("no health reported")
+	}
+	return t, nil
+}
+
+func equalTelemetry(a, b v1.BMCStatus) bool {
+	return a.Ready == b.Ready && a.Message == b.Message && a.PowerState == b.PowerState &&
+		a.Health == b.Health && a.FirmwareVersion == b.FirmwareVersion
+}
+
+// ParseHealth parses comma-separated Redfish health values, such as
+// "Critical" or "Warning,Critical"
+func ParseHealth(value string) ([]string, error) {
+	var healths []string
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		var health string
+		for _, known := range []string{redfish.HealthOK, redfish.HealthWarning, redfish.HealthCritical} {
+			if strings.EqualFold(field, known) {
+				health = known
+			}
+		}
+		if health == "" {
+			return nil, fmt.Errorf("unknown health %q: want %s, %s, or %s", field, redfish.HealthOK, redfish.HealthWarning, redfish.HealthCritical)
+		}
+		if !slices.Contains(healths, health) {
+			healths = append(healths, health)
+		}
+	}
+	return healths, nil
+}
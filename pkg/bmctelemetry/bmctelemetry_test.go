@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bmctelemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/bmcdiscovery"
+	"github.com/openchami/boot-service/pkg/clients/redfish"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// fakeStore keeps BMCs and nodes in memory
+type fakeStore struct {
+	mu     sync.Mutex
+	bmcs   []v1.BMC
+	nodes  []v1.Node
+	writes int
+}
+
+func (f *fakeStore) GetBMCs(context.Context) ([]v1.BMC, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.bmcs), nil
+}
+
+func (f *fakeStore) GetNodes(context.Context) ([]v1.Node, error) {
+	return slices.Clone(f.nodes), nil
+}
+
+func (f *fakeStore) UpdateBMCStatus(_ context.Context, uid string, status v1.BMCStatus) (*v1.BMC, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.writes++
+	for i := range f.bmcs {
+		if f.bmcs[i].Metadata.UID == uid {
+			f.bmcs[i].Status = status
+			bmc := f.bmcs[i]
+			return &bmc, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+// redfishServer serves a Redfish service with one manager and one system
+// whose health is set by the returned function
+func redfishServer(t *testing.T) (*httptest.Server, func(health string)) {
+	t.Helper()
+	var mu sync.Mutex
+	systemHealth := redfish.HealthOK
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		docs := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"RedfishVersion": "1.11.0",
+				"Managers":       map[string]string{"@odata.id": "/redfish/v1/Managers"},
+				"Systems":        map[string]string{"@odata.id": "/redfish/v1/Systems"},
+			},
+			"/redfish/v1/Managers":   map[string]any{"Members": []map[string]string{{"@odata.id": "/redfish/v1/Managers/1"}}},
+			"/redfish/v1/Managers/1": map[string]any{"FirmwareVersion": "2.4.1", "Status": map[string]string{"Health": "OK"}},
+			"/redfish/v1/Systems":    map[string]any{"Members": []map[string]string{{"@odata.id": "/redfish/v1/Systems/1"}}},
+			"/redfish/v1/Systems/1": map[string]any{
+				"PowerState": "On",
+				"Status":     map[string]string{"Health": "OK", "HealthRollup": systemHealth},
+			},
+		}
+		doc, ok := docs[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(doc) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+	return server, func(health string) {
+		mu.Lock()
+		defer mu.Unlock()
+		systemHealth = health
+	}
+}
+
+func TestPoll(t *testing.T) {
+	server, setHealth := redfishServer(t)
+	store := &fakeStore{bmcs: []v1.BMC{
+		{Spec: v1.BMCSpec{XName: "x0c0s0b0"}},
+		{Spec: v1.BMCSpec{XName: "x0c0s1b0"}}, // without an address
+	}}
+	store.bmcs[0].Metadata.UID = "bmc-0"
+	store.bmcs[0].Metadata.Annotations = map[string]string{bmcdiscovery.EndpointAnnotation: server.URL + redfish.ServiceRootPath}
+	store.bmcs[0].Status = v1.BMCStatus{Phase: bmcdiscovery.PhaseDiscovered, Nodes: []string{"x0c0s0b0n0"}}
+	store.bmcs[1].Metadata.UID = "bmc-1"
+	poller := NewPoller(store, server.Client(), Config{Interval: time.Minute, Concurrency: 2}, nil)
+	ctx := context.Background()
+
+	status, err := poller.Poll(ctx)
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if status.Polled != 1 || status.Updated != 1 || status.Skipped != 1 || status.Runs != 1 {
+		t.Errorf("status = %+v, want 1 polled and updated, 1 skipped", status)
+	}
+	got := store.bmcs[0].Status
+	if got.PowerState != "On" || got.Health != redfish.HealthOK || got.FirmwareVersion != "2.4.1" || !got.Ready {
+		t.Errorf("BMC status = %+v, want On, OK, firmware 2.4.1, ready", got)
+	}
+	if got.Phase != bmcdiscovery.PhaseDiscovered || !slices.Equal(got.Nodes, []string{"x0c0s0b0n0"}) {
+		t.Errorf("poll dropped the discovered status: %+v", got)
+	}
+
+	// Nothing changed, so nothing is written
+	writes := store.writes
+	if status, _ := poller.Poll(ctx); status.Unchanged != 1 || store.writes != writes {
+		t.Errorf("expected an unchanged poll without writes, got %+v and %d writes", status, store.writes-writes)
+	}
+
+	// A failing component of the system is its health rollup
+	setHealth(redfish.HealthCritical)
+	if _, err := poller.Poll(ctx); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if health := store.bmcs[0].Status.Health; health != redfish.HealthCritical {
+		t.Errorf("health = %q, want Critical", health)
+	}
+
+	// An unreachable BMC keeps its telemetry and is not ready
+	server.Close()
+	status, _ = poller.Poll(ctx)
+	got = store.bmcs[0].Status
+	if status.Unreachable != 1 || got.Ready || got.Message == "" || got.Health != redfish.HealthCritical {
+		t.Errorf("expected an unreachable BMC keeping its health, got %+v and status %+v", status, got)
+	}
+	if last := poller.LastPoll(); last.Runs != 4 || last.Interval != "1m0s" {
+		t.Errorf("LastPoll = %+v, want 4 runs every 1m0s", last)
+	}
+}
+
+// bmcEvent returns the event of writing bmc
+func bmcEvent(t *testing.T, bmc v1.BMC) resourcewatch.Event {
+	t.Helper()
+	data, err := json.Marshal(bmc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resourcewatch.Event{Type: "update", ResourceType: "BMC", UID: bmc.Metadata.UID, New: data}
+}
+
+func TestFaults(t *testing.T) {
+	store := &fakeStore{nodes: []v1.Node{
+		{Spec: v1.NodeSpec{XName: "x0c0s0b0n0"}},
+		{Spec: v1.NodeSpec{XName: "x0c0s0b0n1"}},
+		{Spec: v1.NodeSpec{XName: "x0c0s1b0n0"}},
+	}}
+	var invalidated []string
+	faults := NewFaults(store, []string{redfish.HealthCritical}, func(node string) { invalidated = append(invalidated, node) }, nil)
+	ctx := context.Background()
+
+	bmc := v1.BMC{Spec: v1.BMCSpec{XName: "x0c0s0b0"}, Status: v1.BMCStatus{Health: redfish.HealthWarning, Nodes: []string{"x9c0s0b0n0"}}}
+	bmc.Metadata.UID = "bmc-0"
+	bmc.Metadata.Name = "x0c0s0b0"
+	store.bmcs = []v1.BMC{bmc}
+	if err := faults.Load(ctx); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if _, ok := faults.Faulted("x0c0s0b0n0"); ok {
+		t.Error("a BMC with Warning health faulted its node")
+	}
+
+	bmc.Status.Health = redfish.HealthCritical
+	faults.HandleResourceChange(ctx, bmcEvent(t, bmc))
+	for _, node := range []string{"x0c0s0b0n0", "x0c0s0b0n1", "x9c0s0b0n0"} {
+		if reason, ok := faults.Faulted(node); !ok || reason != "BMC x0c0s0b0 reports Critical health" {
+			t.Errorf("Faulted(%s) = %q, %v; want the BMC's fault", node, reason, ok)
+		}
+	}
+	if _, ok := faults.Faulted("x0c0s1b0n0"); ok {
+		t.Error("a node of another BMC is faulted")
+	}
+	slices.Sort(invalidated)
+	if want := []string{"x0c0s0b0n0", "x0c0s0b0n1", "x9c0s0b0n0"}; !slices.Equal(invalidated, want) {
+		t.Errorf("invalidated %v, want %v", invalidated, want)
+	}
+	if list := faults.List(); len(list) != 1 || list[0].BMC != "x0c0s0b0" {
+		t.Errorf("List = %+v, want the BMC's fault", list)
+	}
+
+	// Writes that leave the fault as it was invalidate nothing
+	invalidated = nil
+	bmc.Status.PowerState = "Off"
+	faults.HandleResourceChange(ctx, bmcEvent(t, bmc))
+	if len(invalidated) != 0 {
+		t.Errorf("an unchanged fault invalidated %v", invalidated)
+	}
+
+	// Deleting the BMC clears its fault
+	faults.HandleResourceChange(ctx, resourcewatch.Event{Type: "delete", ResourceType: "BMC", UID: "bmc-0"})
+	if _, ok := faults.Faulted("x0c0s0b0n0"); ok || len(invalidated) != 3 {
+		t.Errorf("expected the fault cleared and its nodes invalidated, got %v", invalidated)
+	}
+}
+
+func TestParseHealth(t *testing.T) {
+	health, err := ParseHealth(" critical, Warning,CRITICAL ")
+	if err != nil || !slices.Equal(health, []string{redfish.HealthCritical, redfish.HealthWarning}) {
+		t.Errorf("ParseHealth = %v, %v", health, err)
+	}
+	if health, err := ParseHealth(""); err != nil || len(health) != 0 {
+		t.Errorf("ParseHealth(\"\") = %v, %v; want none", health, err)
+	}
+	if _, err := ParseHealth("Broken"); err == nil {
+		t.Error("expected an error for an unknown health")
+	}
+}
@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bmctelemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"sort"
+	"sync"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// Inventory lists BMCs and nodes. client.Client and client.InProcessClient
+// implement it.
+type Inventory interface {
+	GetBMCs(ctx context.Context) ([]v1.BMC, error)
+	GetNodes(ctx context.Context) ([]v1.Node, error)
+}
+
+// Fault is a BMC reporting a hardware fault
+type Fault struct {
+	BMC    string `json:"bmc"` // name
+	XName  string `json:"xname,omitempty"`
+	Health string `json:"health"`
+	// Nodes are the nodes correlated with the BMC. Nodes whose xnames are
+	// beneath the BMC's are faulted too.
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// manages reports whether node, by xname, is managed by the BMC of f
+func (f Fault) manages(node string) bool {
+	return slices.Contains(f.Nodes, node) || (f.XName != "" && validation.NodeBMC(node) == f.XName)
+}
+
+// Faults tracks the BMCs whose health is a fault, from the BMC statuses in
+// storage, so every replica refuses the nodes they manage whichever one
+// polls. It implements bootscript.HardwareFaults.
+type Faults struct {
+	inventory  Inventory
+	health     []string // health values that are faults
+	invalidate func(node string)
+	logger     *log.Logger
+
+	mu     sync.RWMutex
+	faults map[string]Fault // by BMC UID
+}
+
+// NewFaults creates a fault index treating the given health values as
+// faults. invalidate is called for every node whose fault appears or clears.
+func NewFaults(inventory Inventory, health []string, invalidate func(node string), logger *log.Logger) *Faults {
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	if invalidate == nil {
+		invalidate = func(string) {}
+	}
+	return &Faults{inventory: inventory, health: health, invalidate: invalidate, logger: logger, faults: map[string]Fault{}}
+}
+
+// Load indexes the BMCs in storage
+func (f *Faults) Load(ctx context.Context) error {
+	bmcs, err := f.inventory.GetBMCs(ctx)
+	if err != nil {
+		return fmt.Errorf("listing BMCs: %w", err)
+	}
+	faults := map[string]Fault{}
+	for i := range bmcs {
+		if fault, ok := f.fault(&bmcs[i]); ok {
+			faults[bmcs[i].Metadata.UID] = fault
+		}
+	}
+	f.mu.Lock()
+	f.faults = faults
+	f.mu.Unlock()
+	return nil
+}
+
+// fault returns the fault bmc reports, or ok false when it is healthy
+func (f *Faults) fault(bmc *v1.BMC) (Fault, bool) {
+	if !slices.Contains(f.health, bmc.Status.Health) {
+		return Fault{}, false
+	}
+	return Fault{
+		BMC:    bmc.Metadata.Name,
+		XName:  bmc.Spec.XName,
+		Health: bmc.Status.Health,
+		Nodes:  slices.Clone(bmc.Status.Nodes),
+	}, true
+}
+
+// HandleResourceChange re-indexes written BMCs and drops the cached scripts
+// of the nodes whose fault appeared, changed, or cleared
+func (f *Faults) HandleResourceChange(ctx context.Context, event resourcewatch.Event) {
+	if event.ResourceType != "BMC" {
+		return
+	}
+	var bmc *v1.BMC
+	if event.New != nil {
+		bmc = &v1.BMC{}
+		if err := json.Unmarshal(event.New, bmc); err != nil {
+			f.logger.Printf("Failed to decode BMC %s: %v", event.UID, err)
+			return
+		}
+	}
+
+	f.mu.Lock()
+	old, faulted := f.faults[event.UID]
+	var fault Fault
+	var ok bool
+	if bmc != nil {
+		fault, ok = f.fault(bmc)
+	}
+	if ok {
+		f.faults[event.UID] = fault
+	} else {
+		delete(f.faults, event.UID)
+	}
+	f.mu.Unlock()
+
+	if ok == faulted && (!ok || equalFault(fault, old)) {
+		return
+	}
+	switch {
+	case ok:
+		f.logger.Printf("BMC %s reports %s health; refusing to boot its nodes", fault.BMC, fault.Health)
+	default:
+		f.logger.Printf("BMC %s no longer reports a fault", old.BMC)
+	}
+	f.invalidateNodes(ctx, old, fault)
+}
+
+// invalidateNodes drops the cached scripts of the nodes of the given faults
+func (f *Faults) invalidateNodes(ctx context.Context, faults ...Fault) {
+	nodes, err := f.inventory.GetNodes(ctx)
+	if err != nil {
+		f.logger.Printf("Failed to list nodes to invalidate: %v", err)
+	}
+	seen := map[string]bool{}
+	invalidate := func(node string) {
+		if node != "" && !seen[node] {
+			seen[node] = true
+			f.invalidate(node)
+		}
+	}
+	for _, fault := range faults {
+		for _, node := range fault.Nodes {
+			invalidate(node)
+		}
+		for _, node := range nodes {
+			if fault.XName != "" && validation.NodeBMC(node.Spec.XName) == fault.XName {
+				invalidate(node.Spec.XName)
+			}
+		}
+	}
+}
+
+func equalFault(a, b Fault) bool {
+	return a.XName == b.XName && a.Health == b.Health && slices.Equal(a.Nodes, b.Nodes)
+}
+
+// Faulted returns why node has a hardware fault. It implements
+// bootscript.HardwareFaults.
+func (f *Faults) Faulted(node string) (string, bool) {
+	if node == "" {
+		return "", false
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, fault := range f.faults {
+		if fault.manages(node) {
+			return fmt.Sprintf("BMC %s reports %s health", fault.BMC, fault.Health), true
+		}
+	}
+	return "", false
+}
+
+// List returns the faults, by BMC name
+func (f *Faults) List() []Fault {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	faults := make([]Fault, 0, len(f.faults))
+	for _, fault := range f.faults {
+		faults = append(faults, fault)
+	}
+	sort.Slice(faults, func(i, j int) bool { return faults[i].BMC < faults[j].BMC })
+	return faults
+}
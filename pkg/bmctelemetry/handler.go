@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bmctelemetry
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Path serves the BMC telemetry API
+const Path = "/admin/bmc-telemetry"
+
+// Handler serves the BMC telemetry API
+type Handler struct {
+	poller *Poller
+	faults *Faults
+}
+
+// NewHandler creates a BMC telemetry API handler
+func NewHandler(poller *Poller, faults *Faults) *Handler {
+	return &Handler{poller: poller, faults: faults}
+}
+
+// RegisterRoutes registers GET /admin/bmc-telemetry/status and POST
+// /admin/bmc-telemetry/poll
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route(Path, func(r chi.Router) {
+		r.Use(tenancy.Unscoped)
+		r.Get("/status", h.GetStatus)
+		r.Post("/poll", h.Poll)
+	})
+}
+
+// StatusResponse is the body of GET /admin/bmc-telemetry/status
+type StatusResponse struct {
+	// LastPoll is the last poll made by this replica
+	LastPoll Status `json:"lastPoll"`
+	// Faults are the BMCs reporting a hardware fault, whose nodes are
+	// refused boot scripts
+	Faults []Fault `json:"faults"`
+}
+
+// GetStatus handles GET /admin/bmc-telemetry/status
+func (h *Handler) GetStatus(w http.ResponseWriter, _ *http.Request) {
+	httputil.WriteJSON(w, http.StatusOK, StatusResponse{LastPoll: h.poller.LastPoll(), Faults: h.faults.List()})
+}
+
+// Poll handles POST /admin/bmc-telemetry/poll, which polls every BMC now
+// instead of waiting for the interval
+func (h *Handler) Poll(w http.ResponseWriter, r *http.Request) {
+	status, err := h.poller.Poll(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadGateway, "Poll failed", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, status)
+}
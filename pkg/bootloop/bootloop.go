@@ -0,0 +1,258 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package bootloop detects nodes stuck in a boot loop: nodes that request
+// their boot script more than a threshold number of times within a window
+// without phoning home in between. A detected loop is reported to a webhook
+// and, when a diagnostic configuration is set, the node boots that
+// configuration until an operator clears the loop.
+//
+// Detection state is kept in memory by each replica unless the detector is
+// given a shared State, such as one stored in Redis.
+package bootloop
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/openchami/boot-service/pkg/activity"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+)
+
+// EventType is the type of the webhook event sent for a detected loop
+const EventType = "boot-loop"
+
+// Config controls detection
+type Config struct {
+	// Threshold is the number of requests within Window that is a loop
+	Threshold int
+	// Window is how far back requests are counted
+	Window time.Duration
+	// Diagnostic names the boot configuration that looping nodes boot;
+	// empty leaves their configuration alone
+	Diagnostic string
+}
+
+// Loop is a detected boot loop
+type Loop struct {
+	Node string `json:"node"`
+	// Requests counts the requests within the window, up to the latest
+	Requests   int       `json:"requests"`
+	DetectedAt time.Time `json:"detectedAt"`
+	LastSeen   time.Time `json:"lastSeen"`
+	LastClient string    `json:"lastClient,omitempty"`
+	// Diagnostic is the configuration the node boots instead of its own
+	Diagnostic string `json:"diagnosticConfiguration,omitempty"`
+}
+
+// Event is the webhook body for a detected loop
+type Event struct {
+	Type          string `json:"type"`
+	WindowSeconds int    `json:"windowSeconds"`
+	Loop
+}
+
+// Notifier is told about each detected loop
+type Notifier interface {
+	Notify(event Event)
+}
+
+// NodeResolver maps the identifier a node phoned home with to its xname
+type NodeResolver interface {
+	ResolveNodeName(ctx context.Context, identifier string) (string, error)
+}
+
+// Detector counts boot script requests per node. It implements
+// bootscript.AccessRecorder for requests, bootscript.BootLoopGuard for the
+// diagnostic configuration, and boot.ActivityRecorder for phone-home reports.
+type Detector struct {
+	config   Config
+	resolver NodeResolver
+	logger   *log.Logger
+
+	// invalidate drops the cached scripts of a node whose script changes
+	invalidate func(node string)
+	notifiers  []Notifier
+	now        func() time.Time
+
+	state    State
+	detected atomic.Uint64 // by this replica
+}
+
+// NewDetector creates a detector. resolver maps phone-home identifiers to
+// nodes; invalidate, which may be nil, is called when a node starts or stops
+// booting the diagnostic configuration.
+func NewDetector(config Config, resolver NodeResolver, invalidate func(node string), logger *log.Logger) *Detector {
+	if invalidate == nil {
+		invalidate = func(string) {}
+	}
+	return &Detector{
+		config:     config,
+		resolver:   resolver,
+		logger:     logger,
+		invalidate: invalidate,
+		now:        time.Now,
+		state:      NewMemoryState(),
+	}
+}
+
+// SetState keeps the requests and loops of nodes in state, such as one
+// shared by every replica, instead of in memory. Call it before recording
+// requests.
+func (d *Detector) SetState(state State) {
+	d.state = state
+}
+
+// AddNotifier reports every detected loop to notifier
+func (d *Detector) AddNotifier(notifier Notifier) {
+	d.notifiers = append(d.notifiers, notifier)
+}
+
+// RecordAccess counts a boot script request of node
+func (d *Detector) RecordAccess(node string, access bootscript.Access) {
+	now := d.now().UTC()
+	requests, err := d.state.AddRequest(node, now, now.Add(-d.config.Window))
+	if err != nil {
+		d.logger.Printf("Failed to count the request of node %s: %v", node, err)
+		return
+	}
+
+	loop, looping, err := d.state.Loop(node)
+	if err != nil {
+		d.logger.Printf("Failed to read the boot loop of node %s: %v", node, err)
+		return
+	}
+	if looping {
+		loop.Requests = requests
+		loop.LastSeen = now
+		loop.LastClient = access.Client
+		if err := d.state.UpdateLoop(loop); err != nil {
+			d.logger.Printf("Failed to update the boot loop of node %s: %v", node, err)
+		}
+		return
+	}
+	if requests <= d.config.Threshold {
+		return
+	}
+	loop = Loop{
+		Node:       node,
+		Requests:   requests,
+		DetectedAt: now,
+		LastSeen:   now,
+		LastClient: access.Client,
+		Diagnostic: d.config.Diagnostic,
+	}
+	// Only the replica that stores the loop reports it
+	if created, err := d.state.CreateLoop(loop); err != nil || !created {
+		if err != nil {
+			d.logger.Printf("Failed to store the boot loop of node %s: %v", node, err)
+		}
+		return
+	}
+
+	d.detected.Add(1)
+	if loop.Diagnostic != "" {
+		d.logger.Printf("Node %s is in a boot loop (%d requests in %s); booting %s", node, loop.Requests, d.config.Window, loop.Diagnostic)
+		d.invalidate(node)
+	} else {
+		d.logger.Printf("Node %s is in a boot loop (%d requests in %s)", node, loop.Requests, d.config.Window)
+	}
+	event := Event{Type: EventType, WindowSeconds: int(d.config.Window / time.Second), Loop: loop}
+	for _, notifier := range d.notifiers {
+		notifier.Notify(event)
+	}
+}
+
+// Publish resets the request count of a node that phoned home, which ends
+// its loop unless it was switched to the diagnostic configuration. Other
+// events are ignored.
+func (d *Detector) Publish(event activity.Event) {
+	if event.Type != activity.PhoneHome {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, identifier := range []string{event.Identifier, event.Hostname} {
+		if identifier == "" {
+			continue
+		}
+		if node, err := d.resolver.ResolveNodeName(ctx, identifier); err == nil {
+			d.PhoneHome(node)
+			return
+		}
+	}
+}
+
+// PhoneHome resets the request count of node
+func (d *Detector) PhoneHome(node string) {
+	if err := d.state.ResetRequests(node); err != nil {
+		d.logger.Printf("Failed to reset the requests of node %s: %v", node, err)
+	}
+	loop, ok, err := d.state.Loop(node)
+	if err != nil {
+		d.logger.Printf("Failed to read the boot loop of node %s: %v", node, err)
+		return
+	}
+	if !ok || loop.Diagnostic != "" {
+		return
+	}
+	if _, deleted, err := d.state.DeleteLoop(node); err != nil {
+		d.logger.Printf("Failed to end the boot loop of node %s: %v", node, err)
+	} else if deleted {
+		d.logger.Printf("Node %s phoned home; boot loop ended", node)
+	}
+}
+
+// Diagnostic returns the configuration node boots because of its loop. It
+// implements bootscript.BootLoopGuard. If the state cannot be read, the node
+// boots its own configuration.
+func (d *Detector) Diagnostic(node string) (string, bool) {
+	loop, ok, err := d.state.Loop(node)
+	if err != nil {
+		d.logger.Printf("Failed to read the boot loop of node %s: %v", node, err)
+		return "", false
+	}
+	if ok && loop.Diagnostic != "" {
+		return loop.Diagnostic, true
+	}
+	return "", false
+}
+
+// Loops returns the current loops, sorted by node
+func (d *Detector) Loops() []Loop {
+	loops, err := d.state.Loops()
+	if err != nil {
+		d.logger.Printf("Failed to list boot loops: %v", err)
+		return []Loop{}
+	}
+	return loops
+}
+
+// Clear ends the loop of node, which boots its own configuration again. It
+// reports whether node was looping.
+func (d *Detector) Clear(node string) bool {
+	loop, ok, err := d.state.DeleteLoop(node)
+	if err != nil {
+		d.logger.Printf("Failed to clear the boot loop of node %s: %v", node, err)
+		return false
+	}
+	if err := d.state.ResetRequests(node); err != nil {
+		d.logger.Printf("Failed to reset the requests of node %s: %v", node, err)
+	}
+	if !ok {
+		return false
+	}
+	d.logger.Printf("Boot loop of node %s cleared", node)
+	if loop.Diagnostic != "" {
+		d.invalidate(node)
+	}
+	return true
+}
+
+// Detected returns how many loops this replica has detected
+func (d *Detector) Detected() uint64 {
+	return d.detected.Load()
+}
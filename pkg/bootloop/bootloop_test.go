@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootloop
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/pkg/activity"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+)
+
+// testResolver knows nodes by xname and hostname
+type testResolver map[string]string
+
+func (r testResolver) ResolveNodeName(_ context.Context, identifier string) (string, error) {
+	if node, ok := r[identifier]; ok {
+		return node, nil
+	}
+	return "", bootscript.ErrNodeNotFound
+}
+
+type testNotifier struct{ events []Event }
+
+func (n *testNotifier) Notify(event Event) { n.events = append(n.events, event) }
+
+// newTestDetector returns a detector with a clock advanced by the returned
+// function, and the nodes whose scripts it invalidated
+func newTestDetector(config Config) (*Detector, func(time.Duration), *[]string) {
+	var invalidated []string
+	resolver := testResolver{"x0c0s0b0n0": "x0c0s0b0n0", "nid0001": "x0c0s0b0n0"}
+	detector := NewDetector(config, resolver, func(node string) { invalidated = append(invalidated, node) }, log.New(io.Discard, "", 0))
+	now := time.Date(2026, 3, 7, 9, 0, 0, 0, time.UTC)
+	detector.now = func() time.Time { return now }
+	return detector, func(d time.Duration) { now = now.Add(d) }, &invalidated
+}
+
+func TestDetector_Threshold(t *testing.T) {
+	detector, advance, _ := newTestDetector(Config{Threshold: 3, Window: 10 * time.Minute})
+	notifier := &testNotifier{}
+	detector.AddNotifier(notifier)
+	access := bootscript.Access{Client: "10.1.0.21"}
+
+	// Requests spread beyond the window are not a loop
+	for range 5 {
+		detector.RecordAccess("x0c0s0b0n0", access)
+		advance(4 * time.Minute)
+	}
+	if loops := detector.Loops(); len(loops) != 0 {
+		t.Fatalf("Loops = %+v, want none for requests spread over the window", loops)
+	}
+	advance(10 * time.Minute)
+
+	// A phone-home resets the count
+	detector.RecordAccess("x0c0s0b0n0", access)
+	detector.RecordAccess("x0c0s0b0n0", access)
+	detector.Publish(activity.Event{Type: activity.PhoneHome, Identifier: "i-12345", Hostname: "nid0001"})
+	detector.RecordAccess("x0c0s0b0n0", access)
+	detector.RecordAccess("x0c0s0b0n0", access)
+	if loops := detector.Loops(); len(loops) != 0 {
+		t.Fatalf("Loops = %+v, want none after phoning home", loops)
+	}
+
+	detector.RecordAccess("x0c0s0b0n0", access)
+	detector.RecordAccess("x0c0s0b0n0", access)
+	loops := detector.Loops()
+	if len(loops) != 1 || loops[0].Node != "x0c0s0b0n0" || loops[0].Requests != 4 || loops[0].LastClient != "10.1.0.21" {
+		t.Fatalf("Loops = %+v, want x0c0s0b0n0 with 4 requests", loops)
+	}
+	if len(notifier.events) != 1 || notifier.events[0].Type != EventType || notifier.events[0].WindowSeconds != 600 {
+		t.Errorf("notified %+v, want one boot-loop event", notifier.events)
+	}
+	if detector.Detected() != 1 {
+		t.Errorf("Detected = %d, want 1", detector.Detected())
+	}
+
+	// Further requests update the loop without notifying again
+	detector.RecordAccess("x0c0s0b0n0", access)
+	if len(notifier.events) != 1 || detector.Loops()[0].Requests != 5 {
+		t.Errorf("after another request: %d events, loop %+v", len(notifier.events), detector.Loops()[0])
+	}
+
+	// Without a diagnostic configuration, phoning home ends the loop
+	detector.PhoneHome("x0c0s0b0n0")
+	if loops := detector.Loops(); len(loops) != 0 {
+		t.Errorf("Loops = %+v, want none after phoning home", loops)
+	}
+}
+
+func TestDetector_Diagnostic(t *testing.T) {
+	detector, _, invalidated := newTestDetector(Config{Threshold: 1, Window: time.Minute, Diagnostic: "rescue"})
+
+	detector.RecordAccess("x0c0s0b0n0", bootscript.Access{})
+	if _, ok := detector.Diagnostic("x0c0s0b0n0"); ok {
+		t.Fatal("Diagnostic is set before the threshold was passed")
+	}
+	detector.RecordAccess("x0c0s0b0n0", bootscript.Access{})
+	if config, ok := detector.Diagnostic("x0c0s0b0n0"); !ok || config != "rescue" {
+		t.Fatalf("Diagnostic = %q, %v, want rescue", config, ok)
+	}
+
+	// The diagnostic image phoning home does not end the loop
+	detector.PhoneHome("x0c0s0b0n0")
+	if _, ok := detector.Diagnostic("x0c0s0b0n0"); !ok {
+		t.Fatal("Diagnostic ended when the node phoned home")
+	}
+
+	if !detector.Clear("x0c0s0b0n0") {
+		t.Fatal("Clear reported no loop")
+	}
+	if _, ok := detector.Diagnostic("x0c0s0b0n0"); ok {
+		t.Error("Diagnostic is still set after Clear")
+	}
+	if len(*invalidated) != 2 {
+		t.Errorf("invalidated %v, want the node when switched and when cleared", *invalidated)
+	}
+	if detector.Clear("x0c0s0b0n0") {
+		t.Error("second Clear reported a loop")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	detector, _, _ := newTestDetector(Config{Threshold: 1, Window: time.Minute})
+	detector.RecordAccess("x0c0s0b0n0", bootscript.Access{})
+	detector.RecordAccess("x0c0s0b0n0", bootscript.Access{})
+
+	r := chi.NewRouter()
+	NewHandler(detector).RegisterRoutes(r)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, nil))
+	var loops []Loop
+	if err := json.NewDecoder(w.Body).Decode(&loops); err != nil {
+		t.Fatalf("failed to decode loops: %v", err)
+	}
+	if w.Code != http.StatusOK || len(loops) != 1 || loops[0].Node != "x0c0s0b0n0" {
+		t.Fatalf("GET %s = %d %+v, want the looping node", Path, w.Code, loops)
+	}
+
+	for _, want := range []int{http.StatusNoContent, http.StatusNotFound} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, Path+"/x0c0s0b0n0", nil))
+		if w.Code != want {
+			t.Errorf("DELETE returned %d, want %d", w.Code, want)
+		}
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	received := make(chan Event, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("failed to decode event: %v", err)
+		}
+		received <- event
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notifier := NewWebhookNotifier(server.URL, log.New(io.Discard, "", 0))
+	go notifier.Run(ctx)
+	notifier.Notify(Event{Type: EventType, Loop: Loop{Node: "x0c0s0b0n0", Requests: 6}})
+
+	select {
+	case event := <-received:
+		if event.Node != "x0c0s0b0n0" || event.Requests != 6 {
+			t.Errorf("webhook received %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook received nothing")
+	}
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootloop
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Path serves the detected boot loops
+const Path = "/admin/boot-loops"
+
+// Handler serves the boot loop API
+type Handler struct {
+	detector *Detector
+}
+
+// NewHandler creates a boot loop API handler
+func NewHandler(detector *Detector) *Handler {
+	return &Handler{detector: detector}
+}
+
+// RegisterRoutes registers GET /admin/boot-loops and
+// DELETE /admin/boot-loops/{node}
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route(Path, func(r chi.Router) {
+		r.Use(tenancy.Unscoped)
+		r.Get("/", h.ListLoops)
+		r.Delete("/{node}", h.ClearLoop)
+	})
+}
+
+// ListLoops handles GET /admin/boot-loops
+func (h *Handler) ListLoops(w http.ResponseWriter, r *http.Request) { //nolint:revive
+	httputil.WriteJSON(w, http.StatusOK, h.detector.Loops())
+}
+
+// ClearLoop handles DELETE /admin/boot-loops/{node}, which returns the node,
+// by xname, to its own configuration
+func (h *Handler) ClearLoop(w http.ResponseWriter, r *http.Request) {
+	node := chi.URLParam(r, "node")
+	if !h.detector.Clear(node) {
+		httputil.WriteError(w, http.StatusNotFound, "Boot loop not found", "node "+node+" is not in a boot loop")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
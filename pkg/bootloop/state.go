@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootloop
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// State keeps the recent requests and the loops of nodes. Replicas sharing
+// a State count each node's requests together and agree on its loop.
+type State interface {
+	// AddRequest records a request of node made at, forgets its requests
+	// before cutoff, and returns how many are left
+	AddRequest(node string, at, cutoff time.Time) (int, error)
+	// ResetRequests forgets the requests of node
+	ResetRequests(node string) error
+
+	// Loop returns the loop of node, or ok false when it has none
+	Loop(node string) (loop Loop, ok bool, err error)
+	// Loops returns every loop, sorted by node
+	Loops() ([]Loop, error)
+	// CreateLoop stores loop unless its node already has one. It reports
+	// whether loop was stored.
+	CreateLoop(loop Loop) (bool, error)
+	// UpdateLoop replaces the loop of its node, if it still has one
+	UpdateLoop(loop Loop) error
+	// DeleteLoop removes the loop of node and returns it
+	DeleteLoop(node string) (loop Loop, ok bool, err error)
+}
+
+// memoryState is the State of a single replica
+type memoryState struct {
+	mu       sync.Mutex
+	requests map[string][]time.Time // oldest first
+	loops    map[string]Loop
+}
+
+// NewMemoryState creates a State kept in memory, which is not shared with
+// other replicas
+func NewMemoryState() State {
+	return &memoryState{requests: make(map[string][]time.Time), loops: make(map[string]Loop)}
+}
+
+func (s *memoryState) AddRequest(node string, at, cutoff time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := append(s.requests[node], at)
+	for len(requests) > 0 && requests[0].Before(cutoff) {
+		requests = requests[1:]
+	}
+	s.requests[node] = requests
+	return len(requests), nil
+}
+
+func (s *memoryState) ResetRequests(node string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.requests, node)
+	return nil
+}
+
+func (s *memoryState) Loop(node string) (Loop, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	loop, ok := s.loops[node]
+	return loop, ok, nil
+}
+
+func (s *memoryState) Loops() ([]Loop, error) {
+	s.mu.Lock()
+	loops := make([]Loop, 0, len(s.loops))
+	for _, loop := range s.loops {
+		loops = append(loops, loop)
+	}
+	s.mu.Unlock()
+	sort.Slice(loops, func(i, j int) bool { return loops[i].Node < loops[j].Node })
+	return loops, nil
+}
+
+func (s *memoryState) CreateLoop(loop Loop) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.loops[loop.Node]; ok {
+		return false, nil
+	}
+	s.loops[loop.Node] = loop
+	return true, nil
+}
+
+func (s *memoryState) UpdateLoop(loop Loop) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.loops[loop.Node]; ok {
+		s.loops[loop.Node] = loop
+	}
+	return nil
+}
+
+func (s *memoryState) DeleteLoop(node string) (Loop, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	loop, ok := s.loops[node]
+	delete(s.loops, node)
+	return loop, ok, nil
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootloop
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// webhookQueueSize bounds the events waiting for delivery
+const webhookQueueSize = 256
+
+// WebhookNotifier POSTs each event as JSON to a URL. Events are queued and
+// sent in order by Run, so a slow receiver does not delay boot scripts; when
+// the queue is full new events are dropped.
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+	queue      chan Event
+	logger     *log.Logger
+}
+
+// NewWebhookNotifier creates a notifier to url
+func NewWebhookNotifier(url string, logger *log.Logger) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:        url,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		queue:      make(chan Event, webhookQueueSize),
+		logger:     logger,
+	}
+}
+
+// Notify queues event for delivery
+func (n *WebhookNotifier) Notify(event Event) {
+	select {
+	case n.queue <- event:
+	default:
+		n.logger.Printf("Boot loop webhook queue full; dropped event for node %s", event.Node)
+	}
+}
+
+// Run delivers queued events until ctx is done. An event the receiver
+// rejects is logged and skipped.
+func (n *WebhookNotifier) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-n.queue:
+			if err := n.send(ctx, event); err != nil && ctx.Err() == nil {
+				n.logger.Printf("Failed to deliver boot loop event for node %s to webhook: %v", event.Node, err)
+			}
+		}
+	}
+}
+
+func (n *WebhookNotifier) send(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package bootonce stores one-time boot overrides: a boot configuration, such
+// as memtest or a firmware update image, that a node boots on its next boot
+// script request only before returning to its own.
+//
+// Overrides are stored in the watched resource backend, so the audit log
+// records who set each one and when it was served. With shared storage,
+// replicas follow the overrides other replicas set and serve.
+package bootonce
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// ResourceType is the storage resource type of one-time boot overrides
+const ResourceType = "BootOnce"
+
+// ErrInvalidOverride is returned for an override that cannot be applied
+var ErrInvalidOverride = errors.New("invalid boot-once override")
+
+// Override is the configuration one node boots next
+type Override struct {
+	Node          string `json:"node"`
+	Configuration string `json:"configuration"`
+	Reason        string `json:"reason,omitempty"`
+	// RequestedBy is the token subject that set the override, if known
+	RequestedBy string    `json:"requestedBy,omitempty"`
+	RequestedAt time.Time `json:"requestedAt,omitzero"`
+	// ServedAt is when the node booted the override; zero while pending
+	ServedAt time.Time `json:"servedAt,omitzero"`
+}
+
+// Pending reports whether the node has yet to boot the override
+func (o Override) Pending() bool {
+	return o.ServedAt.IsZero()
+}
+
+// Store holds the overrides in memory and persists them in the resource
+// storage backend. A served override is kept, with its time, until the next
+// one replaces it. The writes of other replicas reach the store through
+// HandleResourceChange. It implements bootscript.BootOnce.
+type Store struct {
+	backend fabricaStorage.StorageBackend
+	logger  *log.Logger
+
+	// invalidate drops the cached scripts of a node given an override
+	invalidate func(node string)
+	now        func() time.Time
+
+	mu        sync.Mutex
+	overrides map[string]Override
+}
+
+// NewStore creates an override store persisted in backend. invalidate, which
+// may be nil, is called when an override is set. Call Load to restore the
+// stored overrides.
+func NewStore(backend fabricaStorage.StorageBackend, invalidate func(node string), logger *log.Logger) *Store {
+	if invalidate == nil {
+		invalidate = func(string) {}
+	}
+	return &Store{
+		backend:    backend,
+		logger:     logger,
+		invalidate: invalidate,
+		now:        time.Now,
+		overrides:  make(map[string]Override),
+	}
+}
+
+// Load restores the stored overrides
+func (s *Store) Load(ctx context.Context) error {
+	items, err := s.backend.LoadAll(ctx, ResourceType)
+	if err != nil {
+		return fmt.Errorf("loading boot-once overrides: %w", err)
+	}
+	overrides := make(map[string]Override, len(items))
+	pending := 0
+	for _, item := range items {
+		var override Override
+		if err := json.Unmarshal(item, &override); err != nil {
+			return fmt.Errorf("decoding boot-once override: %w", err)
+		}
+		overrides[override.Node] = override
+		if override.Pending() {
+			pending++
+		}
+	}
+
+	s.mu.Lock()
+	s.overrides = overrides
+	s.mu.Unlock()
+	if pending > 0 {
+		s.logger.Printf("%d nodes boot a one-time override next", pending)
+	}
+	return nil
+}
+
+// Set stores an override, replacing any earlier one of the node
+func (s *Store) Set(ctx context.Context, override Override) (Override, error) {
+	if override.Node == "" {
+		return Override{}, fmt.Errorf("%w: node is required", ErrInvalidOverride)
+	}
+	if override.Configuration == "" {
+		return Override{}, fmt.Errorf("%w: configuration is required", ErrInvalidOverride)
+	}
+	override.RequestedAt = s.now().UTC()
+	override.ServedAt = time.Time{}
+
+	s.mu.Lock()
+	if err := s.save(ctx, override); err != nil {
+		s.mu.Unlock()
+		return Override{}, err
+	}
+	s.overrides[override.Node] = override
+	s.mu.Unlock()
+
+	s.logger.Printf("Node %s boots %s once, set by %q: %s", override.Node, override.Configuration, override.RequestedBy, override.Reason)
+	s.invalidate(override.Node)
+	return override, nil
+}
+
+// Cancel drops the pending override of node. It reports whether there was
+// one.
+func (s *Store) Cancel(ctx context.Context, node string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	override, ok := s.overrides[node]
+	if !ok || !override.Pending() {
+		return false, nil
+	}
+	if err := s.backend.Delete(ctx, ResourceType, node); err != nil && !errors.Is(err, fabricaStorage.ErrNotFound) {
+		return false, fmt.Errorf("deleting boot-once override of node %s: %w", node, err)
+	}
+	delete(s.overrides, node)
+	s.logger.Printf("Boot-once override of node %s canceled", node)
+	return true, nil
+}
+
+// Get returns the latest override of node, pending or served
+func (s *Store) Get(node string) (Override, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	override, ok := s.overrides[node]
+	return override, ok
+}
+
+// Pending returns the configuration node boots next. It implements
+// bootscript.BootOnce.
+func (s *Store) Pending(node string) (string, bool) {
+	override, ok := s.Get(node)
+	if !ok || !override.Pending() {
+		return "", false
+	}
+	return override.Configuration, true
+}
+
+// Take returns the configuration node boots next and marks the override
+// served. The override is read again from storage first, so one another
+// replica has already served or canceled is not served twice. A served time
+// that cannot be stored is logged; the node still boots the override this
+// once. It implements bootscript.BootOnce.
+func (s *Store) Take(ctx context.Context, node string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	override, ok := s.overrides[node]
+	if !ok || !override.Pending() {
+		return "", false
+	}
+	stored, err := s.load(ctx, node)
+	switch {
+	case errors.Is(err, fabricaStorage.ErrNotFound):
+		delete(s.overrides, node)
+		return "", false
+	case err != nil:
+		s.logger.Printf("Failed to read boot-once override of node %s: %v", node, err)
+	default:
+		override = stored
+		if !override.Pending() {
+			s.overrides[node] = override
+			return "", false
+		}
+	}
+	override.ServedAt = s.now().UTC()
+	s.overrides[node] = override
+	if err := s.save(ctx, override); err != nil {
+		s.logger.Printf("Failed to record boot-once override of node %s as served: %v", node, err)
+	}
+	s.logger.Printf("Node %s booting one-time override %s", node, override.Configuration)
+	return override.Configuration, true
+}
+
+// HandleResourceChange follows the overrides other replicas write and drops
+// the overrides of deleted nodes
+func (s *Store) HandleResourceChange(ctx context.Context, event resourcewatch.Event) {
+	if event.ResourceType == ResourceType {
+		// The store's own writes are already applied
+		if resourcewatch.Remote(ctx) {
+			s.apply(event)
+		}
+		return
+	}
+	if event.ResourceType != "Node" || event.New != nil || event.Old == nil {
+		return
+	}
+	var node apiv1.Node
+	if json.Unmarshal(event.Old, &node) != nil || node.Spec.XName == "" {
+		return
+	}
+	name := node.Spec.XName
+
+	s.mu.Lock()
+	_, ok := s.overrides[name]
+	delete(s.overrides, name)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+	if err := s.backend.Delete(ctx, ResourceType, name); err != nil && !errors.Is(err, fabricaStorage.ErrNotFound) {
+		s.logger.Printf("Failed to delete boot-once override of node %s: %v", name, err)
+	}
+}
+
+// apply records an override write made by another replica
+func (s *Store) apply(event resourcewatch.Event) {
+	if event.New == nil {
+		s.mu.Lock()
+		delete(s.overrides, event.UID)
+		s.mu.Unlock()
+		return
+	}
+	var override Override
+	if err := json.Unmarshal(event.New, &override); err != nil {
+		s.logger.Printf("Ignoring undecodable boot-once override of node %s: %v", event.UID, err)
+		return
+	}
+	s.mu.Lock()
+	s.overrides[event.UID] = override
+	s.mu.Unlock()
+	if override.Pending() {
+		s.invalidate(event.UID)
+	}
+}
+
+// load reads the stored override of node
+func (s *Store) load(ctx context.Context, node string) (Override, error) {
+	data, err := s.backend.Load(ctx, ResourceType, node)
+	if err != nil {
+		return Override{}, err
+	}
+	var override Override
+	if err := json.Unmarshal(data, &override); err != nil {
+		return Override{}, fmt.Errorf("decoding boot-once override of node %s: %w", node, err)
+	}
+	return override, nil
+}
+
+// save stores override
+func (s *Store) save(ctx context.Context, override Override) error {
+	data, err := json.Marshal(override)
+	if err != nil {
+		return fmt.Errorf("encoding boot-once override of node %s: %w", override.Node, err)
+	}
+	if err := s.backend.Save(ctx, ResourceType, override.Node, data); err != nil {
+		return fmt.Errorf("saving boot-once override of node %s: %w", override.Node, err)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootonce
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"testing"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+func newTestStore(t *testing.T) (*Store, *resourcewatch.Backend, *[]resourcewatch.Event) {
+	t.Helper()
+	files, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	backend := resourcewatch.NewBackend(files)
+	var events []resourcewatch.Event
+	backend.Subscribe(func(_ context.Context, event resourcewatch.Event) {
+		events = append(events, event)
+	})
+	return NewStore(backend, nil, log.New(io.Discard, "", 0)), backend, &events
+}
+
+func TestStore_TakeServesOnce(t *testing.T) {
+	store, backend, events := newTestStore(t)
+	ctx := context.Background()
+
+	override, err := store.Set(ctx, Override{Node: "x0c0s0b0n0", Configuration: "memtest", Reason: "ECC errors"})
+	if err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if !override.Pending() || override.RequestedAt.IsZero() {
+		t.Errorf("override = %+v, want pending with a request time", override)
+	}
+	if config, ok := store.Pending("x0c0s0b0n0"); !ok || config != "memtest" {
+		t.Errorf("Pending = %q, %v; want memtest", config, ok)
+	}
+
+	if config, ok := store.Take(ctx, "x0c0s0b0n0"); !ok || config != "memtest" {
+		t.Fatalf("Take = %q, %v; want memtest", config, ok)
+	}
+	if config, ok := store.Take(ctx, "x0c0s0b0n0"); ok {
+		t.Errorf("second Take = %q, want nothing", config)
+	}
+	if _, ok := store.Pending("x0c0s0b0n0"); ok {
+		t.Error("served override still pending")
+	}
+
+	// The request and the boot are both written, for the audit log
+	if len(*events) != 2 || (*events)[0].Type != resourcewatch.Created || (*events)[1].Type != resourcewatch.Updated {
+		t.Errorf("events = %+v, want created then updated", *events)
+	}
+
+	restored := NewStore(backend, nil, log.New(io.Discard, "", 0))
+	if err := restored.Load(ctx); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	served, ok := restored.Get("x0c0s0b0n0")
+	if !ok || served.Pending() || served.Configuration != "memtest" {
+		t.Errorf("restored override = %+v, %v; want served memtest", served, ok)
+	}
+}
+
+func TestStore_Cancel(t *testing.T) {
+	store, _, _ := newTestStore(t)
+	ctx := context.Background()
+
+	if _, err := store.Set(ctx, Override{Node: "x0c0s0b0n0"}); !errors.Is(err, ErrInvalidOverride) {
+		t.Errorf("Set without configuration = %v, want ErrInvalidOverride", err)
+	}
+	if _, err := store.Set(ctx, Override{Node: "x0c0s0b0n0", Configuration: "memtest"}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if canceled, err := store.Cancel(ctx, "x0c0s0b0n0"); err != nil || !canceled {
+		t.Fatalf("Cancel = %v, %v; want true", canceled, err)
+	}
+	if _, ok := store.Take(ctx, "x0c0s0b0n0"); ok {
+		t.Error("canceled override was served")
+	}
+
+	// A served override cannot be canceled
+	if _, err := store.Set(ctx, Override{Node: "x0c0s0b0n0", Configuration: "memtest"}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	store.Take(ctx, "x0c0s0b0n0")
+	if canceled, err := store.Cancel(ctx, "x0c0s0b0n0"); err != nil || canceled {
+		t.Errorf("Cancel of a served override = %v, %v; want false", canceled, err)
+	}
+}
+
+func TestStore_DropsDeletedNodes(t *testing.T) {
+	store, _, _ := newTestStore(t)
+	ctx := context.Background()
+	if _, err := store.Set(ctx, Override{Node: "x0c0s0b0n0", Configuration: "memtest"}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+
+	var node apiv1.Node
+	node.Spec.XName = "x0c0s0b0n0"
+	data, _ := json.Marshal(node)
+	store.HandleResourceChange(ctx, resourcewatch.Event{Type: resourcewatch.Deleted, ResourceType: "Node", Old: data})
+	if _, ok := store.Get("x0c0s0b0n0"); ok {
+		t.Error("override of a deleted node was kept")
+	}
+}
+
+// fakeSource reports the writes of shared storage when told to
+type fakeSource struct {
+	fn resourcewatch.Subscriber
+}
+
+func (s *fakeSource) Watch(_ context.Context, fn resourcewatch.Subscriber) error {
+	s.fn = fn
+	return nil
+}
+
+func TestStore_FollowsOtherReplicas(t *testing.T) {
+	ctx := context.Background()
+	files, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	// Two replicas share storage, which reports each one's writes to the
+	// other
+	source := &fakeSource{}
+	local, remote := resourcewatch.NewBackend(files), resourcewatch.NewBackend(files)
+	if err := remote.Follow(ctx, source); err != nil {
+		t.Fatalf("Follow returned error: %v", err)
+	}
+	local.Subscribe(func(ctx context.Context, event resourcewatch.Event) { source.fn(ctx, event) })
+	var invalidated []string
+	setter := NewStore(local, nil, log.New(io.Discard, "", 0))
+	replica := NewStore(remote, func(node string) { invalidated = append(invalidated, node) }, log.New(io.Discard, "", 0))
+	remote.Subscribe(replica.HandleResourceChange)
+
+	if _, err := setter.Set(ctx, Override{Node: "x0c0s0b0n0", Configuration: "memtest"}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if config, ok := replica.Pending("x0c0s0b0n0"); !ok || config != "memtest" {
+		t.Fatalf("Pending on another replica = %q, %v; want memtest", config, ok)
+	}
+	if len(invalidated) != 1 || invalidated[0] != "x0c0s0b0n0" {
+		t.Errorf("invalidated = %v, want the node's scripts", invalidated)
+	}
+
+	// The override is served once, by whichever replica the node asks
+	if config, ok := replica.Take(ctx, "x0c0s0b0n0"); !ok || config != "memtest" {
+		t.Fatalf("Take on another replica = %q, %v; want memtest", config, ok)
+	}
+	if config, ok := setter.Take(ctx, "x0c0s0b0n0"); ok {
+		t.Errorf("Take after another replica served the override = %q, want nothing", config)
+	}
+
+	if _, err := setter.Set(ctx, Override{Node: "x0c0s0b0n0", Configuration: "memtest"}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if _, err := setter.Cancel(ctx, "x0c0s0b0n0"); err != nil {
+		t.Fatalf("Cancel returned error: %v", err)
+	}
+	if _, ok := replica.Get("x0c0s0b0n0"); ok {
+		t.Error("override canceled by another replica was kept")
+	}
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// API is the part of the boot API used by the boot script controllers, node
+// provider integrations, and legacy handlers. *Client implements it over HTTP
+// and *InProcessClient directly against storage.
+type API interface {
+	GetNodes(ctx context.Context) ([]v1.Node, error)
+	GetNode(ctx context.Context, uid string) (*v1.Node, error)
+	CreateNode(ctx context.Context, req CreateNodeRequest) (*v1.Node, error)
+	UpdateNode(ctx context.Context, uid string, req UpdateNodeRequest) (*v1.Node, error)
+	UpdateNodeStatus(ctx context.Context, uid string, status v1.NodeStatus) (*v1.Node, error)
+	DeleteNode(ctx context.Context, uid string) error
+
+	GetBootConfigurations(ctx context.Context) ([]v1.BootConfiguration, error)
+	GetBootConfiguration(ctx context.Context, uid string) (*v1.BootConfiguration, error)
+	CreateBootConfiguration(ctx context.Context, req CreateBootConfigurationRequest) (*v1.BootConfiguration, error)
+	UpdateBootConfiguration(ctx context.Context, uid string, req UpdateBootConfigurationRequest) (*v1.BootConfiguration, error)
+	DeleteBootConfiguration(ctx context.Context, uid string) error
+}
+
+// BMCAPI is the part of the boot API used to manage BMCs, such as by BMC
+// discovery. *Client and *InProcessClient implement it.
+type BMCAPI interface {
+	GetBMCs(ctx context.Context) ([]v1.BMC, error)
+	CreateBMC(ctx context.Context, req CreateBMCRequest) (*v1.BMC, error)
+	UpdateBMC(ctx context.Context, uid string, req UpdateBMCRequest) (*v1.BMC, error)
+	UpdateBMCStatus(ctx context.Context, uid string, status v1.BMCStatus) (*v1.BMC, error)
+}
+
+var (
+	_ API    = (*Client)(nil)
+	_ API    = (*InProcessClient)(nil)
+	_ BMCAPI = (*Client)(nil)
+	_ BMCAPI = (*InProcessClient)(nil)
+)
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by *APIError through errors.Is, for example
+// errors.Is(err, client.ErrNotFound) after GetNode.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+)
+
+// APIError is an HTTP error response from the boot service. Clients created
+// with NewClientWithOptions return it, wrapped, for every status of 400 and
+// above.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	Message    string
+}
+
+// Error implements error
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("API error (%d): %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
+}
+
+// Is reports whether the status code corresponds to target
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict || e.StatusCode == http.StatusPreconditionFailed
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// newAPIError builds an APIError from an error response body. Both the
+// generated {"error": ...} body and the problem body of the boot API handlers
+// are understood.
+func newAPIError(req *http.Request, statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Method: req.Method, URL: req.URL.Redacted()}
+
+	var decoded struct {
+		Error  string `json:"error"`
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	}
+	switch {
+	case json.Unmarshal(body, &decoded) != nil:
+		apiErr.Message = strings.TrimSpace(string(body))
+	case decoded.Error != "":
+		apiErr.Message = decoded.Error
+	case decoded.Detail != "":
+		apiErr.Message = decoded.Detail
+	default:
+		apiErr.Message = decoded.Title
+	}
+	return apiErr
+}
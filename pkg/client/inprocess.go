@@ -0,0 +1,355 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/fabrica"
+	"github.com/openchami/fabrica/pkg/resource"
+	"github.com/openchami/fabrica/pkg/validation"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/storage"
+)
+
+// apiVersion is the apiVersion of resources created in-process
+const apiVersion = "boot.openchami.io/v1"
+
+// InProcessClient implements API directly against the storage backend of the
+// running server, applying the same validation, metadata, and events as the
+// generated HTTP handlers. Errors are *APIError values with the status the
+// HTTP API would have returned, so errors.Is(err, ErrNotFound) works with
+// either client.
+type InProcessClient struct{}
+
+// NewInProcessClient creates a client for the storage initialized with
+// storage.Init or storage.InitFileBackend
+func NewInProcessClient() *InProcessClient {
+	return &InProcessClient{}
+}
+
+// GetNodes returns all nodes
+func (c *InProcessClient) GetNodes(ctx context.Context) ([]v1.Node, error) {
+	nodes, err := storage.LoadAllNodes(ctx)
+	if err != nil {
+		return nil, inProcessError(http.MethodGet, "/nodes", http.StatusInternalServerError, "failed to load nodes: %v", err)
+	}
+	result := make([]v1.Node, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, *node)
+	}
+	return result, nil
+}
+
+// GetNode returns a node by UID
+func (c *InProcessClient) GetNode(ctx context.Context, uid string) (*v1.Node, error) {
+	node, err := storage.LoadNode(ctx, uid)
+	if err != nil {
+		return nil, inProcessError(http.MethodGet, "/nodes/"+uid, http.StatusNotFound, "Node not found: %v", err)
+	}
+	return node, nil
+}
+
+// CreateNode creates a node
+func (c *InProcessClient) CreateNode(ctx context.Context, req CreateNodeRequest) (*v1.Node, error) {
+	if err := validation.ValidateResource(&req); err != nil {
+		return nil, inProcessError(http.MethodPost, "/nodes", http.StatusBadRequest, "validation failed: %v", err)
+	}
+	node := &v1.Node{APIVersion: apiVersion, Kind: "Node", Spec: req.Spec}
+	if err := newMetadata(&node.Metadata, "Node", req.Metadata, req.Labels, req.Annotations); err != nil {
+		return nil, inProcessError(http.MethodPost, "/nodes", http.StatusInternalServerError, "%v", err)
+	}
+	if err := validation.ValidateWithContext(ctx, node); err != nil {
+		return nil, inProcessError(http.MethodPost, "/nodes", http.StatusBadRequest, "validation failed: %v", err)
+	}
+	if err := storage.SaveNode(ctx, node); err != nil {
+		return nil, inProcessError(http.MethodPost, "/nodes", http.StatusInternalServerError, "failed to save Node: %v", err)
+	}
+	if err := events.PublishResourceCreated(ctx, "Node", node.Metadata.UID, node.Metadata.Name, node); err != nil {
+		log.Printf("Warning: Failed to publish resource created event for Node %s: %v", node.Metadata.UID, err)
+	}
+	return node, nil
+}
+
+// UpdateNode replaces the spec of a node and merges its labels and annotations
+func (c *InProcessClient) UpdateNode(ctx context.Context, uid string, req UpdateNodeRequest) (*v1.Node, error) {
+	node, err := c.GetNode(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	node.Spec = req.Spec
+	updateMetadata(&node.Metadata, req.Metadata, req.Labels, req.Annotations)
+	if err := validation.ValidateWithContext(ctx, node); err != nil {
+		return nil, inProcessError(http.MethodPut, "/nodes/"+uid, http.StatusBadRequest, "validation failed: %v", err)
+	}
+	if err := storage.SaveNode(ctx, node); err != nil {
+		return nil, inProcessError(http.MethodPut, "/nodes/"+uid, http.StatusInternalServerError, "failed to save Node: %v", err)
+	}
+	if err := events.PublishResourceUpdated(ctx, "Node", node.Metadata.UID, node.Metadata.Name, node,
+		map[string]interface{}{"updatedAt": node.Metadata.UpdatedAt}); err != nil {
+		log.Printf("Warning: Failed to publish resource updated event for Node %s: %v", node.Metadata.UID, err)
+	}
+	return node, nil
+}
+
+// UpdateNodeStatus replaces the status of a node, leaving its spec alone
+func (c *InProcessClient) UpdateNodeStatus(ctx context.Context, uid string, status v1.NodeStatus) (*v1.Node, error) {
+	node, err := c.GetNode(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	node.Status = status
+	node.Metadata.UpdatedAt = time.Now()
+	if err := validation.ValidateWithContext(ctx, node); err != nil {
+		return nil, inProcessError(http.MethodPut, "/nodes/"+uid+"/status", http.StatusBadRequest, "validation failed: %v", err)
+	}
+	if err := storage.SaveNode(ctx, node); err != nil {
+		return nil, inProcessError(http.MethodPut, "/nodes/"+uid+"/status", http.StatusInternalServerError, "failed to save Node status: %v", err)
+	}
+	if err := events.PublishResourceUpdated(ctx, "Node", node.Metadata.UID, node.Metadata.Name, node,
+		map[string]interface{}{"updatedAt": node.Metadata.UpdatedAt, "updateType": "status"}); err != nil {
+		log.Printf("Warning: Failed to publish status update event for Node %s: %v", node.Metadata.UID, err)
+	}
+	return node, nil
+}
+
+// DeleteNode deletes a node by UID
+func (c *InProcessClient) DeleteNode(ctx context.Context, uid string) error {
+	node, err := c.GetNode(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if err := storage.DeleteNode(ctx, uid); err != nil {
+		return inProcessError(http.MethodDelete, "/nodes/"+uid, http.StatusInternalServerError, "failed to delete Node: %v", err)
+	}
+	if err := events.PublishResourceDeleted(ctx, "Node", uid, node.Metadata.Name,
+		map[string]interface{}{"deletedAt": time.Now()}); err != nil {
+		log.Printf("Warning: Failed to publish resource deleted event for Node %s: %v", uid, err)
+	}
+	return nil
+}
+
+// GetBootConfigurations returns all boot configurations
+func (c *InProcessClient) GetBootConfigurations(ctx context.Context) ([]v1.BootConfiguration, error) {
+	configs, err := storage.LoadAllBootConfigurations(ctx)
+	if err != nil {
+		return nil, inProcessError(http.MethodGet, "/bootconfigurations", http.StatusInternalServerError,
+			"failed to load bootconfigurations: %v", err)
+	}
+	result := make([]v1.BootConfiguration, 0, len(configs))
+	for _, config := range configs {
+		result = append(result, *config)
+	}
+	return result, nil
+}
+
+// GetBootConfiguration returns a boot configuration by UID
+func (c *InProcessClient) GetBootConfiguration(ctx context.Context, uid string) (*v1.BootConfiguration, error) {
+	config, err := storage.LoadBootConfiguration(ctx, uid)
+	if err != nil {
+		return nil, inProcessError(http.MethodGet, "/bootconfigurations/"+uid, http.StatusNotFound,
+			"BootConfiguration not found: %v", err)
+	}
+	return config, nil
+}
+
+// CreateBootConfiguration creates a boot configuration
+func (c *InProcessClient) CreateBootConfiguration(ctx context.Context, req CreateBootConfigurationRequest) (*v1.BootConfiguration, error) {
+	const endpoint = "/bootconfigurations"
+	if err := validation.ValidateResource(&req); err != nil {
+		return nil, inProcessError(http.MethodPost, endpoint, http.StatusBadRequest, "validation failed: %v", err)
+	}
+	config := &v1.BootConfiguration{APIVersion: apiVersion, Kind: "BootConfiguration", Spec: req.Spec}
+	if err := newMetadata(&config.Metadata, "BootConfiguration", req.Metadata, req.Labels, req.Annotations); err != nil {
+		return nil, inProcessError(http.MethodPost, endpoint, http.StatusInternalServerError, "%v", err)
+	}
+	if err := validation.ValidateWithContext(ctx, config); err != nil {
+		return nil, inProcessError(http.MethodPost, endpoint, http.StatusBadRequest, "validation failed: %v", err)
+	}
+	if err := storage.SaveBootConfiguration(ctx, config); err != nil {
+		return nil, inProcessError(http.MethodPost, endpoint, http.StatusInternalServerError,
+			"failed to save BootConfiguration: %v", err)
+	}
+	if err := events.PublishResourceCreated(ctx, "BootConfiguration", config.Metadata.UID, config.Metadata.Name, config); err != nil {
+		log.Printf("Warning: Failed to publish resource created event for BootConfiguration %s: %v", config.Metadata.UID, err)
+	}
+	return config, nil
+}
+
+// UpdateBootConfiguration replaces the spec of a boot configuration and
+// merges its labels and annotations
+func (c *InProcessClient) UpdateBootConfiguration(ctx context.Context, uid string, req UpdateBootConfigurationRequest) (*v1.BootConfiguration, error) {
+	endpoint := "/bootconfigurations/" + uid
+	config, err := c.GetBootConfiguration(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	config.Spec = req.Spec
+	updateMetadata(&config.Metadata, req.Metadata, req.Labels, req.Annotations)
+	if err := validation.ValidateWithContext(ctx, config); err != nil {
+		return nil, inProcessError(http.MethodPut, endpoint, http.StatusBadRequest, "validation failed: %v", err)
+	}
+	if err := storage.SaveBootConfiguration(ctx, config); err != nil {
+		return nil, inProcessError(http.MethodPut, endpoint, http.StatusInternalServerError,
+			"failed to save BootConfiguration: %v", err)
+	}
+	if err := events.PublishResourceUpdated(ctx, "BootConfiguration", config.Metadata.UID, config.Metadata.Name, config,
+		map[string]interface{}{"updatedAt": config.Metadata.UpdatedAt}); err != nil {
+		log.Printf("Warning: Failed to publish resource updated event for BootConfiguration %s: %v", config.Metadata.UID, err)
+	}
+	return config, nil
+}
+
+// DeleteBootConfiguration deletes a boot configuration by UID
+func (c *InProcessClient) DeleteBootConfiguration(ctx context.Context, uid string) error {
+	config, err := c.GetBootConfiguration(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if err := storage.DeleteBootConfiguration(ctx, uid); err != nil {
+		return inProcessError(http.MethodDelete, "/bootconfigurations/"+uid, http.StatusInternalServerError,
+			"failed to delete BootConfiguration: %v", err)
+	}
+	if err := events.PublishResourceDeleted(ctx, "BootConfiguration", uid, config.Metadata.Name,
+		map[string]interface{}{"deletedAt": time.Now()}); err != nil {
+		log.Printf("Warning: Failed to publish resource deleted event for BootConfiguration %s: %v", uid, err)
+	}
+	return nil
+}
+
+// GetBMCs returns all BMCs
+func (c *InProcessClient) GetBMCs(ctx context.Context) ([]v1.BMC, error) {
+	bmcs, err := storage.LoadAllBMCs(ctx)
+	if err != nil {
+		return nil, inProcessError(http.MethodGet, "/bmcs", http.StatusInternalServerError, "failed to load bmcs: %v", err)
+	}
+	result := make([]v1.BMC, 0, len(bmcs))
+	for _, bmc := range bmcs {
+		result = append(result, *bmc)
+	}
+	return result, nil
+}
+
+// GetBMC returns a BMC by UID
+func (c *InProcessClient) GetBMC(ctx context.Context, uid string) (*v1.BMC, error) {
+	bmc, err := storage.LoadBMC(ctx, uid)
+	if err != nil {
+		return nil, inProcessError(http.MethodGet, "/bmcs/"+uid, http.StatusNotFound, "BMC not found: %v", err)
+	}
+	return bmc, nil
+}
+
+// CreateBMC creates a BMC
+func (c *InProcessClient) CreateBMC(ctx context.Context, req CreateBMCRequest) (*v1.BMC, error) {
+	if err := validation.ValidateResource(&req); err != nil {
+		return nil, inProcessError(http.MethodPost, "/bmcs", http.StatusBadRequest, "validation failed: %v", err)
+	}
+	bmc := &v1.BMC{APIVersion: apiVersion, Kind: "BMC", Spec: req.Spec}
+	if err := newMetadata(&bmc.Metadata, "BMC", req.Metadata, req.Labels, req.Annotations); err != nil {
+		return nil, inProcessError(http.MethodPost, "/bmcs", http.StatusInternalServerError, "%v", err)
+	}
+	if err := validation.ValidateWithContext(ctx, bmc); err != nil {
+		return nil, inProcessError(http.MethodPost, "/bmcs", http.StatusBadRequest, "validation failed: %v", err)
+	}
+	if err := storage.SaveBMC(ctx, bmc); err != nil {
+		return nil, inProcessError(http.MethodPost, "/bmcs", http.StatusInternalServerError, "failed to save BMC: %v", err)
+	}
+	if err := events.PublishResourceCreated(ctx, "BMC", bmc.Metadata.UID, bmc.Metadata.Name, bmc); err != nil {
+		log.Printf("Warning: Failed to publish resource created event for BMC %s: %v", bmc.Metadata.UID, err)
+	}
+	return bmc, nil
+}
+
+// UpdateBMC replaces the spec of a BMC and merges its labels and annotations
+func (c *InProcessClient) UpdateBMC(ctx context.Context, uid string, req UpdateBMCRequest) (*v1.BMC, error) {
+	bmc, err := c.GetBMC(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	bmc.Spec = req.Spec
+	updateMetadata(&bmc.Metadata, req.Metadata, req.Labels, req.Annotations)
+	if err := validation.ValidateWithContext(ctx, bmc); err != nil {
+		return nil, inProcessError(http.MethodPut, "/bmcs/"+uid, http.StatusBadRequest, "validation failed: %v", err)
+	}
+	if err := storage.SaveBMC(ctx, bmc); err != nil {
+		return nil, inProcessError(http.MethodPut, "/bmcs/"+uid, http.StatusInternalServerError, "failed to save BMC: %v", err)
+	}
+	if err := events.PublishResourceUpdated(ctx, "BMC", bmc.Metadata.UID, bmc.Metadata.Name, bmc,
+		map[string]interface{}{"updatedAt": bmc.Metadata.UpdatedAt}); err != nil {
+		log.Printf("Warning: Failed to publish resource updated event for BMC %s: %v", bmc.Metadata.UID, err)
+	}
+	return bmc, nil
+}
+
+// UpdateBMCStatus replaces the status of a BMC, leaving its spec alone
+func (c *InProcessClient) UpdateBMCStatus(ctx context.Context, uid string, status v1.BMCStatus) (*v1.BMC, error) {
+	bmc, err := c.GetBMC(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	bmc.Status = status
+	bmc.Metadata.UpdatedAt = time.Now()
+	if err := storage.SaveBMC(ctx, bmc); err != nil {
+		return nil, inProcessError(http.MethodPut, "/bmcs/"+uid+"/status", http.StatusInternalServerError, "failed to save BMC status: %v", err)
+	}
+	if err := events.PublishResourceUpdated(ctx, "BMC", bmc.Metadata.UID, bmc.Metadata.Name, bmc,
+		map[string]interface{}{"updatedAt": bmc.Metadata.UpdatedAt, "updateType": "status"}); err != nil {
+		log.Printf("Warning: Failed to publish status update event for BMC %s: %v", bmc.Metadata.UID, err)
+	}
+	return bmc, nil
+}
+
+// newMetadata initializes the metadata of a created resource like the
+// generated create handlers: a new UID, timestamps, and request labels and
+// annotations
+func newMetadata(meta *fabrica.Metadata, kind string, req fabrica.Metadata, labels, annotations map[string]string) error {
+	uid, err := resource.GenerateUIDForResource(kind)
+	if err != nil {
+		return fmt.Errorf("failed to generate UID: %w", err)
+	}
+	*meta = req
+	meta.UID = uid
+	now := time.Now()
+	meta.CreatedAt = now
+	meta.UpdatedAt = now
+	mergeLabels(meta, labels, annotations)
+	return nil
+}
+
+// updateMetadata applies an update request's metadata like the generated
+// update handlers
+func updateMetadata(meta *fabrica.Metadata, req fabrica.Metadata, labels, annotations map[string]string) {
+	if req.Name != "" {
+		meta.Name = req.Name
+	}
+	mergeLabels(meta, labels, annotations)
+	meta.UpdatedAt = time.Now()
+}
+
+func mergeLabels(meta *fabrica.Metadata, labels, annotations map[string]string) {
+	if meta.Labels == nil {
+		meta.Labels = make(map[string]string)
+	}
+	for k, v := range labels {
+		meta.Labels[k] = v
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = make(map[string]string)
+	}
+	for k, v := range annotations {
+		meta.Annotations[k] = v
+	}
+}
+
+// inProcessError returns the *APIError the HTTP API would have returned
+func inProcessError(method, endpoint string, status int, format string, args ...interface{}) *APIError {
+	return &APIError{StatusCode: status, Method: method, URL: endpoint, Message: fmt.Sprintf(format, args...)}
+}
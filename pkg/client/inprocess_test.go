@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/fabrica"
+	"github.com/openchami/fabrica/pkg/resource"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/storage"
+)
+
+func init() {
+	resource.RegisterResourcePrefix("Node", "node")
+	resource.RegisterResourcePrefix("BootConfiguration", "bootconfiguration")
+	resource.RegisterResourcePrefix("BMC", "bmc")
+}
+
+func TestInProcessClient_Nodes(t *testing.T) {
+	if err := storage.InitFileBackend(t.TempDir()); err != nil {
+		t.Fatalf("failed to initialize storage: %v", err)
+	}
+	ctx := context.Background()
+	c := NewInProcessClient()
+
+	created, err := c.CreateNode(ctx, CreateNodeRequest{
+		Metadata: fabrica.Metadata{Name: "x1000c0s0b0n0"},
+		Spec:     v1.NodeSpec{XName: "x1000c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:01"},
+		Labels:   map[string]string{"rack": "1"},
+	})
+	if err != nil {
+		t.Fatalf("CreateNode failed: %v", err)
+	}
+	if created.Metadata.UID == "" || created.Kind != "Node" || created.Metadata.Labels["rack"] != "1" || created.Metadata.CreatedAt.IsZero() {
+		t.Errorf("unexpected created node: %+v", created)
+	}
+
+	updated, err := c.UpdateNode(ctx, created.Metadata.UID, UpdateNodeRequest{
+		Spec: v1.NodeSpec{XName: "x1000c0s0b0n0", NID: 2, BootMAC: "aa:bb:cc:dd:ee:01"},
+	})
+	if err != nil || updated.Spec.NID != 2 || updated.Metadata.Labels["rack"] != "1" {
+		t.Fatalf("UpdateNode() = %+v, %v", updated, err)
+	}
+
+	if _, err := c.UpdateNodeStatus(ctx, created.Metadata.UID, v1.NodeStatus{State: "ready"}); err != nil {
+		t.Fatalf("UpdateNodeStatus failed: %v", err)
+	}
+
+	nodes, err := c.GetNodes(ctx)
+	if err != nil || len(nodes) != 1 || nodes[0].Spec.NID != 2 || nodes[0].Status.State != "ready" {
+		t.Fatalf("GetNodes() = %+v, %v", nodes, err)
+	}
+
+	if _, err := c.CreateNode(ctx, CreateNodeRequest{Spec: v1.NodeSpec{XName: "bogus"}}); statusOf(err) != 400 {
+		t.Errorf("expected 400 for invalid node, got %v", err)
+	}
+
+	if err := c.DeleteNode(ctx, created.Metadata.UID); err != nil {
+		t.Fatalf("DeleteNode failed: %v", err)
+	}
+	if _, err := c.GetNode(ctx, created.Metadata.UID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+	if err := c.DeleteNode(ctx, created.Metadata.UID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound deleting a missing node, got %v", err)
+	}
+}
+
+func TestInProcessClient_BootConfigurations(t *testing.T) {
+	if err := storage.InitFileBackend(t.TempDir()); err != nil {
+		t.Fatalf("failed to initialize storage: %v", err)
+	}
+	ctx := context.Background()
+	c := NewInProcessClient()
+
+	created, err := c.CreateBootConfiguration(ctx, CreateBootConfigurationRequest{
+		Metadata: fabrica.Metadata{Name: "compute"},
+		Spec:     v1.BootConfigurationSpec{Kernel: "http://files/vmlinuz", Groups: []string{"compute"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateBootConfiguration failed: %v", err)
+	}
+
+	updated, err := c.UpdateBootConfiguration(ctx, created.Metadata.UID, UpdateBootConfigurationRequest{
+		Metadata: fabrica.Metadata{Name: "compute-v2"},
+		Spec:     v1.BootConfigurationSpec{Kernel: "http://files/vmlinuz-2", Groups: []string{"compute"}},
+	})
+	if err != nil || updated.Metadata.Name != "compute-v2" || updated.Spec.Kernel != "http://files/vmlinuz-2" {
+		t.Fatalf("UpdateBootConfiguration() = %+v, %v", updated, err)
+	}
+	if _, err := c.UpdateBootConfiguration(ctx, created.Metadata.UID, UpdateBootConfigurationRequest{}); statusOf(err) != 400 {
+		t.Errorf("expected 400 for configuration without kernel, got %v", err)
+	}
+
+	configs, err := c.GetBootConfigurations(ctx)
+	if err != nil || len(configs) != 1 || configs[0].Metadata.Name != "compute-v2" {
+		t.Fatalf("GetBootConfigurations() = %+v, %v", configs, err)
+	}
+	if err := c.DeleteBootConfiguration(ctx, created.Metadata.UID); err != nil {
+		t.Fatalf("DeleteBootConfiguration failed: %v", err)
+	}
+	if _, err := c.GetBootConfiguration(ctx, created.Metadata.UID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestInProcessClient_BMCs(t *testing.T) {
+	if err := storage.InitFileBackend(t.TempDir()); err != nil {
+		t.Fatalf("failed to initialize storage: %v", err)
+	}
+	ctx := context.Background()
+	c := NewInProcessClient()
+
+	created, err := c.CreateBMC(ctx, CreateBMCRequest{
+		Metadata: fabrica.Metadata{Name: "x1000c0s0b0"},
+		Spec:     v1.BMCSpec{XName: "x1000c0s0b0", Interface: v1.BMCInterface{MAC: "AA:BB:CC:DD:EE:10"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateBMC failed: %v", err)
+	}
+	if created.Kind != "BMC" || created.Spec.Interface.MAC != "aa:bb:cc:dd:ee:10" {
+		t.Errorf("unexpected created BMC: %+v", created)
+	}
+
+	updated, err := c.UpdateBMC(ctx, created.Metadata.UID, UpdateBMCRequest{
+		Spec: v1.BMCSpec{XName: "x1000c0s0b0", Interface: v1.BMCInterface{MAC: "aa:bb:cc:dd:ee:10", IP: "10.0.0.10"}},
+	})
+	if err != nil || updated.Spec.Interface.IP != "10.0.0.10" {
+		t.Fatalf("UpdateBMC() = %+v, %v", updated, err)
+	}
+	if _, err := c.UpdateBMC(ctx, created.Metadata.UID, UpdateBMCRequest{Spec: v1.BMCSpec{XName: "x1000c0s0b0n0"}}); statusOf(err) != 400 {
+		t.Errorf("expected 400 for a node xname, got %v", err)
+	}
+	if _, err := c.UpdateBMCStatus(ctx, created.Metadata.UID, v1.BMCStatus{Ready: true}); err != nil {
+		t.Fatalf("UpdateBMCStatus failed: %v", err)
+	}
+
+	bmcs, err := c.GetBMCs(ctx)
+	if err != nil || len(bmcs) != 1 || bmcs[0].Spec.Interface.IP != "10.0.0.10" || !bmcs[0].Status.Ready {
+		t.Fatalf("GetBMCs() = %+v, %v", bmcs, err)
+	}
+	if _, err := c.GetBMC(ctx, "bmc-missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a missing BMC, got %v", err)
+	}
+}
+
+// statusOf returns the status code of an *APIError, or 0
+func statusOf(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
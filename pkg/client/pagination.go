@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// DefaultPageSize is the page size used by the iterators when none is given
+const DefaultPageSize = 100
+
+// ListOptions selects one page of a list endpoint. Resources are ordered by
+// UID; After is the UID of the last resource of the previous page.
+type ListOptions struct {
+	Limit int
+	After string
+}
+
+// ListNodes returns one page of nodes
+func (c *Client) ListNodes(ctx context.Context, opts ListOptions) ([]v1.Node, error) {
+	var nodes []v1.Node
+	if err := c.listPage(ctx, "/nodes", opts, &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// ListBootConfigurations returns one page of boot configurations
+func (c *Client) ListBootConfigurations(ctx context.Context, opts ListOptions) ([]v1.BootConfiguration, error) {
+	var configs []v1.BootConfiguration
+	if err := c.listPage(ctx, "/bootconfigurations", opts, &configs); err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
+
+// AllNodes iterates over all nodes, fetching pageSize nodes per request.
+// Iteration stops after the first error.
+func (c *Client) AllNodes(ctx context.Context, pageSize int) iter.Seq2[v1.Node, error] {
+	return paginate(ctx, pageSize, c.ListNodes, func(node v1.Node) string { return node.Metadata.UID })
+}
+
+// AllBootConfigurations iterates over all boot configurations, fetching
+// pageSize configurations per request. Iteration stops after the first error.
+func (c *Client) AllBootConfigurations(ctx context.Context, pageSize int) iter.Seq2[v1.BootConfiguration, error] {
+	return paginate(ctx, pageSize, c.ListBootConfigurations,
+		func(config v1.BootConfiguration) string { return config.Metadata.UID })
+}
+
+// paginate requests pages until one is shorter than pageSize
+func paginate[T any](ctx context.Context, pageSize int, list func(context.Context, ListOptions) ([]T, error), uid func(T) string) iter.Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return func(yield func(T, error) bool) {
+		opts := ListOptions{Limit: pageSize}
+		for {
+			page, err := list(ctx, opts)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if len(page) < pageSize {
+				return
+			}
+			opts.After = uid(page[len(page)-1])
+		}
+	}
+}
+
+// listPage performs a GET with limit and after query parameters. The
+// generated doRequest cannot carry a query string, so the request is built
+// here with the same headers.
+func (c *Client) listPage(ctx context.Context, endpoint string, opts ListOptions, result interface{}) error {
+	u := *c.baseURL
+	u.Path = path.Join(u.Path, endpoint)
+	query := url.Values{}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.After != "" {
+		query.Set("after", opts.After)
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	acceptType := "application/json"
+	if c.version != "" {
+		acceptType = fmt.Sprintf("application/json;version=%s", c.version)
+	}
+	req.Header.Set("Accept", acceptType)
+	if c.bearerToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return newAPIError(req, resp.StatusCode, body)
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
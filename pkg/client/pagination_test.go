@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/resource"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// pagedNodesServer serves count nodes with UIDs node-00 through node-NN using
+// the limit and after query parameters
+func pagedNodesServer(t *testing.T, count int, requests *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RawQuery)
+		if r.URL.Path != "/nodes" {
+			http.NotFound(w, r)
+			return
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		after := r.URL.Query().Get("after")
+		page := []v1.Node{}
+		for i := 0; i < count && len(page) < limit; i++ {
+			uid := fmt.Sprintf("node-%02d", i)
+			if uid > after {
+				page = append(page, v1.Node{Metadata: resource.Metadata{UID: uid}})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+}
+
+func TestAllNodes(t *testing.T) {
+	var requests []string
+	server := pagedNodesServer(t, 5, &requests)
+	defer server.Close()
+
+	c, _ := NewClient(server.URL, nil, DefaultLogger())
+	var uids []string
+	for node, err := range c.AllNodes(context.Background(), 2) {
+		if err != nil {
+			t.Fatalf("iteration failed: %v", err)
+		}
+		uids = append(uids, node.Metadata.UID)
+	}
+
+	if fmt.Sprint(uids) != "[node-00 node-01 node-02 node-03 node-04]" {
+		t.Errorf("got %v", uids)
+	}
+	want := []string{"limit=2", "after=node-01&limit=2", "after=node-03&limit=2"}
+	if fmt.Sprint(requests) != fmt.Sprint(want) {
+		t.Errorf("got requests %q, want %q", requests, want)
+	}
+
+	// Breaking out of the loop stops fetching pages
+	requests = nil
+	for range c.AllNodes(context.Background(), 2) {
+		break
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected one request after break, got %q", requests)
+	}
+}
+
+func TestAllBootConfigurations_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"token expired"}`))
+	}))
+	defer server.Close()
+
+	c, _ := NewClient(server.URL, nil, DefaultLogger())
+	var errs int
+	for _, err := range c.AllBootConfigurations(context.Background(), 0) {
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("expected ErrUnauthorized, got %v", err)
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("got %d results, want a single error", errs)
+	}
+}
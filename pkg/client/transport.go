@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// maxErrorBody bounds how much of an error response is read into an APIError
+const maxErrorBody = 64 << 10
+
+// TokenSource returns the bearer token for a request. It is called for every
+// attempt, so it can return a refreshed token.
+type TokenSource func(ctx context.Context) (string, error)
+
+// RetryPolicy controls retries of failed requests
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per request; 1 disables
+	// retries and 0 uses the default
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry; it doubles on every
+	// further retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts, including waits requested by
+	// a Retry-After header
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+	}
+}
+
+// Options configures NewClientWithOptions
+type Options struct {
+	// HTTPClient is the client whose transport is wrapped. Its Timeout, if
+	// any, covers all attempts of a request. Defaults to a client with a 30
+	// second timeout.
+	HTTPClient *http.Client
+	// Retry is the retry policy; the zero value uses DefaultRetryPolicy
+	Retry RetryPolicy
+	// BearerToken is sent as Authorization: Bearer <token>
+	BearerToken string
+	// TokenSource supplies the bearer token per request instead of
+	// BearerToken, for tokens that expire
+	TokenSource TokenSource
+}
+
+// NewClientWithOptions creates a client that retries transient failures,
+// returns *APIError for error responses, and authenticates with a bearer
+// token. Use it instead of NewClient in services that consume the boot API.
+func NewClientWithOptions(baseURL string, opts Options, logger zerolog.Logger) (*Client, error) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if opts.HTTPClient != nil {
+		copied := *opts.HTTPClient
+		httpClient = &copied
+	}
+	httpClient.Transport = &Transport{
+		Base:        httpClient.Transport,
+		Retry:       opts.Retry,
+		TokenSource: opts.TokenSource,
+	}
+
+	c, err := NewClient(baseURL, httpClient, logger)
+	if err != nil {
+		return nil, err
+	}
+	if opts.BearerToken != "" {
+		c = c.WithBearerToken(opts.BearerToken)
+	}
+	return c, nil
+}
+
+// Transport is an http.RoundTripper that retries transient failures with
+// exponential backoff and turns error responses into *APIError.
+//
+// Network errors and 502, 503, and 504 responses are retried for idempotent
+// methods (GET, HEAD, OPTIONS, PUT, DELETE). 429 responses are retried for
+// every method, since the server did not process the request. Retries stop
+// when the request context is done.
+type Transport struct {
+	// Base performs the requests; defaults to http.DefaultTransport
+	Base http.RoundTripper
+	// Retry is the retry policy; the zero value uses DefaultRetryPolicy
+	Retry RetryPolicy
+	// TokenSource, when set, supplies the bearer token of requests that do
+	// not already carry an Authorization header
+	TokenSource TokenSource
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	policy := t.Retry
+	if policy.MaxAttempts == 0 {
+		policy = DefaultRetryPolicy()
+	}
+	ctx := req.Context()
+
+	for attempt := 1; ; attempt++ {
+		attemptReq, err := t.prepare(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+		canRetry := attempt < policy.MaxAttempts && (req.Body == nil || req.GetBody != nil)
+
+		resp, err := base.RoundTrip(attemptReq)
+		if err != nil {
+			if !canRetry || !isIdempotent(req.Method) || ctx.Err() != nil {
+				return nil, err
+			}
+			if err := sleep(ctx, backoff(policy, attempt, "")); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if resp.StatusCode < 400 {
+			return resp, nil
+		}
+
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		resp.Body.Close() //nolint:errcheck
+		apiErr := newAPIError(req, resp.StatusCode, body)
+		if !canRetry || !isRetryableStatus(req.Method, resp.StatusCode) {
+			return nil, apiErr
+		}
+		if err := sleep(ctx, backoff(policy, attempt, resp.Header.Get("Retry-After"))); err != nil {
+			return nil, errors.Join(apiErr, err)
+		}
+	}
+}
+
+// prepare returns the request to send for an attempt, with a fresh body and
+// the bearer token from the token source
+func (t *Transport) prepare(req *http.Request, attempt int) (*http.Request, error) {
+	needsToken := t.TokenSource != nil && req.Header.Get("Authorization") == ""
+	if attempt == 1 && !needsToken {
+		return req, nil
+	}
+
+	attemptReq := req.Clone(req.Context())
+	if attempt > 1 && req.Body != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		attemptReq.Body = body
+	}
+	if needsToken {
+		token, err := t.TokenSource(req.Context())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bearer token: %w", err)
+		}
+		attemptReq.Header.Set("Authorization", "Bearer "+token)
+	}
+	return attemptReq, nil
+}
+
+// isIdempotent reports whether a request can be repeated safely
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isRetryableStatus reports whether a response status is worth retrying
+func isRetryableStatus(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+// backoff returns the wait before the retry following attempt. A Retry-After
+// value in seconds takes precedence; otherwise the wait doubles per attempt
+// with jitter.
+func backoff(policy RetryPolicy, attempt int, retryAfter string) time.Duration {
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds)*time.Second, policy.MaxBackoff)
+	}
+	wait := policy.InitialBackoff << (attempt - 1)
+	if wait <= 0 || wait > policy.MaxBackoff {
+		wait = policy.MaxBackoff
+	}
+	// Jitter in [wait/2, wait] spreads out retries from many clients
+	if half := int64(wait / 2); half > 0 {
+		wait = time.Duration(half + rand.Int64N(half+1))
+	}
+	return wait
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
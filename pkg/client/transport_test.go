@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func fastRetries() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestTransport_RetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Method == http.MethodPut && string(body) == "" {
+			t.Error("retried request lost its body")
+		}
+		_, _ = w.Write([]byte(`{"metadata":{"uid":"node-1"}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClientWithOptions(server.URL, Options{Retry: fastRetries()}, DefaultLogger())
+	if err != nil {
+		t.Fatalf("NewClientWithOptions failed: %v", err)
+	}
+
+	node, err := c.GetNode(context.Background(), "node-1")
+	if err != nil || node.Metadata.UID != "node-1" {
+		t.Fatalf("GetNode() = %v, %v; want success after retries", node, err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("got %d attempts, want 3", calls.Load())
+	}
+
+	calls.Store(0)
+	if _, err := c.UpdateNode(context.Background(), "node-1", UpdateNodeRequest{}); err != nil {
+		t.Fatalf("UpdateNode() failed after retries: %v", err)
+	}
+}
+
+func TestTransport_DoesNotRetryNonIdempotentRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c, _ := NewClientWithOptions(server.URL, Options{Retry: fastRetries()}, DefaultLogger())
+	if _, err := c.CreateNode(context.Background(), CreateNodeRequest{}); err == nil {
+		t.Fatal("expected CreateNode to fail")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("POST was attempted %d times, want 1", calls.Load())
+	}
+}
+
+func TestTransport_TypedErrors(t *testing.T) {
+	tests := []struct {
+		status  int
+		body    string
+		target  error
+		message string
+	}{
+		{http.StatusNotFound, `{"error":"Node not found","code":404}`, ErrNotFound, "Node not found"},
+		{http.StatusConflict, `{"type":"about:blank","title":"Conflict","detail":"name taken","status":409}`, ErrConflict, "name taken"},
+		{http.StatusUnauthorized, `missing token`, ErrUnauthorized, "missing token"},
+		{http.StatusForbidden, `{}`, ErrForbidden, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(http.StatusText(tc.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			c, _ := NewClientWithOptions(server.URL, Options{Retry: fastRetries()}, DefaultLogger())
+			_, err := c.GetNode(context.Background(), "node-1")
+			if !errors.Is(err, tc.target) {
+				t.Fatalf("errors.Is(%v, %v) = false", err, tc.target)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tc.status || apiErr.Message != tc.message {
+				t.Errorf("unexpected APIError %+v", apiErr)
+			}
+			if errors.Is(err, ErrConflict) && tc.target != ErrConflict {
+				t.Errorf("%d should not match ErrConflict", tc.status)
+			}
+		})
+	}
+}
+
+func TestTransport_BearerTokens(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	static, _ := NewClientWithOptions(server.URL, Options{BearerToken: "static"}, DefaultLogger())
+	if _, err := static.GetNodes(context.Background()); err != nil {
+		t.Fatalf("GetNodes failed: %v", err)
+	}
+
+	var refreshes int
+	source := func(ctx context.Context) (string, error) {
+		refreshes++
+		return "refreshed", nil
+	}
+	dynamic, _ := NewClientWithOptions(server.URL, Options{TokenSource: source}, DefaultLogger())
+	if _, err := dynamic.ListNodes(context.Background(), ListOptions{Limit: 1}); err != nil {
+		t.Fatalf("ListNodes failed: %v", err)
+	}
+
+	if len(seen) != 2 || seen[0] != "Bearer static" || seen[1] != "Bearer refreshed" || refreshes != 1 {
+		t.Errorf("unexpected Authorization headers %q (%d refreshes)", seen, refreshes)
+	}
+}
+
+func TestTransport_StopsWhenContextDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond, MaxBackoff: time.Minute}
+	c, _ := NewClientWithOptions(server.URL, Options{Retry: policy}, DefaultLogger())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.GetNodes(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("retry wait ignored the context deadline (%s)", elapsed)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt, max := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		if wait := backoff(policy, attempt, ""); wait < max/2 || wait > max {
+			t.Errorf("backoff(attempt %d) = %s, want within [%s, %s]", attempt, wait, max/2, max)
+		}
+	}
+	if wait := backoff(policy, 1, "30"); wait != time.Second {
+		t.Errorf("Retry-After not capped at MaxBackoff: %s", wait)
+	}
+}
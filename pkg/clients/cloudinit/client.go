@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package cloudinit provides a client for the OpenCHAMI cloud-init service,
+// which serves the cloud-init data of each node. The boot service registers
+// the nodes it syncs there and points booting nodes at it with a NoCloud
+// datasource parameter.
+package cloudinit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// refreshAfter is how long a registration is trusted before it is sent
+// again, so a cloud-init service that lost its data gets it back
+const refreshAfter = time.Hour
+
+// NodeInfo is the instance information of a node registered with the
+// cloud-init service
+type NodeInfo struct {
+	ID            string   `json:"id"`
+	LocalHostname string   `json:"local-hostname"`
+	MAC           string   `json:"mac,omitempty"`
+	NID           int32    `json:"nid,omitempty"`
+	Role          string   `json:"role,omitempty"`
+	SubRole       string   `json:"sub-role,omitempty"`
+	Groups        []string `json:"groups,omitempty"`
+	// UserData is the merged user-data of the node, when a UserDataSource
+	// is set
+	UserData string `json:"user-data,omitempty"`
+}
+
+// UserDataSource returns the merged user-data of a node, empty when none
+// applies
+type UserDataSource interface {
+	UserData(ctx context.Context, node v1.NodeSpec) (string, error)
+}
+
+// NewNodeInfo returns the instance information of a node. Nodes without a
+// hostname use their xname.
+func NewNodeInfo(node v1.NodeSpec) NodeInfo {
+	hostname := node.Hostname
+	if hostname == "" {
+		hostname = node.XName
+	}
+	return NodeInfo{
+		ID:            node.XName,
+		LocalHostname: hostname,
+		MAC:           node.BootMAC,
+		NID:           node.NID,
+		Role:          node.Role,
+		SubRole:       node.SubRole,
+		Groups:        node.Groups,
+	}
+}
+
+// Config holds configuration for the cloud-init service client
+type Config struct {
+	// BaseURL is where the cloud-init service is served, such as
+	// http://cloud-init:27777/cloud-init
+	BaseURL   string
+	AuthToken string
+	Timeout   time.Duration
+}
+
+// Client registers nodes with the cloud-init service. Registrations are
+// remembered, so a node is only sent again when its information changes or
+// its registration is an hour old.
+type Client struct {
+	config     Config
+	httpClient *http.Client
+	logger     *log.Logger
+	userData   UserDataSource
+
+	mu         sync.Mutex
+	registered map[string]registration
+}
+
+type registration struct {
+	info NodeInfo
+	at   time.Time
+}
+
+// NewClient creates a cloud-init service client
+func NewClient(config Config, logger *log.Logger) *Client {
+	return &Client{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+		logger:     logger,
+		registered: make(map[string]registration),
+	}
+}
+
+// SeedURL returns the NoCloud seed URL nodes fetch their cloud-init data
+// from: the base URL with a trailing slash
+func (c *Client) SeedURL() string {
+	return strings.TrimSuffix(c.config.BaseURL, "/") + "/"
+}
+
+// SetUserData makes registrations carry the user-data source returns for
+// each node, so nodes are registered again when their user-data changes.
+// Call it before registering nodes.
+func (c *Client) SetUserData(source UserDataSource) {
+	c.userData = source
+}
+
+// RegisterNodes registers or refreshes the nodes with the cloud-init
+// service. Every node is tried; the errors of those that failed are
+// returned together.
+func (c *Client) RegisterNodes(ctx context.Context, nodes []v1.NodeSpec) error {
+	var errs []error
+	registered := 0
+	for _, node := range nodes {
+		if node.XName == "" {
+			continue
+		}
+		sent, err := c.RegisterNode(ctx, node)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if sent {
+			registered++
+		}
+	}
+	if registered > 0 || len(errs) > 0 {
+		c.logger.Printf("Registered %d nodes with cloud-init, %d failed", registered, len(errs))
+	}
+	return errors.Join(errs...)
+}
+
+// RegisterNode registers or refreshes a node with the cloud-init service,
+// reporting false if its registration was already current
+func (c *Client) RegisterNode(ctx context.Context, node v1.NodeSpec) (bool, error) {
+	info := NewNodeInfo(node)
+	if c.userData != nil {
+		userData, err := c.userData.UserData(ctx, node)
+		if err != nil {
+			return false, fmt.Errorf("merging user-data of node %s: %w", node.XName, err)
+		}
+		info.UserData = userData
+	}
+	c.mu.Lock()
+	previous, ok := c.registered[info.ID]
+	c.mu.Unlock()
+	if ok && time.Since(previous.at) < refreshAfter && reflect.DeepEqual(previous.info, info) {
+		return false, nil
+	}
+
+	if err := c.put(ctx, info); err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	c.registered[info.ID] = registration{info: info, at: time.Now()}
+	c.mu.Unlock()
+	return true, nil
+}
+
+// put sends a node with PUT <base>/admin/instance-info/{xname}
+func (c *Client) put(ctx context.Context, info NodeInfo) error {
+	body, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("encoding node %s: %w", info.ID, err)
+	}
+	endpoint := strings.TrimSuffix(c.config.BaseURL, "/") + "/admin/instance-info/" + url.PathEscape(info.ID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating cloud-init request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.config.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.AuthToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("registering node %s with cloud-init: %w", info.ID, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("cloud-init service returned %s for node %s", resp.Status, info.ID)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package cloudinit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+func TestClient_RegisterNodes(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string]NodeInfo)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer ci-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodPut || r.URL.Path == "/cloud-init/admin/instance-info/x0c0s0b0n9" {
+			http.Error(w, "refused", http.StatusBadRequest)
+			return
+		}
+		var info NodeInfo
+		if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		received[r.URL.Path] = info
+		requests++
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL + "/cloud-init/", AuthToken: "ci-token"}, log.New(io.Discard, "", 0))
+	if seed := client.SeedURL(); seed != server.URL+"/cloud-init/" {
+		t.Errorf("SeedURL = %q", seed)
+	}
+
+	nodes := []v1.NodeSpec{
+		{XName: "x0c0s0b0n0", NID: 1, Role: "Compute", Groups: []string{"compute"}},
+		{XName: "x0c0s0b0n1", NID: 2, Hostname: "nid002"},
+	}
+	ctx := context.Background()
+	if err := client.RegisterNodes(ctx, nodes); err != nil {
+		t.Fatalf("RegisterNodes returned error: %v", err)
+	}
+	info := received["/cloud-init/admin/instance-info/x0c0s0b0n0"]
+	if info.ID != "x0c0s0b0n0" || info.LocalHostname != "x0c0s0b0n0" || info.NID != 1 || len(info.Groups) != 1 {
+		t.Errorf("registered x0c0s0b0n0 as %+v", info)
+	}
+	if info := received["/cloud-init/admin/instance-info/x0c0s0b0n1"]; info.LocalHostname != "nid002" {
+		t.Errorf("registered x0c0s0b0n1 as %+v, want its hostname", info)
+	}
+
+	// Unchanged nodes are not sent again; changed ones are
+	nodes[1].Hostname = "nid002-new"
+	if err := client.RegisterNodes(ctx, nodes); err != nil {
+		t.Fatalf("second RegisterNodes returned error: %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("cloud-init service received %d registrations, want 3", requests)
+	}
+
+	if err := client.RegisterNodes(ctx, []v1.NodeSpec{{XName: "x0c0s0b0n9"}}); err == nil {
+		t.Error("expected an error for a refused registration")
+	}
+}
+
+// staticUserData returns the same user-data for every node
+type staticUserData string
+
+func (s *staticUserData) UserData(context.Context, v1.NodeSpec) (string, error) {
+	return string(*s), nil
+}
+
+func TestClient_RegisterNodeUserData(t *testing.T) {
+	var received []NodeInfo
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var info NodeInfo
+		if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		received = append(received, info)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL}, log.New(io.Discard, "", 0))
+	userData := staticUserData("#cloud-config\ntimezone: UTC\n")
+	client.SetUserData(&userData)
+
+	node := v1.NodeSpec{XName: "x0c0s0b0n0"}
+	ctx := context.Background()
+	if _, err := client.RegisterNode(ctx, node); err != nil {
+		t.Fatalf("RegisterNode failed: %v", err)
+	}
+	if len(received) != 1 || received[0].UserData != string(userData) {
+		t.Fatalf("received = %+v, want the node's user-data", received)
+	}
+
+	// Changed user-data registers the node again
+	if sent, _ := client.RegisterNode(ctx, node); sent {
+		t.Error("unchanged node registered again")
+	}
+	userData = "#cloud-config\ntimezone: Europe/Berlin\n"
+	if sent, err := client.RegisterNode(ctx, node); err != nil || !sent || received[1].UserData != string(userData) {
+		t.Errorf("RegisterNode after a user-data change = %v, %v, want the new user-data sent", sent, err)
+	}
+}
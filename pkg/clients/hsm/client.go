@@ -6,16 +6,24 @@
 package hsm
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/openchami/boot-service/internal/flight"
+	"github.com/openchami/boot-service/pkg/validation"
 )
 
+// ErrNotFound is returned for components HSM does not know
+var ErrNotFound = errors.New("not found in HSM")
+
 // HSMComponent represents a component from HSM
 type HSMComponent struct { //nolint:revive
 	ID              string            `json:"ID"`
@@ -55,6 +63,16 @@ type HSMMembership struct {
 	PartitionName string   `json:"partitionName"`
 }
 
+// HSMGroup is a group from the HSM groups endpoint
+type HSMGroup struct { //nolint:revive
+	Label       string   `json:"label"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Members     struct {
+		IDs []string `json:"ids"`
+	} `json:"members"`
+}
+
 // HSMEthernetResponse represents the response from HSM ethernet interfaces endpoint
 type HSMEthernetResponse struct { //nolint:revive
 	EthernetInterfaces []HSMEthernetInterface `json:"EthernetInterfaces"`
@@ -91,6 +109,12 @@ type HSMClient struct { //nolint:revive
 	httpClient *http.Client
 	logger     *log.Logger
 	cache      *HSMCache
+
+	// Concurrent cache misses for the same data share one HSM request
+	componentsCalls flight.Group[[]HSMComponent]
+	componentCalls  flight.Group[*HSMComponent]
+	ethernetCalls   flight.Group[[]HSMEthernetInterface]
+	membershipCalls flight.Group[*HSMMembership]
 }
 
 // HSMCache provides caching for HSM responses to reduce load
@@ -223,7 +247,10 @@ func (c *HSMClient) GetComponents(ctx context.Context) ([]HSMComponent, error) {
 		c.logger.Printf("HSM components cache hit")
 		return data.([]HSMComponent), nil
 	}
+	return c.componentsCalls.Do(ctx, "all_components", c.fetchComponents)
+}
 
+func (c *HSMClient) fetchComponents(ctx context.Context) ([]HSMComponent, error) {
 	url := fmt.Sprintf("%s/hsm/v2/State/Components", c.config.BaseURL)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -265,7 +292,12 @@ func (c *HSMClient) GetComponent(ctx context.Context, componentID string) (*HSMC
 		c.logger.Printf("HSM component cache hit for %s", componentID)
 		return data.(*HSMComponent), nil
 	}
+	return c.componentCalls.Do(ctx, cacheKey, func(ctx context.Context) (*HSMComponent, error) {
+		return c.fetchComponent(ctx, cacheKey, componentID)
+	})
+}
 
+func (c *HSMClient) fetchComponent(ctx context.Context, cacheKey, componentID string) (*HSMComponent, error) {
 	url := fmt.Sprintf("%s/hsm/v2/State/Components/%s", c.config.BaseURL, componentID)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -284,7 +316,7 @@ func (c *HSMClient) GetComponent(ctx context.Context, componentID string) (*HSMC
 	defer resp.Body.Close() //nolint:errcheck //nolint:errcheck
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("component %s not found in HSM", componentID)
+		return nil, fmt.Errorf("component %s %w", componentID, ErrNotFound)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -310,7 +342,10 @@ func (c *HSMClient) GetEthernetInterfaces(ctx context.Context) ([]HSMEthernetInt
 		c.logger.Printf("HSM ethernet interfaces cache hit")
 		return data.([]HSMEthernetInterface), nil
 	}
+	return c.ethernetCalls.Do(ctx, "all_ethernet", c.fetchEthernetInterfaces)
+}
 
+func (c *HSMClient) fetchEthernetInterfaces(ctx context.Context) ([]HSMEthernetInterface, error) {
 	url := fmt.Sprintf("%s/hsm/v2/Inventory/EthernetInterfaces", c.config.BaseURL)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -351,7 +386,12 @@ func (c *HSMClient) GetMembership(ctx context.Context, componentID string) (*HSM
 		c.logger.Printf("HSM membership cache hit for %s", componentID)
 		return data.(*HSMMembership), nil
 	}
+	return c.membershipCalls.Do(ctx, cacheKey, func(ctx context.Context) (*HSMMembership, error) {
+		return c.fetchMembership(ctx, cacheKey, componentID)
+	})
+}
 
+func (c *HSMClient) fetchMembership(ctx context.Context, cacheKey, componentID string) (*HSMMembership, error) {
 	url := fmt.Sprintf("%s/hsm/v2/memberships/%s", c.config.BaseURL, componentID)
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -382,6 +422,36 @@ func (c *HSMClient) GetMembership(ctx context.Context, componentID string) (*HSM
 	return &membership, nil
 }
 
+// GetGroups lists the groups HSM knows with their members. Groups are not
+// cached, so a group sync always sees current memberships.
+func (c *HSMClient) GetGroups(ctx context.Context) ([]HSMGroup, error) {
+	url := fmt.Sprintf("%s/hsm/v2/groups", c.config.BaseURL)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HSM groups request: %w", err)
+	}
+
+	if err := c.addAuthHeader(ctx, req); err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call HSM groups endpoint: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HSM returned status %d", resp.StatusCode)
+	}
+	var groups []HSMGroup
+	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
+		return nil, fmt.Errorf("failed to decode HSM response: %w", err)
+	}
+
+	c.logger.Printf("Retrieved %d groups from HSM", len(groups))
+	return groups, nil
+}
+
 // GetComponentByMAC finds a component by its MAC address
 func (c *HSMClient) GetComponentByMAC(ctx context.Context, macAddress string) (*HSMComponent, error) {
 	// Get ethernet interfaces to find the component ID
@@ -393,7 +463,7 @@ func (c *HSMClient) GetComponentByMAC(ctx context.Context, macAddress string) (*
 	// Find the component ID for this MAC address
 	var componentID string
 	for _, iface := range interfaces {
-		if iface.MACAddress == macAddress {
+		if validation.EqualMAC(iface.MACAddress, macAddress) {
 			componentID = iface.ComponentID
 			break
 		}
@@ -407,6 +477,64 @@ func (c *HSMClient) GetComponentByMAC(ctx context.Context, macAddress string) (*
 	return c.GetComponent(ctx, componentID)
 }
 
+// CreateComponent adds a component to HSM. HSM keeps the state of a
+// component it already knows.
+func (c *HSMClient) CreateComponent(ctx context.Context, component HSMComponent) error {
+	body := HSMResponse{Components: []HSMComponent{component}}
+	if _, err := c.post(ctx, "/hsm/v2/State/Components", body); err != nil {
+		return fmt.Errorf("failed to create component %s: %w", component.ID, err)
+	}
+	c.cache.mu.Lock()
+	delete(c.cache.components, "all_components")
+	delete(c.cache.components, fmt.Sprintf("component_%s", component.ID))
+	c.cache.mu.Unlock()
+	return nil
+}
+
+// CreateEthernetInterface adds an ethernet interface to HSM. It reports
+// false, without an error, when HSM already has an interface with the MAC.
+func (c *HSMClient) CreateEthernetInterface(ctx context.Context, iface HSMEthernetInterface) (bool, error) {
+	status, err := c.post(ctx, "/hsm/v2/Inventory/EthernetInterfaces", iface)
+	if status == http.StatusConflict {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create ethernet interface %s: %w", iface.MACAddress, err)
+	}
+	c.cache.mu.Lock()
+	delete(c.cache.ethernetInterfaces, "all_ethernet")
+	c.cache.mu.Unlock()
+	return true, nil
+}
+
+// post sends body to path of HSM as JSON, returning the response status
+func (c *HSMClient) post(ctx context.Context, path string, body any) (int, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode HSM request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.config.BaseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create HSM request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if err := c.addAuthHeader(ctx, req); err != nil {
+		return 0, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call HSM: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("HSM returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
 // Health checks if HSM is reachable and responding
 func (c *HSMClient) Health(ctx context.Context) error {
 	url := fmt.Sprintf("%s/hsm/v2/service/ready", c.config.BaseURL)
@@ -453,6 +581,8 @@ func (c *HSMClient) GetStats(ctx context.Context) map[string]interface{} { //nol
 		"authenticated":   c.config.AuthToken != "" || c.config.AuthTokenProvider != nil || c.config.ServiceTokenManager != nil,
 		"cache_expiry":    c.cache.expiry.String(),
 		"request_timeout": c.config.Timeout.String(),
+		"deduplicated_requests": c.componentsCalls.Shared() + c.componentCalls.Shared() +
+			c.ethernetCalls.Shared() + c.membershipCalls.Shared(),
 	}
 
 	// Add cache stats if available
@@ -8,12 +8,16 @@ package hsm
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -335,6 +339,83 @@ func TestHSMClient_Cache(t *testing.T) {
 	t.Logf("✅ Cache working correctly: %d server calls for 3 requests", callCount)
 }
 
+// TestHSMClient_DeduplicatesConcurrentLookups tests that concurrent cache
+// misses for the same component share one HSM request
+func TestHSMClient_DeduplicatesConcurrentLookups(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(HSMComponent{ID: "x1000c0s0b0n0", NID: 1}) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	config := DefaultHSMConfig()
+	config.BaseURL = server.URL
+	client, err := NewHSMClient(config, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("Failed to create HSM client: %v", err)
+	}
+
+	const lookups = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, lookups)
+	for range lookups {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			comp, err := client.GetComponent(context.Background(), "x1000c0s0b0n0")
+			if err == nil && comp.ID != "x1000c0s0b0n0" {
+				err = fmt.Errorf("got component %q", comp.ID)
+			}
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetComponent failed: %v", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Expected 1 HSM request, got %d", got)
+	}
+	if got := client.GetStats(context.Background())["deduplicated_requests"]; got != uint64(lookups-1) {
+		t.Errorf("Expected %d deduplicated requests, got %v", lookups-1, got)
+	}
+}
+
+// TestHSMClient_DeduplicationHonoursCallerContext tests that a caller stops
+// waiting for a shared lookup when its own context is done
+func TestHSMClient_DeduplicationHonoursCallerContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		json.NewEncoder(w).Encode(HSMComponent{ID: "x1000c0s0b0n0"}) //nolint:errcheck
+	}))
+	defer server.Close()
+	defer close(release)
+
+	config := DefaultHSMConfig()
+	config.BaseURL = server.URL
+	client, err := NewHSMClient(config, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("Failed to create HSM client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetComponent(ctx, "x1000c0s0b0n0"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
 // TestHSMClient_Health tests health check functionality
 func TestHSMClient_Health(t *testing.T) {
 	// Mock HSM server
@@ -368,6 +449,33 @@ func TestHSMClient_Health(t *testing.T) {
 	t.Logf("✅ HSM health check passed")
 }
 
+// TestHSMClient_GetGroups tests listing groups and their members
+func TestHSMClient_GetGroups(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/hsm/v2/groups" {
+			t.Errorf("Expected path /hsm/v2/groups, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"label":"compute","members":{"ids":["x1000c0s0b0n0","x1000c0s0b0n1"]}},{"label":"gpu","members":{"ids":[]}}]`)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	config := DefaultHSMConfig()
+	config.BaseURL = server.URL
+	client, err := NewHSMClient(config, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("Failed to create HSM client: %v", err)
+	}
+
+	groups, err := client.GetGroups(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get groups: %v", err)
+	}
+	if len(groups) != 2 || groups[0].Label != "compute" || len(groups[0].Members.IDs) != 2 {
+		t.Errorf("groups = %+v", groups)
+	}
+}
+
 // TestHSMClient_ErrorHandling tests error scenarios
 func TestHSMClient_ErrorHandling(t *testing.T) {
 	// Mock HSM server that returns errors
@@ -0,0 +1,225 @@
+// Copyright © 2026 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package hsm
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// Conflict policies decide whether a sync overwrites a field of a node that
+// was edited outside HSM
+const (
+	// PolicyHSM overwrites local edits: HSM is authoritative
+	PolicyHSM = "hsm"
+	// PolicyLocal keeps local edits until they are reverted
+	PolicyLocal = "local"
+	// PolicyLastWriterWins keeps a local edit until HSM's value changes
+	PolicyLastWriterWins = "last-writer-wins"
+)
+
+// Node fields a sync writes, as named in conflict policies
+const (
+	FieldNID     = "nid"
+	FieldBootMAC = "bootMac"
+	FieldRole    = "role"
+	FieldSubRole = "subRole"
+	FieldGroups  = "groups"
+)
+
+// Node annotations of conflict handling
+const (
+	// PolicyAnnotation sets the conflict policy of one node, in the syntax
+	// of ParseConflictPolicy, over the configured one
+	PolicyAnnotation = "boot.openchami.io/hsm-sync-policy"
+	// SyncedAnnotation records the values HSM reported at the last sync,
+	// which tell local edits from HSM changes
+	SyncedAnnotation = "boot.openchami.io/hsm-synced"
+)
+
+// Kept sides of a conflict
+const (
+	KeptHSM   = "hsm"
+	KeptLocal = "local"
+)
+
+// syncedField is a node field a sync writes
+type syncedField struct {
+	name string
+	// get returns the value of the field in a form compared across syncs
+	get func(spec *v1.NodeSpec) string
+	// set copies the field from src to dst
+	set func(dst, src *v1.NodeSpec)
+}
+
+var syncedFields = []syncedField{
+	{FieldNID, func(s *v1.NodeSpec) string { return strconv.Itoa(int(s.NID)) }, func(d, s *v1.NodeSpec) { d.NID = s.NID }},
+	{FieldBootMAC, func(s *v1.NodeSpec) string { return validation.NormalizeMAC(s.BootMAC) }, func(d, s *v1.NodeSpec) { d.BootMAC = s.BootMAC }},
+	{FieldRole, func(s *v1.NodeSpec) string { return s.Role }, func(d, s *v1.NodeSpec) { d.Role = s.Role }},
+	{FieldSubRole, func(s *v1.NodeSpec) string { return s.SubRole }, func(d, s *v1.NodeSpec) { d.SubRole = s.SubRole }},
+	{FieldGroups, func(s *v1.NodeSpec) string { return strings.Join(slices.Sorted(slices.Values(s.Groups)), ",") },
+		func(d, s *v1.NodeSpec) { d.Groups = s.Groups }},
+}
+
+// ConflictPolicy assigns a policy to each field a sync writes
+type ConflictPolicy struct {
+	// Default applies to fields without their own policy; PolicyHSM when
+	// empty
+	Default string
+	// Fields maps field names to their policy
+	Fields map[string]string
+}
+
+// ParseConflictPolicy parses a comma-separated policy and field overrides,
+// such as "local", "groups=local", or "hsm,groups=local,bootMac=last-writer-wins".
+// The policy may be omitted.
+func ParseConflictPolicy(value string) (ConflictPolicy, error) {
+	var policy ConflictPolicy
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		field, fieldPolicy, override := strings.Cut(part, "=")
+		if !override {
+			if policy.Default != "" {
+				return ConflictPolicy{}, fmt.Errorf("policy %q given twice", value)
+			}
+			if !validPolicy(part) {
+				return ConflictPolicy{}, fmt.Errorf("unknown policy %q, want %s, %s, or %s", part, PolicyHSM, PolicyLocal, PolicyLastWriterWins)
+			}
+			policy.Default = part
+			continue
+		}
+		field, fieldPolicy = strings.TrimSpace(field), strings.TrimSpace(fieldPolicy)
+		if !slices.ContainsFunc(syncedFields, func(f syncedField) bool { return f.name == field }) {
+			return ConflictPolicy{}, fmt.Errorf("unknown field %q, want %s, %s, %s, %s, or %s", field, FieldNID, FieldBootMAC, FieldRole, FieldSubRole, FieldGroups)
+		}
+		if !validPolicy(fieldPolicy) {
+			return ConflictPolicy{}, fmt.Errorf("unknown policy %q for %s, want %s, %s, or %s", fieldPolicy, field, PolicyHSM, PolicyLocal, PolicyLastWriterWins)
+		}
+		if policy.Fields == nil {
+			policy.Fields = make(map[string]string)
+		}
+		policy.Fields[field] = fieldPolicy
+	}
+	return policy, nil
+}
+
+func validPolicy(policy string) bool {
+	return policy == PolicyHSM || policy == PolicyLocal || policy == PolicyLastWriterWins
+}
+
+// For returns the policy of field
+func (p ConflictPolicy) For(field string) string {
+	if policy, ok := p.Fields[field]; ok {
+		return policy
+	}
+	if p.Default == "" {
+		return PolicyHSM
+	}
+	return p.Default
+}
+
+// with returns p overridden by the policy of one node. A node policy that
+// sets the default replaces p entirely.
+func (p ConflictPolicy) with(node ConflictPolicy) ConflictPolicy {
+	if node.Default != "" {
+		return node
+	}
+	fields := maps.Clone(p.Fields)
+	if fields == nil {
+		fields = make(map[string]string, len(node.Fields))
+	}
+	maps.Copy(fields, node.Fields)
+	return ConflictPolicy{Default: p.Default, Fields: fields}
+}
+
+// Conflict is a field of a node edited outside HSM whose value differs
+// from the one HSM reports
+type Conflict struct {
+	Node   string `json:"node"`
+	Field  string `json:"field"`
+	Policy string `json:"policy"`
+	Local  string `json:"local"`
+	HSM    string `json:"hsm"`
+	// Kept is KeptLocal when the local edit was kept, so the node diverges
+	// from HSM, or KeptHSM when HSM's value overwrote it
+	Kept string `json:"kept"`
+}
+
+// ConflictObserver is told each conflict a sync finds
+type ConflictObserver func(conflict Conflict)
+
+// reconcileNode returns the spec of existing with the values HSM reported
+// applied as policy allows, and the conflicts found. A field counts as edited locally when its value is
+// not the one HSM reported at the last sync; without a record, any
+// difference does. Last writer wins keeps a local edit unless HSM's value
+// changed since the last sync, since the sync sees that change after the
+// edit.
+func reconcileNode(existing *v1.Node, reported v1.NodeSpec, policy ConflictPolicy) (v1.NodeSpec, []Conflict) {
+	merged := existing.Spec
+	recorded := recordedValues(existing)
+	var conflicts []Conflict
+	for _, field := range syncedFields {
+		local, hsmValue := field.get(&existing.Spec), field.get(&reported)
+		if local == hsmValue {
+			continue
+		}
+		last, known := recorded[field.name]
+		if known && local == last {
+			// Not edited since the last sync
+			field.set(&merged, &reported)
+			continue
+		}
+
+		fieldPolicy := policy.For(field.name)
+		conflict := Conflict{Node: existing.Spec.XName, Field: field.name, Policy: fieldPolicy, Local: local, HSM: hsmValue, Kept: KeptHSM}
+		if fieldPolicy == PolicyLocal || (fieldPolicy == PolicyLastWriterWins && known && hsmValue == last) {
+			conflict.Kept = KeptLocal
+		} else {
+			field.set(&merged, &reported)
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return merged, conflicts
+}
+
+// recordedValues returns the values recorded in the SyncedAnnotation of
+// node, or nil without a readable record
+func recordedValues(node *v1.Node) map[string]string {
+	var recorded map[string]string
+	if data, ok := node.Metadata.Annotations[SyncedAnnotation]; ok {
+		_ = json.Unmarshal([]byte(data), &recorded)
+	}
+	return recorded
+}
+
+// reportedValues returns the values of spec to record in SyncedAnnotation
+func reportedValues(spec v1.NodeSpec) map[string]string {
+	values := make(map[string]string, len(syncedFields))
+	for _, field := range syncedFields {
+		values[field.name] = field.get(&spec)
+	}
+	return values
+}
+
+// encodeValues encodes recorded values for SyncedAnnotation
+func encodeValues(values map[string]string) string {
+	data, _ := json.Marshal(values) // a map of strings always encodes
+	return string(data)
+}
+
+// specChanged reports whether a field a sync writes differs between a and b
+func specChanged(a, b *v1.NodeSpec) bool {
+	return slices.ContainsFunc(syncedFields, func(field syncedField) bool { return field.get(a) != field.get(b) })
+}
@@ -0,0 +1,168 @@
+// Copyright © 2026 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package hsm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	fabrica "github.com/openchami/fabrica/pkg/resource"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/client"
+)
+
+func TestParseConflictPolicy(t *testing.T) {
+	policy, err := ParseConflictPolicy("local, groups=hsm,bootMac=last-writer-wins")
+	if err != nil {
+		t.Fatalf("ParseConflictPolicy failed: %v", err)
+	}
+	for field, want := range map[string]string{FieldNID: PolicyLocal, FieldGroups: PolicyHSM, FieldBootMAC: PolicyLastWriterWins} {
+		if got := policy.For(field); got != want {
+			t.Errorf("policy of %s = %s, want %s", field, got, want)
+		}
+	}
+	if empty, err := ParseConflictPolicy(""); err != nil || empty.For(FieldRole) != PolicyHSM {
+		t.Errorf("empty policy = %+v, %v, want HSM authoritative", empty, err)
+	}
+
+	// A node policy that sets the default replaces the configured one;
+	// field overrides add to it
+	node, _ := ParseConflictPolicy("role=hsm")
+	if got := policy.with(node); got.For(FieldRole) != PolicyHSM || got.For(FieldGroups) != PolicyHSM || got.For(FieldNID) != PolicyLocal {
+		t.Errorf("policy with node overrides = %+v", got)
+	}
+	node, _ = ParseConflictPolicy("last-writer-wins")
+	if got := policy.with(node); got.For(FieldGroups) != PolicyLastWriterWins {
+		t.Errorf("policy with node default = %+v, want last writer wins throughout", got)
+	}
+}
+
+// nodeStore is a boot service holding nodes in memory
+type nodeStore struct {
+	client.API
+	nodes map[string]*v1.Node
+}
+
+func (s *nodeStore) GetNodes(context.Context) ([]v1.Node, error) {
+	nodes := make([]v1.Node, 0, len(s.nodes))
+	for _, node := range s.nodes {
+		nodes = append(nodes, *node)
+	}
+	return nodes, nil
+}
+
+func (s *nodeStore) CreateNode(_ context.Context, req client.CreateNodeRequest) (*v1.Node, error) {
+	node := &v1.Node{Metadata: fabrica.Metadata{UID: "node-" + req.Spec.XName, Annotations: req.Annotations}, Spec: req.Spec}
+	s.nodes[node.Metadata.UID] = node
+	return node, nil
+}
+
+func (s *nodeStore) UpdateNode(_ context.Context, uid string, req client.UpdateNodeRequest) (*v1.Node, error) {
+	node := s.nodes[uid]
+	node.Spec = req.Spec
+	if node.Metadata.Annotations == nil {
+		node.Metadata.Annotations = map[string]string{}
+	}
+	for key, value := range req.Annotations {
+		node.Metadata.Annotations[key] = value
+	}
+	return node, nil
+}
+
+func TestIntegrationService_ConflictPolicy(t *testing.T) {
+	// HSM reports one node, whose role and NID tests change
+	component := HSMComponent{ID: "x1000c0s0b0n0", Type: "Node", Role: "Compute", NID: 1}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/hsm/v2/State/Components":
+			json.NewEncoder(w).Encode(HSMResponse{Components: []HSMComponent{component}}) //nolint:errcheck
+		case "/hsm/v2/memberships/x1000c0s0b0n0":
+			w.Write([]byte(`{"id": "x1000c0s0b0n0", "groupLabels": ["compute"]}`)) //nolint:errcheck
+		default:
+			w.Write([]byte("[]")) //nolint:errcheck
+		}
+	}))
+	defer server.Close()
+
+	config := DefaultIntegrationConfig()
+	config.HSMConfig.BaseURL = server.URL
+	config.ConflictPolicy, _ = ParseConflictPolicy("hsm,groups=local,role=last-writer-wins")
+	logger := log.New(io.Discard, "", 0)
+	hsmClient, err := NewHSMClient(config.HSMConfig, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &nodeStore{nodes: map[string]*v1.Node{}}
+	service, err := NewIntegrationServiceWithClient(hsmClient, config, store, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reported []Conflict
+	service.SetConflictObserver(func(conflict Conflict) { reported = append(reported, conflict) })
+	sync := func() *v1.Node {
+		t.Helper()
+		hsmClient.ClearCache()
+		if err := service.SyncNodesFromHSM(context.Background()); err != nil {
+			t.Fatalf("SyncNodesFromHSM failed: %v", err)
+		}
+		return store.nodes["node-x1000c0s0b0n0"]
+	}
+
+	node := sync()
+	if node == nil || node.Metadata.Annotations[SyncedAnnotation] == "" {
+		t.Fatalf("created node = %+v, want the reported values recorded", node)
+	}
+
+	// Local edits to every policy's field, and to a field sync does not own
+	node.Spec.NID = 99
+	node.Spec.Groups = []string{"compute", "debug"}
+	node.Spec.Role = "Service"
+	node.Spec.Hostname = "nid001"
+	node = sync()
+	if node.Spec.NID != 1 || !slices.Equal(node.Spec.Groups, []string{"compute", "debug"}) || node.Spec.Role != "Service" || node.Spec.Hostname != "nid001" {
+		t.Errorf("node after local edits = %+v, want NID from HSM and the other edits kept", node.Spec)
+	}
+	if status := service.LastSync(); status.Conflicts != 3 || status.Updated != 1 {
+		t.Errorf("status = %+v, want 3 conflicts and the node updated", status)
+	}
+	divergences := service.Divergences()
+	if len(divergences) != 2 || divergences[0].Field != FieldGroups || divergences[1].Field != FieldRole || divergences[1].HSM != "Compute" {
+		t.Errorf("divergences = %+v, want groups and role kept", divergences)
+	}
+	if len(reported) != 3 {
+		t.Errorf("reported conflicts = %+v, want 3", reported)
+	}
+
+	// Kept edits are only reported once
+	reported = nil
+	sync()
+	if len(reported) != 0 || len(service.Divergences()) != 2 {
+		t.Errorf("second sync reported %+v, want nothing new", reported)
+	}
+
+	// A change in HSM outranks an older local edit under last writer wins
+	component.Role = "Application"
+	node = sync()
+	if node.Spec.Role != "Application" || !slices.Equal(node.Spec.Groups, []string{"compute", "debug"}) {
+		t.Errorf("node after HSM change = %+v, want HSM's new role and the local groups", node.Spec)
+	}
+	if len(reported) != 1 || reported[0].Field != FieldRole || reported[0].Kept != KeptHSM {
+		t.Errorf("reported conflicts = %+v, want the role overwritten", reported)
+	}
+
+	// A node's own policy overrides the configured one
+	node.Metadata.Annotations[PolicyAnnotation] = "hsm"
+	node = sync()
+	if !slices.Equal(node.Spec.Groups, []string{"compute"}) || len(service.Divergences()) != 0 {
+		t.Errorf("node with its own policy = %+v, divergences %+v; want HSM's groups", node.Spec, service.Divergences())
+	}
+}
@@ -6,29 +6,74 @@
 package hsm
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"log"
+	"slices"
+	"sync"
 	"time"
 
 	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/flight"
 	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/validation"
 )
 
 // IntegrationService provides HSM integration for the boot service
 type IntegrationService struct {
-	hsmClient    *HSMClient
-	bootClient   client.Client
-	logger       *log.Logger
-	syncEnabled  bool
-	syncInterval time.Duration
+	hsmClient   *HSMClient
+	bootClient  client.API
+	logger      *log.Logger
+	syncEnabled bool
+
+	// syncMu serializes syncs, so one run on request waits for a
+	// background one
+	syncMu sync.Mutex
+
+	mu              sync.Mutex
+	syncInterval    time.Duration
+	intervalChanged chan struct{}
+	lastSync        SyncStatus
+	observer        SyncObserver
+	paused          PauseCheck
+	conflictPolicy  ConflictPolicy
+	onConflict      ConflictObserver
+	divergences     []Conflict // local edits the last sync kept
+
+	// resolutions shares one resolution among concurrent requests for the
+	// same identifier
+	resolutions flight.Group[*v1.Node]
 }
 
+// SyncStatus reports the outcome of the most recent HSM sync
+type SyncStatus struct {
+	Runs     int       `json:"runs"`             // syncs since startup
+	LastRun  time.Time `json:"lastRun,omitzero"` // when the last sync started
+	Duration string    `json:"duration,omitempty"`
+	Created  int       `json:"created"`
+	Updated  int       `json:"updated"`
+	Skipped  int       `json:"skipped"`
+	Failed   int       `json:"failed"` // nodes that could not be synced
+	// Conflicts counts the local edits that differ from HSM, kept or
+	// overwritten as the conflict policy says
+	Conflicts int    `json:"conflicts"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SyncObserver is told the nodes a sync created, updated, or found current
+type SyncObserver func(ctx context.Context, nodes []v1.NodeSpec)
+
+// PauseCheck reports whether background syncs are paused
+type PauseCheck func(ctx context.Context) bool
+
 // IntegrationConfig holds configuration for HSM integration
 type IntegrationConfig struct {
 	HSMConfig    HSMConfig     `json:"hsm"`
 	SyncEnabled  bool          `json:"syncEnabled"`
 	SyncInterval time.Duration `json:"syncInterval"`
+	// ConflictPolicy decides which local node edits a sync overwrites
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy"`
 }
 
 // DefaultIntegrationConfig returns default integration configuration
@@ -41,7 +86,7 @@ func DefaultIntegrationConfig() IntegrationConfig {
 }
 
 // NewIntegrationService creates a new HSM integration service
-func NewIntegrationService(config IntegrationConfig, bootClient client.Client, logger *log.Logger) (*IntegrationService, error) {
+func NewIntegrationService(config IntegrationConfig, bootClient client.API, logger *log.Logger) (*IntegrationService, error) {
 	if logger == nil {
 		logger = log.New(log.Writer(), "hsm-integration: ", log.LstdFlags)
 	}
@@ -52,15 +97,17 @@ func NewIntegrationService(config IntegrationConfig, bootClient client.Client, l
 	}
 
 	return &IntegrationService{
-		hsmClient:    hsmClient,
-		bootClient:   bootClient,
-		logger:       logger,
-		syncEnabled:  config.SyncEnabled,
-		syncInterval: config.SyncInterval,
+		hsmClient:       hsmClient,
+		bootClient:      bootClient,
+		logger:          logger,
+		syncEnabled:     config.SyncEnabled,
+		syncInterval:    config.SyncInterval,
+		intervalChanged: make(chan struct{}, 1),
+		conflictPolicy:  config.ConflictPolicy,
 	}, nil
 }
 
-func NewIntegrationServiceWithClient(hsmClient *HSMClient, config IntegrationConfig, bootClient client.Client, logger *log.Logger) (*IntegrationService, error) {
+func NewIntegrationServiceWithClient(hsmClient *HSMClient, config IntegrationConfig, bootClient client.API, logger *log.Logger) (*IntegrationService, error) {
 	if logger == nil {
 		logger = log.New(log.Writer(), "hsm-integration: ", log.LstdFlags)
 	}
@@ -70,22 +117,91 @@ func NewIntegrationServiceWithClient(hsmClient *HSMClient, config IntegrationCon
 	}
 
 	return &IntegrationService{
-		hsmClient:    hsmClient,
-		bootClient:   bootClient,
-		logger:       logger,
-		syncEnabled:  config.SyncEnabled,
-		syncInterval: config.SyncInterval,
+		hsmClient:       hsmClient,
+		bootClient:      bootClient,
+		logger:          logger,
+		syncEnabled:     config.SyncEnabled,
+		syncInterval:    config.SyncInterval,
+		intervalChanged: make(chan struct{}, 1),
+		conflictPolicy:  config.ConflictPolicy,
 	}, nil
 }
 
 // SyncNodesFromHSM synchronizes node data from HSM to the boot service
 func (s *IntegrationService) SyncNodesFromHSM(ctx context.Context) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	start := time.Now()
+	status, err := s.syncNodes(ctx)
+	status.LastRun = start
+	status.Duration = time.Since(start).Round(time.Millisecond).String()
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	s.mu.Lock()
+	status.Runs = s.lastSync.Runs + 1
+	s.lastSync = status
+	s.mu.Unlock()
+	return err
+}
+
+// SetSyncObserver sets the observer told the nodes of each sync
+func (s *IntegrationService) SetSyncObserver(observer SyncObserver) {
+	s.mu.Lock()
+	s.observer = observer
+	s.mu.Unlock()
+}
+
+// SetConflictPolicy changes the conflict policy of later syncs
+func (s *IntegrationService) SetConflictPolicy(policy ConflictPolicy) {
+	s.mu.Lock()
+	s.conflictPolicy = policy
+	s.mu.Unlock()
+}
+
+// SetConflictObserver sets the observer told the conflicts of each sync.
+// A local edit that stays kept is only reported by the sync that first
+// finds it.
+func (s *IntegrationService) SetConflictObserver(observer ConflictObserver) {
+	s.mu.Lock()
+	s.onConflict = observer
+	s.mu.Unlock()
+}
+
+// Divergences returns the local edits the last sync kept over the values
+// HSM reports, sorted by node and field
+func (s *IntegrationService) Divergences() []Conflict {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.divergences)
+}
+
+// SetPauseCheck sets the check the sync worker makes before each sync;
+// while it reports true, scheduled syncs are skipped
+func (s *IntegrationService) SetPauseCheck(paused PauseCheck) {
+	s.mu.Lock()
+	s.paused = paused
+	s.mu.Unlock()
+}
+
+// LastSync returns the outcome of the most recent sync
+func (s *IntegrationService) LastSync() SyncStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastSync
+}
+
+// syncNodes runs one sync, counting what it did
+func (s *IntegrationService) syncNodes(ctx context.Context) (SyncStatus, error) {
+	var status SyncStatus
 	s.logger.Printf("Starting HSM node synchronization")
 
 	// Get components from HSM
 	components, err := s.hsmClient.GetComponents(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get components from HSM: %w", err)
+		return status, fmt.Errorf("failed to get components from HSM: %w", err)
 	}
 
 	// Filter for compute nodes
@@ -101,21 +217,21 @@ func (s *IntegrationService) SyncNodesFromHSM(ctx context.Context) error {
 	// Get ethernet interfaces for MAC address mapping
 	interfaces, err := s.hsmClient.GetEthernetInterfaces(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get ethernet interfaces from HSM: %w", err)
+		return status, fmt.Errorf("failed to get ethernet interfaces from HSM: %w", err)
 	}
 
 	// Create MAC address lookup map
 	macMap := make(map[string]string) // componentID -> MAC
 	for _, iface := range interfaces {
 		if iface.Type == "Node" {
-			macMap[iface.ComponentID] = iface.MACAddress
+			macMap[iface.ComponentID] = validation.NormalizeMAC(iface.MACAddress)
 		}
 	}
 
 	// Get existing nodes from boot service
 	existingNodes, err := s.bootClient.GetNodes(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get existing nodes: %w", err)
+		return status, fmt.Errorf("failed to get existing nodes: %w", err)
 	}
 
 	// Create lookup map for existing nodes
@@ -124,8 +240,13 @@ func (s *IntegrationService) SyncNodesFromHSM(ctx context.Context) error {
 		existingMap[existingNodes[i].Spec.XName] = &existingNodes[i]
 	}
 
+	s.mu.Lock()
+	policy := s.conflictPolicy
+	s.mu.Unlock()
+
 	// Sync each compute node
-	var created, updated, skipped int
+	var synced []v1.NodeSpec
+	var conflicts []Conflict
 	for _, comp := range computeNodes {
 		membership, err := s.hsmClient.GetMembership(ctx, comp.ID)
 		if err != nil {
@@ -135,114 +256,162 @@ func (s *IntegrationService) SyncNodesFromHSM(ctx context.Context) error {
 		if membership != nil {
 			groups = membership.GroupLabels
 		}
-		err = s.syncNode(ctx, comp, macMap, groups, existingMap)
+		reported := v1.NodeSpec{
+			XName:   comp.ID,
+			NID:     comp.NID,
+			BootMAC: macMap[comp.ID],
+			Role:    comp.Role,
+			SubRole: comp.SubRole,
+			Groups:  groups,
+		}
+
+		existing, exists := existingMap[comp.ID]
+		if !exists {
+			if err := s.createNode(ctx, reported); err != nil {
+				s.logger.Printf("Warning: Failed to sync node %s: %v", comp.ID, err)
+				status.Failed++
+				continue
+			}
+			synced = append(synced, reported)
+			status.Created++
+			continue
+		}
+
+		spec, nodeConflicts, changed, err := s.updateNode(ctx, existing, reported, s.nodePolicy(policy, existing))
 		if err != nil {
 			s.logger.Printf("Warning: Failed to sync node %s: %v", comp.ID, err)
+			status.Failed++
 			continue
 		}
-
-		// Track what we did
-		if existing, exists := existingMap[comp.ID]; exists {
-			if s.needsUpdate(comp, macMap, groups, existing) {
-				updated++
-			} else {
-				skipped++
-			}
+		synced = append(synced, spec)
+		conflicts = append(conflicts, nodeConflicts...)
+		if changed {
+			status.Updated++
 		} else {
-			created++
+			status.Skipped++
 		}
 	}
+	status.Conflicts = len(conflicts)
 
-	s.logger.Printf("HSM sync complete: %d created, %d updated, %d skipped", created, updated, skipped)
-	return nil
-}
-
-// syncNode synchronizes a single node from HSM
-func (s *IntegrationService) syncNode(ctx context.Context, comp HSMComponent, macMap map[string]string, groups []string, existingMap map[string]*v1.Node) error {
-	// Check if node already exists
-	existing, exists := existingMap[comp.ID]
-
-	// Get MAC address for this component
-	bootMAC := macMap[comp.ID]
-
-	// Create node spec from HSM data
-	nodeSpec := v1.NodeSpec{
-		XName:   comp.ID,
-		NID:     comp.NID,
-		BootMAC: bootMAC,
-		Role:    comp.Role,
-		SubRole: comp.SubRole,
-		Groups:  groups,
-	}
-
-	if exists {
-		// Update existing node if needed
-		if s.needsUpdate(comp, macMap, groups, existing) {
-			updateReq := client.UpdateNodeRequest{
-				Spec: nodeSpec,
-			}
-
-			_, err := s.bootClient.UpdateNode(ctx, existing.Metadata.UID, updateReq)
-			if err != nil {
-				return fmt.Errorf("failed to update node %s: %w", comp.ID, err)
-			}
-
-			s.logger.Printf("Updated node %s from HSM", comp.ID)
-		}
-	} else {
-		// Create new node
-		createReq := client.CreateNodeRequest{
-			Spec: nodeSpec,
-		}
-		createReq.Metadata.Name = comp.ID
+	s.logger.Printf("HSM sync complete: %d created, %d updated, %d skipped, %d failed, %d conflicts",
+		status.Created, status.Updated, status.Skipped, status.Failed, status.Conflicts)
 
-		_, err := s.bootClient.CreateNode(ctx, createReq)
-		if err != nil {
-			return fmt.Errorf("failed to create node %s: %w", comp.ID, err)
-		}
+	s.recordConflicts(conflicts)
+	s.mu.Lock()
+	observer := s.observer
+	s.mu.Unlock()
+	if observer != nil {
+		observer(ctx, synced)
+	}
+	return status, nil
+}
 
-		s.logger.Printf("Created node %s from HSM", comp.ID)
+// createNode creates a node from the values HSM reported
+func (s *IntegrationService) createNode(ctx context.Context, reported v1.NodeSpec) error {
+	createReq := client.CreateNodeRequest{
+		Spec:        reported,
+		Annotations: map[string]string{SyncedAnnotation: encodeValues(reportedValues(reported))},
 	}
+	createReq.Metadata.Name = reported.XName
 
+	if _, err := s.bootClient.CreateNode(ctx, createReq); err != nil {
+		return fmt.Errorf("failed to create node %s: %w", reported.XName, err)
+	}
+	s.logger.Printf("Created node %s from HSM", reported.XName)
 	return nil
 }
 
-// needsUpdate checks if a node needs to be updated based on HSM data
-func (s *IntegrationService) needsUpdate(comp HSMComponent, macMap map[string]string, groups []string, existing *v1.Node) bool {
-	// Check if NID changed
-	if comp.NID != existing.Spec.NID {
-		return true
+// updateNode applies the values HSM reported to an existing node as policy
+// allows, returning the node's spec and conflicts, and whether its spec
+// changed. The reported values are recorded with the node so the next sync
+// can tell local edits from HSM changes.
+func (s *IntegrationService) updateNode(ctx context.Context, existing *v1.Node, reported v1.NodeSpec, policy ConflictPolicy) (v1.NodeSpec, []Conflict, bool, error) {
+	merged, conflicts := reconcileNode(existing, reported, policy)
+	changed := specChanged(&merged, &existing.Spec)
+	record := encodeValues(reportedValues(reported))
+	if !changed && existing.Metadata.Annotations[SyncedAnnotation] == record {
+		return existing.Spec, conflicts, false, nil
 	}
 
-	// Check if Role changed
-	if comp.Role != existing.Spec.Role {
-		return true
+	updateReq := client.UpdateNodeRequest{
+		Spec:        merged,
+		Annotations: map[string]string{SyncedAnnotation: record},
+	}
+	if _, err := s.bootClient.UpdateNode(ctx, existing.Metadata.UID, updateReq); err != nil {
+		return v1.NodeSpec{}, nil, false, fmt.Errorf("failed to update node %s: %w", existing.Spec.XName, err)
 	}
+	if changed {
+		s.logger.Printf("Updated node %s from HSM", existing.Spec.XName)
+	}
+	return merged, conflicts, changed, nil
+}
 
-	// Check if SubRole changed
-	if comp.SubRole != existing.Spec.SubRole {
-		return true
+// nodePolicy returns policy overridden by the PolicyAnnotation of node. An
+// annotation that cannot be parsed is ignored.
+func (s *IntegrationService) nodePolicy(policy ConflictPolicy, node *v1.Node) ConflictPolicy {
+	value, ok := node.Metadata.Annotations[PolicyAnnotation]
+	if !ok {
+		return policy
 	}
+	nodePolicy, err := ParseConflictPolicy(value)
+	if err != nil {
+		s.logger.Printf("Warning: ignoring %s of node %s: %v", PolicyAnnotation, node.Spec.XName, err)
+		return policy
+	}
+	return policy.with(nodePolicy)
+}
 
-	// Check if membership has changed
-	if !stringSlicesEqual(groups, existing.Spec.Groups) {
-		return true
+// recordConflicts remembers the local edits a sync kept and tells the
+// conflict observer the conflicts not reported by the previous sync
+func (s *IntegrationService) recordConflicts(conflicts []Conflict) {
+	var divergences []Conflict
+	for _, conflict := range conflicts {
+		if conflict.Kept == KeptLocal {
+			divergences = append(divergences, conflict)
+		}
 	}
+	slices.SortFunc(divergences, func(a, b Conflict) int {
+		return cmp.Or(cmp.Compare(a.Node, b.Node), cmp.Compare(a.Field, b.Field))
+	})
 
-	// Check if MAC address changed
-	bootMAC := macMap[comp.ID]
-	return bootMAC != existing.Spec.BootMAC
+	s.mu.Lock()
+	previous := s.divergences
+	s.divergences = divergences
+	observer := s.onConflict
+	s.mu.Unlock()
+	if observer == nil {
+		return
+	}
+	for _, conflict := range conflicts {
+		if conflict.Kept == KeptLocal && slices.Contains(previous, conflict) {
+			continue
+		}
+		observer(conflict)
+	}
 }
 
-// ResolveNodeByIdentifier resolves a node using HSM as fallback
+// ResolveNodeByIdentifier resolves a node using HSM as fallback. Concurrent
+// calls for the same identifier share one resolution.
 func (s *IntegrationService) ResolveNodeByIdentifier(ctx context.Context, identifier string) (*v1.Node, error) {
+	node, err := s.resolutions.Do(ctx, identifier, func(ctx context.Context) (*v1.Node, error) {
+		return s.resolveNode(ctx, identifier)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Each caller gets its own copy of the shared result
+	resolved := *node
+	return &resolved, nil
+}
+
+func (s *IntegrationService) resolveNode(ctx context.Context, identifier string) (*v1.Node, error) {
 	// First try to find in our local database
 	nodes, err := s.bootClient.GetNodes(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nodes from boot service: %w", err)
 	}
 
-	// Try to match by XName, NID, or MAC
+	// Try to match by XName, NID, any interface MAC, hostname, or alias
 	for i := range nodes {
 		n := &nodes[i]
 		if n.Spec.XName == identifier {
@@ -251,7 +420,7 @@ func (s *IntegrationService) ResolveNodeByIdentifier(ctx context.Context, identi
 		if fmt.Sprintf("%d", n.Spec.NID) == identifier {
 			return n, nil
 		}
-		if n.Spec.BootMAC == identifier {
+		if n.Spec.HasMAC(identifier) || n.Spec.HasAlias(identifier) {
 			return n, nil
 		}
 	}
@@ -285,7 +454,7 @@ func (s *IntegrationService) convertHSMComponentToNode(ctx context.Context, comp
 	var bootMAC string
 	for _, iface := range interfaces {
 		if iface.ComponentID == comp.ID {
-			bootMAC = iface.MACAddress
+			bootMAC = validation.NormalizeMAC(iface.MACAddress)
 			break
 		}
 	}
@@ -314,7 +483,7 @@ func (s *IntegrationService) StartSyncWorker(ctx context.Context) {
 		return
 	}
 
-	s.logger.Printf("Starting HSM sync worker (interval: %v)", s.syncInterval)
+	s.logger.Printf("Starting HSM sync worker (interval: %v)", s.SyncInterval())
 
 	// Wait for HSM to be ready before starting sync loop
 	if !s.waitForHSMReady(ctx) {
@@ -322,11 +491,13 @@ func (s *IntegrationService) StartSyncWorker(ctx context.Context) {
 		return
 	}
 
-	ticker := time.NewTicker(s.syncInterval)
+	ticker := time.NewTicker(s.SyncInterval())
 	defer ticker.Stop()
 
 	// Do initial sync (HSM is now ready)
-	if err := s.SyncNodesFromHSM(ctx); err != nil {
+	if s.pausedNow(ctx) {
+		s.logger.Printf("HSM sync paused; skipping initial sync")
+	} else if err := s.SyncNodesFromHSM(ctx); err != nil {
 		s.logger.Printf("Initial HSM sync failed: %v", err)
 	}
 
@@ -336,7 +507,16 @@ func (s *IntegrationService) StartSyncWorker(ctx context.Context) {
 			s.logger.Printf("HSM sync worker stopped")
 			return
 
+		case <-s.intervalChanged:
+			interval := s.SyncInterval()
+			ticker.Reset(interval)
+			s.logger.Printf("HSM sync interval changed to %v", interval)
+
 		case <-ticker.C:
+			if s.pausedNow(ctx) {
+				s.logger.Printf("HSM sync paused; skipping scheduled sync")
+				continue
+			}
 			if err := s.SyncNodesFromHSM(ctx); err != nil {
 				s.logger.Printf("HSM sync failed: %v", err)
 			}
@@ -344,6 +524,39 @@ func (s *IntegrationService) StartSyncWorker(ctx context.Context) {
 	}
 }
 
+// pausedNow reports whether the pause check pauses syncs
+func (s *IntegrationService) pausedNow(ctx context.Context) bool {
+	s.mu.Lock()
+	paused := s.paused
+	s.mu.Unlock()
+	return paused != nil && paused(ctx)
+}
+
+// SyncEnabled reports whether the sync worker runs scheduled syncs
+func (s *IntegrationService) SyncEnabled() bool {
+	return s.syncEnabled
+}
+
+// SyncInterval returns the current sync interval
+func (s *IntegrationService) SyncInterval() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.syncInterval
+}
+
+// SetSyncInterval changes the sync interval of a running worker
+func (s *IntegrationService) SetSyncInterval(interval time.Duration) {
+	s.mu.Lock()
+	s.syncInterval = interval
+	s.mu.Unlock()
+
+	select {
+	case s.intervalChanged <- struct{}{}:
+	default: // a change is already pending
+	}
+}
+
 // waitForHSMReady waits for HSM to become available with exponential backoff
 func (s *IntegrationService) waitForHSMReady(ctx context.Context) bool {
 	maxRetries := 10
@@ -393,7 +606,9 @@ func (s *IntegrationService) GetHSMStats(ctx context.Context) (map[string]interf
 		"hsm_integration_enabled": true,
 		"hsm_client_stats":        hsmStats,
 		"sync_enabled":            s.syncEnabled,
-		"sync_interval":           s.syncInterval.String(),
+		"sync_interval":           s.SyncInterval().String(),
+		"deduplicated_lookups":    s.resolutions.Shared(),
+		"last_sync":               s.LastSync(),
 	}
 
 	return stats, nil
@@ -408,25 +623,10 @@ func (s *IntegrationService) GetStats(ctx context.Context) map[string]interface{
 		"hsm_integration_enabled": true,
 		"hsm_client_stats":        hsmStats,
 		"sync_enabled":            s.syncEnabled,
-		"sync_interval":           s.syncInterval.String(),
+		"sync_interval":           s.SyncInterval().String(),
+		"deduplicated_lookups":    s.resolutions.Shared(),
+		"last_sync":               s.LastSync(),
 	}
 
 	return stats
 }
-
-func stringSlicesEqual(a, b []string) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	seen := make(map[string]int, len(a))
-	for _, v := range a {
-		seen[v]++
-	}
-	for _, v := range b {
-		seen[v]--
-		if seen[v] < 0 {
-			return false
-		}
-	}
-	return true
-}
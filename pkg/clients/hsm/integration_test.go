@@ -0,0 +1,93 @@
+// Copyright © 2026 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package hsm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/client"
+)
+
+// syncTarget records the nodes a sync creates, refusing one of them
+type syncTarget struct {
+	client.API
+	refuse string
+}
+
+func (s *syncTarget) GetNodes(context.Context) ([]v1.Node, error) { return nil, nil }
+
+func (s *syncTarget) CreateNode(_ context.Context, req client.CreateNodeRequest) (*v1.Node, error) {
+	if req.Spec.XName == s.refuse {
+		return nil, errors.New("storage full")
+	}
+	return &v1.Node{Spec: req.Spec}, nil
+}
+
+func TestIntegrationService_LastSync(t *testing.T) {
+	var unavailable atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case unavailable.Load():
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		case r.URL.Path == "/hsm/v2/State/Components":
+			json.NewEncoder(w).Encode(HSMResponse{Components: []HSMComponent{ //nolint:errcheck
+				{ID: "x1000c0s0b0n0", Type: "Node", Role: "Compute", NID: 1},
+				{ID: "x1000c0s0b0n1", Type: "Node", Role: "Compute", NID: 2},
+				{ID: "x1000c0s0b0", Type: "NodeBMC"},
+			}})
+		default:
+			w.Write([]byte("[]")) //nolint:errcheck
+		}
+	}))
+	defer server.Close()
+
+	config := DefaultIntegrationConfig()
+	config.HSMConfig.BaseURL = server.URL
+	logger := log.New(io.Discard, "", 0)
+	hsmClient, err := NewHSMClient(config.HSMConfig, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewIntegrationServiceWithClient(hsmClient, config, &syncTarget{refuse: "x1000c0s0b0n1"}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var observed []v1.NodeSpec
+	service.SetSyncObserver(func(_ context.Context, nodes []v1.NodeSpec) {
+		observed = nodes
+	})
+	if status := service.LastSync(); status.Runs != 0 {
+		t.Errorf("status before any sync = %+v", status)
+	}
+
+	if err := service.SyncNodesFromHSM(context.Background()); err != nil {
+		t.Fatalf("SyncNodesFromHSM failed: %v", err)
+	}
+	status := service.LastSync()
+	if status.Runs != 1 || status.Created != 1 || status.Failed != 1 || status.Error != "" || status.LastRun.IsZero() {
+		t.Errorf("status = %+v, want one created and one failed", status)
+	}
+	if len(observed) != 1 || observed[0].XName != "x1000c0s0b0n0" || observed[0].NID != 1 {
+		t.Errorf("observed nodes = %+v, want only the created node", observed)
+	}
+
+	unavailable.Store(true)
+	hsmClient.ClearCache()
+	if err := service.SyncNodesFromHSM(context.Background()); err == nil {
+		t.Fatal("expected error syncing from an unavailable HSM")
+	}
+	if status := service.LastSync(); status.Runs != 2 || status.Error == "" || status.Created != 0 {
+		t.Errorf("status = %+v, want the failed second run", status)
+	}
+}
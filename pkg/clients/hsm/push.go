@@ -0,0 +1,192 @@
+// Copyright © 2026 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package hsm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// pushRetryInterval is how often nodes whose push failed are retried
+const pushRetryInterval = time.Minute
+
+// pushedDescription marks the ethernet interfaces a Pusher creates in HSM
+const pushedDescription = "Added by boot-service"
+
+// Pusher creates the HSM component and ethernet interfaces of nodes created
+// in the boot service, manually or by discovery, so HSM learns about
+// hardware the boot service saw first. Nodes created by HSM sync are not
+// pushed back, and components HSM already knows are left as they are.
+type Pusher struct {
+	client *HSMClient
+	logger *log.Logger
+
+	mu      sync.Mutex
+	pending map[string]v1.NodeSpec // by xname
+	trigger chan struct{}
+}
+
+// NewPusher creates a pusher writing to client
+func NewPusher(client *HSMClient, logger *log.Logger) *Pusher {
+	return &Pusher{
+		client:  client,
+		logger:  logger,
+		pending: make(map[string]v1.NodeSpec),
+		trigger: make(chan struct{}, 1),
+	}
+}
+
+// HandleResourceChange queues a node for pushing when it gets an xname
+// outside HSM sync: on creation, or when a discovered node is adopted.
+// Writes of other replicas are pushed there.
+func (p *Pusher) HandleResourceChange(ctx context.Context, event resourcewatch.Event) {
+	if event.ResourceType != "Node" || event.Type == resourcewatch.Deleted || resourcewatch.Remote(ctx) {
+		return
+	}
+	var node v1.Node
+	if json.Unmarshal(event.New, &node) != nil || !pushable(&node) {
+		return
+	}
+	var old v1.Node
+	if event.Old != nil && json.Unmarshal(event.Old, &old) == nil && pushable(&old) && old.Spec.XName == node.Spec.XName {
+		return
+	}
+
+	p.mu.Lock()
+	p.pending[node.Spec.XName] = node.Spec
+	p.mu.Unlock()
+	select {
+	case p.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// pushable reports whether node is a node HSM may not know: one with an
+// xname that neither awaits adoption nor came from HSM sync
+func pushable(node *v1.Node) bool {
+	if node.Spec.XName == "" || node.Discovered() {
+		return false
+	}
+	_, synced := node.Metadata.Annotations[SyncedAnnotation]
+	return !synced
+}
+
+// Run pushes queued nodes until ctx is done, retrying failed pushes every
+// minute
+func (p *Pusher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pushRetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.trigger:
+		case <-ticker.C:
+		}
+		p.flush(ctx)
+	}
+}
+
+// flush pushes the queued nodes, keeping those that failed queued
+func (p *Pusher) flush(ctx context.Context) {
+	p.mu.Lock()
+	nodes := p.pending
+	p.pending = make(map[string]v1.NodeSpec)
+	p.mu.Unlock()
+
+	for xname, spec := range nodes {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := p.Push(ctx, spec); err != nil {
+			p.logger.Printf("Failed to push node %s to HSM, retrying: %v", xname, err)
+			p.mu.Lock()
+			if _, requeued := p.pending[xname]; !requeued {
+				p.pending[xname] = spec
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// Push creates the component of spec in HSM unless HSM knows it, and the
+// ethernet interfaces of its MACs that HSM lacks
+func (p *Pusher) Push(ctx context.Context, spec v1.NodeSpec) error {
+	created := false
+	_, err := p.client.GetComponent(ctx, spec.XName)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		if err := p.client.CreateComponent(ctx, nodeComponent(spec)); err != nil {
+			return err
+		}
+		created = true
+	case err != nil:
+		return fmt.Errorf("failed to look up component %s: %w", spec.XName, err)
+	}
+
+	added := 0
+	for _, iface := range nodeInterfaces(spec) {
+		ok, err := p.client.CreateEthernetInterface(ctx, iface)
+		if err != nil {
+			return err
+		}
+		if ok {
+			added++
+		}
+	}
+	if created || added > 0 {
+		p.logger.Printf("Pushed node %s to HSM (component created: %t, interfaces added: %d)", spec.XName, created, added)
+	}
+	return nil
+}
+
+// nodeComponent returns the HSM component of a node
+func nodeComponent(spec v1.NodeSpec) HSMComponent {
+	role := spec.Role
+	if role == "" {
+		role = "Compute"
+	}
+	return HSMComponent{
+		ID:      spec.XName,
+		Type:    "Node",
+		State:   "Populated",
+		Flag:    "OK",
+		Enabled: true,
+		Role:    role,
+		SubRole: spec.SubRole,
+		NID:     spec.NID,
+	}
+}
+
+// nodeInterfaces returns the HSM ethernet interfaces of the MACs of a node,
+// the boot MAC first
+func nodeInterfaces(spec v1.NodeSpec) []HSMEthernetInterface {
+	ips := make(map[string]string)
+	for _, iface := range spec.Interfaces {
+		if iface.IP != "" {
+			ips[validation.NormalizeMAC(iface.MAC)] = iface.IP
+		}
+	}
+	var interfaces []HSMEthernetInterface
+	for _, mac := range spec.MACs() {
+		interfaces = append(interfaces, HSMEthernetInterface{
+			MACAddress:  mac,
+			IPAddress:   ips[validation.NormalizeMAC(mac)],
+			ComponentID: spec.XName,
+			Type:        "Node",
+			Description: pushedDescription,
+		})
+	}
+	return interfaces
+}
@@ -0,0 +1,169 @@
+// Copyright © 2026 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package hsm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	fabrica "github.com/openchami/fabrica/pkg/resource"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// fakeInventory is an HSM that stores the components and ethernet
+// interfaces posted to it
+type fakeInventory struct {
+	mu         sync.Mutex
+	components map[string]HSMComponent
+	interfaces map[string]HSMEthernetInterface // by MAC
+	posts      int
+}
+
+func newFakeInventory(t *testing.T) (*fakeInventory, *HSMClient) {
+	t.Helper()
+	inventory := &fakeInventory{components: map[string]HSMComponent{}, interfaces: map[string]HSMEthernetInterface{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inventory.mu.Lock()
+		defer inventory.mu.Unlock()
+		switch {
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/hsm/v2/State/Components/"):
+			component, ok := inventory.components[strings.TrimPrefix(r.URL.Path, "/hsm/v2/State/Components/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(component) //nolint:errcheck
+		case r.Method == "POST" && r.URL.Path == "/hsm/v2/State/Components":
+			inventory.posts++
+			var body HSMResponse
+			json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+			for _, component := range body.Components {
+				inventory.components[component.ID] = component
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "POST" && r.URL.Path == "/hsm/v2/Inventory/EthernetInterfaces":
+			inventory.posts++
+			var iface HSMEthernetInterface
+			json.NewDecoder(r.Body).Decode(&iface) //nolint:errcheck
+			if _, ok := inventory.interfaces[iface.MACAddress]; ok {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			inventory.interfaces[iface.MACAddress] = iface
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	config := DefaultHSMConfig()
+	config.BaseURL = server.URL
+	client, err := NewHSMClient(config, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return inventory, client
+}
+
+func TestPusher_Push(t *testing.T) {
+	ctx := context.Background()
+	inventory, client := newFakeInventory(t)
+	pusher := NewPusher(client, log.New(io.Discard, "", 0))
+
+	spec := v1.NodeSpec{
+		XName:      "x1000c0s0b0n0",
+		NID:        7,
+		BootMAC:    "AA:BB:CC:DD:EE:01",
+		Interfaces: []v1.NodeInterface{{MAC: "aa:bb:cc:dd:ee:02", IP: "10.0.0.7"}},
+	}
+	if err := pusher.Push(ctx, spec); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	component := inventory.components["x1000c0s0b0n0"]
+	if component.Type != "Node" || component.Role != "Compute" || component.NID != 7 || !component.Enabled {
+		t.Errorf("component = %+v, want an enabled compute node", component)
+	}
+	if iface := inventory.interfaces["aa:bb:cc:dd:ee:02"]; iface.ComponentID != "x1000c0s0b0n0" || iface.IPAddress != "10.0.0.7" {
+		t.Errorf("interface = %+v, want the node's second interface", iface)
+	}
+	if len(inventory.interfaces) != 2 {
+		t.Errorf("interfaces = %+v, want the boot MAC too", inventory.interfaces)
+	}
+
+	// HSM's own component is not overwritten, and interfaces it has are
+	// not an error
+	inventory.components["x1000c0s0b0n0"] = HSMComponent{ID: "x1000c0s0b0n0", Type: "Node", Role: "Application"}
+	client.ClearCache()
+	inventory.posts = 0
+	if err := pusher.Push(ctx, spec); err != nil {
+		t.Fatalf("second Push failed: %v", err)
+	}
+	if inventory.components["x1000c0s0b0n0"].Role != "Application" || inventory.posts != 2 {
+		t.Errorf("component = %+v after %d posts, want HSM's component kept", inventory.components["x1000c0s0b0n0"], inventory.posts)
+	}
+}
+
+func TestPusher_HandleResourceChange(t *testing.T) {
+	_, client := newFakeInventory(t)
+	pusher := NewPusher(client, log.New(io.Discard, "", 0))
+	event := func(eventType string, old, node *v1.Node) resourcewatch.Event {
+		e := resourcewatch.Event{Type: eventType, ResourceType: "Node", UID: "node-1"}
+		if old != nil {
+			e.Old, _ = json.Marshal(old)
+		}
+		if node != nil {
+			e.New, _ = json.Marshal(node)
+		}
+		return e
+	}
+	queued := func() []string {
+		pusher.mu.Lock()
+		defer pusher.mu.Unlock()
+		var xnames []string
+		for xname := range pusher.pending {
+			xnames = append(xnames, xname)
+		}
+		clear(pusher.pending)
+		return xnames
+	}
+	ctx := context.Background()
+
+	manual := &v1.Node{Spec: v1.NodeSpec{XName: "x1000c0s0b0n0"}}
+	pusher.HandleResourceChange(ctx, event(resourcewatch.Created, nil, manual))
+	if got := queued(); len(got) != 1 || got[0] != "x1000c0s0b0n0" {
+		t.Errorf("queued after a manual create = %v, want the node", got)
+	}
+
+	synced := &v1.Node{Metadata: fabrica.Metadata{Annotations: map[string]string{SyncedAnnotation: "{}"}}, Spec: v1.NodeSpec{XName: "x1000c0s0b1n0"}}
+	pusher.HandleResourceChange(ctx, event(resourcewatch.Created, nil, synced))
+	edited := *manual
+	edited.Spec.Hostname = "nid001"
+	pusher.HandleResourceChange(ctx, event(resourcewatch.Updated, manual, &edited))
+	if got := queued(); len(got) != 0 {
+		t.Errorf("queued after a synced create and an edit = %v, want nothing", got)
+	}
+
+	// A discovered node is pushed once adopted
+	discovered := &v1.Node{Metadata: fabrica.Metadata{Labels: map[string]string{v1.DiscoveredLabel: "true"}}}
+	pusher.HandleResourceChange(ctx, event(resourcewatch.Created, nil, discovered))
+	if got := queued(); len(got) != 0 {
+		t.Errorf("queued after discovery = %v, want nothing until adopted", got)
+	}
+	adopted := &v1.Node{Spec: v1.NodeSpec{XName: "x1000c0s1b0n0"}}
+	pusher.HandleResourceChange(ctx, event(resourcewatch.Updated, discovered, adopted))
+	if got := queued(); len(got) != 1 || got[0] != "x1000c0s1b0n0" {
+		t.Errorf("queued after adoption = %v, want the node", got)
+	}
+}
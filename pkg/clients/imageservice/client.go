@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package imageservice provides a client for the OpenCHAMI image service,
+// which publishes the kernel, initrd, and root filesystem of each built image
+package imageservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openchami/boot-service/internal/flight"
+)
+
+// ErrNotFound is returned when the image service has no image for a reference
+var ErrNotFound = errors.New("image not found")
+
+// Image is the boot view of an image: where its artifacts are served from
+type Image struct {
+	Name   string `json:"name"`
+	Kernel string `json:"kernel"`
+	Initrd string `json:"initrd,omitempty"`
+	RootFS string `json:"rootfs,omitempty"`
+}
+
+// Config holds configuration for the image service client
+type Config struct {
+	BaseURL   string
+	AuthToken string
+	Timeout   time.Duration
+	// CacheTTL is how long a resolved image is used before it is fetched
+	// again
+	CacheTTL time.Duration
+}
+
+// Client resolves image references with the image service. Resolutions are
+// cached; when the service cannot be reached, the last resolution of a
+// reference is used however old it is, so nodes keep booting.
+type Client struct {
+	config     Config
+	httpClient *http.Client
+	logger     *log.Logger
+
+	mu    sync.Mutex
+	cache map[string]cachedImage
+	calls flight.Group[*Image]
+}
+
+type cachedImage struct {
+	image     Image
+	fetchedAt time.Time
+}
+
+// NewClient creates an image service client
+func NewClient(config Config, logger *log.Logger) *Client {
+	return &Client{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+		logger:     logger,
+		cache:      make(map[string]cachedImage),
+	}
+}
+
+// ResolveImage returns the image a reference, such as "compute" or
+// "compute:v2", currently points to
+func (c *Client) ResolveImage(ctx context.Context, ref string) (*Image, error) {
+	c.mu.Lock()
+	cached, ok := c.cache[ref]
+	c.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < c.config.CacheTTL {
+		image := cached.image
+		return &image, nil
+	}
+
+	image, err := c.calls.Do(ctx, ref, func(ctx context.Context) (*Image, error) {
+		return c.fetch(ctx, ref)
+	})
+	if err != nil {
+		if ok && !errors.Is(err, ErrNotFound) {
+			c.logger.Printf("Using image %s resolved at %s: %v", ref, cached.fetchedAt.Format(time.RFC3339), err)
+			image := cached.image
+			return &image, nil
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if ok && cached.image != *image {
+		c.logger.Printf("Image %s changed: kernel %s, initrd %s, rootfs %s", ref, image.Kernel, image.Initrd, image.RootFS)
+	}
+	c.cache[ref] = cachedImage{image: *image, fetchedAt: time.Now()}
+	c.mu.Unlock()
+
+	result := *image
+	return &result, nil
+}
+
+// fetch gets an image from GET <base>/images/{ref}
+func (c *Client) fetch(ctx context.Context, ref string) (*Image, error) {
+	endpoint := strings.TrimSuffix(c.config.BaseURL, "/") + "/images/" + url.PathEscape(ref)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating image request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.config.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.AuthToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting image %s: %w", ref, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, ref)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("image service returned %s for image %s", resp.Status, ref)
+	}
+
+	var image Image
+	if err := json.NewDecoder(resp.Body).Decode(&image); err != nil {
+		return nil, fmt.Errorf("decoding image %s: %w", ref, err)
+	}
+	if image.Kernel == "" {
+		return nil, fmt.Errorf("image %s has no kernel", ref)
+	}
+	return &image, nil
+}
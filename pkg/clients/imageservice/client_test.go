@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package imageservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_ResolveImage(t *testing.T) {
+	var requests atomic.Int32
+	var down atomic.Bool
+	kernel := "http://images.example.com/compute/v1/vmlinuz"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Bearer image-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if down.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path != "/images/compute:latest" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Image{
+			Name:   "compute",
+			Kernel: kernel,
+			Initrd: "http://images.example.com/compute/v1/initrd",
+			RootFS: "http://images.example.com/compute/v1/rootfs.squashfs",
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL, AuthToken: "image-token", CacheTTL: time.Hour}, log.New(io.Discard, "", 0))
+	ctx := context.Background()
+
+	image, err := client.ResolveImage(ctx, "compute:latest")
+	if err != nil {
+		t.Fatalf("ResolveImage failed: %v", err)
+	}
+	if image.Kernel != kernel || image.RootFS != "http://images.example.com/compute/v1/rootfs.squashfs" {
+		t.Errorf("unexpected image: %+v", image)
+	}
+
+	if _, err := client.ResolveImage(ctx, "compute:latest"); err != nil {
+		t.Fatalf("cached ResolveImage failed: %v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("image service received %d requests, want 1 while cached", n)
+	}
+
+	if _, err := client.ResolveImage(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ResolveImage(missing) error = %v, want ErrNotFound", err)
+	}
+
+	// An expired image is still used while the service is unavailable
+	client.config.CacheTTL = 0
+	down.Store(true)
+	image, err = client.ResolveImage(ctx, "compute:latest")
+	if err != nil {
+		t.Fatalf("ResolveImage with the service down failed: %v", err)
+	}
+	if image.Kernel != kernel {
+		t.Errorf("stale image kernel = %q, want %q", image.Kernel, kernel)
+	}
+	if _, err := client.ResolveImage(ctx, "other"); err == nil {
+		t.Error("ResolveImage of an uncached image succeeded with the service down")
+	}
+}
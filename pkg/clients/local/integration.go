@@ -9,6 +9,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"maps"
+	"slices"
 	"time"
 
 	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
@@ -18,13 +20,15 @@ import (
 // IntegrationService provides local YAML-based node management
 type IntegrationService struct {
 	yamlProvider *YAMLNodeProvider
-	bootClient   client.Client
+	bootClient   client.API
 	logger       *log.Logger
 	config       IntegrationConfig
 }
 
 // IntegrationConfig configures the local YAML integration
 type IntegrationConfig struct {
+	// YAMLFile is a nodes file, or a directory whose .yaml and .yml files
+	// are merged, e.g. one file per rack
 	YAMLFile     string        `yaml:"yaml_file"`
 	AutoReload   bool          `yaml:"auto_reload"`
 	SyncEnabled  bool          `yaml:"sync_enabled"`
@@ -42,7 +46,7 @@ func DefaultIntegrationConfig() IntegrationConfig {
 }
 
 // NewIntegrationService creates a new local integration service
-func NewIntegrationService(config IntegrationConfig, bootClient client.Client, logger *log.Logger) (*IntegrationService, error) {
+func NewIntegrationService(config IntegrationConfig, bootClient client.API, logger *log.Logger) (*IntegrationService, error) {
 	// Create YAML provider
 	yamlProvider, err := NewYAMLNodeProvider(config.YAMLFile, config.AutoReload, logger)
 	if err != nil {
@@ -67,25 +71,7 @@ func (s *IntegrationService) ResolveNodeByIdentifier(ctx context.Context, identi
 		return nil, fmt.Errorf("node not found in YAML: %w", err)
 	}
 
-	// Create and return node resource
-	nodeResource := &apiv1.Node{
-		Spec: apiv1.NodeSpec{
-			XName:   yamlNode.XName,
-			Role:    yamlNode.Role,
-			SubRole: yamlNode.SubRole,
-			BootMAC: yamlNode.BootMAC,
-		},
-		Status: apiv1.NodeStatus{
-			State: yamlNode.State,
-		},
-	}
-
-	// Add NID if present
-	if yamlNode.NID > 0 {
-		nodeResource.Spec.NID = int32(yamlNode.NID)
-	}
-
-	return nodeResource, nil
+	return yamlNode.Node(), nil
 }
 
 // SyncNodesFromYAML synchronizes all nodes from YAML to the boot service
@@ -108,15 +94,7 @@ func (s *IntegrationService) SyncNodesFromYAML(ctx context.Context) error {
 		existingNode, err := s.bootClient.GetNode(ctx, yamlNode.XName)
 		if err != nil {
 			// Node doesn't exist, create it
-			createReq := client.CreateNodeRequest{
-				Spec: apiv1.NodeSpec{
-					XName:   yamlNode.XName,
-					Role:    yamlNode.Role,
-					SubRole: yamlNode.SubRole,
-					BootMAC: yamlNode.BootMAC,
-					NID:     int32(yamlNode.NID),
-				},
-			}
+			createReq := client.CreateNodeRequest{Spec: yamlNode.Node().Spec}
 			createReq.Metadata.Name = yamlNode.XName
 
 			_, err = s.bootClient.CreateNode(ctx, createReq)
@@ -129,15 +107,7 @@ func (s *IntegrationService) SyncNodesFromYAML(ctx context.Context) error {
 		} else {
 			// Node exists, update if different
 			if s.shouldUpdateNode(existingNode, yamlNode) {
-				updateReq := client.UpdateNodeRequest{
-					Spec: apiv1.NodeSpec{
-						XName:   yamlNode.XName,
-						Role:    yamlNode.Role,
-						SubRole: yamlNode.SubRole,
-						BootMAC: yamlNode.BootMAC,
-						NID:     int32(yamlNode.NID),
-					},
-				}
+				updateReq := client.UpdateNodeRequest{Spec: yamlNode.Node().Spec}
 
 				_, err = s.bootClient.UpdateNode(ctx, yamlNode.XName, updateReq)
 				if err != nil {
@@ -202,11 +172,18 @@ func (s *IntegrationService) GetStats(ctx context.Context) map[string]interface{
 
 // shouldUpdateNode determines if a node needs to be updated
 func (s *IntegrationService) shouldUpdateNode(existing *apiv1.Node, yamlNode YAMLNode) bool {
-	// Compare key fields
+	// Compare key fields; MACs compare in the notation the service stores
+	spec := yamlNode.Node().Spec
 	if existing.Spec.Role != yamlNode.Role ||
 		existing.Spec.SubRole != yamlNode.SubRole ||
-		existing.Spec.BootMAC != yamlNode.BootMAC ||
-		existing.Status.State != yamlNode.State {
+		existing.Spec.BootMAC != spec.BootMAC ||
+		existing.Spec.Hostname != yamlNode.Hostname ||
+		existing.Status.State != yamlNode.State ||
+		!slices.Equal(existing.Spec.Aliases, yamlNode.Aliases) ||
+		existing.Spec.UUID != spec.UUID ||
+		existing.Spec.Serial != spec.Serial ||
+		!slices.Equal(existing.Spec.MACs(), spec.MACs()) ||
+		!maps.Equal(existing.Spec.Metadata, yamlNode.Metadata) {
 		return true
 	}
 
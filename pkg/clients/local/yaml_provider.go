@@ -10,23 +10,54 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/validation"
 )
 
-// YAMLNodeProvider provides node information from a local YAML file
+// YAMLNodeProvider provides node information from a local YAML file, or
+// from every YAML file in a directory merged into one index
 type YAMLNodeProvider struct {
 	filePath     string
+	files        map[string]*nodeFile
 	nodes        map[string]YAMLNode
+	problems     []LoadProblem
 	lastModified time.Time
 	mutex        sync.RWMutex
+	loadMutex    sync.Mutex
 	logger       *log.Logger
 	autoReload   bool
 }
 
+// nodeFile is the last read of one nodes file
+type nodeFile struct {
+	modTime time.Time
+	size    int64
+	nodes   []YAMLNode
+	err     error
+}
+
+// LoadProblem is a nodes file that could not be read, or a node entry left
+// out of the index because it reuses an identifier of an earlier entry
+type LoadProblem struct {
+	File     string `json:"file"`
+	Location string `json:"location,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (p LoadProblem) String() string {
+	if p.Location == "" {
+		return fmt.Sprintf("%s: %s", p.File, p.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", p.File, p.Location, p.Message)
+}
+
 // YAMLNode represents a node configuration in the YAML file
 type YAMLNode struct {
 	ID                 string              `yaml:"id"`
@@ -38,6 +69,10 @@ type YAMLNode struct {
 	Enabled            bool                `yaml:"enabled"`
 	NID                int                 `yaml:"nid,omitempty"`
 	BootMAC            string              `yaml:"boot_mac,omitempty"`
+	Hostname           string              `yaml:"hostname,omitempty"`
+	Aliases            []string            `yaml:"aliases,omitempty"`
+	UUID               string              `yaml:"uuid,omitempty"`
+	Serial             string              `yaml:"serial,omitempty"`
 	EthernetInterfaces []EthernetInterface `yaml:"ethernet_interfaces,omitempty"`
 	Metadata           map[string]string   `yaml:"metadata,omitempty"`
 }
@@ -49,16 +84,48 @@ type EthernetInterface struct {
 	Description string `yaml:"description,omitempty"`
 }
 
+// Node converts the YAML entry to a Node resource
+func (n YAMLNode) Node() *apiv1.Node {
+	node := &apiv1.Node{
+		Spec: apiv1.NodeSpec{
+			XName:    n.XName,
+			Role:     n.Role,
+			SubRole:  n.SubRole,
+			BootMAC:  validation.NormalizeMAC(n.BootMAC),
+			Hostname: n.Hostname,
+			Aliases:  n.Aliases,
+			UUID:     strings.ToLower(n.UUID),
+			Serial:   n.Serial,
+			Metadata: n.Metadata,
+		},
+		Status: apiv1.NodeStatus{
+			State: n.State,
+		},
+	}
+	for _, iface := range n.EthernetInterfaces {
+		node.Spec.Interfaces = append(node.Spec.Interfaces, apiv1.NodeInterface{MAC: validation.NormalizeMAC(iface.MACAddress), IP: iface.IPAddress})
+	}
+
+	// Add NID if present
+	if n.NID > 0 {
+		node.Spec.NID = int32(n.NID)
+	}
+
+	return node
+}
+
 // YAMLNodesFile represents the structure of the YAML file
 type YAMLNodesFile struct {
 	Version string     `yaml:"version"`
 	Nodes   []YAMLNode `yaml:"nodes"`
 }
 
-// NewYAMLNodeProvider creates a new YAML node provider
+// NewYAMLNodeProvider creates a new YAML node provider. filePath is a nodes
+// file or a directory of them; see NodeFiles.
 func NewYAMLNodeProvider(filePath string, autoReload bool, logger *log.Logger) (*YAMLNodeProvider, error) {
 	provider := &YAMLNodeProvider{
 		filePath:   filePath,
+		files:      make(map[string]*nodeFile),
 		nodes:      make(map[string]YAMLNode),
 		logger:     logger,
 		autoReload: autoReload,
@@ -70,80 +137,227 @@ func NewYAMLNodeProvider(filePath string, autoReload bool, logger *log.Logger) (
 		return nil, fmt.Errorf("failed to load initial nodes from %s: %w", filePath, err)
 	}
 
-	provider.logger.Printf("YAML node provider initialized with %d nodes from %s", len(provider.nodes), filePath)
+	provider.logger.Printf("YAML node provider initialized with %d nodes from %s", len(uniqueNodes(provider.nodes)), filePath)
 	return provider, nil
 }
 
-// loadNodes loads node data from the YAML file
+// NodeFiles returns the nodes files at path: path itself for a file, or the
+// .yaml and .yml files directly in a directory, sorted by name. Hidden files
+// are skipped.
+func NodeFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("checking file stats: %w", err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading directory: %w", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := strings.ToLower(filepath.Ext(name))
+		if entry.IsDir() || strings.HasPrefix(name, ".") || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		files = append(files, filepath.Join(path, name))
+	}
+	return files, nil
+}
+
+// loadNodes loads node data from the YAML file or directory. Files whose
+// modification time and size are unchanged since the last read are not read
+// again when auto-reload is enabled. A file of a directory that cannot be
+// read is reported as a problem and keeps the nodes of its last good read;
+// for a single file the error is returned and the index is left unchanged.
 func (p *YAMLNodeProvider) loadNodes() error {
-	// Check if file needs reloading by comparing modification time
-	fileInfo, err := os.Stat(p.filePath)
+	p.loadMutex.Lock()
+	defer p.loadMutex.Unlock()
+
+	info, err := os.Stat(p.filePath)
 	if err != nil {
 		return fmt.Errorf("checking file stats: %w", err)
 	}
+	paths, err := NodeFiles(p.filePath)
+	if err != nil {
+		return err
+	}
 
-	// Skip reload if auto-reload is enabled and file hasn't changed
-	if p.autoReload && !p.lastModified.IsZero() && !fileInfo.ModTime().After(p.lastModified) {
-		// File hasn't been modified since last load
+	changed := len(paths) != len(p.files)
+	files := make(map[string]*nodeFile, len(paths))
+	for _, path := range paths {
+		previous := p.files[path]
+		file, err := p.readFile(path, previous)
+		if err != nil && !info.IsDir() {
+			return err
+		}
+		files[path] = file
+		changed = changed || file != previous
+	}
+	if !changed {
 		return nil
 	}
 
-	// Read YAML file
-	data, err := os.ReadFile(p.filePath)
-	if err != nil {
-		return fmt.Errorf("reading YAML file: %w", err)
+	nodes, problems := buildIndex(paths, files)
+
+	p.mutex.Lock()
+	p.files = files
+	p.nodes = nodes
+	p.problems = problems
+	p.lastModified = time.Now()
+	p.mutex.Unlock()
+
+	for _, problem := range problems {
+		p.logger.Printf("Warning: %s", problem)
 	}
+	p.logger.Printf("Loaded %d nodes from %d YAML files, indexed %d entries", len(uniqueNodes(nodes)), len(paths), len(nodes))
+	return nil
+}
 
-	// Parse YAML
-	var yamlFile YAMLNodesFile
-	err = yaml.Unmarshal(data, &yamlFile)
+// readFile reads and parses one nodes file, or returns previous when the
+// file has not changed since it was read
+func (p *YAMLNodeProvider) readFile(path string, previous *nodeFile) (*nodeFile, error) {
+	fileInfo, err := os.Stat(path)
 	if err != nil {
-		return fmt.Errorf("parsing YAML: %w", err)
+		return p.failedFile(previous, fmt.Errorf("checking file stats: %w", err))
+	}
+	if p.autoReload && previous != nil && fileInfo.ModTime().Equal(previous.modTime) && fileInfo.Size() == previous.size {
+		return previous, nil
 	}
 
-	// Build lookup maps
-	p.mutex.Lock()
-	defer p.mutex.Unlock()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return p.failedFile(previous, fmt.Errorf("reading YAML file: %w", err))
+	}
+	var yamlFile YAMLNodesFile
+	if err := yaml.Unmarshal(data, &yamlFile); err != nil {
+		return p.failedFile(previous, fmt.Errorf("parsing YAML: %w", err))
+	}
+	return &nodeFile{modTime: fileInfo.ModTime(), size: fileInfo.Size(), nodes: yamlFile.Nodes}, nil
+}
 
-	p.nodes = make(map[string]YAMLNode)
+// failedFile records err for a file, keeping the nodes of its last good read
+func (p *YAMLNodeProvider) failedFile(previous *nodeFile, err error) (*nodeFile, error) {
+	file := &nodeFile{err: err}
+	if previous != nil {
+		file.modTime, file.size, file.nodes = previous.modTime, previous.size, previous.nodes
+	}
+	return file, err
+}
 
-	for _, node := range yamlFile.Nodes {
-		// Index by ID, XName, and MAC addresses for flexible lookup
-		if node.ID != "" {
-			p.nodes[node.ID] = node
-		}
-		if node.XName != "" {
-			p.nodes[node.XName] = node
-		}
-		if node.BootMAC != "" {
-			p.nodes[strings.ToLower(node.BootMAC)] = node
+// buildIndex merges the nodes of files, in the order of paths, into one
+// lookup map by ID, XName, MAC addresses, and NID. An entry that reuses an
+// identifier of an earlier entry is left out and reported.
+func buildIndex(paths []string, files map[string]*nodeFile) (map[string]YAMLNode, []LoadProblem) {
+	nodes := make(map[string]YAMLNode)
+	var problems []LoadProblem
+	owners := map[string]string{} // kind and value -> location of the first entry
+
+	for _, path := range paths {
+		file := files[path]
+		if file.err != nil {
+			message := file.err.Error()
+			if len(file.nodes) > 0 {
+				message += fmt.Sprintf(" (keeping %d nodes from the last good read)", len(file.nodes))
+			}
+			problems = append(problems, LoadProblem{File: path, Message: message})
 		}
 
-		// Index by all ethernet interface MAC addresses
-		for _, iface := range node.EthernetInterfaces {
-			if iface.MACAddress != "" {
-				p.nodes[strings.ToLower(iface.MACAddress)] = node
+		for i, node := range file.nodes {
+			location := fmt.Sprintf("nodes[%d]", i)
+			if node.XName != "" {
+				location += " (" + node.XName + ")"
+			}
+			keys := nodeKeys(node)
+			conflict := ""
+			for _, key := range keys {
+				if first, ok := owners[key.kind+" "+key.value]; ok {
+					conflict = fmt.Sprintf("duplicate %s %s, first used by %s", key.kind, key.value, first)
+					break
+				}
+			}
+			if conflict != "" {
+				problems = append(problems, LoadProblem{File: path, Location: location, Message: conflict + "; entry skipped"})
+				continue
+			}
+
+			for _, key := range keys {
+				owners[key.kind+" "+key.value] = path + " " + location
+				nodes[key.value] = node
 			}
 		}
+	}
+	return nodes, problems
+}
+
+// nodeKey is one identifier a node is indexed by
+type nodeKey struct {
+	kind  string
+	value string
+}
 
-		// Index by NID if present
-		if node.NID > 0 {
-			p.nodes[fmt.Sprintf("%d", node.NID)] = node
+// nodeKeys returns the identifiers of node. A MAC listed as both the boot
+// MAC and an interface counts once.
+func nodeKeys(node YAMLNode) []nodeKey {
+	var keys []nodeKey
+	if node.XName != "" {
+		keys = append(keys, nodeKey{"xname", node.XName})
+	}
+	if node.ID != "" && node.ID != node.XName {
+		keys = append(keys, nodeKey{"id", node.ID})
+	}
+	macs := map[string]bool{}
+	for _, mac := range append([]string{node.BootMAC}, interfaceMACs(node)...) {
+		mac = strings.ToLower(validation.NormalizeMAC(mac))
+		if mac != "" && !macs[mac] {
+			macs[mac] = true
+			keys = append(keys, nodeKey{"mac", mac})
+		}
+	}
+	if node.NID > 0 {
+		keys = append(keys, nodeKey{"nid", fmt.Sprintf("%d", node.NID)})
+	}
+	aliases := map[string]bool{}
+	for _, alias := range append([]string{node.Hostname}, node.Aliases...) {
+		alias = strings.ToLower(alias)
+		if alias != "" && !aliases[alias] {
+			aliases[alias] = true
+			keys = append(keys, nodeKey{"alias", alias})
 		}
 	}
+	if node.UUID != "" {
+		keys = append(keys, nodeKey{"uuid", strings.ToLower(node.UUID)})
+	}
+	if node.Serial != "" {
+		// Serials are looked up with the serial: prefix, so they never
+		// shadow another identifier
+		keys = append(keys, nodeKey{"serial", "serial:" + node.Serial})
+	}
+	return keys
+}
 
-	// Update last modified time to the actual file modification time
-	if fileInfo, statErr := os.Stat(p.filePath); statErr == nil {
-		p.lastModified = fileInfo.ModTime()
-	} else {
-		p.lastModified = time.Now()
+func interfaceMACs(node YAMLNode) []string {
+	macs := make([]string, 0, len(node.EthernetInterfaces))
+	for _, iface := range node.EthernetInterfaces {
+		macs = append(macs, iface.MACAddress)
 	}
+	return macs
+}
 
-	p.logger.Printf("Loaded %d nodes from YAML file, indexed %d entries", len(yamlFile.Nodes), len(p.nodes))
-	return nil
+// Problems returns the problems found by the last load: files that could not
+// be read and entries skipped for duplicate identifiers
+func (p *YAMLNodeProvider) Problems() []LoadProblem {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return append([]LoadProblem(nil), p.problems...)
 }
 
-// GetNodeByIdentifier retrieves a node by any identifier (ID, XName, MAC, NID)
+// GetNodeByIdentifier retrieves a node by any identifier (ID, XName, MAC, NID,
+// hostname, alias, UUID, or serial:<serial>)
 func (p *YAMLNodeProvider) GetNodeByIdentifier(ctx context.Context, identifier string) (*YAMLNode, error) { //nolint:revive
 	// Reload if auto-reload is enabled
 	if p.autoReload {
@@ -161,15 +375,15 @@ func (p *YAMLNodeProvider) GetNodeByIdentifier(ctx context.Context, identifier s
 		return &node, nil
 	}
 
-	// Try lowercase MAC address lookup
-	if node, found := p.nodes[strings.ToLower(identifier)]; found {
+	// Try MAC address in any notation, hostname, and alias lookup
+	if node, found := p.nodes[strings.ToLower(validation.NormalizeMAC(identifier))]; found {
 		return &node, nil
 	}
 
 	return nil, fmt.Errorf("node not found for identifier: %s", identifier)
 }
 
-// GetAllNodes returns all nodes from the YAML file
+// GetAllNodes returns all nodes from the YAML file or directory
 func (p *YAMLNodeProvider) GetAllNodes(ctx context.Context) ([]YAMLNode, error) { //nolint:revive
 	// Reload if auto-reload is enabled
 	if p.autoReload {
@@ -181,18 +395,21 @@ func (p *YAMLNodeProvider) GetAllNodes(ctx context.Context) ([]YAMLNode, error)
 	p.mutex.RLock()
 	defer p.mutex.RUnlock()
 
-	// Build unique list of nodes (since we have multiple index entries per node)
+	return uniqueNodes(p.nodes), nil
+}
+
+// uniqueNodes builds the list of nodes in an index, which has several
+// entries per node
+func uniqueNodes(index map[string]YAMLNode) []YAMLNode {
 	seen := make(map[string]bool)
 	var nodes []YAMLNode
-
-	for _, node := range p.nodes {
+	for _, node := range index {
 		if !seen[node.XName] {
 			nodes = append(nodes, node)
 			seen[node.XName] = true
 		}
 	}
-
-	return nodes, nil
+	return nodes
 }
 
 // GetNodesByRole returns nodes filtered by role
@@ -222,8 +439,13 @@ func (p *YAMLNodeProvider) HealthCheck(ctx context.Context) error { //nolint:rev
 
 	p.mutex.RLock()
 	nodeCount := len(p.nodes)
+	fileCount := len(p.files)
+	failed := p.failedFiles()
 	p.mutex.RUnlock()
 
+	if len(failed) > 0 {
+		return fmt.Errorf("YAML health check failed: %d of %d node files could not be read: %s", len(failed), fileCount, strings.Join(failed, "; "))
+	}
 	if nodeCount == 0 {
 		return fmt.Errorf("YAML file contains no nodes")
 	}
@@ -231,36 +453,50 @@ func (p *YAMLNodeProvider) HealthCheck(ctx context.Context) error { //nolint:rev
 	return nil
 }
 
+// failedFiles describes the files whose last read failed. The caller holds
+// p.mutex.
+func (p *YAMLNodeProvider) failedFiles() []string {
+	var failed []string
+	for _, problem := range p.problems {
+		if problem.Location == "" {
+			failed = append(failed, problem.String())
+		}
+	}
+	return failed
+}
+
 // GetStats returns statistics about the loaded nodes
 func (p *YAMLNodeProvider) GetStats(ctx context.Context) map[string]interface{} { //nolint:revive
 	p.mutex.RLock()
 	defer p.mutex.RUnlock()
 
 	// Count unique nodes and roles
-	uniqueNodes := make(map[string]bool)
+	nodes := uniqueNodes(p.nodes)
 	roles := make(map[string]int)
-
-	for _, node := range p.nodes {
-		if !uniqueNodes[node.XName] {
-			uniqueNodes[node.XName] = true
-			roles[node.Role]++
-		}
+	for _, node := range nodes {
+		roles[node.Role]++
+	}
+	problems := make([]string, 0, len(p.problems))
+	for _, problem := range p.problems {
+		problems = append(problems, problem.String())
 	}
 
 	stats := map[string]interface{}{
 		"yaml_file":     p.filePath,
+		"yaml_files":    len(p.files),
 		"last_loaded":   p.lastModified,
 		"auto_reload":   p.autoReload,
-		"total_nodes":   len(uniqueNodes),
+		"total_nodes":   len(nodes),
 		"total_indexes": len(p.nodes),
 		"roles":         roles,
-		"yaml_healthy":  true,
+		"load_problems": problems,
+		"yaml_healthy":  len(p.failedFiles()) == 0,
 	}
 
 	return stats
 }
 
-// Reload forces a reload of the YAML file
+// Reload forces a reload of the YAML file or directory
 func (p *YAMLNodeProvider) Reload(ctx context.Context) error { //nolint:revive
 	return p.loadNodes()
 }
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package local
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeNodesFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+const rack1 = `nodes:
+  - xname: x1000c0s0b0n0
+    nid: 1
+    boot_mac: aa:bb:cc:dd:ee:01
+    role: Compute
+  - xname: x1000c0s1b0n0
+    nid: 2
+    boot_mac: aa:bb:cc:dd:ee:02
+    role: Compute
+`
+
+const rack2 = `nodes:
+  - xname: x1001c0s0b0n0
+    nid: 3
+    ethernet_interfaces:
+      - mac_address: AA:BB:CC:DD:EE:03
+    role: Management
+  - xname: x1000c0s0b0n0
+    nid: 4
+  - xname: x1001c0s1b0n0
+    nid: 5
+    boot_mac: AA:BB:CC:DD:EE:02
+`
+
+func newTestProvider(t *testing.T, path string, autoReload bool) *YAMLNodeProvider {
+	t.Helper()
+	provider, err := NewYAMLNodeProvider(path, autoReload, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewYAMLNodeProvider returned error: %v", err)
+	}
+	return provider
+}
+
+func TestYAMLNodeProvider_Directory(t *testing.T) {
+	dir := t.TempDir()
+	writeNodesFile(t, dir, "rack1.yaml", rack1)
+	writeNodesFile(t, dir, "rack2.yml", rack2)
+	writeNodesFile(t, dir, "notes.txt", "not a nodes file")
+	writeNodesFile(t, dir, ".rack3.yaml.swp", "nodes: [")
+	ctx := context.Background()
+
+	provider := newTestProvider(t, dir, false)
+	nodes, err := provider.GetAllNodes(ctx)
+	if err != nil {
+		t.Fatalf("GetAllNodes returned error: %v", err)
+	}
+	if len(nodes) != 3 {
+		t.Errorf("got %d nodes, want 3 from both files without the conflicting entries", len(nodes))
+	}
+	for identifier, want := range map[string]string{
+		"x1001c0s0b0n0":     "x1001c0s0b0n0",
+		"aa:bb:cc:dd:ee:03": "x1001c0s0b0n0",
+		"AA:BB:CC:DD:EE:02": "x1000c0s1b0n0",
+		"x1000c0s0b0n0":     "x1000c0s0b0n0",
+		"1":                 "x1000c0s0b0n0",
+	} {
+		node, err := provider.GetNodeByIdentifier(ctx, identifier)
+		if err != nil || node.XName != want {
+			t.Errorf("GetNodeByIdentifier(%s) = %v, %v; want %s", identifier, node, err, want)
+		}
+	}
+	if _, err := provider.GetNodeByIdentifier(ctx, "4"); err == nil {
+		t.Error("expected the NID of a skipped duplicate entry not to be indexed")
+	}
+
+	problems := provider.Problems()
+	if len(problems) != 2 {
+		t.Fatalf("problems = %v, want the two conflicting entries", problems)
+	}
+	if !strings.HasSuffix(problems[0].File, "rack2.yml") || problems[0].Location != "nodes[1] (x1000c0s0b0n0)" ||
+		!strings.Contains(problems[0].Message, "duplicate xname x1000c0s0b0n0, first used by ") {
+		t.Errorf("problems[0] = %v, want the duplicate xname", problems[0])
+	}
+	if !strings.Contains(problems[1].Message, "duplicate mac aa:bb:cc:dd:ee:02") {
+		t.Errorf("problems[1] = %v, want the duplicate MAC", problems[1])
+	}
+	if err := provider.HealthCheck(ctx); err != nil {
+		t.Errorf("HealthCheck returned error for conflicts only: %v", err)
+	}
+}
+
+func TestYAMLNodeProvider_DirectoryFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeNodesFile(t, dir, "rack1.yaml", rack1)
+	rack2Path := writeNodesFile(t, dir, "rack2.yaml", "nodes:\n  - xname: x1001c0s0b0n0\n    nid: 3\n")
+	ctx := context.Background()
+
+	provider := newTestProvider(t, dir, true)
+	if nodes, _ := provider.GetAllNodes(ctx); len(nodes) != 3 {
+		t.Fatalf("got %d nodes, want 3", len(nodes))
+	}
+
+	// A broken file keeps the nodes of its last good read and is reported
+	writeNodesFile(t, dir, "rack2.yaml", "nodes: [")
+	if err := os.Chtimes(rack2Path, time.Now(), time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if nodes, _ := provider.GetAllNodes(ctx); len(nodes) != 3 {
+		t.Errorf("got %d nodes after breaking rack2.yaml, want its last good nodes kept", len(nodes))
+	}
+	err := provider.HealthCheck(ctx)
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 node files") || !strings.Contains(err.Error(), "rack2.yaml") {
+		t.Errorf("HealthCheck error = %v, want the broken file reported", err)
+	}
+	if stats := provider.GetStats(ctx); stats["yaml_healthy"] != false || stats["yaml_files"] != 2 {
+		t.Errorf("stats = %v, want an unhealthy index of 2 files", stats)
+	}
+
+	// Removing the file drops its nodes
+	if err := os.Remove(rack2Path); err != nil {
+		t.Fatal(err)
+	}
+	if nodes, _ := provider.GetAllNodes(ctx); len(nodes) != 2 {
+		t.Errorf("got %d nodes after removing rack2.yaml, want 2", len(nodes))
+	}
+	if err := provider.HealthCheck(ctx); err != nil {
+		t.Errorf("HealthCheck returned error: %v", err)
+	}
+}
+
+func TestYAMLNodeProvider_SingleFile(t *testing.T) {
+	path := writeNodesFile(t, t.TempDir(), "nodes.yaml", rack1)
+	provider := newTestProvider(t, path, false)
+	ctx := context.Background()
+
+	writeNodesFile(t, filepath.Dir(path), "nodes.yaml", "nodes: [")
+	if err := provider.Reload(ctx); err == nil {
+		t.Error("expected Reload of a broken single file to fail")
+	}
+	if nodes, _ := provider.GetAllNodes(ctx); len(nodes) != 2 {
+		t.Errorf("got %d nodes, want the index left unchanged", len(nodes))
+	}
+
+	if _, err := NewYAMLNodeProvider(filepath.Join(t.TempDir(), "missing.yaml"), false, log.New(io.Discard, "", 0)); err == nil {
+		t.Error("expected an error for a missing nodes file")
+	}
+}
+
+func TestYAMLNodeProvider_Aliases(t *testing.T) {
+	path := writeNodesFile(t, t.TempDir(), "nodes.yaml", `nodes:
+  - xname: x1000c0s0b0n0
+    nid: 1
+    boot_mac: aa:bb:cc:dd:ee:01
+    hostname: nid000001
+    aliases: [Compute-01]
+    ethernet_interfaces:
+      - mac_address: AA:BB:CC:DD:EE:11
+  - xname: x1000c0s1b0n0
+    nid: 2
+    aliases: [compute-01]
+`)
+	provider := newTestProvider(t, path, false)
+	ctx := context.Background()
+
+	for _, identifier := range []string{"nid000001", "compute-01", "aa:bb:cc:dd:ee:11", "AA-BB-CC-DD-EE-01", "aabb.ccdd.ee11"} {
+		node, err := provider.GetNodeByIdentifier(ctx, identifier)
+		if err != nil {
+			t.Errorf("GetNodeByIdentifier(%s) returned error: %v", identifier, err)
+			continue
+		}
+		if node.XName != "x1000c0s0b0n0" {
+			t.Errorf("GetNodeByIdentifier(%s) = %s, want x1000c0s0b0n0", identifier, node.XName)
+		}
+	}
+	if problems := provider.Problems(); len(problems) != 1 || !strings.Contains(problems[0].Message, "compute-01") {
+		t.Errorf("Problems() = %+v, want the duplicate alias reported", problems)
+	}
+
+	spec := provider.nodes["x1000c0s0b0n0"].Node().Spec
+	if macs := spec.MACs(); len(macs) != 2 || macs[1] != "aa:bb:cc:dd:ee:11" {
+		t.Errorf("Node().Spec.MACs() = %v, want the boot MAC and interface MAC", macs)
+	}
+}
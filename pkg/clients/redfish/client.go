@@ -0,0 +1,282 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package redfish reads the parts of BMC Redfish services the boot service
+// uses: the service root, managers, computer systems, and their Ethernet
+// interfaces.
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ServiceRootPath is the Redfish service root every Redfish service serves
+const ServiceRootPath = "/redfish/v1/"
+
+// maxResponse bounds the Redfish documents read
+const maxResponse = 1 << 20
+
+// Health values of a Redfish Status, from best to worst
+const (
+	HealthOK       = "OK"
+	HealthWarning  = "Warning"
+	HealthCritical = "Critical"
+)
+
+// ODataID references a Redfish resource
+type ODataID struct {
+	ID string `json:"@odata.id"`
+}
+
+// Status is the state and health of a Redfish resource
+type Status struct {
+	State        string `json:"State"`
+	Health       string `json:"Health"`
+	HealthRollup string `json:"HealthRollup"` // including its subordinate resources
+}
+
+// ServiceRoot is the part of the Redfish service root read
+type ServiceRoot struct {
+	RedfishVersion string  `json:"RedfishVersion"`
+	Vendor         string  `json:"Vendor"`
+	Product        string  `json:"Product"`
+	Managers       ODataID `json:"Managers"`
+	Systems        ODataID `json:"Systems"`
+}
+
+// Manager is the part of a Redfish manager, the BMC itself, read
+type Manager struct {
+	Manufacturer       string  `json:"Manufacturer"`
+	Model              string  `json:"Model"`
+	FirmwareVersion    string  `json:"FirmwareVersion"`
+	Status             Status  `json:"Status"`
+	EthernetInterfaces ODataID `json:"EthernetInterfaces"`
+}
+
+// Reset types of the ComputerSystem.Reset action
+const (
+	ResetOn              = "On"
+	ResetForceRestart    = "ForceRestart"
+	ResetGracefulRestart = "GracefulRestart"
+	ResetPowerCycle      = "PowerCycle"
+)
+
+// System is the part of a Redfish computer system read
+type System struct {
+	ID                 string  `json:"@odata.id"`
+	PowerState         string  `json:"PowerState"`
+	Status             Status  `json:"Status"`
+	EthernetInterfaces ODataID `json:"EthernetInterfaces"`
+	Actions            struct {
+		Reset struct {
+			Target string `json:"target"`
+		} `json:"#ComputerSystem.Reset"`
+	} `json:"Actions"`
+}
+
+// EthernetInterface is the part of a Redfish Ethernet interface read
+type EthernetInterface struct {
+	MACAddress    string `json:"MACAddress"`
+	IPv4Addresses []struct {
+		Address string `json:"Address"`
+	} `json:"IPv4Addresses"`
+}
+
+// Client reads one Redfish service
+type Client struct {
+	HTTP    *http.Client
+	BaseURL string // such as https://10.254.1.12:443
+	// Username and Password, when set, are sent with every request but the
+	// one for the service root, which services serve without credentials
+	Username string
+	Password string
+}
+
+// NewHTTPClient returns an HTTP client for Redfish services that verifies
+// their certificates unless insecure is set
+func NewHTTPClient(insecure bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecure} //nolint:gosec // BMCs commonly use self-signed certificates
+	return &http.Client{Transport: transport}
+}
+
+// Get decodes the Redfish resource at path into v
+func (c *Client) Get(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(c.BaseURL, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.Username != "" && path != ServiceRootPath {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponse)) //nolint:errcheck
+		return fmt.Errorf("GET %s: %s", path, resp.Status)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponse)).Decode(v); err != nil {
+		return fmt.Errorf("GET %s: %w", path, err)
+	}
+	return nil
+}
+
+// Post posts body as JSON to the Redfish resource at path, such as an
+// action target
+func (c *Client) Post(ctx context.Context, path string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.BaseURL, "/")+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()                                     //nolint:errcheck
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponse)) //nolint:errcheck
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("POST %s: %s", path, resp.Status)
+	}
+	return nil
+}
+
+// ResetSystem resets system, such as with ResetForceRestart
+func (c *Client) ResetSystem(ctx context.Context, system System, resetType string) error {
+	target := system.Actions.Reset.Target
+	if target == "" {
+		if system.ID == "" {
+			return fmt.Errorf("system has no reset action")
+		}
+		target = strings.TrimSuffix(system.ID, "/") + "/Actions/ComputerSystem.Reset"
+	}
+	return c.Post(ctx, target, map[string]string{"ResetType": resetType})
+}
+
+// ServiceRoot returns the service root, failing for a service that is not
+// Redfish
+func (c *Client) ServiceRoot(ctx context.Context) (ServiceRoot, error) {
+	var root ServiceRoot
+	if err := c.Get(ctx, ServiceRootPath, &root); err != nil {
+		return root, err
+	}
+	if root.RedfishVersion == "" {
+		return root, fmt.Errorf("GET %s: not a Redfish service root", ServiceRootPath)
+	}
+	return root, nil
+}
+
+// Members returns the members of the collection at path
+func (c *Client) Members(ctx context.Context, path string) ([]string, error) {
+	var coll struct {
+		Members []ODataID `json:"Members"`
+	}
+	if err := c.Get(ctx, path, &coll); err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, member := range coll.Members {
+		if member.ID != "" {
+			ids = append(ids, member.ID)
+		}
+	}
+	return ids, nil
+}
+
+// FirstManager returns the first manager of root, or ok false when it has
+// none
+func (c *Client) FirstManager(ctx context.Context, root ServiceRoot) (manager Manager, ok bool, err error) {
+	if root.Managers.ID == "" {
+		return manager, false, nil
+	}
+	ids, err := c.Members(ctx, root.Managers.ID)
+	if err != nil || len(ids) == 0 {
+		return manager, false, err
+	}
+	if err := c.Get(ctx, ids[0], &manager); err != nil {
+		return manager, false, err
+	}
+	return manager, true, nil
+}
+
+// Systems returns the computer systems of root
+func (c *Client) Systems(ctx context.Context, root ServiceRoot) ([]System, error) {
+	if root.Systems.ID == "" {
+		return nil, nil
+	}
+	ids, err := c.Members(ctx, root.Systems.ID)
+	if err != nil {
+		return nil, err
+	}
+	systems := make([]System, 0, len(ids))
+	for _, id := range ids {
+		var system System
+		if err := c.Get(ctx, id, &system); err != nil {
+			return systems, err
+		}
+		systems = append(systems, system)
+	}
+	return systems, nil
+}
+
+// Interfaces reads the Ethernet interfaces of the collection at path,
+// returning those read before any error
+func (c *Client) Interfaces(ctx context.Context, path string) ([]EthernetInterface, error) {
+	if path == "" {
+		return nil, nil
+	}
+	ids, err := c.Members(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	var interfaces []EthernetInterface
+	for _, id := range ids {
+		var iface EthernetInterface
+		if err := c.Get(ctx, id, &iface); err != nil {
+			return interfaces, err
+		}
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces, nil
+}
+
+// WorseHealth returns the worse of two Redfish health values. Values other
+// than OK, Warning, and Critical rank below OK.
+func WorseHealth(a, b string) string {
+	if healthRank(b) > healthRank(a) {
+		return b
+	}
+	return a
+}
+
+func healthRank(health string) int {
+	switch health {
+	case HealthOK:
+		return 1
+	case HealthWarning:
+		return 2
+	case HealthCritical:
+		return 3
+	}
+	return 0
+}
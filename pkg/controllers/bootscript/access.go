@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+)
+
+// Access describes where a boot script request came from
+type Access struct {
+	Client string
+	// Proxy is the trusted proxy that forwarded the request for Client, if
+	// any
+	Proxy     string
+	UserAgent string
+	// UUID is the SMBIOS UUID the node reported, if any
+	UUID string
+}
+
+// AccessRecorder counts the boot script requests of each node
+type AccessRecorder interface {
+	RecordAccess(node string, access Access)
+}
+
+type accessKey struct{}
+
+// WithAccess marks ctx as a node's own boot script request, which is counted
+// by the access recorder. Previews, signatures, and pre-warming are not
+// marked.
+func WithAccess(ctx context.Context, access Access) context.Context {
+	return context.WithValue(ctx, accessKey{}, access)
+}
+
+// SetAccessRecorder counts every boot script served to a known node in a
+// context marked with WithAccess, including scripts served from the cache
+func (c *BootScriptController) SetAccessRecorder(recorder AccessRecorder) {
+	c.access = recorder
+}
+
+// recordAccess counts a request of node if ctx is marked
+func (c *BootScriptController) recordAccess(ctx context.Context, node string) {
+	if c.access == nil || node == "" {
+		return
+	}
+	if access, ok := ctx.Value(accessKey{}).(Access); ok {
+		c.access.RecordAccess(node, access)
+	}
+}
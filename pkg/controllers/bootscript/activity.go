@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+
+	"github.com/openchami/boot-service/pkg/activity"
+)
+
+// ActivityRecorder receives a match event for every boot script served
+type ActivityRecorder interface {
+	Publish(event activity.Event)
+}
+
+// SetActivityRecorder reports every boot script served to recorder, with the
+// node and configuration it matched. Pre-warming and previews are not
+// reported.
+func (c *BootScriptController) SetActivityRecorder(recorder ActivityRecorder) {
+	c.activity = recorder
+}
+
+// recordRender reports a rendered script and remembers the match of a cached
+// one, so cache hits are reported and counted with it
+func (c *BootScriptController) recordRender(ctx context.Context, cacheKey, identifier string, result renderResult) {
+	if c.activity == nil && c.access == nil {
+		return
+	}
+	event := c.rememberMatch(cacheKey, identifier, result)
+	c.recordAccess(ctx, event.Node)
+	if c.activity != nil {
+		c.activity.Publish(event)
+	}
+}
+
+// rememberMatch returns the match event of a rendered result and, if the
+// script is cached, remembers it for cache hits
+func (c *BootScriptController) rememberMatch(cacheKey, identifier string, result renderResult) activity.Event {
+	event := matchEvent(identifier, result)
+	if result.template == TemplateDefault && (c.activity != nil || c.access != nil) {
+		c.matches.Store(cacheKey, event)
+	}
+	return event
+}
+
+// matchEvent describes the match of a rendered result
+func matchEvent(identifier string, result renderResult) activity.Event {
+	event := activity.Event{Type: activity.Match, Identifier: identifier, Template: result.template, Reason: result.reason}
+	if result.node != nil {
+		event.Node = result.node.Spec.XName
+		event.Tenant = result.node.Spec.Tenant
+	}
+	if result.config != nil {
+		event.Config = result.config.Metadata.Name
+		event.Kernel = result.config.Spec.Kernel
+		event.Initrd = result.config.Spec.Initrd
+	}
+	return event
+}
+
+// recordCacheHit reports a script served from the cache
+func (c *BootScriptController) recordCacheHit(ctx context.Context, cacheKey, identifier string) {
+	if c.activity == nil && c.access == nil {
+		return
+	}
+	event := activity.Event{Type: activity.Match, Identifier: identifier, Template: TemplateDefault}
+	if match, ok := c.matches.Load(cacheKey); ok {
+		event = match.(activity.Event)
+	}
+	event.Cached = true
+	c.recordAccess(ctx, event.Node)
+	if c.activity != nil {
+		c.activity.Publish(event)
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/openchami/boot-service/pkg/activity"
+)
+
+func TestGenerateBootScript_RecordsActivity(t *testing.T) {
+	ctx := context.Background()
+	resources := secretResources("console=ttyS0")
+	resources.Nodes[0].Spec.Tenant = "site-a"
+	controller := NewBootScriptControllerWithReader(resources, log.New(io.Discard, "", 0))
+	feed := activity.NewFeed(10)
+	events := feed.Subscribe()
+	defer events.Close()
+	controller.SetActivityRecorder(feed)
+
+	for _, cached := range []bool{false, true} {
+		if _, err := controller.GenerateBootScript(ctx, "aa:bb:cc:dd:ee:01", ""); err != nil {
+			t.Fatalf("GenerateBootScript failed: %v", err)
+		}
+		event := <-events.Events
+		if event.Type != activity.Match || event.Identifier != "aa:bb:cc:dd:ee:01" || event.Node != "x1000c0s0b0n0" ||
+			event.Config != "compute" || event.Template != TemplateDefault || event.Tenant != "site-a" || event.Cached != cached {
+			t.Errorf("match event (cached %v) = %+v", cached, event)
+		}
+	}
+
+	if _, err := controller.GenerateBootScript(ctx, "x9999c0s0b0n0", ""); err != nil {
+		t.Fatalf("GenerateBootScript failed: %v", err)
+	}
+	if event := <-events.Events; event.Template != TemplateError || event.Reason == "" || event.Node != "" {
+		t.Errorf("match event for an unknown node = %+v", event)
+	}
+
+	if _, err := controller.PreviewBootScript(ctx, "x1000c0s0b0n0", ""); err != nil {
+		t.Fatalf("PreviewBootScript failed: %v", err)
+	}
+	if len(events.Events) != 0 {
+		t.Error("previews should not be reported")
+	}
+}
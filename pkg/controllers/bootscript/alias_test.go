@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func TestResolveNodeByAnyMACOrAlias(t *testing.T) {
+	nodes := []apiv1.Node{
+		{
+			Metadata: resource.Metadata{UID: "nod-1"},
+			Spec: apiv1.NodeSpec{
+				XName:    "x0c0s0b0n0",
+				NID:      1,
+				BootMAC:  "aa:bb:cc:dd:ee:01",
+				Hostname: "nid000001",
+				Aliases:  []string{"4c4c4544-0031-3010-8033-b4c04f4e3232"},
+				Interfaces: []apiv1.NodeInterface{
+					{MAC: "aa:bb:cc:dd:ee:01"},
+					{MAC: "AA:BB:CC:DD:EE:11"},
+				},
+			},
+		},
+		{
+			// A UID equal to another node's hostname must still win
+			Metadata: resource.Metadata{UID: "ncn-m001"},
+			Spec:     apiv1.NodeSpec{XName: "x0c0s1b0n0", NID: 2, BootMAC: "aa:bb:cc:dd:ee:02"},
+		},
+		{
+			Metadata: resource.Metadata{UID: "nod-3"},
+			Spec:     apiv1.NodeSpec{XName: "x0c0s2b0n0", NID: 3, Hostname: "ncn-m001"},
+		},
+	}
+	controller := newTestControllerWithData(t, nodes, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		identifier string
+		want       string
+	}{
+		{"aa:bb:cc:dd:ee:01", "x0c0s0b0n0"},
+		{"aa:bb:cc:dd:ee:11", "x0c0s0b0n0"},
+		{"AA-BB-CC-DD-EE-01", "x0c0s0b0n0"},
+		{"aabb.ccdd.ee11", "x0c0s0b0n0"},
+		{"NID000001", "x0c0s0b0n0"},
+		{"4C4C4544-0031-3010-8033-B4C04F4E3232", "x0c0s0b0n0"},
+		{"ncn-m001", "x0c0s1b0n0"},
+	}
+	for _, tt := range tests {
+		node, err := controller.resolveNode(ctx, controller.parseNodeIdentifier(tt.identifier))
+		if err != nil {
+			t.Errorf("resolveNode(%s) returned error: %v", tt.identifier, err)
+			continue
+		}
+		if node.Spec.XName != tt.want {
+			t.Errorf("resolveNode(%s) = %s, want %s", tt.identifier, node.Spec.XName, tt.want)
+		}
+	}
+
+	if _, err := controller.resolveNode(ctx, controller.parseNodeIdentifier("aa:bb:cc:dd:ee:99")); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("resolveNode(unknown MAC) error = %v, want ErrNodeNotFound", err)
+	}
+}
+
+func TestResolveNodeByUUIDOrSerial(t *testing.T) {
+	nodes := []apiv1.Node{
+		{
+			Metadata: resource.Metadata{UID: "nod-1"},
+			Spec:     apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, UUID: "4c4c4544-0042-3510-8052-b4c04f384d32", Serial: "42"},
+		},
+		{
+			Metadata: resource.Metadata{UID: "nod-2"},
+			Spec:     apiv1.NodeSpec{XName: "x0c0s1b0n0", NID: 42, Serial: "CZ2D1234"},
+		},
+	}
+	controller := newTestControllerWithData(t, nodes, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		identifier string
+		want       string
+	}{
+		{"4C4C4544-0042-3510-8052-B4C04F384D32", "x0c0s0b0n0"},
+		// A numeric serial needs the prefix; bare, it is a NID
+		{"serial:42", "x0c0s0b0n0"},
+		{"42", "x0c0s1b0n0"},
+		{"serial:CZ2D1234", "x0c0s1b0n0"},
+		{"CZ2D1234", "x0c0s1b0n0"},
+	}
+	for _, tt := range tests {
+		node, err := controller.resolveNode(ctx, controller.parseNodeIdentifier(tt.identifier))
+		if err != nil {
+			t.Errorf("resolveNode(%s) returned error: %v", tt.identifier, err)
+			continue
+		}
+		if node.Spec.XName != tt.want {
+			t.Errorf("resolveNode(%s) = %s, want %s", tt.identifier, node.Spec.XName, tt.want)
+		}
+	}
+}
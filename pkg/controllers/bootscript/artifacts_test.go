@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+type staticArtifactResolver map[string]string
+
+func (r staticArtifactResolver) ResolveArtifactURL(_ context.Context, name string) (string, error) {
+	url, ok := r[name]
+	if !ok {
+		return "", fmt.Errorf("artifact not found: %s", name)
+	}
+	return url, nil
+}
+
+func TestResolveArtifacts(t *testing.T) {
+	controller := createTestController(t)
+	config := &apiv1.BootConfiguration{
+		Spec: apiv1.BootConfigurationSpec{
+			Kernel:         "http://files.example.com/old-vmlinuz",
+			KernelArtifact: "kernel-6.1",
+			InitrdArtifact: "initrd-6.1",
+		},
+	}
+
+	if _, err := controller.resolveArtifacts(context.Background(), config); err == nil {
+		t.Fatal("expected error when no artifact resolver is configured")
+	}
+
+	controller.SetArtifactResolver(staticArtifactResolver{
+		"kernel-6.1": "http://files.example.com/vmlinuz-6.1",
+		"initrd-6.1": "http://files.example.com/initrd-6.1",
+	})
+
+	resolved, err := controller.resolveArtifacts(context.Background(), config)
+	if err != nil {
+		t.Fatalf("resolveArtifacts returned error: %v", err)
+	}
+	if resolved.Spec.Kernel != "http://files.example.com/vmlinuz-6.1" || resolved.Spec.Initrd != "http://files.example.com/initrd-6.1" {
+		t.Errorf("unexpected resolved spec: %+v", resolved.Spec)
+	}
+	if config.Spec.Kernel != "http://files.example.com/old-vmlinuz" {
+		t.Error("resolveArtifacts must not modify the stored configuration")
+	}
+
+	config.Spec.InitrdArtifact = "missing"
+	if _, err := controller.resolveArtifacts(context.Background(), config); err == nil {
+		t.Error("expected error for unknown artifact")
+	}
+}
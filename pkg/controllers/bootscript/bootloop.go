@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// BootLoopGuard switches nodes stuck in a boot loop to a diagnostic
+// configuration
+type BootLoopGuard interface {
+	// Diagnostic returns the name of the configuration node, by xname,
+	// boots instead of its own, or ok false when it boots normally
+	Diagnostic(node string) (config string, ok bool)
+}
+
+// SetBootLoopGuard serves nodes the guard switched to a diagnostic
+// configuration that configuration. The guard calls InvalidateNodeScripts
+// when it switches a node, so cached scripts do not outlive the switch.
+func (c *BootScriptController) SetBootLoopGuard(guard BootLoopGuard) {
+	c.bootLoops = guard
+}
+
+// InvalidateNodeScripts drops the cached scripts of a node, by xname
+func (c *BootScriptController) InvalidateNodeScripts(node string) {
+	c.generation.Add(1)
+	c.cache.InvalidateByNodeID(node)
+}
+
+// ResolveNodeName returns the xname of the node identified by UID, xname,
+// NID, MAC, hostname, or alias
+func (c *BootScriptController) ResolveNodeName(ctx context.Context, identifier string) (string, error) {
+	node, err := c.resolveNode(ctx, c.parseNodeIdentifier(identifier))
+	if err != nil {
+		return "", err
+	}
+	return node.Spec.XName, nil
+}
+
+// bootLoopDiagnostic returns the script of a node switched to a diagnostic
+// configuration
+func (c *BootScriptController) bootLoopDiagnostic(ctx context.Context, identifier string, node *apiv1.Node, profile string) (renderResult, bool) {
+	if c.bootLoops == nil {
+		return renderResult{}, false
+	}
+	name, ok := c.bootLoops.Diagnostic(node.Spec.XName)
+	if !ok {
+		return renderResult{}, false
+	}
+	result := c.renderFallbackConfiguration(ctx, identifier, node, profile, name)
+	if result.reason == "" {
+		result.reason = fmt.Sprintf("boot loop: diagnostic configuration %s", name)
+	}
+	return result, true
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// testBootLoops switches the nodes it maps to a diagnostic configuration
+type testBootLoops map[string]string
+
+func (l testBootLoops) Diagnostic(node string) (string, bool) {
+	config, ok := l[node]
+	return config, ok
+}
+
+func TestBootLoopDiagnosticConfiguration(t *testing.T) {
+	nodes := []apiv1.Node{{
+		Metadata: resource.Metadata{UID: "nod-1"},
+		Spec:     apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:01", Groups: []string{"compute"}},
+	}}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "compute", UID: "bc-1"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz"},
+		},
+		{
+			Metadata: resource.Metadata{Name: "rescue", UID: "bc-2"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"rescue"}, Kernel: "http://files.example.com/rescue"},
+		},
+	}
+	controller := newTestControllerWithData(t, nodes, configs)
+	loops := testBootLoops{}
+	controller.SetBootLoopGuard(loops)
+	ctx := context.Background()
+
+	generate := func() string {
+		t.Helper()
+		script, err := controller.GenerateBootScript(ctx, "aa:bb:cc:dd:ee:01", "")
+		if err != nil {
+			t.Fatalf("GenerateBootScript returned error: %v", err)
+		}
+		return script
+	}
+
+	if script := generate(); !strings.Contains(script, "files.example.com/vmlinuz") {
+		t.Fatalf("expected the node's own kernel, got:\n%s", script)
+	}
+
+	// The guard drops the node's cached scripts when it switches the node
+	loops["x0c0s0b0n0"] = "rescue"
+	controller.InvalidateNodeScripts("x0c0s0b0n0")
+	if script := generate(); !strings.Contains(script, "files.example.com/rescue") {
+		t.Errorf("expected the diagnostic kernel, got:\n%s", script)
+	}
+
+	delete(loops, "x0c0s0b0n0")
+	controller.InvalidateNodeScripts("x0c0s0b0n0")
+	if script := generate(); !strings.Contains(script, "files.example.com/vmlinuz") {
+		t.Errorf("expected the node's own kernel after the loop ended, got:\n%s", script)
+	}
+
+	if name, err := controller.ResolveNodeName(ctx, "AA-BB-CC-DD-EE-01"); err != nil || name != "x0c0s0b0n0" {
+		t.Errorf("ResolveNodeName = %q, %v, want x0c0s0b0n0", name, err)
+	}
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// BootOnce serves nodes another configuration for a single boot
+type BootOnce interface {
+	// Pending returns the configuration node, by xname, boots next
+	// instead of its own, or ok false when it boots normally
+	Pending(node string) (config string, ok bool)
+	// Take returns the pending configuration of node like Pending, and
+	// uses it up so the node boots normally afterwards
+	Take(ctx context.Context, node string) (config string, ok bool)
+}
+
+// SetBootOnce serves a node with a pending one-time override that
+// configuration. A node's own boot script request, marked with WithAccess,
+// uses the override up; previews and other requests only show it. Scripts
+// rendered from an override are not cached, and the overrides call
+// InvalidateNodeScripts when one is set.
+func (c *BootScriptController) SetBootOnce(bootOnce BootOnce) {
+	c.bootOnce = bootOnce
+}
+
+// bootOnceOverride returns the script of a node with a one-time override
+func (c *BootScriptController) bootOnceOverride(ctx context.Context, identifier string, node *apiv1.Node, profile string) (renderResult, bool) {
+	if c.bootOnce == nil {
+		return renderResult{}, false
+	}
+	var name string
+	var ok bool
+	if _, boot := ctx.Value(accessKey{}).(Access); boot {
+		name, ok = c.bootOnce.Take(ctx, node.Spec.XName)
+	} else {
+		name, ok = c.bootOnce.Pending(node.Spec.XName)
+	}
+	if !ok {
+		return renderResult{}, false
+	}
+	result := c.renderFallbackConfiguration(ctx, identifier, node, profile, name)
+	if result.reason == "" {
+		result.reason = fmt.Sprintf("boot once: configuration %s", name)
+	}
+	result.uncached = true
+	return result, true
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// testBootOnce holds the pending override of each node it maps
+type testBootOnce map[string]string
+
+func (o testBootOnce) Pending(node string) (string, bool) {
+	config, ok := o[node]
+	return config, ok
+}
+
+func (o testBootOnce) Take(_ context.Context, node string) (string, bool) {
+	config, ok := o[node]
+	delete(o, node)
+	return config, ok
+}
+
+func TestBootOnceOverride(t *testing.T) {
+	nodes := []apiv1.Node{{
+		Metadata: resource.Metadata{UID: "nod-1"},
+		Spec:     apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:01", Groups: []string{"compute"}},
+	}}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "compute", UID: "bc-1"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz"},
+		},
+		{
+			Metadata: resource.Metadata{Name: "memtest", UID: "bc-2"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"diagnostics"}, Kernel: "http://files.example.com/memtest"},
+		},
+	}
+	controller := newTestControllerWithData(t, nodes, configs)
+	overrides := testBootOnce{}
+	controller.SetBootOnce(overrides)
+	boot := WithAccess(context.Background(), Access{Client: "10.1.0.21"})
+
+	generate := func(ctx context.Context) string {
+		t.Helper()
+		script, err := controller.GenerateBootScript(ctx, "aa:bb:cc:dd:ee:01", "")
+		if err != nil {
+			t.Fatalf("GenerateBootScript returned error: %v", err)
+		}
+		return script
+	}
+
+	if script := generate(boot); !strings.Contains(script, "files.example.com/vmlinuz") {
+		t.Fatalf("expected the node's own kernel, got:\n%s", script)
+	}
+
+	// The store drops the node's cached scripts when it sets an override
+	overrides["x0c0s0b0n0"] = "memtest"
+	controller.InvalidateNodeScripts("x0c0s0b0n0")
+
+	// Previews show the override without using it up
+	preview, err := controller.PreviewBootScript(context.Background(), "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("PreviewBootScript returned error: %v", err)
+	}
+	if !strings.Contains(preview.Script, "files.example.com/memtest") {
+		t.Errorf("expected the preview to show the override, got:\n%s", preview.Script)
+	}
+	if _, pending := overrides["x0c0s0b0n0"]; !pending {
+		t.Fatal("the preview used up the override")
+	}
+
+	if script := generate(boot); !strings.Contains(script, "files.example.com/memtest") {
+		t.Errorf("expected the override's kernel, got:\n%s", script)
+	}
+	if script := generate(boot); !strings.Contains(script, "files.example.com/vmlinuz") {
+		t.Errorf("expected the node's own kernel after the override, got:\n%s", script)
+	}
+}
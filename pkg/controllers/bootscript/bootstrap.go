@@ -86,11 +86,12 @@ Example configuration scoring:
 # iPXE Templates
 
 Boot scripts are generated from Go templates with access to node and configuration data.
-Three built-in templates are provided:
+Four built-in templates are provided:
 
   - DefaultIPXETemplate: Standard boot sequence with kernel, initrd, and parameters
   - MinimalIPXETemplate: Bare-minimum boot script for unconfigured nodes
   - ErrorIPXETemplate: Error handling script for troubleshooting
+  - FallbackIPXETemplate: Retry script served when the deadline budget is exhausted
 
 Template variables include:
 
@@ -124,7 +125,7 @@ HSM Provider: Integrates with Hardware State Manager for production deployments
   - MAC address to XName resolution
 
 YAML Provider: File-based configuration for development and testing
-  - Simple YAML file format
+  - Simple YAML file format, in one file or a directory of files
   - Automatic reload on file changes
   - Useful for offline development
 
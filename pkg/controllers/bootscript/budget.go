@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrBudgetExhausted is returned by a boot script stage that ran out of time
+var ErrBudgetExhausted = errors.New("boot script deadline budget exhausted")
+
+// Budget limits how long boot script generation may take. Zero durations
+// disable the corresponding limit; the zero Budget imposes no limits.
+type Budget struct {
+	// Total bounds the whole generation, across all stages
+	Total time.Duration
+	// NodeLookup bounds resolving the node, from storage or a node provider
+	NodeLookup time.Duration
+	// ConfigLookup bounds loading the boot configurations
+	ConfigLookup time.Duration
+	// ArtifactResolution bounds resolving kernelArtifact and initrdArtifact
+	ArtifactResolution time.Duration
+	// FallbackRetryDelay is how long the fallback script waits before
+	// rebooting the node to retry
+	FallbackRetryDelay time.Duration
+}
+
+// DefaultBudget returns the budget the server uses unless configured otherwise
+func DefaultBudget() Budget {
+	return Budget{
+		Total:              5 * time.Second,
+		NodeLookup:         2 * time.Second,
+		ConfigLookup:       2 * time.Second,
+		ArtifactResolution: 2 * time.Second,
+		FallbackRetryDelay: 10 * time.Second,
+	}
+}
+
+// SetBudget replaces the deadline budget; it is safe to call while boot
+// scripts are being generated
+func (c *BootScriptController) SetBudget(budget Budget) {
+	c.budget.Store(&budget)
+}
+
+// Budget returns the current deadline budget
+func (c *BootScriptController) Budget() Budget {
+	if budget := c.budget.Load(); budget != nil {
+		return *budget
+	}
+	return Budget{}
+}
+
+// runStage runs fn with at most limit of the remaining budget in ctx. It
+// returns as soon as the deadline passes even if fn does not honour its
+// context, so a stuck backend cannot hold the request; fn then finishes in
+// the background and its result is discarded.
+func runStage[T any](ctx context.Context, stage string, limit time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	if limit > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limit)
+		defer cancel()
+	}
+	if ctx.Done() == nil {
+		return fn(ctx)
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := fn(ctx)
+		done <- result{value, err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil && errors.Is(res.err, context.DeadlineExceeded) {
+			return res.value, fmt.Errorf("%w: %s: %v", ErrBudgetExhausted, stage, res.err)
+		}
+		return res.value, res.err
+	case <-ctx.Done():
+		var zero T
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return zero, fmt.Errorf("%w: %s did not finish in time", ErrBudgetExhausted, stage)
+		}
+		return zero, ctx.Err()
+	}
+}
+
+// generateFallbackScript creates the script served when the budget is
+// exhausted. It waits and reboots, so the node retries instead of halting
+// or waiting for a TFTP timeout.
+func (c *BootScriptController) generateFallbackScript(identifier string) string {
+	delay := int(c.Budget().FallbackRetryDelay / time.Second)
+	script := FallbackIPXETemplate
+	script = strings.ReplaceAll(script, "{{.Identifier}}", identifier)
+	script = strings.ReplaceAll(script, "{{.Delay}}", strconv.Itoa(delay))
+	return script
+}
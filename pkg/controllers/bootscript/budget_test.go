@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// slowResources is a ResourceReader whose boot configuration lookup blocks
+// without honouring its context, like a hung storage backend
+type slowResources struct {
+	StaticResources
+	release chan struct{}
+}
+
+func (s *slowResources) GetBootConfigurations(ctx context.Context) ([]apiv1.BootConfiguration, error) {
+	<-s.release
+	return s.StaticResources.GetBootConfigurations(ctx)
+}
+
+func TestBudget_ServesFallbackWhenStageHangs(t *testing.T) {
+	resources := &slowResources{release: make(chan struct{})}
+	defer close(resources.release)
+	resources.Nodes = []apiv1.Node{{Spec: apiv1.NodeSpec{XName: "x1000c0s0b0n0", BootMAC: "aa:bb:cc:dd:ee:01"}}}
+
+	controller := NewBootScriptControllerWithReader(resources, log.New(io.Discard, "", 0))
+	controller.SetBudget(Budget{Total: time.Second, ConfigLookup: 20 * time.Millisecond, FallbackRetryDelay: 7 * time.Second})
+
+	start := time.Now()
+	script, err := controller.GenerateBootScript(context.Background(), "x1000c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("fallback took %s, want about the configuration lookup budget", elapsed)
+	}
+	for _, want := range []string{"#!ipxe", "x1000c0s0b0n0", "sleep 7", "reboot"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("fallback script missing %q:\n%s", want, script)
+		}
+	}
+	if stats := controller.CacheStats(); stats.TotalEntries != 0 {
+		t.Errorf("fallback script was cached: %+v", stats)
+	}
+}
+
+func TestBudget_TotalDeadline(t *testing.T) {
+	resources := &slowResources{release: make(chan struct{})}
+	defer close(resources.release)
+	resources.Nodes = []apiv1.Node{{Spec: apiv1.NodeSpec{XName: "x1000c0s0b0n0"}}}
+
+	controller := NewBootScriptControllerWithReader(resources, log.New(io.Discard, "", 0))
+	controller.SetBudget(Budget{Total: 20 * time.Millisecond})
+
+	result := controller.render(context.Background(), "x1000c0s0b0n0", "")
+	if result.template != TemplateFallback || !strings.Contains(result.reason, "configuration lookup") {
+		t.Errorf("got template %s (%s), want fallback for the configuration lookup", result.template, result.reason)
+	}
+	if result.node == nil {
+		t.Error("expected the resolved node to be reported with the fallback")
+	}
+}
+
+func TestRunStage(t *testing.T) {
+	ctx := context.Background()
+
+	value, err := runStage(ctx, "fast", time.Second, func(context.Context) (int, error) { return 42, nil })
+	if value != 42 || err != nil {
+		t.Errorf("runStage() = %d, %v; want 42, nil", value, err)
+	}
+
+	// Stages that honour their context report the exhausted budget too
+	_, err = runStage(ctx, "honouring", 10*time.Millisecond, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	if !errors.Is(err, ErrBudgetExhausted) {
+		t.Errorf("expected ErrBudgetExhausted, got %v", err)
+	}
+
+	// Other errors and cancellation are passed through
+	stageErr := errors.New("storage offline")
+	if _, err := runStage(ctx, "failing", 0, func(context.Context) (int, error) { return 0, stageErr }); err != stageErr {
+		t.Errorf("expected stage error, got %v", err)
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := runStage(canceled, "canceled", 0, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}); errors.Is(err, ErrBudgetExhausted) || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancellation, got %v", err)
+	}
+}
+
+// countingResources counts boot configuration lookups and blocks them until
+// released
+type countingResources struct {
+	StaticResources
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (c *countingResources) GetBootConfigurations(ctx context.Context) ([]apiv1.BootConfiguration, error) {
+	c.calls.Add(1)
+	<-c.release
+	return c.StaticResources.GetBootConfigurations(ctx)
+}
+
+func TestGenerateBootScript_CoalescesConcurrentRequests(t *testing.T) {
+	resources := &countingResources{release: make(chan struct{})}
+	resources.Nodes = []apiv1.Node{{Spec: apiv1.NodeSpec{XName: "x1000c0s0b0n0"}}}
+
+	controller := NewBootScriptControllerWithReader(resources, log.New(io.Discard, "", 0))
+
+	const requests = 10
+	var wg sync.WaitGroup
+	scripts := make([]string, requests)
+	for i := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scripts[i], _ = controller.GenerateBootScript(context.Background(), "x1000c0s0b0n0", "")
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(resources.release)
+	wg.Wait()
+
+	if calls := resources.calls.Load(); calls != 1 {
+		t.Errorf("boot configurations loaded %d times, want 1", calls)
+	}
+	if got := controller.CoalescedRequests(); got != requests-1 {
+		t.Errorf("CoalescedRequests() = %d, want %d", got, requests-1)
+	}
+	for i, script := range scripts {
+		if script != scripts[0] || !strings.HasPrefix(script, "#!ipxe") {
+			t.Errorf("request %d got script %q", i, script)
+		}
+	}
+}
+
+// blockingNodes counts node listings and blocks them until released
+type blockingNodes struct {
+	StaticResources
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (b *blockingNodes) GetNodes(ctx context.Context) ([]apiv1.Node, error) {
+	b.calls.Add(1)
+	<-b.release
+	return b.StaticResources.GetNodes(ctx)
+}
+
+func TestResolveNode_SharesConcurrentListings(t *testing.T) {
+	resources := &blockingNodes{release: make(chan struct{})}
+	for i := range 5 {
+		resources.Nodes = append(resources.Nodes, apiv1.Node{Spec: apiv1.NodeSpec{XName: fmt.Sprintf("x1000c0s%db0n0", i)}})
+	}
+	controller := NewBootScriptControllerWithReader(resources, log.New(io.Discard, "", 0))
+
+	var wg sync.WaitGroup
+	for _, node := range resources.Nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := controller.parseNodeIdentifier(node.Spec.XName)
+			if resolved, err := controller.resolveNode(context.Background(), id); err != nil || resolved.Spec.XName != node.Spec.XName {
+				t.Errorf("resolveNode(%s) = %v, %v", node.Spec.XName, resolved, err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(resources.release)
+	wg.Wait()
+
+	if calls := resources.calls.Load(); calls != 1 {
+		t.Errorf("nodes listed %d times, want 1", calls)
+	}
+	if got := controller.DeduplicatedLookups(); got != 4 {
+		t.Errorf("DeduplicatedLookups() = %d, want 4", got)
+	}
+}
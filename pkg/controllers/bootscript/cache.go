@@ -5,10 +5,42 @@
 package bootscript
 
 import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"strconv"
 	"sync"
 	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/artifacts"
+	"github.com/openchami/boot-service/pkg/paramprofiles"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// Default script cache limits
+const (
+	DefaultCacheTTL        = 5 * time.Minute
+	DefaultCacheMaxEntries = 10000
+	DefaultCacheMaxBytes   = 64 << 20 // 64 MiB
 )
 
+// Cache stores generated boot scripts. ScriptCache keeps them in process
+// memory; other implementations can share them between replicas.
+type Cache interface {
+	Get(cacheKey string) (string, bool)
+	Set(cacheKey, script, nodeID, configID string)
+	Invalidate(cacheKey string)
+	InvalidateByNodeID(nodeID string)
+	InvalidateByConfigID(configID string)
+	Clear()
+	Stats() CacheStats
+	Close()
+}
+
+// cacheEntryOverhead approximates the per-entry bookkeeping cost in bytes
+const cacheEntryOverhead = 128
+
 // CacheEntry represents a cached boot script
 type CacheEntry struct {
 	Script      string
@@ -16,20 +48,47 @@ type CacheEntry struct {
 	ExpiresAt   time.Time
 	NodeID      string
 	ConfigID    string
+
+	key  string
+	size int64
 }
 
-// ScriptCache manages caching of generated boot scripts
+// ScriptCache is a bounded LRU cache of generated boot scripts. Entries expire
+// after the TTL, and the least recently used entries are evicted when the
+// entry count or the estimated memory use exceeds its limits.
 type ScriptCache struct {
-	mu      sync.RWMutex
-	entries map[string]*CacheEntry
-	ttl     time.Duration
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List // front is most recently used
+	ttl        time.Duration
+	maxEntries int
+	maxBytes   int64
+	bytes      int64
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
-// NewScriptCache creates a new script cache with the specified TTL
+// NewScriptCache creates a new script cache with the specified TTL and the
+// default size limits
 func NewScriptCache(ttl time.Duration) *ScriptCache {
+	return NewBoundedScriptCache(ttl, DefaultCacheMaxEntries, DefaultCacheMaxBytes)
+}
+
+// NewBoundedScriptCache creates a script cache holding at most maxEntries
+// scripts and about maxBytes of data. Call Close to stop its cleanup routine.
+func NewBoundedScriptCache(ttl time.Duration, maxEntries int, maxBytes int64) *ScriptCache {
 	cache := &ScriptCache{
-		entries: make(map[string]*CacheEntry),
-		ttl:     ttl,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		done:       make(chan struct{}),
 	}
 
 	// Start cleanup routine
@@ -38,27 +97,49 @@ func NewScriptCache(ttl time.Duration) *ScriptCache {
 	return cache
 }
 
+// SetLimits changes the TTL and size limits, evicting least recently used
+// entries if the cache no longer fits. Entries already cached keep their
+// original expiry.
+func (c *ScriptCache) SetLimits(ttl time.Duration, maxEntries int, maxBytes int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ttl = ttl
+	c.maxEntries = maxEntries
+	c.maxBytes = maxBytes
+	c.evictToFit()
+}
+
+// Close stops the cleanup routine. The cache remains usable.
+func (c *ScriptCache) Close() {
+	c.closeOnce.Do(func() { close(c.done) })
+}
+
 // Get retrieves a cached script if it exists and is not expired
 func (c *ScriptCache) Get(cacheKey string) (string, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	entry, exists := c.entries[cacheKey]
+	elem, exists := c.entries[cacheKey]
 	if !exists {
+		c.misses++
 		return "", false
 	}
 
-	// Check if entry has expired
+	entry := elem.Value.(*CacheEntry)
 	if time.Now().After(entry.ExpiresAt) {
-		// Entry expired, remove it
-		delete(c.entries, cacheKey)
+		c.remove(elem)
+		c.misses++
 		return "", false
 	}
 
+	c.lru.MoveToFront(elem)
+	c.hits++
 	return entry.Script, true
 }
 
-// Set stores a script in the cache
+// Set stores a script in the cache, evicting least recently used entries as
+// needed
 func (c *ScriptCache) Set(cacheKey, script, nodeID, configID string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -70,9 +151,20 @@ func (c *ScriptCache) Set(cacheKey, script, nodeID, configID string) {
 		ExpiresAt:   now.Add(c.ttl),
 		NodeID:      nodeID,
 		ConfigID:    configID,
+		key:         cacheKey,
+		size:        int64(len(cacheKey)+len(script)+len(nodeID)+len(configID)) + cacheEntryOverhead,
 	}
 
-	c.entries[cacheKey] = entry
+	if elem, exists := c.entries[cacheKey]; exists {
+		c.remove(elem)
+	}
+	if entry.size > c.maxBytes {
+		return
+	}
+
+	c.entries[cacheKey] = c.lru.PushFront(entry)
+	c.bytes += entry.size
+	c.evictToFit()
 }
 
 // Invalidate removes a specific entry from the cache
@@ -80,31 +172,19 @@ func (c *ScriptCache) Invalidate(cacheKey string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	delete(c.entries, cacheKey)
+	if elem, exists := c.entries[cacheKey]; exists {
+		c.remove(elem)
+	}
 }
 
 // InvalidateByNodeID removes all cache entries for a specific node
 func (c *ScriptCache) InvalidateByNodeID(nodeID string) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	for key, entry := range c.entries {
-		if entry.NodeID == nodeID {
-			delete(c.entries, key)
-		}
-	}
+	c.removeWhere(func(entry *CacheEntry) bool { return entry.NodeID == nodeID })
 }
 
 // InvalidateByConfigID removes all cache entries using a specific configuration
 func (c *ScriptCache) InvalidateByConfigID(configID string) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	for key, entry := range c.entries {
-		if entry.ConfigID == configID {
-			delete(c.entries, key)
-		}
-	}
+	c.removeWhere(func(entry *CacheEntry) bool { return entry.ConfigID == configID })
 }
 
 // Clear removes all entries from the cache
@@ -112,19 +192,21 @@ func (c *ScriptCache) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.entries = make(map[string]*CacheEntry)
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+	c.bytes = 0
 }
 
 // Stats returns cache statistics
 func (c *ScriptCache) Stats() CacheStats {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	now := time.Now()
 	expired := 0
 
-	for _, entry := range c.entries {
-		if now.After(entry.ExpiresAt) {
+	for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+		if now.After(elem.Value.(*CacheEntry).ExpiresAt) {
 			expired++
 		}
 	}
@@ -133,6 +215,12 @@ func (c *ScriptCache) Stats() CacheStats {
 		TotalEntries:   len(c.entries),
 		ExpiredEntries: expired,
 		ValidEntries:   len(c.entries) - expired,
+		Bytes:          c.bytes,
+		MaxEntries:     c.maxEntries,
+		MaxBytes:       c.maxBytes,
+		Hits:           c.hits,
+		Misses:         c.misses,
+		Evictions:      c.evictions,
 	}
 }
 
@@ -141,35 +229,96 @@ type CacheStats struct {
 	TotalEntries   int
 	ExpiredEntries int
 	ValidEntries   int
+	Bytes          int64 // estimated memory use
+	MaxEntries     int
+	MaxBytes       int64
+	Hits           uint64
+	Misses         uint64
+	Evictions      uint64 // entries evicted to stay within the size limits
 }
 
-// cleanup periodically removes expired entries
+// cleanup periodically removes expired entries until Close is called
 func (c *ScriptCache) cleanup() {
-	ticker := time.NewTicker(c.ttl / 2) // Clean up twice per TTL period
-	defer ticker.Stop()
-
-	for range ticker.C {
-		c.cleanupExpired()
+	for {
+		c.mu.Lock()
+		interval := c.ttl / 2 // Clean up twice per TTL period
+		c.mu.Unlock()
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+			c.cleanupExpired()
+		case <-c.done:
+			timer.Stop()
+			return
+		}
 	}
 }
 
 // cleanupExpired removes expired entries from the cache
 func (c *ScriptCache) cleanupExpired() {
+	now := time.Now()
+	c.removeWhere(func(entry *CacheEntry) bool { return now.After(entry.ExpiresAt) })
+}
+
+func (c *ScriptCache) removeWhere(match func(*CacheEntry) bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	now := time.Now()
-	for key, entry := range c.entries {
-		if now.After(entry.ExpiresAt) {
-			delete(c.entries, key)
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if match(elem.Value.(*CacheEntry)) {
+			c.remove(elem)
+		}
+		elem = next
+	}
+}
+
+// evictToFit evicts least recently used entries until the cache is within its
+// limits; the caller must hold c.mu
+func (c *ScriptCache) evictToFit() {
+	for c.lru.Len() > 0 && (c.lru.Len() > c.maxEntries || c.bytes > c.maxBytes) {
+		c.remove(c.lru.Back())
+		c.evictions++
+	}
+}
+
+// remove deletes an entry; the caller must hold c.mu
+func (c *ScriptCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*CacheEntry)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size
+}
+
+// generateCacheKey creates a cache key from node identifier and requested profile
+func (c *BootScriptController) generateCacheKey(identifier string, profile string) string {
+	if profile == "" {
+		profile = "default"
+	}
+	// Scripts cached before maintenance mode was switched are not reused
+	if c.maintenance != nil {
+		if revision := c.maintenance.Revision(); revision > 0 {
+			return identifier + ":" + profile + ":m" + strconv.FormatUint(revision, 10)
 		}
 	}
+	return identifier + ":" + profile
 }
 
-// generateCacheKey creates a cache key from node identifier and configuration
-func (c *BootScriptController) generateCacheKey(identifier string, configName string) string {
-	if configName == "" {
-		configName = "default"
+// HandleResourceChange invalidates cached scripts affected by a resource
+// write. A node write drops that node's scripts. A configuration or artifact
+// write can change the script of any node, including which configuration it
+// matches, so it clears the cache.
+func (c *BootScriptController) HandleResourceChange(ctx context.Context, event resourcewatch.Event) { //nolint:revive
+	c.generation.Add(1)
+	switch event.ResourceType {
+	case "Node":
+		for _, data := range []json.RawMessage{event.Old, event.New} {
+			var node apiv1.Node
+			if data != nil && json.Unmarshal(data, &node) == nil && node.Spec.XName != "" {
+				c.cache.InvalidateByNodeID(node.Spec.XName)
+			}
+		}
+	case "BootConfiguration", artifacts.ResourceType, paramprofiles.ResourceType:
+		c.cache.Clear()
 	}
-	return identifier + ":" + configName
 }
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// chainScript renders the script that chains identifier to url
+func chainScript(identifier, url string) string {
+	script := strings.ReplaceAll(ChainIPXETemplate, "{{.Identifier}}", identifier)
+	return strings.ReplaceAll(script, "{{.URL}}", url)
+}
+
+// expandChainURL renders the node variables in a configuration or node
+// chainURL
+func (c *BootScriptController) expandChainURL(ctx context.Context, url string, node *apiv1.Node) (string, error) {
+	expanded, err := expandNodeTemplate("chainURL", url, node, c.templateEnv(ctx))
+	if err != nil {
+		return "", err
+	}
+	if expanded == "" || strings.ContainsAny(expanded, " \t\r\n") {
+		return "", fmt.Errorf("chainURL %q expands to %q, which is not a URL", url, expanded)
+	}
+	return expanded, nil
+}
+
+// nodeChain returns the script for a node with its own chainURL, which
+// takes the place of any configuration it matches
+func (c *BootScriptController) nodeChain(ctx context.Context, identifier string, node *apiv1.Node) (renderResult, bool) {
+	if node.Spec.ChainURL == "" {
+		return renderResult{}, false
+	}
+	url, err := c.expandChainURL(ctx, node.Spec.ChainURL, node)
+	if err != nil {
+		reason := fmt.Sprintf("Node chainURL: %v", err)
+		return renderResult{script: c.generateErrorScript(reason), template: TemplateError, reason: reason, node: node}, true
+	}
+	return renderResult{script: chainScript(identifier, url), template: TemplateChain, reason: "node chainURL " + url, node: node, chainURL: url}, true
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func newChainTestController(t *testing.T) *BootScriptController {
+	t.Helper()
+
+	nodes := []apiv1.Node{
+		{
+			Metadata: resource.Metadata{UID: "nod-1"},
+			Spec:     apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:01", Groups: []string{"vendor"}},
+		},
+		{
+			// Delegated by its own chainURL although the vendor configuration matches
+			Metadata: resource.Metadata{UID: "nod-2"},
+			Spec: apiv1.NodeSpec{XName: "x0c0s1b0n0", NID: 2, BootMAC: "aa:bb:cc:dd:ee:02", Groups: []string{"vendor"},
+				ChainURL: "tftp://10.1.0.1/{{.Hostname}}.ipxe", Hostname: "ncn-m001"},
+		},
+	}
+	configs := []apiv1.BootConfiguration{{
+		Metadata: resource.Metadata{Name: "vendor", UID: "bc-1"},
+		Spec:     apiv1.BootConfigurationSpec{Groups: []string{"vendor"}, ChainURL: "http://provision.example.com/ipxe/{{.XName}}?mac=${mac}"},
+	}}
+	return newTestControllerWithData(t, nodes, configs)
+}
+
+func TestChainConfiguration(t *testing.T) {
+	controller := newChainTestController(t)
+	ctx := context.Background()
+
+	script, err := controller.GenerateBootScript(ctx, "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "chain http://provision.example.com/ipxe/x0c0s0b0n0?mac=${mac} ||") {
+		t.Errorf("expected a chain to the templated URL, got:\n%s", script)
+	}
+	if strings.Contains(script, "kernel ") {
+		t.Errorf("expected no kernel in a chain script, got:\n%s", script)
+	}
+	if stats := controller.cache.Stats(); stats.TotalEntries != 1 {
+		t.Errorf("cache entries = %d, want the configuration's chain script cached", stats.TotalEntries)
+	}
+
+	preview, err := controller.PreviewBootScript(ctx, "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("PreviewBootScript returned error: %v", err)
+	}
+	if preview.Template != TemplateDefault || preview.MatchedConfiguration != "vendor" || preview.ChainURL != "http://provision.example.com/ipxe/x0c0s0b0n0?mac=${mac}" {
+		t.Errorf("preview = %+v, want the vendor configuration's chain URL", preview)
+	}
+	if preview.Params != "" {
+		t.Errorf("preview params = %q, want none for a chain", preview.Params)
+	}
+}
+
+func TestNodeChainOverride(t *testing.T) {
+	controller := newChainTestController(t)
+	ctx := context.Background()
+
+	script, err := controller.GenerateBootScript(ctx, "aa:bb:cc:dd:ee:02", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "chain tftp://10.1.0.1/ncn-m001.ipxe ||") {
+		t.Errorf("expected the node's own chain URL, got:\n%s", script)
+	}
+
+	preview, err := controller.PreviewBootScript(ctx, "x0c0s1b0n0", "")
+	if err != nil {
+		t.Fatalf("PreviewBootScript returned error: %v", err)
+	}
+	if preview.Template != TemplateChain || preview.ChainURL != "tftp://10.1.0.1/ncn-m001.ipxe" || preview.MatchedConfiguration != "" {
+		t.Errorf("preview = %+v, want the node chain without a configuration", preview)
+	}
+
+	warmed, err := controller.Prewarm(ctx)
+	if err != nil {
+		t.Fatalf("Prewarm returned error: %v", err)
+	}
+	if warmed != 1 {
+		t.Errorf("Prewarm warmed %d nodes, want only the node without its own chainURL", warmed)
+	}
+}
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import "strings"
+
+// SetCloudInitSeed makes generated scripts point nodes at the cloud-init
+// service serving seedURL, with a ds=nocloud-net;s=<seedURL> kernel
+// parameter. Configurations and nodes that set ds themselves keep theirs.
+// Call it before serving scripts.
+func (c *BootScriptController) SetCloudInitSeed(seedURL string) {
+	c.cloudInitSeed = seedURL
+}
+
+// withDatasource adds the cloud-init datasource parameter to params
+func (c *BootScriptController) withDatasource(params string) string {
+	if c.cloudInitSeed == "" || hasParam(params, "ds") {
+		return params
+	}
+	return strings.TrimSpace(params + " ds=nocloud-net;s=" + c.cloudInitSeed)
+}
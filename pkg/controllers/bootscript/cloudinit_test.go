@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func TestCloudInitSeed(t *testing.T) {
+	nodes := []apiv1.Node{
+		{
+			Metadata: resource.Metadata{UID: "nod-1"},
+			Spec:     apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, Groups: []string{"compute"}},
+		},
+		{
+			Metadata: resource.Metadata{UID: "nod-2"},
+			Spec:     apiv1.NodeSpec{XName: "x0c0s0b0n1", NID: 2, Groups: []string{"compute"}, ParamsAppend: "ds=nocloud;s=http://local/"},
+		},
+	}
+	configs := []apiv1.BootConfiguration{{
+		Metadata: resource.Metadata{Name: "compute", UID: "bc-1"},
+		Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz", Params: "console=ttyS0"},
+	}}
+	controller := newTestControllerWithData(t, nodes, configs)
+	controller.SetCloudInitSeed("http://cloud-init.example.com/cloud-init/")
+
+	script, err := controller.GenerateBootScript(context.Background(), "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "console=ttyS0 ds=nocloud-net;s=http://cloud-init.example.com/cloud-init/") {
+		t.Errorf("expected the cloud-init datasource, got:\n%s", script)
+	}
+
+	// A node that chose its own datasource keeps it
+	script, err = controller.GenerateBootScript(context.Background(), "x0c0s0b0n1", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if strings.Contains(script, "nocloud-net") || !strings.Contains(script, "ds=nocloud;s=http://local/") {
+		t.Errorf("expected the node's own datasource, got:\n%s", script)
+	}
+}
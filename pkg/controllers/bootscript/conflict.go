@@ -0,0 +1,268 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/admission"
+)
+
+// Conflict reports configurations that tie as the best match for nodes:
+// each node scores the same against all of them at the same priority, so
+// which one it boots is decided only by name.
+type Conflict struct {
+	Configurations []string `json:"configurations"`
+	// Profile is the profile requested when the tie happens. It is empty
+	// when the tied configurations belong to different profiles, which only
+	// compete for requests without a profile.
+	Profile  string   `json:"profile,omitempty"`
+	Score    int      `json:"score"`
+	Priority int      `json:"priority"`
+	Nodes    []string `json:"nodes"` // xnames of the affected nodes
+}
+
+// ConflictReport lists the conflicts among the configurations active at a
+// time
+type ConflictReport struct {
+	At        time.Time  `json:"at"`
+	Conflicts []Conflict `json:"conflicts"`
+}
+
+// Conflicts reports which nodes have tied best matches among the
+// configurations active at t
+func (c *BootScriptController) Conflicts(ctx context.Context, t time.Time) (*ConflictReport, error) {
+	configs, err := c.client.GetBootConfigurations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting boot configurations: %w", err)
+	}
+	nodes, err := c.client.GetNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting nodes: %w", err)
+	}
+
+	conflicts := c.findConflicts(configs, nodes, func(config *apiv1.BootConfiguration) bool {
+		return c.configActive(config, t)
+	})
+	return &ConflictReport{At: t, Conflicts: conflicts}, nil
+}
+
+// ConflictsWith reports the conflicts config would be part of if it were
+// stored, replacing the stored configuration with the same UID. The other
+// configurations are those active now; config itself is checked whatever
+// its schedule.
+func (c *BootScriptController) ConflictsWith(ctx context.Context, config *apiv1.BootConfiguration) ([]Conflict, error) {
+	stored, err := c.client.GetBootConfigurations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting boot configurations: %w", err)
+	}
+	nodes, err := c.client.GetNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting nodes: %w", err)
+	}
+
+	configs := make([]apiv1.BootConfiguration, 0, len(stored)+1)
+	for _, existing := range stored {
+		if config.Metadata.UID == "" || existing.Metadata.UID != config.Metadata.UID {
+			configs = append(configs, existing)
+		}
+	}
+	configs = append(configs, *config)
+
+	written := &configs[len(configs)-1]
+	name := configDisplayName(config)
+	now := time.Now()
+	var involved []Conflict
+	for _, conflict := range c.findConflicts(configs, nodes, func(candidate *apiv1.BootConfiguration) bool {
+		return candidate == written || c.configActive(candidate, now)
+	}) {
+		if slices.Contains(conflict.Configurations, name) {
+			involved = append(involved, conflict)
+		}
+	}
+	return involved, nil
+}
+
+// findConflicts groups the nodes whose best matches among the configs
+// accepted by active tie, both within each profile and across profiles
+func (c *BootScriptController) findConflicts(configs []apiv1.BootConfiguration, nodes []apiv1.Node, active func(*apiv1.BootConfiguration) bool) []Conflict {
+	var eligible []*apiv1.BootConfiguration
+	for i := range configs {
+		if active(&configs[i]) {
+			eligible = append(eligible, &configs[i])
+		}
+	}
+
+	byKey := map[string]*Conflict{}
+	record := func(profile string, tied []configCandidate, node *apiv1.Node) {
+		names := make([]string, 0, len(tied))
+		for _, candidate := range tied {
+			names = append(names, configDisplayName(candidate.config))
+		}
+		sort.Strings(names)
+		key := fmt.Sprintf("%s\x00%s\x00%d\x00%d", profile, strings.Join(names, "\x00"), tied[0].score, tied[0].config.Spec.Priority)
+		conflict, ok := byKey[key]
+		if !ok {
+			conflict = &Conflict{Configurations: names, Profile: profile, Score: tied[0].score, Priority: tied[0].config.Spec.Priority}
+			byKey[key] = conflict
+		}
+		xname := node.Spec.XName
+		if xname == "" {
+			xname = node.Metadata.UID
+		}
+		conflict.Nodes = append(conflict.Nodes, xname)
+	}
+
+	for i := range nodes {
+		node := &nodes[i]
+		var candidates []configCandidate
+		for _, config := range eligible {
+			if score := c.calculateConfigScore(config, node); score > 0 {
+				candidates = append(candidates, configCandidate{config: config, score: score})
+			}
+		}
+		if len(candidates) < 2 {
+			continue
+		}
+		sortCandidates(candidates)
+
+		// Requests without a profile choose across all profiles; ties within
+		// one profile are reported for that profile below
+		if tied := topTies(candidates); len(tied) > 1 && !sameProfile(tied) {
+			record("", tied, node)
+		}
+
+		byProfile := map[string][]configCandidate{}
+		for _, candidate := range candidates {
+			profile := profileName(candidate.config)
+			byProfile[profile] = append(byProfile[profile], candidate)
+		}
+		for profile, group := range byProfile {
+			if tied := topTies(group); len(tied) > 1 {
+				record(profile, tied, node)
+			}
+		}
+	}
+
+	conflicts := make([]Conflict, 0, len(byKey))
+	for _, conflict := range byKey {
+		sort.Strings(conflict.Nodes)
+		conflicts = append(conflicts, *conflict)
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		a, b := conflicts[i], conflicts[j]
+		if a.Profile != b.Profile {
+			return a.Profile < b.Profile
+		}
+		return strings.Join(a.Configurations, "\x00") < strings.Join(b.Configurations, "\x00")
+	})
+	return conflicts
+}
+
+func profileName(config *apiv1.BootConfiguration) string {
+	if config.Spec.Profile == "" {
+		return "default"
+	}
+	return config.Spec.Profile
+}
+
+func sameProfile(candidates []configCandidate) bool {
+	for _, candidate := range candidates[1:] {
+		if profileName(candidate.config) != profileName(candidates[0].config) {
+			return false
+		}
+	}
+	return true
+}
+
+// ConflictHook is an admission.Hook that checks boot configuration writes
+// for conflicts with the stored configurations. It logs them, and with
+// reject also denies the write.
+type ConflictHook struct {
+	controller *BootScriptController
+	reject     bool
+	logger     *log.Logger
+}
+
+// NewConflictHook checks writes against the configurations and nodes the
+// controller sees
+func NewConflictHook(controller *BootScriptController, reject bool, logger *log.Logger) *ConflictHook {
+	if logger == nil {
+		logger = log.New(log.Writer(), "conflicts: ", log.LstdFlags)
+	}
+	return &ConflictHook{controller: controller, reject: reject, logger: logger}
+}
+
+// Name returns the hook's name
+func (h *ConflictHook) Name() string {
+	return "conflicts"
+}
+
+// Admit checks a boot configuration write; other kinds are allowed
+func (h *ConflictHook) Admit(ctx context.Context, req admission.Request) (admission.Response, error) {
+	if req.Kind != "BootConfiguration" {
+		return admission.Response{Allowed: true}, nil
+	}
+	var config apiv1.BootConfiguration
+	if err := json.Unmarshal(req.Object, &config); err != nil {
+		return admission.Response{}, fmt.Errorf("decoding boot configuration: %w", err)
+	}
+
+	conflicts, err := h.controller.ConflictsWith(ctx, &config)
+	if err != nil {
+		if !h.reject {
+			h.logger.Printf("Could not check boot configuration %s for conflicts: %v", configDisplayName(&config), err)
+			return admission.Response{Allowed: true}, nil
+		}
+		return admission.Response{}, err
+	}
+	if len(conflicts) == 0 {
+		return admission.Response{Allowed: true}, nil
+	}
+
+	reason := describeConflicts(configDisplayName(&config), conflicts)
+	if h.reject {
+		return admission.Response{Reason: reason}, nil
+	}
+	h.logger.Printf("Warning: %s", reason)
+	return admission.Response{Allowed: true}, nil
+}
+
+// maxReportedNodes bounds how many affected nodes a conflict message names
+const maxReportedNodes = 5
+
+// describeConflicts explains the conflicts of the configuration name
+func describeConflicts(name string, conflicts []Conflict) string {
+	parts := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		var others []string
+		for _, other := range conflict.Configurations {
+			if other != name {
+				others = append(others, other)
+			}
+		}
+		nodes := conflict.Nodes
+		more := ""
+		if len(nodes) > maxReportedNodes {
+			more = fmt.Sprintf(" and %d more", len(nodes)-maxReportedNodes)
+			nodes = nodes[:maxReportedNodes]
+		}
+		scope := "requests without a profile"
+		if conflict.Profile != "" {
+			scope = "profile " + conflict.Profile
+		}
+		parts = append(parts, fmt.Sprintf("ties with %s in %s (score %d, priority %d) for %d node(s): %s%s",
+			strings.Join(others, ", "), scope, conflict.Score, conflict.Priority, len(conflict.Nodes), strings.Join(nodes, ", "), more))
+	}
+	return fmt.Sprintf("boot configuration %s %s", name, strings.Join(parts, "; "))
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/admission"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func newConflictTestController(t *testing.T) *BootScriptController {
+	t.Helper()
+
+	nodes := []apiv1.Node{
+		{Metadata: resource.Metadata{UID: "nod-1"}, Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, Groups: []string{"compute"}}},
+		{Metadata: resource.Metadata{UID: "nod-2"}, Spec: apiv1.NodeSpec{XName: "x0c0s0b0n1", NID: 2, Groups: []string{"compute"}}},
+		{Metadata: resource.Metadata{UID: "nod-3"}, Spec: apiv1.NodeSpec{XName: "x0c0s1b0n0", NID: 3, Groups: []string{"compute"}}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{Metadata: resource.Metadata{Name: "compute", UID: "bc-1"}, Spec: apiv1.BootConfigurationSpec{Groups: []string{"compute"}}},
+		// Ties with compute everywhere but on node 3, where its NID wins
+		{Metadata: resource.Metadata{Name: "compute-new", UID: "bc-2"}, Spec: apiv1.BootConfigurationSpec{Groups: []string{"compute"}}},
+		{Metadata: resource.Metadata{Name: "node-3", UID: "bc-3"}, Spec: apiv1.BootConfigurationSpec{NIDs: []int32{3}}},
+		// Ties only for requests without a profile
+		{Metadata: resource.Metadata{Name: "node-3-debug", UID: "bc-4"}, Spec: apiv1.BootConfigurationSpec{NIDs: []int32{3}, Profile: "debug"}},
+		// Never active
+		{Metadata: resource.Metadata{Name: "retired", UID: "bc-5"}, Spec: apiv1.BootConfigurationSpec{
+			Groups:      []string{"compute"},
+			ActiveUntil: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		}},
+	}
+	return newTestControllerWithData(t, nodes, configs)
+}
+
+func TestConflicts(t *testing.T) {
+	controller := newConflictTestController(t)
+
+	report, err := controller.Conflicts(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("Conflicts returned error: %v", err)
+	}
+	want := []Conflict{
+		{Configurations: []string{"node-3", "node-3-debug"}, Score: 75, Priority: 0, Nodes: []string{"x0c0s1b0n0"}},
+		{Configurations: []string{"compute", "compute-new"}, Profile: "default", Score: 25, Priority: 0, Nodes: []string{"x0c0s0b0n0", "x0c0s0b0n1"}},
+	}
+	if !reflect.DeepEqual(report.Conflicts, want) {
+		t.Errorf("Conflicts =\n%+v\nwant\n%+v", report.Conflicts, want)
+	}
+}
+
+func TestConflictHook(t *testing.T) {
+	controller := newConflictTestController(t)
+	ctx := context.Background()
+
+	request := func(config apiv1.BootConfiguration) admission.Request {
+		object, err := json.Marshal(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return admission.Request{Operation: admission.Create, Kind: "BootConfiguration", UID: config.Metadata.UID, Object: object}
+	}
+	clash := apiv1.BootConfiguration{
+		Metadata: resource.Metadata{Name: "compute-next", UID: "bc-6"},
+		Spec:     apiv1.BootConfigurationSpec{NIDs: []int32{3}},
+	}
+	// Raising the priority of an existing configuration resolves its ties
+	resolved := apiv1.BootConfiguration{
+		Metadata: resource.Metadata{Name: "compute-new", UID: "bc-2"},
+		Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Priority: 10},
+	}
+
+	reject := NewConflictHook(controller, true, nil)
+	resp, err := reject.Admit(ctx, request(clash))
+	if err != nil {
+		t.Fatalf("Admit returned error: %v", err)
+	}
+	if resp.Allowed || !strings.Contains(resp.Reason, "ties with node-3") || !strings.Contains(resp.Reason, "x0c0s1b0n0") {
+		t.Errorf("expected the write denied naming node-3, got %+v", resp)
+	}
+	if resp, err := reject.Admit(ctx, request(resolved)); err != nil || !resp.Allowed {
+		t.Errorf("expected the update resolving the tie allowed, got %+v, %v", resp, err)
+	}
+	if resp, err := reject.Admit(ctx, admission.Request{Kind: "Node", Object: json.RawMessage(`{}`)}); err != nil || !resp.Allowed {
+		t.Errorf("expected node writes allowed, got %+v, %v", resp, err)
+	}
+
+	warn := NewConflictHook(controller, false, log.New(io.Discard, "", 0))
+	if resp, err := warn.Admit(ctx, request(clash)); err != nil || !resp.Allowed {
+		t.Errorf("expected the write allowed with a warning, got %+v, %v", resp, err)
+	}
+}
@@ -7,31 +7,123 @@ package bootscript
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
 	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/flight"
 	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/hostlist"
 	"github.com/openchami/boot-service/pkg/validation"
 )
 
+// Lookup errors
+var (
+	ErrNodeNotFound          = errors.New("node not found")
+	ErrConfigurationNotFound = errors.New("boot configuration not found")
+)
+
 // BootScriptController handles iPXE boot script generation
 type BootScriptController struct { //nolint:revive
-	client client.Client
-	logger *log.Logger
-	cache  *ScriptCache
+	client    ResourceReader
+	logger    *log.Logger
+	cache     Cache
+	artifacts ArtifactResolver
+	profiles  ParamProfileResolver
+	images    ImageResolver
+	policy    BootPolicy
+	secrets   SecretStore
+	activity  ActivityRecorder
+	access    AccessRecorder
+	bootLoops BootLoopGuard
+	budget    atomic.Pointer[Budget]
+	scoring   atomic.Pointer[Scoring]
+
+	// resolvedSecrets holds the secret values resolved for served scripts,
+	// for RedactSecrets
+	resolvedSecrets resolvedSecrets
+
+	maintenance Maintenance
+	holdScript  string
+	cordons     Cordons
+	faults      HardwareFaults
+	bootOnce    BootOnce
+
+	// cloudInitSeed is the NoCloud seed URL added to kernel parameters
+	cloudInitSeed string
+
+	discovery       Discovery
+	discoveryConfig string
+
+	fallbackPolicy FallbackPolicy
+	fallbacks      fallbackCounters
+	lintFailures   atomic.Uint64
+
+	// inflight coalesces concurrent generations of the same script
+	inflight  singleflight.Group
+	coalesced atomic.Uint64
+	// nodeLookups shares one node listing among concurrent resolutions
+	nodeLookups flight.Group[[]apiv1.Node]
+	// generation counts resource writes and invalidations, so rendering and
+	// pre-warming can tell when what they loaded went stale
+	generation atomic.Uint64
+	// matches holds the match event of each cached script, by cache key
+	matches sync.Map
+	// scheduleTransition is when, in Unix nanoseconds, a configuration
+	// schedule may next change what cached scripts should be; 0 if never
+	scheduleTransition atomic.Int64
+}
+
+// ResourceReader lists the nodes and boot configurations that boot scripts
+// are selected from. Every client.API implementation is a ResourceReader.
+type ResourceReader interface {
+	GetNodes(ctx context.Context) ([]apiv1.Node, error)
+	GetBootConfigurations(ctx context.Context) ([]apiv1.BootConfiguration, error)
+}
+
+// ArtifactResolver resolves artifact names referenced by boot configurations
+type ArtifactResolver interface {
+	ResolveArtifactURL(ctx context.Context, name string) (string, error)
+}
+
+// SetArtifactResolver enables kernelArtifact and initrdArtifact references in
+// boot configurations
+func (c *BootScriptController) SetArtifactResolver(resolver ArtifactResolver) {
+	c.artifacts = resolver
+}
+
+// SetScriptCache replaces the script cache, closing the previous one
+func (c *BootScriptController) SetScriptCache(cache Cache) {
+	c.cache.Close()
+	c.cache = cache
+}
+
+// CacheStats returns script cache statistics
+func (c *BootScriptController) CacheStats() CacheStats {
+	return c.cache.Stats()
 }
 
 // NewBootScriptController creates a new controller instance
-func NewBootScriptController(client client.Client, logger *log.Logger) *BootScriptController {
+func NewBootScriptController(client client.API, logger *log.Logger) *BootScriptController {
+	return NewBootScriptControllerWithReader(client, logger)
+}
+
+// NewBootScriptControllerWithReader creates a controller that reads nodes
+// and boot configurations from reader
+func NewBootScriptControllerWithReader(reader ResourceReader, logger *log.Logger) *BootScriptController {
 	return &BootScriptController{
-		client: client,
+		client: reader,
 		logger: logger,
-		cache:  NewScriptCache(5 * time.Minute), // 5 minute cache
+		cache:  NewScriptCache(DefaultCacheTTL),
 	}
 }
 
@@ -50,62 +142,274 @@ const (
 	IdentifierNID
 	IdentifierMAC
 	IdentifierUnknown
+	IdentifierUUID
+	IdentifierSerial
 )
 
+// SerialPrefix marks an identifier as a node's serial number, which could
+// otherwise read as any other identifier: serial:<serial>
+const SerialPrefix = "serial:"
+
 type configCandidate struct {
 	config *apiv1.BootConfiguration
 	score  int
 }
 
+// Templates used to render boot scripts
+const (
+	TemplateDefault  = "default"
+	TemplateMinimal  = "minimal"
+	TemplateError    = "error"
+	TemplateFallback = "fallback"
+	TemplateHold     = "hold"
+	TemplateLocal    = "local"
+	TemplateChain    = "chain"
+)
+
+// renderResult describes how a boot script was produced
+type renderResult struct {
+	script   string
+	template string
+	reason   string
+	node     *apiv1.Node
+	config   *apiv1.BootConfiguration // with artifact references resolved
+	chainURL string                   // the expanded URL of a chain template
+	fallback *fallbackUse             // set when no configuration matched
+	// unavailable is returned instead of the script of a cordoned node
+	unavailable *UnavailableError
+	// uncached is set for scripts served once, such as a boot-once
+	// override, and for scripts carrying secret values
+	uncached bool
+}
+
 // GenerateBootScript generates an iPXE boot script for a node
 func (c *BootScriptController) GenerateBootScript(ctx context.Context, identifier, profile string) (string, error) {
 	c.logger.Printf("Generating boot script for identifier: %s", identifier)
 
+	// MACs in any notation, and UUIDs in any case, share one cache entry
+	identifier = c.parseNodeIdentifier(identifier).Value
+
 	// Check cache first
-	cacheKey := c.generateCacheKey(identifier, profile)
+	c.expireScheduledScripts(time.Now())
+	cacheKey := requestCacheKey(ctx, firmwareCacheKey(ctx, c.generateCacheKey(identifier, profile)))
 	if cached, found := c.cache.Get(cacheKey); found {
 		c.logger.Printf("Cache hit for identifier: %s", identifier)
+		c.recordCacheHit(ctx, cacheKey, identifier)
 		return cached, nil
 	}
 
+	// Identical concurrent requests, as in a reboot storm, share one
+	// generation. It runs detached from the first caller's cancellation so a
+	// disconnect does not fail the others; the deadline budget still bounds it.
+	rendered := false
+	value, _, shared := c.inflight.Do(cacheKey, func() (interface{}, error) {
+		rendered = true
+		generation := c.generation.Load()
+		renderCtx, secrets := withSecretUse(context.WithoutCancel(ctx))
+		result := c.render(renderCtx, identifier, profile)
+		if secrets.resolved.Load() {
+			result.uncached = true
+		}
+		// A write during the render may have invalidated what it loaded, and
+		// the script is not cached then
+		if result.template == TemplateDefault && result.fallback == nil && !result.uncached && c.generation.Load() == generation {
+			// Cache the result under the lookup key; HandleResourceChange
+			// drops it when the node, its configuration, or its artifacts change
+			configName := result.config.Metadata.Name
+			c.cache.Set(cacheKey, result.script, result.node.Spec.XName, configName)
+			c.logger.Printf("Generated boot script for node %s using config %s", result.node.Spec.XName, configName)
+		}
+		return result, nil
+	})
+	if shared && !rendered {
+		c.coalesced.Add(1)
+	}
+	result := value.(renderResult)
+	if result.fallback != nil {
+		c.fallbacks.add(*result.fallback)
+	}
+	c.recordRender(ctx, cacheKey, identifier, result)
+	if result.unavailable != nil {
+		return "", result.unavailable
+	}
+	return result.script, nil
+}
+
+// DeduplicatedLookups returns how many node lookups were served by a
+// concurrent identical lookup instead of reaching the resource API
+func (c *BootScriptController) DeduplicatedLookups() uint64 {
+	return c.nodeLookups.Shared()
+}
+
+// CoalescedRequests returns how many boot script requests shared a
+// concurrent generation instead of rendering their own
+func (c *BootScriptController) CoalescedRequests() uint64 {
+	return c.coalesced.Load()
+}
+
+// render resolves the node and configuration and builds the boot script
+// without consulting the cache. Each stage runs within the deadline budget;
+// when it is exhausted the fallback script is returned instead.
+func (c *BootScriptController) render(ctx context.Context, identifier, profile string) renderResult {
+	budget := c.Budget()
+	if budget.Total > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget.Total)
+		defer cancel()
+	}
+
 	// Parse and resolve node identifier
 	nodeID := c.parseNodeIdentifier(identifier)
-	node, err := c.resolveNode(ctx, nodeID)
+	node, err := runStage(ctx, "node lookup", budget.NodeLookup, func(ctx context.Context) (*apiv1.Node, error) {
+		return c.resolveNode(ctx, nodeID)
+	})
+	if errors.Is(err, ErrBudgetExhausted) {
+		return c.fallback(identifier, err, nil, nil)
+	}
+	if errors.Is(err, ErrNodeNotFound) {
+		// An unknown node may be registered by discovery
+		if result, discovered := c.discoverNode(ctx, identifier, nodeID, profile); discovered {
+			return result
+		}
+		return c.unmatched(ctx, identifier, nil, profile, err)
+	}
 	if err != nil {
-		return c.generateErrorScript(fmt.Sprintf("Node resolution failed: %v", err)), nil
+		reason := fmt.Sprintf("Node resolution failed: %v", err)
+		return renderResult{script: c.generateErrorScript(reason), template: TemplateError, reason: reason}
+	}
+
+	// Maintenance mode overrides the configuration of held nodes
+	if result, held := c.maintenanceHold(identifier, node); held {
+		return result
+	}
+	// A discovered node boots the discovery configuration until adopted
+	if result, discovered := c.discoveryBoot(ctx, identifier, node, profile); discovered {
+		return result
+	}
+	// So does a cordon, until the node is uncordoned
+	if result, cordoned := c.cordonHold(identifier, node); cordoned {
+		return result
+	}
+	// A node whose BMC reports a hardware fault is not reprovisioned
+	if result, faulted := c.hardwareFault(node); faulted {
+		return result
+	}
+	// A one-time override beats everything the node would boot otherwise
+	if result, once := c.bootOnceOverride(ctx, identifier, node, profile); once {
+		return result
+	}
+	// A node stuck in a boot loop may be switched to a diagnostic configuration
+	if result, diverted := c.bootLoopDiagnostic(ctx, identifier, node, profile); diverted {
+		return result
+	}
+	// A node's own chainURL delegates it whatever it matches
+	if result, chained := c.nodeChain(ctx, identifier, node); chained {
+		return result
 	}
 
 	// Find best matching configuration
-	config, err := c.findBootConfiguration(ctx, node, profile)
+	config, err := runStage(ctx, "configuration lookup", budget.ConfigLookup, func(ctx context.Context) (*apiv1.BootConfiguration, error) {
+		return c.findBootConfiguration(ctx, node, profile)
+	})
+	if errors.Is(err, ErrBudgetExhausted) {
+		return c.fallback(identifier, err, node, nil)
+	}
+	if errors.Is(err, ErrAmbiguousMatch) {
+		reason := err.Error()
+		c.logger.Printf("Refusing to boot node %s: %s", node.Spec.XName, reason)
+		return renderResult{script: c.generateErrorScript(reason), template: TemplateError, reason: reason, node: node}
+	}
+	if err != nil {
+		// Nodes without a configuration boot as the fallback policy says
+		return c.unmatched(ctx, identifier, node, profile, err)
+	}
+
+	resolved, err := runStage(ctx, "artifact resolution", budget.ArtifactResolution, func(ctx context.Context) (*apiv1.BootConfiguration, error) {
+		return c.resolveArtifacts(ctx, config)
+	})
+	if errors.Is(err, ErrBudgetExhausted) {
+		return c.fallback(identifier, err, node, config)
+	}
+	if err != nil {
+		reason := fmt.Sprintf("Artifact resolution failed: %v", err)
+		return renderResult{script: c.generateErrorScript(reason), template: TemplateError, reason: reason, node: node, config: config}
+	}
+	// A node that reported its firmware boots the matching variant
+	resolved = applyFirmware(ctx, resolved)
+	_, err = runStage(ctx, "policy evaluation", 0, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, c.checkBootPolicy(ctx, node, resolved, profile)
+	})
+	if errors.Is(err, ErrBootDenied) {
+		reason := err.Error()
+		c.logger.Printf("Boot of %s by node %s refused: %s", resolved.Metadata.Name, node.Spec.XName, reason)
+		return renderResult{script: c.generateErrorScript(reason), template: TemplateError, reason: reason, node: node, config: resolved}
+	}
 	if err != nil {
-		c.logger.Printf("No configuration found for node %s: %v", node.Spec.XName, err)
-		// Return minimal script for nodes without configuration
-		return c.generateMinimalScript(identifier), nil
+		// The node retries once the policy can be evaluated again
+		return c.fallback(identifier, fmt.Errorf("policy evaluation failed: %w", err), node, resolved)
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return c.fallback(identifier, fmt.Errorf("%w before rendering", ErrBudgetExhausted), node, resolved)
 	}
 
 	// Generate iPXE script
-	script, err := c.buildIPXEScript(config, node)
+	script, err := c.buildIPXEScript(ctx, resolved, node)
 	if err != nil {
-		return c.generateErrorScript(fmt.Sprintf("Script generation failed: %v", err)), nil
+		reason := fmt.Sprintf("Script generation failed: %v", err)
+		return renderResult{script: c.generateErrorScript(reason), template: TemplateError, reason: reason, node: node, config: resolved}
+	}
+	if err := LintScript(script); err != nil {
+		return c.lintFailed(identifier, err, node, resolved)
+	}
+
+	return renderResult{script: script, template: TemplateDefault, node: node, config: resolved}
+}
+
+// resolveArtifacts returns a copy of config with artifact and image
+// references replaced by the URLs they currently resolve to, and its root
+// filesystem rendered into its params
+func (c *BootScriptController) resolveArtifacts(ctx context.Context, config *apiv1.BootConfiguration) (*apiv1.BootConfiguration, error) {
+	if config.Spec.KernelArtifact == "" && config.Spec.InitrdArtifact == "" && config.Spec.ImageRef == "" && config.Spec.RootFS == nil {
+		return config, nil
+	}
+	if c.artifacts == nil && (config.Spec.KernelArtifact != "" || config.Spec.InitrdArtifact != "") {
+		return nil, fmt.Errorf("configuration %s references artifacts but no artifact registry is configured", config.Metadata.Name)
 	}
 
-	// Cache the result
-	configName := ""
-	if config != nil {
-		configName = config.Metadata.Name
+	resolved := *config
+	if config.Spec.ImageRef != "" {
+		if err := c.resolveImage(ctx, &resolved.Spec); err != nil {
+			return nil, err
+		}
+	}
+	if resolved.Spec.RootFS != nil {
+		if err := applyRootFS(&resolved.Spec); err != nil {
+			return nil, err
+		}
+	}
+	if config.Spec.KernelArtifact != "" {
+		url, err := c.artifacts.ResolveArtifactURL(ctx, config.Spec.KernelArtifact)
+		if err != nil {
+			return nil, fmt.Errorf("kernel artifact %s: %w", config.Spec.KernelArtifact, err)
+		}
+		resolved.Spec.Kernel = url
+	}
+	if config.Spec.InitrdArtifact != "" {
+		url, err := c.artifacts.ResolveArtifactURL(ctx, config.Spec.InitrdArtifact)
+		if err != nil {
+			return nil, fmt.Errorf("initrd artifact %s: %w", config.Spec.InitrdArtifact, err)
+		}
+		resolved.Spec.Initrd = url
 	}
-	cacheKey = c.generateCacheKey(identifier, configName)
-	c.cache.Set(cacheKey, script, node.Spec.XName, configName)
 
-	c.logger.Printf("Generated boot script for node %s using config %s", node.Spec.XName, configName)
-	return script, nil
+	return &resolved, nil
 }
 
 // parseNodeIdentifier determines what type of identifier we're dealing with
 func (c *BootScriptController) parseNodeIdentifier(identifier string) NodeIdentifier {
 	// Check if it's an XName (format: x<cabinet>c<chassis>s<slot>b<blade>n<node>)
 	if validation.ValidateXName(identifier) {
-		return NodeIdentifier{Value: identifier, Type: IdentifierXName}
+		return NodeIdentifier{Value: validation.NormalizeXName(identifier), Type: IdentifierXName}
 	}
 
 	// Check if it's a numeric NID
@@ -113,9 +417,17 @@ func (c *BootScriptController) parseNodeIdentifier(identifier string) NodeIdenti
 		return NodeIdentifier{Value: identifier, Type: IdentifierNID}
 	}
 
-	// Check if it's a MAC address
+	// Check if it's a MAC address, in any notation
 	if validation.ValidateMAC(identifier) {
-		return NodeIdentifier{Value: identifier, Type: IdentifierMAC}
+		return NodeIdentifier{Value: validation.NormalizeMAC(identifier), Type: IdentifierMAC}
+	}
+
+	// Check if it's an SMBIOS UUID, in any case, or a serial number
+	if validation.IsUUID(identifier) {
+		return NodeIdentifier{Value: strings.ToLower(identifier), Type: IdentifierUUID}
+	}
+	if strings.HasPrefix(identifier, SerialPrefix) {
+		return NodeIdentifier{Value: identifier, Type: IdentifierSerial}
 	}
 
 	return NodeIdentifier{Value: identifier, Type: IdentifierUnknown}
@@ -123,8 +435,8 @@ func (c *BootScriptController) parseNodeIdentifier(identifier string) NodeIdenti
 
 // resolveNode finds a node based on the identifier
 func (c *BootScriptController) resolveNode(ctx context.Context, identifier NodeIdentifier) (*apiv1.Node, error) {
-	// Get all nodes
-	nodes, err := c.client.GetNodes(ctx)
+	// Get all nodes; concurrent resolutions share one listing
+	nodes, err := c.nodeLookups.Do(ctx, "nodes", c.client.GetNodes)
 	if err != nil {
 		return nil, fmt.Errorf("getting nodes: %w", err)
 	}
@@ -142,23 +454,69 @@ func (c *BootScriptController) resolveNode(ctx context.Context, identifier NodeI
 				return &nodeItem, nil
 			}
 		case IdentifierMAC:
-			if strings.EqualFold(nodeItem.Spec.BootMAC, identifier.Value) {
+			// Nodes may PXE boot from any of their interfaces
+			if nodeItem.Spec.HasMAC(identifier.Value) {
+				return &nodeItem, nil
+			}
+		case IdentifierUUID:
+			if nodeItem.Spec.HasUUID(identifier.Value) {
+				return &nodeItem, nil
+			}
+		case IdentifierSerial:
+			if nodeItem.Spec.HasSerial(strings.TrimPrefix(identifier.Value, SerialPrefix)) {
+				return &nodeItem, nil
+			}
+		case IdentifierUnknown:
+			if nodeItem.Metadata.UID == identifier.Value {
+				return &nodeItem, nil
+			}
+		}
+	}
+
+	// Hostnames and aliases are tried after UIDs so they never shadow one,
+	// and serials after aliases. UUIDs may be recorded as aliases too.
+	if identifier.Type == IdentifierUnknown || identifier.Type == IdentifierUUID {
+		for _, nodeItem := range nodes {
+			if nodeItem.Spec.HasAlias(identifier.Value) {
+				return &nodeItem, nil
+			}
+		}
+	}
+	if identifier.Type == IdentifierUnknown {
+		for _, nodeItem := range nodes {
+			if nodeItem.Spec.HasSerial(identifier.Value) {
 				return &nodeItem, nil
 			}
 		}
 	}
 
-	return nil, fmt.Errorf("node not found for identifier %s", identifier.Value)
+	return nil, fmt.Errorf("%w for identifier %s", ErrNodeNotFound, identifier.Value)
 }
 
 // findBootConfiguration finds the best matching configuration for a node
 func (c *BootScriptController) findBootConfiguration(ctx context.Context, node *apiv1.Node, profile string) (*apiv1.BootConfiguration, error) {
 	// Get all boot configurations
-	configs, err := c.client.GetBootConfigurations(ctx)
+	configs, err := c.bootConfigurations(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("getting boot configurations: %w", err)
 	}
 
+	at, override := evaluationTime(ctx)
+	if !override {
+		c.trackScheduleTransitions(configs, at)
+	}
+	return c.selectConfigurationAt(configs, node, profile, at)
+}
+
+// selectConfiguration picks the best matching configuration for a node from
+// the configs active now
+func (c *BootScriptController) selectConfiguration(configs []apiv1.BootConfiguration, node *apiv1.Node, profile string) (*apiv1.BootConfiguration, error) {
+	return c.selectConfigurationAt(configs, node, profile, time.Now())
+}
+
+// selectConfigurationAt picks the best matching configuration for a node from
+// the configs active at the given time
+func (c *BootScriptController) selectConfigurationAt(configs []apiv1.BootConfiguration, node *apiv1.Node, profile string, at time.Time) (*apiv1.BootConfiguration, error) {
 	if len(configs) == 0 {
 		return nil, fmt.Errorf("no boot configurations found")
 	}
@@ -170,7 +528,8 @@ func (c *BootScriptController) findBootConfiguration(ctx context.Context, node *
 		return p
 	}
 
-	findBestCandidate := func(targetProfile string, filterByProfile bool) *apiv1.BootConfiguration {
+	strict := c.Scoring().Strict
+	findBestCandidate := func(targetProfile string, filterByProfile bool) (*apiv1.BootConfiguration, error) {
 		var candidates []configCandidate
 
 		targetProfile = normalizeProfile(targetProfile)
@@ -181,6 +540,9 @@ func (c *BootScriptController) findBootConfiguration(ctx context.Context, node *
 			if filterByProfile && configProfile != targetProfile {
 				continue
 			}
+			if !c.configActive(&configItem, at) {
+				continue
+			}
 
 			score := c.calculateConfigScore(&configItem, node)
 			if score > 0 {
@@ -189,69 +551,107 @@ func (c *BootScriptController) findBootConfiguration(ctx context.Context, node *
 		}
 
 		if len(candidates) == 0 {
-			return nil
+			return nil, nil
 		}
 
-		// Sort by score (descending), priority (descending), and name (ascending)
-		// to keep deterministic selection when score and priority are identical.
-		sort.Slice(candidates, func(i, j int) bool {
-			if candidates[i].score != candidates[j].score {
-				return candidates[i].score > candidates[j].score
-			}
-			if candidates[i].config.Spec.Priority != candidates[j].config.Spec.Priority {
-				return candidates[i].config.Spec.Priority > candidates[j].config.Spec.Priority
-			}
-			return candidates[i].config.Metadata.Name < candidates[j].config.Metadata.Name
-		})
+		sortCandidates(candidates)
 
-		return candidates[0].config
+		if strict {
+			if err := ambiguity(candidates); err != nil {
+				return nil, err
+			}
+		}
+		return candidates[0].config, nil
 	}
 
 	// DHCP-style profile-less requests should auto-resolve the best configuration
 	// across all profiles using score and priority.
 	if profile == "" {
-		if match := findBestCandidate("", false); match != nil {
-			return match, nil
+		if match, err := findBestCandidate("", false); match != nil || err != nil {
+			return match, err
 		}
 		return nil, fmt.Errorf("no matching configurations found for node %s", node.Spec.XName)
 	}
 
 	if profile != "" && profile != "default" {
-		if match := findBestCandidate(profile, true); match != nil {
-			return match, nil
+		if match, err := findBestCandidate(profile, true); match != nil || err != nil {
+			return match, err
 		}
 		c.logger.Printf("No config found for profile '%s', falling back to default", profile)
 	}
 
-	if match := findBestCandidate("default", true); match != nil {
-		return match, nil
+	if match, err := findBestCandidate("default", true); match != nil || err != nil {
+		return match, err
 	}
 
 	return nil, fmt.Errorf("no matching configurations found for node %s", node.Spec.XName)
 }
 
+// sortCandidates sorts by score (descending), priority (descending), and
+// name (ascending) to keep deterministic selection when score and priority
+// are identical
+func sortCandidates(candidates []configCandidate) {
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		if candidates[i].config.Spec.Priority != candidates[j].config.Spec.Priority {
+			return candidates[i].config.Spec.Priority > candidates[j].config.Spec.Priority
+		}
+		return candidates[i].config.Metadata.Name < candidates[j].config.Metadata.Name
+	})
+}
+
 // calculateConfigScore determines how well a configuration matches a node
 func (c *BootScriptController) calculateConfigScore(config *apiv1.BootConfiguration, node *apiv1.Node) int {
 	score := 0
+	for _, component := range c.scoreBreakdown(config, node) {
+		score += component.Points
+	}
+	return score
+}
+
+// ScoreComponent is one matching rule that contributed to a configuration score
+type ScoreComponent struct {
+	Rule   string `json:"rule"` // host, mac, nid, group, default
+	Value  string `json:"value,omitempty"`
+	Points int    `json:"points"`
+}
+
+// scoreBreakdown lists the rules of config that match node
+func (c *BootScriptController) scoreBreakdown(config *apiv1.BootConfiguration, node *apiv1.Node) []ScoreComponent {
+	var components []ScoreComponent
+	weights := c.Scoring().Weights
+
+	// A configuration owned by a tenant never applies to another tenant's nodes
+	if config.Spec.Tenant != "" && config.Spec.Tenant != node.Spec.Tenant {
+		return nil
+	}
 
 	// Host/XName pattern matching
 	for _, host := range config.Spec.Hosts {
 		if c.matchesPattern(host, node.Spec.XName) || c.matchesPattern(host, node.Spec.Hostname) {
-			score += 50
+			components = append(components, ScoreComponent{Rule: "host", Value: host, Points: weights.Host})
 		}
 	}
 
 	// MAC address matching
 	for _, mac := range config.Spec.MACs {
-		if strings.EqualFold(mac, node.Spec.BootMAC) {
-			score += 100 // Exact MAC match is highest priority
+		if node.Spec.HasMAC(mac) {
+			// Exact MAC match is highest priority
+			components = append(components, ScoreComponent{Rule: "mac", Value: mac, Points: weights.MAC})
 		}
 	}
 
 	// NID matching
 	for _, nid := range config.Spec.NIDs {
 		if nid == node.Spec.NID {
-			score += 75
+			components = append(components, ScoreComponent{Rule: "nid", Value: strconv.Itoa(int(nid)), Points: weights.NID})
+		}
+	}
+	for _, expr := range config.Spec.NIDRanges {
+		if ranges, err := hostlist.ParseRanges(expr); err == nil && ranges.Contains(int(node.Spec.NID)) {
+			components = append(components, ScoreComponent{Rule: "nid", Value: expr, Points: weights.NID})
 		}
 	}
 
@@ -259,33 +659,43 @@ func (c *BootScriptController) calculateConfigScore(config *apiv1.BootConfigurat
 	for _, configGroup := range config.Spec.Groups {
 		for _, nodeGroup := range node.Spec.Groups {
 			if configGroup == nodeGroup {
-				score += 25
+				components = append(components, ScoreComponent{Rule: "group", Value: configGroup, Points: weights.Group})
 			}
 		}
 	}
 
 	// Base score for any configuration (fallback)
-	if score == 0 && len(config.Spec.Hosts) == 0 && len(config.Spec.MACs) == 0 &&
-		len(config.Spec.NIDs) == 0 && len(config.Spec.Groups) == 0 {
-		score = 1 // Default/catch-all configuration
+	if len(components) == 0 && len(config.Spec.Hosts) == 0 && len(config.Spec.MACs) == 0 &&
+		len(config.Spec.NIDs) == 0 && len(config.Spec.NIDRanges) == 0 && len(config.Spec.Groups) == 0 {
+		components = append(components, ScoreComponent{Rule: "default", Points: weights.Default}) // Default/catch-all configuration
 	}
 
-	return score
+	return components
 }
 
-// matchesPattern checks if a pattern matches a value (supports wildcards)
+// matchesPattern checks if a pattern matches a value (supports wildcards
+// and hostlist expressions such as nid00[000-100])
 func (c *BootScriptController) matchesPattern(pattern, value string) bool {
-	// Simple pattern matching - could be enhanced with regex later
 	if pattern == "*" {
 		return true
 	}
 	if pattern == value {
 		return true
 	}
-	// TODO: Add more sophisticated pattern matching if needed
+	if value != "" && hostlist.IsExpression(pattern) {
+		parsed, err := hostlist.Parse(pattern)
+		return err == nil && parsed.Match(value)
+	}
 	return false
 }
 
+// fallback returns the fallback script result for a request whose script
+// cannot be generated or served safely
+func (c *BootScriptController) fallback(identifier string, err error, node *apiv1.Node, config *apiv1.BootConfiguration) renderResult {
+	c.logger.Printf("Serving fallback boot script for %s: %v", identifier, err)
+	return renderResult{script: c.generateFallbackScript(identifier), template: TemplateFallback, reason: err.Error(), node: node, config: config}
+}
+
 // generateMinimalScript creates a minimal iPXE script for nodes without configuration
 func (c *BootScriptController) generateMinimalScript(identifier string) string {
 	// Use a simple string replacement for the minimal template
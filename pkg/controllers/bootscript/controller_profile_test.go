@@ -220,7 +220,7 @@ func newTestControllerWithData(t *testing.T, nodes []apiv1.Node, configs []apiv1
 		t.Fatalf("failed to create test client: %v", err)
 	}
 
-	return NewBootScriptController(*bootClient, log.New(io.Discard, "", 0))
+	return NewBootScriptController(bootClient, log.New(io.Discard, "", 0))
 }
 
 func writeJSONResponse(t *testing.T, w http.ResponseWriter, data interface{}) {
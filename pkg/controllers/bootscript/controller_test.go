@@ -5,11 +5,13 @@
 package bootscript
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
 
 	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
 )
 
 // TestScriptCache tests the caching functionality
@@ -44,6 +46,102 @@ func TestScriptCache(t *testing.T) {
 	}
 }
 
+// TestScriptCacheLimits tests LRU eviction and the hit/miss counters
+func TestScriptCacheLimits(t *testing.T) {
+	entrySize := int64(len("k1")+len("script")+len("n")+len("c")) + cacheEntryOverhead
+
+	tests := []struct {
+		name       string
+		maxEntries int
+		maxBytes   int64
+	}{
+		{name: "entry limit", maxEntries: 2, maxBytes: DefaultCacheMaxBytes},
+		{name: "byte limit", maxEntries: DefaultCacheMaxEntries, maxBytes: 2 * entrySize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewBoundedScriptCache(time.Minute, tt.maxEntries, tt.maxBytes)
+			defer cache.Close()
+
+			cache.Set("k1", "script", "n", "c")
+			cache.Set("k2", "script", "n", "c")
+			cache.Get("k1") // k2 is now least recently used
+			cache.Set("k3", "script", "n", "c")
+
+			if _, found := cache.Get("k2"); found {
+				t.Error("expected least recently used entry to be evicted")
+			}
+			for _, key := range []string{"k1", "k3"} {
+				if _, found := cache.Get(key); !found {
+					t.Errorf("expected %s to stay cached", key)
+				}
+			}
+
+			stats := cache.Stats()
+			if stats.TotalEntries != 2 || stats.Bytes != 2*entrySize {
+				t.Errorf("got %d entries and %d bytes, want 2 and %d", stats.TotalEntries, stats.Bytes, 2*entrySize)
+			}
+			if stats.Hits != 3 || stats.Misses != 1 || stats.Evictions != 1 {
+				t.Errorf("got hits=%d misses=%d evictions=%d, want 3, 1, 1", stats.Hits, stats.Misses, stats.Evictions)
+			}
+		})
+	}
+
+	// Lowering the limits at runtime evicts down to the new size
+	shrinking := NewBoundedScriptCache(time.Minute, 10, DefaultCacheMaxBytes)
+	defer shrinking.Close()
+	for _, key := range []string{"k1", "k2", "k3"} {
+		shrinking.Set(key, "script", "n", "c")
+	}
+	shrinking.SetLimits(time.Minute, 1, DefaultCacheMaxBytes)
+	if _, found := shrinking.Get("k3"); !found || shrinking.Stats().TotalEntries != 1 {
+		t.Errorf("expected only the most recent entry after SetLimits, got %+v", shrinking.Stats())
+	}
+
+	// A script larger than the byte limit is never cached
+	cache := NewBoundedScriptCache(time.Minute, 10, cacheEntryOverhead)
+	cache.Close()
+	cache.Close() // Close is idempotent
+	cache.Set("big", "script", "n", "c")
+	if stats := cache.Stats(); stats.TotalEntries != 0 || stats.Bytes != 0 {
+		t.Errorf("expected oversized script to be skipped, got %+v", stats)
+	}
+}
+
+// TestHandleResourceChange tests cache invalidation on resource writes
+func TestHandleResourceChange(t *testing.T) {
+	controller := createTestController(t)
+	ctx := context.Background()
+
+	controller.cache.Set("x0c0s0b0n0:default", "script-a", "x0c0s0b0n0", "compute")
+	controller.cache.Set("x0c0s1b0n0:default", "script-b", "x0c0s1b0n0", "compute")
+
+	// A node write drops only that node's scripts, under its old and new xname
+	controller.HandleResourceChange(ctx, resourcewatch.Event{
+		Type:         resourcewatch.Updated,
+		ResourceType: "Node",
+		Old:          []byte(`{"spec":{"xname":"x0c0s0b0n0"}}`),
+		New:          []byte(`{"spec":{"xname":"x0c0s0b0n9"}}`),
+	})
+	if _, found := controller.cache.Get("x0c0s0b0n0:default"); found {
+		t.Error("expected script of updated node to be invalidated")
+	}
+	if _, found := controller.cache.Get("x0c0s1b0n0:default"); !found {
+		t.Error("expected script of unrelated node to stay cached")
+	}
+
+	// A configuration write can retarget any node
+	controller.HandleResourceChange(ctx, resourcewatch.Event{
+		Type:         resourcewatch.Created,
+		ResourceType: "BootConfiguration",
+		New:          []byte(`{"metadata":{"name":"gpu"}}`),
+	})
+	if stats := controller.cache.Stats(); stats.TotalEntries != 0 {
+		t.Errorf("expected configuration write to clear the cache, %d entries left", stats.TotalEntries)
+	}
+}
+
 // TestIPXETemplates tests the iPXE script generation templates
 func TestIPXETemplates(t *testing.T) {
 	controller := createTestController(t)
@@ -70,7 +168,7 @@ func TestIPXETemplates(t *testing.T) {
 		},
 	}
 
-	script, err := controller.buildIPXEScript(config, testNode)
+	script, err := controller.buildIPXEScript(context.Background(), config, testNode)
 	if err != nil {
 		t.Errorf("Unexpected error building iPXE script: %v", err)
 		return
@@ -141,7 +239,10 @@ func TestTemplateVariablePreparation(t *testing.T) {
 		},
 	}
 
-	vars := controller.prepareTemplateVars(config, testNode)
+	vars, err := controller.prepareTemplateVars(context.Background(), config, testNode)
+	if err != nil {
+		t.Fatalf("Unexpected error preparing template vars: %v", err)
+	}
 
 	if vars["XName"] != "x0c0s1b0n0" {
 		t.Errorf("Expected XName x0c0s1b0n0, got %v", vars["XName"])
@@ -172,7 +273,7 @@ func TestTemplateVariablePreparation(t *testing.T) {
 		cfg := &apiv1.BootConfiguration{
 			Spec: apiv1.BootConfigurationSpec{Kernel: config.Spec.Kernel, Params: ""},
 		}
-		v := controller.prepareTemplateVars(cfg, testNode)
+		v, _ := controller.prepareTemplateVars(context.Background(), cfg, testNode)
 		if v["Params"] != "BOOTIF=01-aa-bb-cc-dd-ee-ff" {
 			t.Errorf("Expected BOOTIF only, got %v", v["Params"])
 		}
@@ -185,7 +286,7 @@ func TestTemplateVariablePreparation(t *testing.T) {
 				Params: "console=ttyS0,115200 BOOTIF=01-11-22-33-44-55-66",
 			},
 		}
-		v := controller.prepareTemplateVars(cfg, testNode)
+		v, _ := controller.prepareTemplateVars(context.Background(), cfg, testNode)
 		if v["Params"] != "console=ttyS0,115200 BOOTIF=01-11-22-33-44-55-66" {
 			t.Errorf("Expected unchanged Params, got %v", v["Params"])
 		}
@@ -193,7 +294,7 @@ func TestTemplateVariablePreparation(t *testing.T) {
 
 	t.Run("EmptyBootMAC", func(t *testing.T) {
 		noMacNode := &apiv1.Node{Spec: apiv1.NodeSpec{XName: "x0c0s1b0n0", BootMAC: ""}}
-		v := controller.prepareTemplateVars(config, noMacNode)
+		v, _ := controller.prepareTemplateVars(context.Background(), config, noMacNode)
 		if v["Params"] != "console=ttyS0,115200" {
 			t.Errorf("Expected unchanged Params when no MAC, got %v", v["Params"])
 		}
@@ -229,3 +330,9 @@ func TestFilenameExtraction(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckTemplates(t *testing.T) {
+	if err := CheckTemplates(); err != nil {
+		t.Fatalf("CheckTemplates failed: %v", err)
+	}
+}
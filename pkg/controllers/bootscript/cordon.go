@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// Cordon actions
+const (
+	// CordonHold serves the hold script, which waits and reboots
+	CordonHold = "hold"
+	// CordonUnavailable refuses the request with 503 and Retry-After
+	CordonUnavailable = "unavailable"
+)
+
+// Cordon is how a cordoned node is kept from booting
+type Cordon struct {
+	// Action is CordonHold or CordonUnavailable
+	Action string
+	Reason string
+	// RetryAfter is sent with CordonUnavailable
+	RetryAfter time.Duration
+}
+
+// Cordons decides which nodes are cordoned
+type Cordons interface {
+	// Cordoned returns the cordon of node, by xname, or ok false when it
+	// boots normally
+	Cordoned(node string) (cordon Cordon, ok bool)
+}
+
+// UnavailableError is returned for a node cordoned with CordonUnavailable
+type UnavailableError struct {
+	Node       string
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("node %s is cordoned: %s", e.Node, e.Reason)
+}
+
+// SetCordons keeps cordoned nodes from booting their configuration. They are
+// served the hold script, or refused with an *UnavailableError. The cordons
+// call InvalidateNodeScripts when a node is cordoned, so cached scripts do
+// not outlive the cordon.
+func (c *BootScriptController) SetCordons(cordons Cordons) {
+	c.cordons = cordons
+}
+
+// cordonHold returns the script for a cordoned node
+func (c *BootScriptController) cordonHold(identifier string, node *apiv1.Node) (renderResult, bool) {
+	if c.cordons == nil {
+		return renderResult{}, false
+	}
+	cordon, ok := c.cordons.Cordoned(node.Spec.XName)
+	if !ok {
+		return renderResult{}, false
+	}
+	reason := strings.Join(strings.Fields(cordon.Reason), " ")
+	if reason == "" {
+		reason = "no reason given"
+	}
+
+	script := c.holdScript
+	if script == "" {
+		script = HoldIPXETemplate
+	}
+	script = strings.ReplaceAll(script, "{{.Identifier}}", identifier)
+	script = strings.ReplaceAll(script, "{{.Reason}}", reason)
+	result := renderResult{script: script, template: TemplateHold, reason: "cordoned: " + reason, node: node}
+	if cordon.Action == CordonUnavailable {
+		result.unavailable = &UnavailableError{Node: node.Spec.XName, Reason: reason, RetryAfter: cordon.RetryAfter}
+	}
+	return result, true
+}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// testCordons cordons the nodes it maps
+type testCordons map[string]Cordon
+
+func (c testCordons) Cordoned(node string) (Cordon, bool) {
+	cordon, ok := c[node]
+	return cordon, ok
+}
+
+func TestCordonedNodes(t *testing.T) {
+	nodes := []apiv1.Node{{
+		Metadata: resource.Metadata{UID: "nod-1"},
+		Spec:     apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:01", Groups: []string{"compute"}},
+	}}
+	configs := []apiv1.BootConfiguration{{
+		Metadata: resource.Metadata{Name: "compute", UID: "bc-1"},
+		Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz"},
+	}}
+	controller := newTestControllerWithData(t, nodes, configs)
+	cordons := testCordons{}
+	controller.SetCordons(cordons)
+	ctx := context.Background()
+
+	script, err := controller.GenerateBootScript(ctx, "aa:bb:cc:dd:ee:01", "")
+	if err != nil || !strings.Contains(script, "files.example.com/vmlinuz") {
+		t.Fatalf("expected the node's own kernel, got %v:\n%s", err, script)
+	}
+
+	// Cordoning drops the node's cached scripts
+	cordons["x0c0s0b0n0"] = Cordon{Action: CordonHold, Reason: "flaky\nDIMM"}
+	controller.InvalidateNodeScripts("x0c0s0b0n0")
+	script, err = controller.GenerateBootScript(ctx, "aa:bb:cc:dd:ee:01", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if strings.Contains(script, "vmlinuz") || !strings.Contains(script, "flaky DIMM") {
+		t.Errorf("expected the hold script with the reason, got:\n%s", script)
+	}
+	if _, err := controller.Prewarm(ctx); err != nil {
+		t.Fatalf("Prewarm returned error: %v", err)
+	}
+	if script, _ := controller.GenerateBootScript(ctx, "x0c0s0b0n0", ""); strings.Contains(script, "vmlinuz") {
+		t.Errorf("prewarming cached the script of a cordoned node:\n%s", script)
+	}
+
+	cordons["x0c0s0b0n0"] = Cordon{Action: CordonUnavailable, Reason: "flaky DIMM", RetryAfter: 5 * time.Minute}
+	_, err = controller.GenerateBootScript(ctx, "aa:bb:cc:dd:ee:01", "")
+	var unavailable *UnavailableError
+	if !errors.As(err, &unavailable) {
+		t.Fatalf("expected an UnavailableError, got %v", err)
+	}
+	if unavailable.Node != "x0c0s0b0n0" || unavailable.RetryAfter != 5*time.Minute {
+		t.Errorf("UnavailableError = %+v, want node x0c0s0b0n0 retrying after 5m", unavailable)
+	}
+
+	delete(cordons, "x0c0s0b0n0")
+	controller.InvalidateNodeScripts("x0c0s0b0n0")
+	script, err = controller.GenerateBootScript(ctx, "aa:bb:cc:dd:ee:01", "")
+	if err != nil || !strings.Contains(script, "files.example.com/vmlinuz") {
+		t.Errorf("expected the node's own kernel after uncordoning, got %v:\n%s", err, script)
+	}
+}
+
+// testFaults faults the nodes it maps
+type testFaults map[string]string
+
+func (f testFaults) Faulted(node string) (string, bool) {
+	reason, ok := f[node]
+	return reason, ok
+}
+
+func TestHardwareFaults(t *testing.T) {
+	nodes := []apiv1.Node{{
+		Metadata: resource.Metadata{UID: "nod-1"},
+		Spec:     apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:01", Groups: []string{"compute"}},
+	}}
+	configs := []apiv1.BootConfiguration{{
+		Metadata: resource.Metadata{Name: "compute", UID: "bc-1"},
+		Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz"},
+	}}
+	controller := newTestControllerWithData(t, nodes, configs)
+	faults := testFaults{}
+	controller.SetHardwareFaults(faults)
+	ctx := context.Background()
+
+	faults["x0c0s0b0n0"] = "BMC x0c0s0b0 reports Critical health"
+	script, err := controller.GenerateBootScript(ctx, "aa:bb:cc:dd:ee:01", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if strings.Contains(script, "vmlinuz") || !strings.Contains(script, "Critical health") {
+		t.Errorf("expected the error script with the fault, got:\n%s", script)
+	}
+
+	delete(faults, "x0c0s0b0n0")
+	controller.InvalidateNodeScripts("x0c0s0b0n0")
+	script, err = controller.GenerateBootScript(ctx, "aa:bb:cc:dd:ee:01", "")
+	if err != nil || !strings.Contains(script, "files.example.com/vmlinuz") {
+		t.Errorf("expected the node's own kernel once the fault cleared, got %v:\n%s", err, script)
+	}
+}
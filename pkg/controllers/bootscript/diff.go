@@ -0,0 +1,241 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// ConfigurationDiff compares two boot configurations for review before b
+// replaces a
+type ConfigurationDiff struct {
+	A string `json:"a"`
+	B string `json:"b"`
+	// Kernel and Initrd are set when their sources differ
+	Kernel *ValueChange `json:"kernel,omitempty"`
+	Initrd *ValueChange `json:"initrd,omitempty"`
+	Params ParamsDiff   `json:"params"`
+	// Nodes would boot another configuration if b replaced a
+	Nodes []AssignmentChange `json:"nodes"`
+}
+
+// ValueChange is a value of a and the value of b replacing it
+type ValueChange struct {
+	A string `json:"a"`
+	B string `json:"b"`
+}
+
+// ParamsDiff compares kernel parameters key by key. Keys are the text
+// before "=", or the whole token for flags such as "quiet".
+type ParamsDiff struct {
+	// Added and Removed list the parameters whose key only b or only a has
+	Added   []string      `json:"added"`
+	Removed []string      `json:"removed"`
+	Changed []ParamChange `json:"changed"`
+}
+
+// ParamChange lists the parameters with one key in a and in b, which may
+// repeat a key, such as console=
+type ParamChange struct {
+	Key string   `json:"key"`
+	A   []string `json:"a"`
+	B   []string `json:"b"`
+}
+
+// AssignmentChange is a node that would boot another configuration. An
+// empty configuration is the fallback for nodes nothing matches.
+type AssignmentChange struct {
+	UID   string `json:"uid"`
+	XName string `json:"xname"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// DiffConfigurations compares configurations a and b, identified by UID or
+// name. Nodes are assigned as if b replaced a and were active now. Params
+// are compared with their parameter profiles merged in and their templates
+// unexpanded.
+func (c *BootScriptController) DiffConfigurations(ctx context.Context, a, b string) (*ConfigurationDiff, error) {
+	configs, err := c.client.GetBootConfigurations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting boot configurations: %w", err)
+	}
+	find := func(id string) (*apiv1.BootConfiguration, error) {
+		for i := range configs {
+			if configs[i].Metadata.UID == id || configs[i].Metadata.Name == id {
+				return &configs[i], nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", ErrConfigurationNotFound, id)
+	}
+	configA, err := find(a)
+	if err != nil {
+		return nil, err
+	}
+	configB, err := find(b)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &ConfigurationDiff{
+		A:      configA.Metadata.Name,
+		B:      configB.Metadata.Name,
+		Kernel: valueChange(kernelSource(&configA.Spec), kernelSource(&configB.Spec)),
+		Initrd: valueChange(initrdSource(&configA.Spec), initrdSource(&configB.Spec)),
+		Nodes:  []AssignmentChange{},
+	}
+	paramsA, err := c.configurationParams(ctx, configA)
+	if err != nil {
+		return nil, err
+	}
+	paramsB, err := c.configurationParams(ctx, configB)
+	if err != nil {
+		return nil, err
+	}
+	diff.Params = diffParams(paramsA, paramsB)
+
+	// The configurations after b replaces a: b active whatever its schedule
+	replaced := make([]apiv1.BootConfiguration, 0, len(configs))
+	for i := range configs {
+		switch configs[i].Metadata.UID {
+		case configA.Metadata.UID:
+		case configB.Metadata.UID:
+			activated := configs[i]
+			activated.Spec.ActiveFrom, activated.Spec.ActiveUntil, activated.Spec.Windows = time.Time{}, time.Time{}, nil
+			replaced = append(replaced, activated)
+		default:
+			replaced = append(replaced, configs[i])
+		}
+	}
+
+	nodes, err := c.client.GetNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting nodes: %w", err)
+	}
+	for _, assignment := range c.assignments(configs, replaced, nodes, time.Now()) {
+		if assignment.From != assignment.To {
+			diff.Nodes = append(diff.Nodes, assignment)
+		}
+	}
+
+	return diff, nil
+}
+
+// assignments returns the configuration each node boots at a time from the
+// before configs and from the after configs, sorted by xname
+func (c *BootScriptController) assignments(before, after []apiv1.BootConfiguration, nodes []apiv1.Node, at time.Time) []AssignmentChange {
+	selected := func(configs []apiv1.BootConfiguration, node *apiv1.Node) string {
+		if config, err := c.selectConfigurationAt(configs, node, "", at); err == nil {
+			return config.Metadata.Name
+		}
+		return ""
+	}
+	assignments := make([]AssignmentChange, 0, len(nodes))
+	for i := range nodes {
+		node := &nodes[i]
+		assignments = append(assignments, AssignmentChange{
+			UID:   node.Metadata.UID,
+			XName: node.Spec.XName,
+			From:  selected(before, node),
+			To:    selected(after, node),
+		})
+	}
+	sort.Slice(assignments, func(i, j int) bool { return assignments[i].XName < assignments[j].XName })
+	return assignments
+}
+
+// configurationParams returns the params of a configuration merged over its
+// parameter profiles
+func (c *BootScriptController) configurationParams(ctx context.Context, config *apiv1.BootConfiguration) (string, error) {
+	profiles, err := c.resolveParamProfiles(ctx, config.Spec.ParamProfiles)
+	if err != nil {
+		return "", fmt.Errorf("paramProfiles of %s: %w", config.Metadata.Name, err)
+	}
+	params := ""
+	for _, profile := range profiles {
+		params = overrideParams(params, profile)
+	}
+	return overrideParams(params, config.Spec.Params), nil
+}
+
+// kernelSource describes where a configuration's kernel comes from
+func kernelSource(spec *apiv1.BootConfigurationSpec) string {
+	switch {
+	case spec.ChainURL != "":
+		return "chain:" + spec.ChainURL
+	case spec.ImageRef != "":
+		return "image:" + spec.ImageRef
+	case spec.KernelArtifact != "":
+		return "artifact:" + spec.KernelArtifact
+	}
+	return spec.Kernel
+}
+
+// initrdSource describes where a configuration's initrd comes from
+func initrdSource(spec *apiv1.BootConfigurationSpec) string {
+	switch {
+	case spec.ChainURL != "":
+		return "chain:" + spec.ChainURL
+	case spec.ImageRef != "":
+		return "image:" + spec.ImageRef
+	case spec.InitrdArtifact != "":
+		return "artifact:" + spec.InitrdArtifact
+	}
+	return spec.Initrd
+}
+
+// valueChange returns the change from a to b, or nil when they are equal
+func valueChange(a, b string) *ValueChange {
+	if a == b {
+		return nil
+	}
+	return &ValueChange{A: a, B: b}
+}
+
+// diffParams compares two kernel parameter strings key by key, in the order
+// keys first appear in a, then in b
+func diffParams(a, b string) ParamsDiff {
+	diff := ParamsDiff{Added: []string{}, Removed: []string{}, Changed: []ParamChange{}}
+	byKeyA, keysA := groupParams(a)
+	byKeyB, keysB := groupParams(b)
+
+	for _, key := range keysA {
+		paramsB, ok := byKeyB[key]
+		switch {
+		case !ok:
+			diff.Removed = append(diff.Removed, byKeyA[key]...)
+		case !slices.Equal(byKeyA[key], paramsB):
+			diff.Changed = append(diff.Changed, ParamChange{Key: key, A: byKeyA[key], B: paramsB})
+		}
+	}
+	for _, key := range keysB {
+		if _, ok := byKeyA[key]; !ok {
+			diff.Added = append(diff.Added, byKeyB[key]...)
+		}
+	}
+	return diff
+}
+
+// groupParams groups parameters by key, returning the keys in the order they
+// first appear
+func groupParams(params string) (map[string][]string, []string) {
+	byKey := make(map[string][]string)
+	var keys []string
+	for _, param := range strings.Fields(params) {
+		key := paramKey(param)
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], param)
+	}
+	return byKey, keys
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func TestDiffConfigurations(t *testing.T) {
+	nodes := []apiv1.Node{
+		{Metadata: resource.Metadata{UID: "nod-1"}, Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, Groups: []string{"compute"}}},
+		{Metadata: resource.Metadata{UID: "nod-2"}, Spec: apiv1.NodeSpec{XName: "x0c0s1b0n0", NID: 2, Groups: []string{"compute", "gpu"}}},
+		{Metadata: resource.Metadata{UID: "nod-3"}, Spec: apiv1.NodeSpec{XName: "x0c0s2b0n0", NID: 3, Groups: []string{"io"}}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "compute", UID: "bc-1"},
+			Spec: apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz",
+				Initrd: "http://files.example.com/initrd", Params: "console=ttyS0 quiet root=live:http://files.example.com/v1.squashfs"},
+		},
+		{
+			Metadata: resource.Metadata{Name: "gpu", UID: "bc-2"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"gpu"}, NIDs: []int32{2}, Kernel: "http://files.example.com/vmlinuz-gpu"},
+		},
+		{
+			// Not active yet, but compared as if it replaced compute now
+			Metadata: resource.Metadata{Name: "compute-v2", UID: "bc-3"},
+			Spec: apiv1.BootConfigurationSpec{Groups: []string{"compute", "io"}, KernelArtifact: "vmlinuz-v2",
+				Initrd: "http://files.example.com/initrd", ActiveFrom: time.Now().Add(24 * time.Hour),
+				Params: "console=ttyS0 console=tty0 root=live:http://files.example.com/v2.squashfs hugepages=2"},
+		},
+	}
+	controller := newTestControllerWithData(t, nodes, configs)
+
+	diff, err := controller.DiffConfigurations(context.Background(), "compute", "bc-3")
+	if err != nil {
+		t.Fatalf("DiffConfigurations returned error: %v", err)
+	}
+	if diff.A != "compute" || diff.B != "compute-v2" || diff.Initrd != nil {
+		t.Errorf("diff header = %+v, want compute and compute-v2 with the same initrd", diff)
+	}
+	if diff.Kernel == nil || diff.Kernel.A != "http://files.example.com/vmlinuz" || diff.Kernel.B != "artifact:vmlinuz-v2" {
+		t.Errorf("kernel change = %+v, want the URL replaced by the artifact", diff.Kernel)
+	}
+
+	wantParams := ParamsDiff{
+		Added:   []string{"hugepages=2"},
+		Removed: []string{"quiet"},
+		Changed: []ParamChange{
+			{Key: "console", A: []string{"console=ttyS0"}, B: []string{"console=ttyS0", "console=tty0"}},
+			{Key: "root", A: []string{"root=live:http://files.example.com/v1.squashfs"}, B: []string{"root=live:http://files.example.com/v2.squashfs"}},
+		},
+	}
+	if !reflect.DeepEqual(diff.Params, wantParams) {
+		t.Errorf("params diff = %+v, want %+v", diff.Params, wantParams)
+	}
+
+	// The gpu node keeps its better match; the io node gains one
+	wantNodes := []AssignmentChange{
+		{UID: "nod-1", XName: "x0c0s0b0n0", From: "compute", To: "compute-v2"},
+		{UID: "nod-3", XName: "x0c0s2b0n0", From: "", To: "compute-v2"},
+	}
+	if !reflect.DeepEqual(diff.Nodes, wantNodes) {
+		t.Errorf("assignment changes = %+v, want %+v", diff.Nodes, wantNodes)
+	}
+
+	if _, err := controller.DiffConfigurations(context.Background(), "compute", "missing"); !errors.Is(err, ErrConfigurationNotFound) {
+		t.Errorf("expected ErrConfigurationNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/activity"
+)
+
+// Discovery registers nodes that boot with an unknown MAC
+type Discovery interface {
+	// Register creates a discovered node for mac, with the SMBIOS UUID and
+	// source address of its request, or returns the node that has it
+	Register(ctx context.Context, mac, uuid, source string) (*apiv1.Node, error)
+}
+
+// SetDiscovery registers the unknown MACs of nodes' own boot script
+// requests, marked with WithAccess, with discovery, and serves discovered
+// nodes the named configuration until they are adopted. Discovery scripts
+// are not cached. Call it before serving scripts.
+func (c *BootScriptController) SetDiscovery(discovery Discovery, configuration string) {
+	c.discovery = discovery
+	c.discoveryConfig = configuration
+}
+
+// discoverNode registers the unknown node that requested identifier and
+// returns its discovery script
+func (c *BootScriptController) discoverNode(ctx context.Context, identifier string, nodeID NodeIdentifier, profile string) (renderResult, bool) {
+	if c.discovery == nil || nodeID.Type != IdentifierMAC {
+		return renderResult{}, false
+	}
+	access, boot := ctx.Value(accessKey{}).(Access)
+	if !boot {
+		return renderResult{}, false
+	}
+	node, err := c.discovery.Register(ctx, nodeID.Value, access.UUID, access.Client)
+	if err != nil {
+		c.logger.Printf("Discovery of %s failed: %v", identifier, err)
+		return renderResult{}, false
+	}
+	if c.activity != nil && node.Discovered() {
+		c.activity.Publish(activity.Event{
+			Type:       activity.Discovery,
+			Identifier: identifier,
+			Client:     access.Client,
+			Node:       node.Spec.XName,
+			Reason:     "registered as node " + node.Metadata.Name,
+			Tenant:     node.Spec.Tenant,
+		})
+	}
+	// Maintenance mode holds the node like any other
+	if result, held := c.maintenanceHold(identifier, node); held {
+		return result, true
+	}
+	return c.discoveryBoot(ctx, identifier, node, profile)
+}
+
+// discoveryBoot returns the discovery script of a discovered node
+func (c *BootScriptController) discoveryBoot(ctx context.Context, identifier string, node *apiv1.Node, profile string) (renderResult, bool) {
+	if c.discovery == nil || !node.Discovered() {
+		return renderResult{}, false
+	}
+	result := c.renderFallbackConfiguration(ctx, identifier, node, profile, c.discoveryConfig)
+	if result.reason == "" {
+		result.reason = fmt.Sprintf("discovered: configuration %s", c.discoveryConfig)
+	}
+	result.uncached = true
+	return result, true
+}
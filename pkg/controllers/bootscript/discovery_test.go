@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"io"
+	"log"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/activity"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// testDiscovery adds the nodes it registers to resources
+type testDiscovery struct {
+	resources  *StaticResources
+	registered []string
+}
+
+func (d *testDiscovery) Register(_ context.Context, mac, uuid, source string) (*apiv1.Node, error) {
+	d.registered = append(d.registered, mac+" "+uuid+" "+source)
+	d.resources.Nodes = append(d.resources.Nodes, apiv1.Node{
+		Metadata: resource.Metadata{Name: "discovered-aabbccddee99", Labels: map[string]string{apiv1.DiscoveredLabel: "true"}},
+		Spec:     apiv1.NodeSpec{BootMAC: mac, UUID: uuid},
+	})
+	return &d.resources.Nodes[len(d.resources.Nodes)-1], nil
+}
+
+func TestDiscovery(t *testing.T) {
+	resources := &StaticResources{
+		BootConfigurations: []apiv1.BootConfiguration{
+			{
+				Metadata: resource.Metadata{Name: "compute", UID: "bc-1"},
+				Spec:     apiv1.BootConfigurationSpec{Kernel: "http://files.example.com/vmlinuz"},
+			},
+			{
+				Metadata: resource.Metadata{Name: "inventory", UID: "bc-2"},
+				Spec:     apiv1.BootConfigurationSpec{Groups: []string{"inventory"}, Kernel: "http://files.example.com/inventory"},
+			},
+		},
+	}
+	controller := NewBootScriptControllerWithReader(resources, log.New(io.Discard, "", 0))
+	discovery := &testDiscovery{resources: resources}
+	controller.SetDiscovery(discovery, "inventory")
+	feed := activity.NewFeed(10)
+	events := feed.Subscribe()
+	defer events.Close()
+	controller.SetActivityRecorder(feed)
+
+	// Previews do not register the node
+	if _, err := controller.PreviewBootScript(context.Background(), "aa:bb:cc:dd:ee:99", ""); err != nil {
+		t.Fatalf("PreviewBootScript returned error: %v", err)
+	}
+	if len(discovery.registered) != 0 {
+		t.Fatalf("preview registered %v", discovery.registered)
+	}
+
+	boot := WithAccess(context.Background(), Access{Client: "10.1.0.50", UUID: "4c4c4544-0042-3510-8052-b4c04f4e4d32"})
+	for i := 0; i < 2; i++ {
+		script, err := controller.GenerateBootScript(boot, "aa:bb:cc:dd:ee:99", "")
+		if err != nil {
+			t.Fatalf("GenerateBootScript returned error: %v", err)
+		}
+		if !strings.Contains(script, "files.example.com/inventory") {
+			t.Fatalf("request %d: expected the discovery kernel, got:\n%s", i, script)
+		}
+	}
+	if len(discovery.registered) != 1 || discovery.registered[0] != "aa:bb:cc:dd:ee:99 4c4c4544-0042-3510-8052-b4c04f4e4d32 10.1.0.50" {
+		t.Errorf("registered = %v, want the node once", discovery.registered)
+	}
+	event := <-events.Events
+	if event.Type != activity.Discovery || event.Client != "10.1.0.50" || !strings.Contains(event.Reason, "discovered-aabbccddee99") {
+		t.Errorf("discovery event = %+v", event)
+	}
+
+	// An adopted node boots its own configuration
+	resources.Nodes[0].Metadata.Labels = nil
+	resources.Nodes[0].Spec.XName = "x1000c0s0b0n0"
+	script, err := controller.GenerateBootScript(boot, "aa:bb:cc:dd:ee:99", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "files.example.com/vmlinuz") {
+		t.Errorf("expected the adopted node's kernel, got:\n%s", script)
+	}
+}
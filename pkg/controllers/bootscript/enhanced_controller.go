@@ -19,7 +19,7 @@ type EnhancedBootScriptController struct {
 }
 
 // NewEnhancedBootScriptController creates a new enhanced controller with HSM integration
-func NewEnhancedBootScriptController(bootClient client.Client, hsmConfig hsm.IntegrationConfig, logger *log.Logger) (*EnhancedBootScriptController, error) {
+func NewEnhancedBootScriptController(bootClient client.API, hsmConfig hsm.IntegrationConfig, logger *log.Logger) (*EnhancedBootScriptController, error) {
 	// Create base controller
 	baseController := NewBootScriptController(bootClient, logger)
 
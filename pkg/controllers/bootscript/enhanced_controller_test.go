@@ -55,7 +55,7 @@ func TestEnhancedController_HSMIntegration(t *testing.T) {
 
 	// Create enhanced controller
 	logger := log.New(os.Stdout, "hsm-test: ", log.LstdFlags)
-	controller, err := NewEnhancedBootScriptController(*bootClient, hsmConfig, logger)
+	controller, err := NewEnhancedBootScriptController(bootClient, hsmConfig, logger)
 	if err != nil {
 		t.Fatalf("Failed to create enhanced controller: %v", err)
 	}
@@ -258,7 +258,7 @@ func TestEnhancedController_HSMSyncWorker(t *testing.T) {
 
 	// Create enhanced controller
 	logger := log.New(os.Stdout, "sync-test: ", log.LstdFlags)
-	controller, err := NewEnhancedBootScriptController(*bootClient, hsmConfig, logger)
+	controller, err := NewEnhancedBootScriptController(bootClient, hsmConfig, logger)
 	if err != nil {
 		t.Fatalf("Failed to create enhanced controller: %v", err)
 	}
@@ -306,7 +306,7 @@ func BenchmarkEnhancedController_WithHSM(b *testing.B) {
 
 	// Create enhanced controller
 	logger := log.New(os.Stdout, "bench: ", log.LstdFlags)
-	controller, err := NewEnhancedBootScriptController(*bootClient, hsmConfig, logger)
+	controller, err := NewEnhancedBootScriptController(bootClient, hsmConfig, logger)
 	if err != nil {
 		b.Fatalf("Failed to create enhanced controller: %v", err)
 	}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// holdRetryDelay is how long nodes held by maintenance mode wait before
+// asking again, as in HoldIPXETemplate
+const holdRetryDelay = 60
+
+// BootEntry is what a node boots, for boot loaders other than iPXE, such as
+// GRUB under UEFI HTTP boot
+type BootEntry struct {
+	Identifier    string `json:"identifier"`
+	Node          string `json:"node,omitempty"` // xname
+	Configuration string `json:"configuration,omitempty"`
+	// Template is the iPXE script template the node would be served, as in
+	// previews. Kernel is only set for default.
+	Template string `json:"template"`
+	Reason   string `json:"reason,omitempty"`
+	Kernel   string `json:"kernel,omitempty"`
+	Initrd   string `json:"initrd,omitempty"`
+	Params   string `json:"params,omitempty"`
+	ChainURL string `json:"chainURL,omitempty"`
+	// RetryDelay is how many seconds a fallback or held node waits before
+	// rebooting to ask again
+	RetryDelay int `json:"retryDelay,omitempty"`
+}
+
+// ResolveBootEntry matches a node the way GenerateBootScript does and
+// returns what it boots instead of an iPXE script. Boot entries are not
+// cached.
+func (c *BootScriptController) ResolveBootEntry(ctx context.Context, identifier, profile string) (*BootEntry, error) {
+	identifier = c.parseNodeIdentifier(identifier).Value
+	result := c.render(ctx, identifier, profile)
+	if result.fallback != nil {
+		c.fallbacks.add(*result.fallback)
+	}
+	if c.activity != nil {
+		c.activity.Publish(matchEvent(identifier, result))
+	}
+
+	entry := &BootEntry{Identifier: identifier, Template: result.template, Reason: result.reason, ChainURL: result.chainURL}
+	if result.node != nil {
+		entry.Node = result.node.Spec.XName
+	}
+	if result.config != nil {
+		entry.Configuration = result.config.Metadata.Name
+	}
+	switch result.template {
+	case TemplateFallback:
+		entry.RetryDelay = int(c.Budget().FallbackRetryDelay / time.Second)
+	case TemplateHold:
+		entry.RetryDelay = holdRetryDelay
+	case TemplateDefault:
+		node := result.node
+		if node == nil {
+			// A fallback configuration booting an unknown node
+			node = placeholderNode(c.parseNodeIdentifier(identifier))
+		}
+		config := result.config
+		if config.Spec.ChainURL != "" {
+			url, err := c.expandChainURL(ctx, config.Spec.ChainURL, node)
+			if err != nil {
+				return nil, err
+			}
+			entry.ChainURL = url
+			break
+		}
+		params, err := c.renderParams(ctx, config, node)
+		if err != nil {
+			return nil, fmt.Errorf("expanding params of %s: %w", config.Metadata.Name, err)
+		}
+		entry.Kernel = config.Spec.Kernel
+		entry.Initrd = config.Spec.Initrd
+		entry.Params = buildParams(params, node.Spec.BootMAC)
+	}
+	return entry, nil
+}
@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// Fallback actions for nodes without a matching configuration
+const (
+	// FallbackMinimal serves the minimal script, which tries the firmware's
+	// default boot
+	FallbackMinimal = "minimal"
+	// FallbackConfiguration boots a named boot configuration, such as a
+	// cluster-wide default or a rescue image
+	FallbackConfiguration = "configuration"
+	// FallbackChain chains to another iPXE script URL
+	FallbackChain = "chain"
+)
+
+// Fallback rule names reported in metrics and boot activity
+const (
+	// FallbackRuleDefault is the policy's cluster-wide rule
+	FallbackRuleDefault = "default"
+	// FallbackRuleBuiltin is the behavior without a policy rule: the minimal
+	// script for known nodes and the error script for unknown ones
+	FallbackRuleBuiltin = "builtin"
+)
+
+// FallbackRule says what a node boots when no configuration matches it or
+// the node is unknown
+type FallbackRule struct {
+	Action string `json:"action"`
+	// Configuration names the boot configuration booted by the
+	// configuration action
+	Configuration string `json:"configuration,omitempty"`
+	// ChainURL is the script chained to by the chain action. iPXE expands
+	// settings such as ${mac} in it.
+	ChainURL string `json:"chainURL,omitempty"`
+}
+
+// Validate checks that the rule has what its action needs
+func (r FallbackRule) Validate() error {
+	switch r.Action {
+	case FallbackMinimal:
+	case FallbackConfiguration:
+		if r.Configuration == "" {
+			return fmt.Errorf("action %q requires configuration", r.Action)
+		}
+	case FallbackChain:
+		parsed, err := url.Parse(r.ChainURL)
+		if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https" && parsed.Scheme != "tftp") {
+			return fmt.Errorf("action %q requires an http, https, or tftp chainURL", r.Action)
+		}
+	default:
+		return fmt.Errorf("action must be %q, %q, or %q", FallbackMinimal, FallbackConfiguration, FallbackChain)
+	}
+	return nil
+}
+
+// FallbackPolicy chooses the fallback rule for nodes without a matching
+// configuration
+type FallbackPolicy interface {
+	// Fallback returns the rule for node, nil for an unknown node, and the
+	// name of the policy entry it came from; ok is false when the built-in
+	// behavior applies
+	Fallback(node *apiv1.Node) (rule FallbackRule, name string, ok bool)
+}
+
+// SetFallbackPolicy makes nodes without a matching configuration, and
+// unknown nodes, boot as policy says. Fallback scripts are not cached, so a
+// changed policy applies to the next request.
+func (c *BootScriptController) SetFallbackPolicy(policy FallbackPolicy) {
+	c.fallbackPolicy = policy
+}
+
+// fallbackUse records which rule produced a fallback script
+type fallbackUse struct {
+	rule   string
+	action string
+}
+
+// FallbackCount is how many boot script requests a fallback rule served
+type FallbackCount struct {
+	Rule   string `json:"rule"`
+	Action string `json:"action"`
+	Count  uint64 `json:"count"`
+}
+
+// fallbackCounters counts served fallback scripts by rule and action
+type fallbackCounters struct {
+	counts sync.Map // fallbackUse -> *atomic.Uint64
+}
+
+func (f *fallbackCounters) add(use fallbackUse) {
+	counter, _ := f.counts.LoadOrStore(use, new(atomic.Uint64))
+	counter.(*atomic.Uint64).Add(1)
+}
+
+// FallbackUsage returns how many boot script requests each fallback rule
+// served, sorted by rule and action. The error action counts unknown nodes
+// served the error script by the built-in behavior.
+func (c *BootScriptController) FallbackUsage() []FallbackCount {
+	var counts []FallbackCount
+	c.fallbacks.counts.Range(func(key, value any) bool {
+		use := key.(fallbackUse)
+		counts = append(counts, FallbackCount{Rule: use.rule, Action: use.action, Count: value.(*atomic.Uint64).Load()})
+		return true
+	})
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Rule != counts[j].Rule {
+			return counts[i].Rule < counts[j].Rule
+		}
+		return counts[i].Action < counts[j].Action
+	})
+	return counts
+}
+
+// unmatched renders the script for an unknown node (node nil) or a node no
+// configuration matched, as the fallback policy says. cause is why no
+// configuration was selected.
+func (c *BootScriptController) unmatched(ctx context.Context, identifier string, node *apiv1.Node, profile string, cause error) renderResult {
+	var rule FallbackRule
+	name, ok := "", false
+	if c.fallbackPolicy != nil {
+		rule, name, ok = c.fallbackPolicy.Fallback(node)
+	}
+	if !ok {
+		use := &fallbackUse{rule: FallbackRuleBuiltin, action: FallbackMinimal}
+		if node == nil {
+			use.action = TemplateError
+			reason := fmt.Sprintf("Node resolution failed: %v", cause)
+			return renderResult{script: c.generateErrorScript(reason), template: TemplateError, reason: reason, fallback: use}
+		}
+		c.logger.Printf("No configuration found for node %s: %v", node.Spec.XName, cause)
+		return renderResult{script: c.generateMinimalScript(identifier), template: TemplateMinimal, reason: cause.Error(), node: node, fallback: use}
+	}
+
+	use := &fallbackUse{rule: name, action: rule.Action}
+	reason := fmt.Sprintf("%v; fallback policy %s: %s", cause, name, rule.Action)
+	switch rule.Action {
+	case FallbackChain:
+		reason += " " + rule.ChainURL
+		return renderResult{script: chainScript(identifier, rule.ChainURL), template: TemplateChain, reason: reason, node: node, chainURL: rule.ChainURL, fallback: use}
+	case FallbackConfiguration:
+		reason += " " + rule.Configuration
+		result := c.renderFallbackConfiguration(ctx, identifier, node, profile, rule.Configuration)
+		if result.reason == "" {
+			result.reason = reason
+		}
+		result.fallback = use
+		return result
+	default:
+		return renderResult{script: c.generateMinimalScript(identifier), template: TemplateMinimal, reason: reason, node: node, fallback: use}
+	}
+}
+
+// renderFallbackConfiguration renders the named configuration for node. An
+// unknown node boots it with only the identifier known about it.
+func (c *BootScriptController) renderFallbackConfiguration(ctx context.Context, identifier string, node *apiv1.Node, profile, name string) renderResult {
+	configs, err := c.bootConfigurations(ctx)
+	if err != nil {
+		reason := fmt.Sprintf("Fallback configuration lookup failed: %v", err)
+		return renderResult{script: c.generateErrorScript(reason), template: TemplateError, reason: reason, node: node}
+	}
+	var config *apiv1.BootConfiguration
+	for i := range configs {
+		if configs[i].Metadata.Name == name {
+			config = &configs[i]
+			break
+		}
+	}
+	if config == nil {
+		reason := fmt.Sprintf("Fallback configuration %s: %v", name, ErrConfigurationNotFound)
+		return renderResult{script: c.generateErrorScript(reason), template: TemplateError, reason: reason, node: node}
+	}
+
+	resolved, err := c.resolveArtifacts(ctx, config)
+	if err != nil {
+		reason := fmt.Sprintf("Artifact resolution failed: %v", err)
+		return renderResult{script: c.generateErrorScript(reason), template: TemplateError, reason: reason, node: node, config: config}
+	}
+	resolved = applyFirmware(ctx, resolved)
+	target := node
+	if target == nil {
+		target = placeholderNode(c.parseNodeIdentifier(identifier))
+	} else if err := c.checkBootPolicy(ctx, node, resolved, profile); errors.Is(err, ErrBootDenied) {
+		reason := err.Error()
+		return renderResult{script: c.generateErrorScript(reason), template: TemplateError, reason: reason, node: node, config: resolved}
+	} else if err != nil {
+		return c.fallback(identifier, fmt.Errorf("policy evaluation failed: %w", err), node, resolved)
+	}
+	script, err := c.buildIPXEScript(ctx, resolved, target)
+	if err != nil {
+		reason := fmt.Sprintf("Script generation failed: %v", err)
+		return renderResult{script: c.generateErrorScript(reason), template: TemplateError, reason: reason, node: node, config: resolved}
+	}
+	return renderResult{script: script, template: TemplateDefault, node: node, config: resolved}
+}
+
+// placeholderNode describes an unknown node by the identifier it booted with
+func placeholderNode(identifier NodeIdentifier) *apiv1.Node {
+	node := &apiv1.Node{}
+	switch identifier.Type {
+	case IdentifierXName:
+		node.Spec.XName = identifier.Value
+	case IdentifierNID:
+		nid, _ := strconv.ParseInt(identifier.Value, 10, 32)
+		node.Spec.NID = int32(nid)
+	case IdentifierMAC:
+		node.Spec.BootMAC = identifier.Value
+	}
+	return node
+}
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// testFallbackPolicy gives Management nodes the rescue configuration and
+// everything else the default rule, when set
+type testFallbackPolicy struct {
+	def *FallbackRule
+}
+
+func (p *testFallbackPolicy) Fallback(node *apiv1.Node) (FallbackRule, string, bool) {
+	if node != nil && node.Spec.Role == "Management" {
+		return FallbackRule{Action: FallbackConfiguration, Configuration: "rescue"}, "role:Management", true
+	}
+	if p.def == nil {
+		return FallbackRule{}, "", false
+	}
+	return *p.def, FallbackRuleDefault, true
+}
+
+func newFallbackTestController(t *testing.T) *BootScriptController {
+	t.Helper()
+
+	nodes := []apiv1.Node{
+		{
+			Metadata: resource.Metadata{UID: "nod-1"},
+			Spec:     apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:01", Role: "Compute", Groups: []string{"compute"}},
+		},
+		{
+			Metadata: resource.Metadata{UID: "nod-2"},
+			Spec:     apiv1.NodeSpec{XName: "x0c0s1b0n0", NID: 2, BootMAC: "aa:bb:cc:dd:ee:02", Role: "Management", Groups: []string{"ncn"}},
+		},
+	}
+	configs := []apiv1.BootConfiguration{{
+		// Matches no node; only booted by the fallback policy
+		Metadata: resource.Metadata{Name: "rescue", UID: "bc-1"},
+		Spec:     apiv1.BootConfigurationSpec{Groups: []string{"rescue"}, Kernel: "http://files.example.com/vmlinuz-rescue", Params: "rescue"},
+	}}
+	return newTestControllerWithData(t, nodes, configs)
+}
+
+func TestFallbackRuleValidate(t *testing.T) {
+	valid := []FallbackRule{
+		{Action: FallbackMinimal},
+		{Action: FallbackConfiguration, Configuration: "rescue"},
+		{Action: FallbackChain, ChainURL: "http://discovery.example.com/boot.ipxe?mac=${mac}"},
+		{Action: FallbackChain, ChainURL: "tftp://10.0.0.1/undionly.kpxe"},
+	}
+	for _, rule := range valid {
+		if err := rule.Validate(); err != nil {
+			t.Errorf("Validate(%+v) returned error: %v", rule, err)
+		}
+	}
+	invalid := []FallbackRule{
+		{},
+		{Action: "reboot"},
+		{Action: FallbackConfiguration},
+		{Action: FallbackChain},
+		{Action: FallbackChain, ChainURL: "file:///boot.ipxe"},
+	}
+	for _, rule := range invalid {
+		if err := rule.Validate(); err == nil {
+			t.Errorf("Validate(%+v) succeeded, want an error", rule)
+		}
+	}
+}
+
+func TestFallbackBuiltinBehavior(t *testing.T) {
+	controller := newFallbackTestController(t)
+	ctx := context.Background()
+
+	script, err := controller.GenerateBootScript(ctx, "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "no configuration available") {
+		t.Errorf("expected the minimal script, got:\n%s", script)
+	}
+	script, err = controller.GenerateBootScript(ctx, "aa:bb:cc:dd:ee:99", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "node not found") {
+		t.Errorf("expected the error script for an unknown node, got:\n%s", script)
+	}
+
+	want := []FallbackCount{
+		{Rule: FallbackRuleBuiltin, Action: TemplateError, Count: 1},
+		{Rule: FallbackRuleBuiltin, Action: FallbackMinimal, Count: 1},
+	}
+	if got := controller.FallbackUsage(); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("FallbackUsage = %+v, want %+v", got, want)
+	}
+}
+
+func TestFallbackPolicyRules(t *testing.T) {
+	controller := newFallbackTestController(t)
+	controller.SetFallbackPolicy(&testFallbackPolicy{def: &FallbackRule{Action: FallbackChain, ChainURL: "http://discovery.example.com/boot.ipxe?mac=${mac}"}})
+	ctx := context.Background()
+
+	// A role rule boots the rescue configuration
+	script, err := controller.GenerateBootScript(ctx, "x0c0s1b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "vmlinuz-rescue") || !strings.Contains(script, "rescue") {
+		t.Errorf("expected the rescue configuration, got:\n%s", script)
+	}
+	if stats := controller.cache.Stats(); stats.TotalEntries != 0 {
+		t.Errorf("cache entries = %d, want fallback scripts left uncached", stats.TotalEntries)
+	}
+
+	// Nodes without a role rule, and unknown nodes, follow the default rule
+	for _, identifier := range []string{"x0c0s0b0n0", "aa:bb:cc:dd:ee:99"} {
+		script, err = controller.GenerateBootScript(ctx, identifier, "")
+		if err != nil {
+			t.Fatalf("GenerateBootScript returned error: %v", err)
+		}
+		if !strings.Contains(script, "chain http://discovery.example.com/boot.ipxe?mac=${mac} ||") {
+			t.Errorf("expected %s chained to the discovery script, got:\n%s", identifier, script)
+		}
+	}
+
+	preview, err := controller.PreviewBootScript(ctx, "aa:bb:cc:dd:ee:99", "")
+	if err != nil {
+		t.Fatalf("PreviewBootScript returned error: %v", err)
+	}
+	if preview.Template != TemplateChain || !strings.Contains(preview.Reason, "fallback policy default: chain") {
+		t.Errorf("preview template %q, reason %q; want the default chain rule", preview.Template, preview.Reason)
+	}
+
+	want := []FallbackCount{
+		{Rule: FallbackRuleDefault, Action: FallbackChain, Count: 2},
+		{Rule: "role:Management", Action: FallbackConfiguration, Count: 1},
+	}
+	if got := controller.FallbackUsage(); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("FallbackUsage = %+v, want %+v without the preview", got, want)
+	}
+}
+
+func TestFallbackConfigurationForUnknownNode(t *testing.T) {
+	controller := newFallbackTestController(t)
+	controller.SetFallbackPolicy(&testFallbackPolicy{def: &FallbackRule{Action: FallbackConfiguration, Configuration: "rescue"}})
+
+	script, err := controller.GenerateBootScript(context.Background(), "aa:bb:cc:dd:ee:99", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "vmlinuz-rescue") || !strings.Contains(script, "BOOTIF=01-aa-bb-cc-dd-ee-99") {
+		t.Errorf("expected the rescue configuration booted by MAC, got:\n%s", script)
+	}
+
+	controller.SetFallbackPolicy(&testFallbackPolicy{def: &FallbackRule{Action: FallbackConfiguration, Configuration: "missing"}})
+	script, err = controller.GenerateBootScript(context.Background(), "aa:bb:cc:dd:ee:99", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "Fallback configuration missing") {
+		t.Errorf("expected the error script for a missing fallback configuration, got:\n%s", script)
+	}
+}
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"regexp"
+	"sort"
+	"strings"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// Firmware is the boot firmware a node reported, from iPXE's ${platform}
+// and ${buildarch} settings
+type Firmware struct {
+	Platform string // "efi" or "pcbios"; empty when unknown
+	Arch     string
+}
+
+// firmwareArch matches iPXE ${buildarch} values such as x86_64 and arm64
+var firmwareArch = regexp.MustCompile(`^[a-z0-9_]{1,16}$`)
+
+// NewFirmware returns the firmware a request reported. Unrecognized values
+// are dropped, so they neither select a variant nor split the script cache.
+func NewFirmware(platform, arch string) Firmware {
+	platform = strings.ToLower(strings.TrimSpace(platform))
+	if platform != apiv1.FirmwareEFI && platform != apiv1.FirmwareBIOS {
+		return Firmware{}
+	}
+	arch = strings.ToLower(strings.TrimSpace(arch))
+	if !firmwareArch.MatchString(arch) {
+		arch = ""
+	}
+	return Firmware{Platform: platform, Arch: arch}
+}
+
+func (f Firmware) String() string {
+	if f.Arch == "" {
+		return f.Platform
+	}
+	return f.Platform + "/" + f.Arch
+}
+
+type firmwareKey struct{}
+
+// WithFirmware renders scripts under ctx for a node booting with firmware:
+// boot configurations with firmware variants use the matching variant
+// instead of a script that picks one at boot time
+func WithFirmware(ctx context.Context, firmware Firmware) context.Context {
+	if firmware.Platform == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, firmwareKey{}, firmware)
+}
+
+// firmwareFrom returns the firmware set with WithFirmware, if any
+func firmwareFrom(ctx context.Context) Firmware {
+	firmware, _ := ctx.Value(firmwareKey{}).(Firmware)
+	return firmware
+}
+
+// firmwareCacheKey extends a script cache key with the firmware under ctx,
+// since the script for a known firmware differs from the one that picks a
+// variant at boot time
+func firmwareCacheKey(ctx context.Context, key string) string {
+	if firmware := firmwareFrom(ctx); firmware.Platform != "" {
+		return key + ":fw=" + firmware.String()
+	}
+	return key
+}
+
+// orderedVariants returns config's firmware variants in the order they are
+// tried: variants for a specific arch before those for any arch
+func orderedVariants(config *apiv1.BootConfiguration) []apiv1.FirmwareVariant {
+	variants := append([]apiv1.FirmwareVariant(nil), config.Spec.Firmware...)
+	sort.SliceStable(variants, func(i, j int) bool {
+		return variants[i].Arch != "" && variants[j].Arch == ""
+	})
+	return variants
+}
+
+// withVariant returns a copy of config with variant's fields in place of its
+// own and no firmware variants left
+func withVariant(config *apiv1.BootConfiguration, variant *apiv1.FirmwareVariant) *apiv1.BootConfiguration {
+	applied := *config
+	applied.Spec.Firmware = nil
+	if variant == nil {
+		return &applied
+	}
+	if variant.Kernel != "" {
+		applied.Spec.Kernel = variant.Kernel
+	}
+	if variant.Initrd != "" {
+		applied.Spec.Initrd = variant.Initrd
+	}
+	if variant.Params != "" {
+		applied.Spec.Params = variant.Params
+	}
+	return &applied
+}
+
+// applyFirmware returns config with the variant for the firmware under ctx
+// applied. Configurations without variants, and requests that did not
+// report their firmware, are returned unchanged.
+func applyFirmware(ctx context.Context, config *apiv1.BootConfiguration) *apiv1.BootConfiguration {
+	firmware := firmwareFrom(ctx)
+	if len(config.Spec.Firmware) == 0 || firmware.Platform == "" {
+		return config
+	}
+	for _, variant := range orderedVariants(config) {
+		if variant.Matches(firmware.Platform, firmware.Arch) {
+			return withVariant(config, &variant)
+		}
+	}
+	return withVariant(config, nil)
+}
+
+// firmwareBranch is one firmware variant in a script that picks the variant
+// at boot time
+type firmwareBranch struct {
+	Label          string
+	Platform       string // empty for the configuration's own values
+	Arch           string
+	Kernel         string
+	Initrd         string
+	Params         string
+	KernelFilename string
+	InitrdFilename string
+}
+
+// buildFirmwareScript renders a script that compares ${platform} and
+// ${buildarch} against config's firmware variants and boots the first that
+// matches, or the configuration's own kernel when none does
+func (c *BootScriptController) buildFirmwareScript(ctx context.Context, config *apiv1.BootConfiguration, node *apiv1.Node) (string, error) {
+	vars, err := c.prepareTemplateVars(ctx, config, node)
+	if err != nil {
+		return "", err
+	}
+
+	var branches []firmwareBranch
+	add := func(label, platform, arch string, applied *apiv1.BootConfiguration) error {
+		params, err := c.renderParams(ctx, applied, node)
+		if err != nil {
+			return err
+		}
+		branches = append(branches, firmwareBranch{
+			Label:          label,
+			Platform:       platform,
+			Arch:           arch,
+			Kernel:         applied.Spec.Kernel,
+			Initrd:         applied.Spec.Initrd,
+			Params:         buildParams(params, node.Spec.BootMAC),
+			KernelFilename: extractFilename(applied.Spec.Kernel),
+			InitrdFilename: extractFilename(applied.Spec.Initrd),
+		})
+		return nil
+	}
+	for _, variant := range orderedVariants(config) {
+		label := "firmware_" + variant.Platform
+		if variant.Arch != "" {
+			label += "_" + variant.Arch
+		}
+		if err := add(label, variant.Platform, variant.Arch, withVariant(config, &variant)); err != nil {
+			return "", err
+		}
+	}
+	if err := add("firmware_default", "", "", withVariant(config, nil)); err != nil {
+		return "", err
+	}
+	vars["Branches"] = branches
+
+	tmpl, err := template.New("firmware").Parse(FirmwareIPXETemplate)
+	if err != nil {
+		return "", fmt.Errorf("parsing firmware iPXE template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("executing firmware iPXE template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// FirmwareIPXETemplate boots a configuration with firmware variants for a
+// node that did not report its firmware, picking the variant at boot time
+const FirmwareIPXETemplate = `#!ipxe
+# iPXE Boot Script
+# Generated by OpenCHAMI Boot Service
+# Node: {{.XName}} (NID: {{.NID}})
+# Configuration: {{.ConfigName}}
+# Role: {{.Role}}{{if .Groups}} Groups: {{.Groups}}{{end}}
+
+echo Starting boot for {{.XName}}
+echo Using configuration: {{.ConfigName}}
+echo Firmware: ${platform} ${buildarch}
+
+# Configure network interface
+dhcp
+
+# Pick the kernel for this firmware
+{{- range .Branches}}{{if .Platform}}
+iseq ${platform} {{.Platform}} {{if .Arch}}&& iseq ${buildarch} {{.Arch}} {{end}}&& goto {{.Label}} ||
+{{- end}}{{end}}
+goto firmware_default
+{{range .Branches}}
+:{{.Label}}
+echo Downloading kernel: {{.KernelFilename}}
+kernel {{.Kernel}}{{if .Params}} {{.Params}}{{end}}
+{{- if .Initrd}}
+echo Downloading initrd: {{.InitrdFilename}}
+initrd {{.Initrd}}
+{{- end}}
+goto boot
+{{end}}
+:boot
+echo Booting {{.XName}}...
+boot
+`
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func newFirmwareTestController(t *testing.T) *BootScriptController {
+	t.Helper()
+
+	nodes := []apiv1.Node{{
+		Metadata: resource.Metadata{UID: "nod-1"},
+		Spec:     apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1},
+	}}
+	configs := []apiv1.BootConfiguration{{
+		Metadata: resource.Metadata{Name: "compute", UID: "bc-1"},
+		Spec: apiv1.BootConfigurationSpec{
+			Kernel: "http://files.example.com/vmlinuz",
+			Initrd: "http://files.example.com/initrd",
+			Params: "console=ttyS0",
+			Firmware: []apiv1.FirmwareVariant{
+				{Platform: apiv1.FirmwareEFI, Params: "console=ttyS0 efi=runtime"},
+				{Platform: apiv1.FirmwareEFI, Arch: "arm64", Kernel: "http://files.example.com/vmlinuz-arm64", Initrd: "http://files.example.com/initrd-arm64"},
+				{Platform: apiv1.FirmwareBIOS, Kernel: "http://files.example.com/vmlinuz-legacy"},
+			},
+		},
+	}}
+	return newTestControllerWithData(t, nodes, configs)
+}
+
+func TestFirmwareBranchingScript(t *testing.T) {
+	controller := newFirmwareTestController(t)
+
+	script, err := controller.GenerateBootScript(context.Background(), "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	dispatch := []string{
+		"iseq ${platform} efi && iseq ${buildarch} arm64 && goto firmware_efi_arm64 ||",
+		"iseq ${platform} efi && goto firmware_efi ||",
+		"iseq ${platform} pcbios && goto firmware_pcbios ||",
+		"goto firmware_default",
+	}
+	if !strings.Contains(script, strings.Join(dispatch, "\n")) {
+		t.Errorf("expected arch-specific variants tried first, got:\n%s", script)
+	}
+	for _, want := range []string{
+		":firmware_efi_arm64\necho Downloading kernel: vmlinuz-arm64\nkernel http://files.example.com/vmlinuz-arm64 console=ttyS0\n",
+		":firmware_efi\necho Downloading kernel: vmlinuz\nkernel http://files.example.com/vmlinuz console=ttyS0 efi=runtime\n",
+		":firmware_pcbios\necho Downloading kernel: vmlinuz-legacy\nkernel http://files.example.com/vmlinuz-legacy console=ttyS0\n",
+		"initrd http://files.example.com/initrd-arm64\n",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("expected script to contain %q, got:\n%s", want, script)
+		}
+	}
+	if err := LintScript(script); err != nil {
+		t.Errorf("LintScript returned %v", err)
+	}
+}
+
+func TestFirmwareVariantSelection(t *testing.T) {
+	controller := newFirmwareTestController(t)
+
+	tests := []struct {
+		firmware Firmware
+		kernel   string
+		params   string
+	}{
+		{NewFirmware("EFI", "arm64"), "http://files.example.com/vmlinuz-arm64", "console=ttyS0"},
+		{NewFirmware("efi", "x86_64"), "http://files.example.com/vmlinuz", "console=ttyS0 efi=runtime"},
+		{NewFirmware("pcbios", ""), "http://files.example.com/vmlinuz-legacy", "console=ttyS0"},
+		{NewFirmware("coreboot", "x86_64"), "", ""}, // unknown: picks at boot time
+	}
+	for _, tt := range tests {
+		ctx := WithFirmware(context.Background(), tt.firmware)
+		script, err := controller.GenerateBootScript(ctx, "x0c0s0b0n0", "")
+		if err != nil {
+			t.Fatalf("GenerateBootScript(%v) returned error: %v", tt.firmware, err)
+		}
+		if tt.kernel == "" {
+			if !strings.Contains(script, "goto firmware_default") {
+				t.Errorf("expected the branching script for %v, got:\n%s", tt.firmware, script)
+			}
+			continue
+		}
+		if strings.Contains(script, "iseq") || !strings.Contains(script, "set kernel "+tt.kernel+"\n") || !strings.Contains(script, "set params "+tt.params+"\n") {
+			t.Errorf("expected kernel %s and params %q for %v, got:\n%s", tt.kernel, tt.params, tt.firmware, script)
+		}
+
+		preview, err := controller.PreviewBootScript(ctx, "x0c0s0b0n0", "")
+		if err != nil {
+			t.Fatalf("PreviewBootScript returned error: %v", err)
+		}
+		if preview.Kernel != tt.kernel || preview.Params != tt.params {
+			t.Errorf("preview for %v = %s %q", tt.firmware, preview.Kernel, preview.Params)
+		}
+	}
+	// Each firmware, and the branching script, is cached separately
+	if stats := controller.cache.Stats(); stats.TotalEntries != 4 {
+		t.Errorf("cache entries = %d, want 4", stats.TotalEntries)
+	}
+}
@@ -6,7 +6,9 @@ package bootscript
 
 import (
 	"context"
+	"errors"
 	"log"
+	"time"
 
 	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
 	"github.com/openchami/boot-service/pkg/client"
@@ -46,7 +48,7 @@ type ProviderConfig struct {
 }
 
 // NewFlexibleBootScriptController creates a controller with the specified provider
-func NewFlexibleBootScriptController(bootClient client.Client, config ProviderConfig, logger *log.Logger) (*FlexibleBootScriptController, error) {
+func NewFlexibleBootScriptController(bootClient client.API, config ProviderConfig, logger *log.Logger) (*FlexibleBootScriptController, error) {
 	// Create base controller
 	baseController := NewBootScriptController(bootClient, logger)
 
@@ -124,7 +126,13 @@ func (c *FlexibleBootScriptController) GenerateBootScriptWithFallback(ctx contex
 	c.logger.Printf("Standard resolution failed for %s, trying %s provider: %v", identifier, c.providerType, err)
 
 	// Try external provider resolution
-	node, err := c.nodeProvider.ResolveNodeByIdentifier(ctx, identifier)
+	node, err := runStage(ctx, c.providerType+" node lookup", c.Budget().NodeLookup, func(ctx context.Context) (*apiv1.Node, error) {
+		return c.nodeProvider.ResolveNodeByIdentifier(ctx, identifier)
+	})
+	if errors.Is(err, ErrBudgetExhausted) {
+		c.logger.Printf("Serving fallback boot script for %s: %v", identifier, err)
+		return c.generateFallbackScript(identifier), nil
+	}
 	if err != nil {
 		c.logger.Printf("%s provider fallback also failed for %s: %v", c.providerType, identifier, err)
 		// Return minimal script as final fallback
@@ -154,6 +162,35 @@ func (c *FlexibleBootScriptController) StartBackgroundSync(ctx context.Context)
 	c.syncProvider.StartSyncWorker(ctx)
 }
 
+// SetSyncInterval changes the background sync interval, reporting false if
+// the provider does not support changing it at runtime
+func (c *FlexibleBootScriptController) SetSyncInterval(interval time.Duration) bool {
+	setter, ok := c.syncProvider.(interface{ SetSyncInterval(time.Duration) })
+	if !ok {
+		return false
+	}
+	setter.SetSyncInterval(interval)
+	return true
+}
+
+// SetSyncObserver sets the observer told the nodes of each background sync,
+// reporting false if the provider does not support one
+func (c *FlexibleBootScriptController) SetSyncObserver(observer hsm.SyncObserver) bool {
+	setter, ok := c.syncProvider.(interface{ SetSyncObserver(hsm.SyncObserver) })
+	if !ok {
+		return false
+	}
+	setter.SetSyncObserver(observer)
+	return true
+}
+
+// HSMIntegration returns the HSM provider, reporting false for other
+// providers
+func (c *FlexibleBootScriptController) HSMIntegration() (*hsm.IntegrationService, bool) {
+	integration, ok := c.nodeProvider.(*hsm.IntegrationService)
+	return integration, ok
+}
+
 // GetProviderStats returns statistics from the current provider
 func (c *FlexibleBootScriptController) GetProviderStats(ctx context.Context) map[string]interface{} {
 	if c.nodeProvider == nil {
@@ -186,7 +223,7 @@ func (c *FlexibleBootScriptController) GetProviderType() string {
 }
 
 // NewHSMController creates a controller specifically configured for HSM
-func NewHSMController(bootClient client.Client, hsmConfig hsm.IntegrationConfig, logger *log.Logger) *FlexibleBootScriptController {
+func NewHSMController(bootClient client.API, hsmConfig hsm.IntegrationConfig, logger *log.Logger) *FlexibleBootScriptController {
 	config := ProviderConfig{
 		Type:      "hsm",
 		HSMConfig: &hsmConfig,
@@ -202,7 +239,7 @@ func NewHSMController(bootClient client.Client, hsmConfig hsm.IntegrationConfig,
 }
 
 // NewYAMLController creates a controller specifically configured for YAML
-func NewYAMLController(bootClient client.Client, yamlConfig local.IntegrationConfig, logger *log.Logger) *FlexibleBootScriptController {
+func NewYAMLController(bootClient client.API, yamlConfig local.IntegrationConfig, logger *log.Logger) *FlexibleBootScriptController {
 	config := ProviderConfig{
 		Type:       "yaml",
 		YAMLConfig: &yamlConfig,
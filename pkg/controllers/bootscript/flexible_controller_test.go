@@ -153,7 +153,7 @@ func TestFlexibleController_YAMLProvider(t *testing.T) {
 
 	// Create flexible controller with YAML provider
 	logger := log.New(os.Stdout, "yaml-test: ", log.LstdFlags)
-	controller := NewYAMLController(*bootClient, yamlConfig, logger)
+	controller := NewYAMLController(bootClient, yamlConfig, logger)
 	if controller == nil {
 		t.Fatal("Failed to create YAML controller")
 	}
@@ -253,7 +253,7 @@ func TestFlexibleController_HSMProvider(t *testing.T) {
 
 	// Create flexible controller with HSM provider
 	logger := log.New(os.Stdout, "hsm-test: ", log.LstdFlags)
-	controller := NewHSMController(*bootClient, hsmConfig, logger)
+	controller := NewHSMController(bootClient, hsmConfig, logger)
 	if controller == nil {
 		t.Fatal("Failed to create HSM controller")
 	}
@@ -339,8 +339,8 @@ func TestFlexibleController_ProviderComparison(t *testing.T) {
 
 	logger := log.New(os.Stdout, "comparison-test: ", log.LstdFlags)
 
-	yamlController := NewYAMLController(*bootClient, yamlConfig, logger)
-	hsmController := NewHSMController(*bootClient, hsmConfig, logger)
+	yamlController := NewYAMLController(bootClient, yamlConfig, logger)
+	hsmController := NewHSMController(bootClient, hsmConfig, logger)
 
 	ctx := context.Background()
 
@@ -398,7 +398,7 @@ func BenchmarkFlexibleController_YAML(b *testing.B) {
 	}
 
 	logger := log.New(os.Stdout, "bench: ", log.LstdFlags)
-	controller := NewYAMLController(*bootClient, yamlConfig, logger)
+	controller := NewYAMLController(bootClient, yamlConfig, logger)
 
 	ctx := context.Background()
 
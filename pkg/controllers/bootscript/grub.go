@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// GrubConfig renders a boot entry as a GRUB configuration, for nodes that
+// UEFI HTTP boot GRUB instead of iPXE. Kernel and initrd URLs become GRUB
+// (http,host) paths. Chaining needs iPXE, so a chain entry stops at the GRUB
+// prompt like an error does.
+func GrubConfig(entry *BootEntry) string {
+	var b strings.Builder
+	b.WriteString("# GRUB configuration for UEFI HTTP boot\n")
+	b.WriteString("# Generated by OpenCHAMI Boot Service\n")
+	fmt.Fprintf(&b, "# Node: %s\n", grubComment(entry.Identifier))
+	if entry.Configuration != "" {
+		fmt.Fprintf(&b, "# Configuration: %s\n", grubComment(entry.Configuration))
+	}
+	b.WriteString("\n")
+
+	switch entry.Template {
+	case TemplateDefault:
+		if entry.ChainURL != "" {
+			writeGrubStop(&b, "Cannot chain to "+entry.ChainURL+" without iPXE")
+			break
+		}
+		fmt.Fprintf(&b, "set default=0\nset timeout=0\n\nmenuentry %s {\n", grubQuote(entry.Configuration))
+		fmt.Fprintf(&b, "\techo %s\n", grubQuote("Loading kernel for "+entry.Identifier))
+		b.WriteString("\tlinux " + grubPath(entry.Kernel))
+		if entry.Params != "" {
+			b.WriteString(" " + grubArgs(entry.Params))
+		}
+		b.WriteString("\n")
+		if entry.Initrd != "" {
+			b.WriteString("\tinitrd " + grubPath(entry.Initrd) + "\n")
+		}
+		b.WriteString("}\n")
+	case TemplateMinimal, TemplateLocal:
+		fmt.Fprintf(&b, "echo %s\n", grubQuote(entry.Reason))
+		b.WriteString("# Return to the firmware, which tries the next boot device\nexit\n")
+	case TemplateFallback, TemplateHold:
+		fmt.Fprintf(&b, "echo %s\n", grubQuote(entry.Reason))
+		fmt.Fprintf(&b, "echo %s\n", grubQuote(fmt.Sprintf("Retrying in %d seconds...", entry.RetryDelay)))
+		fmt.Fprintf(&b, "sleep %d\nreboot\n", entry.RetryDelay)
+	case TemplateChain:
+		writeGrubStop(&b, "Cannot chain to "+entry.ChainURL+" without iPXE")
+	default:
+		writeGrubStop(&b, entry.Reason)
+	}
+	return b.String()
+}
+
+// writeGrubStop leaves the node at the GRUB prompt with a message, which
+// prevents boot loops the way the error script does
+func writeGrubStop(b *strings.Builder, message string) {
+	b.WriteString("echo \"Boot configuration failed\"\n")
+	fmt.Fprintf(b, "echo %s\n", grubQuote(message))
+	b.WriteString("echo \"Please contact system administrator\"\n")
+}
+
+// grubPath converts an http or https URL to the GRUB (http,host)/path form.
+// Paths, which GRUB resolves against the server it was loaded from, are
+// returned unchanged.
+func grubPath(location string) string {
+	parsed, err := url.Parse(location)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return location
+	}
+	path := parsed.EscapedPath()
+	if parsed.RawQuery != "" {
+		path += "?" + parsed.RawQuery
+	}
+	return "(" + parsed.Scheme + "," + parsed.Host + ")" + path
+}
+
+// grubQuote double-quotes s for a GRUB command argument
+func grubQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", " ").Replace(s) + `"`
+}
+
+// grubArgs quotes the kernel parameters in params that GRUB would otherwise
+// split or expand, such as ds=nocloud-net;s=URL
+func grubArgs(params string) string {
+	args := strings.Fields(params)
+	for i, arg := range args {
+		if strings.ContainsAny(arg, ";&|<>$\"'\\") {
+			args[i] = grubQuote(arg)
+		}
+	}
+	return strings.Join(args, " ")
+}
+
+// grubComment keeps s on one comment line
+func grubComment(s string) string {
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func TestResolveBootEntryGrubConfig(t *testing.T) {
+	nodes := []apiv1.Node{
+		{Metadata: resource.Metadata{UID: "nod-1"}, Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:01", Groups: []string{"compute"}}},
+		{Metadata: resource.Metadata{UID: "nod-2"}, Spec: apiv1.NodeSpec{XName: "x0c0s1b0n0", NID: 2, BootMAC: "aa:bb:cc:dd:ee:02", Groups: []string{"vendor"}}},
+		{Metadata: resource.Metadata{UID: "nod-3"}, Spec: apiv1.NodeSpec{XName: "x0c0s2b0n0", NID: 3, BootMAC: "aa:bb:cc:dd:ee:03"}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "compute", UID: "bc-1"},
+			Spec: apiv1.BootConfigurationSpec{
+				Groups: []string{"compute"},
+				Kernel: "http://files.example.com:8080/vmlinuz",
+				Initrd: "/images/initrd",
+				Params: "console=ttyS0 nid={{.NID}}",
+				Firmware: []apiv1.FirmwareVariant{
+					{Platform: apiv1.FirmwareEFI, Params: "console=ttyS0 efi=runtime"},
+					{Platform: apiv1.FirmwareBIOS, Kernel: "http://files.example.com/vmlinuz-legacy"},
+				},
+			},
+		},
+		{
+			Metadata: resource.Metadata{Name: "vendor", UID: "bc-2"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"vendor"}, ChainURL: "http://provision.example.com/boot.ipxe"},
+		},
+	}
+	controller := newTestControllerWithData(t, nodes, configs)
+	ctx := WithFirmware(context.Background(), NewFirmware(apiv1.FirmwareEFI, ""))
+
+	entry, err := controller.ResolveBootEntry(ctx, "AA-BB-CC-DD-EE-01", "")
+	if err != nil {
+		t.Fatalf("ResolveBootEntry returned error: %v", err)
+	}
+	want := BootEntry{
+		Identifier: "aa:bb:cc:dd:ee:01", Node: "x0c0s0b0n0", Configuration: "compute", Template: TemplateDefault,
+		Kernel: "http://files.example.com:8080/vmlinuz", Initrd: "/images/initrd", Params: "console=ttyS0 efi=runtime BOOTIF=01-aa-bb-cc-dd-ee-01",
+	}
+	if *entry != want {
+		t.Errorf("entry = %+v, want %+v", *entry, want)
+	}
+	config := GrubConfig(entry)
+	for _, line := range []string{
+		`menuentry "compute" {`,
+		"\tlinux (http,files.example.com:8080)/vmlinuz console=ttyS0 efi=runtime BOOTIF=01-aa-bb-cc-dd-ee-01\n",
+		"\tinitrd /images/initrd\n",
+	} {
+		if !strings.Contains(config, line) {
+			t.Errorf("expected GRUB config to contain %q, got:\n%s", line, config)
+		}
+	}
+
+	// Chaining needs iPXE, and unmatched nodes return to the firmware
+	entry, err = controller.ResolveBootEntry(ctx, "aa:bb:cc:dd:ee:02", "")
+	if err != nil {
+		t.Fatalf("ResolveBootEntry returned error: %v", err)
+	}
+	if entry.ChainURL != "http://provision.example.com/boot.ipxe" || entry.Kernel != "" {
+		t.Errorf("chain entry = %+v", *entry)
+	}
+	if config := GrubConfig(entry); strings.Contains(config, "menuentry") || !strings.Contains(config, "without iPXE") {
+		t.Errorf("expected the chain entry to stop at the prompt, got:\n%s", config)
+	}
+
+	entry, err = controller.ResolveBootEntry(ctx, "aa:bb:cc:dd:ee:03", "")
+	if err != nil {
+		t.Fatalf("ResolveBootEntry returned error: %v", err)
+	}
+	if config := GrubConfig(entry); entry.Template != TemplateMinimal || !strings.HasSuffix(config, "exit\n") {
+		t.Errorf("expected the unmatched node to exit, got %s:\n%s", entry.Template, config)
+	}
+}
+
+func TestGrubQuoteAndPath(t *testing.T) {
+	if got := grubQuote(`say "hi" to $USER`); got != `"say \"hi\" to \$USER"` {
+		t.Errorf("grubQuote = %s", got)
+	}
+	if got := grubArgs("console=ttyS0 ds=nocloud-net;s=http://ci/cloud-init/"); got != `console=ttyS0 "ds=nocloud-net;s=http://ci/cloud-init/"` {
+		t.Errorf("grubArgs = %s", got)
+	}
+	for in, want := range map[string]string{
+		"https://s3.example.com/bucket/vmlinuz?X-Amz-Signature=abc": "(https,s3.example.com)/bucket/vmlinuz?X-Amz-Signature=abc",
+		"/boot/vmlinuz":           "/boot/vmlinuz",
+		"tftp://10.0.0.1/vmlinuz": "tftp://10.0.0.1/vmlinuz",
+	} {
+		if got := grubPath(in); got != want {
+			t.Errorf("grubPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// HardwareFaults decides which nodes have a hardware fault
+type HardwareFaults interface {
+	// Faulted returns why node, by xname, has a hardware fault, or ok false
+	// when it has none
+	Faulted(node string) (reason string, ok bool)
+}
+
+// SetHardwareFaults refuses boot scripts to nodes with a hardware fault,
+// serving them the error script instead of their configuration. The faults
+// call InvalidateNodeScripts when a node's fault appears or clears, so cached
+// scripts do not outlive it.
+func (c *BootScriptController) SetHardwareFaults(faults HardwareFaults) {
+	c.faults = faults
+}
+
+// hardwareFault returns the script for a node with a hardware fault
+func (c *BootScriptController) hardwareFault(node *apiv1.Node) (renderResult, bool) {
+	if c.faults == nil {
+		return renderResult{}, false
+	}
+	fault, ok := c.faults.Faulted(node.Spec.XName)
+	if !ok {
+		return renderResult{}, false
+	}
+	reason := "hardware fault: " + fault
+	c.logger.Printf("Refusing to boot node %s: %s", node.Spec.XName, reason)
+	return renderResult{script: c.generateErrorScript(reason), template: TemplateError, reason: reason, node: node}, true
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/clients/imageservice"
+)
+
+// ImageResolver resolves the image references of boot configurations to the
+// image's current kernel, initrd, and root filesystem
+type ImageResolver interface {
+	ResolveImage(ctx context.Context, ref string) (*imageservice.Image, error)
+}
+
+// SetImageResolver enables imageRef references in boot configurations
+func (c *BootScriptController) SetImageResolver(resolver ImageResolver) {
+	c.images = resolver
+}
+
+// resolveImage replaces the kernel and initrd of spec with those of its
+// image. An image root filesystem is the default rootfs URL; without a
+// rootfs it is booted with root=live: unless the configuration's params
+// choose the root themselves.
+func (c *BootScriptController) resolveImage(ctx context.Context, spec *apiv1.BootConfigurationSpec) error {
+	if c.images == nil {
+		return fmt.Errorf("image %s is referenced but no image service is configured", spec.ImageRef)
+	}
+	image, err := c.images.ResolveImage(ctx, spec.ImageRef)
+	if err != nil {
+		return fmt.Errorf("image %s: %w", spec.ImageRef, err)
+	}
+
+	spec.Kernel = image.Kernel
+	spec.Initrd = image.Initrd
+	if spec.RootFS != nil {
+		if spec.RootFS.URL == "" {
+			if image.RootFS == "" {
+				return fmt.Errorf("image %s has no root filesystem for rootfs", spec.ImageRef)
+			}
+			rootFS := *spec.RootFS
+			rootFS.URL = image.RootFS
+			spec.RootFS = &rootFS
+		}
+		return nil
+	}
+	if image.RootFS != "" && !hasParam(spec.Params, "root") {
+		spec.Params = strings.TrimSpace("root=live:" + image.RootFS + " " + spec.Params)
+	}
+	return nil
+}
+
+// hasParam reports whether params sets the parameter key
+func hasParam(params, key string) bool {
+	for _, param := range strings.Fields(params) {
+		if paramKey(param) == key {
+			return true
+		}
+	}
+	return false
+}
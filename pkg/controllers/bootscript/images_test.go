@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/clients/imageservice"
+)
+
+type staticImageResolver map[string]imageservice.Image
+
+func (r staticImageResolver) ResolveImage(_ context.Context, ref string) (*imageservice.Image, error) {
+	image, ok := r[ref]
+	if !ok {
+		return nil, imageservice.ErrNotFound
+	}
+	return &image, nil
+}
+
+func TestResolveArtifacts_ImageRef(t *testing.T) {
+	controller := createTestController(t)
+	config := &apiv1.BootConfiguration{
+		Spec: apiv1.BootConfigurationSpec{ImageRef: "compute:latest", Params: "console=ttyS0,115200"},
+	}
+
+	if _, err := controller.resolveArtifacts(context.Background(), config); err == nil {
+		t.Fatal("expected error when no image resolver is configured")
+	}
+
+	controller.SetImageResolver(staticImageResolver{"compute:latest": {
+		Kernel: "http://images.example.com/vmlinuz",
+		Initrd: "http://images.example.com/initrd",
+		RootFS: "http://images.example.com/rootfs.squashfs",
+	}})
+	resolved, err := controller.resolveArtifacts(context.Background(), config)
+	if err != nil {
+		t.Fatalf("resolveArtifacts returned error: %v", err)
+	}
+	if resolved.Spec.Kernel != "http://images.example.com/vmlinuz" || resolved.Spec.Initrd != "http://images.example.com/initrd" {
+		t.Errorf("unexpected resolved spec: %+v", resolved.Spec)
+	}
+	if want := "root=live:http://images.example.com/rootfs.squashfs console=ttyS0,115200"; resolved.Spec.Params != want {
+		t.Errorf("Params = %q, want %q", resolved.Spec.Params, want)
+	}
+	if config.Spec.Kernel != "" {
+		t.Error("resolveArtifacts must not modify the stored configuration")
+	}
+
+	// Params that choose the root keep it
+	config.Spec.Params = "root=nfs:10.0.0.1:/export/compute"
+	resolved, err = controller.resolveArtifacts(context.Background(), config)
+	if err != nil {
+		t.Fatalf("resolveArtifacts returned error: %v", err)
+	}
+	if resolved.Spec.Params != config.Spec.Params {
+		t.Errorf("Params = %q, want %q", resolved.Spec.Params, config.Spec.Params)
+	}
+
+	config.Spec.ImageRef = "missing"
+	if _, err := controller.resolveArtifacts(context.Background(), config); err == nil {
+		t.Error("expected error for unknown image")
+	}
+}
+
+func TestResolveArtifacts_RootFS(t *testing.T) {
+	controller := createTestController(t)
+	config := &apiv1.BootConfiguration{
+		Spec: apiv1.BootConfigurationSpec{
+			Kernel: "http://images.example.com/vmlinuz",
+			Params: "console=ttyS0,115200 rd.live.overlay.size=8192",
+			RootFS: &apiv1.RootFS{URL: "http://images.example.com/compute.squashfs", OverlaySizeMiB: 4096, ToRAM: true},
+		},
+	}
+
+	resolved, err := controller.resolveArtifacts(context.Background(), config)
+	if err != nil {
+		t.Fatalf("resolveArtifacts returned error: %v", err)
+	}
+	// The hand-written overlay size wins over the generated one
+	want := "root=live:http://images.example.com/compute.squashfs rd.live.overlay.overlayfs=1 rd.live.ram=1 console=ttyS0,115200 rd.live.overlay.size=8192"
+	if resolved.Spec.Params != want {
+		t.Errorf("Params = %q, want %q", resolved.Spec.Params, want)
+	}
+
+	// Without a URL the rootfs boots the image's root filesystem
+	controller.SetImageResolver(staticImageResolver{"compute": {
+		Kernel: "http://images.example.com/vmlinuz",
+		RootFS: "http://images.example.com/compute-v2.squashfs",
+	}})
+	config = &apiv1.BootConfiguration{
+		Spec: apiv1.BootConfigurationSpec{
+			ImageRef: "compute",
+			RootFS:   &apiv1.RootFS{Distro: "debian-live"},
+		},
+	}
+	resolved, err = controller.resolveArtifacts(context.Background(), config)
+	if err != nil {
+		t.Fatalf("resolveArtifacts returned error: %v", err)
+	}
+	if want := "boot=live fetch=http://images.example.com/compute-v2.squashfs"; resolved.Spec.Params != want {
+		t.Errorf("Params = %q, want %q", resolved.Spec.Params, want)
+	}
+	if config.Spec.RootFS.URL != "" {
+		t.Error("resolveArtifacts must not modify the stored configuration")
+	}
+}
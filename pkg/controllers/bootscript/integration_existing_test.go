@@ -44,7 +44,7 @@ func TestBootLogicWithExistingData(t *testing.T) {
 
 	// Create controller with real client
 	logger := log.New(os.Stdout, "test: ", log.LstdFlags)
-	controller := NewBootScriptController(*bootClient, logger)
+	controller := NewBootScriptController(bootClient, logger)
 
 	ctx := context.Background()
 	seedIntegrationData(t, bootClient, ctx)
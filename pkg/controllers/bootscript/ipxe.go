@@ -6,6 +6,7 @@ package bootscript
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"html/template"
 	"net"
@@ -15,9 +16,25 @@ import (
 )
 
 // buildIPXEScript generates an iPXE script from configuration and node data
-func (c *BootScriptController) buildIPXEScript(config *apiv1.BootConfiguration, node *apiv1.Node) (string, error) {
+func (c *BootScriptController) buildIPXEScript(ctx context.Context, config *apiv1.BootConfiguration, node *apiv1.Node) (string, error) {
+	// Chain configurations delegate the node instead of booting a kernel
+	if config.Spec.ChainURL != "" {
+		url, err := c.expandChainURL(ctx, config.Spec.ChainURL, node)
+		if err != nil {
+			return "", err
+		}
+		return chainScript(node.Spec.XName, url), nil
+	}
+	// Without the node's firmware, the script picks the variant itself
+	if len(config.Spec.Firmware) > 0 {
+		return c.buildFirmwareScript(ctx, config, node)
+	}
+
 	// Prepare template variables
-	vars := c.prepareTemplateVars(config, node)
+	vars, err := c.prepareTemplateVars(ctx, config, node)
+	if err != nil {
+		return "", err
+	}
 
 	// Use default template if no custom template is specified
 	tmplContent := DefaultIPXETemplate
@@ -36,8 +53,63 @@ func (c *BootScriptController) buildIPXEScript(config *apiv1.BootConfiguration,
 	return buf.String(), nil
 }
 
+// CheckTemplates compiles the built-in iPXE templates and renders each for a
+// sample node, so a broken template is caught before nodes boot. Rendered
+// scripts must pass LintScript.
+func CheckTemplates() error {
+	templates := map[string]string{
+		TemplateDefault:  DefaultIPXETemplate,
+		TemplateMinimal:  MinimalIPXETemplate,
+		TemplateError:    ErrorIPXETemplate,
+		TemplateFallback: FallbackIPXETemplate,
+		TemplateHold:     HoldIPXETemplate,
+		TemplateLocal:    LocalBootIPXETemplate,
+		TemplateChain:    ChainIPXETemplate,
+		"firmware":       FirmwareIPXETemplate,
+	}
+	sample := map[string]interface{}{
+		"XName":          "x0c0s0b0n0",
+		"NID":            "1",
+		"Role":           "Compute",
+		"Kernel":         "http://example.com/vmlinuz",
+		"Initrd":         "http://example.com/initrd",
+		"Params":         "console=ttyS0",
+		"ConfigName":     "check",
+		"KernelFilename": "vmlinuz",
+		"InitrdFilename": "initrd",
+		"Identifier":     "x0c0s0b0n0",
+		"Error":          "check",
+		"Reason":         "check",
+		"Delay":          10,
+		"URL":            "http://example.com/boot.ipxe",
+		"Branches": []firmwareBranch{
+			{Label: "firmware_efi_x86_64", Platform: "efi", Arch: "x86_64", Kernel: "http://example.com/vmlinuz.efi"},
+			{Label: "firmware_default", Kernel: "http://example.com/vmlinuz", Initrd: "http://example.com/initrd"},
+		},
+	}
+	for name, content := range templates {
+		tmpl, err := template.New(name).Parse(content)
+		if err != nil {
+			return fmt.Errorf("parsing %s iPXE template: %w", name, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, sample); err != nil {
+			return fmt.Errorf("executing %s iPXE template: %w", name, err)
+		}
+		if err := LintScript(buf.String()); err != nil {
+			return fmt.Errorf("%s iPXE template: %w", name, err)
+		}
+	}
+	return nil
+}
+
 // prepareTemplateVars creates the variable map for template substitution
-func (c *BootScriptController) prepareTemplateVars(config *apiv1.BootConfiguration, node *apiv1.Node) map[string]interface{} {
+func (c *BootScriptController) prepareTemplateVars(ctx context.Context, config *apiv1.BootConfiguration, node *apiv1.Node) (map[string]interface{}, error) {
+	params, err := c.renderParams(ctx, config, node)
+	if err != nil {
+		return nil, err
+	}
+
 	vars := map[string]interface{}{
 		// Node information
 		"XName":    node.Spec.XName,
@@ -51,7 +123,7 @@ func (c *BootScriptController) prepareTemplateVars(config *apiv1.BootConfigurati
 		// Boot configuration
 		"Kernel":   config.Spec.Kernel,
 		"Initrd":   config.Spec.Initrd,
-		"Params":   buildParams(config.Spec.Params, node.Spec.BootMAC),
+		"Params":   buildParams(params, node.Spec.BootMAC),
 		"Priority": config.Spec.Priority,
 
 		// Configuration metadata
@@ -63,7 +135,7 @@ func (c *BootScriptController) prepareTemplateVars(config *apiv1.BootConfigurati
 		"InitrdFilename": extractFilename(config.Spec.Initrd),
 	}
 
-	return vars
+	return vars, nil
 }
 
 // extractFilename extracts the filename from a URL or path
@@ -167,6 +239,60 @@ echo Boot script generation failed
 echo Error: {{.Error}}
 echo Please contact system administrator
 
-# Halt system to prevent boot loops
-halt
+# Stop at the iPXE shell to prevent boot loops (iPXE has no halt command)
+shell
+`
+
+// FallbackIPXETemplate is used when the deadline budget is exhausted
+const FallbackIPXETemplate = `#!ipxe
+# Fallback iPXE Boot Script
+# Node: {{.Identifier}}
+
+echo Boot service could not generate a boot script for {{.Identifier}} in time
+echo Retrying in {{.Delay}} seconds...
+
+# Reboot to retry instead of waiting for a network boot timeout
+sleep {{.Delay}}
+reboot
+`
+
+// HoldIPXETemplate is served to nodes held by maintenance mode
+const HoldIPXETemplate = `#!ipxe
+# Maintenance Hold iPXE Boot Script
+# Node: {{.Identifier}}
+
+echo Boot service is in maintenance mode: {{.Reason}}
+echo Holding {{.Identifier}}; retrying in 60 seconds...
+
+# Reboot to ask again instead of booting anything
+sleep 60
+reboot
+`
+
+// LocalBootIPXETemplate boots nodes held by maintenance mode from local disk
+const LocalBootIPXETemplate = `#!ipxe
+# Maintenance Local Boot iPXE Script
+# Node: {{.Identifier}}
+
+echo Boot service is in maintenance mode: {{.Reason}}
+echo Booting {{.Identifier}} from local disk...
+
+# Return to the firmware, which tries the next boot device
+exit
+`
+
+// ChainIPXETemplate delegates a node to another boot server: a
+// configuration or node chainURL, or the fallback policy's chain action
+const ChainIPXETemplate = `#!ipxe
+# Chain iPXE Boot Script
+# Node: {{.Identifier}}
+
+echo Chaining {{.Identifier}} to {{.URL}}...
+
+chain {{.URL}} || goto failed
+
+:failed
+echo Chain failed; retrying in 30 seconds...
+sleep 30
+reboot
 `
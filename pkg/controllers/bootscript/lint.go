@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"fmt"
+	"strings"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// MaxScriptLineLength is the longest script line served. Some firmware iPXE
+// builds truncate or reject longer command lines, which usually happens to a
+// kernel command line with many parameters.
+const MaxScriptLineLength = 4096
+
+// ipxeCommands are the commands of the iPXE scripting language
+var ipxeCommands = map[string]bool{}
+
+func init() {
+	for _, command := range strings.Fields(`
+		autoboot boot certfree certstat certstore chain choose clear colour
+		console cpair cpuid dhcp echo exit fcels fcstat form goto ibstat
+		ifclose ifconf ifopen ifstat imgargs imgdecrypt imgexec imgextract
+		imgfetch imgfree imgload imgselect imgstat imgtrust imgverify inc
+		initrd ipstat iseq isset item kernel login lotest menu module
+		neighbour nslookup nstat ntp param params pciscan ping poweroff
+		profstat prompt pxebs read reboot route sanboot sanhook sanunhook
+		set shell shim show sleep sync time vcreate vdestroy`) {
+		ipxeCommands[command] = true
+	}
+}
+
+// LintIssue is a problem found in a boot script
+type LintIssue struct {
+	Line    int    `json:"line"` // 1-based
+	Message string `json:"message"`
+}
+
+func (i LintIssue) String() string {
+	return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+}
+
+// LintError reports the issues that make a script unsafe to serve
+type LintError struct {
+	Issues []LintIssue
+}
+
+func (e *LintError) Error() string {
+	messages := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		messages = append(messages, issue.String())
+	}
+	return "invalid iPXE script: " + strings.Join(messages, "; ")
+}
+
+// LintScript checks an iPXE script for the #!ipxe header, unknown commands,
+// goto targets without a label, and lines over MaxScriptLineLength. It
+// returns nil for a clean script.
+func LintScript(script string) error {
+	var issues []LintIssue
+	lines := strings.Split(script, "\n")
+	if strings.TrimSpace(lines[0]) != "#!ipxe" {
+		issues = append(issues, LintIssue{Line: 1, Message: "script does not start with #!ipxe"})
+	}
+
+	labels := map[string]bool{}
+	type jump struct {
+		line   int
+		target string
+	}
+	var jumps []jump
+	for i, line := range lines {
+		number := i + 1
+		if len(line) > MaxScriptLineLength {
+			issues = append(issues, LintIssue{Line: number, Message: fmt.Sprintf("line is %d bytes, over the %d byte limit", len(line), MaxScriptLineLength)})
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if label, ok := strings.CutPrefix(line, ":"); ok {
+			labels[strings.TrimSpace(label)] = true
+			continue
+		}
+		for _, command := range splitCommands(line) {
+			fields := strings.Fields(command)
+			if len(fields) == 0 {
+				continue
+			}
+			name := fields[0]
+			// A command held in a setting cannot be checked
+			if strings.Contains(name, "${") {
+				continue
+			}
+			if !ipxeCommands[name] {
+				issues = append(issues, LintIssue{Line: number, Message: fmt.Sprintf("unknown command %q", name)})
+				continue
+			}
+			if name == "goto" && len(fields) > 1 && !strings.Contains(fields[1], "${") {
+				jumps = append(jumps, jump{line: number, target: fields[1]})
+			}
+		}
+	}
+	for _, j := range jumps {
+		if !labels[j.target] {
+			issues = append(issues, LintIssue{Line: j.line, Message: fmt.Sprintf("goto %s has no matching :%s label", j.target, j.target)})
+		}
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+	return &LintError{Issues: issues}
+}
+
+// splitCommands splits a script line at the || and && operators
+func splitCommands(line string) []string {
+	var commands []string
+	for _, part := range strings.Split(line, "||") {
+		commands = append(commands, strings.Split(part, "&&")...)
+	}
+	return commands
+}
+
+// lintFailed serves the fallback script instead of a script that failed
+// linting, so the node retries rather than running a broken script
+func (c *BootScriptController) lintFailed(identifier string, err error, node *apiv1.Node, config *apiv1.BootConfiguration) renderResult {
+	c.lintFailures.Add(1)
+	return c.fallback(identifier, err, node, config)
+}
+
+// LintFailures returns how many generated boot scripts failed linting and
+// were replaced by the fallback script
+func (c *BootScriptController) LintFailures() uint64 {
+	return c.lintFailures.Load()
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func TestLintScript(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{"clean", "#!ipxe\ndhcp\n:retry\nchain http://x/boot.ipxe || goto retry\n${next} now\n", nil},
+		{"header", "dhcp\n", []string{"line 1: script does not start with #!ipxe"}},
+		{"unknown command", "#!ipxe\ndhcp && halt\n", []string{`line 2: unknown command "halt"`}},
+		{"missing label", "#!ipxe\ngoto failed\n", []string{"line 2: goto failed has no matching :failed label"}},
+		{"long line", "#!ipxe\nset params " + strings.Repeat("a", MaxScriptLineLength) + "\n", []string{"line 2: line is 4107 bytes, over the 4096 byte limit"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := LintScript(tt.script)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("LintScript returned %v", err)
+				}
+				return
+			}
+			var lintErr *LintError
+			if !errors.As(err, &lintErr) {
+				t.Fatalf("LintScript returned %v, want a *LintError", err)
+			}
+			var got []string
+			for _, issue := range lintErr.Issues {
+				got = append(got, issue.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("issues =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestLintFailureServesFallback(t *testing.T) {
+	nodes := []apiv1.Node{{Metadata: resource.Metadata{UID: "nod-1"}, Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1}}}
+	configs := []apiv1.BootConfiguration{{
+		Metadata: resource.Metadata{Name: "huge", UID: "bc-1"},
+		Spec: apiv1.BootConfigurationSpec{
+			Kernel: "http://files.example.com/vmlinuz",
+			Params: strings.TrimSpace(strings.Repeat("option=value ", 400)),
+		},
+	}}
+	controller := newTestControllerWithData(t, nodes, configs)
+
+	script, err := controller.GenerateBootScript(context.Background(), "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "Fallback iPXE Boot Script") {
+		t.Errorf("expected the fallback script, got:\n%.200s", script)
+	}
+	if got := controller.LintFailures(); got != 1 {
+		t.Errorf("LintFailures = %d, want 1", got)
+	}
+	if stats := controller.cache.Stats(); stats.TotalEntries != 0 {
+		t.Errorf("cache entries = %d, want the rejected script not cached", stats.TotalEntries)
+	}
+
+	preview, err := controller.PreviewBootScript(context.Background(), "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("PreviewBootScript returned error: %v", err)
+	}
+	if preview.Template != TemplateFallback || !strings.Contains(preview.Reason, "over the 4096 byte limit") {
+		t.Errorf("preview = %s: %s", preview.Template, preview.Reason)
+	}
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"strings"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// Maintenance actions for held nodes
+const (
+	// MaintenanceHold serves the hold script, which waits and reboots
+	MaintenanceHold = "hold"
+	// MaintenanceLocal serves a script that boots from local disk
+	MaintenanceLocal = "local"
+)
+
+// Maintenance decides which nodes maintenance mode holds
+type Maintenance interface {
+	// Hold returns the action (MaintenanceHold or MaintenanceLocal) and
+	// reason for a held node, or held false when it boots normally
+	Hold(node *apiv1.Node) (action, reason string, held bool)
+	// Revision changes whenever maintenance mode is switched
+	Revision() uint64
+}
+
+// SetMaintenance serves nodes held by maintenance in place of their
+// configuration. holdScript replaces HoldIPXETemplate when set; it may use
+// the same {{.Identifier}} and {{.Reason}} placeholders. Cached scripts are
+// keyed by the maintenance revision, so switching takes effect at once.
+func (c *BootScriptController) SetMaintenance(maintenance Maintenance, holdScript string) {
+	c.maintenance = maintenance
+	c.holdScript = holdScript
+}
+
+// maintenanceHold returns the script for a node held by maintenance mode
+func (c *BootScriptController) maintenanceHold(identifier string, node *apiv1.Node) (renderResult, bool) {
+	if c.maintenance == nil {
+		return renderResult{}, false
+	}
+	action, reason, held := c.maintenance.Hold(node)
+	if !held {
+		return renderResult{}, false
+	}
+	if reason == "" {
+		reason = "no reason given"
+	}
+	// Keep the reason on the echo line it is rendered into
+	reason = strings.Join(strings.Fields(reason), " ")
+
+	script, template := c.holdScript, TemplateHold
+	if action == MaintenanceLocal {
+		script, template = LocalBootIPXETemplate, TemplateLocal
+	} else if script == "" {
+		script = HoldIPXETemplate
+	}
+	script = strings.ReplaceAll(script, "{{.Identifier}}", identifier)
+	script = strings.ReplaceAll(script, "{{.Reason}}", reason)
+	return renderResult{script: script, template: template, reason: "maintenance mode: " + reason, node: node}, true
+}
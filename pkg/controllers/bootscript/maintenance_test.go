@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// testMaintenance holds nodes in group, or none when group is empty
+type testMaintenance struct {
+	group    string
+	action   string
+	revision uint64
+}
+
+func (m *testMaintenance) Hold(node *apiv1.Node) (string, string, bool) {
+	if m.group == "" || !slices.Contains(node.Spec.Groups, m.group) {
+		return "", "", false
+	}
+	return m.action, "storage\noutage", true
+}
+
+func (m *testMaintenance) Revision() uint64 { return m.revision }
+
+func newMaintenanceTestController(t *testing.T) *BootScriptController {
+	t.Helper()
+
+	nodes := []apiv1.Node{
+		{
+			Metadata: resource.Metadata{UID: "nod-1"},
+			Spec:     apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:01", Groups: []string{"compute"}},
+		},
+		{
+			Metadata: resource.Metadata{UID: "nod-2"},
+			Spec:     apiv1.NodeSpec{XName: "x0c0s1b0n0", NID: 2, BootMAC: "aa:bb:cc:dd:ee:02", Groups: []string{"io"}},
+		},
+	}
+	configs := []apiv1.BootConfiguration{{
+		Metadata: resource.Metadata{Name: "all", UID: "bc-1"},
+		Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute", "io"}, Kernel: "http://files.example.com/vmlinuz"},
+	}}
+	return newTestControllerWithData(t, nodes, configs)
+}
+
+func TestMaintenanceHoldsSelectedNodes(t *testing.T) {
+	controller := newMaintenanceTestController(t)
+	maintenance := &testMaintenance{}
+	controller.SetMaintenance(maintenance, "")
+	ctx := context.Background()
+
+	// Cached before maintenance mode is switched on
+	script, err := controller.GenerateBootScript(ctx, "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "vmlinuz") {
+		t.Fatalf("expected the configured kernel, got:\n%s", script)
+	}
+
+	maintenance.group, maintenance.action, maintenance.revision = "compute", MaintenanceHold, 1
+	script, err = controller.GenerateBootScript(ctx, "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "sleep 60") || !strings.Contains(script, "maintenance mode: storage outage") {
+		t.Errorf("expected the hold script with the reason on one line, got:\n%s", script)
+	}
+	if stats := controller.cache.Stats(); stats.TotalEntries != 1 {
+		t.Errorf("cache entries = %d, want only the script cached before maintenance", stats.TotalEntries)
+	}
+
+	script, err = controller.GenerateBootScript(ctx, "x0c0s1b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "vmlinuz") {
+		t.Errorf("expected node outside the held group to boot normally, got:\n%s", script)
+	}
+
+	maintenance.action, maintenance.revision = MaintenanceLocal, 2
+	script, err = controller.GenerateBootScript(ctx, "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "\nexit") {
+		t.Errorf("expected the local boot script, got:\n%s", script)
+	}
+
+	maintenance.group, maintenance.revision = "", 3
+	script, err = controller.GenerateBootScript(ctx, "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "vmlinuz") {
+		t.Errorf("expected the configured kernel after maintenance, got:\n%s", script)
+	}
+}
+
+func TestMaintenanceCustomHoldScript(t *testing.T) {
+	controller := newMaintenanceTestController(t)
+	controller.SetMaintenance(&testMaintenance{group: "compute", action: MaintenanceHold, revision: 1}, "#!ipxe\necho {{.Identifier}} held: {{.Reason}}\nshell\n")
+
+	script, err := controller.GenerateBootScript(context.Background(), "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if want := "echo x0c0s0b0n0 held: storage outage\n"; !strings.Contains(script, want) {
+		t.Errorf("expected %q in the custom hold script, got:\n%s", want, script)
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// NodeMatches lists the configurations that currently match a node
+type NodeMatches struct {
+	NodeUID   string        `json:"nodeUID"`
+	NodeXName string        `json:"nodeXName"`
+	Matches   []ConfigMatch `json:"matches"`
+}
+
+// ConfigurationMatches lists the nodes a configuration currently matches
+type ConfigurationMatches struct {
+	ConfigurationUID  string      `json:"configurationUID"`
+	ConfigurationName string      `json:"configurationName"`
+	Profile           string      `json:"profile"`
+	Matches           []NodeMatch `json:"matches"`
+}
+
+// NodeMatch explains how a configuration scored against a node
+type NodeMatch struct {
+	UID       string           `json:"uid"`
+	XName     string           `json:"xname"`
+	Score     int              `json:"score"`
+	Breakdown []ScoreComponent `json:"breakdown"`
+	// Selected is true when the node boots this configuration today
+	Selected bool `json:"selected"`
+	// SelectedConfiguration is the configuration the node boots today
+	SelectedConfiguration string `json:"selectedConfiguration,omitempty"`
+}
+
+// MatchingConfigurations returns the configurations that match a node,
+// sorted in selection order. The node may be identified by UID, xname, NID,
+// any interface MAC, hostname, or alias.
+func (c *BootScriptController) MatchingConfigurations(ctx context.Context, identifier string) (*NodeMatches, error) {
+	node, err := c.resolveNode(ctx, c.parseNodeIdentifier(identifier))
+	if err != nil {
+		return nil, err
+	}
+
+	configs, err := c.client.GetBootConfigurations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting boot configurations: %w", err)
+	}
+
+	selected, _ := c.selectConfiguration(configs, node, "")
+
+	result := &NodeMatches{
+		NodeUID:   node.Metadata.UID,
+		NodeXName: node.Spec.XName,
+		Matches:   []ConfigMatch{},
+	}
+	for _, match := range c.explainMatches(configs, node, selected, time.Now()) {
+		if match.Score > 0 {
+			result.Matches = append(result.Matches, match)
+		}
+	}
+
+	return result, nil
+}
+
+// ConfigurationMatches returns the nodes a configuration matches, highest
+// score first. The configuration may be identified by UID or name.
+func (c *BootScriptController) ConfigurationMatches(ctx context.Context, id string) (*ConfigurationMatches, error) {
+	configs, err := c.client.GetBootConfigurations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting boot configurations: %w", err)
+	}
+
+	var config *apiv1.BootConfiguration
+	for i := range configs {
+		if configs[i].Metadata.UID == id || configs[i].Metadata.Name == id {
+			config = &configs[i]
+			break
+		}
+	}
+	if config == nil {
+		return nil, fmt.Errorf("%w: %s", ErrConfigurationNotFound, id)
+	}
+
+	nodes, err := c.client.GetNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting nodes: %w", err)
+	}
+
+	result := &ConfigurationMatches{
+		ConfigurationUID:  config.Metadata.UID,
+		ConfigurationName: config.Metadata.Name,
+		Profile:           config.Spec.Profile,
+		Matches:           []NodeMatch{},
+	}
+	if result.Profile == "" {
+		result.Profile = "default"
+	}
+
+	for i := range nodes {
+		node := &nodes[i]
+		breakdown := c.scoreBreakdown(config, node)
+		if len(breakdown) == 0 {
+			continue
+		}
+
+		match := NodeMatch{
+			UID:       node.Metadata.UID,
+			XName:     node.Spec.XName,
+			Breakdown: breakdown,
+		}
+		for _, component := range breakdown {
+			match.Score += component.Points
+		}
+		if selected, err := c.selectConfiguration(configs, node, ""); err == nil {
+			match.Selected = selected.Metadata.UID == config.Metadata.UID
+			match.SelectedConfiguration = selected.Metadata.Name
+		}
+		result.Matches = append(result.Matches, match)
+	}
+
+	sort.SliceStable(result.Matches, func(i, j int) bool {
+		if result.Matches[i].Score != result.Matches[j].Score {
+			return result.Matches[i].Score > result.Matches[j].Score
+		}
+		return result.Matches[i].XName < result.Matches[j].XName
+	})
+
+	return result, nil
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func newMatchTestController(t *testing.T) *BootScriptController {
+	t.Helper()
+
+	nodes := []apiv1.Node{
+		{
+			Metadata: resource.Metadata{UID: "nod-1"},
+			Spec:     apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:01", Groups: []string{"compute"}},
+		},
+		{
+			Metadata: resource.Metadata{UID: "nod-2"},
+			Spec:     apiv1.NodeSpec{XName: "x0c0s1b0n0", NID: 2, BootMAC: "aa:bb:cc:dd:ee:02", Groups: []string{"compute", "gpu"}},
+		},
+		{
+			Metadata: resource.Metadata{UID: "nod-3"},
+			Spec:     apiv1.NodeSpec{XName: "x0c0s2b0n0", NID: 3, BootMAC: "aa:bb:cc:dd:ee:03", Groups: []string{"io"}},
+		},
+	}
+
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "compute", UID: "bc-1"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz"},
+		},
+		{
+			Metadata: resource.Metadata{Name: "gpu", UID: "bc-2"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"gpu"}, NIDs: []int32{2}, Kernel: "http://files.example.com/vmlinuz-gpu"},
+		},
+	}
+
+	return newTestControllerWithData(t, nodes, configs)
+}
+
+func TestConfigurationMatches(t *testing.T) {
+	controller := newMatchTestController(t)
+
+	result, err := controller.ConfigurationMatches(context.Background(), "compute")
+	if err != nil {
+		t.Fatalf("ConfigurationMatches returned error: %v", err)
+	}
+
+	if result.ConfigurationUID != "bc-1" || result.Profile != "default" {
+		t.Errorf("unexpected configuration header: %+v", result)
+	}
+	if len(result.Matches) != 2 {
+		t.Fatalf("got %d matches, want 2: %+v", len(result.Matches), result.Matches)
+	}
+
+	byXName := map[string]NodeMatch{}
+	for _, match := range result.Matches {
+		byXName[match.XName] = match
+	}
+	if match := byXName["x0c0s0b0n0"]; !match.Selected || match.Score != 25 {
+		t.Errorf("x0c0s0b0n0 match = %+v, want selected with score 25", match)
+	}
+	// x0c0s1b0n0 matches compute but boots gpu, which scores higher
+	if match := byXName["x0c0s1b0n0"]; match.Selected || match.SelectedConfiguration != "gpu" {
+		t.Errorf("x0c0s1b0n0 match = %+v, want not selected, booting gpu", match)
+	}
+
+	if _, err := controller.ConfigurationMatches(context.Background(), "missing"); !errors.Is(err, ErrConfigurationNotFound) {
+		t.Errorf("expected ErrConfigurationNotFound, got %v", err)
+	}
+}
+
+func TestMatchingConfigurations(t *testing.T) {
+	controller := newMatchTestController(t)
+
+	result, err := controller.MatchingConfigurations(context.Background(), "nod-2")
+	if err != nil {
+		t.Fatalf("MatchingConfigurations returned error: %v", err)
+	}
+
+	if result.NodeXName != "x0c0s1b0n0" || len(result.Matches) != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if first := result.Matches[0]; first.Name != "gpu" || !first.Selected || first.Score != 100 {
+		t.Errorf("first match = %+v, want selected gpu with score 100", first)
+	}
+
+	result, err = controller.MatchingConfigurations(context.Background(), "x0c0s2b0n0")
+	if err != nil {
+		t.Fatalf("MatchingConfigurations returned error: %v", err)
+	}
+	if len(result.Matches) != 0 {
+		t.Errorf("got %d matches for unmatched node, want 0", len(result.Matches))
+	}
+
+	if _, err := controller.MatchingConfigurations(context.Background(), "x9c0s0b0n0"); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("expected ErrNodeNotFound, got %v", err)
+	}
+}
+
+func TestSelectConfiguration_TenantScoped(t *testing.T) {
+	controller := NewBootScriptControllerWithReader(&StaticResources{}, nil)
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "red-compute", UID: "bc-1"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Priority: 10, Tenant: "red", Kernel: "http://files.example.com/red"},
+		},
+		{
+			Metadata: resource.Metadata{Name: "site-compute", UID: "bc-2"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/site"},
+		},
+	}
+
+	tests := []struct {
+		tenant string
+		want   string
+	}{
+		{tenant: "red", want: "red-compute"},
+		{tenant: "blue", want: "site-compute"},
+		{tenant: "", want: "site-compute"},
+	}
+	for _, tt := range tests {
+		node := &apiv1.Node{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", Groups: []string{"compute"}, Tenant: tt.tenant}}
+		config, err := controller.selectConfiguration(configs, node, "")
+		if err != nil {
+			t.Fatalf("tenant %q: selectConfiguration returned error: %v", tt.tenant, err)
+		}
+		if config.Metadata.Name != tt.want {
+			t.Errorf("tenant %q: selected %s, want %s", tt.tenant, config.Metadata.Name, tt.want)
+		}
+	}
+}
+
+func TestScoreBreakdownRanges(t *testing.T) {
+	controller := newMatchTestController(t)
+	node := &apiv1.Node{Spec: apiv1.NodeSpec{XName: "x1000c0s5b0n2", NID: 1500, Hostname: "nid001500"}}
+
+	tests := []struct {
+		name string
+		spec apiv1.BootConfigurationSpec
+		want int
+	}{
+		{"xname hostlist", apiv1.BootConfigurationSpec{Hosts: []string{"x1000c0s[0-7]b0n[0-3]"}}, 50},
+		{"hostname hostlist", apiv1.BootConfigurationSpec{Hosts: []string{"nid00[1000-1999]"}}, 50},
+		{"hostlist miss", apiv1.BootConfigurationSpec{Hosts: []string{"x1000c0s[0-4]b0n[0-3]"}}, 0},
+		{"nid range", apiv1.BootConfigurationSpec{NIDRanges: []string{"1000-1999"}}, 75},
+		{"nid list", apiv1.BootConfigurationSpec{NIDRanges: []string{"1,1500,2000-2999"}}, 75},
+		{"nid range miss", apiv1.BootConfigurationSpec{NIDRanges: []string{"2000-2999"}}, 0},
+	}
+	for _, tt := range tests {
+		config := &apiv1.BootConfiguration{Spec: tt.spec}
+		if got := controller.calculateConfigScore(config, node); got != tt.want {
+			t.Errorf("%s: score = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"fmt"
+	"sort"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// ConfigOverlap reports two configurations in the same profile and with the
+// same priority that share a target. A node matched only through that target
+// scores the same against both, so which one it boots is ambiguous.
+type ConfigOverlap struct {
+	First    string `json:"first"`
+	Second   string `json:"second"`
+	Profile  string `json:"profile"`
+	Priority int    `json:"priority"`
+	Target   string `json:"target"` // e.g. "group compute", or "default" for catch-alls
+}
+
+// FindOverlaps returns the overlapping target pairs among configs
+func FindOverlaps(configs []apiv1.BootConfiguration) []ConfigOverlap {
+	type bucket struct {
+		profile  string
+		priority int
+		target   string
+	}
+	owners := map[bucket][]string{}
+
+	for i := range configs {
+		config := &configs[i]
+		profile := config.Spec.Profile
+		if profile == "" {
+			profile = "default"
+		}
+		for _, target := range configTargets(config) {
+			key := bucket{profile: profile, priority: config.Spec.Priority, target: target}
+			owners[key] = append(owners[key], configDisplayName(config))
+		}
+	}
+
+	var overlaps []ConfigOverlap
+	for key, names := range owners {
+		for i := 0; i < len(names); i++ {
+			for j := i + 1; j < len(names); j++ {
+				overlaps = append(overlaps, ConfigOverlap{
+					First:    names[i],
+					Second:   names[j],
+					Profile:  key.profile,
+					Priority: key.priority,
+					Target:   key.target,
+				})
+			}
+		}
+	}
+
+	sort.Slice(overlaps, func(i, j int) bool {
+		a, b := overlaps[i], overlaps[j]
+		if a.First != b.First {
+			return a.First < b.First
+		}
+		if a.Second != b.Second {
+			return a.Second < b.Second
+		}
+		return a.Target < b.Target
+	})
+	return overlaps
+}
+
+// configTargets lists the exact targets of a configuration, or "default" for
+// a configuration without targeting criteria
+func configTargets(config *apiv1.BootConfiguration) []string {
+	var targets []string
+	for _, host := range config.Spec.Hosts {
+		targets = append(targets, "host "+host)
+	}
+	for _, mac := range config.Spec.MACs {
+		targets = append(targets, "mac "+validation.NormalizeMAC(mac))
+	}
+	for _, nid := range config.Spec.NIDs {
+		targets = append(targets, fmt.Sprintf("nid %d", nid))
+	}
+	for _, nids := range config.Spec.NIDRanges {
+		targets = append(targets, "nids "+nids)
+	}
+	for _, group := range config.Spec.Groups {
+		targets = append(targets, "group "+group)
+	}
+	if len(targets) == 0 {
+		targets = append(targets, "default")
+	}
+	return targets
+}
+
+func configDisplayName(config *apiv1.BootConfiguration) string {
+	if config.Metadata.Name != "" {
+		return config.Metadata.Name
+	}
+	return config.Metadata.UID
+}
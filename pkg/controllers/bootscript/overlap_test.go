@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"reflect"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func TestFindOverlaps(t *testing.T) {
+	config := func(name, profile string, priority int, spec apiv1.BootConfigurationSpec) apiv1.BootConfiguration {
+		spec.Profile = profile
+		spec.Priority = priority
+		return apiv1.BootConfiguration{Metadata: resource.Metadata{Name: name}, Spec: spec}
+	}
+
+	configs := []apiv1.BootConfiguration{
+		config("compute", "", 0, apiv1.BootConfigurationSpec{Groups: []string{"compute"}, MACs: []string{"AA:BB:CC:DD:EE:01"}}),
+		config("compute-new", "default", 0, apiv1.BootConfigurationSpec{Groups: []string{"compute"}, MACs: []string{"aa:bb:cc:dd:ee:01"}}),
+		// Higher priority breaks the tie
+		config("compute-pinned", "", 10, apiv1.BootConfigurationSpec{Groups: []string{"compute"}}),
+		// Other profiles never compete
+		config("compute-debug", "debug", 0, apiv1.BootConfigurationSpec{Groups: []string{"compute"}}),
+		config("fallback-a", "", 0, apiv1.BootConfigurationSpec{}),
+		config("fallback-b", "", 0, apiv1.BootConfigurationSpec{}),
+	}
+
+	want := []ConfigOverlap{
+		{First: "compute", Second: "compute-new", Profile: "default", Priority: 0, Target: "group compute"},
+		{First: "compute", Second: "compute-new", Profile: "default", Priority: 0, Target: "mac aa:bb:cc:dd:ee:01"},
+		{First: "fallback-a", Second: "fallback-b", Profile: "default", Priority: 0, Target: "default"},
+	}
+	if got := FindOverlaps(configs); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindOverlaps =\n%+v\nwant\n%+v", got, want)
+	}
+}
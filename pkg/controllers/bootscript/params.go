@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/templatefuncs"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// expandParams renders node-specific template variables in a kernel
+// parameter string. Parameters without template actions are returned as-is.
+//
+// Available variables: .XName, .NID, .BootMAC, .Role, .SubRole, .Hostname,
+// .Groups, .IP, .IPArgs, .Gateway, .Netmask, .Metadata (node spec metadata),
+// .Labels and .Annotations (resource metadata), and .Request (values
+// reported in the boot script request). Missing map keys render as empty
+// strings.
+//
+// {{secret "name"}} inserts the value of a secret, looked up with env. The
+// templatefuncs library is available as well.
+func expandParams(params string, node *apiv1.Node, env templateEnv) (string, error) {
+	return expandNodeTemplate("kernel parameter", params, node, env)
+}
+
+// expandNodeTemplate renders the kernel parameter template variables in text,
+// which is described by kind in errors
+func expandNodeTemplate(kind, text string, node *apiv1.Node, env templateEnv) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	funcs := templatefuncs.Funcs()
+	funcs["secret"] = func(name string) (string, error) {
+		if env.secret == nil {
+			return "", fmt.Errorf("secret %q is referenced but secrets are not available", name)
+		}
+		return env.secret(name)
+	}
+	tmpl, err := template.New(kind).Option("missingkey=zero").Funcs(funcs).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parsing %s template: %w", kind, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, paramsTemplateData(node, env.request)); err != nil {
+		return "", fmt.Errorf("executing %s template: %w", kind, err)
+	}
+
+	return strings.Join(strings.Fields(buf.String()), " "), nil
+}
+
+// nodeParams expands the matched configuration's parameters for a node and
+// applies the node's overlays. Layers are merged in this order, each
+// replacing the parameters with the same key before it: the configuration's
+// profiles, the configuration's params, the node's profiles, and the node's
+// paramsOverride. The node's paramsAppend is added at the end.
+func nodeParams(config *apiv1.BootConfiguration, node *apiv1.Node, profiles paramProfiles, env templateEnv) (string, error) {
+	params := ""
+	for _, profile := range profiles.config {
+		expanded, err := expandParams(profile, node, env)
+		if err != nil {
+			return "", fmt.Errorf("configuration paramProfiles: %w", err)
+		}
+		params = overrideParams(params, expanded)
+	}
+
+	configParams, err := expandParams(config.Spec.Params, node, env)
+	if err != nil {
+		return "", err
+	}
+	if len(profiles.config) == 0 {
+		params = configParams
+	} else {
+		params = overrideParams(params, configParams)
+	}
+
+	for _, profile := range profiles.node {
+		expanded, err := expandParams(profile, node, env)
+		if err != nil {
+			return "", fmt.Errorf("node paramProfiles: %w", err)
+		}
+		params = overrideParams(params, expanded)
+	}
+
+	if node.Spec.ParamsOverride != "" {
+		override, err := expandParams(node.Spec.ParamsOverride, node, env)
+		if err != nil {
+			return "", fmt.Errorf("node paramsOverride: %w", err)
+		}
+		params = overrideParams(params, override)
+	}
+
+	if node.Spec.ParamsAppend != "" {
+		appendParams, err := expandParams(node.Spec.ParamsAppend, node, env)
+		if err != nil {
+			return "", fmt.Errorf("node paramsAppend: %w", err)
+		}
+		params = strings.TrimSpace(params + " " + appendParams)
+	}
+
+	return params, nil
+}
+
+// overrideParams replaces every parameter in base whose key appears in
+// override. Keys are the text before "=", or the whole token for flags such as
+// "quiet". Overriding parameters take the position of the first replaced
+// parameter; new keys are added at the end.
+func overrideParams(base, override string) string {
+	overrides := make(map[string][]string)
+	var order []string
+	for _, param := range strings.Fields(override) {
+		key := paramKey(param)
+		if _, seen := overrides[key]; !seen {
+			order = append(order, key)
+		}
+		overrides[key] = append(overrides[key], param)
+	}
+
+	result := make([]string, 0, len(order))
+	placed := make(map[string]bool, len(order))
+	for _, param := range strings.Fields(base) {
+		key := paramKey(param)
+		replacement, ok := overrides[key]
+		if !ok {
+			result = append(result, param)
+			continue
+		}
+		if !placed[key] {
+			result = append(result, replacement...)
+			placed[key] = true
+		}
+	}
+	for _, key := range order {
+		if !placed[key] {
+			result = append(result, overrides[key]...)
+		}
+	}
+
+	return strings.Join(result, " ")
+}
+
+func paramKey(param string) string {
+	key, _, _ := strings.Cut(param, "=")
+	return key
+}
+
+// paramsTemplateData builds the variable map exposed to kernel parameter
+// templates from a node and the values its request reported
+func paramsTemplateData(node *apiv1.Node, request map[string]string) map[string]interface{} {
+	ip := ""
+	for _, iface := range node.Spec.Interfaces {
+		if iface.IP == "" {
+			continue
+		}
+		if ip == "" || strings.EqualFold(iface.MAC, node.Spec.BootMAC) {
+			ip = iface.IP
+		}
+	}
+
+	gateway, netmask := "", ""
+	if iface := node.Spec.ManagementInterface(); iface != nil {
+		gateway = iface.Gateway
+		netmask, _ = validation.NormalizeNetmask(iface.Netmask)
+	}
+
+	return map[string]interface{}{
+		"XName":       node.Spec.XName,
+		"NID":         fmt.Sprintf("%d", node.Spec.NID),
+		"BootMAC":     node.Spec.BootMAC,
+		"Role":        node.Spec.Role,
+		"SubRole":     node.Spec.SubRole,
+		"Hostname":    node.Spec.Hostname,
+		"Groups":      strings.Join(node.Spec.Groups, ","),
+		"IP":          ip,
+		"IPArgs":      ipKernelArgs(node),
+		"Gateway":     gateway,
+		"Netmask":     netmask,
+		"Metadata":    stringMapOrEmpty(node.Spec.Metadata),
+		"Labels":      stringMapOrEmpty(node.Metadata.Labels),
+		"Annotations": stringMapOrEmpty(node.Metadata.Annotations),
+		"Request":     stringMapOrEmpty(request),
+	}
+}
+
+// ipKernelArgs generates the ip= kernel argument configuring the node's
+// management interface statically, in the dracut syntax NetworkManager's
+// initrd generator and systemd-network-generator read as well. A tagged
+// interface with a name is configured as a VLAN device, declared with vlan=.
+// It returns "" when no interface has an IPv4 address.
+func ipKernelArgs(node *apiv1.Node) string {
+	iface := node.Spec.ManagementInterface()
+	if iface == nil || !validation.ValidateIPv4(iface.IP) {
+		return ""
+	}
+	netmask, _ := validation.NormalizeNetmask(iface.Netmask)
+	device := iface.Name
+	var args []string
+	if iface.VLAN > 0 && device != "" {
+		vlanDevice := fmt.Sprintf("%s.%d", device, iface.VLAN)
+		args = append(args, "vlan="+vlanDevice+":"+device)
+		device = vlanDevice
+	}
+	args = append(args, fmt.Sprintf("ip=%s::%s:%s:%s:%s:none", iface.IP, iface.Gateway, netmask, node.Spec.Hostname, device))
+	return strings.Join(args, " ")
+}
+
+func stringMapOrEmpty(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
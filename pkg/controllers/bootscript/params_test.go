@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+func TestExpandParams(t *testing.T) {
+	node := &apiv1.Node{
+		Spec: apiv1.NodeSpec{
+			XName:    "x1000c0s0b0n0",
+			NID:      7,
+			BootMAC:  "aa:bb:cc:dd:ee:ff",
+			Hostname: "nid0007",
+			Interfaces: []apiv1.NodeInterface{
+				{MAC: "11:22:33:44:55:66", IP: "10.0.0.99"},
+				{MAC: "AA:BB:CC:DD:EE:FF", IP: "10.0.0.7"},
+			},
+			Metadata: map[string]string{"console": "ttyS1,115200"},
+		},
+	}
+	node.Metadata.Labels = map[string]string{"rack": "r12"}
+
+	tests := []struct {
+		name     string
+		params   string
+		expected string
+	}{
+		{"NoTemplate", "console=ttyS0  quiet", "console=ttyS0  quiet"},
+		{"NodeFields", "hostname={{.Hostname}} xname={{.XName}} nid={{.NID}}", "hostname=nid0007 xname=x1000c0s0b0n0 nid=7"},
+		{"BootInterfaceIP", "ip={{.IP}}::10.0.0.1:255.255.255.0:{{.Hostname}}:eth0:none", "ip=10.0.0.7::10.0.0.1:255.255.255.0:nid0007:eth0:none"},
+		{"NodeMetadata", "console={{.Metadata.console}}", "console=ttyS1,115200"},
+		{"Labels", "rack={{.Labels.rack}}", "rack=r12"},
+		{"MissingKeyIsEmpty", "quiet {{.Metadata.missing}}", "quiet"},
+		{"Conditional", "{{if .Metadata.console}}console={{.Metadata.console}}{{end}}", "console=ttyS1,115200"},
+		{"Functions", `ip={{.IP}}::{{cidrHost 1 (printf "%s/24" .IP)}}:{{cidrNetmask (printf "%s/24" .IP)}} tty={{default "ttyS0" .Metadata.missing}}`, "ip=10.0.0.7::10.0.0.1:255.255.255.0 tty=ttyS0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandParams(tt.params, node, templateEnv{})
+			if err != nil {
+				t.Fatalf("expandParams(%q) returned error: %v", tt.params, err)
+			}
+			if got != tt.expected {
+				t.Errorf("expandParams(%q) = %q, want %q", tt.params, got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := expandParams("console={{.Hostname", node, templateEnv{}); err == nil {
+		t.Error("expected error for malformed template")
+	}
+}
+
+func TestIPKernelArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		interfaces []apiv1.NodeInterface
+		want       string
+	}{
+		{"NoAddress", []apiv1.NodeInterface{{MAC: "aa:bb:cc:dd:ee:ff"}}, ""},
+		{"BootInterface", []apiv1.NodeInterface{
+			{MAC: "11:22:33:44:55:66", IP: "10.2.0.7", Name: "eth1"},
+			{MAC: "aa:bb:cc:dd:ee:ff", IP: "10.1.0.7", Netmask: "16", Gateway: "10.1.0.1", Name: "eth0"},
+		}, "ip=10.1.0.7::10.1.0.1:255.255.0.0:nid0007:eth0:none"},
+		{"ManagementType", []apiv1.NodeInterface{
+			{MAC: "aa:bb:cc:dd:ee:ff", IP: "10.1.0.7", Name: "eth0"},
+			{MAC: "11:22:33:44:55:66", IP: "10.2.0.7", Netmask: "255.255.255.0", Type: "management", Name: "eno1", VLAN: 100},
+		}, "vlan=eno1.100:eno1 ip=10.2.0.7:::255.255.255.0:nid0007:eno1.100:none"},
+		{"Unnamed", []apiv1.NodeInterface{{MAC: "aa:bb:cc:dd:ee:ff", IP: "10.1.0.7", VLAN: 100}}, "ip=10.1.0.7::::nid0007::none"},
+		{"IPv6", []apiv1.NodeInterface{{MAC: "aa:bb:cc:dd:ee:ff", IP: "fd00::7"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &apiv1.Node{Spec: apiv1.NodeSpec{BootMAC: "aa:bb:cc:dd:ee:ff", Hostname: "nid0007", Interfaces: tt.interfaces}}
+			if got := ipKernelArgs(node); got != tt.want {
+				t.Errorf("ipKernelArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	node := &apiv1.Node{Spec: apiv1.NodeSpec{BootMAC: "aa:bb:cc:dd:ee:ff", Hostname: "nid0007", Interfaces: tests[1].interfaces}}
+	got, err := expandParams("console=ttyS0 {{.IPArgs}} rd.route=0.0.0.0/0:{{.Gateway}} mask={{.Netmask}}", node, templateEnv{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "console=ttyS0 ip=10.1.0.7::10.1.0.1:255.255.0.0:nid0007:eth0:none rd.route=0.0.0.0/0:10.1.0.1 mask=255.255.0.0"; got != want {
+		t.Errorf("expandParams() = %q, want %q", got, want)
+	}
+}
+
+func TestBuildIPXEScript_ExpandsParamsTemplate(t *testing.T) {
+	controller := createTestController(t)
+
+	config := &apiv1.BootConfiguration{
+		Spec: apiv1.BootConfigurationSpec{
+			Kernel: "http://files.example.com/vmlinuz",
+			Params: "console=ttyS0 hostname={{.Hostname}}",
+		},
+	}
+	node := &apiv1.Node{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", Hostname: "compute-001", BootMAC: "aa:bb:cc:dd:ee:ff"}}
+
+	script, err := controller.buildIPXEScript(context.Background(), config, node)
+	if err != nil {
+		t.Fatalf("buildIPXEScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "set params console=ttyS0 hostname=compute-001 BOOTIF=01-aa-bb-cc-dd-ee-ff") {
+		t.Errorf("script does not contain expanded params:\n%s", script)
+	}
+}
+
+func TestNodeParams_Overlays(t *testing.T) {
+	config := &apiv1.BootConfiguration{
+		Spec: apiv1.BootConfigurationSpec{
+			Params: "console=tty0 root=live:http://images/compute.squashfs console=ttyS0,115200 quiet",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		override string
+		append   string
+		expected string
+	}{
+		{"NoOverlay", "", "", "console=tty0 root=live:http://images/compute.squashfs console=ttyS0,115200 quiet"},
+		{"Append", "", "debug loglevel=7", "console=tty0 root=live:http://images/compute.squashfs console=ttyS0,115200 quiet debug loglevel=7"},
+		{"OverrideReplacesAllOccurrences", "console=ttyS1,115200", "", "console=ttyS1,115200 root=live:http://images/compute.squashfs quiet"},
+		{"OverrideAddsNewKeys", "rd.shell", "", "console=tty0 root=live:http://images/compute.squashfs console=ttyS0,115200 quiet rd.shell"},
+		{"OverrideThenAppend", "quiet=0", "{{.XName}}", "console=tty0 root=live:http://images/compute.squashfs console=ttyS0,115200 quiet=0 x0c0s0b0n0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &apiv1.Node{Spec: apiv1.NodeSpec{
+				XName:          "x0c0s0b0n0",
+				ParamsOverride: tt.override,
+				ParamsAppend:   tt.append,
+			}}
+
+			got, err := nodeParams(config, node, paramProfiles{}, templateEnv{})
+			if err != nil {
+				t.Fatalf("nodeParams returned error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("nodeParams = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+type staticProfiles map[string]string
+
+func (p staticProfiles) ResolveParamProfiles(_ context.Context, names []string) ([]string, error) {
+	params := make([]string, 0, len(names))
+	for _, name := range names {
+		params = append(params, p[name])
+	}
+	return params, nil
+}
+
+func TestRenderParams_ProfileMergeOrder(t *testing.T) {
+	controller := createTestController(t)
+	config := &apiv1.BootConfiguration{Spec: apiv1.BootConfigurationSpec{
+		Params:        "console=tty0 quiet",
+		ParamProfiles: []string{"serial", "hugepages"},
+	}}
+	node := &apiv1.Node{Spec: apiv1.NodeSpec{
+		XName:          "x0c0s0b0n0",
+		ParamProfiles:  []string{"debug"},
+		ParamsOverride: "loglevel=3",
+	}}
+
+	if _, err := controller.renderParams(context.Background(), config, node); err == nil {
+		t.Fatal("renderParams succeeded without a profile resolver")
+	}
+
+	controller.SetParamProfileResolver(staticProfiles{
+		"serial":    "console=ttyS0,115200 earlyprintk=ttyS0",
+		"hugepages": "hugepages=1024",
+		"debug":     "loglevel=7 quiet=0 nodename={{.XName}}",
+	})
+	got, err := controller.renderParams(context.Background(), config, node)
+	if err != nil {
+		t.Fatalf("renderParams returned error: %v", err)
+	}
+	// The configuration's params replace its profiles' console; the node's
+	// profile and override come after
+	want := "console=tty0 earlyprintk=ttyS0 hugepages=1024 quiet=0 loglevel=3 nodename=x0c0s0b0n0"
+	if got != want {
+		t.Errorf("renderParams = %q, want %q", got, want)
+	}
+}
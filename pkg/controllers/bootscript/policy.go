@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// ErrBootDenied is returned when the boot policy refuses a configuration
+var ErrBootDenied = errors.New("boot denied by policy")
+
+// BootPolicy decides whether a node may boot a configuration. It returns the
+// reasons for a denial, or none to allow the boot.
+type BootPolicy interface {
+	BootDenials(ctx context.Context, node *apiv1.Node, config *apiv1.BootConfiguration, profile string) ([]string, error)
+}
+
+// SetBootPolicy makes every rendered script subject to policy. Scripts are
+// cached after the policy allowed them, so a changed decision applies once
+// the entry is invalidated or expires.
+func (c *BootScriptController) SetBootPolicy(policy BootPolicy) {
+	c.policy = policy
+}
+
+// checkBootPolicy returns ErrBootDenied with the policy's reasons when it
+// denies the boot, or the error that kept it from deciding
+func (c *BootScriptController) checkBootPolicy(ctx context.Context, node *apiv1.Node, config *apiv1.BootConfiguration, profile string) error {
+	if c.policy == nil {
+		return nil
+	}
+	denials, err := c.policy.BootDenials(ctx, node, config, profile)
+	if err != nil {
+		return err
+	}
+	if len(denials) > 0 {
+		return fmt.Errorf("%w: %s", ErrBootDenied, strings.Join(denials, "; "))
+	}
+	return nil
+}
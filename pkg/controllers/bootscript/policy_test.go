@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// stubPolicy denies configurations whose kernel does not come from an
+// approved server
+type stubPolicy struct {
+	err error
+}
+
+func (p stubPolicy) BootDenials(_ context.Context, _ *apiv1.Node, config *apiv1.BootConfiguration, _ string) ([]string, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if !strings.HasPrefix(config.Spec.Kernel, "http://approved.example.com/") {
+		return []string{"kernel is not from an approved server"}, nil
+	}
+	return nil, nil
+}
+
+func TestBootPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       stubPolicy
+		wantTemplate string
+		wantScript   string
+	}{
+		{
+			name:         "denied",
+			wantTemplate: TemplateError,
+			wantScript:   "kernel is not from an approved server",
+		},
+		{
+			name:         "unavailable",
+			policy:       stubPolicy{err: errors.New("connection refused")},
+			wantTemplate: TemplateFallback,
+			wantScript:   "reboot",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewBootScriptControllerWithReader(prewarmResources(), log.New(io.Discard, "", 0))
+			controller.SetBootPolicy(tt.policy)
+
+			rendered := controller.render(context.Background(), "x1000c0s0b0n0", "")
+			if rendered.template != tt.wantTemplate || !strings.Contains(rendered.script, tt.wantScript) {
+				t.Errorf("render() = %s template:\n%s", rendered.template, rendered.script)
+			}
+			if _, err := controller.GenerateBootScript(context.Background(), "x1000c0s0b0n0", ""); err != nil {
+				t.Fatalf("GenerateBootScript failed: %v", err)
+			}
+			if stats := controller.CacheStats(); stats.TotalEntries != 0 {
+				t.Errorf("refused script was cached: %+v", stats)
+			}
+			if warmed, err := controller.Prewarm(context.Background()); err != nil || warmed != 0 {
+				t.Errorf("Prewarm() = %d, %v; want no scripts warmed", warmed, err)
+			}
+		})
+	}
+}
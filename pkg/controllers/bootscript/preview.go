@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// BootScriptPreview is the result of a dry-run boot script generation. It
+// explains how the script was chosen in addition to the script itself.
+type BootScriptPreview struct { //nolint:revive
+	Identifier string `json:"identifier"`
+	Profile    string `json:"profile,omitempty"`
+	NodeXName  string `json:"nodeXName,omitempty"`
+	NodeUID    string `json:"nodeUID,omitempty"`
+
+	// Template is the script template used: default, minimal, error,
+	// fallback, hold, local, or chain
+	Template string `json:"template"`
+	// Reason explains why a template other than default was used, or which
+	// fallback rule chose the configuration
+	Reason string `json:"reason,omitempty"`
+
+	MatchedConfiguration string `json:"matchedConfiguration,omitempty"`
+	Kernel               string `json:"kernel,omitempty"`
+	Initrd               string `json:"initrd,omitempty"`
+	Params               string `json:"params,omitempty"`
+	// ChainURL is the URL the node is chained to by its own or its
+	// configuration's chainURL
+	ChainURL string `json:"chainURL,omitempty"`
+
+	// Candidates lists every configuration in selection order
+	Candidates []ConfigMatch `json:"candidates"`
+
+	Script string `json:"script"`
+}
+
+// ConfigMatch explains how a configuration scored against a node
+type ConfigMatch struct {
+	Name      string           `json:"name"`
+	UID       string           `json:"uid"`
+	Profile   string           `json:"profile"`
+	Priority  int              `json:"priority"`
+	Score     int              `json:"score"`
+	Breakdown []ScoreComponent `json:"breakdown"`
+	Selected  bool             `json:"selected"`
+	// Inactive is true when the configuration's schedule keeps it from
+	// being selected at the time evaluated
+	Inactive bool `json:"inactive,omitempty"`
+	// Tied is true when the configuration ties with the best one on score
+	// and priority, so only name order decides between them; strict
+	// scoring refuses to decide
+	Tied bool `json:"tied,omitempty"`
+}
+
+// PreviewBootScript generates the boot script for a node without reading or
+// writing the script cache, and explains which configuration matched. With
+// WithEvaluationTime on ctx it previews the script for that time.
+func (c *BootScriptController) PreviewBootScript(ctx context.Context, identifier, profile string) (*BootScriptPreview, error) {
+	ctx = withRedactedSecrets(ctx)
+	result := c.render(ctx, identifier, profile)
+
+	preview := &BootScriptPreview{
+		Identifier: identifier,
+		Profile:    profile,
+		Template:   result.template,
+		Reason:     result.reason,
+		Candidates: []ConfigMatch{},
+		Script:     result.script,
+	}
+
+	if result.config != nil {
+		preview.MatchedConfiguration = result.config.Metadata.Name
+		preview.Kernel = result.config.Spec.Kernel
+		preview.Initrd = result.config.Spec.Initrd
+	}
+
+	if result.node == nil {
+		return preview, nil
+	}
+	preview.NodeXName = result.node.Spec.XName
+	preview.NodeUID = result.node.Metadata.UID
+
+	chainURL := ""
+	switch result.template {
+	case TemplateChain:
+		chainURL = result.node.Spec.ChainURL
+	case TemplateDefault:
+		chainURL = result.config.Spec.ChainURL
+	}
+	if chainURL != "" {
+		expanded, err := c.expandChainURL(ctx, chainURL, result.node)
+		if err != nil {
+			return nil, err
+		}
+		preview.ChainURL = expanded
+	} else if result.template == TemplateDefault {
+		params, err := c.renderParams(ctx, result.config, result.node)
+		if err != nil {
+			return nil, err
+		}
+		preview.Params = buildParams(params, result.node.Spec.BootMAC)
+	}
+
+	configs, err := c.client.GetBootConfigurations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting boot configurations: %w", err)
+	}
+	at, _ := evaluationTime(ctx)
+	preview.Candidates = c.explainMatches(configs, result.node, result.config, at)
+
+	return preview, nil
+}
+
+// explainMatches scores every configuration against node, sorted the same way
+// findBootConfiguration ranks candidates, with configurations inactive at
+// the given time last
+func (c *BootScriptController) explainMatches(configs []apiv1.BootConfiguration, node *apiv1.Node, selected *apiv1.BootConfiguration, at time.Time) []ConfigMatch {
+	matches := make([]ConfigMatch, 0, len(configs))
+	for i := range configs {
+		config := &configs[i]
+		breakdown := c.scoreBreakdown(config, node)
+
+		match := ConfigMatch{
+			Name:      config.Metadata.Name,
+			UID:       config.Metadata.UID,
+			Profile:   config.Spec.Profile,
+			Priority:  config.Spec.Priority,
+			Breakdown: breakdown,
+			Selected:  selected != nil && config.Metadata.UID == selected.Metadata.UID,
+			Inactive:  !c.configActive(config, at),
+		}
+		if match.Profile == "" {
+			match.Profile = "default"
+		}
+		if match.Breakdown == nil {
+			match.Breakdown = []ScoreComponent{}
+		}
+		for _, component := range breakdown {
+			match.Score += component.Points
+		}
+		matches = append(matches, match)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Inactive != matches[j].Inactive {
+			return !matches[i].Inactive
+		}
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if matches[i].Priority != matches[j].Priority {
+			return matches[i].Priority > matches[j].Priority
+		}
+		return matches[i].Name < matches[j].Name
+	})
+	markTies(matches)
+
+	return matches
+}
+
+// markTies flags the matches that tie with the selected match, or with the
+// best active match when none was selected
+func markTies(matches []ConfigMatch) {
+	best := -1
+	for i, match := range matches {
+		if match.Selected {
+			best = i
+			break
+		}
+	}
+	if best < 0 && len(matches) > 0 && !matches[0].Inactive && matches[0].Score > 0 {
+		best = 0
+	}
+	if best < 0 {
+		return
+	}
+
+	var tied []int
+	for i, match := range matches {
+		if !match.Inactive && match.Score == matches[best].Score && match.Priority == matches[best].Priority {
+			tied = append(tied, i)
+		}
+	}
+	if len(tied) < 2 {
+		return
+	}
+	for _, i := range tied {
+		matches[i].Tied = true
+	}
+}
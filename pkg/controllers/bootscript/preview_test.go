@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func TestPreviewBootScript_ExplainsMatch(t *testing.T) {
+	nodes := []apiv1.Node{
+		{
+			Metadata: resource.Metadata{UID: "nod-1"},
+			Spec: apiv1.NodeSpec{
+				XName:   "x0c0s0b0n0",
+				NID:     42,
+				BootMAC: "aa:bb:cc:dd:ee:ff",
+				Groups:  []string{"compute"},
+			},
+		},
+	}
+
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "catch-all", UID: "bc-1"},
+			Spec:     apiv1.BootConfigurationSpec{Kernel: "http://files.example.com/vmlinuz-default"},
+		},
+		{
+			Metadata: resource.Metadata{Name: "compute", UID: "bc-2"},
+			Spec: apiv1.BootConfigurationSpec{
+				Groups: []string{"compute"},
+				NIDs:   []int32{42},
+				Kernel: "http://files.example.com/vmlinuz-compute",
+				Params: "console=ttyS0",
+			},
+		},
+		{
+			Metadata: resource.Metadata{Name: "gpu", UID: "bc-3"},
+			Spec: apiv1.BootConfigurationSpec{
+				Groups: []string{"gpu"},
+				Kernel: "http://files.example.com/vmlinuz-gpu",
+			},
+		},
+	}
+
+	controller := newTestControllerWithData(t, nodes, configs)
+	preview, err := controller.PreviewBootScript(context.Background(), "nod-1", "")
+	if err != nil {
+		t.Fatalf("PreviewBootScript returned error: %v", err)
+	}
+
+	if preview.Template != TemplateDefault || preview.MatchedConfiguration != "compute" {
+		t.Fatalf("template %q, config %q; want default, compute", preview.Template, preview.MatchedConfiguration)
+	}
+	if preview.NodeXName != "x0c0s0b0n0" {
+		t.Errorf("NodeXName = %q, want x0c0s0b0n0", preview.NodeXName)
+	}
+	if preview.Params != "console=ttyS0 BOOTIF=01-aa-bb-cc-dd-ee-ff" {
+		t.Errorf("Params = %q", preview.Params)
+	}
+	if !strings.Contains(preview.Script, "vmlinuz-compute") {
+		t.Errorf("script does not use the compute kernel: %s", preview.Script)
+	}
+
+	if len(preview.Candidates) != 3 {
+		t.Fatalf("got %d candidates, want 3", len(preview.Candidates))
+	}
+	top := preview.Candidates[0]
+	if top.Name != "compute" || !top.Selected || top.Score != 100 || len(top.Breakdown) != 2 {
+		t.Errorf("top candidate = %+v, want selected compute with nid and group matches", top)
+	}
+	if preview.Candidates[1].Name != "catch-all" || preview.Candidates[1].Breakdown[0].Rule != "default" {
+		t.Errorf("second candidate = %+v, want catch-all default match", preview.Candidates[1])
+	}
+	if last := preview.Candidates[2]; last.Name != "gpu" || last.Score != 0 || last.Selected {
+		t.Errorf("last candidate = %+v, want unmatched gpu", last)
+	}
+
+	if stats := controller.cache.Stats(); stats.TotalEntries != 0 {
+		t.Errorf("preview wrote %d cache entries, want 0", stats.TotalEntries)
+	}
+}
+
+func TestPreviewBootScript_UnknownNode(t *testing.T) {
+	controller := newTestControllerWithData(t, nil, nil)
+	preview, err := controller.PreviewBootScript(context.Background(), "x9c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("PreviewBootScript returned error: %v", err)
+	}
+
+	if preview.Template != TemplateError || preview.Reason == "" {
+		t.Errorf("template %q, reason %q; want error template with reason", preview.Template, preview.Reason)
+	}
+	if len(preview.Candidates) != 0 {
+		t.Errorf("got %d candidates for unknown node, want 0", len(preview.Candidates))
+	}
+}
@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/artifacts"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// errPrewarmInterrupted is returned when a resource changed while scripts
+// were being pre-warmed; the scripts rendered so far may be stale
+var errPrewarmInterrupted = errors.New("resources changed during pre-warm")
+
+// Prewarm renders the default boot script of every stored node and caches it
+// under the node's xname and boot MAC, the identifiers nodes request scripts
+// by. Nodes and configurations are loaded once for the whole run. It returns
+// the number of nodes whose scripts were cached.
+func (c *BootScriptController) Prewarm(ctx context.Context) (int, error) {
+	generation := c.generation.Load()
+
+	nodes, err := c.client.GetNodes(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("getting nodes: %w", err)
+	}
+	configs, err := c.client.GetBootConfigurations(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("getting boot configurations: %w", err)
+	}
+	c.trackScheduleTransitions(configs, time.Now())
+
+	// Artifact references are resolved once per configuration
+	resolvedConfigs := make(map[string]*apiv1.BootConfiguration)
+	warmed := 0
+	for i := range nodes {
+		if err := ctx.Err(); err != nil {
+			return warmed, err
+		}
+		node := &nodes[i]
+		if node.Spec.XName == "" {
+			continue
+		}
+		// Held nodes get the uncached maintenance script
+		if _, held := c.maintenanceHold(node.Spec.XName, node); held {
+			continue
+		}
+		// So are cordoned nodes and nodes delegated by their own chainURL
+		if c.cordons != nil {
+			if _, cordoned := c.cordons.Cordoned(node.Spec.XName); cordoned {
+				continue
+			}
+		}
+		if node.Spec.ChainURL != "" {
+			continue
+		}
+		// Nodes with a one-time override and nodes switched to a diagnostic
+		// configuration are rendered on request
+		if c.bootOnce != nil {
+			if _, once := c.bootOnce.Pending(node.Spec.XName); once {
+				continue
+			}
+		}
+		if c.bootLoops != nil {
+			if _, diverted := c.bootLoops.Diagnostic(node.Spec.XName); diverted {
+				continue
+			}
+		}
+		config, err := c.selectConfiguration(configs, node, "")
+		if err != nil {
+			// Nodes without a configuration get the uncached fallback script
+			continue
+		}
+		resolved, ok := resolvedConfigs[config.Metadata.UID]
+		if !ok {
+			resolved, err = c.resolveArtifacts(ctx, config)
+			if err != nil {
+				c.logger.Printf("Pre-warm skipped configuration %s: %v", config.Metadata.Name, err)
+			}
+			resolvedConfigs[config.Metadata.UID] = resolved
+		}
+		if resolved == nil {
+			continue
+		}
+		// Scripts the policy refuses, or cannot decide on, are left to be
+		// rendered on request
+		if err := c.checkBootPolicy(ctx, node, resolved, ""); err != nil {
+			continue
+		}
+		buildCtx, secrets := withSecretUse(ctx)
+		script, err := c.buildIPXEScript(buildCtx, resolved, node)
+		if err != nil {
+			c.logger.Printf("Pre-warm failed for node %s: %v", node.Spec.XName, err)
+			continue
+		}
+		// Scripts with secret values are rendered on request
+		if secrets.resolved.Load() {
+			continue
+		}
+
+		// A write since the run started may have invalidated what was loaded
+		if c.generation.Load() != generation {
+			return warmed, errPrewarmInterrupted
+		}
+		result := renderResult{template: TemplateDefault, node: node, config: resolved}
+		for _, identifier := range append([]string{node.Spec.XName}, node.Spec.MACs()...) {
+			cacheKey := c.generateCacheKey(identifier, "")
+			c.cache.Set(cacheKey, script, node.Spec.XName, resolved.Metadata.Name)
+			c.rememberMatch(cacheKey, identifier, result)
+		}
+		warmed++
+	}
+	return warmed, nil
+}
+
+// Prewarmer keeps the boot script cache warm. It pre-warms once when started
+// and again after nodes, boot configurations, or artifacts change, so the
+// first boot after a rollout is served from the cache.
+type Prewarmer struct {
+	controller *BootScriptController
+	delay      time.Duration
+	trigger    chan struct{}
+	logger     *log.Logger
+}
+
+// NewPrewarmer creates a pre-warm worker for controller. Runs start delay
+// after the last change, so a burst of writes causes a single run.
+func NewPrewarmer(controller *BootScriptController, delay time.Duration, logger *log.Logger) *Prewarmer {
+	if logger == nil {
+		logger = controller.logger
+	}
+	return &Prewarmer{
+		controller: controller,
+		delay:      delay,
+		trigger:    make(chan struct{}, 1),
+		logger:     logger,
+	}
+}
+
+// HandleResourceChange schedules a run after a write that can change boot
+// scripts
+func (p *Prewarmer) HandleResourceChange(ctx context.Context, event resourcewatch.Event) { //nolint:revive
+	switch event.ResourceType {
+	case "Node", "BootConfiguration", artifacts.ResourceType:
+		p.Trigger()
+	}
+}
+
+// Trigger schedules a run
+func (p *Prewarmer) Trigger() {
+	select {
+	case p.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Run pre-warms the cache now and after every change until ctx is done
+func (p *Prewarmer) Run(ctx context.Context) {
+	p.Trigger()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.trigger:
+		}
+
+		// Wait for writes to settle
+		timer := time.NewTimer(p.delay)
+	settle:
+		for {
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-p.trigger:
+				timer.Reset(p.delay)
+			case <-timer.C:
+				break settle
+			}
+		}
+
+		start := time.Now()
+		warmed, err := p.controller.Prewarm(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case errors.Is(err, errPrewarmInterrupted):
+			p.logger.Printf("Boot script pre-warm restarting after %d nodes: %v", warmed, err)
+			p.Trigger()
+		case err != nil:
+			p.logger.Printf("Boot script pre-warm failed after %d nodes: %v", warmed, err)
+		default:
+			p.logger.Printf("Pre-warmed boot scripts for %d nodes in %s", warmed, time.Since(start).Round(time.Millisecond))
+		}
+	}
+}
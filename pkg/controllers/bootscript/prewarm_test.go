@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+func prewarmResources() *StaticResources {
+	return &StaticResources{
+		Nodes: []apiv1.Node{
+			{Spec: apiv1.NodeSpec{XName: "x1000c0s0b0n0", BootMAC: "AA:BB:CC:DD:EE:01", Groups: []string{"compute"}}},
+			{Spec: apiv1.NodeSpec{XName: "x1000c0s1b0n0", BootMAC: "aa:bb:cc:dd:ee:02"}},
+		},
+		BootConfigurations: []apiv1.BootConfiguration{{
+			Metadata: resource.Metadata{Name: "compute", UID: "bc-1"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz"},
+		}},
+	}
+}
+
+func TestPrewarm(t *testing.T) {
+	resources := prewarmResources()
+	controller := NewBootScriptControllerWithReader(resources, log.New(io.Discard, "", 0))
+
+	warmed, err := controller.Prewarm(context.Background())
+	if err != nil {
+		t.Fatalf("Prewarm failed: %v", err)
+	}
+	// The second node matches no configuration and is not cached
+	if warmed != 1 {
+		t.Errorf("warmed %d nodes, want 1", warmed)
+	}
+
+	for _, identifier := range []string{"x1000c0s0b0n0", "aa:bb:cc:dd:ee:01"} {
+		script, found := controller.cache.Get(controller.generateCacheKey(identifier, ""))
+		if !found {
+			t.Errorf("no cached script for %s", identifier)
+			continue
+		}
+		rendered := controller.render(context.Background(), identifier, "")
+		if script != rendered.script || !strings.Contains(script, "vmlinuz") {
+			t.Errorf("cached script for %s differs from rendered script:\n%s", identifier, script)
+		}
+	}
+	if _, found := controller.cache.Get(controller.generateCacheKey("x1000c0s1b0n0", "")); found {
+		t.Error("node without a configuration was cached")
+	}
+}
+
+// changingResources reports a resource change while configurations load
+type changingResources struct {
+	*StaticResources
+	controller *BootScriptController
+}
+
+func (c *changingResources) GetBootConfigurations(ctx context.Context) ([]apiv1.BootConfiguration, error) {
+	c.controller.HandleResourceChange(ctx, resourcewatch.Event{ResourceType: "BootConfiguration"})
+	return c.StaticResources.GetBootConfigurations(ctx)
+}
+
+func TestPrewarm_InterruptedByChange(t *testing.T) {
+	resources := &changingResources{StaticResources: prewarmResources()}
+	controller := NewBootScriptControllerWithReader(resources, log.New(io.Discard, "", 0))
+	resources.controller = controller
+
+	if _, err := controller.Prewarm(context.Background()); !errors.Is(err, errPrewarmInterrupted) {
+		t.Fatalf("Prewarm() error = %v, want %v", err, errPrewarmInterrupted)
+	}
+	if stats := controller.CacheStats(); stats.TotalEntries != 0 {
+		t.Errorf("stale scripts cached: %+v", stats)
+	}
+}
+
+// countingPrewarmResources counts node listings
+type countingPrewarmResources struct {
+	*StaticResources
+	listings atomic.Int32
+}
+
+func (c *countingPrewarmResources) GetNodes(ctx context.Context) ([]apiv1.Node, error) {
+	c.listings.Add(1)
+	return c.StaticResources.GetNodes(ctx)
+}
+
+func TestPrewarmer_RunsOnStartAndAfterChanges(t *testing.T) {
+	resources := &countingPrewarmResources{StaticResources: prewarmResources()}
+	controller := NewBootScriptControllerWithReader(resources, log.New(io.Discard, "", 0))
+	prewarmer := NewPrewarmer(controller, 10*time.Millisecond, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go prewarmer.Run(ctx)
+
+	waitFor := func(what string, done func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !done() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", what)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	waitFor("startup pre-warm", func() bool { return controller.CacheStats().TotalEntries == 2 })
+
+	// A burst of writes causes a single run once it settles
+	for range 5 {
+		event := resourcewatch.Event{ResourceType: "BootConfiguration"}
+		controller.HandleResourceChange(ctx, event)
+		prewarmer.HandleResourceChange(ctx, event)
+	}
+	waitFor("pre-warm after change", func() bool { return controller.CacheStats().TotalEntries == 2 })
+	if got := resources.listings.Load(); got != 2 {
+		t.Errorf("nodes listed %d times, want 2", got)
+	}
+}
+
+func TestGenerateBootScript_NotCachedAfterChange(t *testing.T) {
+	resources := &changingResources{StaticResources: prewarmResources()}
+	controller := NewBootScriptControllerWithReader(resources, log.New(io.Discard, "", 0))
+	resources.controller = controller
+
+	script, err := controller.GenerateBootScript(context.Background(), "x1000c0s0b0n0", "")
+	if err != nil || !strings.Contains(script, "vmlinuz") {
+		t.Fatalf("GenerateBootScript = %q, %v; want the compute script", script, err)
+	}
+	// The configuration changed while the script rendered
+	if stats := controller.CacheStats(); stats.TotalEntries != 0 {
+		t.Errorf("script rendered from stale resources was cached: %+v", stats)
+	}
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// ParamProfileResolver expands parameter profile references into the
+// parameters of each profile, including the profiles it includes, in merge
+// order
+type ParamProfileResolver interface {
+	ResolveParamProfiles(ctx context.Context, names []string) ([]string, error)
+}
+
+// SetParamProfileResolver enables paramProfiles references in boot
+// configurations and nodes
+func (c *BootScriptController) SetParamProfileResolver(resolver ParamProfileResolver) {
+	c.profiles = resolver
+}
+
+// paramProfiles holds the resolved profile parameters a node boots with, in
+// merge order
+type paramProfiles struct {
+	config []string
+	node   []string
+}
+
+// renderParams resolves the parameter profiles referenced by config and node
+// and returns the node's kernel parameters, with the cloud-init datasource
+// if one is set
+func (c *BootScriptController) renderParams(ctx context.Context, config *apiv1.BootConfiguration, node *apiv1.Node) (string, error) {
+	var profiles paramProfiles
+	var err error
+	if profiles.config, err = c.resolveParamProfiles(ctx, config.Spec.ParamProfiles); err != nil {
+		return "", fmt.Errorf("paramProfiles of %s: %w", config.Metadata.Name, err)
+	}
+	if profiles.node, err = c.resolveParamProfiles(ctx, node.Spec.ParamProfiles); err != nil {
+		return "", fmt.Errorf("node paramProfiles: %w", err)
+	}
+	params, err := nodeParams(config, node, profiles, c.templateEnv(ctx))
+	if err != nil {
+		return "", err
+	}
+	return c.withDatasource(params), nil
+}
+
+func (c *BootScriptController) resolveParamProfiles(ctx context.Context, names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if c.profiles == nil {
+		return nil, errors.New("parameter profiles are referenced but no profile store is configured")
+	}
+	return c.profiles.ResolveParamProfiles(ctx, names)
+}
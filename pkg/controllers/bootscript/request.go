@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"net/url"
+)
+
+type requestVarsKey struct{}
+
+// WithRequestVars adds values the node reported in its boot script request,
+// such as the serial number or UUID its firmware appended to the URL, to
+// ctx. Kernel parameter templates read them as {{.Request.name}}.
+func WithRequestVars(ctx context.Context, vars map[string]string) context.Context {
+	if len(vars) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, requestVarsKey{}, vars)
+}
+
+// requestVarsFrom returns the values set with WithRequestVars, if any
+func requestVarsFrom(ctx context.Context) map[string]string {
+	vars, _ := ctx.Value(requestVarsKey{}).(map[string]string)
+	return vars
+}
+
+// requestCacheKey extends a script cache key with the request values under
+// ctx, since templates may render them into the script
+func requestCacheKey(ctx context.Context, key string) string {
+	vars := requestVarsFrom(ctx)
+	if len(vars) == 0 {
+		return key
+	}
+	values := url.Values{}
+	for name, value := range vars {
+		values.Set(name, value)
+	}
+	// Encode sorts by name, so equal values share an entry
+	return key + ":req=" + values.Encode()
+}
+
+// templateEnv holds what node templates can reach besides the node itself
+type templateEnv struct {
+	secret  secretFunc
+	request map[string]string
+}
+
+// templateEnv returns the environment of node templates rendered in ctx
+func (c *BootScriptController) templateEnv(ctx context.Context) templateEnv {
+	return templateEnv{secret: c.secretFunc(ctx), request: requestVarsFrom(ctx)}
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"fmt"
+	"strings"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/rootfs"
+)
+
+// applyRootFS renders the root filesystem of spec into kernel parameters
+// placed before its params. Parameters whose key the params set themselves
+// are left out, so hand-written params override generated ones.
+func applyRootFS(spec *apiv1.BootConfigurationSpec) error {
+	generated, err := rootfs.Params(spec.RootFS.Distro, spec.RootFS.Options())
+	if err != nil {
+		return fmt.Errorf("rootfs: %w", err)
+	}
+
+	params := make([]string, 0, len(strings.Fields(generated))+1)
+	for _, param := range strings.Fields(generated) {
+		if !hasParam(spec.Params, paramKey(param)) {
+			params = append(params, param)
+		}
+	}
+	if spec.Params != "" {
+		params = append(params, spec.Params)
+	}
+	spec.Params = strings.Join(params, " ")
+	return nil
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+type evaluationTimeKey struct{}
+
+// WithEvaluationTime makes configuration selection under ctx use the
+// schedules in effect at t instead of now. Previews use it to show what a
+// node will boot during a maintenance window.
+func WithEvaluationTime(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, evaluationTimeKey{}, t)
+}
+
+// evaluationTime returns the time schedules are evaluated at under ctx, and
+// whether it was set with WithEvaluationTime
+func evaluationTime(ctx context.Context) (time.Time, bool) {
+	if t, ok := ctx.Value(evaluationTimeKey{}).(time.Time); ok {
+		return t, true
+	}
+	return time.Now(), false
+}
+
+// ScheduledConfiguration reports whether a configuration is active at a
+// given time and when that next changes
+type ScheduledConfiguration struct {
+	Name      string `json:"name"`
+	UID       string `json:"uid"`
+	Profile   string `json:"profile"`
+	Active    bool   `json:"active"`
+	Scheduled bool   `json:"scheduled"` // false for configurations that are always active
+	// NextTransition is when the configuration may next become active or
+	// inactive; omitted when it never changes again
+	NextTransition time.Time `json:"nextTransition,omitzero"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// ScheduleReport lists which configurations are active at a time
+type ScheduleReport struct {
+	At             time.Time                `json:"at"`
+	Configurations []ScheduledConfiguration `json:"configurations"`
+}
+
+// ActiveConfigurations reports which configurations are active at t, active
+// ones first, each group sorted by name
+func (c *BootScriptController) ActiveConfigurations(ctx context.Context, t time.Time) (*ScheduleReport, error) {
+	configs, err := c.client.GetBootConfigurations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting boot configurations: %w", err)
+	}
+
+	report := &ScheduleReport{At: t, Configurations: make([]ScheduledConfiguration, 0, len(configs))}
+	for i := range configs {
+		config := &configs[i]
+		entry := ScheduledConfiguration{
+			Name:    config.Metadata.Name,
+			UID:     config.Metadata.UID,
+			Profile: config.Spec.Profile,
+		}
+		if entry.Profile == "" {
+			entry.Profile = "default"
+		}
+		sched, err := config.Spec.Schedule()
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Active = sched.Active(t)
+			entry.Scheduled = !sched.IsZero()
+			entry.NextTransition = sched.NextTransition(t)
+		}
+		report.Configurations = append(report.Configurations, entry)
+	}
+
+	sort.SliceStable(report.Configurations, func(i, j int) bool {
+		a, b := report.Configurations[i], report.Configurations[j]
+		if a.Active != b.Active {
+			return a.Active
+		}
+		return a.Name < b.Name
+	})
+	return report, nil
+}
+
+// configActive reports whether config is active at t. A configuration whose
+// schedule cannot be parsed is never active.
+func (c *BootScriptController) configActive(config *apiv1.BootConfiguration, t time.Time) bool {
+	sched, err := config.Spec.Schedule()
+	if err != nil {
+		c.logger.Printf("Skipping boot configuration %s: %v", config.Metadata.Name, err)
+		return false
+	}
+	return sched.Active(t)
+}
+
+// trackScheduleTransitions records the earliest time after now at which a
+// schedule in configs may change which configuration a node boots
+func (c *BootScriptController) trackScheduleTransitions(configs []apiv1.BootConfiguration, now time.Time) {
+	var next time.Time
+	for i := range configs {
+		sched, err := configs[i].Spec.Schedule()
+		if err != nil || sched.IsZero() {
+			continue
+		}
+		if transition := sched.NextTransition(now); !transition.IsZero() && (next.IsZero() || transition.Before(next)) {
+			next = transition
+		}
+	}
+	if next.IsZero() {
+		return
+	}
+	for {
+		current := c.scheduleTransition.Load()
+		if current != 0 && current <= next.UnixNano() {
+			return
+		}
+		if c.scheduleTransition.CompareAndSwap(current, next.UnixNano()) {
+			return
+		}
+	}
+}
+
+// expireScheduledScripts drops cached scripts once a schedule transition
+// recorded by trackScheduleTransitions has passed, so nodes switch to the
+// newly active configuration without waiting for the cache TTL
+func (c *BootScriptController) expireScheduledScripts(now time.Time) {
+	next := c.scheduleTransition.Load()
+	if next == 0 || now.UnixNano() < next {
+		return
+	}
+	if c.scheduleTransition.CompareAndSwap(next, 0) {
+		c.logger.Printf("Boot configuration schedule transition at %s; clearing cached boot scripts", time.Unix(0, next).UTC().Format(time.RFC3339))
+		c.generation.Add(1)
+		c.cache.Clear()
+	}
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func newScheduleTestController(t *testing.T, activeFrom time.Time) *BootScriptController {
+	t.Helper()
+
+	nodes := []apiv1.Node{{
+		Metadata: resource.Metadata{UID: "nod-1"},
+		Spec:     apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:01", Groups: []string{"compute"}},
+	}}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "current", UID: "bc-1"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz-current"},
+		},
+		{
+			// Live from activeFrom, during the nightly 02:00-04:00 UTC window
+			Metadata: resource.Metadata{Name: "upgrade", UID: "bc-2"},
+			Spec: apiv1.BootConfigurationSpec{
+				Groups:     []string{"compute"},
+				Kernel:     "http://files.example.com/vmlinuz-upgrade",
+				Priority:   10,
+				ActiveFrom: activeFrom,
+				Windows:    []apiv1.ScheduleWindow{{Cron: "0 2 * * *", Duration: "2h"}},
+			},
+		},
+	}
+	return newTestControllerWithData(t, nodes, configs)
+}
+
+func TestScheduledConfigurationSelection(t *testing.T) {
+	activeFrom := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	controller := newScheduleTestController(t, activeFrom)
+
+	tests := map[string]string{
+		"2026-02-20T03:00:00Z": "current", // window open, before activeFrom
+		"2026-03-05T03:00:00Z": "upgrade",
+		"2026-03-05T05:00:00Z": "current", // outside the window
+	}
+	for at, want := range tests {
+		parsed, _ := time.Parse(time.RFC3339, at)
+		preview, err := controller.PreviewBootScript(WithEvaluationTime(context.Background(), parsed), "x0c0s0b0n0", "")
+		if err != nil {
+			t.Fatalf("PreviewBootScript returned error: %v", err)
+		}
+		if preview.MatchedConfiguration != want {
+			t.Errorf("at %s matched %q, want %q", at, preview.MatchedConfiguration, want)
+		}
+		inactive := preview.Candidates[len(preview.Candidates)-1]
+		if want == "current" && (inactive.Name != "upgrade" || !inactive.Inactive) {
+			t.Errorf("at %s expected upgrade listed last as inactive, got %+v", at, preview.Candidates)
+		}
+	}
+
+	report, err := controller.ActiveConfigurations(context.Background(), time.Date(2026, 3, 5, 3, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ActiveConfigurations returned error: %v", err)
+	}
+	byName := map[string]ScheduledConfiguration{}
+	for _, entry := range report.Configurations {
+		byName[entry.Name] = entry
+	}
+	upgrade := byName["upgrade"]
+	if !upgrade.Active || !upgrade.Scheduled {
+		t.Errorf("expected upgrade to be active and scheduled, got %+v", upgrade)
+	}
+	if want := time.Date(2026, 3, 5, 4, 0, 0, 0, time.UTC); !upgrade.NextTransition.Equal(want) {
+		t.Errorf("upgrade next transition = %s, want %s", upgrade.NextTransition, want)
+	}
+	if current := byName["current"]; !current.Active || current.Scheduled || !current.NextTransition.IsZero() {
+		t.Errorf("expected current to be active and unscheduled, got %+v", current)
+	}
+
+	report, err = controller.ActiveConfigurations(context.Background(), time.Date(2026, 3, 5, 5, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ActiveConfigurations returned error: %v", err)
+	}
+	if last := report.Configurations[1]; last.Name != "upgrade" || last.Active {
+		t.Errorf("expected inactive upgrade listed last, got %+v", report.Configurations)
+	}
+}
+
+func TestScheduleTransitionClearsCache(t *testing.T) {
+	activeFrom := time.Now().Add(time.Hour)
+	controller := newScheduleTestController(t, activeFrom)
+
+	script, err := controller.GenerateBootScript(context.Background(), "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript returned error: %v", err)
+	}
+	if !strings.Contains(script, "vmlinuz-current") {
+		t.Fatalf("expected the current kernel before activeFrom, got:\n%s", script)
+	}
+	if controller.CacheStats().TotalEntries != 1 {
+		t.Fatalf("expected the script to be cached")
+	}
+	if got := controller.scheduleTransition.Load(); got == 0 || got > activeFrom.UnixNano() {
+		t.Fatalf("recorded transition %s, want no later than %s", time.Unix(0, got), activeFrom)
+	}
+
+	controller.expireScheduledScripts(time.Now())
+	if controller.CacheStats().TotalEntries != 1 {
+		t.Fatal("cache cleared before the transition")
+	}
+	controller.expireScheduledScripts(activeFrom)
+	if controller.CacheStats().TotalEntries != 0 {
+		t.Fatal("cache not cleared at the transition")
+	}
+	if controller.scheduleTransition.Load() != 0 {
+		t.Fatal("transition not reset after clearing the cache")
+	}
+}
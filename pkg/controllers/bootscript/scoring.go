@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrAmbiguousMatch is returned in strict scoring mode when the best
+// configurations for a node tie on score and priority
+var ErrAmbiguousMatch = errors.New("ambiguous boot configuration match")
+
+// Weights are the points each matching rule adds to a configuration's score
+type Weights struct {
+	MAC     int `json:"mac"`
+	NID     int `json:"nid"`
+	Host    int `json:"host"`
+	Group   int `json:"group"`
+	Default int `json:"default"` // a configuration without targeting criteria
+}
+
+// Scoring decides how configurations are ranked for a node
+type Scoring struct {
+	Weights Weights `json:"weights"`
+	// Strict refuses to pick between configurations that tie on score and
+	// priority, instead of choosing the first by name. The node gets an
+	// error script naming them.
+	Strict bool `json:"strict"`
+}
+
+// DefaultScoring returns the scoring the server uses unless configured
+// otherwise
+func DefaultScoring() Scoring {
+	return Scoring{Weights: Weights{MAC: 100, NID: 75, Host: 50, Group: 25, Default: 1}}
+}
+
+// Validate checks that every rule adds at least one point, so a match is
+// never scored as no match
+func (s Scoring) Validate() error {
+	w := s.Weights
+	if w.MAC < 1 || w.NID < 1 || w.Host < 1 || w.Group < 1 || w.Default < 1 {
+		return errors.New("match weights must be >= 1")
+	}
+	return nil
+}
+
+// SetScoring replaces the scoring weights and mode. Changing them drops
+// cached scripts, since the configuration a node boots may change.
+func (c *BootScriptController) SetScoring(scoring Scoring) {
+	previous := DefaultScoring()
+	if old := c.scoring.Swap(&scoring); old != nil {
+		previous = *old
+	}
+	if previous != scoring {
+		c.cache.Clear()
+	}
+}
+
+// Scoring returns the current scoring weights and mode
+func (c *BootScriptController) Scoring() Scoring {
+	if scoring := c.scoring.Load(); scoring != nil {
+		return *scoring
+	}
+	return DefaultScoring()
+}
+
+// ambiguity returns ErrAmbiguousMatch naming the candidates that tie with
+// the first, which must be sorted in selection order, or nil if the first
+// wins outright
+func ambiguity(candidates []configCandidate) error {
+	top := topTies(candidates)
+	if len(top) < 2 {
+		return nil
+	}
+	tied := make([]string, 0, len(top))
+	for _, candidate := range top {
+		tied = append(tied, candidate.config.Metadata.Name)
+	}
+	return fmt.Errorf("%w: %s tie with score %d and priority %d", ErrAmbiguousMatch,
+		strings.Join(tied, ", "), candidates[0].score, candidates[0].config.Spec.Priority)
+}
+
+// topTies returns the leading candidates that tie with the first on score and
+// priority; candidates must be sorted in selection order
+func topTies(candidates []configCandidate) []configCandidate {
+	n := 0
+	for n < len(candidates) && candidates[n].score == candidates[0].score &&
+		candidates[n].config.Spec.Priority == candidates[0].config.Spec.Priority {
+		n++
+	}
+	return candidates[:n]
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func newScoringTestController(t *testing.T) *BootScriptController {
+	t.Helper()
+
+	nodes := []apiv1.Node{{
+		Metadata: resource.Metadata{UID: "nod-1"},
+		Spec:     apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:01", Groups: []string{"compute", "gpu"}},
+	}}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "by-nid", UID: "bc-1"},
+			Spec:     apiv1.BootConfigurationSpec{NIDs: []int32{1}, Kernel: "http://files.example.com/vmlinuz-nid"},
+		},
+		{
+			Metadata: resource.Metadata{Name: "compute", UID: "bc-2"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz-compute"},
+		},
+		{
+			Metadata: resource.Metadata{Name: "gpu", UID: "bc-3"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"gpu"}, Kernel: "http://files.example.com/vmlinuz-gpu"},
+		},
+	}
+	return newTestControllerWithData(t, nodes, configs)
+}
+
+func TestScoringWeights(t *testing.T) {
+	controller := newScoringTestController(t)
+	ctx := context.Background()
+
+	script, err := controller.GenerateBootScript(ctx, "x0c0s0b0n0", "")
+	if err != nil || !strings.Contains(script, "vmlinuz-nid") {
+		t.Fatalf("expected the NID configuration by default, got %v:\n%s", err, script)
+	}
+
+	scoring := DefaultScoring()
+	scoring.Weights.Group = 200
+	controller.SetScoring(scoring)
+	if stats := controller.cache.Stats(); stats.TotalEntries != 0 {
+		t.Errorf("cache entries = %d, want scripts dropped after a weight change", stats.TotalEntries)
+	}
+
+	script, err = controller.GenerateBootScript(ctx, "x0c0s0b0n0", "")
+	if err != nil || !strings.Contains(script, "vmlinuz-compute") {
+		t.Errorf("expected the first group configuration with heavier groups, got %v:\n%s", err, script)
+	}
+
+	preview, err := controller.PreviewBootScript(ctx, "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("PreviewBootScript returned error: %v", err)
+	}
+	if len(preview.Candidates) != 3 || preview.Candidates[2].Name != "by-nid" || preview.Candidates[2].Score != 75 {
+		t.Fatalf("unexpected candidates: %+v", preview.Candidates)
+	}
+	if !preview.Candidates[0].Tied || !preview.Candidates[1].Tied || preview.Candidates[2].Tied {
+		t.Errorf("expected the two group configurations marked tied: %+v", preview.Candidates)
+	}
+}
+
+func TestScoringStrict(t *testing.T) {
+	controller := newScoringTestController(t)
+	ctx := context.Background()
+
+	scoring := DefaultScoring()
+	scoring.Weights.Group = 200
+	scoring.Strict = true
+	controller.SetScoring(scoring)
+
+	node := &apiv1.Node{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, Groups: []string{"compute", "gpu"}}}
+	if _, err := controller.findBootConfiguration(ctx, node, ""); !errors.Is(err, ErrAmbiguousMatch) {
+		t.Fatalf("findBootConfiguration error = %v, want ErrAmbiguousMatch", err)
+	}
+
+	preview, err := controller.PreviewBootScript(ctx, "x0c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("PreviewBootScript returned error: %v", err)
+	}
+	if preview.Template != TemplateError || !strings.Contains(preview.Reason, "compute, gpu") {
+		t.Errorf("expected an error script naming the tied configurations, got %+v", preview)
+	}
+
+	// Without the tie the strict mode selects as usual
+	scoring.Weights.Group = 25
+	controller.SetScoring(scoring)
+	script, err := controller.GenerateBootScript(ctx, "x0c0s0b0n0", "")
+	if err != nil || !strings.Contains(script, "vmlinuz-nid") {
+		t.Errorf("expected the NID configuration, got %v:\n%s", err, script)
+	}
+
+	if err := (Scoring{Weights: Weights{MAC: 1, NID: 1, Host: 0, Group: 1, Default: 1}}).Validate(); err == nil {
+		t.Error("expected a zero weight to be rejected")
+	}
+}
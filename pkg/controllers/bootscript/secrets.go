@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// SecretStore looks up the values kernel parameters reference with
+// {{secret "name"}}
+type SecretStore interface {
+	Secret(ctx context.Context, name string) (string, error)
+}
+
+// SetSecretStore enables {{secret "name"}} references in kernel parameters
+func (c *BootScriptController) SetSecretStore(store SecretStore) {
+	c.secrets = store
+}
+
+// secretFunc resolves a secret reference while rendering parameters
+type secretFunc func(name string) (string, error)
+
+type redactSecretsKey struct{}
+
+// withRedactedSecrets marks ctx so rendered scripts show a placeholder in
+// place of each secret value. Previews are rendered this way, so secrets only
+// ever leave the service in the scripts served to nodes.
+func withRedactedSecrets(ctx context.Context) context.Context {
+	return context.WithValue(ctx, redactSecretsKey{}, true)
+}
+
+// secretUse records whether a render resolved a secret value. Scripts that
+// carry one are not cached, so secret values are not kept in memory or in a
+// shared cache, and a rotated secret takes effect on the next boot.
+type secretUse struct {
+	resolved atomic.Bool
+}
+
+type secretUseKey struct{}
+
+// withSecretUse returns ctx with a secretUse that secret lookups in it set
+func withSecretUse(ctx context.Context) (context.Context, *secretUse) {
+	use := &secretUse{}
+	return context.WithValue(ctx, secretUseKey{}, use), use
+}
+
+// resolvedSecrets holds the value last resolved for each secret name, for
+// RedactSecrets. Holding one value per name keeps it bounded by the secrets
+// referenced and drops a value once the secret is rotated.
+type resolvedSecrets struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (r *resolvedSecrets) store(name, value string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.values == nil {
+		r.values = map[string]string{}
+	}
+	r.values[name] = value
+}
+
+// redactedSecret is the placeholder rendered for the secret name
+func redactedSecret(name string) string {
+	return fmt.Sprintf("[redacted:%s]", name)
+}
+
+// secretFunc returns the lookup used for secret references rendered in ctx.
+// A redacted lookup still checks that the secret exists when a store is set.
+func (c *BootScriptController) secretFunc(ctx context.Context) secretFunc {
+	redact, _ := ctx.Value(redactSecretsKey{}).(bool)
+	return func(name string) (string, error) {
+		if c.secrets == nil {
+			if redact {
+				return redactedSecret(name), nil
+			}
+			return "", fmt.Errorf("secret %q is referenced but no secret store is configured", name)
+		}
+		value, err := c.secrets.Secret(ctx, name)
+		if err != nil {
+			return "", err
+		}
+		if redact {
+			return redactedSecret(name), nil
+		}
+		if use, ok := ctx.Value(secretUseKey{}).(*secretUse); ok {
+			use.resolved.Store(true)
+		}
+		if value != "" {
+			c.resolvedSecrets.store(name, value)
+		}
+		return value, nil
+	}
+}
+
+// RedactSecrets replaces the secret values resolved for served scripts with
+// the placeholder previews show, for copies of scripts kept outside the
+// service, such as legacy API recordings. Scripts with secret values are
+// never cached, so every such script was rendered here with the values
+// RedactSecrets knows.
+func (c *BootScriptController) RedactSecrets(text string) string {
+	type secret struct{ value, name string }
+	var secrets []secret
+	c.resolvedSecrets.mu.Lock()
+	for name, value := range c.resolvedSecrets.values {
+		secrets = append(secrets, secret{value, name})
+	}
+	c.resolvedSecrets.mu.Unlock()
+	// A value that contains another is replaced first
+	slices.SortFunc(secrets, func(a, b secret) int { return cmp.Compare(len(b.value), len(a.value)) })
+	for _, s := range secrets {
+		text = strings.ReplaceAll(text, s.value, redactedSecret(s.name))
+	}
+	return text
+}
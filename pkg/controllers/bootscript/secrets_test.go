@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/resource"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+type mapSecrets map[string]string
+
+func (m mapSecrets) Secret(_ context.Context, name string) (string, error) {
+	value, ok := m[name]
+	if !ok {
+		return "", fmt.Errorf("secret not found: %s", name)
+	}
+	return value, nil
+}
+
+func secretResources(params string) *StaticResources {
+	return &StaticResources{
+		Nodes: []apiv1.Node{{Spec: apiv1.NodeSpec{XName: "x1000c0s0b0n0", BootMAC: "aa:bb:cc:dd:ee:01"}}},
+		BootConfigurations: []apiv1.BootConfiguration{{
+			Metadata: resource.Metadata{Name: "compute", UID: "bc-1"},
+			Spec:     apiv1.BootConfigurationSpec{Kernel: "http://files.example.com/vmlinuz", Params: params},
+		}},
+	}
+}
+
+func TestSecretParams(t *testing.T) {
+	ctx := context.Background()
+	resources := secretResources(`console=ttyS0 token={{secret "site/token"}}`)
+	if err := resources.BootConfigurations[0].Validate(ctx); err != nil {
+		t.Fatalf("configuration with a secret reference rejected: %v", err)
+	}
+	controller := NewBootScriptControllerWithReader(resources, log.New(io.Discard, "", 0))
+	controller.SetSecretStore(mapSecrets{"site/token": "s3cr3t"})
+
+	script, err := controller.GenerateBootScript(ctx, "x1000c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript failed: %v", err)
+	}
+	if !strings.Contains(script, "token=s3cr3t") {
+		t.Errorf("secret not rendered into boot script:\n%s", script)
+	}
+
+	preview, err := controller.PreviewBootScript(ctx, "x1000c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("PreviewBootScript failed: %v", err)
+	}
+	if strings.Contains(preview.Script, "s3cr3t") || strings.Contains(preview.Params, "s3cr3t") {
+		t.Errorf("preview reveals the secret: %+v", preview)
+	}
+	if !strings.Contains(preview.Params, "token=[redacted:site/token]") {
+		t.Errorf("preview params = %q, want a redacted placeholder", preview.Params)
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	ctx := context.Background()
+	resources := secretResources(`root=nfs:{{secret "nfs/user"}}:{{secret "nfs/password"}}@10.0.0.1:/root rd.luks.passphrase={{secret "luks"}}`)
+	controller := NewBootScriptControllerWithReader(resources, log.New(io.Discard, "", 0))
+	controller.SetSecretStore(mapSecrets{"nfs/user": "admin", "nfs/password": "adminpw", "luks": "correct horse"})
+
+	script, err := controller.GenerateBootScript(ctx, "x1000c0s0b0n0", "")
+	if err != nil {
+		t.Fatalf("GenerateBootScript failed: %v", err)
+	}
+	// A repeated request renders the same script again
+	if again, err := controller.GenerateBootScript(ctx, "x1000c0s0b0n0", ""); err != nil || again != script {
+		t.Fatalf("second GenerateBootScript = %q, %v; want the same script", again, err)
+	}
+
+	redacted := controller.RedactSecrets(script)
+	for _, value := range []string{"admin", "adminpw", "correct horse"} {
+		if strings.Contains(redacted, value) {
+			t.Errorf("redacted script reveals %q:\n%s", value, redacted)
+		}
+	}
+	want := "root=nfs:[redacted:nfs/user]:[redacted:nfs/password]@10.0.0.1:/root rd.luks.passphrase=[redacted:luks]"
+	if !strings.Contains(redacted, want) {
+		t.Errorf("redacted script lacks %q:\n%s", want, redacted)
+	}
+}
+
+func TestRedactSecrets_SharedCache(t *testing.T) {
+	ctx := context.Background()
+	cache := NewScriptCache(DefaultCacheTTL)
+	defer cache.Close()
+
+	// Two replicas share a cache, as with the Redis backend; the second has
+	// resolved no secrets of its own, as after a restart
+	replicas := make([]*BootScriptController, 2)
+	for i := range replicas {
+		replicas[i] = NewBootScriptControllerWithReader(secretResources(`token={{secret "site/token"}}`), log.New(io.Discard, "", 0))
+		replicas[i].cache = cache
+		replicas[i].SetSecretStore(mapSecrets{"site/token": "s3cr3t"})
+	}
+	if _, err := replicas[0].Prewarm(ctx); err != nil {
+		t.Fatalf("Prewarm failed: %v", err)
+	}
+	if _, err := replicas[0].GenerateBootScript(ctx, "x1000c0s0b0n0", ""); err != nil {
+		t.Fatalf("GenerateBootScript failed: %v", err)
+	}
+
+	script, err := replicas[1].GenerateBootScript(ctx, "x1000c0s0b0n0", "")
+	if err != nil || !strings.Contains(script, "token=s3cr3t") {
+		t.Fatalf("GenerateBootScript() = %q, %v", script, err)
+	}
+	if redacted := replicas[1].RedactSecrets(script); strings.Contains(redacted, "s3cr3t") {
+		t.Errorf("script served by the second replica not redacted:\n%s", redacted)
+	}
+}
+
+func TestSecretParams_NotCached(t *testing.T) {
+	ctx := context.Background()
+	controller := NewBootScriptControllerWithReader(secretResources(`token={{secret "site/token"}}`), log.New(io.Discard, "", 0))
+	secrets := mapSecrets{"site/token": "first"}
+	controller.SetSecretStore(secrets)
+
+	if warmed, err := controller.Prewarm(ctx); err != nil || warmed != 0 {
+		t.Errorf("Prewarm() = %d, %v; want the script with a secret left to be rendered on request", warmed, err)
+	}
+	script, err := controller.GenerateBootScript(ctx, "x1000c0s0b0n0", "")
+	if err != nil || !strings.Contains(script, "token=first") {
+		t.Fatalf("GenerateBootScript() = %q, %v", script, err)
+	}
+	if entries := controller.CacheStats().TotalEntries; entries != 0 {
+		t.Errorf("cache holds %d scripts, want none with a secret value", entries)
+	}
+
+	// A rotated secret is served on the next request and replaces the old
+	// value held for redaction
+	secrets["site/token"] = "second"
+	script, err = controller.GenerateBootScript(ctx, "x1000c0s0b0n0", "")
+	if err != nil || !strings.Contains(script, "token=second") {
+		t.Fatalf("GenerateBootScript() after rotation = %q, %v", script, err)
+	}
+	if values := controller.resolvedSecrets.values; len(values) != 1 || values["site/token"] != "second" {
+		t.Errorf("resolved secrets = %v, want only the current value", values)
+	}
+}
+
+func TestSecretParams_Unresolved(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		store SecretStore
+	}{
+		{name: "no store"},
+		{name: "missing secret", store: mapSecrets{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewBootScriptControllerWithReader(secretResources(`token={{secret "site/token"}}`), log.New(io.Discard, "", 0))
+			if tt.store != nil {
+				controller.SetSecretStore(tt.store)
+			}
+			rendered := controller.render(ctx, "x1000c0s0b0n0", "")
+			if rendered.template != TemplateError || !strings.Contains(rendered.reason, "site/token") {
+				t.Errorf("render() = %s template, reason %q", rendered.template, rendered.reason)
+			}
+		})
+	}
+
+	// Previews without a store, such as the render command's, show where
+	// secrets go
+	controller := NewBootScriptControllerWithReader(secretResources(`token={{secret "site/token"}}`), log.New(io.Discard, "", 0))
+	preview, err := controller.PreviewBootScript(ctx, "x1000c0s0b0n0", "")
+	if err != nil || preview.Template != TemplateDefault || !strings.HasPrefix(preview.Params, "token=[redacted:site/token] ") {
+		t.Errorf("PreviewBootScript() = %+v, %v", preview, err)
+	}
+}
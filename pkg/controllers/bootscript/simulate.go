@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// Sample sizes of rendered scripts in a simulation
+const (
+	DefaultSimulationSample = 5
+	MaxSimulationSample     = 50
+)
+
+// ErrInvalidProposal is returned when a proposed configuration cannot be
+// simulated
+var ErrInvalidProposal = errors.New("invalid proposed configuration")
+
+// Simulation is the impact of storing a proposed boot configuration
+type Simulation struct {
+	Configuration string `json:"configuration"`
+	// Replaces is set when the proposal has the UID or name of a stored
+	// configuration, which it replaces
+	Replaces string `json:"replaces,omitempty"`
+	// Nodes would boot another configuration
+	Nodes []AssignmentChange `json:"nodes"`
+	// Affected counts the nodes that boot the proposed or the replaced
+	// configuration before or after, whose scripts may change
+	Affected int `json:"affected"`
+	// Scripts compares the boot scripts of a sample of the affected nodes,
+	// the nodes switching configuration first
+	Scripts []ScriptChange `json:"scripts"`
+	// Conflicts lists the ties the proposal would be part of
+	Conflicts []Conflict `json:"conflicts"`
+}
+
+// ScriptChange compares a node's boot script before and after a change
+type ScriptChange struct {
+	UID   string `json:"uid"`
+	XName string `json:"xname"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	// Diff is a line diff of the scripts, empty when they are the same
+	Diff string `json:"diff"`
+}
+
+type configurationsKey struct{}
+
+// withConfigurations makes configuration selection under ctx choose from
+// configs instead of the stored configurations
+func withConfigurations(ctx context.Context, configs []apiv1.BootConfiguration) context.Context {
+	return context.WithValue(ctx, configurationsKey{}, configs)
+}
+
+// bootConfigurations returns the configurations selection chooses from
+// under ctx
+func (c *BootScriptController) bootConfigurations(ctx context.Context) ([]apiv1.BootConfiguration, error) {
+	if configs, ok := ctx.Value(configurationsKey{}).([]apiv1.BootConfiguration); ok {
+		return configs, nil
+	}
+	return c.client.GetBootConfigurations(ctx)
+}
+
+// Simulate reports what storing proposed would change, without storing it:
+// the nodes that would switch configuration, the script changes of up to
+// sample affected nodes, and the conflicts it would be part of. The
+// proposal replaces the stored configuration with its UID or, without one,
+// its name, and is simulated as active now whatever its schedule. Scripts
+// are rendered as previews, with secrets redacted.
+func (c *BootScriptController) Simulate(ctx context.Context, proposed apiv1.BootConfiguration, sample int) (*Simulation, error) {
+	if proposed.Metadata.Name == "" {
+		return nil, fmt.Errorf("%w: metadata.name is required", ErrInvalidProposal)
+	}
+	if proposed.Spec.Kernel == "" && proposed.Spec.KernelArtifact == "" && proposed.Spec.ImageRef == "" && proposed.Spec.ChainURL == "" {
+		return nil, fmt.Errorf("%w: kernel, kernelArtifact, imageRef, or chainURL is required", ErrInvalidProposal)
+	}
+	if sample < 0 || sample > MaxSimulationSample {
+		return nil, fmt.Errorf("%w: sample must be between 0 and %d", ErrInvalidProposal, MaxSimulationSample)
+	}
+
+	stored, err := c.client.GetBootConfigurations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting boot configurations: %w", err)
+	}
+	simulation := &Simulation{Configuration: proposed.Metadata.Name, Scripts: []ScriptChange{}}
+	replacedName := ""
+	for i := range stored {
+		byUID := proposed.Metadata.UID != "" && stored[i].Metadata.UID == proposed.Metadata.UID
+		byName := proposed.Metadata.UID == "" && stored[i].Metadata.Name == proposed.Metadata.Name
+		if byUID || byName {
+			replacedName = stored[i].Metadata.Name
+			proposed.Metadata.UID = stored[i].Metadata.UID
+			simulation.Replaces = replacedName
+			break
+		}
+	}
+	proposed.Spec.ActiveFrom, proposed.Spec.ActiveUntil, proposed.Spec.Windows = time.Time{}, time.Time{}, nil
+
+	after := make([]apiv1.BootConfiguration, 0, len(stored)+1)
+	for i := range stored {
+		if simulation.Replaces == "" || stored[i].Metadata.UID != proposed.Metadata.UID {
+			after = append(after, stored[i])
+		}
+	}
+	after = append(after, proposed)
+
+	nodes, err := c.client.GetNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting nodes: %w", err)
+	}
+	now := time.Now()
+	var switching, staying []AssignmentChange
+	for _, assignment := range c.assignments(stored, after, nodes, now) {
+		switch {
+		case assignment.From != assignment.To:
+			switching = append(switching, assignment)
+		case assignment.From == proposed.Metadata.Name || (replacedName != "" && assignment.From == replacedName):
+			// Nodes staying on the replaced configuration boot the proposal
+			staying = append(staying, assignment)
+		}
+	}
+	simulation.Nodes = append([]AssignmentChange{}, switching...)
+	simulation.Affected = len(switching) + len(staying)
+
+	previewCtx := WithEvaluationTime(withRedactedSecrets(ctx), now)
+	for _, assignment := range append(switching, staying...) {
+		if len(simulation.Scripts) == sample {
+			break
+		}
+		if assignment.XName == "" {
+			continue
+		}
+		before := c.render(previewCtx, assignment.XName, "")
+		changed := c.render(withConfigurations(previewCtx, after), assignment.XName, "")
+		simulation.Scripts = append(simulation.Scripts, ScriptChange{
+			UID:   assignment.UID,
+			XName: assignment.XName,
+			From:  assignment.From,
+			To:    assignment.To,
+			Diff:  diffLines(before.script, changed.script),
+		})
+	}
+
+	conflicts, err := c.ConflictsWith(ctx, &proposed)
+	if err != nil {
+		return nil, err
+	}
+	simulation.Conflicts = append([]Conflict{}, conflicts...)
+
+	return simulation, nil
+}
+
+// diffLines returns a line diff of a and b: lines only in a prefixed with
+// "-", lines only in b with "+", and common lines with " ". It is empty when
+// a and b are the same.
+func diffLines(a, b string) string {
+	if a == b {
+		return ""
+	}
+	linesA := strings.Split(strings.TrimSuffix(a, "\n"), "\n")
+	linesB := strings.Split(strings.TrimSuffix(b, "\n"), "\n")
+
+	// common[i][j] is the length of the longest common subsequence of
+	// linesA[i:] and linesB[j:]; boot scripts are short
+	common := make([][]int, len(linesA)+1)
+	for i := range common {
+		common[i] = make([]int, len(linesB)+1)
+	}
+	for i := len(linesA) - 1; i >= 0; i-- {
+		for j := len(linesB) - 1; j >= 0; j-- {
+			if linesA[i] == linesB[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	var diff strings.Builder
+	i, j := 0, 0
+	for i < len(linesA) || j < len(linesB) {
+		switch {
+		case i < len(linesA) && j < len(linesB) && linesA[i] == linesB[j]:
+			diff.WriteString(" " + linesA[i] + "\n")
+			i++
+			j++
+		case j == len(linesB) || (i < len(linesA) && common[i+1][j] >= common[i][j+1]):
+			diff.WriteString("-" + linesA[i] + "\n")
+			i++
+		default:
+			diff.WriteString("+" + linesB[j] + "\n")
+			j++
+		}
+	}
+	return diff.String()
+}
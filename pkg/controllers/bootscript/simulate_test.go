@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func newSimulationTestController(t *testing.T) *BootScriptController {
+	t.Helper()
+	nodes := []apiv1.Node{
+		{Metadata: resource.Metadata{UID: "nod-1"}, Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, Groups: []string{"compute"}}},
+		{Metadata: resource.Metadata{UID: "nod-2"}, Spec: apiv1.NodeSpec{XName: "x0c0s1b0n0", NID: 2, Groups: []string{"compute"}}},
+		{Metadata: resource.Metadata{UID: "nod-3"}, Spec: apiv1.NodeSpec{XName: "x0c0s2b0n0", NID: 3, Groups: []string{"io"}}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "compute", UID: "bc-1"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz", Params: "console=ttyS0"},
+		},
+		{
+			Metadata: resource.Metadata{Name: "io", UID: "bc-2"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"io"}, Kernel: "http://files.example.com/vmlinuz-io"},
+		},
+	}
+	return newTestControllerWithData(t, nodes, configs)
+}
+
+func TestSimulate_ReplaceByName(t *testing.T) {
+	controller := newSimulationTestController(t)
+
+	proposed := apiv1.BootConfiguration{
+		Metadata: resource.Metadata{Name: "compute"},
+		Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, NIDs: []int32{3}, Kernel: "http://files.example.com/vmlinuz-6.8", Params: "console=ttyS0"},
+	}
+	simulation, err := controller.Simulate(context.Background(), proposed, 2)
+	if err != nil {
+		t.Fatalf("Simulate returned error: %v", err)
+	}
+	if simulation.Replaces != "compute" {
+		t.Errorf("replaces = %q, want compute", simulation.Replaces)
+	}
+
+	// The io node's NID outscores its group match; the compute nodes stay
+	wantNodes := []AssignmentChange{{UID: "nod-3", XName: "x0c0s2b0n0", From: "io", To: "compute"}}
+	if !reflect.DeepEqual(simulation.Nodes, wantNodes) {
+		t.Errorf("nodes = %+v, want %+v", simulation.Nodes, wantNodes)
+	}
+	if simulation.Affected != 3 {
+		t.Errorf("affected = %d, want 3", simulation.Affected)
+	}
+
+	// The switching node comes first in the sample, then the compute nodes
+	if len(simulation.Scripts) != 2 || simulation.Scripts[0].XName != "x0c0s2b0n0" || simulation.Scripts[1].XName != "x0c0s0b0n0" {
+		t.Fatalf("scripts = %+v, want x0c0s2b0n0 then x0c0s0b0n0", simulation.Scripts)
+	}
+	for _, script := range simulation.Scripts {
+		if !strings.Contains(script.Diff, "+set kernel http://files.example.com/vmlinuz-6.8\n") {
+			t.Errorf("script diff of %s does not add the new kernel:\n%s", script.XName, script.Diff)
+		}
+	}
+	if !strings.Contains(simulation.Scripts[0].Diff, "-set kernel http://files.example.com/vmlinuz-io\n") {
+		t.Errorf("script diff of the io node does not remove its kernel:\n%s", simulation.Scripts[0].Diff)
+	}
+	if len(simulation.Conflicts) != 0 {
+		t.Errorf("conflicts = %+v, want none", simulation.Conflicts)
+	}
+}
+
+func TestSimulate_NewConfigurationConflicts(t *testing.T) {
+	controller := newSimulationTestController(t)
+
+	proposed := apiv1.BootConfiguration{
+		Metadata: resource.Metadata{Name: "compute-debug"},
+		Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz", Params: "console=ttyS0 debug"},
+	}
+	simulation, err := controller.Simulate(context.Background(), proposed, DefaultSimulationSample)
+	if err != nil {
+		t.Fatalf("Simulate returned error: %v", err)
+	}
+	if simulation.Replaces != "" {
+		t.Errorf("replaces = %q, want none", simulation.Replaces)
+	}
+	if len(simulation.Conflicts) != 1 || !reflect.DeepEqual(simulation.Conflicts[0].Configurations, []string{"compute", "compute-debug"}) {
+		t.Errorf("conflicts = %+v, want compute and compute-debug tied", simulation.Conflicts)
+	}
+}
+
+func TestSimulate_InvalidProposal(t *testing.T) {
+	controller := newSimulationTestController(t)
+
+	tests := map[string]struct {
+		proposed apiv1.BootConfiguration
+		sample   int
+	}{
+		"no name":      {apiv1.BootConfiguration{Spec: apiv1.BootConfigurationSpec{Kernel: "http://files.example.com/vmlinuz"}}, 1},
+		"no kernel":    {apiv1.BootConfiguration{Metadata: resource.Metadata{Name: "compute"}}, 1},
+		"large sample": {apiv1.BootConfiguration{Metadata: resource.Metadata{Name: "compute"}, Spec: apiv1.BootConfigurationSpec{Kernel: "http://files.example.com/vmlinuz"}}, MaxSimulationSample + 1},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := controller.Simulate(context.Background(), tt.proposed, tt.sample); !errors.Is(err, ErrInvalidProposal) {
+				t.Errorf("expected ErrInvalidProposal, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	got := diffLines("a\nb\nc\n", "a\nx\nc\nd\n")
+	want := " a\n-b\n+x\n c\n+d\n"
+	if got != want {
+		t.Errorf("diffLines = %q, want %q", got, want)
+	}
+	if diffLines("a\n", "a\n") != "" {
+		t.Error("diffLines of equal scripts should be empty")
+	}
+}
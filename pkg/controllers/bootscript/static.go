@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package bootscript
+
+import (
+	"context"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// StaticResources is a fixed set of nodes and boot configurations, for
+// rendering boot scripts from files without a running service
+type StaticResources struct {
+	Nodes              []apiv1.Node
+	BootConfigurations []apiv1.BootConfiguration
+}
+
+// GetNodes returns the nodes
+func (s *StaticResources) GetNodes(ctx context.Context) ([]apiv1.Node, error) { //nolint:revive
+	return s.Nodes, nil
+}
+
+// GetBootConfigurations returns the boot configurations
+func (s *StaticResources) GetBootConfigurations(ctx context.Context) ([]apiv1.BootConfiguration, error) { //nolint:revive
+	return s.BootConfigurations, nil
+}
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package cordon keeps individual nodes from booting their configuration.
+// A cordoned node is served a hold script, or refused with 503 and
+// Retry-After, until it is uncordoned, so a flaky node does not rejoin the
+// cluster after a reboot while its configuration stays in place.
+package cordon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// ResourceType is the storage resource type of node cordons
+const ResourceType = "NodeCordon"
+
+// Actions taken for cordoned nodes
+const (
+	// ActionHold serves a script that waits and reboots
+	ActionHold = bootscript.CordonHold
+	// ActionUnavailable refuses boot script requests with 503
+	ActionUnavailable = bootscript.CordonUnavailable
+)
+
+// DefaultRetryAfter is the Retry-After of ActionUnavailable when the cordon
+// does not set one
+const DefaultRetryAfter = 300
+
+// ErrInvalidCordon is returned for a cordon that cannot be applied
+var ErrInvalidCordon = errors.New("invalid cordon")
+
+// Cordon keeps one node from booting its configuration
+type Cordon struct {
+	Node string `json:"node"`
+	// Action is hold (default) or unavailable
+	Action string `json:"action,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// RetryAfterSeconds is sent in Retry-After with ActionUnavailable
+	RetryAfterSeconds int `json:"retryAfterSeconds,omitempty"`
+	// CordonedBy is the token subject that cordoned the node, if known
+	CordonedBy string    `json:"cordonedBy,omitempty"`
+	CordonedAt time.Time `json:"cordonedAt,omitzero"`
+}
+
+// Validate checks a cordon and fills in the defaults
+func (c *Cordon) Validate() error {
+	if c.Node == "" {
+		return fmt.Errorf("%w: node is required", ErrInvalidCordon)
+	}
+	switch c.Action {
+	case "":
+		c.Action = ActionHold
+	case ActionHold, ActionUnavailable:
+	default:
+		return fmt.Errorf("%w: action must be %q or %q", ErrInvalidCordon, ActionHold, ActionUnavailable)
+	}
+	if c.RetryAfterSeconds < 0 {
+		return fmt.Errorf("%w: retryAfterSeconds must not be negative", ErrInvalidCordon)
+	}
+	if c.Action == ActionUnavailable && c.RetryAfterSeconds == 0 {
+		c.RetryAfterSeconds = DefaultRetryAfter
+	}
+	if c.Action == ActionHold {
+		c.RetryAfterSeconds = 0
+	}
+	return nil
+}
+
+// Store holds the cordons in memory and persists them in the resource
+// storage backend, so they survive restarts. The cordons other replicas write
+// reach the store through HandleResourceChange. It implements
+// bootscript.Cordons.
+type Store struct {
+	backend fabricaStorage.StorageBackend
+	logger  *log.Logger
+
+	// invalidate drops the cached scripts of a node whose cordon changes
+	invalidate func(node string)
+
+	mu      sync.RWMutex
+	cordons map[string]Cordon
+}
+
+// NewStore creates a cordon store persisted in backend. invalidate, which may
+// be nil, is called when a node is cordoned or uncordoned. Call Load to
+// restore the stored cordons.
+func NewStore(backend fabricaStorage.StorageBackend, invalidate func(node string), logger *log.Logger) *Store {
+	if invalidate == nil {
+		invalidate = func(string) {}
+	}
+	return &Store{backend: backend, logger: logger, invalidate: invalidate, cordons: make(map[string]Cordon)}
+}
+
+// Load restores the stored cordons
+func (s *Store) Load(ctx context.Context) error {
+	items, err := s.backend.LoadAll(ctx, ResourceType)
+	if err != nil {
+		return fmt.Errorf("loading node cordons: %w", err)
+	}
+	cordons := make(map[string]Cordon, len(items))
+	for _, item := range items {
+		var cordon Cordon
+		if err := json.Unmarshal(item, &cordon); err != nil {
+			return fmt.Errorf("decoding node cordon: %w", err)
+		}
+		cordons[cordon.Node] = cordon
+	}
+
+	s.mu.Lock()
+	s.cordons = cordons
+	s.mu.Unlock()
+	if len(cordons) > 0 {
+		s.logger.Printf("%d nodes are cordoned", len(cordons))
+	}
+	return nil
+}
+
+// Cordon applies and stores a cordon, replacing any earlier one of the node
+func (s *Store) Cordon(ctx context.Context, cordon Cordon) (Cordon, error) {
+	if err := cordon.Validate(); err != nil {
+		return Cordon{}, err
+	}
+	if cordon.CordonedAt.IsZero() {
+		cordon.CordonedAt = time.Now().UTC()
+	}
+	data, err := json.Marshal(cordon)
+	if err != nil {
+		return Cordon{}, fmt.Errorf("encoding cordon of node %s: %w", cordon.Node, err)
+	}
+
+	s.mu.Lock()
+	if err := s.backend.Save(ctx, ResourceType, cordon.Node, data); err != nil {
+		s.mu.Unlock()
+		return Cordon{}, fmt.Errorf("saving cordon of node %s: %w", cordon.Node, err)
+	}
+	s.cordons[cordon.Node] = cordon
+	s.mu.Unlock()
+
+	s.logger.Printf("Node %s cordoned (%s) by %q: %s", cordon.Node, cordon.Action, cordon.CordonedBy, cordon.Reason)
+	s.invalidate(cordon.Node)
+	return cordon, nil
+}
+
+// Uncordon lets node boot its configuration again. It reports whether the
+// node was cordoned.
+func (s *Store) Uncordon(ctx context.Context, node string) (bool, error) {
+	s.mu.Lock()
+	if _, ok := s.cordons[node]; !ok {
+		s.mu.Unlock()
+		return false, nil
+	}
+	if err := s.backend.Delete(ctx, ResourceType, node); err != nil && !errors.Is(err, fabricaStorage.ErrNotFound) {
+		s.mu.Unlock()
+		return false, fmt.Errorf("deleting cordon of node %s: %w", node, err)
+	}
+	delete(s.cordons, node)
+	s.mu.Unlock()
+
+	s.logger.Printf("Node %s uncordoned", node)
+	s.invalidate(node)
+	return true, nil
+}
+
+// Get returns the cordon of node
+func (s *Store) Get(node string) (Cordon, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cordon, ok := s.cordons[node]
+	return cordon, ok
+}
+
+// List returns the cordons, sorted by node
+func (s *Store) List() []Cordon {
+	s.mu.RLock()
+	cordons := make([]Cordon, 0, len(s.cordons))
+	for _, cordon := range s.cordons {
+		cordons = append(cordons, cordon)
+	}
+	s.mu.RUnlock()
+	sort.Slice(cordons, func(i, j int) bool { return cordons[i].Node < cordons[j].Node })
+	return cordons
+}
+
+// Cordoned returns how node is kept from booting. It implements
+// bootscript.Cordons.
+func (s *Store) Cordoned(node string) (bootscript.Cordon, bool) {
+	cordon, ok := s.Get(node)
+	if !ok {
+		return bootscript.Cordon{}, false
+	}
+	return bootscript.Cordon{
+		Action:     cordon.Action,
+		Reason:     cordon.Reason,
+		RetryAfter: time.Duration(cordon.RetryAfterSeconds) * time.Second,
+	}, true
+}
+
+// HandleResourceChange follows the cordons other replicas write and drops
+// the cordons of deleted nodes
+func (s *Store) HandleResourceChange(ctx context.Context, event resourcewatch.Event) {
+	if event.ResourceType == ResourceType {
+		// The store's own writes are already applied
+		if resourcewatch.Remote(ctx) {
+			s.apply(event)
+		}
+		return
+	}
+	if event.ResourceType != "Node" || event.New != nil || event.Old == nil {
+		return
+	}
+	var node apiv1.Node
+	if json.Unmarshal(event.Old, &node) != nil || node.Spec.XName == "" {
+		return
+	}
+	if _, err := s.Uncordon(ctx, node.Spec.XName); err != nil {
+		s.logger.Printf("Failed to drop cordon of deleted node %s: %v", node.Spec.XName, err)
+	}
+}
+
+// apply records a cordon write made by another replica
+func (s *Store) apply(event resourcewatch.Event) {
+	var cordon Cordon
+	if event.New != nil {
+		if err := json.Unmarshal(event.New, &cordon); err != nil {
+			s.logger.Printf("Ignoring undecodable cordon of node %s: %v", event.UID, err)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	if event.New == nil {
+		delete(s.cordons, event.UID)
+	} else {
+		s.cordons[event.UID] = cordon
+	}
+	s.mu.Unlock()
+	s.invalidate(event.UID)
+}
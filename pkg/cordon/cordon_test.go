@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package cordon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+func newTestStore(t *testing.T) (*Store, fabricaStorage.StorageBackend, *[]string) {
+	t.Helper()
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	var invalidated []string
+	store := NewStore(backend, func(node string) { invalidated = append(invalidated, node) }, log.New(io.Discard, "", 0))
+	return store, backend, &invalidated
+}
+
+func TestStore_CordonPersists(t *testing.T) {
+	store, backend, invalidated := newTestStore(t)
+	ctx := context.Background()
+
+	c, err := store.Cordon(ctx, Cordon{Node: "x0c0s0b0n0", Reason: "flaky DIMM"})
+	if err != nil {
+		t.Fatalf("Cordon returned error: %v", err)
+	}
+	if c.Action != ActionHold || c.CordonedAt.IsZero() || c.RetryAfterSeconds != 0 {
+		t.Errorf("cordon = %+v, want the hold action and a time", c)
+	}
+	if _, err := store.Cordon(ctx, Cordon{Node: "x0c0s1b0n0", Action: ActionUnavailable}); err != nil {
+		t.Fatalf("Cordon returned error: %v", err)
+	}
+	if len(*invalidated) != 2 {
+		t.Errorf("invalidated %v, want both nodes", *invalidated)
+	}
+
+	restored := NewStore(backend, nil, log.New(io.Discard, "", 0))
+	if err := restored.Load(ctx); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	cordons := restored.List()
+	if len(cordons) != 2 || cordons[0].Node != "x0c0s0b0n0" || cordons[1].Node != "x0c0s1b0n0" {
+		t.Fatalf("restored cordons = %+v", cordons)
+	}
+	held, ok := restored.Cordoned("x0c0s1b0n0")
+	if !ok || held.Action != ActionUnavailable || held.RetryAfter != DefaultRetryAfter*time.Second {
+		t.Errorf("Cordoned = %+v, %v; want unavailable with the default Retry-After", held, ok)
+	}
+
+	if uncordoned, err := restored.Uncordon(ctx, "x0c0s0b0n0"); err != nil || !uncordoned {
+		t.Fatalf("Uncordon = %v, %v; want true", uncordoned, err)
+	}
+	if uncordoned, err := restored.Uncordon(ctx, "x0c0s0b0n0"); err != nil || uncordoned {
+		t.Errorf("second Uncordon = %v, %v; want false", uncordoned, err)
+	}
+	if _, err := backend.Load(ctx, ResourceType, "x0c0s0b0n0"); !errors.Is(err, fabricaStorage.ErrNotFound) {
+		t.Errorf("stored cordon after Uncordon: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCordon_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		cordon Cordon
+		valid  bool
+	}{
+		{"hold", Cordon{Node: "x0c0s0b0n0", Action: ActionHold}, true},
+		{"unavailable with retry", Cordon{Node: "x0c0s0b0n0", Action: ActionUnavailable, RetryAfterSeconds: 60}, true},
+		{"no node", Cordon{Action: ActionHold}, false},
+		{"unknown action", Cordon{Node: "x0c0s0b0n0", Action: "drain"}, false},
+		{"negative retry", Cordon{Node: "x0c0s0b0n0", Action: ActionUnavailable, RetryAfterSeconds: -1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cordon.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Validate returned error: %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidCordon) {
+				t.Errorf("Validate = %v, want ErrInvalidCordon", err)
+			}
+		})
+	}
+}
+
+func TestStore_DropsDeletedNodes(t *testing.T) {
+	store, _, _ := newTestStore(t)
+	ctx := context.Background()
+	if _, err := store.Cordon(ctx, Cordon{Node: "x0c0s0b0n0"}); err != nil {
+		t.Fatalf("Cordon returned error: %v", err)
+	}
+
+	var node apiv1.Node
+	node.Spec.XName = "x0c0s0b0n0"
+	data, _ := json.Marshal(node)
+	store.HandleResourceChange(ctx, resourcewatch.Event{Type: resourcewatch.Deleted, ResourceType: "Node", Old: data})
+	if _, ok := store.Get("x0c0s0b0n0"); ok {
+		t.Error("cordon of a deleted node was kept")
+	}
+}
+
+// fakeSource reports the writes of shared storage when told to
+type fakeSource struct {
+	fn resourcewatch.Subscriber
+}
+
+func (s *fakeSource) Watch(_ context.Context, fn resourcewatch.Subscriber) error {
+	s.fn = fn
+	return nil
+}
+
+func TestStore_FollowsOtherReplicas(t *testing.T) {
+	ctx := context.Background()
+	files, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	// Two replicas share storage, which reports each one's writes to the
+	// other
+	source := &fakeSource{}
+	local, remote := resourcewatch.NewBackend(files), resourcewatch.NewBackend(files)
+	if err := remote.Follow(ctx, source); err != nil {
+		t.Fatalf("Follow returned error: %v", err)
+	}
+	local.Subscribe(func(ctx context.Context, event resourcewatch.Event) { source.fn(ctx, event) })
+	store := NewStore(local, nil, log.New(io.Discard, "", 0))
+	var invalidated []string
+	replica := NewStore(remote, func(node string) { invalidated = append(invalidated, node) }, log.New(io.Discard, "", 0))
+	remote.Subscribe(replica.HandleResourceChange)
+
+	if _, err := store.Cordon(ctx, Cordon{Node: "x0c0s0b0n0", Reason: "flaky DIMM"}); err != nil {
+		t.Fatalf("Cordon returned error: %v", err)
+	}
+	if cordon, ok := replica.Cordoned("x0c0s0b0n0"); !ok || cordon.Reason != "flaky DIMM" {
+		t.Errorf("Cordoned on another replica = %+v, %v; want the cordon", cordon, ok)
+	}
+	if _, err := store.Uncordon(ctx, "x0c0s0b0n0"); err != nil {
+		t.Fatalf("Uncordon returned error: %v", err)
+	}
+	if _, ok := replica.Cordoned("x0c0s0b0n0"); ok {
+		t.Error("node still cordoned on another replica after it was uncordoned")
+	}
+	if len(invalidated) != 2 {
+		t.Errorf("invalidated = %v, want the node's scripts dropped twice", invalidated)
+	}
+}
+
+func TestHandler_ListCordons(t *testing.T) {
+	store, _, _ := newTestStore(t)
+	if _, err := store.Cordon(context.Background(), Cordon{Node: "x0c0s0b0n0"}); err != nil {
+		t.Fatalf("Cordon returned error: %v", err)
+	}
+	router := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, nil))
+	var cordons []Cordon
+	if w.Code != http.StatusOK || json.NewDecoder(w.Body).Decode(&cordons) != nil || len(cordons) != 1 {
+		t.Fatalf("GET %s = %d: %v", Path, w.Code, cordons)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, Path, nil)
+	req = req.WithContext(tenancy.WithTenant(req.Context(), "tenant-a"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("tenant-scoped GET = %d, want 403", w.Code)
+	}
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package cordon
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Path lists the cordoned nodes
+const Path = "/admin/cordons"
+
+// Handler serves the cordon listing. Nodes are cordoned through the boot
+// API's /nodes/{uid}/cordon.
+type Handler struct {
+	store *Store
+}
+
+// NewHandler creates a cordon listing handler
+func NewHandler(store *Store) *Handler {
+	return &Handler{store: store}
+}
+
+// RegisterRoutes registers GET /admin/cordons
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.With(tenancy.Unscoped).Get(Path, h.ListCordons)
+}
+
+// ListCordons handles GET /admin/cordons
+func (h *Handler) ListCordons(w http.ResponseWriter, r *http.Request) { //nolint:revive
+	httputil.WriteJSON(w, http.StatusOK, h.store.List())
+}
@@ -0,0 +1,406 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package declarative reconciles storage with nodes and boot configurations
+// declared outside the service, such as in a Git repository or as
+// Kubernetes custom resources.
+//
+// Declared resources are matched to stored ones by UID when the declaration
+// sets metadata.uid, and otherwise by the xname of a node or the name of a
+// boot configuration. Resources written by a reconcile are annotated with
+// the manager that declared them, and with pruning, resources of that
+// manager that are no longer declared are deleted; resources it never
+// declared are left alone.
+package declarative
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/audit"
+)
+
+// ManagedAnnotation names the manager that declared a resource, such as
+// gitops
+const ManagedAnnotation = "boot.openchami.io/managed-by"
+
+// SourceAnnotation records where the manager declares a resource, such as a
+// file in a repository
+const SourceAnnotation = "boot.openchami.io/managed-source"
+
+// DigestAnnotation holds digests of the declaration last applied and of the
+// resource it stored. Validation normalizes some fields, such as MAC
+// addresses, so a declaration is compared with what it produced rather than
+// with the stored resource directly.
+const DigestAnnotation = "boot.openchami.io/managed-digest"
+
+// Kinds that can be declared, as stored
+const (
+	NodeKind              = "Node"
+	BootConfigurationKind = "BootConfiguration"
+)
+
+const apiVersion = "boot.openchami.io/v1"
+
+// Change actions
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Change is a difference between the declared resources and storage
+type Change struct {
+	Kind   string `json:"kind"`
+	UID    string `json:"uid"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	Source string `json:"source,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Resource is a declared or stored node or boot configuration
+type Resource struct {
+	kind   string
+	value  any // *v1.Node or *v1.BootConfiguration
+	source string
+
+	uid  string
+	key  string // xname of a node, name of a boot configuration
+	spec json.RawMessage
+}
+
+// Decode reads a declared node or boot configuration from JSON. Unknown
+// fields are rejected so typos are reported. source says where it is
+// declared.
+func Decode(kind string, data []byte, source string) (*Resource, error) {
+	return decode(kind, data, true, source)
+}
+
+func decode(kind string, data []byte, strict bool, source string) (*Resource, error) {
+	var value any
+	switch kind {
+	case NodeKind:
+		value = &v1.Node{}
+	case BootConfigurationKind:
+		value = &v1.BootConfiguration{}
+	default:
+		return nil, fmt.Errorf("unsupported kind %q: expected Node or BootConfiguration", kind)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(value); err != nil {
+		return nil, err
+	}
+	return newResource(value, source)
+}
+
+// newResource wraps a decoded node or boot configuration
+func newResource(value any, source string) (*Resource, error) {
+	r := &Resource{value: value, source: source}
+	var spec any
+	switch v := value.(type) {
+	case *v1.Node:
+		r.kind, r.key, r.uid, spec = NodeKind, v.Spec.XName, v.Metadata.UID, v.Spec
+	case *v1.BootConfiguration:
+		r.kind, r.key, r.uid, spec = BootConfigurationKind, v.Metadata.Name, v.Metadata.UID, v.Spec
+	default:
+		return nil, fmt.Errorf("unsupported resource %T", value)
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	r.spec = data
+	return r, nil
+}
+
+// Check returns the problems that keep declared resources from being
+// reconciled together: a missing xname or name, and resources declared
+// twice
+func Check(declared []*Resource) []string {
+	var problems []string
+	byKey := map[string]string{}
+	byUID := map[string]string{}
+	for _, r := range declared {
+		if r.key == "" {
+			field := "metadata.name"
+			if r.kind == NodeKind {
+				field = "spec.xname"
+			}
+			problems = append(problems, fmt.Sprintf("%s: %s without %s", r.source, r.kind, field))
+			continue
+		}
+		if first, ok := byKey[r.kind+"/"+r.key]; ok {
+			problems = append(problems, fmt.Sprintf("%s: %s %s is also declared in %s", r.source, r.kind, r.key, first))
+		}
+		byKey[r.kind+"/"+r.key] = r.source
+		if r.uid == "" {
+			continue
+		}
+		if first, ok := byUID[r.kind+"/"+r.uid]; ok {
+			problems = append(problems, fmt.Sprintf("%s: %s uid %s is also declared in %s", r.source, r.kind, r.uid, first))
+		}
+		byUID[r.kind+"/"+r.uid] = r.source
+	}
+	return problems
+}
+
+// Options controls a reconcile
+type Options struct {
+	// Manager names who declares the resources; it is recorded in
+	// ManagedAnnotation and is the audit subject of the writes
+	Manager string
+	// Revision identifies the declarations, such as a commit, and is
+	// recorded as the audit request ID
+	Revision string
+	// Apply writes the changes; otherwise they are only reported
+	Apply bool
+	// Prune deletes resources of Manager that are no longer declared
+	Prune bool
+}
+
+// Reconcile compares declared with the nodes and boot configurations in
+// backend, which should be the API backend so writes are watched and
+// audited, and returns the changes found. With opts.Apply the changes are
+// written; those that fail carry an error, and the failures are also
+// returned joined.
+func Reconcile(ctx context.Context, backend fabricaStorage.StorageBackend, declared []*Resource, opts Options, logger *log.Logger) ([]Change, error) {
+	r := &reconciler{backend: backend, opts: opts, logger: logger, now: time.Now}
+	writes, err := r.plan(ctx, declared)
+	if err != nil {
+		return []Change{}, err
+	}
+	changes := make([]Change, 0, len(writes))
+	var errs []error
+	for i := range writes {
+		w := &writes[i]
+		if opts.Apply {
+			if err := r.apply(ctx, w); err != nil {
+				w.change.Error = err.Error()
+				errs = append(errs, fmt.Errorf("%s %s %s: %w", w.change.Action, w.change.Kind, w.change.Name, err))
+			}
+		}
+		changes = append(changes, w.change)
+	}
+	return changes, errors.Join(errs...)
+}
+
+type reconciler struct {
+	backend fabricaStorage.StorageBackend
+	opts    Options
+	logger  *log.Logger
+	now     func() time.Time
+}
+
+// write is one planned change
+type write struct {
+	change Change
+	// current is the stored resource; nil for creates
+	current *Resource
+	// wanted is the declared resource; nil for deletes
+	wanted *Resource
+}
+
+// plan compares the declared resources with storage, nodes before boot
+// configurations and deletes last
+func (r *reconciler) plan(ctx context.Context, declared []*Resource) ([]write, error) {
+	var writes, deletes []write
+	for _, kind := range []string{NodeKind, BootConfigurationKind} {
+		stored, err := r.loadStored(ctx, kind)
+		if err != nil {
+			return nil, err
+		}
+		byUID := map[string]*Resource{}
+		byKey := map[string]*Resource{}
+		for _, res := range stored {
+			byUID[res.uid] = res
+			if res.key != "" {
+				byKey[res.key] = res
+			}
+		}
+
+		matched := map[string]bool{}
+		for _, wanted := range declared {
+			if wanted.kind != kind {
+				continue
+			}
+			current := byKey[wanted.key]
+			if wanted.uid != "" {
+				current = byUID[wanted.uid]
+			}
+			if current == nil {
+				writes = append(writes, write{wanted: wanted, change: Change{
+					Kind: kind, UID: wanted.uid, Name: wanted.key, Action: ActionCreate, Source: wanted.source,
+				}})
+				continue
+			}
+			matched[current.uid] = true
+			if !r.drifted(current, wanted) {
+				continue
+			}
+			writes = append(writes, write{current: current, wanted: wanted, change: Change{
+				Kind: kind, UID: current.uid, Name: wanted.key, Action: ActionUpdate, Source: wanted.source,
+			}})
+		}
+
+		if !r.opts.Prune {
+			continue
+		}
+		for _, current := range stored {
+			if matched[current.uid] || annotations(current)[ManagedAnnotation] != r.opts.Manager {
+				continue
+			}
+			deletes = append(deletes, write{current: current, change: Change{
+				Kind: kind, UID: current.uid, Name: current.key, Action: ActionDelete,
+				Source: annotations(current)[SourceAnnotation],
+			}})
+		}
+	}
+	return append(writes, deletes...), nil
+}
+
+// loadStored returns the stored resources of kind
+func (r *reconciler) loadStored(ctx context.Context, kind string) ([]*Resource, error) {
+	items, err := r.backend.LoadAll(ctx, kind)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s resources: %w", kind, err)
+	}
+	stored := make([]*Resource, 0, len(items))
+	for _, item := range items {
+		res, err := decode(kind, item, false, "")
+		if err != nil {
+			r.logger.Printf("Skipping unreadable %s: %v", kind, err)
+			continue
+		}
+		stored = append(stored, res)
+	}
+	return stored, nil
+}
+
+// drifted reports whether a stored resource differs from its declaration:
+// the declaration changed since it was applied, or the resource was written
+// since by another client
+func (r *reconciler) drifted(current, wanted *Resource) bool {
+	have := annotations(current)
+	return have[ManagedAnnotation] != r.opts.Manager ||
+		have[SourceAnnotation] != wanted.source ||
+		have[DigestAnnotation] != digest(wanted, true)+":"+digest(current, false)
+}
+
+// digest summarizes the name, labels, and spec of a resource, and with
+// withAnnotations its annotations, which a reconcile adds to. Empty labels
+// are not stored, so they digest as none.
+func digest(res *Resource, withAnnotations bool) string {
+	meta := metadata(res)
+	summary := struct {
+		Name        string            `json:"name"`
+		Labels      map[string]string `json:"labels,omitempty"`
+		Annotations map[string]string `json:"annotations,omitempty"`
+		Spec        json.RawMessage   `json:"spec"`
+	}{Name: meta.Name, Labels: meta.Labels, Spec: res.spec}
+	if withAnnotations {
+		summary.Annotations = meta.Annotations
+	}
+	data, _ := json.Marshal(summary)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// apply makes one planned change
+func (r *reconciler) apply(ctx context.Context, w *write) error {
+	ctx = audit.WithActor(ctx, audit.Actor{Subject: r.opts.Manager, RequestID: r.opts.Revision})
+	if w.change.Action == ActionDelete {
+		return r.backend.Delete(ctx, w.change.Kind, w.current.uid)
+	}
+
+	// Digest the declaration before a create reuses it as the stored resource
+	declared := digest(w.wanted, true)
+	now := r.now().UTC()
+	wanted := metadata(w.wanted)
+	var err error
+	var value any
+	meta := resource.Metadata{UID: wanted.UID, CreatedAt: now}
+	if w.current != nil {
+		// Keep the stored identity and status; the declaration sets the rest
+		value, meta = w.current.value, *metadata(w.current)
+	} else {
+		value = w.wanted.value
+		if meta.UID == "" {
+			uid, err := resource.GenerateUIDForResource(w.change.Kind)
+			if err != nil {
+				return fmt.Errorf("failed to generate UID: %w", err)
+			}
+			meta.UID = uid
+		}
+	}
+	meta.Name = wanted.Name
+	meta.Labels = maps.Clone(wanted.Labels)
+	meta.Annotations = maps.Clone(meta.Annotations)
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	maps.Copy(meta.Annotations, wanted.Annotations)
+	meta.Annotations[ManagedAnnotation] = r.opts.Manager
+	meta.Annotations[SourceAnnotation] = w.wanted.source
+	meta.UpdatedAt = now
+
+	switch v := value.(type) {
+	case *v1.Node:
+		v.APIVersion, v.Kind, v.Metadata = apiVersion, NodeKind, meta
+		v.Spec = w.wanted.value.(*v1.Node).Spec
+		err = v.Validate(ctx)
+	case *v1.BootConfiguration:
+		v.APIVersion, v.Kind, v.Metadata = apiVersion, BootConfigurationKind, meta
+		v.Spec = w.wanted.value.(*v1.BootConfiguration).Spec
+		err = v.Validate(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	stored, err := newResource(value, w.wanted.source)
+	if err != nil {
+		return err
+	}
+	metadata(stored).Annotations[DigestAnnotation] = declared + ":" + digest(stored, false)
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := r.backend.Save(ctx, w.change.Kind, meta.UID, data); err != nil {
+		return err
+	}
+	w.change.UID = meta.UID
+	return nil
+}
+
+// metadata returns the metadata of a node or boot configuration
+func metadata(res *Resource) *resource.Metadata {
+	switch v := res.value.(type) {
+	case *v1.Node:
+		return &v.Metadata
+	case *v1.BootConfiguration:
+		return &v.Metadata
+	}
+	return &resource.Metadata{}
+}
+
+// annotations returns the annotations of a node or boot configuration
+func annotations(res *Resource) map[string]string {
+	return metadata(res).Annotations
+}
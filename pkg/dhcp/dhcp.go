@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package dhcp renders static DHCP host reservations from node data, so DHCP
+// servers such as dnsmasq or CoreDHCP (used by coresmd) hand out the
+// addresses recorded on the nodes.
+package dhcp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// Supported output formats
+const (
+	// FormatDnsmasq renders dhcp-host lines for a dnsmasq configuration file
+	FormatDnsmasq = "dnsmasq"
+	// FormatCoreDHCP renders "<mac> <ip>" lines for the CoreDHCP file plugin
+	FormatCoreDHCP = "coredhcp"
+)
+
+// Options selects the nodes and addresses rendered
+type Options struct {
+	// Group limits the output to nodes in this group
+	Group string
+	// Subnet, when valid, limits the output to addresses in this prefix
+	Subnet netip.Prefix
+}
+
+// Reservation is one MAC address with its fixed address and host name
+type Reservation struct {
+	MAC      string
+	IP       netip.Addr
+	Hostname string
+	XName    string
+}
+
+// Result is the reservations of a set of nodes and the interfaces left out
+type Result struct {
+	Nodes        int
+	Reservations []Reservation
+	Warnings     []string
+}
+
+func (r *Result) warnf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// ValidFormat reports whether format is a supported output format
+func ValidFormat(format string) bool {
+	return format == FormatDnsmasq || format == FormatCoreDHCP
+}
+
+// Reservations builds the host reservations of nodes: one for each
+// interface MAC, and for the boot MAC when no interface lists it. The host
+// name, the node's hostname or else its xname, goes with the boot MAC, or
+// with the first interface of a node without one. Interfaces with an invalid
+// MAC or IPv4 address, or a MAC or IP already reserved for another node, are
+// left out with a warning. Nodes are sorted by xname, boot MAC first, so the
+// output is stable.
+func Reservations(nodes []v1.Node, opts Options) *Result {
+	sorted := make([]v1.Node, len(nodes))
+	copy(sorted, nodes)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Spec.XName < sorted[j].Spec.XName })
+
+	result := &Result{}
+	macOwners := map[string]string{}
+	ipOwners := map[netip.Addr]string{}
+	for i := range sorted {
+		node := &sorted[i]
+		if opts.Group != "" && !hasGroup(node.Spec.Groups, opts.Group) {
+			continue
+		}
+		result.Nodes++
+
+		hostname := node.Spec.Hostname
+		if hostname == "" {
+			hostname = node.Spec.XName
+		}
+		named := false
+		for _, iface := range nodeInterfaces(node) {
+			mac, err := net.ParseMAC(iface.MAC)
+			if err != nil || len(mac) != 6 {
+				result.warnf("%s: skipped interface with invalid MAC %q", node.Spec.XName, iface.MAC)
+				continue
+			}
+			reservation := Reservation{MAC: mac.String(), XName: node.Spec.XName}
+			if iface.IP != "" {
+				addr, err := netip.ParseAddr(iface.IP)
+				if err != nil || !addr.Is4() {
+					result.warnf("%s: skipped interface %s with invalid IPv4 address %q", node.Spec.XName, reservation.MAC, iface.IP)
+					continue
+				}
+				reservation.IP = addr
+			}
+			if owner, ok := macOwners[reservation.MAC]; ok {
+				if owner != node.Spec.XName {
+					result.warnf("%s: skipped MAC %s, already reserved for %s", node.Spec.XName, reservation.MAC, owner)
+				}
+				continue
+			}
+			if owner, ok := ipOwners[reservation.IP]; ok && reservation.IP.IsValid() && owner != node.Spec.XName {
+				result.warnf("%s: skipped IP %s, already reserved for %s", node.Spec.XName, reservation.IP, owner)
+				continue
+			}
+			if opts.Subnet.IsValid() && !opts.Subnet.Contains(reservation.IP) {
+				continue
+			}
+
+			macOwners[reservation.MAC] = node.Spec.XName
+			if reservation.IP.IsValid() {
+				ipOwners[reservation.IP] = node.Spec.XName
+			}
+			if !named && (node.Spec.BootMAC == "" || validation.EqualMAC(reservation.MAC, node.Spec.BootMAC)) {
+				reservation.Hostname = hostname
+				named = true
+			}
+			result.Reservations = append(result.Reservations, reservation)
+		}
+	}
+	return result
+}
+
+// nodeInterfaces returns the interfaces of node, the boot MAC first
+func nodeInterfaces(node *v1.Node) []v1.NodeInterface {
+	var interfaces []v1.NodeInterface
+	bootListed := false
+	for _, iface := range node.Spec.Interfaces {
+		if validation.EqualMAC(iface.MAC, node.Spec.BootMAC) && !bootListed {
+			bootListed = true
+			interfaces = append([]v1.NodeInterface{iface}, interfaces...)
+			continue
+		}
+		interfaces = append(interfaces, iface)
+	}
+	if node.Spec.BootMAC != "" && !bootListed {
+		interfaces = append([]v1.NodeInterface{{MAC: node.Spec.BootMAC}}, interfaces...)
+	}
+	return interfaces
+}
+
+func hasGroup(groups []string, group string) bool {
+	for _, g := range groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// Write renders result in format. dnsmasq output starts with a comment
+// header and lists the warnings as comments; CoreDHCP output has only the
+// reservations with an address, since its file plugin has no comment syntax.
+func Write(w io.Writer, format string, result *Result) error {
+	out := bufio.NewWriter(w)
+	switch format {
+	case FormatDnsmasq:
+		warnings := append([]string(nil), result.Warnings...)
+		var lines []string
+		for _, r := range result.Reservations {
+			// A newline or comma in the host name would add fields or
+			// directives to a file dnsmasq loads as root
+			if !safeHostname(r.Hostname) {
+				warnings = append(warnings, fmt.Sprintf("%s: skipped MAC %s with invalid hostname %q", r.XName, r.MAC, r.Hostname))
+				continue
+			}
+			fields := []string{r.MAC}
+			if r.IP.IsValid() {
+				fields = append(fields, r.IP.String())
+			}
+			if r.Hostname != "" {
+				fields = append(fields, r.Hostname)
+			}
+			lines = append(lines, "dhcp-host="+strings.Join(fields, ","))
+		}
+		fmt.Fprintf(out, "# Host reservations for %d nodes generated by boot-service; do not edit\n", result.Nodes) //nolint:errcheck
+		for _, warning := range warnings {
+			fmt.Fprintf(out, "# warning: %s\n", warning) //nolint:errcheck
+		}
+		for _, line := range lines {
+			fmt.Fprintln(out, line) //nolint:errcheck
+		}
+	case FormatCoreDHCP:
+		for _, r := range result.Reservations {
+			if r.IP.IsValid() {
+				fmt.Fprintf(out, "%s %s\n", r.MAC, r.IP) //nolint:errcheck
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported format %q: must be %s or %s", format, FormatDnsmasq, FormatCoreDHCP)
+	}
+	return out.Flush()
+}
+
+// safeHostname reports whether hostname has only letters, digits, '.', and
+// '-', so it can be written into a dnsmasq dhcp-host line
+func safeHostname(hostname string) bool {
+	for _, c := range hostname {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package dhcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+func testNodes() []v1.Node {
+	return []v1.Node{
+		{Spec: v1.NodeSpec{XName: "x1000c0s1b0n0", BootMAC: "aa:bb:cc:dd:ee:02", Groups: []string{"compute"},
+			Interfaces: []v1.NodeInterface{{MAC: "AA:BB:CC:DD:EE:02", IP: "10.1.0.2"}}}},
+		{Spec: v1.NodeSpec{XName: "x1000c0s0b0n0", BootMAC: "aa:bb:cc:dd:ee:01", Hostname: "nid0001", Groups: []string{"compute"},
+			Interfaces: []v1.NodeInterface{
+				{MAC: "aa:bb:cc:dd:ee:11", IP: "10.2.0.1"},
+				{MAC: "aa:bb:cc:dd:ee:01", IP: "10.1.0.1"},
+			}}},
+		// Boot MAC only, no address
+		{Spec: v1.NodeSpec{XName: "x1000c0s2b0n0", BootMAC: "aa:bb:cc:dd:ee:03", Groups: []string{"storage"}}},
+		// Conflicts and invalid values
+		{Spec: v1.NodeSpec{XName: "x1000c0s3b0n0", Groups: []string{"compute"},
+			Interfaces: []v1.NodeInterface{
+				{MAC: "aa:bb:cc:dd:ee:01", IP: "10.1.0.9"},
+				{MAC: "aa:bb:cc:dd:ee:04", IP: "10.1.0.1"},
+				{MAC: "not-a-mac"},
+				{MAC: "aa:bb:cc:dd:ee:05", IP: "fd00::5"},
+				{MAC: "aa:bb:cc:dd:ee:06", IP: "10.1.0.6"},
+			}}},
+	}
+}
+
+func render(t *testing.T, format string, opts Options) (string, *Result) {
+	t.Helper()
+	result := Reservations(testNodes(), opts)
+	var buf bytes.Buffer
+	if err := Write(&buf, format, result); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	return buf.String(), result
+}
+
+func TestDnsmasq(t *testing.T) {
+	out, result := render(t, FormatDnsmasq, Options{})
+
+	want := `# Host reservations for 4 nodes generated by boot-service; do not edit
+# warning: x1000c0s3b0n0: skipped MAC aa:bb:cc:dd:ee:01, already reserved for x1000c0s0b0n0
+# warning: x1000c0s3b0n0: skipped IP 10.1.0.1, already reserved for x1000c0s0b0n0
+# warning: x1000c0s3b0n0: skipped interface with invalid MAC "not-a-mac"
+# warning: x1000c0s3b0n0: skipped interface aa:bb:cc:dd:ee:05 with invalid IPv4 address "fd00::5"
+dhcp-host=aa:bb:cc:dd:ee:01,10.1.0.1,nid0001
+dhcp-host=aa:bb:cc:dd:ee:11,10.2.0.1
+dhcp-host=aa:bb:cc:dd:ee:02,10.1.0.2,x1000c0s1b0n0
+dhcp-host=aa:bb:cc:dd:ee:03,x1000c0s2b0n0
+dhcp-host=aa:bb:cc:dd:ee:06,10.1.0.6,x1000c0s3b0n0
+`
+	if out != want {
+		t.Errorf("dnsmasq output:\n%s\nwant:\n%s", out, want)
+	}
+	if len(result.Warnings) != 4 {
+		t.Errorf("warnings = %v, want 4", result.Warnings)
+	}
+}
+
+func TestDnsmasq_SkipsUnsafeHostnames(t *testing.T) {
+	// Stored before hostnames were validated
+	result := Reservations([]v1.Node{
+		{Spec: v1.NodeSpec{XName: "x1000c0s0b0n0", BootMAC: "aa:bb:cc:dd:ee:01", Hostname: "n1\ndhcp-script=/tmp/x"}},
+		{Spec: v1.NodeSpec{XName: "x1000c0s1b0n0", BootMAC: "aa:bb:cc:dd:ee:02", Hostname: "n2,10.9.9.9"}},
+		{Spec: v1.NodeSpec{XName: "x1000c0s2b0n0", BootMAC: "aa:bb:cc:dd:ee:03", Hostname: "nid0003"}},
+	}, Options{})
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatDnsmasq, result); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	var hosts []string
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			hosts = append(hosts, line)
+		}
+	}
+	if len(hosts) != 1 || hosts[0] != "dhcp-host=aa:bb:cc:dd:ee:03,nid0003" {
+		t.Errorf("directives = %q, want only the reservation of nid0003", hosts)
+	}
+	if !strings.Contains(buf.String(), `# warning: x1000c0s0b0n0: skipped MAC aa:bb:cc:dd:ee:01 with invalid hostname "n1\ndhcp-script=/tmp/x"`) {
+		t.Errorf("output does not warn about the skipped hostname:\n%s", buf.String())
+	}
+}
+
+func TestCoreDHCPWithFilters(t *testing.T) {
+	out, _ := render(t, FormatCoreDHCP, Options{Group: "compute", Subnet: netip.MustParsePrefix("10.1.0.0/16")})
+
+	want := "aa:bb:cc:dd:ee:01 10.1.0.1\naa:bb:cc:dd:ee:02 10.1.0.2\naa:bb:cc:dd:ee:06 10.1.0.6\n"
+	if out != want {
+		t.Errorf("coredhcp output:\n%s\nwant:\n%s", out, want)
+	}
+
+	if err := Write(&bytes.Buffer{}, "isc", &Result{}); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
+
+type testLister struct {
+	nodes []v1.Node
+	err   error
+}
+
+func (l testLister) GetNodes(context.Context) ([]v1.Node, error) {
+	return l.nodes, l.err
+}
+
+func serveHosts(t *testing.T, lister NodeLister, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	router := chi.NewRouter()
+	NewHandler(lister).RegisterRoutes(router)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestHandler_GetHosts(t *testing.T) {
+	rec := serveHosts(t, testLister{nodes: testNodes()}, Path+"?group=storage")
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Fatalf("GET = %d %s, want plain text", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rec.Body.String(), "dhcp-host=aa:bb:cc:dd:ee:03,x1000c0s2b0n0\n") || strings.Contains(rec.Body.String(), "nid0001") {
+		t.Errorf("body = %s, want only the storage node in dnsmasq format", rec.Body.String())
+	}
+
+	rec = serveHosts(t, testLister{nodes: testNodes()}, Path+"?format=coredhcp&subnet=10.2.0.0/16")
+	if rec.Body.String() != "aa:bb:cc:dd:ee:11 10.2.0.1\n" || rec.Header().Get("X-Skipped-Interfaces") != "2" {
+		t.Errorf("coredhcp body %q, skipped %q; want the 10.2 interface and the 2 invalid interfaces skipped", rec.Body.String(), rec.Header().Get("X-Skipped-Interfaces"))
+	}
+
+	for _, target := range []string{Path + "?format=isc", Path + "?subnet=10.1.0.0", Path + "?subnet=fd00::/64"} {
+		if rec := serveHosts(t, testLister{}, target); rec.Code != http.StatusBadRequest {
+			t.Errorf("GET %s status = %d, want 400", target, rec.Code)
+		}
+	}
+	if rec := serveHosts(t, testLister{err: errors.New("storage down")}, Path); rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500 when nodes cannot be listed", rec.Code)
+	}
+}
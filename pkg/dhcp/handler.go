@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package dhcp
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/netip"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/httputil"
+)
+
+// Path serves the DHCP host reservations
+const Path = "/dhcp/hosts"
+
+// NodeLister lists the nodes reservations are rendered from. client.API
+// implements it.
+type NodeLister interface {
+	GetNodes(ctx context.Context) ([]v1.Node, error)
+}
+
+// Handler serves DHCP host reservations
+type Handler struct {
+	nodes NodeLister
+}
+
+// NewHandler creates a DHCP reservations handler reading nodes from nodes
+func NewHandler(nodes NodeLister) *Handler {
+	return &Handler{nodes: nodes}
+}
+
+// RegisterRoutes registers GET /dhcp/hosts
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get(Path, h.GetHosts)
+}
+
+// GetHosts handles GET /dhcp/hosts?format=dnsmasq|coredhcp, with optional
+// group and subnet (CIDR) filters. The X-Skipped-Interfaces header counts the
+// interfaces left out for invalid or conflicting addresses.
+func (h *Handler) GetHosts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = FormatDnsmasq
+	}
+	if !ValidFormat(format) {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid format", "format must be "+FormatDnsmasq+" or "+FormatCoreDHCP)
+		return
+	}
+	opts := Options{Group: query.Get("group")}
+	if subnet := query.Get("subnet"); subnet != "" {
+		prefix, err := netip.ParsePrefix(subnet)
+		if err != nil || !prefix.Addr().Is4() {
+			httputil.WriteError(w, http.StatusBadRequest, "Invalid subnet", "subnet must be an IPv4 CIDR such as 10.1.0.0/16")
+			return
+		}
+		opts.Subnet = prefix.Masked()
+	}
+
+	nodes, err := h.nodes.GetNodes(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to list nodes", err.Error())
+		return
+	}
+	result := Reservations(nodes, opts)
+
+	var buf bytes.Buffer
+	if err := Write(&buf, format, result); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to render reservations", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Skipped-Interfaces", strconv.Itoa(len(result.Warnings)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package discovery registers nodes on their first boot. A boot script
+// request from an unknown MAC creates a Node named discovered-<mac>, with the
+// SMBIOS UUID and address of the request, labeled v1.DiscoveredLabel and in
+// the discovered state. The node boots the discovery configuration, such as
+// an inventory image, until an operator assigns it an xname and removes the
+// label.
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/openchami/fabrica/pkg/fabrica"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// NamePrefix starts the name of every discovered node
+const NamePrefix = "discovered-"
+
+var (
+	// ErrSource is returned when a request comes from outside the
+	// networks allowed to register nodes
+	ErrSource = errors.New("source not allowed to register nodes")
+	// ErrLimit is returned when as many discovered nodes as allowed are
+	// awaiting adoption
+	ErrLimit = errors.New("discovered node limit reached")
+)
+
+// Config limits which requests register nodes
+type Config struct {
+	// Networks are the networks whose requests register nodes; empty
+	// allows any
+	Networks httputil.Networks
+	// Limit is how many discovered nodes may await adoption at once; 0
+	// allows any number
+	Limit int
+}
+
+// Registrar creates discovered nodes
+type Registrar struct {
+	client client.API
+	config Config
+	logger *log.Logger
+
+	// mu serializes registrations, so a node retrying its request while it
+	// is registered is only created once
+	mu sync.Mutex
+
+	// indexMu guards the index of stored nodes, which Load builds and
+	// HandleResourceChange keeps current, so an unknown MAC is found
+	// without listing every node
+	indexMu    sync.RWMutex
+	byMAC      map[string]string   // node UID by MAC
+	macs       map[string][]string // MACs by node UID
+	discovered map[string]bool     // UIDs of nodes awaiting adoption
+}
+
+// NewRegistrar creates a registrar that creates nodes with api, for the
+// requests config allows
+func NewRegistrar(api client.API, config Config, logger *log.Logger) *Registrar {
+	return &Registrar{
+		client:     api,
+		config:     config,
+		logger:     logger,
+		byMAC:      map[string]string{},
+		macs:       map[string][]string{},
+		discovered: map[string]bool{},
+	}
+}
+
+// Load indexes the nodes in storage
+func (r *Registrar) Load(ctx context.Context) error {
+	nodes, err := r.client.GetNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("getting nodes: %w", err)
+	}
+	r.indexMu.Lock()
+	defer r.indexMu.Unlock()
+	for i := range nodes {
+		r.index(nodes[i].Metadata.UID, &nodes[i])
+	}
+	return nil
+}
+
+// HandleResourceChange re-indexes written nodes
+func (r *Registrar) HandleResourceChange(_ context.Context, event resourcewatch.Event) {
+	if event.ResourceType != "Node" {
+		return
+	}
+	var node *v1.Node
+	if event.New != nil {
+		node = &v1.Node{}
+		if err := json.Unmarshal(event.New, node); err != nil {
+			r.logger.Printf("Failed to decode node %s: %v", event.UID, err)
+			return
+		}
+	}
+	r.indexMu.Lock()
+	defer r.indexMu.Unlock()
+	r.index(event.UID, node)
+}
+
+// index replaces the entry of the node uid with node, or removes it when
+// node is nil. indexMu must be held.
+func (r *Registrar) index(uid string, node *v1.Node) {
+	for _, mac := range r.macs[uid] {
+		if r.byMAC[mac] == uid {
+			delete(r.byMAC, mac)
+		}
+	}
+	delete(r.macs, uid)
+	delete(r.discovered, uid)
+	if node == nil {
+		return
+	}
+	macs := node.Spec.MACs()
+	for _, mac := range macs {
+		r.byMAC[mac] = uid
+	}
+	r.macs[uid] = macs
+	if node.Discovered() {
+		r.discovered[uid] = true
+	}
+}
+
+// Register creates a discovered node for mac, with the SMBIOS UUID and source
+// address of its request when they are valid. If a node with the MAC already
+// exists it is returned instead. A request from outside the configured
+// networks returns ErrSource, and one made when the limit of discovered nodes
+// is reached ErrLimit.
+func (r *Registrar) Register(ctx context.Context, mac, uuid, source string) (*v1.Node, error) {
+	if !validation.ValidateMAC(mac) {
+		return nil, fmt.Errorf("invalid MAC %q", mac)
+	}
+	mac = strings.ToLower(validation.NormalizeMAC(mac))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.indexMu.RLock()
+	uid, known := r.byMAC[mac]
+	pending := len(r.discovered)
+	r.indexMu.RUnlock()
+	if known {
+		node, err := r.client.GetNode(ctx, uid)
+		if err != nil {
+			return nil, fmt.Errorf("getting node %s: %w", uid, err)
+		}
+		return node, nil
+	}
+
+	ip := net.ParseIP(source)
+	if len(r.config.Networks) > 0 && (ip == nil || !r.config.Networks.Contains(ip)) {
+		return nil, fmt.Errorf("%w: %s", ErrSource, source)
+	}
+	if r.config.Limit > 0 && pending >= r.config.Limit {
+		return nil, fmt.Errorf("%w: %d nodes await adoption", ErrLimit, pending)
+	}
+
+	spec := v1.NodeSpec{BootMAC: mac}
+	if validation.IsUUID(uuid) {
+		spec.UUID = strings.ToLower(uuid)
+	}
+	if ip != nil {
+		spec.Interfaces = []v1.NodeInterface{{MAC: mac, IP: source}}
+	}
+	node, err := r.client.CreateNode(ctx, client.CreateNodeRequest{
+		Metadata: fabrica.Metadata{Name: Name(mac)},
+		Spec:     spec,
+		Labels:   map[string]string{v1.DiscoveredLabel: "true"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating discovered node: %w", err)
+	}
+	r.logger.Printf("Discovered node %s (MAC %s, address %s)", node.Metadata.Name, mac, source)
+
+	// The node boots the discovery configuration either way; the state
+	// only shows operators what it is
+	status := node.Status
+	status.State = v1.NodeStateDiscovered
+	if updated, err := r.client.UpdateNodeStatus(ctx, node.Metadata.UID, status); err != nil {
+		r.logger.Printf("Failed to set the state of discovered node %s: %v", node.Metadata.Name, err)
+	} else {
+		node = updated
+	}
+	// Indexed now as well, in case the write is not watched
+	r.indexMu.Lock()
+	r.index(node.Metadata.UID, node)
+	r.indexMu.Unlock()
+	return node, nil
+}
+
+// Name returns the name of the node discovered with mac
+func Name(mac string) string {
+	return NamePrefix + strings.ReplaceAll(strings.ToLower(validation.NormalizeMAC(mac)), ":", "")
+}
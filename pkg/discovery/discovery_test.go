@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/resource"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/internal/storage"
+	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+func init() {
+	resource.RegisterResourcePrefix("Node", "node")
+}
+
+func TestRegister(t *testing.T) {
+	if err := storage.InitFileBackend(t.TempDir()); err != nil {
+		t.Fatalf("failed to initialize storage: %v", err)
+	}
+	ctx := context.Background()
+	api := client.NewInProcessClient()
+	registrar := NewRegistrar(api, Config{}, log.New(io.Discard, "", 0))
+
+	node, err := registrar.Register(ctx, "AA-BB-CC-DD-EE-99", "4C4C4544-0042-3510-8052-B4C04F4E4D32", "10.1.0.50")
+	if err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if node.Metadata.Name != "discovered-aabbccddee99" || !node.Discovered() || node.Status.State != v1.NodeStateDiscovered {
+		t.Errorf("node = %+v, want a discovered node", node)
+	}
+	if node.Spec.XName != "" || node.Spec.UUID != "4c4c4544-0042-3510-8052-b4c04f4e4d32" ||
+		len(node.Spec.Interfaces) != 1 || node.Spec.Interfaces[0].IP != "10.1.0.50" {
+		t.Errorf("spec = %+v, want the UUID and address of the request", node.Spec)
+	}
+
+	// A retry returns the node instead of creating another
+	again, err := registrar.Register(ctx, "aa:bb:cc:dd:ee:99", "", "10.1.0.50")
+	if err != nil || again.Metadata.UID != node.Metadata.UID {
+		t.Errorf("second Register = %+v, %v; want the same node", again, err)
+	}
+
+	// What the request claims is only kept when it is valid
+	other, err := registrar.Register(ctx, "aa:bb:cc:dd:ee:98", "not-a-uuid", "somewhere")
+	if err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if other.Spec.UUID != "" || len(other.Spec.Interfaces) != 0 {
+		t.Errorf("spec = %+v, want the invalid UUID and address dropped", other.Spec)
+	}
+
+	if _, err := registrar.Register(ctx, "bogus", "", ""); err == nil {
+		t.Error("Register accepted an invalid MAC")
+	}
+	nodes, _ := api.GetNodes(ctx)
+	if len(nodes) != 2 {
+		t.Errorf("got %d nodes, want 2", len(nodes))
+	}
+}
+
+func TestRegister_KnownNodes(t *testing.T) {
+	if err := storage.InitFileBackend(t.TempDir()); err != nil {
+		t.Fatalf("failed to initialize storage: %v", err)
+	}
+	ctx := context.Background()
+	api := client.NewInProcessClient()
+	existing, err := api.CreateNode(ctx, client.CreateNodeRequest{
+		Spec: v1.NodeSpec{XName: "x0c0s0b0n0", BootMAC: "aa:bb:cc:dd:ee:01"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	registrar := NewRegistrar(api, Config{}, log.New(io.Discard, "", 0))
+	if err := registrar.Load(ctx); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if node, err := registrar.Register(ctx, "AA:BB:CC:DD:EE:01", "", ""); err != nil || node.Metadata.UID != existing.Metadata.UID {
+		t.Errorf("Register of a loaded node = %+v, %v; want %s", node, err, existing.Metadata.UID)
+	}
+
+	// A node stored after Load is indexed from its write
+	added, err := api.CreateNode(ctx, client.CreateNodeRequest{
+		Spec: v1.NodeSpec{XName: "x0c0s0b0n1", Interfaces: []v1.NodeInterface{{MAC: "aa:bb:cc:dd:ee:02"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(added)
+	registrar.HandleResourceChange(ctx, resourcewatch.Event{Type: resourcewatch.Created, ResourceType: "Node", UID: added.Metadata.UID, New: data})
+	if node, err := registrar.Register(ctx, "aa:bb:cc:dd:ee:02", "", ""); err != nil || node.Metadata.UID != added.Metadata.UID {
+		t.Errorf("Register of a written node = %+v, %v; want %s", node, err, added.Metadata.UID)
+	}
+	nodes, _ := api.GetNodes(ctx)
+	if len(nodes) != 2 {
+		t.Errorf("got %d nodes, want 2", len(nodes))
+	}
+}
+
+func TestRegister_Limits(t *testing.T) {
+	if err := storage.InitFileBackend(t.TempDir()); err != nil {
+		t.Fatalf("failed to initialize storage: %v", err)
+	}
+	ctx := context.Background()
+	api := client.NewInProcessClient()
+	networks, _ := httputil.ParseNetworks([]string{"10.1.0.0/16"})
+	registrar := NewRegistrar(api, Config{Networks: networks, Limit: 1}, log.New(io.Discard, "", 0))
+
+	if _, err := registrar.Register(ctx, "aa:bb:cc:dd:ee:01", "", "192.168.0.5"); !errors.Is(err, ErrSource) {
+		t.Errorf("Register from outside the networks error = %v, want ErrSource", err)
+	}
+	if _, err := registrar.Register(ctx, "aa:bb:cc:dd:ee:01", "", ""); !errors.Is(err, ErrSource) {
+		t.Errorf("Register without an address error = %v, want ErrSource", err)
+	}
+	node, err := registrar.Register(ctx, "aa:bb:cc:dd:ee:01", "", "10.1.0.5")
+	if err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if _, err := registrar.Register(ctx, "aa:bb:cc:dd:ee:02", "", "10.1.0.6"); !errors.Is(err, ErrLimit) {
+		t.Errorf("Register past the limit error = %v, want ErrLimit", err)
+	}
+	// Nodes already registered are still returned
+	if again, err := registrar.Register(ctx, "aa:bb:cc:dd:ee:01", "", "10.1.0.5"); err != nil || again.Metadata.UID != node.Metadata.UID {
+		t.Errorf("second Register = %+v, %v; want the same node", again, err)
+	}
+
+	// Adopting the node makes room for another
+	delete(node.Metadata.Labels, v1.DiscoveredLabel)
+	node.Spec.XName = "x0c0s0b0n0"
+	data, _ := json.Marshal(node)
+	registrar.HandleResourceChange(ctx, resourcewatch.Event{Type: resourcewatch.Updated, ResourceType: "Node", UID: node.Metadata.UID, New: data})
+	if _, err := registrar.Register(ctx, "aa:bb:cc:dd:ee:02", "", "10.1.0.6"); err != nil {
+		t.Errorf("Register after adoption returned error: %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package fallback stores the fallback policy, which says what unknown nodes
+// and nodes without a matching boot configuration boot: the minimal script,
+// a named configuration such as a rescue image, or another iPXE script.
+package fallback
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// ResourceType is the storage resource type of the fallback policy
+const ResourceType = "FallbackPolicy"
+
+// policyKey is the storage key of the single fallback policy record
+const policyKey = "policy"
+
+// ErrInvalidPolicy is returned for a policy that cannot be applied
+var ErrInvalidPolicy = errors.New("invalid fallback policy")
+
+// Policy is the fallback policy. Without a rule for a node, known nodes get
+// the minimal script and unknown nodes the error script.
+type Policy struct {
+	// Default applies to unknown nodes and to nodes whose role has no rule
+	Default *bootscript.FallbackRule `json:"default,omitempty"`
+	// Roles applies to known nodes by role, compared case-insensitively
+	Roles map[string]bootscript.FallbackRule `json:"roles,omitempty"`
+	// UpdatedBy is the token subject that last changed the policy, if known
+	UpdatedBy string    `json:"updatedBy,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitzero"`
+	// Revision increases with every change
+	Revision uint64 `json:"revision"`
+}
+
+// Validate checks every rule of the policy
+func (p Policy) Validate() error {
+	if p.Default != nil {
+		if err := p.Default.Validate(); err != nil {
+			return fmt.Errorf("%w: default: %w", ErrInvalidPolicy, err)
+		}
+	}
+	seen := make(map[string]string, len(p.Roles))
+	for role, rule := range p.Roles {
+		if role == "" {
+			return fmt.Errorf("%w: empty role name", ErrInvalidPolicy)
+		}
+		if other, ok := seen[strings.ToLower(role)]; ok {
+			return fmt.Errorf("%w: roles %q and %q differ only in case", ErrInvalidPolicy, other, role)
+		}
+		seen[strings.ToLower(role)] = role
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("%w: role %s: %w", ErrInvalidPolicy, role, err)
+		}
+	}
+	return nil
+}
+
+// IsZero reports whether the policy has no rules
+func (p Policy) IsZero() bool {
+	return p.Default == nil && len(p.Roles) == 0
+}
+
+// Rule returns the rule for node, nil for an unknown node, and its name:
+// "role:<role>" or "default"
+func (p Policy) Rule(node *apiv1.Node) (bootscript.FallbackRule, string, bool) {
+	if node != nil && node.Spec.Role != "" {
+		for role, rule := range p.Roles {
+			if strings.EqualFold(role, node.Spec.Role) {
+				return rule, "role:" + role, true
+			}
+		}
+	}
+	if p.Default != nil {
+		return *p.Default, bootscript.FallbackRuleDefault, true
+	}
+	return bootscript.FallbackRule{}, "", false
+}
+
+// Store holds the fallback policy in memory and persists it in the resource
+// storage backend, so it survives restarts. The policy other replicas set
+// reaches the store through HandleResourceChange.
+type Store struct {
+	backend fabricaStorage.StorageBackend
+	logger  *log.Logger
+
+	mu     sync.RWMutex
+	policy Policy
+}
+
+// NewStore creates a fallback policy store persisted in backend. Call Load
+// to restore the stored policy.
+func NewStore(backend fabricaStorage.StorageBackend, logger *log.Logger) *Store {
+	return &Store{backend: backend, logger: logger}
+}
+
+// Load restores the stored policy. A missing policy keeps the built-in
+// behavior.
+func (s *Store) Load(ctx context.Context) error {
+	data, err := s.backend.Load(ctx, ResourceType, policyKey)
+	if errors.Is(err, fabricaStorage.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("loading fallback policy: %w", err)
+	}
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return fmt.Errorf("decoding fallback policy: %w", err)
+	}
+
+	s.mu.Lock()
+	s.policy = policy
+	s.mu.Unlock()
+	if !policy.IsZero() {
+		s.logger.Printf("Fallback policy loaded (revision %d, %d role rules)", policy.Revision, len(policy.Roles))
+	}
+	return nil
+}
+
+// Policy returns the current policy
+func (s *Store) Policy() Policy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clonePolicy(s.policy)
+}
+
+// Set validates, applies, and stores a new policy, replacing the old one
+func (s *Store) Set(ctx context.Context, policy Policy) (Policy, error) {
+	if err := policy.Validate(); err != nil {
+		return Policy{}, err
+	}
+	policy = clonePolicy(policy)
+	policy.UpdatedAt = time.Now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	policy.Revision = s.policy.Revision + 1
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return Policy{}, fmt.Errorf("encoding fallback policy: %w", err)
+	}
+	if err := s.backend.Save(ctx, ResourceType, policyKey, data); err != nil {
+		return Policy{}, fmt.Errorf("saving fallback policy: %w", err)
+	}
+	s.policy = policy
+
+	if policy.IsZero() {
+		s.logger.Printf("Fallback policy cleared by %q", policy.UpdatedBy)
+	} else {
+		s.logger.Printf("Fallback policy updated by %q (revision %d, %d role rules)", policy.UpdatedBy, policy.Revision, len(policy.Roles))
+	}
+	return clonePolicy(policy), nil
+}
+
+// HandleResourceChange follows the policy other replicas set
+func (s *Store) HandleResourceChange(ctx context.Context, event resourcewatch.Event) {
+	// The store's own writes are already applied
+	if event.ResourceType != ResourceType || event.UID != policyKey || !resourcewatch.Remote(ctx) {
+		return
+	}
+	var policy Policy
+	if event.New != nil {
+		if err := json.Unmarshal(event.New, &policy); err != nil {
+			s.logger.Printf("Ignoring undecodable fallback policy: %v", err)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	if event.New == nil {
+		policy.Revision = s.policy.Revision + 1
+	}
+	s.policy = policy
+	s.mu.Unlock()
+	s.logger.Printf("Fallback policy updated on another replica (revision %d, %d role rules)", policy.Revision, len(policy.Roles))
+}
+
+// Fallback returns the rule for node. It implements
+// bootscript.FallbackPolicy.
+func (s *Store) Fallback(node *apiv1.Node) (bootscript.FallbackRule, string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.policy.Rule(node)
+}
+
+func clonePolicy(policy Policy) Policy {
+	if policy.Default != nil {
+		rule := *policy.Default
+		policy.Default = &rule
+	}
+	policy.Roles = maps.Clone(policy.Roles)
+	return policy
+}
@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package fallback
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+func newTestStore(t *testing.T) (*Store, fabricaStorage.StorageBackend) {
+	t.Helper()
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	return NewStore(backend, log.New(io.Discard, "", 0)), backend
+}
+
+func nodeWithRole(role string) *apiv1.Node {
+	node := &apiv1.Node{}
+	node.Spec.XName = "x1000c0s0b0n0"
+	node.Spec.Role = role
+	return node
+}
+
+func testPolicy() Policy {
+	return Policy{
+		Default: &bootscript.FallbackRule{Action: bootscript.FallbackChain, ChainURL: "http://discovery.example.com/boot.ipxe"},
+		Roles: map[string]bootscript.FallbackRule{
+			"Compute":    {Action: bootscript.FallbackConfiguration, Configuration: "compute-default"},
+			"Management": {Action: bootscript.FallbackConfiguration, Configuration: "rescue"},
+		},
+	}
+}
+
+func TestStore_SetPersists(t *testing.T) {
+	store, backend := newTestStore(t)
+	ctx := context.Background()
+
+	policy, err := store.Set(ctx, testPolicy())
+	if err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if policy.Revision != 1 || policy.UpdatedAt.IsZero() {
+		t.Errorf("Revision = %d, UpdatedAt = %v; want revision 1 and a time", policy.Revision, policy.UpdatedAt)
+	}
+
+	restored := NewStore(backend, log.New(io.Discard, "", 0))
+	if err := restored.Load(ctx); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	got := restored.Policy()
+	if got.Default == nil || got.Default.ChainURL != "http://discovery.example.com/boot.ipxe" || len(got.Roles) != 2 {
+		t.Errorf("restored policy = %+v, want the stored one", got)
+	}
+
+	cleared, err := restored.Set(ctx, Policy{})
+	if err != nil {
+		t.Fatalf("clearing returned error: %v", err)
+	}
+	if !cleared.IsZero() || cleared.Revision != 2 {
+		t.Errorf("cleared policy = %+v, want no rules with revision 2", cleared)
+	}
+}
+
+// fakeSource reports the writes of shared storage when told to
+type fakeSource struct {
+	fn resourcewatch.Subscriber
+}
+
+func (s *fakeSource) Watch(_ context.Context, fn resourcewatch.Subscriber) error {
+	s.fn = fn
+	return nil
+}
+
+func TestStore_FollowsOtherReplicas(t *testing.T) {
+	ctx := context.Background()
+	files, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	// Two replicas share storage, which reports each one's writes to the
+	// other
+	source := &fakeSource{}
+	local, remote := resourcewatch.NewBackend(files), resourcewatch.NewBackend(files)
+	if err := remote.Follow(ctx, source); err != nil {
+		t.Fatalf("Follow returned error: %v", err)
+	}
+	local.Subscribe(func(ctx context.Context, event resourcewatch.Event) { source.fn(ctx, event) })
+	store := NewStore(local, log.New(io.Discard, "", 0))
+	replica := NewStore(remote, log.New(io.Discard, "", 0))
+	remote.Subscribe(replica.HandleResourceChange)
+
+	if _, err := store.Set(ctx, testPolicy()); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	rule, _, ok := replica.Fallback(nodeWithRole("Management"))
+	if !ok || rule.Configuration != "rescue" {
+		t.Errorf("Fallback on another replica = %+v, %v; want rescue", rule, ok)
+	}
+	if replica.Policy().Revision != 1 {
+		t.Errorf("Revision on another replica = %d, want 1", replica.Policy().Revision)
+	}
+
+	if _, err := store.Set(ctx, Policy{}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if _, _, ok := replica.Fallback(nodeWithRole("Management")); ok {
+		t.Error("cleared policy still applies on another replica")
+	}
+}
+
+func TestStore_LoadWithoutPolicy(t *testing.T) {
+	store, _ := newTestStore(t)
+	if err := store.Load(context.Background()); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if _, _, ok := store.Fallback(nil); ok {
+		t.Error("expected the built-in behavior without a stored policy")
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	invalid := map[string]Policy{
+		"bad default":  {Default: &bootscript.FallbackRule{Action: "reboot"}},
+		"bad role":     {Roles: map[string]bootscript.FallbackRule{"Compute": {Action: bootscript.FallbackChain}}},
+		"empty role":   {Roles: map[string]bootscript.FallbackRule{"": {Action: bootscript.FallbackMinimal}}},
+		"case clashes": {Roles: map[string]bootscript.FallbackRule{"Compute": {Action: bootscript.FallbackMinimal}, "compute": {Action: bootscript.FallbackMinimal}}},
+	}
+	for name, policy := range invalid {
+		if err := policy.Validate(); !errors.Is(err, ErrInvalidPolicy) {
+			t.Errorf("%s: Validate error = %v, want ErrInvalidPolicy", name, err)
+		}
+	}
+	if err := testPolicy().Validate(); err != nil {
+		t.Errorf("Validate returned error for a valid policy: %v", err)
+	}
+}
+
+func TestPolicy_Rule(t *testing.T) {
+	policy := testPolicy()
+
+	rule, name, ok := policy.Rule(nodeWithRole("management"))
+	if !ok || name != "role:Management" || rule.Configuration != "rescue" {
+		t.Errorf("Rule(management) = %+v, %q, %v; want the Management rule", rule, name, ok)
+	}
+	for _, node := range []*apiv1.Node{nil, nodeWithRole(""), nodeWithRole("Storage")} {
+		if rule, name, ok := policy.Rule(node); !ok || name != bootscript.FallbackRuleDefault || rule.Action != bootscript.FallbackChain {
+			t.Errorf("Rule(%v) = %+v, %q, %v; want the default rule", node, rule, name, ok)
+		}
+	}
+
+	policy.Default = nil
+	if _, _, ok := policy.Rule(nodeWithRole("Storage")); ok {
+		t.Error("expected no rule for a role without one and no default")
+	}
+}
+
+func serveFallback(t *testing.T, store *Store, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	router := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(router)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_PutAndDelete(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	body := `{"default": {"action": "minimal"}, "roles": {"Management": {"action": "configuration", "configuration": "rescue"}}}`
+	rec := serveFallback(t, store, httptest.NewRequest(http.MethodPut, Path, strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d, body %s", rec.Code, rec.Body.String())
+	}
+	if _, name, ok := store.Fallback(nodeWithRole("Management")); !ok || name != "role:Management" {
+		t.Errorf("Fallback = %q, %v; want the Management rule", name, ok)
+	}
+
+	rec = serveFallback(t, store, httptest.NewRequest(http.MethodGet, Path, nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"configuration":"rescue"`) {
+		t.Errorf("GET = %d %s, want the stored policy", rec.Code, rec.Body.String())
+	}
+
+	rec = serveFallback(t, store, httptest.NewRequest(http.MethodDelete, Path, nil))
+	if rec.Code != http.StatusOK || !store.Policy().IsZero() {
+		t.Errorf("DELETE = %d, policy %+v; want no rules", rec.Code, store.Policy())
+	}
+}
+
+func TestHandler_RejectsInvalidPolicy(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	for _, body := range []string{
+		`{"default": {"action": "chain"}}`,
+		`{"defualt": {"action": "minimal"}}`,
+	} {
+		rec := serveFallback(t, store, httptest.NewRequest(http.MethodPut, Path, strings.NewReader(body)))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("PUT %s status = %d, want 400", body, rec.Code)
+		}
+	}
+	if store.Policy().Revision != 0 {
+		t.Error("expected the policy to stay unchanged")
+	}
+}
+
+func TestHandler_RefusesTenants(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	req := httptest.NewRequest(http.MethodPut, Path, strings.NewReader(`{"default": {"action": "minimal"}}`))
+	req = req.WithContext(tenancy.WithTenant(req.Context(), "blue"))
+	if rec := serveFallback(t, store, req); rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", rec.Code)
+	}
+}
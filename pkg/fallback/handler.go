@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package fallback
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/audit"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Path serves the fallback policy
+const Path = "/admin/fallback-policy"
+
+// maxRequestSize bounds the body of a policy update
+const maxRequestSize = 64 << 10
+
+// Handler serves the fallback policy API
+type Handler struct {
+	store *Store
+}
+
+// NewHandler creates a fallback policy API handler
+func NewHandler(store *Store) *Handler {
+	return &Handler{store: store}
+}
+
+// RegisterRoutes registers GET, PUT, and DELETE /admin/fallback-policy
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route(Path, func(r chi.Router) {
+		r.Use(tenancy.Unscoped)
+		r.Get("/", h.GetPolicy)
+		r.Put("/", h.PutPolicy)
+		r.Delete("/", h.DeletePolicy)
+	})
+}
+
+// GetPolicy handles GET /admin/fallback-policy
+func (h *Handler) GetPolicy(w http.ResponseWriter, r *http.Request) { //nolint:revive
+	httputil.WriteJSON(w, http.StatusOK, h.store.Policy())
+}
+
+// PutPolicy handles PUT /admin/fallback-policy, which replaces the policy
+// with the body
+func (h *Handler) PutPolicy(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	decoder.DisallowUnknownFields()
+	var policy Policy
+	if err := decoder.Decode(&policy); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid fallback policy", err.Error())
+		return
+	}
+	h.set(w, r, Policy{Default: policy.Default, Roles: policy.Roles})
+}
+
+// DeletePolicy handles DELETE /admin/fallback-policy, which restores the
+// built-in behavior
+func (h *Handler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	h.set(w, r, Policy{})
+}
+
+func (h *Handler) set(w http.ResponseWriter, r *http.Request, policy Policy) {
+	if actor, ok := audit.ActorFromContext(r.Context()); ok && actor.Subject != "" && actor.Subject != audit.AnonymousSubject {
+		policy.UpdatedBy = actor.Subject
+	}
+	policy, err := h.store.Set(r.Context(), policy)
+	if errors.Is(err, ErrInvalidPolicy) {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid fallback policy", err.Error())
+		return
+	}
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to update fallback policy", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, policy)
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package gitops keeps nodes and boot configurations in step with YAML files
+// in a Git repository, so changes to boot state can be reviewed through pull
+// requests.
+//
+// A Syncer pulls the repository on an interval and compares the resources
+// declared in its files with storage. The differences are reported as drift
+// and, in apply mode, written. Declared resources are matched to stored ones
+// by UID when the file sets metadata.uid, and otherwise by the xname of a
+// node or the name of a boot configuration. Resources written by a sync are
+// annotated as managed, and with pruning, managed resources removed from the
+// repository are deleted; resources the repository never declared are left
+// alone.
+package gitops
+
+import (
+	"context"
+	"log"
+	"path/filepath"
+	"sync"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/openchami/boot-service/pkg/declarative"
+)
+
+// managedBy is the manager recorded on resources written by a sync, and the
+// audit subject of its writes
+const managedBy = "gitops"
+
+// Modes
+const (
+	// ModeApply writes the drift found by each sync
+	ModeApply = "apply"
+	// ModeReport only reports it
+	ModeReport = "report"
+)
+
+// Status reports the last sync
+type Status struct {
+	Repository string    `json:"repository"`
+	Branch     string    `json:"branch"`
+	Path       string    `json:"path,omitempty"`
+	Mode       string    `json:"mode"`
+	Prune      bool      `json:"prune"`
+	Commit     string    `json:"commit,omitempty"`
+	SyncedAt   time.Time `json:"syncedAt,omitzero"`
+	// Drift lists the changes a report-mode sync found, or those an
+	// apply-mode sync made
+	Drift []declarative.Change `json:"drift"`
+	Error string               `json:"error,omitempty"`
+}
+
+// Options controls what a sync reads and writes
+type Options struct {
+	// Path is the directory of the repository holding resource files
+	Path string
+	// Mode is ModeApply or ModeReport
+	Mode string
+	// Prune deletes managed resources no longer declared
+	Prune bool
+}
+
+// Syncer syncs storage from a repository
+type Syncer struct {
+	repo    *Repo
+	backend fabricaStorage.StorageBackend
+	opts    Options
+	logger  *log.Logger
+	now     func() time.Time
+
+	// syncMu serializes syncs
+	syncMu sync.Mutex
+
+	mu     sync.RWMutex
+	status Status
+}
+
+// NewSyncer creates a syncer that reads repo and writes through backend,
+// which should be the API backend so syncs are watched and audited like
+// other writes
+func NewSyncer(repo *Repo, backend fabricaStorage.StorageBackend, opts Options, logger *log.Logger) *Syncer {
+	if opts.Mode == "" {
+		opts.Mode = ModeApply
+	}
+	s := &Syncer{repo: repo, backend: backend, opts: opts, logger: logger, now: time.Now}
+	s.status = s.newStatus()
+	return s
+}
+
+// Status returns the result of the last sync
+func (s *Syncer) Status() Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status
+}
+
+// Sync pulls the repository and reconciles storage with it
+func (s *Syncer) Sync(ctx context.Context) (Status, error) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	status := s.newStatus()
+	changes, err := s.sync(ctx, &status)
+	status.SyncedAt = s.now().UTC()
+	status.Drift = changes
+	if err != nil {
+		status.Error = err.Error()
+	}
+	s.mu.Lock()
+	s.status = status
+	s.mu.Unlock()
+	return status, err
+}
+
+func (s *Syncer) sync(ctx context.Context, status *Status) ([]declarative.Change, error) {
+	commit, err := s.repo.Pull(ctx)
+	if err != nil {
+		return []declarative.Change{}, err
+	}
+	status.Commit = commit
+	declared, err := load(filepath.Join(s.repo.Dir(), s.opts.Path))
+	if err != nil {
+		return []declarative.Change{}, err
+	}
+	return declarative.Reconcile(ctx, s.backend, declared, declarative.Options{
+		Manager:  managedBy,
+		Revision: commit,
+		Apply:    s.opts.Mode == ModeApply,
+		Prune:    s.opts.Prune,
+	}, s.logger)
+}
+
+// Run syncs every interval until ctx is done
+func (s *Syncer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		status, err := s.Sync(ctx)
+		switch {
+		case err != nil:
+			s.logger.Printf("Sync failed: %v", err)
+		case len(status.Drift) > 0 && s.opts.Mode == ModeApply:
+			s.logger.Printf("Applied %d changes from %s", len(status.Drift), status.Commit)
+		case len(status.Drift) > 0:
+			s.logger.Printf("Storage has drifted from %s by %d changes", status.Commit, len(status.Drift))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Syncer) newStatus() Status {
+	return Status{
+		Repository: s.repo.Redacted(),
+		Branch:     s.repo.config.Branch,
+		Path:       s.opts.Path,
+		Mode:       s.opts.Mode,
+		Prune:      s.opts.Prune,
+		Drift:      []declarative.Change{},
+	}
+}
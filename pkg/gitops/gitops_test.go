@@ -0,0 +1,277 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package gitops
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	gitobject "github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/openchami/fabrica/pkg/resource"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/declarative"
+)
+
+func init() {
+	resource.RegisterResourcePrefix("Node", "node")
+	resource.RegisterResourcePrefix("BootConfiguration", "bc")
+}
+
+const nodesFile = `kind: Node
+metadata:
+  name: x1000c0s0b0n0
+spec:
+  xname: x1000c0s0b0n0
+  nid: 1
+  bootMac: AA:BB:CC:DD:EE:01
+---
+kind: Node
+metadata:
+  name: x1000c0s0b0n1
+spec:
+  xname: x1000c0s0b0n1
+  nid: 2
+  bootMac: aa:bb:cc:dd:ee:02
+`
+
+const configsFile = `kind: BootConfiguration
+metadata:
+  name: compute
+  labels:
+    team: hpc
+spec:
+  groups: [compute]
+  kernel: http://images/vmlinuz
+  params: console=ttyS0
+`
+
+// testRepo is a Git repository on disk that tests commit resource files to
+type testRepo struct {
+	t        *testing.T
+	dir      string
+	worktree *git.Worktree
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := git.PlainInitWithOptions(dir, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName("main")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testRepo{t: t, dir: dir, worktree: worktree}
+}
+
+// commit writes files, removing those with empty contents, and commits them
+func (r *testRepo) commit(files map[string]string) {
+	r.t.Helper()
+	for name, contents := range files {
+		path := filepath.Join(r.dir, name)
+		if contents == "" {
+			if err := os.Remove(path); err != nil {
+				r.t.Fatal(err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			r.t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			r.t.Fatal(err)
+		}
+	}
+	if err := r.worktree.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		r.t.Fatal(err)
+	}
+	signature := &gitobject.Signature{Name: "test", Email: "test@example.com", When: time.Now()}
+	if _, err := r.worktree.Commit("update", &git.CommitOptions{Author: signature}); err != nil {
+		r.t.Fatal(err)
+	}
+}
+
+func newTestSyncer(t *testing.T, repo *testRepo, opts Options) (*Syncer, fabricaStorage.StorageBackend) {
+	t.Helper()
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	checkout, err := NewRepo(RepoConfig{URL: repo.dir, Branch: "main", Dir: filepath.Join(t.TempDir(), "checkout")})
+	if err != nil {
+		t.Fatalf("NewRepo returned error: %v", err)
+	}
+	return NewSyncer(checkout, backend, opts, log.New(io.Discard, "", 0)), backend
+}
+
+func actions(changes []declarative.Change) []string {
+	out := make([]string, 0, len(changes))
+	for _, change := range changes {
+		out = append(out, change.Action+" "+change.Kind+" "+change.Name)
+	}
+	return out
+}
+
+func loadNode(t *testing.T, backend fabricaStorage.StorageBackend, uid string) v1.Node {
+	t.Helper()
+	data, err := backend.Load(context.Background(), declarative.NodeKind, uid)
+	if err != nil {
+		t.Fatalf("failed to load node %s: %v", uid, err)
+	}
+	var node v1.Node
+	if err := json.Unmarshal(data, &node); err != nil {
+		t.Fatal(err)
+	}
+	return node
+}
+
+func TestSyncer_ApplyAndPrune(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	repo.commit(map[string]string{"site/nodes.yaml": nodesFile, "site/configs.yml": configsFile, "README.md": "# Boot state"})
+	syncer, backend := newTestSyncer(t, repo, Options{Path: "site", Mode: ModeApply, Prune: true})
+
+	// A node created through the API is left alone
+	unmanaged := `{"kind":"Node","metadata":{"uid":"node-api","name":"x1000c0s1b0n0"},"spec":{"xname":"x1000c0s1b0n0"}}`
+	if err := backend.Save(ctx, declarative.NodeKind, "node-api", []byte(unmanaged)); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := syncer.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+	want := "create Node x1000c0s0b0n0,create Node x1000c0s0b0n1,create BootConfiguration compute"
+	if got := strings.Join(actions(status.Drift), ","); got != want {
+		t.Errorf("first sync = %s, want %s", got, want)
+	}
+	if len(status.Commit) != 40 {
+		t.Errorf("status commit = %q, want a commit hash", status.Commit)
+	}
+	node := loadNode(t, backend, status.Drift[0].UID)
+	if node.Spec.BootMAC != "aa:bb:cc:dd:ee:01" || node.Metadata.Annotations[declarative.SourceAnnotation] != "nodes.yaml" {
+		t.Errorf("created node = %+v", node)
+	}
+
+	// Normalized fields are not drift
+	status, err = syncer.Sync(ctx)
+	if err != nil || len(status.Drift) != 0 {
+		t.Fatalf("second sync = %v, %v; want no drift", actions(status.Drift), err)
+	}
+
+	// Writes made around the repository are reverted
+	node.Spec.NID = 99
+	data, _ := json.Marshal(node)
+	if err := backend.Save(ctx, declarative.NodeKind, node.Metadata.UID, data); err != nil {
+		t.Fatal(err)
+	}
+	status, err = syncer.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+	if got := strings.Join(actions(status.Drift), ","); got != "update Node x1000c0s0b0n0" {
+		t.Errorf("sync after an API write = %s, want the node updated", got)
+	}
+	if node := loadNode(t, backend, node.Metadata.UID); node.Spec.NID != 1 {
+		t.Errorf("node NID = %d, want the declared 1", node.Spec.NID)
+	}
+
+	// Resources removed from the repository are pruned
+	repo.commit(map[string]string{"site/configs.yml": ""})
+	status, err = syncer.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+	if got := strings.Join(actions(status.Drift), ","); got != "delete BootConfiguration compute" {
+		t.Errorf("sync after removing a file = %s, want the configuration deleted", got)
+	}
+	if configs, _ := backend.List(ctx, declarative.BootConfigurationKind); len(configs) != 0 {
+		t.Errorf("boot configurations after prune = %v, want none", configs)
+	}
+	if exists, _ := backend.Exists(ctx, declarative.NodeKind, "node-api"); !exists {
+		t.Error("prune deleted a node the repository never declared")
+	}
+}
+
+func TestSyncer_ReportMode(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	repo.commit(map[string]string{"nodes.yaml": nodesFile})
+	syncer, backend := newTestSyncer(t, repo, Options{Mode: ModeReport})
+
+	status, err := syncer.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+	if len(status.Drift) != 2 {
+		t.Errorf("drift = %v, want two nodes to create", actions(status.Drift))
+	}
+	if nodes, _ := backend.List(ctx, declarative.NodeKind); len(nodes) != 0 {
+		t.Errorf("report mode stored nodes: %v", nodes)
+	}
+	if got := syncer.Status(); got.Commit != status.Commit || len(got.Drift) != 2 {
+		t.Errorf("Status = %+v, want the last sync", got)
+	}
+}
+
+func TestSyncer_RejectsInvalidRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	repo.commit(map[string]string{
+		"nodes.yaml": nodesFile,
+		"more.yaml":  "kind: Node\nspec:\n  xname: x1000c0s0b0n0\n",
+		"typo.yaml":  "kind: BootConfiguration\nmetadata:\n  name: login\nspec:\n  kernal: http://images/vmlinuz\n",
+		"bmc.yaml":   "kind: BMC\nmetadata:\n  name: x1000c0s0b0\n",
+	})
+	syncer, backend := newTestSyncer(t, repo, Options{Mode: ModeApply})
+
+	_, err := syncer.Sync(ctx)
+	if err == nil {
+		t.Fatal("Sync accepted an invalid repository")
+	}
+	for _, problem := range []string{"x1000c0s0b0n0 is also declared", `unknown field "kernal"`, `unsupported kind "BMC"`} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("error %q does not report %s", err, problem)
+		}
+	}
+	if nodes, _ := backend.List(ctx, declarative.NodeKind); len(nodes) != 0 {
+		t.Errorf("an invalid repository was partly applied: %v", nodes)
+	}
+	if syncer.Status().Error == "" {
+		t.Error("Status does not report the failed sync")
+	}
+}
+
+func TestTransport(t *testing.T) {
+	tests := map[string]string{
+		"https://git.example.com/site/boot.git": TransportHTTP,
+		"ssh://git@git.example.com/site/boot":   TransportSSH,
+		"git@git.example.com:site/boot.git":     TransportSSH,
+		"/srv/git/boot":                         TransportFile,
+		"file:///srv/git/boot":                  TransportFile,
+		"ftp://git.example.com/boot":            "",
+		"boot":                                  "",
+	}
+	for repoURL, want := range tests {
+		if got := Transport(repoURL); got != want {
+			t.Errorf("Transport(%q) = %q, want %q", repoURL, got, want)
+		}
+	}
+}
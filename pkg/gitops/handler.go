@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package gitops
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Path serves the sync status
+const Path = "/admin/gitops"
+
+// Handler serves the GitOps API
+type Handler struct {
+	syncer *Syncer
+}
+
+// NewHandler creates a GitOps API handler
+func NewHandler(syncer *Syncer) *Handler {
+	return &Handler{syncer: syncer}
+}
+
+// RegisterRoutes registers GET /admin/gitops and POST /admin/gitops/sync
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route(Path, func(r chi.Router) {
+		r.Use(tenancy.Unscoped)
+		r.Get("/", h.GetStatus)
+		r.Post("/sync", h.Sync)
+	})
+}
+
+// GetStatus handles GET /admin/gitops, reporting the last sync
+func (h *Handler) GetStatus(w http.ResponseWriter, r *http.Request) { //nolint:revive
+	httputil.WriteJSON(w, http.StatusOK, h.syncer.Status())
+}
+
+// Sync handles POST /admin/gitops/sync, which syncs now instead of waiting
+// for the interval
+func (h *Handler) Sync(w http.ResponseWriter, r *http.Request) {
+	status, err := h.syncer.Sync(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Sync failed", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, status)
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package gitops
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/openchami/boot-service/pkg/declarative"
+)
+
+// load reads the nodes and boot configurations declared in the .yaml and
+// .yml files under root. A file may hold several resources as separate YAML
+// documents, each with a kind of Node or BootConfiguration. Every problem
+// found is returned, joined into one error, since a partial declaration
+// must not be applied.
+func load(root string) ([]*declarative.Resource, error) {
+	var objects []*declarative.Resource
+	var problems []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ext := filepath.Ext(path); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		source, _ := filepath.Rel(root, path)
+		found, fileProblems := loadFile(path, source)
+		objects = append(objects, found...)
+		problems = append(problems, fileProblems...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	problems = append(problems, declarative.Check(objects)...)
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid repository:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return objects, nil
+}
+
+// loadFile reads the resources in one file and the problems found in it
+func loadFile(path, source string) ([]*declarative.Resource, []string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("%s: %v", source, err)}
+	}
+	var objects []*declarative.Resource
+	var problems []string
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for index := 1; ; index++ {
+		var document map[string]any
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// The rest of the file cannot be read past a syntax error
+			problems = append(problems, fmt.Sprintf("%s: %v", source, err))
+			break
+		}
+		if document == nil {
+			continue
+		}
+		location := source
+		if index > 1 {
+			location = fmt.Sprintf("%s (document %d)", source, index)
+		}
+		kind, _ := document["kind"].(string)
+		encoded, err := json.Marshal(document)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", location, err))
+			continue
+		}
+		obj, err := declarative.Decode(kind, encoded, source)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", location, err))
+			continue
+		}
+		objects = append(objects, obj)
+	}
+	return objects, problems
+}
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package gitops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+// Transports a repository URL can use
+const (
+	TransportHTTP = "http"
+	TransportSSH  = "ssh"
+	TransportFile = "file"
+)
+
+// scpURL matches the scp-like ssh syntax, such as git@github.com:site/boot.git
+var scpURL = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^/]`)
+
+// Transport returns the transport of a repository URL, or "" when git would
+// not accept it
+func Transport(repoURL string) string {
+	switch {
+	case scpURL.MatchString(repoURL):
+		return TransportSSH
+	case filepath.IsAbs(repoURL):
+		return TransportFile
+	}
+	parsed, err := url.Parse(repoURL)
+	if err != nil {
+		return ""
+	}
+	switch parsed.Scheme {
+	case "http", "https":
+		if parsed.Host != "" {
+			return TransportHTTP
+		}
+	case "ssh":
+		if parsed.Host != "" {
+			return TransportSSH
+		}
+	case "file":
+		return TransportFile
+	}
+	return ""
+}
+
+// RepoConfig locates a repository and the credentials to read it
+type RepoConfig struct {
+	URL    string
+	Branch string
+	// Dir holds the checkout; a temporary directory when empty
+	Dir string
+
+	// SSHKeyFile authenticates ssh URLs; without it the ssh agent is used.
+	// SSHKnownHostsFile verifies the server, in place of ~/.ssh/known_hosts.
+	SSHKeyFile        string
+	SSHKnownHostsFile string
+
+	// Username and Password (or token) authenticate http(s) URLs
+	Username string
+	Password string
+}
+
+// Repo is a checkout of one branch of a repository. Git is spoken in
+// process, so no git installation is needed.
+type Repo struct {
+	config RepoConfig
+	auth   transport.AuthMethod
+}
+
+// NewRepo prepares a checkout of config.Branch of config.URL. Nothing is
+// fetched until Pull.
+func NewRepo(config RepoConfig) (*Repo, error) {
+	kind := Transport(config.URL)
+	if kind == "" {
+		return nil, fmt.Errorf("unsupported repository URL %q", config.URL)
+	}
+	if config.Branch == "" {
+		return nil, fmt.Errorf("a branch is required")
+	}
+	if config.Dir == "" {
+		dir, err := os.MkdirTemp("", "boot-service-gitops-")
+		if err != nil {
+			return nil, err
+		}
+		config.Dir = dir
+	}
+
+	repo := &Repo{config: config}
+	switch {
+	case kind == TransportHTTP && (config.Username != "" || config.Password != ""):
+		repo.auth = &githttp.BasicAuth{Username: config.Username, Password: config.Password}
+	case kind == TransportSSH && config.SSHKeyFile != "":
+		endpoint, err := transport.NewEndpoint(config.URL)
+		if err != nil {
+			return nil, err
+		}
+		user := endpoint.User
+		if user == "" {
+			user = "git"
+		}
+		keys, err := gitssh.NewPublicKeysFromFile(user, config.SSHKeyFile, "")
+		if err != nil {
+			return nil, fmt.Errorf("reading ssh key: %w", err)
+		}
+		if config.SSHKnownHostsFile != "" {
+			if keys.HostKeyCallback, err = gitssh.NewKnownHostsCallback(config.SSHKnownHostsFile); err != nil {
+				return nil, fmt.Errorf("reading known hosts: %w", err)
+			}
+		}
+		repo.auth = keys
+	}
+	return repo, nil
+}
+
+// Dir returns the directory of the checkout
+func (r *Repo) Dir() string {
+	return r.config.Dir
+}
+
+// Pull brings the checkout to the tip of the branch, cloning it first if
+// needed, and returns the commit checked out
+func (r *Repo) Pull(ctx context.Context) (string, error) {
+	branch := plumbing.NewBranchReferenceName(r.config.Branch)
+	remote := plumbing.NewRemoteReferenceName(git.DefaultRemoteName, r.config.Branch)
+
+	repo, err := git.PlainOpen(r.config.Dir)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		repo, err = git.PlainCloneContext(ctx, r.config.Dir, false, &git.CloneOptions{
+			URL:           r.config.URL,
+			Auth:          r.auth,
+			ReferenceName: branch,
+			SingleBranch:  true,
+		})
+		if err != nil {
+			return "", fmt.Errorf("cloning %s: %w", r.Redacted(), err)
+		}
+	} else if err != nil {
+		return "", err
+	} else {
+		err = repo.FetchContext(ctx, &git.FetchOptions{
+			RemoteName: git.DefaultRemoteName,
+			Auth:       r.auth,
+			RefSpecs:   []gitconfig.RefSpec{gitconfig.RefSpec("+" + branch + ":" + remote)},
+			Force:      true,
+		})
+		if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+			return "", fmt.Errorf("fetching %s: %w", r.Redacted(), err)
+		}
+	}
+
+	ref, err := repo.Reference(remote, true)
+	if err != nil {
+		return "", fmt.Errorf("branch %s: %w", r.config.Branch, err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+	// Discard anything but the fetched commit
+	if err := worktree.Reset(&git.ResetOptions{Commit: ref.Hash(), Mode: git.HardReset}); err != nil {
+		return "", err
+	}
+	if err := worktree.Clean(&git.CleanOptions{Dir: true}); err != nil {
+		return "", err
+	}
+	return ref.Hash().String(), nil
+}
+
+// Redacted returns the repository URL without any password it embeds
+func (r *Repo) Redacted() string {
+	if parsed, err := url.Parse(r.config.URL); err == nil && parsed.User != nil {
+		return parsed.Redacted()
+	}
+	return r.config.URL
+}
@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package groupsync keeps node group memberships in step with the groups of
+// the OpenCHAMI inventory service (the HSM groups API). Boot configurations
+// that target groups then follow group changes made in the inventory
+// without a full node sync.
+//
+// Groups set on a node locally are kept: a sync only adds and removes the
+// groups the inventory put there, which it records in InventoryAnnotation.
+package groupsync
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/clients/hsm"
+)
+
+// InventoryAnnotation records the groups of a node that came from the
+// inventory at the last sync, comma separated
+const InventoryAnnotation = "boot.openchami.io/inventory-groups"
+
+// GroupSource lists inventory groups. *hsm.HSMClient implements it.
+type GroupSource interface {
+	GetGroups(ctx context.Context) ([]hsm.HSMGroup, error)
+}
+
+// NodeStore lists and updates nodes. client.API implements it.
+type NodeStore interface {
+	GetNodes(ctx context.Context) ([]v1.Node, error)
+	UpdateNode(ctx context.Context, uid string, req client.UpdateNodeRequest) (*v1.Node, error)
+}
+
+// Status reports the last group sync
+type Status struct {
+	Interval string    `json:"interval"`
+	Runs     int       `json:"runs"`             // syncs since startup
+	LastRun  time.Time `json:"lastRun,omitzero"` // when the last sync started
+	Duration string    `json:"duration,omitempty"`
+	Groups   int       `json:"groups"` // inventory groups seen
+	Updated  int       `json:"updated"`
+	Skipped  int       `json:"skipped"`
+	Failed   int       `json:"failed"` // nodes that could not be updated
+	Error    string    `json:"error,omitempty"`
+}
+
+// Syncer copies inventory group memberships to nodes
+type Syncer struct {
+	groups   GroupSource
+	nodes    NodeStore
+	interval time.Duration
+	logger   *log.Logger
+
+	// syncMu serializes syncs; mu guards last
+	syncMu sync.Mutex
+	mu     sync.Mutex
+	last   Status
+}
+
+// NewSyncer creates a syncer that syncs every interval once started
+func NewSyncer(groups GroupSource, nodes NodeStore, interval time.Duration, logger *log.Logger) *Syncer {
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	return &Syncer{groups: groups, nodes: nodes, interval: interval, logger: logger}
+}
+
+// Start syncs now and then every interval until ctx is done
+func (s *Syncer) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.Run(ctx); err != nil {
+			s.logger.Printf("Group sync failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// LastSync returns the outcome of the last sync
+func (s *Syncer) LastSync() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := s.last
+	status.Interval = s.interval.String()
+	return status
+}
+
+// Run syncs now and returns the outcome. A sync already running is waited
+// for first.
+func (s *Syncer) Run(ctx context.Context) (Status, error) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	start := time.Now()
+	status, err := s.sync(ctx)
+	status.LastRun = start
+	status.Duration = time.Since(start).Round(time.Millisecond).String()
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	s.mu.Lock()
+	status.Runs = s.last.Runs + 1
+	s.last = status
+	s.mu.Unlock()
+	status.Interval = s.interval.String()
+	return status, err
+}
+
+// sync runs one sync, counting what it did
+func (s *Syncer) sync(ctx context.Context) (Status, error) {
+	var status Status
+	groups, err := s.groups.GetGroups(ctx)
+	if err != nil {
+		return status, fmt.Errorf("listing inventory groups: %w", err)
+	}
+	status.Groups = len(groups)
+	members := memberships(groups)
+
+	nodes, err := s.nodes.GetNodes(ctx)
+	if err != nil {
+		return status, fmt.Errorf("listing nodes: %w", err)
+	}
+	for i := range nodes {
+		node := &nodes[i]
+		inventory := members[strings.ToLower(node.Spec.XName)]
+		merged := mergeGroups(node.Spec.Groups, previousGroups(node), inventory)
+		record := strings.Join(inventory, ",")
+		if slices.Equal(merged, node.Spec.Groups) && node.Metadata.Annotations[InventoryAnnotation] == record {
+			status.Skipped++
+			continue
+		}
+
+		spec := node.Spec
+		spec.Groups = merged
+		req := client.UpdateNodeRequest{
+			Spec:        spec,
+			Annotations: map[string]string{InventoryAnnotation: record},
+		}
+		if _, err := s.nodes.UpdateNode(ctx, node.Metadata.UID, req); err != nil {
+			s.logger.Printf("Warning: failed to update groups of node %s: %v", node.Spec.XName, err)
+			status.Failed++
+			continue
+		}
+		if !slices.Equal(merged, node.Spec.Groups) {
+			s.logger.Printf("Node %s groups changed from %v to %v", node.Spec.XName, node.Spec.Groups, merged)
+		}
+		status.Updated++
+	}
+
+	s.logger.Printf("Group sync complete: %d groups, %d nodes updated, %d unchanged, %d failed",
+		status.Groups, status.Updated, status.Skipped, status.Failed)
+	return status, nil
+}
+
+// memberships returns the sorted group labels of each member, by lower-case
+// xname
+func memberships(groups []hsm.HSMGroup) map[string][]string {
+	members := map[string][]string{}
+	for _, group := range groups {
+		if group.Label == "" {
+			continue
+		}
+		for _, id := range group.Members.IDs {
+			id = strings.ToLower(strings.TrimSpace(id))
+			if !slices.Contains(members[id], group.Label) {
+				members[id] = append(members[id], group.Label)
+			}
+		}
+	}
+	for _, labels := range members {
+		slices.Sort(labels)
+	}
+	return members
+}
+
+// previousGroups returns the groups the inventory gave node at the last sync
+func previousGroups(node *v1.Node) []string {
+	record := node.Metadata.Annotations[InventoryAnnotation]
+	if record == "" {
+		return nil
+	}
+	return strings.Split(record, ",")
+}
+
+// mergeGroups returns current without the groups the inventory gave before,
+// followed by the groups the inventory gives now. Groups set locally keep
+// their order.
+func mergeGroups(current, previous, inventory []string) []string {
+	merged := []string{}
+	for _, group := range current {
+		if !slices.Contains(previous, group) && !slices.Contains(merged, group) {
+			merged = append(merged, group)
+		}
+	}
+	for _, group := range inventory {
+		if !slices.Contains(merged, group) {
+			merged = append(merged, group)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package groupsync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/clients/hsm"
+)
+
+type fakeGroups struct {
+	groups []hsm.HSMGroup
+	err    error
+}
+
+func (f *fakeGroups) GetGroups(context.Context) ([]hsm.HSMGroup, error) {
+	return f.groups, f.err
+}
+
+// fakeNodes applies updates to its nodes, merging annotations as the node
+// store does
+type fakeNodes struct {
+	nodes   []v1.Node
+	updates int
+}
+
+func (f *fakeNodes) GetNodes(context.Context) ([]v1.Node, error) {
+	return slices.Clone(f.nodes), nil
+}
+
+func (f *fakeNodes) UpdateNode(_ context.Context, uid string, req client.UpdateNodeRequest) (*v1.Node, error) {
+	for i := range f.nodes {
+		if f.nodes[i].Metadata.UID == uid {
+			f.updates++
+			f.nodes[i].Spec = req.Spec
+			if f.nodes[i].Metadata.Annotations == nil {
+				f.nodes[i].Metadata.Annotations = map[string]string{}
+			}
+			for k, v := range req.Annotations {
+				f.nodes[i].Metadata.Annotations[k] = v
+			}
+			return &f.nodes[i], nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func group(label string, ids ...string) hsm.HSMGroup {
+	var g hsm.HSMGroup
+	g.Label = label
+	g.Members.IDs = ids
+	return g
+}
+
+func node(uid, xname string, groups ...string) v1.Node {
+	var n v1.Node
+	n.Metadata.UID = uid
+	n.Spec.XName = xname
+	n.Spec.Groups = groups
+	return n
+}
+
+func TestSyncer_Run(t *testing.T) {
+	ctx := context.Background()
+	groups := &fakeGroups{groups: []hsm.HSMGroup{
+		group("compute", "x1000c0s0b0n0", "X1000C0S0B0N1"),
+		group("gpu", "x1000c0s0b0n0"),
+	}}
+	nodes := &fakeNodes{nodes: []v1.Node{
+		node("n0", "x1000c0s0b0n0", "local"),
+		node("n1", "x1000c0s0b0n1"),
+		node("n2", "x1000c0s0b0n2", "local"),
+	}}
+	syncer := NewSyncer(groups, nodes, time.Minute, nil)
+
+	status, err := syncer.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if status.Groups != 2 || status.Updated != 2 || status.Skipped != 1 || status.Runs != 1 {
+		t.Errorf("status = %+v, want 2 groups and the 2 grouped nodes updated", status)
+	}
+	for i, want := range [][]string{{"local", "compute", "gpu"}, {"compute"}, {"local"}} {
+		if got := nodes.nodes[i].Spec.Groups; !slices.Equal(got, want) {
+			t.Errorf("node %d groups = %v, want %v", i, got, want)
+		}
+	}
+
+	// Nothing changes on a second sync
+	if status, _ := syncer.Run(ctx); status.Updated != 0 || status.Skipped != 3 {
+		t.Errorf("second sync = %+v, want every node unchanged", status)
+	}
+
+	// Inventory groups a node leaves are removed; local groups stay
+	groups.groups = []hsm.HSMGroup{group("compute", "x1000c0s0b0n1", "x1000c0s0b0n2")}
+	if _, err := syncer.Run(ctx); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	for i, want := range [][]string{{"local"}, {"compute"}, {"local", "compute"}} {
+		if got := nodes.nodes[i].Spec.Groups; !slices.Equal(got, want) {
+			t.Errorf("after regrouping, node %d groups = %v, want %v", i, got, want)
+		}
+	}
+
+	groups.err = errors.New("inventory unavailable")
+	if _, err := syncer.Run(ctx); err == nil {
+		t.Error("Run succeeded without the inventory")
+	}
+	if last := syncer.LastSync(); last.Runs != 4 || last.Error == "" || last.Interval != "1m0s" {
+		t.Errorf("LastSync = %+v, want the failed fourth sync", last)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	groups := &fakeGroups{groups: []hsm.HSMGroup{group("compute", "x1000c0s0b0n0")}}
+	nodes := &fakeNodes{nodes: []v1.Node{node("n0", "x1000c0s0b0n0")}}
+	router := chi.NewRouter()
+	NewHandler(NewSyncer(groups, nodes, time.Minute, nil)).RegisterRoutes(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, Path+"/run", nil))
+	if w.Code != http.StatusOK || nodes.updates != 1 {
+		t.Errorf("run: expected status 200 and an update, got %d: %s", w.Code, w.Body)
+	}
+
+	groups.err = errors.New("inventory unavailable")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, Path+"/run", nil))
+	if w.Code != http.StatusBadGateway {
+		t.Errorf("failed run: expected status 502, got %d: %s", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path+"/status", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("status: expected status 200, got %d: %s", w.Code, w.Body)
+	}
+}
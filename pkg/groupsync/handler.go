@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package groupsync
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Path serves the group sync API
+const Path = "/admin/group-sync"
+
+// Handler serves the group sync API
+type Handler struct {
+	syncer *Syncer
+}
+
+// NewHandler creates a group sync API handler
+func NewHandler(syncer *Syncer) *Handler {
+	return &Handler{syncer: syncer}
+}
+
+// RegisterRoutes registers GET /admin/group-sync/status and POST
+// /admin/group-sync/run
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route(Path, func(r chi.Router) {
+		r.Use(tenancy.Unscoped)
+		r.Get("/status", h.GetStatus)
+		r.Post("/run", h.Run)
+	})
+}
+
+// GetStatus handles GET /admin/group-sync/status
+func (h *Handler) GetStatus(w http.ResponseWriter, _ *http.Request) {
+	httputil.WriteJSON(w, http.StatusOK, h.syncer.LastSync())
+}
+
+// Run handles POST /admin/group-sync/run, which syncs now instead of
+// waiting for the interval
+func (h *Handler) Run(w http.ResponseWriter, r *http.Request) {
+	status, err := h.syncer.Run(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadGateway, "Group sync failed", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, status)
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package boot
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/pkg/accessstats"
+)
+
+// AccessStatsReader returns the boot script requests of a node, by xname
+type AccessStatsReader interface {
+	Get(ctx context.Context, node string) (accessstats.Stats, error)
+}
+
+// SetAccessStats enables GET /nodes/{uid}/access-stats, served from stats.
+// Requests are counted by the controller's access recorder. Call it before
+// registering routes.
+func (h *Handler) SetAccessStats(stats AccessStatsReader) {
+	h.accessStats = stats
+}
+
+// GetNodeAccessStats handles GET /nodes/{uid}/access-stats. The node may be
+// identified as for GET /nodes/{uid}/bootscript.
+func (h *Handler) GetNodeAccessStats(w http.ResponseWriter, r *http.Request) {
+	matcher, ok := h.controller.(ConfigurationMatcher)
+	if !ok {
+		h.writeError(w, http.StatusNotImplemented, "Access statistics not supported", "The configured boot controller cannot resolve nodes")
+		return
+	}
+	matches, err := matcher.MatchingConfigurations(r.Context(), chi.URLParam(r, "uid"))
+	if err != nil {
+		h.writeMatchError(w, err)
+		return
+	}
+
+	stats, err := h.accessStats.Get(r.Context(), matches.NodeXName)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to load access statistics", err.Error())
+		return
+	}
+	h.writeJSON(w, http.StatusOK, stats)
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package boot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/openchami/boot-service/pkg/audit"
+	"github.com/openchami/boot-service/pkg/bootonce"
+)
+
+// maxBootOnceSize bounds the body of a boot-once request
+const maxBootOnceSize = 64 << 10
+
+// BootOnceOverrides stores the one-time boot overrides of nodes, by xname
+type BootOnceOverrides interface {
+	Set(ctx context.Context, override bootonce.Override) (bootonce.Override, error)
+	Cancel(ctx context.Context, node string) (bool, error)
+	Get(node string) (bootonce.Override, bool)
+}
+
+// SetBootOnce enables the /nodes/{uid}/boot-once endpoints, stored in
+// overrides. The controller serves and uses up the overrides. Call it before
+// registering routes.
+func (h *Handler) SetBootOnce(overrides BootOnceOverrides) {
+	h.bootOnce = overrides
+}
+
+// GetNodeBootOnce handles GET /nodes/{uid}/boot-once, which returns the
+// node's pending override, or the last one it booted
+func (h *Handler) GetNodeBootOnce(w http.ResponseWriter, r *http.Request) {
+	node, ok := h.resolveNodeXName(w, r)
+	if !ok {
+		return
+	}
+	override, found := h.bootOnce.Get(node)
+	if !found {
+		h.writeError(w, http.StatusNotFound, "No boot-once override", "node "+node+" has no boot-once override")
+		return
+	}
+	h.writeJSON(w, http.StatusOK, override)
+}
+
+// PostNodeBootOnce handles POST /nodes/{uid}/boot-once, which makes the node
+// boot the configuration named in the body on its next boot script request
+func (h *Handler) PostNodeBootOnce(w http.ResponseWriter, r *http.Request) {
+	node, ok := h.resolveNodeXName(w, r)
+	if !ok {
+		return
+	}
+	var override bootonce.Override
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBootOnceSize)).Decode(&override); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid boot-once override", err.Error())
+		return
+	}
+	override.Node = node
+	override.RequestedBy = ""
+	if actor, ok := audit.ActorFromContext(r.Context()); ok && actor.Subject != "" && actor.Subject != audit.AnonymousSubject {
+		override.RequestedBy = actor.Subject
+	}
+
+	if override.Configuration != "" {
+		configs, err := h.client.GetBootConfigurations(r.Context())
+		if err != nil {
+			h.writeError(w, http.StatusInternalServerError, "Failed to list boot configurations", err.Error())
+			return
+		}
+		found := false
+		for _, config := range configs {
+			if config.Metadata.Name == override.Configuration {
+				found = true
+				break
+			}
+		}
+		if !found {
+			h.writeError(w, http.StatusBadRequest, "Invalid boot-once override", "boot configuration "+override.Configuration+" not found")
+			return
+		}
+	}
+
+	override, err := h.bootOnce.Set(r.Context(), override)
+	if errors.Is(err, bootonce.ErrInvalidOverride) {
+		h.writeError(w, http.StatusBadRequest, "Invalid boot-once override", err.Error())
+		return
+	}
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to set boot-once override", err.Error())
+		return
+	}
+	h.writeJSON(w, http.StatusOK, override)
+}
+
+// DeleteNodeBootOnce handles DELETE /nodes/{uid}/boot-once, which cancels
+// the node's pending override
+func (h *Handler) DeleteNodeBootOnce(w http.ResponseWriter, r *http.Request) {
+	node, ok := h.resolveNodeXName(w, r)
+	if !ok {
+		return
+	}
+	canceled, err := h.bootOnce.Cancel(r.Context(), node)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to cancel boot-once override", err.Error())
+		return
+	}
+	if !canceled {
+		h.writeError(w, http.StatusNotFound, "No boot-once override", "node "+node+" has no pending boot-once override")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
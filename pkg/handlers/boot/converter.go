@@ -10,6 +10,8 @@ import (
 	"time"
 
 	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/hostlist"
 )
 
 // ConvertNodeToLegacyIdentifiers extracts legacy identifiers from a modern Node resource
@@ -18,9 +20,7 @@ func ConvertNodeToLegacyIdentifiers(n *apiv1.Node) (hosts []string, macs []strin
 		hosts = append(hosts, n.Spec.XName)
 	}
 
-	if n.Spec.BootMAC != "" {
-		macs = append(macs, n.Spec.BootMAC)
-	}
+	macs = n.Spec.MACs()
 
 	if n.Spec.NID != 0 {
 		nids = append(nids, strconv.Itoa(int(n.Spec.NID)))
@@ -34,13 +34,31 @@ func ConvertBootConfigurationToLegacy(config *apiv1.BootConfiguration) BootParam
 	// Extract target identifiers from boot configuration
 	var hosts, macs, nids []string
 
-	hosts = config.Spec.Hosts
+	// Legacy clients only understand literal targets, so hostlists and NID
+	// ranges are expanded; one too large to expand is passed through as is
+	for _, host := range config.Spec.Hosts {
+		hosts = append(hosts, expandHostlist(host)...)
+	}
 	macs = config.Spec.MACs
 
 	// Convert NIDs to strings
 	for _, nid := range config.Spec.NIDs {
 		nids = append(nids, strconv.Itoa(int(nid)))
 	}
+	for _, expr := range config.Spec.NIDRanges {
+		ranges, err := hostlist.ParseRanges(expr)
+		if err != nil {
+			continue
+		}
+		numbers, err := ranges.Numbers()
+		if err != nil {
+			nids = append(nids, expr)
+			continue
+		}
+		for _, nid := range numbers {
+			nids = append(nids, strconv.Itoa(nid))
+		}
+	}
 
 	// Include groups as hosts
 	hosts = append(hosts, config.Spec.Groups...)
@@ -64,6 +82,21 @@ func ConvertBootConfigurationToLegacy(config *apiv1.BootConfiguration) BootParam
 	}
 }
 
+func expandHostlist(host string) []string {
+	if !hostlist.IsExpression(host) {
+		return []string{host}
+	}
+	pattern, err := hostlist.Parse(host)
+	if err != nil {
+		return []string{host}
+	}
+	names, err := pattern.Expand()
+	if err != nil {
+		return []string{host}
+	}
+	return names
+}
+
 // ConvertLegacyToBootConfiguration converts legacy BootParameters to modern BootConfiguration
 func ConvertLegacyToBootConfiguration(legacy BootParameters) *apiv1.BootConfiguration {
 	// Convert string NIDs to int32
@@ -110,7 +143,7 @@ func ConvertLegacyRequestToBootConfiguration(req BootParametersRequest) *apiv1.B
 
 // ExtractNodeIdentifier extracts the best node identifier from a BootScriptRequest
 func ExtractNodeIdentifier(req BootScriptRequest) string {
-	// Prefer host (xname), then mac, then nid
+	// Prefer host (xname), then mac, then nid, then uuid, then serial
 	if req.Host != "" {
 		return req.Host
 	}
@@ -120,9 +153,25 @@ func ExtractNodeIdentifier(req BootScriptRequest) string {
 	if req.Nid != "" {
 		return req.Nid
 	}
+	if ids := hardwareIdentifiers(req); len(ids) > 0 {
+		return ids[0]
+	}
 	return ""
 }
 
+// hardwareIdentifiers returns the node identifiers of the SMBIOS UUID and
+// serial number in req
+func hardwareIdentifiers(req BootScriptRequest) []string {
+	var ids []string
+	if req.UUID != "" {
+		ids = append(ids, req.UUID)
+	}
+	if req.Serial != "" {
+		ids = append(ids, bootscript.SerialPrefix+req.Serial)
+	}
+	return ids
+}
+
 // ParseNodeIdentifiersFromQuery parses legacy query parameters for node identifiers
 func ParseNodeIdentifiersFromQuery(host, mac, nid, name string) []string {
 	var identifiers []string
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package boot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/pkg/audit"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/cordon"
+)
+
+// maxCordonSize bounds the body of a cordon request
+const maxCordonSize = 64 << 10
+
+// NodeCordons stores the cordons of nodes, by xname
+type NodeCordons interface {
+	Cordon(ctx context.Context, c cordon.Cordon) (cordon.Cordon, error)
+	Uncordon(ctx context.Context, node string) (bool, error)
+	Get(node string) (cordon.Cordon, bool)
+}
+
+// SetCordons enables the /nodes/{uid}/cordon and /nodes/{uid}/uncordon
+// endpoints, stored in cordons. The controller keeps the cordoned nodes from
+// booting. Call it before registering routes.
+func (h *Handler) SetCordons(cordons NodeCordons) {
+	h.cordons = cordons
+}
+
+// GetNodeCordon handles GET /nodes/{uid}/cordon
+func (h *Handler) GetNodeCordon(w http.ResponseWriter, r *http.Request) {
+	node, ok := h.resolveNodeXName(w, r)
+	if !ok {
+		return
+	}
+	c, cordoned := h.cordons.Get(node)
+	if !cordoned {
+		h.writeError(w, http.StatusNotFound, "Node not cordoned", "node "+node+" is not cordoned")
+		return
+	}
+	h.writeJSON(w, http.StatusOK, c)
+}
+
+// PostNodeCordon handles POST /nodes/{uid}/cordon, which keeps the node from
+// booting its configuration with the action and reason in the optional body
+func (h *Handler) PostNodeCordon(w http.ResponseWriter, r *http.Request) {
+	node, ok := h.resolveNodeXName(w, r)
+	if !ok {
+		return
+	}
+	var c cordon.Cordon
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxCordonSize)).Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		h.writeError(w, http.StatusBadRequest, "Invalid cordon", err.Error())
+		return
+	}
+	c.Node = node
+	c.CordonedBy = ""
+	if actor, ok := audit.ActorFromContext(r.Context()); ok && actor.Subject != "" && actor.Subject != audit.AnonymousSubject {
+		c.CordonedBy = actor.Subject
+	}
+
+	c, err := h.cordons.Cordon(r.Context(), c)
+	if errors.Is(err, cordon.ErrInvalidCordon) {
+		h.writeError(w, http.StatusBadRequest, "Invalid cordon", err.Error())
+		return
+	}
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to cordon node", err.Error())
+		return
+	}
+	h.writeJSON(w, http.StatusOK, c)
+}
+
+// PostNodeUncordon handles POST /nodes/{uid}/uncordon. Uncordoning a node
+// that is not cordoned succeeds too.
+func (h *Handler) PostNodeUncordon(w http.ResponseWriter, r *http.Request) {
+	node, ok := h.resolveNodeXName(w, r)
+	if !ok {
+		return
+	}
+	if _, err := h.cordons.Uncordon(r.Context(), node); err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to uncordon node", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// resolveNodeXName resolves the {uid} of a node request, identified as for
+// GET /nodes/{uid}/bootscript, to the node's xname
+func (h *Handler) resolveNodeXName(w http.ResponseWriter, r *http.Request) (string, bool) {
+	matcher, ok := h.controller.(ConfigurationMatcher)
+	if !ok {
+		h.writeError(w, http.StatusNotImplemented, "Node lookup not supported", "The configured boot controller cannot resolve nodes")
+		return "", false
+	}
+	matches, err := matcher.MatchingConfigurations(r.Context(), chi.URLParam(r, "uid"))
+	if err != nil {
+		h.writeMatchError(w, err)
+		return "", false
+	}
+	return matches.NodeXName, true
+}
+
+// writeScriptError writes the error of a boot script that could not be
+// generated. A node cordoned as unavailable gets 503 with Retry-After.
+func (h *Handler) writeScriptError(w http.ResponseWriter, err error) {
+	var unavailable *bootscript.UnavailableError
+	if errors.As(err, &unavailable) {
+		if unavailable.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(unavailable.RetryAfter.Seconds()))))
+		}
+		h.writeError(w, http.StatusServiceUnavailable, "Node cordoned", err.Error())
+		return
+	}
+	h.writeError(w, http.StatusInternalServerError, "Failed to generate boot script", err.Error())
+}
@@ -9,16 +9,23 @@ package boot
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/activity"
 	"github.com/openchami/boot-service/pkg/client"
 	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/hostlist"
+	"github.com/openchami/boot-service/pkg/validation"
 )
 
 // BootController interface for boot script generation
@@ -26,15 +33,97 @@ type BootController interface {
 	GenerateBootScript(ctx context.Context, identifier string, profile string) (string, error)
 }
 
+// BootScriptPreviewer is implemented by controllers that support dry-run
+// boot script generation
+type BootScriptPreviewer interface {
+	PreviewBootScript(ctx context.Context, identifier string, profile string) (*bootscript.BootScriptPreview, error)
+}
+
+// ConfigurationMatcher is implemented by controllers that can explain which
+// nodes and configurations match each other
+type ConfigurationMatcher interface {
+	MatchingConfigurations(ctx context.Context, identifier string) (*bootscript.NodeMatches, error)
+	ConfigurationMatches(ctx context.Context, id string) (*bootscript.ConfigurationMatches, error)
+}
+
+// ConfigurationDiffer is implemented by controllers that can compare two
+// boot configurations
+type ConfigurationDiffer interface {
+	DiffConfigurations(ctx context.Context, a, b string) (*bootscript.ConfigurationDiff, error)
+}
+
+// ConfigurationSimulator is implemented by controllers that can report the
+// impact of a proposed boot configuration without storing it
+type ConfigurationSimulator interface {
+	Simulate(ctx context.Context, proposed apiv1.BootConfiguration, sample int) (*bootscript.Simulation, error)
+}
+
+// NodeResolver is implemented by controllers that can tell whether an
+// identifier names a known node
+type NodeResolver interface {
+	ResolveNodeName(ctx context.Context, identifier string) (string, error)
+}
+
+// ScheduleReporter is implemented by controllers that can report which boot
+// configurations their schedules make active at a given time
+type ScheduleReporter interface {
+	ActiveConfigurations(ctx context.Context, at time.Time) (*bootscript.ScheduleReport, error)
+}
+
+// ConflictReporter is implemented by controllers that can report nodes whose
+// best matching configurations tie
+type ConflictReporter interface {
+	Conflicts(ctx context.Context, at time.Time) (*bootscript.ConflictReport, error)
+}
+
+// ScriptSigner signs boot scripts for clients that verify them
+type ScriptSigner interface {
+	Sign(data []byte) ([]byte, error)
+	CertificatePEM() []byte
+}
+
+// ActivityRecorder receives boot activity events
+type ActivityRecorder interface {
+	Publish(event activity.Event)
+}
+
+// maxPhoneHomeSize bounds the body of a phone-home report
+const maxPhoneHomeSize = 64 << 10
+
+// maxSimulationSize bounds the body of a simulated boot configuration
+const maxSimulationSize = 1 << 20
+
+// SigningCertificatePath is where the boot script signing certificate is
+// published when scripts are signed
+const SigningCertificatePath = "/.well-known/boot-service/script-signing.pem"
+
+// Headers reporting a node's firmware, for clients that cannot add the
+// platform and buildarch query parameters
+const (
+	FirmwarePlatformHeader = "X-Boot-Platform"
+	FirmwareArchHeader     = "X-Boot-Arch"
+)
+
 // Handler handles boot API requests for both modern and legacy endpoints
 type Handler struct {
-	client     client.Client
-	controller BootController
-	logger     *log.Logger
+	client           client.API
+	controller       BootController
+	logger           *log.Logger
+	scriptMiddleware []func(http.Handler) http.Handler
+	signer           ScriptSigner
+	activity         ActivityRecorder
+	upstream         *Upstream
+	httpBootLoader   string
+	requestVars      []string
+	accessStats      AccessStatsReader
+	timelines        TimelineReader
+	userData         UserDataRenderer
+	cordons          NodeCordons
+	bootOnce         BootOnceOverrides
 }
 
 // NewHandler creates a new boot API handler with standard controller
-func NewHandler(c client.Client, logger *log.Logger) *Handler {
+func NewHandler(c client.API, logger *log.Logger) *Handler {
 	controller := bootscript.NewBootScriptController(c, logger)
 	return &Handler{
 		client:     c,
@@ -44,7 +133,7 @@ func NewHandler(c client.Client, logger *log.Logger) *Handler {
 }
 
 // NewHandlerWithController creates a new boot API handler with a custom controller
-func NewHandlerWithController(c client.Client, controller BootController, logger *log.Logger) *Handler {
+func NewHandlerWithController(c client.API, controller BootController, logger *log.Logger) *Handler {
 	return &Handler{
 		client:     c,
 		controller: controller,
@@ -52,6 +141,26 @@ func NewHandlerWithController(c client.Client, controller BootController, logger
 	}
 }
 
+// SetBootScriptMiddleware sets middleware, such as rate limiting, that runs
+// only for GET /bootscript and GET /boot/v1/bootscript. Call it before
+// registering routes.
+func (h *Handler) SetBootScriptMiddleware(middleware ...func(http.Handler) http.Handler) {
+	h.scriptMiddleware = middleware
+}
+
+// SetScriptSigner enables detached signatures of boot scripts, served next
+// to each script with a .sig suffix. Call it before registering routes.
+func (h *Handler) SetScriptSigner(signer ScriptSigner) {
+	h.signer = signer
+}
+
+// SetActivityRecorder reports every boot script request to recorder and
+// enables POST /phone-home/{id}, which reports booted nodes. Call it before
+// registering routes.
+func (h *Handler) SetActivityRecorder(recorder ActivityRecorder) {
+	h.activity = recorder
+}
+
 // RegisterModernRoutes registers modern boot API routes at root paths
 // These are always available regardless of enable_legacy_api setting
 func (h *Handler) RegisterModernRoutes(r chi.Router) {
@@ -63,8 +172,49 @@ func (h *Handler) RegisterModernRoutes(r chi.Router) {
 		r.Delete("/", h.DeleteBootParameters)
 	})
 
-	// Boot script endpoint
-	r.Get("/bootscript", h.GetBootScript)
+	// Boot script endpoints
+	r.With(h.scriptMiddleware...).Get("/bootscript", h.GetBootScript)
+	r.Get("/bootscript/preview", h.PreviewBootScript)
+	r.Get("/nodes/{uid}/bootscript", h.GetNodeBootScript)
+	if h.signer != nil {
+		r.With(h.scriptMiddleware...).Get("/bootscript.sig", h.GetBootScriptSignature)
+		r.Get("/nodes/{uid}/bootscript.sig", h.GetNodeBootScriptSignature)
+		r.Get(SigningCertificatePath, h.GetSigningCertificate)
+	}
+
+	// UEFI HTTP boot entry points for nodes that bypass iPXE
+	h.registerHTTPBootRoutes(r)
+
+	if h.activity != nil {
+		r.Post("/phone-home/{id}", h.PostPhoneHome)
+	}
+
+	// Match explain endpoints
+	r.Get("/nodes/{uid}/matching-configs", h.GetNodeMatchingConfigurations)
+	if h.accessStats != nil {
+		r.Get("/nodes/{uid}/access-stats", h.GetNodeAccessStats)
+	}
+	if h.timelines != nil {
+		r.Get("/nodes/{uid}/timeline", h.GetNodeTimeline)
+	}
+	if h.userData != nil {
+		r.Get("/nodes/{uid}/user-data", h.GetNodeUserData)
+	}
+	if h.cordons != nil {
+		r.Get("/nodes/{uid}/cordon", h.GetNodeCordon)
+		r.Post("/nodes/{uid}/cordon", h.PostNodeCordon)
+		r.Post("/nodes/{uid}/uncordon", h.PostNodeUncordon)
+	}
+	if h.bootOnce != nil {
+		r.Get("/nodes/{uid}/boot-once", h.GetNodeBootOnce)
+		r.Post("/nodes/{uid}/boot-once", h.PostNodeBootOnce)
+		r.Delete("/nodes/{uid}/boot-once", h.DeleteNodeBootOnce)
+	}
+	r.Get("/bootconfigurations/{uid}/matches", h.GetConfigurationMatches)
+	r.Get("/bootconfigurations/active", h.GetActiveConfigurations)
+	r.Get("/bootconfigurations/conflicts", h.GetConfigurationConflicts)
+	r.Get("/bootconfigurations/diff", h.GetConfigurationDiff)
+	r.Post("/bootconfigurations/simulate", h.PostConfigurationSimulation)
 
 	// Service endpoints
 	r.Route("/service", func(r chi.Router) {
@@ -77,6 +227,10 @@ func (h *Handler) RegisterModernRoutes(r chi.Router) {
 // These are ONLY available when enable_legacy_api: true
 func (h *Handler) RegisterLegacyRoutes(r chi.Router) {
 	r.Route("/boot/v1", func(r chi.Router) {
+		if h.upstream != nil {
+			r.Use(h.upstream.Middleware)
+		}
+
 		// Boot parameters endpoints
 		r.Route("/bootparameters", func(r chi.Router) {
 			r.Get("/", h.GetBootParameters)
@@ -86,7 +240,10 @@ func (h *Handler) RegisterLegacyRoutes(r chi.Router) {
 		})
 
 		// Boot script endpoint
-		r.Get("/bootscript", h.GetBootScript)
+		r.With(h.scriptMiddleware...).Get("/bootscript", h.GetBootScript)
+		if h.signer != nil {
+			r.With(h.scriptMiddleware...).Get("/bootscript.sig", h.GetBootScriptSignature)
+		}
 
 		// Service endpoints
 		r.Route("/service", func(r chi.Router) {
@@ -118,6 +275,9 @@ func (h *Handler) GetBootParameters(w http.ResponseWriter, r *http.Request) {
 	if host != "" || mac != "" || nid != "" || name != "" {
 		identifiers := ParseNodeIdentifiersFromQuery(host, mac, nid, name)
 		filteredConfigs = h.filterConfigurationsByIdentifiers(configs, identifiers)
+		if len(filteredConfigs) == 0 {
+			markMissing(ctx)
+		}
 	} else {
 		filteredConfigs = configs
 	}
@@ -290,34 +450,148 @@ func (h *Handler) DeleteBootParameters(w http.ResponseWriter, r *http.Request) {
 
 // GetBootScript handles GET /bootscript and GET /boot/v1/bootscript
 func (h *Handler) GetBootScript(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	identifier, ok := h.bootScriptIdentifier(w, r)
+	if !ok {
+		return
+	}
 
-	// Parse query parameters for node identification
-	host := r.URL.Query().Get("host")
-	mac := r.URL.Query().Get("mac")
-	nid := r.URL.Query().Get("nid")
+	if isDryRun(r) {
+		h.writeBootScriptPreview(w, r, identifier)
+		return
+	}
+
+	h.writeBootScript(w, r, identifier)
+}
+
+// GetBootScriptSignature handles GET /bootscript.sig and GET
+// /boot/v1/bootscript.sig, returning the detached signature of the script
+// served for the same query
+func (h *Handler) GetBootScriptSignature(w http.ResponseWriter, r *http.Request) {
+	identifier, ok := h.bootScriptIdentifier(w, r)
+	if !ok {
+		return
+	}
+
+	h.writeBootScriptSignature(w, r, identifier)
+}
 
-	// Create boot script request
+// bootScriptIdentifier returns the node identified by the host, mac, nid,
+// uuid, or serial query parameter, or writes an error if there is none
+func (h *Handler) bootScriptIdentifier(w http.ResponseWriter, r *http.Request) (string, bool) {
+	// Parse query parameters for node identification
+	query := r.URL.Query()
 	req := BootScriptRequest{
-		Host:   host,
-		Mac:    mac,
-		Nid:    nid,
-		Format: r.URL.Query().Get("format"), // defaults to "ipxe"
+		Host:   query.Get("host"),
+		Mac:    query.Get("mac"),
+		Nid:    query.Get("nid"),
+		UUID:   query.Get("uuid"),
+		Serial: query.Get("serial"),
+		Format: query.Get("format"), // defaults to "ipxe"
 	}
 
 	// Extract the node identifier
-	identifier := ExtractNodeIdentifier(req)
+	identifier := h.requestIdentifier(r.Context(), req)
 	if identifier == "" {
-		h.writeError(w, http.StatusBadRequest, "Missing node identifier", "At least one node identifier (host, mac, or nid) must be provided")
+		h.writeError(w, http.StatusBadRequest, "Missing node identifier", "At least one node identifier (host, mac, nid, uuid, or serial) must be provided")
+		return "", false
+	}
+	return identifier, true
+}
+
+// requestIdentifier returns the identifier of the node a boot script request
+// is for. Unless host names the node, an SMBIOS UUID or serial number that
+// names a known node is preferred to the MAC, which changes when the NIC is
+// replaced.
+func (h *Handler) requestIdentifier(ctx context.Context, req BootScriptRequest) string {
+	resolver, ok := h.controller.(NodeResolver)
+	if ok && req.Host == "" && (req.Mac != "" || req.Nid != "") {
+		for _, id := range hardwareIdentifiers(req) {
+			if _, err := resolver.ResolveNodeName(ctx, id); err == nil {
+				return id
+			}
+		}
+	}
+	return ExtractNodeIdentifier(req)
+}
+
+// PreviewBootScript handles GET /bootscript/preview. It accepts the same node
+// identifiers as GET /bootscript and returns the script with an explanation
+// of the match, without using the script cache.
+func (h *Handler) PreviewBootScript(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	identifier := h.requestIdentifier(r.Context(), BootScriptRequest{
+		Host:   query.Get("host"),
+		Mac:    query.Get("mac"),
+		Nid:    query.Get("nid"),
+		UUID:   query.Get("uuid"),
+		Serial: query.Get("serial"),
+	})
+	if identifier == "" {
+		h.writeError(w, http.StatusBadRequest, "Missing node identifier", "At least one node identifier (host, mac, nid, uuid, or serial) must be provided")
+		return
+	}
+
+	h.writeBootScriptPreview(w, r, identifier)
+}
+
+// GetNodeBootScript handles GET /nodes/{uid}/bootscript. The node may be
+// identified by UID, xname, NID, any interface MAC, hostname, or alias. With
+// ?dry-run=true the response is a JSON preview instead of the script.
+func (h *Handler) GetNodeBootScript(w http.ResponseWriter, r *http.Request) {
+	identifier := chi.URLParam(r, "uid")
+
+	if isDryRun(r) {
+		h.writeBootScriptPreview(w, r, identifier)
+		return
+	}
+
+	h.writeBootScript(w, r, identifier)
+}
+
+// GetNodeBootScriptSignature handles GET /nodes/{uid}/bootscript.sig
+func (h *Handler) GetNodeBootScriptSignature(w http.ResponseWriter, r *http.Request) {
+	h.writeBootScriptSignature(w, r, chi.URLParam(r, "uid"))
+}
+
+// GetSigningCertificate handles GET /.well-known/boot-service/script-signing.pem
+func (h *Handler) GetSigningCertificate(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	w.WriteHeader(http.StatusOK)
+	w.Write(h.signer.CertificatePEM()) //nolint:errcheck
+}
+
+func (h *Handler) writeBootScript(w http.ResponseWriter, r *http.Request, identifier string) {
+	format := r.URL.Query().Get("format")
+	if format != "" && format != FormatIPXE && format != FormatKexec {
+		h.writeError(w, http.StatusBadRequest, "Invalid format", "format must be ipxe or kexec, not "+format)
+		return
+	}
+
+	if h.activity != nil {
+		h.activity.Publish(activity.Event{Type: activity.Request, Identifier: identifier, Client: clientAddress(r)})
+	}
+
+	if readThrough(r.Context()) && h.nodeUnknown(r.Context(), identifier) {
+		markMissing(r.Context())
+	}
+
+	if format == FormatKexec {
+		h.writeKexecEntry(w, r, identifier)
 		return
 	}
 
 	// Generate the boot script using our boot logic
 	// Ignore profile query parameter and always auto-resolve best configuration.
 	// Profile selection is driven by matching score and priority within boot logic.
+	ctx := bootscript.WithAccess(h.scriptContext(r), bootscript.Access{
+		Client:    clientAddress(r),
+		Proxy:     httputil.Proxy(r),
+		UserAgent: r.UserAgent(),
+		UUID:      r.URL.Query().Get("uuid"),
+	})
 	script, err := h.controller.GenerateBootScript(ctx, identifier, "")
 	if err != nil {
-		h.writeError(w, http.StatusInternalServerError, "Failed to generate boot script", err.Error())
+		h.writeScriptError(w, err)
 		return
 	}
 
@@ -327,9 +601,323 @@ func (h *Handler) GetBootScript(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(script)) //nolint:errcheck
 }
 
+// writeKexecEntry returns the kernel the node boots as a KexecEntry. A node
+// that would not boot a kernel, such as one without a configuration or
+// held by maintenance mode, gets 409 so its agent reboots through firmware.
+func (h *Handler) writeKexecEntry(w http.ResponseWriter, r *http.Request, identifier string) {
+	resolver, ok := h.controller.(BootEntryResolver)
+	if !ok {
+		h.writeError(w, http.StatusNotImplemented, "kexec format not supported", "The configured boot controller cannot resolve boot entries")
+		return
+	}
+	entry, err := resolver.ResolveBootEntry(h.scriptContext(r), identifier, "")
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to resolve boot entry", err.Error())
+		return
+	}
+	if entry.Kernel == "" {
+		detail := entry.Reason
+		if entry.ChainURL != "" {
+			detail = "the node chains to " + entry.ChainURL
+		}
+		h.writeError(w, http.StatusConflict, "Node cannot kexec", fmt.Sprintf("%s script: %s", entry.Template, detail))
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, KexecEntry{
+		Node:          entry.Node,
+		Configuration: entry.Configuration,
+		Kernel:        entry.Kernel,
+		Initrd:        entry.Initrd,
+		Cmdline:       entry.Params,
+	})
+}
+
+func (h *Handler) writeBootScriptSignature(w http.ResponseWriter, r *http.Request, identifier string) {
+	script, err := h.controller.GenerateBootScript(h.scriptContext(r), identifier, "")
+	if err != nil {
+		h.writeScriptError(w, err)
+		return
+	}
+	signature, err := h.signer.Sign([]byte(script))
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to sign boot script", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pkcs7-signature")
+	w.WriteHeader(http.StatusOK)
+	w.Write(signature) //nolint:errcheck
+}
+
+func (h *Handler) writeBootScriptPreview(w http.ResponseWriter, r *http.Request, identifier string) {
+	previewer, ok := h.controller.(BootScriptPreviewer)
+	if !ok {
+		h.writeError(w, http.StatusNotImplemented, "Boot script preview not supported", "The configured boot controller does not support dry-run previews")
+		return
+	}
+
+	ctx := h.scriptContext(r)
+	if at, ok, err := evaluationTime(r); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid evaluation time", err.Error())
+		return
+	} else if ok {
+		ctx = bootscript.WithEvaluationTime(ctx, at)
+	}
+
+	preview, err := previewer.PreviewBootScript(ctx, identifier, "")
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to preview boot script", err.Error())
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, preview)
+}
+
+// PostPhoneHome handles POST /phone-home/{id}, where a booted node reports in,
+// such as with the cloud-init phone_home module and a URL ending in
+// /phone-home/$INSTANCE_ID. The form or JSON body may give the hostname.
+func (h *Handler) PostPhoneHome(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxPhoneHomeSize)
+	var hostname string
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		var report struct {
+			Hostname string `json:"hostname"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid phone-home report", err.Error())
+			return
+		}
+		hostname = report.Hostname
+	} else {
+		if err := r.ParseForm(); err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid phone-home report", err.Error())
+			return
+		}
+		hostname = r.PostForm.Get("hostname")
+	}
+
+	h.activity.Publish(activity.Event{
+		Type:       activity.PhoneHome,
+		Identifier: chi.URLParam(r, "id"),
+		Client:     clientAddress(r),
+		Hostname:   hostname,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// clientAddress returns the address a request came from, without the port.
+// Behind a trusted proxy, that is the client it forwarded the request for.
+func clientAddress(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// GetNodeMatchingConfigurations handles GET /nodes/{uid}/matching-configs
+func (h *Handler) GetNodeMatchingConfigurations(w http.ResponseWriter, r *http.Request) {
+	matcher, ok := h.controller.(ConfigurationMatcher)
+	if !ok {
+		h.writeError(w, http.StatusNotImplemented, "Match explanation not supported", "The configured boot controller does not support match explanation")
+		return
+	}
+
+	matches, err := matcher.MatchingConfigurations(r.Context(), chi.URLParam(r, "uid"))
+	if err != nil {
+		h.writeMatchError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, matches)
+}
+
+// GetConfigurationMatches handles GET /bootconfigurations/{uid}/matches
+func (h *Handler) GetConfigurationMatches(w http.ResponseWriter, r *http.Request) {
+	matcher, ok := h.controller.(ConfigurationMatcher)
+	if !ok {
+		h.writeError(w, http.StatusNotImplemented, "Match explanation not supported", "The configured boot controller does not support match explanation")
+		return
+	}
+
+	matches, err := matcher.ConfigurationMatches(r.Context(), chi.URLParam(r, "uid"))
+	if err != nil {
+		h.writeMatchError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, matches)
+}
+
+// GetActiveConfigurations handles GET /bootconfigurations/active, which lists
+// the configurations active now, or at the RFC 3339 time in ?at=
+func (h *Handler) GetActiveConfigurations(w http.ResponseWriter, r *http.Request) {
+	reporter, ok := h.controller.(ScheduleReporter)
+	if !ok {
+		h.writeError(w, http.StatusNotImplemented, "Schedule preview not supported", "The configured boot controller does not support schedule previews")
+		return
+	}
+
+	at, ok, err := evaluationTime(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid evaluation time", err.Error())
+		return
+	}
+	if !ok {
+		at = time.Now().UTC()
+	}
+
+	report, err := reporter.ActiveConfigurations(r.Context(), at)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to evaluate schedules", err.Error())
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, report)
+}
+
+// GetConfigurationConflicts handles GET /bootconfigurations/conflicts, which
+// lists the nodes whose best matching configurations tie among those active
+// now, or at the RFC 3339 time in ?at=
+func (h *Handler) GetConfigurationConflicts(w http.ResponseWriter, r *http.Request) {
+	reporter, ok := h.controller.(ConflictReporter)
+	if !ok {
+		h.writeError(w, http.StatusNotImplemented, "Conflict detection not supported", "The configured boot controller does not support conflict detection")
+		return
+	}
+
+	at, ok, err := evaluationTime(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid evaluation time", err.Error())
+		return
+	}
+	if !ok {
+		at = time.Now().UTC()
+	}
+
+	report, err := reporter.Conflicts(r.Context(), at)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to evaluate conflicts", err.Error())
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, report)
+}
+
+// GetConfigurationDiff handles GET /bootconfigurations/diff?a=<id>&b=<id>,
+// which compares the kernel, initrd, and kernel parameters of two
+// configurations and lists the nodes that would boot another configuration
+// if b replaced a
+func (h *Handler) GetConfigurationDiff(w http.ResponseWriter, r *http.Request) {
+	differ, ok := h.controller.(ConfigurationDiffer)
+	if !ok {
+		h.writeError(w, http.StatusNotImplemented, "Configuration diff not supported", "The configured boot controller does not support configuration diffs")
+		return
+	}
+
+	a, b := r.URL.Query().Get("a"), r.URL.Query().Get("b")
+	if a == "" || b == "" {
+		h.writeError(w, http.StatusBadRequest, "Invalid configuration diff", "a and b must name the configurations to compare")
+		return
+	}
+
+	diff, err := differ.DiffConfigurations(r.Context(), a, b)
+	if err != nil {
+		h.writeMatchError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, diff)
+}
+
+// PostConfigurationSimulation handles POST /bootconfigurations/simulate,
+// which reports what storing the boot configuration in the body would
+// change without storing it. ?sample= sets how many nodes' scripts are
+// rendered and compared.
+func (h *Handler) PostConfigurationSimulation(w http.ResponseWriter, r *http.Request) {
+	simulator, ok := h.controller.(ConfigurationSimulator)
+	if !ok {
+		h.writeError(w, http.StatusNotImplemented, "Configuration simulation not supported", "The configured boot controller does not support configuration simulation")
+		return
+	}
+
+	sample := bootscript.DefaultSimulationSample
+	if value := r.URL.Query().Get("sample"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid sample", err.Error())
+			return
+		}
+		sample = n
+	}
+
+	var proposed apiv1.BootConfiguration
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSimulationSize)).Decode(&proposed); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	simulation, err := simulator.Simulate(r.Context(), proposed, sample)
+	if errors.Is(err, bootscript.ErrInvalidProposal) {
+		h.writeError(w, http.StatusBadRequest, "Invalid configuration simulation", err.Error())
+		return
+	}
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to simulate configuration", err.Error())
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, simulation)
+}
+
+// evaluationTime parses the RFC 3339 ?at= query parameter, reporting
+// whether it was given
+func evaluationTime(r *http.Request) (time.Time, bool, error) {
+	value := r.URL.Query().Get("at")
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("at must be an RFC 3339 time such as 2026-03-01T02:00:00Z: %w", err)
+	}
+	return at, true, nil
+}
+
+func (h *Handler) writeMatchError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, bootscript.ErrNodeNotFound):
+		h.writeError(w, http.StatusNotFound, "Node not found", err.Error())
+	case errors.Is(err, bootscript.ErrConfigurationNotFound):
+		h.writeError(w, http.StatusNotFound, "Boot configuration not found", err.Error())
+	default:
+		h.writeError(w, http.StatusInternalServerError, "Failed to evaluate matches", err.Error())
+	}
+}
+
+// withFirmware adds the firmware the request reported to its context, from
+// the platform and buildarch query parameters, as in a chain URL ending in
+// ?mac=${mac}&platform=${platform}&buildarch=${buildarch}, or the firmware
+// headers
+func withFirmware(r *http.Request) context.Context {
+	query := r.URL.Query()
+	platform, arch := query.Get("platform"), query.Get("buildarch")
+	if platform == "" {
+		platform, arch = r.Header.Get(FirmwarePlatformHeader), r.Header.Get(FirmwareArchHeader)
+	}
+	return bootscript.WithFirmware(r.Context(), bootscript.NewFirmware(platform, arch))
+}
+
+// isDryRun reports whether the request asks for a dry-run preview
+func isDryRun(r *http.Request) bool {
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry-run"))
+	return dryRun
+}
+
 // GetServiceStatus handles GET /service/status and GET /boot/v1/service/status
 func (h *Handler) GetServiceStatus(w http.ResponseWriter, r *http.Request) { //nolint:revive
 	status := CreateServiceStatus("2.0.0-fabrica")
+	status.Details["validation_profile"] = validation.ActiveProfile().String()
 	h.writeJSON(w, http.StatusOK, status)
 }
 
@@ -387,11 +975,16 @@ func (h *Handler) configMatchesIdentifiers(config apiv1.BootConfiguration, ident
 			if host == identifier {
 				return true
 			}
+			if hostlist.IsExpression(host) {
+				if pattern, err := hostlist.Parse(host); err == nil && pattern.Match(identifier) {
+					return true
+				}
+			}
 		}
 
 		// Check MACs
 		for _, mac := range config.Spec.MACs {
-			if mac == identifier {
+			if validation.EqualMAC(mac, identifier) {
 				return true
 			}
 		}
@@ -403,6 +996,11 @@ func (h *Handler) configMatchesIdentifiers(config apiv1.BootConfiguration, ident
 					return true
 				}
 			}
+			for _, expr := range config.Spec.NIDRanges {
+				if ranges, err := hostlist.ParseRanges(expr); err == nil && ranges.Contains(nid) {
+					return true
+				}
+			}
 		}
 
 		// Check groups
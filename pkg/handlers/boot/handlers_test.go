@@ -5,6 +5,7 @@
 package boot
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"log"
@@ -15,8 +16,14 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/accessstats"
+	"github.com/openchami/boot-service/pkg/activity"
+	"github.com/openchami/boot-service/pkg/bootonce"
 	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/cordon"
 	"github.com/openchami/fabrica/pkg/resource"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
 )
 
 func TestGetBootScript_ProfileQueryParameterIgnored(t *testing.T) {
@@ -70,7 +77,7 @@ func TestGetBootScript_ProfileQueryParameterIgnored(t *testing.T) {
 	}
 
 	// Create boot handler with bootscript controller
-	handler := NewHandler(*bootClient, log.New(io.Discard, "", 0))
+	handler := NewHandler(bootClient, log.New(io.Discard, "", 0))
 
 	// Create router and register modern routes
 	router := chi.NewRouter()
@@ -170,7 +177,7 @@ func TestGetBootScript_MissingNodeIdentifier(t *testing.T) {
 		t.Fatalf("failed to create boot client: %v", err)
 	}
 
-	handler := NewHandler(*bootClient, log.New(io.Discard, "", 0))
+	handler := NewHandler(bootClient, log.New(io.Discard, "", 0))
 	router := chi.NewRouter()
 	handler.RegisterModernRoutes(router)
 
@@ -202,7 +209,7 @@ func TestRegisterModernAndLegacyRoutes_Separately(t *testing.T) {
 		t.Fatalf("failed to create boot client: %v", err)
 	}
 
-	handler := NewHandler(*bootClient, log.New(io.Discard, "", 0))
+	handler := NewHandler(bootClient, log.New(io.Discard, "", 0))
 
 	// Test 1: Only modern routes registered
 	router1 := chi.NewRouter()
@@ -262,6 +269,155 @@ func TestRegisterModernAndLegacyRoutes_Separately(t *testing.T) {
 	}
 }
 
+func TestGetBootScript_DryRun(t *testing.T) {
+	nodes := []apiv1.Node{
+		{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:ff"}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "default-config"},
+			Spec:     apiv1.BootConfigurationSpec{Kernel: "http://files.example.com/vmlinuz"},
+		},
+	}
+
+	backendServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes":
+			writeJSONResponse(t, w, nodes)
+		case "/bootconfigurations":
+			writeJSONResponse(t, w, configs)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer backendServer.Close()
+
+	bootClient, err := client.NewClient(backendServer.URL, backendServer.Client(), client.DefaultLogger())
+	if err != nil {
+		t.Fatalf("failed to create boot client: %v", err)
+	}
+
+	handler := NewHandler(bootClient, log.New(io.Discard, "", 0))
+	router := chi.NewRouter()
+	handler.RegisterModernRoutes(router)
+
+	for _, path := range []string{
+		"/bootscript?mac=aa:bb:cc:dd:ee:ff&dry-run=true",
+		"/bootscript/preview?nid=1",
+		"/nodes/x0c0s0b0n0/bootscript?dry-run=true",
+	} {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d: %s", path, w.Code, w.Body.String())
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s: expected JSON response, got %q", path, ct)
+		}
+
+		var preview bootscript.BootScriptPreview
+		if err := json.NewDecoder(w.Body).Decode(&preview); err != nil {
+			t.Fatalf("%s: failed to decode preview: %v", path, err)
+		}
+		if preview.MatchedConfiguration != "default-config" || len(preview.Candidates) != 1 || !strings.HasPrefix(preview.Script, "#!ipxe") {
+			t.Errorf("%s: unexpected preview %+v", path, preview)
+		}
+	}
+
+	// Without dry-run the node route returns the script itself
+	req := httptest.NewRequest("GET", "/nodes/x0c0s0b0n0/bootscript", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Body.String(), "#!ipxe") {
+		t.Errorf("expected iPXE script from node bootscript route, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// prefixSigner "signs" a script by prefixing it, so tests can check what
+// was signed
+type prefixSigner struct{}
+
+func (prefixSigner) Sign(data []byte) ([]byte, error) {
+	return append([]byte("signed:"), data...), nil
+}
+
+func (prefixSigner) CertificatePEM() []byte {
+	return []byte("-----BEGIN CERTIFICATE-----\n")
+}
+
+func TestGetBootScriptSignature(t *testing.T) {
+	nodes := []apiv1.Node{
+		{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:ff"}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{Spec: apiv1.BootConfigurationSpec{Kernel: "http://files.example.com/vmlinuz"}},
+	}
+	backendServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes":
+			writeJSONResponse(t, w, nodes)
+		case "/bootconfigurations":
+			writeJSONResponse(t, w, configs)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer backendServer.Close()
+
+	bootClient, err := client.NewClient(backendServer.URL, backendServer.Client(), client.DefaultLogger())
+	if err != nil {
+		t.Fatalf("failed to create boot client: %v", err)
+	}
+
+	get := func(router chi.Router, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	// Without a signer there are no signature routes
+	handler := NewHandler(bootClient, log.New(io.Discard, "", 0))
+	router := chi.NewRouter()
+	handler.RegisterModernRoutes(router)
+	if w := get(router, "/bootscript.sig?mac=aa:bb:cc:dd:ee:ff"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for signature without signing, got %d", w.Code)
+	}
+
+	handler = NewHandler(bootClient, log.New(io.Discard, "", 0))
+	handler.SetScriptSigner(prefixSigner{})
+	router = chi.NewRouter()
+	handler.RegisterModernRoutes(router)
+	handler.RegisterLegacyRoutes(router)
+
+	script := get(router, "/bootscript?mac=aa:bb:cc:dd:ee:ff").Body.String()
+	if !strings.HasPrefix(script, "#!ipxe") {
+		t.Fatalf("unexpected boot script: %s", script)
+	}
+	for _, path := range []string{
+		"/bootscript.sig?mac=aa:bb:cc:dd:ee:ff",
+		"/boot/v1/bootscript.sig?mac=aa:bb:cc:dd:ee:ff",
+		"/nodes/x0c0s0b0n0/bootscript.sig",
+	} {
+		w := get(router, path)
+		if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/pkcs7-signature" {
+			t.Errorf("%s: got %d %q", path, w.Code, w.Header().Get("Content-Type"))
+			continue
+		}
+		if w.Body.String() != "signed:"+script {
+			t.Errorf("%s: signature is not of the served script:\n%s", path, w.Body.String())
+		}
+	}
+
+	if w := get(router, "/bootscript.sig"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for signature without node identifier, got %d", w.Code)
+	}
+	if w := get(router, SigningCertificatePath); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "CERTIFICATE") {
+		t.Errorf("expected signing certificate, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
 func writeJSONResponse(t *testing.T, w http.ResponseWriter, data interface{}) {
 	t.Helper()
 	w.Header().Set("Content-Type", "application/json")
@@ -269,3 +425,630 @@ func writeJSONResponse(t *testing.T, w http.ResponseWriter, data interface{}) {
 		t.Fatalf("failed to encode JSON response: %v", err)
 	}
 }
+
+// staticController serves the same script for every node
+type staticController string
+
+func (s staticController) GenerateBootScript(_ context.Context, _, _ string) (string, error) {
+	return string(s), nil
+}
+
+func TestBootActivity(t *testing.T) {
+	feed := activity.NewFeed(10)
+	events := feed.Subscribe()
+	defer events.Close()
+
+	handler := NewHandlerWithController(nil, staticController("#!ipxe\n"), log.New(io.Discard, "", 0))
+	handler.SetActivityRecorder(feed)
+	router := chi.NewRouter()
+	handler.RegisterModernRoutes(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/bootscript?mac=aa:bb:cc:dd:ee:ff", nil)
+	req.RemoteAddr = "10.0.0.5:4242"
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	if event := <-events.Events; event.Type != activity.Request || event.Identifier != "aa:bb:cc:dd:ee:ff" || event.Client != "10.0.0.5" {
+		t.Errorf("request event = %+v", event)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/phone-home/x0c0s0b0n0", strings.NewReader("instance_id=x0c0s0b0n0&hostname=nid0001"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("phone-home returned %d: %s", w.Code, w.Body.String())
+	}
+	if event := <-events.Events; event.Type != activity.PhoneHome || event.Identifier != "x0c0s0b0n0" || event.Hostname != "nid0001" {
+		t.Errorf("phone-home event = %+v", event)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/phone-home/x0c0s0b0n0", strings.NewReader(`{"hostname":`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("invalid phone-home report returned %d", w.Code)
+	}
+
+	// Without a recorder there is no phone-home endpoint
+	router = chi.NewRouter()
+	NewHandlerWithController(nil, staticController("#!ipxe\n"), log.New(io.Discard, "", 0)).RegisterModernRoutes(router)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/phone-home/x0c0s0b0n0", nil))
+	if w.Code != http.StatusNotFound && w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("phone-home without a recorder returned %d", w.Code)
+	}
+}
+
+func TestConvertBootConfigurationToLegacy_ExpandsRanges(t *testing.T) {
+	config := &apiv1.BootConfiguration{Spec: apiv1.BootConfigurationSpec{
+		Hosts:     []string{"x1000c0s0b0n[0-1]", "compute"},
+		NIDs:      []int32{7},
+		NIDRanges: []string{"10-12"},
+	}}
+
+	legacy := ConvertBootConfigurationToLegacy(config)
+	if strings.Join(legacy.Hosts, ",") != "x1000c0s0b0n0,x1000c0s0b0n1,compute" {
+		t.Errorf("Hosts = %v, want the hostlist expanded", legacy.Hosts)
+	}
+	if strings.Join(legacy.Nids, ",") != "7,10,11,12" {
+		t.Errorf("Nids = %v, want the NID range expanded", legacy.Nids)
+	}
+
+	handler := &Handler{}
+	if !handler.configMatchesIdentifiers(*config, []string{"x1000c0s0b0n1"}) || !handler.configMatchesIdentifiers(*config, []string{"11"}) {
+		t.Error("expected hostlist and NID range members to match")
+	}
+	if handler.configMatchesIdentifiers(*config, []string{"x1000c0s0b0n2"}) {
+		t.Error("expected a host outside the hostlist not to match")
+	}
+}
+
+func TestConvertBootConfigurationToLegacy_HugeRange(t *testing.T) {
+	config := &apiv1.BootConfiguration{Spec: apiv1.BootConfigurationSpec{
+		NIDRanges: []string{"0-9223372036854775807", "0-2147483647"},
+	}}
+
+	legacy := ConvertBootConfigurationToLegacy(config)
+	if strings.Join(legacy.Nids, ",") != "0-2147483647" {
+		t.Errorf("Nids = %v, want the unexpandable range kept as written", legacy.Nids)
+	}
+}
+
+func TestGetBootScript_Firmware(t *testing.T) {
+	nodes := []apiv1.Node{
+		{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:ff"}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "default-config"},
+			Spec: apiv1.BootConfigurationSpec{
+				Kernel:   "http://files.example.com/vmlinuz",
+				Firmware: []apiv1.FirmwareVariant{{Platform: apiv1.FirmwareEFI, Kernel: "http://files.example.com/vmlinuz.efi"}},
+			},
+		},
+	}
+
+	backendServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes":
+			writeJSONResponse(t, w, nodes)
+		case "/bootconfigurations":
+			writeJSONResponse(t, w, configs)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer backendServer.Close()
+
+	bootClient, err := client.NewClient(backendServer.URL, backendServer.Client(), client.DefaultLogger())
+	if err != nil {
+		t.Fatalf("failed to create boot client: %v", err)
+	}
+
+	handler := NewHandler(bootClient, log.New(io.Discard, "", 0))
+	router := chi.NewRouter()
+	handler.RegisterModernRoutes(router)
+
+	tests := []struct {
+		path     string
+		platform string
+		want     string
+	}{
+		{"/bootscript?mac=aa:bb:cc:dd:ee:ff", "", "goto firmware_default"},
+		{"/bootscript?mac=aa:bb:cc:dd:ee:ff&platform=efi&buildarch=x86_64", "", "set kernel http://files.example.com/vmlinuz.efi\n"},
+		{"/bootscript?mac=aa:bb:cc:dd:ee:ff&platform=pcbios", "", "set kernel http://files.example.com/vmlinuz\n"},
+		{"/nodes/x0c0s0b0n0/bootscript", "efi", "set kernel http://files.example.com/vmlinuz.efi\n"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.path, nil)
+		if tt.platform != "" {
+			req.Header.Set(FirmwarePlatformHeader, tt.platform)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("%s (platform header %q): expected %q, got %d: %s", tt.path, tt.platform, tt.want, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestGetBootScript_Kexec(t *testing.T) {
+	nodes := []apiv1.Node{
+		{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:ff", Groups: []string{"compute"}}},
+		{Spec: apiv1.NodeSpec{XName: "x0c0s1b0n0", NID: 2, BootMAC: "aa:bb:cc:dd:ee:01"}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "compute"},
+			Spec: apiv1.BootConfigurationSpec{
+				Groups: []string{"compute"},
+				Kernel: "http://files.example.com/vmlinuz",
+				Initrd: "http://files.example.com/initrd",
+				Params: "console=ttyS0 nid={{.NID}}",
+			},
+		},
+	}
+
+	backendServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes":
+			writeJSONResponse(t, w, nodes)
+		case "/bootconfigurations":
+			writeJSONResponse(t, w, configs)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer backendServer.Close()
+
+	bootClient, err := client.NewClient(backendServer.URL, backendServer.Client(), client.DefaultLogger())
+	if err != nil {
+		t.Fatalf("failed to create boot client: %v", err)
+	}
+
+	handler := NewHandler(bootClient, log.New(io.Discard, "", 0))
+	router := chi.NewRouter()
+	handler.RegisterModernRoutes(router)
+
+	for _, path := range []string{"/bootscript?nid=1&format=kexec", "/nodes/x0c0s0b0n0/bootscript?format=kexec"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d: %s", path, w.Code, w.Body.String())
+		}
+		var entry KexecEntry
+		if err := json.NewDecoder(w.Body).Decode(&entry); err != nil {
+			t.Fatalf("%s: failed to decode kexec entry: %v", path, err)
+		}
+		want := KexecEntry{
+			Node: "x0c0s0b0n0", Configuration: "compute",
+			Kernel: "http://files.example.com/vmlinuz", Initrd: "http://files.example.com/initrd",
+			Cmdline: "console=ttyS0 nid=1 BOOTIF=01-aa-bb-cc-dd-ee-ff",
+		}
+		if entry != want {
+			t.Errorf("%s: entry = %+v, want %+v", path, entry, want)
+		}
+	}
+
+	// A node without a configuration cannot kexec, and formats are checked
+	for path, status := range map[string]int{
+		"/bootscript?nid=2&format=kexec": http.StatusConflict,
+		"/bootscript?nid=1&format=grub":  http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != status {
+			t.Errorf("%s: expected status %d, got %d: %s", path, status, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestGetBootScript_RequestVars(t *testing.T) {
+	nodes := []apiv1.Node{
+		{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:ff"}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "default-config"},
+			Spec: apiv1.BootConfigurationSpec{
+				Kernel: "http://files.example.com/vmlinuz",
+				Params: `console=ttyS0 serial={{.Request.serial}} asset={{.Request.asset | default "none"}}`,
+			},
+		},
+	}
+
+	backendServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes":
+			writeJSONResponse(t, w, nodes)
+		case "/bootconfigurations":
+			writeJSONResponse(t, w, configs)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer backendServer.Close()
+
+	bootClient, err := client.NewClient(backendServer.URL, backendServer.Client(), client.DefaultLogger())
+	if err != nil {
+		t.Fatalf("failed to create boot client: %v", err)
+	}
+
+	handler := NewHandler(bootClient, log.New(io.Discard, "", 0))
+	handler.SetRequestVars([]string{"serial", "asset"})
+	router := chi.NewRouter()
+	handler.RegisterModernRoutes(router)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/bootscript?mac=aa:bb:cc:dd:ee:ff&serial=SN123&asset=A7", "serial=SN123 asset=A7"},
+		// Each set of values renders its own script rather than a cached one
+		{"/bootscript?mac=aa:bb:cc:dd:ee:ff&serial=SN456", "serial=SN456 asset=none"},
+		// Values the node did not report, or that are unsafe, render empty
+		{"/bootscript?mac=aa:bb:cc:dd:ee:ff&serial=SN%0A789&uuid=1234", "serial= asset=none"},
+		{"/nodes/x0c0s0b0n0/bootscript?serial=SN123", "serial=SN123 asset=none"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("%s: expected %q, got %d: %s", tt.path, tt.want, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestGetBootScript_HardwareIdentifiers(t *testing.T) {
+	nodes := []apiv1.Node{
+		{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:01", UUID: "4c4c4544-0042-3510-8052-b4c04f384d32", Serial: "SN0001"}},
+		{Spec: apiv1.NodeSpec{XName: "x0c0s1b0n0", NID: 2, BootMAC: "aa:bb:cc:dd:ee:02"}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "default-config"},
+			Spec:     apiv1.BootConfigurationSpec{Kernel: "http://files.example.com/vmlinuz"},
+		},
+	}
+
+	backendServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes":
+			writeJSONResponse(t, w, nodes)
+		case "/bootconfigurations":
+			writeJSONResponse(t, w, configs)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer backendServer.Close()
+
+	bootClient, err := client.NewClient(backendServer.URL, backendServer.Client(), client.DefaultLogger())
+	if err != nil {
+		t.Fatalf("failed to create boot client: %v", err)
+	}
+	logger := log.New(io.Discard, "", 0)
+	handler := NewHandlerWithController(bootClient, bootscript.NewBootScriptController(bootClient, logger), logger)
+	router := chi.NewRouter()
+	handler.RegisterModernRoutes(router)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		// The NIC was replaced, but the UUID and serial still name the node
+		{"/bootscript?mac=aa:bb:cc:dd:ee:99&uuid=4C4C4544-0042-3510-8052-B4C04F384D32", "Node: x0c0s0b0n0"},
+		{"/bootscript?mac=aa:bb:cc:dd:ee:99&serial=SN0001", "Node: x0c0s0b0n0"},
+		{"/bootscript?serial=SN0001", "Node: x0c0s0b0n0"},
+		{"/nodes/4c4c4544-0042-3510-8052-b4c04f384d32/bootscript", "Node: x0c0s0b0n0"},
+		// An unknown UUID leaves the node to its MAC
+		{"/bootscript?mac=aa:bb:cc:dd:ee:02&uuid=00000000-0000-0000-0000-000000000000", "Node: x0c0s1b0n0"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("%s: expected %q, got %d: %s", tt.path, tt.want, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestParseRequestVars(t *testing.T) {
+	names, err := ParseRequestVars(" serial, uuid,,serial ")
+	if err != nil {
+		t.Fatalf("ParseRequestVars failed: %v", err)
+	}
+	if strings.Join(names, ",") != "serial,uuid" {
+		t.Errorf("ParseRequestVars = %q, want [serial uuid]", names)
+	}
+
+	for _, list := range []string{"serial,mac", "asset-tag", "1st"} {
+		if _, err := ParseRequestVars(list); err == nil {
+			t.Errorf("ParseRequestVars(%q) succeeded, want an error", list)
+		}
+	}
+}
+
+type accessCounter map[string]accessstats.Stats
+
+func (c accessCounter) RecordAccess(node string, access bootscript.Access) {
+	stats := c[node]
+	stats.Node = node
+	stats.Requests++
+	stats.LastClient = access.Client
+	stats.LastUserAgent = access.UserAgent
+	c[node] = stats
+}
+
+func (c accessCounter) Get(_ context.Context, node string) (accessstats.Stats, error) {
+	stats := c[node]
+	stats.Node = node
+	return stats, nil
+}
+
+func TestGetNodeAccessStats(t *testing.T) {
+	nodes := []apiv1.Node{
+		{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:ff", Groups: []string{"compute"}}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "compute"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz"},
+		},
+	}
+
+	backendServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes":
+			writeJSONResponse(t, w, nodes)
+		case "/bootconfigurations":
+			writeJSONResponse(t, w, configs)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer backendServer.Close()
+
+	bootClient, err := client.NewClient(backendServer.URL, backendServer.Client(), client.DefaultLogger())
+	if err != nil {
+		t.Fatalf("failed to create boot client: %v", err)
+	}
+
+	logger := log.New(io.Discard, "", 0)
+	counter := accessCounter{}
+	controller := bootscript.NewBootScriptController(bootClient, logger)
+	controller.SetAccessRecorder(counter)
+	handler := NewHandlerWithController(bootClient, controller, logger)
+	handler.SetAccessStats(counter)
+	router := chi.NewRouter()
+	handler.RegisterModernRoutes(router)
+
+	// The second request is served from the cache; the preview is not counted
+	for _, path := range []string{
+		"/bootscript?mac=aa:bb:cc:dd:ee:ff",
+		"/bootscript?mac=aa:bb:cc:dd:ee:ff",
+		"/nodes/x0c0s0b0n0/bootscript",
+		"/nodes/x0c0s0b0n0/bootscript?dry-run=true",
+	} {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("User-Agent", "iPXE/1.21.1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d: %s", path, w.Code, w.Body.String())
+		}
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/nodes/1/access-stats", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var stats accessstats.Stats
+	if err := json.NewDecoder(w.Body).Decode(&stats); err != nil {
+		t.Fatalf("failed to decode access stats: %v", err)
+	}
+	if stats.Node != "x0c0s0b0n0" || stats.Requests != 3 || stats.LastUserAgent != "iPXE/1.21.1" || stats.LastClient != "192.0.2.1" {
+		t.Errorf("access stats = %+v, want 3 requests from 192.0.2.1 by iPXE/1.21.1", stats)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/nodes/x9c0s0b0n0/access-stats", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown node: expected status 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestNodeCordon(t *testing.T) {
+	nodes := []apiv1.Node{
+		{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:ff", Groups: []string{"compute"}}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "compute"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz"},
+		},
+	}
+
+	backendServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes":
+			writeJSONResponse(t, w, nodes)
+		case "/bootconfigurations":
+			writeJSONResponse(t, w, configs)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer backendServer.Close()
+
+	bootClient, err := client.NewClient(backendServer.URL, backendServer.Client(), client.DefaultLogger())
+	if err != nil {
+		t.Fatalf("failed to create boot client: %v", err)
+	}
+	storageBackend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+
+	logger := log.New(io.Discard, "", 0)
+	controller := bootscript.NewBootScriptController(bootClient, logger)
+	cordons := cordon.NewStore(storageBackend, controller.InvalidateNodeScripts, logger)
+	controller.SetCordons(cordons)
+	handler := NewHandlerWithController(bootClient, controller, logger)
+	handler.SetCordons(cordons)
+	router := chi.NewRouter()
+	handler.RegisterModernRoutes(router)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	// Cache the node's script before it is cordoned
+	if w := serve("GET", "/bootscript?mac=aa:bb:cc:dd:ee:ff", ""); !strings.Contains(w.Body.String(), "vmlinuz") {
+		t.Fatalf("expected the node's kernel, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w := serve("POST", "/nodes/aa:bb:cc:dd:ee:ff/cordon", `{"reason": "flaky DIMM"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("cordon: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = serve("GET", "/bootscript?mac=aa:bb:cc:dd:ee:ff", "")
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), "vmlinuz") || !strings.Contains(w.Body.String(), "flaky DIMM") {
+		t.Errorf("expected the hold script, got %d: %s", w.Code, w.Body.String())
+	}
+	w = serve("GET", "/nodes/1/cordon", "")
+	var c cordon.Cordon
+	if w.Code != http.StatusOK || json.NewDecoder(w.Body).Decode(&c) != nil || c.Node != "x0c0s0b0n0" || c.Action != cordon.ActionHold {
+		t.Errorf("GET cordon = %d: %+v", w.Code, c)
+	}
+
+	// Without a body the cordon holds the node with no reason
+	if w := serve("POST", "/nodes/x0c0s0b0n0/cordon", ""); w.Code != http.StatusOK {
+		t.Errorf("cordon without a body: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := serve("POST", "/nodes/x0c0s0b0n0/cordon", `{"action": "unavailable", "retryAfterSeconds": 120}`); w.Code != http.StatusOK {
+		t.Fatalf("cordon: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = serve("GET", "/nodes/x0c0s0b0n0/bootscript", "")
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") != "120" {
+		t.Errorf("expected 503 with Retry-After 120, got %d (Retry-After %q): %s", w.Code, w.Header().Get("Retry-After"), w.Body.String())
+	}
+
+	if w := serve("POST", "/nodes/x0c0s0b0n0/cordon", `{"action": "drain"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid action: expected status 400, got %d", w.Code)
+	}
+	if w := serve("POST", "/nodes/x9c0s0b0n0/cordon", ""); w.Code != http.StatusNotFound {
+		t.Errorf("unknown node: expected status 404, got %d", w.Code)
+	}
+
+	if w := serve("POST", "/nodes/x0c0s0b0n0/uncordon", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("uncordon: expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("GET", "/bootscript?mac=aa:bb:cc:dd:ee:ff", ""); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "vmlinuz") {
+		t.Errorf("expected the node's kernel after uncordoning, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("GET", "/nodes/x0c0s0b0n0/cordon", ""); w.Code != http.StatusNotFound {
+		t.Errorf("GET cordon after uncordoning: expected status 404, got %d", w.Code)
+	}
+}
+
+func TestNodeBootOnce(t *testing.T) {
+	nodes := []apiv1.Node{
+		{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:ff", Groups: []string{"compute"}}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "compute"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"compute"}, Kernel: "http://files.example.com/vmlinuz"},
+		},
+		{
+			Metadata: resource.Metadata{Name: "memtest"},
+			Spec:     apiv1.BootConfigurationSpec{Groups: []string{"diagnostics"}, Kernel: "http://files.example.com/memtest"},
+		},
+	}
+
+	backendServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes":
+			writeJSONResponse(t, w, nodes)
+		case "/bootconfigurations":
+			writeJSONResponse(t, w, configs)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer backendServer.Close()
+
+	bootClient, err := client.NewClient(backendServer.URL, backendServer.Client(), client.DefaultLogger())
+	if err != nil {
+		t.Fatalf("failed to create boot client: %v", err)
+	}
+	storageBackend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+
+	logger := log.New(io.Discard, "", 0)
+	controller := bootscript.NewBootScriptController(bootClient, logger)
+	overrides := bootonce.NewStore(storageBackend, controller.InvalidateNodeScripts, logger)
+	controller.SetBootOnce(overrides)
+	handler := NewHandlerWithController(bootClient, controller, logger)
+	handler.SetBootOnce(overrides)
+	router := chi.NewRouter()
+	handler.RegisterModernRoutes(router)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	// Cache the node's script before the override is set
+	if w := serve("GET", "/bootscript?mac=aa:bb:cc:dd:ee:ff", ""); !strings.Contains(w.Body.String(), "vmlinuz") {
+		t.Fatalf("expected the node's kernel, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := serve("POST", "/nodes/x0c0s0b0n0/boot-once", `{"configuration": "rescue"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("unknown configuration: expected status 400, got %d", w.Code)
+	}
+	if w := serve("POST", "/nodes/x9c0s0b0n0/boot-once", `{"configuration": "memtest"}`); w.Code != http.StatusNotFound {
+		t.Errorf("unknown node: expected status 404, got %d", w.Code)
+	}
+	w := serve("POST", "/nodes/1/boot-once", `{"configuration": "memtest", "reason": "ECC errors"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("boot-once: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := serve("GET", "/bootscript?mac=aa:bb:cc:dd:ee:ff", ""); !strings.Contains(w.Body.String(), "files.example.com/memtest") {
+		t.Errorf("expected the override's kernel, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("GET", "/bootscript?mac=aa:bb:cc:dd:ee:ff", ""); !strings.Contains(w.Body.String(), "files.example.com/vmlinuz") {
+		t.Errorf("expected the node's kernel after the override, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = serve("GET", "/nodes/x0c0s0b0n0/boot-once", "")
+	var override bootonce.Override
+	if w.Code != http.StatusOK || json.NewDecoder(w.Body).Decode(&override) != nil || override.Pending() || override.Configuration != "memtest" {
+		t.Errorf("GET boot-once = %d: %+v, want the served override", w.Code, override)
+	}
+	if w := serve("DELETE", "/nodes/x0c0s0b0n0/boot-once", ""); w.Code != http.StatusNotFound {
+		t.Errorf("cancel after serving: expected status 404, got %d", w.Code)
+	}
+
+	if w := serve("POST", "/nodes/x0c0s0b0n0/boot-once", `{"configuration": "memtest"}`); w.Code != http.StatusOK {
+		t.Fatalf("boot-once: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("DELETE", "/nodes/x0c0s0b0n0/boot-once", ""); w.Code != http.StatusNoContent {
+		t.Errorf("cancel: expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("GET", "/bootscript?mac=aa:bb:cc:dd:ee:ff", ""); !strings.Contains(w.Body.String(), "files.example.com/vmlinuz") {
+		t.Errorf("expected the node's kernel after canceling, got %d: %s", w.Code, w.Body.String())
+	}
+}
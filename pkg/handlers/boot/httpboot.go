@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package boot
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/activity"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// BootEntryResolver is implemented by controllers that can resolve what a
+// node boots for boot loaders other than iPXE
+type BootEntryResolver interface {
+	ResolveBootEntry(ctx context.Context, identifier, profile string) (*bootscript.BootEntry, error)
+}
+
+// SetHTTPBootLoader serves loader, the path of an EFI binary such as a
+// signed shim or GRUB, or an http(s) URL to redirect to, at
+// /httpboot/{mac}/boot.efi. Call it before registering routes.
+func (h *Handler) SetHTTPBootLoader(loader string) {
+	h.httpBootLoader = loader
+}
+
+// registerHTTPBootRoutes registers the UEFI HTTP boot entry points. A node's
+// DHCP boot file URL names its MAC, so GRUB loaded from
+// /httpboot/{mac}/boot.efi reads grub.cfg from the same directory.
+func (h *Handler) registerHTTPBootRoutes(r chi.Router) {
+	r.With(h.scriptMiddleware...).Get("/httpboot/{mac}/grub.cfg", h.GetGrubConfig)
+	if h.httpBootLoader != "" {
+		r.Get("/httpboot/{mac}/boot.efi", h.GetHTTPBootLoader)
+	}
+}
+
+// GetGrubConfig handles GET /httpboot/{mac}/grub.cfg, the GRUB configuration
+// booting what the node's boot script would. Firmware variants for efi
+// apply; ?buildarch= selects an arch-specific one.
+func (h *Handler) GetGrubConfig(w http.ResponseWriter, r *http.Request) {
+	mac, ok := h.httpBootMAC(w, r)
+	if !ok {
+		return
+	}
+	resolver, ok := h.controller.(BootEntryResolver)
+	if !ok {
+		h.writeError(w, http.StatusNotImplemented, "UEFI HTTP boot not supported", "The configured boot controller cannot resolve boot entries")
+		return
+	}
+	if h.activity != nil {
+		h.activity.Publish(activity.Event{Type: activity.Request, Identifier: mac, Client: clientAddress(r)})
+	}
+
+	firmware := bootscript.NewFirmware(apiv1.FirmwareEFI, r.URL.Query().Get("buildarch"))
+	entry, err := resolver.ResolveBootEntry(bootscript.WithFirmware(r.Context(), firmware), mac, "")
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to resolve boot entry", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(bootscript.GrubConfig(entry))) //nolint:errcheck
+}
+
+// GetHTTPBootLoader handles GET /httpboot/{mac}/boot.efi, serving the same
+// EFI loader to every node
+func (h *Handler) GetHTTPBootLoader(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.httpBootMAC(w, r); !ok {
+		return
+	}
+	if strings.HasPrefix(h.httpBootLoader, "http://") || strings.HasPrefix(h.httpBootLoader, "https://") {
+		http.Redirect(w, r, h.httpBootLoader, http.StatusFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/efi")
+	http.ServeFile(w, r, h.httpBootLoader)
+}
+
+// httpBootMAC returns the normalized MAC in the request path, or writes an
+// error if it is not one
+func (h *Handler) httpBootMAC(w http.ResponseWriter, r *http.Request) (string, bool) {
+	mac := chi.URLParam(r, "mac")
+	if !validation.ValidateMAC(mac) {
+		h.writeError(w, http.StatusBadRequest, "Invalid MAC address", "The path must name the node's MAC address, not "+mac)
+		return "", false
+	}
+	return validation.NormalizeMAC(mac), true
+}
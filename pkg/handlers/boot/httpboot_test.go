@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package boot
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func TestHTTPBoot(t *testing.T) {
+	nodes := []apiv1.Node{
+		{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:ff"}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{
+			Metadata: resource.Metadata{Name: "default-config"},
+			Spec: apiv1.BootConfigurationSpec{
+				Kernel:   "http://files.example.com/vmlinuz",
+				Firmware: []apiv1.FirmwareVariant{{Platform: apiv1.FirmwareEFI, Arch: "arm64", Kernel: "http://files.example.com/vmlinuz-arm64"}},
+			},
+		},
+	}
+
+	backendServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes":
+			writeJSONResponse(t, w, nodes)
+		case "/bootconfigurations":
+			writeJSONResponse(t, w, configs)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer backendServer.Close()
+
+	bootClient, err := client.NewClient(backendServer.URL, backendServer.Client(), client.DefaultLogger())
+	if err != nil {
+		t.Fatalf("failed to create boot client: %v", err)
+	}
+	loader := filepath.Join(t.TempDir(), "grubx64.efi")
+	if err := os.WriteFile(loader, []byte("MZ-efi-binary"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := NewHandler(bootClient, log.New(io.Discard, "", 0))
+	handler.SetHTTPBootLoader(loader)
+	router := chi.NewRouter()
+	handler.RegisterModernRoutes(router)
+
+	tests := []struct {
+		path   string
+		status int
+		want   string
+	}{
+		{"/httpboot/aa-bb-cc-dd-ee-ff/grub.cfg", http.StatusOK, "\tlinux (http,files.example.com)/vmlinuz BOOTIF=01-aa-bb-cc-dd-ee-ff\n"},
+		{"/httpboot/aa:bb:cc:dd:ee:ff/grub.cfg?buildarch=arm64", http.StatusOK, "\tlinux (http,files.example.com)/vmlinuz-arm64 "},
+		{"/httpboot/00:00:00:00:00:01/grub.cfg", http.StatusOK, "node not found"},
+		{"/httpboot/not-a-mac/grub.cfg", http.StatusBadRequest, "Invalid MAC address"},
+		{"/httpboot/aa:bb:cc:dd:ee:ff/boot.efi", http.StatusOK, "MZ-efi-binary"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Code != tt.status || !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("%s: expected %d with %q, got %d: %s", tt.path, tt.status, tt.want, w.Code, w.Body.String())
+		}
+	}
+
+	// A loader URL is redirected to
+	handler.SetHTTPBootLoader("https://files.example.com/shimx64.efi")
+	router = chi.NewRouter()
+	handler.RegisterModernRoutes(router)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/httpboot/aa:bb:cc:dd:ee:ff/boot.efi", nil))
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "https://files.example.com/shimx64.efi" {
+		t.Errorf("expected a redirect to the loader URL, got %d %q", w.Code, w.Header().Get("Location"))
+	}
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package boot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+)
+
+// maxRequestVarLength bounds a reported value, which ends up in scripts
+const maxRequestVarLength = 256
+
+var (
+	// requestVarName keeps names usable as {{.Request.name}}
+	requestVarName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+	// reservedQueryParams are read by the boot script endpoints themselves
+	reservedQueryParams = map[string]bool{
+		"mac": true, "nid": true, "host": true, "name": true, "platform": true,
+		"buildarch": true, "format": true, "dry-run": true, "at": true, "profile": true,
+	}
+)
+
+// ParseRequestVars parses a comma-separated list of query parameter names
+// for SetRequestVars
+func ParseRequestVars(list string) ([]string, error) {
+	var names []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+			continue
+		case !requestVarName.MatchString(name):
+			return nil, fmt.Errorf("invalid request variable %q: use letters, digits, and '_', starting with a letter", name)
+		case reservedQueryParams[name]:
+			return nil, fmt.Errorf("request variable %q is a boot script query parameter", name)
+		case seen[name]:
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// SetRequestVars makes the named query parameters of boot script requests,
+// such as serial in ?mac=${mac}&serial=${serial}, available to kernel
+// parameter templates as {{.Request.serial}}
+func (h *Handler) SetRequestVars(names []string) {
+	h.requestVars = names
+}
+
+// scriptContext returns the context boot scripts for r are rendered in: with
+// the firmware and the request variables the node reported
+func (h *Handler) scriptContext(r *http.Request) context.Context {
+	ctx := withFirmware(r)
+	if len(h.requestVars) == 0 {
+		return ctx
+	}
+
+	query := r.URL.Query()
+	vars := make(map[string]string, len(h.requestVars))
+	for _, name := range h.requestVars {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		if len(value) > maxRequestVarLength || strings.IndexFunc(value, unicode.IsControl) >= 0 {
+			h.logger.Printf("Ignoring request variable %s from %s: value is too long or has control characters", name, r.RemoteAddr)
+			continue
+		}
+		vars[name] = value
+	}
+	return bootscript.WithRequestVars(ctx, vars)
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package boot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/pkg/timeline"
+)
+
+// TimelineReader returns the boot timeline of a node, by xname
+type TimelineReader interface {
+	Get(ctx context.Context, node string) (timeline.Timeline, error)
+}
+
+// SetTimeline enables GET /nodes/{uid}/timeline, served from timelines.
+// Events are recorded from the activity of the controller and handler.
+// Call it before registering routes.
+func (h *Handler) SetTimeline(timelines TimelineReader) {
+	h.timelines = timelines
+}
+
+// GetNodeTimeline handles GET /nodes/{uid}/timeline, oldest event first.
+// The RFC 3339 ?since= and ?until= query parameters bound the events
+// returned. The node may be identified as for GET /nodes/{uid}/bootscript.
+func (h *Handler) GetNodeTimeline(w http.ResponseWriter, r *http.Request) {
+	since, err := timeParam(r, "since")
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid time range", err.Error())
+		return
+	}
+	until, err := timeParam(r, "until")
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid time range", err.Error())
+		return
+	}
+
+	matcher, ok := h.controller.(ConfigurationMatcher)
+	if !ok {
+		h.writeError(w, http.StatusNotImplemented, "Boot timelines not supported", "The configured boot controller cannot resolve nodes")
+		return
+	}
+	matches, err := matcher.MatchingConfigurations(r.Context(), chi.URLParam(r, "uid"))
+	if err != nil {
+		h.writeMatchError(w, err)
+		return
+	}
+
+	nodeTimeline, err := h.timelines.Get(r.Context(), matches.NodeXName)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to load boot timeline", err.Error())
+		return
+	}
+	h.writeJSON(w, http.StatusOK, nodeTimeline.Between(since, until))
+}
+
+// timeParam parses an optional RFC 3339 query parameter
+func timeParam(r *http.Request, name string) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC 3339 time such as 2026-03-01T02:00:00Z: %w", name, err)
+	}
+	return at, nil
+}
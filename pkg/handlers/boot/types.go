@@ -63,10 +63,30 @@ type BootScriptRequest struct {
 	Mac  string `json:"mac,omitempty"`
 	Nid  string `json:"nid,omitempty"`
 
+	// SMBIOS identifiers, as iPXE reports them in ${uuid} and ${serial}
+	UUID   string `json:"uuid,omitempty"`
+	Serial string `json:"serial,omitempty"`
+
 	// Optional parameters
 	Retry  bool   `json:"retry,omitempty"`
 	Token  string `json:"token,omitempty"`
-	Format string `json:"format,omitempty"` // defaults to "ipxe"
+	Format string `json:"format,omitempty"` // FormatIPXE (the default) or FormatKexec
+}
+
+// Boot script formats
+const (
+	FormatIPXE  = "ipxe"
+	FormatKexec = "kexec" // a KexecEntry for an on-node kexec agent
+)
+
+// KexecEntry is the format=kexec response: the kernel an on-node agent
+// loads with kexec instead of rebooting through firmware
+type KexecEntry struct {
+	Node          string `json:"node"`
+	Configuration string `json:"configuration"`
+	Kernel        string `json:"kernel"`
+	Initrd        string `json:"initrd,omitempty"`
+	Cmdline       string `json:"cmdline"`
 }
 
 // ServiceStatus represents the legacy service status format
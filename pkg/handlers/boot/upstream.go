@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package boot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+)
+
+// UpstreamSourceHeader is set to "bss" on legacy responses served by the
+// upstream BSS instead of this service
+const UpstreamSourceHeader = "X-Boot-Service-Source"
+
+// maxUpstreamBody bounds legacy request and upstream response bodies
+const maxUpstreamBody = 10 << 20
+
+// Upstream is a BSS instance the legacy API falls back to during migration.
+// Legacy writes are applied locally and mirrored to it (dual-write), and
+// reads of boot parameters or boot scripts this service does not have are
+// answered by it (read-through), so nodes can move over gradually.
+type Upstream struct {
+	baseURL string
+	token   string
+	client  *http.Client
+	logger  *log.Logger
+}
+
+// NewUpstream forwards legacy requests to the BSS at baseURL, such as
+// http://bss:27778, with token as bearer token when set
+func NewUpstream(baseURL, token string, timeout time.Duration, logger *log.Logger) (*Upstream, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid BSS upstream URL %q", baseURL)
+	}
+	if logger == nil {
+		logger = log.New(log.Writer(), "bss-upstream: ", log.LstdFlags)
+	}
+	return &Upstream{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: timeout},
+		logger:  logger,
+	}, nil
+}
+
+// SetUpstream enables dual-write and read-through to a BSS instance on the
+// legacy /boot/v1 routes. Call it before registering routes.
+func (h *Handler) SetUpstream(upstream *Upstream) {
+	h.upstream = upstream
+}
+
+type missingKey struct{}
+
+// markMissing records that the legacy request asked for something this
+// service does not have, so the upstream BSS answers it instead
+func markMissing(ctx context.Context) {
+	if missing, ok := ctx.Value(missingKey{}).(*bool); ok {
+		*missing = true
+	}
+}
+
+// readThrough reports whether requests under ctx fall back to an upstream BSS
+func readThrough(ctx context.Context) bool {
+	_, ok := ctx.Value(missingKey{}).(*bool)
+	return ok
+}
+
+// nodeUnknown reports whether the boot script controller has no node or no
+// matching configuration for identifier
+func (h *Handler) nodeUnknown(ctx context.Context, identifier string) bool {
+	matcher, ok := h.controller.(ConfigurationMatcher)
+	if !ok {
+		return false
+	}
+	matches, err := matcher.MatchingConfigurations(ctx, identifier)
+	if errors.Is(err, bootscript.ErrNodeNotFound) {
+		return true
+	}
+	return err == nil && len(matches.Matches) == 0
+}
+
+// Middleware serves legacy requests locally first. Reads the local handler
+// marks as missing are answered by the upstream BSS instead. Writes are sent
+// to both; the upstream response is returned only when the local one is 404.
+func (u *Upstream) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body []byte
+		if r.Body != nil {
+			var err error
+			body, err = io.ReadAll(http.MaxBytesReader(w, r.Body, maxUpstreamBody))
+			if err != nil {
+				http.Error(w, "failed to read request body", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		missing := false
+		local := &bufferedResponse{header: http.Header{}, status: http.StatusOK}
+		next.ServeHTTP(local, r.WithContext(context.WithValue(r.Context(), missingKey{}, &missing)))
+
+		if isReadMethod(r.Method) {
+			if missing {
+				resp, err := u.forward(r, body)
+				if err == nil {
+					resp.writeTo(w)
+					return
+				}
+				u.logger.Printf("Read-through of %s %s failed, serving local response: %v", r.Method, r.URL.RequestURI(), err)
+			}
+			local.writeTo(w)
+			return
+		}
+
+		// Dual-write: BSS keeps receiving every change until cutover
+		resp, err := u.forward(r, body)
+		switch {
+		case err != nil:
+			u.logger.Printf("Dual-write of %s %s to BSS failed: %v", r.Method, r.URL.RequestURI(), err)
+		case local.status == http.StatusNotFound && resp.status < 400:
+			// The resource only exists in BSS so far
+			resp.writeTo(w)
+			return
+		case resp.status >= 400:
+			u.logger.Printf("Dual-write of %s %s to BSS returned %d", r.Method, r.URL.RequestURI(), resp.status)
+		}
+		local.writeTo(w)
+	})
+}
+
+// forward sends a copy of r to the upstream BSS
+func (u *Upstream) forward(r *http.Request, body []byte) (*bufferedResponse, error) {
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, u.baseURL+r.URL.RequestURI(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range []string{"Content-Type", "Accept"} {
+		if value := r.Header.Get(name); value != "" {
+			req.Header.Set(name, value)
+		}
+	}
+	if u.token != "" {
+		req.Header.Set("Authorization", "Bearer "+u.token)
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxUpstreamBody))
+	if err != nil {
+		return nil, fmt.Errorf("reading BSS response: %w", err)
+	}
+
+	result := &bufferedResponse{header: http.Header{}, status: resp.StatusCode}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		result.header.Set("Content-Type", contentType)
+	}
+	result.header.Set(UpstreamSourceHeader, "bss")
+	result.body.Write(data)
+	return result, nil
+}
+
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// bufferedResponse holds a response until it is chosen to be sent
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+	wrote  bool
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	if !b.wrote {
+		b.status = status
+		b.wrote = true
+	}
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	b.wrote = true
+	return b.body.Write(p)
+}
+
+func (b *bufferedResponse) writeTo(w http.ResponseWriter) {
+	for name, values := range b.header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(b.status)
+	w.Write(b.body.Bytes()) //nolint:errcheck
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package boot
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/client"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func TestUpstream(t *testing.T) {
+	nodes := []apiv1.Node{
+		{Metadata: resource.Metadata{UID: "nod-1"}, Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0", NID: 1, BootMAC: "aa:bb:cc:dd:ee:ff"}},
+	}
+	configs := []apiv1.BootConfiguration{
+		{Metadata: resource.Metadata{UID: "bc-1"}, Spec: apiv1.BootConfigurationSpec{Hosts: []string{"x0c0s0b0n0"}, Kernel: "http://files.example.com/vmlinuz"}},
+	}
+	backendServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes":
+			writeJSONResponse(t, w, nodes)
+		case "/bootconfigurations":
+			writeJSONResponse(t, w, configs)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer backendServer.Close()
+
+	var mu sync.Mutex
+	var bssRequests []string
+	bss := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		bssRequests = append(bssRequests, r.Method+" "+r.URL.RequestURI()+" "+r.Header.Get("Authorization"))
+		mu.Unlock()
+		if r.URL.Path == "/boot/v1/bootscript" {
+			_, _ = io.WriteString(w, "#!ipxe\necho from bss\n")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[{"hosts":["x9c0s0b0n0"]}]`)
+	}))
+	defer bss.Close()
+
+	bootClient, err := client.NewClient(backendServer.URL, backendServer.Client(), client.DefaultLogger())
+	if err != nil {
+		t.Fatalf("failed to create boot client: %v", err)
+	}
+	logger := log.New(io.Discard, "", 0)
+	upstream, err := NewUpstream(bss.URL, "bss-token", time.Second, logger)
+	if err != nil {
+		t.Fatalf("NewUpstream: %v", err)
+	}
+	handler := NewHandlerWithController(bootClient, bootscript.NewBootScriptController(bootClient, logger), logger)
+	handler.SetUpstream(upstream)
+	router := chi.NewRouter()
+	handler.RegisterModernRoutes(router)
+	handler.RegisterLegacyRoutes(router)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	// Known nodes and parameters are served locally
+	if w := serve("GET", "/boot/v1/bootscript?mac=aa:bb:cc:dd:ee:ff", ""); w.Header().Get(UpstreamSourceHeader) != "" || !strings.Contains(w.Body.String(), "vmlinuz") {
+		t.Errorf("expected the local script, got %q from %q", w.Body.String(), w.Header().Get(UpstreamSourceHeader))
+	}
+	if w := serve("GET", "/boot/v1/bootparameters?name=x0c0s0b0n0", ""); w.Header().Get(UpstreamSourceHeader) != "" {
+		t.Errorf("expected local boot parameters, got %s", w.Body.String())
+	}
+
+	// Unknown ones are read through to BSS
+	if w := serve("GET", "/boot/v1/bootscript?mac=aa:bb:cc:dd:ee:99", ""); w.Header().Get(UpstreamSourceHeader) != "bss" || w.Body.String() != "#!ipxe\necho from bss\n" {
+		t.Errorf("expected the BSS script, got %d %q", w.Code, w.Body.String())
+	}
+	if w := serve("GET", "/boot/v1/bootparameters?name=x9c0s0b0n0", ""); w.Header().Get(UpstreamSourceHeader) != "bss" || !strings.Contains(w.Body.String(), "x9c0s0b0n0") {
+		t.Errorf("expected BSS boot parameters, got %d %q", w.Code, w.Body.String())
+	}
+	// The modern API never falls back
+	if w := serve("GET", "/bootparameters?name=x9c0s0b0n0", ""); w.Header().Get(UpstreamSourceHeader) != "" {
+		t.Errorf("modern API read through to BSS: %s", w.Body.String())
+	}
+
+	// An update of parameters only BSS has returns the BSS response
+	if w := serve("PUT", "/boot/v1/bootparameters", `{"hosts":["x9c0s0b0n0"],"kernel":"vmlinuz"}`); w.Code != http.StatusOK || w.Header().Get(UpstreamSourceHeader) != "bss" {
+		t.Errorf("expected the BSS update response, got %d %q", w.Code, w.Body.String())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{
+		"GET /boot/v1/bootscript?mac=aa:bb:cc:dd:ee:99 Bearer bss-token",
+		"GET /boot/v1/bootparameters?name=x9c0s0b0n0 Bearer bss-token",
+		"PUT /boot/v1/bootparameters Bearer bss-token",
+	}
+	if strings.Join(bssRequests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("BSS requests =\n%s\nwant\n%s", strings.Join(bssRequests, "\n"), strings.Join(want, "\n"))
+	}
+}
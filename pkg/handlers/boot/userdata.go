@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package boot
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/userdata"
+)
+
+// UserDataRenderer merges the user-data of a node
+type UserDataRenderer interface {
+	Render(ctx context.Context, node v1.NodeSpec) (userdata.Document, error)
+}
+
+// SetUserData enables GET /nodes/{uid}/user-data, merged by renderer. Call
+// it before registering routes.
+func (h *Handler) SetUserData(renderer UserDataRenderer) {
+	h.userData = renderer
+}
+
+// GetNodeUserData handles GET /nodes/{uid}/user-data, returning the merged
+// user-data of the node as cloud-init receives it, or, with ?explain=true,
+// as JSON naming the user-data merged. The node may be identified as for
+// GET /nodes/{uid}/bootscript.
+func (h *Handler) GetNodeUserData(w http.ResponseWriter, r *http.Request) {
+	matcher, ok := h.controller.(ConfigurationMatcher)
+	if !ok {
+		h.writeError(w, http.StatusNotImplemented, "User-data not supported", "The configured boot controller cannot resolve nodes")
+		return
+	}
+	matches, err := matcher.MatchingConfigurations(r.Context(), chi.URLParam(r, "uid"))
+	if err != nil {
+		h.writeMatchError(w, err)
+		return
+	}
+	node, err := h.client.GetNode(r.Context(), matches.NodeUID)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to load node", err.Error())
+		return
+	}
+
+	doc, err := h.userData.Render(r.Context(), node.Spec)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to merge user-data", err.Error())
+		return
+	}
+	if r.URL.Query().Get("explain") == "true" {
+		h.writeJSON(w, http.StatusOK, doc)
+		return
+	}
+	if doc.Content == "" {
+		h.writeError(w, http.StatusNotFound, "No user-data", "No user-data applies to node "+node.Spec.XName)
+		return
+	}
+	// A multi-part document is a MIME message carrying its own headers
+	contentType := doc.ContentType
+	if contentType == userdata.Multipart {
+		contentType = "text/plain"
+	}
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(doc.Content)) //nolint:errcheck
+}
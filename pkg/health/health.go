@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package health serves liveness and readiness probes. Liveness only reports
+// that the process is serving requests; readiness runs dependency checks.
+package health
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+)
+
+// Check and report statuses
+const (
+	StatusOK       = "ok"       // every check passed
+	StatusDegraded = "degraded" // only non-critical checks failed
+	StatusFailed   = "failed"   // a critical check failed
+)
+
+// DefaultTimeout bounds each readiness check
+const DefaultTimeout = 2 * time.Second
+
+// CheckFunc reports whether a dependency is usable
+type CheckFunc func(ctx context.Context) error
+
+// Result is the outcome of one check
+type Result struct {
+	Status     string `json:"status"`
+	Critical   bool   `json:"critical"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"durationMs"`
+}
+
+// Report is the outcome of every readiness check
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+type check struct {
+	name     string
+	critical bool
+	run      CheckFunc
+}
+
+// Checker runs readiness checks
+type Checker struct {
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	checks []check
+}
+
+// NewChecker creates a checker that gives each check up to timeout
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{timeout: timeout}
+}
+
+// Add registers a check. The service is not ready while a critical check
+// fails; a failing non-critical check only degrades the report.
+func (c *Checker) Add(name string, critical bool, run CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, check{name: name, critical: critical, run: run})
+}
+
+// Names returns the registered check names, sorted
+func (c *Checker) Names() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	names := make([]string, 0, len(c.checks))
+	for _, check := range c.checks {
+		names = append(names, check.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run runs every check concurrently
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := append([]check(nil), c.checks...)
+	c.mu.RUnlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+	for i, check := range checks {
+		result := results[i]
+		report.Checks[check.name] = result
+		switch {
+		case result.Status == StatusOK:
+		case result.Critical:
+			report.Status = StatusFailed
+		case report.Status == StatusOK:
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+// runCheck runs one check within the checker's timeout
+func (c *Checker) runCheck(ctx context.Context, check check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	errs := make(chan error, 1)
+	go func() { errs <- check.run(ctx) }()
+	var err error
+	select {
+	case err = <-errs:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := Result{Status: StatusOK, Critical: check.critical, DurationMS: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Handler serves the liveness and readiness probes
+type Handler struct {
+	checker *Checker
+}
+
+// NewHandler creates a probe handler for checker
+func NewHandler(checker *Checker) *Handler {
+	return &Handler{checker: checker}
+}
+
+// RegisterRoutes registers GET /livez and GET /readyz
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/livez", h.GetLive)
+	r.Get("/readyz", h.GetReady)
+}
+
+// GetLive handles GET /livez. It checks no dependencies, since restarting the
+// service would not bring them back.
+func (h *Handler) GetLive(w http.ResponseWriter, _ *http.Request) {
+	httputil.WriteJSON(w, http.StatusOK, map[string]string{"status": StatusOK})
+}
+
+// GetReady handles GET /readyz, returning 503 while a critical check fails
+func (h *Handler) GetReady(w http.ResponseWriter, r *http.Request) {
+	report := h.checker.Run(r.Context())
+	status := http.StatusOK
+	if report.Status == StatusFailed {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	httputil.WriteJSON(w, status, report)
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func ok(context.Context) error { return nil }
+
+func failing(context.Context) error { return errors.New("connection refused") }
+
+func TestChecker_Run(t *testing.T) {
+	tests := map[string]struct {
+		storage, hsm CheckFunc
+		want         string
+	}{
+		"all pass":             {storage: ok, hsm: ok, want: StatusOK},
+		"non-critical failure": {storage: ok, hsm: failing, want: StatusDegraded},
+		"critical failure":     {storage: failing, hsm: ok, want: StatusFailed},
+		"both failures":        {storage: failing, hsm: failing, want: StatusFailed},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			checker := NewChecker(time.Second)
+			checker.Add("storage", true, tt.storage)
+			checker.Add("hsm", false, tt.hsm)
+			report := checker.Run(context.Background())
+			if report.Status != tt.want {
+				t.Errorf("status = %s, want %s", report.Status, tt.want)
+			}
+			if len(report.Checks) != 2 || !report.Checks["storage"].Critical || report.Checks["hsm"].Critical {
+				t.Errorf("checks = %+v", report.Checks)
+			}
+		})
+	}
+}
+
+func TestChecker_Timeout(t *testing.T) {
+	checker := NewChecker(10 * time.Millisecond)
+	block := make(chan struct{})
+	defer close(block)
+	checker.Add("storage", true, func(context.Context) error {
+		<-block // ignores its context
+		return nil
+	})
+
+	report := checker.Run(context.Background())
+	if result := report.Checks["storage"]; result.Status != StatusFailed || result.Error != context.DeadlineExceeded.Error() {
+		t.Errorf("result = %+v, want a deadline failure", result)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Add("templates", true, ok)
+	checker.Add("hsm", false, failing)
+	router := chi.NewRouter()
+	NewHandler(checker).RegisterRoutes(router)
+
+	get := func(path string) (*httptest.ResponseRecorder, Report) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var report Report
+		if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+			t.Fatalf("%s returned invalid JSON: %v", path, err)
+		}
+		return w, report
+	}
+
+	w, report := get("/readyz")
+	if w.Code != http.StatusOK || report.Status != StatusDegraded || report.Checks["hsm"].Error != "connection refused" {
+		t.Errorf("/readyz = %d %+v, want 200 and degraded", w.Code, report)
+	}
+
+	checker.Add("storage", true, failing)
+	if w, report = get("/readyz"); w.Code != http.StatusServiceUnavailable || report.Status != StatusFailed {
+		t.Errorf("/readyz = %d %+v, want 503 and failed", w.Code, report)
+	}
+	if w, report = get("/livez"); w.Code != http.StatusOK || report.Status != StatusOK {
+		t.Errorf("/livez = %d %+v, want 200 regardless of checks", w.Code, report)
+	}
+}
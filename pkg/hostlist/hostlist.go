@@ -0,0 +1,287 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package hostlist parses numeric range lists such as 1000-1999 and hostlist
+// expressions such as x1000c0s[0-7]b0n[0-3] or nid00[000-100], so a boot
+// configuration can target thousands of nodes without enumerating them.
+package hostlist
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MaxExpansion bounds how many names Expand produces, so a short expression
+// cannot exhaust memory
+const MaxExpansion = 1 << 16
+
+// ErrTooLarge is returned by Expand when an expression names more than
+// MaxExpansion hosts
+var ErrTooLarge = errors.New("expression expands to too many names")
+
+// span is an inclusive range of numbers
+type span struct {
+	first, last int
+}
+
+// Ranges is a set of non-negative numbers given as a comma-separated list of
+// numbers and ranges, such as "1,5,1000-1999"
+type Ranges struct {
+	spans []span
+	width int // digits numbers are zero-padded to, 0 for none
+}
+
+// ParseRanges parses a comma-separated list of numbers and inclusive ranges
+func ParseRanges(expr string) (Ranges, error) {
+	var ranges Ranges
+	if strings.TrimSpace(expr) == "" {
+		return ranges, errors.New("empty range list")
+	}
+	for _, item := range strings.Split(expr, ",") {
+		item = strings.TrimSpace(item)
+		firstText, lastText, isRange := strings.Cut(item, "-")
+		if !isRange {
+			lastText = firstText
+		}
+		first, err := parseNumber(firstText)
+		if err != nil {
+			return Ranges{}, fmt.Errorf("invalid range %q: %w", item, err)
+		}
+		last, err := parseNumber(lastText)
+		if err != nil {
+			return Ranges{}, fmt.Errorf("invalid range %q: %w", item, err)
+		}
+		if last < first {
+			return Ranges{}, fmt.Errorf("invalid range %q: end is below start", item)
+		}
+		// A leading zero, as in 000-100, pads every number to that width
+		if len(firstText) > 1 && firstText[0] == '0' {
+			if ranges.width != 0 && ranges.width != len(firstText) {
+				return Ranges{}, fmt.Errorf("invalid range %q: mixed zero padding", item)
+			}
+			ranges.width = len(firstText)
+		}
+		ranges.spans = append(ranges.spans, span{first, last})
+	}
+	return ranges, nil
+}
+
+func parseNumber(text string) (int, error) {
+	if text == "" {
+		return 0, errors.New("missing number")
+	}
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("%q is not a number", text)
+		}
+	}
+	// NIDs are 32-bit, and the bound keeps span sizes from overflowing
+	n, err := strconv.ParseInt(text, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%q is above %d", text, math.MaxInt32)
+	}
+	return int(n), nil
+}
+
+// Contains reports whether n is in the set
+func (r Ranges) Contains(n int) bool {
+	for _, s := range r.spans {
+		if n >= s.first && n <= s.last {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns how many numbers the set lists, counting overlaps twice, or
+// MaxExpansion+1 for any larger number
+func (r Ranges) Len() int {
+	total := 0
+	for _, s := range r.spans {
+		total = addBounded(total, s.last-s.first+1)
+	}
+	return total
+}
+
+// addBounded returns a+b for sizes up to MaxExpansion+1, or MaxExpansion+1
+// when the sum is larger, so it cannot overflow
+func addBounded(a, b int) int {
+	if a > MaxExpansion || b > MaxExpansion-a {
+		return MaxExpansion + 1
+	}
+	return a + b
+}
+
+// mulBounded returns a*b for positive sizes up to MaxExpansion+1, or
+// MaxExpansion+1 when the product is larger, so it cannot overflow
+func mulBounded(a, b int) int {
+	if a > MaxExpansion || b > MaxExpansion/a {
+		return MaxExpansion + 1
+	}
+	return a * b
+}
+
+// Numbers returns the numbers of the set in listed order. It fails with
+// ErrTooLarge beyond MaxExpansion numbers.
+func (r Ranges) Numbers() ([]int, error) {
+	if r.Len() > MaxExpansion {
+		return nil, ErrTooLarge
+	}
+	numbers := make([]int, 0, r.Len())
+	for _, s := range r.spans {
+		for n := s.first; n <= s.last; n++ {
+			numbers = append(numbers, n)
+		}
+	}
+	return numbers, nil
+}
+
+// matchDigits reports whether digits, without sign or spaces, is a member
+// written with the set's zero padding
+func (r Ranges) matchDigits(digits string) bool {
+	if r.width > 0 && len(digits) != r.width {
+		return false
+	}
+	if r.width == 0 && len(digits) > 1 && digits[0] == '0' {
+		return false
+	}
+	n, err := strconv.Atoi(digits)
+	return err == nil && r.Contains(n)
+}
+
+func (r Ranges) format(n int) string {
+	return fmt.Sprintf("%0*d", r.width, n)
+}
+
+// segment is literal text, or a bracketed range set when literal is empty
+type segment struct {
+	literal string
+	ranges  Ranges
+}
+
+// Pattern is a parsed hostlist expression: literal text with bracketed range
+// lists, each standing for one of its numbers
+type Pattern struct {
+	expr     string
+	segments []segment
+}
+
+// IsExpression reports whether value uses hostlist brackets
+func IsExpression(value string) bool {
+	return strings.ContainsAny(value, "[]")
+}
+
+// Parse parses a hostlist expression such as x1000c0s[0-7]b0n[0-3]
+func Parse(expr string) (Pattern, error) {
+	pattern := Pattern{expr: expr}
+	rest := expr
+	for rest != "" {
+		open := strings.IndexByte(rest, '[')
+		if open < 0 {
+			if strings.Contains(rest, "]") {
+				return Pattern{}, fmt.Errorf("invalid hostlist %q: unmatched ]", expr)
+			}
+			pattern.segments = append(pattern.segments, segment{literal: rest})
+			break
+		}
+		if open > 0 {
+			if strings.Contains(rest[:open], "]") {
+				return Pattern{}, fmt.Errorf("invalid hostlist %q: unmatched ]", expr)
+			}
+			pattern.segments = append(pattern.segments, segment{literal: rest[:open]})
+		}
+		end := strings.IndexByte(rest, ']')
+		if end < open {
+			return Pattern{}, fmt.Errorf("invalid hostlist %q: unmatched [", expr)
+		}
+		ranges, err := ParseRanges(rest[open+1 : end])
+		if err != nil {
+			return Pattern{}, fmt.Errorf("invalid hostlist %q: %w", expr, err)
+		}
+		pattern.segments = append(pattern.segments, segment{ranges: ranges})
+		rest = rest[end+1:]
+	}
+	if len(pattern.segments) == 0 {
+		return Pattern{}, errors.New("empty hostlist")
+	}
+	return pattern, nil
+}
+
+// String returns the expression the pattern was parsed from
+func (p Pattern) String() string {
+	return p.expr
+}
+
+// Len returns how many names the pattern expands to, or MaxExpansion+1 for
+// any larger number
+func (p Pattern) Len() int {
+	total := 1
+	for _, seg := range p.segments {
+		if seg.literal == "" {
+			total = mulBounded(total, seg.ranges.Len())
+		}
+	}
+	return total
+}
+
+// Match reports whether value is one of the names of the pattern, without
+// expanding it
+func (p Pattern) Match(value string) bool {
+	return matchSegments(p.segments, value)
+}
+
+func matchSegments(segments []segment, value string) bool {
+	if len(segments) == 0 {
+		return value == ""
+	}
+	seg := segments[0]
+	if seg.literal != "" {
+		rest, ok := strings.CutPrefix(value, seg.literal)
+		return ok && matchSegments(segments[1:], rest)
+	}
+	// The number may be followed by more digits from a later segment, so try
+	// each length
+	digits := 0
+	for digits < len(value) && value[digits] >= '0' && value[digits] <= '9' {
+		digits++
+	}
+	for n := digits; n > 0; n-- {
+		if seg.ranges.matchDigits(value[:n]) && matchSegments(segments[1:], value[n:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Expand returns the names of the pattern in order. It fails with
+// ErrTooLarge beyond MaxExpansion names.
+func (p Pattern) Expand() ([]string, error) {
+	if p.Len() > MaxExpansion {
+		return nil, ErrTooLarge
+	}
+	names := []string{""}
+	for _, seg := range p.segments {
+		if seg.literal != "" {
+			for i := range names {
+				names[i] += seg.literal
+			}
+			continue
+		}
+		numbers, err := seg.ranges.Numbers()
+		if err != nil {
+			return nil, err
+		}
+		expanded := make([]string, 0, len(names)*len(numbers))
+		for _, name := range names {
+			for _, n := range numbers {
+				expanded = append(expanded, name+seg.ranges.format(n))
+			}
+		}
+		names = expanded
+	}
+	return names, nil
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package hostlist
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseRanges(t *testing.T) {
+	ranges, err := ParseRanges("1, 5,1000-1999")
+	if err != nil {
+		t.Fatalf("ParseRanges returned error: %v", err)
+	}
+	for _, n := range []int{1, 5, 1000, 1500, 1999} {
+		if !ranges.Contains(n) {
+			t.Errorf("Contains(%d) = false, want true", n)
+		}
+	}
+	for _, n := range []int{0, 2, 999, 2000} {
+		if ranges.Contains(n) {
+			t.Errorf("Contains(%d) = true, want false", n)
+		}
+	}
+	if ranges.Len() != 1002 {
+		t.Errorf("Len() = %d, want 1002", ranges.Len())
+	}
+
+	for _, expr := range []string{"", "a-b", "5-1", "1-", "-1", "1,,2", "00-10,000-100"} {
+		if _, err := ParseRanges(expr); err == nil {
+			t.Errorf("ParseRanges(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestPatternMatch(t *testing.T) {
+	tests := []struct {
+		expr  string
+		value string
+		want  bool
+	}{
+		{"x1000c0s[0-7]b0n[0-3]", "x1000c0s0b0n0", true},
+		{"x1000c0s[0-7]b0n[0-3]", "x1000c0s7b0n3", true},
+		{"x1000c0s[0-7]b0n[0-3]", "x1000c0s8b0n0", false},
+		{"x1000c0s[0-7]b0n[0-3]", "x1000c0s0b0n4", false},
+		{"x1000c0s[0-7]b0n[0-3]", "x1000c0s00b0n0", false},
+		{"nid00[000-100]", "nid00042", true},
+		{"nid00[000-100]", "nid00100", true},
+		{"nid00[000-100]", "nid0042", false},
+		{"nid00[000-100]", "nid00101", false},
+		{"x[1000-1001]c0s[1,3,5]b0n0", "x1001c0s3b0n0", true},
+		{"x[1000-1001]c0s[1,3,5]b0n0", "x1001c0s2b0n0", false},
+		{"n[1-12]1", "n121", true},
+		{"n[1-12]1", "n11", true},
+	}
+	for _, tt := range tests {
+		pattern, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", tt.expr, err)
+		}
+		if got := pattern.Match(tt.value); got != tt.want {
+			t.Errorf("Parse(%q).Match(%q) = %v, want %v", tt.expr, tt.value, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"", "x[0-3", "x0-3]", "x[]", "x[a-b]", "x]0[", "x[3-1]"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestPatternExpand(t *testing.T) {
+	pattern, err := Parse("x1000c0s[0-1]b0n[0-1]")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	names, err := pattern.Expand()
+	if err != nil {
+		t.Fatalf("Expand returned error: %v", err)
+	}
+	want := []string{"x1000c0s0b0n0", "x1000c0s0b0n1", "x1000c0s1b0n0", "x1000c0s1b0n1"}
+	if !slices.Equal(names, want) {
+		t.Errorf("Expand() = %v, want %v", names, want)
+	}
+
+	padded, _ := Parse("nid[008-010]")
+	if names, _ := padded.Expand(); !slices.Equal(names, []string{"nid008", "nid009", "nid010"}) {
+		t.Errorf("Expand() = %v, want zero-padded names", names)
+	}
+
+	huge, _ := Parse("x[0-999]c[0-999]")
+	if _, err := huge.Expand(); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expand() error = %v, want ErrTooLarge", err)
+	}
+}
+
+func TestHugeRanges(t *testing.T) {
+	if _, err := ParseRanges("0-9223372036854775807"); err == nil {
+		t.Error("ParseRanges accepted a number beyond the NID range")
+	}
+
+	ranges, err := ParseRanges("0-2147483647,0-2147483647")
+	if err != nil {
+		t.Fatalf("ParseRanges returned error: %v", err)
+	}
+	if ranges.Len() != MaxExpansion+1 {
+		t.Errorf("Len() = %d, want %d", ranges.Len(), MaxExpansion+1)
+	}
+	if _, err := ranges.Numbers(); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Numbers() error = %v, want ErrTooLarge", err)
+	}
+
+	pattern, err := Parse("x[0-2147483647]c[0-2147483647]s[0-2147483647]")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if pattern.Len() != MaxExpansion+1 {
+		t.Errorf("Len() = %d, want %d", pattern.Len(), MaxExpansion+1)
+	}
+	if _, err := pattern.Expand(); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expand() error = %v, want ErrTooLarge", err)
+	}
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package hsmsync
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/audit"
+	"github.com/openchami/boot-service/pkg/clients/hsm"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Path serves the HSM sync API
+const Path = "/admin/sync"
+
+// maxPauseSize bounds the body of POST /admin/sync/pause
+const maxPauseSize = 64 << 10
+
+// Handler serves the HSM sync API
+type Handler struct {
+	control *Control
+}
+
+// NewHandler creates an HSM sync API handler
+func NewHandler(control *Control) *Handler {
+	return &Handler{control: control}
+}
+
+// RegisterRoutes registers GET /admin/sync/{status,conflicts} and POST
+// /admin/sync/{run,pause,resume}
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route(Path, func(r chi.Router) {
+		r.Use(tenancy.Unscoped)
+		r.Get("/status", h.GetStatus)
+		r.Get("/conflicts", h.GetConflicts)
+		r.Post("/run", h.Run)
+		r.Post("/pause", h.Pause)
+		r.Post("/resume", h.Resume)
+	})
+}
+
+// GetStatus handles GET /admin/sync/status
+func (h *Handler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.control.Status(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to read sync status", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, status)
+}
+
+// GetConflicts handles GET /admin/sync/conflicts, listing the fields of
+// nodes that the last sync left diverging from HSM
+func (h *Handler) GetConflicts(w http.ResponseWriter, _ *http.Request) {
+	conflicts := h.control.Conflicts()
+	if conflicts == nil {
+		conflicts = []hsm.Conflict{}
+	}
+	httputil.WriteJSON(w, http.StatusOK, conflicts)
+}
+
+// Run handles POST /admin/sync/run, which syncs now instead of waiting for
+// the interval
+func (h *Handler) Run(w http.ResponseWriter, r *http.Request) {
+	status, err := h.control.Run(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadGateway, "Sync failed", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, status)
+}
+
+// Pause handles POST /admin/sync/pause, which stops scheduled syncs with
+// the reason in the optional body
+func (h *Handler) Pause(w http.ResponseWriter, r *http.Request) {
+	var body Pause
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPauseSize)).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid pause", err.Error())
+		return
+	}
+	// Only the reason is taken from the body
+	pause := Pause{Reason: body.Reason}
+	if actor, ok := audit.ActorFromContext(r.Context()); ok && actor.Subject != "" && actor.Subject != audit.AnonymousSubject {
+		pause.PausedBy = actor.Subject
+	}
+
+	pause, err := h.control.Pause(r.Context(), pause)
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to pause sync", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, pause)
+}
+
+// Resume handles POST /admin/sync/resume. Resuming syncs that are not
+// paused succeeds too.
+func (h *Handler) Resume(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.control.Resume(r.Context()); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to resume sync", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
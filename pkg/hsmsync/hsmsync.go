@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package hsmsync controls the background sync of nodes from HSM: it
+// reports the last sync, runs one on request, pauses scheduled syncs, such
+// as while HSM is being repaired or its data is known to be wrong, and lists
+// the nodes whose local edits the conflict policy keeps over HSM.
+//
+// A pause is stored with the resources, so it survives restarts and
+// applies to whichever replica runs the background sync.
+package hsmsync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/openchami/boot-service/pkg/activity"
+	"github.com/openchami/boot-service/pkg/clients/hsm"
+)
+
+// ResourceType is the storage resource type of a sync pause
+const ResourceType = "HSMSyncPause"
+
+// pauseUID is the UID of the one stored pause
+const pauseUID = "hsm"
+
+// Syncer runs HSM syncs. *hsm.IntegrationService implements it.
+type Syncer interface {
+	SyncNodesFromHSM(ctx context.Context) error
+	LastSync() hsm.SyncStatus
+	SyncEnabled() bool
+	SyncInterval() time.Duration
+	SetPauseCheck(paused hsm.PauseCheck)
+	Divergences() []hsm.Conflict
+}
+
+// Pause stops scheduled syncs until it is lifted
+type Pause struct {
+	Reason string `json:"reason,omitempty"`
+	// PausedBy is the token subject that paused syncs, if known
+	PausedBy string    `json:"pausedBy,omitempty"`
+	PausedAt time.Time `json:"pausedAt,omitzero"`
+}
+
+// Status reports the background sync
+type Status struct {
+	// Enabled is set when scheduled syncs run (hsm_sync_enabled)
+	Enabled  bool   `json:"enabled"`
+	Interval string `json:"interval"`
+	Paused   bool   `json:"paused"`
+	Pause    *Pause `json:"pause,omitempty"`
+	// LastSync is the last sync this replica ran
+	LastSync hsm.SyncStatus `json:"lastSync"`
+}
+
+// Control runs, reports, and pauses the syncs of a Syncer
+type Control struct {
+	syncer  Syncer
+	backend fabricaStorage.StorageBackend
+	logger  *log.Logger
+}
+
+// NewControl creates a control of syncer that stores its pause in backend,
+// and makes scheduled syncs of syncer honor the pause
+func NewControl(syncer Syncer, backend fabricaStorage.StorageBackend, logger *log.Logger) *Control {
+	c := &Control{syncer: syncer, backend: backend, logger: logger}
+	syncer.SetPauseCheck(c.paused)
+	return c
+}
+
+// Status returns the state of the background sync
+func (c *Control) Status(ctx context.Context) (Status, error) {
+	pause, err := c.Paused(ctx)
+	if err != nil {
+		return Status{}, err
+	}
+	return Status{
+		Enabled:  c.syncer.SyncEnabled(),
+		Interval: c.syncer.SyncInterval().String(),
+		Paused:   pause != nil,
+		Pause:    pause,
+		LastSync: c.syncer.LastSync(),
+	}, nil
+}
+
+// Run syncs now, even while scheduled syncs are paused, and returns the
+// outcome. A sync already running is waited for first.
+func (c *Control) Run(ctx context.Context) (hsm.SyncStatus, error) {
+	err := c.syncer.SyncNodesFromHSM(ctx)
+	return c.syncer.LastSync(), err
+}
+
+// Pause stops scheduled syncs, replacing any earlier pause
+func (c *Control) Pause(ctx context.Context, pause Pause) (Pause, error) {
+	if pause.PausedAt.IsZero() {
+		pause.PausedAt = time.Now().UTC()
+	}
+	data, err := json.Marshal(pause)
+	if err != nil {
+		return Pause{}, fmt.Errorf("encoding HSM sync pause: %w", err)
+	}
+	if err := c.backend.Save(ctx, ResourceType, pauseUID, data); err != nil {
+		return Pause{}, fmt.Errorf("saving HSM sync pause: %w", err)
+	}
+	c.logger.Printf("HSM sync paused by %q: %s", pause.PausedBy, pause.Reason)
+	return pause, nil
+}
+
+// Resume lets scheduled syncs run again. It reports whether syncs were
+// paused.
+func (c *Control) Resume(ctx context.Context) (bool, error) {
+	err := c.backend.Delete(ctx, ResourceType, pauseUID)
+	if errors.Is(err, fabricaStorage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("deleting HSM sync pause: %w", err)
+	}
+	c.logger.Printf("HSM sync resumed")
+	return true, nil
+}
+
+// Paused returns the pause of scheduled syncs, or nil when they run
+func (c *Control) Paused(ctx context.Context) (*Pause, error) {
+	data, err := c.backend.Load(ctx, ResourceType, pauseUID)
+	if errors.Is(err, fabricaStorage.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading HSM sync pause: %w", err)
+	}
+	var pause Pause
+	if err := json.Unmarshal(data, &pause); err != nil {
+		return nil, fmt.Errorf("decoding HSM sync pause: %w", err)
+	}
+	return &pause, nil
+}
+
+// Conflicts returns the local node edits the last sync kept over the values
+// HSM reports
+func (c *Control) Conflicts() []hsm.Conflict {
+	return c.syncer.Divergences()
+}
+
+// ConflictEvent describes a sync conflict as boot activity
+func ConflictEvent(conflict hsm.Conflict) activity.Event {
+	reason := fmt.Sprintf("%s: HSM value %q overwrote local %q (policy %s)", conflict.Field, conflict.HSM, conflict.Local, conflict.Policy)
+	if conflict.Kept == hsm.KeptLocal {
+		reason = fmt.Sprintf("%s: kept local %q over HSM value %q (policy %s)", conflict.Field, conflict.Local, conflict.HSM, conflict.Policy)
+	}
+	return activity.Event{Type: activity.SyncConflict, Node: conflict.Node, Reason: reason}
+}
+
+// paused is the pause check of the syncer. A pause that cannot be read
+// does not stop syncs.
+func (c *Control) paused(ctx context.Context) bool {
+	pause, err := c.Paused(ctx)
+	if err != nil {
+		c.logger.Printf("Failed to read HSM sync pause: %v", err)
+		return false
+	}
+	return pause != nil
+}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package hsmsync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/openchami/boot-service/pkg/clients/hsm"
+)
+
+// fakeSyncer counts syncs, failing them while err is set
+type fakeSyncer struct {
+	err    error
+	status hsm.SyncStatus
+	check  hsm.PauseCheck
+}
+
+func (s *fakeSyncer) SyncNodesFromHSM(context.Context) error {
+	s.status.Runs++
+	s.status.Created = 2
+	if s.err != nil {
+		s.status.Error = s.err.Error()
+	}
+	return s.err
+}
+
+func (s *fakeSyncer) LastSync() hsm.SyncStatus            { return s.status }
+func (s *fakeSyncer) SyncEnabled() bool                   { return true }
+func (s *fakeSyncer) SyncInterval() time.Duration         { return 5 * time.Minute }
+func (s *fakeSyncer) SetPauseCheck(paused hsm.PauseCheck) { s.check = paused }
+func (s *fakeSyncer) Divergences() []hsm.Conflict         { return nil }
+
+func newTestControl(t *testing.T) (*Control, *fakeSyncer) {
+	t.Helper()
+
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	syncer := &fakeSyncer{}
+	return NewControl(syncer, backend, log.New(io.Discard, "", 0)), syncer
+}
+
+func TestControl_PauseAndResume(t *testing.T) {
+	ctx := context.Background()
+	control, syncer := newTestControl(t)
+
+	if syncer.check(ctx) {
+		t.Fatal("syncs are paused before a pause")
+	}
+	if _, err := control.Pause(ctx, Pause{Reason: "HSM rebuild", PausedBy: "alice"}); err != nil {
+		t.Fatalf("Pause failed: %v", err)
+	}
+	if !syncer.check(ctx) {
+		t.Error("the pause check does not report the pause")
+	}
+	status, err := control.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if !status.Paused || status.Pause.Reason != "HSM rebuild" || status.Pause.PausedAt.IsZero() || status.Interval != "5m0s" {
+		t.Errorf("Status = %+v, want paused for the HSM rebuild", status)
+	}
+
+	// A paused sync still runs on request
+	if result, err := control.Run(ctx); err != nil || result.Runs != 1 {
+		t.Errorf("Run = %+v, %v, want one sync", result, err)
+	}
+
+	if resumed, err := control.Resume(ctx); err != nil || !resumed {
+		t.Fatalf("Resume = %v, %v, want resumed", resumed, err)
+	}
+	if syncer.check(ctx) {
+		t.Error("syncs are still paused after resuming")
+	}
+	if resumed, err := control.Resume(ctx); err != nil || resumed {
+		t.Errorf("second Resume = %v, %v, want nothing to resume", resumed, err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	control, syncer := newTestControl(t)
+	router := chi.NewRouter()
+	NewHandler(control).RegisterRoutes(router)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := serve("POST", "/admin/sync/pause", `{"reason": "HSM rebuild", "pausedBy": "mallory"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("pause: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var pause Pause
+	if err := json.NewDecoder(w.Body).Decode(&pause); err != nil {
+		t.Fatal(err)
+	}
+	if pause.Reason != "HSM rebuild" || pause.PausedBy != "" {
+		t.Errorf("pause = %+v, want the reason only", pause)
+	}
+
+	w = serve("POST", "/admin/sync/run", "")
+	if w.Code != http.StatusOK || syncer.status.Runs != 1 {
+		t.Errorf("run: expected status 200 and a sync, got %d: %s", w.Code, w.Body.String())
+	}
+	syncer.err = errors.New("HSM unavailable")
+	if w := serve("POST", "/admin/sync/run", ""); w.Code != http.StatusBadGateway {
+		t.Errorf("failed run: expected status 502, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = serve("GET", "/admin/sync/status", "")
+	var status Status
+	if err := json.NewDecoder(w.Body).Decode(&status); err != nil {
+		t.Fatal(err)
+	}
+	if !status.Paused || status.LastSync.Runs != 2 || status.LastSync.Error != "HSM unavailable" {
+		t.Errorf("status = %+v, want paused after two runs, the last failed", status)
+	}
+
+	if w := serve("POST", "/admin/sync/resume", ""); w.Code != http.StatusNoContent {
+		t.Errorf("resume: expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if paused, _ := control.Paused(context.Background()); paused != nil {
+		t.Errorf("syncs are paused after resuming: %+v", paused)
+	}
+}
@@ -0,0 +1,264 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package kubernetes
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// In-cluster defaults, as mounted into every pod with a service account
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	// DefaultTokenFile is the service account token of the pod
+	DefaultTokenFile = serviceAccountDir + "/token"
+	// DefaultCAFile verifies the API server of the cluster
+	DefaultCAFile = serviceAccountDir + "/ca.crt"
+)
+
+// Group and version of the custom resources
+const (
+	Group   = "boot.openchami.io"
+	Version = "v1"
+)
+
+// Plural names of the custom resources, as they appear in API paths
+const (
+	nodesResource              = "nodes"
+	bootConfigurationsResource = "bootconfigurations"
+)
+
+// request timing
+const (
+	defaultTimeout = 30 * time.Second
+	// watchTimeout asks the API server to end each watch, so a watch that
+	// went quiet is noticed
+	watchTimeout = 5 * time.Minute
+)
+
+// maxListSize bounds the size of a list response
+const maxListSize = 256 << 20
+
+// Config selects the Kubernetes API server and the namespace to read
+type Config struct {
+	// APIURL is the API server; in a pod it defaults to the cluster's,
+	// from KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT
+	APIURL string
+	// TokenFile holds the bearer token, read again before each request
+	// since projected tokens are rotated. It defaults to the service account
+	// token when that exists.
+	TokenFile string
+	// CAFile verifies the API server; it defaults to the service account CA
+	// when that exists, and to the system roots otherwise
+	CAFile string
+	// Namespace to read custom resources from; every namespace when empty
+	Namespace string
+	// HTTPClient must not set a timeout, which would end watches
+	HTTPClient *http.Client
+}
+
+// client reads custom resources from the API server
+type client struct {
+	config     Config
+	baseURL    string
+	httpClient *http.Client
+}
+
+// newClient fills in the in-cluster defaults of config and creates a client
+func newClient(config Config) (*client, error) {
+	if config.APIURL == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" {
+			return nil, errors.New("an API URL is required outside a Kubernetes pod")
+		}
+		if port == "" {
+			port = "443"
+		}
+		config.APIURL = "https://" + net.JoinHostPort(host, port)
+	}
+	parsed, err := url.Parse(config.APIURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid Kubernetes API URL %q", config.APIURL)
+	}
+	if config.TokenFile == "" && fileExists(DefaultTokenFile) {
+		config.TokenFile = DefaultTokenFile
+	}
+	if config.CAFile == "" && fileExists(DefaultCAFile) {
+		config.CAFile = DefaultCAFile
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if config.CAFile != "" {
+			pem, err := os.ReadFile(config.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading Kubernetes CA: %w", err)
+			}
+			roots := x509.NewCertPool()
+			if !roots.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates in %s", config.CAFile)
+			}
+			transport.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+		}
+		httpClient = &http.Client{Transport: transport}
+	}
+	return &client{
+		config:     config,
+		baseURL:    strings.TrimRight(config.APIURL, "/"),
+		httpClient: httpClient,
+	}, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// object is a custom resource as the API server returns it
+type object struct {
+	Metadata struct {
+		Name            string            `json:"name"`
+		Namespace       string            `json:"namespace"`
+		Labels          map[string]string `json:"labels"`
+		ResourceVersion string            `json:"resourceVersion"`
+	} `json:"metadata"`
+	Spec json.RawMessage `json:"spec"`
+}
+
+// objectList is a list of custom resources
+type objectList struct {
+	Metadata struct {
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+	Items []object `json:"items"`
+}
+
+// watchEvent is one event of a watch. An ERROR event carries a Status, such
+// as 410 Gone when the version the watch started from is too old.
+type watchEvent struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+// errExpired ends a watch whose resource version the API server no longer
+// has
+var errExpired = errors.New("resource version expired")
+
+// path returns the API path of a resource in the configured namespace
+func (c *client) path(resource string) string {
+	if c.config.Namespace == "" {
+		return fmt.Sprintf("/apis/%s/%s/%s", Group, Version, resource)
+	}
+	return fmt.Sprintf("/apis/%s/%s/namespaces/%s/%s", Group, Version, url.PathEscape(c.config.Namespace), resource)
+}
+
+// list returns the custom resources of a plural name
+func (c *client) list(ctx context.Context, resource string) (*objectList, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+	body, err := c.get(ctx, c.path(resource), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close() //nolint:errcheck
+	var list objectList
+	if err := json.NewDecoder(io.LimitReader(body, maxListSize)).Decode(&list); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", resource, err)
+	}
+	return &list, nil
+}
+
+// watch calls fn for each change to the custom resources of a plural name
+// after resourceVersion, until the API server ends the watch, and returns
+// the last resource version seen
+func (c *client) watch(ctx context.Context, resource, resourceVersion string, fn func(eventType string)) (string, error) {
+	query := url.Values{
+		"watch":               {"1"},
+		"resourceVersion":     {resourceVersion},
+		"allowWatchBookmarks": {"true"},
+		"timeoutSeconds":      {fmt.Sprint(int(watchTimeout.Seconds()))},
+	}
+	body, err := c.get(ctx, c.path(resource), query)
+	if err != nil {
+		return resourceVersion, err
+	}
+	defer body.Close() //nolint:errcheck
+	decoder := json.NewDecoder(body)
+	for {
+		var event watchEvent
+		if err := decoder.Decode(&event); err != nil {
+			if errors.Is(err, io.EOF) {
+				return resourceVersion, nil
+			}
+			return resourceVersion, err
+		}
+		if event.Type == "ERROR" {
+			var status struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+			}
+			_ = json.Unmarshal(event.Object, &status)
+			if status.Code == http.StatusGone {
+				return resourceVersion, errExpired
+			}
+			return resourceVersion, fmt.Errorf("watch failed: %s", status.Message)
+		}
+		var changed object
+		if err := json.Unmarshal(event.Object, &changed); err == nil && changed.Metadata.ResourceVersion != "" {
+			resourceVersion = changed.Metadata.ResourceVersion
+		}
+		if event.Type != "BOOKMARK" {
+			fn(event.Type)
+		}
+	}
+}
+
+// get sends a GET request and returns the response body
+func (c *client) get(ctx context.Context, path string, query url.Values) (io.ReadCloser, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.config.TokenFile != "" {
+		token, err := os.ReadFile(c.config.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading Kubernetes token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to Kubernetes failed: %w", err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp.Body, nil
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	var status struct {
+		Message string `json:"message"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&status)
+	if status.Message == "" {
+		status.Message = resp.Status
+	}
+	return nil, fmt.Errorf("GET %s: %s", path, status.Message)
+}
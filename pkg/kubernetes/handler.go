@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package kubernetes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Path serves the operator status
+const Path = "/admin/kubernetes"
+
+// Handler serves the operator API
+type Handler struct {
+	operator *Operator
+}
+
+// NewHandler creates an operator API handler
+func NewHandler(operator *Operator) *Handler {
+	return &Handler{operator: operator}
+}
+
+// RegisterRoutes registers GET /admin/kubernetes and
+// POST /admin/kubernetes/reconcile
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route(Path, func(r chi.Router) {
+		r.Use(tenancy.Unscoped)
+		r.Get("/", h.GetStatus)
+		r.Post("/reconcile", h.Reconcile)
+	})
+}
+
+// GetStatus handles GET /admin/kubernetes, reporting the last reconcile
+func (h *Handler) GetStatus(w http.ResponseWriter, r *http.Request) { //nolint:revive
+	httputil.WriteJSON(w, http.StatusOK, h.operator.Status())
+}
+
+// Reconcile handles POST /admin/kubernetes/reconcile, which reconciles now
+// instead of waiting for a change or the resync interval
+func (h *Handler) Reconcile(w http.ResponseWriter, r *http.Request) {
+	status, err := h.operator.Reconcile(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Reconcile failed", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, status)
+}
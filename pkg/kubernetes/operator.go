@@ -0,0 +1,283 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package kubernetes runs the service as an operator: nodes and boot
+// configurations declared as Kubernetes custom resources are reconciled into
+// storage, so sites running on Kubernetes can manage boot data with kubectl
+// and Argo CD.
+//
+// The custom resources are the kinds Node and BootConfiguration of
+// boot.openchami.io/v1, with the spec the API accepts. Their Kubernetes name
+// becomes the resource name and their labels are copied. The operator
+// watches them, and reconciles all of them whenever one changes and on a
+// resync interval. Resources it writes are annotated as managed by
+// kubernetes, and are deleted when their custom resource is; resources
+// created through the API are left alone.
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/openchami/boot-service/pkg/declarative"
+)
+
+// managedBy is the manager recorded on resources written by the operator,
+// and the audit subject of its writes
+const managedBy = "kubernetes"
+
+// Watch timing
+const (
+	// settle is how long the operator waits after a change for others
+	// before reconciling, so applying many resources reconciles once
+	settle        = time.Second
+	minWatchRetry = time.Second
+	maxWatchRetry = 30 * time.Second
+)
+
+// kinds pairs each declared kind with its custom resource's plural name
+var kinds = []struct{ kind, resource string }{
+	{declarative.NodeKind, nodesResource},
+	{declarative.BootConfigurationKind, bootConfigurationsResource},
+}
+
+// Status reports the last reconcile
+type Status struct {
+	APIURL    string `json:"apiUrl"`
+	Namespace string `json:"namespace,omitempty"`
+	// Resources counts the custom resources read
+	Resources    int       `json:"resources"`
+	ReconciledAt time.Time `json:"reconciledAt,omitzero"`
+	// Changes lists the writes the reconcile made
+	Changes []declarative.Change `json:"changes"`
+	Error   string               `json:"error,omitempty"`
+}
+
+// Operator reconciles storage with custom resources
+type Operator struct {
+	client  *client
+	backend fabricaStorage.StorageBackend
+	logger  *log.Logger
+	now     func() time.Time
+	settle  time.Duration
+
+	// changed is signaled by watches
+	changed chan struct{}
+
+	// reconcileMu serializes reconciles
+	reconcileMu sync.Mutex
+
+	mu     sync.RWMutex
+	status Status
+}
+
+// NewOperator creates an operator that reads custom resources as config
+// says and writes through backend, which should be the API backend so
+// reconciles are watched and audited like other writes
+func NewOperator(config Config, backend fabricaStorage.StorageBackend, logger *log.Logger) (*Operator, error) {
+	c, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+	o := &Operator{
+		client:  c,
+		backend: backend,
+		logger:  logger,
+		now:     time.Now,
+		settle:  settle,
+		changed: make(chan struct{}, 1),
+	}
+	o.status = o.newStatus()
+	return o, nil
+}
+
+// APIURL returns the API server the operator reads from
+func (o *Operator) APIURL() string {
+	return o.client.config.APIURL
+}
+
+// Status returns the result of the last reconcile
+func (o *Operator) Status() Status {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.status
+}
+
+// Reconcile reads the custom resources and writes storage to match them
+func (o *Operator) Reconcile(ctx context.Context) (Status, error) {
+	o.reconcileMu.Lock()
+	defer o.reconcileMu.Unlock()
+
+	status := o.newStatus()
+	changes, err := o.reconcile(ctx, &status)
+	status.ReconciledAt = o.now().UTC()
+	status.Changes = changes
+	if err != nil {
+		status.Error = err.Error()
+	}
+	o.mu.Lock()
+	o.status = status
+	o.mu.Unlock()
+	return status, err
+}
+
+func (o *Operator) reconcile(ctx context.Context, status *Status) ([]declarative.Change, error) {
+	var declared []*declarative.Resource
+	var problems, versions []string
+	for _, k := range kinds {
+		list, err := o.client.list(ctx, k.resource)
+		if err != nil {
+			return []declarative.Change{}, err
+		}
+		versions = append(versions, k.resource+"@"+list.Metadata.ResourceVersion)
+		for _, item := range list.Items {
+			res, err := declare(k.kind, item)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s %s: %v", k.kind, source(item), err))
+				continue
+			}
+			declared = append(declared, res)
+		}
+	}
+	status.Resources = len(declared) + len(problems)
+
+	// Pruning with part of the resources would delete the rest
+	problems = append(problems, declarative.Check(declared)...)
+	if len(problems) > 0 {
+		return []declarative.Change{}, fmt.Errorf("invalid custom resources:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return declarative.Reconcile(ctx, o.backend, declared, declarative.Options{
+		Manager:  managedBy,
+		Revision: strings.Join(versions, ","),
+		Apply:    true,
+		Prune:    true,
+	}, o.logger)
+}
+
+// declare converts a custom resource into the resource it declares
+func declare(kind string, item object) (*declarative.Resource, error) {
+	doc := map[string]any{
+		"kind": kind,
+		"metadata": map[string]any{
+			"name":   item.Metadata.Name,
+			"labels": item.Metadata.Labels,
+		},
+	}
+	if len(item.Spec) > 0 {
+		doc["spec"] = item.Spec
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return declarative.Decode(kind, data, source(item))
+}
+
+// source names a custom resource as namespace/name
+func source(item object) string {
+	if item.Metadata.Namespace == "" {
+		return item.Metadata.Name
+	}
+	return item.Metadata.Namespace + "/" + item.Metadata.Name
+}
+
+// Run reconciles when custom resources change, and every resync interval in
+// case a change was missed, until ctx is done
+func (o *Operator) Run(ctx context.Context, resync time.Duration) {
+	for _, k := range kinds {
+		go o.follow(ctx, k.resource)
+	}
+	ticker := time.NewTicker(resync)
+	defer ticker.Stop()
+	for {
+		status, err := o.Reconcile(ctx)
+		switch {
+		case err != nil:
+			o.logger.Printf("Reconcile failed: %v", err)
+		case len(status.Changes) > 0:
+			o.logger.Printf("Applied %d changes from %d custom resources", len(status.Changes), status.Resources)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-o.changed:
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(o.settle):
+			}
+			// Changes made while settling are picked up by this reconcile
+			select {
+			case <-o.changed:
+			default:
+			}
+		}
+	}
+}
+
+// follow watches the custom resources of a plural name, signaling changed
+// for every change, until ctx is done
+func (o *Operator) follow(ctx context.Context, resource string) {
+	retry := minWatchRetry
+	version := ""
+	for {
+		var err error
+		if version == "" {
+			// Watch from the current version rather than replaying every
+			// resource as added
+			var list *objectList
+			if list, err = o.client.list(ctx, resource); err == nil {
+				version = list.Metadata.ResourceVersion
+			}
+		}
+		if err == nil {
+			version, err = o.client.watch(ctx, resource, version, func(string) { o.signal() })
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		switch {
+		case err == nil:
+			retry = minWatchRetry
+			continue
+		case errors.Is(err, errExpired):
+			// Changes since the version may have been missed
+			version = ""
+			o.signal()
+			continue
+		}
+		o.logger.Printf("Watch of %s failed, retrying in %s: %v", resource, retry, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retry):
+		}
+		retry = min(retry*2, maxWatchRetry)
+	}
+}
+
+// signal asks Run to reconcile
+func (o *Operator) signal() {
+	select {
+	case o.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (o *Operator) newStatus() Status {
+	return Status{
+		APIURL:    o.client.config.APIURL,
+		Namespace: o.client.config.Namespace,
+		Changes:   []declarative.Change{},
+	}
+}
@@ -0,0 +1,289 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/declarative"
+)
+
+func init() {
+	resource.RegisterResourcePrefix("Node", "node")
+	resource.RegisterResourcePrefix("BootConfiguration", "bc")
+}
+
+// fakeAPIServer serves custom resources the way the Kubernetes API server
+// does, to the extent the operator uses it
+type fakeAPIServer struct {
+	t *testing.T
+
+	mu        sync.Mutex
+	version   int
+	resources map[string][]object // by plural name
+	watchers  map[string][]chan watchEvent
+	tokens    []string
+}
+
+func newFakeAPIServer(t *testing.T) (*fakeAPIServer, *httptest.Server) {
+	f := &fakeAPIServer{t: t, resources: map[string][]object{}, watchers: map[string][]chan watchEvent{}}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+// put adds or replaces a custom resource and tells watchers
+func (f *fakeAPIServer) put(resource, namespace, name, labels, spec string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.version++
+	var item object
+	raw := fmt.Sprintf(`{"metadata":{"name":%q,"namespace":%q,"labels":%s,"resourceVersion":"%d"},"spec":%s}`,
+		name, namespace, labels, f.version, spec)
+	if err := json.Unmarshal([]byte(raw), &item); err != nil {
+		f.t.Fatal(err)
+	}
+	items := f.resources[resource][:0:0]
+	for _, existing := range f.resources[resource] {
+		if existing.Metadata.Name != name || existing.Metadata.Namespace != namespace {
+			items = append(items, existing)
+		}
+	}
+	f.resources[resource] = append(items, item)
+	for _, watcher := range f.watchers[resource] {
+		watcher <- watchEvent{Type: "ADDED", Object: json.RawMessage(raw)}
+	}
+}
+
+// remove deletes a custom resource
+func (f *fakeAPIServer) remove(resource, namespace, name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.version++
+	var items []object
+	for _, existing := range f.resources[resource] {
+		if existing.Metadata.Name != name || existing.Metadata.Namespace != namespace {
+			items = append(items, existing)
+		}
+	}
+	f.resources[resource] = items
+}
+
+func (f *fakeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.tokens = append(f.tokens, r.Header.Get("Authorization"))
+	f.mu.Unlock()
+	resource := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	namespace := ""
+	if parts := strings.Split(r.URL.Path, "/"); len(parts) == 7 && parts[4] == "namespaces" {
+		namespace = parts[5]
+	}
+	if r.URL.Query().Get("watch") != "" {
+		f.serveWatch(w, r, resource)
+		return
+	}
+
+	f.mu.Lock()
+	list := objectList{Items: []object{}}
+	list.Metadata.ResourceVersion = fmt.Sprint(f.version)
+	for _, item := range f.resources[resource] {
+		if namespace == "" || item.Metadata.Namespace == namespace {
+			list.Items = append(list.Items, item)
+		}
+	}
+	f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(list)
+}
+
+func (f *fakeAPIServer) serveWatch(w http.ResponseWriter, r *http.Request, resource string) {
+	events := make(chan watchEvent, 16)
+	f.mu.Lock()
+	f.watchers[resource] = append(f.watchers[resource], events)
+	f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.(http.Flusher).Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-events:
+			_ = json.NewEncoder(w).Encode(event)
+			w.(http.Flusher).Flush()
+		}
+	}
+}
+
+func newTestOperator(t *testing.T, config Config) (*Operator, fabricaStorage.StorageBackend) {
+	t.Helper()
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	operator, err := NewOperator(config, backend, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewOperator returned error: %v", err)
+	}
+	return operator, backend
+}
+
+func actions(changes []declarative.Change) string {
+	out := make([]string, 0, len(changes))
+	for _, change := range changes {
+		out = append(out, change.Action+" "+change.Kind+" "+change.Name)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestOperator_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	api, server := newFakeAPIServer(t)
+	api.put(nodesResource, "boot", "x1000c0s0b0n0", `{}`, `{"xname":"x1000c0s0b0n0","nid":1,"bootMac":"AA:BB:CC:DD:EE:01"}`)
+	api.put(bootConfigurationsResource, "boot", "compute", `{"team":"hpc"}`, `{"groups":["compute"],"kernel":"http://images/vmlinuz"}`)
+	api.put(bootConfigurationsResource, "other", "login", `{}`, `{"groups":["login"],"kernel":"http://images/vmlinuz"}`)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	operator, backend := newTestOperator(t, Config{APIURL: server.URL, TokenFile: tokenFile, Namespace: "boot"})
+
+	// A node created through the API is left alone
+	unmanaged := `{"kind":"Node","metadata":{"uid":"node-api","name":"x1000c0s1b0n0"},"spec":{"xname":"x1000c0s1b0n0"}}`
+	if err := backend.Save(ctx, declarative.NodeKind, "node-api", []byte(unmanaged)); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := operator.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if got, want := actions(status.Changes), "create Node x1000c0s0b0n0,create BootConfiguration compute"; got != want {
+		t.Errorf("first reconcile = %s, want %s", got, want)
+	}
+	if api.tokens[0] != "Bearer secret" {
+		t.Errorf("Authorization = %q, want the token file's bearer token", api.tokens[0])
+	}
+	data, err := backend.Load(ctx, declarative.BootConfigurationKind, status.Changes[1].UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config v1.BootConfiguration
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Metadata.Labels["team"] != "hpc" || config.Metadata.Annotations[declarative.SourceAnnotation] != "boot/compute" {
+		t.Errorf("created boot configuration metadata = %+v", config.Metadata)
+	}
+
+	if status, err := operator.Reconcile(ctx); err != nil || len(status.Changes) != 0 {
+		t.Fatalf("second reconcile = %s, %v; want no changes", actions(status.Changes), err)
+	}
+
+	// Deleting a custom resource deletes what it declared
+	api.remove(bootConfigurationsResource, "boot", "compute")
+	status, err = operator.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if got := actions(status.Changes); got != "delete BootConfiguration compute" {
+		t.Errorf("reconcile after a delete = %s, want the configuration deleted", got)
+	}
+	if exists, _ := backend.Exists(ctx, declarative.NodeKind, "node-api"); !exists {
+		t.Error("reconcile deleted a node no custom resource declared")
+	}
+}
+
+func TestOperator_RejectsInvalidResources(t *testing.T) {
+	ctx := context.Background()
+	api, server := newFakeAPIServer(t)
+	api.put(nodesResource, "a", "x1000c0s0b0n0", `{}`, `{"xname":"x1000c0s0b0n0"}`)
+	api.put(bootConfigurationsResource, "a", "compute", `{}`, `{"kernal":"http://images/vmlinuz"}`)
+	api.put(bootConfigurationsResource, "a", "login", `{}`, `{"kernel":"http://images/vmlinuz"}`)
+	api.put(bootConfigurationsResource, "b", "login", `{}`, `{"kernel":"http://images/vmlinuz"}`)
+	operator, backend := newTestOperator(t, Config{APIURL: server.URL})
+
+	_, err := operator.Reconcile(ctx)
+	if err == nil {
+		t.Fatal("Reconcile accepted invalid custom resources")
+	}
+	for _, problem := range []string{`a/compute: json: unknown field "kernal"`, "login is also declared in a/login"} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("error %q does not report %s", err, problem)
+		}
+	}
+	if nodes, _ := backend.List(ctx, declarative.NodeKind); len(nodes) != 0 {
+		t.Errorf("invalid custom resources were partly applied: %v", nodes)
+	}
+	if operator.Status().Error == "" {
+		t.Error("Status does not report the failed reconcile")
+	}
+}
+
+func TestOperator_RunFollowsWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	api, server := newFakeAPIServer(t)
+	operator, backend := newTestOperator(t, Config{APIURL: server.URL})
+	operator.settle = 10 * time.Millisecond
+	go operator.Run(ctx, time.Hour)
+
+	// Wait for both watches before changing anything
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		api.mu.Lock()
+		watching := len(api.watchers[nodesResource]) > 0 && len(api.watchers[bootConfigurationsResource]) > 0
+		api.mu.Unlock()
+		if watching {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the operator did not watch the custom resources")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	api.put(nodesResource, "boot", "x1000c0s0b0n0", `{}`, `{"xname":"x1000c0s0b0n0"}`)
+	for {
+		if nodes, _ := backend.List(ctx, declarative.NodeKind); len(nodes) == 1 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("a new custom resource was not reconciled")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestNewOperator_InCluster(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.96.0.1")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "443")
+	operator, _ := newTestOperator(t, Config{})
+	if got := operator.APIURL(); got != "https://10.96.0.1:443" {
+		t.Errorf("APIURL = %q, want the in-cluster API server", got)
+	}
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	if _, err := NewOperator(Config{}, nil, log.New(io.Discard, "", 0)); err == nil {
+		t.Error("NewOperator accepted no API URL outside a pod")
+	}
+}
@@ -0,0 +1,255 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package leader elects one replica to run background workers.
+//
+// Every replica serves reads, but writers such as the HSM sync worker must
+// run on only one of them or they overwrite each other. An Elector holds a
+// renewable lease in a shared Lock and runs those workers only while it owns
+// the lease, stopping them as soon as it loses it.
+package leader
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Lock is a lease held by at most one replica at a time
+type Lock interface {
+	// TryAcquire acquires the lease for id, or renews it if id already holds
+	// it, and reports whether id holds the lease afterwards.
+	TryAcquire(ctx context.Context, id string, ttl time.Duration) (bool, error)
+	// Release gives up the lease if id holds it
+	Release(ctx context.Context, id string) error
+	// Holder returns the ID holding the lease, or "" if it is free
+	Holder(ctx context.Context) (string, error)
+}
+
+// Status describes the election as seen by one replica
+type Status struct {
+	ID              string     `json:"id"`
+	Leader          string     `json:"leader"` // empty when no replica holds the lease
+	IsLeader        bool       `json:"isLeader"`
+	LeaderSince     *time.Time `json:"leaderSince,omitempty"`
+	LeaseTTLSeconds int        `json:"leaseTTLSeconds"`
+}
+
+// Elector campaigns for leadership and tracks whether this replica leads
+type Elector struct {
+	lock   Lock
+	id     string
+	ttl    time.Duration
+	logger *log.Logger
+
+	mu       sync.Mutex
+	isLeader bool
+	since    time.Time
+	changed  chan struct{} // closed and replaced on every leadership change
+}
+
+// NewElector creates an elector that campaigns as id for a lease of ttl.
+// The lease is renewed every ttl/3.
+func NewElector(lock Lock, id string, ttl time.Duration, logger *log.Logger) *Elector {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &Elector{
+		lock:    lock,
+		id:      id,
+		ttl:     ttl,
+		logger:  logger,
+		changed: make(chan struct{}),
+	}
+}
+
+// DefaultID returns an instance ID built from the hostname and process ID
+func DefaultID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "boot-service"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// ID returns the ID this elector campaigns as
+func (e *Elector) ID() string {
+	return e.id
+}
+
+// Run campaigns until ctx is done, then releases the lease
+func (e *Elector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+
+	e.campaign(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			e.setLeader(false)
+			releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := e.lock.Release(releaseCtx, e.id); err != nil {
+				e.logger.Printf("Failed to release leader lease: %v", err)
+			}
+			cancel()
+			return
+		case <-ticker.C:
+			e.campaign(ctx)
+		}
+	}
+}
+
+// IsLeader reports whether this replica currently holds the lease
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.isLeader
+}
+
+// Status returns the election status, including the current lease holder
+func (e *Elector) Status(ctx context.Context) (Status, error) {
+	holder, err := e.lock.Holder(ctx)
+	if err != nil {
+		return Status{}, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	status := Status{
+		ID:              e.id,
+		Leader:          holder,
+		IsLeader:        e.isLeader,
+		LeaseTTLSeconds: int(e.ttl / time.Second),
+	}
+	if e.isLeader {
+		since := e.since
+		status.LeaderSince = &since
+	}
+	return status, nil
+}
+
+// RunWhileLeader calls fn whenever this replica becomes leader and cancels the
+// context passed to fn when leadership is lost. It returns once ctx is done
+// and fn has returned.
+func (e *Elector) RunWhileLeader(ctx context.Context, fn func(ctx context.Context)) {
+	for {
+		if !e.waitFor(ctx, true) {
+			return
+		}
+
+		leaderCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			fn(leaderCtx)
+		}()
+
+		e.waitFor(ctx, false)
+		cancel()
+		<-done
+	}
+}
+
+// campaign tries to acquire or renew the lease. An error counts as losing
+// it, because another replica may take over once the lease expires.
+func (e *Elector) campaign(ctx context.Context) {
+	held, err := e.lock.TryAcquire(ctx, e.id, e.ttl)
+	if err != nil {
+		e.logger.Printf("Leader lease renewal failed: %v", err)
+		held = false
+	}
+	e.setLeader(held)
+}
+
+func (e *Elector) setLeader(isLeader bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.isLeader == isLeader {
+		return
+	}
+
+	e.isLeader = isLeader
+	if isLeader {
+		e.since = time.Now()
+		e.logger.Printf("Instance %s became leader", e.id)
+	} else {
+		e.logger.Printf("Instance %s is no longer leader", e.id)
+	}
+	close(e.changed)
+	e.changed = make(chan struct{})
+}
+
+// waitFor blocks until leadership matches isLeader, reporting false if ctx
+// is done first
+func (e *Elector) waitFor(ctx context.Context, isLeader bool) bool {
+	for {
+		e.mu.Lock()
+		current, changed := e.isLeader, e.changed
+		e.mu.Unlock()
+
+		if current == isLeader {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-changed:
+		}
+	}
+}
+
+// LocalLock is an in-process Lock. It suits a single replica, which then
+// always leads.
+type LocalLock struct {
+	mu      sync.Mutex
+	holder  string
+	expires time.Time
+}
+
+// NewLocalLock creates an in-process lock
+func NewLocalLock() *LocalLock {
+	return &LocalLock{}
+}
+
+// TryAcquire acquires or renews the lease for id
+func (l *LocalLock) TryAcquire(_ context.Context, id string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.holder != "" && l.holder != id && now.Before(l.expires) {
+		return false, nil
+	}
+	l.holder = id
+	l.expires = now.Add(ttl)
+	return true, nil
+}
+
+// Release gives up the lease if id holds it
+func (l *LocalLock) Release(_ context.Context, id string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.holder == id {
+		l.holder = ""
+	}
+	return nil
+}
+
+// Holder returns the ID holding an unexpired lease
+func (l *LocalLock) Holder(_ context.Context) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.holder == "" || time.Now().After(l.expires) {
+		return "", nil
+	}
+	return l.holder, nil
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package leader
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func waitUntil(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestElector_FailoverStopsAndStartsWorkers(t *testing.T) {
+	lock := NewLocalLock()
+	logger := log.New(io.Discard, "", 0)
+	ttl := 60 * time.Millisecond
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+
+	electorA := NewElector(lock, "a", ttl, logger)
+	electorB := NewElector(lock, "b", ttl, logger)
+
+	var running [2]atomic.Int32
+	worker := func(i int) func(ctx context.Context) {
+		return func(ctx context.Context) {
+			running[i].Add(1)
+			<-ctx.Done()
+			running[i].Add(-1)
+		}
+	}
+
+	go electorA.Run(ctxA)
+	waitUntil(t, "a to lead", electorA.IsLeader)
+	go electorB.Run(ctxB)
+	doneA := make(chan struct{})
+	go func() {
+		electorA.RunWhileLeader(ctxA, worker(0))
+		close(doneA)
+	}()
+	go electorB.RunWhileLeader(ctxB, worker(1))
+
+	waitUntil(t, "a's worker to start", func() bool { return running[0].Load() == 1 })
+	if electorB.IsLeader() || running[1].Load() != 0 {
+		t.Fatal("expected only one leader running workers")
+	}
+
+	cancelA()
+	<-doneA
+	if running[0].Load() != 0 {
+		t.Error("expected a's worker to stop with its context")
+	}
+	waitUntil(t, "b to take over", func() bool { return running[1].Load() == 1 })
+
+	status, err := electorB.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status returned error: %v", err)
+	}
+	if status.Leader != "b" || !status.IsLeader || status.LeaderSince == nil {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func TestHandler_GetStatus(t *testing.T) {
+	elector := NewElector(NewLocalLock(), "replica-1", 15*time.Second, log.New(io.Discard, "", 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go elector.Run(ctx)
+	waitUntil(t, "replica-1 to lead", elector.IsLeader)
+
+	r := chi.NewRouter()
+	NewHandler(elector).RegisterRoutes(r)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/leader", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /admin/leader returned status %d", rec.Code)
+	}
+	var status Status
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatalf("failed to decode status: %v", err)
+	}
+	if status.ID != "replica-1" || status.Leader != "replica-1" || !status.IsLeader || status.LeaseTTLSeconds != 15 {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package leader
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+)
+
+// Handler serves the leader election status
+type Handler struct {
+	elector *Elector
+}
+
+// NewHandler creates a leader status handler
+func NewHandler(elector *Elector) *Handler {
+	return &Handler{elector: elector}
+}
+
+// RegisterRoutes registers GET /admin/leader
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/admin/leader", h.GetStatus)
+}
+
+// GetStatus handles GET /admin/leader
+func (h *Handler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.elector.Status(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusServiceUnavailable, "Leader status unavailable", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, status)
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package maintenance
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/audit"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Path serves the maintenance mode switch
+const Path = "/admin/maintenance"
+
+// maxRequestSize bounds the body of an enable request
+const maxRequestSize = 64 << 10
+
+// Handler serves the maintenance mode API
+type Handler struct {
+	maintenance *Switch
+}
+
+// NewHandler creates a maintenance mode API handler
+func NewHandler(maintenance *Switch) *Handler {
+	return &Handler{maintenance: maintenance}
+}
+
+// RegisterRoutes registers GET, PUT, and DELETE /admin/maintenance
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route(Path, func(r chi.Router) {
+		r.Use(tenancy.Unscoped)
+		r.Get("/", h.GetState)
+		r.Put("/", h.Enable)
+		r.Delete("/", h.Disable)
+	})
+}
+
+// GetState handles GET /admin/maintenance
+func (h *Handler) GetState(w http.ResponseWriter, r *http.Request) { //nolint:revive
+	httputil.WriteJSON(w, http.StatusOK, h.maintenance.State())
+}
+
+// Enable handles PUT /admin/maintenance, which turns maintenance mode on
+// with the action, groups, and reason in the body
+func (h *Handler) Enable(w http.ResponseWriter, r *http.Request) {
+	var state State
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&state); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid maintenance state", err.Error())
+		return
+	}
+	state.Enabled = true
+	state.EnabledAt = time.Time{}
+	if actor, ok := audit.ActorFromContext(r.Context()); ok && actor.Subject != "" && actor.Subject != audit.AnonymousSubject {
+		state.EnabledBy = actor.Subject
+	}
+	h.set(w, r, state)
+}
+
+// Disable handles DELETE /admin/maintenance
+func (h *Handler) Disable(w http.ResponseWriter, r *http.Request) {
+	h.set(w, r, State{})
+}
+
+func (h *Handler) set(w http.ResponseWriter, r *http.Request, state State) {
+	state, err := h.maintenance.Set(r.Context(), state)
+	if errors.Is(err, ErrInvalidState) {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid maintenance state", err.Error())
+		return
+	}
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to switch maintenance mode", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, state)
+}
@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package maintenance provides the service-wide maintenance mode switch.
+// While it is on, held nodes are served a hold or local-disk boot script
+// instead of their configuration, so nothing is reprovisioned by accident
+// during an incident.
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// ResourceType is the storage resource type of the maintenance state
+const ResourceType = "MaintenanceMode"
+
+// stateKey is the storage key of the single maintenance state record
+const stateKey = "state"
+
+// Actions taken for held nodes
+const (
+	// ActionHold serves a script that waits and reboots
+	ActionHold = "hold"
+	// ActionLocal serves a script that boots from local disk
+	ActionLocal = "local"
+)
+
+// ErrInvalidState is returned for a state that cannot be applied
+var ErrInvalidState = errors.New("invalid maintenance state")
+
+// State is the maintenance mode setting
+type State struct {
+	Enabled bool `json:"enabled"`
+	// Action is what held nodes boot: hold (default) or local
+	Action string `json:"action,omitempty"`
+	// Groups limits maintenance to nodes in one of these groups. Empty
+	// holds every node.
+	Groups []string `json:"groups,omitempty"`
+	Reason string   `json:"reason,omitempty"`
+	// EnabledBy is the token subject that enabled maintenance, if known
+	EnabledBy string    `json:"enabledBy,omitempty"`
+	EnabledAt time.Time `json:"enabledAt,omitzero"`
+	// Revision increases with every change
+	Revision uint64 `json:"revision"`
+}
+
+// Validate checks an enabled state and fills in the default action
+func (s *State) Validate() error {
+	if !s.Enabled {
+		return nil
+	}
+	switch s.Action {
+	case "":
+		s.Action = ActionHold
+	case ActionHold, ActionLocal:
+	default:
+		return fmt.Errorf("%w: action must be %q or %q", ErrInvalidState, ActionHold, ActionLocal)
+	}
+	if slices.Contains(s.Groups, "") {
+		return fmt.Errorf("%w: empty group name", ErrInvalidState)
+	}
+	return nil
+}
+
+// Holds reports whether the state holds node
+func (s State) Holds(node *apiv1.Node) bool {
+	if !s.Enabled {
+		return false
+	}
+	if len(s.Groups) == 0 {
+		return true
+	}
+	for _, group := range node.Spec.Groups {
+		if slices.Contains(s.Groups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// Switch holds the maintenance state in memory and persists it in the
+// resource storage backend, so it survives restarts. The state other
+// replicas set reaches the switch through HandleResourceChange.
+type Switch struct {
+	backend fabricaStorage.StorageBackend
+	logger  *log.Logger
+
+	mu    sync.RWMutex
+	state State
+}
+
+// NewSwitch creates a maintenance switch persisted in backend. Call Load to
+// restore the stored state.
+func NewSwitch(backend fabricaStorage.StorageBackend, logger *log.Logger) *Switch {
+	return &Switch{backend: backend, logger: logger}
+}
+
+// Load restores the stored state. A missing state leaves maintenance off.
+func (s *Switch) Load(ctx context.Context) error {
+	data, err := s.backend.Load(ctx, ResourceType, stateKey)
+	if errors.Is(err, fabricaStorage.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("loading maintenance state: %w", err)
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("decoding maintenance state: %w", err)
+	}
+
+	s.mu.Lock()
+	s.state = state
+	s.mu.Unlock()
+	if state.Enabled {
+		s.logger.Printf("Maintenance mode is on (%s%s): %s", state.Action, groupsSuffix(state.Groups), state.Reason)
+	}
+	return nil
+}
+
+// State returns the current state
+func (s *Switch) State() State {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	state := s.state
+	state.Groups = slices.Clone(state.Groups)
+	return state
+}
+
+// Set applies and stores a new state. Disabling keeps nothing but the
+// revision.
+func (s *Switch) Set(ctx context.Context, state State) (State, error) {
+	if err := state.Validate(); err != nil {
+		return State{}, err
+	}
+	if !state.Enabled {
+		state = State{}
+	} else if state.EnabledAt.IsZero() {
+		state.EnabledAt = time.Now().UTC()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state.Revision = s.state.Revision + 1
+	data, err := json.Marshal(state)
+	if err != nil {
+		return State{}, fmt.Errorf("encoding maintenance state: %w", err)
+	}
+	if err := s.backend.Save(ctx, ResourceType, stateKey, data); err != nil {
+		return State{}, fmt.Errorf("saving maintenance state: %w", err)
+	}
+	s.state = state
+
+	if state.Enabled {
+		s.logger.Printf("Maintenance mode enabled (%s%s) by %q: %s", state.Action, groupsSuffix(state.Groups), state.EnabledBy, state.Reason)
+	} else {
+		s.logger.Printf("Maintenance mode disabled")
+	}
+	return state, nil
+}
+
+// HandleResourceChange follows the state other replicas set
+func (s *Switch) HandleResourceChange(ctx context.Context, event resourcewatch.Event) {
+	// The switch's own writes are already applied
+	if event.ResourceType != ResourceType || event.UID != stateKey || !resourcewatch.Remote(ctx) {
+		return
+	}
+	var state State
+	if event.New != nil {
+		if err := json.Unmarshal(event.New, &state); err != nil {
+			s.logger.Printf("Ignoring undecodable maintenance state: %v", err)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	if event.New == nil {
+		state.Revision = s.state.Revision + 1
+	}
+	s.state = state
+	s.mu.Unlock()
+	if state.Enabled {
+		s.logger.Printf("Maintenance mode enabled (%s%s) on another replica by %q: %s", state.Action, groupsSuffix(state.Groups), state.EnabledBy, state.Reason)
+	} else {
+		s.logger.Printf("Maintenance mode disabled on another replica")
+	}
+}
+
+// Hold returns the action and reason for a node held by maintenance mode,
+// or held false when the node boots normally. It implements
+// bootscript.Maintenance.
+func (s *Switch) Hold(node *apiv1.Node) (action, reason string, held bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.state.Holds(node) {
+		return "", "", false
+	}
+	return s.state.Action, s.state.Reason, true
+}
+
+// Revision returns the state's revision, which changes whenever maintenance
+// is switched. It implements bootscript.Maintenance.
+func (s *Switch) Revision() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.state.Revision
+}
+
+func groupsSuffix(groups []string) string {
+	if len(groups) == 0 {
+		return ", all nodes"
+	}
+	return fmt.Sprintf(", groups %v", groups)
+}
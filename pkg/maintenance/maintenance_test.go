@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+func newTestSwitch(t *testing.T) (*Switch, fabricaStorage.StorageBackend) {
+	t.Helper()
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	return NewSwitch(backend, log.New(io.Discard, "", 0)), backend
+}
+
+func testNode(groups ...string) *apiv1.Node {
+	node := &apiv1.Node{}
+	node.Spec.XName = "x1000c0s0b0n0"
+	node.Spec.Groups = groups
+	return node
+}
+
+func TestSwitch_SetPersists(t *testing.T) {
+	sw, backend := newTestSwitch(t)
+	ctx := context.Background()
+
+	state, err := sw.Set(ctx, State{Enabled: true, Groups: []string{"compute"}, Reason: "storage outage"})
+	if err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if state.Action != ActionHold {
+		t.Errorf("Action = %q, want default %q", state.Action, ActionHold)
+	}
+	if state.EnabledAt.IsZero() || state.Revision != 1 {
+		t.Errorf("EnabledAt = %v, Revision = %d; want a time and revision 1", state.EnabledAt, state.Revision)
+	}
+
+	restored := NewSwitch(backend, log.New(io.Discard, "", 0))
+	if err := restored.Load(ctx); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	got := restored.State()
+	if !got.Enabled || got.Reason != "storage outage" || got.Revision != 1 {
+		t.Errorf("restored state = %+v, want the enabled state", got)
+	}
+
+	state, err = restored.Set(ctx, State{})
+	if err != nil {
+		t.Fatalf("disabling returned error: %v", err)
+	}
+	if state.Enabled || state.Reason != "" || state.Revision != 2 {
+		t.Errorf("disabled state = %+v, want off with revision 2", state)
+	}
+}
+
+func TestSwitch_LoadWithoutState(t *testing.T) {
+	sw, _ := newTestSwitch(t)
+	if err := sw.Load(context.Background()); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if sw.State().Enabled || sw.Revision() != 0 {
+		t.Errorf("state = %+v, want maintenance off", sw.State())
+	}
+}
+
+func TestSwitch_SetRejectsInvalidState(t *testing.T) {
+	sw, _ := newTestSwitch(t)
+	ctx := context.Background()
+
+	for _, state := range []State{
+		{Enabled: true, Action: "wipe"},
+		{Enabled: true, Groups: []string{"compute", ""}},
+	} {
+		if _, err := sw.Set(ctx, state); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("Set(%+v) error = %v, want ErrInvalidState", state, err)
+		}
+	}
+	if sw.Revision() != 0 {
+		t.Errorf("Revision = %d, want 0 after rejected changes", sw.Revision())
+	}
+}
+
+// fakeSource reports the writes of shared storage when told to
+type fakeSource struct {
+	fn resourcewatch.Subscriber
+}
+
+func (s *fakeSource) Watch(_ context.Context, fn resourcewatch.Subscriber) error {
+	s.fn = fn
+	return nil
+}
+
+func TestSwitch_FollowsOtherReplicas(t *testing.T) {
+	ctx := context.Background()
+	files, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	// Two replicas share storage, which reports each one's writes to the
+	// other
+	source := &fakeSource{}
+	local, remote := resourcewatch.NewBackend(files), resourcewatch.NewBackend(files)
+	if err := remote.Follow(ctx, source); err != nil {
+		t.Fatalf("Follow returned error: %v", err)
+	}
+	local.Subscribe(func(ctx context.Context, event resourcewatch.Event) { source.fn(ctx, event) })
+	sw := NewSwitch(local, log.New(io.Discard, "", 0))
+	replica := NewSwitch(remote, log.New(io.Discard, "", 0))
+	remote.Subscribe(replica.HandleResourceChange)
+
+	if _, err := sw.Set(ctx, State{Enabled: true, Reason: "storage outage"}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if action, _, held := replica.Hold(testNode()); !held || action != ActionHold {
+		t.Errorf("Hold on another replica = %q, %v; want held", action, held)
+	}
+	if replica.Revision() != sw.Revision() {
+		t.Errorf("Revision on another replica = %d, want %d", replica.Revision(), sw.Revision())
+	}
+
+	if _, err := sw.Set(ctx, State{}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if _, _, held := replica.Hold(testNode()); held {
+		t.Error("node still held on another replica after maintenance was disabled")
+	}
+}
+
+func TestSwitch_Hold(t *testing.T) {
+	sw, _ := newTestSwitch(t)
+	ctx := context.Background()
+
+	if _, _, held := sw.Hold(testNode("compute")); held {
+		t.Error("expected no node held while maintenance is off")
+	}
+
+	if _, err := sw.Set(ctx, State{Enabled: true, Action: ActionLocal, Groups: []string{"compute"}, Reason: "network change"}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	action, reason, held := sw.Hold(testNode("io", "compute"))
+	if !held || action != ActionLocal || reason != "network change" {
+		t.Errorf("Hold = %q, %q, %v; want local, the reason, held", action, reason, held)
+	}
+	if _, _, held := sw.Hold(testNode("io")); held {
+		t.Error("expected node outside the held groups to boot normally")
+	}
+
+	if _, err := sw.Set(ctx, State{Enabled: true}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if _, _, held := sw.Hold(testNode()); !held {
+		t.Error("expected every node held when no groups are given")
+	}
+}
+
+func serveMaintenance(t *testing.T, sw *Switch, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	router := chi.NewRouter()
+	NewHandler(sw).RegisterRoutes(router)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_EnableAndDisable(t *testing.T) {
+	sw, _ := newTestSwitch(t)
+
+	rec := serveMaintenance(t, sw, httptest.NewRequest(http.MethodPut, Path, strings.NewReader(`{"groups":["compute"],"reason":"outage","enabledBy":"ops"}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d, body %s", rec.Code, rec.Body.String())
+	}
+	var state State
+	if err := json.Unmarshal(rec.Body.Bytes(), &state); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !state.Enabled || state.Action != ActionHold || state.EnabledBy != "ops" {
+		t.Errorf("state = %+v, want enabled hold by ops", state)
+	}
+
+	rec = serveMaintenance(t, sw, httptest.NewRequest(http.MethodGet, Path, nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"enabled":true`) {
+		t.Errorf("GET = %d %s, want the enabled state", rec.Code, rec.Body.String())
+	}
+
+	rec = serveMaintenance(t, sw, httptest.NewRequest(http.MethodDelete, Path, nil))
+	if rec.Code != http.StatusOK || sw.State().Enabled {
+		t.Errorf("DELETE = %d, enabled %v; want maintenance off", rec.Code, sw.State().Enabled)
+	}
+}
+
+func TestHandler_RejectsInvalidAction(t *testing.T) {
+	sw, _ := newTestSwitch(t)
+
+	rec := serveMaintenance(t, sw, httptest.NewRequest(http.MethodPut, Path, strings.NewReader(`{"action":"wipe"}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+	if sw.State().Enabled {
+		t.Error("expected maintenance to stay off")
+	}
+}
+
+func TestHandler_RefusesTenants(t *testing.T) {
+	sw, _ := newTestSwitch(t)
+
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		req := httptest.NewRequest(method, Path, strings.NewReader(`{}`))
+		req = req.WithContext(tenancy.WithTenant(req.Context(), "blue"))
+		if rec := serveMaintenance(t, sw, req); rec.Code != http.StatusForbidden {
+			t.Errorf("%s status = %d, want 403", method, rec.Code)
+		}
+	}
+	if sw.State().Enabled {
+		t.Error("expected maintenance to stay off")
+	}
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package migrate converts the state of an existing Boot Script Service (BSS)
+// deployment into boot-service resources.
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/openchami/boot-service/pkg/handlers/boot"
+)
+
+// Host is a node as reported by the BSS /boot/v1/hosts endpoint, which
+// mirrors the HSM component fields BSS caches
+type Host struct {
+	ID      string   `json:"ID"`
+	Type    string   `json:"Type,omitempty"`
+	NID     int32    `json:"NID,omitempty"`
+	Role    string   `json:"Role,omitempty"`
+	SubRole string   `json:"SubRole,omitempty"`
+	FQDN    string   `json:"FQDN,omitempty"`
+	MAC     []string `json:"MAC,omitempty"`
+}
+
+// Source reads boot parameters and hosts from a BSS deployment
+type Source interface {
+	BootParameters(ctx context.Context) ([]boot.BootParameters, error)
+	Hosts(ctx context.Context) ([]Host, error)
+}
+
+// HTTPSource reads from the BSS REST API
+type HTTPSource struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewHTTPSource creates a source for the BSS instance at baseURL. The URL may
+// include the /boot/v1 prefix or not. token is sent as a bearer token when set.
+func NewHTTPSource(baseURL, token string) (*HTTPSource, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid BSS URL %q: must be an http or https URL", baseURL)
+	}
+
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	if !strings.HasSuffix(baseURL, "/boot/v1") {
+		baseURL += "/boot/v1"
+	}
+
+	return &HTTPSource{
+		baseURL:    baseURL,
+		token:      token,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+// BootParameters returns every boot parameters entry stored in BSS
+func (s *HTTPSource) BootParameters(ctx context.Context) ([]boot.BootParameters, error) {
+	var params []boot.BootParameters
+	if err := s.get(ctx, "/bootparameters", &params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// Hosts returns the nodes known to BSS
+func (s *HTTPSource) Hosts(ctx context.Context) ([]Host, error) {
+	var hosts []Host
+	if err := s.get(ctx, "/hosts", &hosts); err != nil {
+		return nil, err
+	}
+	return hosts, nil
+}
+
+func (s *HTTPSource) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("GET %s: %w", req.URL, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %s", req.URL, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("GET %s: decoding response: %w", req.URL, err)
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/handlers/boot"
+	"github.com/openchami/boot-service/pkg/snapshot"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// Annotations set on migrated resources
+const (
+	// MigratedFromAnnotation marks resources created or updated by a migration
+	MigratedFromAnnotation = "boot.openchami.io/migrated-from"
+	// CommentAnnotation keeps the comment of the source BSS entry
+	CommentAnnotation = "boot.openchami.io/bss-comment"
+)
+
+const apiVersion = "boot.openchami.io/v1"
+
+var nameInvalidChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Report summarizes the conversion of a BSS deployment
+type Report struct {
+	BootParameters     int      `json:"bootParameters"`
+	Hosts              int      `json:"hosts"`
+	Nodes              int      `json:"nodes"`
+	BootConfigurations int      `json:"bootConfigurations"`
+	Warnings           []string `json:"warnings,omitempty"`
+}
+
+func (r *Report) warnf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Read fetches everything needed for a migration from src
+func Read(ctx context.Context, src Source) ([]boot.BootParameters, []Host, error) {
+	params, err := src.BootParameters(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	hosts, err := src.Hosts(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return params, hosts, nil
+}
+
+// Convert turns BSS boot parameters and hosts into nodes and boot
+// configurations, converting each entry through the legacy API converter.
+// Resources already in existing, the current state of the target storage,
+// keep their UID when a converted resource has the same xname (nodes) or name
+// (boot configurations), so re-running a migration updates rather than
+// duplicates. Entries that cannot be converted are skipped and reported as
+// warnings.
+func Convert(params []boot.BootParameters, hosts []Host, existing *snapshot.Snapshot) (*snapshot.Snapshot, *Report, error) {
+	if existing == nil {
+		existing = &snapshot.Snapshot{}
+	}
+	now := time.Now().UTC()
+	report := &Report{BootParameters: len(params), Hosts: len(hosts)}
+	out := &snapshot.Snapshot{Version: snapshot.FormatVersion, ExportedAt: now}
+
+	configs, err := convertBootParameters(params, existing, now, report)
+	if err != nil {
+		return nil, nil, err
+	}
+	out.BootConfigurations = configs
+
+	// BSS targets role names such as "Compute" through hosts entries, which
+	// become groups here, so nodes join the groups named after their roles
+	groupTargets := map[string]bool{}
+	for i := range configs {
+		for _, group := range configs[i].Spec.Groups {
+			groupTargets[group] = true
+		}
+	}
+
+	nodes, err := convertHosts(hosts, existing, groupTargets, now, report)
+	if err != nil {
+		return nil, nil, err
+	}
+	out.Nodes = nodes
+
+	report.Nodes = len(out.Nodes)
+	report.BootConfigurations = len(out.BootConfigurations)
+	return out, report, nil
+}
+
+func convertBootParameters(params []boot.BootParameters, existing *snapshot.Snapshot, now time.Time, report *Report) ([]v1.BootConfiguration, error) {
+	existingByName := map[string]*v1.BootConfiguration{}
+	for i := range existing.BootConfigurations {
+		existingByName[existing.BootConfigurations[i].Metadata.Name] = &existing.BootConfigurations[i]
+	}
+
+	var configs []v1.BootConfiguration
+	usedNames := map[string]bool{}
+	for i, entry := range params {
+		location := fmt.Sprintf("bootparameters[%d]", i)
+
+		config := boot.ConvertLegacyToBootConfiguration(entry)
+		if len(config.Spec.NIDs) != len(entry.Nids) {
+			report.warnf("%s: dropped non-numeric NIDs from %v", location, entry.Nids)
+		}
+
+		// BSS keeps XNames, role names, and "Default" together in hosts
+		isDefault := false
+		config.Spec.Hosts = nil
+		for _, host := range entry.Hosts {
+			switch {
+			case strings.EqualFold(host, "default"):
+				isDefault = true
+			case validation.ValidateXNameOrDefault(host) && host != "":
+				config.Spec.Hosts = append(config.Spec.Hosts, host)
+			default:
+				config.Spec.Groups = append(config.Spec.Groups, host)
+			}
+		}
+		for j, mac := range config.Spec.MACs {
+			config.Spec.MACs[j] = validation.NormalizeMAC(mac)
+		}
+
+		hasTargets := len(config.Spec.Hosts)+len(config.Spec.MACs)+len(config.Spec.NIDs)+len(config.Spec.Groups) > 0
+		if !hasTargets && !isDefault {
+			report.warnf("%s: skipped, it targets no nodes", location)
+			continue
+		}
+		if entry.CloudInit.MetaData != nil || entry.CloudInit.UserData != nil ||
+			entry.CloudInit.VendorData != nil || entry.CloudInit.NetworkData != nil {
+			report.warnf("%s: cloud-init data is not migrated", location)
+		}
+
+		name := uniqueName(configName(config), usedNames)
+		config.APIVersion = apiVersion
+		config.Kind = "BootConfiguration"
+		config.Metadata.Name = name
+		config.Metadata.Annotations = map[string]string{MigratedFromAnnotation: "bss"}
+		if entry.Meta.Comment != "" {
+			config.Metadata.Annotations[CommentAnnotation] = entry.Meta.Comment
+		}
+		config.Metadata.CreatedAt = entry.Meta.CreatedAt
+		if config.Metadata.CreatedAt.IsZero() {
+			config.Metadata.CreatedAt = now
+		}
+		config.Metadata.UpdatedAt = now
+
+		if current, ok := existingByName[name]; ok {
+			config.Metadata.UID = current.Metadata.UID
+			config.Metadata.CreatedAt = current.Metadata.CreatedAt
+			config.Metadata.Labels = current.Metadata.Labels
+		} else {
+			uid, err := resource.GenerateUIDForResource("BootConfiguration")
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate UID: %w", err)
+			}
+			config.Metadata.UID = uid
+		}
+
+		if err := config.Validate(context.Background()); err != nil {
+			report.warnf("%s (%s): skipped: %v", location, name, err)
+			continue
+		}
+		configs = append(configs, *config)
+	}
+	return configs, nil
+}
+
+func convertHosts(hosts []Host, existing *snapshot.Snapshot, groupTargets map[string]bool, now time.Time, report *Report) ([]v1.Node, error) {
+	existingByXName := map[string]*v1.Node{}
+	for i := range existing.Nodes {
+		existingByXName[existing.Nodes[i].Spec.XName] = &existing.Nodes[i]
+	}
+
+	var nodes []v1.Node
+	seen := map[string]bool{}
+	for i, host := range hosts {
+		location := fmt.Sprintf("hosts[%d]", i)
+		if host.Type != "" && host.Type != "Node" {
+			continue
+		}
+		if !validation.ValidateXName(host.ID) {
+			report.warnf("%s: skipped, invalid xname %q", location, host.ID)
+			continue
+		}
+		if seen[host.ID] {
+			report.warnf("%s: skipped duplicate xname %s", location, host.ID)
+			continue
+		}
+		seen[host.ID] = true
+
+		node := v1.Node{
+			APIVersion: apiVersion,
+			Kind:       "Node",
+			Metadata:   resource.Metadata{Name: host.ID, CreatedAt: now},
+		}
+		if current, ok := existingByXName[host.ID]; ok {
+			node = *current
+		} else {
+			uid, err := resource.GenerateUIDForResource("Node")
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate UID: %w", err)
+			}
+			node.Metadata.UID = uid
+		}
+		node.Metadata.UpdatedAt = now
+		if node.Metadata.Annotations == nil {
+			node.Metadata.Annotations = map[string]string{}
+		}
+		node.Metadata.Annotations[MigratedFromAnnotation] = "bss"
+
+		node.Spec.XName = host.ID
+		node.Spec.NID = host.NID
+		node.Spec.Role = host.Role
+		node.Spec.SubRole = host.SubRole
+		if host.FQDN != "" {
+			node.Spec.Hostname = host.FQDN
+		}
+
+		node.Spec.BootMAC = ""
+		node.Spec.Interfaces = nil
+		for _, mac := range host.MAC {
+			if !validation.ValidateMAC(mac) {
+				report.warnf("%s (%s): dropped invalid MAC %q", location, host.ID, mac)
+				continue
+			}
+			mac = validation.NormalizeMAC(mac)
+			if node.Spec.BootMAC == "" {
+				node.Spec.BootMAC = mac
+			}
+			node.Spec.Interfaces = append(node.Spec.Interfaces, v1.NodeInterface{MAC: mac})
+		}
+
+		for _, role := range []string{host.Role, host.SubRole} {
+			if role != "" && groupTargets[role] && !containsString(node.Spec.Groups, role) {
+				node.Spec.Groups = append(node.Spec.Groups, role)
+			}
+		}
+
+		if err := node.Validate(context.Background()); err != nil {
+			report.warnf("%s (%s): skipped: %v", location, host.ID, err)
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// configName names a converted configuration after its first target
+func configName(config *v1.BootConfiguration) string {
+	target := "default"
+	switch {
+	case len(config.Spec.Hosts) > 0:
+		target = config.Spec.Hosts[0]
+	case len(config.Spec.Groups) > 0:
+		target = config.Spec.Groups[0]
+	case len(config.Spec.MACs) > 0:
+		target = config.Spec.MACs[0]
+	case len(config.Spec.NIDs) > 0:
+		target = fmt.Sprintf("nid-%d", config.Spec.NIDs[0])
+	}
+
+	name := nameInvalidChars.ReplaceAllString(strings.ToLower(target), "-")
+	return "bss-" + strings.Trim(name, "-")
+}
+
+// uniqueName appends a numeric suffix to name until it is unused
+func uniqueName(name string, used map[string]bool) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
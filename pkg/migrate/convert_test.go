@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/resource"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/handlers/boot"
+	"github.com/openchami/boot-service/pkg/snapshot"
+)
+
+func init() {
+	resource.RegisterResourcePrefix("Node", "node")
+	resource.RegisterResourcePrefix("BootConfiguration", "bootconfiguration")
+}
+
+func TestConvert(t *testing.T) {
+	params := []boot.BootParameters{
+		{Hosts: []string{"Default"}, Kernel: "http://files/vmlinuz", Params: "console=ttyS0"},
+		{Hosts: []string{"x0c0s0b0n0", "Compute"}, Kernel: "http://files/compute", Nids: []string{"1", "two"}},
+		{Macs: []string{"AA:BB:CC:DD:EE:02"}, Kernel: "http://files/vmlinuz"},
+		{Kernel: "http://files/orphan"},
+		{Hosts: []string{"x0c0s9b0n0"}},
+	}
+	hosts := []Host{
+		{ID: "x0c0s0b0n0", Type: "Node", NID: 1, Role: "Compute", MAC: []string{"AA:BB:CC:DD:EE:01", "bogus"}},
+		{ID: "x0c0s1b0n0", NID: 2, Role: "Application"},
+		{ID: "x0c0s0b0", Type: "NodeBMC"},
+		{ID: "node-1"},
+	}
+
+	existing := &snapshot.Snapshot{
+		BootConfigurations: []v1.BootConfiguration{{Metadata: resource.Metadata{UID: "bootconfiguration-keep", Name: "bss-default"}}},
+		Nodes:              []v1.Node{{Metadata: resource.Metadata{UID: "node-keep"}, Spec: v1.NodeSpec{XName: "x0c0s1b0n0", Groups: []string{"login"}}}},
+	}
+
+	converted, report, err := Convert(params, hosts, existing)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	configs := map[string]v1.BootConfiguration{}
+	for _, config := range converted.BootConfigurations {
+		configs[config.Metadata.Name] = config
+	}
+	if len(configs) != 3 {
+		t.Fatalf("got configurations %v, want 3", reflect.ValueOf(configs).MapKeys())
+	}
+
+	if def := configs["bss-default"]; def.Metadata.UID != "bootconfiguration-keep" || len(def.Spec.Hosts) != 0 || def.Spec.Params != "console=ttyS0" {
+		t.Errorf("default configuration not converted to a catch-all keeping its UID: %+v", def)
+	}
+	compute := configs["bss-x0c0s0b0n0"]
+	if !reflect.DeepEqual(compute.Spec.Hosts, []string{"x0c0s0b0n0"}) || !reflect.DeepEqual(compute.Spec.Groups, []string{"Compute"}) ||
+		!reflect.DeepEqual(compute.Spec.NIDs, []int32{1}) {
+		t.Errorf("unexpected targets for compute configuration: %+v", compute.Spec)
+	}
+	if compute.Metadata.Annotations[MigratedFromAnnotation] != "bss" {
+		t.Errorf("missing migration annotation: %v", compute.Metadata.Annotations)
+	}
+	if mac := configs["bss-aa-bb-cc-dd-ee-02"]; !reflect.DeepEqual(mac.Spec.MACs, []string{"aa:bb:cc:dd:ee:02"}) {
+		t.Errorf("expected lowercased MAC target, got %+v", mac.Spec)
+	}
+
+	if len(converted.Nodes) != 2 {
+		t.Fatalf("got %d nodes, want 2", len(converted.Nodes))
+	}
+	node := converted.Nodes[0]
+	if node.Spec.BootMAC != "aa:bb:cc:dd:ee:01" || !reflect.DeepEqual(node.Spec.Groups, []string{"Compute"}) {
+		t.Errorf("unexpected converted node: %+v", node.Spec)
+	}
+	if kept := converted.Nodes[1]; kept.Metadata.UID != "node-keep" || !reflect.DeepEqual(kept.Spec.Groups, []string{"login"}) {
+		t.Errorf("existing node not updated in place: %+v", kept)
+	}
+
+	for _, want := range []string{
+		`bootparameters[1]: dropped non-numeric NIDs from [1 two]`,
+		"bootparameters[3]: skipped, it targets no nodes",
+		"bootparameters[4] (bss-x0c0s9b0n0): skipped: kernel, kernelArtifact, or imageRef field is required",
+		`hosts[0] (x0c0s0b0n0): dropped invalid MAC "bogus"`,
+		`hosts[3]: skipped, invalid xname "node-1"`,
+	} {
+		if !containsString(report.Warnings, want) {
+			t.Errorf("report missing warning %q, got %q", want, report.Warnings)
+		}
+	}
+	if report.BootParameters != 5 || report.Hosts != 4 || report.Nodes != 2 || report.BootConfigurations != 3 {
+		t.Errorf("unexpected report counts: %+v", report)
+	}
+}
+
+func TestHTTPSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/boot/v1/bootparameters":
+			_, _ = w.Write([]byte(`[{"hosts":["Default"],"kernel":"http://files/vmlinuz"}]`))
+		case "/boot/v1/hosts":
+			_, _ = w.Write([]byte(`[{"ID":"x0c0s0b0n0","NID":1,"MAC":["aa:bb:cc:dd:ee:01"]}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	for _, baseURL := range []string{server.URL, server.URL + "/boot/v1/"} {
+		src, err := NewHTTPSource(baseURL, "secret")
+		if err != nil {
+			t.Fatalf("NewHTTPSource(%s) failed: %v", baseURL, err)
+		}
+		params, hosts, err := Read(context.Background(), src)
+		if err != nil {
+			t.Fatalf("Read from %s failed: %v", baseURL, err)
+		}
+		if len(params) != 1 || params[0].Kernel != "http://files/vmlinuz" || len(hosts) != 1 || hosts[0].NID != 1 {
+			t.Errorf("unexpected data from %s: %+v %+v", baseURL, params, hosts)
+		}
+	}
+
+	src, _ := NewHTTPSource(server.URL, "")
+	if _, err := src.Hosts(context.Background()); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+	if _, err := NewHTTPSource("bss:27778", ""); err == nil {
+		t.Error("expected URL without scheme to be rejected")
+	}
+}
+
+func TestGroupBootConfigRows(t *testing.T) {
+	str := func(s string) sql.NullString { return sql.NullString{String: s, Valid: s != ""} }
+	rows := []bootConfigRow{
+		{configID: "a", kernel: str("http://files/a"), cmdline: str("quiet"), xname: str("x0c0s0b0n0")},
+		{configID: "a", kernel: str("http://files/a"), cmdline: str("quiet"), bootMAC: str("aa:bb:cc:dd:ee:02")},
+		{configID: "b", kernel: str("http://files/b")},
+	}
+
+	params := groupBootConfigRows(rows)
+	if len(params) != 2 {
+		t.Fatalf("got %d entries, want 2", len(params))
+	}
+	if !reflect.DeepEqual(params[0].Hosts, []string{"x0c0s0b0n0"}) || !reflect.DeepEqual(params[0].Macs, []string{"aa:bb:cc:dd:ee:02"}) ||
+		params[0].Params != "quiet" {
+		t.Errorf("unexpected first entry: %+v", params[0])
+	}
+	if params[1].Kernel != "http://files/b" || len(params[1].Hosts)+len(params[1].Macs)+len(params[1].Nids) != 0 {
+		t.Errorf("unexpected unassigned entry: %+v", params[1])
+	}
+}
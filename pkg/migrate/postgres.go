@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	// Registers the "pgx" database/sql driver
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/openchami/boot-service/pkg/handlers/boot"
+)
+
+// bootConfigQuery lists each BSS boot config with the nodes assigned to it
+// through boot groups. Configs without assigned nodes are kept with NULL
+// node columns.
+const bootConfigQuery = `
+SELECT bc.id::text, bc.kernel_uri, bc.initrd_uri, bc.cmdline, n.xname, n.boot_mac, n.nid
+FROM boot_configs bc
+LEFT JOIN boot_groups bg ON bg.boot_config_id = bc.id
+LEFT JOIN boot_group_assignments bga ON bga.boot_group_id = bg.id
+LEFT JOIN nodes n ON n.id = bga.node_id
+ORDER BY bc.id, n.xname`
+
+const hostQuery = `SELECT xname, boot_mac, nid FROM nodes ORDER BY xname`
+
+// PostgresSource reads directly from the PostgreSQL database of a BSS
+// deployment that uses the postgres backend
+type PostgresSource struct {
+	db *sql.DB
+}
+
+// NewPostgresSource opens the BSS database described by dsn, e.g.
+// postgres://bss:secret@db:5432/bssdb?sslmode=disable
+func NewPostgresSource(ctx context.Context, dsn string) (*PostgresSource, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening BSS database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close() //nolint:errcheck
+		return nil, fmt.Errorf("connecting to BSS database: %w", err)
+	}
+	return &PostgresSource{db: db}, nil
+}
+
+// Close closes the database connection
+func (s *PostgresSource) Close() error {
+	return s.db.Close()
+}
+
+// bootConfigRow is one row of bootConfigQuery
+type bootConfigRow struct {
+	configID string
+	kernel   sql.NullString
+	initrd   sql.NullString
+	cmdline  sql.NullString
+	xname    sql.NullString
+	bootMAC  sql.NullString
+	nid      sql.NullInt64
+}
+
+// BootParameters returns one entry per BSS boot config, targeting the nodes
+// assigned to it
+func (s *PostgresSource) BootParameters(ctx context.Context) ([]boot.BootParameters, error) {
+	rows, err := s.db.QueryContext(ctx, bootConfigQuery)
+	if err != nil {
+		return nil, fmt.Errorf("querying BSS boot configs: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var configRows []bootConfigRow
+	for rows.Next() {
+		var row bootConfigRow
+		if err := rows.Scan(&row.configID, &row.kernel, &row.initrd, &row.cmdline, &row.xname, &row.bootMAC, &row.nid); err != nil {
+			return nil, fmt.Errorf("reading BSS boot configs: %w", err)
+		}
+		configRows = append(configRows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading BSS boot configs: %w", err)
+	}
+	return groupBootConfigRows(configRows), nil
+}
+
+// Hosts returns the nodes stored in the BSS database
+func (s *PostgresSource) Hosts(ctx context.Context) ([]Host, error) {
+	rows, err := s.db.QueryContext(ctx, hostQuery)
+	if err != nil {
+		return nil, fmt.Errorf("querying BSS nodes: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var hosts []Host
+	for rows.Next() {
+		var xname, bootMAC sql.NullString
+		var nid sql.NullInt64
+		if err := rows.Scan(&xname, &bootMAC, &nid); err != nil {
+			return nil, fmt.Errorf("reading BSS nodes: %w", err)
+		}
+		host := Host{ID: xname.String, NID: int32(nid.Int64)}
+		if bootMAC.String != "" {
+			host.MAC = []string{bootMAC.String}
+		}
+		hosts = append(hosts, host)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading BSS nodes: %w", err)
+	}
+	return hosts, nil
+}
+
+// groupBootConfigRows folds the per-node rows of each boot config, which
+// arrive ordered by config, into one boot parameters entry
+func groupBootConfigRows(rows []bootConfigRow) []boot.BootParameters {
+	var params []boot.BootParameters
+	lastID := ""
+	for _, row := range rows {
+		if len(params) == 0 || row.configID != lastID {
+			params = append(params, boot.BootParameters{
+				Kernel: row.kernel.String,
+				Initrd: row.initrd.String,
+				Params: row.cmdline.String,
+				Meta:   boot.MetaData{Comment: "BSS boot config " + row.configID},
+			})
+			lastID = row.configID
+		}
+
+		entry := &params[len(params)-1]
+		switch {
+		case row.xname.String != "":
+			entry.Hosts = append(entry.Hosts, row.xname.String)
+		case row.bootMAC.String != "":
+			entry.Macs = append(entry.Macs, row.bootMAC.String)
+		case row.nid.Valid:
+			entry.Nids = append(entry.Nids, fmt.Sprint(row.nid.Int64))
+		}
+	}
+	return params
+}
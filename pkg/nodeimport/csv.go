@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package nodeimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// parseCSV reads a CSV inventory with a header row. Each column is mapped to
+// a node field by mapping or, without a mapping entry, by a header that
+// names the field; other columns are ignored with a warning.
+func parseCSV(in io.Reader, mapping map[string]string, report *Report) ([]Entry, error) {
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("empty CSV input: a header row is required")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+	// Spreadsheet exports often start with a byte order mark
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	columns, err := mapColumns(header, mapping, report)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		entry := Entry{Location: fmt.Sprintf("row %d", line), Values: map[string]string{}}
+		for i, cell := range row {
+			if field := columns[i]; field != "" {
+				if cell = strings.TrimSpace(cell); cell != "" {
+					entry.Values[field] = cell
+				}
+			}
+		}
+		if len(entry.Values) == 0 {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// mapColumns returns the node field of each header column, or "" for
+// ignored columns
+func mapColumns(header []string, mapping map[string]string, report *Report) ([]string, error) {
+	columns := make([]string, len(header))
+	index := func(name string) int {
+		return slices.IndexFunc(header, func(column string) bool {
+			return strings.EqualFold(strings.TrimSpace(column), strings.TrimSpace(name))
+		})
+	}
+
+	mapped := map[string]bool{}
+	for field, column := range mapping {
+		if !isField(field) {
+			return nil, fmt.Errorf("unknown node field %q in mapping: must be one of %s, or metadata.<key>", field, strings.Join(Fields, ", "))
+		}
+		i := index(column)
+		if i < 0 {
+			return nil, fmt.Errorf("column %q mapped to %s is not in the CSV header", column, field)
+		}
+		if columns[i] != "" {
+			return nil, fmt.Errorf("column %q is mapped to both %s and %s", column, columns[i], field)
+		}
+		columns[i] = field
+		mapped[field] = true
+	}
+
+	for i, column := range header {
+		if columns[i] != "" {
+			continue
+		}
+		column = strings.TrimSpace(column)
+		field := canonicalField(column)
+		if field == "" || mapped[field] {
+			if column != "" {
+				report.warnf("column %q is not mapped to a node field", column)
+			}
+			continue
+		}
+		columns[i] = field
+		mapped[field] = true
+	}
+
+	if !mapped[FieldXName] {
+		return nil, errors.New("no column is mapped to xname")
+	}
+	return columns, nil
+}
+
+// canonicalField returns the field a column header names, compared
+// case-insensitively, or ""
+func canonicalField(column string) string {
+	for _, field := range Fields {
+		if strings.EqualFold(column, field) {
+			return field
+		}
+	}
+	if len(column) > len(metadataPrefix) && strings.EqualFold(column[:len(metadataPrefix)], metadataPrefix) {
+		return metadataPrefix + column[len(metadataPrefix):]
+	}
+	return ""
+}
+
+func isField(field string) bool {
+	return slices.Contains(Fields, field) || (strings.HasPrefix(field, metadataPrefix) && len(field) > len(metadataPrefix))
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package nodeimport
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/openchami/boot-service/internal/httputil"
+)
+
+// Path serves node imports
+const Path = "/nodes:import"
+
+// maxRequestSize bounds an uploaded inventory
+const maxRequestSize = 32 << 20
+
+// Handler serves the node import API
+type Handler struct {
+	backend fabricaStorage.StorageBackend
+}
+
+// NewHandler creates a node import API handler writing to backend
+func NewHandler(backend fabricaStorage.StorageBackend) *Handler {
+	return &Handler{backend: backend}
+}
+
+// RegisterRoutes registers POST /nodes:import
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Post(Path, h.Import)
+}
+
+// Import handles POST /nodes:import. The body is the inventory; its format
+// comes from the format query parameter or the Content-Type (text/csv for
+// CSV, application/json for SLS). Repeated map=<field>=<column> parameters
+// map CSV columns to node fields, dryRun=true reports without writing, and
+// strict=true writes nothing when any entry is invalid.
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := Options{Format: query.Get("format")}
+	if opts.Format == "" {
+		opts.Format = formatFromContentType(r.Header.Get("Content-Type"))
+	}
+	var err error
+	if opts.DryRun, err = boolParam(query.Get("dryRun")); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid dryRun parameter", err.Error())
+		return
+	}
+	if opts.Strict, err = boolParam(query.Get("strict")); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid strict parameter", err.Error())
+		return
+	}
+	if opts.Mapping, err = ParseMapping(query["map"]); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid field mapping", err.Error())
+		return
+	}
+
+	report, err := Run(r.Context(), h.backend, http.MaxBytesReader(w, r.Body, maxRequestSize), opts)
+	switch {
+	case errors.Is(err, ErrInvalidEntries):
+		httputil.WriteJSON(w, http.StatusUnprocessableEntity, report)
+	case errors.Is(err, ErrInvalidInput):
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid node import", err.Error())
+	case err != nil:
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to import nodes", err.Error())
+	default:
+		httputil.WriteJSON(w, http.StatusOK, report)
+	}
+}
+
+// ParseMapping parses <field>=<column> mapping entries
+func ParseMapping(entries []string) (map[string]string, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	mapping := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		field, column, ok := strings.Cut(entry, "=")
+		field, column = strings.TrimSpace(field), strings.TrimSpace(column)
+		if !ok || field == "" || column == "" {
+			return nil, fmt.Errorf("invalid mapping %q: must be <field>=<column>", entry)
+		}
+		if _, dup := mapping[field]; dup {
+			return nil, fmt.Errorf("field %s is mapped more than once", field)
+		}
+		mapping[field] = column
+	}
+	return mapping, nil
+}
+
+func formatFromContentType(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return FormatCSV
+	case "application/json":
+		return FormatSLS
+	}
+	return ""
+}
+
+func boolParam(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
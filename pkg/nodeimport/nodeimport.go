@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package nodeimport creates and updates nodes from inventories kept outside
+// the service: CSV spreadsheets and Cray System Layout Service (SLS) dumps.
+package nodeimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/snapshot"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// Supported input formats
+const (
+	FormatCSV = "csv"
+	FormatSLS = "sls"
+)
+
+// Node fields an entry can set. CSV columns map onto these names; a column
+// mapped to "metadata.<key>" sets that node metadata key.
+const (
+	FieldXName    = "xname"
+	FieldNID      = "nid"
+	FieldBootMAC  = "bootMac"
+	FieldMACs     = "macs"
+	FieldRole     = "role"
+	FieldSubRole  = "subRole"
+	FieldHostname = "hostname"
+	FieldGroups   = "groups"
+
+	metadataPrefix = "metadata."
+)
+
+// Fields lists the node fields an entry can set, besides metadata keys
+var Fields = []string{FieldXName, FieldNID, FieldBootMAC, FieldMACs, FieldRole, FieldSubRole, FieldHostname, FieldGroups}
+
+// ImportedFromAnnotation records the format of the last import that created
+// or updated a node
+const ImportedFromAnnotation = "boot.openchami.io/imported-from"
+
+const apiVersion = "boot.openchami.io/v1"
+
+// ErrInvalidInput is returned for input that cannot be read at all
+var ErrInvalidInput = errors.New("invalid import input")
+
+// ErrInvalidEntries is returned by a strict import when any entry is invalid
+var ErrInvalidEntries = errors.New("invalid entries in import")
+
+// Options controls how an inventory is read and applied
+type Options struct {
+	// Format is FormatCSV or FormatSLS
+	Format string
+	// Mapping maps node fields to CSV column headers. Columns named after a
+	// field, compared case-insensitively, are mapped without an entry.
+	Mapping map[string]string
+	// DryRun validates and reports without writing
+	DryRun bool
+	// Strict writes nothing when any entry is invalid, instead of skipping
+	// the invalid entries
+	Strict bool
+}
+
+// Entry is one node read from an inventory: the values of the fields it
+// sets, keyed by field name
+type Entry struct {
+	// Location names the entry in the input, e.g. "row 3"
+	Location string
+	Values   map[string]string
+}
+
+// Problem is an entry that was skipped
+type Problem struct {
+	Location string `json:"location"`
+	XName    string `json:"xname,omitempty"`
+	Error    string `json:"error"`
+}
+
+// Report summarizes an import
+type Report struct {
+	Format   string          `json:"format"`
+	DryRun   bool            `json:"dryRun"`
+	Entries  int             `json:"entries"`
+	Nodes    int             `json:"nodes"`
+	Skipped  []Problem       `json:"skipped,omitempty"`
+	Warnings []string        `json:"warnings,omitempty"`
+	Import   snapshot.Result `json:"import,omitempty"`
+}
+
+func (r *Report) warnf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (r *Report) skip(entry Entry, err error) {
+	r.Skipped = append(r.Skipped, Problem{Location: entry.Location, XName: entry.Values[FieldXName], Error: err.Error()})
+}
+
+// Parse reads the entries of an inventory in opts.Format
+func Parse(in io.Reader, opts Options) ([]Entry, *Report, error) {
+	report := &Report{Format: opts.Format, DryRun: opts.DryRun}
+	var entries []Entry
+	var err error
+	switch opts.Format {
+	case FormatCSV:
+		entries, err = parseCSV(in, opts.Mapping, report)
+	case FormatSLS:
+		if len(opts.Mapping) > 0 {
+			return nil, nil, fmt.Errorf("%w: field mappings apply to CSV input only", ErrInvalidInput)
+		}
+		entries, err = parseSLS(in, report)
+	default:
+		return nil, nil, fmt.Errorf("%w: unsupported format %q: must be %s or %s", ErrInvalidInput, opts.Format, FormatCSV, FormatSLS)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+	report.Entries = len(entries)
+	return entries, report, nil
+}
+
+// Run reads an inventory from in and creates or updates its nodes in
+// backend. Nodes already stored with the same xname keep their UID, and
+// fields the inventory does not set keep their current value. Invalid
+// entries are skipped and reported; with opts.Strict nothing is written
+// when any entry is invalid and ErrInvalidEntries is returned along with the
+// report.
+func Run(ctx context.Context, backend fabricaStorage.StorageBackend, in io.Reader, opts Options) (*Report, error) {
+	entries, report, err := Parse(in, opts)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := snapshot.Export(ctx, backend)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := Convert(ctx, entries, existing, opts.Format, report)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Strict && len(report.Skipped) > 0 {
+		return report, fmt.Errorf("%w: %d of %d entries skipped", ErrInvalidEntries, len(report.Skipped), report.Entries)
+	}
+
+	result, err := snapshot.Import(ctx, backend, converted, snapshot.ImportOptions{DryRun: opts.DryRun})
+	if err != nil {
+		return report, err
+	}
+	report.Import = snapshot.Result{"Node": result["Node"]}
+	return report, nil
+}
+
+// Convert applies entries to the nodes in existing, the current state of the
+// target storage, and returns the created and updated nodes. Entries that
+// cannot be applied are skipped and added to report.
+func Convert(ctx context.Context, entries []Entry, existing *snapshot.Snapshot, format string, report *Report) (*snapshot.Snapshot, error) {
+	if existing == nil {
+		existing = &snapshot.Snapshot{}
+	}
+	existingByXName := map[string]*v1.Node{}
+	for i := range existing.Nodes {
+		existingByXName[existing.Nodes[i].Spec.XName] = &existing.Nodes[i]
+	}
+
+	now := time.Now().UTC()
+	out := &snapshot.Snapshot{Version: snapshot.FormatVersion, ExportedAt: now}
+	seen := map[string]string{}
+	for _, entry := range entries {
+		xname := entry.Values[FieldXName]
+		if xname == "" {
+			report.skip(entry, errors.New("missing xname"))
+			continue
+		}
+		if !validation.ValidateXName(xname) {
+			report.skip(entry, fmt.Errorf("invalid xname %q", xname))
+			continue
+		}
+		if first, ok := seen[xname]; ok {
+			report.skip(entry, fmt.Errorf("duplicate xname, first listed at %s", first))
+			continue
+		}
+		seen[xname] = entry.Location
+
+		node := v1.Node{
+			APIVersion: apiVersion,
+			Kind:       "Node",
+			Metadata:   resource.Metadata{Name: xname, CreatedAt: now},
+		}
+		if current, ok := existingByXName[xname]; ok {
+			node = *current
+			node.Spec.Groups = append([]string(nil), current.Spec.Groups...)
+			node.Spec.Interfaces = append([]v1.NodeInterface(nil), current.Spec.Interfaces...)
+			node.Spec.Metadata = maps.Clone(current.Spec.Metadata)
+		} else {
+			uid, err := resource.GenerateUIDForResource("Node")
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate UID: %w", err)
+			}
+			node.Metadata.UID = uid
+		}
+
+		if err := apply(&node.Spec, entry.Values); err != nil {
+			report.skip(entry, err)
+			continue
+		}
+		node.Metadata.UpdatedAt = now
+		node.Metadata.Annotations = maps.Clone(node.Metadata.Annotations)
+		if node.Metadata.Annotations == nil {
+			node.Metadata.Annotations = map[string]string{}
+		}
+		node.Metadata.Annotations[ImportedFromAnnotation] = format
+
+		if err := node.Validate(ctx); err != nil {
+			report.skip(entry, err)
+			continue
+		}
+		out.Nodes = append(out.Nodes, node)
+	}
+	report.Nodes = len(out.Nodes)
+	return out, nil
+}
+
+// apply sets the fields in values on spec. Empty values leave the field
+// unchanged.
+func apply(spec *v1.NodeSpec, values map[string]string) error {
+	spec.XName = values[FieldXName]
+	if value := values[FieldNID]; value != "" {
+		nid, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || nid < 0 {
+			return fmt.Errorf("invalid nid %q", value)
+		}
+		spec.NID = int32(nid)
+	}
+	if value := values[FieldRole]; value != "" {
+		spec.Role = value
+	}
+	if value := values[FieldSubRole]; value != "" {
+		spec.SubRole = value
+	}
+	if value := values[FieldHostname]; value != "" {
+		spec.Hostname = value
+	}
+	if value := values[FieldGroups]; value != "" {
+		spec.Groups = splitList(value)
+	}
+
+	if value := values[FieldMACs]; value != "" {
+		spec.Interfaces = nil
+		for _, mac := range splitList(value) {
+			if !validation.ValidateMAC(mac) {
+				return fmt.Errorf("invalid MAC %q", mac)
+			}
+			spec.Interfaces = append(spec.Interfaces, v1.NodeInterface{MAC: validation.NormalizeMAC(mac)})
+		}
+		if values[FieldBootMAC] == "" && len(spec.Interfaces) > 0 && !hasInterface(spec.Interfaces, spec.BootMAC) {
+			spec.BootMAC = spec.Interfaces[0].MAC
+		}
+	}
+	if value := values[FieldBootMAC]; value != "" {
+		if !validation.ValidateMAC(value) {
+			return fmt.Errorf("invalid bootMac %q", value)
+		}
+		spec.BootMAC = validation.NormalizeMAC(value)
+		if !hasInterface(spec.Interfaces, spec.BootMAC) {
+			spec.Interfaces = append([]v1.NodeInterface{{MAC: spec.BootMAC}}, spec.Interfaces...)
+		}
+	}
+
+	for field, value := range values {
+		key, ok := strings.CutPrefix(field, metadataPrefix)
+		if !ok || value == "" {
+			continue
+		}
+		if spec.Metadata == nil {
+			spec.Metadata = map[string]string{}
+		}
+		spec.Metadata[key] = value
+	}
+	return nil
+}
+
+// splitList splits a list cell on semicolons, commas, and whitespace
+func splitList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' ' || r == '\t'
+	})
+}
+
+func hasInterface(interfaces []v1.NodeInterface, mac string) bool {
+	for _, iface := range interfaces {
+		if validation.EqualMAC(iface.MAC, mac) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,290 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package nodeimport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/resource"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/snapshot"
+)
+
+func init() {
+	resource.RegisterResourcePrefix("Node", "node")
+}
+
+func newTestBackend(t *testing.T) fabricaStorage.StorageBackend {
+	t.Helper()
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	return backend
+}
+
+func storedNodes(t *testing.T, backend fabricaStorage.StorageBackend) map[string]v1.Node {
+	t.Helper()
+	state, err := snapshot.Export(context.Background(), backend)
+	if err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+	nodes := map[string]v1.Node{}
+	for _, node := range state.Nodes {
+		nodes[node.Spec.XName] = node
+	}
+	return nodes
+}
+
+const inventoryCSV = `Component,NID,Boot MAC,role,groups,Rack,Notes
+x1000c0s0b0n0,1,AA:BB:CC:DD:EE:01,Compute,compute;gpu,r1,first
+x1000c0s0b0n1,2,aa:bb:cc:dd:ee:02,Compute,compute,r1,
+x1000c0s0b0n2,three,aa:bb:cc:dd:ee:03,Compute,,r1,
+not-an-xname,4,aa:bb:cc:dd:ee:04,Compute,,r2,
+x1000c0s0b0n0,5,aa:bb:cc:dd:ee:05,Compute,,r2,duplicate
+,,,,,,
+`
+
+func csvOptions() Options {
+	return Options{
+		Format:  FormatCSV,
+		Mapping: map[string]string{FieldXName: "Component", FieldBootMAC: "Boot MAC", "metadata.rack": "rack"},
+	}
+}
+
+func TestRunCSV(t *testing.T) {
+	backend := newTestBackend(t)
+	ctx := context.Background()
+
+	report, err := Run(ctx, backend, strings.NewReader(inventoryCSV), csvOptions())
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if report.Entries != 5 || report.Nodes != 2 || report.Import["Node"].Created != 2 {
+		t.Errorf("report = %+v, want 5 entries and 2 created nodes", report)
+	}
+	wantSkipped := map[string]string{
+		"row 4": `invalid nid "three"`,
+		"row 5": `invalid xname "not-an-xname"`,
+		"row 6": "duplicate xname, first listed at row 2",
+	}
+	if len(report.Skipped) != len(wantSkipped) {
+		t.Errorf("skipped = %+v, want %d entries", report.Skipped, len(wantSkipped))
+	}
+	for _, problem := range report.Skipped {
+		if want := wantSkipped[problem.Location]; want == "" || !strings.Contains(problem.Error, want) {
+			t.Errorf("skipped %s: %q, want %q", problem.Location, problem.Error, want)
+		}
+	}
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0], `"Notes"`) {
+		t.Errorf("warnings = %v, want the unmapped Notes column", report.Warnings)
+	}
+
+	node := storedNodes(t, backend)["x1000c0s0b0n0"]
+	if node.Spec.NID != 1 || node.Spec.BootMAC != "aa:bb:cc:dd:ee:01" || node.Spec.Role != "Compute" {
+		t.Errorf("node spec = %+v, want the first row", node.Spec)
+	}
+	if len(node.Spec.Groups) != 2 || node.Spec.Groups[1] != "gpu" || node.Spec.Metadata["rack"] != "r1" {
+		t.Errorf("groups %v, metadata %v; want compute and gpu in rack r1", node.Spec.Groups, node.Spec.Metadata)
+	}
+	if len(node.Spec.Interfaces) != 1 || node.Metadata.Annotations[ImportedFromAnnotation] != FormatCSV {
+		t.Errorf("interfaces %v, annotations %v; want the boot MAC and the import annotation", node.Spec.Interfaces, node.Metadata.Annotations)
+	}
+}
+
+func TestRunUpdatesExistingNodes(t *testing.T) {
+	backend := newTestBackend(t)
+	ctx := context.Background()
+
+	if _, err := Run(ctx, backend, strings.NewReader(inventoryCSV), csvOptions()); err != nil {
+		t.Fatalf("first Run returned error: %v", err)
+	}
+	before := storedNodes(t, backend)["x1000c0s0b0n0"]
+
+	// Only the hostname is set, so the other fields keep their value
+	update := "xname,hostname\nx1000c0s0b0n0,nid0001\n"
+	report, err := Run(ctx, backend, strings.NewReader(update), Options{Format: FormatCSV})
+	if err != nil {
+		t.Fatalf("second Run returned error: %v", err)
+	}
+	if counts := report.Import["Node"]; counts.Created != 0 || counts.Updated != 1 {
+		t.Errorf("import counts = %+v, want one update", counts)
+	}
+	after := storedNodes(t, backend)["x1000c0s0b0n0"]
+	if after.Metadata.UID != before.Metadata.UID || after.Spec.Hostname != "nid0001" {
+		t.Errorf("node after update = %+v, want the same UID with the new hostname", after)
+	}
+	if after.Spec.BootMAC != before.Spec.BootMAC || len(after.Spec.Groups) != 2 || after.Spec.Metadata["rack"] != "r1" {
+		t.Errorf("node spec after update = %+v, want the other fields kept", after.Spec)
+	}
+}
+
+func TestRunDryRunAndStrict(t *testing.T) {
+	backend := newTestBackend(t)
+	ctx := context.Background()
+
+	opts := csvOptions()
+	opts.DryRun = true
+	report, err := Run(ctx, backend, strings.NewReader(inventoryCSV), opts)
+	if err != nil {
+		t.Fatalf("dry run returned error: %v", err)
+	}
+	if report.Import["Node"].Created != 2 || len(storedNodes(t, backend)) != 0 {
+		t.Errorf("dry run report %+v with %d stored nodes, want 2 reported and none written", report.Import, len(storedNodes(t, backend)))
+	}
+
+	opts = csvOptions()
+	opts.Strict = true
+	report, err = Run(ctx, backend, strings.NewReader(inventoryCSV), opts)
+	if !errors.Is(err, ErrInvalidEntries) {
+		t.Fatalf("strict Run error = %v, want ErrInvalidEntries", err)
+	}
+	if report == nil || len(report.Skipped) != 3 || len(storedNodes(t, backend)) != 0 {
+		t.Errorf("strict report %+v, want 3 skipped entries and nothing written", report)
+	}
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		mapping map[string]string
+		want    string
+	}{
+		"empty":            {input: "", want: "header row is required"},
+		"no xname":         {input: "nid,role\n1,Compute\n", want: "no column is mapped to xname"},
+		"unknown field":    {input: "xname\n", mapping: map[string]string{"kernel": "xname"}, want: `unknown node field "kernel"`},
+		"missing column":   {input: "xname\n", mapping: map[string]string{FieldNID: "Node ID"}, want: `column "Node ID" mapped to nid`},
+		"column mapped 2x": {input: "name\n", mapping: map[string]string{FieldXName: "name", FieldHostname: "name"}, want: "mapped to both"},
+	}
+	for name, tt := range tests {
+		_, _, err := Parse(strings.NewReader(tt.input), Options{Format: FormatCSV, Mapping: tt.mapping})
+		if !errors.Is(err, ErrInvalidInput) || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want ErrInvalidInput containing %q", name, err, tt.want)
+		}
+	}
+}
+
+const slsDump = `{
+  "Hardware": {
+    "x3000c0s1b0n0": {
+      "Parent": "x3000c0s1b0", "Xname": "x3000c0s1b0n0", "Type": "comptype_node", "Class": "River", "TypeString": "Node",
+      "ExtraProperties": {"NID": 100001, "Role": "Management", "SubRole": "Master", "Aliases": ["ncn-m001"]}
+    },
+    "x1000c0s0b0n0": {
+      "Parent": "x1000c0s0b0", "Xname": "x1000c0s0b0n0", "Type": "comptype_node", "Class": "Mountain", "TypeString": "Node",
+      "ExtraProperties": {"NID": 1000, "Role": "Compute", "Aliases": ["nid001000"]}
+    },
+    "x3000c0w14": {
+      "Parent": "x3000", "Xname": "x3000c0w14", "Type": "comptype_mgmt_switch", "Class": "River", "TypeString": "MgmtSwitch",
+      "ExtraProperties": {"IP4addr": "10.254.0.2"}
+    }
+  },
+  "Networks": {}
+}`
+
+func TestRunSLS(t *testing.T) {
+	backend := newTestBackend(t)
+	ctx := context.Background()
+
+	report, err := Run(ctx, backend, strings.NewReader(slsDump), Options{Format: FormatSLS})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if report.Entries != 2 || report.Nodes != 2 || len(report.Warnings) != 1 {
+		t.Errorf("report = %+v, want 2 nodes and a warning for the switch", report)
+	}
+	node := storedNodes(t, backend)["x3000c0s1b0n0"]
+	if node.Spec.NID != 100001 || node.Spec.Role != "Management" || node.Spec.SubRole != "Master" || node.Spec.Hostname != "ncn-m001" {
+		t.Errorf("node spec = %+v, want the SLS properties", node.Spec)
+	}
+	if node.Spec.Metadata["slsClass"] != "River" {
+		t.Errorf("metadata = %v, want the SLS class", node.Spec.Metadata)
+	}
+
+	// The hardware list form of the same nodes updates them
+	list := `[{"Xname": "x1000c0s0b0n0", "Type": "comptype_node", "ExtraProperties": {"NID": 1000, "Role": "Application"}}]`
+	report, err = Run(ctx, backend, strings.NewReader(list), Options{Format: FormatSLS})
+	if err != nil {
+		t.Fatalf("Run of the hardware list returned error: %v", err)
+	}
+	if counts := report.Import["Node"]; counts.Updated != 1 {
+		t.Errorf("import counts = %+v, want one update", counts)
+	}
+	if node := storedNodes(t, backend)["x1000c0s0b0n0"]; node.Spec.Role != "Application" || node.Spec.Hostname != "nid001000" {
+		t.Errorf("node spec = %+v, want the new role and the kept hostname", node.Spec)
+	}
+
+	if _, err := Run(ctx, backend, strings.NewReader(slsDump), Options{Format: FormatSLS, Mapping: map[string]string{FieldXName: "Xname"}}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("SLS with a mapping error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func serveImport(t *testing.T, backend fabricaStorage.StorageBackend, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	router := chi.NewRouter()
+	NewHandler(backend).RegisterRoutes(router)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_Import(t *testing.T) {
+	backend := newTestBackend(t)
+
+	target := Path + "?dryRun=true&map=xname%3DComponent&map=bootMac%3DBoot+MAC"
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(inventoryCSV))
+	req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	rec := serveImport(t, backend, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST status = %d, body %s", rec.Code, rec.Body.String())
+	}
+	var report Report
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	if !report.DryRun || report.Format != FormatCSV || report.Import["Node"].Created != 2 || len(report.Skipped) != 3 {
+		t.Errorf("report = %+v, want a CSV dry run creating 2 nodes", report)
+	}
+	if len(storedNodes(t, backend)) != 0 {
+		t.Error("expected the dry run to write nothing")
+	}
+
+	req = httptest.NewRequest(http.MethodPost, Path+"?strict=true&map=xname%3DComponent", strings.NewReader(inventoryCSV))
+	req.Header.Set("Content-Type", "text/csv")
+	if rec := serveImport(t, backend, req); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("strict POST status = %d, want 422", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, Path, strings.NewReader(slsDump))
+	req.Header.Set("Content-Type", "application/json")
+	if rec := serveImport(t, backend, req); rec.Code != http.StatusOK || len(storedNodes(t, backend)) != 2 {
+		t.Errorf("SLS POST status = %d, body %s; want 2 nodes written", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandler_RejectsBadRequests(t *testing.T) {
+	backend := newTestBackend(t)
+
+	for _, target := range []string{
+		Path,                           // no format
+		Path + "?format=xlsx",          // unknown format
+		Path + "?format=csv&map=xname", // malformed mapping
+		Path + "?format=csv&dryRun=maybe",
+	} {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader("xname\nx1000c0s0b0n0\n"))
+		if rec := serveImport(t, backend, req); rec.Code != http.StatusBadRequest {
+			t.Errorf("POST %s status = %d, want 400", target, rec.Code)
+		}
+	}
+}
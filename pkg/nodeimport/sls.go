@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package nodeimport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// slsNodeType is the SLS hardware type of compute, application, and
+// management nodes
+const slsNodeType = "comptype_node"
+
+// slsHardware is an SLS hardware entry
+type slsHardware struct {
+	Xname           string            `json:"Xname"`
+	Type            string            `json:"Type"`
+	Class           string            `json:"Class,omitempty"`
+	ExtraProperties slsNodeProperties `json:"ExtraProperties"`
+}
+
+// slsNodeProperties are the ExtraProperties of a comptype_node entry
+type slsNodeProperties struct {
+	NID     *int64   `json:"NID,omitempty"`
+	Role    string   `json:"Role,omitempty"`
+	SubRole string   `json:"SubRole,omitempty"`
+	Aliases []string `json:"Aliases,omitempty"`
+}
+
+// parseSLS reads the node entries of an SLS dump: either a full dumpstate
+// document, whose Hardware object is keyed by xname, or the array returned
+// by GET /v1/hardware. Entries of other hardware types are ignored. SLS
+// holds no MAC addresses, so imported nodes keep their current ones.
+func parseSLS(in io.Reader, report *Report) ([]Entry, error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return nil, fmt.Errorf("reading SLS dump: %w", err)
+	}
+
+	var hardware []slsHardware
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case len(trimmed) == 0:
+		return nil, errors.New("empty SLS dump")
+	case trimmed[0] == '[':
+		if err := json.Unmarshal(trimmed, &hardware); err != nil {
+			return nil, fmt.Errorf("decoding SLS hardware list: %w", err)
+		}
+	default:
+		var dump struct {
+			Hardware map[string]slsHardware `json:"Hardware"`
+		}
+		if err := json.Unmarshal(trimmed, &dump); err != nil {
+			return nil, fmt.Errorf("decoding SLS dump: %w", err)
+		}
+		if dump.Hardware == nil {
+			return nil, errors.New("SLS dump has no Hardware object")
+		}
+		keys := make([]string, 0, len(dump.Hardware))
+		for key := range dump.Hardware {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			entry := dump.Hardware[key]
+			if entry.Xname == "" {
+				entry.Xname = key
+			}
+			hardware = append(hardware, entry)
+		}
+	}
+
+	var entries []Entry
+	ignored := 0
+	for _, hw := range hardware {
+		if hw.Type != slsNodeType {
+			ignored++
+			continue
+		}
+		props := hw.ExtraProperties
+		entry := Entry{
+			Location: "hardware " + hw.Xname,
+			Values: map[string]string{
+				FieldXName:   hw.Xname,
+				FieldRole:    props.Role,
+				FieldSubRole: props.SubRole,
+			},
+		}
+		if props.NID != nil {
+			entry.Values[FieldNID] = strconv.FormatInt(*props.NID, 10)
+		}
+		if len(props.Aliases) > 0 {
+			entry.Values[FieldHostname] = props.Aliases[0]
+		}
+		if hw.Class != "" {
+			entry.Values[metadataPrefix+"slsClass"] = hw.Class
+		}
+		entries = append(entries, entry)
+	}
+	if ignored > 0 {
+		report.warnf("ignored %d SLS hardware entries that are not nodes", ignored)
+	}
+	return entries, nil
+}
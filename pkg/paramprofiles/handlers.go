@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package paramprofiles
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Handler serves the parameter profile API
+type Handler struct {
+	store *Store
+}
+
+// NewHandler creates a parameter profile API handler
+func NewHandler(store *Store) *Handler {
+	return &Handler{store: store}
+}
+
+// RegisterRoutes registers the parameter profile routes at /parameterprofiles.
+// Every tenant's boot configurations use the profiles, so tenants may read
+// but not change them.
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route("/parameterprofiles", func(r chi.Router) {
+		r.Get("/", h.ListProfiles)
+		r.Get("/{name}", h.GetProfile)
+		r.Group(func(r chi.Router) {
+			r.Use(tenancy.Unscoped)
+			r.Post("/", h.PutProfile)
+			r.Put("/{name}", h.PutProfile)
+			r.Delete("/{name}", h.DeleteProfile)
+		})
+	})
+}
+
+// ListProfiles handles GET /parameterprofiles
+func (h *Handler) ListProfiles(w http.ResponseWriter, r *http.Request) {
+	profiles, err := h.store.List(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to list parameter profiles", err.Error())
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, profiles)
+}
+
+// GetProfile handles GET /parameterprofiles/{name}
+func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	profile, err := h.store.Get(r.Context(), chi.URLParam(r, "name"))
+	if err != nil {
+		h.writeStoreError(w, "Failed to get parameter profile", err)
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, profile)
+}
+
+// PutProfile handles POST /parameterprofiles and PUT /parameterprofiles/{name}
+func (h *Handler) PutProfile(w http.ResponseWriter, r *http.Request) {
+	var profile Profile
+	if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	status := http.StatusCreated
+	if name := chi.URLParam(r, "name"); name != "" {
+		if profile.Name != "" && profile.Name != name {
+			httputil.WriteError(w, http.StatusBadRequest, "Invalid request body", "parameter profile name does not match URL")
+			return
+		}
+		profile.Name = name
+		status = http.StatusOK
+	}
+
+	if err := profile.Validate(); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid parameter profile", err.Error())
+		return
+	}
+
+	stored, err := h.store.Put(r.Context(), profile)
+	switch {
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrCycle):
+		// A missing or circular include
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid parameter profile", err.Error())
+		return
+	case err != nil:
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to store parameter profile", err.Error())
+		return
+	}
+
+	httputil.WriteJSON(w, status, stored)
+}
+
+// DeleteProfile handles DELETE /parameterprofiles/{name}
+func (h *Handler) DeleteProfile(w http.ResponseWriter, r *http.Request) {
+	if err := h.store.Delete(r.Context(), chi.URLParam(r, "name")); err != nil {
+		h.writeStoreError(w, "Failed to delete parameter profile", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) writeStoreError(w http.ResponseWriter, title string, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		httputil.WriteError(w, http.StatusNotFound, title, err.Error())
+	case errors.Is(err, ErrInUse):
+		httputil.WriteError(w, http.StatusConflict, title, err.Error())
+	default:
+		httputil.WriteError(w, http.StatusInternalServerError, title, err.Error())
+	}
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package paramprofiles stores reusable sets of kernel parameters, such as
+// console settings, hugepages, or NIC naming, that boot configurations and
+// nodes reference by name instead of copying them.
+//
+// A profile may include other profiles. Referenced profiles are merged in a
+// fixed order, each overriding the parameters with the same key before it:
+// a profile's includes come before the profile itself, and profiles come in
+// the order they are referenced.
+package paramprofiles
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// ResourceType is the storage resource type used for parameter profiles
+const ResourceType = "ParameterProfile"
+
+var (
+	// ErrNotFound is returned when a profile name is not stored
+	ErrNotFound = errors.New("parameter profile not found")
+
+	// ErrInUse is returned when deleting a profile another profile
+	// includes, or a boot configuration or node references
+	ErrInUse = errors.New("parameter profile is in use")
+
+	// ErrCycle is returned when profiles include each other
+	ErrCycle = errors.New("parameter profiles include each other")
+
+	namePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]*[a-z0-9])?$`)
+)
+
+// Profile is a named set of kernel parameters
+type Profile struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Params may use the node variables of kernel parameter templates, such
+	// as {{.XName}}
+	Params string `json:"params" yaml:"params"`
+	// Includes names profiles merged before this one
+	Includes  []string  `json:"includes,omitempty" yaml:"includes,omitempty"`
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" yaml:"updatedAt"`
+}
+
+// Validate checks the static fields of a profile
+func (p *Profile) Validate() error {
+	if !ValidName(p.Name) {
+		return fmt.Errorf("invalid parameter profile name %q: use lowercase letters, digits, '.', '_' and '-'", p.Name)
+	}
+	if strings.TrimSpace(p.Params) == "" && len(p.Includes) == 0 {
+		return errors.New("params or includes is required")
+	}
+	if strings.Contains(p.Params, "{{") {
+		if _, err := template.New("params").Funcs(validation.ParamsTemplateFuncs).Parse(p.Params); err != nil {
+			return fmt.Errorf("invalid params template: %w", err)
+		}
+	}
+	for _, name := range p.Includes {
+		if !ValidName(name) {
+			return fmt.Errorf("invalid included profile name %q", name)
+		}
+		if name == p.Name {
+			return fmt.Errorf("%w: %s includes itself", ErrCycle, name)
+		}
+	}
+	return nil
+}
+
+// ValidName reports whether name can be used as a profile name
+func ValidName(name string) bool {
+	return len(name) <= 253 && namePattern.MatchString(name) && !strings.Contains(name, "..")
+}
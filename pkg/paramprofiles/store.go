@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package paramprofiles
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+)
+
+// Store persists parameter profiles
+type Store struct {
+	backend fabricaStorage.StorageBackend
+	logger  *log.Logger
+}
+
+// NewStore creates a profile store persisted in the given storage backend
+func NewStore(backend fabricaStorage.StorageBackend, logger *log.Logger) *Store {
+	return &Store{backend: backend, logger: logger}
+}
+
+// Put validates and stores a profile. Included profiles must exist and may
+// not include it back. Storing an existing name replaces the profile while
+// keeping its creation time.
+func (s *Store) Put(ctx context.Context, profile Profile) (*Profile, error) {
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	profiles, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	profile.CreatedAt = now
+	if existing, ok := profiles[profile.Name]; ok {
+		profile.CreatedAt = existing.CreatedAt
+	}
+	profile.UpdatedAt = now
+
+	profiles[profile.Name] = &profile
+	if _, err := order([]string{profile.Name}, profiles); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return nil, fmt.Errorf("encoding parameter profile %s: %w", profile.Name, err)
+	}
+	if err := s.backend.Save(ctx, ResourceType, profile.Name, data); err != nil {
+		return nil, fmt.Errorf("saving parameter profile %s: %w", profile.Name, err)
+	}
+
+	s.logger.Printf("Stored parameter profile %s", profile.Name)
+	return &profile, nil
+}
+
+// Get returns the profile stored under name
+func (s *Store) Get(ctx context.Context, name string) (*Profile, error) {
+	if !ValidName(name) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	data, err := s.backend.Load(ctx, ResourceType, name)
+	if err != nil {
+		if errors.Is(err, fabricaStorage.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		return nil, fmt.Errorf("loading parameter profile %s: %w", name, err)
+	}
+
+	var profile Profile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("decoding parameter profile %s: %w", name, err)
+	}
+	return &profile, nil
+}
+
+// List returns all profiles sorted by name
+func (s *Store) List(ctx context.Context) ([]Profile, error) {
+	profiles, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Profile, 0, len(profiles))
+	for _, profile := range profiles {
+		result = append(result, *profile)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// Delete removes a profile that no other profile includes and no boot
+// configuration or node references
+func (s *Store) Delete(ctx context.Context, name string) error {
+	profiles, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := profiles[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	for _, profile := range profiles {
+		if slices.Contains(profile.Includes, name) {
+			return fmt.Errorf("%w: %s is included by %s", ErrInUse, name, profile.Name)
+		}
+	}
+	if err := s.checkReferences(ctx, name); err != nil {
+		return err
+	}
+
+	if err := s.backend.Delete(ctx, ResourceType, name); err != nil {
+		return fmt.Errorf("deleting parameter profile %s: %w", name, err)
+	}
+	return nil
+}
+
+// ResolveParamProfiles returns the params of the named profiles and the
+// profiles they include, in merge order. It implements
+// bootscript.ParamProfileResolver.
+func (s *Store) ResolveParamProfiles(ctx context.Context, names []string) ([]string, error) {
+	profiles, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ordered, err := order(names, profiles)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make([]string, 0, len(ordered))
+	for _, profile := range ordered {
+		if profile.Params != "" {
+			params = append(params, profile.Params)
+		}
+	}
+	return params, nil
+}
+
+// referrers are the resource types whose specs name profiles in
+// paramProfiles, with how each is called in errors
+var referrers = []struct{ resourceType, noun string }{
+	{"BootConfiguration", "boot configuration"},
+	{"Node", "node"},
+}
+
+// referrer is the part of a stored boot configuration or node that names
+// it and the profiles it references
+type referrer struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		XName         string   `json:"xname"`
+		ParamProfiles []string `json:"paramProfiles"`
+	} `json:"spec"`
+}
+
+// checkReferences returns ErrInUse if a boot configuration or node
+// references the named profile
+func (s *Store) checkReferences(ctx context.Context, name string) error {
+	for _, r := range referrers {
+		rawData, err := s.backend.LoadAll(ctx, r.resourceType)
+		if err != nil {
+			return fmt.Errorf("loading %ss: %w", r.noun, err)
+		}
+		for _, data := range rawData {
+			var ref referrer
+			if json.Unmarshal(data, &ref) != nil || !slices.Contains(ref.Spec.ParamProfiles, name) {
+				continue
+			}
+			return fmt.Errorf("%w: %s is referenced by %s %s", ErrInUse, name, r.noun, cmp.Or(ref.Spec.XName, ref.Metadata.Name))
+		}
+	}
+	return nil
+}
+
+// load returns every stored profile by name
+func (s *Store) load(ctx context.Context) (map[string]*Profile, error) {
+	rawData, err := s.backend.LoadAll(ctx, ResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("loading parameter profiles: %w", err)
+	}
+
+	profiles := make(map[string]*Profile, len(rawData))
+	for _, data := range rawData {
+		var profile Profile
+		if err := json.Unmarshal(data, &profile); err != nil {
+			return nil, fmt.Errorf("decoding parameter profile: %w", err)
+		}
+		profiles[profile.Name] = &profile
+	}
+	return profiles, nil
+}
+
+// order returns the named profiles in merge order: each profile after the
+// profiles it includes, and each profile once, at its first position
+func order(names []string, profiles map[string]*Profile) ([]*Profile, error) {
+	var result []*Profile
+	done := map[string]bool{}
+	visiting := map[string]bool{}
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if done[name] {
+			return nil
+		}
+		if visiting[name] {
+			return fmt.Errorf("%w: %s", ErrCycle, name)
+		}
+		profile, ok := profiles[name]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		visiting[name] = true
+		for _, include := range profile.Includes {
+			if err := visit(include); err != nil {
+				return err
+			}
+		}
+		delete(visiting, name)
+		done[name] = true
+		result = append(result, profile)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,243 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package paramprofiles
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	return NewStore(backend, log.New(io.Discard, "", 0))
+}
+
+func mustPut(t *testing.T, store *Store, profile Profile) {
+	t.Helper()
+	if _, err := store.Put(context.Background(), profile); err != nil {
+		t.Fatalf("Put(%s) failed: %v", profile.Name, err)
+	}
+}
+
+func TestStore_ResolveMergeOrder(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	mustPut(t, store, Profile{Name: "serial", Params: "console=ttyS0,115200"})
+	mustPut(t, store, Profile{Name: "hugepages", Params: "hugepagesz=2M hugepages=1024"})
+	mustPut(t, store, Profile{Name: "debug", Params: "loglevel=7", Includes: []string{"serial"}})
+	mustPut(t, store, Profile{Name: "compute", Params: "quiet", Includes: []string{"serial", "hugepages"}})
+
+	got, err := store.ResolveParamProfiles(ctx, []string{"debug", "compute"})
+	if err != nil {
+		t.Fatalf("ResolveParamProfiles failed: %v", err)
+	}
+	// Includes come first and serial is only merged once
+	want := []string{"console=ttyS0,115200", "loglevel=7", "hugepagesz=2M hugepages=1024", "quiet"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveParamProfiles = %q, want %q", got, want)
+	}
+
+	if _, err := store.ResolveParamProfiles(ctx, []string{"missing"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ResolveParamProfiles(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestStore_PutRejectsBadIncludes(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	if _, err := store.Put(ctx, Profile{Name: "a", Includes: []string{"b"}}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Put with a missing include error = %v, want ErrNotFound", err)
+	}
+
+	mustPut(t, store, Profile{Name: "a", Params: "quiet"})
+	mustPut(t, store, Profile{Name: "b", Params: "debug", Includes: []string{"a"}})
+	if _, err := store.Put(ctx, Profile{Name: "a", Params: "quiet", Includes: []string{"b"}}); !errors.Is(err, ErrCycle) {
+		t.Errorf("Put with a circular include error = %v, want ErrCycle", err)
+	}
+
+	// The rejected write left the stored profile unchanged
+	profile, err := store.Get(ctx, "a")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if len(profile.Includes) != 0 {
+		t.Errorf("Includes = %v after rejected write, want none", profile.Includes)
+	}
+
+	if err := store.Delete(ctx, "a"); !errors.Is(err, ErrInUse) {
+		t.Errorf("Delete of an included profile error = %v, want ErrInUse", err)
+	}
+}
+
+func TestStore_DeleteReferencedProfile(t *testing.T) {
+	ctx := context.Background()
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	store := NewStore(backend, log.New(io.Discard, "", 0))
+	mustPut(t, store, Profile{Name: "serial", Params: "console=ttyS0,115200"})
+	mustPut(t, store, Profile{Name: "debug", Params: "debug"})
+
+	config := `{"metadata":{"name":"compute","uid":"bc-1"},"spec":{"kernel":"http://files.example.com/vmlinuz","paramProfiles":["serial"]}}`
+	node := `{"metadata":{"name":"x0c0s0b0n0","uid":"nod-1"},"spec":{"xname":"x0c0s0b0n0","paramProfiles":["debug"]}}`
+	if err := backend.Save(ctx, "BootConfiguration", "bc-1", []byte(config)); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Save(ctx, "Node", "nod-1", []byte(node)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Delete(ctx, "serial"); !errors.Is(err, ErrInUse) {
+		t.Errorf("Delete of a profile a boot configuration references error = %v, want ErrInUse", err)
+	}
+	if err := store.Delete(ctx, "debug"); !errors.Is(err, ErrInUse) {
+		t.Errorf("Delete of a profile a node references error = %v, want ErrInUse", err)
+	}
+
+	if err := backend.Delete(ctx, "Node", "nod-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete(ctx, "debug"); err != nil {
+		t.Errorf("Delete of an unreferenced profile failed: %v", err)
+	}
+}
+
+func TestProfileValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile Profile
+		wantErr bool
+	}{
+		{"Valid", Profile{Name: "serial-console", Params: "console=ttyS0,115200"}, false},
+		{"IncludesOnly", Profile{Name: "compute", Includes: []string{"serial"}}, false},
+		{"Template", Profile{Name: "nic", Params: "ifname=mgmt:{{.BootMAC}}"}, false},
+		{"BadName", Profile{Name: "Serial Console", Params: "quiet"}, true},
+		{"Empty", Profile{Name: "empty"}, true},
+		{"BadTemplate", Profile{Name: "nic", Params: "ifname={{.BootMAC"}, true},
+		{"SelfInclude", Profile{Name: "loop", Params: "quiet", Includes: []string{"loop"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.profile.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandler_PutAndDelete(t *testing.T) {
+	store := newTestStore(t)
+	r := chi.NewRouter()
+	NewHandler(store).RegisterRoutes(r)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	put := func(path string, profile Profile) int {
+		body, _ := json.Marshal(profile)
+		req, _ := http.NewRequest(http.MethodPut, server.URL+path, bytes.NewReader(body))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("PUT %s failed: %v", path, err)
+		}
+		resp.Body.Close() //nolint:errcheck
+		return resp.StatusCode
+	}
+
+	if status := put("/parameterprofiles/serial", Profile{Params: "console=ttyS0,115200"}); status != http.StatusOK {
+		t.Fatalf("PUT /parameterprofiles/serial returned %d, want %d", status, http.StatusOK)
+	}
+	if status := put("/parameterprofiles/compute", Profile{Includes: []string{"missing"}}); status != http.StatusBadRequest {
+		t.Errorf("PUT with a missing include returned %d, want %d", status, http.StatusBadRequest)
+	}
+	if status := put("/parameterprofiles/compute", Profile{Includes: []string{"serial"}}); status != http.StatusOK {
+		t.Fatalf("PUT /parameterprofiles/compute returned %d, want %d", status, http.StatusOK)
+	}
+
+	resp, err := http.Get(server.URL + "/parameterprofiles")
+	if err != nil {
+		t.Fatalf("GET /parameterprofiles failed: %v", err)
+	}
+	var profiles []Profile
+	if err := json.NewDecoder(resp.Body).Decode(&profiles); err != nil {
+		t.Fatalf("failed to decode profiles: %v", err)
+	}
+	resp.Body.Close() //nolint:errcheck
+	if len(profiles) != 2 || profiles[0].Name != "compute" || profiles[1].Name != "serial" {
+		t.Errorf("GET /parameterprofiles = %+v, want compute and serial", profiles)
+	}
+
+	del := func(name string) int {
+		req, _ := http.NewRequest(http.MethodDelete, server.URL+"/parameterprofiles/"+name, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("DELETE %s failed: %v", name, err)
+		}
+		resp.Body.Close() //nolint:errcheck
+		return resp.StatusCode
+	}
+	if status := del("serial"); status != http.StatusConflict {
+		t.Errorf("DELETE of an included profile returned %d, want %d", status, http.StatusConflict)
+	}
+	if status := del("compute"); status != http.StatusNoContent {
+		t.Errorf("DELETE /parameterprofiles/compute returned %d, want %d", status, http.StatusNoContent)
+	}
+	if status := del("compute"); status != http.StatusNotFound {
+		t.Errorf("second DELETE returned %d, want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestHandler_TenantWritesRefused(t *testing.T) {
+	store := newTestStore(t)
+	mustPut(t, store, Profile{Name: "serial", Params: "console=ttyS0,115200"})
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(tenancy.WithTenant(r.Context(), "red")))
+		})
+	})
+	NewHandler(store).RegisterRoutes(r)
+
+	serve := func(method, path, body string) int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewReader([]byte(body))))
+		return w.Code
+	}
+	if status := serve(http.MethodGet, "/parameterprofiles/serial", ""); status != http.StatusOK {
+		t.Errorf("tenant GET returned %d, want %d", status, http.StatusOK)
+	}
+	if status := serve(http.MethodPut, "/parameterprofiles/serial", `{"params":"console=ttyS1"}`); status != http.StatusForbidden {
+		t.Errorf("tenant PUT returned %d, want %d", status, http.StatusForbidden)
+	}
+	if status := serve(http.MethodDelete, "/parameterprofiles/serial", ""); status != http.StatusForbidden {
+		t.Errorf("tenant DELETE returned %d, want %d", status, http.StatusForbidden)
+	}
+	profile, err := store.Get(context.Background(), "serial")
+	if err != nil || profile.Params != "console=ttyS0,115200" {
+		t.Errorf("profile after tenant writes = %+v, %v; want it unchanged", profile, err)
+	}
+}
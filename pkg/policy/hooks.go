@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package policy
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/admission"
+)
+
+// AdmissionHook is an admission.Hook that evaluates resource writes. The
+// policy input is the admission.Request, with the resource in input.object
+// and the one it replaces in input.oldObject.
+type AdmissionHook struct {
+	opa      *OPA
+	path     string
+	failOpen bool
+	logger   *log.Logger
+}
+
+// NewAdmissionHook evaluates writes with the rule at path. With failOpen,
+// writes are allowed when OPA cannot be reached.
+func NewAdmissionHook(opa *OPA, path string, failOpen bool, logger *log.Logger) *AdmissionHook {
+	if logger == nil {
+		logger = log.New(log.Writer(), "policy: ", log.LstdFlags)
+	}
+	return &AdmissionHook{opa: opa, path: path, failOpen: failOpen, logger: logger}
+}
+
+// Name returns the hook's name
+func (h *AdmissionHook) Name() string {
+	return "opa"
+}
+
+// Admit evaluates req
+func (h *AdmissionHook) Admit(ctx context.Context, req admission.Request) (admission.Response, error) {
+	denials, err := h.opa.Denials(ctx, h.path, req)
+	if err != nil {
+		if h.failOpen {
+			h.logger.Printf("Policy evaluation failed, allowing %s of %s %s: %v", req.Operation, req.Kind, req.UID, err)
+			return admission.Response{Allowed: true}, nil
+		}
+		return admission.Response{}, err
+	}
+	if len(denials) > 0 {
+		return admission.Response{Reason: strings.Join(denials, "; ")}, nil
+	}
+	return admission.Response{Allowed: true}, nil
+}
+
+// BootInput is the policy input for a boot script request
+type BootInput struct {
+	Node          *apiv1.Node              `json:"node"`
+	Configuration *apiv1.BootConfiguration `json:"configuration"` // with artifact references resolved
+	Profile       string                   `json:"profile,omitempty"`
+	Time          time.Time                `json:"time"`
+}
+
+// BootPolicy is a bootscript.BootPolicy that evaluates which configuration a
+// node may boot
+type BootPolicy struct {
+	opa      *OPA
+	path     string
+	failOpen bool
+	logger   *log.Logger
+	now      func() time.Time
+}
+
+// NewBootPolicy evaluates boot script requests with the rule at path. With
+// failOpen, boots are allowed when OPA cannot be reached; otherwise the node
+// is served the fallback script and retries.
+func NewBootPolicy(opa *OPA, path string, failOpen bool, logger *log.Logger) *BootPolicy {
+	if logger == nil {
+		logger = log.New(log.Writer(), "policy: ", log.LstdFlags)
+	}
+	return &BootPolicy{opa: opa, path: path, failOpen: failOpen, logger: logger, now: time.Now}
+}
+
+// BootDenials evaluates whether node may boot config
+func (p *BootPolicy) BootDenials(ctx context.Context, node *apiv1.Node, config *apiv1.BootConfiguration, profile string) ([]string, error) {
+	input := BootInput{Node: node, Configuration: config, Profile: profile, Time: p.now().UTC()}
+	denials, err := p.opa.Denials(ctx, p.path, input)
+	if err != nil && p.failOpen {
+		p.logger.Printf("Policy evaluation failed, allowing boot of node %s: %v", node.Spec.XName, err)
+		return nil, nil
+	}
+	return denials, err
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package policy evaluates Open Policy Agent (OPA) policies for resource
+// writes and boot script requests.
+//
+// Policies run in an OPA server queried through its data API. Each decision
+// is a rule that collects denial messages, in the style of
+//
+//	package bootservice.admission
+//
+//	deny contains msg if {
+//		input.kind == "BootConfiguration"
+//		not startswith(input.object.spec.kernel, "https://images.example.com/")
+//		msg := "kernels must come from images.example.com"
+//	}
+//
+// An empty or undefined rule allows the request.
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxResponseSize bounds the size of an OPA response body
+const maxResponseSize = 1 << 20
+
+// OPA queries an OPA server's data API
+type OPA struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewOPA creates a client for the OPA server at baseURL, e.g.
+// http://localhost:8181
+func NewOPA(baseURL string, timeout time.Duration) *OPA {
+	return &OPA{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Denials evaluates the rule at path, e.g. "bootservice/admission/deny", with
+// input and returns its denial messages. A rule that is true rather than a
+// set of messages denies with a generic message.
+func (o *OPA) Denials(ctx context.Context, path string, input any) ([]string, error) {
+	body, err := json.Marshal(map[string]any{"input": input})
+	if err != nil {
+		return nil, err
+	}
+	url := o.baseURL + "/v1/data/" + strings.Trim(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OPA returned %s for %s", resp.Status, path)
+	}
+
+	var decision struct {
+		Result any `json:"result"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&decision); err != nil {
+		return nil, fmt.Errorf("decoding OPA response for %s: %w", path, err)
+	}
+
+	switch result := decision.Result.(type) {
+	case nil:
+		// The rule is undefined for this input
+		return nil, nil
+	case bool:
+		if result {
+			return []string{"denied by policy " + path}, nil
+		}
+		return nil, nil
+	case []any:
+		denials := make([]string, 0, len(result))
+		for _, item := range result {
+			if message, ok := item.(string); ok {
+				denials = append(denials, message)
+			} else {
+				encoded, _ := json.Marshal(item)
+				denials = append(denials, string(encoded))
+			}
+		}
+		return denials, nil
+	default:
+		return nil, fmt.Errorf("OPA rule %s returned %T, want a set of messages or a boolean", path, result)
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/admission"
+)
+
+// newFakeOPA serves fixed results per rule path and records the last input
+func newFakeOPA(t *testing.T, results map[string]string, lastInput *map[string]any) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Input map[string]any `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if lastInput != nil {
+			*lastInput = body.Input
+		}
+		result, ok := results[strings.TrimPrefix(r.URL.Path, "/v1/data/")]
+		if !ok {
+			http.Error(w, "no such policy", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(result)) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOPA_Denials(t *testing.T) {
+	server := newFakeOPA(t, map[string]string{
+		"set":       `{"result":["kernel not approved","params frozen"]}`,
+		"empty":     `{"result":[]}`,
+		"undefined": `{}`,
+		"true":      `{"result":true}`,
+		"false":     `{"result":false}`,
+		"object":    `{"result":{"allow":true}}`,
+	}, nil)
+	opa := NewOPA(server.URL+"/", time.Second)
+
+	tests := []struct {
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{path: "set", want: []string{"kernel not approved", "params frozen"}},
+		{path: "empty"},
+		{path: "undefined"},
+		{path: "true", want: []string{"denied by policy true"}},
+		{path: "false"},
+		{path: "object", wantErr: true},
+		{path: "missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		denials, err := opa.Denials(context.Background(), tt.path, map[string]any{})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Denials(%s) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			continue
+		}
+		if strings.Join(denials, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("Denials(%s) = %q, want %q", tt.path, denials, tt.want)
+		}
+	}
+}
+
+func TestAdmissionHook(t *testing.T) {
+	var input map[string]any
+	server := newFakeOPA(t, map[string]string{
+		"bootservice/admission/deny": `{"result":["kernels must come from images.example.com"]}`,
+	}, &input)
+	logger := log.New(io.Discard, "", 0)
+
+	hook := NewAdmissionHook(NewOPA(server.URL, time.Second), "bootservice/admission/deny", false, logger)
+	resp, err := hook.Admit(context.Background(), admission.Request{
+		Operation: admission.Create,
+		Kind:      "BootConfiguration",
+		Object:    json.RawMessage(`{"spec":{"kernel":"http://evil.example.com/vmlinuz"}}`),
+	})
+	if err != nil || resp.Allowed || resp.Reason != "kernels must come from images.example.com" {
+		t.Errorf("Admit() = %+v, %v", resp, err)
+	}
+	if object, _ := input["object"].(map[string]any); object == nil || input["kind"] != "BootConfiguration" {
+		t.Errorf("policy input = %v", input)
+	}
+
+	unreachable := NewOPA("http://127.0.0.1:1", time.Second)
+	if _, err := NewAdmissionHook(unreachable, "x", false, logger).Admit(context.Background(), admission.Request{}); err == nil {
+		t.Error("expected error when OPA is unreachable")
+	}
+	if resp, err := NewAdmissionHook(unreachable, "x", true, logger).Admit(context.Background(), admission.Request{}); err != nil || !resp.Allowed {
+		t.Errorf("fail-open Admit() = %+v, %v", resp, err)
+	}
+}
+
+func TestBootPolicy(t *testing.T) {
+	var input map[string]any
+	server := newFakeOPA(t, map[string]string{
+		"bootservice/bootscript/deny": `{"result":["kernel has no registered checksum"]}`,
+	}, &input)
+
+	policy := NewBootPolicy(NewOPA(server.URL, time.Second), "bootservice/bootscript/deny", false, log.New(io.Discard, "", 0))
+	node := &apiv1.Node{Spec: apiv1.NodeSpec{XName: "x1000c0s0b0n0"}}
+	config := &apiv1.BootConfiguration{Spec: apiv1.BootConfigurationSpec{Kernel: "http://images.example.com/vmlinuz"}}
+
+	denials, err := policy.BootDenials(context.Background(), node, config, "debug")
+	if err != nil || len(denials) != 1 {
+		t.Fatalf("BootDenials() = %q, %v", denials, err)
+	}
+	if input["profile"] != "debug" || input["node"] == nil || input["configuration"] == nil || input["time"] == nil {
+		t.Errorf("policy input = %v", input)
+	}
+}
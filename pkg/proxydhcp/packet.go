@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package proxydhcp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+)
+
+// BOOTP operations
+const (
+	opRequest = 1
+	opReply   = 2
+)
+
+// DHCP message types (option 53)
+const (
+	msgDiscover = 1
+	msgOffer    = 2
+	msgRequest  = 3
+	msgAck      = 5
+)
+
+// DHCP options
+const (
+	optPad             = 0
+	optVendorSpecific  = 43
+	optMessageType     = 53
+	optServerID        = 54
+	optVendorClass     = 60
+	optUserClass       = 77
+	optClientArch      = 93
+	optClientMachineID = 97
+	optEnd             = 255
+)
+
+// pxeDiscoveryControl is PXE vendor sub-option 6. Bit 3 tells the client to
+// download the boot file named in the reply rather than run boot server
+// discovery.
+const (
+	pxeDiscoveryControl = 6
+	pxeUseBootFile      = 0x08
+)
+
+const (
+	headerSize = 236
+	fileSize   = 128
+)
+
+var magicCookie = []byte{99, 130, 83, 99}
+
+// packet is a BOOTP message with DHCP options
+type packet struct {
+	op     byte
+	xid    uint32
+	flags  uint16
+	ciaddr net.IP
+	siaddr net.IP
+	giaddr net.IP
+	chaddr net.HardwareAddr
+	file   string
+	// options holds option values by code, and order the codes in the order
+	// they are written
+	options map[byte][]byte
+	order   []byte
+}
+
+// parsePacket decodes a BOOTP message and its DHCP options
+func parsePacket(data []byte) (*packet, error) {
+	if len(data) < headerSize+len(magicCookie) || !bytes.Equal(data[headerSize:headerSize+4], magicCookie) {
+		return nil, errors.New("not a DHCP message")
+	}
+	hlen := int(data[2])
+	if hlen > 16 {
+		return nil, errors.New("invalid hardware address length")
+	}
+	p := &packet{
+		op:      data[0],
+		xid:     binary.BigEndian.Uint32(data[4:8]),
+		flags:   binary.BigEndian.Uint16(data[10:12]),
+		ciaddr:  net.IP(append([]byte(nil), data[12:16]...)),
+		siaddr:  net.IP(append([]byte(nil), data[20:24]...)),
+		giaddr:  net.IP(append([]byte(nil), data[24:28]...)),
+		chaddr:  net.HardwareAddr(append([]byte(nil), data[28:28+hlen]...)),
+		file:    string(bytes.TrimRight(data[108:108+fileSize], "\x00")),
+		options: map[byte][]byte{},
+	}
+	for rest := data[headerSize+4:]; len(rest) > 0; {
+		code := rest[0]
+		if code == optEnd {
+			break
+		}
+		if code == optPad {
+			rest = rest[1:]
+			continue
+		}
+		if len(rest) < 2 || len(rest) < 2+int(rest[1]) {
+			return nil, errors.New("truncated option")
+		}
+		// Repeated options are concatenated (RFC 3396)
+		p.setOption(code, append(p.options[code], rest[2:2+rest[1]]...))
+		rest = rest[2+rest[1]:]
+	}
+	return p, nil
+}
+
+// setOption sets the value of an option
+func (p *packet) setOption(code byte, value []byte) {
+	if _, ok := p.options[code]; !ok {
+		p.order = append(p.order, code)
+	}
+	p.options[code] = value
+}
+
+// marshal encodes the packet
+func (p *packet) marshal() []byte {
+	data := make([]byte, headerSize, headerSize+64)
+	data[0] = p.op
+	data[1] = 1 // Ethernet
+	data[2] = byte(len(p.chaddr))
+	binary.BigEndian.PutUint32(data[4:], p.xid)
+	binary.BigEndian.PutUint16(data[10:], p.flags)
+	copy(data[12:16], p.ciaddr.To4())
+	copy(data[20:24], p.siaddr.To4())
+	copy(data[24:28], p.giaddr.To4())
+	copy(data[28:44], p.chaddr)
+	copy(data[108:108+fileSize-1], p.file)
+	data = append(data, magicCookie...)
+	for _, code := range p.order {
+		value := p.options[code]
+		for len(value) > 255 {
+			data = append(append(data, code, 255), value[:255]...)
+			value = value[255:]
+		}
+		data = append(append(data, code, byte(len(value))), value...)
+	}
+	return append(data, optEnd)
+}
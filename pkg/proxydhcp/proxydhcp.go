@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package proxydhcp answers PXE clients as a ProxyDHCP server (PXE 2.1):
+// alongside a DHCP server that hands out addresses, it tells the nodes in
+// the node store where to load their boot file from. Firmware PXE clients
+// are sent the iPXE binary for their architecture over TFTP; iPXE clients
+// are sent the boot script URL.
+package proxydhcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/tftp"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// Ports a ProxyDHCP server listens on: the DHCP server port, where it sees
+// the broadcast discover, and the PXE boot server port clients may send a
+// request to
+const (
+	DHCPPort = 67
+	PXEPort  = 4011
+
+	clientPort = 68
+)
+
+// IndexTTL bounds how long node store changes take to reach the responder
+const IndexTTL = 10 * time.Second
+
+// NodeLister lists the nodes answered. client.API implements it.
+type NodeLister interface {
+	GetNodes(ctx context.Context) ([]v1.Node, error)
+}
+
+// Config configures the responder
+type Config struct {
+	// ServerIP is the IPv4 address of this service, sent as the next server
+	// to load boot files from and as the DHCP server identifier
+	ServerIP net.IP
+	// BootFiles are the binaries served over TFTP by client architecture
+	BootFiles *tftp.BootFiles
+	// ScriptURL is the boot script URL sent to clients already running
+	// iPXE; iPXE expands settings such as ${net0/mac} in it
+	ScriptURL string
+}
+
+// Server is a ProxyDHCP responder
+type Server struct {
+	nodes  NodeLister
+	config Config
+	logger *log.Logger
+
+	mu       sync.Mutex
+	known    map[string]bool
+	loadedAt time.Time
+}
+
+// NewServer creates a responder answering the nodes listed by nodes
+func NewServer(nodes NodeLister, config Config, logger *log.Logger) *Server {
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	return &Server{nodes: nodes, config: config, logger: logger}
+}
+
+// Serve answers PXE requests received on conn until it is closed. conn must
+// allow broadcasts to reach clients without an address yet.
+func (s *Server) Serve(conn net.PacketConn) error {
+	buf := make([]byte, 1500)
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		req, err := parsePacket(buf[:n])
+		if err != nil {
+			continue
+		}
+		reply, ok := s.reply(context.Background(), req)
+		if !ok {
+			continue
+		}
+		if _, err := conn.WriteTo(reply.marshal(), replyAddr(req, from)); err != nil {
+			s.logger.Printf("Failed to answer %s: %v", req.chaddr, err)
+		}
+	}
+}
+
+// replyAddr is where the reply to req goes: back to a relay agent, to a
+// client that already has an address, or broadcast
+func replyAddr(req *packet, from net.Addr) net.Addr {
+	switch {
+	case !req.giaddr.IsUnspecified():
+		return &net.UDPAddr{IP: req.giaddr, Port: DHCPPort}
+	case !req.ciaddr.IsUnspecified():
+		return from
+	}
+	return &net.UDPAddr{IP: net.IPv4bcast, Port: clientPort}
+}
+
+// reply builds the answer to a PXE discover or request from a known node,
+// or reports false when the request is not one to answer
+func (s *Server) reply(ctx context.Context, req *packet) (*packet, bool) {
+	if req.op != opRequest || len(req.chaddr) != 6 || !strings.HasPrefix(string(req.options[optVendorClass]), "PXEClient") {
+		return nil, false
+	}
+	var replyType byte
+	switch msgType := req.options[optMessageType]; {
+	case bytes.Equal(msgType, []byte{msgDiscover}):
+		replyType = msgOffer
+	case bytes.Equal(msgType, []byte{msgRequest}):
+		replyType = msgAck
+	default:
+		return nil, false
+	}
+
+	mac := req.chaddr.String()
+	known, err := s.knownMAC(ctx, mac)
+	if err != nil {
+		s.logger.Printf("Failed to list nodes: %v", err)
+		return nil, false
+	}
+	if !known {
+		return nil, false
+	}
+
+	file, ok := s.bootFile(req)
+	if !ok {
+		s.logger.Printf("No boot file for %s (architecture %d)", mac, clientArch(req))
+		return nil, false
+	}
+
+	reply := &packet{
+		op:      opReply,
+		xid:     req.xid,
+		flags:   req.flags,
+		ciaddr:  req.ciaddr,
+		siaddr:  s.config.ServerIP,
+		giaddr:  req.giaddr,
+		chaddr:  req.chaddr,
+		file:    file,
+		options: map[byte][]byte{},
+	}
+	reply.setOption(optMessageType, []byte{replyType})
+	reply.setOption(optServerID, s.config.ServerIP.To4())
+	reply.setOption(optVendorClass, []byte("PXEClient"))
+	if id, ok := req.options[optClientMachineID]; ok {
+		reply.setOption(optClientMachineID, id)
+	}
+	reply.setOption(optVendorSpecific, []byte{pxeDiscoveryControl, 1, pxeUseBootFile, optEnd})
+	s.logger.Printf("Sent %s to %s", file, mac)
+	return reply, true
+}
+
+// bootFile returns the file a client loads next: the boot script for iPXE,
+// or the binary for its architecture
+func (s *Server) bootFile(req *packet) (string, bool) {
+	if string(bytes.TrimRight(req.options[optUserClass], "\x00")) == "iPXE" && s.config.ScriptURL != "" {
+		return s.config.ScriptURL, true
+	}
+	if s.config.BootFiles == nil {
+		return "", false
+	}
+	return s.config.BootFiles.Name(clientArch(req))
+}
+
+// clientArch returns the client architecture of req, 0 (x86 BIOS) when the
+// client sent none
+func clientArch(req *packet) uint16 {
+	if arch := req.options[optClientArch]; len(arch) >= 2 {
+		return binary.BigEndian.Uint16(arch)
+	}
+	return 0
+}
+
+// knownMAC reports whether mac is the boot MAC or an interface of a node in
+// the node store, listed at most every IndexTTL
+func (s *Server) knownMAC(ctx context.Context, mac string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.known == nil || time.Since(s.loadedAt) > IndexTTL {
+		nodes, err := s.nodes.GetNodes(ctx)
+		if err != nil {
+			return false, fmt.Errorf("listing nodes: %w", err)
+		}
+		s.known = map[string]bool{}
+		for _, node := range nodes {
+			if node.Spec.BootMAC != "" {
+				s.known[validation.NormalizeMAC(node.Spec.BootMAC)] = true
+			}
+			for _, iface := range node.Spec.Interfaces {
+				s.known[validation.NormalizeMAC(iface.MAC)] = true
+			}
+		}
+		s.loadedAt = time.Now()
+	}
+	return s.known[validation.NormalizeMAC(mac)], nil
+}
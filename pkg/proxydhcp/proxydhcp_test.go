@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package proxydhcp
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/tftp"
+)
+
+type testLister []v1.Node
+
+func (l testLister) GetNodes(context.Context) ([]v1.Node, error) {
+	return l, nil
+}
+
+func testServer(t *testing.T) *Server {
+	t.Helper()
+	files, err := tftp.ParseBootFiles([]string{"0=/srv/undionly.kpxe", "7=/srv/ipxe.efi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var node v1.Node
+	node.Spec.XName = "x1000c0s0b0n0"
+	node.Spec.BootMAC = "AA-BB-CC-DD-EE-01"
+	return NewServer(testLister{node}, Config{
+		ServerIP:  net.IPv4(10, 0, 0, 1),
+		BootFiles: files,
+		ScriptURL: "http://10.0.0.1:8080/bootscript?mac=${net0/mac}",
+	}, nil)
+}
+
+// discover builds a PXE client's DHCPDISCOVER
+func discover(mac string, arch uint16, userClass string) *packet {
+	hw, _ := net.ParseMAC(mac)
+	p := &packet{
+		op: opRequest, xid: 0x1234, chaddr: hw,
+		ciaddr: net.IPv4zero, siaddr: net.IPv4zero, giaddr: net.IPv4zero,
+		options: map[byte][]byte{},
+	}
+	p.setOption(optMessageType, []byte{msgDiscover})
+	p.setOption(optClientArch, []byte{byte(arch >> 8), byte(arch)})
+	p.setOption(optVendorClass, []byte("PXEClient:Arch:00007:UNDI:003016"))
+	p.setOption(optClientMachineID, []byte{0, 1, 2, 3})
+	if userClass != "" {
+		p.setOption(optUserClass, []byte(userClass))
+	}
+	return p
+}
+
+func TestReply(t *testing.T) {
+	server := testServer(t)
+
+	// The packet survives encoding, as it would on the wire
+	req, err := parsePacket(discover("aa:bb:cc:dd:ee:01", 7, "").marshal())
+	if err != nil {
+		t.Fatalf("parsePacket failed: %v", err)
+	}
+	reply, ok := server.reply(context.Background(), req)
+	if !ok {
+		t.Fatal("no reply to a known node")
+	}
+	if reply.file != "ipxe.efi" || !reply.siaddr.Equal(net.IPv4(10, 0, 0, 1)) || reply.xid != 0x1234 {
+		t.Errorf("reply = file %q, next server %s, xid %x", reply.file, reply.siaddr, reply.xid)
+	}
+	if !bytes.Equal(reply.options[optMessageType], []byte{msgOffer}) ||
+		string(reply.options[optVendorClass]) != "PXEClient" ||
+		!bytes.Equal(reply.options[optClientMachineID], []byte{0, 1, 2, 3}) {
+		t.Errorf("reply options = %v", reply.options)
+	}
+	// No address is offered; the DHCP server does that
+	encoded := reply.marshal()
+	if !net.IP(encoded[16:20]).Equal(net.IPv4zero) {
+		t.Errorf("reply offers address %s", net.IP(encoded[16:20]))
+	}
+	if decoded, err := parsePacket(encoded); err != nil || decoded.file != "ipxe.efi" {
+		t.Errorf("reply decodes to %+v, %v", decoded, err)
+	}
+
+	if reply, _ := server.reply(context.Background(), discover("aa:bb:cc:dd:ee:01", 0, "")); reply.file != "undionly.kpxe" {
+		t.Errorf("BIOS client file = %q, want undionly.kpxe", reply.file)
+	}
+	if reply, _ := server.reply(context.Background(), discover("aa:bb:cc:dd:ee:01", 7, "iPXE")); reply.file != server.config.ScriptURL {
+		t.Errorf("iPXE client file = %q, want the boot script URL", reply.file)
+	}
+
+	request := discover("aa:bb:cc:dd:ee:01", 7, "")
+	request.setOption(optMessageType, []byte{msgRequest})
+	if reply, ok := server.reply(context.Background(), request); !ok || !bytes.Equal(reply.options[optMessageType], []byte{msgAck}) {
+		t.Errorf("request answered with %v, want an ack", reply)
+	}
+}
+
+func TestReply_Ignored(t *testing.T) {
+	server := testServer(t)
+
+	notPXE := discover("aa:bb:cc:dd:ee:01", 7, "")
+	notPXE.setOption(optVendorClass, []byte("MSFT 5.0"))
+	tests := map[string]*packet{
+		"unknown node":          discover("aa:bb:cc:dd:ee:99", 7, ""),
+		"not a PXE client":      notPXE,
+		"unmapped architecture": discover("aa:bb:cc:dd:ee:01", 11, ""),
+	}
+	for name, req := range tests {
+		if reply, ok := server.reply(context.Background(), req); ok {
+			t.Errorf("%s: answered with %q", name, reply.file)
+		}
+	}
+}
+
+func TestServe(t *testing.T) {
+	server := testServer(t)
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()    //nolint:errcheck
+	go server.Serve(conn) //nolint:errcheck
+
+	// A client with an address, as on the PXE port, is answered directly
+	client, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close() //nolint:errcheck
+	req := discover("aa:bb:cc:dd:ee:01", 7, "")
+	req.setOption(optMessageType, []byte{msgRequest})
+	req.ciaddr = net.IPv4(127, 0, 0, 1)
+	if _, err := client.WriteTo(req.marshal(), conn.LocalAddr()); err != nil {
+		t.Fatal(err)
+	}
+	client.SetReadDeadline(time.Now().Add(2 * time.Second)) //nolint:errcheck
+	buf := make([]byte, 1500)
+	n, _, err := client.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("no reply: %v", err)
+	}
+	reply, err := parsePacket(buf[:n])
+	if err != nil || reply.op != opReply || reply.file != "ipxe.efi" {
+		t.Errorf("reply = %+v, %v", reply, err)
+	}
+}
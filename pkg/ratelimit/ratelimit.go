@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package ratelimit provides global and per-client-IP token bucket rate
+// limiting for HTTP endpoints that are hit hard during boot storms.
+package ratelimit
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/openchami/boot-service/internal/httputil"
+)
+
+// idleTimeout is how long a client IP's bucket is kept after its last request
+const idleTimeout = 5 * time.Minute
+
+// Config sets the token bucket limits. A rate of zero disables that limit.
+type Config struct {
+	// Rate is the sustained number of requests per second for all clients
+	Rate float64
+	// Burst is the number of requests allowed at once above Rate
+	Burst int
+	// PerIPRate is the sustained number of requests per second per client IP
+	PerIPRate float64
+	// PerIPBurst is the number of requests one client IP may make at once
+	PerIPBurst int
+}
+
+// Enabled reports whether any limit is configured
+func (c Config) Enabled() bool {
+	return c.Rate > 0 || c.PerIPRate > 0
+}
+
+// Limiter enforces a global and a per-client-IP request rate
+type Limiter struct {
+	mu        sync.Mutex
+	config    Config
+	global    *rate.Limiter
+	clients   map[string]*client
+	lastSweep time.Time
+	now       func() time.Time
+
+	rejected atomic.Uint64
+}
+
+type client struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewLimiter creates a limiter with the given limits
+func NewLimiter(config Config) *Limiter {
+	l := &Limiter{clients: map[string]*client{}, now: time.Now}
+	l.SetConfig(config)
+	return l
+}
+
+// SetConfig replaces the limits. Client buckets start over with the new limits.
+func (l *Limiter) SetConfig(config Config) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.config = config
+	l.global = newBucket(config.Rate, config.Burst)
+	l.clients = map[string]*client{}
+}
+
+// Rejected returns the number of requests refused so far
+func (l *Limiter) Rejected() uint64 {
+	return l.rejected.Load()
+}
+
+// Allow reports whether a request from ip may proceed now. When it may not,
+// it also returns how long the client should wait before retrying.
+func (l *Limiter) Allow(ip string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+
+	// Check the client's own bucket first so one noisy client cannot drain
+	// the global bucket for everyone else
+	var clientToken *rate.Reservation
+	if l.config.PerIPRate > 0 {
+		c, ok := l.clients[ip]
+		if !ok {
+			c = &client{limiter: newBucket(l.config.PerIPRate, l.config.PerIPBurst)}
+			l.clients[ip] = c
+		}
+		c.lastSeen = now
+		clientToken = c.limiter.ReserveN(now, 1)
+		if wait := clientToken.DelayFrom(now); wait > 0 {
+			clientToken.CancelAt(now)
+			return l.reject(wait)
+		}
+	}
+	if l.global != nil {
+		token := l.global.ReserveN(now, 1)
+		if wait := token.DelayFrom(now); wait > 0 {
+			token.CancelAt(now)
+			if clientToken != nil {
+				// The request is refused, so it does not count against the client
+				clientToken.CancelAt(now)
+			}
+			return l.reject(wait)
+		}
+	}
+
+	l.sweep(now)
+	return true, 0
+}
+
+func (l *Limiter) reject(wait time.Duration) (bool, time.Duration) {
+	l.rejected.Add(1)
+	return false, wait
+}
+
+// sweep drops the buckets of clients that have been idle for a while
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleTimeout {
+		return
+	}
+	l.lastSweep = now
+	for ip, c := range l.clients {
+		if now.Sub(c.lastSeen) > idleTimeout {
+			delete(l.clients, ip)
+		}
+	}
+}
+
+// Middleware refuses requests over the limits with 429 Too Many Requests and
+// a Retry-After header. The client IP is taken from RemoteAddr.
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if allowed, wait := l.Allow(clientIP(r)); !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			httputil.WriteError(w, http.StatusTooManyRequests, "Too Many Requests",
+				"Request rate limit exceeded; retry later")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// newBucket returns a token bucket, or nil when the rate is zero
+func newBucket(limit float64, burst int) *rate.Limiter {
+	if limit <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(limit), max(burst, 1))
+}
+
+// clientIP returns the host part of the request's remote address
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestLimiter returns a limiter whose clock only moves when advanced
+func newTestLimiter(config Config) (*Limiter, func(time.Duration)) {
+	now := time.Unix(1700000000, 0)
+	l := NewLimiter(config)
+	l.now = func() time.Time { return now }
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestLimiter_PerIP(t *testing.T) {
+	l, advance := newTestLimiter(Config{PerIPRate: 1, PerIPBurst: 2})
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow("10.0.0.1"); !ok {
+			t.Fatalf("request %d within burst refused", i)
+		}
+	}
+	ok, wait := l.Allow("10.0.0.1")
+	if ok {
+		t.Fatal("request over burst allowed")
+	}
+	if wait <= 0 || wait > time.Second {
+		t.Errorf("retry after %s, want (0, 1s]", wait)
+	}
+
+	// Another client has its own bucket
+	if ok, _ := l.Allow("10.0.0.2"); !ok {
+		t.Error("request from a different client refused")
+	}
+
+	advance(time.Second)
+	if ok, _ := l.Allow("10.0.0.1"); !ok {
+		t.Error("request refused after the bucket refilled")
+	}
+	if got := l.Rejected(); got != 1 {
+		t.Errorf("Rejected() = %d, want 1", got)
+	}
+}
+
+func TestLimiter_Global(t *testing.T) {
+	l, _ := newTestLimiter(Config{Rate: 10, Burst: 3, PerIPRate: 100, PerIPBurst: 100})
+
+	allowed := 0
+	for i := 0; i < 6; i++ {
+		if ok, _ := l.Allow(fmt.Sprintf("10.0.0.%d", i+1)); ok {
+			allowed++
+		}
+	}
+	if allowed != 3 {
+		t.Errorf("allowed %d requests across clients, want the global burst of 3", allowed)
+	}
+}
+
+func TestLimiter_Disabled(t *testing.T) {
+	l, _ := newTestLimiter(Config{})
+	if l.config.Enabled() {
+		t.Fatal("zero config reported as enabled")
+	}
+	for i := 0; i < 1000; i++ {
+		if ok, _ := l.Allow("10.0.0.1"); !ok {
+			t.Fatalf("request %d refused with limits disabled", i)
+		}
+	}
+}
+
+func TestLimiter_SetConfig(t *testing.T) {
+	l, _ := newTestLimiter(Config{PerIPRate: 1, PerIPBurst: 1})
+	l.Allow("10.0.0.1")
+	if ok, _ := l.Allow("10.0.0.1"); ok {
+		t.Fatal("request over burst allowed")
+	}
+
+	l.SetConfig(Config{})
+	if ok, _ := l.Allow("10.0.0.1"); !ok {
+		t.Error("request refused after limits were disabled")
+	}
+}
+
+func TestLimiter_Middleware(t *testing.T) {
+	l, _ := newTestLimiter(Config{PerIPRate: 0.5, PerIPBurst: 1})
+	handler := l.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	statuses := make([]int, 2)
+	var last *httptest.ResponseRecorder
+	for i := range statuses {
+		req := httptest.NewRequest(http.MethodGet, "/bootscript?mac=aa:bb:cc:dd:ee:ff", nil)
+		req.RemoteAddr = "192.0.2.10:40000"
+		last = httptest.NewRecorder()
+		handler.ServeHTTP(last, req)
+		statuses[i] = last.Code
+	}
+	if statuses[0] != http.StatusOK || statuses[1] != http.StatusTooManyRequests {
+		t.Fatalf("got statuses %v, want [200 429]", statuses)
+	}
+	if got := last.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Retry-After = %q, want 2", got)
+	}
+}
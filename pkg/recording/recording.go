@@ -0,0 +1,243 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package recording captures legacy BSS API exchanges so they can be replayed
+// against a real BSS instance to compare responses before cutover.
+//
+// A Recorder is HTTP middleware that appends each request and the response
+// it produced to a JSON Lines file. Credentials are dropped and secret-looking
+// values redacted before anything is written. Replay sends recorded requests
+// to another server and reports where its responses differ.
+package recording
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxBodyBytes bounds how much of each request and response body is recorded
+const MaxBodyBytes = 1 << 20
+
+// Redacted replaces secret values in recordings
+const Redacted = "REDACTED"
+
+// Exchange is one recorded request and the response it was served
+type Exchange struct {
+	Time            time.Time         `json:"time"`
+	Method          string            `json:"method"`
+	URL             string            `json:"url"` // path and query
+	RequestHeaders  map[string]string `json:"requestHeaders,omitempty"`
+	RequestBody     string            `json:"requestBody,omitempty"`
+	Status          int               `json:"status"`
+	ResponseHeaders map[string]string `json:"responseHeaders,omitempty"`
+	ResponseBody    string            `json:"responseBody"`
+	// Truncated is set when a body exceeded MaxBodyBytes; such exchanges
+	// cannot be compared byte for byte
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// recordedHeaders are the headers kept in recordings; others, including
+// Authorization and Cookie, are dropped
+var recordedHeaders = []string{"Content-Type", "Accept"}
+
+// secretName matches parameter and field names whose values are redacted
+var secretName = regexp.MustCompile(`(?i)token|secret|password|passwd|key`)
+
+var (
+	// name=value pairs in kernel parameters and query strings
+	secretAssignment = regexp.MustCompile(`(?i)([\w.-]*(?:token|secret|password|passwd|key)[\w.-]*=)[^\s&"']+`)
+	// "name": "value" pairs in JSON
+	secretField = regexp.MustCompile(`(?i)("[\w.-]*(?:token|secret|password|passwd|key)[\w.-]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// Sanitize redacts secret-looking values in a recorded body
+func Sanitize(body string) string {
+	body = secretAssignment.ReplaceAllString(body, "${1}"+Redacted)
+	return secretField.ReplaceAllString(body, `${1}"`+Redacted+`"`)
+}
+
+// sanitizeURL redacts query parameters with secret-looking names
+func sanitizeURL(u *url.URL) string {
+	query := u.Query()
+	for name, values := range query {
+		if secretName.MatchString(name) {
+			for i := range values {
+				values[i] = Redacted
+			}
+		}
+	}
+	result := u.Path
+	if len(query) > 0 {
+		result += "?" + query.Encode()
+	}
+	return result
+}
+
+func recordHeaders(header http.Header) map[string]string {
+	var kept map[string]string
+	for _, name := range recordedHeaders {
+		if value := header.Get(name); value != "" {
+			if kept == nil {
+				kept = map[string]string{}
+			}
+			kept[name] = value
+		}
+	}
+	return kept
+}
+
+// Recorder appends exchanges to a file
+type Recorder struct {
+	mu     sync.Mutex
+	file   *os.File
+	logger *log.Logger
+
+	// redact hides values Sanitize cannot recognize, such as resolved
+	// kernel parameter secrets
+	redact func(body string) string
+}
+
+// NewRecorder appends recordings to the file at path, creating it if needed
+func NewRecorder(path string, logger *log.Logger) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening recording file: %w", err)
+	}
+	if logger == nil {
+		logger = log.New(log.Writer(), "recording: ", log.LstdFlags)
+	}
+	return &Recorder{file: file, logger: logger}, nil
+}
+
+// SetRedactor redacts recorded bodies with redact, after Sanitize. Call it
+// before the recorder is used.
+func (r *Recorder) SetRedactor(redact func(body string) string) {
+	r.redact = redact
+}
+
+// sanitize redacts secret-looking and known secret values in a body
+func (r *Recorder) sanitize(body string) string {
+	body = Sanitize(body)
+	if r.redact != nil {
+		body = r.redact(body)
+	}
+	return body
+}
+
+// Close closes the recording file
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// Record appends an exchange
+func (r *Recorder) Record(exchange Exchange) error {
+	line, err := json.Marshal(exchange)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err = r.file.Write(append(line, '\n'))
+	return err
+}
+
+// Middleware records every request served by next
+func (r *Recorder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		exchange := Exchange{
+			Time:           time.Now().UTC(),
+			Method:         req.Method,
+			URL:            sanitizeURL(req.URL),
+			RequestHeaders: recordHeaders(req.Header),
+		}
+		if req.Body != nil {
+			body, err := io.ReadAll(io.LimitReader(req.Body, MaxBodyBytes+1))
+			if err != nil {
+				http.Error(w, "failed to read request body", http.StatusBadRequest)
+				return
+			}
+			// Hand the handler the whole body, including any part not recorded
+			req.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), req.Body), req.Body}
+			if len(body) > MaxBodyBytes {
+				body = body[:MaxBodyBytes]
+				exchange.Truncated = true
+			}
+			exchange.RequestBody = r.sanitize(string(body))
+		}
+
+		capture := &capturingWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(capture, req)
+
+		exchange.Status = capture.status
+		exchange.ResponseHeaders = recordHeaders(w.Header())
+		exchange.ResponseBody = r.sanitize(capture.body.String())
+		exchange.Truncated = exchange.Truncated || capture.truncated
+		if err := r.Record(exchange); err != nil {
+			r.logger.Printf("Failed to record %s %s: %v", exchange.Method, exchange.URL, err)
+		}
+	})
+}
+
+// capturingWriter keeps a copy of the status and the first MaxBodyBytes of
+// a response
+type capturingWriter struct {
+	http.ResponseWriter
+	status    int
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (w *capturingWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *capturingWriter) Write(p []byte) (int, error) {
+	if room := MaxBodyBytes - w.body.Len(); room < len(p) {
+		w.body.Write(p[:max(room, 0)])
+		w.truncated = true
+	} else {
+		w.body.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// ReadExchanges reads a recording file
+func ReadExchanges(r io.Reader) ([]Exchange, error) {
+	var exchanges []Exchange
+	decoder := json.NewDecoder(r)
+	for {
+		var exchange Exchange
+		if err := decoder.Decode(&exchange); err == io.EOF {
+			return exchanges, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("reading exchange %d: %w", len(exchanges)+1, err)
+		}
+		exchanges = append(exchanges, exchange)
+	}
+}
+
+// isWrite reports whether replaying the exchange changes the target's state
+func (e Exchange) isWrite() bool {
+	switch strings.ToUpper(e.Method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
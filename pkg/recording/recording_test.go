@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package recording
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"console=ttyS0 api_token=abc123 quiet", "console=ttyS0 api_token=REDACTED quiet"},
+		{`{"params":"x","password": "hunter2"}`, `{"params":"x","password": "REDACTED"}`},
+		{`{"apiKey":"a\"b","kernel":"vmlinuz"}`, `{"apiKey":"REDACTED","kernel":"vmlinuz"}`},
+		{"nothing secret", "nothing secret"},
+	}
+	for _, tt := range tests {
+		if got := Sanitize(tt.in); got != tt.want {
+			t.Errorf("Sanitize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRecorder_Redactor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legacy.jsonl")
+	recorder, err := NewRecorder(path, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	recorder.SetRedactor(func(body string) string {
+		return strings.ReplaceAll(body, "correct horse", "[redacted:luks]")
+	})
+	handler := recorder.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "#!ipxe\nkernel vmlinuz rd.luks.passphrase=correct horse\n")
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/boot/v1/bootscript?mac=aa:bb:cc:dd:ee:01", nil))
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close() //nolint:errcheck
+	exchanges, err := ReadExchanges(file)
+	if err != nil || len(exchanges) != 1 {
+		t.Fatalf("ReadExchanges = %d exchanges, %v; want 1", len(exchanges), err)
+	}
+	if body := exchanges[0].ResponseBody; strings.Contains(body, "correct horse") || !strings.Contains(body, "[redacted:luks]") {
+		t.Errorf("recorded body = %q, want the secret redacted", body)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legacy.jsonl")
+	recorder, err := NewRecorder(path, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	local := http.NewServeMux()
+	local.HandleFunc("GET /boot/v1/bootscript", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "#!ipxe\nkernel vmlinuz token=s3cret\n")
+	})
+	local.HandleFunc("GET /boot/v1/bootparameters", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[{"hosts":["x0"],"kernel":"vmlinuz"}]`)
+	})
+	local.HandleFunc("POST /boot/v1/bootparameters", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "hunter2") {
+			t.Errorf("handler got a sanitized body: %s", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	server := httptest.NewServer(recorder.Middleware(local))
+	defer server.Close()
+
+	for _, req := range []struct{ method, path, body string }{
+		{http.MethodGet, "/boot/v1/bootscript?mac=aa:bb:cc:dd:ee:01&access_token=xyz", ""},
+		{http.MethodGet, "/boot/v1/bootparameters", ""},
+		{http.MethodPost, "/boot/v1/bootparameters", `{"hosts":["x0"],"password":"hunter2"}`},
+	} {
+		httpReq, _ := http.NewRequest(req.method, server.URL+req.path, strings.NewReader(req.body))
+		httpReq.Header.Set("Authorization", "Bearer secret")
+		resp, err := http.DefaultClient.Do(httpReq)
+		if err != nil {
+			t.Fatalf("%s %s: %v", req.method, req.path, err)
+		}
+		_ = resp.Body.Close()
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+	exchanges, err := ReadExchanges(file)
+	if err != nil {
+		t.Fatalf("ReadExchanges: %v", err)
+	}
+	if len(exchanges) != 3 {
+		t.Fatalf("recorded %d exchanges, want 3", len(exchanges))
+	}
+	if got := exchanges[0].URL; got != "/boot/v1/bootscript?access_token=REDACTED&mac=aa%3Abb%3Acc%3Add%3Aee%3A01" {
+		t.Errorf("URL = %q", got)
+	}
+	if got := exchanges[0].ResponseBody; got != "#!ipxe\nkernel vmlinuz token=REDACTED\n" {
+		t.Errorf("ResponseBody = %q", got)
+	}
+	if _, ok := exchanges[2].RequestHeaders["Authorization"]; ok || strings.Contains(exchanges[2].RequestBody, "hunter2") {
+		t.Errorf("credentials recorded: %+v", exchanges[2])
+	}
+	if exchanges[2].Status != http.StatusCreated {
+		t.Errorf("Status = %d, want 201", exchanges[2].Status)
+	}
+
+	// The target formats bootparameters differently and serves another kernel
+	target := http.NewServeMux()
+	target.HandleFunc("GET /boot/v1/bootscript", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "#!ipxe\nkernel vmlinuz token=other\n")
+	})
+	target.HandleFunc("GET /boot/v1/bootparameters", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "[{\"kernel\": \"vmlinuz\", \"hosts\": [\"x0\"]}]\n")
+	})
+	bss := httptest.NewServer(target)
+	defer bss.Close()
+
+	summary := Replay(context.Background(), exchanges, ReplayOptions{BaseURL: bss.URL})
+	outcomes := []string{summary.Results[0].Outcome, summary.Results[1].Outcome, summary.Results[2].Outcome}
+	if want := []string{Match, Equivalent, Skipped}; strings.Join(outcomes, ",") != strings.Join(want, ",") {
+		t.Errorf("outcomes = %v, want %v", outcomes, want)
+	}
+	if !summary.Compatible() {
+		t.Errorf("expected the replay compatible: %+v", summary.Counts)
+	}
+
+	summary = Replay(context.Background(), exchanges, ReplayOptions{BaseURL: bss.URL, IncludeWrites: true})
+	if summary.Results[2].Outcome != Mismatch || summary.Compatible() {
+		t.Errorf("expected the unhandled write to mismatch: %+v", summary.Results[2])
+	}
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package recording
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Outcomes of replaying an exchange
+const (
+	// Match means the status and body are byte-for-byte identical
+	Match = "match"
+	// Equivalent means the status matches and both bodies are JSON that
+	// decode to the same value, differing only in formatting or key order
+	Equivalent = "equivalent"
+	Mismatch   = "mismatch"
+	// Skipped exchanges were not sent: writes without IncludeWrites, and
+	// exchanges with truncated bodies
+	Skipped = "skipped"
+	Failed  = "failed" // the request could not be sent
+)
+
+// ReplayOptions configures Replay
+type ReplayOptions struct {
+	// BaseURL is the server requests are sent to, such as http://bss:27778
+	BaseURL string
+	// Token is sent as a bearer token when set
+	Token string
+	// IncludeWrites also replays POST, PUT, PATCH, and DELETE requests,
+	// which change the target's state
+	IncludeWrites bool
+	Client        *http.Client
+}
+
+// Result compares one recorded exchange with the target's response
+type Result struct {
+	Method         string `json:"method"`
+	URL            string `json:"url"`
+	Outcome        string `json:"outcome"`
+	RecordedStatus int    `json:"recordedStatus"`
+	Status         int    `json:"status,omitempty"`
+	// Body is the target's response body, included unless it matched
+	Body  string `json:"body,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Summary counts results by outcome
+type Summary struct {
+	Results []Result       `json:"results"`
+	Counts  map[string]int `json:"counts"`
+}
+
+// Compatible reports whether no exchange mismatched or failed
+func (s *Summary) Compatible() bool {
+	return s.Counts[Mismatch] == 0 && s.Counts[Failed] == 0
+}
+
+// Replay sends the recorded requests to opts.BaseURL in order and compares
+// the responses with the recorded ones
+func Replay(ctx context.Context, exchanges []Exchange, opts ReplayOptions) *Summary {
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	baseURL := strings.TrimRight(opts.BaseURL, "/")
+
+	summary := &Summary{Results: make([]Result, 0, len(exchanges)), Counts: map[string]int{}}
+	for _, exchange := range exchanges {
+		result := Result{Method: exchange.Method, URL: exchange.URL, RecordedStatus: exchange.Status}
+		switch {
+		case exchange.Truncated:
+			result.Outcome = Skipped
+			result.Error = "body was truncated when recorded"
+		case exchange.isWrite() && !opts.IncludeWrites:
+			result.Outcome = Skipped
+		default:
+			replayExchange(ctx, client, baseURL, opts.Token, exchange, &result)
+		}
+		summary.Counts[result.Outcome]++
+		summary.Results = append(summary.Results, result)
+	}
+	return summary
+}
+
+func replayExchange(ctx context.Context, client *http.Client, baseURL, token string, exchange Exchange, result *Result) {
+	var body io.Reader
+	if exchange.RequestBody != "" {
+		body = strings.NewReader(exchange.RequestBody)
+	}
+	req, err := http.NewRequestWithContext(ctx, exchange.Method, baseURL+exchange.URL, body)
+	if err != nil {
+		result.Outcome, result.Error = Failed, err.Error()
+		return
+	}
+	for name, value := range exchange.RequestHeaders {
+		req.Header.Set(name, value)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Outcome, result.Error = Failed, err.Error()
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxBodyBytes))
+	if err != nil {
+		result.Outcome, result.Error = Failed, fmt.Sprintf("reading response: %v", err)
+		return
+	}
+
+	result.Status = resp.StatusCode
+	// Recorded bodies are sanitized, so compare against the sanitized reply
+	got := Sanitize(string(data))
+	switch {
+	case resp.StatusCode != exchange.Status:
+		result.Outcome = Mismatch
+	case got == exchange.ResponseBody:
+		result.Outcome = Match
+	case jsonEqual(got, exchange.ResponseBody):
+		result.Outcome = Equivalent
+	default:
+		result.Outcome = Mismatch
+	}
+	if result.Outcome != Match {
+		result.Body = got
+	}
+}
+
+// jsonEqual reports whether a and b are JSON documents with the same value
+func jsonEqual(a, b string) bool {
+	var left, right any
+	if json.Unmarshal([]byte(a), &left) != nil || json.Unmarshal([]byte(b), &right) != nil {
+		return false
+	}
+	return reflect.DeepEqual(left, right)
+}
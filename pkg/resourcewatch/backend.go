@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package resourcewatch reports writes to stored resources.
+//
+// Backend wraps the Fabrica storage backend and calls subscribers after every
+// successful save or delete, with the stored data before and after the write.
+// Because generated handlers, legacy endpoints, and provider sync all persist
+// through the same backend, subscribers see every change regardless of which
+// API made it.
+//
+// When storage is shared by several replicas, Follow also publishes the
+// writes other replicas make, as reported by the storage itself.
+package resourcewatch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+)
+
+// Event types
+const (
+	Created = "created"
+	Updated = "updated"
+	Deleted = "deleted"
+)
+
+// Event describes a committed write to a stored resource
+type Event struct {
+	Type         string
+	ResourceType string // storage resource type, e.g. "Node" or "BootConfiguration"
+	UID          string
+	Old          json.RawMessage // nil for creates
+	New          json.RawMessage // nil for deletes
+}
+
+// Subscriber is called synchronously after a write is committed
+type Subscriber func(ctx context.Context, event Event)
+
+// Backend is a storage backend that notifies subscribers of writes
+type Backend struct {
+	fabricaStorage.StorageBackend
+
+	mu          sync.RWMutex
+	subscribers []Subscriber
+
+	// pending holds the writes made through the backend while following
+	// shared storage, so the storage's report of them is not published
+	// twice
+	pendingMu sync.Mutex
+	following bool
+	pending   map[pendingKey][]*pendingWrite
+}
+
+// Source reports every write to shared storage, by any replica
+type Source interface {
+	Watch(ctx context.Context, fn Subscriber) error
+}
+
+type pendingKey struct {
+	resourceType string
+	uid          string
+}
+
+type pendingWrite struct {
+	data json.RawMessage // nil for deletes
+	at   time.Time
+}
+
+// pendingTTL is how long a write's own report is waited for before it is
+// forgotten
+const pendingTTL = time.Minute
+
+type remoteKey struct{}
+
+// Remote reports whether an event published to ctx was a write made by
+// another replica, which subscribers such as the audit log already handled
+// there
+func Remote(ctx context.Context) bool {
+	remote, _ := ctx.Value(remoteKey{}).(bool)
+	return remote
+}
+
+// NewBackend wraps backend
+func NewBackend(backend fabricaStorage.StorageBackend) *Backend {
+	return &Backend{StorageBackend: backend}
+}
+
+// Subscribe registers fn to be called for every write
+func (b *Backend) Subscribe(fn Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers = append(b.subscribers, fn)
+}
+
+// Follow publishes the writes source reports that were not made through the
+// backend, with a context marked Remote, until ctx is done
+func (b *Backend) Follow(ctx context.Context, source Source) error {
+	b.pendingMu.Lock()
+	b.following = true
+	b.pending = map[pendingKey][]*pendingWrite{}
+	b.pendingMu.Unlock()
+
+	return source.Watch(ctx, func(ctx context.Context, event Event) {
+		if b.own(event) {
+			return
+		}
+		b.publish(context.WithValue(ctx, remoteKey{}, true), event)
+	})
+}
+
+// expect records a write about to be made through the backend while
+// following shared storage, and returns nil otherwise
+func (b *Backend) expect(resourceType, uid string, data json.RawMessage) *pendingWrite {
+	b.pendingMu.Lock()
+	defer b.pendingMu.Unlock()
+	if !b.following {
+		return nil
+	}
+	key := pendingKey{resourceType, uid}
+	write := &pendingWrite{data: data, at: time.Now()}
+	b.pending[key] = append(b.pending[key], write)
+	return write
+}
+
+// forget drops a recorded write that failed
+func (b *Backend) forget(resourceType, uid string, write *pendingWrite) {
+	if write == nil {
+		return
+	}
+	b.pendingMu.Lock()
+	defer b.pendingMu.Unlock()
+	b.drop(pendingKey{resourceType, uid}, func(w *pendingWrite) bool { return w == write })
+}
+
+// own reports whether event is the report of a write made through the
+// backend, forgetting the write if so
+func (b *Backend) own(event Event) bool {
+	b.pendingMu.Lock()
+	defer b.pendingMu.Unlock()
+	return b.drop(pendingKey{event.ResourceType, event.UID}, func(w *pendingWrite) bool {
+		return bytes.Equal(w.data, event.New) && (w.data == nil) == (event.New == nil)
+	})
+}
+
+// drop removes the first write of key that match accepts, and writes too old
+// to still be reported. b.pendingMu must be held.
+func (b *Backend) drop(key pendingKey, match func(*pendingWrite) bool) bool {
+	found := false
+	writes := b.pending[key][:0]
+	for _, w := range b.pending[key] {
+		switch {
+		case !found && match(w):
+			found = true
+		case time.Since(w.at) < pendingTTL:
+			writes = append(writes, w)
+		}
+	}
+	if len(writes) == 0 {
+		delete(b.pending, key)
+	} else {
+		b.pending[key] = writes
+	}
+	return found
+}
+
+// Save stores a resource and notifies subscribers
+func (b *Backend) Save(ctx context.Context, resourceType, uid string, data json.RawMessage) error {
+	old := b.previous(ctx, resourceType, uid)
+	pending := b.expect(resourceType, uid, data)
+	if err := b.StorageBackend.Save(ctx, resourceType, uid, data); err != nil {
+		b.forget(resourceType, uid, pending)
+		return err
+	}
+
+	b.publish(ctx, saveEvent(resourceType, uid, old, data))
+	return nil
+}
+
+// SaveWithVersion stores a resource at a specific API version and notifies subscribers
+func (b *Backend) SaveWithVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, version string) error {
+	old := b.previous(ctx, resourceType, uid)
+	pending := b.expect(resourceType, uid, data)
+	if err := b.StorageBackend.SaveWithVersion(ctx, resourceType, uid, data, version); err != nil {
+		b.forget(resourceType, uid, pending)
+		return err
+	}
+
+	b.publish(ctx, saveEvent(resourceType, uid, old, data))
+	return nil
+}
+
+// Delete removes a resource and notifies subscribers
+func (b *Backend) Delete(ctx context.Context, resourceType, uid string) error {
+	old := b.previous(ctx, resourceType, uid)
+	pending := b.expect(resourceType, uid, nil)
+	if err := b.StorageBackend.Delete(ctx, resourceType, uid); err != nil {
+		b.forget(resourceType, uid, pending)
+		return err
+	}
+
+	b.publish(ctx, Event{Type: Deleted, ResourceType: resourceType, UID: uid, Old: old})
+	return nil
+}
+
+// previous returns the stored data before a write, or nil if there is none
+func (b *Backend) previous(ctx context.Context, resourceType, uid string) json.RawMessage {
+	data, err := b.StorageBackend.Load(ctx, resourceType, uid)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (b *Backend) publish(ctx context.Context, event Event) {
+	b.mu.RLock()
+	subscribers := b.subscribers
+	b.mu.RUnlock()
+
+	for _, fn := range subscribers {
+		fn(ctx, event)
+	}
+}
+
+func saveEvent(resourceType, uid string, old, data json.RawMessage) Event {
+	event := Event{Type: Updated, ResourceType: resourceType, UID: uid, Old: old, New: data}
+	if old == nil {
+		event.Type = Created
+	}
+	return event
+}
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package resourcewatch
+
+import (
+	"context"
+	"testing"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+)
+
+func TestBackend_PublishesWrites(t *testing.T) {
+	inner, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	backend := NewBackend(inner)
+
+	var events []Event
+	backend.Subscribe(func(_ context.Context, event Event) {
+		events = append(events, event)
+	})
+
+	ctx := context.Background()
+	if err := backend.Save(ctx, "Node", "nod-1", []byte(`{"v":1}`)); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := backend.Save(ctx, "Node", "nod-1", []byte(`{"v":2}`)); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := backend.Delete(ctx, "Node", "nod-1"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if err := backend.Delete(ctx, "Node", "nod-1"); err == nil {
+		t.Fatal("expected error deleting a missing resource")
+	}
+
+	want := []struct {
+		typ, old, new string
+	}{
+		{Created, "", `{"v":1}`},
+		{Updated, `{"v":1}`, `{"v":2}`},
+		{Deleted, `{"v":2}`, ""},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		got := events[i]
+		if got.Type != w.typ || got.ResourceType != "Node" || got.UID != "nod-1" ||
+			string(got.Old) != w.old || string(got.New) != w.new {
+			t.Errorf("event %d = {%s %s %s old=%s new=%s}, want {%s Node nod-1 old=%s new=%s}",
+				i, got.Type, got.ResourceType, got.UID, got.Old, got.New, w.typ, w.old, w.new)
+		}
+	}
+}
+
+// fakeSource reports the writes of shared storage when told to
+type fakeSource struct {
+	fn Subscriber
+}
+
+func (s *fakeSource) Watch(_ context.Context, fn Subscriber) error {
+	s.fn = fn
+	return nil
+}
+
+func TestBackend_FollowPublishesRemoteWrites(t *testing.T) {
+	inner, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	backend := NewBackend(inner)
+
+	type published struct {
+		event  Event
+		remote bool
+	}
+	var events []published
+	backend.Subscribe(func(ctx context.Context, event Event) {
+		events = append(events, published{event, Remote(ctx)})
+	})
+	source := &fakeSource{}
+	ctx := context.Background()
+	if err := backend.Follow(ctx, source); err != nil {
+		t.Fatalf("Follow returned error: %v", err)
+	}
+
+	// The storage's report of a local write is not published again
+	if err := backend.Save(ctx, "Node", "nod-1", []byte(`{"v":1}`)); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	source.fn(ctx, Event{Type: Created, ResourceType: "Node", UID: "nod-1", New: []byte(`{"v":1}`)})
+	if err := backend.Delete(ctx, "Node", "nod-1"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	source.fn(ctx, Event{Type: Deleted, ResourceType: "Node", UID: "nod-1", Old: []byte(`{"v":1}`)})
+	// Writes by other replicas are
+	source.fn(ctx, Event{Type: Created, ResourceType: "Node", UID: "nod-2", New: []byte(`{"v":2}`)})
+	source.fn(ctx, Event{Type: Deleted, ResourceType: "Node", UID: "nod-2", Old: []byte(`{"v":2}`)})
+
+	want := []published{
+		{Event{Type: Created, UID: "nod-1"}, false},
+		{Event{Type: Deleted, UID: "nod-1"}, false},
+		{Event{Type: Created, UID: "nod-2"}, true},
+		{Event{Type: Deleted, UID: "nod-2"}, true},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		got := events[i]
+		if got.event.Type != w.event.Type || got.event.UID != w.event.UID || got.remote != w.remote {
+			t.Errorf("event %d = %s %s (remote %v), want %s %s (remote %v)",
+				i, got.event.Type, got.event.UID, got.remote, w.event.Type, w.event.UID, w.remote)
+		}
+	}
+	if len(backend.pending) != 0 {
+		t.Errorf("pending writes left after their reports: %v", backend.pending)
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package resourcewatch
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultWatchBuffer is the number of events a watcher may fall behind by
+// before it is dropped
+const DefaultWatchBuffer = 256
+
+// Hub fans committed writes out to watchers. Subscribe its Publish method to
+// a Backend.
+type Hub struct {
+	buffer int
+
+	mu       sync.Mutex
+	watchers map[*Watcher]struct{}
+	closed   bool
+}
+
+// Watcher receives the events of one resource type. Events is closed when the
+// watcher is stopped or, if it fell more than the hub's buffer behind, dropped.
+type Watcher struct {
+	Events <-chan Event
+
+	hub          *Hub
+	resourceType string
+	events       chan Event
+	dropped      bool
+}
+
+// NewHub creates a hub whose watchers may fall buffer events behind
+func NewHub(buffer int) *Hub {
+	if buffer <= 0 {
+		buffer = DefaultWatchBuffer
+	}
+	return &Hub{buffer: buffer, watchers: map[*Watcher]struct{}{}}
+}
+
+// Watch starts delivering events for resourceType
+func (h *Hub) Watch(resourceType string) *Watcher {
+	events := make(chan Event, h.buffer)
+	w := &Watcher{Events: events, hub: h, resourceType: resourceType, events: events}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(events)
+		return w
+	}
+	h.watchers[w] = struct{}{}
+	return w
+}
+
+// Publish delivers event to the watchers of its resource type without
+// blocking the write that caused it. A watcher too far behind to take the
+// event is dropped, so its client can list again instead of missing changes.
+func (h *Hub) Publish(_ context.Context, event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for w := range h.watchers {
+		if w.resourceType != event.ResourceType {
+			continue
+		}
+		select {
+		case w.events <- event:
+		default:
+			w.dropped = true
+			h.remove(w)
+		}
+	}
+}
+
+// Close stops every watcher, and any started later, so long-running watch
+// requests end when the server shuts down
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for w := range h.watchers {
+		h.remove(w)
+	}
+}
+
+// Len returns the number of active watchers
+func (h *Hub) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.watchers)
+}
+
+// remove closes a watcher's channel. The caller holds h.mu.
+func (h *Hub) remove(w *Watcher) {
+	if _, ok := h.watchers[w]; ok {
+		delete(h.watchers, w)
+		close(w.events)
+	}
+}
+
+// Stop stops delivering events and closes Events
+func (w *Watcher) Stop() {
+	w.hub.mu.Lock()
+	defer w.hub.mu.Unlock()
+	w.hub.remove(w)
+}
+
+// Dropped reports whether the watcher was dropped for falling behind. It is
+// meaningful once Events is closed.
+func (w *Watcher) Dropped() bool {
+	w.hub.mu.Lock()
+	defer w.hub.mu.Unlock()
+	return w.dropped
+}
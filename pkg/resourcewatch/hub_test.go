@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package resourcewatch
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHub(t *testing.T) {
+	ctx := context.Background()
+	hub := NewHub(2)
+	nodes, configs := hub.Watch("Node"), hub.Watch("BootConfiguration")
+
+	hub.Publish(ctx, Event{Type: Created, ResourceType: "Node", UID: "nod-1"})
+	if event := <-nodes.Events; event.UID != "nod-1" {
+		t.Errorf("node watcher got %+v", event)
+	}
+	if len(configs.Events) != 0 {
+		t.Error("boot configuration watcher got a node event")
+	}
+
+	// A watcher more than the buffer behind is dropped
+	for i := 0; i < 3; i++ {
+		hub.Publish(ctx, Event{Type: Updated, ResourceType: "Node", UID: "nod-1"})
+	}
+	for range nodes.Events {
+	}
+	if !nodes.Dropped() {
+		t.Error("expected the lagging watcher to be dropped")
+	}
+	nodes.Stop()
+
+	configs.Stop()
+	if _, ok := <-configs.Events; ok || configs.Dropped() {
+		t.Error("expected a stopped watcher's events to be closed without dropping it")
+	}
+	if hub.Len() != 0 {
+		t.Errorf("hub has %d watchers after all stopped", hub.Len())
+	}
+
+	watcher := hub.Watch("Node")
+	hub.Close()
+	if _, ok := <-watcher.Events; ok {
+		t.Error("expected Close to stop watchers")
+	}
+	if _, ok := <-hub.Watch("Node").Events; ok {
+		t.Error("expected watchers started after Close to be stopped")
+	}
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package rootfs renders the kernel parameters that boot a node from a
+// network root filesystem image, such as a squashfs served over HTTP, with
+// a writable overlay. Each distribution's initramfs spells these parameters
+// differently, so they are produced by a builder registered per distro.
+package rootfs
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Built-in distros
+const (
+	// DistroDracut is dracut's dmsquash-live module, used by RHEL, Rocky,
+	// Fedora, and SUSE initramfs images
+	DistroDracut = "dracut"
+	// DistroDebianLive is Debian and Ubuntu's live-boot
+	DistroDebianLive = "debian-live"
+
+	// DefaultDistro is used when a root filesystem does not name a distro
+	DefaultDistro = DistroDracut
+)
+
+// Overlay modes
+const (
+	// OverlayFS layers a writable tmpfs over the image with overlayfs
+	OverlayFS = "overlayfs"
+	// OverlayReadOnly mounts the image read-only, without an overlay
+	OverlayReadOnly = "readonly"
+)
+
+// ErrUnknownDistro is returned for a distro without a registered builder
+var ErrUnknownDistro = errors.New("unknown root filesystem distro")
+
+// Options describe a network root filesystem
+type Options struct {
+	// URL is the http or https URL of the image
+	URL string
+	// Overlay is OverlayFS (the default when empty) or OverlayReadOnly
+	Overlay string
+	// OverlaySizeMiB limits the writable overlay; 0 leaves the initramfs
+	// default
+	OverlaySizeMiB int
+	// ToRAM copies the image into memory before mounting it
+	ToRAM bool
+}
+
+// Builder returns the kernel parameters that boot a distro from a root
+// filesystem. Options have been checked for the fields every distro shares.
+type Builder func(options Options) ([]string, error)
+
+var (
+	mu       sync.RWMutex
+	builders = map[string]Builder{
+		DistroDracut:     dracut,
+		DistroDebianLive: debianLive,
+	}
+)
+
+// Register adds or replaces the builder of a distro
+func Register(distro string, builder Builder) {
+	mu.Lock()
+	defer mu.Unlock()
+	builders[distro] = builder
+}
+
+// Distros returns the names of the registered distros, sorted
+func Distros() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(builders))
+	for name := range builders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Params returns the kernel parameters that boot distro from the root
+// filesystem described by options, joined by spaces. An empty distro is
+// DefaultDistro.
+func Params(distro string, options Options) (string, error) {
+	if options.URL == "" {
+		return "", errors.New("root filesystem URL is required")
+	}
+	if err := Validate(distro, options); err != nil {
+		return "", err
+	}
+	if distro == "" {
+		distro = DefaultDistro
+	}
+	mu.RLock()
+	builder := builders[distro]
+	mu.RUnlock()
+
+	params, err := builder(options)
+	if err != nil {
+		return "", fmt.Errorf("%s root filesystem: %w", distro, err)
+	}
+	return strings.Join(params, " "), nil
+}
+
+// Validate checks the fields every distro shares and that distro is
+// registered. An empty URL, to be filled in later, is accepted.
+func Validate(distro string, options Options) error {
+	if distro == "" {
+		distro = DefaultDistro
+	}
+	mu.RLock()
+	_, ok := builders[distro]
+	mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w %q (known: %s)", ErrUnknownDistro, distro, strings.Join(Distros(), ", "))
+	}
+
+	if options.URL != "" {
+		parsed, err := url.Parse(options.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("root filesystem URL must be an http(s) URL: %q", options.URL)
+		}
+		if strings.ContainsAny(options.URL, " \t\n") {
+			return fmt.Errorf("root filesystem URL must not contain whitespace: %q", options.URL)
+		}
+	}
+	switch options.Overlay {
+	case "", OverlayFS, OverlayReadOnly:
+	default:
+		return fmt.Errorf("invalid overlay %q (want %s or %s)", options.Overlay, OverlayFS, OverlayReadOnly)
+	}
+	if options.OverlaySizeMiB < 0 {
+		return errors.New("overlay size must not be negative")
+	}
+	if options.Overlay == OverlayReadOnly && options.OverlaySizeMiB > 0 {
+		return errors.New("overlay size cannot be combined with a read-only root")
+	}
+	return nil
+}
+
+// dracut boots with dmsquash-live, which fetches root=live: URLs itself
+func dracut(options Options) ([]string, error) {
+	params := []string{"root=live:" + options.URL}
+	if options.Overlay == OverlayReadOnly {
+		params = append(params, "rd.live.overlay.readonly=1")
+	} else {
+		params = append(params, "rd.live.overlay.overlayfs=1")
+	}
+	if options.OverlaySizeMiB > 0 {
+		params = append(params, fmt.Sprintf("rd.live.overlay.size=%d", options.OverlaySizeMiB))
+	}
+	if options.ToRAM {
+		params = append(params, "rd.live.ram=1")
+	}
+	return params, nil
+}
+
+// debianLive boots with live-boot, which always mounts an overlay
+func debianLive(options Options) ([]string, error) {
+	if options.Overlay == OverlayReadOnly {
+		return nil, errors.New("live-boot cannot mount a read-only root")
+	}
+	params := []string{"boot=live", "fetch=" + options.URL}
+	if options.OverlaySizeMiB > 0 {
+		params = append(params, fmt.Sprintf("overlay-size=%dm", options.OverlaySizeMiB))
+	}
+	if options.ToRAM {
+		params = append(params, "toram")
+	}
+	return params, nil
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package rootfs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParams(t *testing.T) {
+	const image = "http://images.example.com/compute.squashfs"
+	tests := []struct {
+		name    string
+		distro  string
+		options Options
+		want    string
+		wantErr bool
+	}{
+		{"DefaultDistro", "", Options{URL: image}, "root=live:" + image + " rd.live.overlay.overlayfs=1", false},
+		{"DracutSizedToRAM", DistroDracut, Options{URL: image, OverlaySizeMiB: 4096, ToRAM: true},
+			"root=live:" + image + " rd.live.overlay.overlayfs=1 rd.live.overlay.size=4096 rd.live.ram=1", false},
+		{"DracutReadOnly", DistroDracut, Options{URL: image, Overlay: OverlayReadOnly}, "root=live:" + image + " rd.live.overlay.readonly=1", false},
+		{"DebianLive", DistroDebianLive, Options{URL: image, OverlaySizeMiB: 2048, ToRAM: true},
+			"boot=live fetch=" + image + " overlay-size=2048m toram", false},
+		{"DebianLiveReadOnly", DistroDebianLive, Options{URL: image, Overlay: OverlayReadOnly}, "", true},
+		{"NotHTTP", "", Options{URL: "/images/compute.squashfs"}, "", true},
+		{"BadOverlay", "", Options{URL: image, Overlay: "zram"}, "", true},
+		{"ReadOnlySized", "", Options{URL: image, Overlay: OverlayReadOnly, OverlaySizeMiB: 512}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Params(tt.distro, tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Params() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Params() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if _, err := Params("nixos", Options{URL: "http://images.example.com/nixos.squashfs"}); !errors.Is(err, ErrUnknownDistro) {
+		t.Fatalf("Params(nixos) error = %v, want ErrUnknownDistro", err)
+	}
+
+	Register("nixos", func(options Options) ([]string, error) {
+		return []string{"root=live:" + options.URL, "nixos.live=1"}, nil
+	})
+	t.Cleanup(func() {
+		mu.Lock()
+		delete(builders, "nixos")
+		mu.Unlock()
+	})
+
+	got, err := Params("nixos", Options{URL: "http://images.example.com/nixos.squashfs"})
+	if err != nil {
+		t.Fatalf("Params(nixos) failed: %v", err)
+	}
+	if want := "root=live:http://images.example.com/nixos.squashfs nixos.live=1"; got != want {
+		t.Errorf("Params(nixos) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package schedule decides when a scheduled resource, such as a boot
+// configuration limited to maintenance windows, is active.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Window is a recurring period that starts at every time its cron
+// expression matches and lasts for a fixed duration
+type Window struct {
+	expr     string
+	start    cron.Schedule
+	duration time.Duration
+}
+
+// ParseWindow parses a window from a standard five-field cron expression,
+// or a descriptor such as @daily, and a Go duration. Expressions are
+// evaluated in UTC unless prefixed with CRON_TZ=<zone>.
+func ParseWindow(expr, duration string) (Window, error) {
+	start, err := cron.ParseStandard(expr)
+	if err != nil {
+		return Window{}, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return Window{}, fmt.Errorf("invalid window duration %q: %w", duration, err)
+	}
+	if d < time.Minute {
+		return Window{}, fmt.Errorf("window duration %q is shorter than a minute", duration)
+	}
+	return Window{expr: expr, start: start, duration: d}, nil
+}
+
+// String returns the window's cron expression
+func (w Window) String() string {
+	return w.expr
+}
+
+// Active reports whether t falls within a window that started at or before t
+func (w Window) Active(t time.Time) bool {
+	t = t.UTC()
+	// The first start after t-duration is the start of the window covering t,
+	// if any window does
+	start := w.start.Next(t.Add(-w.duration))
+	return !start.IsZero() && !start.After(t)
+}
+
+// nextTransition returns the first time after t at which a window starts or
+// ends. Overlapping windows may report an end at which the window stays
+// active; callers only need a time before which the state cannot change.
+func (w Window) nextTransition(t time.Time) time.Time {
+	t = t.UTC()
+	next := w.start.Next(t)
+	// Expressions that never match, such as 30 February, have no next start
+	if covering := w.start.Next(t.Add(-w.duration)); !covering.IsZero() {
+		next = earliest(next, covering.Add(w.duration))
+	}
+	return next
+}
+
+// Schedule limits a resource to the time between From and Until, and to
+// its windows. A zero From or Until leaves that side open; no windows means
+// the resource is active throughout.
+type Schedule struct {
+	From    time.Time
+	Until   time.Time
+	Windows []Window
+}
+
+// ErrEmptyRange is returned by Validate when Until is not after From
+var ErrEmptyRange = errors.New("activeUntil must be after activeFrom")
+
+// Validate checks that the schedule can ever be active
+func (s Schedule) Validate() error {
+	if !s.From.IsZero() && !s.Until.IsZero() && !s.Until.After(s.From) {
+		return ErrEmptyRange
+	}
+	return nil
+}
+
+// IsZero reports whether the schedule places no limits
+func (s Schedule) IsZero() bool {
+	return s.From.IsZero() && s.Until.IsZero() && len(s.Windows) == 0
+}
+
+// Active reports whether the schedule is active at t
+func (s Schedule) Active(t time.Time) bool {
+	if !s.From.IsZero() && t.Before(s.From) {
+		return false
+	}
+	if !s.Until.IsZero() && !t.Before(s.Until) {
+		return false
+	}
+	if len(s.Windows) == 0 {
+		return true
+	}
+	for _, window := range s.Windows {
+		if window.Active(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextTransition returns the first time after t at which Active may change,
+// or the zero time if it never changes again
+func (s Schedule) NextTransition(t time.Time) time.Time {
+	if !s.Until.IsZero() && !t.Before(s.Until) {
+		return time.Time{}
+	}
+
+	var next time.Time
+	if s.From.After(t) {
+		next = s.From
+	}
+	if !s.Until.IsZero() {
+		next = earliest(next, s.Until)
+	}
+	// Windows before From cannot change anything
+	from := t
+	if s.From.After(t) {
+		from = s.From
+	}
+	for _, window := range s.Windows {
+		next = earliest(next, window.nextTransition(from))
+	}
+	return next
+}
+
+// earliest returns the earlier of two times, ignoring zero times
+func earliest(a, b time.Time) time.Time {
+	switch {
+	case a.IsZero():
+		return b
+	case b.IsZero():
+		return a
+	case b.Before(a):
+		return b
+	}
+	return a
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func mustTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestWindowActive(t *testing.T) {
+	// Saturdays 02:00-06:00 UTC
+	window, err := ParseWindow("0 2 * * 6", "4h")
+	if err != nil {
+		t.Fatalf("ParseWindow returned error: %v", err)
+	}
+
+	tests := map[string]bool{
+		"2026-03-07T01:59:00Z": false,
+		"2026-03-07T02:00:00Z": true,
+		"2026-03-07T05:59:59Z": true,
+		"2026-03-07T06:00:00Z": false,
+		"2026-03-08T03:00:00Z": false,
+	}
+	for at, want := range tests {
+		if got := window.Active(mustTime(t, at)); got != want {
+			t.Errorf("Active(%s) = %v, want %v", at, got, want)
+		}
+	}
+
+	zoned, err := ParseWindow("CRON_TZ=America/Denver 0 2 * * 6", "1h")
+	if err != nil {
+		t.Fatalf("ParseWindow returned error: %v", err)
+	}
+	// 02:00 MST is 09:00 UTC
+	if !zoned.Active(mustTime(t, "2026-03-07T09:30:00Z")) || zoned.Active(mustTime(t, "2026-03-07T02:30:00Z")) {
+		t.Error("CRON_TZ window not evaluated in its zone")
+	}
+
+	never, err := ParseWindow("0 0 30 2 *", "1h")
+	if err != nil {
+		t.Fatalf("ParseWindow returned error: %v", err)
+	}
+	if never.Active(mustTime(t, "2026-03-07T09:30:00Z")) {
+		t.Error("window that never opens reported active")
+	}
+}
+
+func TestParseWindowErrors(t *testing.T) {
+	for _, tt := range []struct{ expr, duration string }{
+		{"not cron", "1h"},
+		{"0 2 * * 6", "four hours"},
+		{"0 2 * * 6", "30s"},
+	} {
+		if _, err := ParseWindow(tt.expr, tt.duration); err == nil {
+			t.Errorf("ParseWindow(%q, %q) succeeded, want error", tt.expr, tt.duration)
+		}
+	}
+}
+
+func TestScheduleActive(t *testing.T) {
+	window, err := ParseWindow("0 2 * * *", "2h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := Schedule{
+		From:    mustTime(t, "2026-03-01T00:00:00Z"),
+		Until:   mustTime(t, "2026-04-01T00:00:00Z"),
+		Windows: []Window{window},
+	}
+
+	tests := map[string]bool{
+		"2026-02-28T03:00:00Z": false, // window open, before From
+		"2026-03-05T03:00:00Z": true,
+		"2026-03-05T05:00:00Z": false, // between windows
+		"2026-04-01T03:00:00Z": false, // window open, after Until
+	}
+	for at, want := range tests {
+		if got := s.Active(mustTime(t, at)); got != want {
+			t.Errorf("Active(%s) = %v, want %v", at, got, want)
+		}
+	}
+
+	if !(Schedule{}).Active(time.Now()) || !(Schedule{}).IsZero() {
+		t.Error("empty schedule should always be active")
+	}
+	if err := (Schedule{From: s.Until, Until: s.From}).Validate(); err == nil {
+		t.Error("expected error for activeUntil before activeFrom")
+	}
+}
+
+func TestScheduleNextTransition(t *testing.T) {
+	window, err := ParseWindow("0 2 * * *", "2h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := Schedule{From: mustTime(t, "2026-03-01T00:00:00Z"), Until: mustTime(t, "2026-04-01T00:00:00Z"), Windows: []Window{window}}
+
+	tests := map[string]string{
+		"2026-02-20T00:00:00Z": "2026-03-01T00:00:00Z", // From
+		"2026-03-05T00:00:00Z": "2026-03-05T02:00:00Z", // window opens
+		"2026-03-05T03:00:00Z": "2026-03-05T04:00:00Z", // window closes
+		"2026-03-31T23:00:00Z": "2026-04-01T00:00:00Z", // Until
+	}
+	for at, want := range tests {
+		if got := s.NextTransition(mustTime(t, at)); !got.Equal(mustTime(t, want)) {
+			t.Errorf("NextTransition(%s) = %s, want %s", at, got, want)
+		}
+	}
+	if got := s.NextTransition(mustTime(t, "2026-05-01T00:00:00Z")); !got.IsZero() {
+		t.Errorf("NextTransition after Until = %s, want zero", got)
+	}
+	if got := (Schedule{From: s.From}).NextTransition(mustTime(t, "2026-05-01T00:00:00Z")); !got.IsZero() {
+		t.Errorf("NextTransition of an open-ended schedule = %s, want zero", got)
+	}
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package schemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+)
+
+// contentType is the media type of JSON Schema documents
+const contentType = "application/schema+json"
+
+// Handler serves the spec schemas
+type Handler struct {
+	registry *Registry
+}
+
+// NewHandler creates a schema handler
+func NewHandler(registry *Registry) *Handler {
+	return &Handler{registry: registry}
+}
+
+// RegisterRoutes registers the schema routes under Path
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route(Path, func(r chi.Router) {
+		r.Get("/", h.ListSchemas)
+		r.Get("/{name}", h.GetSchema)
+	})
+}
+
+// SchemaLink names a published schema and where it is served
+type SchemaLink struct {
+	Name string `json:"name"`
+	Href string `json:"href"`
+}
+
+// ListSchemas handles GET /schemas
+func (h *Handler) ListSchemas(w http.ResponseWriter, _ *http.Request) {
+	names := h.registry.Names()
+	links := make([]SchemaLink, 0, len(names))
+	for _, name := range names {
+		links = append(links, SchemaLink{Name: name, Href: Path + "/" + name})
+	}
+	httputil.WriteJSON(w, http.StatusOK, links)
+}
+
+// GetSchema handles GET /schemas/{name}. A ".json" suffix, which editors
+// often expect, is accepted.
+func (h *Handler) GetSchema(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSuffix(chi.URLParam(r, "name"), ".json")
+	document, ok := h.registry.JSONSchema(name)
+	if !ok {
+		httputil.WriteError(w, http.StatusNotFound, "Schema not found", "no schema named "+name)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(document)
+}
+
+// ValidationError is the response to a write whose spec does not match its
+// schema
+type ValidationError struct {
+	httputil.ErrorResponse
+	Errors []FieldError `json:"errors"`
+}
+
+// ValidateWrites rejects creates (POST to a collection) and replacements
+// (PUT to a resource) whose spec does not match its schema, reporting every
+// field that does not. Other requests, and bodies that are not JSON objects,
+// are passed on for the handlers to report.
+func (r *Registry) ValidateWrites(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		name, ok := r.writeSchema(req)
+		if !ok || req.Body == nil {
+			next.ServeHTTP(w, req)
+			return
+		}
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			httputil.WriteError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+			return
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		var payload struct {
+			Spec json.RawMessage `json:"spec"`
+		}
+		if json.Unmarshal(body, &payload) != nil || len(payload.Spec) == 0 {
+			next.ServeHTTP(w, req)
+			return
+		}
+		if errs := r.Validate(name, payload.Spec, "/spec"); len(errs) > 0 {
+			WriteValidationError(w, name, errs)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// WriteValidationError responds to a write whose spec does not match the
+// named schema, reporting every field in errs
+func WriteValidationError(w http.ResponseWriter, name string, errs []FieldError) {
+	httputil.WriteJSON(w, http.StatusBadRequest, ValidationError{
+		ErrorResponse: httputil.ErrorResponse{
+			Type:   "about:blank",
+			Title:  "Invalid " + name,
+			Detail: errs[0].Error(),
+			Status: http.StatusBadRequest,
+		},
+		Errors: errs,
+	})
+}
+
+// writeSchema returns the schema of the spec req writes, if it creates or
+// replaces a resource
+func (r *Registry) writeSchema(req *http.Request) (string, bool) {
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	collection, rest, hasUID := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	name, ok := r.SpecSchema("/" + collection)
+	switch {
+	case !ok:
+		return "", false
+	case req.Method == http.MethodPost && !hasUID:
+		return name, true
+	case req.Method == http.MethodPut && hasUID && rest != "" && !strings.Contains(rest, "/"):
+		return name, true
+	}
+	return "", false
+}
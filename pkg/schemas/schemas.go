@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package schemas publishes JSON Schemas of the resource specs, generated
+// from the API types, and validates the specs of incoming writes against
+// them. Editors and the ochami CLI can check a spec before sending it, and
+// the server reports every field that does not match at once.
+//
+// The schemas describe the shape of a spec: its fields and their types, with
+// unknown fields rejected so that misspelled fields are not silently
+// dropped. Rules that depend on other fields or on stored resources, such as
+// "kernel or imageRef is required", are still checked by the resources'
+// Validate methods.
+package schemas
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// Path is where the schemas are published, each at Path/<name>
+const Path = "/schemas"
+
+// jsonSchemaDialect is the JSON Schema version of the published schemas
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// specs are the published schemas by name, with the resource collection
+// whose writes carry the spec
+var specs = []struct {
+	name       string
+	collection string
+	value      any
+}{
+	{"NodeSpec", "/nodes", &v1.NodeSpec{}},
+	{"BootConfigurationSpec", "/bootconfigurations", &v1.BootConfigurationSpec{}},
+	{"BMCSpec", "/bmcs", &v1.BMCSpec{}},
+}
+
+// FieldError is a field of a spec that does not match its schema
+type FieldError struct {
+	// Field is the JSON pointer of the field within the request body, such
+	// as /spec/interfaces/0/vlan
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// Registry holds the spec schemas
+type Registry struct {
+	schemas     map[string]*openapi3.Schema
+	collections map[string]string
+}
+
+// NewRegistry generates the spec schemas
+func NewRegistry() (*Registry, error) {
+	r := &Registry{schemas: map[string]*openapi3.Schema{}, collections: map[string]string{}}
+	for _, spec := range specs {
+		ref, err := openapi3gen.NewSchemaRefForValue(spec.value, nil, openapi3gen.SchemaCustomizer(customize))
+		if err != nil {
+			return nil, fmt.Errorf("generating schema %s: %w", spec.name, err)
+		}
+		r.schemas[spec.name] = ref.Value
+		r.collections[spec.collection] = spec.name
+	}
+	return r, nil
+}
+
+// customize rejects unknown fields of structs and applies the length limits
+// of validate tags to strings
+func customize(_ string, t reflect.Type, tag reflect.StructTag, schema *openapi3.Schema) error {
+	if schema.Type.Is(openapi3.TypeObject) && len(schema.Properties) > 0 {
+		schema.AdditionalProperties = openapi3.AdditionalProperties{Has: openapi3.Ptr(false)}
+	}
+	if t.Kind() != reflect.String {
+		return nil
+	}
+	for _, rule := range strings.Split(tag.Get("validate"), ",") {
+		if limit, ok := strings.CutPrefix(rule, "max="); ok {
+			if n, err := strconv.ParseUint(limit, 10, 64); err == nil {
+				schema.MaxLength = openapi3.Ptr(n)
+			}
+		}
+	}
+	return nil
+}
+
+// Names returns the names of the published schemas
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.schemas))
+	for name := range r.schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// JSONSchema returns the named schema as a standalone JSON Schema document
+func (r *Registry) JSONSchema(name string) (map[string]any, bool) {
+	schema, ok := r.schemas[name]
+	if !ok {
+		return nil, false
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, false
+	}
+	var document map[string]any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, false
+	}
+	toJSONSchema(document)
+	document["$schema"] = jsonSchemaDialect
+	document["$id"] = Path + "/" + name
+	document["title"] = name
+	return document, true
+}
+
+// toJSONSchema rewrites the OpenAPI nullable keyword, which JSON Schema does
+// not have, as a "null" type
+func toJSONSchema(node any) {
+	switch node := node.(type) {
+	case map[string]any:
+		if nullable, _ := node["nullable"].(bool); nullable {
+			if typ, ok := node["type"].(string); ok {
+				node["type"] = []any{typ, "null"}
+			}
+		}
+		delete(node, "nullable")
+		for _, child := range node {
+			toJSONSchema(child)
+		}
+	case []any:
+		for _, child := range node {
+			toJSONSchema(child)
+		}
+	}
+}
+
+// Validate checks spec against the named schema. Field errors are reported
+// relative to prefix, the JSON pointer of the spec in its document.
+func (r *Registry) Validate(name string, spec json.RawMessage, prefix string) []FieldError {
+	schema, ok := r.schemas[name]
+	if !ok {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(spec, &value); err != nil {
+		return []FieldError{{Field: prefix, Message: err.Error()}}
+	}
+	if value == nil {
+		return nil
+	}
+	err := schema.VisitJSON(value, openapi3.MultiErrors())
+	if err == nil {
+		return nil
+	}
+	var fieldErrors []FieldError
+	collectErrors(err, prefix, &fieldErrors)
+	sort.SliceStable(fieldErrors, func(i, j int) bool { return fieldErrors[i].Field < fieldErrors[j].Field })
+	return fieldErrors
+}
+
+// collectErrors flattens the schema errors of err into field errors
+func collectErrors(err error, prefix string, out *[]FieldError) {
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		for _, err := range multi {
+			collectErrors(err, prefix, out)
+		}
+		return
+	}
+	var schemaErr *openapi3.SchemaError
+	if !errors.As(err, &schemaErr) {
+		*out = append(*out, FieldError{Field: prefix, Message: err.Error()})
+		return
+	}
+	field := prefix
+	for _, part := range schemaErr.JSONPointer() {
+		field += "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(part)
+	}
+	*out = append(*out, FieldError{Field: field, Message: schemaErr.Reason})
+}
+
+// SpecSchema returns the name of the schema of the specs written to the
+// collection at path, such as /nodes
+func (r *Registry) SpecSchema(collection string) (string, bool) {
+	name, ok := r.collections[collection]
+	return name, ok
+}
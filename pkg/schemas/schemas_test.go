@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package schemas
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func testRegistry(t *testing.T) *Registry {
+	t.Helper()
+	registry, err := NewRegistry()
+	if err != nil {
+		t.Fatalf("NewRegistry failed: %v", err)
+	}
+	return registry
+}
+
+func TestValidate(t *testing.T) {
+	registry := testRegistry(t)
+
+	valid := `{"xname": "x1000c0s0b0n0", "nid": 1, "interfaces": [{"mac": "aa:bb:cc:dd:ee:ff", "vlan": 100}]}`
+	if errs := registry.Validate("NodeSpec", json.RawMessage(valid), "/spec"); len(errs) != 0 {
+		t.Errorf("valid spec reported %v", errs)
+	}
+
+	invalid := `{"xname": "x1000c0s0b0n0", "nid": "one", "bootmac": "aa:bb:cc:dd:ee:ff", "interfaces": [{"vlan": "100"}]}`
+	var fields []string
+	for _, err := range registry.Validate("NodeSpec", json.RawMessage(invalid), "/spec") {
+		fields = append(fields, err.Field)
+	}
+	want := []string{"/spec", "/spec/interfaces/0/vlan", "/spec/nid"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("invalid spec reported fields %v, want %v", fields, want)
+	}
+
+	long := `{"description": "` + strings.Repeat("x", 201) + `"}`
+	if errs := registry.Validate("BMCSpec", json.RawMessage(long), "/spec"); len(errs) != 1 || errs[0].Field != "/spec/description" {
+		t.Errorf("overlong description reported %v", errs)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	registry := testRegistry(t)
+	if names := registry.Names(); !reflect.DeepEqual(names, []string{"BMCSpec", "BootConfigurationSpec", "NodeSpec"}) {
+		t.Errorf("Names() = %v", names)
+	}
+
+	document, ok := registry.JSONSchema("BootConfigurationSpec")
+	if !ok {
+		t.Fatal("no BootConfigurationSpec schema")
+	}
+	if document["$schema"] != jsonSchemaDialect || document["$id"] != "/schemas/BootConfigurationSpec" || document["additionalProperties"] != false {
+		t.Errorf("schema header = %v, %v, %v", document["$schema"], document["$id"], document["additionalProperties"])
+	}
+	rootfs := document["properties"].(map[string]any)["rootfs"].(map[string]any)
+	if _, ok := rootfs["nullable"]; ok || !reflect.DeepEqual(rootfs["type"], []any{"object", "null"}) {
+		t.Errorf("nullable rootfs = %v", rootfs)
+	}
+	if _, ok := registry.JSONSchema("Profile"); ok {
+		t.Error("unknown schema found")
+	}
+}
+
+func TestValidateWrites(t *testing.T) {
+	registry := testRegistry(t)
+	r := chi.NewRouter()
+	r.Use(registry.ValidateWrites)
+	NewHandler(registry).RegisterRoutes(r)
+	var received string
+	r.HandleFunc("/*", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		received = ""
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	invalid := `{"metadata": {"name": "n0"}, "spec": {"xname": "x1000c0s0b0n0", "nid": "one"}}`
+	rec := serve(http.MethodPost, "/nodes", invalid)
+	var response ValidationError
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil || rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid create = %d, %v", rec.Code, err)
+	}
+	if len(response.Errors) != 1 || response.Errors[0].Field != "/spec/nid" || response.Title != "Invalid NodeSpec" {
+		t.Errorf("invalid create reported %+v", response)
+	}
+
+	valid := `{"spec": {"kernel": "http://example.com/vmlinuz", "params": "quiet"}}`
+	if rec := serve(http.MethodPut, "/bootconfigurations/bc-1", valid); rec.Code != http.StatusOK || received != valid {
+		t.Errorf("valid replace = %d, handler read %q", rec.Code, received)
+	}
+	// Status writes, patches, and other routes are not checked
+	for _, req := range [][2]string{
+		{http.MethodPut, "/nodes/n-1/status"},
+		{http.MethodPatch, "/nodes/n-1"},
+		{http.MethodPost, "/bootconfigurations/simulate"},
+		{http.MethodPost, "/parameterprofiles"},
+	} {
+		if rec := serve(req[0], req[1], invalid); rec.Code != http.StatusOK {
+			t.Errorf("%s %s = %d, want it passed on", req[0], req[1], rec.Code)
+		}
+	}
+
+	rec = serve(http.MethodGet, "/schemas/NodeSpec.json", "")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != contentType || !strings.Contains(rec.Body.String(), `"xname"`) {
+		t.Errorf("GET /schemas/NodeSpec.json = %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if rec := serve(http.MethodGet, "/schemas/Other", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("GET /schemas/Other = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
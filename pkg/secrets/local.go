@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// KeySize is the size of a local store key, for AES-256-GCM
+const KeySize = 32
+
+// localFile is the on-disk form of a LocalStore. Each value is the base64 of
+// a GCM nonce followed by the sealed secret, with the secret's name as
+// additional data so values cannot be swapped between names.
+type localFile struct {
+	Secrets map[string]string `json:"secrets"`
+}
+
+// LocalStore keeps secrets encrypted in a JSON file. The file is re-read
+// when it changes, so secrets set by another process apply without a
+// restart.
+type LocalStore struct {
+	path string
+	aead cipher.AEAD
+
+	mu     sync.Mutex
+	info   os.FileInfo // of the file sealed was read from
+	sealed map[string]string
+}
+
+// LoadKey reads a base64-encoded 32-byte key, such as one written by
+// "openssl rand -base64 32"
+func LoadKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading secrets key: %w", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(key) != KeySize {
+		return nil, fmt.Errorf("secrets key %s must be %d base64-encoded bytes", path, KeySize)
+	}
+	return key, nil
+}
+
+// NewLocalStore opens the store in path, which need not exist yet, with key
+func NewLocalStore(path string, key []byte) (*LocalStore, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secrets key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	store := &LocalStore{path: path, aead: aead}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// Secret returns the value of the secret name
+func (s *LocalStore) Secret(_ context.Context, name string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return "", err
+	}
+	sealed, ok := s.sealed[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return s.open(name, sealed)
+}
+
+// Names returns the names of the stored secrets, sorted
+func (s *LocalStore) Names() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(s.sealed))
+	for name := range s.sealed {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// Set stores value as the secret name
+func (s *LocalStore) Set(name, value string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	sealed := base64.StdEncoding.EncodeToString(s.aead.Seal(nonce, nonce, []byte(value), []byte(name)))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+	next := make(map[string]string, len(s.sealed)+1)
+	for k, v := range s.sealed {
+		next[k] = v
+	}
+	next[name] = sealed
+	return s.save(next)
+}
+
+// Delete removes the secret name
+func (s *LocalStore) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+	if _, ok := s.sealed[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	next := make(map[string]string, len(s.sealed))
+	for k, v := range s.sealed {
+		if k != name {
+			next[k] = v
+		}
+	}
+	return s.save(next)
+}
+
+func (s *LocalStore) open(name, sealed string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil || len(data) < s.aead.NonceSize() {
+		return "", fmt.Errorf("secret %s is corrupt", name)
+	}
+	nonce, ciphertext := data[:s.aead.NonceSize()], data[s.aead.NonceSize():]
+	value, err := s.aead.Open(nil, nonce, ciphertext, []byte(name))
+	if err != nil {
+		return "", fmt.Errorf("secret %s cannot be decrypted with this key", name)
+	}
+	return string(value), nil
+}
+
+// load re-reads the file when it changed since it was last read. The caller
+// holds s.mu.
+func (s *LocalStore) load() error {
+	info, err := os.Stat(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.sealed, s.info = map[string]string{}, nil
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading secrets file: %w", err)
+	}
+	// Writers replace the file, so an unchanged file is the same one
+	if s.sealed != nil && s.info != nil && os.SameFile(info, s.info) &&
+		info.ModTime().Equal(s.info.ModTime()) && info.Size() == s.info.Size() {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("reading secrets file: %w", err)
+	}
+	var file localFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("parsing secrets file %s: %w", s.path, err)
+	}
+	if file.Secrets == nil {
+		file.Secrets = map[string]string{}
+	}
+	s.sealed, s.info = file.Secrets, info
+	return nil
+}
+
+// save atomically replaces the file with secrets. The caller holds s.mu.
+func (s *LocalStore) save(secrets map[string]string) error {
+	data, err := json.MarshalIndent(localFile{Secrets: secrets}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".secrets-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close() //nolint:errcheck
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+	// Force a re-read so the next lookup sees exactly what was written
+	s.sealed = nil
+	return nil
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testKey(fill byte) []byte {
+	return bytes.Repeat([]byte{fill}, KeySize)
+}
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "secrets.json")
+
+	store, err := NewLocalStore(path, testKey(1))
+	if err != nil {
+		t.Fatalf("NewLocalStore failed: %v", err)
+	}
+	if _, err := store.Secret(ctx, "root-password"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound from an empty store, got %v", err)
+	}
+
+	if err := store.Set("root-password", "hunter2"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := store.Set("site/token", "abc 123"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := store.Set("../escape", "x"); err == nil {
+		t.Error("expected error for an invalid name")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Fatal("secret stored in plain text")
+	}
+
+	// A second store on the same file, like the serve command, sees writes
+	// made through the first, like the secrets command
+	reader, err := NewLocalStore(path, testKey(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := reader.Secret(ctx, "site/token"); err != nil || value != "abc 123" {
+		t.Errorf("Secret() = %q, %v", value, err)
+	}
+	if err := store.Set("site/token", "rotated"); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := reader.Secret(ctx, "site/token"); err != nil || value != "rotated" {
+		t.Errorf("Secret() after rotation = %q, %v", value, err)
+	}
+
+	if err := store.Delete("site/token"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if names, err := reader.Names(); err != nil || strings.Join(names, ",") != "root-password" {
+		t.Errorf("Names() = %v, %v", names, err)
+	}
+	if err := store.Delete("site/token"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound deleting a missing secret, got %v", err)
+	}
+
+	wrongKey, err := NewLocalStore(path, testKey(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wrongKey.Secret(ctx, "root-password"); err == nil {
+		t.Error("expected error decrypting with the wrong key")
+	}
+}
+
+func TestLocalStore_ValuesBoundToNames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.json")
+	store, err := NewLocalStore(path, testKey(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("a", "value-a"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Moving a sealed value to another name must not decrypt
+	var file localFile
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	file.Secrets["b"] = file.Secrets["a"]
+	data, _ = json.Marshal(file)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Secret(context.Background(), "b"); err == nil {
+		t.Error("expected error for a value moved between names")
+	}
+}
+
+func TestLoadKey(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.key")
+	short := filepath.Join(dir, "short.key")
+	os.WriteFile(good, []byte(base64.StdEncoding.EncodeToString(testKey(3))+"\n"), 0o600)  //nolint:errcheck
+	os.WriteFile(short, []byte(base64.StdEncoding.EncodeToString([]byte("short"))), 0o600) //nolint:errcheck
+
+	if key, err := LoadKey(good); err != nil || !bytes.Equal(key, testKey(3)) {
+		t.Errorf("LoadKey(good) = %x, %v", key, err)
+	}
+	if _, err := LoadKey(short); err == nil {
+		t.Error("expected error for a short key")
+	}
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package secrets stores the values kernel parameters reference with
+// {{secret "name"}}, so tokens and passwords are resolved when a boot script
+// is rendered instead of being kept in boot configurations.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ErrNotFound is returned for a secret that does not exist
+var ErrNotFound = errors.New("secret not found")
+
+// Store looks up secret values
+type Store interface {
+	Secret(ctx context.Context, name string) (string, error)
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+// ValidateName reports whether name may name a secret: letters, digits, and
+// ".", "_", "/", or "-", starting with a letter or digit
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid secret name %q", name)
+	}
+	return nil
+}
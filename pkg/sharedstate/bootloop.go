@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package sharedstate
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/openchami/boot-service/pkg/bootloop"
+)
+
+// updateLoopScript replaces a loop only if it is still stored
+var updateLoopScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], ARGV[1]) == 1 then
+	redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// RedisBootLoopState is a bootloop.State shared by every replica.
+//
+// The requests of each node are a sorted set at <prefix>:bootloop:requests:<node>
+// scored by request time, which expires with the window. Loops are fields of
+// the hash at <prefix>:bootloop:loops.
+type RedisBootLoopState struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+var _ bootloop.State = (*RedisBootLoopState)(nil)
+
+// NewRedisBootLoopState creates boot loop detection state stored in Redis
+// under prefix
+func NewRedisBootLoopState(client redis.UniversalClient, prefix string) *RedisBootLoopState {
+	if prefix == "" {
+		prefix = DefaultKeyPrefix
+	}
+	return &RedisBootLoopState{client: client, prefix: prefix}
+}
+
+// AddRequest records a request of node and returns how many it made since
+// cutoff
+func (s *RedisBootLoopState) AddRequest(node string, at, cutoff time.Time) (int, error) {
+	ctx := context.Background()
+	key := s.requestsKey(node)
+	// Requests made in the same millisecond by several replicas are
+	// counted separately
+	member := strconv.FormatInt(at.UnixNano(), 10) + ":" + rand.Text()
+
+	var count *redis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(at.UnixMilli()), Member: member})
+		pipe.ZRemRangeByScore(ctx, key, "-inf", "("+strconv.FormatInt(cutoff.UnixMilli(), 10))
+		count = pipe.ZCard(ctx, key)
+		pipe.PExpire(ctx, key, at.Sub(cutoff))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(count.Val()), nil
+}
+
+// ResetRequests forgets the requests of node
+func (s *RedisBootLoopState) ResetRequests(node string) error {
+	return s.client.Del(context.Background(), s.requestsKey(node)).Err()
+}
+
+// Loop returns the loop of node
+func (s *RedisBootLoopState) Loop(node string) (bootloop.Loop, bool, error) {
+	data, err := s.client.HGet(context.Background(), s.loopsKey(), node).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return bootloop.Loop{}, false, nil
+	}
+	if err != nil {
+		return bootloop.Loop{}, false, err
+	}
+	loop, err := decodeLoop(node, data)
+	return loop, err == nil, err
+}
+
+// Loops returns every loop, sorted by node
+func (s *RedisBootLoopState) Loops() ([]bootloop.Loop, error) {
+	fields, err := s.client.HGetAll(context.Background(), s.loopsKey()).Result()
+	if err != nil {
+		return nil, err
+	}
+	loops := make([]bootloop.Loop, 0, len(fields))
+	for node, data := range fields {
+		loop, err := decodeLoop(node, []byte(data))
+		if err != nil {
+			return nil, err
+		}
+		loops = append(loops, loop)
+	}
+	sort.Slice(loops, func(i, j int) bool { return loops[i].Node < loops[j].Node })
+	return loops, nil
+}
+
+// CreateLoop stores loop unless its node already has one
+func (s *RedisBootLoopState) CreateLoop(loop bootloop.Loop) (bool, error) {
+	data, err := json.Marshal(loop)
+	if err != nil {
+		return false, err
+	}
+	return s.client.HSetNX(context.Background(), s.loopsKey(), loop.Node, data).Result()
+}
+
+// UpdateLoop replaces the loop of its node, if it still has one
+func (s *RedisBootLoopState) UpdateLoop(loop bootloop.Loop) error {
+	data, err := json.Marshal(loop)
+	if err != nil {
+		return err
+	}
+	return updateLoopScript.Run(context.Background(), s.client, []string{s.loopsKey()}, loop.Node, data).Err()
+}
+
+// DeleteLoop removes the loop of node and returns it
+func (s *RedisBootLoopState) DeleteLoop(node string) (bootloop.Loop, bool, error) {
+	ctx := context.Background()
+	var get *redis.StringCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.HGet(ctx, s.loopsKey(), node)
+		pipe.HDel(ctx, s.loopsKey(), node)
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return bootloop.Loop{}, false, nil
+	}
+	if err != nil {
+		return bootloop.Loop{}, false, err
+	}
+	loop, err := decodeLoop(node, []byte(get.Val()))
+	return loop, err == nil, err
+}
+
+func decodeLoop(node string, data []byte) (bootloop.Loop, error) {
+	var loop bootloop.Loop
+	if err := json.Unmarshal(data, &loop); err != nil {
+		return bootloop.Loop{}, fmt.Errorf("decoding boot loop of node %s: %w", node, err)
+	}
+	return loop, nil
+}
+
+func (s *RedisBootLoopState) requestsKey(node string) string {
+	return s.prefix + ":bootloop:requests:" + node
+}
+
+func (s *RedisBootLoopState) loopsKey() string {
+	return s.prefix + ":bootloop:loops"
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package sharedstate
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/openchami/boot-service/pkg/bootloop"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+)
+
+// testResolver knows every node by its xname
+type testResolver struct{}
+
+func (testResolver) ResolveNodeName(_ context.Context, identifier string) (string, error) {
+	return identifier, nil
+}
+
+func TestRedisBootLoopState_SharedAcrossReplicas(t *testing.T) {
+	server := miniredis.RunT(t)
+	newDetector := func() *bootloop.Detector {
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { client.Close() }) //nolint:errcheck
+		detector := bootloop.NewDetector(bootloop.Config{Threshold: 3, Window: 10 * time.Minute, Diagnostic: "rescue"},
+			testResolver{}, nil, log.New(io.Discard, "", 0))
+		detector.SetState(NewRedisBootLoopState(client, ""))
+		return detector
+	}
+	replicaA, replicaB := newDetector(), newDetector()
+	access := bootscript.Access{Client: "10.1.0.21"}
+
+	// Requests spread over both replicas are counted together
+	replicaA.RecordAccess("x0c0s0b0n0", access)
+	replicaB.RecordAccess("x0c0s0b0n0", access)
+	replicaA.RecordAccess("x0c0s0b0n0", access)
+	if loops := replicaA.Loops(); len(loops) != 0 {
+		t.Fatalf("Loops = %+v, want none at the threshold", loops)
+	}
+	replicaB.RecordAccess("x0c0s0b0n0", access)
+	if config, ok := replicaA.Diagnostic("x0c0s0b0n0"); !ok || config != "rescue" {
+		t.Fatalf("replica A Diagnostic = %q, %v; want the loop replica B detected", config, ok)
+	}
+	replicaA.RecordAccess("x0c0s0b0n0", access)
+	if loops := replicaB.Loops(); len(loops) != 1 || loops[0].Requests != 5 {
+		t.Errorf("replica B Loops = %+v, want one loop with 5 requests", loops)
+	}
+	// Only the replica that detected the loop reports it
+	if replicaA.Detected() != 0 || replicaB.Detected() != 1 {
+		t.Errorf("Detected = %d and %d, want 0 and 1", replicaA.Detected(), replicaB.Detected())
+	}
+
+	// A loop cleared on one replica is cleared on both
+	if !replicaA.Clear("x0c0s0b0n0") {
+		t.Fatal("Clear reported no loop")
+	}
+	if _, ok := replicaB.Diagnostic("x0c0s0b0n0"); ok {
+		t.Error("replica B still boots the diagnostic configuration after Clear")
+	}
+	if replicaB.Clear("x0c0s0b0n0") {
+		t.Error("second Clear reported a loop")
+	}
+
+	// Requests outside the window are forgotten
+	replicaA.RecordAccess("x0c0s1b0n0", access)
+	server.FastForward(11 * time.Minute)
+	if keys := server.Keys(); len(keys) != 0 {
+		t.Errorf("expected request history to expire, %d keys left: %v", len(keys), keys)
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package sharedstate
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/openchami/boot-service/pkg/leader"
+)
+
+// acquireScript sets the lease if it is free and renews it if id holds it
+var acquireScript = redis.NewScript(`
+local holder = redis.call('GET', KEYS[1])
+if holder == false then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+	return 1
+end
+if holder == ARGV[1] then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// releaseScript deletes the lease only if id holds it
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// RedisLock is a leader.Lock stored at <prefix>:leader
+type RedisLock struct {
+	client redis.UniversalClient
+	key    string
+}
+
+var _ leader.Lock = (*RedisLock)(nil)
+
+// NewRedisLock creates a leader lease stored in Redis under prefix
+func NewRedisLock(client redis.UniversalClient, prefix string) *RedisLock {
+	if prefix == "" {
+		prefix = DefaultKeyPrefix
+	}
+	return &RedisLock{client: client, key: prefix + ":leader"}
+}
+
+// TryAcquire acquires or renews the lease for id
+func (l *RedisLock) TryAcquire(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	held, err := acquireScript.Run(ctx, l.client, []string{l.key}, id, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return held == 1, nil
+}
+
+// Release gives up the lease if id holds it
+func (l *RedisLock) Release(ctx context.Context, id string) error {
+	return releaseScript.Run(ctx, l.client, []string{l.key}, id).Err()
+}
+
+// Holder returns the ID holding the lease, or "" if it is free
+func (l *RedisLock) Holder(ctx context.Context) (string, error) {
+	holder, err := l.client.Get(ctx, l.key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return holder, err
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package sharedstate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedisLock(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close() //nolint:errcheck
+
+	lock := NewRedisLock(client, "")
+	ctx := context.Background()
+	ttl := 10 * time.Second
+
+	acquire := func(id string, want bool) {
+		t.Helper()
+		held, err := lock.TryAcquire(ctx, id, ttl)
+		if err != nil {
+			t.Fatalf("TryAcquire(%s) returned error: %v", id, err)
+		}
+		if held != want {
+			t.Fatalf("TryAcquire(%s) = %v, want %v", id, held, want)
+		}
+	}
+
+	acquire("a", true)
+	acquire("b", false)
+	acquire("a", true) // renewal
+
+	if holder, err := lock.Holder(ctx); err != nil || holder != "a" {
+		t.Fatalf("Holder = %q, %v; want a", holder, err)
+	}
+
+	// Only the holder can release the lease
+	if err := lock.Release(ctx, "b"); err != nil {
+		t.Fatalf("Release returned error: %v", err)
+	}
+	acquire("b", false)
+
+	// An expired lease can be taken over
+	server.FastForward(ttl + time.Second)
+	acquire("b", true)
+
+	if err := lock.Release(ctx, "b"); err != nil {
+		t.Fatalf("Release returned error: %v", err)
+	}
+	if holder, err := lock.Holder(ctx); err != nil || holder != "" {
+		t.Fatalf("Holder after release = %q, %v; want empty", holder, err)
+	}
+}
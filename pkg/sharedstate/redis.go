@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package sharedstate keeps state that must agree across boot-service
+// replicas in Redis.
+//
+// With several replicas behind a load balancer, a per-process cache lets one
+// replica serve a boot script that another replica has already invalidated.
+// RedisScriptCache stores scripts in Redis instead, so an invalidation made by
+// any replica is seen by all of them. RedisBootLoopState likewise counts the
+// boot script requests of each node across replicas, so boot loops are
+// detected however requests are balanced.
+package sharedstate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+)
+
+// DefaultKeyPrefix namespaces keys when several services share a Redis database
+const DefaultKeyPrefix = "boot-service"
+
+// NewRedisClient creates a client from a redis:// or rediss:// URL
+func NewRedisClient(url string) (*redis.Client, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+	return redis.NewClient(opts), nil
+}
+
+// RedisScriptCache is a bootscript.Cache backed by Redis.
+//
+// Each script is a hash at <prefix>:script:<key> that expires after the TTL.
+// Sets at <prefix>:node:<id> and <prefix>:config:<id> index the keys by node
+// and configuration for invalidation.
+type RedisScriptCache struct {
+	client redis.UniversalClient
+	prefix string
+	ttl    atomic.Int64 // time.Duration
+	logger *log.Logger
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+var _ bootscript.Cache = (*RedisScriptCache)(nil)
+
+// NewRedisScriptCache creates a script cache stored in Redis under prefix
+func NewRedisScriptCache(client redis.UniversalClient, prefix string, ttl time.Duration, logger *log.Logger) *RedisScriptCache {
+	if prefix == "" {
+		prefix = DefaultKeyPrefix
+	}
+	if logger == nil {
+		logger = log.Default()
+	}
+	cache := &RedisScriptCache{
+		client: client,
+		prefix: prefix,
+		logger: logger,
+	}
+	cache.SetTTL(ttl)
+	return cache
+}
+
+// SetTTL changes the lifetime of scripts cached from now on
+func (c *RedisScriptCache) SetTTL(ttl time.Duration) {
+	c.ttl.Store(int64(ttl))
+}
+
+// Get retrieves a cached script. Redis errors are logged and treated as misses.
+func (c *RedisScriptCache) Get(cacheKey string) (string, bool) {
+	script, err := c.client.HGet(context.Background(), c.scriptKey(cacheKey), "script").Result()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			c.logger.Printf("Redis script cache read failed: %v", err)
+		}
+		c.misses.Add(1)
+		return "", false
+	}
+
+	c.hits.Add(1)
+	return script, true
+}
+
+// Set stores a script and indexes it by node and configuration
+func (c *RedisScriptCache) Set(cacheKey, script, nodeID, configID string) {
+	ctx := context.Background()
+	key := c.scriptKey(cacheKey)
+	ttl := time.Duration(c.ttl.Load())
+
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "script", script, "node", nodeID, "config", configID)
+		pipe.Expire(ctx, key, ttl)
+		for _, index := range []string{c.nodeKey(nodeID), c.configKey(configID)} {
+			pipe.SAdd(ctx, index, cacheKey)
+			pipe.Expire(ctx, index, ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		c.logger.Printf("Redis script cache write failed: %v", err)
+	}
+}
+
+// Invalidate removes a specific entry from the cache
+func (c *RedisScriptCache) Invalidate(cacheKey string) {
+	if err := c.client.Del(context.Background(), c.scriptKey(cacheKey)).Err(); err != nil {
+		c.logger.Printf("Redis script cache invalidation failed: %v", err)
+	}
+}
+
+// InvalidateByNodeID removes all cache entries for a specific node
+func (c *RedisScriptCache) InvalidateByNodeID(nodeID string) {
+	c.invalidateIndex(c.nodeKey(nodeID))
+}
+
+// InvalidateByConfigID removes all cache entries using a specific configuration
+func (c *RedisScriptCache) InvalidateByConfigID(configID string) {
+	c.invalidateIndex(c.configKey(configID))
+}
+
+// Clear removes all cached scripts and indexes under the key prefix
+func (c *RedisScriptCache) Clear() {
+	ctx := context.Background()
+
+	for _, pattern := range []string{c.scriptKey("*"), c.nodeKey("*"), c.configKey("*")} {
+		iter := c.client.Scan(ctx, 0, pattern, 500).Iterator()
+		var keys []string
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+			if len(keys) == 500 {
+				c.unlink(ctx, keys)
+				keys = keys[:0]
+			}
+		}
+		if err := iter.Err(); err != nil {
+			c.logger.Printf("Redis script cache clear failed: %v", err)
+			return
+		}
+		c.unlink(ctx, keys)
+	}
+}
+
+// Stats returns the hit and miss counts of this replica. Redis manages entry
+// expiry and memory, so entry, byte, and eviction counts are not reported.
+func (c *RedisScriptCache) Stats() bootscript.CacheStats {
+	return bootscript.CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+	}
+}
+
+// Close is a no-op; the caller owns the Redis client
+func (c *RedisScriptCache) Close() {}
+
+func (c *RedisScriptCache) invalidateIndex(index string) {
+	ctx := context.Background()
+
+	cacheKeys, err := c.client.SMembers(ctx, index).Result()
+	if err != nil {
+		c.logger.Printf("Redis script cache invalidation failed: %v", err)
+		return
+	}
+
+	keys := []string{index}
+	for _, cacheKey := range cacheKeys {
+		keys = append(keys, c.scriptKey(cacheKey))
+	}
+	c.unlink(ctx, keys)
+}
+
+func (c *RedisScriptCache) unlink(ctx context.Context, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	if err := c.client.Unlink(ctx, keys...).Err(); err != nil {
+		c.logger.Printf("Redis script cache invalidation failed: %v", err)
+	}
+}
+
+func (c *RedisScriptCache) scriptKey(cacheKey string) string {
+	return c.prefix + ":script:" + cacheKey
+}
+
+func (c *RedisScriptCache) nodeKey(nodeID string) string {
+	return c.prefix + ":node:" + nodeID
+}
+
+func (c *RedisScriptCache) configKey(configID string) string {
+	return c.prefix + ":config:" + configID
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package sharedstate
+
+import (
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newReplicaCaches returns two caches sharing one Redis server, as two
+// replicas would
+func newReplicaCaches(t *testing.T) (*miniredis.Miniredis, *RedisScriptCache, *RedisScriptCache) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	logger := log.New(io.Discard, "", 0)
+	newCache := func() *RedisScriptCache {
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { client.Close() }) //nolint:errcheck
+		return NewRedisScriptCache(client, "", time.Minute, logger)
+	}
+
+	return server, newCache(), newCache()
+}
+
+func TestRedisScriptCache_SharedAcrossReplicas(t *testing.T) {
+	server, replicaA, replicaB := newReplicaCaches(t)
+
+	replicaA.Set("x0c0s0b0n0:default", "script-a", "x0c0s0b0n0", "compute")
+	replicaA.Set("x0c0s1b0n0:default", "script-b", "x0c0s1b0n0", "compute")
+	replicaA.Set("x0c0s2b0n0:default", "script-c", "x0c0s2b0n0", "io")
+
+	if script, found := replicaB.Get("x0c0s0b0n0:default"); !found || script != "script-a" {
+		t.Fatalf("replica B Get = %q, %v; want script-a, true", script, found)
+	}
+
+	// Invalidation on one replica is seen by the other
+	replicaB.InvalidateByNodeID("x0c0s0b0n0")
+	if _, found := replicaA.Get("x0c0s0b0n0:default"); found {
+		t.Error("expected node invalidation to reach replica A")
+	}
+
+	replicaB.InvalidateByConfigID("compute")
+	if _, found := replicaA.Get("x0c0s1b0n0:default"); found {
+		t.Error("expected configuration invalidation to reach replica A")
+	}
+	if _, found := replicaA.Get("x0c0s2b0n0:default"); !found {
+		t.Error("expected script of another configuration to stay cached")
+	}
+
+	replicaB.Clear()
+	if keys := server.Keys(); len(keys) != 0 {
+		t.Errorf("expected Clear to remove all keys, %d left: %v", len(keys), keys)
+	}
+
+	stats := replicaA.Stats()
+	if stats.Hits != 1 || stats.Misses != 2 {
+		t.Errorf("got hits=%d misses=%d, want 1 and 2", stats.Hits, stats.Misses)
+	}
+}
+
+func TestRedisScriptCache_Expiry(t *testing.T) {
+	server, cache, _ := newReplicaCaches(t)
+
+	cache.Set("x0c0s0b0n0:default", "script", "x0c0s0b0n0", "compute")
+	server.FastForward(2 * time.Minute)
+
+	if _, found := cache.Get("x0c0s0b0n0:default"); found {
+		t.Error("expected cached script to expire after the TTL")
+	}
+}
+
+func TestRedisScriptCache_UnavailableIsMiss(t *testing.T) {
+	server, cache, _ := newReplicaCaches(t)
+	server.Close()
+
+	cache.Set("x0c0s0b0n0:default", "script", "x0c0s0b0n0", "compute")
+	if _, found := cache.Get("x0c0s0b0n0:default"); found {
+		t.Error("expected unavailable Redis to behave as a cache miss")
+	}
+}
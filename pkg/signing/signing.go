@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package signing signs boot scripts so iPXE clients can verify them.
+//
+// Signatures are detached CMS (PKCS #7) SignedData in DER form, made over the
+// script bytes with SHA-256 and RSA and without signed attributes, the format
+// iPXE's imgverify command checks. They are equivalent to
+//
+//	openssl cms -sign -binary -noattr -in script -signer cert.pem \
+//	    -inkey key.pem -certfile chain.pem -outform DER -out script.sig
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/smallstep/pkcs7"
+)
+
+// Signer signs boot scripts with a code signing certificate
+type Signer struct {
+	cert    *x509.Certificate
+	chain   []*x509.Certificate
+	key     *rsa.PrivateKey
+	certPEM []byte
+}
+
+// Load reads a PEM certificate file, whose first certificate is the signing
+// certificate and any others its intermediates, and the PEM private key for
+// it
+func Load(certFile, keyFile string) (*Signer, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading signing certificate: %w", err)
+	}
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading signing key: %w", err)
+	}
+	signer, err := Parse(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("%s, %s: %w", certFile, keyFile, err)
+	}
+	return signer, nil
+}
+
+// Parse is like Load for PEM data already in memory
+func Parse(certPEM, keyPEM []byte) (*Signer, error) {
+	var certs []*x509.Certificate
+	for rest := certPEM; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing signing certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no signing certificate found")
+	}
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parsing signing key: %w", err)
+	}
+	return New(certs, key)
+}
+
+// New creates a signer from the signing certificate, followed by its
+// intermediates, and its private key. iPXE only accepts RSA signatures from
+// certificates valid for code signing.
+func New(certs []*x509.Certificate, key crypto.PrivateKey) (*Signer, error) {
+	if len(certs) == 0 {
+		return nil, errors.New("signing certificate is required")
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key must be an RSA key, not %T", key)
+	}
+	cert := certs[0]
+	if !rsaKey.PublicKey.Equal(cert.PublicKey) {
+		return nil, errors.New("signing key does not match the signing certificate")
+	}
+	if !slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageCodeSigning) {
+		return nil, errors.New("signing certificate lacks the code signing extended key usage iPXE requires")
+	}
+
+	var certPEM bytes.Buffer
+	for _, c := range certs {
+		pem.Encode(&certPEM, &pem.Block{Type: "CERTIFICATE", Bytes: c.Raw}) //nolint:errcheck
+	}
+	return &Signer{cert: cert, chain: certs[1:], key: rsaKey, certPEM: certPEM.Bytes()}, nil
+}
+
+// Sign returns the detached signature of data
+func (s *Signer) Sign(data []byte) ([]byte, error) {
+	signed, err := pkcs7.NewSignedData(data)
+	if err != nil {
+		return nil, err
+	}
+	signed.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+	signed.SetEncryptionAlgorithm(pkcs7.OIDEncryptionAlgorithmRSA)
+	if err := signed.SignWithoutAttr(s.cert, s.key, pkcs7.SignerInfoConfig{}); err != nil {
+		return nil, fmt.Errorf("signing: %w", err)
+	}
+	for _, cert := range s.chain {
+		signed.AddCertificate(cert)
+	}
+	signed.Detach()
+	return signed.Finish()
+}
+
+// CertificatePEM returns the signing certificate and its intermediates in
+// PEM form
+func (s *Signer) CertificatePEM() []byte {
+	return s.certPEM
+}
+
+func parsePrivateKey(data []byte) (crypto.PrivateKey, error) {
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("no private key found")
+		}
+		switch block.Type {
+		case "PRIVATE KEY":
+			return x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			return x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			return x509.ParseECPrivateKey(block.Bytes)
+		}
+	}
+}
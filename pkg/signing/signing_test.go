@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/smallstep/pkcs7"
+)
+
+// newCertificate issues a certificate for key, signed by parent or
+// self-signed when parent is nil
+func newCertificate(t *testing.T, name string, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer, usage []x509.ExtKeyUsage) *x509.Certificate {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           usage,
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	return cert
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	return key
+}
+
+func TestSign(t *testing.T) {
+	caKey, signerKey := newRSAKey(t), newRSAKey(t)
+	ca := newCertificate(t, "Boot CA", caKey, nil, nil, nil)
+	cert := newCertificate(t, "boot-service", signerKey, ca, caKey, []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning})
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	var certPEM bytes.Buffer
+	for _, c := range []*x509.Certificate{cert, ca} {
+		pem.Encode(&certPEM, &pem.Block{Type: "CERTIFICATE", Bytes: c.Raw}) //nolint:errcheck
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(signerKey)})
+	if err := os.WriteFile(certFile, certPEM.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := Load(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !bytes.Equal(signer.CertificatePEM(), certPEM.Bytes()) {
+		t.Error("CertificatePEM does not return the certificate chain")
+	}
+	if parsed, err := Parse(certPEM.Bytes(), keyPEM); err != nil || !bytes.Equal(parsed.CertificatePEM(), certPEM.Bytes()) {
+		t.Errorf("Parse failed: %v", err)
+	}
+	if _, err := Parse(keyPEM, keyPEM); err == nil {
+		t.Error("expected error parsing a key as the certificate")
+	}
+
+	script := []byte("#!ipxe\nkernel http://files.example.com/vmlinuz\nboot\n")
+	signature, err := signer.Sign(script)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	p7, err := pkcs7.Parse(signature)
+	if err != nil {
+		t.Fatalf("parsing signature: %v", err)
+	}
+	if len(p7.Content) != 0 {
+		t.Error("signature is not detached")
+	}
+	if len(p7.Certificates) != 2 {
+		t.Errorf("signature carries %d certificates, want the signer and its intermediate", len(p7.Certificates))
+	}
+	for _, signer := range p7.Signers {
+		if len(signer.AuthenticatedAttributes) != 0 {
+			t.Error("signature has signed attributes, which iPXE cannot verify")
+		}
+	}
+	p7.Content = script
+	if err := p7.Verify(); err != nil {
+		t.Errorf("signature does not verify: %v", err)
+	}
+	p7.Content = append(script, '#')
+	if err := p7.Verify(); err == nil {
+		t.Error("signature verifies a modified script")
+	}
+}
+
+func TestNew_RejectsUnusableKeys(t *testing.T) {
+	key := newRSAKey(t)
+	codeSigning := []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		cert *x509.Certificate
+		key  crypto.PrivateKey
+	}{
+		"server certificate": {newCertificate(t, "server", key, nil, nil, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}), key},
+		"mismatched key":     {newCertificate(t, "signer", key, nil, nil, codeSigning), newRSAKey(t)},
+		"ECDSA key":          {newCertificate(t, "signer", ecKey, nil, nil, codeSigning), ecKey},
+	}
+	for name, tt := range tests {
+		if _, err := New([]*x509.Certificate{tt.cert}, tt.key); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := New([]*x509.Certificate{newCertificate(t, "signer", key, nil, nil, codeSigning)}, key); err != nil {
+		t.Errorf("New failed for a code signing certificate: %v", err)
+	}
+}
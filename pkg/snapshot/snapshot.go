@@ -0,0 +1,288 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package snapshot dumps and restores the full resource state held in a
+// storage backend, for backups, migrations between backends, and seeding a
+// service from files kept in version control.
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+	"gopkg.in/yaml.v3"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/artifacts"
+)
+
+// FormatVersion is the snapshot format written by Export
+const FormatVersion = 1
+
+// Resource type names as stored in the backend
+const (
+	nodeType              = "Node"
+	bootConfigurationType = "BootConfiguration"
+	bmcType               = "BMC"
+)
+
+// Snapshot is the full resource state of a service
+type Snapshot struct {
+	Version            int                    `json:"version" yaml:"version"`
+	ExportedAt         time.Time              `json:"exportedAt" yaml:"exportedAt"`
+	Nodes              []v1.Node              `json:"nodes" yaml:"nodes"`
+	BootConfigurations []v1.BootConfiguration `json:"bootConfigurations" yaml:"bootConfigurations"`
+	BMCs               []v1.BMC               `json:"bmcs" yaml:"bmcs"`
+	Artifacts          []artifacts.Artifact   `json:"artifacts,omitempty" yaml:"artifacts,omitempty"`
+}
+
+// Counts records the changes an import made to one resource type
+type Counts struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Deleted int `json:"deleted"`
+}
+
+// Result summarizes an import by resource type
+type Result map[string]Counts
+
+// ImportOptions controls how a snapshot is applied
+type ImportOptions struct {
+	// Replace deletes stored resources that are not in the snapshot
+	Replace bool
+	// DryRun reports the changes without writing them
+	DryRun bool
+}
+
+// record is one resource ready to be written
+type record struct {
+	uid  string
+	data json.RawMessage
+}
+
+// Export reads every node, boot configuration, BMC, and artifact record
+// from backend
+func Export(ctx context.Context, backend fabricaStorage.StorageBackend) (*Snapshot, error) {
+	snapshot := &Snapshot{Version: FormatVersion, ExportedAt: time.Now().UTC()}
+
+	if err := loadAll(ctx, backend, nodeType, &snapshot.Nodes); err != nil {
+		return nil, err
+	}
+	if err := loadAll(ctx, backend, bootConfigurationType, &snapshot.BootConfigurations); err != nil {
+		return nil, err
+	}
+	if err := loadAll(ctx, backend, bmcType, &snapshot.BMCs); err != nil {
+		return nil, err
+	}
+	if err := loadAll(ctx, backend, artifacts.ResourceType, &snapshot.Artifacts); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(snapshot.Nodes, func(i, j int) bool { return snapshot.Nodes[i].Metadata.UID < snapshot.Nodes[j].Metadata.UID })
+	sort.Slice(snapshot.BootConfigurations, func(i, j int) bool {
+		return snapshot.BootConfigurations[i].Metadata.UID < snapshot.BootConfigurations[j].Metadata.UID
+	})
+	sort.Slice(snapshot.BMCs, func(i, j int) bool { return snapshot.BMCs[i].Metadata.UID < snapshot.BMCs[j].Metadata.UID })
+	sort.Slice(snapshot.Artifacts, func(i, j int) bool { return snapshot.Artifacts[i].Name < snapshot.Artifacts[j].Name })
+	return snapshot, nil
+}
+
+// loadAll decodes every stored resource of resourceType into out, which must
+// point to a slice
+func loadAll(ctx context.Context, backend fabricaStorage.StorageBackend, resourceType string, out interface{}) error {
+	rawData, err := backend.LoadAll(ctx, resourceType)
+	if err != nil {
+		return fmt.Errorf("loading %s resources: %w", resourceType, err)
+	}
+
+	var list bytes.Buffer
+	list.WriteByte('[')
+	for i, data := range rawData {
+		if i > 0 {
+			list.WriteByte(',')
+		}
+		list.Write(data)
+	}
+	list.WriteByte(']')
+
+	if err := json.Unmarshal(list.Bytes(), out); err != nil {
+		return fmt.Errorf("decoding %s resources: %w", resourceType, err)
+	}
+	return nil
+}
+
+// Write encodes snapshot as indented JSON
+func Write(w io.Writer, snapshot *Snapshot) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(snapshot)
+}
+
+// Read decodes a snapshot written by Write. YAML is accepted as well, and
+// unknown fields are rejected so that typos do not silently drop data.
+func Read(r io.Reader) (*Snapshot, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	var snapshot Snapshot
+	if err := decoder.Decode(&snapshot); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("snapshot is empty")
+		}
+		return nil, err
+	}
+	if snapshot.Version > FormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d (newest supported is %d)", snapshot.Version, FormatVersion)
+	}
+	return &snapshot, nil
+}
+
+// Validate checks every resource in the snapshot and returns all problems
+// found, joined into one error
+func (s *Snapshot) Validate(ctx context.Context) error {
+	var problems []string
+	seen := map[string]bool{}
+	check := func(kind, uid, name string, err error) {
+		location := kind + " " + uid
+		if name != "" && name != uid {
+			location += " (" + name + ")"
+		}
+		switch {
+		case uid == "":
+			problems = append(problems, fmt.Sprintf("%s %s: missing metadata.uid", kind, name))
+		case seen[kind+"/"+uid]:
+			problems = append(problems, location+": duplicate uid")
+		}
+		seen[kind+"/"+uid] = true
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", location, err))
+		}
+	}
+
+	for i := range s.Nodes {
+		node := &s.Nodes[i]
+		check(nodeType, node.Metadata.UID, node.Spec.XName, node.Validate(ctx))
+	}
+	for i := range s.BootConfigurations {
+		config := &s.BootConfigurations[i]
+		check(bootConfigurationType, config.Metadata.UID, config.Metadata.Name, config.Validate(ctx))
+	}
+	for i := range s.BMCs {
+		bmc := &s.BMCs[i]
+		check(bmcType, bmc.Metadata.UID, bmc.Metadata.Name, bmc.Validate(ctx))
+	}
+	for i := range s.Artifacts {
+		artifact := &s.Artifacts[i]
+		check(artifacts.ResourceType, artifact.Name, "", artifact.Validate())
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid snapshot:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// Import validates snapshot and writes its resources to backend, keeping
+// their UIDs. Existing resources with the same UID are overwritten; with
+// opts.Replace, stored resources missing from the snapshot are deleted.
+// Nothing is written when validation fails.
+func Import(ctx context.Context, backend fabricaStorage.StorageBackend, snapshot *Snapshot, opts ImportOptions) (Result, error) {
+	if err := snapshot.Validate(ctx); err != nil {
+		return nil, err
+	}
+
+	records := map[string][]record{}
+	add := func(resourceType, uid string, resource interface{}) error {
+		data, err := json.Marshal(resource)
+		if err != nil {
+			return fmt.Errorf("encoding %s %s: %w", resourceType, uid, err)
+		}
+		records[resourceType] = append(records[resourceType], record{uid: uid, data: data})
+		return nil
+	}
+	for i := range snapshot.Nodes {
+		if err := add(nodeType, snapshot.Nodes[i].Metadata.UID, &snapshot.Nodes[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range snapshot.BootConfigurations {
+		if err := add(bootConfigurationType, snapshot.BootConfigurations[i].Metadata.UID, &snapshot.BootConfigurations[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range snapshot.BMCs {
+		if err := add(bmcType, snapshot.BMCs[i].Metadata.UID, &snapshot.BMCs[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range snapshot.Artifacts {
+		if err := add(artifacts.ResourceType, snapshot.Artifacts[i].Name, &snapshot.Artifacts[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	result := Result{}
+	for _, resourceType := range []string{nodeType, bootConfigurationType, bmcType, artifacts.ResourceType} {
+		counts, err := importType(ctx, backend, resourceType, records[resourceType], opts)
+		if err != nil {
+			return result, err
+		}
+		result[resourceType] = counts
+	}
+	return result, nil
+}
+
+func importType(ctx context.Context, backend fabricaStorage.StorageBackend, resourceType string, records []record, opts ImportOptions) (Counts, error) {
+	var counts Counts
+
+	existing, err := backend.List(ctx, resourceType)
+	if err != nil {
+		return counts, fmt.Errorf("listing %s resources: %w", resourceType, err)
+	}
+	stored := make(map[string]bool, len(existing))
+	for _, uid := range existing {
+		stored[uid] = true
+	}
+
+	wanted := make(map[string]bool, len(records))
+	for _, rec := range records {
+		wanted[rec.uid] = true
+		if stored[rec.uid] {
+			counts.Updated++
+		} else {
+			counts.Created++
+		}
+		if opts.DryRun {
+			continue
+		}
+		if err := backend.Save(ctx, resourceType, rec.uid, rec.data); err != nil {
+			return counts, fmt.Errorf("saving %s %s: %w", resourceType, rec.uid, err)
+		}
+	}
+
+	if !opts.Replace {
+		return counts, nil
+	}
+	for _, uid := range existing {
+		if wanted[uid] {
+			continue
+		}
+		counts.Deleted++
+		if opts.DryRun {
+			continue
+		}
+		if err := backend.Delete(ctx, resourceType, uid); err != nil && !errors.Is(err, fabricaStorage.ErrNotFound) {
+			return counts, fmt.Errorf("deleting %s %s: %w", resourceType, uid, err)
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/artifacts"
+)
+
+func newTestBackend(t *testing.T) fabricaStorage.StorageBackend {
+	t.Helper()
+
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	return backend
+}
+
+func saveTestResource(t *testing.T, backend fabricaStorage.StorageBackend, resourceType, uid string, value interface{}) {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("failed to encode %s: %v", uid, err)
+	}
+	if err := backend.Save(context.Background(), resourceType, uid, data); err != nil {
+		t.Fatalf("failed to save %s: %v", uid, err)
+	}
+}
+
+func testNode(uid, xname string) v1.Node {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return v1.Node{
+		APIVersion: "boot.openchami.io/v1",
+		Kind:       "Node",
+		Metadata:   resource.Metadata{UID: uid, Name: xname, CreatedAt: created, UpdatedAt: created},
+		Spec:       v1.NodeSpec{XName: xname, NID: 1, BootMAC: "aa:bb:cc:dd:ee:01", Groups: []string{"compute"}},
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := newTestBackend(t)
+
+	saveTestResource(t, source, "Node", "node-1", testNode("node-1", "x0c0s0b0n0"))
+	saveTestResource(t, source, "BootConfiguration", "bootconfiguration-1", v1.BootConfiguration{
+		Kind:     "BootConfiguration",
+		Metadata: resource.Metadata{UID: "bootconfiguration-1", Name: "compute"},
+		Spec:     v1.BootConfigurationSpec{Kernel: "http://files/vmlinuz", Groups: []string{"compute"}},
+	})
+	saveTestResource(t, source, "BMC", "bmc-1", v1.BMC{Kind: "BMC", Metadata: resource.Metadata{UID: "bmc-1", Name: "x0c0s0b0"}})
+	saveTestResource(t, source, artifacts.ResourceType, "vmlinuz", artifacts.Artifact{
+		Name: "vmlinuz", URL: "http://files/vmlinuz", SHA256: strings.Repeat("a", 64),
+	})
+
+	exported, err := Export(ctx, source)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, exported); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	read, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+
+	target := newTestBackend(t)
+	result, err := Import(ctx, target, read, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	for _, resourceType := range []string{"Node", "BootConfiguration", "BMC", artifacts.ResourceType} {
+		if result[resourceType] != (Counts{Created: 1}) {
+			t.Errorf("%s: got %+v, want 1 created", resourceType, result[resourceType])
+		}
+	}
+
+	reexported, err := Export(ctx, target)
+	if err != nil {
+		t.Fatalf("Export of imported state failed: %v", err)
+	}
+	reexported.ExportedAt = exported.ExportedAt
+	want, _ := json.Marshal(exported)
+	got, _ := json.Marshal(reexported)
+	if !bytes.Equal(got, want) {
+		t.Errorf("imported state differs from source:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestImportReplaceAndDryRun(t *testing.T) {
+	ctx := context.Background()
+	backend := newTestBackend(t)
+	saveTestResource(t, backend, "Node", "node-1", testNode("node-1", "x0c0s0b0n0"))
+	saveTestResource(t, backend, "Node", "node-2", testNode("node-2", "x0c0s1b0n0"))
+
+	state := &Snapshot{Version: FormatVersion, Nodes: []v1.Node{testNode("node-1", "x0c0s0b0n0"), testNode("node-3", "x0c0s2b0n0")}}
+
+	result, err := Import(ctx, backend, state, ImportOptions{Replace: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if want := (Counts{Created: 1, Updated: 1, Deleted: 1}); result["Node"] != want {
+		t.Errorf("dry run: got %+v, want %+v", result["Node"], want)
+	}
+	if uids, _ := backend.List(ctx, "Node"); len(uids) != 2 {
+		t.Fatalf("dry run changed storage: %v", uids)
+	}
+
+	if _, err := Import(ctx, backend, state, ImportOptions{Replace: true}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	uids, _ := backend.List(ctx, "Node")
+	if strings.Join(uids, ",") != "node-1,node-3" && strings.Join(uids, ",") != "node-3,node-1" {
+		t.Errorf("got stored nodes %v, want node-1 and node-3", uids)
+	}
+}
+
+func TestImportRejectsInvalidSnapshot(t *testing.T) {
+	ctx := context.Background()
+	backend := newTestBackend(t)
+
+	invalid := testNode("node-2", "x0c0s1b0n0")
+	invalid.Spec.BootMAC = "not-a-mac"
+	state := &Snapshot{Nodes: []v1.Node{
+		testNode("node-1", "x0c0s0b0n0"),
+		testNode("node-1", "x0c0s0b0n0"),
+		invalid,
+		testNode("", "x0c0s2b0n0"),
+	}}
+
+	_, err := Import(ctx, backend, state, ImportOptions{})
+	if err == nil {
+		t.Fatal("expected invalid snapshot to be rejected")
+	}
+	for _, want := range []string{"node-1 (x0c0s0b0n0): duplicate uid", "node-2 (x0c0s1b0n0):", "x0c0s2b0n0: missing metadata.uid"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error missing %q: %v", want, err)
+		}
+	}
+	if uids, _ := backend.List(ctx, "Node"); len(uids) != 0 {
+		t.Errorf("expected nothing written, found %v", uids)
+	}
+}
+
+func TestReadRejectsUnknownFieldsAndNewerVersions(t *testing.T) {
+	if _, err := Read(strings.NewReader(`{"version": 1, "nodez": []}`)); err == nil {
+		t.Error("expected unknown field to be rejected")
+	}
+	if _, err := Read(strings.NewReader(`{"version": 99}`)); err == nil {
+		t.Error("expected newer snapshot version to be rejected")
+	}
+	if _, err := Read(strings.NewReader("version: 1\nnodes: []\n")); err != nil {
+		t.Errorf("expected YAML snapshot to be accepted: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package storagemigration marks storage while a restore, import, or
+// migration rewrites it, so replicas sharing the storage report themselves
+// not ready instead of serving boot scripts from half-written data.
+//
+// The marker is a record in storage that the command writing it renews
+// while it runs. A command that dies leaves a marker that expires on its
+// own.
+package storagemigration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+)
+
+// ResourceType is the storage resource type of the marker
+const ResourceType = "StorageMigration"
+
+// markerKey is the storage key of the single marker record
+const markerKey = "marker"
+
+// TTL is how long a marker lasts without being renewed
+const TTL = 2 * time.Minute
+
+// Marker describes a migration in progress
+type Marker struct {
+	// Operation names the command, such as restore
+	Operation string    `json:"operation"`
+	Host      string    `json:"host,omitempty"`
+	PID       int       `json:"pid,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Begin marks storage as being migrated by operation and renews the marker
+// until end is called, which removes it
+func Begin(ctx context.Context, backend fabricaStorage.StorageBackend, operation string, logger *log.Logger) (end func(), err error) {
+	host, _ := os.Hostname()
+	now := time.Now().UTC()
+	marker := Marker{Operation: operation, Host: host, PID: os.Getpid(), StartedAt: now, ExpiresAt: now.Add(TTL)}
+	if err := save(ctx, backend, marker); err != nil {
+		return nil, fmt.Errorf("marking storage as migrating: %w", err)
+	}
+
+	renewCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(TTL / 4)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-renewCtx.Done():
+				return
+			case <-ticker.C:
+				marker.ExpiresAt = time.Now().UTC().Add(TTL)
+				if err := save(renewCtx, backend, marker); err != nil && renewCtx.Err() == nil {
+					logger.Printf("Failed to renew the storage migration marker: %v", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+		err := backend.Delete(context.WithoutCancel(ctx), ResourceType, markerKey)
+		if err != nil && !errors.Is(err, fabricaStorage.ErrNotFound) {
+			logger.Printf("Failed to remove the storage migration marker, which expires at %s: %v", marker.ExpiresAt.Format(time.RFC3339), err)
+		}
+	}, nil
+}
+
+// Active returns the migration in progress, or nil when there is none or
+// its marker expired
+func Active(ctx context.Context, backend fabricaStorage.StorageBackend) (*Marker, error) {
+	data, err := backend.Load(ctx, ResourceType, markerKey)
+	if errors.Is(err, fabricaStorage.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var marker Marker
+	if err := json.Unmarshal(data, &marker); err != nil {
+		return nil, fmt.Errorf("decoding storage migration marker: %w", err)
+	}
+	if !time.Now().Before(marker.ExpiresAt) {
+		return nil, nil
+	}
+	return &marker, nil
+}
+
+// Check fails while a migration is in progress, for use as a readiness
+// check
+func Check(backend fabricaStorage.StorageBackend) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		marker, err := Active(ctx, backend)
+		switch {
+		case err != nil:
+			return err
+		case marker != nil:
+			return fmt.Errorf("storage %s in progress since %s (host %s, pid %d)",
+				marker.Operation, marker.StartedAt.Format(time.RFC3339), marker.Host, marker.PID)
+		}
+		return nil
+	}
+}
+
+func save(ctx context.Context, backend fabricaStorage.StorageBackend, marker Marker) error {
+	data, err := json.Marshal(marker)
+	if err != nil {
+		return err
+	}
+	return backend.Save(ctx, ResourceType, markerKey, data)
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package storagemigration
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+)
+
+func TestBegin(t *testing.T) {
+	ctx := context.Background()
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	check := Check(backend)
+	if err := check(ctx); err != nil {
+		t.Fatalf("check before a migration = %v, want nil", err)
+	}
+
+	end, err := Begin(ctx, backend, "restore", log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("Begin returned error: %v", err)
+	}
+	if err := check(ctx); err == nil || !strings.Contains(err.Error(), "storage restore in progress") {
+		t.Errorf("check during a migration = %v, want it to fail", err)
+	}
+
+	end()
+	if err := check(ctx); err != nil {
+		t.Errorf("check after a migration = %v, want nil", err)
+	}
+}
+
+func TestActive_IgnoresExpiredMarker(t *testing.T) {
+	ctx := context.Background()
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	// A command that died without removing its marker
+	data, _ := json.Marshal(Marker{Operation: "import", StartedAt: time.Now().Add(-time.Hour), ExpiresAt: time.Now().Add(-time.Minute)})
+	if err := backend.Save(ctx, ResourceType, markerKey, data); err != nil {
+		t.Fatal(err)
+	}
+	if marker, err := Active(ctx, backend); err != nil || marker != nil {
+		t.Errorf("Active = %+v, %v; want an expired marker ignored", marker, err)
+	}
+}
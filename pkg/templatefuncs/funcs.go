@@ -0,0 +1,293 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package templatefuncs is the function library of the node templates that
+// render into boot scripts: kernel parameters, node overlays, parameter
+// profiles, and chain URLs. It covers string handling, defaults, base64,
+// MAC formatting, and IP subnet math, so templates can compute values such
+// as gateway addresses instead of storing them.
+//
+// The string functions follow the argument order of their sprig namesakes,
+// with the value last so they read well in pipelines:
+//
+//	{{.Hostname | trimPrefix "node-" | upper}}
+//
+// Nothing here reads files, runs commands, or otherwise reaches outside the
+// template; env only reads variables named BOOT_SERVICE_TEMPLATE_<name>.
+package templatefuncs
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// EnvPrefix is prepended to the names env looks up, so templates cannot read
+// the service's own settings and credentials
+const EnvPrefix = "BOOT_SERVICE_TEMPLATE_"
+
+// Funcs returns the function library. Each call returns a new map, which
+// callers may extend.
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		// Strings
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old, replacement, s string) string { return strings.ReplaceAll(s, old, replacement) },
+		"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"split":      split,
+		"join":       join,
+		"quote":      strconv.Quote,
+
+		// Defaults
+		"default":  defaultValue,
+		"empty":    empty,
+		"coalesce": coalesce,
+		"ternary":  ternary,
+
+		// Numbers
+		"atoi": func(s string) (int, error) { return strconv.Atoi(strings.TrimSpace(s)) },
+		"add":  func(a, b any) (int, error) { return arith(a, b, func(x, y int) int { return x + y }) },
+		"sub":  func(a, b any) (int, error) { return arith(a, b, func(x, y int) int { return x - y }) },
+
+		// Encoding
+		"b64enc": func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+		"b64dec": func(s string) (string, error) {
+			decoded, err := base64.StdEncoding.DecodeString(s)
+			return string(decoded), err
+		},
+
+		// Network
+		"macFormat":   macFormat,
+		"cidrNetwork": cidrNetwork,
+		"cidrNetmask": cidrNetmask,
+		"cidrPrefix":  cidrPrefix,
+		"cidrHost":    cidrHost,
+		"ipAdd":       ipAdd,
+
+		// Environment
+		"env": func(name string) string { return os.Getenv(EnvPrefix + name) },
+	}
+}
+
+// split splits s around sep into a list
+func split(sep, s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, sep)
+}
+
+// join concatenates the elements of a list, rendered as strings, with sep
+func join(sep string, list any) (string, error) {
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: expected a list, got %T", list)
+	}
+	parts := make([]string, value.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(value.Index(i).Interface())
+	}
+	return strings.Join(parts, sep), nil
+}
+
+// defaultValue returns value, or def when value is empty
+func defaultValue(def, value any) any {
+	if empty(value) {
+		return def
+	}
+	return value
+}
+
+// empty reports whether value is nil, zero, or an empty string, list, or map
+func empty(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
+
+// coalesce returns the first value that is not empty
+func coalesce(values ...any) any {
+	for _, value := range values {
+		if !empty(value) {
+			return value
+		}
+	}
+	return nil
+}
+
+// ternary returns yes if condition is true and no otherwise
+func ternary(yes, no any, condition bool) any {
+	if condition {
+		return yes
+	}
+	return no
+}
+
+// arith applies op to two integers, given as numbers or decimal strings,
+// such as {{add .NID 100}}
+func arith(a, b any, op func(x, y int) int) (int, error) {
+	x, err := toInt(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := toInt(b)
+	if err != nil {
+		return 0, err
+	}
+	return op(x, y), nil
+}
+
+func toInt(value any) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int32:
+		return int(v), nil
+	case int64:
+		return int(v), nil
+	case string:
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return 0, fmt.Errorf("not an integer: %q", v)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("not an integer: %v", value)
+	}
+}
+
+// macFormat rewrites a MAC address in any notation as lowercase hex octets
+// joined by sep, e.g. {{macFormat "-" .BootMAC}} or {{macFormat "" .BootMAC}}
+func macFormat(sep, mac string) (string, error) {
+	hw, err := net.ParseMAC(mac)
+	if err != nil {
+		compact := strings.NewReplacer(":", "", "-", "", ".", "").Replace(mac)
+		if len(compact) != 12 {
+			return "", fmt.Errorf("invalid MAC address: %q", mac)
+		}
+		if hw, err = net.ParseMAC(compact[0:2] + ":" + compact[2:4] + ":" + compact[4:6] + ":" + compact[6:8] + ":" + compact[8:10] + ":" + compact[10:12]); err != nil {
+			return "", fmt.Errorf("invalid MAC address: %q", mac)
+		}
+	}
+	octets := make([]string, len(hw))
+	for i, b := range hw {
+		octets[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(octets, sep), nil
+}
+
+func parseCIDR(cidr string) (net.IP, *net.IPNet, error) {
+	ip, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CIDR: %q", cidr)
+	}
+	return ip, network, nil
+}
+
+// cidrNetwork returns the network address of a CIDR, e.g. 10.1.2.0 for
+// 10.1.2.3/24
+func cidrNetwork(cidr string) (string, error) {
+	_, network, err := parseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+	return network.IP.String(), nil
+}
+
+// cidrNetmask returns the dotted netmask of an IPv4 CIDR, e.g. 255.255.255.0
+// for 10.1.2.3/24
+func cidrNetmask(cidr string) (string, error) {
+	_, network, err := parseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+	if network.IP.To4() == nil {
+		return "", fmt.Errorf("cidrNetmask: %q is not an IPv4 CIDR", cidr)
+	}
+	return net.IP(network.Mask).String(), nil
+}
+
+// cidrPrefix returns the prefix length of a CIDR, e.g. 24 for 10.1.2.3/24
+func cidrPrefix(cidr string) (int, error) {
+	_, network, err := parseCIDR(cidr)
+	if err != nil {
+		return 0, err
+	}
+	ones, _ := network.Mask.Size()
+	return ones, nil
+}
+
+// cidrHost returns the nth address of a CIDR's network, e.g. the gateway
+// 10.1.0.1 for {{cidrHost 1 "10.1.2.3/16"}}. Negative n counts back from the
+// last address.
+func cidrHost(n any, cidr string) (string, error) {
+	index, err := toInt(n)
+	if err != nil {
+		return "", err
+	}
+	_, network, err := parseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+	ones, bits := network.Mask.Size()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	offset := big.NewInt(int64(index))
+	if index < 0 {
+		offset.Add(offset, size)
+	}
+	if offset.Sign() < 0 || offset.Cmp(size) >= 0 {
+		return "", fmt.Errorf("cidrHost: %d is outside %s", index, network)
+	}
+	return addToIP(network.IP, offset)
+}
+
+// ipAdd returns ip advanced by n addresses, e.g. 10.1.2.13 for
+// {{ipAdd 10 "10.1.2.3"}}
+func ipAdd(n any, ip string) (string, error) {
+	delta, err := toInt(n)
+	if err != nil {
+		return "", err
+	}
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	if parsed == nil {
+		return "", fmt.Errorf("invalid IP address: %q", ip)
+	}
+	return addToIP(parsed, big.NewInt(int64(delta)))
+}
+
+func addToIP(ip net.IP, delta *big.Int) (string, error) {
+	size := net.IPv6len
+	if v4 := ip.To4(); v4 != nil {
+		ip, size = v4, net.IPv4len
+	}
+	value := new(big.Int).SetBytes(ip)
+	value.Add(value, delta)
+	if value.Sign() < 0 || value.BitLen() > size*8 {
+		return "", errors.New("IP address arithmetic overflowed")
+	}
+	result := value.FillBytes(make([]byte, size))
+	return net.IP(result).String(), nil
+}
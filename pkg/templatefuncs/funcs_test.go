@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package templatefuncs
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+)
+
+func render(t *testing.T, text string, data any) (string, error) {
+	t.Helper()
+	tmpl, err := template.New("test").Funcs(Funcs()).Parse(text)
+	if err != nil {
+		t.Fatalf("parsing %q: %v", text, err)
+	}
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	return buf.String(), err
+}
+
+func TestFuncs(t *testing.T) {
+	t.Setenv(EnvPrefix+"NTP", "10.0.0.5")
+	t.Setenv("HOME_SECRET", "leaked")
+	data := map[string]string{
+		"IP":       "10.1.2.3",
+		"NID":      "7",
+		"MAC":      "AA-BB-CC-DD-EE-FF",
+		"Hostname": "node-compute-7",
+		"Empty":    "",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"Pipeline", `{{.Hostname | trimPrefix "node-" | upper}}`, "COMPUTE-7"},
+		{"Replace", `{{replace "-" "_" .Hostname}}`, "node_compute_7"},
+		{"SplitJoin", `{{split "-" .Hostname | join ","}}`, "node,compute,7"},
+		{"Default", `{{default "ttyS0" .Empty}} {{default "ttyS0" .NID}}`, "ttyS0 7"},
+		{"Coalesce", `{{coalesce .Empty "" .IP}}`, "10.1.2.3"},
+		{"Ternary", `{{ternary "big" "small" (contains "compute" .Hostname)}}`, "big"},
+		{"Add", `{{add .NID 100}} {{sub .NID 1}}`, "107 6"},
+		{"Base64", `{{b64enc "hello"}} {{b64dec "aGVsbG8="}}`, "aGVsbG8= hello"},
+		{"MACFormat", `{{macFormat ":" .MAC}} {{macFormat "" .MAC}} {{macFormat "-" "aabbccddeeff"}}`, "aa:bb:cc:dd:ee:ff aabbccddeeff aa-bb-cc-dd-ee-ff"},
+		{"Gateway", `{{cidrHost 1 (printf "%s/16" .IP)}}`, "10.1.0.1"},
+		{"Broadcast", `{{cidrHost -1 (printf "%s/24" .IP)}}`, "10.1.2.255"},
+		{"Subnet", `{{cidrNetwork "10.1.2.3/20"}} {{cidrNetmask "10.1.2.3/20"}} {{cidrPrefix "10.1.2.3/20"}}`, "10.1.0.0 255.255.240.0 20"},
+		{"IPAdd", `{{.IP | ipAdd 300}}`, "10.1.3.47"},
+		{"IPv6", `{{cidrHost 1 "fd00::/64"}}`, "fd00::1"},
+		{"Env", `{{env "NTP"}}|{{env "HOME_SECRET"}}`, "10.0.0.5|"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := render(t, tt.template, data)
+			if err != nil {
+				t.Fatalf("render failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("render = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuncsErrors(t *testing.T) {
+	for _, text := range []string{
+		`{{macFormat ":" "not-a-mac"}}`,
+		`{{cidrHost 1 "10.1.2.3"}}`,
+		`{{cidrHost 256 "10.1.2.0/24"}}`,
+		`{{cidrNetmask "fd00::/64"}}`,
+		`{{ipAdd 1 "255.255.255.255"}}`,
+		`{{add "seven" 1}}`,
+	} {
+		if _, err := render(t, text, nil); err == nil {
+			t.Errorf("%s rendered without error", text)
+		}
+	}
+}
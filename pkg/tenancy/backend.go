@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package tenancy
+
+import (
+	"context"
+	"encoding/json"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+)
+
+// scopedTypes are the storage resource types that carry spec.tenant
+var scopedTypes = map[string]bool{
+	"Node":              true,
+	"BootConfiguration": true,
+}
+
+// Backend is a storage backend that enforces tenant scoping. Resources of
+// other tenants are reported as not found, and writes that name or replace
+// another tenant's resource fail with ErrForbidden. Because every API
+// persists through storage, the handlers cannot bypass it.
+type Backend struct {
+	fabricaStorage.StorageBackend
+}
+
+// NewBackend wraps backend
+func NewBackend(backend fabricaStorage.StorageBackend) *Backend {
+	return &Backend{StorageBackend: backend}
+}
+
+// LoadAll returns the resources visible in ctx
+func (b *Backend) LoadAll(ctx context.Context, resourceType string) ([]json.RawMessage, error) {
+	items, err := b.StorageBackend.LoadAll(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	return b.filter(ctx, resourceType, items), nil
+}
+
+// LoadAllWithVersion returns the resources visible in ctx at a specific API version
+func (b *Backend) LoadAllWithVersion(ctx context.Context, resourceType, version string) ([]json.RawMessage, error) {
+	items, err := b.StorageBackend.LoadAllWithVersion(ctx, resourceType, version)
+	if err != nil {
+		return nil, err
+	}
+	return b.filter(ctx, resourceType, items), nil
+}
+
+// Load returns a resource if it is visible in ctx
+func (b *Backend) Load(ctx context.Context, resourceType, uid string) (json.RawMessage, error) {
+	data, err := b.StorageBackend.Load(ctx, resourceType, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !b.visible(ctx, resourceType, data) {
+		return nil, fabricaStorage.ErrNotFound
+	}
+	return data, nil
+}
+
+// LoadWithVersion returns a resource at a specific API version if it is visible in ctx
+func (b *Backend) LoadWithVersion(ctx context.Context, resourceType, uid, version string) (json.RawMessage, string, error) {
+	data, served, err := b.StorageBackend.LoadWithVersion(ctx, resourceType, uid, version)
+	if err != nil {
+		return nil, "", err
+	}
+	if !b.visible(ctx, resourceType, data) {
+		return nil, "", fabricaStorage.ErrNotFound
+	}
+	return data, served, nil
+}
+
+// Exists reports whether a resource exists and is visible in ctx
+func (b *Backend) Exists(ctx context.Context, resourceType, uid string) (bool, error) {
+	if _, ok := FromContext(ctx); !ok || !scopedTypes[resourceType] {
+		return b.StorageBackend.Exists(ctx, resourceType, uid)
+	}
+	if _, err := b.Load(ctx, resourceType, uid); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// List returns the UIDs of the resources visible in ctx
+func (b *Backend) List(ctx context.Context, resourceType string) ([]string, error) {
+	uids, err := b.StorageBackend.List(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := FromContext(ctx); !ok || !scopedTypes[resourceType] {
+		return uids, nil
+	}
+	visible := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		if ok, _ := b.Exists(ctx, resourceType, uid); ok {
+			visible = append(visible, uid)
+		}
+	}
+	return visible, nil
+}
+
+// Save stores a resource owned by the tenant of ctx
+func (b *Backend) Save(ctx context.Context, resourceType, uid string, data json.RawMessage) error {
+	if err := b.checkWrite(ctx, resourceType, uid, data); err != nil {
+		return err
+	}
+	return b.StorageBackend.Save(ctx, resourceType, uid, data)
+}
+
+// SaveWithVersion stores a resource owned by the tenant of ctx at a specific API version
+func (b *Backend) SaveWithVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, version string) error {
+	if err := b.checkWrite(ctx, resourceType, uid, data); err != nil {
+		return err
+	}
+	return b.StorageBackend.SaveWithVersion(ctx, resourceType, uid, data, version)
+}
+
+// Delete removes a resource if it is visible in ctx
+func (b *Backend) Delete(ctx context.Context, resourceType, uid string) error {
+	if _, err := b.Load(ctx, resourceType, uid); err != nil {
+		return err
+	}
+	return b.StorageBackend.Delete(ctx, resourceType, uid)
+}
+
+// checkWrite refuses to store data owned by another tenant or to replace a
+// resource of another tenant
+func (b *Backend) checkWrite(ctx context.Context, resourceType, uid string, data json.RawMessage) error {
+	if _, ok := FromContext(ctx); !ok || !scopedTypes[resourceType] {
+		return nil
+	}
+	if !Visible(ctx, Owner(data)) {
+		return ErrForbidden
+	}
+	existing, err := b.StorageBackend.Load(ctx, resourceType, uid)
+	if err == nil && !Visible(ctx, Owner(existing)) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func (b *Backend) visible(ctx context.Context, resourceType string, data json.RawMessage) bool {
+	return !scopedTypes[resourceType] || Visible(ctx, Owner(data))
+}
+
+func (b *Backend) filter(ctx context.Context, resourceType string, items []json.RawMessage) []json.RawMessage {
+	if _, ok := FromContext(ctx); !ok || !scopedTypes[resourceType] {
+		return items
+	}
+	visible := make([]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		if Visible(ctx, Owner(item)) {
+			visible = append(visible, item)
+		}
+	}
+	return visible
+}
+
+// Owner returns the spec.tenant of a stored resource
+func Owner(data json.RawMessage) string {
+	var resource struct {
+		Spec struct {
+			Tenant string `json:"tenant"`
+		} `json:"spec"`
+	}
+	if json.Unmarshal(data, &resource) != nil {
+		return ""
+	}
+	return resource.Spec.Tenant
+}
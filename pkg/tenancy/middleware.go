@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package tenancy
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/auth"
+)
+
+// Middleware restricts each request to the tenant in the ClusterID claim of
+// its verified token. Tokens with adminScope are unrestricted. It must run
+// after the authentication middleware; requests without verified claims are
+// refused with 401 and tokens without a ClusterID with 403.
+func Middleware(adminScope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, err := auth.GetClaimsFromRequest(r)
+			if err != nil {
+				httputil.WriteError(w, http.StatusUnauthorized, "Unauthorized",
+					"A bearer token is required to access tenant resources")
+				return
+			}
+			if adminScope != "" && slices.Contains(claims.Scope, adminScope) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if claims.ClusterID == "" {
+				httputil.WriteError(w, http.StatusForbidden, "Forbidden",
+					"The token has no cluster_id claim naming its tenant")
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithTenant(r.Context(), claims.ClusterID)))
+		})
+	}
+}
+
+// AdministratorsOnly refuses requests whose verified token lacks adminScope,
+// for the APIs that act on every tenant's resources. It must run after the
+// authentication middleware; requests without verified claims are refused
+// with 401 and tokens without adminScope, or any token when it is empty,
+// with 403.
+func AdministratorsOnly(adminScope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, err := auth.GetClaimsFromRequest(r)
+			if err != nil {
+				httputil.WriteError(w, http.StatusUnauthorized, "Unauthorized",
+					"A bearer token is required to access administration APIs")
+				return
+			}
+			if adminScope == "" || !slices.Contains(claims.Scope, adminScope) {
+				httputil.WriteError(w, http.StatusForbidden, "Forbidden",
+					"Administration APIs require a token with the admin scope")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Unscoped refuses requests restricted to a tenant with 403, for the APIs that
+// act on every tenant's resources. Requests without a tenant, which includes
+// every request when tenancy is disabled, pass through.
+func Unscoped(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, scoped := FromContext(r.Context()); scoped {
+			httputil.WriteError(w, http.StatusForbidden, "Forbidden",
+				"This API requires an administrator token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package tenancy scopes nodes and boot configurations to tenants.
+//
+// A request's tenant is taken from the ClusterID claim of its bearer token and
+// carried in its context. Resources record their owner in spec.tenant; a
+// context with a tenant only sees and writes that tenant's resources. A
+// context without a tenant, such as an administrator's request or an internal
+// lookup for a boot script, is unrestricted.
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrForbidden is returned when a write names or replaces a resource owned
+// by another tenant
+var ErrForbidden = errors.New("resource belongs to another tenant")
+
+type contextKey struct{}
+
+// WithTenant returns a context restricted to tenant
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenant)
+}
+
+// WithoutTenant returns a context that is not restricted to a tenant, for
+// checks that must see the resources of every tenant
+func WithoutTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, "")
+}
+
+// FromContext returns the tenant ctx is restricted to, if any
+func FromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(contextKey{}).(string)
+	return tenant, ok && tenant != ""
+}
+
+// Visible reports whether a resource owned by owner is visible in ctx
+func Visible(ctx context.Context, owner string) bool {
+	tenant, ok := FromContext(ctx)
+	return !ok || owner == tenant
+}
+
+// Assign defaults *owner to the tenant of ctx and fails with ErrForbidden if
+// it already names a different tenant
+func Assign(ctx context.Context, owner *string) error {
+	tenant, ok := FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if *owner == "" {
+		*owner = tenant
+		return nil
+	}
+	if *owner != tenant {
+		return fmt.Errorf("%w: tenant %q", ErrForbidden, *owner)
+	}
+	return nil
+}
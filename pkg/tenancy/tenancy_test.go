@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package tenancy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+	"github.com/openchami/tokensmith/pkg/authn"
+)
+
+func TestAssign(t *testing.T) {
+	tests := []struct {
+		name    string
+		tenant  string
+		owner   string
+		want    string
+		wantErr bool
+	}{
+		{name: "unrestricted keeps owner", owner: "blue", want: "blue"},
+		{name: "unrestricted keeps empty owner", want: ""},
+		{name: "defaults to tenant", tenant: "red", want: "red"},
+		{name: "same tenant", tenant: "red", owner: "red", want: "red"},
+		{name: "other tenant", tenant: "red", owner: "blue", want: "blue", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.tenant != "" {
+				ctx = WithTenant(ctx, tt.tenant)
+			}
+			owner := tt.owner
+			err := Assign(ctx, &owner)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrForbidden)) {
+				t.Fatalf("Assign() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if owner != tt.want {
+				t.Errorf("owner = %q, want %q", owner, tt.want)
+			}
+		})
+	}
+}
+
+func nodeData(tenant string) json.RawMessage {
+	return json.RawMessage(`{"kind":"Node","spec":{"xname":"x1000c0s0b0n0","tenant":"` + tenant + `"}}`)
+}
+
+func TestBackend(t *testing.T) {
+	base, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileBackend: %v", err)
+	}
+	backend := NewBackend(base)
+	admin := context.Background()
+	red := WithTenant(admin, "red")
+	blue := WithTenant(admin, "blue")
+
+	if err := backend.Save(red, "Node", "node-red", nodeData("red")); err != nil {
+		t.Fatalf("Save own node: %v", err)
+	}
+	if err := backend.Save(admin, "Node", "node-blue", nodeData("blue")); err != nil {
+		t.Fatalf("Save as admin: %v", err)
+	}
+
+	t.Run("lists only own resources", func(t *testing.T) {
+		items, err := backend.LoadAll(red, "Node")
+		if err != nil || len(items) != 1 || Owner(items[0]) != "red" {
+			t.Errorf("LoadAll(red) = %d items, %v", len(items), err)
+		}
+		uids, err := backend.List(blue, "Node")
+		if err != nil || len(uids) != 1 || uids[0] != "node-blue" {
+			t.Errorf("List(blue) = %v, %v", uids, err)
+		}
+		if items, _ := backend.LoadAll(admin, "Node"); len(items) != 2 {
+			t.Errorf("LoadAll(admin) = %d items, want 2", len(items))
+		}
+	})
+
+	t.Run("hides other tenants' resources", func(t *testing.T) {
+		if _, err := backend.Load(red, "Node", "node-blue"); !errors.Is(err, fabricaStorage.ErrNotFound) {
+			t.Errorf("Load(other tenant) error = %v, want not found", err)
+		}
+		if ok, _ := backend.Exists(red, "Node", "node-blue"); ok {
+			t.Error("Exists(other tenant) = true")
+		}
+		if err := backend.Delete(red, "Node", "node-blue"); !errors.Is(err, fabricaStorage.ErrNotFound) {
+			t.Errorf("Delete(other tenant) error = %v, want not found", err)
+		}
+	})
+
+	t.Run("refuses cross-tenant writes", func(t *testing.T) {
+		if err := backend.Save(red, "Node", "node-new", nodeData("blue")); !errors.Is(err, ErrForbidden) {
+			t.Errorf("Save(other owner) error = %v, want %v", err, ErrForbidden)
+		}
+		if err := backend.Save(red, "Node", "node-blue", nodeData("red")); !errors.Is(err, ErrForbidden) {
+			t.Errorf("Save(replace other tenant) error = %v, want %v", err, ErrForbidden)
+		}
+		data, err := base.Load(admin, "Node", "node-blue")
+		if err != nil || Owner(data) != "blue" {
+			t.Errorf("blue node changed: %s, %v", data, err)
+		}
+	})
+
+	t.Run("unscoped types are shared", func(t *testing.T) {
+		if err := backend.Save(red, "BMC", "bmc-1", json.RawMessage(`{"spec":{}}`)); err != nil {
+			t.Fatalf("Save BMC: %v", err)
+		}
+		if _, err := backend.Load(blue, "BMC", "bmc-1"); err != nil {
+			t.Errorf("Load BMC as other tenant: %v", err)
+		}
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		claims     map[string]any
+		wantStatus int
+		wantTenant string
+	}{
+		{name: "no token", wantStatus: http.StatusUnauthorized},
+		{name: "no cluster id", claims: map[string]any{"sub": "user"}, wantStatus: http.StatusForbidden},
+		{name: "tenant", claims: map[string]any{"sub": "user", "cluster_id": "red"}, wantStatus: http.StatusOK, wantTenant: "red"},
+		{name: "admin", claims: map[string]any{"sub": "ops", "cluster_id": "red", "scope": []string{"admin"}}, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotTenant string
+			handler := Middleware("admin")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotTenant, _ = FromContext(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/nodes", nil)
+			if tt.claims != nil {
+				req = req.WithContext(authn.ContextWithVerifiedClaims(req.Context(), tt.claims))
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if gotTenant != tt.wantTenant {
+				t.Errorf("tenant = %q, want %q", gotTenant, tt.wantTenant)
+			}
+		})
+	}
+}
+
+func TestUnscoped(t *testing.T) {
+	handler := Unscoped(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, tt := range []struct {
+		name       string
+		ctx        context.Context
+		wantStatus int
+	}{
+		{name: "unscoped", ctx: context.Background(), wantStatus: http.StatusOK},
+		{name: "tenant", ctx: WithTenant(context.Background(), "red"), wantStatus: http.StatusForbidden},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/backups", nil).WithContext(tt.ctx))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
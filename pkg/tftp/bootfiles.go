@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package tftp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BootFiles maps DHCP client architectures (option 93) to the boot binaries
+// served to them
+type BootFiles struct {
+	// byArch is the file name served to each architecture
+	byArch map[uint16]string
+	// paths is the local path of each file name
+	paths map[string]string
+}
+
+// ParseBootFiles parses "<arch>=<path>" entries, where arch is a DHCP
+// option 93 client architecture such as 0 (x86 BIOS), 7 (x86-64 UEFI), or 11
+// (ARM64 UEFI). Each file is served by its base name, which must not name
+// two different paths.
+func ParseBootFiles(entries []string) (*BootFiles, error) {
+	files := &BootFiles{byArch: map[uint16]string{}, paths: map[string]string{}}
+	for _, entry := range entries {
+		arch, path, ok := strings.Cut(entry, "=")
+		code, err := strconv.ParseUint(strings.TrimSpace(arch), 10, 16)
+		path = strings.TrimSpace(path)
+		if !ok || err != nil || path == "" {
+			return nil, fmt.Errorf("invalid boot file %q: want <arch>=<path>", entry)
+		}
+		if _, ok := files.byArch[uint16(code)]; ok {
+			return nil, fmt.Errorf("architecture %d has more than one boot file", code)
+		}
+		name := filepath.Base(path)
+		if existing, ok := files.paths[name]; ok && existing != path {
+			return nil, fmt.Errorf("boot files %s and %s have the same name", existing, path)
+		}
+		files.byArch[uint16(code)] = name
+		files.paths[name] = path
+	}
+	return files, nil
+}
+
+// Check reports an error if a boot file is not a readable regular file
+func (f *BootFiles) Check() error {
+	for _, path := range f.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("boot file %s is not a regular file", path)
+		}
+	}
+	return nil
+}
+
+// Path returns the local path of the file a client requested by name
+func (f *BootFiles) Path(name string) (string, bool) {
+	path, ok := f.paths[strings.TrimLeft(name, "/")]
+	return path, ok
+}
+
+// Name returns the name of the file served to arch
+func (f *BootFiles) Name(arch uint16) (string, bool) {
+	name, ok := f.byArch[arch]
+	return name, ok
+}
+
+// Architectures returns the mapped architectures in ascending order
+func (f *BootFiles) Architectures() []uint16 {
+	archs := make([]uint16, 0, len(f.byArch))
+	for arch := range f.byArch {
+		archs = append(archs, arch)
+	}
+	sort.Slice(archs, func(i, j int) bool { return archs[i] < archs[j] })
+	return archs
+}
+
+// WriteDnsmasq renders dnsmasq options handing each architecture its boot
+// file from the TFTP server at server, or from dnsmasq's own address when
+// server is empty. Clients already running iPXE are tagged ipxe and left
+// out, so they are not sent the binary they just loaded.
+func (f *BootFiles) WriteDnsmasq(w io.Writer, server string) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprint(out, "# Boot files by client architecture (DHCP option 93) generated by boot-service; do not edit\n"+ //nolint:errcheck
+		"dhcp-userclass=set:ipxe,iPXE\n")
+	for _, arch := range f.Architectures() {
+		boot := []string{fmt.Sprintf("tag:arch%d", arch), "tag:!ipxe", f.byArch[arch]}
+		if server != "" {
+			boot = append(boot, "", server)
+		}
+		fmt.Fprintf(out, "dhcp-match=set:arch%d,option:client-arch,%d\ndhcp-boot=%s\n", arch, arch, strings.Join(boot, ",")) //nolint:errcheck
+	}
+	return out.Flush()
+}
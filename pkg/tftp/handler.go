@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package tftp
+
+import (
+	"bytes"
+	"net/http"
+	"net/netip"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+)
+
+// Path serves the DHCP options naming each architecture's boot file
+const Path = "/dhcp/bootfiles"
+
+// Handler serves the DHCP options pointing clients at the TFTP server
+type Handler struct {
+	files  *BootFiles
+	server string
+}
+
+// NewHandler creates a handler for files served from the TFTP server at
+// server, an IP address, or dnsmasq's own when empty
+func NewHandler(files *BootFiles, server string) *Handler {
+	return &Handler{files: files, server: server}
+}
+
+// RegisterRoutes registers GET /dhcp/bootfiles
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get(Path, h.GetBootFiles)
+}
+
+// GetBootFiles handles GET /dhcp/bootfiles, rendering dnsmasq options that
+// hand each architecture its boot file. ?server= overrides the TFTP server
+// address.
+func (h *Handler) GetBootFiles(w http.ResponseWriter, r *http.Request) {
+	server := h.server
+	if value := r.URL.Query().Get("server"); value != "" {
+		addr, err := netip.ParseAddr(value)
+		if err != nil || !addr.Is4() {
+			httputil.WriteError(w, http.StatusBadRequest, "Invalid server", "server must be the IPv4 address of the TFTP server")
+			return
+		}
+		server = addr.String()
+	}
+
+	var buf bytes.Buffer
+	if err := h.files.WriteDnsmasq(&buf, server); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to render boot files", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
@@ -0,0 +1,311 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package tftp serves network boot binaries, such as undionly.kpxe and
+// ipxe.efi, over read-only TFTP (RFC 1350) in octet mode with the blksize,
+// timeout, and tsize options (RFC 2347-2349). Firmware loads them before chaining to the
+// HTTP boot script, so one service covers the whole netboot path.
+package tftp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Opcodes
+const (
+	opRRQ   = 1
+	opWRQ   = 2
+	opDATA  = 3
+	opACK   = 4
+	opERROR = 5
+	opOACK  = 6
+)
+
+// Error codes
+const (
+	errNotDefined      = 0
+	errFileNotFound    = 1
+	errAccessViolation = 2
+	errIllegalOp       = 4
+	errUnknownTID      = 5
+)
+
+// Transfer defaults
+const (
+	// DefaultTimeout is how long a transfer waits for an acknowledgement
+	// before sending a packet again
+	DefaultTimeout = 2 * time.Second
+	// DefaultRetries is how often a packet is sent again before the
+	// transfer is abandoned
+	DefaultRetries = 5
+	// DefaultMaxTransfers bounds the transfers in progress, each of which
+	// holds a port and a file open
+	DefaultMaxTransfers = 256
+
+	defaultBlockSize = 512
+	minBlockSize     = 8
+	maxBlockSize     = 65464
+	maxRequestSize   = 1024
+)
+
+// Server serves boot files over TFTP
+type Server struct {
+	files     *BootFiles
+	logger    *log.Logger
+	timeout   time.Duration
+	retries   int
+	transfers chan struct{} // a slot for each transfer in progress
+}
+
+// NewServer creates a server for files
+func NewServer(files *BootFiles, logger *log.Logger) *Server {
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	return &Server{
+		files:     files,
+		logger:    logger,
+		timeout:   DefaultTimeout,
+		retries:   DefaultRetries,
+		transfers: make(chan struct{}, DefaultMaxTransfers),
+	}
+}
+
+// Serve answers read requests on conn, each transfer from its own port as
+// the protocol requires, until conn is closed. Requests beyond
+// DefaultMaxTransfers transfers in progress are dropped, and the clients
+// send them again after their timeout.
+func (s *Server) Serve(conn net.PacketConn) error {
+	localHost := ""
+	if addr, ok := conn.LocalAddr().(*net.UDPAddr); ok && !addr.IP.IsUnspecified() {
+		localHost = addr.IP.String()
+	}
+	buf := make([]byte, maxRequestSize)
+	for {
+		n, client, err := conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		select {
+		case s.transfers <- struct{}{}:
+		default:
+			continue
+		}
+		packet := append([]byte(nil), buf[:n]...)
+		go func() {
+			defer func() { <-s.transfers }()
+			s.handle(localHost, client, packet)
+		}()
+	}
+}
+
+// request is a parsed read or write request
+type request struct {
+	opcode   uint16
+	filename string
+	mode     string
+	options  map[string]string
+}
+
+// parseRequest parses an RRQ or WRQ packet: the opcode, then NUL-terminated
+// filename, mode, and option name and value pairs
+func parseRequest(packet []byte) (*request, error) {
+	if len(packet) < 2 {
+		return nil, errors.New("short packet")
+	}
+	req := &request{opcode: binary.BigEndian.Uint16(packet), options: map[string]string{}}
+	if req.opcode != opRRQ && req.opcode != opWRQ {
+		return req, nil
+	}
+	fields := bytes.Split(packet[2:], []byte{0})
+	if len(fields) < 3 || len(fields[len(fields)-1]) != 0 {
+		return nil, errors.New("malformed request")
+	}
+	fields = fields[:len(fields)-1]
+	req.filename, req.mode = string(fields[0]), strings.ToLower(string(fields[1]))
+	for i := 2; i+1 < len(fields); i += 2 {
+		req.options[strings.ToLower(string(fields[i]))] = string(fields[i+1])
+	}
+	return req, nil
+}
+
+// handle answers one request from client, on a new port
+func (s *Server) handle(localHost string, client net.Addr, packet []byte) {
+	conn, err := net.ListenPacket("udp", net.JoinHostPort(localHost, "0"))
+	if err != nil {
+		s.logger.Printf("Failed to open a transfer port for %s: %v", client, err)
+		return
+	}
+	defer conn.Close() //nolint:errcheck
+
+	req, err := parseRequest(packet)
+	switch {
+	case err != nil:
+		sendError(conn, client, errNotDefined, err.Error())
+		return
+	case req.opcode == opWRQ:
+		sendError(conn, client, errAccessViolation, "read-only server")
+		return
+	case req.opcode != opRRQ:
+		sendError(conn, client, errIllegalOp, "expected a read request")
+		return
+	case req.mode == "netascii":
+		// Boot files are binary, so there are no line endings to convert
+		sendError(conn, client, errNotDefined, "netascii mode is not supported, use octet")
+		return
+	case req.mode != "octet":
+		sendError(conn, client, errIllegalOp, "unsupported mode "+req.mode)
+		return
+	}
+
+	path, ok := s.files.Path(req.filename)
+	if !ok {
+		s.logger.Printf("%s requested unknown file %q", client, req.filename)
+		sendError(conn, client, errFileNotFound, "file not found")
+		return
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		s.logger.Printf("Failed to open %s: %v", path, err)
+		sendError(conn, client, errFileNotFound, "file not found")
+		return
+	}
+	defer file.Close() //nolint:errcheck
+
+	start := time.Now()
+	sent, err := s.send(conn, client, file, req.options)
+	if err != nil {
+		s.logger.Printf("Sending %s to %s failed after %d bytes: %v", req.filename, client, sent, err)
+		return
+	}
+	s.logger.Printf("Sent %s to %s (%d bytes in %s)", req.filename, client, sent, time.Since(start).Round(time.Millisecond))
+}
+
+// send transfers file to client, negotiating the options it requested, and
+// returns the bytes sent
+func (s *Server) send(conn net.PacketConn, client net.Addr, file *os.File, options map[string]string) (int64, error) {
+	blockSize, timeout := defaultBlockSize, s.timeout
+	accepted := map[string]string{}
+	if value, ok := options["blksize"]; ok {
+		if size, err := strconv.Atoi(value); err == nil && size >= minBlockSize {
+			blockSize = min(size, maxBlockSize)
+			accepted["blksize"] = strconv.Itoa(blockSize)
+		}
+	}
+	if value, ok := options["timeout"]; ok {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 1 && seconds <= 255 {
+			timeout = time.Duration(seconds) * time.Second
+			accepted["timeout"] = value
+		}
+	}
+	if _, ok := options["tsize"]; ok {
+		if info, err := file.Stat(); err == nil {
+			accepted["tsize"] = strconv.FormatInt(info.Size(), 10)
+		}
+	}
+
+	if len(accepted) > 0 {
+		if err := s.exchange(conn, client, oackPacket(accepted), 0, timeout); err != nil {
+			return 0, err
+		}
+	}
+
+	var sent int64
+	data := make([]byte, 4+blockSize)
+	binary.BigEndian.PutUint16(data, opDATA)
+	for block := uint16(1); ; block++ {
+		n, err := io.ReadFull(file, data[4:])
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			sendError(conn, client, errNotDefined, "read error")
+			return sent, err
+		}
+		binary.BigEndian.PutUint16(data[2:], block)
+		if err := s.exchange(conn, client, data[:4+n], block, timeout); err != nil {
+			return sent, err
+		}
+		sent += int64(n)
+		// A block shorter than the block size ends the transfer
+		if n < blockSize {
+			return sent, nil
+		}
+	}
+}
+
+// exchange sends packet to client until it acknowledges block
+func (s *Server) exchange(conn net.PacketConn, client net.Addr, packet []byte, block uint16, timeout time.Duration) error {
+	reply := make([]byte, maxRequestSize)
+	for attempt := 0; attempt <= s.retries; attempt++ {
+		if _, err := conn.WriteTo(packet, client); err != nil {
+			return err
+		}
+		deadline := time.Now().Add(timeout)
+		for {
+			if err := conn.SetReadDeadline(deadline); err != nil {
+				return err
+			}
+			n, from, err := conn.ReadFrom(reply)
+			if err != nil {
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					break
+				}
+				return err
+			}
+			if from.String() != client.String() {
+				sendError(conn, from, errUnknownTID, "unknown transfer ID")
+				continue
+			}
+			if n < 4 {
+				continue
+			}
+			switch binary.BigEndian.Uint16(reply) {
+			case opACK:
+				// Acknowledgements of earlier blocks are ignored rather than
+				// answered, so duplicates do not double the traffic
+				if binary.BigEndian.Uint16(reply[2:]) == block {
+					return nil
+				}
+			case opERROR:
+				return fmt.Errorf("client error %d: %s", binary.BigEndian.Uint16(reply[2:]), bytes.TrimRight(reply[4:n], "\x00"))
+			}
+		}
+	}
+	return fmt.Errorf("no acknowledgement of block %d after %d attempts", block, s.retries+1)
+}
+
+// oackPacket builds an option acknowledgement
+func oackPacket(options map[string]string) []byte {
+	packet := binary.BigEndian.AppendUint16(nil, opOACK)
+	for _, name := range []string{"blksize", "timeout", "tsize"} {
+		if value, ok := options[name]; ok {
+			packet = append(packet, name...)
+			packet = append(packet, 0)
+			packet = append(packet, value...)
+			packet = append(packet, 0)
+		}
+	}
+	return packet
+}
+
+// sendError sends an ERROR packet, ending the transfer
+func sendError(conn net.PacketConn, to net.Addr, code uint16, message string) {
+	packet := binary.BigEndian.AppendUint16(nil, opERROR)
+	packet = binary.BigEndian.AppendUint16(packet, code)
+	packet = append(packet, message...)
+	packet = append(packet, 0)
+	conn.WriteTo(packet, to) //nolint:errcheck
+}
@@ -0,0 +1,267 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package tftp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func startServer(t *testing.T, files *BootFiles) net.Addr {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewServer(files, nil)
+	server.timeout = 200 * time.Millisecond
+	go server.Serve(conn)              //nolint:errcheck
+	t.Cleanup(func() { conn.Close() }) //nolint:errcheck
+	return conn.LocalAddr()
+}
+
+func requestPacket(opcode uint16, name string, options ...string) []byte {
+	packet := binary.BigEndian.AppendUint16(nil, opcode)
+	for _, field := range append([]string{name, "octet"}, options...) {
+		packet = append(append(packet, field...), 0)
+	}
+	return packet
+}
+
+// fetch reads name from server like a firmware client, returning the file
+// and the options the server acknowledged
+func fetch(t *testing.T, server net.Addr, name string, options ...string) ([]byte, map[string]string) {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close() //nolint:errcheck
+	if _, err := conn.WriteTo(requestPacket(opRRQ, name, options...), server); err != nil {
+		t.Fatal(err)
+	}
+
+	var data []byte
+	acknowledged := map[string]string{}
+	buf := make([]byte, 70000)
+	for {
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second)) //nolint:errcheck
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("reading %s: %v", name, err)
+		}
+		ack := binary.BigEndian.AppendUint16(nil, opACK)
+		switch binary.BigEndian.Uint16(buf) {
+		case opOACK:
+			fields := bytes.Split(buf[2:n-1], []byte{0})
+			for i := 0; i+1 < len(fields); i += 2 {
+				acknowledged[string(fields[i])] = string(fields[i+1])
+			}
+			conn.WriteTo(binary.BigEndian.AppendUint16(ack, 0), from) //nolint:errcheck
+		case opDATA:
+			data = append(data, buf[4:n]...)
+			conn.WriteTo(append(ack, buf[2:4]...), from) //nolint:errcheck
+			blockSize := 512
+			if size, err := strconv.Atoi(acknowledged["blksize"]); err == nil {
+				blockSize = size
+			}
+			if n-4 < blockSize {
+				return data, acknowledged
+			}
+		case opERROR:
+			return nil, map[string]string{"error": string(bytes.TrimRight(buf[4:n], "\x00"))}
+		}
+	}
+}
+
+func testBootFiles(t *testing.T) (*BootFiles, []byte) {
+	t.Helper()
+	dir := t.TempDir()
+	content := bytes.Repeat([]byte("0123456789abcdef"), 100) // 1600 bytes
+	efi := filepath.Join(dir, "ipxe.efi")
+	if err := os.WriteFile(efi, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "undionly.kpxe"), []byte("bios"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	files, err := ParseBootFiles([]string{"0=" + filepath.Join(dir, "undionly.kpxe"), "7=" + efi, "9=" + efi})
+	if err != nil {
+		t.Fatalf("ParseBootFiles failed: %v", err)
+	}
+	return files, content
+}
+
+func TestServer(t *testing.T) {
+	files, content := testBootFiles(t)
+	server := startServer(t, files)
+
+	data, _ := fetch(t, server, "ipxe.efi")
+	if !bytes.Equal(data, content) {
+		t.Errorf("ipxe.efi = %d bytes, want %d", len(data), len(content))
+	}
+
+	data, options := fetch(t, server, "/ipxe.efi", "blksize", "1400", "tsize", "0")
+	if !bytes.Equal(data, content) {
+		t.Errorf("ipxe.efi with options = %d bytes, want %d", len(data), len(content))
+	}
+	if options["blksize"] != "1400" || options["tsize"] != "1600" {
+		t.Errorf("acknowledged options = %v, want blksize 1400 and tsize 1600", options)
+	}
+
+	// A file of exactly one block ends with an empty block
+	data, _ = fetch(t, server, "undionly.kpxe", "blksize", "4")
+	if string(data) != "bios" {
+		t.Errorf("undionly.kpxe = %q, want bios", data)
+	}
+
+	if _, result := fetch(t, server, "../etc/passwd"); result["error"] != "file not found" {
+		t.Errorf("unknown file = %v, want file not found", result)
+	}
+}
+
+func TestServer_RejectsWrites(t *testing.T) {
+	files, _ := testBootFiles(t)
+	server := startServer(t, files)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()                                     //nolint:errcheck
+	conn.WriteTo(requestPacket(opWRQ, "ipxe.efi"), server) //nolint:errcheck
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))  //nolint:errcheck
+	buf := make([]byte, 512)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if binary.BigEndian.Uint16(buf) != opERROR || binary.BigEndian.Uint16(buf[2:]) != errAccessViolation {
+		t.Errorf("write request answered with %v, want an access violation", buf[:n])
+	}
+}
+
+func TestServer_RejectsNetascii(t *testing.T) {
+	files, _ := testBootFiles(t)
+	server := startServer(t, files)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close() //nolint:errcheck
+	packet := binary.BigEndian.AppendUint16(nil, opRRQ)
+	packet = append(append(append(packet, "ipxe.efi"...), 0), "netascii\x00"...)
+	conn.WriteTo(packet, server)                          //nolint:errcheck
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second)) //nolint:errcheck
+	buf := make([]byte, 512)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if binary.BigEndian.Uint16(buf) != opERROR || binary.BigEndian.Uint16(buf[2:]) != errNotDefined {
+		t.Errorf("netascii request answered with %v, want an error", buf[:n])
+	}
+}
+
+func TestServer_LimitsTransfers(t *testing.T) {
+	files, content := testBootFiles(t)
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewServer(files, nil)
+	server.timeout = 200 * time.Millisecond
+	server.transfers = make(chan struct{}, 1)
+	go server.Serve(conn)              //nolint:errcheck
+	t.Cleanup(func() { conn.Close() }) //nolint:errcheck
+
+	// With every slot taken the request is dropped unanswered
+	server.transfers <- struct{}{}
+	client, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()                                               //nolint:errcheck
+	client.WriteTo(requestPacket(opRRQ, "ipxe.efi"), conn.LocalAddr()) //nolint:errcheck
+	client.SetReadDeadline(time.Now().Add(300 * time.Millisecond))     //nolint:errcheck
+	if _, _, err := client.ReadFrom(make([]byte, 512)); err == nil {
+		t.Error("request beyond the transfer limit was answered")
+	}
+
+	<-server.transfers
+	if data, _ := fetch(t, conn.LocalAddr(), "ipxe.efi"); !bytes.Equal(data, content) {
+		t.Errorf("ipxe.efi after a slot freed = %d bytes, want %d", len(data), len(content))
+	}
+}
+
+func TestParseBootFiles(t *testing.T) {
+	files, err := ParseBootFiles([]string{"7=/srv/ipxe.efi", " 9 = /srv/ipxe.efi", "0=/srv/undionly.kpxe"})
+	if err != nil {
+		t.Fatalf("ParseBootFiles failed: %v", err)
+	}
+	if name, _ := files.Name(9); name != "ipxe.efi" {
+		t.Errorf("arch 9 = %q, want ipxe.efi", name)
+	}
+	if path, ok := files.Path("undionly.kpxe"); !ok || path != "/srv/undionly.kpxe" {
+		t.Errorf("undionly.kpxe = %q, %v", path, ok)
+	}
+
+	for _, entries := range [][]string{
+		{"ipxe.efi"},
+		{"x86=/srv/ipxe.efi"},
+		{"70000=/srv/ipxe.efi"},
+		{"7=/srv/ipxe.efi", "7=/srv/other.efi"},
+		{"7=/srv/a/ipxe.efi", "11=/srv/b/ipxe.efi"},
+	} {
+		if _, err := ParseBootFiles(entries); err == nil {
+			t.Errorf("ParseBootFiles(%q) succeeded, want an error", entries)
+		}
+	}
+}
+
+func TestHandler_GetBootFiles(t *testing.T) {
+	files, err := ParseBootFiles([]string{"9=/srv/ipxe.efi", "0=/srv/undionly.kpxe"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := chi.NewRouter()
+	NewHandler(files, "10.0.0.1").RegisterRoutes(r)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	want := `# Boot files by client architecture (DHCP option 93) generated by boot-service; do not edit
+dhcp-userclass=set:ipxe,iPXE
+dhcp-match=set:arch0,option:client-arch,0
+dhcp-boot=tag:arch0,tag:!ipxe,undionly.kpxe,,10.0.0.1
+dhcp-match=set:arch9,option:client-arch,9
+dhcp-boot=tag:arch9,tag:!ipxe,ipxe.efi,,10.0.0.1
+`
+	if rec.Code != http.StatusOK || rec.Body.String() != want {
+		t.Errorf("GET %s = %d\n%s\nwant\n%s", Path, rec.Code, rec.Body, want)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path+"?server=10.9.9.9", nil))
+	if !strings.Contains(rec.Body.String(), "ipxe.efi,,10.9.9.9\n") {
+		t.Errorf("?server= did not override the server:\n%s", rec.Body)
+	}
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path+"?server=tftp.example.com", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid server returned %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,290 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package timeline keeps a boot timeline for each node: the scripts it was
+// served, with the configuration, kernel, and initrd they booted, the
+// scripts that failed, and the times it phoned home. Support staff read it
+// to reconstruct what a node was told to boot and when.
+//
+// Events are collected in memory from the boot activity feed and added to
+// the stored timeline periodically; replicas sharing storage each add their
+// own. A timeline keeps its most recent MaxEntries events from the last
+// Retention.
+package timeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/activity"
+	"github.com/openchami/boot-service/pkg/controllers/bootscript"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// ResourceType is the storage resource type of node timelines
+const ResourceType = "NodeTimeline"
+
+// Limits of a stored timeline
+const (
+	MaxEntries = 1000
+	Retention  = 30 * 24 * time.Hour
+)
+
+// Entry kinds
+const (
+	Script    = "script"     // a boot script was served
+	Failure   = "failure"    // an error or fallback script was served
+	PhoneHome = "phone-home" // the booted node reported in
+	Discovery = "discovery"  // the node was registered on its first boot
+)
+
+// Entry is one event of a node's timeline
+type Entry struct {
+	Time       time.Time `json:"time"`
+	Event      string    `json:"event"`
+	Identifier string    `json:"identifier,omitempty"` // as the node gave it
+	Client     string    `json:"client,omitempty"`
+	Template   string    `json:"template,omitempty"`
+	Config     string    `json:"config,omitempty"`
+	Kernel     string    `json:"kernel,omitempty"`
+	Initrd     string    `json:"initrd,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	Cached     bool      `json:"cached,omitempty"`
+	Hostname   string    `json:"hostname,omitempty"`
+}
+
+// Timeline is the boot timeline of one node, oldest event first
+type Timeline struct {
+	Node    string  `json:"node"`
+	Entries []Entry `json:"events"`
+}
+
+// merge adds entries to t and trims it to the limits, counted back from now
+func (t *Timeline) merge(entries []Entry, now time.Time) {
+	t.Entries = append(t.Entries, entries...)
+	slices.SortStableFunc(t.Entries, func(a, b Entry) int { return a.Time.Compare(b.Time) })
+	cutoff := now.Add(-Retention)
+	first, _ := slices.BinarySearchFunc(t.Entries, cutoff, func(entry Entry, cutoff time.Time) int {
+		return entry.Time.Compare(cutoff)
+	})
+	first = max(first, len(t.Entries)-MaxEntries)
+	t.Entries = slices.Clip(t.Entries[first:])
+}
+
+// Between returns the entries of t from since to until; a zero bound is
+// open
+func (t Timeline) Between(since, until time.Time) Timeline {
+	entries := []Entry{}
+	for _, entry := range t.Entries {
+		if (since.IsZero() || !entry.Time.Before(since)) && (until.IsZero() || !entry.Time.After(until)) {
+			entries = append(entries, entry)
+		}
+	}
+	return Timeline{Node: t.Node, Entries: entries}
+}
+
+// NodeResolver maps the identifier a node phoned home with to its xname
+type NodeResolver interface {
+	ResolveNodeName(ctx context.Context, identifier string) (string, error)
+}
+
+// Recorder builds node timelines from boot activity. It implements the
+// activity recorders of the boot script controller and handler.
+type Recorder struct {
+	backend  fabricaStorage.StorageBackend
+	resolver NodeResolver
+	logger   *log.Logger
+	now      func() time.Time
+
+	mu      sync.Mutex
+	pending map[string][]Entry // events not yet added to the stored timelines
+}
+
+// NewRecorder creates a recorder persisted in backend. Phone-home reports
+// are attributed to nodes through resolver. Run adds the recorded events to
+// storage.
+func NewRecorder(backend fabricaStorage.StorageBackend, resolver NodeResolver, logger *log.Logger) *Recorder {
+	return &Recorder{backend: backend, resolver: resolver, logger: logger, now: time.Now, pending: make(map[string][]Entry)}
+}
+
+// Publish records a boot activity event in the timeline of its node. Events
+// that cannot be attributed to a node, such as requests from unknown
+// nodes, are ignored.
+func (r *Recorder) Publish(event activity.Event) {
+	entry := Entry{
+		Time:       event.Time,
+		Identifier: event.Identifier,
+		Client:     event.Client,
+		Reason:     event.Reason,
+	}
+	if entry.Time.IsZero() {
+		entry.Time = r.now().UTC()
+	}
+	node := event.Node
+	switch event.Type {
+	case activity.Match:
+		entry.Event = Script
+		if event.Template == bootscript.TemplateError || event.Template == bootscript.TemplateFallback {
+			entry.Event = Failure
+		}
+		entry.Template = event.Template
+		entry.Config = event.Config
+		entry.Kernel = event.Kernel
+		entry.Initrd = event.Initrd
+		entry.Cached = event.Cached
+	case activity.PhoneHome:
+		entry.Event = PhoneHome
+		entry.Hostname = event.Hostname
+		node = r.resolve(event.Identifier, event.Hostname)
+	case activity.Discovery:
+		entry.Event = Discovery
+	default:
+		// Requests are followed by the match that describes them
+		return
+	}
+	if node == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Only the most recent events would be stored
+	pending := append(r.pending[node], entry)
+	if len(pending) > MaxEntries {
+		pending = pending[len(pending)-MaxEntries:]
+	}
+	r.pending[node] = pending
+}
+
+// resolve returns the xname of the node identified by one of identifiers,
+// or "" when none is known
+func (r *Recorder) resolve(identifiers ...string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, identifier := range identifiers {
+		if identifier == "" {
+			continue
+		}
+		if node, err := r.resolver.ResolveNodeName(ctx, identifier); err == nil {
+			return node
+		}
+	}
+	return ""
+}
+
+// Get returns the timeline of node, including events not yet stored. A
+// node that has not booted has an empty timeline.
+func (r *Recorder) Get(ctx context.Context, node string) (Timeline, error) {
+	timeline, err := r.load(ctx, node)
+	if err != nil {
+		return Timeline{}, err
+	}
+	r.mu.Lock()
+	pending := slices.Clone(r.pending[node])
+	r.mu.Unlock()
+	timeline.merge(pending, r.now())
+	return timeline, nil
+}
+
+// Run adds the recorded events to storage every interval until ctx is done,
+// and once more then
+func (r *Recorder) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// Flush with a fresh context: ctx is already done
+			if err := r.Flush(context.WithoutCancel(ctx)); err != nil {
+				r.logger.Printf("Failed to store node timelines: %v", err)
+			}
+			return
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				r.logger.Printf("Failed to store node timelines: %v", err)
+			}
+		}
+	}
+}
+
+// Flush adds the recorded events to the stored timelines. Events of a node
+// that could not be stored are kept for the next flush.
+func (r *Recorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[string][]Entry, len(pending))
+	r.mu.Unlock()
+
+	var errs []error
+	for node, entries := range pending {
+		if err := r.add(ctx, node, entries); err != nil {
+			errs = append(errs, err)
+			r.mu.Lock()
+			r.pending[node] = append(entries, r.pending[node]...)
+			r.mu.Unlock()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HandleResourceChange drops the timelines of deleted nodes
+func (r *Recorder) HandleResourceChange(ctx context.Context, event resourcewatch.Event) {
+	if event.ResourceType != "Node" || event.New != nil || event.Old == nil {
+		return
+	}
+	var node apiv1.Node
+	if json.Unmarshal(event.Old, &node) != nil || node.Spec.XName == "" {
+		return
+	}
+	name := node.Spec.XName
+
+	r.mu.Lock()
+	delete(r.pending, name)
+	r.mu.Unlock()
+	if err := r.backend.Delete(ctx, ResourceType, name); err != nil && !errors.Is(err, fabricaStorage.ErrNotFound) {
+		r.logger.Printf("Failed to delete timeline of node %s: %v", name, err)
+	}
+}
+
+// add merges entries into the stored timeline of node
+func (r *Recorder) add(ctx context.Context, node string, entries []Entry) error {
+	timeline, err := r.load(ctx, node)
+	if err != nil {
+		return err
+	}
+	timeline.merge(entries, r.now())
+	data, err := json.Marshal(timeline)
+	if err != nil {
+		return fmt.Errorf("encoding timeline of node %s: %w", node, err)
+	}
+	if err := r.backend.Save(ctx, ResourceType, node, data); err != nil {
+		return fmt.Errorf("saving timeline of node %s: %w", node, err)
+	}
+	return nil
+}
+
+// load returns the stored timeline of node
+func (r *Recorder) load(ctx context.Context, node string) (Timeline, error) {
+	data, err := r.backend.Load(ctx, ResourceType, node)
+	if errors.Is(err, fabricaStorage.ErrNotFound) {
+		return Timeline{Node: node, Entries: []Entry{}}, nil
+	}
+	if err != nil {
+		return Timeline{}, fmt.Errorf("loading timeline of node %s: %w", node, err)
+	}
+	var timeline Timeline
+	if err := json.Unmarshal(data, &timeline); err != nil {
+		return Timeline{}, fmt.Errorf("decoding timeline of node %s: %w", node, err)
+	}
+	return timeline, nil
+}
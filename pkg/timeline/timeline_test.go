@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package timeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	apiv1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/activity"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+)
+
+// resolver knows nodes by MAC
+type resolver map[string]string
+
+func (r resolver) ResolveNodeName(_ context.Context, identifier string) (string, error) {
+	if node, ok := r[identifier]; ok {
+		return node, nil
+	}
+	return "", errors.New("node not found")
+}
+
+func newTestRecorder(t *testing.T) (*Recorder, fabricaStorage.StorageBackend) {
+	t.Helper()
+
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	nodes := resolver{"aa:bb:cc:dd:ee:01": "x0c0s0b0n0"}
+	return NewRecorder(backend, nodes, log.New(io.Discard, "", 0)), backend
+}
+
+func TestRecorder_BuildsTimeline(t *testing.T) {
+	ctx := context.Background()
+	recorder, backend := newTestRecorder(t)
+	start := time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+	recorder.now = func() time.Time { return start.Add(time.Hour) }
+
+	events := []activity.Event{
+		{Type: activity.Request, Time: start, Identifier: "aa:bb:cc:dd:ee:01"},
+		{Type: activity.Match, Time: start.Add(time.Second), Identifier: "aa:bb:cc:dd:ee:01", Node: "x0c0s0b0n0",
+			Template: "default", Config: "compute", Kernel: "http://images/vmlinuz", Initrd: "http://images/initrd"},
+		{Type: activity.PhoneHome, Time: start.Add(5 * time.Minute), Identifier: "aa:bb:cc:dd:ee:01", Hostname: "nid001"},
+		{Type: activity.Match, Time: start.Add(10 * time.Minute), Identifier: "aa:bb:cc:dd:ee:01", Node: "x0c0s0b0n0",
+			Template: "error", Reason: "template failed"},
+		// Unattributable events are dropped
+		{Type: activity.Match, Time: start, Identifier: "aa:bb:cc:dd:ee:99", Template: "error"},
+		{Type: activity.PhoneHome, Time: start, Identifier: "aa:bb:cc:dd:ee:99"},
+	}
+	// Split across two flushes, the second event of each out of order
+	for _, event := range events[:2] {
+		recorder.Publish(event)
+	}
+	recorder.Publish(events[3])
+	if err := recorder.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	for _, event := range []activity.Event{events[2], events[4], events[5]} {
+		recorder.Publish(event)
+	}
+
+	got, err := recorder.Get(ctx, "x0c0s0b0n0")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	want := []string{Script, PhoneHome, Failure}
+	if len(got.Entries) != len(want) {
+		t.Fatalf("timeline = %+v, want %v", got.Entries, want)
+	}
+	for i, entry := range got.Entries {
+		if entry.Event != want[i] {
+			t.Errorf("event %d = %s, want %s", i, entry.Event, want[i])
+		}
+	}
+	if script := got.Entries[0]; script.Config != "compute" || script.Kernel != "http://images/vmlinuz" || script.Initrd != "http://images/initrd" {
+		t.Errorf("script event = %+v, want the configuration and artifacts served", script)
+	}
+	if got.Entries[1].Hostname != "nid001" || got.Entries[2].Reason != "template failed" {
+		t.Errorf("timeline = %+v", got.Entries)
+	}
+
+	window := got.Between(start.Add(time.Minute), start.Add(6*time.Minute))
+	if len(window.Entries) != 1 || window.Entries[0].Event != PhoneHome {
+		t.Errorf("Between = %+v, want only the phone-home", window.Entries)
+	}
+
+	// A restarted recorder reads what was stored
+	if err := recorder.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	restarted := NewRecorder(backend, resolver{}, log.New(io.Discard, "", 0))
+	restarted.now = recorder.now
+	if stored, err := restarted.Get(ctx, "x0c0s0b0n0"); err != nil || len(stored.Entries) != 3 {
+		t.Errorf("stored timeline = %+v, %v, want 3 events", stored, err)
+	}
+}
+
+func TestRecorder_TrimsTimeline(t *testing.T) {
+	ctx := context.Background()
+	recorder, _ := newTestRecorder(t)
+	now := time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+	recorder.now = func() time.Time { return now }
+
+	recorder.Publish(activity.Event{Type: activity.Match, Time: now.Add(-Retention - time.Hour), Node: "x0c0s0b0n0", Template: "default"})
+	for i := range MaxEntries + 5 {
+		recorder.Publish(activity.Event{Type: activity.Match, Time: now.Add(time.Duration(i-MaxEntries) * time.Second), Node: "x0c0s0b0n0", Template: "default"})
+	}
+	if err := recorder.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	got, err := recorder.Get(ctx, "x0c0s0b0n0")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if len(got.Entries) != MaxEntries || !got.Entries[len(got.Entries)-1].Time.Equal(now.Add(4*time.Second)) {
+		t.Errorf("timeline has %d events ending %v, want the latest %d", len(got.Entries), got.Entries[len(got.Entries)-1].Time, MaxEntries)
+	}
+}
+
+func TestRecorder_DropsDeletedNodes(t *testing.T) {
+	ctx := context.Background()
+	recorder, backend := newTestRecorder(t)
+
+	recorder.Publish(activity.Event{Type: activity.Match, Node: "x0c0s0b0n0", Template: "default"})
+	if err := recorder.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	old, _ := json.Marshal(apiv1.Node{Spec: apiv1.NodeSpec{XName: "x0c0s0b0n0"}})
+	recorder.HandleResourceChange(ctx, resourcewatch.Event{ResourceType: "Node", Old: old})
+
+	if exists, _ := backend.Exists(ctx, ResourceType, "x0c0s0b0n0"); exists {
+		t.Error("the timeline of a deleted node was kept")
+	}
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package trash
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// restorePaths maps kept resource types to their restore endpoint
+var restorePaths = map[string]string{
+	"BootConfiguration": "/bootconfigurations/{uid}/restore",
+	"Node":              "/nodes/{uid}/restore",
+}
+
+// Handler serves the restore endpoints of a bin
+type Handler struct {
+	bin      *Bin
+	backend  fabricaStorage.StorageBackend
+	validate Validator
+}
+
+// NewHandler creates a handler that restores resources into backend, the
+// backend the API writes through, once validate, which may be nil, accepts
+// them
+func NewHandler(bin *Bin, backend fabricaStorage.StorageBackend, validate Validator) *Handler {
+	return &Handler{bin: bin, backend: backend, validate: validate}
+}
+
+// RegisterRoutes registers POST /<collection>/{uid}/restore for every type
+// the bin keeps
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	for resourceType, path := range restorePaths {
+		if h.bin.Keeps(resourceType) {
+			r.Post(path, h.restore(resourceType))
+		}
+	}
+}
+
+// restore handles POST /<collection>/{uid}/restore, which returns a deleted
+// resource to storage with its UID
+func (h *Handler) restore(resourceType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		uid := chi.URLParam(r, "uid")
+		entry, err := h.bin.Get(r.Context(), resourceType, uid)
+		if err == nil && !tenancy.Visible(r.Context(), tenancy.Owner(entry.Object)) {
+			err = ErrNotFound
+		}
+		if err == nil {
+			entry, err = h.bin.Restore(r.Context(), h.backend, resourceType, uid, h.validate)
+		}
+		switch {
+		case errors.Is(err, ErrNotFound):
+			httputil.WriteError(w, http.StatusNotFound, "Not found", "no deleted "+resourceType+" "+uid)
+		case errors.Is(err, ErrExists):
+			httputil.WriteError(w, http.StatusConflict, "Conflict", err.Error())
+		case errors.Is(err, ErrInvalid):
+			httputil.WriteError(w, http.StatusBadRequest, "Validation failed", err.Error())
+		case err != nil:
+			httputil.WriteError(w, http.StatusInternalServerError, "Restore failed", err.Error())
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(entry.Object)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package trash keeps deleted resources for a retention window, so a
+// mistaken delete, such as of a boot configuration targeting thousands of
+// nodes, can be undone.
+//
+// A Bin wraps the storage backend beneath the watched backend: deleting a
+// resource of a kept type moves it into the bin, where it stays until it is
+// restored or its retention window ends. Entries are stored alongside the
+// resources, so they survive restarts and are shared by replicas.
+package trash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/openchami/boot-service/pkg/audit"
+)
+
+// ResourceType is the storage resource type of bin entries
+const ResourceType = "DeletedResource"
+
+// ErrNotFound is returned for resources that are not in the bin
+var ErrNotFound = errors.New("deleted resource not found")
+
+// ErrExists is returned when restoring a resource whose UID is in use again
+var ErrExists = errors.New("a resource with the same UID exists")
+
+// ErrInvalid is returned when restoring a resource its Validator refuses
+var ErrInvalid = errors.New("deleted resource is not valid")
+
+// Validator checks a deleted resource of resourceType before it is restored
+// and returns it as it should be stored, so a resource the API would refuse
+// to create now is not restored either
+type Validator func(ctx context.Context, resourceType string, object json.RawMessage) (json.RawMessage, error)
+
+// Entry is a deleted resource
+type Entry struct {
+	ResourceType string          `json:"resourceType"`
+	UID          string          `json:"uid"`
+	DeletedAt    time.Time       `json:"deletedAt"`
+	DeletedBy    string          `json:"deletedBy,omitempty"`
+	ExpiresAt    time.Time       `json:"expiresAt"`
+	Object       json.RawMessage `json:"object"`
+}
+
+// Bin keeps deleted resources of some types
+type Bin struct {
+	resourceTypes map[string]bool
+	retention     time.Duration
+	logger        *log.Logger
+	now           func() time.Time
+
+	// backend stores the entries; set by Wrap
+	backend fabricaStorage.StorageBackend
+}
+
+// NewBin creates a bin that keeps deleted resources of resourceTypes for
+// retention
+func NewBin(retention time.Duration, logger *log.Logger, resourceTypes ...string) *Bin {
+	types := make(map[string]bool, len(resourceTypes))
+	for _, resourceType := range resourceTypes {
+		types[resourceType] = true
+	}
+	return &Bin{resourceTypes: types, retention: retention, logger: logger, now: time.Now}
+}
+
+// Keeps reports whether deleted resources of resourceType are kept
+func (b *Bin) Keeps(resourceType string) bool {
+	return b.resourceTypes[resourceType]
+}
+
+// Wrap returns backend with deletes of kept types moving resources into the
+// bin, which stores its entries in backend
+func (b *Bin) Wrap(backend fabricaStorage.StorageBackend) fabricaStorage.StorageBackend {
+	b.backend = backend
+	return &deletingBackend{StorageBackend: backend, bin: b}
+}
+
+// deletingBackend moves deleted resources into the bin
+type deletingBackend struct {
+	fabricaStorage.StorageBackend
+	bin *Bin
+}
+
+// Delete moves a resource of a kept type into the bin before deleting it
+func (d *deletingBackend) Delete(ctx context.Context, resourceType, uid string) error {
+	if !d.bin.Keeps(resourceType) {
+		return d.StorageBackend.Delete(ctx, resourceType, uid)
+	}
+	data, err := d.StorageBackend.Load(ctx, resourceType, uid)
+	if err != nil {
+		return err
+	}
+	now := d.bin.now().UTC()
+	entry := Entry{
+		ResourceType: resourceType,
+		UID:          uid,
+		DeletedAt:    now,
+		ExpiresAt:    now.Add(d.bin.retention),
+		Object:       data,
+	}
+	if actor, ok := audit.ActorFromContext(ctx); ok {
+		entry.DeletedBy = actor.Subject
+	}
+	if err := d.bin.save(ctx, entry); err != nil {
+		return fmt.Errorf("keeping deleted %s %s: %w", resourceType, uid, err)
+	}
+	if err := d.StorageBackend.Delete(ctx, resourceType, uid); err != nil {
+		// The resource was not deleted, so it is not in the bin either
+		if removeErr := d.bin.remove(ctx, resourceType, uid); removeErr != nil {
+			d.bin.logger.Printf("Failed to drop the bin entry of %s %s: %v", resourceType, uid, removeErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// List returns the deleted resources of resourceType, most recently deleted
+// first
+func (b *Bin) List(ctx context.Context, resourceType string) ([]Entry, error) {
+	entries, err := b.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+	kept := entries[:0]
+	for _, entry := range entries {
+		if entry.ResourceType == resourceType {
+			kept = append(kept, entry)
+		}
+	}
+	return kept, nil
+}
+
+// Get returns a deleted resource
+func (b *Bin) Get(ctx context.Context, resourceType, uid string) (Entry, error) {
+	data, err := b.backend.Load(ctx, ResourceType, entryUID(resourceType, uid))
+	if errors.Is(err, fabricaStorage.ErrNotFound) {
+		return Entry{}, ErrNotFound
+	}
+	if err != nil {
+		return Entry{}, err
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Entry{}, fmt.Errorf("decoding deleted %s %s: %w", resourceType, uid, err)
+	}
+	return entry, nil
+}
+
+// Restore saves a deleted resource to target, which should be the watched
+// backend so the restore is published like any other write, and removes it
+// from the bin. validate, when set, checks the resource first.
+func (b *Bin) Restore(ctx context.Context, target fabricaStorage.StorageBackend, resourceType, uid string, validate Validator) (Entry, error) {
+	entry, err := b.Get(ctx, resourceType, uid)
+	if err != nil {
+		return Entry{}, err
+	}
+	exists, err := target.Exists(ctx, resourceType, uid)
+	if err != nil {
+		return Entry{}, err
+	}
+	if exists {
+		return Entry{}, ErrExists
+	}
+	if validate != nil {
+		object, err := validate(ctx, resourceType, entry.Object)
+		if err != nil {
+			return Entry{}, fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+		entry.Object = object
+	}
+	if err := target.Save(ctx, resourceType, uid, entry.Object); err != nil {
+		return Entry{}, err
+	}
+	if err := b.remove(ctx, resourceType, uid); err != nil {
+		b.logger.Printf("Restored %s %s but failed to remove it from the bin: %v", resourceType, uid, err)
+	}
+	return entry, nil
+}
+
+// Purge permanently deletes the entries whose retention window has ended and
+// returns how many it deleted
+func (b *Bin) Purge(ctx context.Context) (int, error) {
+	entries, err := b.all(ctx)
+	if err != nil {
+		return 0, err
+	}
+	now := b.now()
+	purged := 0
+	var errs []error
+	for _, entry := range entries {
+		if now.Before(entry.ExpiresAt) {
+			continue
+		}
+		if err := b.remove(ctx, entry.ResourceType, entry.UID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		purged++
+	}
+	return purged, errors.Join(errs...)
+}
+
+// RunPurge purges expired entries every interval until ctx is done
+func (b *Bin) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if purged, err := b.Purge(ctx); err != nil {
+			b.logger.Printf("Failed to purge deleted resources: %v", err)
+		} else if purged > 0 {
+			b.logger.Printf("Purged %d deleted resources past retention", purged)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// all returns every entry, most recently deleted first
+func (b *Bin) all(ctx context.Context) ([]Entry, error) {
+	items, err := b.backend.LoadAll(ctx, ResourceType)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(items))
+	for _, item := range items {
+		var entry Entry
+		if err := json.Unmarshal(item, &entry); err != nil {
+			b.logger.Printf("Skipping unreadable deleted resource: %v", err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].DeletedAt.After(entries[j].DeletedAt) })
+	return entries, nil
+}
+
+func (b *Bin) save(ctx context.Context, entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return b.backend.Save(ctx, ResourceType, entryUID(entry.ResourceType, entry.UID), data)
+}
+
+func (b *Bin) remove(ctx context.Context, resourceType, uid string) error {
+	err := b.backend.Delete(ctx, ResourceType, entryUID(resourceType, uid))
+	if errors.Is(err, fabricaStorage.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// entryUID is the storage UID of the entry of a deleted resource
+func entryUID(resourceType, uid string) string {
+	return resourceType + "." + uid
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package trash
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+)
+
+// newTestBin returns a bin keeping boot configurations for a day, the
+// backend it wraps, and the wrapped backend, with the bin's clock reading
+// *now
+func newTestBin(t *testing.T, now *time.Time) (*Bin, fabricaStorage.StorageBackend, fabricaStorage.StorageBackend) {
+	t.Helper()
+	raw, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	bin := NewBin(24*time.Hour, log.New(io.Discard, "", 0), "BootConfiguration")
+	bin.now = func() time.Time { return *now }
+	return bin, raw, bin.Wrap(raw)
+}
+
+func TestBin_DeleteAndRestore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	bin, raw, wrapped := newTestBin(t, &now)
+
+	config := []byte(`{"kind":"BootConfiguration","metadata":{"uid":"bc-1","name":"compute"}}`)
+	if err := wrapped.Save(ctx, "BootConfiguration", "bc-1", config); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := wrapped.Delete(ctx, "BootConfiguration", "bc-1"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if exists, _ := raw.Exists(ctx, "BootConfiguration", "bc-1"); exists {
+		t.Fatal("deleted boot configuration is still stored")
+	}
+
+	entries, err := bin.List(ctx, "BootConfiguration")
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].UID != "bc-1" || !entries[0].ExpiresAt.Equal(now.Add(24*time.Hour)) {
+		t.Fatalf("bin entries = %+v, want bc-1 expiring in a day", entries)
+	}
+
+	// Restoring fails while the UID is in use again
+	if err := wrapped.Save(ctx, "BootConfiguration", "bc-1", config); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if _, err := bin.Restore(ctx, wrapped, "BootConfiguration", "bc-1", nil); !errors.Is(err, ErrExists) {
+		t.Errorf("Restore over an existing resource returned %v, want ErrExists", err)
+	}
+	if err := raw.Delete(ctx, "BootConfiguration", "bc-1"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	if _, err := bin.Restore(ctx, wrapped, "BootConfiguration", "bc-1", nil); err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+	data, err := raw.Load(ctx, "BootConfiguration", "bc-1")
+	if err != nil || string(data) != string(config) {
+		t.Errorf("restored boot configuration = %s, %v; want %s", data, err, config)
+	}
+	if _, err := bin.Get(ctx, "BootConfiguration", "bc-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after restore returned %v, want ErrNotFound", err)
+	}
+}
+
+func TestBin_OtherTypesDeletePermanently(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	bin, _, wrapped := newTestBin(t, &now)
+
+	if err := wrapped.Save(ctx, "Node", "node-1", []byte(`{"kind":"Node"}`)); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := wrapped.Delete(ctx, "Node", "node-1"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if entries, _ := bin.List(ctx, "Node"); len(entries) != 0 {
+		t.Errorf("bin kept a deleted node: %+v", entries)
+	}
+	if err := wrapped.Delete(ctx, "BootConfiguration", "missing"); !errors.Is(err, fabricaStorage.ErrNotFound) {
+		t.Errorf("Delete of a missing boot configuration returned %v, want ErrNotFound", err)
+	}
+}
+
+func TestBin_Purge(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	bin, _, wrapped := newTestBin(t, &now)
+
+	for _, uid := range []string{"bc-1", "bc-2"} {
+		if err := wrapped.Save(ctx, "BootConfiguration", uid, []byte(`{}`)); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+		if err := wrapped.Delete(ctx, "BootConfiguration", uid); err != nil {
+			t.Fatalf("Delete returned error: %v", err)
+		}
+		now = now.Add(12 * time.Hour)
+	}
+
+	// bc-1 was deleted a day ago and bc-2 twelve hours ago
+	purged, err := bin.Purge(ctx)
+	if err != nil {
+		t.Fatalf("Purge returned error: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("Purge removed %d entries, want 1", purged)
+	}
+	entries, _ := bin.List(ctx, "BootConfiguration")
+	if len(entries) != 1 || entries[0].UID != "bc-2" {
+		t.Errorf("bin entries after purge = %+v, want bc-2", entries)
+	}
+}
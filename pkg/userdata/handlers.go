@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package userdata
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Handler serves the user-data API
+type Handler struct {
+	store *Store
+}
+
+// NewHandler creates a user-data API handler
+func NewHandler(store *Store) *Handler {
+	return &Handler{store: store}
+}
+
+// RegisterRoutes registers the user-data routes at /userdata: the default
+// at /userdata/default and the rest at /userdata/{scope}/{target}. The
+// default applies to every tenant's nodes, so tenants may not access it.
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route("/userdata", func(r chi.Router) {
+		r.Get("/", h.ListUserData)
+		r.Route("/default", func(r chi.Router) {
+			r.Use(tenancy.Unscoped)
+			r.Get("/", h.GetUserData)
+			r.Put("/", h.PutUserData)
+			r.Delete("/", h.DeleteUserData)
+		})
+		r.Get("/{scope}/{target}", h.GetUserData)
+		r.Put("/{scope}/{target}", h.PutUserData)
+		r.Delete("/{scope}/{target}", h.DeleteUserData)
+	})
+}
+
+// ListUserData handles GET /userdata. A tenant only sees the user-data it
+// may manage.
+func (h *Handler) ListUserData(w http.ResponseWriter, r *http.Request) {
+	all, err := h.store.List(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to list user-data", err.Error())
+		return
+	}
+
+	visible := make([]UserData, 0, len(all))
+	for _, userData := range all {
+		err := h.store.Authorize(r.Context(), userData.Scope, userData.Target)
+		switch {
+		case err == nil:
+			visible = append(visible, userData)
+		case !errors.Is(err, ErrForbidden):
+			httputil.WriteError(w, http.StatusInternalServerError, "Failed to list user-data", err.Error())
+			return
+		}
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, visible)
+}
+
+// GetUserData handles GET /userdata/default and GET /userdata/{scope}/{target}
+func (h *Handler) GetUserData(w http.ResponseWriter, r *http.Request) {
+	scope, target := scopeParams(r)
+	if err := h.store.Authorize(r.Context(), scope, target); err != nil {
+		h.writeStoreError(w, "Failed to get user-data", err)
+		return
+	}
+	userData, err := h.store.Get(r.Context(), scope, target)
+	if err != nil {
+		h.writeStoreError(w, "Failed to get user-data", err)
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, userData)
+}
+
+// PutUserData handles PUT /userdata/default and PUT
+// /userdata/{scope}/{target}. The scope and target come from the URL.
+func (h *Handler) PutUserData(w http.ResponseWriter, r *http.Request) {
+	var userData UserData
+	if err := json.NewDecoder(r.Body).Decode(&userData); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	scope, target := scopeParams(r)
+	if (userData.Scope != "" && userData.Scope != scope) || (userData.Target != "" && userData.Target != target) {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid request body", "user-data scope and target do not match URL")
+		return
+	}
+	userData.Scope, userData.Target = scope, target
+
+	if err := userData.Validate(); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid user-data", err.Error())
+		return
+	}
+	if err := h.store.Authorize(r.Context(), scope, target); err != nil {
+		h.writeStoreError(w, "Failed to store user-data", err)
+		return
+	}
+
+	stored, err := h.store.Put(r.Context(), userData)
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to store user-data", err.Error())
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, stored)
+}
+
+// DeleteUserData handles DELETE /userdata/default and DELETE
+// /userdata/{scope}/{target}
+func (h *Handler) DeleteUserData(w http.ResponseWriter, r *http.Request) {
+	scope, target := scopeParams(r)
+	if err := h.store.Authorize(r.Context(), scope, target); err != nil {
+		h.writeStoreError(w, "Failed to delete user-data", err)
+		return
+	}
+	if err := h.store.Delete(r.Context(), scope, target); err != nil {
+		h.writeStoreError(w, "Failed to delete user-data", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// scopeParams returns the scope and target of a request's URL
+func scopeParams(r *http.Request) (string, string) {
+	scope := chi.URLParam(r, "scope")
+	if scope == "" {
+		return ScopeDefault, ""
+	}
+	return scope, chi.URLParam(r, "target")
+}
+
+func (h *Handler) writeStoreError(w http.ResponseWriter, title string, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		httputil.WriteError(w, http.StatusNotFound, title, err.Error())
+		return
+	case errors.Is(err, ErrForbidden):
+		httputil.WriteError(w, http.StatusForbidden, title, err.Error())
+		return
+	}
+	httputil.WriteError(w, http.StatusInternalServerError, title, err.Error())
+}
@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package userdata
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// scopeOrder ranks scopes in merge order
+var scopeOrder = map[string]int{ScopeDefault: 0, ScopeRole: 1, ScopeGroup: 2, ScopeNode: 3}
+
+// Store persists user-data
+type Store struct {
+	backend fabricaStorage.StorageBackend
+	logger  *log.Logger
+}
+
+// NewStore creates a user-data store persisted in the given storage backend
+func NewStore(backend fabricaStorage.StorageBackend, logger *log.Logger) *Store {
+	return &Store{backend: backend, logger: logger}
+}
+
+// Put validates and stores user-data. Storing user-data for an existing
+// scope and target replaces it while keeping its creation time.
+func (s *Store) Put(ctx context.Context, userData UserData) (*UserData, error) {
+	if err := userData.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	userData.CreatedAt = now
+	existing, err := s.Get(ctx, userData.Scope, userData.Target)
+	switch {
+	case err == nil:
+		userData.CreatedAt = existing.CreatedAt
+	case !errors.Is(err, ErrNotFound):
+		return nil, err
+	}
+	userData.UpdatedAt = now
+
+	data, err := json.Marshal(userData)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s user-data: %w", userData.name(), err)
+	}
+	if err := s.backend.Save(ctx, ResourceType, key(userData.Scope, userData.Target), data); err != nil {
+		return nil, fmt.Errorf("saving %s user-data: %w", userData.name(), err)
+	}
+
+	s.logger.Printf("Stored %s user-data", userData.name())
+	return &userData, nil
+}
+
+// Get returns the user-data stored for a scope and target
+func (s *Store) Get(ctx context.Context, scope, target string) (*UserData, error) {
+	probe := UserData{Scope: scope, Target: target}
+	if _, ok := scopeOrder[scope]; !ok || (scope != ScopeDefault && !ValidTarget(target)) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, probe.name())
+	}
+
+	data, err := s.backend.Load(ctx, ResourceType, key(scope, target))
+	if err != nil {
+		if errors.Is(err, fabricaStorage.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, probe.name())
+		}
+		return nil, fmt.Errorf("loading %s user-data: %w", probe.name(), err)
+	}
+
+	var userData UserData
+	if err := json.Unmarshal(data, &userData); err != nil {
+		return nil, fmt.Errorf("decoding %s user-data: %w", probe.name(), err)
+	}
+	return &userData, nil
+}
+
+// List returns all user-data in merge order of scope, then by target
+func (s *Store) List(ctx context.Context) ([]UserData, error) {
+	rawData, err := s.backend.LoadAll(ctx, ResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("loading user-data: %w", err)
+	}
+
+	result := make([]UserData, 0, len(rawData))
+	for _, data := range rawData {
+		var userData UserData
+		if err := json.Unmarshal(data, &userData); err != nil {
+			return nil, fmt.Errorf("decoding user-data: %w", err)
+		}
+		result = append(result, userData)
+	}
+	slices.SortFunc(result, func(a, b UserData) int {
+		return cmp.Or(cmp.Compare(scopeOrder[a.Scope], scopeOrder[b.Scope]), cmp.Compare(a.Target, b.Target))
+	})
+	return result, nil
+}
+
+// Delete removes the user-data stored for a scope and target
+func (s *Store) Delete(ctx context.Context, scope, target string) error {
+	if _, err := s.Get(ctx, scope, target); err != nil {
+		return err
+	}
+	if err := s.backend.Delete(ctx, ResourceType, key(scope, target)); err != nil {
+		return fmt.Errorf("deleting user-data: %w", err)
+	}
+	return nil
+}
+
+// Authorize checks that the user-data of a scope and target only applies to
+// nodes of the tenant of ctx. A tenant may manage the user-data of its own
+// nodes and of groups whose nodes all belong to it. The default and role
+// user-data apply across tenants, so only a context without a tenant may
+// manage them, as it may any other user-data.
+func (s *Store) Authorize(ctx context.Context, scope, target string) error {
+	tenant, ok := tenancy.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	probe := UserData{Scope: scope, Target: target}
+	if scope != ScopeNode && scope != ScopeGroup {
+		return fmt.Errorf("%w: %s", ErrForbidden, probe.name())
+	}
+
+	// Nodes of other tenants must be seen to refuse groups they are in
+	rawNodes, err := s.backend.LoadAll(tenancy.WithoutTenant(ctx), "Node")
+	if err != nil {
+		return fmt.Errorf("loading nodes: %w", err)
+	}
+	matched := false
+	for _, data := range rawNodes {
+		var node v1.Node
+		if err := json.Unmarshal(data, &node); err != nil {
+			return fmt.Errorf("decoding node: %w", err)
+		}
+		if (scope == ScopeNode && node.Spec.XName != target) || (scope == ScopeGroup && !slices.Contains(node.Spec.Groups, target)) {
+			continue
+		}
+		if node.Spec.Tenant != tenant {
+			return fmt.Errorf("%w: %s", ErrForbidden, probe.name())
+		}
+		matched = true
+	}
+	if !matched {
+		return fmt.Errorf("%w: %s has no nodes of tenant %q", ErrForbidden, probe.name(), tenant)
+	}
+	return nil
+}
+
+// Resolve returns the user-data that applies to a node, in merge order
+func (s *Store) Resolve(ctx context.Context, node v1.NodeSpec) ([]UserData, error) {
+	all, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []UserData
+	for _, userData := range all {
+		switch userData.Scope {
+		case ScopeDefault:
+		case ScopeRole:
+			if userData.Target != node.Role {
+				continue
+			}
+		case ScopeGroup:
+			if !slices.Contains(node.Groups, userData.Target) {
+				continue
+			}
+		case ScopeNode:
+			if userData.Target != node.XName {
+				continue
+			}
+		default:
+			continue
+		}
+		parts = append(parts, userData)
+	}
+	return parts, nil
+}
+
+// Render returns the merged user-data of a node
+func (s *Store) Render(ctx context.Context, node v1.NodeSpec) (Document, error) {
+	parts, err := s.Resolve(ctx, node)
+	if err != nil {
+		return Document{}, err
+	}
+	return Merge(parts)
+}
+
+// UserData returns the merged user-data of a node, empty when none applies.
+// It implements cloudinit.UserDataSource.
+func (s *Store) UserData(ctx context.Context, node v1.NodeSpec) (string, error) {
+	doc, err := s.Render(ctx, node)
+	return doc.Content, err
+}
@@ -0,0 +1,268 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package userdata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+	"gopkg.in/yaml.v3"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	return NewStore(backend, log.New(io.Discard, "", 0))
+}
+
+func mustPut(t *testing.T, store *Store, userData UserData) {
+	t.Helper()
+	if _, err := store.Put(context.Background(), userData); err != nil {
+		t.Fatalf("Put(%s) failed: %v", userData.name(), err)
+	}
+}
+
+func TestUserData_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		userData UserData
+		wantErr  bool
+	}{
+		{"default", UserData{Scope: ScopeDefault, Content: "#cloud-config\nntp:\n  enabled: true\n"}, false},
+		{"role script", UserData{Scope: ScopeRole, Target: "Compute", Content: "#!/bin/sh\necho hi\n"}, false},
+		{"default with target", UserData{Scope: ScopeDefault, Target: "all", Content: "#cloud-config\n"}, true},
+		{"group without target", UserData{Scope: ScopeGroup, Content: "#cloud-config\n"}, true},
+		{"unknown scope", UserData{Scope: "rack", Target: "r1", Content: "#cloud-config\n"}, true},
+		{"no header", UserData{Scope: ScopeNode, Target: "x1000c0s0b0n0", Content: "packages: [vim]\n"}, true},
+		{"jinja", UserData{Scope: ScopeDefault, Content: "## template: jinja\n#cloud-config\n"}, true},
+		{"not a mapping", UserData{Scope: ScopeDefault, Content: "#cloud-config\n- vim\n"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.userData.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStore_RenderMergeOrder(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	mustPut(t, store, UserData{Scope: ScopeNode, Target: "x1000c0s0b0n0", Content: "#cloud-config\nntp:\n  servers: [ntp.node]\n"})
+	mustPut(t, store, UserData{Scope: ScopeGroup, Target: "gpu", Content: "#cloud-config\npackages: [cuda]\n"})
+	mustPut(t, store, UserData{Scope: ScopeGroup, Target: "compute", Content: "#cloud-config\npackages: [slurm]\nntp:\n  servers: [ntp.compute]\n"})
+	mustPut(t, store, UserData{Scope: ScopeRole, Target: "Compute", Content: "#cloud-config\nntp:\n  enabled: true\n  servers: [ntp.role]\n"})
+	mustPut(t, store, UserData{Scope: ScopeDefault, Content: "#cloud-config\npackages: [vim]\ntimezone: UTC\n"})
+	mustPut(t, store, UserData{Scope: ScopeGroup, Target: "storage", Content: "#cloud-config\npackages: [lustre]\n"})
+
+	node := v1.NodeSpec{XName: "x1000c0s0b0n0", Role: "Compute", Groups: []string{"gpu", "compute"}}
+	doc, err := store.Render(ctx, node)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	wantSources := []string{"default", "role Compute", "group compute", "group gpu", "node x1000c0s0b0n0"}
+	if !reflect.DeepEqual(doc.Sources, wantSources) {
+		t.Errorf("sources = %v, want %v", doc.Sources, wantSources)
+	}
+	if doc.ContentType != CloudConfig || !strings.HasPrefix(doc.Content, "#cloud-config\n") {
+		t.Fatalf("document = %+v, want one cloud-config", doc)
+	}
+
+	// Lists are appended in merge order; the node's values win
+	var merged map[string]any
+	if err := yaml.Unmarshal([]byte(doc.Content), &merged); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"packages": []any{"vim", "slurm", "cuda"},
+		"timezone": "UTC",
+		"ntp":      map[string]any{"enabled": true, "servers": []any{"ntp.role", "ntp.compute", "ntp.node"}},
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("merged = %v, want %v", merged, want)
+	}
+
+	// The same parts always merge to the same document
+	again, _ := store.Render(ctx, v1.NodeSpec{XName: "x1000c0s0b0n0", Role: "Compute", Groups: []string{"compute", "gpu"}})
+	if again.Content != doc.Content {
+		t.Errorf("rendering again gave\n%s\nwant\n%s", again.Content, doc.Content)
+	}
+
+	if doc, err := store.Render(ctx, v1.NodeSpec{XName: "x1000c0s0b1n0", Role: "Service"}); err != nil || !reflect.DeepEqual(doc.Sources, []string{"default"}) {
+		t.Errorf("service node document = %+v, %v, want the default only", doc, err)
+	}
+}
+
+func TestStore_RenderMultipart(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	mustPut(t, store, UserData{Scope: ScopeDefault, Content: "#cloud-config\npackages: [vim]\n"})
+	mustPut(t, store, UserData{Scope: ScopeGroup, Target: "compute", Content: "#!/bin/sh\necho compute\n"})
+	mustPut(t, store, UserData{Scope: ScopeNode, Target: "x1000c0s0b0n0", Content: "#cloud-config\ntimezone: UTC\n"})
+
+	doc, err := store.Render(ctx, v1.NodeSpec{XName: "x1000c0s0b0n0", Groups: []string{"compute"}})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if doc.ContentType != Multipart {
+		t.Fatalf("content type = %s, want %s", doc.ContentType, Multipart)
+	}
+
+	header, body, _ := strings.Cut(doc.Content, "\n\n")
+	_, params, err := mime.ParseMediaType(strings.TrimPrefix(strings.Split(header, "\n")[0], "Content-Type: "))
+	if err != nil {
+		t.Fatalf("invalid MIME header %q: %v", header, err)
+	}
+	reader := multipart.NewReader(strings.NewReader(body), params["boundary"])
+	var types, contents []string
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		data, _ := io.ReadAll(part)
+		types = append(types, part.Header.Get("Content-Type"))
+		contents = append(contents, string(data))
+	}
+	if len(types) != 2 || !strings.HasPrefix(types[0], CloudConfig) || !strings.HasPrefix(types[1], "text/x-shellscript") {
+		t.Fatalf("parts = %v, want the merged cloud-config, then the script", types)
+	}
+	if !strings.Contains(contents[0], "timezone: UTC") || !strings.Contains(contents[0], "- vim") || contents[1] != "#!/bin/sh\necho compute\n" {
+		t.Errorf("part contents = %q", contents)
+	}
+}
+
+func TestStore_PutKeepsCreationTime(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	first, err := store.Put(ctx, UserData{Scope: ScopeRole, Target: "Compute", Content: "#cloud-config\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := store.Put(ctx, UserData{Scope: ScopeRole, Target: "Compute", Content: "#cloud-config\ntimezone: UTC\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !second.CreatedAt.Equal(first.CreatedAt) || second.UpdatedAt.Before(first.UpdatedAt) {
+		t.Errorf("replaced user-data = %+v, want the first creation time", second)
+	}
+	if err := store.Delete(ctx, ScopeRole, "Compute"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Get(ctx, ScopeRole, "Compute"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrNotFound", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	router := chi.NewRouter()
+	NewHandler(newTestStore(t)).RegisterRoutes(router)
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	if w := serve("PUT", "/userdata/default", `{"content": "#cloud-config\ntimezone: UTC\n"}`); w.Code != http.StatusOK {
+		t.Errorf("put default: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("PUT", "/userdata/group/compute", `{"content": "#!/bin/sh\necho hi\n"}`); w.Code != http.StatusOK {
+		t.Errorf("put group: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("PUT", "/userdata/group/compute", `{"scope": "role", "content": "#!/bin/sh\n"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("mismatched scope: expected status 400, got %d", w.Code)
+	}
+	if w := serve("PUT", "/userdata/rack/r1", `{"content": "#!/bin/sh\n"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("unknown scope: expected status 400, got %d", w.Code)
+	}
+	if w := serve("GET", "/userdata/group/compute", ""); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"target":"compute"`) {
+		t.Errorf("get group: got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("DELETE", "/userdata/default", ""); w.Code != http.StatusNoContent {
+		t.Errorf("delete default: expected status 204, got %d", w.Code)
+	}
+	if w := serve("GET", "/userdata/default", ""); w.Code != http.StatusNotFound {
+		t.Errorf("get deleted default: expected status 404, got %d", w.Code)
+	}
+}
+
+func TestHandler_Tenancy(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	for uid, node := range map[string]string{
+		"nod-1": `{"spec":{"xname":"x1000c0s0b0n0","groups":["red-compute","shared"],"tenant":"red"}}`,
+		"nod-2": `{"spec":{"xname":"x1000c0s0b1n0","groups":["shared"],"tenant":"blue"}}`,
+	} {
+		if err := store.backend.Save(ctx, "Node", uid, []byte(node)); err != nil {
+			t.Fatalf("Save(%s) failed: %v", uid, err)
+		}
+	}
+	mustPut(t, store, UserData{Scope: ScopeDefault, Content: "#cloud-config\ntimezone: UTC\n"})
+	mustPut(t, store, UserData{Scope: ScopeNode, Target: "x1000c0s0b1n0", Content: "#!/bin/sh\necho blue\n"})
+
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(tenancy.WithTenant(r.Context(), "red")))
+		})
+	})
+	NewHandler(store).RegisterRoutes(router)
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	tests := []struct {
+		method, path string
+		want         int
+	}{
+		{"PUT", "/userdata/node/x1000c0s0b0n0", http.StatusOK},
+		{"PUT", "/userdata/group/red-compute", http.StatusOK},
+		{"PUT", "/userdata/node/x1000c0s0b1n0", http.StatusForbidden},
+		{"GET", "/userdata/node/x1000c0s0b1n0", http.StatusForbidden},
+		{"DELETE", "/userdata/node/x1000c0s0b1n0", http.StatusForbidden},
+		{"PUT", "/userdata/group/shared", http.StatusForbidden},
+		{"PUT", "/userdata/group/unclaimed", http.StatusForbidden},
+		{"PUT", "/userdata/role/Compute", http.StatusForbidden},
+		{"PUT", "/userdata/default", http.StatusForbidden},
+		{"GET", "/userdata/default", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		if w := serve(tt.method, tt.path, `{"content": "#!/bin/sh\necho red\n"}`); w.Code != tt.want {
+			t.Errorf("%s %s: expected status %d, got %d: %s", tt.method, tt.path, tt.want, w.Code, w.Body.String())
+		}
+	}
+
+	w := serve("GET", "/userdata", "")
+	var listed []UserData
+	if err := json.Unmarshal(w.Body.Bytes(), &listed); err != nil {
+		t.Fatalf("list: %v: %s", err, w.Body.String())
+	}
+	if len(listed) != 2 {
+		t.Errorf("list returned %d user-data, want the tenant's node and group: %+v", len(listed), listed)
+	}
+}
@@ -0,0 +1,258 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package userdata stores cloud-init user-data attached to every node, to a
+// role, to a group, or to one node, so identical user-data is written once
+// instead of for each of thousands of nodes.
+//
+// The user-data of a node merges the parts that apply to it in a fixed order,
+// each later part overriding the ones before: the default, the node's role,
+// its groups sorted by name, and the node itself. Cloud-config parts are
+// merged into one cloud-config: mappings merge key by key, lists are
+// appended, and other values are replaced. Parts of other types, such as
+// shell scripts, follow it in a MIME multi-part document. Jinja templates
+// are not supported.
+package userdata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ResourceType is the storage resource type used for user-data
+const ResourceType = "UserData"
+
+// Scopes of user-data, in merge order
+const (
+	ScopeDefault = "default"
+	ScopeRole    = "role"
+	ScopeGroup   = "group"
+	ScopeNode    = "node"
+)
+
+// Content types of user-data parts
+const (
+	CloudConfig = "text/cloud-config"
+	Multipart   = "multipart/mixed"
+)
+
+var (
+	// ErrNotFound is returned when no user-data is stored for a scope and
+	// target
+	ErrNotFound = errors.New("user-data not found")
+
+	// ErrForbidden is returned when user-data applies to nodes outside the
+	// tenant of a request
+	ErrForbidden = errors.New("user-data applies beyond the tenant's nodes")
+
+	targetPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?$`)
+
+	// partTypes maps the first line of a part to its MIME type
+	partTypes = []struct{ prefix, contentType string }{
+		{"#cloud-config", CloudConfig},
+		{"#cloud-boothook", "text/cloud-boothook"},
+		{"#include", "text/x-include-url"},
+		{"#part-handler", "text/part-handler"},
+		{"#!", "text/x-shellscript"},
+	}
+)
+
+// UserData is user-data attached to the nodes of a scope
+type UserData struct {
+	Scope string `json:"scope" yaml:"scope"`
+	// Target is the role, group, or xname the user-data applies to; empty
+	// for the default
+	Target      string    `json:"target,omitempty" yaml:"target,omitempty"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Content     string    `json:"content" yaml:"content"`
+	CreatedAt   time.Time `json:"createdAt" yaml:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt" yaml:"updatedAt"`
+}
+
+// Validate checks the scope, target, and content of user-data
+func (u *UserData) Validate() error {
+	switch u.Scope {
+	case ScopeDefault:
+		if u.Target != "" {
+			return errors.New("default user-data has no target")
+		}
+	case ScopeRole, ScopeGroup, ScopeNode:
+		if !ValidTarget(u.Target) {
+			return fmt.Errorf("invalid %s %q: use letters, digits, '.', '_' and '-'", u.Scope, u.Target)
+		}
+	default:
+		return fmt.Errorf("unknown scope %q, want %s, %s, %s, or %s", u.Scope, ScopeDefault, ScopeRole, ScopeGroup, ScopeNode)
+	}
+
+	if strings.HasPrefix(u.Content, "## template:") {
+		return errors.New("templated user-data is not supported")
+	}
+	contentType := partType(u.Content)
+	if contentType == "" {
+		return fmt.Errorf("content must start with #cloud-config, #!, #include, #cloud-boothook, or #part-handler")
+	}
+	if contentType == CloudConfig {
+		if _, err := parseCloudConfig(u.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidTarget reports whether target can name a role, group, or node
+func ValidTarget(target string) bool {
+	return len(target) <= 253 && targetPattern.MatchString(target)
+}
+
+// key returns the storage UID of user-data. Scopes contain no '.', so the
+// first one separates the target.
+func key(scope, target string) string {
+	if scope == ScopeDefault {
+		return ScopeDefault
+	}
+	return scope + "." + target
+}
+
+// name identifies user-data in merged documents and errors
+func (u *UserData) name() string {
+	if u.Scope == ScopeDefault {
+		return ScopeDefault
+	}
+	return u.Scope + " " + u.Target
+}
+
+// partType returns the MIME type of a part by its first line, or "" when
+// the type is unknown
+func partType(content string) string {
+	for _, t := range partTypes {
+		if strings.HasPrefix(content, t.prefix) {
+			return t.contentType
+		}
+	}
+	return ""
+}
+
+// parseCloudConfig parses a cloud-config part, which must be a mapping
+func parseCloudConfig(content string) (map[string]any, error) {
+	config := map[string]any{}
+	if err := yaml.Unmarshal([]byte(content), &config); err != nil {
+		return nil, fmt.Errorf("invalid cloud-config: %w", err)
+	}
+	return config, nil
+}
+
+// Document is the merged user-data of a node
+type Document struct {
+	// ContentType is text/cloud-config, multipart/mixed, or the type of
+	// the node's only part
+	ContentType string `json:"contentType"`
+	Content     string `json:"content"`
+	// Sources names the user-data merged, in merge order
+	Sources []string `json:"sources"`
+}
+
+// Merge merges parts, given in merge order, into one document. Without
+// parts the document is empty.
+func Merge(parts []UserData) (Document, error) {
+	doc := Document{Sources: []string{}}
+	var merged map[string]any
+	type part struct{ name, contentType, content string }
+	var others []part
+	for i := range parts {
+		doc.Sources = append(doc.Sources, parts[i].name())
+		contentType := partType(parts[i].Content)
+		if contentType != CloudConfig {
+			others = append(others, part{parts[i].name(), contentType, parts[i].Content})
+			continue
+		}
+		config, err := parseCloudConfig(parts[i].Content)
+		if err != nil {
+			return Document{}, fmt.Errorf("%s: %w", parts[i].name(), err)
+		}
+		merged = mergeValues(merged, config).(map[string]any)
+	}
+
+	var all []part
+	if merged != nil {
+		data, err := yaml.Marshal(merged)
+		if err != nil {
+			return Document{}, fmt.Errorf("encoding cloud-config: %w", err)
+		}
+		all = append(all, part{"cloud-config", CloudConfig, "#cloud-config\n" + string(data)})
+	}
+	all = append(all, others...)
+
+	switch len(all) {
+	case 0:
+		return doc, nil
+	case 1:
+		doc.ContentType, doc.Content = all[0].contentType, all[0].content
+		return doc, nil
+	}
+
+	// The boundary derives from the parts, so the same parts always give
+	// the same document, and cannot occur in them by chance
+	hash := sha256.New()
+	for _, p := range all {
+		hash.Write([]byte(p.content))
+	}
+	boundary := "boot-service-" + hex.EncodeToString(hash.Sum(nil))[:32]
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return Document{}, err
+	}
+	for i, p := range all {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", p.contentType+`; charset="utf-8"`)
+		header.Set("MIME-Version", "1.0")
+		header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="part-%03d-%s"`, i+1, strings.ReplaceAll(p.name, " ", "-")))
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return Document{}, err
+		}
+		w.Write([]byte(p.content)) //nolint:errcheck // writes to a buffer
+	}
+	if err := writer.Close(); err != nil {
+		return Document{}, err
+	}
+
+	doc.ContentType = Multipart
+	doc.Content = fmt.Sprintf("Content-Type: %s; boundary=%q\nMIME-Version: 1.0\n\n%s", Multipart, boundary, body.String())
+	return doc, nil
+}
+
+// mergeValues merges override into base: mappings key by key, lists
+// appended, and other values replaced
+func mergeValues(base, override any) any {
+	switch o := override.(type) {
+	case map[string]any:
+		b, ok := base.(map[string]any)
+		if !ok || b == nil {
+			b = map[string]any{}
+		}
+		for k, v := range o {
+			b[k] = mergeValues(b[k], v)
+		}
+		return b
+	case []any:
+		if b, ok := base.([]any); ok {
+			return append(b, o...)
+		}
+		return o
+	default:
+		return override
+	}
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package utilityboot is the library of built-in utility boot configurations:
+// memtest86+, a rescue shell, and a disk wipe. Enabled entries are installed
+// as ordinary boot configurations named builtin-<entry>, whose kernels and
+// initrds are read from a base URL the site fills with the images, so a node
+// can be sent to one with a boot-once override instead of a configuration
+// written by hand.
+//
+// A built-in configuration only matches nodes in its own group, also named
+// builtin-<entry>, so it boots nothing until it is asked for.
+package utilityboot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+// NamePrefix starts the name and group of every built-in configuration
+const NamePrefix = "builtin-"
+
+// Label marks built-in configurations with their library entry. Only
+// configurations carrying it are updated by Install.
+const Label = "boot.openchami.io/builtin"
+
+// Library entries
+const (
+	// Memtest runs memtest86+
+	Memtest = "memtest"
+	// Rescue boots a rescue initramfs to a shell
+	Rescue = "rescue"
+	// WipeDisk boots an image that erases the node's disks
+	WipeDisk = "wipe-disk"
+)
+
+// priority lets a built-in configuration win over the node's own when the
+// node is put in its group
+const priority = 100
+
+// console is the kernel console of the Linux-based entries
+const console = "console=tty0 console=ttyS0,115200"
+
+// Names returns the library entries
+func Names() []string {
+	return []string{Memtest, Rescue, WipeDisk}
+}
+
+// ParseNames parses a comma-separated list of library entries
+func ParseNames(list string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(Names(), name) {
+			return nil, fmt.Errorf("unknown utility boot configuration %q (available: %s)", name, strings.Join(Names(), ", "))
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// Spec returns the boot configuration spec of a library entry, with its files
+// under baseURL:
+//
+//	memtest:   memtest86+/memtest64.bin, and memtest64.efi for UEFI
+//	rescue:    rescue/vmlinuz, rescue/initrd.img
+//	wipe-disk: wipe-disk/vmlinuz, wipe-disk/initrd.img
+func Spec(name, baseURL string) (v1.BootConfigurationSpec, error) {
+	base := strings.TrimSuffix(baseURL, "/")
+	spec := v1.BootConfigurationSpec{Groups: []string{NamePrefix + name}, Priority: priority}
+	switch name {
+	case Memtest:
+		spec.Kernel = base + "/memtest86+/memtest64.bin"
+		spec.Firmware = []v1.FirmwareVariant{{Platform: v1.FirmwareEFI, Kernel: base + "/memtest86+/memtest64.efi"}}
+	case Rescue:
+		spec.Kernel = base + "/rescue/vmlinuz"
+		spec.Initrd = base + "/rescue/initrd.img"
+		spec.Params = console + " rd.shell rd.break=pre-mount"
+	case WipeDisk:
+		spec.Kernel = base + "/wipe-disk/vmlinuz"
+		spec.Initrd = base + "/wipe-disk/initrd.img"
+		// The image checks wipe.node against the node it runs on before
+		// erasing anything
+		spec.Params = console + " wipe.disks=all wipe.node={{.XName}}"
+	default:
+		return v1.BootConfigurationSpec{}, fmt.Errorf("unknown utility boot configuration %q", name)
+	}
+	return spec, nil
+}
+
+// Install creates or updates the boot configurations of the named entries in
+// backend. A configuration of the same name without Label belongs to the
+// site and is left alone.
+func Install(ctx context.Context, backend fabricaStorage.StorageBackend, names []string, baseURL string, logger *log.Logger) error {
+	items, err := backend.LoadAll(ctx, "BootConfiguration")
+	if err != nil {
+		return fmt.Errorf("loading boot configurations: %w", err)
+	}
+	existing := make(map[string]v1.BootConfiguration, len(items))
+	for _, item := range items {
+		var config v1.BootConfiguration
+		if json.Unmarshal(item, &config) == nil {
+			existing[config.Metadata.Name] = config
+		}
+	}
+
+	for _, name := range names {
+		spec, err := Spec(name, baseURL)
+		if err != nil {
+			return err
+		}
+		configName := NamePrefix + name
+		config, found := existing[configName]
+		switch {
+		case found && config.Metadata.Labels[Label] != name:
+			logger.Printf("Boot configuration %s is not built in; leaving it alone", configName)
+			continue
+		case found && reflect.DeepEqual(config.Spec, spec):
+			continue
+		case found:
+			config.Spec = spec
+			config.Metadata.UpdatedAt = time.Now().UTC()
+		default:
+			now := time.Now().UTC()
+			config = v1.BootConfiguration{
+				APIVersion: "boot.openchami.io/v1",
+				Kind:       "BootConfiguration",
+				Metadata: resource.Metadata{
+					Name:      configName,
+					UID:       uid(configName),
+					Labels:    map[string]string{Label: name},
+					CreatedAt: now,
+					UpdatedAt: now,
+				},
+				Spec: spec,
+			}
+		}
+
+		data, err := json.Marshal(config)
+		if err != nil {
+			return fmt.Errorf("encoding boot configuration %s: %w", configName, err)
+		}
+		if err := backend.Save(ctx, "BootConfiguration", config.Metadata.UID, data); err != nil {
+			return fmt.Errorf("saving boot configuration %s: %w", configName, err)
+		}
+		logger.Printf("Installed utility boot configuration %s", configName)
+	}
+	return nil
+}
+
+// uid derives the UID of a built-in configuration from its name, so replicas
+// installing it at the same time write the same resource
+func uid(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return "bootconfiguration-" + hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package utilityboot
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/resource"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+)
+
+func TestSpecsAreValid(t *testing.T) {
+	for _, name := range Names() {
+		spec, err := Spec(name, "http://files.example.com/utility/")
+		if err != nil {
+			t.Fatalf("%s: Spec returned error: %v", name, err)
+		}
+		config := v1.BootConfiguration{Metadata: resource.Metadata{Name: NamePrefix + name}, Spec: spec}
+		if err := config.Validate(context.Background()); err != nil {
+			t.Errorf("%s: invalid configuration: %v", name, err)
+		}
+		if !strings.HasPrefix(spec.Kernel, "http://files.example.com/utility/") || strings.Contains(strings.TrimPrefix(spec.Kernel, "http://"), "//") {
+			t.Errorf("%s: kernel = %q", name, spec.Kernel)
+		}
+		if !reflect.DeepEqual(spec.Groups, []string{NamePrefix + name}) {
+			t.Errorf("%s: groups = %v, want only its own", name, spec.Groups)
+		}
+	}
+}
+
+func TestParseNames(t *testing.T) {
+	names, err := ParseNames(" memtest, wipe-disk,,memtest ")
+	if err != nil || !reflect.DeepEqual(names, []string{Memtest, WipeDisk}) {
+		t.Errorf("ParseNames = %v, %v; want memtest and wipe-disk", names, err)
+	}
+	if _, err := ParseNames("memtest,fdisk"); err == nil {
+		t.Error("ParseNames accepted an unknown entry")
+	}
+}
+
+func TestInstall(t *testing.T) {
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	ctx := context.Background()
+	logger := log.New(io.Discard, "", 0)
+
+	// The site's own builtin-rescue is left alone
+	site := v1.BootConfiguration{
+		Metadata: resource.Metadata{Name: NamePrefix + Rescue, UID: "bootconfiguration-site"},
+		Spec:     v1.BootConfigurationSpec{Kernel: "http://site.example.com/rescue"},
+	}
+	data, _ := json.Marshal(site)
+	if err := backend.Save(ctx, "BootConfiguration", site.Metadata.UID, data); err != nil {
+		t.Fatalf("failed to save configuration: %v", err)
+	}
+
+	if err := Install(ctx, backend, Names(), "http://files.example.com/utility", logger); err != nil {
+		t.Fatalf("Install returned error: %v", err)
+	}
+	if err := Install(ctx, backend, []string{Memtest}, "http://mirror.example.com/utility", logger); err != nil {
+		t.Fatalf("second Install returned error: %v", err)
+	}
+
+	configs := loadConfigs(t, backend)
+	if len(configs) != 3 {
+		t.Fatalf("got %d configurations, want 3: %v", len(configs), configs)
+	}
+	if kernel := configs[NamePrefix+Memtest].Spec.Kernel; kernel != "http://mirror.example.com/utility/memtest86+/memtest64.bin" {
+		t.Errorf("memtest kernel = %q, want the updated base URL", kernel)
+	}
+	if kernel := configs[NamePrefix+Rescue].Spec.Kernel; kernel != "http://site.example.com/rescue" {
+		t.Errorf("rescue kernel = %q, want the site's", kernel)
+	}
+	if label := configs[NamePrefix+WipeDisk].Metadata.Labels[Label]; label != WipeDisk {
+		t.Errorf("wipe-disk label = %q", label)
+	}
+}
+
+func loadConfigs(t *testing.T, backend fabricaStorage.StorageBackend) map[string]v1.BootConfiguration {
+	t.Helper()
+	items, err := backend.LoadAll(context.Background(), "BootConfiguration")
+	if err != nil {
+		t.Fatalf("failed to load configurations: %v", err)
+	}
+	configs := make(map[string]v1.BootConfiguration)
+	for _, item := range items {
+		var config v1.BootConfiguration
+		if err := json.Unmarshal(item, &config); err != nil {
+			t.Fatalf("failed to decode configuration: %v", err)
+		}
+		configs[config.Metadata.Name] = config
+	}
+	return configs
+}
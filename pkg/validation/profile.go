@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// Validation profiles
+const (
+	// ProfileStrict accepts lowercase node xnames, MAC addresses in a
+	// notation net.ParseMAC reads, and http(s) URLs or absolute paths
+	ProfileStrict = "strict"
+	// ProfileLenient also accepts xnames in any case, MAC addresses with
+	// one-digit octets or separated by dots or spaces, and URLs of any
+	// scheme or relative paths
+	ProfileLenient = "lenient"
+)
+
+// Profile sets how strictly node xnames, MAC addresses, and kernel and
+// initrd URLs are validated, wherever resources are written
+type Profile struct {
+	Name string
+	// XName, MAC, and URL, when set, replace the profile's rule for their
+	// field: a value is valid when the pattern matches all of it
+	XName *regexp.Regexp
+	MAC   *regexp.Regexp
+	URL   *regexp.Regexp
+}
+
+// NewProfile creates the named profile with the given patterns, each of
+// which may be empty, overriding its rules
+func NewProfile(name, xnamePattern, macPattern, urlPattern string) (*Profile, error) {
+	if name != ProfileStrict && name != ProfileLenient {
+		return nil, fmt.Errorf("unknown validation profile %q: want %s or %s", name, ProfileStrict, ProfileLenient)
+	}
+	profile := &Profile{Name: name}
+	for _, field := range []struct {
+		name    string
+		pattern string
+		re      **regexp.Regexp
+	}{
+		{"xname", xnamePattern, &profile.XName},
+		{"mac", macPattern, &profile.MAC},
+		{"url", urlPattern, &profile.URL},
+	} {
+		if field.pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(`^(?:` + field.pattern + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s pattern: %w", field.name, err)
+		}
+		*field.re = re
+	}
+	return profile, nil
+}
+
+// Overrides returns the fields whose rule a pattern replaces
+func (p *Profile) Overrides() []string {
+	var fields []string
+	if p.XName != nil {
+		fields = append(fields, "xname")
+	}
+	if p.MAC != nil {
+		fields = append(fields, "mac")
+	}
+	if p.URL != nil {
+		fields = append(fields, "url")
+	}
+	return fields
+}
+
+// String describes the profile, such as "lenient (custom xname, mac)"
+func (p *Profile) String() string {
+	if overrides := p.Overrides(); len(overrides) > 0 {
+		return p.Name + " (custom " + strings.Join(overrides, ", ") + ")"
+	}
+	return p.Name
+}
+
+var active atomic.Pointer[Profile]
+
+func init() {
+	active.Store(&Profile{Name: ProfileStrict})
+}
+
+// SetProfile makes profile the one validation applies
+func SetProfile(profile *Profile) {
+	active.Store(profile)
+}
+
+// ActiveProfile returns the profile validation applies, strict unless set
+func ActiveProfile() *Profile {
+	return active.Load()
+}
+
+// validXName applies the profile to a node xname
+func (p *Profile) validXName(xname string) bool {
+	switch {
+	case p.XName != nil:
+		return p.XName.MatchString(xname)
+	case p.Name == ProfileLenient:
+		return GetXNameType(strings.ToLower(xname)) == XNameTypeNode
+	}
+	return GetXNameType(xname) == XNameTypeNode
+}
+
+// validMAC applies the profile to a non-empty MAC address
+func (p *Profile) validMAC(mac string) bool {
+	switch {
+	case p.MAC != nil:
+		return p.MAC.MatchString(mac)
+	case p.Name == ProfileLenient && looseMACPattern.MatchString(mac):
+		return true
+	}
+	_, err := net.ParseMAC(mac)
+	return err == nil
+}
+
+// validURL applies the profile to a non-empty kernel or initrd location
+func (p *Profile) validURL(value string) bool {
+	switch {
+	case p.URL != nil:
+		return p.URL.MatchString(value)
+	case p.Name == ProfileLenient:
+		if strings.ContainsFunc(value, func(r rune) bool { return r <= ' ' }) {
+			return false
+		}
+		if parsed, err := url.Parse(value); err == nil && parsed.Scheme != "" {
+			return parsed.Host != "" || parsed.Opaque != "" || parsed.Path != ""
+		}
+		return value != "/"
+	}
+	if parsed, err := url.Parse(value); err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") {
+		return true
+	}
+	return strings.HasPrefix(value, "/") && len(value) > 1
+}
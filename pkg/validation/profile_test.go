@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import "testing"
+
+func TestProfiles(t *testing.T) {
+	t.Cleanup(func() { SetProfile(&Profile{Name: ProfileStrict}) })
+
+	lenient, err := NewProfile(ProfileLenient, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom, err := NewProfile(ProfileStrict, `cn\d{3}`, "", `s3://.+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each value's validity under the strict, lenient, and custom profiles
+	tests := []struct {
+		value                  string
+		check                  func(string) bool
+		strict, loose, matched bool
+	}{
+		{"x1000c0s0b0n0", ValidateXName, true, true, false},
+		{"X1000C0S0B0N0", ValidateXName, false, true, false},
+		{"cn001", ValidateXName, false, false, true},
+		{"aa:bb:cc:dd:ee:ff", ValidateMAC, true, true, true},
+		{"a:b:c:d:e:f", ValidateMAC, false, true, false},
+		{"aa bb cc dd ee ff", ValidateMAC, false, true, false},
+		{"http://images/vmlinuz", ValidateURLOrPath, true, true, false},
+		{"tftp://10.0.0.1/vmlinuz", ValidateURLOrPath, false, true, false},
+		{"images/vmlinuz", ValidateURLOrPath, false, true, false},
+		{"s3://boot/vmlinuz", ValidateURLOrPath, false, true, true},
+		{"http://images/vm linuz", ValidateURLOrPath, true, false, false},
+	}
+	for _, tt := range tests {
+		for profile, want := range map[*Profile]bool{{Name: ProfileStrict}: tt.strict, lenient: tt.loose, custom: tt.matched} {
+			SetProfile(profile)
+			if got := tt.check(tt.value); got != want {
+				t.Errorf("%s: %q = %v, want %v", profile, tt.value, got, want)
+			}
+		}
+	}
+
+	if _, err := NewProfile("relaxed", "", "", ""); err == nil {
+		t.Error("unknown profile accepted")
+	}
+	if _, err := NewProfile(ProfileStrict, "cn[", "", ""); err == nil {
+		t.Error("invalid pattern accepted")
+	}
+	if got := custom.String(); got != "strict (custom xname, url)" {
+		t.Errorf("String() = %q", got)
+	}
+}
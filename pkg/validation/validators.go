@@ -7,21 +7,40 @@ package validation
 
 import (
 	"net"
-	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
+
+	"github.com/openchami/boot-service/pkg/templatefuncs"
 )
 
-// ValidateXName validates Cray XName format (e.g., x1000c0s0b0n0)
+// ParamsTemplateFuncs declares the functions kernel parameter templates may
+// call, so templates can be parsed when they are stored: the templatefuncs
+// library and secret, which the boot script controller supplies when
+// rendering.
+var ParamsTemplateFuncs = paramsTemplateFuncs()
+
+func paramsTemplateFuncs() template.FuncMap {
+	funcs := templatefuncs.Funcs()
+	funcs["secret"] = func(string) (string, error) { return "", nil }
+	return funcs
+}
+
+// ValidateXName validates a node XName (e.g., x1000c0s0b0n0) under the
+// active profile. Use GetXNameType or ValidateXNameType for other
+// components.
 func ValidateXName(xname string) bool {
-	if xname == "" {
-		return false
-	}
+	return ActiveProfile().validXName(xname)
+}
 
-	// Basic XName pattern: x<cab>c<chassis>s<slot>b<blade>n<node>
-	pattern := `^x\d+c\d+s\d+b\d+n\d+$`
-	matched, _ := regexp.MatchString(pattern, xname)
-	return matched
+// NormalizeXName returns an xname in the lowercase form HSM uses, as the
+// lenient profile accepts any case. Other values are returned unchanged.
+func NormalizeXName(xname string) string {
+	if lower := strings.ToLower(xname); lower != xname && IsXName(lower) {
+		return lower
+	}
+	return xname
 }
 
 // ValidateXNameOrDefault validates XName format or allows wildcards and defaults
@@ -47,36 +66,115 @@ func ValidateXNameOrDefault(xname string) bool {
 	return ValidateXName(xname)
 }
 
-// ValidateMAC validates MAC address format
+// ValidateMAC validates MAC address format under the active profile
 func ValidateMAC(mac string) bool {
 	if mac == "" {
 		return true // Optional field
 	}
 
-	_, err := net.ParseMAC(mac)
-	return err == nil
+	return ActiveProfile().validMAC(mac)
 }
 
-// ValidateURLOrPath validates URL format or file path
-func ValidateURLOrPath(value string) bool {
-	if value == "" {
-		return false // Required field should not be empty
+// looseMACPattern matches a MAC address written as six octets of one or two
+// hex digits, separated by colons, hyphens, dots, or spaces, as some
+// inventories export them
+var looseMACPattern = regexp.MustCompile(`^[0-9A-Fa-f]{1,2}([:. -][0-9A-Fa-f]{1,2}){5}$`)
+
+// NormalizeMAC returns mac in canonical form: lowercase hex pairs separated by
+// colons. It accepts colon, hyphen, and Cisco dotted (aabb.ccdd.eeff)
+// notation in any case, and the loose notation the lenient profile accepts.
+// A value that is not a MAC address is returned unchanged.
+func NormalizeMAC(mac string) string {
+	trimmed := strings.TrimSpace(mac)
+	if looseMACPattern.MatchString(trimmed) {
+		octets := strings.FieldsFunc(trimmed, func(r rune) bool { return strings.ContainsRune(":. -", r) })
+		for i, octet := range octets {
+			if len(octet) == 1 {
+				octets[i] = "0" + octet
+			}
+		}
+		trimmed = strings.Join(octets, ":")
+	}
+	hw, err := net.ParseMAC(trimmed)
+	if err != nil {
+		return mac
 	}
+	return hw.String()
+}
 
-	// Check if it's a valid URL
-	if parsedURL, err := url.Parse(value); err == nil {
-		// Must have scheme (http/https) for URLs
-		if parsedURL.Scheme == "http" || parsedURL.Scheme == "https" {
-			return true
+// EqualMAC reports whether a and b are the same, non-empty MAC address in
+// any notation
+func EqualMAC(a, b string) bool {
+	return a != "" && b != "" && strings.EqualFold(NormalizeMAC(a), NormalizeMAC(b))
+}
+
+// NormalizeNetmask returns an IPv4 netmask given in dotted notation, such as
+// 255.255.0.0, or as a prefix length, such as 16, in dotted notation. It
+// reports false for anything else, including non-contiguous masks.
+func NormalizeNetmask(netmask string) (string, bool) {
+	if bits, err := strconv.Atoi(netmask); err == nil {
+		if bits < 0 || bits > 32 {
+			return "", false
 		}
+		return net.IP(net.CIDRMask(bits, 32)).String(), true
+	}
+	ip := net.ParseIP(netmask).To4()
+	if ip == nil {
+		return "", false
 	}
+	if _, bits := net.IPMask(ip).Size(); bits == 0 {
+		return "", false
+	}
+	return ip.String(), true
+}
+
+// ValidateIPv4 validates an optional IPv4 address
+func ValidateIPv4(ip string) bool {
+	return ip == "" || net.ParseIP(ip).To4() != nil && !strings.Contains(ip, ":")
+}
+
+// hostnameLabelPattern matches one label of an RFC 1123 host name
+var hostnameLabelPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?$`)
 
-	// Check if it's a valid absolute file path
-	if strings.HasPrefix(value, "/") {
-		return len(value) > 1 // More than just "/"
+// ValidateHostname validates an optional RFC 1123 host name: dot-separated
+// labels of letters, digits, and inner hyphens, at most 253 characters
+func ValidateHostname(hostname string) bool {
+	if hostname == "" {
+		return true
+	}
+	if len(hostname) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(hostname, ".") {
+		if !hostnameLabelPattern.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// uuidPattern matches a UUID in the 8-4-4-4-12 hex digit form iPXE reports
+// as ${uuid}
+var uuidPattern = regexp.MustCompile(`^[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}$`)
+
+// IsUUID reports whether value is a UUID such as an SMBIOS system UUID
+func IsUUID(value string) bool {
+	return uuidPattern.MatchString(value)
+}
+
+// ValidateUUID validates an optional UUID
+func ValidateUUID(uuid string) bool {
+	return uuid == "" || IsUUID(uuid)
+}
+
+// ValidateURLOrPath validates a kernel or initrd URL or file path under the
+// active profile
+func ValidateURLOrPath(value string) bool {
+	if value == "" {
+		return false // Required field should not be empty
 	}
 
-	return false
+	return ActiveProfile().validURL(value)
 }
 
 // ValidateURLOrPathOptional validates URL format or file path, allowing empty values
@@ -87,3 +185,19 @@ func ValidateURLOrPathOptional(value string) bool {
 
 	return ValidateURLOrPath(value)
 }
+
+// ValidateChainURL validates an iPXE chain URL. It must be an http, https,
+// or tftp URL and may use the same template variables as kernel parameters;
+// iPXE settings such as ${mac} are left to iPXE.
+func ValidateChainURL(value string) bool {
+	lower := strings.ToLower(value)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") && !strings.HasPrefix(lower, "tftp://") {
+		return false
+	}
+	if strings.Contains(value, "{{") {
+		if _, err := template.New("chainURL").Funcs(ParamsTemplateFuncs).Parse(value); err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeMAC(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"aa:bb:cc:dd:ee:ff", "aa:bb:cc:dd:ee:ff"},
+		{"AA:BB:CC:DD:EE:FF", "aa:bb:cc:dd:ee:ff"},
+		{"aa-bb-cc-dd-ee-ff", "aa:bb:cc:dd:ee:ff"},
+		{"AA-Bb-cC-dd-EE-ff", "aa:bb:cc:dd:ee:ff"},
+		{"aabb.ccdd.eeff", "aa:bb:cc:dd:ee:ff"},
+		{"AABB.CCDD.EEFF", "aa:bb:cc:dd:ee:ff"},
+		{" aa:bb:cc:dd:ee:ff ", "aa:bb:cc:dd:ee:ff"},
+		{"AABBCCDDEEFF", "aa:bb:cc:dd:ee:ff"},
+		{"a:b:c:d:e:f", "0a:0b:0c:0d:0e:0f"},
+		{"AA BB CC DD EE 0", "aa:bb:cc:dd:ee:00"},
+		{"", ""},
+		{"x1000c0s0b0n0", "x1000c0s0b0n0"},
+		{"aa:bb:cc", "aa:bb:cc"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeMAC(tt.input); got != tt.want {
+			t.Errorf("NormalizeMAC(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestEqualMAC(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"aa:bb:cc:dd:ee:ff", "AA-BB-CC-DD-EE-FF", true},
+		{"aabb.ccdd.eeff", "aa:bb:cc:dd:ee:ff", true},
+		{"aa:bb:cc:dd:ee:ff", "aa:bb:cc:dd:ee:00", false},
+		{"", "", false},
+		{"aa:bb:cc:dd:ee:ff", "", false},
+	}
+	for _, tt := range tests {
+		if got := EqualMAC(tt.a, tt.b); got != tt.want {
+			t.Errorf("EqualMAC(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestValidateUUID(t *testing.T) {
+	for _, uuid := range []string{"", "4c4c4544-0042-3510-8052-b4c04f384d32", "4C4C4544-0042-3510-8052-B4C04F384D32"} {
+		if !ValidateUUID(uuid) {
+			t.Errorf("ValidateUUID(%q) = false, want true", uuid)
+		}
+	}
+	for _, uuid := range []string{"4c4c4544004235108052b4c04f384d32", "4c4c4544-0042-3510-8052-b4c04f384d3", "x1000c0s0b0n0"} {
+		if ValidateUUID(uuid) {
+			t.Errorf("ValidateUUID(%q) = true, want false", uuid)
+		}
+	}
+}
+
+func TestValidateHostname(t *testing.T) {
+	for _, hostname := range []string{"", "nid0001", "node-42.cluster.example.com", "N1"} {
+		if !ValidateHostname(hostname) {
+			t.Errorf("ValidateHostname(%q) = false, want true", hostname)
+		}
+	}
+	for _, hostname := range []string{"n1\ndhcp-script=/tmp/x", "n1,n2", "-n1", "n1-", "n1..example", "n_1", "n1 ", strings.Repeat("a", 64)} {
+		if ValidateHostname(hostname) {
+			t.Errorf("ValidateHostname(%q) = true, want false", hostname)
+		}
+	}
+}
+
+func TestNormalizeNetmask(t *testing.T) {
+	tests := map[string]string{"16": "255.255.0.0", "32": "255.255.255.255", "0": "0.0.0.0", "255.255.255.0": "255.255.255.0"}
+	for netmask, want := range tests {
+		if got, ok := NormalizeNetmask(netmask); !ok || got != want {
+			t.Errorf("NormalizeNetmask(%q) = %q, %v, want %q", netmask, got, ok, want)
+		}
+	}
+	for _, netmask := range []string{"33", "-1", "255.0.255.0", "ffff::", "mask"} {
+		if got, ok := NormalizeNetmask(netmask); ok {
+			t.Errorf("NormalizeNetmask(%q) = %q, want invalid", netmask, got)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import "strings"
+
+// XNameType is the kind of hardware component an xname names, using the
+// type names of the HMS xname taxonomy
+type XNameType string
+
+// XName types. An xname is a sequence of lowercase letter and number pairs,
+// such as x1000c0s0b0n0; the letters determine the type.
+const (
+	XNameTypeInvalid               XNameType = ""
+	XNameTypeSystem                XNameType = "System"
+	XNameTypeCDU                   XNameType = "CDU"
+	XNameTypeCDUMgmtSwitch         XNameType = "CDUMgmtSwitch"
+	XNameTypeCabinet               XNameType = "Cabinet"
+	XNameTypeCabinetBMC            XNameType = "CabinetBMC"
+	XNameTypeCabinetCDU            XNameType = "CabinetCDU"
+	XNameTypeCabinetPDUController  XNameType = "CabinetPDUController"
+	XNameTypeCabinetPDU            XNameType = "CabinetPDU"
+	XNameTypeCabinetPDUOutlet      XNameType = "CabinetPDUOutlet"
+	XNameTypeCEC                   XNameType = "CEC"
+	XNameTypeChassis               XNameType = "Chassis"
+	XNameTypeChassisBMC            XNameType = "ChassisBMC"
+	XNameTypeCMMFpga               XNameType = "CMMFpga"
+	XNameTypeCMMRectifier          XNameType = "CMMRectifier"
+	XNameTypeComputeModule         XNameType = "ComputeModule"
+	XNameTypeNodeEnclosure         XNameType = "NodeEnclosure"
+	XNameTypeNodeBMC               XNameType = "NodeBMC"
+	XNameTypeNodeBMCNic            XNameType = "NodeBMCNic"
+	XNameTypeNode                  XNameType = "Node"
+	XNameTypeNodeNic               XNameType = "NodeNic"
+	XNameTypeNodeHsnNic            XNameType = "NodeHsnNic"
+	XNameTypeNodeAccel             XNameType = "NodeAccel"
+	XNameTypeProcessor             XNameType = "Processor"
+	XNameTypeMemory                XNameType = "Memory"
+	XNameTypeStorageGroup          XNameType = "StorageGroup"
+	XNameTypeDrive                 XNameType = "Drive"
+	XNameTypeRouterModule          XNameType = "RouterModule"
+	XNameTypeRouterBMC             XNameType = "RouterBMC"
+	XNameTypeRouterBMCNic          XNameType = "RouterBMCNic"
+	XNameTypeRouterFpga            XNameType = "RouterFpga"
+	XNameTypeHSNBoard              XNameType = "HSNBoard"
+	XNameTypeHSNAsic               XNameType = "HSNAsic"
+	XNameTypeHSNConnector          XNameType = "HSNConnector"
+	XNameTypeHSNConnectorPort      XNameType = "HSNConnectorPort"
+	XNameTypeMgmtSwitch            XNameType = "MgmtSwitch"
+	XNameTypeMgmtSwitchConnector   XNameType = "MgmtSwitchConnector"
+	XNameTypeMgmtHLSwitchEnclosure XNameType = "MgmtHLSwitchEnclosure"
+	XNameTypeMgmtHLSwitch          XNameType = "MgmtHLSwitch"
+)
+
+// xnameTypes maps the letters of an xname, in order, to its type
+var xnameTypes = map[string]XNameType{
+	"s":       XNameTypeSystem,
+	"d":       XNameTypeCDU,
+	"dw":      XNameTypeCDUMgmtSwitch,
+	"x":       XNameTypeCabinet,
+	"xb":      XNameTypeCabinetBMC,
+	"xd":      XNameTypeCabinetCDU,
+	"xm":      XNameTypeCabinetPDUController,
+	"xmp":     XNameTypeCabinetPDU,
+	"xmpj":    XNameTypeCabinetPDUOutlet,
+	"xe":      XNameTypeCEC,
+	"xc":      XNameTypeChassis,
+	"xcb":     XNameTypeChassisBMC,
+	"xcf":     XNameTypeCMMFpga,
+	"xct":     XNameTypeCMMRectifier,
+	"xcs":     XNameTypeComputeModule,
+	"xcse":    XNameTypeNodeEnclosure,
+	"xcsb":    XNameTypeNodeBMC,
+	"xcsbi":   XNameTypeNodeBMCNic,
+	"xcsbn":   XNameTypeNode,
+	"xcsbni":  XNameTypeNodeNic,
+	"xcsbnh":  XNameTypeNodeHsnNic,
+	"xcsbna":  XNameTypeNodeAccel,
+	"xcsbnp":  XNameTypeProcessor,
+	"xcsbnd":  XNameTypeMemory,
+	"xcsbng":  XNameTypeStorageGroup,
+	"xcsbngk": XNameTypeDrive,
+	"xcr":     XNameTypeRouterModule,
+	"xcrb":    XNameTypeRouterBMC,
+	"xcrbi":   XNameTypeRouterBMCNic,
+	"xcrf":    XNameTypeRouterFpga,
+	"xcre":    XNameTypeHSNBoard,
+	"xcra":    XNameTypeHSNAsic,
+	"xcrj":    XNameTypeHSNConnector,
+	"xcrjp":   XNameTypeHSNConnectorPort,
+	"xcw":     XNameTypeMgmtSwitch,
+	"xcwj":    XNameTypeMgmtSwitchConnector,
+	"xch":     XNameTypeMgmtHLSwitchEnclosure,
+	"xchs":    XNameTypeMgmtHLSwitch,
+}
+
+// BMCXNameTypes are the xname types of management controllers a BMC
+// resource may name
+var BMCXNameTypes = []XNameType{
+	XNameTypeNodeBMC,
+	XNameTypeChassisBMC,
+	XNameTypeRouterBMC,
+	XNameTypeCabinetBMC,
+	XNameTypeCabinetPDUController,
+}
+
+// GetXNameType returns the type of the component xname names, or
+// XNameTypeInvalid if it is not an xname. Like HSM, it only accepts
+// lowercase xnames.
+func GetXNameType(xname string) XNameType {
+	letters := make([]byte, 0, 8)
+	for i := 0; i < len(xname); {
+		letter := xname[i]
+		if letter < 'a' || letter > 'z' {
+			return XNameTypeInvalid
+		}
+		letters = append(letters, letter)
+		i++
+
+		start := i
+		for i < len(xname) && xname[i] >= '0' && xname[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return XNameTypeInvalid
+		}
+	}
+
+	xnameType := xnameTypes[string(letters)]
+	// The whole system is s0; other numbers name nothing
+	if xnameType == XNameTypeSystem && xname != "s0" {
+		return XNameTypeInvalid
+	}
+	return xnameType
+}
+
+// IsXName reports whether value is an xname of any type
+func IsXName(value string) bool {
+	return GetXNameType(value) != XNameTypeInvalid
+}
+
+// ValidateXNameType reports whether xname is an xname of one of types
+func ValidateXNameType(xname string, types ...XNameType) bool {
+	xnameType := GetXNameType(xname)
+	if xnameType == XNameTypeInvalid {
+		return false
+	}
+	for _, t := range types {
+		if xnameType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// NodeBMC returns the xname of the BMC of a node, such as x1000c0s0b0 for
+// x1000c0s0b0n0, or "" if node is not a node xname
+func NodeBMC(node string) string {
+	if GetXNameType(node) != XNameTypeNode {
+		return ""
+	}
+	return node[:strings.LastIndexByte(node, 'n')]
+}
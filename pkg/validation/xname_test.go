@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import "testing"
+
+func TestGetXNameType(t *testing.T) {
+	tests := []struct {
+		xname string
+		want  XNameType
+	}{
+		{"s0", XNameTypeSystem},
+		{"d0", XNameTypeCDU},
+		{"d0w1", XNameTypeCDUMgmtSwitch},
+		{"x1000", XNameTypeCabinet},
+		{"x1000b0", XNameTypeCabinetBMC},
+		{"x3000m0", XNameTypeCabinetPDUController},
+		{"x3000m0p0j12", XNameTypeCabinetPDUOutlet},
+		{"x1000c0", XNameTypeChassis},
+		{"x1000c0b0", XNameTypeChassisBMC},
+		{"x1000c0s0", XNameTypeComputeModule},
+		{"x1000c0s0b0", XNameTypeNodeBMC},
+		{"x1000c0s0b0n0", XNameTypeNode},
+		{"x1000c0s0b0n0p1", XNameTypeProcessor},
+		{"x1000c0s0b0n0h0", XNameTypeNodeHsnNic},
+		{"x1000c0s0b0n0g1k2", XNameTypeDrive},
+		{"x1000c0r7", XNameTypeRouterModule},
+		{"x1000c0r7b0", XNameTypeRouterBMC},
+		{"x1000c0r7e0", XNameTypeHSNBoard},
+		{"x1000c0r7j4p1", XNameTypeHSNConnectorPort},
+		{"x3000c0w14", XNameTypeMgmtSwitch},
+		{"x3000c0h12s1", XNameTypeMgmtHLSwitch},
+
+		{"", XNameTypeInvalid},
+		{"s1", XNameTypeInvalid},
+		{"X1000c0s0b0n0", XNameTypeInvalid},
+		{"x1000c0s0b0n", XNameTypeInvalid},
+		{"x1000c0s0n0", XNameTypeInvalid},
+		{"x1000c0s0b0n0z1", XNameTypeInvalid},
+		{"nid000001", XNameTypeInvalid},
+		{"x1000-c0", XNameTypeInvalid},
+	}
+	for _, tt := range tests {
+		if got := GetXNameType(tt.xname); got != tt.want {
+			t.Errorf("GetXNameType(%q) = %q, want %q", tt.xname, got, tt.want)
+		}
+	}
+}
+
+func TestValidateXNameType(t *testing.T) {
+	if !ValidateXName("x1000c0s0b0n0") || ValidateXName("x1000c0s0b0") {
+		t.Error("ValidateXName should accept node xnames only")
+	}
+	for _, xname := range []string{"x1000c0s0b0", "x1000c0b0", "x1000c0r7b0", "x1000b0"} {
+		if !ValidateXNameType(xname, BMCXNameTypes...) {
+			t.Errorf("ValidateXNameType(%q, BMC types) = false, want true", xname)
+		}
+	}
+	if ValidateXNameType("x1000c0s0b0n0", BMCXNameTypes...) {
+		t.Error("a node xname should not validate as a BMC")
+	}
+}
+
+func TestNodeBMC(t *testing.T) {
+	tests := map[string]string{
+		"x1000c0s0b0n0":  "x1000c0s0b0",
+		"x3000c0s17b1n2": "x3000c0s17b1",
+		"x1000c0s0b0":    "",
+		"nid000001":      "",
+	}
+	for node, want := range tests {
+		if got := NodeBMC(node); got != want {
+			t.Errorf("NodeBMC(%q) = %q, want %q", node, got, want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/openchami/boot-service/pkg/secrets"
+)
+
+// SecretStore resolves {{secret "name"}} kernel parameter references from
+// Vault. The secret name is read from the value field of the secret at
+// <path>/<name>.
+type SecretStore struct {
+	client *Client
+	path   string
+}
+
+// NewSecretStore reads secrets below path, for example
+// secret/data/boot-service/params for a KV version 2 mount
+func NewSecretStore(client *Client, path string) *SecretStore {
+	return &SecretStore{client: client, path: path}
+}
+
+// Secret returns the value of the secret name
+func (s *SecretStore) Secret(ctx context.Context, name string) (string, error) {
+	if err := secrets.ValidateName(name); err != nil {
+		return "", err
+	}
+	value, err := s.client.ReadField(ctx, Reference{Path: s.path + "/" + name, Field: "value"})
+	if errors.Is(err, ErrNotFound) {
+		return "", fmt.Errorf("%w: %s", secrets.ErrNotFound, name)
+	}
+	return value, err
+}
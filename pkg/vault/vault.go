@@ -0,0 +1,356 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package vault reads service credentials from HashiCorp Vault.
+//
+// The client logs in with a token, a token file kept fresh by Vault Agent, or
+// AppRole, and keeps its token renewed. Settings refer to a secret field with
+// a reference of the form
+//
+//	vault:<path>#<field>
+//
+// where path is the API path below /v1, for example
+// vault:secret/data/boot-service#hsm_token for a KV version 2 secret or
+// vault:database/creds/boot-service#password for a dynamic credential.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReferencePrefix marks a setting whose value is read from Vault
+const ReferencePrefix = "vault:"
+
+// maxResponseSize bounds the size of a Vault response body
+const maxResponseSize = 1 << 20
+
+// Renewal timing
+const (
+	minRenewInterval = 5 * time.Second
+	retryInterval    = 10 * time.Second
+	// tokenFileInterval is how often a token file is re-read when its token
+	// does not expire
+	tokenFileInterval = time.Minute
+)
+
+// ErrNotFound is returned for a path or field that does not exist
+var ErrNotFound = errors.New("vault secret not found")
+
+// Reference names a field of a Vault secret
+type Reference struct {
+	Path  string
+	Field string
+}
+
+func (r Reference) String() string {
+	return ReferencePrefix + r.Path + "#" + r.Field
+}
+
+// IsReference reports whether value is a Vault reference
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, ReferencePrefix)
+}
+
+// ParseReference parses a vault:<path>#<field> reference
+func ParseReference(value string) (Reference, error) {
+	path, field, ok := strings.Cut(strings.TrimPrefix(value, ReferencePrefix), "#")
+	path = strings.Trim(path, "/")
+	if !IsReference(value) || !ok || path == "" || field == "" {
+		return Reference{}, fmt.Errorf("invalid Vault reference %q: want vault:<path>#<field>", value)
+	}
+	return Reference{Path: path, Field: field}, nil
+}
+
+// Config selects the Vault server and how to log in. Exactly one of Token,
+// TokenFile, or RoleID with SecretIDFile is used, in that order.
+type Config struct {
+	Address      string
+	Token        string
+	TokenFile    string
+	RoleID       string
+	SecretIDFile string
+	HTTPClient   *http.Client
+}
+
+// Client reads secrets from Vault
+type Client struct {
+	config     Config
+	httpClient *http.Client
+	logger     *log.Logger
+
+	mu        sync.RWMutex
+	token     string
+	ttl       time.Duration // 0 when the token does not expire
+	renewable bool
+}
+
+// NewClient creates a client; call Login before reading secrets
+func NewClient(config Config, logger *log.Logger) (*Client, error) {
+	parsed, err := url.Parse(config.Address)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid Vault address %q", config.Address)
+	}
+	if config.Token == "" && config.TokenFile == "" && (config.RoleID == "" || config.SecretIDFile == "") {
+		return nil, errors.New("vault needs a token, a token file, or an AppRole role ID and secret ID file")
+	}
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if logger == nil {
+		logger = log.New(log.Writer(), "vault: ", log.LstdFlags)
+	}
+	config.Address = strings.TrimRight(config.Address, "/")
+	return &Client{config: config, httpClient: httpClient, logger: logger}, nil
+}
+
+// Login obtains a token with the configured method
+func (c *Client) Login(ctx context.Context) error {
+	if c.config.Token == "" && c.config.TokenFile == "" {
+		return c.loginAppRole(ctx)
+	}
+
+	token := c.config.Token
+	if token == "" {
+		data, err := os.ReadFile(c.config.TokenFile)
+		if err != nil {
+			return fmt.Errorf("reading Vault token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	var resp struct {
+		Data struct {
+			TTL       int  `json:"ttl"`
+			Renewable bool `json:"renewable"`
+		} `json:"data"`
+	}
+	if err := c.do(ctx, http.MethodGet, "auth/token/lookup-self", token, nil, &resp); err != nil {
+		return fmt.Errorf("looking up Vault token: %w", err)
+	}
+	c.setToken(token, resp.Data.TTL, resp.Data.Renewable)
+	return nil
+}
+
+type authResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+		Renewable     bool   `json:"renewable"`
+	} `json:"auth"`
+}
+
+func (c *Client) loginAppRole(ctx context.Context) error {
+	secretID, err := os.ReadFile(c.config.SecretIDFile)
+	if err != nil {
+		return fmt.Errorf("reading Vault AppRole secret ID: %w", err)
+	}
+	body := map[string]string{"role_id": c.config.RoleID, "secret_id": strings.TrimSpace(string(secretID))}
+	var resp authResponse
+	if err := c.do(ctx, http.MethodPost, "auth/approle/login", "", body, &resp); err != nil {
+		return fmt.Errorf("logging in to Vault with AppRole: %w", err)
+	}
+	c.setToken(resp.Auth.ClientToken, resp.Auth.LeaseDuration, resp.Auth.Renewable)
+	return nil
+}
+
+func (c *Client) setToken(token string, ttlSeconds int, renewable bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+	c.ttl = time.Duration(ttlSeconds) * time.Second
+	c.renewable = renewable
+}
+
+// RunRenewal keeps the token valid until ctx is done: a renewable token is
+// renewed halfway through its lifetime, and a token that cannot be renewed
+// is replaced by logging in again
+func (c *Client) RunRenewal(ctx context.Context) {
+	for {
+		wait, ok := c.renewAfter()
+		if !ok {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		if err := c.renew(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Printf("Vault token renewal failed, logging in again: %v", err)
+			for c.Login(ctx) != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(retryInterval):
+				}
+			}
+		}
+	}
+}
+
+// renewAfter returns how long the current token can be used before renewal,
+// or false when it never needs renewing
+func (c *Client) renewAfter() (time.Duration, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.ttl == 0 {
+		// A token file may be replaced by Vault Agent at any time
+		return tokenFileInterval, c.config.TokenFile != ""
+	}
+	return max(c.ttl/2, minRenewInterval), true
+}
+
+func (c *Client) renew(ctx context.Context) error {
+	c.mu.RLock()
+	token, renewable := c.token, c.renewable
+	c.mu.RUnlock()
+	if !renewable || c.config.TokenFile != "" {
+		return c.Login(ctx)
+	}
+
+	var resp authResponse
+	if err := c.do(ctx, http.MethodPost, "auth/token/renew-self", token, struct{}{}, &resp); err != nil {
+		return err
+	}
+	// Renewal stops extending a token at its maximum TTL; log in again then
+	if resp.Auth.LeaseDuration < int(minRenewInterval.Seconds()) {
+		return errors.New("token reached its maximum TTL")
+	}
+	c.setToken(resp.Auth.ClientToken, resp.Auth.LeaseDuration, resp.Auth.Renewable)
+	return nil
+}
+
+// Read returns the data of the secret at path. KV version 2 secrets are
+// unwrapped, so their fields are at the top level like every other engine's.
+func (c *Client) Read(ctx context.Context, path string) (map[string]any, error) {
+	c.mu.RLock()
+	token := c.token
+	c.mu.RUnlock()
+
+	var resp struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := c.do(ctx, http.MethodGet, strings.Trim(path, "/"), token, nil, &resp); err != nil {
+		return nil, err
+	}
+	if inner, ok := resp.Data["data"].(map[string]any); ok {
+		if _, kv2 := resp.Data["metadata"]; kv2 {
+			return inner, nil
+		}
+	}
+	return resp.Data, nil
+}
+
+// ReadField returns one field of a secret as a string
+func (c *Client) ReadField(ctx context.Context, ref Reference) (string, error) {
+	data, err := c.Read(ctx, ref.Path)
+	if err != nil {
+		return "", err
+	}
+	value, ok := data[ref.Field]
+	if !ok || value == nil {
+		return "", fmt.Errorf("%w: %s has no field %s", ErrNotFound, ref.Path, ref.Field)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// CachedField returns a function reading ref that re-reads it from Vault at
+// most once per interval. When Vault cannot be reached the last value is
+// kept.
+func (c *Client) CachedField(ref Reference, interval time.Duration) func(context.Context) (string, error) {
+	var (
+		mu      sync.Mutex
+		value   string
+		fetched time.Time
+	)
+	return func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !fetched.IsZero() && time.Since(fetched) < interval {
+			return value, nil
+		}
+		next, err := c.ReadField(ctx, ref)
+		if err != nil {
+			if fetched.IsZero() {
+				return "", err
+			}
+			c.logger.Printf("Keeping previous value of %s: %v", ref, err)
+			fetched = time.Now()
+			return value, nil
+		}
+		value, fetched = next, time.Now()
+		return value, nil
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, path, token string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.config.Address+"/v1/"+path, reader)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, path)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		if json.Unmarshal(data, &vaultErr) == nil && len(vaultErr.Errors) > 0 {
+			return fmt.Errorf("vault returned %s: %s", resp.Status, strings.Join(vaultErr.Errors, "; "))
+		}
+		return fmt.Errorf("vault returned %s", resp.Status)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decoding Vault response: %w", err)
+	}
+	return nil
+}
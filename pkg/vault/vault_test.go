@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openchami/boot-service/pkg/secrets"
+)
+
+// fakeVault serves the few Vault endpoints the client uses
+type fakeVault struct {
+	mu       sync.Mutex
+	tokens   map[string]int // token -> TTL in seconds
+	secrets  map[string]any // path -> response data
+	renewals int
+}
+
+func newFakeVault(t *testing.T) (*fakeVault, *httptest.Server) {
+	fake := &fakeVault{tokens: map[string]int{"root": 0}, secrets: map[string]any{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	write := func(status int, body any) {
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body) //nolint:errcheck
+	}
+	if r.URL.Path == "/v1/auth/approle/login" {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+		if body["role_id"] != "boot-service" || body["secret_id"] != "s3cret" {
+			write(http.StatusBadRequest, map[string]any{"errors": []string{"invalid role or secret ID"}})
+			return
+		}
+		f.tokens["approle-token"] = 60
+		write(http.StatusOK, map[string]any{"auth": map[string]any{"client_token": "approle-token", "lease_duration": 60, "renewable": true}})
+		return
+	}
+
+	token := r.Header.Get("X-Vault-Token")
+	ttl, ok := f.tokens[token]
+	if !ok {
+		write(http.StatusForbidden, map[string]any{"errors": []string{"permission denied"}})
+		return
+	}
+	switch r.URL.Path {
+	case "/v1/auth/token/lookup-self":
+		write(http.StatusOK, map[string]any{"data": map[string]any{"ttl": ttl, "renewable": ttl > 0}})
+	case "/v1/auth/token/renew-self":
+		f.renewals++
+		write(http.StatusOK, map[string]any{"auth": map[string]any{"client_token": token, "lease_duration": ttl, "renewable": true}})
+	default:
+		data, ok := f.secrets[r.URL.Path[len("/v1/"):]]
+		if !ok {
+			write(http.StatusNotFound, map[string]any{"errors": []string{}})
+			return
+		}
+		write(http.StatusOK, map[string]any{"data": data})
+	}
+}
+
+func (f *fakeVault) set(path string, data any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.secrets[path] = data
+}
+
+func newClient(t *testing.T, config Config) *Client {
+	t.Helper()
+	client, err := NewClient(config, nil)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if err := client.Login(context.Background()); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	return client
+}
+
+func TestParseReference(t *testing.T) {
+	ref, err := ParseReference("vault:secret/data/boot-service#hsm_token")
+	if err != nil || ref != (Reference{Path: "secret/data/boot-service", Field: "hsm_token"}) {
+		t.Fatalf("ParseReference = %+v, %v", ref, err)
+	}
+	if ref.String() != "vault:secret/data/boot-service#hsm_token" {
+		t.Errorf("String() = %q", ref.String())
+	}
+	for _, value := range []string{"vault:secret/data/boot-service", "vault:#field", "vault:path#", "secret/data/x#y"} {
+		if _, err := ParseReference(value); err == nil {
+			t.Errorf("expected error parsing %q", value)
+		}
+	}
+}
+
+func TestNewClient_RequiresAddressAndAuth(t *testing.T) {
+	if _, err := NewClient(Config{Address: "vault:8200", Token: "root"}, nil); err == nil {
+		t.Error("expected error for an address without a scheme")
+	}
+	if _, err := NewClient(Config{Address: "https://vault:8200", RoleID: "boot-service"}, nil); err == nil {
+		t.Error("expected error for an AppRole role ID without a secret ID file")
+	}
+}
+
+func TestClient_ReadField(t *testing.T) {
+	fake, server := newFakeVault(t)
+	fake.set("secret/data/boot-service", map[string]any{
+		"data":     map[string]any{"hsm_token": "abc", "port": 6379},
+		"metadata": map[string]any{"version": 3},
+	})
+	fake.set("database/creds/boot-service", map[string]any{"username": "v-boot", "password": "pw"})
+	client := newClient(t, Config{Address: server.URL, Token: "root"})
+	ctx := context.Background()
+
+	tests := map[string]string{
+		"vault:secret/data/boot-service#hsm_token":   "abc",
+		"vault:secret/data/boot-service#port":        "6379",
+		"vault:database/creds/boot-service#password": "pw",
+	}
+	for value, want := range tests {
+		ref, _ := ParseReference(value)
+		if got, err := client.ReadField(ctx, ref); err != nil || got != want {
+			t.Errorf("ReadField(%s) = %q, %v, want %q", value, got, err, want)
+		}
+	}
+
+	for _, value := range []string{"vault:secret/data/boot-service#missing", "vault:secret/data/other#hsm_token"} {
+		ref, _ := ParseReference(value)
+		if _, err := client.ReadField(ctx, ref); !errors.Is(err, ErrNotFound) {
+			t.Errorf("ReadField(%s) error = %v, want ErrNotFound", value, err)
+		}
+	}
+}
+
+func TestClient_Login(t *testing.T) {
+	_, server := newFakeVault(t)
+	dir := t.TempDir()
+
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("root\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	newClient(t, Config{Address: server.URL, TokenFile: tokenFile})
+
+	client, err := NewClient(Config{Address: server.URL, Token: "wrong"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Login(context.Background()); err == nil {
+		t.Error("expected error logging in with an unknown token")
+	}
+
+	secretIDFile := filepath.Join(dir, "secret-id")
+	if err := os.WriteFile(secretIDFile, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	client = newClient(t, Config{Address: server.URL, RoleID: "boot-service", SecretIDFile: secretIDFile})
+	if wait, ok := client.renewAfter(); !ok || wait != 30*time.Second {
+		t.Errorf("renewAfter = %v, %v, want 30s", wait, ok)
+	}
+}
+
+func TestClient_Renew(t *testing.T) {
+	fake, server := newFakeVault(t)
+	secretIDFile := filepath.Join(t.TempDir(), "secret-id")
+	if err := os.WriteFile(secretIDFile, []byte("s3cret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	client := newClient(t, Config{Address: server.URL, RoleID: "boot-service", SecretIDFile: secretIDFile})
+
+	if err := client.renew(context.Background()); err != nil {
+		t.Fatalf("renew failed: %v", err)
+	}
+	if fake.renewals != 1 {
+		t.Errorf("renewals = %d, want 1", fake.renewals)
+	}
+
+	// A token at its maximum TTL is not worth renewing
+	fake.mu.Lock()
+	fake.tokens["approle-token"] = 1
+	fake.mu.Unlock()
+	if err := client.renew(context.Background()); err == nil {
+		t.Error("expected error renewing a token at its maximum TTL")
+	}
+
+	// A static token that never expires needs no renewal
+	root := newClient(t, Config{Address: server.URL, Token: "root"})
+	if _, ok := root.renewAfter(); ok {
+		t.Error("expected no renewal for a token that does not expire")
+	}
+}
+
+func TestClient_CachedField(t *testing.T) {
+	fake, server := newFakeVault(t)
+	fake.set("secret/hsm", map[string]any{"token": "one"})
+	client := newClient(t, Config{Address: server.URL, Token: "root"})
+	ctx := context.Background()
+
+	get := client.CachedField(Reference{Path: "secret/hsm", Field: "token"}, time.Hour)
+	fake.set("secret/hsm", map[string]any{"token": "two"})
+	if value, err := get(ctx); err != nil || value != "two" {
+		t.Fatalf("first read = %q, %v", value, err)
+	}
+	fake.set("secret/hsm", map[string]any{"token": "three"})
+	if value, _ := get(ctx); value != "two" {
+		t.Errorf("cached read = %q, want two", value)
+	}
+
+	refresh := client.CachedField(Reference{Path: "secret/hsm", Field: "token"}, 0)
+	if value, _ := refresh(ctx); value != "three" {
+		t.Errorf("read = %q, want three", value)
+	}
+	// The last value is kept while Vault cannot serve it
+	fake.set("secret/hsm", map[string]any{})
+	if value, err := refresh(ctx); err != nil || value != "three" {
+		t.Errorf("read after removal = %q, %v, want three", value, err)
+	}
+}
+
+func TestSecretStore(t *testing.T) {
+	fake, server := newFakeVault(t)
+	fake.set("secret/data/params/site/join-token", map[string]any{
+		"data":     map[string]any{"value": "t0ken"},
+		"metadata": map[string]any{},
+	})
+	store := NewSecretStore(newClient(t, Config{Address: server.URL, Token: "root"}), "secret/data/params")
+	ctx := context.Background()
+
+	if value, err := store.Secret(ctx, "site/join-token"); err != nil || value != "t0ken" {
+		t.Errorf("Secret = %q, %v", value, err)
+	}
+	if _, err := store.Secret(ctx, "missing"); !errors.Is(err, secrets.ErrNotFound) {
+		t.Errorf("expected secrets.ErrNotFound, got %v", err)
+	}
+	if _, err := store.Secret(ctx, "../sys/seal"); err == nil {
+		t.Error("expected error for an invalid secret name")
+	}
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/openchami/boot-service/internal/httputil"
+	"github.com/openchami/boot-service/pkg/audit"
+	"github.com/openchami/boot-service/pkg/tenancy"
+)
+
+// Path serves the workflow API
+const Path = "/workflows"
+
+// maxRequestSize bounds the body of a workflow request
+const maxRequestSize = 1 << 20
+
+// Handler serves the workflow API
+type Handler struct {
+	manager *Manager
+}
+
+// NewHandler creates a workflow API handler
+func NewHandler(manager *Manager) *Handler {
+	return &Handler{manager: manager}
+}
+
+// RegisterRoutes registers POST /workflows/reboot, GET /workflows, GET and
+// DELETE /workflows/{id}, and POST /workflows/{id}/pause and /resume
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route(Path, func(r chi.Router) {
+		r.Use(tenancy.Unscoped)
+		r.Get("/", h.List)
+		r.Post("/reboot", h.Reboot)
+		r.Get("/{id}", h.Get)
+		r.Delete("/{id}", h.Cancel)
+		r.Post("/{id}/pause", h.Pause)
+		r.Post("/{id}/resume", h.Resume)
+	})
+}
+
+// Reboot handles POST /workflows/reboot, which starts a reboot workflow and
+// returns it. Its progress is reported at /workflows/{id}.
+func (h *Handler) Reboot(w http.ResponseWriter, r *http.Request) {
+	var req RebootRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid workflow request", err.Error())
+		return
+	}
+	req.RequestedBy = ""
+	if actor, ok := audit.ActorFromContext(r.Context()); ok && actor.Subject != "" && actor.Subject != audit.AnonymousSubject {
+		req.RequestedBy = actor.Subject
+	}
+
+	workflow, err := h.manager.StartReboot(r.Context(), req)
+	switch {
+	case errors.Is(err, ErrInvalidRequest):
+		httputil.WriteError(w, http.StatusBadRequest, "Invalid workflow request", err.Error())
+	case errors.Is(err, ErrNodeBusy):
+		httputil.WriteError(w, http.StatusConflict, "Node busy", err.Error())
+	case err != nil:
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to start workflow", err.Error())
+	default:
+		httputil.WriteJSON(w, http.StatusAccepted, workflow)
+	}
+}
+
+// List handles GET /workflows, which lists the stored workflows, whichever
+// replica they were started through, newest first, without their nodes
+func (h *Handler) List(w http.ResponseWriter, _ *http.Request) {
+	httputil.WriteJSON(w, http.StatusOK, h.manager.List())
+}
+
+// Get handles GET /workflows/{id}, which reports the progress the leader
+// stored last
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	workflow, err := h.manager.Get(chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.WriteError(w, http.StatusNotFound, "Workflow not found", err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, workflow)
+}
+
+// Cancel handles DELETE /workflows/{id}, which has the leader stop the
+// workflow, rolling back the nodes it has not finished, and returns it
+func (h *Handler) Cancel(w http.ResponseWriter, r *http.Request) {
+	workflow, err := h.manager.Cancel(r.Context(), chi.URLParam(r, "id"))
+	switch {
+	case errors.Is(err, ErrNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "Workflow not found", err.Error())
+	case err != nil:
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to cancel workflow", err.Error())
+	default:
+		httputil.WriteJSON(w, http.StatusOK, workflow)
+	}
+}
+
+// Pause handles POST /workflows/{id}/pause, which stops the workflow from
+// starting nodes until it is resumed
+func (h *Handler) Pause(w http.ResponseWriter, r *http.Request) {
+	workflow, err := h.manager.Pause(r.Context(), chi.URLParam(r, "id"))
+	writePauseResult(w, workflow, err)
+}
+
+// Resume handles POST /workflows/{id}/resume
+func (h *Handler) Resume(w http.ResponseWriter, r *http.Request) {
+	workflow, err := h.manager.Resume(r.Context(), chi.URLParam(r, "id"))
+	writePauseResult(w, workflow, err)
+}
+
+func writePauseResult(w http.ResponseWriter, workflow Workflow, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "Workflow not found", err.Error())
+	case errors.Is(err, ErrFinished):
+		httputil.WriteError(w, http.StatusConflict, "Workflow finished", err.Error())
+	case err != nil:
+		httputil.WriteError(w, http.StatusInternalServerError, "Failed to change workflow", err.Error())
+	default:
+		httputil.WriteJSON(w, http.StatusOK, workflow)
+	}
+}
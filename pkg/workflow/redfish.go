@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/bmcdiscovery"
+	"github.com/openchami/boot-service/pkg/clients/redfish"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// Redfish power cycles nodes with the ComputerSystem.Reset action of their
+// BMC's Redfish service. It implements PowerCycler.
+type Redfish struct {
+	HTTP *http.Client
+	// Port of the Redfish services of BMCs not found by discovery; 443 when
+	// zero
+	Port     int
+	Username string
+	Password string
+	// ResetType resets a powered-on system; redfish.ResetForceRestart when
+	// empty. A powered-off system is turned on.
+	ResetType string
+	Timeout   time.Duration // for one power cycle
+}
+
+// PowerCycle resets the system of node. A BMC with several systems resets
+// the one with an interface holding a MAC of the node.
+func (p *Redfish) PowerCycle(ctx context.Context, node v1.Node, bmc v1.BMC) error {
+	port := p.Port
+	if port == 0 {
+		port = 443
+	}
+	baseURL := bmcdiscovery.BaseURL(&bmc, port)
+	if baseURL == "" {
+		return fmt.Errorf("BMC %s has no address", bmc.Metadata.Name)
+	}
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+
+	c := &redfish.Client{HTTP: p.HTTP, BaseURL: baseURL, Username: p.Username, Password: p.Password}
+	root, err := c.ServiceRoot(ctx)
+	if err != nil {
+		return err
+	}
+	systems, err := c.Systems(ctx, root)
+	if err != nil {
+		return err
+	}
+	system, err := nodeSystem(ctx, c, node, systems)
+	if err != nil {
+		return fmt.Errorf("BMC %s: %w", bmc.Metadata.Name, err)
+	}
+
+	resetType := p.ResetType
+	if resetType == "" {
+		resetType = redfish.ResetForceRestart
+	}
+	if strings.EqualFold(system.PowerState, "Off") {
+		resetType = redfish.ResetOn
+	}
+	return c.ResetSystem(ctx, system, resetType)
+}
+
+// nodeSystem returns the only system of systems, or the one with an
+// interface holding a MAC of node
+func nodeSystem(ctx context.Context, c *redfish.Client, node v1.Node, systems []redfish.System) (redfish.System, error) {
+	switch len(systems) {
+	case 0:
+		return redfish.System{}, fmt.Errorf("no systems")
+	case 1:
+		return systems[0], nil
+	}
+	macs := node.Spec.MACs()
+	for _, system := range systems {
+		interfaces, _ := c.Interfaces(ctx, system.EthernetInterfaces.ID)
+		for _, iface := range interfaces {
+			mac := strings.ToLower(validation.NormalizeMAC(strings.TrimSpace(iface.MACAddress)))
+			if mac != "" && slices.Contains(macs, mac) {
+				return system, nil
+			}
+		}
+	}
+	return redfish.System{}, fmt.Errorf("none of its %d systems has a MAC of node %s", len(systems), node.Spec.XName)
+}
@@ -0,0 +1,1365 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package workflow orchestrates reboots of many nodes into a boot
+// configuration. A reboot workflow sets a one-time boot override of each
+// node, power cycles the nodes through their BMCs in rate-limited batches or
+// in a rolling window of nodes in flight, and waits for each to phone home,
+// retrying power cycles and canceling the overrides of nodes that never do.
+// Too many failures stop a workflow before it reaches the rest of its nodes,
+// and workflows may be paused and resumed.
+//
+// Workflows are stored in the resource storage backend, so any replica
+// reports them and refuses to reboot a node twice. Only the leader runs them;
+// pauses, resumes, and cancellations requested through other replicas reach
+// it through storage as well. Phone-home reports are read from node
+// timelines, so a node may report to any replica.
+package workflow
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/bmcdiscovery"
+	"github.com/openchami/boot-service/pkg/bootonce"
+	"github.com/openchami/boot-service/pkg/cordon"
+	"github.com/openchami/boot-service/pkg/hostlist"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/timeline"
+	"github.com/openchami/boot-service/pkg/validation"
+)
+
+// TypeReboot is the type of reboot workflows
+const TypeReboot = "reboot"
+
+// ResourceType is the storage resource type of workflows
+const ResourceType = "Workflow"
+
+// commandResourceType is the storage resource type of the pauses, resumes,
+// and cancellations waiting for the leader, by workflow ID
+const commandResourceType = "WorkflowCommand"
+
+// Commands
+const (
+	commandPause  = "pause"
+	commandResume = "resume"
+	commandCancel = "cancel"
+)
+
+// controlTimeout bounds the wait for the leader to pause, resume, or cancel
+// a workflow
+const controlTimeout = 30 * time.Second
+
+// Rollout strategies
+const (
+	// StrategyBatch reboots batches of nodes one after another
+	StrategyBatch = "batch"
+	// StrategyRolling starts a node whenever fewer than MaxInFlight are
+	// rebooting
+	StrategyRolling = "rolling"
+)
+
+// Workflow states
+const (
+	StateRunning   = "running"
+	StatePaused    = "paused"    // starts no nodes until resumed
+	StateSucceeded = "succeeded" // every node phoned home or was skipped
+	StateFailed    = "failed"    // a node failed
+	StateCanceled  = "canceled"
+)
+
+// Node states, in the order a node goes through them
+const (
+	NodePending     = "pending"      // its batch has not started
+	NodeOverrideSet = "override-set" // boots the configuration next
+	NodePowerCycled = "power-cycled"
+	NodeBooted      = "booted"    // was served the configuration
+	NodeSucceeded   = "succeeded" // phoned home
+	NodeFailed      = "failed"
+	NodeCanceled    = "canceled" // the workflow was canceled while it rebooted
+	NodeSkipped     = "skipped"  // the workflow ended before its turn, or it was cordoned
+)
+
+// MaxNodes bounds the nodes of one workflow
+const MaxNodes = 10000
+
+// maxFinished is how many finished workflows are kept for their reports
+const maxFinished = 100
+
+var (
+	// ErrInvalidRequest is returned for a workflow that cannot be started
+	ErrInvalidRequest = errors.New("invalid workflow request")
+	// ErrNodeBusy is returned for a workflow targeting a node another
+	// running workflow reboots
+	ErrNodeBusy = errors.New("node is rebooted by a running workflow")
+	// ErrNotFound is returned for an unknown workflow
+	ErrNotFound = errors.New("workflow not found")
+	// ErrFinished is returned for pausing or resuming a finished workflow
+	ErrFinished = errors.New("workflow finished")
+
+	// errCanceled is the cause of a run canceled on request rather than
+	// because the leader stopped running workflows
+	errCanceled = errors.New("workflow canceled")
+)
+
+// Inventory lists nodes, boot configurations, and BMCs. client.Client and
+// client.InProcessClient implement it.
+type Inventory interface {
+	GetNodes(ctx context.Context) ([]v1.Node, error)
+	GetBootConfigurations(ctx context.Context) ([]v1.BootConfiguration, error)
+	GetBMCs(ctx context.Context) ([]v1.BMC, error)
+}
+
+// Overrides sets and cancels one-time boot overrides. *bootonce.Store
+// implements it.
+type Overrides interface {
+	Set(ctx context.Context, override bootonce.Override) (bootonce.Override, error)
+	Cancel(ctx context.Context, node string) (bool, error)
+}
+
+// Timelines returns node boot timelines. *timeline.Recorder implements it.
+type Timelines interface {
+	Get(ctx context.Context, node string) (timeline.Timeline, error)
+}
+
+// Cordons reports and sets node cordons. *cordon.Store implements it.
+type Cordons interface {
+	Get(node string) (cordon.Cordon, bool)
+	Cordon(ctx context.Context, c cordon.Cordon) (cordon.Cordon, error)
+}
+
+// PowerCycler power cycles a node through its BMC
+type PowerCycler interface {
+	PowerCycle(ctx context.Context, node v1.Node, bmc v1.BMC) error
+}
+
+// Config configures reboot workflows; requests may override the batch size,
+// delay, timeout, and retries
+type Config struct {
+	BatchSize  int           // nodes power cycled at once
+	BatchDelay time.Duration // pause between batches
+	// Timeout is how long a node has to phone home after a power cycle
+	Timeout time.Duration
+	// Retries is how many more times a node that does not phone home is
+	// power cycled
+	Retries int
+	// PollInterval is how often timelines are read for phone-home reports;
+	// 5 seconds when zero
+	PollInterval time.Duration
+	// SaveInterval is how often the progress of a running workflow is
+	// stored; 5 seconds when zero
+	SaveInterval time.Duration
+}
+
+// RebootRequest is the body of POST /workflows/reboot
+type RebootRequest struct {
+	// Nodes are xnames or hostlist expressions, such as x1000c0s[0-7]b0n0
+	Nodes []string `json:"nodes,omitempty"`
+	// Groups adds the nodes of these groups
+	Groups []string `json:"groups,omitempty"`
+	// Configuration is the boot configuration the nodes boot once
+	Configuration string `json:"configuration"`
+	Reason        string `json:"reason,omitempty"`
+
+	// Strategy is StrategyBatch (default) or StrategyRolling
+	Strategy string `json:"strategy,omitempty"`
+	// BatchSize and BatchDelaySeconds apply to StrategyBatch
+	BatchSize         int `json:"batchSize,omitempty"`
+	BatchDelaySeconds int `json:"batchDelaySeconds,omitempty"`
+	// MaxInFlight and MaxPerCabinet apply to StrategyRolling: at most
+	// MaxInFlight nodes, the batch size by default, and at most
+	// MaxPerCabinet of one cabinet, unlimited when zero, reboot at once
+	MaxInFlight    int  `json:"maxInFlight,omitempty"`
+	MaxPerCabinet  int  `json:"maxPerCabinet,omitempty"`
+	TimeoutSeconds int  `json:"timeoutSeconds,omitempty"`
+	Retries        *int `json:"retries,omitempty"`
+	// MaxFailures is how many nodes may fail before the workflow stops
+	// starting nodes; 0 stops at the first. MaxFailurePercent sets it to a
+	// percentage of the nodes instead.
+	MaxFailures       int `json:"maxFailures,omitempty"`
+	MaxFailurePercent int `json:"maxFailurePercent,omitempty"`
+	// CordonFailed cordons the nodes that fail after being power cycled, so
+	// they are held instead of booting again until an operator uncordons
+	// them
+	CordonFailed bool `json:"cordonFailed,omitempty"`
+
+	// RequestedBy is the token subject starting the workflow, if known
+	RequestedBy string `json:"-"`
+}
+
+// NodeProgress is the progress of one node of a workflow
+type NodeProgress struct {
+	Node     string `json:"node"`
+	BMC      string `json:"bmc,omitempty"`
+	Batch    int    `json:"batch,omitempty"` // from 1, with StrategyBatch
+	State    string `json:"state"`
+	Attempts int    `json:"attempts"` // power cycles issued
+	// RolledBack is set when the node's pending override was canceled, so
+	// it boots its own configuration again
+	RolledBack bool `json:"rolledBack,omitempty"`
+	// Cordoned is set when the workflow cordoned the node after it failed
+	Cordoned      bool      `json:"cordoned,omitempty"`
+	PowerCycledAt time.Time `json:"powerCycledAt,omitzero"` // the last power cycle
+	BootedAt      time.Time `json:"bootedAt,omitzero"`
+	PhonedHomeAt  time.Time `json:"phonedHomeAt,omitzero"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// Workflow reports a workflow and the progress of its nodes
+type Workflow struct {
+	ID            string         `json:"id"`
+	Type          string         `json:"type"`
+	State         string         `json:"state"`
+	Configuration string         `json:"configuration"`
+	Reason        string         `json:"reason,omitempty"`
+	RequestedBy   string         `json:"requestedBy,omitempty"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	StartedAt     time.Time      `json:"startedAt,omitzero"` // when the leader started running it
+	FinishedAt    time.Time      `json:"finishedAt,omitzero"`
+	Strategy      string         `json:"strategy"`
+	BatchSize     int            `json:"batchSize,omitempty"`
+	BatchDelay    string         `json:"batchDelay,omitempty"`
+	MaxInFlight   int            `json:"maxInFlight,omitempty"`
+	MaxPerCabinet int            `json:"maxPerCabinet,omitempty"`
+	Timeout       string         `json:"timeout"`
+	Retries       int            `json:"retries"`
+	MaxFailures   int            `json:"maxFailures"`
+	CordonFailed  bool           `json:"cordonFailed,omitempty"`
+	Batches       int            `json:"batches,omitempty"`
+	CurrentBatch  int            `json:"currentBatch,omitempty"` // 0 before the first starts
+	Counts        map[string]int `json:"counts"`                 // nodes by state
+	Error         string         `json:"error,omitempty"`
+	Nodes         []NodeProgress `json:"nodes"`
+}
+
+// command is a pause, resume, or cancellation waiting for the leader
+type command struct {
+	Workflow string `json:"workflow"`
+	Action   string `json:"action"`
+}
+
+// run is a workflow the leader runs
+type run struct {
+	mu       sync.Mutex
+	workflow Workflow
+	nodes    []v1.Node
+	bmcs     []v1.BMC
+	cancel   func()
+	done     chan struct{}
+	// resumed wakes the scheduler of a paused workflow
+	resumed chan struct{}
+	// dirty is set when progress was made since the workflow was stored
+	dirty bool
+	// saveMu orders the writes of the workflow
+	saveMu sync.Mutex
+}
+
+// Manager starts workflows and reports their progress
+type Manager struct {
+	backend   fabricaStorage.StorageBackend
+	inventory Inventory
+	overrides Overrides
+	timelines Timelines
+	cycler    PowerCycler
+	cordons   Cordons
+	config    Config
+	logger    *log.Logger
+	now       func() time.Time
+
+	// startMu makes the node-busy check and the write of a new workflow
+	// one step
+	startMu sync.Mutex
+
+	mu        sync.Mutex
+	workflows map[string]Workflow // as stored, by ID
+	runs      map[string]*run     // the workflows this replica runs
+	// changed is closed and replaced whenever workflows changes
+	changed chan struct{}
+
+	// wake tells Run to look for new workflows and commands
+	wake chan struct{}
+}
+
+// NewManager creates a workflow manager storing workflows in backend. Call
+// Load to restore the stored workflows, and Run on the leader to run them.
+func NewManager(backend fabricaStorage.StorageBackend, inventory Inventory, overrides Overrides, timelines Timelines, cycler PowerCycler, config Config, logger *log.Logger) *Manager {
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	if config.BatchSize < 1 {
+		config.BatchSize = 1
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = 5 * time.Second
+	}
+	if config.SaveInterval <= 0 {
+		config.SaveInterval = 5 * time.Second
+	}
+	return &Manager{
+		backend:   backend,
+		inventory: inventory,
+		overrides: overrides,
+		timelines: timelines,
+		cycler:    cycler,
+		config:    config,
+		logger:    logger,
+		now:       time.Now,
+		workflows: map[string]Workflow{},
+		runs:      map[string]*run{},
+		changed:   make(chan struct{}),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// SetCordons makes workflows skip cordoned nodes and lets them cordon the
+// nodes that fail
+func (m *Manager) SetCordons(cordons Cordons) {
+	m.cordons = cordons
+}
+
+// StartReboot validates req, resolves its nodes and their BMCs, and stores
+// the workflow for the leader to run
+func (m *Manager) StartReboot(ctx context.Context, req RebootRequest) (Workflow, error) {
+	if req.Configuration == "" {
+		return Workflow{}, fmt.Errorf("%w: configuration is required", ErrInvalidRequest)
+	}
+	if len(req.Nodes) == 0 && len(req.Groups) == 0 {
+		return Workflow{}, fmt.Errorf("%w: nodes or groups are required", ErrInvalidRequest)
+	}
+	if req.BatchSize < 0 || req.BatchDelaySeconds < 0 || req.MaxInFlight < 0 || req.MaxPerCabinet < 0 || req.TimeoutSeconds < 0 ||
+		req.MaxFailures < 0 || (req.Retries != nil && *req.Retries < 0) {
+		return Workflow{}, fmt.Errorf("%w: batchSize, batchDelaySeconds, maxInFlight, maxPerCabinet, timeoutSeconds, retries, and maxFailures must not be negative", ErrInvalidRequest)
+	}
+	switch req.Strategy {
+	case "":
+		req.Strategy = StrategyBatch
+	case StrategyBatch, StrategyRolling:
+	default:
+		return Workflow{}, fmt.Errorf("%w: strategy must be %q or %q", ErrInvalidRequest, StrategyBatch, StrategyRolling)
+	}
+	if req.Strategy == StrategyBatch && (req.MaxInFlight > 0 || req.MaxPerCabinet > 0) {
+		return Workflow{}, fmt.Errorf("%w: maxInFlight and maxPerCabinet require the %s strategy", ErrInvalidRequest, StrategyRolling)
+	}
+	if req.MaxFailurePercent < 0 || req.MaxFailurePercent > 100 {
+		return Workflow{}, fmt.Errorf("%w: maxFailurePercent must be between 0 and 100", ErrInvalidRequest)
+	}
+	if req.MaxFailurePercent > 0 && req.MaxFailures > 0 {
+		return Workflow{}, fmt.Errorf("%w: maxFailures and maxFailurePercent are exclusive", ErrInvalidRequest)
+	}
+	if req.CordonFailed && m.cordons == nil {
+		return Workflow{}, fmt.Errorf("%w: cordonFailed requires node cordons", ErrInvalidRequest)
+	}
+	configs, err := m.inventory.GetBootConfigurations(ctx)
+	if err != nil {
+		return Workflow{}, fmt.Errorf("listing boot configurations: %w", err)
+	}
+	if !slices.ContainsFunc(configs, func(c v1.BootConfiguration) bool { return c.Metadata.Name == req.Configuration }) {
+		return Workflow{}, fmt.Errorf("%w: boot configuration %s not found", ErrInvalidRequest, req.Configuration)
+	}
+	allNodes, err := m.inventory.GetNodes(ctx)
+	if err != nil {
+		return Workflow{}, fmt.Errorf("listing nodes: %w", err)
+	}
+	nodes, err := selectNodes(allNodes, req.Nodes, req.Groups)
+	if err != nil {
+		return Workflow{}, err
+	}
+	bmcs, err := m.inventory.GetBMCs(ctx)
+	if err != nil {
+		return Workflow{}, fmt.Errorf("listing BMCs: %w", err)
+	}
+
+	workflow := Workflow{
+		ID:            newID(),
+		Type:          TypeReboot,
+		State:         StateRunning,
+		Configuration: req.Configuration,
+		Reason:        req.Reason,
+		RequestedBy:   req.RequestedBy,
+		CreatedAt:     m.now().UTC(),
+		Strategy:      req.Strategy,
+		Retries:       m.config.Retries,
+		MaxFailures:   req.MaxFailures,
+		CordonFailed:  req.CordonFailed,
+	}
+	if req.MaxFailurePercent > 0 {
+		workflow.MaxFailures = len(nodes) * req.MaxFailurePercent / 100
+	}
+	batchSize, batchDelay, timeout := m.config.BatchSize, m.config.BatchDelay, m.config.Timeout
+	if req.BatchSize > 0 {
+		batchSize = req.BatchSize
+	}
+	if req.BatchDelaySeconds > 0 {
+		batchDelay = time.Duration(req.BatchDelaySeconds) * time.Second
+	}
+	if req.TimeoutSeconds > 0 {
+		timeout = time.Duration(req.TimeoutSeconds) * time.Second
+	}
+	if req.Retries != nil {
+		workflow.Retries = *req.Retries
+	}
+	workflow.Timeout = timeout.String()
+	if workflow.Strategy == StrategyBatch {
+		workflow.BatchSize = batchSize
+		workflow.BatchDelay = batchDelay.String()
+		workflow.Batches = (len(nodes) + batchSize - 1) / batchSize
+	} else {
+		workflow.MaxInFlight = batchSize
+		if req.MaxInFlight > 0 {
+			workflow.MaxInFlight = req.MaxInFlight
+		}
+		workflow.MaxPerCabinet = req.MaxPerCabinet
+	}
+	for i, node := range nodes {
+		progress := NodeProgress{Node: node.Spec.XName, State: NodePending}
+		if workflow.Strategy == StrategyBatch {
+			progress.Batch = i/batchSize + 1
+		}
+		for j := range bmcs {
+			if bmcdiscovery.Manages(&bmcs[j], node.Spec.XName) {
+				progress.BMC = bmcs[j].Metadata.Name
+				break
+			}
+		}
+		workflow.Nodes = append(workflow.Nodes, progress)
+	}
+	workflow.Counts = countStates(workflow.Nodes)
+
+	xnames := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		xnames[node.Spec.XName] = true
+	}
+	m.startMu.Lock()
+	defer m.startMu.Unlock()
+	m.mu.Lock()
+	for _, other := range m.workflows {
+		if busy := busyNode(other, xnames); busy != "" {
+			m.mu.Unlock()
+			return Workflow{}, fmt.Errorf("%w: %s", ErrNodeBusy, busy)
+		}
+	}
+	m.mu.Unlock()
+	if err := m.save(ctx, workflow); err != nil {
+		return Workflow{}, err
+	}
+
+	m.logger.Printf("Reboot workflow %s started by %q: %d nodes into %s (%s): %s",
+		workflow.ID, workflow.RequestedBy, len(nodes), workflow.Configuration, workflow.Strategy, workflow.Reason)
+	m.signal()
+	return workflow, nil
+}
+
+// selectNodes returns the nodes named by xname or hostlist expression, or in
+// one of groups, in xname order. Every xname named must exist.
+func selectNodes(nodes []v1.Node, names, groups []string) ([]v1.Node, error) {
+	known := map[string]bool{}
+	for _, node := range nodes {
+		known[node.Spec.XName] = true
+	}
+	var patterns []hostlist.Pattern
+	literal := map[string]bool{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if !hostlist.IsExpression(name) {
+			if !known[name] {
+				return nil, fmt.Errorf("%w: node %s not found", ErrInvalidRequest, name)
+			}
+			literal[name] = true
+			continue
+		}
+		pattern, err := hostlist.Parse(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	var selected []v1.Node
+	for _, node := range nodes {
+		xname := node.Spec.XName
+		if xname == "" {
+			continue
+		}
+		if literal[xname] ||
+			slices.ContainsFunc(patterns, func(p hostlist.Pattern) bool { return p.Match(xname) }) ||
+			slices.ContainsFunc(groups, func(group string) bool { return slices.Contains(node.Spec.Groups, group) }) {
+			selected = append(selected, node)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("%w: no nodes match", ErrInvalidRequest)
+	}
+	if len(selected) > MaxNodes {
+		return nil, fmt.Errorf("%w: %d nodes match, more than %d", ErrInvalidRequest, len(selected), MaxNodes)
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Spec.XName < selected[j].Spec.XName })
+	return selected, nil
+}
+
+// Load restores the stored workflows
+func (m *Manager) Load(ctx context.Context) error {
+	items, err := m.backend.LoadAll(ctx, ResourceType)
+	if err != nil {
+		return fmt.Errorf("loading workflows: %w", err)
+	}
+	workflows := make(map[string]Workflow, len(items))
+	for _, item := range items {
+		var workflow Workflow
+		if err := json.Unmarshal(item, &workflow); err != nil {
+			return fmt.Errorf("decoding workflow: %w", err)
+		}
+		workflows[workflow.ID] = workflow
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, r := range m.runs {
+		workflows[id] = r.snapshot()
+	}
+	m.workflows = workflows
+	m.notify()
+	return nil
+}
+
+// Get returns the workflow with id
+func (m *Manager) Get(id string) (Workflow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	workflow, ok := m.workflows[id]
+	if !ok {
+		return Workflow{}, ErrNotFound
+	}
+	return workflow, nil
+}
+
+// List returns the workflows, newest first, without their nodes
+func (m *Manager) List() []Workflow {
+	m.mu.Lock()
+	workflows := make([]Workflow, 0, len(m.workflows))
+	for _, workflow := range m.workflows {
+		workflow.Nodes = nil
+		workflows = append(workflows, workflow)
+	}
+	m.mu.Unlock()
+
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].CreatedAt.After(workflows[j].CreatedAt) })
+	return workflows
+}
+
+// Cancel stops the workflow with id, rolling back the nodes it has not
+// finished, and returns it once stopped
+func (m *Manager) Cancel(ctx context.Context, id string) (Workflow, error) {
+	return m.control(ctx, id, commandCancel, func(Workflow) bool { return false })
+}
+
+// Pause stops the workflow with id from starting nodes until it is resumed.
+// Nodes already rebooting carry on.
+func (m *Manager) Pause(ctx context.Context, id string) (Workflow, error) {
+	return m.control(ctx, id, commandPause, func(w Workflow) bool { return w.State == StatePaused })
+}
+
+// Resume lets a paused workflow start nodes again
+func (m *Manager) Resume(ctx context.Context, id string) (Workflow, error) {
+	return m.control(ctx, id, commandResume, func(w Workflow) bool { return w.State == StateRunning })
+}
+
+// control stores a command for the leader and waits until applied reports
+// it applied to the workflow with id, or the workflow finished
+func (m *Manager) control(ctx context.Context, id, action string, applied func(Workflow) bool) (Workflow, error) {
+	workflow, err := m.Get(id)
+	if err != nil {
+		return Workflow{}, err
+	}
+	if workflow.FinishedAt.IsZero() {
+		data, err := json.Marshal(command{Workflow: id, Action: action})
+		if err != nil {
+			return Workflow{}, fmt.Errorf("encoding %s of workflow %s: %w", action, id, err)
+		}
+		if err := m.backend.Save(ctx, commandResourceType, id, data); err != nil {
+			return Workflow{}, fmt.Errorf("saving %s of workflow %s: %w", action, id, err)
+		}
+		m.signal()
+		if workflow, err = m.waitFor(ctx, id, applied); err != nil {
+			return Workflow{}, fmt.Errorf("waiting for the leader to %s workflow %s: %w", action, id, err)
+		}
+	}
+	if !workflow.FinishedAt.IsZero() && action != commandCancel {
+		return Workflow{}, ErrFinished
+	}
+	return workflow, nil
+}
+
+// waitFor returns the workflow with id once done reports true for it or it
+// finished, waiting at most controlTimeout
+func (m *Manager) waitFor(ctx context.Context, id string, done func(Workflow) bool) (Workflow, error) {
+	ctx, cancel := context.WithTimeout(ctx, controlTimeout)
+	defer cancel()
+	for {
+		m.mu.Lock()
+		workflow, ok := m.workflows[id]
+		changed := m.changed
+		m.mu.Unlock()
+		if !ok {
+			return Workflow{}, ErrNotFound
+		}
+		if !workflow.FinishedAt.IsZero() || done(workflow) {
+			return workflow, nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return Workflow{}, ctx.Err()
+		}
+	}
+}
+
+// Run runs the stored workflows, and those stored later, until ctx is done,
+// then cancels them, rolling back the nodes they have not finished. Only the
+// leader runs workflows. A workflow left running by a leader that stopped
+// without canceling it, such as one that crashed, is canceled and rolled
+// back when Run starts.
+func (m *Manager) Run(ctx context.Context) {
+	if err := m.Load(ctx); err != nil {
+		m.logger.Printf("Failed to load workflows: %v", err)
+	}
+	m.interrupt(ctx)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		m.startQueued(ctx, &wg)
+		m.applyCommands(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.wake:
+		}
+	}
+}
+
+// interrupt cancels the stored workflows no replica runs, rolling back their
+// nodes
+func (m *Manager) interrupt(ctx context.Context) {
+	m.mu.Lock()
+	var orphaned []Workflow
+	for id, workflow := range m.workflows {
+		if workflow.FinishedAt.IsZero() && !workflow.StartedAt.IsZero() && m.runs[id] == nil {
+			orphaned = append(orphaned, workflow)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, workflow := range orphaned {
+		workflow.Nodes = slices.Clone(workflow.Nodes)
+		for i := range workflow.Nodes {
+			progress := &workflow.Nodes[i]
+			switch progress.State {
+			case NodePending:
+				progress.State = NodeSkipped
+			case NodeOverrideSet, NodePowerCycled, NodeBooted:
+				canceled, err := m.overrides.Cancel(ctx, progress.Node)
+				if err != nil {
+					m.logger.Printf("Failed to roll back boot-once override of node %s: %v", progress.Node, err)
+				}
+				progress.State = NodeCanceled
+				progress.RolledBack = canceled
+				progress.Error = "workflow interrupted"
+			}
+		}
+		workflow.State = StateCanceled
+		workflow.Error = "interrupted: the replica running it stopped"
+		workflow.FinishedAt = m.now().UTC()
+		workflow.Counts = countStates(workflow.Nodes)
+		if err := m.save(ctx, workflow); err != nil {
+			m.logger.Printf("Failed to store reboot workflow %s: %v", workflow.ID, err)
+			continue
+		}
+		m.logger.Printf("Reboot workflow %s interrupted; its nodes were rolled back", workflow.ID)
+	}
+}
+
+// startQueued starts the stored workflows that have not started
+func (m *Manager) startQueued(ctx context.Context, wg *sync.WaitGroup) {
+	m.mu.Lock()
+	var queued []Workflow
+	for id, workflow := range m.workflows {
+		if workflow.FinishedAt.IsZero() && workflow.StartedAt.IsZero() && m.runs[id] == nil {
+			queued = append(queued, workflow)
+		}
+	}
+	m.mu.Unlock()
+	if len(queued) == 0 {
+		return
+	}
+	sort.Slice(queued, func(i, j int) bool { return queued[i].CreatedAt.Before(queued[j].CreatedAt) })
+
+	// Nodes and BMCs are loaded again, since the workflow may have been
+	// started through another replica
+	nodes, err := m.inventory.GetNodes(ctx)
+	if err != nil {
+		m.logger.Printf("Failed to start reboot workflows: listing nodes: %v", err)
+		return
+	}
+	bmcs, err := m.inventory.GetBMCs(ctx)
+	if err != nil {
+		m.logger.Printf("Failed to start reboot workflows: listing BMCs: %v", err)
+		return
+	}
+	byXName := make(map[string]v1.Node, len(nodes))
+	for _, node := range nodes {
+		byXName[node.Spec.XName] = node
+	}
+	for _, workflow := range queued {
+		m.start(ctx, wg, workflow, byXName, bmcs)
+	}
+}
+
+// start runs a stored workflow, or fails it when another workflow this
+// replica runs reboots one of its nodes
+func (m *Manager) start(ctx context.Context, wg *sync.WaitGroup, workflow Workflow, nodes map[string]v1.Node, bmcs []v1.BMC) {
+	workflow.Nodes = slices.Clone(workflow.Nodes)
+	workflow.StartedAt = m.now().UTC()
+	xnames := make(map[string]bool, len(workflow.Nodes))
+	runNodes := make([]v1.Node, len(workflow.Nodes))
+	for i := range workflow.Nodes {
+		xname := workflow.Nodes[i].Node
+		xnames[xname] = true
+		node, ok := nodes[xname]
+		if !ok {
+			node.Spec.XName = xname
+			workflow.Nodes[i].State = NodeFailed
+			workflow.Nodes[i].Error = "node not found"
+		}
+		runNodes[i] = node
+	}
+	workflow.Counts = countStates(workflow.Nodes)
+
+	m.mu.Lock()
+	for _, other := range m.runs {
+		if busy := busyNode(other.snapshot(), xnames); busy != "" {
+			m.mu.Unlock()
+			workflow.State = StateFailed
+			workflow.Error = fmt.Sprintf("%v: %s", ErrNodeBusy, busy)
+			workflow.FinishedAt = workflow.StartedAt
+			if err := m.save(context.WithoutCancel(ctx), workflow); err != nil {
+				m.logger.Printf("Failed to store reboot workflow %s: %v", workflow.ID, err)
+			}
+			return
+		}
+	}
+	runCtx, cancel := context.WithCancelCause(ctx)
+	r := &run{workflow: workflow, nodes: runNodes, bmcs: bmcs, cancel: func() { cancel(errCanceled) }, done: make(chan struct{}), resumed: make(chan struct{}, 1)}
+	m.runs[workflow.ID] = r
+	m.mu.Unlock()
+
+	batchDelay, _ := time.ParseDuration(workflow.BatchDelay)
+	timeout, _ := time.ParseDuration(workflow.Timeout)
+	m.saveRun(context.WithoutCancel(ctx), r)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		m.runReboot(runCtx, r, batchDelay, timeout)
+		m.mu.Lock()
+		delete(m.runs, workflow.ID)
+		m.mu.Unlock()
+		m.prune(context.WithoutCancel(ctx))
+	}()
+}
+
+// applyCommands applies the stored commands to the workflows this replica
+// runs and deletes them
+func (m *Manager) applyCommands(ctx context.Context) {
+	items, err := m.backend.LoadAll(ctx, commandResourceType)
+	if err != nil {
+		m.logger.Printf("Failed to load workflow commands: %v", err)
+		return
+	}
+	for _, item := range items {
+		var c command
+		if err := json.Unmarshal(item, &c); err != nil {
+			m.logger.Printf("Ignoring undecodable workflow command: %v", err)
+			continue
+		}
+		m.mu.Lock()
+		r := m.runs[c.Workflow]
+		m.mu.Unlock()
+		if r != nil {
+			switch c.Action {
+			case commandCancel:
+				r.cancel()
+			case commandPause, commandResume:
+				m.setPaused(ctx, r, c.Action == commandPause)
+			}
+		}
+		if err := m.backend.Delete(ctx, commandResourceType, c.Workflow); err != nil && !errors.Is(err, fabricaStorage.ErrNotFound) {
+			m.logger.Printf("Failed to delete %s of workflow %s: %v", c.Action, c.Workflow, err)
+		}
+	}
+}
+
+// prune deletes the oldest finished workflows beyond maxFinished
+func (m *Manager) prune(ctx context.Context) {
+	m.mu.Lock()
+	var finished []Workflow
+	for _, workflow := range m.workflows {
+		if !workflow.FinishedAt.IsZero() {
+			finished = append(finished, workflow)
+		}
+	}
+	m.mu.Unlock()
+	if len(finished) <= maxFinished {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i].CreatedAt.Before(finished[j].CreatedAt) })
+	for _, workflow := range finished[:len(finished)-maxFinished] {
+		if err := m.backend.Delete(ctx, ResourceType, workflow.ID); err != nil && !errors.Is(err, fabricaStorage.ErrNotFound) {
+			m.logger.Printf("Failed to delete reboot workflow %s: %v", workflow.ID, err)
+			continue
+		}
+		m.mu.Lock()
+		delete(m.workflows, workflow.ID)
+		m.notify()
+		m.mu.Unlock()
+	}
+}
+
+// setPaused pauses or resumes r
+func (m *Manager) setPaused(ctx context.Context, r *run, paused bool) {
+	var finished bool
+	r.update(func(w *Workflow) {
+		if finished = !w.FinishedAt.IsZero(); finished {
+			return
+		}
+		w.State = StateRunning
+		if paused {
+			w.State = StatePaused
+		}
+	})
+	if finished {
+		return
+	}
+	m.saveRun(ctx, r)
+	id := r.snapshot().ID
+	if paused {
+		m.logger.Printf("Reboot workflow %s paused", id)
+		return
+	}
+	select {
+	case r.resumed <- struct{}{}:
+	default:
+	}
+	m.logger.Printf("Reboot workflow %s resumed", id)
+}
+
+// HandleResourceChange follows the workflows other replicas store and wakes
+// Run for their commands
+func (m *Manager) HandleResourceChange(ctx context.Context, event resourcewatch.Event) {
+	switch event.ResourceType {
+	case ResourceType:
+		// The manager's own writes are already applied
+		if resourcewatch.Remote(ctx) {
+			m.apply(event)
+		}
+	case commandResourceType:
+		if event.New != nil {
+			m.signal()
+		}
+	}
+}
+
+// apply records a workflow write made by another replica
+func (m *Manager) apply(event resourcewatch.Event) {
+	var workflow Workflow
+	if event.New != nil {
+		if err := json.Unmarshal(event.New, &workflow); err != nil {
+			m.logger.Printf("Ignoring undecodable workflow %s: %v", event.UID, err)
+			return
+		}
+	}
+
+	m.mu.Lock()
+	// This replica's runs report their own progress
+	if m.runs[event.UID] == nil {
+		if event.New == nil {
+			delete(m.workflows, event.UID)
+		} else {
+			m.workflows[event.UID] = workflow
+		}
+		m.notify()
+	}
+	m.mu.Unlock()
+	m.signal()
+}
+
+// save stores workflow
+func (m *Manager) save(ctx context.Context, workflow Workflow) error {
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		return fmt.Errorf("encoding workflow %s: %w", workflow.ID, err)
+	}
+	if err := m.backend.Save(ctx, ResourceType, workflow.ID, data); err != nil {
+		return fmt.Errorf("saving workflow %s: %w", workflow.ID, err)
+	}
+	m.mu.Lock()
+	m.workflows[workflow.ID] = workflow
+	m.notify()
+	m.mu.Unlock()
+	return nil
+}
+
+// saveRun stores the progress of r
+func (m *Manager) saveRun(ctx context.Context, r *run) {
+	r.saveMu.Lock()
+	defer r.saveMu.Unlock()
+	r.mu.Lock()
+	r.dirty = false
+	r.mu.Unlock()
+	workflow := r.snapshot()
+	if err := m.save(ctx, workflow); err != nil {
+		m.logger.Printf("Failed to store reboot workflow %s: %v", workflow.ID, err)
+		r.mu.Lock()
+		r.dirty = true
+		r.mu.Unlock()
+	}
+}
+
+// saveProgress stores the progress of r every SaveInterval until stop is
+// closed
+func (m *Manager) saveProgress(ctx context.Context, r *run, stop <-chan struct{}) {
+	ticker := time.NewTicker(m.config.SaveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		r.mu.Lock()
+		dirty := r.dirty
+		r.mu.Unlock()
+		if dirty {
+			m.saveRun(ctx, r)
+		}
+	}
+}
+
+// notify wakes the callers waiting for a workflow to change. m.mu must be
+// held.
+func (m *Manager) notify() {
+	close(m.changed)
+	m.changed = make(chan struct{})
+}
+
+// signal wakes Run
+func (m *Manager) signal() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// runReboot reboots the nodes of r with its strategy until they finish, too
+// many fail, or ctx is done
+func (m *Manager) runReboot(ctx context.Context, r *run, batchDelay, timeout time.Duration) {
+	defer close(r.done)
+	defer r.cancel()
+
+	// Progress is stored even once the workflow is canceled
+	saveCtx := context.WithoutCancel(ctx)
+	stopSaving := make(chan struct{})
+	saving := make(chan struct{})
+	go func() {
+		defer close(saving)
+		m.saveProgress(saveCtx, r, stopSaving)
+	}()
+
+	if r.snapshot().Strategy == StrategyRolling {
+		m.runRolling(ctx, r, timeout)
+	} else {
+		m.runBatches(ctx, r, batchDelay, timeout)
+	}
+
+	r.update(func(w *Workflow) {
+		for i := range w.Nodes {
+			if w.Nodes[i].State == NodePending {
+				w.Nodes[i].State = NodeSkipped
+			}
+		}
+		w.FinishedAt = m.now().UTC()
+		switch {
+		case ctx.Err() != nil:
+			w.State = StateCanceled
+			if !errors.Is(context.Cause(ctx), errCanceled) {
+				w.Error = "canceled: the replica running it stopped"
+			}
+		case w.Counts[NodeFailed] > 0 || w.Error != "":
+			w.State = StateFailed
+		default:
+			w.State = StateSucceeded
+		}
+	})
+	close(stopSaving)
+	<-saving
+	m.saveRun(saveCtx, r)
+	workflow := r.snapshot()
+	m.logger.Printf("Reboot workflow %s %s: %d of %d nodes succeeded, %d failed, %d skipped",
+		workflow.ID, workflow.State, workflow.Counts[NodeSucceeded], len(workflow.Nodes), workflow.Counts[NodeFailed], workflow.Counts[NodeSkipped])
+}
+
+// runBatches runs the batches of r one after another, pausing batchDelay
+// between them
+func (m *Manager) runBatches(ctx context.Context, r *run, batchDelay, timeout time.Duration) {
+	workflow := r.snapshot()
+	for batch := 1; batch <= workflow.Batches; batch++ {
+		if batch > 1 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(batchDelay):
+			}
+		}
+		if !r.waitWhilePaused(ctx) {
+			return
+		}
+		r.update(func(w *Workflow) { w.CurrentBatch = batch })
+
+		var wg sync.WaitGroup
+		for i := range workflow.Nodes {
+			if workflow.Nodes[i].Batch != batch {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m.rebootNode(ctx, r, i, timeout)
+			}()
+		}
+		wg.Wait()
+
+		if ctx.Err() == nil && r.tooManyFailures() {
+			return
+		}
+	}
+}
+
+// runRolling starts the nodes of r in order whenever fewer than MaxInFlight
+// of them, and fewer than MaxPerCabinet of the same cabinet, are rebooting
+func (m *Manager) runRolling(ctx context.Context, r *run, timeout time.Duration) {
+	workflow := r.snapshot()
+	pending := make([]int, len(workflow.Nodes))
+	for i := range pending {
+		pending[i] = i
+	}
+	inFlight := 0
+	cabinets := map[string]int{}
+	finished := make(chan int)
+	finish := func(i int) {
+		inFlight--
+		cabinets[cabinet(workflow.Nodes[i].Node)]--
+	}
+
+	stopped := false
+	for {
+		if !stopped && ctx.Err() == nil && r.snapshot().State != StatePaused {
+			for inFlight < workflow.MaxInFlight {
+				next := slices.IndexFunc(pending, func(i int) bool {
+					return workflow.MaxPerCabinet == 0 || cabinets[cabinet(workflow.Nodes[i].Node)] < workflow.MaxPerCabinet
+				})
+				if next < 0 {
+					break
+				}
+				i := pending[next]
+				pending = slices.Delete(pending, next, next+1)
+				inFlight++
+				cabinets[cabinet(workflow.Nodes[i].Node)]++
+				go func() {
+					m.rebootNode(ctx, r, i, timeout)
+					finished <- i
+				}()
+			}
+		}
+		if inFlight == 0 && (stopped || ctx.Err() != nil || len(pending) == 0) {
+			return
+		}
+
+		// Only finishing nodes matter once no more start
+		if stopped || ctx.Err() != nil {
+			finish(<-finished)
+			continue
+		}
+		select {
+		case i := <-finished:
+			finish(i)
+			stopped = r.tooManyFailures()
+		case <-r.resumed:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// rebootNode sets the override of node i of r and power cycles it until it
+// boots the configuration and phones home, at most 1+Retries times. Once an
+// override was set, it is canceled whichever way the node finishes, so one
+// left pending never fires on a later, unrelated reboot.
+func (m *Manager) rebootNode(ctx context.Context, r *run, i int, timeout time.Duration) {
+	workflow := r.snapshot()
+	progress := workflow.Nodes[i]
+	node := r.nodes[i]
+	// Rollbacks and cordons are made even when the workflow was canceled
+	cleanupCtx := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	}
+	fail := func(state, reason string) {
+		cordoned := false
+		if state == NodeFailed && workflow.CordonFailed && r.snapshot().Nodes[i].Attempts > 0 {
+			cordonCtx, cancel := cleanupCtx()
+			defer cancel()
+			_, err := m.cordons.Cordon(cordonCtx, cordon.Cordon{
+				Node:       progress.Node,
+				Reason:     fmt.Sprintf("reboot workflow %s: %s", workflow.ID, reason),
+				CordonedBy: workflow.RequestedBy,
+			})
+			if err != nil {
+				m.logger.Printf("Failed to cordon node %s: %v", progress.Node, err)
+			}
+			cordoned = err == nil
+		}
+		r.update(func(w *Workflow) {
+			w.Nodes[i].State = state
+			w.Nodes[i].Cordoned = cordoned
+			w.Nodes[i].Error = reason
+		})
+	}
+	rollback := func() {
+		rollbackCtx, cancel := cleanupCtx()
+		defer cancel()
+		canceled, err := m.overrides.Cancel(rollbackCtx, progress.Node)
+		if err != nil {
+			m.logger.Printf("Failed to roll back boot-once override of node %s: %v", progress.Node, err)
+		}
+		r.update(func(w *Workflow) { w.Nodes[i].RolledBack = canceled })
+	}
+
+	// A node cordoned since the workflow started would be held rather than
+	// boot the configuration
+	if m.cordons != nil {
+		if c, ok := m.cordons.Get(progress.Node); ok {
+			r.update(func(w *Workflow) {
+				w.Nodes[i].State = NodeSkipped
+				w.Nodes[i].Error = "cordoned: " + c.Reason
+			})
+			return
+		}
+	}
+
+	var bmc *v1.BMC
+	for j := range r.bmcs {
+		if r.bmcs[j].Metadata.Name == progress.BMC {
+			bmc = &r.bmcs[j]
+		}
+	}
+	if bmc == nil {
+		r.update(func(w *Workflow) {
+			w.Nodes[i].State = NodeFailed
+			w.Nodes[i].Error = "no BMC manages the node"
+		})
+		return
+	}
+
+	var lastErr string
+	for attempt := 1; attempt <= 1+workflow.Retries; attempt++ {
+		if ctx.Err() != nil {
+			fail(NodeCanceled, "workflow canceled")
+			return
+		}
+		// A node that booted the override without phoning home used it up
+		_, err := m.overrides.Set(ctx, bootonce.Override{
+			Node:          progress.Node,
+			Configuration: workflow.Configuration,
+			Reason:        fmt.Sprintf("reboot workflow %s: %s", workflow.ID, workflow.Reason),
+			RequestedBy:   workflow.RequestedBy,
+		})
+		if err != nil {
+			fail(NodeFailed, "setting boot-once override: "+err.Error())
+			return
+		}
+		if attempt == 1 {
+			defer rollback()
+		}
+		r.update(func(w *Workflow) { w.Nodes[i].State = NodeOverrideSet })
+
+		cycledAt := m.now()
+		err = m.cycler.PowerCycle(ctx, node, *bmc)
+		r.update(func(w *Workflow) {
+			w.Nodes[i].Attempts = attempt
+			if err == nil {
+				w.Nodes[i].State = NodePowerCycled
+				w.Nodes[i].PowerCycledAt = cycledAt.UTC()
+			}
+		})
+		if err != nil {
+			lastErr = "power cycle failed: " + err.Error()
+			m.logger.Printf("Reboot workflow %s: node %s attempt %d: %s", workflow.ID, progress.Node, attempt, lastErr)
+			continue
+		}
+
+		phonedHome := m.waitForPhoneHome(ctx, r, i, cycledAt, timeout)
+		if phonedHome {
+			return
+		}
+		lastErr = fmt.Sprintf("no phone-home after booting %s within %s", workflow.Configuration, timeout)
+		if ctx.Err() == nil {
+			m.logger.Printf("Reboot workflow %s: node %s attempt %d: %s", workflow.ID, progress.Node, attempt, lastErr)
+		}
+	}
+	if ctx.Err() != nil {
+		fail(NodeCanceled, "workflow canceled")
+		return
+	}
+	fail(NodeFailed, fmt.Sprintf("%s after %d attempts", lastErr, 1+workflow.Retries))
+}
+
+// waitForPhoneHome reads the timeline of node i of r until it shows the node
+// being served the configuration and then phoning home, both after since,
+// reporting whether it did before timeout. A phone-home from a node that
+// booted anything else, such as its own image through a replica that did not
+// hold the override, does not count.
+func (m *Manager) waitForPhoneHome(ctx context.Context, r *run, i int, since time.Time, timeout time.Duration) bool {
+	node := r.snapshot().Nodes[i].Node
+	configuration := r.snapshot().Configuration
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(m.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-deadline.C:
+			return false
+		case <-ticker.C:
+		}
+		t, err := m.timelines.Get(ctx, node)
+		if err != nil {
+			m.logger.Printf("Failed to read the timeline of node %s: %v", node, err)
+			continue
+		}
+		booted := false
+		for _, entry := range t.Between(since, time.Time{}).Entries {
+			switch {
+			case entry.Event == timeline.Script && entry.Config == configuration && !booted:
+				booted = true
+				r.update(func(w *Workflow) {
+					w.Nodes[i].State = NodeBooted
+					w.Nodes[i].BootedAt = entry.Time.UTC()
+				})
+			case entry.Event == timeline.PhoneHome && booted:
+				r.update(func(w *Workflow) {
+					w.Nodes[i].State = NodeSucceeded
+					w.Nodes[i].PhonedHomeAt = entry.Time.UTC()
+					w.Nodes[i].Error = ""
+				})
+				return true
+			}
+		}
+	}
+}
+
+// snapshot returns a copy of the workflow
+func (r *run) snapshot() Workflow {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	workflow := r.workflow
+	workflow.Nodes = slices.Clone(r.workflow.Nodes)
+	workflow.Counts = countStates(workflow.Nodes)
+	return workflow
+}
+
+// update changes the workflow and recounts its nodes
+func (r *run) update(fn func(w *Workflow)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn(&r.workflow)
+	r.workflow.Counts = countStates(r.workflow.Nodes)
+	r.dirty = true
+}
+
+// waitWhilePaused blocks while r is paused, reporting whether it may go on
+// rather than ctx being done
+func (r *run) waitWhilePaused(ctx context.Context) bool {
+	for r.snapshot().State == StatePaused && ctx.Err() == nil {
+		select {
+		case <-r.resumed:
+		case <-ctx.Done():
+		}
+	}
+	return ctx.Err() == nil
+}
+
+// tooManyFailures reports whether more than MaxFailures nodes of r failed,
+// recording the error that stops it
+func (r *run) tooManyFailures() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	failed := r.workflow.Counts[NodeFailed]
+	if failed <= r.workflow.MaxFailures {
+		return false
+	}
+	r.workflow.Error = fmt.Sprintf("%d nodes failed, more than the %d allowed", failed, r.workflow.MaxFailures)
+	return true
+}
+
+// busyNode returns a node of nodes, by xname, workflow reboots, or "" when
+// it finished or has none of them
+func busyNode(workflow Workflow, nodes map[string]bool) string {
+	if !workflow.FinishedAt.IsZero() {
+		return ""
+	}
+	for _, progress := range workflow.Nodes {
+		if nodes[progress.Node] {
+			return progress.Node
+		}
+	}
+	return ""
+}
+
+func countStates(nodes []NodeProgress) map[string]int {
+	counts := map[string]int{}
+	for _, node := range nodes {
+		counts[node.State]++
+	}
+	return counts
+}
+
+// cabinet returns the cabinet of an xname, such as x1000 for x1000c0s0b0n0,
+// or "" for a name that is not an xname
+func cabinet(xname string) string {
+	if !validation.IsXName(xname) || !strings.HasPrefix(xname, "x") {
+		return ""
+	}
+	if end := strings.IndexFunc(xname[1:], func(c rune) bool { return c < '0' || c > '9' }); end >= 0 {
+		return xname[:end+1]
+	}
+	return xname
+}
+
+// newID returns a random workflow ID
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return "wf-" + hex.EncodeToString(b)
+}
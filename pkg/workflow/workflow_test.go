@@ -0,0 +1,727 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	v1 "github.com/openchami/boot-service/apis/boot.openchami.io/v1"
+	"github.com/openchami/boot-service/pkg/bmcdiscovery"
+	"github.com/openchami/boot-service/pkg/bootonce"
+	"github.com/openchami/boot-service/pkg/clients/redfish"
+	"github.com/openchami/boot-service/pkg/cordon"
+	"github.com/openchami/boot-service/pkg/resourcewatch"
+	"github.com/openchami/boot-service/pkg/timeline"
+)
+
+// fakeInventory holds nodes, one boot configuration, and the BMCs x0c0s*b0
+type fakeInventory struct {
+	nodes []v1.Node
+	bmcs  []v1.BMC
+}
+
+func newFakeInventory(xnames ...string) *fakeInventory {
+	inventory := &fakeInventory{}
+	for _, xname := range xnames {
+		inventory.nodes = append(inventory.nodes, v1.Node{Spec: v1.NodeSpec{XName: xname, Groups: []string{"compute"}}})
+		bmc := v1.BMC{Spec: v1.BMCSpec{XName: strings.TrimSuffix(xname, "n0")}}
+		bmc.Metadata.Name = bmc.Spec.XName
+		inventory.bmcs = append(inventory.bmcs, bmc)
+	}
+	return inventory
+}
+
+func (f *fakeInventory) GetNodes(context.Context) ([]v1.Node, error) { return f.nodes, nil }
+
+func (f *fakeInventory) GetBMCs(context.Context) ([]v1.BMC, error) { return f.bmcs, nil }
+
+func (f *fakeInventory) GetBootConfigurations(context.Context) ([]v1.BootConfiguration, error) {
+	config := v1.BootConfiguration{}
+	config.Metadata.Name = "reprovision"
+	return []v1.BootConfiguration{config}, nil
+}
+
+// fakeOverrides records the pending overrides
+type fakeOverrides struct {
+	mu      sync.Mutex
+	pending map[string]string
+	sets    int
+}
+
+func (f *fakeOverrides) Set(_ context.Context, override bootonce.Override) (bootonce.Override, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pending[override.Node] = override.Configuration
+	f.sets++
+	return override, nil
+}
+
+func (f *fakeOverrides) Cancel(_ context.Context, node string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.pending[node]
+	delete(f.pending, node)
+	return ok, nil
+}
+
+// fakeCluster boots the nodes it power cycles: each takes its override and
+// phones home bootTime later, unless it is silent or its BMC fails
+type fakeCluster struct {
+	overrides *fakeOverrides
+	bootTime  time.Duration
+
+	mu        sync.Mutex
+	timelines map[string][]timeline.Entry
+	cycles    []string
+	silent    map[string]int // power cycles a node ignores
+	broken    map[string]bool
+	// nodes that boot their own image, leaving the override pending
+	ownImage map[string]bool
+	// most nodes booting at once, in all and by cabinet
+	maxBooting, maxBootingInCabinet int
+}
+
+func (f *fakeCluster) PowerCycle(_ context.Context, node v1.Node, bmc v1.BMC) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	xname := node.Spec.XName
+	f.cycles = append(f.cycles, xname)
+	if f.broken[xname] {
+		return errors.New("connection refused")
+	}
+	if f.silent[xname] > 0 {
+		f.silent[xname]--
+		return nil
+	}
+	config := "compute"
+	if !f.ownImage[xname] {
+		f.overrides.mu.Lock()
+		config = f.overrides.pending[xname]
+		delete(f.overrides.pending, xname)
+		f.overrides.mu.Unlock()
+	}
+	now := time.Now()
+	f.timelines[xname] = append(f.timelines[xname],
+		timeline.Entry{Time: now, Event: timeline.Script, Config: config},
+		timeline.Entry{Time: now.Add(f.bootTime), Event: timeline.PhoneHome})
+
+	booting, inCabinet := 0, 0
+	for other, entries := range f.timelines {
+		if entries[len(entries)-1].Time.After(now) {
+			booting++
+			if cabinet(other) == cabinet(xname) {
+				inCabinet++
+			}
+		}
+	}
+	f.maxBooting = max(f.maxBooting, booting)
+	f.maxBootingInCabinet = max(f.maxBootingInCabinet, inCabinet)
+	return nil
+}
+
+// Get returns the entries recorded so far
+func (f *fakeCluster) Get(_ context.Context, node string) (timeline.Timeline, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	entries := slices.DeleteFunc(slices.Clone(f.timelines[node]), func(e timeline.Entry) bool { return e.Time.After(now) })
+	return timeline.Timeline{Node: node, Entries: entries}, nil
+}
+
+// fakeCordons holds cordons in memory
+type fakeCordons struct {
+	mu      sync.Mutex
+	cordons map[string]cordon.Cordon
+}
+
+func (f *fakeCordons) Get(node string) (cordon.Cordon, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, ok := f.cordons[node]
+	return c, ok
+}
+
+func (f *fakeCordons) Cordon(_ context.Context, c cordon.Cordon) (cordon.Cordon, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cordons[c.Node] = c
+	return c, nil
+}
+
+func newTestBackend(t *testing.T) fabricaStorage.StorageBackend {
+	t.Helper()
+	backend, err := fabricaStorage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file backend: %v", err)
+	}
+	return backend
+}
+
+// newStoredManager creates a manager storing workflows in backend, without
+// running them
+func newStoredManager(backend fabricaStorage.StorageBackend, inventory *fakeInventory) (*Manager, *fakeCluster, *fakeOverrides) {
+	overrides := &fakeOverrides{pending: map[string]string{}}
+	cluster := &fakeCluster{overrides: overrides, timelines: map[string][]timeline.Entry{}, silent: map[string]int{}, broken: map[string]bool{}, ownImage: map[string]bool{}}
+	manager := NewManager(backend, inventory, overrides, cluster, cluster, Config{
+		BatchSize:    2,
+		Timeout:      200 * time.Millisecond,
+		Retries:      1,
+		PollInterval: 5 * time.Millisecond,
+		SaveInterval: 5 * time.Millisecond,
+	}, nil)
+	return manager, cluster, overrides
+}
+
+// newTestManager creates a manager that runs its workflows until the test
+// ends
+func newTestManager(t *testing.T, inventory *fakeInventory) (*Manager, *fakeCluster, *fakeOverrides) {
+	t.Helper()
+	manager, cluster, overrides := newStoredManager(newTestBackend(t), inventory)
+	runLeader(t, manager)
+	return manager, cluster, overrides
+}
+
+// runLeader runs the workflows of manager until the test ends
+func runLeader(t *testing.T, manager *Manager) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		manager.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// replicaSource reports the writes of another replica, as shared storage
+// does
+type replicaSource struct {
+	fn resourcewatch.Subscriber
+}
+
+func (s *replicaSource) Watch(_ context.Context, fn resourcewatch.Subscriber) error {
+	s.fn = fn
+	return nil
+}
+
+// newReplicas creates two managers sharing storage and a cluster. The first
+// runs the workflows, as the leader.
+func newReplicas(t *testing.T, inventory *fakeInventory) (*Manager, *Manager, *fakeCluster, *fakeOverrides) {
+	t.Helper()
+	shared := newTestBackend(t)
+	var backends [2]*resourcewatch.Backend
+	var sources [2]*replicaSource
+	for i := range backends {
+		backends[i] = resourcewatch.NewBackend(shared)
+		sources[i] = &replicaSource{}
+		if err := backends[i].Follow(context.Background(), sources[i]); err != nil {
+			t.Fatalf("Follow failed: %v", err)
+		}
+	}
+	for i, backend := range backends {
+		other := sources[1-i]
+		backend.Subscribe(func(ctx context.Context, event resourcewatch.Event) {
+			if !resourcewatch.Remote(ctx) {
+				other.fn(ctx, event)
+			}
+		})
+	}
+
+	leader, cluster, overrides := newStoredManager(backends[0], inventory)
+	follower := NewManager(backends[1], inventory, overrides, cluster, cluster, leader.config, nil)
+	backends[0].Subscribe(leader.HandleResourceChange)
+	backends[1].Subscribe(follower.HandleResourceChange)
+	runLeader(t, leader)
+	return leader, follower, cluster, overrides
+}
+
+// wait returns the workflow once it finished
+func wait(t *testing.T, manager *Manager, id string) Workflow {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		workflow, err := manager.Get(id)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if !workflow.FinishedAt.IsZero() {
+			return workflow
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("workflow %s did not finish", id)
+	return Workflow{}
+}
+
+func TestReboot(t *testing.T) {
+	manager, cluster, overrides := newTestManager(t, newFakeInventory("x0c0s0b0n0", "x0c0s1b0n0", "x0c0s2b0n0"))
+	cluster.silent["x0c0s1b0n0"] = 1 // phones home after its retry
+
+	workflow, err := manager.StartReboot(context.Background(), RebootRequest{
+		Nodes:         []string{"x0c0s[0-2]b0n0"},
+		Configuration: "reprovision",
+		Reason:        "new image",
+	})
+	if err != nil {
+		t.Fatalf("StartReboot failed: %v", err)
+	}
+	if workflow.Batches != 2 || len(workflow.Nodes) != 3 || workflow.Nodes[2].Batch != 2 || workflow.Nodes[0].BMC != "x0c0s0b0" {
+		t.Errorf("workflow = %+v, want 3 nodes with BMCs in 2 batches", workflow)
+	}
+
+	workflow = wait(t, manager, workflow.ID)
+	if workflow.State != StateSucceeded || workflow.Counts[NodeSucceeded] != 3 {
+		t.Fatalf("workflow = %+v, want every node succeeded", workflow)
+	}
+	retried := workflow.Nodes[1]
+	if retried.Attempts != 2 || retried.BootedAt.IsZero() || retried.PhonedHomeAt.IsZero() {
+		t.Errorf("retried node = %+v, want 2 attempts, booted and phoned home", retried)
+	}
+	if len(overrides.pending) != 0 {
+		t.Errorf("overrides left pending: %v", overrides.pending)
+	}
+	// The second batch starts after the first finished
+	if last := cluster.cycles[len(cluster.cycles)-1]; last != "x0c0s2b0n0" {
+		t.Errorf("power cycles = %v, want the second batch last", cluster.cycles)
+	}
+}
+
+func TestRebootRollsBackFailures(t *testing.T) {
+	manager, cluster, overrides := newTestManager(t, newFakeInventory("x0c0s0b0n0", "x0c0s1b0n0", "x0c0s2b0n0"))
+	cluster.silent["x0c0s0b0n0"] = 2 // never phones home
+	cluster.broken["x0c0s1b0n0"] = true
+
+	workflow, err := manager.StartReboot(context.Background(), RebootRequest{Groups: []string{"compute"}, Configuration: "reprovision"})
+	if err != nil {
+		t.Fatalf("StartReboot failed: %v", err)
+	}
+	workflow = wait(t, manager, workflow.ID)
+	if workflow.State != StateFailed || workflow.Error == "" {
+		t.Fatalf("workflow = %+v, want it failed", workflow)
+	}
+	silent, broken, skipped := workflow.Nodes[0], workflow.Nodes[1], workflow.Nodes[2]
+	if silent.State != NodeFailed || !silent.RolledBack || silent.Attempts != 2 || !strings.Contains(silent.Error, "no phone-home") {
+		t.Errorf("silent node = %+v, want failed and rolled back after 2 attempts", silent)
+	}
+	if broken.State != NodeFailed || !strings.Contains(broken.Error, "connection refused") {
+		t.Errorf("broken node = %+v, want failed with the BMC's error", broken)
+	}
+	if skipped.State != NodeSkipped || skipped.Attempts != 0 {
+		t.Errorf("node of the next batch = %+v, want skipped", skipped)
+	}
+	if len(overrides.pending) != 0 {
+		t.Errorf("overrides left pending: %v", overrides.pending)
+	}
+}
+
+func TestRebootRequiresTheConfiguration(t *testing.T) {
+	manager, cluster, overrides := newTestManager(t, newFakeInventory("x0c0s0b0n0"))
+	cluster.ownImage["x0c0s0b0n0"] = true
+
+	workflow, err := manager.StartReboot(context.Background(), RebootRequest{Nodes: []string{"x0c0s0b0n0"}, Configuration: "reprovision"})
+	if err != nil {
+		t.Fatalf("StartReboot failed: %v", err)
+	}
+	workflow = wait(t, manager, workflow.ID)
+	node := workflow.Nodes[0]
+	if workflow.State != StateFailed || node.State != NodeFailed || !node.BootedAt.IsZero() || !node.PhonedHomeAt.IsZero() {
+		t.Errorf("node = %+v, want failed without booting the configuration", node)
+	}
+	if !node.RolledBack || len(overrides.pending) != 0 {
+		t.Errorf("node = %+v, overrides pending %v, want the override rolled back", node, overrides.pending)
+	}
+}
+
+func TestRebootCancel(t *testing.T) {
+	manager, cluster, overrides := newTestManager(t, newFakeInventory("x0c0s0b0n0"))
+	cluster.silent["x0c0s0b0n0"] = 2
+	manager.config.Timeout = time.Minute
+
+	workflow, err := manager.StartReboot(context.Background(), RebootRequest{Nodes: []string{"x0c0s0b0n0"}, Configuration: "reprovision"})
+	if err != nil {
+		t.Fatalf("StartReboot failed: %v", err)
+	}
+	if _, err := manager.StartReboot(context.Background(), RebootRequest{Nodes: []string{"x0c0s0b0n0"}, Configuration: "reprovision"}); !errors.Is(err, ErrNodeBusy) {
+		t.Errorf("expected ErrNodeBusy rebooting a node twice, got %v", err)
+	}
+	for workflow.Nodes[0].State != NodePowerCycled {
+		time.Sleep(5 * time.Millisecond)
+		workflow, _ = manager.Get(workflow.ID)
+	}
+	workflow, err = manager.Cancel(context.Background(), workflow.ID)
+	if err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	if workflow.State != StateCanceled || workflow.Nodes[0].State != NodeCanceled || !workflow.Nodes[0].RolledBack || len(overrides.pending) != 0 {
+		t.Errorf("workflow = %+v, want canceled with the override rolled back", workflow)
+	}
+	if list := manager.List(); len(list) != 1 || list[0].Nodes != nil {
+		t.Errorf("List = %+v, want the workflow without its nodes", list)
+	}
+}
+
+func TestRebootAcrossReplicas(t *testing.T) {
+	leader, follower, cluster, overrides := newReplicas(t, newFakeInventory("x0c0s0b0n0"))
+	cluster.silent["x0c0s0b0n0"] = 2
+	follower.config.Timeout = time.Minute
+	ctx := context.Background()
+
+	// The leader runs a workflow started through another replica, which
+	// reports its progress
+	workflow, err := follower.StartReboot(ctx, RebootRequest{Nodes: []string{"x0c0s0b0n0"}, Configuration: "reprovision"})
+	if err != nil {
+		t.Fatalf("StartReboot failed: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for workflow.Nodes[0].State != NodePowerCycled && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+		workflow, _ = follower.Get(workflow.ID)
+	}
+	if workflow.Nodes[0].State != NodePowerCycled || workflow.StartedAt.IsZero() {
+		t.Fatalf("workflow = %+v, want its node power cycled by the leader", workflow)
+	}
+	if _, err := leader.StartReboot(ctx, RebootRequest{Nodes: []string{"x0c0s0b0n0"}, Configuration: "reprovision"}); !errors.Is(err, ErrNodeBusy) {
+		t.Errorf("expected ErrNodeBusy rebooting the node through the leader, got %v", err)
+	}
+
+	if workflow, err = follower.Pause(ctx, workflow.ID); err != nil || workflow.State != StatePaused {
+		t.Fatalf("Pause = %+v, %v, want paused by the leader", workflow, err)
+	}
+	workflow, err = follower.Cancel(ctx, workflow.ID)
+	if err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	if workflow.State != StateCanceled || !workflow.Nodes[0].RolledBack || len(overrides.pending) != 0 {
+		t.Errorf("workflow = %+v, want canceled with the override rolled back", workflow)
+	}
+	if list := follower.List(); len(list) != 1 || list[0].State != StateCanceled {
+		t.Errorf("List = %+v, want the canceled workflow", list)
+	}
+}
+
+func TestHandlerAcrossReplicas(t *testing.T) {
+	leader, follower, cluster, _ := newReplicas(t, newFakeInventory("x0c0s0b0n0"))
+	cluster.silent["x0c0s0b0n0"] = 2
+	leader.config.Timeout = time.Minute
+	router := chi.NewRouter()
+	NewHandler(follower).RegisterRoutes(router)
+	serve := func(method, path string) (*httptest.ResponseRecorder, Workflow) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		var workflow Workflow
+		_ = json.Unmarshal(rec.Body.Bytes(), &workflow)
+		return rec, workflow
+	}
+
+	// A workflow started through the leader is served by the other replica
+	started, err := leader.StartReboot(context.Background(), RebootRequest{Nodes: []string{"x0c0s0b0n0"}, Configuration: "reprovision"})
+	if err != nil {
+		t.Fatalf("StartReboot failed: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	var list []Workflow
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil || len(list) != 1 || list[0].ID != started.ID {
+		t.Fatalf("GET %s = %d %s, want the workflow", Path, rec.Code, rec.Body)
+	}
+	if rec, workflow := serve(http.MethodGet, Path+"/"+started.ID); rec.Code != http.StatusOK || workflow.ID != started.ID {
+		t.Fatalf("GET = %d %s, want the workflow", rec.Code, rec.Body)
+	}
+	if rec, workflow := serve(http.MethodPost, Path+"/"+started.ID+"/pause"); rec.Code != http.StatusOK || workflow.State != StatePaused {
+		t.Errorf("pause = %d %s, want the workflow paused", rec.Code, rec.Body)
+	}
+	if rec, workflow := serve(http.MethodPost, Path+"/"+started.ID+"/resume"); rec.Code != http.StatusOK || workflow.State != StateRunning {
+		t.Errorf("resume = %d %s, want the workflow running", rec.Code, rec.Body)
+	}
+	if rec, workflow := serve(http.MethodDelete, Path+"/"+started.ID); rec.Code != http.StatusOK || workflow.State != StateCanceled {
+		t.Errorf("DELETE = %d %s, want the workflow canceled", rec.Code, rec.Body)
+	}
+	if rec, _ := serve(http.MethodPost, Path+"/"+started.ID+"/pause"); rec.Code != http.StatusConflict {
+		t.Errorf("pausing a finished workflow = %d, want 409", rec.Code)
+	}
+	if rec, _ := serve(http.MethodGet, Path+"/wf-unknown"); rec.Code != http.StatusNotFound {
+		t.Errorf("GET of an unknown workflow = %d, want 404", rec.Code)
+	}
+}
+
+func TestRunCancelsWorkflowsWhenStopped(t *testing.T) {
+	backend := newTestBackend(t)
+	manager, cluster, overrides := newStoredManager(backend, newFakeInventory("x0c0s0b0n0"))
+	cluster.silent["x0c0s0b0n0"] = 2
+	manager.config.Timeout = time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		manager.Run(ctx)
+	}()
+
+	workflow, err := manager.StartReboot(ctx, RebootRequest{Nodes: []string{"x0c0s0b0n0"}, Configuration: "reprovision"})
+	if err != nil {
+		t.Fatalf("StartReboot failed: %v", err)
+	}
+	for workflow.Nodes[0].State != NodePowerCycled {
+		time.Sleep(5 * time.Millisecond)
+		workflow, _ = manager.Get(workflow.ID)
+	}
+	cancel()
+	<-done
+
+	// The workflow is stored canceled, so a restarted replica reports it
+	restarted, _, _ := newStoredManager(backend, newFakeInventory("x0c0s0b0n0"))
+	if err := restarted.Load(context.Background()); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	workflow, err = restarted.Get(workflow.ID)
+	if err != nil || workflow.State != StateCanceled || !strings.Contains(workflow.Error, "replica") {
+		t.Fatalf("stored workflow = %+v, %v, want canceled as its replica stopped", workflow, err)
+	}
+	if !workflow.Nodes[0].RolledBack || len(overrides.pending) != 0 {
+		t.Errorf("node = %+v, overrides pending %v, want the override rolled back", workflow.Nodes[0], overrides.pending)
+	}
+}
+
+func TestRunInterruptsOrphanedWorkflows(t *testing.T) {
+	ctx := context.Background()
+	backend := newTestBackend(t)
+	manager, _, overrides := newStoredManager(backend, newFakeInventory("x0c0s0b0n0", "x0c0s1b0n0"))
+	overrides.pending["x0c0s0b0n0"] = "reprovision"
+	orphan := Workflow{
+		ID: "wf-orphan", Type: TypeReboot, State: StateRunning, Configuration: "reprovision", StartedAt: time.Now(),
+		Nodes: []NodeProgress{{Node: "x0c0s0b0n0", State: NodePowerCycled}, {Node: "x0c0s1b0n0", State: NodePending}},
+	}
+	data, _ := json.Marshal(orphan)
+	if err := backend.Save(ctx, ResourceType, orphan.ID, data); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := manager.Load(ctx); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	runLeader(t, manager)
+	workflow := wait(t, manager, orphan.ID)
+	rebooting, pending := workflow.Nodes[0], workflow.Nodes[1]
+	if workflow.State != StateCanceled || !strings.Contains(workflow.Error, "interrupted") {
+		t.Errorf("workflow = %+v, want it interrupted", workflow)
+	}
+	if rebooting.State != NodeCanceled || !rebooting.RolledBack || len(overrides.pending) != 0 {
+		t.Errorf("rebooting node = %+v, overrides pending %v, want it rolled back", rebooting, overrides.pending)
+	}
+	if pending.State != NodeSkipped {
+		t.Errorf("pending node = %+v, want skipped", pending)
+	}
+}
+
+func TestRebootRolling(t *testing.T) {
+	manager, cluster, _ := newTestManager(t, newFakeInventory("x0c0s0b0n0", "x0c0s1b0n0", "x0c0s2b0n0", "x1c0s0b0n0", "x1c0s1b0n0", "x1c0s2b0n0"))
+	cluster.bootTime = 30 * time.Millisecond
+
+	workflow, err := manager.StartReboot(context.Background(), RebootRequest{
+		Groups:        []string{"compute"},
+		Configuration: "reprovision",
+		Strategy:      StrategyRolling,
+		MaxInFlight:   3,
+		MaxPerCabinet: 1,
+	})
+	if err != nil {
+		t.Fatalf("StartReboot failed: %v", err)
+	}
+	if workflow.Batches != 0 || workflow.BatchSize != 0 || workflow.MaxInFlight != 3 || workflow.Nodes[0].Batch != 0 {
+		t.Errorf("workflow = %+v, want a rolling window of 3 without batches", workflow)
+	}
+	workflow = wait(t, manager, workflow.ID)
+	if workflow.State != StateSucceeded || workflow.Counts[NodeSucceeded] != 6 {
+		t.Fatalf("workflow = %+v, want every node succeeded", workflow)
+	}
+	// Cabinets, not the window, limit the nodes in flight
+	if cluster.maxBooting > 2 || cluster.maxBootingInCabinet > 1 {
+		t.Errorf("%d nodes and %d of a cabinet booted at once, want at most 2 and 1", cluster.maxBooting, cluster.maxBootingInCabinet)
+	}
+}
+
+func TestRebootRollingStopsAtFailureThreshold(t *testing.T) {
+	manager, cluster, overrides := newTestManager(t, newFakeInventory("x0c0s0b0n0", "x0c0s1b0n0", "x0c0s2b0n0", "x0c0s3b0n0"))
+	cluster.broken["x0c0s0b0n0"] = true
+	cluster.broken["x0c0s1b0n0"] = true
+
+	workflow, err := manager.StartReboot(context.Background(), RebootRequest{
+		Groups:            []string{"compute"},
+		Configuration:     "reprovision",
+		Strategy:          StrategyRolling,
+		MaxInFlight:       1,
+		MaxFailurePercent: 25,
+	})
+	if err != nil {
+		t.Fatalf("StartReboot failed: %v", err)
+	}
+	workflow = wait(t, manager, workflow.ID)
+	if workflow.State != StateFailed || workflow.MaxFailures != 1 || !strings.Contains(workflow.Error, "more than the 1 allowed") {
+		t.Fatalf("workflow = %+v, want it stopped after 2 failures", workflow)
+	}
+	if workflow.Counts[NodeFailed] != 2 || workflow.Counts[NodeSkipped] != 2 || slices.Contains(cluster.cycles, "x0c0s2b0n0") {
+		t.Errorf("workflow = %+v, power cycles %v, want the nodes after the failures skipped", workflow, cluster.cycles)
+	}
+	if len(overrides.pending) != 0 {
+		t.Errorf("overrides left pending: %v", overrides.pending)
+	}
+}
+
+func TestRebootPauseResume(t *testing.T) {
+	manager, cluster, _ := newTestManager(t, newFakeInventory("x0c0s0b0n0", "x0c0s1b0n0", "x0c0s2b0n0"))
+	cluster.bootTime = 20 * time.Millisecond
+
+	workflow, err := manager.StartReboot(context.Background(), RebootRequest{
+		Groups: []string{"compute"}, Configuration: "reprovision", Strategy: StrategyRolling, MaxInFlight: 1,
+	})
+	if err != nil {
+		t.Fatalf("StartReboot failed: %v", err)
+	}
+	if workflow, err = manager.Pause(context.Background(), workflow.ID); err != nil || workflow.State != StatePaused {
+		t.Fatalf("Pause = %+v, %v, want paused", workflow, err)
+	}
+	// The node in flight, if one started, finishes; no other starts
+	for workflow.Counts[NodePending]+workflow.Counts[NodeSucceeded] != 3 {
+		time.Sleep(5 * time.Millisecond)
+		workflow, _ = manager.Get(workflow.ID)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if workflow, _ = manager.Get(workflow.ID); workflow.State != StatePaused || workflow.Counts[NodePending] < 2 {
+		t.Fatalf("paused workflow = %+v, want at most one node rebooted", workflow)
+	}
+
+	if _, err := manager.Resume(context.Background(), workflow.ID); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	workflow = wait(t, manager, workflow.ID)
+	if workflow.State != StateSucceeded || workflow.Counts[NodeSucceeded] != 3 {
+		t.Errorf("workflow = %+v, want every node succeeded after resuming", workflow)
+	}
+	if _, err := manager.Pause(context.Background(), workflow.ID); !errors.Is(err, ErrFinished) {
+		t.Errorf("expected ErrFinished pausing a finished workflow, got %v", err)
+	}
+	if _, err := manager.Resume(context.Background(), "wf-unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound resuming an unknown workflow, got %v", err)
+	}
+}
+
+func TestRebootCordons(t *testing.T) {
+	manager, cluster, _ := newTestManager(t, newFakeInventory("x0c0s0b0n0", "x0c0s1b0n0", "x0c0s2b0n0"))
+	cordons := &fakeCordons{cordons: map[string]cordon.Cordon{"x0c0s0b0n0": {Node: "x0c0s0b0n0", Reason: "bad DIMM"}}}
+	manager.SetCordons(cordons)
+	cluster.silent["x0c0s1b0n0"] = 2
+
+	workflow, err := manager.StartReboot(context.Background(), RebootRequest{
+		Groups: []string{"compute"}, Configuration: "reprovision", MaxFailures: 1, CordonFailed: true, RequestedBy: "alice",
+	})
+	if err != nil {
+		t.Fatalf("StartReboot failed: %v", err)
+	}
+	workflow = wait(t, manager, workflow.ID)
+	held, failed, rebooted := workflow.Nodes[0], workflow.Nodes[1], workflow.Nodes[2]
+	if held.State != NodeSkipped || held.Attempts != 0 || !strings.Contains(held.Error, "bad DIMM") {
+		t.Errorf("cordoned node = %+v, want skipped", held)
+	}
+	if failed.State != NodeFailed || !failed.Cordoned || !failed.RolledBack {
+		t.Errorf("failed node = %+v, want rolled back and cordoned", failed)
+	}
+	if c, ok := cordons.Get("x0c0s1b0n0"); !ok || c.CordonedBy != "alice" || !strings.Contains(c.Reason, workflow.ID) {
+		t.Errorf("cordon of failed node = %+v, %v, want one naming the workflow", c, ok)
+	}
+	if rebooted.State != NodeSucceeded || workflow.State != StateFailed {
+		t.Errorf("workflow = %+v, want the last node succeeded and the workflow failed", workflow)
+	}
+}
+
+func TestStartRebootInvalid(t *testing.T) {
+	manager, _, _ := newTestManager(t, newFakeInventory("x0c0s0b0n0"))
+	for name, req := range map[string]RebootRequest{
+		"no configuration":      {Nodes: []string{"x0c0s0b0n0"}},
+		"unknown configuration": {Nodes: []string{"x0c0s0b0n0"}, Configuration: "memtest"},
+		"no nodes":              {Configuration: "reprovision"},
+		"unknown node":          {Nodes: []string{"x9c0s0b0n0"}, Configuration: "reprovision"},
+		"no match":              {Nodes: []string{"x9c0s[0-3]b0n0"}, Configuration: "reprovision"},
+		"negative batch size":   {Nodes: []string{"x0c0s0b0n0"}, Configuration: "reprovision", BatchSize: -1},
+		"unknown strategy":      {Nodes: []string{"x0c0s0b0n0"}, Configuration: "reprovision", Strategy: "canary"},
+		"batches in flight":     {Nodes: []string{"x0c0s0b0n0"}, Configuration: "reprovision", MaxInFlight: 2},
+		"two failure limits":    {Nodes: []string{"x0c0s0b0n0"}, Configuration: "reprovision", MaxFailures: 1, MaxFailurePercent: 10},
+		"no cordons":            {Nodes: []string{"x0c0s0b0n0"}, Configuration: "reprovision", CordonFailed: true},
+	} {
+		if _, err := manager.StartReboot(context.Background(), req); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%s: expected ErrInvalidRequest, got %v", name, err)
+		}
+	}
+}
+
+func TestRedfishPowerCycle(t *testing.T) {
+	var mu sync.Mutex
+	var resets []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		docs := map[string]any{
+			"/redfish/v1/":        map[string]any{"RedfishVersion": "1.11.0", "Systems": map[string]string{"@odata.id": "/redfish/v1/Systems"}},
+			"/redfish/v1/Systems": map[string]any{"Members": []map[string]string{{"@odata.id": "/redfish/v1/Systems/Node0"}, {"@odata.id": "/redfish/v1/Systems/Node1"}}},
+		}
+		for i, mac := range []string{"aa:bb:cc:dd:ee:00", "AA-BB-CC-DD-EE-01"} {
+			id := "/redfish/v1/Systems/Node" + string(rune('0'+i))
+			docs[id] = map[string]any{
+				"@odata.id": id, "PowerState": []string{"On", "Off"}[i],
+				"EthernetInterfaces": map[string]string{"@odata.id": id + "/EthernetInterfaces"},
+			}
+			docs[id+"/EthernetInterfaces"] = map[string]any{"Members": []map[string]string{{"@odata.id": id + "/EthernetInterfaces/1"}}}
+			docs[id+"/EthernetInterfaces/1"] = map[string]any{"MACAddress": mac}
+		}
+		if r.Method == http.MethodPost {
+			var body struct{ ResetType string }
+			json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+			mu.Lock()
+			resets = append(resets, r.URL.Path+" "+body.ResetType)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		doc, ok := docs[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(doc) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	bmc := v1.BMC{}
+	bmc.Metadata.Name = "x0c0s0b0"
+	bmc.Metadata.Annotations = map[string]string{bmcdiscovery.EndpointAnnotation: server.URL + redfish.ServiceRootPath}
+	cycler := &Redfish{HTTP: server.Client()}
+	ctx := context.Background()
+	for _, mac := range []string{"aa:bb:cc:dd:ee:00", "aa:bb:cc:dd:ee:01"} {
+		if err := cycler.PowerCycle(ctx, v1.Node{Spec: v1.NodeSpec{XName: "x0c0s0b0n0", BootMAC: mac}}, bmc); err != nil {
+			t.Fatalf("PowerCycle failed: %v", err)
+		}
+	}
+	want := []string{
+		"/redfish/v1/Systems/Node0/Actions/ComputerSystem.Reset ForceRestart",
+		"/redfish/v1/Systems/Node1/Actions/ComputerSystem.Reset On",
+	}
+	if !slices.Equal(resets, want) {
+		t.Errorf("resets = %v, want %v", resets, want)
+	}
+	if err := cycler.PowerCycle(ctx, v1.Node{Spec: v1.NodeSpec{XName: "x0c0s0b0n2", BootMAC: "aa:bb:cc:dd:ee:02"}}, bmc); err == nil {
+		t.Error("expected an error for a node none of the systems has a MAC of")
+	}
+}